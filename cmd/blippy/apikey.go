@@ -0,0 +1,126 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dstotijn/blippy/internal/audit"
+	"github.com/dstotijn/blippy/internal/auth"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// runAPIKey implements "blippy apikey create [--agent ID]... [--scope SCOPE]...
+// NAME", "blippy apikey list" and "blippy apikey revoke ID", for managing keys
+// without an existing key.
+func runAPIKey(args []string) error {
+	fs := flag.NewFlagSet("apikey", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blippy apikey create [--agent ID]... [--scope SCOPE]... NAME\n       blippy apikey list\n       blippy apikey revoke ID")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dbPath := cmp.Or(os.Getenv("DATABASE_PATH"), "./blippy.db")
+	db, err := store.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	queries := store.New(db)
+
+	switch fs.Arg(0) {
+	case "create":
+		var scope auth.KeyScope
+		cfs := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		cfs.Func("agent", "restrict the key to an agent ID (repeatable)", func(s string) error {
+			scope.AgentIDs = append(scope.AgentIDs, s)
+			return nil
+		})
+		cfs.Func("scope", "restrict the key to an operation: "+strings.Join(auth.Scopes, ", ")+" (repeatable)", func(s string) error {
+			scope.Scopes = append(scope.Scopes, s)
+			return nil
+		})
+		if err := cfs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+		if cfs.NArg() != 1 {
+			fs.Usage()
+			return errors.New("create takes a key name")
+		}
+		for _, id := range scope.AgentIDs {
+			if _, err := queries.GetAgent(ctx, id); err != nil {
+				return fmt.Errorf("agent %q: %w", id, err)
+			}
+		}
+		apiKey, key, err := auth.CreateKey(ctx, queries, cfs.Arg(0), scope)
+		if err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, queries, audit.Entry{
+			Actor:        "cli",
+			Action:       audit.ActionCreate,
+			ResourceType: "api_key",
+			ResourceID:   apiKey.ID,
+			Procedure:    "blippy apikey create",
+		}); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: failed to record audit entry:", err)
+		}
+		fmt.Printf("Created API key %q (%s). It won't be shown again:\n%s\n", apiKey.Name, apiKey.ID, key)
+	case "list":
+		keys, err := queries.ListAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tAGENTS\tSCOPES\tCREATED\tLAST USED\tREVOKED")
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s\t%s\t%s…\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Prefix,
+				listOrAll(k.AgentIds), listOrAll(k.Scopes), k.CreatedAt,
+				cmp.Or(k.LastUsedAt.String, "-"), cmp.Or(k.RevokedAt.String, "-"))
+		}
+		return w.Flush()
+	case "revoke":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			return errors.New("revoke takes a key ID")
+		}
+		if err := auth.RevokeKey(ctx, queries, fs.Arg(1)); err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, queries, audit.Entry{
+			Actor:        "cli",
+			Action:       audit.ActionRevoke,
+			ResourceType: "api_key",
+			ResourceID:   fs.Arg(1),
+			Procedure:    "blippy apikey revoke",
+		}); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: failed to record audit entry:", err)
+		}
+		fmt.Printf("Revoked API key %s\n", fs.Arg(1))
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown apikey command %q", fs.Arg(0))
+	}
+	return nil
+}
+
+// listOrAll formats a JSON list of key restrictions, where an empty list
+// means no restriction.
+func listOrAll(s string) string {
+	var items []string
+	if err := json.Unmarshal([]byte(s), &items); err != nil || len(items) == 0 {
+		return "all"
+	}
+	return strings.Join(items, ",")
+}
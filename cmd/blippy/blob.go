@@ -0,0 +1,38 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+
+	"github.com/dstotijn/blippy/internal/blob"
+	"github.com/dstotijn/blippy/internal/replica"
+)
+
+// loadBlobBackend configures where blobs are stored from the environment: an
+// S3-compatible bucket if BLOB_S3_BUCKET is set, and BLOB_DIR otherwise.
+func loadBlobBackend() (blob.Backend, string, error) {
+	bucket := os.Getenv("BLOB_S3_BUCKET")
+	if bucket == "" {
+		dir := cmp.Or(os.Getenv("BLOB_DIR"), "./blobs")
+		backend, err := blob.NewDir(dir)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid BLOB_DIR %q: %w", dir, err)
+		}
+		return backend, dir, nil
+	}
+
+	client, err := replica.NewS3Client(replica.S3Config{
+		Endpoint:        os.Getenv("BLOB_S3_ENDPOINT"),
+		Region:          os.Getenv("BLOB_S3_REGION"),
+		Bucket:          bucket,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid blob storage config: %w", err)
+	}
+	prefix := cmp.Or(os.Getenv("BLOB_S3_PREFIX"), "blobs/")
+	return blob.NewS3(client, prefix), "s3://" + bucket + "/" + prefix, nil
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agent"
+	"github.com/dstotijn/blippy/internal/conversation"
+	"github.com/dstotijn/blippy/internal/system"
+	"github.com/dstotijn/blippy/internal/trigger"
+)
+
+// defaultServerURL is the server the client commands talk to if neither
+// --url nor BLIPPY_URL is set.
+const defaultServerURL = "http://localhost:8080"
+
+// apiClient talks to a running server over the Connect API.
+type apiClient struct {
+	agents        agent.AgentServiceClient
+	conversations conversation.ConversationServiceClient
+	triggers      trigger.TriggerServiceClient
+	system        system.SystemServiceClient
+}
+
+// clientFlags registers the flags for reaching the server on fs, and returns
+// a function creating the client after the flags are parsed.
+func clientFlags(fs *flag.FlagSet) func() *apiClient {
+	url := fs.String("url", cmp.Or(os.Getenv("BLIPPY_URL"), defaultServerURL), "server URL (env: BLIPPY_URL)")
+	key := fs.String("api-key", os.Getenv("BLIPPY_API_KEY"), "API key (env: BLIPPY_API_KEY)")
+	return func() *apiClient {
+		return newAPIClient(*url, *key)
+	}
+}
+
+func newAPIClient(url, key string) *apiClient {
+	// No timeout: event streams last as long as the turns they follow.
+	httpClient := &http.Client{Transport: bearerTransport{key: key, base: http.DefaultTransport}}
+	baseURL := strings.TrimSuffix(url, "/") + "/api"
+	return &apiClient{
+		agents:        agent.NewAgentServiceClient(httpClient, baseURL),
+		conversations: conversation.NewConversationServiceClient(httpClient, baseURL),
+		triggers:      trigger.NewTriggerServiceClient(httpClient, baseURL),
+		system:        system.NewSystemServiceClient(httpClient, baseURL),
+	}
+}
+
+// bearerTransport authenticates requests with an API key.
+type bearerTransport struct {
+	key  string
+	base http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.key != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+t.key)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// runAgents implements "blippy agents list" and "blippy agents create".
+func runAgents(args []string) error {
+	fs := flag.NewFlagSet("agents", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blippy agents list\n       blippy agents create --name NAME [--description D] [--system-prompt P | --system-prompt-file F] [--model M] [--tool T]...")
+		fs.PrintDefaults()
+	}
+	newClient := clientFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	c := newClient()
+
+	switch fs.Arg(0) {
+	case "list":
+		res, err := c.agents.ListAgents(ctx, connect.NewRequest(&agent.ListAgentsRequest{}))
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tMODEL\tTOOLS")
+		for _, a := range res.Msg.Agents {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Id, a.Name, cmp.Or(a.Model, "-"), cmp.Or(strings.Join(a.EnabledTools, ","), "-"))
+		}
+		return w.Flush()
+	case "create":
+		req := &agent.CreateAgentRequest{}
+		cfs := flag.NewFlagSet("agents create", flag.ContinueOnError)
+		cfs.StringVar(&req.Name, "name", "", "agent name (required)")
+		cfs.StringVar(&req.Description, "description", "", "agent description")
+		cfs.StringVar(&req.SystemPrompt, "system-prompt", "", "system prompt")
+		promptFile := cfs.String("system-prompt-file", "", "read the system prompt from a file")
+		cfs.StringVar(&req.Model, "model", "", "model (default: the server's default model)")
+		cfs.Func("tool", "enable a tool (repeatable)", func(s string) error {
+			req.EnabledTools = append(req.EnabledTools, s)
+			return nil
+		})
+		if err := cfs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+		if req.Name == "" {
+			fs.Usage()
+			return errors.New("create takes a --name")
+		}
+		if *promptFile != "" {
+			if req.SystemPrompt != "" {
+				return errors.New("--system-prompt and --system-prompt-file are mutually exclusive")
+			}
+			b, err := os.ReadFile(*promptFile)
+			if err != nil {
+				return err
+			}
+			req.SystemPrompt = string(b)
+		}
+		res, err := c.agents.CreateAgent(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created agent %q (%s)\n", res.Msg.Name, res.Msg.Id)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown agents command %q", fs.Arg(0))
+	}
+	return nil
+}
+
+// runTriggers implements "blippy triggers list [--agent ID]", "blippy
+// triggers run ID" and "blippy triggers runs [--limit N] ID".
+func runTriggers(args []string) error {
+	fs := flag.NewFlagSet("triggers", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blippy triggers list [--agent ID]\n       blippy triggers run ID\n       blippy triggers runs [--limit N] ID")
+		fs.PrintDefaults()
+	}
+	newClient := clientFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	c := newClient()
+
+	switch fs.Arg(0) {
+	case "list":
+		req := &trigger.ListTriggersRequest{}
+		lfs := flag.NewFlagSet("triggers list", flag.ContinueOnError)
+		lfs.StringVar(&req.AgentId, "agent", "", "only list the triggers of an agent ID")
+		if err := lfs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+		res, err := c.triggers.ListTriggers(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tAGENT\tSCHEDULE\tENABLED\tNEXT RUN")
+		for _, t := range res.Msg.Triggers {
+			nextRun := "-"
+			if t.NextRunAt != nil {
+				nextRun = t.NextRunAt.AsTime().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\n", t.Id, t.Name, t.AgentId, cmp.Or(t.CronExpr, "-"), t.Enabled, nextRun)
+		}
+		return w.Flush()
+	case "run":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			return errors.New("run takes a trigger ID")
+		}
+		res, err := c.triggers.RunTrigger(ctx, connect.NewRequest(&trigger.RunTriggerRequest{Id: fs.Arg(1)}))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Started trigger run %s\n", res.Msg.TriggerRunId)
+	case "runs":
+		req := &trigger.ListTriggerRunsRequest{}
+		rfs := flag.NewFlagSet("triggers runs", flag.ContinueOnError)
+		limit := rfs.Int("limit", 20, "maximum number of runs to list, most recent first")
+		if err := rfs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+		if rfs.NArg() != 1 {
+			fs.Usage()
+			return errors.New("runs takes a trigger ID")
+		}
+		req.TriggerId = rfs.Arg(0)
+		req.PageSize = int32(*limit)
+		res, err := c.triggers.ListTriggerRuns(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATUS\tSTARTED\tDURATION\tCONVERSATION\tERROR")
+		for _, r := range res.Msg.TriggerRuns {
+			duration := "-"
+			if r.FinishedAt != nil {
+				duration = (time.Duration(r.DurationMs) * time.Millisecond).String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Id, r.Status, r.StartedAt.AsTime().Format(time.RFC3339), duration, cmp.Or(r.ConversationId, "-"), cmp.Or(r.ErrorMessage, "-"))
+		}
+		return w.Flush()
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown triggers command %q", fs.Arg(0))
+	}
+	return nil
+}
+
+// runChat implements "blippy chat [--conversation ID] AGENT [MESSAGE]",
+// sending a message to an agent and streaming its reply. Without a message,
+// it reads messages from stdin, one per line.
+func runChat(args []string) error {
+	fs := flag.NewFlagSet("chat", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blippy chat [--conversation ID] AGENT [MESSAGE]  (AGENT is an ID or name; reads messages from stdin if MESSAGE is omitted)")
+		fs.PrintDefaults()
+	}
+	newClient := clientFlags(fs)
+	convID := fs.String("conversation", "", "continue a conversation instead of starting one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return errors.New("chat takes an agent and an optional message")
+	}
+	ctx := context.Background()
+	c := newClient()
+
+	agentID, err := c.resolveAgent(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *convID == "" {
+		res, err := c.conversations.CreateConversation(ctx, connect.NewRequest(&conversation.CreateConversationRequest{AgentId: agentID}))
+		if err != nil {
+			return err
+		}
+		*convID = res.Msg.Id
+		fmt.Fprintf(os.Stderr, "Conversation %s\n", *convID)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Watch before sending, so the stream doesn't miss the start of a reply.
+	stream, err := c.watch(ctx, *convID)
+	if err != nil {
+		return err
+	}
+	send := func(content string) error {
+		if _, err := c.conversations.Chat(ctx, connect.NewRequest(&conversation.ChatRequest{ConversationId: *convID, Content: content})); err != nil {
+			return err
+		}
+		return stream.printTurn()
+	}
+
+	if fs.NArg() == 2 {
+		return send(fs.Arg(1))
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1<<20)
+	for {
+		fmt.Fprint(os.Stderr, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(os.Stderr)
+			return scanner.Err()
+		}
+		content := strings.TrimSpace(scanner.Text())
+		if content == "" {
+			continue
+		}
+		if err := send(content); err != nil {
+			if stream.closed {
+				return err
+			}
+			// Keep the session going on errors of a single message, such
+			// as a busy conversation or a failed turn.
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+	}
+}
+
+// resolveAgent returns the ID of the agent with the given ID or name.
+func (c *apiClient) resolveAgent(ctx context.Context, idOrName string) (string, error) {
+	res, err := c.agents.ListAgents(ctx, connect.NewRequest(&agent.ListAgentsRequest{}))
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, a := range res.Msg.Agents {
+		if a.Id == idOrName {
+			return a.Id, nil
+		}
+		if a.Name == idOrName {
+			ids = append(ids, a.Id)
+		}
+	}
+	return oneAgent(idOrName, ids)
+}
+
+// oneAgent returns the ID of the only agent with a name, from the IDs of the
+// agents with that name.
+func oneAgent(name string, ids []string) (string, error) {
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("agent %q not found", name)
+	case 1:
+		return ids[0], nil
+	}
+	return "", fmt.Errorf("%d agents are named %q; use an ID: %s", len(ids), name, strings.Join(ids, ", "))
+}
+
+// eventStream receives the events of a conversation in the background.
+type eventStream struct {
+	events chan *conversation.WatchEventsEvent
+	err    error // set before events is closed
+	closed bool
+}
+
+// watch subscribes to the events of a conversation. It returns once the
+// server has subscribed, so a turn started after it returns isn't missed.
+func (c *apiClient) watch(ctx context.Context, convID string) (*eventStream, error) {
+	stream, err := c.conversations.WatchEvents(ctx, connect.NewRequest(&conversation.WatchEventsRequest{
+		ConversationId: convID,
+		EventTypes:     []string{"text_delta", "tool_result", "error", "done"},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	// The server sends its response headers once it has subscribed. Errors,
+	// such as an unknown conversation, are returned by Receive.
+	stream.ResponseHeader()
+
+	s := &eventStream{events: make(chan *conversation.WatchEventsEvent)}
+	go func() {
+		defer close(s.events)
+		defer stream.Close()
+		for stream.Receive() {
+			select {
+			case s.events <- stream.Msg():
+			case <-ctx.Done():
+				return
+			}
+		}
+		s.err = cmp.Or(stream.Err(), errStreamEnded)
+	}()
+	return s, nil
+}
+
+// printTurn prints the streamed reply of a turn until it's done. Tool calls
+// are shown by name on stderr; errors are returned once the turn is done.
+func (s *eventStream) printTurn() error {
+	var turnErr error
+	for event := range s.events {
+		switch e := event.Event.(type) {
+		case *conversation.WatchEventsEvent_TextDelta:
+			fmt.Print(e.TextDelta.Content)
+		case *conversation.WatchEventsEvent_ToolResult:
+			fmt.Fprintf(os.Stderr, "[%s]\n", e.ToolResult.Name)
+		case *conversation.WatchEventsEvent_Gap:
+			fmt.Fprintf(os.Stderr, "[%d events missed]\n", e.Gap.Missed)
+		case *conversation.WatchEventsEvent_Error:
+			turnErr = errors.New(e.Error.Message)
+		case *conversation.WatchEventsEvent_Done:
+			fmt.Println()
+			return turnErr
+		}
+	}
+	s.closed = true
+	return s.err
+}
+
+var errStreamEnded = errors.New("event stream ended before the turn was done")
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAgentsCommand(t *testing.T) {
+	srv := newTestServer(t, `{}`)
+	env := []string{"BLIPPY_URL=" + srv.url, "BLIPPY_API_KEY=blippy_test"}
+
+	res := runBlippy(t, env, "", "agents", "create", "--name", "helper", "--model", "mock", "--tool", "fetch", "--tool", "memory_view")
+	if res.exitCode != 0 {
+		t.Fatalf("agents create: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+	if !strings.HasPrefix(res.stdout, `Created agent "helper" (`) {
+		t.Errorf("agents create output = %q", res.stdout)
+	}
+	agents, err := srv.queries.ListAgents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 1 || agents[0].Model != "mock" || agents[0].EnabledTools != `["fetch","memory_view"]` {
+		t.Fatalf("agents = %+v", agents)
+	}
+
+	res = runBlippy(t, env, "", "agents", "list")
+	if res.exitCode != 0 {
+		t.Fatalf("agents list: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(res.stdout), "\n")
+	if len(lines) != 2 || strings.Fields(lines[0])[0] != "ID" || !slices.Equal(strings.Fields(lines[1]), []string{agents[0].ID, "helper", "mock", "fetch,memory_view"}) {
+		t.Errorf("agents list output = %q", res.stdout)
+	}
+
+	for _, auth := range srv.authHeaders() {
+		if auth != "Bearer blippy_test" {
+			t.Errorf("request with Authorization %q, want the API key", auth)
+		}
+	}
+
+	// Invalid commands exit with an error.
+	for _, args := range [][]string{
+		{"agents", "create"},
+		{"agents", "delete"},
+	} {
+		res := runBlippy(t, env, "", args...)
+		if res.exitCode != 1 || !strings.Contains(res.stderr, "Error: ") {
+			t.Errorf("%s: exit code %d, stderr %q", strings.Join(args, " "), res.exitCode, res.stderr)
+		}
+	}
+
+	// Server errors are reported too.
+	res = runBlippy(t, []string{"BLIPPY_URL=http://127.0.0.1:1"}, "", "agents", "list")
+	if res.exitCode != 1 || !strings.Contains(res.stderr, "Error: ") {
+		t.Errorf("agents list without server: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+}
+
+func TestChatCommand(t *testing.T) {
+	srv := newTestServer(t, `{"rules": [
+		{"match": "weather", "steps": [{"text": "It's sunny."}]},
+		{"match": "fail", "steps": [{"error": "model overloaded"}]}
+	]}`)
+	env := []string{"BLIPPY_URL=" + srv.url}
+	if res := runBlippy(t, env, "", "agents", "create", "--name", "helper"); res.exitCode != 0 {
+		t.Fatalf("agents create: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+
+	res := runBlippy(t, env, "", "chat", "helper", "What's the weather?")
+	if res.exitCode != 0 {
+		t.Fatalf("chat: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+	if res.stdout != "It's sunny.\n" {
+		t.Errorf("chat output = %q, want the reply", res.stdout)
+	}
+	convID, ok := strings.CutPrefix(strings.TrimSpace(res.stderr), "Conversation ")
+	if !ok {
+		t.Fatalf("chat stderr = %q, want the conversation ID", res.stderr)
+	}
+
+	// Messages are read from stdin, and a failed turn doesn't end the
+	// session.
+	res = runBlippy(t, env, "please fail\nWhat's the weather now?\n", "chat", "--conversation", convID, "helper")
+	if res.exitCode != 0 {
+		t.Fatalf("chat from stdin: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+	if !strings.HasSuffix(res.stdout, "It's sunny.\n") || !strings.Contains(res.stderr, "Error: ") {
+		t.Errorf("chat from stdin: stdout %q, stderr %q", res.stdout, res.stderr)
+	}
+
+	res = runBlippy(t, env, "", "chat", "nobody", "hi")
+	if res.exitCode != 1 || !strings.Contains(res.stderr, `Error: agent "nobody" not found`) {
+		t.Errorf("chat with unknown agent: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	srv := newTestServer(t, `{}`)
+	env := []string{"BLIPPY_URL=" + srv.url}
+	if res := runBlippy(t, env, "", "agents", "create", "--name", "helper", "--system-prompt", "Be brief."); res.exitCode != 0 {
+		t.Fatalf("agents create: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+
+	res := runBlippy(t, env, "", "export")
+	if res.exitCode != 0 {
+		t.Fatalf("export: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+	var m struct {
+		Agents []struct {
+			Name         string `json:"name"`
+			SystemPrompt string `json:"system_prompt"`
+		} `json:"agents"`
+	}
+	if err := json.Unmarshal([]byte(res.stdout), &m); err != nil {
+		t.Fatalf("parse export %q: %v", res.stdout, err)
+	}
+	if len(m.Agents) != 1 || m.Agents[0].Name != "helper" || m.Agents[0].SystemPrompt != "Be brief." {
+		t.Errorf("exported agents = %+v", m.Agents)
+	}
+}
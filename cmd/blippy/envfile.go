@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// defaultEnvFile is the configuration file read on start, and written by
+// "blippy setup", unless BLIPPY_CONFIG is set.
+const defaultEnvFile = "blippy.env"
+
+// envFilePath returns the path of the configuration file, and whether it was
+// set explicitly. An empty BLIPPY_CONFIG disables the file.
+func envFilePath() (string, bool) {
+	if path, ok := os.LookupEnv("BLIPPY_CONFIG"); ok {
+		return path, true
+	}
+	return defaultEnvFile, false
+}
+
+// loadEnvFile sets the variables of the configuration file that aren't set
+// in the environment, so the environment takes precedence. The file has a
+// KEY=value per line; blank lines and lines starting with "#" are ignored.
+// A missing file is only an error if its path was set explicitly.
+func loadEnvFile() error {
+	path, explicit := envFilePath()
+	if path == "" {
+		return nil
+	}
+	vars, err := readEnvFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, v := range vars {
+		if _, ok := os.LookupEnv(v.key); !ok {
+			os.Setenv(v.key, v.value)
+		}
+	}
+	return nil
+}
+
+type envVar struct {
+	key, value string
+}
+
+func readEnvFile(path string) ([]envVar, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vars []envVar
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=value", path, i+1)
+		}
+		vars = append(vars, envVar{strings.TrimSpace(key), strings.TrimSpace(value)})
+	}
+	return vars, nil
+}
+
+// writeEnvFile sets variables in the configuration file, keeping its other
+// lines, and creates it if needed. Secrets are written to it, so it's only
+// readable by the owner.
+func writeEnvFile(path string, vars []envVar) error {
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	var lines []string
+	if len(b) == 0 {
+		lines = []string{`# Blippy configuration, written by "blippy setup". Environment variables take precedence.`}
+	} else {
+		lines = strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+	}
+	for _, v := range vars {
+		replaced := false
+		for i, line := range lines {
+			key, _, ok := strings.Cut(strings.TrimSpace(line), "=")
+			if ok && strings.TrimSpace(key) == v.key {
+				lines[i] = v.key + "=" + v.value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			lines = append(lines, v.key+"="+v.value)
+		}
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/dstotijn/blippy/internal/listener"
+)
+
+// loadListeners returns the listeners to serve on: the sockets passed by
+// systemd socket activation if any, otherwise a Unix domain socket at
+// UNIX_SOCKET and/or TCP port. With UNIX_SOCKET set, TCP is only listened on
+// if PORT is set too.
+func loadListeners(port string) ([]net.Listener, error) {
+	listeners, err := listener.Systemd()
+	if err != nil {
+		return nil, fmt.Errorf("socket activation: %w", err)
+	}
+	if listeners != nil {
+		return listeners, nil
+	}
+
+	if path := os.Getenv("UNIX_SOCKET"); path != "" {
+		mode, err := strconv.ParseUint(cmp.Or(os.Getenv("UNIX_SOCKET_MODE"), "660"), 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UNIX_SOCKET_MODE %q, expected octal mode like 660", os.Getenv("UNIX_SOCKET_MODE"))
+		}
+		l, err := listener.Unix(path, os.FileMode(mode))
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on Unix socket: %w", err)
+		}
+		if os.Getenv("PORT") == "" {
+			return []net.Listener{l}, nil
+		}
+		listeners = append(listeners, l)
+	}
+
+	l, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		for _, l := range listeners {
+			l.Close()
+		}
+		return nil, err
+	}
+	return append(listeners, l), nil
+}
@@ -1,48 +1,187 @@
 package main
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/exec"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/dstotijn/blippy/internal/agent"
-	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/alert"
+	"github.com/dstotijn/blippy/internal/audit"
+	"github.com/dstotijn/blippy/internal/auth"
+	"github.com/dstotijn/blippy/internal/blob"
 	"github.com/dstotijn/blippy/internal/conversation"
+	"github.com/dstotijn/blippy/internal/demo"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/eval"
+	"github.com/dstotijn/blippy/internal/events"
 	"github.com/dstotijn/blippy/internal/fsroot"
+	"github.com/dstotijn/blippy/internal/logging"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/metrics"
 	"github.com/dstotijn/blippy/internal/notification"
-	"github.com/dstotijn/blippy/internal/openrouter"
 	"github.com/dstotijn/blippy/internal/pubsub"
-	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/replica"
 	"github.com/dstotijn/blippy/internal/scheduler"
 	"github.com/dstotijn/blippy/internal/server"
+	"github.com/dstotijn/blippy/internal/status"
 	"github.com/dstotijn/blippy/internal/store"
-	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/system"
 	"github.com/dstotijn/blippy/internal/trigger"
+	"github.com/dstotijn/blippy/internal/usage"
+	"github.com/dstotijn/blippy/internal/version"
 	"github.com/dstotijn/blippy/internal/webhook"
 )
 
+// httpShutdownTimeout is how long requests get to finish on shutdown, after
+// agent turns have been drained.
+const httpShutdownTimeout = 5 * time.Second
+
 func main() {
-	if err := run(); err != nil {
-		log.Fatalf("Error: %v", err)
+	var cmd string
+	if len(os.Args) > 1 {
+		cmd = os.Args[1]
+	}
+
+	err := loadEnvFile()
+	if err == nil {
+		err = setupLogging()
+	}
+	if err != nil {
+		fatal(err)
+	}
+	switch cmd {
+	case "migrate":
+		err = runMigrate(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	case "import":
+		err = runImport(os.Args[2:])
+	case "apikey":
+		err = runAPIKey(os.Args[2:])
+	case "agents":
+		err = runAgents(os.Args[2:])
+	case "chat":
+		err = runChat(os.Args[2:])
+	case "triggers":
+		err = runTriggers(os.Args[2:])
+	case "run":
+		err = runOnce(os.Args[2:])
+	case "setup":
+		err = runSetup(os.Args[2:])
+	case "version":
+		fmt.Println(version.Get())
+	default:
+		err = run(os.Args[1:])
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// fatal reports a command's error and exits.
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
+
+// setupLogging sets the default logger, which the log package writes to
+// too, from LOG_LEVEL and LOG_FORMAT.
+func setupLogging() error {
+	level, modules, err := logging.ParseLevels(os.Getenv("LOG_LEVEL"))
+	if err != nil {
+		return fmt.Errorf("invalid LOG_LEVEL %q: %w", os.Getenv("LOG_LEVEL"), err)
+	}
+	logger, err := logging.New(os.Stderr, logging.Config{
+		Format:  cmp.Or(os.Getenv("LOG_FORMAT"), logging.FormatText),
+		Level:   level,
+		Modules: modules,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid LOG_FORMAT %q: %w", os.Getenv("LOG_FORMAT"), err)
 	}
+	slog.SetDefault(logger)
+	return nil
 }
 
-func run() error {
+func run(args []string) error {
+	fs := flag.NewFlagSet("blippy", flag.ContinueOnError)
+	seedDemo := fs.Bool("seed-demo", os.Getenv("SEED") == "1", "create example agents, a channel, a trigger and a conversation if there are no agents (env: SEED=1)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	logger := slog.Default()
 	dbPath := cmp.Or(os.Getenv("DATABASE_PATH"), "./blippy.db")
-	port := cmp.Or(os.Getenv("PORT"), "8080")
-	openRouterAPIKey := os.Getenv("OPENROUTER_API_KEY")
-	model := cmp.Or(os.Getenv("MODEL"), "google/gemini-3-flash-preview")
-	spritesAPIKey := os.Getenv("SPRITES_API_KEY")
+	port := os.Getenv("PORT")
+	evalJudgeModel := os.Getenv("EVAL_JUDGE_MODEL")
+	authDisabled := os.Getenv("AUTH_DISABLED") == "1"
+	shutdownTimeout, err := time.ParseDuration(cmp.Or(os.Getenv("SHUTDOWN_TIMEOUT"), "30s"))
+	if err != nil || shutdownTimeout < 0 {
+		return fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q", os.Getenv("SHUTDOWN_TIMEOUT"))
+	}
+	loopCfg, err := loadLoopConfig()
+	if err != nil {
+		return err
+	}
+	updateCheckInterval, err := time.ParseDuration(cmp.Or(os.Getenv("UPDATE_CHECK_INTERVAL"), "24h"))
+	if err != nil || updateCheckInterval <= 0 {
+		return fmt.Errorf("invalid UPDATE_CHECK_INTERVAL %q", os.Getenv("UPDATE_CHECK_INTERVAL"))
+	}
+	triggerRunRetention, err := time.ParseDuration(cmp.Or(os.Getenv("TRIGGER_RUN_RETENTION"), "720h"))
+	if err != nil || triggerRunRetention < 0 {
+		return fmt.Errorf("invalid TRIGGER_RUN_RETENTION %q", os.Getenv("TRIGGER_RUN_RETENTION"))
+	}
+	orClient, err := loadLLMClient()
+	if err != nil {
+		return err
+	}
 
-	if openRouterAPIKey == "" {
-		return fmt.Errorf("OPENROUTER_API_KEY environment variable is required")
+	if tlsConfigured() {
+		port = cmp.Or(port, "443")
+	} else {
+		port = cmp.Or(port, "8080")
+	}
+	tlsSetup, err := loadTLS(port)
+	if err != nil {
+		return err
+	}
+	listeners, err := loadListeners(port)
+	if err != nil {
+		return err
+	}
+	// Serving closes the listeners too; this covers returning before that.
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	replicaClient, replicaOpts, err := loadReplication()
+	if err != nil {
+		return err
+	}
+	if replicaClient != nil {
+		restored, err := replica.Restore(context.Background(), replicaClient, replicaOpts.Prefix, dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to restore database: %w", err)
+		}
+		if restored {
+			logger.Info("restored database from replica")
+		}
 	}
 
 	db, err := store.Open(dbPath)
@@ -51,85 +190,340 @@ func run() error {
 	}
 	defer db.Close()
 
-	queries := store.New(db)
-	orClient := openrouter.NewClient(openRouterAPIKey)
+	cipher, err := loadCipher()
+	if err != nil {
+		return err
+	}
 
-	// Create adapter services for tools
-	triggerCreator := trigger.NewCreator(queries)
-	channelLister := notification.NewChannelLister(queries)
-	rootLister := fsroot.NewRootLister(queries)
+	queries := store.New(db)
+	if n, err := notification.EncryptStoredConfigs(context.Background(), queries, cipher); err != nil {
+		return fmt.Errorf("failed to encrypt notification channel configs: %w", err)
+	} else if n > 0 {
+		logger.Info("encrypted notification channel configs", "count", n)
+	}
+	if n, err := trigger.EncryptStoredSecrets(context.Background(), queries, cipher); err != nil {
+		return fmt.Errorf("failed to encrypt trigger webhook secrets and vars: %w", err)
+	} else if n > 0 {
+		logger.Info("encrypted trigger webhook secrets and vars", "count", n)
+	}
 
-	// Set up tool registry
-	toolRegistry := tool.NewRegistry()
-	toolRegistry.Register(tool.NewFetchTool())
-	if spritesAPIKey != "" {
-		toolRegistry.Register(tool.NewBashTool(spritesAPIKey))
-		log.Println("Bash tool enabled (SPRITES_API_KEY set)")
+	if *seedDemo {
+		seeded, err := demo.Seed(context.Background(), queries, cipher)
+		if err != nil {
+			return err
+		}
+		if seeded {
+			logger.Info("created demo agents, trigger, notification channel and conversation")
+		}
 	}
-	toolExecutor := tool.NewExecutor(toolRegistry, channelLister, rootLister)
 
-	// Create broker for pub/sub events
-	broker := pubsub.New()
+	var oidcOpts *auth.OIDCOptions
+	if authDisabled {
+		logger.Warn("authentication is disabled (AUTH_DISABLED=1)")
+	} else {
+		oidcOpts, err = loadOIDC(context.Background())
+		if err != nil {
+			return err
+		}
+	}
+	lockout, err := loadLockout()
+	if err != nil {
+		return err
+	}
+	// A configured key replaces the generated initial key, so deployments
+	// can be provisioned without reading it from the log.
+	if adminKey := os.Getenv("ADMIN_API_KEY"); adminKey != "" && !authDisabled {
+		revoked, err := auth.EnsureConfiguredKey(context.Background(), queries, "admin (ADMIN_API_KEY)", adminKey)
+		if err != nil {
+			return fmt.Errorf("invalid ADMIN_API_KEY: %w", err)
+		}
+		if revoked {
+			logger.Warn("ADMIN_API_KEY was revoked; configure a new key")
+		}
+	}
+	// With OIDC, admins log in through the provider and don't need a key.
+	if !authDisabled && oidcOpts == nil {
+		key, err := auth.EnsureKey(context.Background(), queries, "admin")
+		if err != nil {
+			return fmt.Errorf("failed to create initial api key: %w", err)
+		}
+		if key != "" {
+			logger.Info("created initial API key; it won't be shown again", "name", "admin", "key", key)
+		}
+	}
 
-	// Create shared agentic loop
-	loop := &agentloop.Loop{
-		Queries:      queries,
-		ORClient:     orClient,
-		ToolExecutor: toolExecutor,
-		Broker:       broker,
-		DefaultModel: model,
+	rt, err := newAgentRuntime(context.Background(), queries, cipher, orClient, loopCfg, logger)
+	if err != nil {
+		return err
 	}
+	loop, broker := rt.loop, rt.broker
 
-	// Create runner for autonomous execution
-	agentRunner := runner.New(queries, broker, loop)
-	runnerAdapter := runner.NewAdapter(agentRunner)
+	var relay *pubsub.RedisBackend
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		relay, err = pubsub.NewRedisBackend(redisURL, logging.Module(logger, "pubsub"))
+		if err != nil {
+			return err
+		}
+	}
 
-	// Register autonomous tools
-	toolRegistry.Register(tool.NewCallAgentTool(runnerAdapter))
-	toolRegistry.Register(tool.NewScheduleAgentRunTool(triggerCreator))
+	// Maintenance mode, toggled by admins via SystemService.
+	maint := &maintenance.Mode{}
 
-	// Register memory tools
-	toolRegistry.Register(tool.NewMemoryViewTool(queries))
-	toolRegistry.Register(tool.NewMemoryCreateTool(queries))
-	toolRegistry.Register(tool.NewMemoryEditTool(queries))
-	toolRegistry.Register(tool.NewMemoryDeleteTool(queries))
+	// Blob storage for attachments, generated images and exports.
+	blobBackend, blobLocation, err := loadBlobBackend()
+	if err != nil {
+		return err
+	}
+	blobs, err := blob.NewStore(context.Background(), queries, blobBackend, cipher, logging.Module(logger, "blob"))
+	if err != nil {
+		return fmt.Errorf("failed to set up blob storage: %w", err)
+	}
+	logger.Debug("storing blobs", "location", blobLocation)
 
 	// Create and start scheduler
-	logger := slog.Default()
-	sched := scheduler.New(db, queries, agentRunner, logger)
+	sched := scheduler.New(db, queries, rt.runner, maint, logging.Module(logger, "scheduler"))
+	sched.Cipher = cipher
+	sched.AddJob("flush_notification_queue", rt.dispatcher.FlushQueue)
+	sched.AddJob("prune_events", rt.eventLog.Prune)
+	sched.AddJob("recover_checkpoints", loop.RecoverCheckpoints)
+	sched.AddJob("collect_blob_garbage", blobs.CollectGarbage)
+	sched.AddJob("prune_llm_captures", loop.PruneCaptures)
+	if triggerRunRetention > 0 {
+		sched.AddJob("prune_trigger_runs", sched.PruneRuns(triggerRunRetention))
+	}
+	alertChannel, thresholds, err := loadAlerts()
+	if err != nil {
+		return err
+	}
+	if thresholds.Enabled() {
+		monitor := alert.NewMonitor(queries, usage.NewReporter(queries, orClient), notification.NewChannelLister(queries, cipher), rt.dispatcher, alertChannel, thresholds, logging.Module(logger, "alert"))
+		sched.AddJob("check_alerts", monitor.Check)
+		logger.Info("sending alerts to notification channel", "channel", alertChannel)
+	}
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 	sched.Start(ctx)
-	defer sched.Stop()
 
-	agentService := agent.NewService(db, orClient)
-	conversationService := conversation.NewService(db, broker, loop)
-	triggerRPCService := trigger.NewService(db)
-	notificationRPCService := notification.NewService(db)
+	if replicaClient != nil {
+		replicator := replica.New(db, replicaClient, replicaOpts, logging.Module(logger, "replica"))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			replicator.Run(ctx)
+		}()
+		// Wait for the final snapshot before the database is closed.
+		defer func() {
+			cancel()
+			<-done
+		}()
+		logger.Info("replicating database", "interval", replicaOpts.Interval)
+	}
+
+	// Opt-in check for new releases, reported in the logs and the UI.
+	var updates *version.Checker
+	if os.Getenv("UPDATE_CHECK") == "1" {
+		updates = version.NewChecker(version.Get(), version.DefaultReleasesURL, logging.Module(logger, "version"))
+		go updates.Run(ctx, updateCheckInterval)
+	}
+
+	agentService := agent.NewService(db, cipher, orClient)
+	conversationService := conversation.NewService(db, broker, loop, maint, blobs)
+	triggerRPCService := trigger.NewService(db, sched, cipher, orClient, loopCfg.model)
+	notificationRPCService := notification.NewService(db, cipher, rt.webPush)
+	notificationRPCService.AgentScope = auth.AgentIDs
 	fsrootRPCService := fsroot.NewService(db)
-	webhookHandler := webhook.New(queries, agentRunner, logger)
-	srv, err := server.New(agentService, conversationService, triggerRPCService, notificationRPCService, fsrootRPCService, webhookHandler)
+	auditRPCService := audit.NewService(db, logging.Module(logger, "audit"))
+	authRPCService := auth.NewService(db, logging.Module(logger, "auth"), auth.Options{Disabled: authDisabled, OIDC: oidcOpts, Lockout: lockout})
+	systemRPCService := system.NewService(db, sched, loop, maint, cipher, updates, blobs)
+	evalRPCService := eval.NewService(db, rt.runner, orClient, loopCfg.model, evalJudgeModel)
+	if n, err := evalRPCService.InterruptRunning(ctx); err != nil {
+		logger.Error("failed to mark interrupted eval runs", "error", err)
+	} else if n > 0 {
+		logger.Warn("marked eval runs left running as interrupted", "count", n)
+	}
+	// Deprecated generic webhook, superseded by trigger webhooks.
+	var webhookHandler *webhook.Handler
+	if os.Getenv("LEGACY_TRIGGER_WEBHOOK") == "1" {
+		logger.Warn("/webhooks/trigger is deprecated and will be removed; use trigger webhooks instead")
+		webhookHandler = webhook.New(queries, rt.runner, maint, logging.Module(logger, "webhook"))
+	}
+	replyHandler := webhook.NewReplyHandler(queries, rt.runner, maint, logging.Module(logger, "webhook"))
+	triggerWebhookHandler := webhook.NewTriggerWebhookHandler(queries, cipher, sched, maint, logging.Module(logger, "webhook"))
+	triggerWebhookHandler.TrustProxyHeaders = lockout.TrustProxyHeaders
+	telegramHandler := webhook.NewTelegramHandler(queries, cipher, rt.runner, maint, logging.Module(logger, "webhook"))
+	slackHandler := webhook.NewSlackHandler(queries, cipher, rt.runner, maint, logging.Module(logger, "webhook"))
+	eventsHandler := events.NewHandler(queries, broker, logging.Module(logger, "events"))
+	// Opt-in public status page.
+	var statusHandler http.Handler
+	if os.Getenv("STATUS_PAGE") == "1" {
+		statusHandler = status.New(db, sched, maint, logging.Module(logger, "status"))
+	}
+	srv, err := server.New(agentService, conversationService, triggerRPCService, notificationRPCService, fsrootRPCService, auditRPCService, authRPCService, systemRPCService, evalRPCService, webhookHandler, replyHandler, triggerWebhookHandler, telegramHandler, slackHandler, eventsHandler, metrics.Handler(metrics.Broker(broker)), blobs.Handler(), statusHandler)
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
 	}
 
+	// Requests get their own context, so streams stay open while turns are
+	// drained on shutdown.
+	reqCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	// Renew leases of busy conversations, and end turns of conversations
+	// whose lease expired, e.g. after a crash.
+	go broker.MaintainLeases(reqCtx)
+
+	// Relay events to and from other replicas until streams end.
+	if relay != nil {
+		go broker.Relay(reqCtx, relay)
+		logger.Info("relaying events with Redis (REDIS_URL set)")
+	}
 	httpServer := &http.Server{
-		Addr:    ":" + port,
-		Handler: srv.Handler(),
+		Handler:     srv.Handler(),
+		BaseContext: func(net.Listener) context.Context { return reqCtx },
+	}
+	server.Configure(httpServer)
+
+	var redirectServer *http.Server
+	if tlsSetup != nil {
+		httpServer.TLSConfig = tlsSetup.config
+		if tlsSetup.redirect != nil {
+			redirectServer = &http.Server{
+				Addr:    ":" + tlsSetup.redirectPort,
+				Handler: tlsSetup.redirect,
+			}
+			server.Configure(redirectServer)
+			go func() {
+				logger.Info("redirecting HTTP to HTTPS", "port", tlsSetup.redirectPort)
+				if err := redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("HTTP redirect server failed", "error", err)
+				}
+			}()
+		}
 	}
 
 	// Shut down gracefully when context is cancelled (signal received).
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
-		log.Println("Shutting down...")
-		if err := httpServer.Shutdown(context.Background()); err != nil {
-			log.Printf("HTTP server shutdown error: %v", err)
+		logger.Info("shutting down, waiting for agent turns to finish", "timeout", shutdownTimeout)
+
+		drainCtx, cancelDrain := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancelDrain()
+		if n := loop.Drain(drainCtx); n > 0 {
+			logger.Warn("interrupted agent turns", "count", n)
+		}
+		// Eval runs can't start turns anymore, so they finish quickly.
+		evalRPCService.Wait()
+		sched.Stop()
+
+		// End streams, then wait briefly for other requests to finish.
+		cancelRequests()
+		httpCtx, cancelHTTP := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancelHTTP()
+		if redirectServer != nil {
+			redirectServer.Shutdown(httpCtx)
+		}
+		if err := httpServer.Shutdown(httpCtx); err != nil {
+			logger.Error("HTTP server shutdown failed", "error", err)
+			httpServer.Close()
 		}
 	}()
 
-	log.Printf("🤖 Blippy listening on :%s", port)
-	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return err
+	// Serve on all listeners. A listener failing stops the server; after
+	// Shutdown, each returns http.ErrServerClosed.
+	serveErrs := make(chan error, len(listeners))
+	for _, l := range listeners {
+		go func() {
+			if tlsSetup != nil {
+				logger.Info("🤖 Blippy listening", "version", version.Get(), "addr", l.Addr().String(), "tls", true)
+				serveErrs <- httpServer.ServeTLS(l, "", "")
+			} else {
+				logger.Info("🤖 Blippy listening", "version", version.Get(), "addr", l.Addr().String())
+				serveErrs <- httpServer.Serve(l)
+			}
+		}()
+	}
+	for range listeners {
+		if err := <-serveErrs; !errors.Is(err, http.ErrServerClosed) {
+			httpServer.Close()
+			return err
+		}
 	}
+	<-shutdownDone
 	return nil
 }
+
+// loadCipher returns the cipher for encrypting secrets at rest, configured
+// with the ENCRYPTION_KEY environment variable, or the output of the
+// ENCRYPTION_KEY_COMMAND shell command, e.g. to decrypt the key with a KMS.
+// Returns nil if both are unset, in which case secrets are stored as
+// plaintext.
+func loadCipher() (*encryption.Cipher, error) {
+	s, name := os.Getenv("ENCRYPTION_KEY"), "ENCRYPTION_KEY"
+	if command := os.Getenv("ENCRYPTION_KEY_COMMAND"); s == "" && command != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("ENCRYPTION_KEY_COMMAND failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		s, name = string(out), "output of ENCRYPTION_KEY_COMMAND"
+	}
+	if s == "" {
+		return nil, nil
+	}
+	key, err := encryption.ParseKey(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return encryption.New(key)
+}
+
+// loadAlerts returns the notification channel and thresholds of alerts. A
+// channel is required if any threshold is set.
+func loadAlerts() (string, alert.Thresholds, error) {
+	var t alert.Thresholds
+	var err error
+	t.DailySpendUSD, err = strconv.ParseFloat(cmp.Or(os.Getenv("ALERT_DAILY_SPEND_USD"), "0"), 64)
+	if err != nil || t.DailySpendUSD < 0 {
+		return "", alert.Thresholds{}, fmt.Errorf("invalid ALERT_DAILY_SPEND_USD %q", os.Getenv("ALERT_DAILY_SPEND_USD"))
+	}
+	t.ConsecutiveFailures, err = strconv.Atoi(cmp.Or(os.Getenv("ALERT_CONSECUTIVE_FAILURES"), "0"))
+	if err != nil || t.ConsecutiveFailures < 0 {
+		return "", alert.Thresholds{}, fmt.Errorf("invalid ALERT_CONSECUTIVE_FAILURES %q", os.Getenv("ALERT_CONSECUTIVE_FAILURES"))
+	}
+	t.ErrorRate, err = strconv.ParseFloat(cmp.Or(os.Getenv("ALERT_ERROR_RATE"), "0"), 64)
+	if err != nil || t.ErrorRate < 0 || t.ErrorRate > 1 {
+		return "", alert.Thresholds{}, fmt.Errorf("invalid ALERT_ERROR_RATE %q: must be between 0 and 1", os.Getenv("ALERT_ERROR_RATE"))
+	}
+	t.ErrorRateMinRuns, err = strconv.Atoi(cmp.Or(os.Getenv("ALERT_ERROR_RATE_MIN_RUNS"), strconv.Itoa(alert.DefaultErrorRateMinRuns)))
+	if err != nil || t.ErrorRateMinRuns <= 0 {
+		return "", alert.Thresholds{}, fmt.Errorf("invalid ALERT_ERROR_RATE_MIN_RUNS %q", os.Getenv("ALERT_ERROR_RATE_MIN_RUNS"))
+	}
+	channel := os.Getenv("ALERT_CHANNEL")
+	if t.Enabled() && channel == "" {
+		return "", alert.Thresholds{}, errors.New("ALERT_CHANNEL is required when an alert threshold is set")
+	}
+	return channel, t, nil
+}
+
+// loadLockout configures brute-force protection from the environment.
+func loadLockout() (auth.LockoutOptions, error) {
+	threshold, err := strconv.Atoi(cmp.Or(os.Getenv("AUTH_LOCKOUT_THRESHOLD"), strconv.Itoa(auth.DefaultLockoutThreshold)))
+	if err != nil || threshold < 0 {
+		return auth.LockoutOptions{}, fmt.Errorf("invalid AUTH_LOCKOUT_THRESHOLD %q", os.Getenv("AUTH_LOCKOUT_THRESHOLD"))
+	}
+	duration, err := time.ParseDuration(cmp.Or(os.Getenv("AUTH_LOCKOUT_DURATION"), auth.DefaultLockoutDuration.String()))
+	if err != nil || duration <= 0 {
+		return auth.LockoutOptions{}, fmt.Errorf("invalid AUTH_LOCKOUT_DURATION %q", os.Getenv("AUTH_LOCKOUT_DURATION"))
+	}
+	return auth.LockoutOptions{
+		Threshold:         threshold,
+		Duration:          duration,
+		TrustProxyHeaders: os.Getenv("TRUST_PROXY_HEADERS") == "1",
+	}, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agent"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/conversation"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/system"
+	"github.com/dstotijn/blippy/internal/trigger"
+)
+
+// TestMain runs the blippy command instead of the tests when
+// BLIPPY_TEST_MAIN is set, so tests can run commands in a subprocess and
+// check their output and exit code.
+func TestMain(m *testing.M) {
+	if os.Getenv("BLIPPY_TEST_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// blippyResult is the outcome of a blippy command.
+type blippyResult struct {
+	stdout, stderr string
+	exitCode       int
+}
+
+// runBlippy runs a blippy command with the given environment variables and
+// stdin. The configuration file is disabled, and the database is in a
+// temporary directory unless env sets DATABASE_PATH.
+func runBlippy(t *testing.T, env []string, stdin string, args ...string) blippyResult {
+	t.Helper()
+	// Fail instead of hanging if the command doesn't exit.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, os.Args[0], args...)
+	cmd.Env = append(os.Environ(),
+		"BLIPPY_TEST_MAIN=1",
+		"BLIPPY_CONFIG=",
+		"DATABASE_PATH="+filepath.Join(t.TempDir(), "blippy.db"),
+		"BLIPPY_URL=",
+		"BLIPPY_API_KEY=",
+		"LOG_LEVEL=error",
+	)
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("run blippy %s: %v", strings.Join(args, " "), err)
+	}
+	return blippyResult{stdout: stdout.String(), stderr: stderr.String(), exitCode: cmd.ProcessState.ExitCode()}
+}
+
+// writeFixture writes a mock LLM fixture, returning its path.
+func writeFixture(t *testing.T, fixture string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(path, []byte(fixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// testServer serves the Connect API of the client commands, with agent
+// turns answered by a mock LLM.
+type testServer struct {
+	url     string
+	queries *store.Queries
+
+	mu   sync.Mutex
+	auth []string // Authorization headers of requests
+}
+
+func (s *testServer) authHeaders() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.auth
+}
+
+func newTestServer(t *testing.T, fixture string) *testServer {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	cfg, err := loadLoopConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.model = "mock"
+	f, err := openrouter.LoadMockFixture(writeFixture(t, fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orClient := openrouter.NewMockClient(f)
+	rt, err := newAgentRuntime(ctx, queries, nil, orClient, cfg, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatal(err)
+	}
+	maint := &maintenance.Mode{}
+
+	opts := connect.WithInterceptors(apierror.NewInterceptor())
+	mux := http.NewServeMux()
+	mux.Handle(agent.NewAgentServiceHandler(agent.NewService(db, nil, orClient), opts))
+	mux.Handle(conversation.NewConversationServiceHandler(conversation.NewService(db, rt.broker, rt.loop, maint, nil), opts))
+	mux.Handle(trigger.NewTriggerServiceHandler(trigger.NewService(db, nil, nil, orClient, cfg.model), opts))
+	mux.Handle(system.NewSystemServiceHandler(system.NewService(db, nil, rt.loop, maint, nil, nil, nil), opts))
+
+	s := &testServer{queries: queries}
+	srv := httptest.NewServer(http.StripPrefix("/api", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.auth = append(s.auth, r.Header.Get("Authorization"))
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	})))
+	t.Cleanup(func() {
+		// End event streams before closing the server.
+		cancel()
+		srv.Close()
+		rt.loop.Drain(context.Background())
+	})
+	s.url = srv.URL
+	return s
+}
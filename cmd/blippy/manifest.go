@@ -0,0 +1,139 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/audit"
+	"github.com/dstotijn/blippy/internal/manifest"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/system"
+)
+
+// runExport implements "blippy export [-o file] [--url URL]", writing the
+// instance configuration as JSON to stdout or a file. It reads the local
+// database, or exports from a running server if a URL is given.
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	out := fs.String("o", "", "output file (default: stdout)")
+	url := fs.String("url", os.Getenv("BLIPPY_URL"), "export from the server at this URL instead of the local database (env: BLIPPY_URL)")
+	key := fs.String("api-key", os.Getenv("BLIPPY_API_KEY"), "API key of an admin (env: BLIPPY_API_KEY)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var b []byte
+	if *url != "" {
+		res, err := newAPIClient(*url, *key).system.ExportManifest(context.Background(), connect.NewRequest(&system.ExportManifestRequest{}))
+		if err != nil {
+			return err
+		}
+		b = []byte(res.Msg.Manifest)
+	} else {
+		var err error
+		if b, err = exportLocal(); err != nil {
+			return err
+		}
+	}
+
+	if *out == "" {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(*out, b, 0o600)
+}
+
+// exportLocal exports the manifest of the local database.
+func exportLocal() ([]byte, error) {
+	db, err := store.Open(cmp.Or(os.Getenv("DATABASE_PATH"), "./blippy.db"))
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	cipher, err := loadCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := manifest.Export(context.Background(), store.New(db), cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// runImport implements "blippy import [file]", reading a manifest from a
+// file or stdin and creating or updating the entities in it.
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blippy import [file]  (reads stdin if file is omitted or \"-\")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var m manifest.Manifest
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return fmt.Errorf("parse manifest: %w", err)
+	}
+
+	db, err := store.Open(cmp.Or(os.Getenv("DATABASE_PATH"), "./blippy.db"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	cipher, err := loadCipher()
+	if err != nil {
+		return err
+	}
+
+	queries := store.New(db)
+	res, err := manifest.Import(context.Background(), queries, cipher, &m)
+	if err != nil {
+		return err
+	}
+
+	details, _ := json.Marshal(map[string]int{"created": res.Created, "updated": res.Updated, "unchanged": res.Unchanged})
+	if err := audit.Record(context.Background(), queries, audit.Entry{
+		Actor:        "cli",
+		Action:       audit.ActionImport,
+		ResourceType: "manifest",
+		Procedure:    "blippy import",
+		Details:      string(details),
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: failed to record audit entry:", err)
+	}
+
+	for _, w := range res.Warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", w)
+	}
+	fmt.Printf("Imported manifest: %d created, %d updated, %d unchanged\n", res.Created, res.Updated, res.Unchanged)
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// runMigrate implements "blippy migrate [--to N]" and "blippy migrate version".
+// Without --to, the database is migrated to the latest schema version.
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	to := fs.Int("to", -1, "target schema version (default: latest)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blippy migrate [--to N]\n       blippy migrate version")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dbPath := cmp.Or(os.Getenv("DATABASE_PATH"), "./blippy.db")
+	db, err := store.OpenUnmigrated(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	latest, err := store.LatestSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	switch fs.Arg(0) {
+	case "":
+	case "version":
+		version, err := store.SchemaVersion(db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Schema version: %d (latest: %d)\n", version, latest)
+		return nil
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown migrate command %q", fs.Arg(0))
+	}
+
+	if *to > latest {
+		return fmt.Errorf("target version %d is newer than latest version %d", *to, latest)
+	}
+
+	from, err := store.SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if err := store.Migrate(db, *to); err != nil {
+		return err
+	}
+	version, err := store.SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated schema from version %d to %d\n", from, version)
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/auth"
+	"github.com/dstotijn/blippy/internal/oidc"
+)
+
+// loadOIDC configures OIDC login from the environment, discovering the
+// provider's endpoints. Returns nil if OIDC_ISSUER is unset.
+func loadOIDC(ctx context.Context) (*auth.OIDCOptions, error) {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil, nil
+	}
+
+	roles, err := auth.ParseRoleMapping(os.Getenv("OIDC_ROLES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OIDC_ROLES: %w", err)
+	}
+	defaultRole := os.Getenv("OIDC_DEFAULT_ROLE")
+	if defaultRole != "" && !auth.ValidRole(defaultRole) {
+		return nil, fmt.Errorf("invalid OIDC_DEFAULT_ROLE %q", defaultRole)
+	}
+	if len(roles) == 0 && defaultRole == "" {
+		return nil, fmt.Errorf("OIDC_ROLES or OIDC_DEFAULT_ROLE is required, or no one can log in")
+	}
+
+	provider, err := oidc.Discover(ctx, oidc.Config{
+		Issuer:       issuer,
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		Scopes:       strings.Fields(os.Getenv("OIDC_SCOPES")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up OIDC: %w", err)
+	}
+
+	return &auth.OIDCOptions{
+		Provider:    provider,
+		RedirectURL: os.Getenv("OIDC_REDIRECT_URL"),
+		GroupsClaim: os.Getenv("OIDC_GROUPS_CLAIM"),
+		Roles:       roles,
+		DefaultRole: defaultRole,
+	}, nil
+}
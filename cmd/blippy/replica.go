@@ -0,0 +1,47 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/replica"
+)
+
+// loadReplication configures database replication from the environment.
+// Returns a nil client if REPLICA_S3_BUCKET is unset.
+func loadReplication() (*replica.S3Client, replica.Options, error) {
+	bucket := os.Getenv("REPLICA_S3_BUCKET")
+	if bucket == "" {
+		return nil, replica.Options{}, nil
+	}
+
+	client, err := replica.NewS3Client(replica.S3Config{
+		Endpoint:        os.Getenv("REPLICA_S3_ENDPOINT"),
+		Region:          os.Getenv("REPLICA_S3_REGION"),
+		Bucket:          bucket,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	})
+	if err != nil {
+		return nil, replica.Options{}, fmt.Errorf("invalid replication config: %w", err)
+	}
+
+	interval, err := time.ParseDuration(cmp.Or(os.Getenv("REPLICA_INTERVAL"), "15m"))
+	if err != nil || interval <= 0 {
+		return nil, replica.Options{}, fmt.Errorf("invalid REPLICA_INTERVAL %q", os.Getenv("REPLICA_INTERVAL"))
+	}
+	retain, err := strconv.Atoi(cmp.Or(os.Getenv("REPLICA_RETAIN"), "24"))
+	if err != nil || retain < 0 {
+		return nil, replica.Options{}, fmt.Errorf("invalid REPLICA_RETAIN %q", os.Getenv("REPLICA_RETAIN"))
+	}
+
+	return client, replica.Options{
+		Prefix:   cmp.Or(os.Getenv("REPLICA_S3_PREFIX"), "blippy/"),
+		Interval: interval,
+		Retain:   retain,
+	}, nil
+}
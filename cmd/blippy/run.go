@@ -0,0 +1,210 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/conversation"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// runOnce implements "blippy run --agent AGENT [--prompt PROMPT]", running a
+// single turn of an agent and streaming its reply to stdout, e.g. from cron
+// jobs and shell pipelines. It runs against the local database without
+// starting a server, or against a running server if a URL is given.
+func runOnce(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blippy run --agent AGENT [--prompt PROMPT] [--model MODEL]  (AGENT is an ID or name; reads the prompt from stdin if omitted)")
+		fs.PrintDefaults()
+	}
+	agentRef := fs.String("agent", "", "agent ID or name (required)")
+	prompt := fs.String("prompt", "", "prompt (default: read from stdin)")
+	model := fs.String("model", "", "model override (local runs only)")
+	url := fs.String("url", os.Getenv("BLIPPY_URL"), "run on the server at this URL instead of against the local database (env: BLIPPY_URL)")
+	key := fs.String("api-key", os.Getenv("BLIPPY_API_KEY"), "API key (env: BLIPPY_API_KEY)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *agentRef == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return errors.New("run takes an --agent")
+	}
+	if *prompt == "" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		*prompt = strings.TrimSpace(string(b))
+		if *prompt == "" {
+			return errors.New("empty prompt")
+		}
+	}
+
+	// Interrupting stops the run; its output so far is stored.
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if *url != "" {
+		if *model != "" {
+			return errors.New("--model is only supported for local runs")
+		}
+		return runRemote(ctx, newAPIClient(*url, *key), *agentRef, *prompt)
+	}
+	return runLocal(ctx, *agentRef, *prompt, *model)
+}
+
+// runLocal runs an agent with the runner of an embedded agent runtime. Its
+// events are delivered in-process, so the reply streams like it would from a
+// server.
+func runLocal(ctx context.Context, agentRef, prompt, model string) error {
+	db, err := store.Open(cmp.Or(os.Getenv("DATABASE_PATH"), "./blippy.db"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	cipher, err := loadCipher()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadLoopConfig()
+	if err != nil {
+		return err
+	}
+	orClient, err := loadLLMClient()
+	if err != nil {
+		return err
+	}
+	queries := store.New(db)
+	rt, err := newAgentRuntime(ctx, queries, cipher, orClient, cfg, slog.Default())
+	if err != nil {
+		return err
+	}
+
+	agentID, err := resolveLocalAgent(ctx, queries, agentRef)
+	if err != nil {
+		return err
+	}
+
+	// The run's conversation is created by the runner, so it's taken from
+	// the RunStarted activity; subagent runs are nested deeper.
+	activity := rt.broker.SubscribeTopics([]string{pubsub.AgentTopic(agentID)}, pubsub.OnlyTypes("run_started"))
+	defer rt.broker.Unsubscribe(activity)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := rt.runner.Run(ctx, runner.RunOpts{AgentID: agentID, Prompt: prompt, Model: model})
+		done <- err
+	}()
+
+	var convID string
+	for convID == "" {
+		select {
+		case e := <-activity.C:
+			if started := e.GetRunStarted(); started != nil && started.Depth == 0 {
+				convID = started.ConversationId
+			}
+		case err := <-done:
+			// The run failed before its turn started.
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Conversation %s\n", convID)
+
+	// Replay what the run published before subscribing.
+	sub, replay, err := rt.broker.SubscribeFrom(ctx, convID, 0, pubsub.OnlyTypes("text_delta", "tool_result"))
+	if err != nil {
+		return err
+	}
+	defer rt.broker.Unsubscribe(sub)
+	for _, e := range replay {
+		printEvent(e)
+	}
+	for {
+		select {
+		case e := <-sub.C:
+			printEvent(e)
+		case err := <-done:
+			// Events are delivered before the run returns.
+			for len(sub.C) > 0 {
+				printEvent(<-sub.C)
+			}
+			fmt.Println()
+
+			// Let hooks and spawned runs finish before exiting.
+			drainCtx, cancel := context.WithTimeout(ctx, agentloop.DefaultHookTimeout)
+			defer cancel()
+			if n := rt.loop.Drain(drainCtx); n > 0 {
+				fmt.Fprintf(os.Stderr, "Interrupted %d background run(s)\n", n)
+			}
+			return err
+		}
+	}
+}
+
+func printEvent(e *pubsub.Event) {
+	switch p := e.Payload.(type) {
+	case *pubsub.Event_TextDelta:
+		fmt.Print(p.TextDelta.Content)
+	case *pubsub.Event_ToolResult:
+		fmt.Fprintf(os.Stderr, "[%s]\n", p.ToolResult.Name)
+	case *pubsub.Event_Gap:
+		fmt.Fprintf(os.Stderr, "[%d events missed]\n", p.Gap.Missed)
+	}
+}
+
+// resolveLocalAgent returns the ID of the agent with the given ID or name.
+func resolveLocalAgent(ctx context.Context, queries *store.Queries, idOrName string) (string, error) {
+	agents, err := queries.ListAgents(ctx)
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, a := range agents {
+		if a.ID == idOrName {
+			return a.ID, nil
+		}
+		if a.Name == idOrName {
+			ids = append(ids, a.ID)
+		}
+	}
+	return oneAgent(idOrName, ids)
+}
+
+// runRemote sends the prompt to a new conversation on a server, and streams
+// the reply.
+func runRemote(ctx context.Context, c *apiClient, agentRef, prompt string) error {
+	agentID, err := c.resolveAgent(ctx, agentRef)
+	if err != nil {
+		return err
+	}
+	res, err := c.conversations.CreateConversation(ctx, connect.NewRequest(&conversation.CreateConversationRequest{AgentId: agentID}))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Conversation %s\n", res.Msg.Id)
+
+	stream, err := c.watch(ctx, res.Msg.Id)
+	if err != nil {
+		return err
+	}
+	if _, err := c.conversations.Chat(ctx, connect.NewRequest(&conversation.ChatRequest{ConversationId: res.Msg.Id, Content: prompt})); err != nil {
+		return err
+	}
+	return stream.printTurn()
+}
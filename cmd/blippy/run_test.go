@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestRunCommand(t *testing.T) {
+	fixture := writeFixture(t, `{"rules": [
+		{"match": "weather", "steps": [{"text": "It's sunny."}]},
+		{"match": "fail", "steps": [{"error": "model overloaded"}]}
+	]}`)
+
+	dbPath := filepath.Join(t.TempDir(), "blippy.db")
+	db, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err = store.New(db).CreateAgent(context.Background(), store.CreateAgentParams{ID: "agent-1", Name: "helper", Model: "mock", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now})
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := []string{"DATABASE_PATH=" + dbPath, "LLM_PROVIDER=mock", "MOCK_LLM_FIXTURE=" + fixture, "ENCRYPTION_KEY=", "ENCRYPTION_KEY_COMMAND="}
+
+	tests := []struct {
+		name       string
+		stdin      string
+		args       []string
+		wantStdout string
+		wantStderr string
+		wantExit   int
+	}{
+		{
+			name:       "prompt",
+			args:       []string{"run", "--agent", "helper", "--prompt", "What's the weather?"},
+			wantStdout: "It's sunny.\n",
+			wantStderr: "Conversation ",
+		},
+		{
+			name:       "prompt from stdin",
+			stdin:      "What's the weather?\n",
+			args:       []string{"run", "--agent", "agent-1"},
+			wantStdout: "It's sunny.\n",
+			wantStderr: "Conversation ",
+		},
+		{
+			name:       "failed turn",
+			args:       []string{"run", "--agent", "helper", "--prompt", "please fail"},
+			wantStderr: "model overloaded",
+			wantExit:   1,
+		},
+		{
+			name:       "unknown agent",
+			args:       []string{"run", "--agent", "nobody", "--prompt", "hi"},
+			wantStderr: `Error: agent "nobody" not found`,
+			wantExit:   1,
+		},
+		{
+			name:       "empty prompt",
+			args:       []string{"run", "--agent", "helper"},
+			wantStderr: "Error: empty prompt",
+			wantExit:   1,
+		},
+		{
+			name:       "missing agent",
+			args:       []string{"run", "--prompt", "hi"},
+			wantStderr: "Error: run takes an --agent",
+			wantExit:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := runBlippy(t, env, tt.stdin, tt.args...)
+			if res.exitCode != tt.wantExit {
+				t.Fatalf("exit code = %d, want %d; stderr %q", res.exitCode, tt.wantExit, res.stderr)
+			}
+			if tt.wantStdout != "" && res.stdout != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", res.stdout, tt.wantStdout)
+			}
+			if !strings.Contains(res.stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", res.stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunCommandRemote(t *testing.T) {
+	srv := newTestServer(t, `{"rules": [
+		{"match": "weather", "steps": [{"text": "It's sunny."}]},
+		{"match": "fail", "steps": [{"error": "model overloaded"}]}
+	]}`)
+	env := []string{"BLIPPY_URL=" + srv.url}
+	if res := runBlippy(t, env, "", "agents", "create", "--name", "helper"); res.exitCode != 0 {
+		t.Fatalf("agents create: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+
+	res := runBlippy(t, env, "", "run", "--agent", "helper", "--prompt", "What's the weather?")
+	if res.exitCode != 0 {
+		t.Fatalf("run: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+	if res.stdout != "It's sunny.\n" || !strings.HasPrefix(res.stderr, "Conversation ") {
+		t.Errorf("run: stdout %q, stderr %q", res.stdout, res.stderr)
+	}
+
+	res = runBlippy(t, env, "", "run", "--agent", "helper", "--prompt", "please fail")
+	if res.exitCode != 1 || !strings.Contains(res.stderr, "model overloaded") {
+		t.Errorf("failed run: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+
+	res = runBlippy(t, env, "", "run", "--agent", "helper", "--prompt", "hi", "--model", "other")
+	if res.exitCode != 1 || !strings.Contains(res.stderr, "Error: --model is only supported for local runs") {
+		t.Errorf("remote run with --model: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+}
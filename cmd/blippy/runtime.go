@@ -0,0 +1,274 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/fsroot"
+	"github.com/dstotijn/blippy/internal/hooks"
+	"github.com/dstotijn/blippy/internal/logging"
+	"github.com/dstotijn/blippy/internal/notification"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/trigger"
+	"github.com/dstotijn/blippy/internal/usage"
+	"github.com/dstotijn/blippy/internal/webpush"
+)
+
+// defaultModel is the LLM model used if MODEL isn't set.
+const defaultModel = "google/gemini-3-flash-preview"
+
+// loopConfig configures agent turns, from the environment.
+type loopConfig struct {
+	model                string
+	titleModel           string
+	summaryModel         string
+	fallbackModels       []string
+	sandbox              tool.Sandbox
+	sandboxName          string
+	vapidSubject         string
+	eventRetention       time.Duration
+	maxIterations        int
+	maxRepeatedToolCalls int
+	maxTurnDuration      time.Duration
+	maxHistoryTokens     int
+	lazyToolThreshold    int
+	compactionDisabled   bool
+	maxRunDuration       time.Duration
+	approvalTimeout      time.Duration
+	callAgentCacheTTL    time.Duration
+	recordTurns          bool
+}
+
+func loadLoopConfig() (loopConfig, error) {
+	cfg := loopConfig{
+		model:        cmp.Or(os.Getenv("MODEL"), defaultModel),
+		titleModel:   os.Getenv("TITLE_MODEL"),
+		summaryModel: os.Getenv("SUMMARY_MODEL"),
+		vapidSubject: cmp.Or(os.Getenv("VAPID_SUBJECT"), "https://github.com/dstotijn/blippy"),
+		recordTurns:  os.Getenv("RECORD_TURNS") == "1",
+
+		compactionDisabled: os.Getenv("HISTORY_COMPACTION_DISABLED") == "1",
+	}
+	for model := range strings.SplitSeq(os.Getenv("FALLBACK_MODELS"), ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			cfg.fallbackModels = append(cfg.fallbackModels, model)
+		}
+	}
+	var err error
+	cfg.sandbox, cfg.sandboxName, err = loadSandbox()
+	if err != nil {
+		return loopConfig{}, err
+	}
+	cfg.eventRetention, err = time.ParseDuration(cmp.Or(os.Getenv("EVENT_RETENTION"), "24h"))
+	if err != nil || cfg.eventRetention <= 0 {
+		return loopConfig{}, fmt.Errorf("invalid EVENT_RETENTION %q", os.Getenv("EVENT_RETENTION"))
+	}
+	cfg.maxIterations, err = strconv.Atoi(cmp.Or(os.Getenv("MAX_TURN_ITERATIONS"), strconv.Itoa(agentloop.DefaultMaxIterations)))
+	if err != nil || cfg.maxIterations <= 0 {
+		return loopConfig{}, fmt.Errorf("invalid MAX_TURN_ITERATIONS %q", os.Getenv("MAX_TURN_ITERATIONS"))
+	}
+	cfg.maxRepeatedToolCalls, err = strconv.Atoi(cmp.Or(os.Getenv("MAX_REPEATED_TOOL_CALLS"), strconv.Itoa(agentloop.DefaultMaxRepeatedToolCalls)))
+	if err != nil || cfg.maxRepeatedToolCalls <= 1 {
+		return loopConfig{}, fmt.Errorf("invalid MAX_REPEATED_TOOL_CALLS %q", os.Getenv("MAX_REPEATED_TOOL_CALLS"))
+	}
+	cfg.maxTurnDuration, err = time.ParseDuration(cmp.Or(os.Getenv("MAX_TURN_DURATION"), agentloop.DefaultMaxTurnDuration.String()))
+	if err != nil || cfg.maxTurnDuration < 0 {
+		return loopConfig{}, fmt.Errorf("invalid MAX_TURN_DURATION %q", os.Getenv("MAX_TURN_DURATION"))
+	}
+	cfg.maxHistoryTokens, err = strconv.Atoi(cmp.Or(os.Getenv("MAX_HISTORY_TOKENS"), strconv.Itoa(agentloop.DefaultMaxHistoryTokens)))
+	if err != nil || cfg.maxHistoryTokens <= 0 {
+		return loopConfig{}, fmt.Errorf("invalid MAX_HISTORY_TOKENS %q", os.Getenv("MAX_HISTORY_TOKENS"))
+	}
+	cfg.lazyToolThreshold, err = strconv.Atoi(cmp.Or(os.Getenv("LAZY_TOOL_THRESHOLD"), "0"))
+	if err != nil || cfg.lazyToolThreshold < 0 {
+		return loopConfig{}, fmt.Errorf("invalid LAZY_TOOL_THRESHOLD %q", os.Getenv("LAZY_TOOL_THRESHOLD"))
+	}
+	cfg.maxRunDuration, err = time.ParseDuration(cmp.Or(os.Getenv("MAX_RUN_DURATION"), runner.DefaultMaxRunDuration.String()))
+	if err != nil || cfg.maxRunDuration < 0 {
+		return loopConfig{}, fmt.Errorf("invalid MAX_RUN_DURATION %q", os.Getenv("MAX_RUN_DURATION"))
+	}
+	cfg.approvalTimeout, err = time.ParseDuration(cmp.Or(os.Getenv("APPROVAL_TIMEOUT"), agentloop.DefaultApprovalTimeout.String()))
+	if err != nil || cfg.approvalTimeout <= 0 {
+		return loopConfig{}, fmt.Errorf("invalid APPROVAL_TIMEOUT %q", os.Getenv("APPROVAL_TIMEOUT"))
+	}
+	cfg.callAgentCacheTTL, err = time.ParseDuration(cmp.Or(os.Getenv("CALL_AGENT_CACHE_TTL"), tool.DefaultCallAgentCacheTTL.String()))
+	if err != nil || cfg.callAgentCacheTTL < 0 {
+		return loopConfig{}, fmt.Errorf("invalid CALL_AGENT_CACHE_TTL %q", os.Getenv("CALL_AGENT_CACHE_TTL"))
+	}
+	return cfg, nil
+}
+
+// loadSandbox creates the sandbox of the bash tool: SANDBOX is "sprites"
+// (the default with SPRITES_API_KEY), "docker" or "none" (the default
+// without). It returns nil without a sandbox.
+func loadSandbox() (tool.Sandbox, string, error) {
+	name := os.Getenv("SANDBOX")
+	if name == "" {
+		name = "none"
+		if os.Getenv("SPRITES_API_KEY") != "" {
+			name = "sprites"
+		}
+	}
+	switch name {
+	case "none":
+		return nil, name, nil
+	case "sprites":
+		apiKey := os.Getenv("SPRITES_API_KEY")
+		if apiKey == "" {
+			return nil, "", fmt.Errorf("SPRITES_API_KEY environment variable is required for SANDBOX=sprites")
+		}
+		return tool.NewSpritesSandbox(apiKey), name, nil
+	case "docker":
+		pids, err := strconv.Atoi(cmp.Or(os.Getenv("SANDBOX_DOCKER_PIDS"), strconv.Itoa(tool.DefaultDockerPids)))
+		if err != nil || pids == 0 || pids < -1 {
+			return nil, "", fmt.Errorf("invalid SANDBOX_DOCKER_PIDS %q", os.Getenv("SANDBOX_DOCKER_PIDS"))
+		}
+		return tool.NewDockerSandbox(tool.DockerConfig{
+			Image:   os.Getenv("SANDBOX_DOCKER_IMAGE"),
+			CPUs:    os.Getenv("SANDBOX_DOCKER_CPUS"),
+			Memory:  os.Getenv("SANDBOX_DOCKER_MEMORY"),
+			Pids:    pids,
+			Network: os.Getenv("SANDBOX_DOCKER_NETWORK"),
+		}), name, nil
+	default:
+		return nil, "", fmt.Errorf("invalid SANDBOX %q", name)
+	}
+}
+
+// loadLLMClient creates the client of the LLM_PROVIDER.
+func loadLLMClient() (*openrouter.Client, error) {
+	switch provider := cmp.Or(os.Getenv("LLM_PROVIDER"), "openrouter"); provider {
+	case "openrouter":
+		apiKey := os.Getenv("OPENROUTER_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("OPENROUTER_API_KEY environment variable is required")
+		}
+		keys, err := openrouter.ParseKeys(apiKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OPENROUTER_API_KEY: %w", err)
+		}
+		retry := openrouter.DefaultRetryPolicy
+		retry.MaxRetries, err = strconv.Atoi(cmp.Or(os.Getenv("LLM_MAX_RETRIES"), strconv.Itoa(retry.MaxRetries)))
+		if err != nil || retry.MaxRetries < 0 {
+			return nil, fmt.Errorf("invalid LLM_MAX_RETRIES %q", os.Getenv("LLM_MAX_RETRIES"))
+		}
+		client := openrouter.NewClientWithKeys(keys)
+		client.SetRetryPolicy(retry)
+		return client, nil
+	case "mock":
+		fixture, err := openrouter.LoadMockFixture(os.Getenv("MOCK_LLM_FIXTURE"))
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("using mock LLM provider; responses are scripted, not generated")
+		return openrouter.NewMockClient(fixture), nil
+	default:
+		return nil, fmt.Errorf("invalid LLM_PROVIDER %q", provider)
+	}
+}
+
+// agentRuntime runs agent turns, with the tools and notification channels
+// they use. The server and "blippy run" share it.
+type agentRuntime struct {
+	loop       *agentloop.Loop
+	runner     *runner.Runner
+	broker     *pubsub.Broker
+	eventLog   *pubsub.StoreLog
+	dispatcher *notification.Dispatcher
+	webPush    *webpush.Sender
+}
+
+func newAgentRuntime(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher, orClient *openrouter.Client, cfg loopConfig, logger *slog.Logger) (*agentRuntime, error) {
+	webPushSender, err := webpush.NewSender(ctx, queries, cipher, cfg.vapidSubject, logging.Module(logger, "webpush"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up web push: %w", err)
+	}
+
+	// Create adapter services for tools
+	triggerCreator := trigger.NewCreator(queries)
+	channelLister := notification.NewChannelLister(queries, cipher)
+	notificationDispatcher := notification.NewDispatcher(queries, cipher, webPushSender, logging.Module(logger, "notification"))
+	rootLister := fsroot.NewRootLister(queries)
+
+	// Set up tool registry
+	toolRegistry := tool.NewRegistry()
+	toolRegistry.Register(tool.NewFetchTool())
+	if cfg.sandbox != nil {
+		toolRegistry.Register(tool.NewBashTool(cfg.sandbox))
+		slog.Info("bash tool enabled", "sandbox", cfg.sandboxName)
+	}
+	toolExecutor := tool.NewExecutor(toolRegistry, channelLister, notificationDispatcher, rootLister)
+
+	// Create broker for pub/sub events, logged so subscribers can replay them
+	eventLog := pubsub.NewStoreLog(queries, cfg.eventRetention)
+	broker := pubsub.New(eventLog, logging.Module(logger, "pubsub"))
+	broker.UseLeases(pubsub.NewStoreLeases(queries))
+
+	// Create shared agentic loop
+	loop := &agentloop.Loop{
+		Queries:      queries,
+		ORClient:     orClient,
+		ToolExecutor: toolExecutor,
+		Broker:       broker,
+		DefaultModel: cfg.model,
+		TitleModel:   cfg.titleModel,
+		SummaryModel: cfg.summaryModel,
+		Logger:       logging.Module(logger, "agentloop"),
+
+		FallbackModels:       cfg.fallbackModels,
+		MaxIterations:        cfg.maxIterations,
+		MaxRepeatedToolCalls: cfg.maxRepeatedToolCalls,
+		MaxTurnDuration:      cfg.maxTurnDuration,
+		MaxHistoryTokens:     cfg.maxHistoryTokens,
+		CompactionDisabled:   cfg.compactionDisabled,
+		LazyToolThreshold:    cfg.lazyToolThreshold,
+		ApprovalTimeout:      cfg.approvalTimeout,
+		RecordTurns:          cfg.recordTurns,
+		Cipher:               cipher,
+	}
+	hooks.Register(loop, queries, orClient, channelLister, notificationDispatcher)
+
+	// Create runner for autonomous execution
+	agentRunner := runner.New(queries, broker, loop, notificationDispatcher)
+	agentRunner.MaxRunDuration = cfg.maxRunDuration
+	runnerAdapter := runner.NewAdapter(agentRunner)
+
+	// Register autonomous tools
+	toolRegistry.Register(tool.NewCallAgentTool(runnerAdapter, cfg.callAgentCacheTTL))
+	toolRegistry.Register(tool.NewSpawnAgentTool(runnerAdapter))
+	toolRegistry.Register(tool.NewCheckAgentRunTool(runnerAdapter))
+	toolRegistry.Register(tool.NewScheduleAgentRunTool(triggerCreator))
+
+	// Register memory tools
+	toolRegistry.Register(tool.NewMemoryViewTool(queries))
+	toolRegistry.Register(tool.NewMemoryCreateTool(queries))
+	toolRegistry.Register(tool.NewMemoryEditTool(queries))
+	toolRegistry.Register(tool.NewMemoryDeleteTool(queries))
+
+	// Register conversation context tools
+	toolRegistry.Register(tool.NewSetContextTool(queries))
+	toolRegistry.Register(tool.NewGetContextTool(queries))
+
+	toolRegistry.Register(tool.NewUsageReportTool(usage.NewReporter(queries, orClient)))
+
+	return &agentRuntime{
+		loop:       loop,
+		runner:     agentRunner,
+		broker:     broker,
+		eventLog:   eventLog,
+		dispatcher: notificationDispatcher,
+		webPush:    webPushSender,
+	}, nil
+}
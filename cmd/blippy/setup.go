@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/manifest"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// setupTools are the tools enabled for the agent created by "blippy setup".
+var setupTools = []string{"fetch_url", "memory_view", "memory_create", "memory_edit", "memory_delete"}
+
+// runSetup implements "blippy setup [-o file]", a guided first-run setup: it
+// validates the OpenRouter API key, picks the default model, creates the
+// first agent and optionally a notification channel, and writes the settings
+// to the configuration file read on start.
+func runSetup(args []string) error {
+	path, _ := envFilePath()
+	fs := flag.NewFlagSet("setup", flag.ContinueOnError)
+	out := fs.String("o", cmp.Or(path, defaultEnvFile), "configuration file to write (env: BLIPPY_CONFIG)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	p := &prompter{in: bufio.NewScanner(os.Stdin), out: os.Stdout}
+	var vars []envVar
+
+	// 1. LLM provider.
+	var orClient *openrouter.Client
+	if os.Getenv("LLM_PROVIDER") == "mock" {
+		var err error
+		if orClient, err = loadLLMClient(); err != nil {
+			return err
+		}
+	} else {
+		key, err := p.askAPIKey(ctx)
+		if err != nil {
+			return err
+		}
+		orClient = openrouter.NewClient(key)
+		vars = append(vars, envVar{"OPENROUTER_API_KEY", key})
+	}
+
+	// 2. Default model.
+	model, err := p.askModel(ctx, orClient)
+	if err != nil {
+		return err
+	}
+	vars = append(vars, envVar{"MODEL", model})
+
+	// 3. Encryption of secrets at rest.
+	db, err := store.Open(cmp.Or(os.Getenv("DATABASE_PATH"), "./blippy.db"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	queries := store.New(db)
+
+	cipher, err := loadCipher()
+	if err != nil {
+		return err
+	}
+	if cipher == nil {
+		ok, err := p.confirm("Generate an encryption key for secrets at rest, such as channel credentials?", true)
+		if err != nil {
+			return err
+		}
+		if ok {
+			key := make([]byte, 32)
+			rand.Read(key)
+			if cipher, err = encryption.New(key); err != nil {
+				return err
+			}
+			vars = append(vars, envVar{"ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(key)})
+		}
+	}
+
+	// 4. First agent and notification channel.
+	m, err := p.askEntities(ctx, queries)
+	if err != nil {
+		return err
+	}
+	if len(m.Agents) > 0 || len(m.NotificationChannels) > 0 {
+		res, err := manifest.Import(ctx, queries, cipher, m)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(p.out, "Created %d agent(s) and %d notification channel(s)\n", len(m.Agents), len(m.NotificationChannels))
+		for _, w := range res.Warnings {
+			fmt.Fprintln(p.out, "Warning:", w)
+		}
+	}
+
+	if err := writeEnvFile(*out, vars); err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "\nWrote %s. Start the server with \"blippy\"; it reads the file from the working directory.\n", *out)
+	return nil
+}
+
+// prompter asks questions on the terminal.
+type prompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+// ask returns the answer to a question, or def if it's left empty.
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	if !p.in.Scan() {
+		return "", cmp.Or(p.in.Err(), io.ErrUnexpectedEOF)
+	}
+	return cmp.Or(strings.TrimSpace(p.in.Text()), def), nil
+}
+
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	for {
+		answer, err := p.ask(question+" ("+choices+")", "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// askAPIKey asks for an OpenRouter API key until OpenRouter accepts one.
+func (p *prompter) askAPIKey(ctx context.Context) (string, error) {
+	current := os.Getenv("OPENROUTER_API_KEY")
+	question := "OpenRouter API key (from https://openrouter.ai/keys)"
+	if current != "" {
+		question += ", empty keeps the current key"
+	}
+	for {
+		key, err := p.ask(question, "")
+		if err != nil {
+			return "", err
+		}
+		key = cmp.Or(key, current)
+		if key == "" {
+			continue
+		}
+		info, err := openrouter.NewClient(key).CheckKey(ctx)
+		if errors.Is(err, openrouter.ErrInvalidAPIKey) {
+			fmt.Fprintln(p.out, "OpenRouter rejected the key; try again.")
+			current = ""
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("check API key: %w", err)
+		}
+		if info.Limit != nil {
+			fmt.Fprintf(p.out, "Key is valid; $%.2f of its $%.2f limit used.\n", info.Usage, *info.Limit)
+		} else {
+			fmt.Fprintln(p.out, "Key is valid.")
+		}
+		return key, nil
+	}
+}
+
+// askModel asks for the default model until it's one OpenRouter lists,
+// suggesting matching models otherwise.
+func (p *prompter) askModel(ctx context.Context, orClient *openrouter.Client) (string, error) {
+	models, err := orClient.ListModels(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list models: %w", err)
+	}
+	def := cmp.Or(os.Getenv("MODEL"), defaultModel)
+	if !slices.ContainsFunc(models, func(m openrouter.Model) bool { return m.ID == def }) && len(models) > 0 {
+		def = models[0].ID
+	}
+	for {
+		id, err := p.ask("Default model", def)
+		if err != nil {
+			return "", err
+		}
+		var matches []string
+		for _, m := range models {
+			if m.ID == id {
+				return id, nil
+			}
+			if strings.Contains(strings.ToLower(m.ID+" "+m.Name), strings.ToLower(id)) {
+				matches = append(matches, m.ID)
+			}
+		}
+		if len(matches) == 0 {
+			fmt.Fprintf(p.out, "No model matches %q.\n", id)
+			continue
+		}
+		fmt.Fprintln(p.out, "Did you mean one of these?")
+		for _, m := range matches[:min(len(matches), 10)] {
+			fmt.Fprintln(p.out, "  "+m)
+		}
+	}
+}
+
+// askEntities asks for the first agent and a notification channel, and
+// returns a manifest of them.
+func (p *prompter) askEntities(ctx context.Context, queries *store.Queries) (*manifest.Manifest, error) {
+	m := &manifest.Manifest{Version: manifest.FormatVersion}
+
+	agents, err := queries.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	createAgent, err := p.confirm("Create an agent?", len(agents) == 0)
+	if err != nil {
+		return nil, err
+	}
+	var agent manifest.Agent
+	if createAgent {
+		agent.ID = uuid.NewString()
+		if agent.Name, err = p.ask("Agent name", "Assistant"); err != nil {
+			return nil, err
+		}
+		if agent.SystemPrompt, err = p.ask("System prompt", "You are a helpful assistant. Answer concisely."); err != nil {
+			return nil, err
+		}
+		agent.EnabledTools, _ = json.Marshal(setupTools)
+		fmt.Fprintf(p.out, "The agent can use these tools: %s\n", strings.Join(setupTools, ", "))
+	}
+
+	var channel manifest.NotificationChannel
+	for channel.Type == "" {
+		kind, err := p.ask("Notification channel: none, browser (Web Push) or webhook", "none")
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case "none":
+			return appendAgent(m, agent), nil
+		case "browser":
+			channel.Type, channel.Name, channel.Config = "web_push", "browser", json.RawMessage("{}")
+		case "webhook":
+			u, err := p.ask("Webhook URL, which gets notifications as JSON POST requests", "")
+			if err != nil {
+				return nil, err
+			}
+			if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				fmt.Fprintf(p.out, "Invalid URL %q.\n", u)
+				continue
+			}
+			channel.Type, channel.Name = "http_request", "webhook"
+			channel.Config, _ = json.Marshal(map[string]string{"url": u, "method": "POST"})
+		}
+	}
+	channel.ID = uuid.NewString()
+	if channel.Name, err = p.ask("Channel name, which agents see as the notify:<name> tool", channel.Name); err != nil {
+		return nil, err
+	}
+	m.NotificationChannels = append(m.NotificationChannels, channel)
+	if agent.ID != "" {
+		agent.EnabledNotificationChannels, _ = json.Marshal([]string{channel.ID})
+	}
+	return appendAgent(m, agent), nil
+}
+
+// appendAgent adds the agent to the manifest, if one was created.
+func appendAgent(m *manifest.Manifest, agent manifest.Agent) *manifest.Manifest {
+	if agent.ID != "" {
+		m.Agents = append(m.Agents, agent)
+	}
+	return m
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestSetupCommand(t *testing.T) {
+	fixture := writeFixture(t, `{"models": [
+		{"id": "openai/gpt-4o", "name": "GPT-4o"},
+		{"id": "anthropic/claude-sonnet-4", "name": "Claude Sonnet 4"}
+	]}`)
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "blippy.db")
+	envPath := filepath.Join(dir, "blippy.env")
+	env := []string{"DATABASE_PATH=" + dbPath, "LLM_PROVIDER=mock", "MOCK_LLM_FIXTURE=" + fixture, "MODEL=", "ENCRYPTION_KEY=", "ENCRYPTION_KEY_COMMAND="}
+
+	answers := strings.Join([]string{
+		"claude",                    // Default model: suggests matches
+		"anthropic/claude-sonnet-4", // Default model
+		"",                          // Generate an encryption key: yes
+		"",                          // Create an agent: yes
+		"Helper",                    // Agent name
+		"",                          // System prompt: default
+		"webhook",                   // Notification channel
+		"ftp://example.com",         // Webhook URL: invalid
+		"webhook",                   // Notification channel
+		"https://example.com/hook",  // Webhook URL
+		"alerts",                    // Channel name
+	}, "\n") + "\n"
+	res := runBlippy(t, env, answers, "setup", "-o", envPath)
+	if res.exitCode != 0 {
+		t.Fatalf("setup: exit code %d, stdout %q, stderr %q", res.exitCode, res.stdout, res.stderr)
+	}
+	for _, want := range []string{
+		"Did you mean one of these?\n  anthropic/claude-sonnet-4\n",
+		`Invalid URL "ftp://example.com".`,
+		"Created 1 agent(s) and 1 notification channel(s)",
+		"Wrote " + envPath,
+	} {
+		if !strings.Contains(res.stdout, want) {
+			t.Errorf("setup output doesn't contain %q:\n%s", want, res.stdout)
+		}
+	}
+
+	vars, err := readEnvFile(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, v := range vars {
+		got[v.key] = v.value
+	}
+	if got["MODEL"] != "anthropic/claude-sonnet-4" {
+		t.Errorf("MODEL = %q", got["MODEL"])
+	}
+	if key, err := base64.StdEncoding.DecodeString(got["ENCRYPTION_KEY"]); err != nil || len(key) != 32 {
+		t.Errorf("ENCRYPTION_KEY = %q, want a base64 encoded 32 byte key", got["ENCRYPTION_KEY"])
+	}
+	if _, ok := got["OPENROUTER_API_KEY"]; ok {
+		t.Error("OPENROUTER_API_KEY written for the mock provider")
+	}
+
+	db, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := store.New(db)
+	ctx := context.Background()
+	channels, err := queries.ListNotificationChannels(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(channels) != 1 || channels[0].Name != "alerts" || channels[0].Type != "http_request" {
+		t.Fatalf("channels = %+v", channels)
+	}
+	if strings.Contains(channels[0].Config, "example.com") {
+		t.Errorf("channel config %q isn't encrypted", channels[0].Config)
+	}
+	agents, err := queries.ListAgents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 1 || agents[0].Name != "Helper" || agents[0].SystemPrompt != "You are a helpful assistant. Answer concisely." {
+		t.Fatalf("agents = %+v", agents)
+	}
+	if agents[0].EnabledTools != `["fetch_url","memory_view","memory_create","memory_edit","memory_delete"]` {
+		t.Errorf("agent tools = %s", agents[0].EnabledTools)
+	}
+	if agents[0].EnabledNotificationChannels != `["`+channels[0].ID+`"]` {
+		t.Errorf("agent channels = %s, want the created channel", agents[0].EnabledNotificationChannels)
+	}
+
+	// Running setup again keeps the key and agent, and only updates the
+	// settings that changed.
+	env = append(env, "ENCRYPTION_KEY="+got["ENCRYPTION_KEY"])
+	answers = strings.Join([]string{
+		"openai/gpt-4o", // Default model
+		"",              // Create an agent: no, as one exists
+		"",              // Notification channel: none
+	}, "\n") + "\n"
+	res = runBlippy(t, env, answers, "setup", "-o", envPath)
+	if res.exitCode != 0 {
+		t.Fatalf("second setup: exit code %d, stdout %q, stderr %q", res.exitCode, res.stdout, res.stderr)
+	}
+	if vars, err = readEnvFile(envPath); err != nil {
+		t.Fatal(err)
+	}
+	if len(vars) != 2 || vars[0] != (envVar{"MODEL", "openai/gpt-4o"}) || vars[1] != (envVar{"ENCRYPTION_KEY", got["ENCRYPTION_KEY"]}) {
+		t.Errorf("configuration after second setup = %v", vars)
+	}
+	if agents, err = queries.ListAgents(ctx); err != nil || len(agents) != 1 {
+		t.Errorf("agents after second setup = %+v, %v", agents, err)
+	}
+
+	// Running out of answers fails instead of writing the file.
+	res = runBlippy(t, env, "", "setup", "-o", filepath.Join(dir, "other.env"))
+	if res.exitCode != 1 || !strings.Contains(res.stderr, "Error: unexpected EOF") {
+		t.Errorf("setup without answers: exit code %d, stderr %q", res.exitCode, res.stderr)
+	}
+}
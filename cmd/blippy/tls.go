@@ -0,0 +1,88 @@
+package main
+
+import (
+	"cmp"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/dstotijn/blippy/internal/server"
+)
+
+// tlsSetup is the TLS configuration of the server.
+type tlsSetup struct {
+	config *tls.Config
+	// redirect serves plain HTTP: it redirects to HTTPS and, with ACME,
+	// answers HTTP-01 challenges. Nil if redirectPort is empty.
+	redirect     http.Handler
+	redirectPort string
+}
+
+// tlsConfigured reports whether TLS is configured in the environment, so the
+// port can default to 443.
+func tlsConfigured() bool {
+	return os.Getenv("TLS_CERT_FILE") != "" || os.Getenv("ACME_DOMAINS") != ""
+}
+
+// loadTLS configures TLS from the environment: static certificate files with
+// TLS_CERT_FILE and TLS_KEY_FILE, or certificates obtained automatically with
+// ACME for ACME_DOMAINS. Plain HTTP requests to HTTP_REDIRECT_PORT are
+// redirected to port. Returns nil if TLS isn't configured.
+func loadTLS(port string) (*tlsSetup, error) {
+	certFile, keyFile := os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE")
+	domains := os.Getenv("ACME_DOMAINS")
+	redirectPort := os.Getenv("HTTP_REDIRECT_PORT")
+
+	switch {
+	case certFile != "" && domains != "":
+		return nil, errors.New("TLS_CERT_FILE and ACME_DOMAINS can't both be set")
+	case certFile != "" || keyFile != "":
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		s := &tlsSetup{
+			config: &tls.Config{
+				Certificates: []tls.Certificate{cert},
+				NextProtos:   []string{"h2", "http/1.1"},
+			},
+			redirectPort: redirectPort,
+		}
+		if redirectPort != "" {
+			s.redirect = server.RedirectToHTTPS(port)
+		}
+		return s, nil
+	case domains != "":
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      autocert.DirCache(cmp.Or(os.Getenv("ACME_CACHE_DIR"), "./certs")),
+			HostPolicy: autocert.HostWhitelist(strings.Split(domains, ",")...),
+			Email:      os.Getenv("ACME_EMAIL"),
+		}
+		if dir := os.Getenv("ACME_DIRECTORY_URL"); dir != "" {
+			m.Client = &acme.Client{DirectoryURL: dir}
+		}
+		// Port 80 is needed for HTTP-01 challenges, unless the certificate
+		// authority can reach the TLS port for TLS-ALPN-01 challenges.
+		redirectPort = cmp.Or(redirectPort, "80")
+		return &tlsSetup{
+			config:       m.TLSConfig(),
+			redirect:     m.HTTPHandler(server.RedirectToHTTPS(port)),
+			redirectPort: redirectPort,
+		}, nil
+	}
+
+	if redirectPort != "" {
+		return nil, errors.New("HTTP_REDIRECT_PORT requires TLS_CERT_FILE or ACME_DOMAINS")
+	}
+	return nil, nil
+}
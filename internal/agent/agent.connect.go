@@ -47,6 +47,15 @@ const (
 	AgentServiceDeleteAgentProcedure = "/blippy.agent.AgentService/DeleteAgent"
 	// AgentServiceListModelsProcedure is the fully-qualified name of the AgentService's ListModels RPC.
 	AgentServiceListModelsProcedure = "/blippy.agent.AgentService/ListModels"
+	// AgentServiceGetAgentToolStatsProcedure is the fully-qualified name of the AgentService's
+	// GetAgentToolStats RPC.
+	AgentServiceGetAgentToolStatsProcedure = "/blippy.agent.AgentService/GetAgentToolStats"
+	// AgentServiceExportAgentProcedure is the fully-qualified name of the AgentService's ExportAgent
+	// RPC.
+	AgentServiceExportAgentProcedure = "/blippy.agent.AgentService/ExportAgent"
+	// AgentServiceImportAgentProcedure is the fully-qualified name of the AgentService's ImportAgent
+	// RPC.
+	AgentServiceImportAgentProcedure = "/blippy.agent.AgentService/ImportAgent"
 )
 
 // AgentServiceClient is a client for the blippy.agent.AgentService service.
@@ -57,6 +66,15 @@ type AgentServiceClient interface {
 	UpdateAgent(context.Context, *connect.Request[UpdateAgentRequest]) (*connect.Response[Agent], error)
 	DeleteAgent(context.Context, *connect.Request[DeleteAgentRequest]) (*connect.Response[Empty], error)
 	ListModels(context.Context, *connect.Request[ListModelsRequest]) (*connect.Response[ListModelsResponse], error)
+	// GetAgentToolStats aggregates the tool calls of an agent's runs from
+	// their traces, to find unused and failing tools.
+	GetAgentToolStats(context.Context, *connect.Request[GetAgentToolStatsRequest]) (*connect.Response[GetAgentToolStatsResponse], error)
+	// ExportAgent serializes an agent and its cron triggers, to move it
+	// between instances or keep it in version control.
+	ExportAgent(context.Context, *connect.Request[ExportAgentRequest]) (*connect.Response[ExportAgentResponse], error)
+	// ImportAgent creates or updates an agent from a bundle. Triggers are
+	// matched by name.
+	ImportAgent(context.Context, *connect.Request[ImportAgentRequest]) (*connect.Response[ImportAgentResponse], error)
 }
 
 // NewAgentServiceClient constructs a client for the blippy.agent.AgentService service. By default,
@@ -106,17 +124,38 @@ func NewAgentServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(agentServiceMethods.ByName("ListModels")),
 			connect.WithClientOptions(opts...),
 		),
+		getAgentToolStats: connect.NewClient[GetAgentToolStatsRequest, GetAgentToolStatsResponse](
+			httpClient,
+			baseURL+AgentServiceGetAgentToolStatsProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("GetAgentToolStats")),
+			connect.WithClientOptions(opts...),
+		),
+		exportAgent: connect.NewClient[ExportAgentRequest, ExportAgentResponse](
+			httpClient,
+			baseURL+AgentServiceExportAgentProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("ExportAgent")),
+			connect.WithClientOptions(opts...),
+		),
+		importAgent: connect.NewClient[ImportAgentRequest, ImportAgentResponse](
+			httpClient,
+			baseURL+AgentServiceImportAgentProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("ImportAgent")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // agentServiceClient implements AgentServiceClient.
 type agentServiceClient struct {
-	createAgent *connect.Client[CreateAgentRequest, Agent]
-	getAgent    *connect.Client[GetAgentRequest, Agent]
-	listAgents  *connect.Client[ListAgentsRequest, ListAgentsResponse]
-	updateAgent *connect.Client[UpdateAgentRequest, Agent]
-	deleteAgent *connect.Client[DeleteAgentRequest, Empty]
-	listModels  *connect.Client[ListModelsRequest, ListModelsResponse]
+	createAgent       *connect.Client[CreateAgentRequest, Agent]
+	getAgent          *connect.Client[GetAgentRequest, Agent]
+	listAgents        *connect.Client[ListAgentsRequest, ListAgentsResponse]
+	updateAgent       *connect.Client[UpdateAgentRequest, Agent]
+	deleteAgent       *connect.Client[DeleteAgentRequest, Empty]
+	listModels        *connect.Client[ListModelsRequest, ListModelsResponse]
+	getAgentToolStats *connect.Client[GetAgentToolStatsRequest, GetAgentToolStatsResponse]
+	exportAgent       *connect.Client[ExportAgentRequest, ExportAgentResponse]
+	importAgent       *connect.Client[ImportAgentRequest, ImportAgentResponse]
 }
 
 // CreateAgent calls blippy.agent.AgentService.CreateAgent.
@@ -149,6 +188,21 @@ func (c *agentServiceClient) ListModels(ctx context.Context, req *connect.Reques
 	return c.listModels.CallUnary(ctx, req)
 }
 
+// GetAgentToolStats calls blippy.agent.AgentService.GetAgentToolStats.
+func (c *agentServiceClient) GetAgentToolStats(ctx context.Context, req *connect.Request[GetAgentToolStatsRequest]) (*connect.Response[GetAgentToolStatsResponse], error) {
+	return c.getAgentToolStats.CallUnary(ctx, req)
+}
+
+// ExportAgent calls blippy.agent.AgentService.ExportAgent.
+func (c *agentServiceClient) ExportAgent(ctx context.Context, req *connect.Request[ExportAgentRequest]) (*connect.Response[ExportAgentResponse], error) {
+	return c.exportAgent.CallUnary(ctx, req)
+}
+
+// ImportAgent calls blippy.agent.AgentService.ImportAgent.
+func (c *agentServiceClient) ImportAgent(ctx context.Context, req *connect.Request[ImportAgentRequest]) (*connect.Response[ImportAgentResponse], error) {
+	return c.importAgent.CallUnary(ctx, req)
+}
+
 // AgentServiceHandler is an implementation of the blippy.agent.AgentService service.
 type AgentServiceHandler interface {
 	CreateAgent(context.Context, *connect.Request[CreateAgentRequest]) (*connect.Response[Agent], error)
@@ -157,6 +211,15 @@ type AgentServiceHandler interface {
 	UpdateAgent(context.Context, *connect.Request[UpdateAgentRequest]) (*connect.Response[Agent], error)
 	DeleteAgent(context.Context, *connect.Request[DeleteAgentRequest]) (*connect.Response[Empty], error)
 	ListModels(context.Context, *connect.Request[ListModelsRequest]) (*connect.Response[ListModelsResponse], error)
+	// GetAgentToolStats aggregates the tool calls of an agent's runs from
+	// their traces, to find unused and failing tools.
+	GetAgentToolStats(context.Context, *connect.Request[GetAgentToolStatsRequest]) (*connect.Response[GetAgentToolStatsResponse], error)
+	// ExportAgent serializes an agent and its cron triggers, to move it
+	// between instances or keep it in version control.
+	ExportAgent(context.Context, *connect.Request[ExportAgentRequest]) (*connect.Response[ExportAgentResponse], error)
+	// ImportAgent creates or updates an agent from a bundle. Triggers are
+	// matched by name.
+	ImportAgent(context.Context, *connect.Request[ImportAgentRequest]) (*connect.Response[ImportAgentResponse], error)
 }
 
 // NewAgentServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -202,6 +265,24 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(agentServiceMethods.ByName("ListModels")),
 		connect.WithHandlerOptions(opts...),
 	)
+	agentServiceGetAgentToolStatsHandler := connect.NewUnaryHandler(
+		AgentServiceGetAgentToolStatsProcedure,
+		svc.GetAgentToolStats,
+		connect.WithSchema(agentServiceMethods.ByName("GetAgentToolStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceExportAgentHandler := connect.NewUnaryHandler(
+		AgentServiceExportAgentProcedure,
+		svc.ExportAgent,
+		connect.WithSchema(agentServiceMethods.ByName("ExportAgent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceImportAgentHandler := connect.NewUnaryHandler(
+		AgentServiceImportAgentProcedure,
+		svc.ImportAgent,
+		connect.WithSchema(agentServiceMethods.ByName("ImportAgent")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/blippy.agent.AgentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AgentServiceCreateAgentProcedure:
@@ -216,6 +297,12 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 			agentServiceDeleteAgentHandler.ServeHTTP(w, r)
 		case AgentServiceListModelsProcedure:
 			agentServiceListModelsHandler.ServeHTTP(w, r)
+		case AgentServiceGetAgentToolStatsProcedure:
+			agentServiceGetAgentToolStatsHandler.ServeHTTP(w, r)
+		case AgentServiceExportAgentProcedure:
+			agentServiceExportAgentHandler.ServeHTTP(w, r)
+		case AgentServiceImportAgentProcedure:
+			agentServiceImportAgentHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -248,3 +335,15 @@ func (UnimplementedAgentServiceHandler) DeleteAgent(context.Context, *connect.Re
 func (UnimplementedAgentServiceHandler) ListModels(context.Context, *connect.Request[ListModelsRequest]) (*connect.Response[ListModelsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.agent.AgentService.ListModels is not implemented"))
 }
+
+func (UnimplementedAgentServiceHandler) GetAgentToolStats(context.Context, *connect.Request[GetAgentToolStatsRequest]) (*connect.Response[GetAgentToolStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.agent.AgentService.GetAgentToolStats is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) ExportAgent(context.Context, *connect.Request[ExportAgentRequest]) (*connect.Response[ExportAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.agent.AgentService.ExportAgent is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) ImportAgent(context.Context, *connect.Request[ImportAgentRequest]) (*connect.Response[ImportAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.agent.AgentService.ImportAgent is not implemented"))
+}
@@ -74,6 +74,60 @@ func (x *AgentFilesystemRoot) GetEnabledTools() []string {
 	return nil
 }
 
+// A post-turn hook enabled for an agent, e.g. "memory", "usage", "webhook"
+// or "notify_error".
+type AgentHook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config        string                 `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"` // Hook-specific JSON object, optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentHook) Reset() {
+	*x = AgentHook{}
+	mi := &file_agent_agent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentHook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentHook) ProtoMessage() {}
+
+func (x *AgentHook) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentHook.ProtoReflect.Descriptor instead.
+func (*AgentHook) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AgentHook) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AgentHook) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
 type Agent struct {
 	state                       protoimpl.MessageState `protogen:"open.v1"`
 	Id                          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -87,13 +141,34 @@ type Agent struct {
 	Model                       string                 `protobuf:"bytes,9,opt,name=model,proto3" json:"model,omitempty"`
 	EnabledFilesystemRoots      []*AgentFilesystemRoot `protobuf:"bytes,10,rep,name=enabled_filesystem_roots,json=enabledFilesystemRoots,proto3" json:"enabled_filesystem_roots,omitempty"`
 	ForwardedHostEnvVars        []string               `protobuf:"bytes,11,rep,name=forwarded_host_env_vars,json=forwardedHostEnvVars,proto3" json:"forwarded_host_env_vars,omitempty"`
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+	Version                     int64                  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"` // Incremented on every update
+	Hooks                       []*AgentHook           `protobuf:"bytes,13,rep,name=hooks,proto3" json:"hooks,omitempty"`      // Run in order after each turn
+	// Maximum number of autonomous runs (triggers, webhooks) at the same time;
+	// extra runs wait for a slot. 0 means no limit.
+	MaxConcurrentRuns int32 `protobuf:"varint,14,opt,name=max_concurrent_runs,json=maxConcurrentRuns,proto3" json:"max_concurrent_runs,omitempty"`
+	// Conversation titles are generated after the first turn unless disabled,
+	// e.g. for trigger runs that don't need one.
+	TitleGenerationDisabled bool `protobuf:"varint,15,opt,name=title_generation_disabled,json=titleGenerationDisabled,proto3" json:"title_generation_disabled,omitempty"`
+	// Prompt generating titles instead of the server default. "{user}" and
+	// "{assistant}" are replaced with the first exchange.
+	TitlePrompt string `protobuf:"bytes,16,opt,name=title_prompt,json=titlePrompt,proto3" json:"title_prompt,omitempty"`
+	// Model generating titles instead of the server default, e.g. a cheap one.
+	TitleModel string `protobuf:"bytes,17,opt,name=title_model,json=titleModel,proto3" json:"title_model,omitempty"`
+	// Sections composed with the system prompt at turn time, before it.
+	Persona *Persona `protobuf:"bytes,18,opt,name=persona,proto3" json:"persona,omitempty"`
+	// Tool calls matching any of these rules wait for approval.
+	ApprovalRules []*ApprovalRule `protobuf:"bytes,19,rep,name=approval_rules,json=approvalRules,proto3" json:"approval_rules,omitempty"`
+	// BCP 47 language tag, e.g. "de-DE" or "nl". The agent is told to respond
+	// in its language, and titles and "{date}" placeholders use it. Empty uses
+	// English.
+	Locale        string `protobuf:"bytes,20,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Agent) Reset() {
 	*x = Agent{}
-	mi := &file_agent_agent_proto_msgTypes[1]
+	mi := &file_agent_agent_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -105,7 +180,7 @@ func (x *Agent) String() string {
 func (*Agent) ProtoMessage() {}
 
 func (x *Agent) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[1]
+	mi := &file_agent_agent_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +193,7 @@ func (x *Agent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Agent.ProtoReflect.Descriptor instead.
 func (*Agent) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{1}
+	return file_agent_agent_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Agent) GetId() string {
@@ -198,6 +273,221 @@ func (x *Agent) GetForwardedHostEnvVars() []string {
 	return nil
 }
 
+func (x *Agent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Agent) GetHooks() []*AgentHook {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+func (x *Agent) GetMaxConcurrentRuns() int32 {
+	if x != nil {
+		return x.MaxConcurrentRuns
+	}
+	return 0
+}
+
+func (x *Agent) GetTitleGenerationDisabled() bool {
+	if x != nil {
+		return x.TitleGenerationDisabled
+	}
+	return false
+}
+
+func (x *Agent) GetTitlePrompt() string {
+	if x != nil {
+		return x.TitlePrompt
+	}
+	return ""
+}
+
+func (x *Agent) GetTitleModel() string {
+	if x != nil {
+		return x.TitleModel
+	}
+	return ""
+}
+
+func (x *Agent) GetPersona() *Persona {
+	if x != nil {
+		return x.Persona
+	}
+	return nil
+}
+
+func (x *Agent) GetApprovalRules() []*ApprovalRule {
+	if x != nil {
+		return x.ApprovalRules
+	}
+	return nil
+}
+
+func (x *Agent) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// ApprovalRule makes the tool calls of an agent that match it wait for
+// approval, so only risky calls pause its runs. A call matches if its tool
+// matches and its argument meets all conditions that are set; a rule without
+// conditions matches every call of the tool.
+type ApprovalRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tool name, e.g. "bash" or "notify:ops". A trailing "*" matches by
+	// prefix, e.g. "notify:*".
+	Tool string `protobuf:"bytes,1,opt,name=tool,proto3" json:"tool,omitempty"`
+	// Top-level argument the conditions apply to, e.g. "command". Empty
+	// applies them to the JSON of all arguments.
+	Argument string `protobuf:"bytes,2,opt,name=argument,proto3" json:"argument,omitempty"`
+	// Regular expression the argument must match, e.g. "rm|curl".
+	Pattern string `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Size in bytes the argument must exceed, e.g. 1024, if greater than 0.
+	LargerThanBytes int32 `protobuf:"varint,4,opt,name=larger_than_bytes,json=largerThanBytes,proto3" json:"larger_than_bytes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ApprovalRule) Reset() {
+	*x = ApprovalRule{}
+	mi := &file_agent_agent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalRule) ProtoMessage() {}
+
+func (x *ApprovalRule) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalRule.ProtoReflect.Descriptor instead.
+func (*ApprovalRule) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ApprovalRule) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *ApprovalRule) GetArgument() string {
+	if x != nil {
+		return x.Argument
+	}
+	return ""
+}
+
+func (x *ApprovalRule) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *ApprovalRule) GetLargerThanBytes() int32 {
+	if x != nil {
+		return x.LargerThanBytes
+	}
+	return 0
+}
+
+// Persona holds the structured sections of an agent's instructions. Empty
+// sections are left out; the system prompt follows them, for anything else.
+type Persona struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Who the agent is, e.g. "You are the on-call assistant of the platform
+	// team."
+	Role  string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Goals string `protobuf:"bytes,2,opt,name=goals,proto3" json:"goals,omitempty"`
+	// What the agent must or must not do.
+	Constraints string `protobuf:"bytes,3,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	// Tone and format of its answers.
+	Style         string `protobuf:"bytes,4,opt,name=style,proto3" json:"style,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Persona) Reset() {
+	*x = Persona{}
+	mi := &file_agent_agent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Persona) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Persona) ProtoMessage() {}
+
+func (x *Persona) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Persona.ProtoReflect.Descriptor instead.
+func (*Persona) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Persona) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Persona) GetGoals() string {
+	if x != nil {
+		return x.Goals
+	}
+	return ""
+}
+
+func (x *Persona) GetConstraints() string {
+	if x != nil {
+		return x.Constraints
+	}
+	return ""
+}
+
+func (x *Persona) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
 type CreateAgentRequest struct {
 	state                       protoimpl.MessageState `protogen:"open.v1"`
 	Name                        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -208,13 +498,21 @@ type CreateAgentRequest struct {
 	Model                       string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
 	EnabledFilesystemRoots      []*AgentFilesystemRoot `protobuf:"bytes,7,rep,name=enabled_filesystem_roots,json=enabledFilesystemRoots,proto3" json:"enabled_filesystem_roots,omitempty"`
 	ForwardedHostEnvVars        []string               `protobuf:"bytes,8,rep,name=forwarded_host_env_vars,json=forwardedHostEnvVars,proto3" json:"forwarded_host_env_vars,omitempty"`
+	Hooks                       []*AgentHook           `protobuf:"bytes,9,rep,name=hooks,proto3" json:"hooks,omitempty"`
+	MaxConcurrentRuns           int32                  `protobuf:"varint,10,opt,name=max_concurrent_runs,json=maxConcurrentRuns,proto3" json:"max_concurrent_runs,omitempty"`
+	TitleGenerationDisabled     bool                   `protobuf:"varint,11,opt,name=title_generation_disabled,json=titleGenerationDisabled,proto3" json:"title_generation_disabled,omitempty"`
+	TitlePrompt                 string                 `protobuf:"bytes,12,opt,name=title_prompt,json=titlePrompt,proto3" json:"title_prompt,omitempty"`
+	TitleModel                  string                 `protobuf:"bytes,13,opt,name=title_model,json=titleModel,proto3" json:"title_model,omitempty"`
+	Persona                     *Persona               `protobuf:"bytes,14,opt,name=persona,proto3" json:"persona,omitempty"`
+	ApprovalRules               []*ApprovalRule        `protobuf:"bytes,15,rep,name=approval_rules,json=approvalRules,proto3" json:"approval_rules,omitempty"`
+	Locale                      string                 `protobuf:"bytes,16,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields               protoimpl.UnknownFields
 	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *CreateAgentRequest) Reset() {
 	*x = CreateAgentRequest{}
-	mi := &file_agent_agent_proto_msgTypes[2]
+	mi := &file_agent_agent_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -226,7 +524,7 @@ func (x *CreateAgentRequest) String() string {
 func (*CreateAgentRequest) ProtoMessage() {}
 
 func (x *CreateAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[2]
+	mi := &file_agent_agent_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -239,7 +537,7 @@ func (x *CreateAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAgentRequest.ProtoReflect.Descriptor instead.
 func (*CreateAgentRequest) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{2}
+	return file_agent_agent_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateAgentRequest) GetName() string {
@@ -298,6 +596,62 @@ func (x *CreateAgentRequest) GetForwardedHostEnvVars() []string {
 	return nil
 }
 
+func (x *CreateAgentRequest) GetHooks() []*AgentHook {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+func (x *CreateAgentRequest) GetMaxConcurrentRuns() int32 {
+	if x != nil {
+		return x.MaxConcurrentRuns
+	}
+	return 0
+}
+
+func (x *CreateAgentRequest) GetTitleGenerationDisabled() bool {
+	if x != nil {
+		return x.TitleGenerationDisabled
+	}
+	return false
+}
+
+func (x *CreateAgentRequest) GetTitlePrompt() string {
+	if x != nil {
+		return x.TitlePrompt
+	}
+	return ""
+}
+
+func (x *CreateAgentRequest) GetTitleModel() string {
+	if x != nil {
+		return x.TitleModel
+	}
+	return ""
+}
+
+func (x *CreateAgentRequest) GetPersona() *Persona {
+	if x != nil {
+		return x.Persona
+	}
+	return nil
+}
+
+func (x *CreateAgentRequest) GetApprovalRules() []*ApprovalRule {
+	if x != nil {
+		return x.ApprovalRules
+	}
+	return nil
+}
+
+func (x *CreateAgentRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 type GetAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -307,7 +661,7 @@ type GetAgentRequest struct {
 
 func (x *GetAgentRequest) Reset() {
 	*x = GetAgentRequest{}
-	mi := &file_agent_agent_proto_msgTypes[3]
+	mi := &file_agent_agent_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -319,7 +673,7 @@ func (x *GetAgentRequest) String() string {
 func (*GetAgentRequest) ProtoMessage() {}
 
 func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[3]
+	mi := &file_agent_agent_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -332,7 +686,7 @@ func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
 func (*GetAgentRequest) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{3}
+	return file_agent_agent_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetAgentRequest) GetId() string {
@@ -342,27 +696,489 @@ func (x *GetAgentRequest) GetId() string {
 	return ""
 }
 
-type ListAgentsRequest struct {
+type ListAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of results to return. 0 returns all results.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Comma-separated fields, each optionally followed by "asc" or "desc".
+	OrderBy string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Terms joined by "AND", e.g. `name:deploy AND created_at>=2025-01-01`.
+	Filter        string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_agent_agent_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAgentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAgentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type ListAgentsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Agents []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of results matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_agent_agent_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*Agent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type UpdateAgentRequest struct {
+	state                       protoimpl.MessageState `protogen:"open.v1"`
+	Id                          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description                 string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	SystemPrompt                string                 `protobuf:"bytes,4,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	EnabledTools                []string               `protobuf:"bytes,5,rep,name=enabled_tools,json=enabledTools,proto3" json:"enabled_tools,omitempty"`
+	EnabledNotificationChannels []string               `protobuf:"bytes,6,rep,name=enabled_notification_channels,json=enabledNotificationChannels,proto3" json:"enabled_notification_channels,omitempty"`
+	Model                       string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	EnabledFilesystemRoots      []*AgentFilesystemRoot `protobuf:"bytes,8,rep,name=enabled_filesystem_roots,json=enabledFilesystemRoots,proto3" json:"enabled_filesystem_roots,omitempty"`
+	ForwardedHostEnvVars        []string               `protobuf:"bytes,9,rep,name=forwarded_host_env_vars,json=forwardedHostEnvVars,proto3" json:"forwarded_host_env_vars,omitempty"`
+	Version                     int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"` // Version the update is based on; fails with ABORTED if stale
+	Hooks                       []*AgentHook           `protobuf:"bytes,11,rep,name=hooks,proto3" json:"hooks,omitempty"`
+	MaxConcurrentRuns           int32                  `protobuf:"varint,12,opt,name=max_concurrent_runs,json=maxConcurrentRuns,proto3" json:"max_concurrent_runs,omitempty"`
+	TitleGenerationDisabled     bool                   `protobuf:"varint,13,opt,name=title_generation_disabled,json=titleGenerationDisabled,proto3" json:"title_generation_disabled,omitempty"`
+	TitlePrompt                 string                 `protobuf:"bytes,14,opt,name=title_prompt,json=titlePrompt,proto3" json:"title_prompt,omitempty"`
+	TitleModel                  string                 `protobuf:"bytes,15,opt,name=title_model,json=titleModel,proto3" json:"title_model,omitempty"`
+	Persona                     *Persona               `protobuf:"bytes,16,opt,name=persona,proto3" json:"persona,omitempty"`
+	ApprovalRules               []*ApprovalRule        `protobuf:"bytes,17,rep,name=approval_rules,json=approvalRules,proto3" json:"approval_rules,omitempty"`
+	Locale                      string                 `protobuf:"bytes,18,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *UpdateAgentRequest) Reset() {
+	*x = UpdateAgentRequest{}
+	mi := &file_agent_agent_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAgentRequest) ProtoMessage() {}
+
+func (x *UpdateAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAgentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAgentRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateAgentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAgentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateAgentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateAgentRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *UpdateAgentRequest) GetEnabledTools() []string {
+	if x != nil {
+		return x.EnabledTools
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetEnabledNotificationChannels() []string {
+	if x != nil {
+		return x.EnabledNotificationChannels
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *UpdateAgentRequest) GetEnabledFilesystemRoots() []*AgentFilesystemRoot {
+	if x != nil {
+		return x.EnabledFilesystemRoots
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetForwardedHostEnvVars() []string {
+	if x != nil {
+		return x.ForwardedHostEnvVars
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateAgentRequest) GetHooks() []*AgentHook {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetMaxConcurrentRuns() int32 {
+	if x != nil {
+		return x.MaxConcurrentRuns
+	}
+	return 0
+}
+
+func (x *UpdateAgentRequest) GetTitleGenerationDisabled() bool {
+	if x != nil {
+		return x.TitleGenerationDisabled
+	}
+	return false
+}
+
+func (x *UpdateAgentRequest) GetTitlePrompt() string {
+	if x != nil {
+		return x.TitlePrompt
+	}
+	return ""
+}
+
+func (x *UpdateAgentRequest) GetTitleModel() string {
+	if x != nil {
+		return x.TitleModel
+	}
+	return ""
+}
+
+func (x *UpdateAgentRequest) GetPersona() *Persona {
+	if x != nil {
+		return x.Persona
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetApprovalRules() []*ApprovalRule {
+	if x != nil {
+		return x.ApprovalRules
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type DeleteAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAgentRequest) Reset() {
+	*x = DeleteAgentRequest{}
+	mi := &file_agent_agent_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAgentRequest) ProtoMessage() {}
+
+func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAgentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteAgentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_agent_agent_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{11}
+}
+
+type Model struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PromptPricing     string                 `protobuf:"bytes,3,opt,name=prompt_pricing,json=promptPricing,proto3" json:"prompt_pricing,omitempty"`
+	CompletionPricing string                 `protobuf:"bytes,4,opt,name=completion_pricing,json=completionPricing,proto3" json:"completion_pricing,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Model) Reset() {
+	*x = Model{}
+	mi := &file_agent_agent_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Model) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Model) ProtoMessage() {}
+
+func (x *Model) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Model.ProtoReflect.Descriptor instead.
+func (*Model) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Model) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Model) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Model) GetPromptPricing() string {
+	if x != nil {
+		return x.PromptPricing
+	}
+	return ""
+}
+
+func (x *Model) GetCompletionPricing() string {
+	if x != nil {
+		return x.CompletionPricing
+	}
+	return ""
+}
+
+type ListModelsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAgentsRequest) Reset() {
-	*x = ListAgentsRequest{}
-	mi := &file_agent_agent_proto_msgTypes[4]
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_agent_agent_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAgentsRequest) String() string {
+func (x *ListModelsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAgentsRequest) ProtoMessage() {}
+func (*ListModelsRequest) ProtoMessage() {}
 
-func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[4]
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,33 +1189,33 @@ func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
-func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{13}
 }
 
-type ListAgentsResponse struct {
+type ListModelsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	Models        []*Model               `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAgentsResponse) Reset() {
-	*x = ListAgentsResponse{}
-	mi := &file_agent_agent_proto_msgTypes[5]
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_agent_agent_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAgentsResponse) String() string {
+func (x *ListModelsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAgentsResponse) ProtoMessage() {}
+func (*ListModelsResponse) ProtoMessage() {}
 
-func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[5]
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,48 +1226,42 @@ func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
-func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListAgentsResponse) GetAgents() []*Agent {
+func (x *ListModelsResponse) GetModels() []*Model {
 	if x != nil {
-		return x.Agents
+		return x.Models
 	}
 	return nil
 }
 
-type UpdateAgentRequest struct {
-	state                       protoimpl.MessageState `protogen:"open.v1"`
-	Id                          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description                 string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	SystemPrompt                string                 `protobuf:"bytes,4,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
-	EnabledTools                []string               `protobuf:"bytes,5,rep,name=enabled_tools,json=enabledTools,proto3" json:"enabled_tools,omitempty"`
-	EnabledNotificationChannels []string               `protobuf:"bytes,6,rep,name=enabled_notification_channels,json=enabledNotificationChannels,proto3" json:"enabled_notification_channels,omitempty"`
-	Model                       string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
-	EnabledFilesystemRoots      []*AgentFilesystemRoot `protobuf:"bytes,8,rep,name=enabled_filesystem_roots,json=enabledFilesystemRoots,proto3" json:"enabled_filesystem_roots,omitempty"`
-	ForwardedHostEnvVars        []string               `protobuf:"bytes,9,rep,name=forwarded_host_env_vars,json=forwardedHostEnvVars,proto3" json:"forwarded_host_env_vars,omitempty"`
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+type GetAgentToolStatsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// How far back to aggregate, in hours. Defaults to 168 (a week).
+	PeriodHours   int32 `protobuf:"varint,2,opt,name=period_hours,json=periodHours,proto3" json:"period_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAgentRequest) Reset() {
-	*x = UpdateAgentRequest{}
-	mi := &file_agent_agent_proto_msgTypes[6]
+func (x *GetAgentToolStatsRequest) Reset() {
+	*x = GetAgentToolStatsRequest{}
+	mi := &file_agent_agent_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAgentRequest) String() string {
+func (x *GetAgentToolStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAgentRequest) ProtoMessage() {}
+func (*GetAgentToolStatsRequest) ProtoMessage() {}
 
-func (x *UpdateAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[6]
+func (x *GetAgentToolStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,96 +1272,146 @@ func (x *UpdateAgentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAgentRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAgentRequest) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetAgentToolStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentToolStatsRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *UpdateAgentRequest) GetId() string {
+func (x *GetAgentToolStatsRequest) GetAgentId() string {
 	if x != nil {
-		return x.Id
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *UpdateAgentRequest) GetName() string {
+func (x *GetAgentToolStatsRequest) GetPeriodHours() int32 {
 	if x != nil {
-		return x.Name
+		return x.PeriodHours
 	}
-	return ""
+	return 0
+}
+
+// ToolStats is how an agent used a tool in the runs of a period.
+type ToolStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether the tool is in the agent's enabled tools. Enabled tools without
+	// calls are candidates for removal, as their schemas are sent every turn.
+	Enabled        bool    `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Calls          int64   `protobuf:"varint,3,opt,name=calls,proto3" json:"calls,omitempty"`
+	Errors         int64   `protobuf:"varint,4,opt,name=errors,proto3" json:"errors,omitempty"`
+	ErrorRate      float64 `protobuf:"fixed64,5,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	AvgDurationMs  int64   `protobuf:"varint,6,opt,name=avg_duration_ms,json=avgDurationMs,proto3" json:"avg_duration_ms,omitempty"`
+	AvgResultBytes int64   `protobuf:"varint,7,opt,name=avg_result_bytes,json=avgResultBytes,proto3" json:"avg_result_bytes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ToolStats) Reset() {
+	*x = ToolStats{}
+	mi := &file_agent_agent_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAgentRequest) GetDescription() string {
+func (x *ToolStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolStats) ProtoMessage() {}
+
+func (x *ToolStats) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[16]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateAgentRequest) GetSystemPrompt() string {
+// Deprecated: Use ToolStats.ProtoReflect.Descriptor instead.
+func (*ToolStats) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ToolStats) GetName() string {
 	if x != nil {
-		return x.SystemPrompt
+		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdateAgentRequest) GetEnabledTools() []string {
+func (x *ToolStats) GetEnabled() bool {
 	if x != nil {
-		return x.EnabledTools
+		return x.Enabled
 	}
-	return nil
+	return false
 }
 
-func (x *UpdateAgentRequest) GetEnabledNotificationChannels() []string {
+func (x *ToolStats) GetCalls() int64 {
 	if x != nil {
-		return x.EnabledNotificationChannels
+		return x.Calls
 	}
-	return nil
+	return 0
 }
 
-func (x *UpdateAgentRequest) GetModel() string {
+func (x *ToolStats) GetErrors() int64 {
 	if x != nil {
-		return x.Model
+		return x.Errors
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateAgentRequest) GetEnabledFilesystemRoots() []*AgentFilesystemRoot {
+func (x *ToolStats) GetErrorRate() float64 {
 	if x != nil {
-		return x.EnabledFilesystemRoots
+		return x.ErrorRate
 	}
-	return nil
+	return 0
 }
 
-func (x *UpdateAgentRequest) GetForwardedHostEnvVars() []string {
+func (x *ToolStats) GetAvgDurationMs() int64 {
 	if x != nil {
-		return x.ForwardedHostEnvVars
+		return x.AvgDurationMs
 	}
-	return nil
+	return 0
 }
 
-type DeleteAgentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *ToolStats) GetAvgResultBytes() int64 {
+	if x != nil {
+		return x.AvgResultBytes
+	}
+	return 0
+}
+
+type GetAgentToolStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	Runs  int64                  `protobuf:"varint,3,opt,name=runs,proto3" json:"runs,omitempty"`
+	// Most called first, followed by enabled tools that weren't called.
+	Tools         []*ToolStats `protobuf:"bytes,4,rep,name=tools,proto3" json:"tools,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAgentRequest) Reset() {
-	*x = DeleteAgentRequest{}
-	mi := &file_agent_agent_proto_msgTypes[7]
+func (x *GetAgentToolStatsResponse) Reset() {
+	*x = GetAgentToolStatsResponse{}
+	mi := &file_agent_agent_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAgentRequest) String() string {
+func (x *GetAgentToolStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAgentRequest) ProtoMessage() {}
+func (*GetAgentToolStatsResponse) ProtoMessage() {}
 
-func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[7]
+func (x *GetAgentToolStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,39 +1422,63 @@ func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAgentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetAgentToolStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentToolStatsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *DeleteAgentRequest) GetId() string {
+func (x *GetAgentToolStatsResponse) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.Since
 	}
-	return ""
+	return nil
 }
 
-type Empty struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *GetAgentToolStatsResponse) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetAgentToolStatsResponse) GetRuns() int64 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *GetAgentToolStatsResponse) GetTools() []*ToolStats {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+type ExportAgentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "json" (the default) or "yaml".
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
-	mi := &file_agent_agent_proto_msgTypes[8]
+func (x *ExportAgentRequest) Reset() {
+	*x = ExportAgentRequest{}
+	mi := &file_agent_agent_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Empty) String() string {
+func (x *ExportAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*ExportAgentRequest) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[8]
+func (x *ExportAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,36 +1489,50 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ExportAgentRequest.ProtoReflect.Descriptor instead.
+func (*ExportAgentRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{18}
 }
 
-type Model struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PromptPricing     string                 `protobuf:"bytes,3,opt,name=prompt_pricing,json=promptPricing,proto3" json:"prompt_pricing,omitempty"`
-	CompletionPricing string                 `protobuf:"bytes,4,opt,name=completion_pricing,json=completionPricing,proto3" json:"completion_pricing,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *ExportAgentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (x *Model) Reset() {
-	*x = Model{}
-	mi := &file_agent_agent_proto_msgTypes[9]
+func (x *ExportAgentRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ExportAgentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The agent and its cron triggers as a versioned bundle. Notification
+	// channels and filesystem roots are referred to by name, so the bundle can
+	// be imported on another instance.
+	Bundle        string `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAgentResponse) Reset() {
+	*x = ExportAgentResponse{}
+	mi := &file_agent_agent_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Model) String() string {
+func (x *ExportAgentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Model) ProtoMessage() {}
+func (*ExportAgentResponse) ProtoMessage() {}
 
-func (x *Model) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[9]
+func (x *ExportAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,60 +1543,43 @@ func (x *Model) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Model.ProtoReflect.Descriptor instead.
-func (*Model) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *Model) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Model) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Model) GetPromptPricing() string {
-	if x != nil {
-		return x.PromptPricing
-	}
-	return ""
+// Deprecated: Use ExportAgentResponse.ProtoReflect.Descriptor instead.
+func (*ExportAgentResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Model) GetCompletionPricing() string {
+func (x *ExportAgentResponse) GetBundle() string {
 	if x != nil {
-		return x.CompletionPricing
+		return x.Bundle
 	}
 	return ""
 }
 
-type ListModelsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type ImportAgentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A JSON or YAML bundle, as returned by ExportAgent.
+	Bundle string `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// The agent to update; a new agent is created if empty.
+	Id            string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModelsRequest) Reset() {
-	*x = ListModelsRequest{}
-	mi := &file_agent_agent_proto_msgTypes[10]
+func (x *ImportAgentRequest) Reset() {
+	*x = ImportAgentRequest{}
+	mi := &file_agent_agent_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModelsRequest) String() string {
+func (x *ImportAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModelsRequest) ProtoMessage() {}
+func (*ImportAgentRequest) ProtoMessage() {}
 
-func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[10]
+func (x *ImportAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,33 +1590,50 @@ func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
-func (*ListModelsRequest) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ImportAgentRequest.ProtoReflect.Descriptor instead.
+func (*ImportAgentRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{20}
 }
 
-type ListModelsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Models        []*Model               `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+func (x *ImportAgentRequest) GetBundle() string {
+	if x != nil {
+		return x.Bundle
+	}
+	return ""
+}
+
+func (x *ImportAgentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ImportAgentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Agent *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	// Notification channels and filesystem roots of the bundle that don't
+	// exist on this instance, and were left out of the agent.
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModelsResponse) Reset() {
-	*x = ListModelsResponse{}
-	mi := &file_agent_agent_proto_msgTypes[11]
+func (x *ImportAgentResponse) Reset() {
+	*x = ImportAgentResponse{}
+	mi := &file_agent_agent_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModelsResponse) String() string {
+func (x *ImportAgentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModelsResponse) ProtoMessage() {}
+func (*ImportAgentResponse) ProtoMessage() {}
 
-func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_agent_proto_msgTypes[11]
+func (x *ImportAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,14 +1644,21 @@ func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
-func (*ListModelsResponse) Descriptor() ([]byte, []int) {
-	return file_agent_agent_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ImportAgentResponse.ProtoReflect.Descriptor instead.
+func (*ImportAgentResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ListModelsResponse) GetModels() []*Model {
+func (x *ImportAgentResponse) GetAgent() *Agent {
 	if x != nil {
-		return x.Models
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *ImportAgentResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
 	}
 	return nil
 }
@@ -765,7 +1670,10 @@ const file_agent_agent_proto_rawDesc = "" +
 	"\x11agent/agent.proto\x12\fblippy.agent\x1a\x1fgoogle/protobuf/timestamp.proto\"S\n" +
 	"\x13AgentFilesystemRoot\x12\x17\n" +
 	"\aroot_id\x18\x01 \x01(\tR\x06rootId\x12#\n" +
-	"\renabled_tools\x18\x02 \x03(\tR\fenabledTools\"\xfb\x03\n" +
+	"\renabled_tools\x18\x02 \x03(\tR\fenabledTools\"7\n" +
+	"\tAgentHook\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06config\x18\x02 \x01(\tR\x06config\"\x80\a\n" +
 	"\x05Agent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -780,7 +1688,27 @@ const file_agent_agent_proto_rawDesc = "" +
 	"\x05model\x18\t \x01(\tR\x05model\x12[\n" +
 	"\x18enabled_filesystem_roots\x18\n" +
 	" \x03(\v2!.blippy.agent.AgentFilesystemRootR\x16enabledFilesystemRoots\x125\n" +
-	"\x17forwarded_host_env_vars\x18\v \x03(\tR\x14forwardedHostEnvVars\"\x82\x03\n" +
+	"\x17forwarded_host_env_vars\x18\v \x03(\tR\x14forwardedHostEnvVars\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\x12-\n" +
+	"\x05hooks\x18\r \x03(\v2\x17.blippy.agent.AgentHookR\x05hooks\x12.\n" +
+	"\x13max_concurrent_runs\x18\x0e \x01(\x05R\x11maxConcurrentRuns\x12:\n" +
+	"\x19title_generation_disabled\x18\x0f \x01(\bR\x17titleGenerationDisabled\x12!\n" +
+	"\ftitle_prompt\x18\x10 \x01(\tR\vtitlePrompt\x12\x1f\n" +
+	"\vtitle_model\x18\x11 \x01(\tR\n" +
+	"titleModel\x12/\n" +
+	"\apersona\x18\x12 \x01(\v2\x15.blippy.agent.PersonaR\apersona\x12A\n" +
+	"\x0eapproval_rules\x18\x13 \x03(\v2\x1a.blippy.agent.ApprovalRuleR\rapprovalRules\x12\x16\n" +
+	"\x06locale\x18\x14 \x01(\tR\x06locale\"\x84\x01\n" +
+	"\fApprovalRule\x12\x12\n" +
+	"\x04tool\x18\x01 \x01(\tR\x04tool\x12\x1a\n" +
+	"\bargument\x18\x02 \x01(\tR\bargument\x12\x18\n" +
+	"\apattern\x18\x03 \x01(\tR\apattern\x12*\n" +
+	"\x11larger_than_bytes\x18\x04 \x01(\x05R\x0flargerThanBytes\"k\n" +
+	"\aPersona\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x14\n" +
+	"\x05goals\x18\x02 \x01(\tR\x05goals\x12 \n" +
+	"\vconstraints\x18\x03 \x01(\tR\vconstraints\x12\x14\n" +
+	"\x05style\x18\x04 \x01(\tR\x05style\"\xed\x05\n" +
 	"\x12CreateAgentRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12#\n" +
@@ -789,12 +1717,30 @@ const file_agent_agent_proto_rawDesc = "" +
 	"\x1denabled_notification_channels\x18\x05 \x03(\tR\x1benabledNotificationChannels\x12\x14\n" +
 	"\x05model\x18\x06 \x01(\tR\x05model\x12[\n" +
 	"\x18enabled_filesystem_roots\x18\a \x03(\v2!.blippy.agent.AgentFilesystemRootR\x16enabledFilesystemRoots\x125\n" +
-	"\x17forwarded_host_env_vars\x18\b \x03(\tR\x14forwardedHostEnvVars\"!\n" +
+	"\x17forwarded_host_env_vars\x18\b \x03(\tR\x14forwardedHostEnvVars\x12-\n" +
+	"\x05hooks\x18\t \x03(\v2\x17.blippy.agent.AgentHookR\x05hooks\x12.\n" +
+	"\x13max_concurrent_runs\x18\n" +
+	" \x01(\x05R\x11maxConcurrentRuns\x12:\n" +
+	"\x19title_generation_disabled\x18\v \x01(\bR\x17titleGenerationDisabled\x12!\n" +
+	"\ftitle_prompt\x18\f \x01(\tR\vtitlePrompt\x12\x1f\n" +
+	"\vtitle_model\x18\r \x01(\tR\n" +
+	"titleModel\x12/\n" +
+	"\apersona\x18\x0e \x01(\v2\x15.blippy.agent.PersonaR\apersona\x12A\n" +
+	"\x0eapproval_rules\x18\x0f \x03(\v2\x1a.blippy.agent.ApprovalRuleR\rapprovalRules\x12\x16\n" +
+	"\x06locale\x18\x10 \x01(\tR\x06locale\"!\n" +
 	"\x0fGetAgentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x13\n" +
-	"\x11ListAgentsRequest\"A\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x82\x01\n" +
+	"\x11ListAgentsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\"\x88\x01\n" +
 	"\x12ListAgentsResponse\x12+\n" +
-	"\x06agents\x18\x01 \x03(\v2\x13.blippy.agent.AgentR\x06agents\"\x92\x03\n" +
+	"\x06agents\x18\x01 \x03(\v2\x13.blippy.agent.AgentR\x06agents\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\x97\x06\n" +
 	"\x12UpdateAgentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -804,7 +1750,18 @@ const file_agent_agent_proto_rawDesc = "" +
 	"\x1denabled_notification_channels\x18\x06 \x03(\tR\x1benabledNotificationChannels\x12\x14\n" +
 	"\x05model\x18\a \x01(\tR\x05model\x12[\n" +
 	"\x18enabled_filesystem_roots\x18\b \x03(\v2!.blippy.agent.AgentFilesystemRootR\x16enabledFilesystemRoots\x125\n" +
-	"\x17forwarded_host_env_vars\x18\t \x03(\tR\x14forwardedHostEnvVars\"$\n" +
+	"\x17forwarded_host_env_vars\x18\t \x03(\tR\x14forwardedHostEnvVars\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x12-\n" +
+	"\x05hooks\x18\v \x03(\v2\x17.blippy.agent.AgentHookR\x05hooks\x12.\n" +
+	"\x13max_concurrent_runs\x18\f \x01(\x05R\x11maxConcurrentRuns\x12:\n" +
+	"\x19title_generation_disabled\x18\r \x01(\bR\x17titleGenerationDisabled\x12!\n" +
+	"\ftitle_prompt\x18\x0e \x01(\tR\vtitlePrompt\x12\x1f\n" +
+	"\vtitle_model\x18\x0f \x01(\tR\n" +
+	"titleModel\x12/\n" +
+	"\apersona\x18\x10 \x01(\v2\x15.blippy.agent.PersonaR\apersona\x12A\n" +
+	"\x0eapproval_rules\x18\x11 \x03(\v2\x1a.blippy.agent.ApprovalRuleR\rapprovalRules\x12\x16\n" +
+	"\x06locale\x18\x12 \x01(\tR\x06locale\"$\n" +
 	"\x12DeleteAgentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\a\n" +
 	"\x05Empty\"\x81\x01\n" +
@@ -815,7 +1772,35 @@ const file_agent_agent_proto_rawDesc = "" +
 	"\x12completion_pricing\x18\x04 \x01(\tR\x11completionPricing\"\x13\n" +
 	"\x11ListModelsRequest\"A\n" +
 	"\x12ListModelsResponse\x12+\n" +
-	"\x06models\x18\x01 \x03(\v2\x13.blippy.agent.ModelR\x06models2\xc2\x03\n" +
+	"\x06models\x18\x01 \x03(\v2\x13.blippy.agent.ModelR\x06models\"X\n" +
+	"\x18GetAgentToolStatsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fperiod_hours\x18\x02 \x01(\x05R\vperiodHours\"\xd8\x01\n" +
+	"\tToolStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05calls\x18\x03 \x01(\x03R\x05calls\x12\x16\n" +
+	"\x06errors\x18\x04 \x01(\x03R\x06errors\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x05 \x01(\x01R\terrorRate\x12&\n" +
+	"\x0favg_duration_ms\x18\x06 \x01(\x03R\ravgDurationMs\x12(\n" +
+	"\x10avg_result_bytes\x18\a \x01(\x03R\x0eavgResultBytes\"\xc2\x01\n" +
+	"\x19GetAgentToolStatsResponse\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x12\n" +
+	"\x04runs\x18\x03 \x01(\x03R\x04runs\x12-\n" +
+	"\x05tools\x18\x04 \x03(\v2\x17.blippy.agent.ToolStatsR\x05tools\"<\n" +
+	"\x12ExportAgentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"-\n" +
+	"\x13ExportAgentResponse\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\tR\x06bundle\"<\n" +
+	"\x12ImportAgentRequest\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\tR\x06bundle\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\\\n" +
+	"\x13ImportAgentResponse\x12)\n" +
+	"\x05agent\x18\x01 \x01(\v2\x13.blippy.agent.AgentR\x05agent\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings2\xd0\x05\n" +
 	"\fAgentService\x12D\n" +
 	"\vCreateAgent\x12 .blippy.agent.CreateAgentRequest\x1a\x13.blippy.agent.Agent\x12>\n" +
 	"\bGetAgent\x12\x1d.blippy.agent.GetAgentRequest\x1a\x13.blippy.agent.Agent\x12O\n" +
@@ -824,7 +1809,10 @@ const file_agent_agent_proto_rawDesc = "" +
 	"\vUpdateAgent\x12 .blippy.agent.UpdateAgentRequest\x1a\x13.blippy.agent.Agent\x12D\n" +
 	"\vDeleteAgent\x12 .blippy.agent.DeleteAgentRequest\x1a\x13.blippy.agent.Empty\x12O\n" +
 	"\n" +
-	"ListModels\x12\x1f.blippy.agent.ListModelsRequest\x1a .blippy.agent.ListModelsResponseB+Z)github.com/dstotijn/blippy/internal/agentb\x06proto3"
+	"ListModels\x12\x1f.blippy.agent.ListModelsRequest\x1a .blippy.agent.ListModelsResponse\x12d\n" +
+	"\x11GetAgentToolStats\x12&.blippy.agent.GetAgentToolStatsRequest\x1a'.blippy.agent.GetAgentToolStatsResponse\x12R\n" +
+	"\vExportAgent\x12 .blippy.agent.ExportAgentRequest\x1a!.blippy.agent.ExportAgentResponse\x12R\n" +
+	"\vImportAgent\x12 .blippy.agent.ImportAgentRequest\x1a!.blippy.agent.ImportAgentResponseB+Z)github.com/dstotijn/blippy/internal/agentb\x06proto3"
 
 var (
 	file_agent_agent_proto_rawDescOnce sync.Once
@@ -838,47 +1826,76 @@ func file_agent_agent_proto_rawDescGZIP() []byte {
 	return file_agent_agent_proto_rawDescData
 }
 
-var file_agent_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_agent_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_agent_agent_proto_goTypes = []any{
-	(*AgentFilesystemRoot)(nil),   // 0: blippy.agent.AgentFilesystemRoot
-	(*Agent)(nil),                 // 1: blippy.agent.Agent
-	(*CreateAgentRequest)(nil),    // 2: blippy.agent.CreateAgentRequest
-	(*GetAgentRequest)(nil),       // 3: blippy.agent.GetAgentRequest
-	(*ListAgentsRequest)(nil),     // 4: blippy.agent.ListAgentsRequest
-	(*ListAgentsResponse)(nil),    // 5: blippy.agent.ListAgentsResponse
-	(*UpdateAgentRequest)(nil),    // 6: blippy.agent.UpdateAgentRequest
-	(*DeleteAgentRequest)(nil),    // 7: blippy.agent.DeleteAgentRequest
-	(*Empty)(nil),                 // 8: blippy.agent.Empty
-	(*Model)(nil),                 // 9: blippy.agent.Model
-	(*ListModelsRequest)(nil),     // 10: blippy.agent.ListModelsRequest
-	(*ListModelsResponse)(nil),    // 11: blippy.agent.ListModelsResponse
-	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*AgentFilesystemRoot)(nil),       // 0: blippy.agent.AgentFilesystemRoot
+	(*AgentHook)(nil),                 // 1: blippy.agent.AgentHook
+	(*Agent)(nil),                     // 2: blippy.agent.Agent
+	(*ApprovalRule)(nil),              // 3: blippy.agent.ApprovalRule
+	(*Persona)(nil),                   // 4: blippy.agent.Persona
+	(*CreateAgentRequest)(nil),        // 5: blippy.agent.CreateAgentRequest
+	(*GetAgentRequest)(nil),           // 6: blippy.agent.GetAgentRequest
+	(*ListAgentsRequest)(nil),         // 7: blippy.agent.ListAgentsRequest
+	(*ListAgentsResponse)(nil),        // 8: blippy.agent.ListAgentsResponse
+	(*UpdateAgentRequest)(nil),        // 9: blippy.agent.UpdateAgentRequest
+	(*DeleteAgentRequest)(nil),        // 10: blippy.agent.DeleteAgentRequest
+	(*Empty)(nil),                     // 11: blippy.agent.Empty
+	(*Model)(nil),                     // 12: blippy.agent.Model
+	(*ListModelsRequest)(nil),         // 13: blippy.agent.ListModelsRequest
+	(*ListModelsResponse)(nil),        // 14: blippy.agent.ListModelsResponse
+	(*GetAgentToolStatsRequest)(nil),  // 15: blippy.agent.GetAgentToolStatsRequest
+	(*ToolStats)(nil),                 // 16: blippy.agent.ToolStats
+	(*GetAgentToolStatsResponse)(nil), // 17: blippy.agent.GetAgentToolStatsResponse
+	(*ExportAgentRequest)(nil),        // 18: blippy.agent.ExportAgentRequest
+	(*ExportAgentResponse)(nil),       // 19: blippy.agent.ExportAgentResponse
+	(*ImportAgentRequest)(nil),        // 20: blippy.agent.ImportAgentRequest
+	(*ImportAgentResponse)(nil),       // 21: blippy.agent.ImportAgentResponse
+	(*timestamppb.Timestamp)(nil),     // 22: google.protobuf.Timestamp
 }
 var file_agent_agent_proto_depIdxs = []int32{
-	12, // 0: blippy.agent.Agent.created_at:type_name -> google.protobuf.Timestamp
-	12, // 1: blippy.agent.Agent.updated_at:type_name -> google.protobuf.Timestamp
+	22, // 0: blippy.agent.Agent.created_at:type_name -> google.protobuf.Timestamp
+	22, // 1: blippy.agent.Agent.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: blippy.agent.Agent.enabled_filesystem_roots:type_name -> blippy.agent.AgentFilesystemRoot
-	0,  // 3: blippy.agent.CreateAgentRequest.enabled_filesystem_roots:type_name -> blippy.agent.AgentFilesystemRoot
-	1,  // 4: blippy.agent.ListAgentsResponse.agents:type_name -> blippy.agent.Agent
-	0,  // 5: blippy.agent.UpdateAgentRequest.enabled_filesystem_roots:type_name -> blippy.agent.AgentFilesystemRoot
-	9,  // 6: blippy.agent.ListModelsResponse.models:type_name -> blippy.agent.Model
-	2,  // 7: blippy.agent.AgentService.CreateAgent:input_type -> blippy.agent.CreateAgentRequest
-	3,  // 8: blippy.agent.AgentService.GetAgent:input_type -> blippy.agent.GetAgentRequest
-	4,  // 9: blippy.agent.AgentService.ListAgents:input_type -> blippy.agent.ListAgentsRequest
-	6,  // 10: blippy.agent.AgentService.UpdateAgent:input_type -> blippy.agent.UpdateAgentRequest
-	7,  // 11: blippy.agent.AgentService.DeleteAgent:input_type -> blippy.agent.DeleteAgentRequest
-	10, // 12: blippy.agent.AgentService.ListModels:input_type -> blippy.agent.ListModelsRequest
-	1,  // 13: blippy.agent.AgentService.CreateAgent:output_type -> blippy.agent.Agent
-	1,  // 14: blippy.agent.AgentService.GetAgent:output_type -> blippy.agent.Agent
-	5,  // 15: blippy.agent.AgentService.ListAgents:output_type -> blippy.agent.ListAgentsResponse
-	1,  // 16: blippy.agent.AgentService.UpdateAgent:output_type -> blippy.agent.Agent
-	8,  // 17: blippy.agent.AgentService.DeleteAgent:output_type -> blippy.agent.Empty
-	11, // 18: blippy.agent.AgentService.ListModels:output_type -> blippy.agent.ListModelsResponse
-	13, // [13:19] is the sub-list for method output_type
-	7,  // [7:13] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	1,  // 3: blippy.agent.Agent.hooks:type_name -> blippy.agent.AgentHook
+	4,  // 4: blippy.agent.Agent.persona:type_name -> blippy.agent.Persona
+	3,  // 5: blippy.agent.Agent.approval_rules:type_name -> blippy.agent.ApprovalRule
+	0,  // 6: blippy.agent.CreateAgentRequest.enabled_filesystem_roots:type_name -> blippy.agent.AgentFilesystemRoot
+	1,  // 7: blippy.agent.CreateAgentRequest.hooks:type_name -> blippy.agent.AgentHook
+	4,  // 8: blippy.agent.CreateAgentRequest.persona:type_name -> blippy.agent.Persona
+	3,  // 9: blippy.agent.CreateAgentRequest.approval_rules:type_name -> blippy.agent.ApprovalRule
+	2,  // 10: blippy.agent.ListAgentsResponse.agents:type_name -> blippy.agent.Agent
+	0,  // 11: blippy.agent.UpdateAgentRequest.enabled_filesystem_roots:type_name -> blippy.agent.AgentFilesystemRoot
+	1,  // 12: blippy.agent.UpdateAgentRequest.hooks:type_name -> blippy.agent.AgentHook
+	4,  // 13: blippy.agent.UpdateAgentRequest.persona:type_name -> blippy.agent.Persona
+	3,  // 14: blippy.agent.UpdateAgentRequest.approval_rules:type_name -> blippy.agent.ApprovalRule
+	12, // 15: blippy.agent.ListModelsResponse.models:type_name -> blippy.agent.Model
+	22, // 16: blippy.agent.GetAgentToolStatsResponse.since:type_name -> google.protobuf.Timestamp
+	22, // 17: blippy.agent.GetAgentToolStatsResponse.until:type_name -> google.protobuf.Timestamp
+	16, // 18: blippy.agent.GetAgentToolStatsResponse.tools:type_name -> blippy.agent.ToolStats
+	2,  // 19: blippy.agent.ImportAgentResponse.agent:type_name -> blippy.agent.Agent
+	5,  // 20: blippy.agent.AgentService.CreateAgent:input_type -> blippy.agent.CreateAgentRequest
+	6,  // 21: blippy.agent.AgentService.GetAgent:input_type -> blippy.agent.GetAgentRequest
+	7,  // 22: blippy.agent.AgentService.ListAgents:input_type -> blippy.agent.ListAgentsRequest
+	9,  // 23: blippy.agent.AgentService.UpdateAgent:input_type -> blippy.agent.UpdateAgentRequest
+	10, // 24: blippy.agent.AgentService.DeleteAgent:input_type -> blippy.agent.DeleteAgentRequest
+	13, // 25: blippy.agent.AgentService.ListModels:input_type -> blippy.agent.ListModelsRequest
+	15, // 26: blippy.agent.AgentService.GetAgentToolStats:input_type -> blippy.agent.GetAgentToolStatsRequest
+	18, // 27: blippy.agent.AgentService.ExportAgent:input_type -> blippy.agent.ExportAgentRequest
+	20, // 28: blippy.agent.AgentService.ImportAgent:input_type -> blippy.agent.ImportAgentRequest
+	2,  // 29: blippy.agent.AgentService.CreateAgent:output_type -> blippy.agent.Agent
+	2,  // 30: blippy.agent.AgentService.GetAgent:output_type -> blippy.agent.Agent
+	8,  // 31: blippy.agent.AgentService.ListAgents:output_type -> blippy.agent.ListAgentsResponse
+	2,  // 32: blippy.agent.AgentService.UpdateAgent:output_type -> blippy.agent.Agent
+	11, // 33: blippy.agent.AgentService.DeleteAgent:output_type -> blippy.agent.Empty
+	14, // 34: blippy.agent.AgentService.ListModels:output_type -> blippy.agent.ListModelsResponse
+	17, // 35: blippy.agent.AgentService.GetAgentToolStats:output_type -> blippy.agent.GetAgentToolStatsResponse
+	19, // 36: blippy.agent.AgentService.ExportAgent:output_type -> blippy.agent.ExportAgentResponse
+	21, // 37: blippy.agent.AgentService.ImportAgent:output_type -> blippy.agent.ImportAgentResponse
+	29, // [29:38] is the sub-list for method output_type
+	20, // [20:29] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_agent_agent_proto_init() }
@@ -892,7 +1909,7 @@ func file_agent_agent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agent_agent_proto_rawDesc), len(file_agent_agent_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
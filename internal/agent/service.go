@@ -5,24 +5,36 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/listing"
+	"github.com/dstotijn/blippy/internal/locale"
+	"github.com/dstotijn/blippy/internal/manifest"
 	"github.com/dstotijn/blippy/internal/openrouter"
 	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
 )
 
 type Service struct {
 	queries  *store.Queries
+	cipher   *encryption.Cipher
 	orClient *openrouter.Client
 }
 
-func NewService(db *sql.DB, orClient *openrouter.Client) *Service {
+// NewService creates a new Service. The cipher encrypts the vars of triggers
+// in agent bundles, and may be nil to store them as plaintext.
+func NewService(db *sql.DB, cipher *encryption.Cipher, orClient *openrouter.Client) *Service {
 	return &Service{
 		queries:  store.New(db),
+		cipher:   cipher,
 		orClient: orClient,
 	}
 }
@@ -57,6 +69,71 @@ func unmarshalFSRoots(data string) []*AgentFilesystemRoot {
 	return roots
 }
 
+// storedHook is the JSON shape stored in the database for post-turn hooks.
+type storedHook struct {
+	Name   string          `json:"name"`
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+func marshalHooks(protoHooks []*AgentHook) ([]byte, error) {
+	stored := make([]storedHook, len(protoHooks))
+	for i, h := range protoHooks {
+		if h.Name == "" {
+			return nil, errors.New("hook name is required")
+		}
+		stored[i] = storedHook{Name: h.Name}
+		if h.Config == "" {
+			continue
+		}
+		var config map[string]any
+		if err := json.Unmarshal([]byte(h.Config), &config); err != nil {
+			return nil, fmt.Errorf("config of hook %q must be a JSON object", h.Name)
+		}
+		stored[i].Config = json.RawMessage(h.Config)
+	}
+	return json.Marshal(stored)
+}
+
+func unmarshalHooks(data string) []*AgentHook {
+	var stored []storedHook
+	_ = json.Unmarshal([]byte(data), &stored)
+	hooks := make([]*AgentHook, len(stored))
+	for i, s := range stored {
+		hooks[i] = &AgentHook{Name: s.Name, Config: string(s.Config)}
+	}
+	return hooks
+}
+
+func marshalApprovalRules(protoRules []*ApprovalRule) ([]byte, error) {
+	rules := make([]tool.ApprovalRule, len(protoRules))
+	for i, r := range protoRules {
+		rules[i] = tool.ApprovalRule{
+			Tool:            r.Tool,
+			Argument:        r.Argument,
+			Pattern:         r.Pattern,
+			LargerThanBytes: int(r.LargerThanBytes),
+		}
+		if err := rules[i].Validate(); err != nil {
+			return nil, fmt.Errorf("approval rule %d: %w", i+1, err)
+		}
+	}
+	return json.Marshal(rules)
+}
+
+func unmarshalApprovalRules(data string) []*ApprovalRule {
+	rules, _ := tool.DecodeApprovalRules(data)
+	protoRules := make([]*ApprovalRule, len(rules))
+	for i, r := range rules {
+		protoRules[i] = &ApprovalRule{
+			Tool:            r.Tool,
+			Argument:        r.Argument,
+			Pattern:         r.Pattern,
+			LargerThanBytes: int32(r.LargerThanBytes),
+		}
+	}
+	return protoRules
+}
+
 func (s *Service) CreateAgent(ctx context.Context, req *connect.Request[CreateAgentRequest]) (*connect.Response[Agent], error) {
 	now := time.Now().UTC()
 
@@ -80,6 +157,25 @@ func (s *Service) CreateAgent(ctx context.Context, req *connect.Request[CreateAg
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	hooks, err := marshalHooks(req.Msg.Hooks)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	approvalRules, err := marshalApprovalRules(req.Msg.ApprovalRules)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if req.Msg.MaxConcurrentRuns < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max_concurrent_runs must not be negative"))
+	}
+
+	loc, err := locale.Parse(req.Msg.Locale)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	agent, err := s.queries.CreateAgent(ctx, store.CreateAgentParams{
 		ID:                          uuid.NewString(),
 		Name:                        req.Msg.Name,
@@ -90,6 +186,17 @@ func (s *Service) CreateAgent(ctx context.Context, req *connect.Request[CreateAg
 		EnabledFilesystemRoots:      string(enabledFilesystemRoots),
 		Model:                       req.Msg.Model,
 		ForwardedHostEnvVars:        string(forwardedHostEnvVars),
+		Hooks:                       string(hooks),
+		MaxConcurrentRuns:           int64(req.Msg.MaxConcurrentRuns),
+		TitleGenerationDisabled:     boolToInt(req.Msg.TitleGenerationDisabled),
+		TitlePrompt:                 req.Msg.TitlePrompt,
+		TitleModel:                  req.Msg.TitleModel,
+		PersonaRole:                 req.Msg.GetPersona().GetRole(),
+		PersonaGoals:                req.Msg.GetPersona().GetGoals(),
+		PersonaConstraints:          req.Msg.GetPersona().GetConstraints(),
+		PersonaStyle:                req.Msg.GetPersona().GetStyle(),
+		ApprovalRules:               string(approvalRules),
+		Locale:                      loc,
 		CreatedAt:                   now.Format(time.RFC3339),
 		UpdatedAt:                   now.Format(time.RFC3339),
 	})
@@ -104,7 +211,7 @@ func (s *Service) GetAgent(ctx context.Context, req *connect.Request[GetAgentReq
 	agent, err := s.queries.GetAgent(ctx, req.Msg.Id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("agent not found"))
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -113,20 +220,50 @@ func (s *Service) GetAgent(ctx context.Context, req *connect.Request[GetAgentReq
 }
 
 func (s *Service) ListAgents(ctx context.Context, req *connect.Request[ListAgentsRequest]) (*connect.Response[ListAgentsResponse], error) {
-	agents, err := s.queries.ListAgents(ctx)
+	query, err := listing.Parse(listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Filter:    req.Msg.Filter,
+	}, agentTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListAgentsPage, s.queries.CountAgentsPage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	protoAgents := make([]*Agent, len(agents))
-	for i, a := range agents {
+	protoAgents := make([]*Agent, len(page.Items))
+	for i, a := range page.Items {
 		protoAgents[i] = toProtoAgent(a)
 	}
 
-	return connect.NewResponse(&ListAgentsResponse{Agents: protoAgents}), nil
+	return connect.NewResponse(&ListAgentsResponse{
+		Agents:        protoAgents,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}), nil
+}
+
+// agentTable has the fields usable in ListAgents filters and order clauses.
+var agentTable = listing.Table[store.Agent]{
+	Fields: listing.Fields[store.Agent]{
+		"id":          {Column: "id", Value: func(a store.Agent) string { return a.ID }},
+		"name":        {Column: "name", Value: func(a store.Agent) string { return a.Name }},
+		"description": {Column: "description", Value: func(a store.Agent) string { return a.Description }},
+		"model":       {Column: "model", Value: func(a store.Agent) string { return a.Model }},
+		"created_at":  {Column: "created_at", Value: func(a store.Agent) string { return a.CreatedAt }},
+		"updated_at":  {Column: "updated_at", Value: func(a store.Agent) string { return a.UpdatedAt }},
+	},
+	Order: "created_at desc",
 }
 
 func (s *Service) UpdateAgent(ctx context.Context, req *connect.Request[UpdateAgentRequest]) (*connect.Response[Agent], error) {
+	if req.Msg.Version == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("version is required"))
+	}
+
 	enabledTools, err := json.Marshal(req.Msg.EnabledTools)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
@@ -147,6 +284,25 @@ func (s *Service) UpdateAgent(ctx context.Context, req *connect.Request[UpdateAg
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	hooks, err := marshalHooks(req.Msg.Hooks)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	approvalRules, err := marshalApprovalRules(req.Msg.ApprovalRules)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if req.Msg.MaxConcurrentRuns < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max_concurrent_runs must not be negative"))
+	}
+
+	loc, err := locale.Parse(req.Msg.Locale)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	agent, err := s.queries.UpdateAgent(ctx, store.UpdateAgentParams{
 		ID:                          req.Msg.Id,
 		Name:                        req.Msg.Name,
@@ -157,11 +313,26 @@ func (s *Service) UpdateAgent(ctx context.Context, req *connect.Request[UpdateAg
 		EnabledFilesystemRoots:      string(enabledFilesystemRoots),
 		Model:                       req.Msg.Model,
 		ForwardedHostEnvVars:        string(forwardedHostEnvVars),
+		Hooks:                       string(hooks),
+		MaxConcurrentRuns:           int64(req.Msg.MaxConcurrentRuns),
+		TitleGenerationDisabled:     boolToInt(req.Msg.TitleGenerationDisabled),
+		TitlePrompt:                 req.Msg.TitlePrompt,
+		TitleModel:                  req.Msg.TitleModel,
+		PersonaRole:                 req.Msg.GetPersona().GetRole(),
+		PersonaGoals:                req.Msg.GetPersona().GetGoals(),
+		PersonaConstraints:          req.Msg.GetPersona().GetConstraints(),
+		PersonaStyle:                req.Msg.GetPersona().GetStyle(),
+		ApprovalRules:               string(approvalRules),
+		Locale:                      loc,
 		UpdatedAt:                   time.Now().UTC().Format(time.RFC3339),
+		Version:                     req.Msg.Version,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("agent not found"))
+			if _, err := s.queries.GetAgent(ctx, req.Msg.Id); err == nil {
+				return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_VERSION_CONFLICT, errors.New("agent was modified since it was loaded; reload and try again"))
+			}
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -172,7 +343,7 @@ func (s *Service) UpdateAgent(ctx context.Context, req *connect.Request[UpdateAg
 func (s *Service) DeleteAgent(ctx context.Context, req *connect.Request[DeleteAgentRequest]) (*connect.Response[Empty], error) {
 	if _, err := s.queries.GetAgent(ctx, req.Msg.Id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("agent not found"))
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -203,6 +374,108 @@ func (s *Service) ListModels(ctx context.Context, req *connect.Request[ListModel
 	return connect.NewResponse(&ListModelsResponse{Models: protoModels}), nil
 }
 
+// defaultToolStatsPeriod is the period of GetAgentToolStats if none is given.
+const defaultToolStatsPeriod = 7 * 24 * time.Hour
+
+func (s *Service) GetAgentToolStats(ctx context.Context, req *connect.Request[GetAgentToolStatsRequest]) (*connect.Response[GetAgentToolStatsResponse], error) {
+	if req.Msg.PeriodHours < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("period_hours must not be negative"))
+	}
+	agent, err := s.queries.GetAgent(ctx, req.Msg.AgentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	period := defaultToolStatsPeriod
+	if req.Msg.PeriodHours > 0 {
+		period = time.Duration(req.Msg.PeriodHours) * time.Hour
+	}
+	until := time.Now().UTC()
+	since := until.Add(-period)
+	rows, err := s.queries.ListRunTracesByAgentSince(ctx, store.ListRunTracesByAgentSinceParams{
+		AgentID:   agent.ID,
+		StartedAt: since.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	traces := make([]agentloop.Trace, len(rows))
+	for i, row := range rows {
+		_ = json.Unmarshal([]byte(row), &traces[i])
+	}
+
+	var enabledTools []string
+	_ = json.Unmarshal([]byte(agent.EnabledTools), &enabledTools)
+
+	res := &GetAgentToolStatsResponse{
+		Since: timestamppb.New(since),
+		Until: timestamppb.New(until),
+		Runs:  int64(len(traces)),
+	}
+	for _, st := range agentloop.AggregateToolStats(traces, enabledTools) {
+		res.Tools = append(res.Tools, &ToolStats{
+			Name:           st.Name,
+			Enabled:        st.Enabled,
+			Calls:          st.Calls,
+			Errors:         st.Errors,
+			ErrorRate:      st.ErrorRate(),
+			AvgDurationMs:  st.AvgDurationMS(),
+			AvgResultBytes: st.AvgResultBytes(),
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *Service) ExportAgent(ctx context.Context, req *connect.Request[ExportAgentRequest]) (*connect.Response[ExportAgentResponse], error) {
+	if f := req.Msg.Format; f != "" && f != "json" && f != "yaml" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown format %q, expected json or yaml", f))
+	}
+
+	bundle, err := manifest.ExportBundle(ctx, s.queries, s.cipher, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	data, err := manifest.MarshalBundle(bundle, req.Msg.Format)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&ExportAgentResponse{Bundle: string(data)}), nil
+}
+
+func (s *Service) ImportAgent(ctx context.Context, req *connect.Request[ImportAgentRequest]) (*connect.Response[ImportAgentResponse], error) {
+	bundle, err := manifest.UnmarshalBundle([]byte(req.Msg.Bundle))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	res, err := manifest.ImportBundle(ctx, s.queries, s.cipher, bundle, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	agent, err := s.queries.GetAgent(ctx, res.AgentID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&ImportAgentResponse{
+		Agent:    toProtoAgent(agent),
+		Warnings: res.Warnings,
+	}), nil
+}
+
 func toProtoAgent(a store.Agent) *Agent {
 	var enabledTools []string
 	_ = json.Unmarshal([]byte(a.EnabledTools), &enabledTools)
@@ -215,6 +488,13 @@ func toProtoAgent(a store.Agent) *Agent {
 	var forwardedHostEnvVars []string
 	_ = json.Unmarshal([]byte(a.ForwardedHostEnvVars), &forwardedHostEnvVars)
 
+	persona := &Persona{
+		Role:        a.PersonaRole,
+		Goals:       a.PersonaGoals,
+		Constraints: a.PersonaConstraints,
+		Style:       a.PersonaStyle,
+	}
+
 	createdAt, _ := time.Parse(time.RFC3339, a.CreatedAt)
 	updatedAt, _ := time.Parse(time.RFC3339, a.UpdatedAt)
 
@@ -227,8 +507,24 @@ func toProtoAgent(a store.Agent) *Agent {
 		EnabledNotificationChannels: enabledNotificationChannels,
 		EnabledFilesystemRoots:      enabledFilesystemRoots,
 		ForwardedHostEnvVars:        forwardedHostEnvVars,
+		Hooks:                       unmarshalHooks(a.Hooks),
+		MaxConcurrentRuns:           int32(a.MaxConcurrentRuns),
+		TitleGenerationDisabled:     a.TitleGenerationDisabled != 0,
+		TitlePrompt:                 a.TitlePrompt,
+		TitleModel:                  a.TitleModel,
+		Persona:                     persona,
+		ApprovalRules:               unmarshalApprovalRules(a.ApprovalRules),
+		Locale:                      a.Locale,
 		Model:                       a.Model,
 		CreatedAt:                   timestamppb.New(createdAt),
 		UpdatedAt:                   timestamppb.New(updatedAt),
+		Version:                     a.Version,
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
 	}
+	return 0
 }
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TestUpdateAgentVersion checks that updates with a stale version are
+// rejected without changing the agent.
+func TestUpdateAgentVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	svc := NewService(db, nil, nil)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "original", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.UpdateAgent(ctx, connect.NewRequest(&UpdateAgentRequest{Id: "agent-1", Name: "no version"})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("update without version: err = %v, want invalid argument", err)
+	}
+
+	res, err := svc.UpdateAgent(ctx, connect.NewRequest(&UpdateAgentRequest{Id: "agent-1", Name: "first tab", Version: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Version != 2 {
+		t.Errorf("version after update = %d, want 2", res.Msg.Version)
+	}
+
+	if _, err := svc.UpdateAgent(ctx, connect.NewRequest(&UpdateAgentRequest{Id: "agent-1", Name: "second tab", Version: 1})); connect.CodeOf(err) != connect.CodeAborted {
+		t.Errorf("update with stale version: err = %v, want aborted", err)
+	}
+	agent, err := queries.GetAgent(ctx, "agent-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.Name != "first tab" || agent.Version != 2 {
+		t.Errorf("agent after stale update = %q (version %d), want %q (version 2)", agent.Name, agent.Version, "first tab")
+	}
+
+	if _, err := svc.UpdateAgent(ctx, connect.NewRequest(&UpdateAgentRequest{Id: "missing", Name: "x", Version: 1})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("update of missing agent: err = %v, want not found", err)
+	}
+}
@@ -0,0 +1,214 @@
+package agentloop
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// DefaultApprovalTimeout is how long a tool call waits for approval before
+// it's denied.
+const DefaultApprovalTimeout = time.Hour
+
+// ErrApprovalNotFound is returned by ResolveApproval for tool calls that
+// aren't waiting for approval.
+var ErrApprovalNotFound = errors.New("approval not found")
+
+// PendingApproval is a tool call waiting for approval, because it matched an
+// approval rule of its agent.
+type PendingApproval struct {
+	ID             string
+	RunID          string
+	ConversationID string
+	AgentID        string
+	AgentName      string
+	ToolName       string
+	Input          string
+	// Reason describes the rule the call matched.
+	Reason      string
+	RequestedAt time.Time
+	ExpiresAt   time.Time
+}
+
+type approvals struct {
+	mu      sync.Mutex
+	pending map[string]*pendingApproval
+}
+
+type pendingApproval struct {
+	info    PendingApproval
+	decided chan approvalDecision // buffered, receives one decision
+}
+
+type approvalDecision struct {
+	approved bool
+	reason   string
+}
+
+func (a *approvals) add(p *pendingApproval) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending == nil {
+		a.pending = make(map[string]*pendingApproval)
+	}
+	a.pending[p.info.ID] = p
+}
+
+// take removes a pending approval, so it's decided once.
+func (a *approvals) take(id string) (*pendingApproval, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	p, ok := a.pending[id]
+	delete(a.pending, id)
+	return p, ok
+}
+
+// PendingApprovals returns the tool calls of turns on this server that are
+// waiting for approval, oldest first.
+func (l *Loop) PendingApprovals() []PendingApproval {
+	l.approvals.mu.Lock()
+	pending := make([]PendingApproval, 0, len(l.approvals.pending))
+	for _, p := range l.approvals.pending {
+		pending = append(pending, p.info)
+	}
+	l.approvals.mu.Unlock()
+
+	slices.SortFunc(pending, func(a, b PendingApproval) int {
+		return a.RequestedAt.Compare(b.RequestedAt)
+	})
+	return pending
+}
+
+// ResolveApproval approves or denies a tool call waiting for approval. The
+// reason a call is denied is reported to the agent.
+func (l *Loop) ResolveApproval(id string, approved bool, reason string) error {
+	p, ok := l.approvals.take(id)
+	if !ok {
+		return ErrApprovalNotFound
+	}
+	p.decided <- approvalDecision{approved: approved, reason: reason}
+	return nil
+}
+
+// turnApprover makes the tool calls of a turn that match its agent's
+// approval rules wait for approval.
+type turnApprover struct {
+	l     *Loop
+	rules []tool.ApprovalRule
+	run   PendingApproval // the run, conversation and agent of the calls
+	// parentConversationID is the conversation of the turn that called the
+	// agent, if any, which is told about approvals too.
+	parentConversationID string
+}
+
+// withApprover returns a context with the approver of a turn's agent, or
+// without one if the agent has no approval rules.
+func (l *Loop) withApprover(ctx context.Context, opts TurnOpts, info ActiveRun) context.Context {
+	rules, err := tool.DecodeApprovalRules(opts.Agent.ApprovalRules)
+	if err != nil {
+		// Stored rules are validated, so this doesn't happen unless the
+		// database was edited; fail safe by requiring approval for all.
+		l.logger().Error("failed to decode approval rules", "agent_id", opts.Agent.ID, "error", err)
+		rules = []tool.ApprovalRule{{Tool: "*"}}
+	}
+	var approver tool.Approver
+	if len(rules) > 0 {
+		approver = &turnApprover{
+			l:     l,
+			rules: rules,
+			run: PendingApproval{
+				RunID:          info.ID,
+				ConversationID: opts.Conv.ID,
+				AgentID:        opts.Agent.ID,
+				AgentName:      opts.Agent.Name,
+			},
+			parentConversationID: opts.ParentConversationID,
+		}
+	}
+	return tool.WithApprover(ctx, approver)
+}
+
+// Approve implements tool.Approver. Calls matching a rule wait until they're
+// resolved with ResolveApproval, ApprovalTimeout passes, or the turn stops.
+func (a *turnApprover) Approve(ctx context.Context, name string, args json.RawMessage) error {
+	rule, ok := tool.MatchApprovalRules(a.rules, name, args)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	p := &pendingApproval{info: a.run, decided: make(chan approvalDecision, 1)}
+	p.info.ID = uuid.NewString()
+	p.info.ToolName = name
+	p.info.Input = string(args)
+	p.info.Reason = rule.String()
+	p.info.RequestedAt = now
+	p.info.ExpiresAt = now.Add(cmp.Or(a.l.ApprovalTimeout, DefaultApprovalTimeout))
+	a.l.approvals.add(p)
+	a.publish(&pubsub.Event_ApprovalRequested{ApprovalRequested: &pubsub.ApprovalRequested{
+		ApprovalId:     p.info.ID,
+		RunId:          p.info.RunID,
+		ConversationId: p.info.ConversationID,
+		AgentId:        p.info.AgentID,
+		AgentName:      p.info.AgentName,
+		ToolName:       name,
+		Input:          p.info.Input,
+		Reason:         p.info.Reason,
+		ExpiresAt:      p.info.ExpiresAt.UTC().Format(time.RFC3339),
+	}})
+
+	timer := time.NewTimer(time.Until(p.info.ExpiresAt))
+	defer timer.Stop()
+
+	var d approvalDecision
+	select {
+	case d = <-p.decided:
+	case <-timer.C:
+		d = approvalDecision{reason: "not approved in time"}
+		if _, ok := a.l.approvals.take(p.info.ID); !ok {
+			// Resolved while expiring.
+			d = <-p.decided
+		}
+	case <-ctx.Done():
+		d = approvalDecision{reason: "run stopped"}
+		if _, ok := a.l.approvals.take(p.info.ID); !ok {
+			d = <-p.decided
+		}
+	}
+	a.publish(&pubsub.Event_ApprovalResolved{ApprovalResolved: &pubsub.ApprovalResolved{
+		ApprovalId: p.info.ID,
+		Approved:   d.approved,
+		Reason:     d.reason,
+	}})
+
+	if err := context.Cause(ctx); err != nil {
+		return err
+	}
+	if !d.approved {
+		if d.reason == "" {
+			return tool.ErrToolCallDenied
+		}
+		return fmt.Errorf("%w: %s", tool.ErrToolCallDenied, d.reason)
+	}
+	return nil
+}
+
+// publish publishes an approval event to the conversation of the turn and,
+// for subagents, to that of the turn that called the agent, whose user may
+// be the one to approve.
+func (a *turnApprover) publish(payload pubsub.Payload) {
+	a.l.Broker.Publish(a.run.ConversationID, payload)
+	if a.parentConversationID != "" {
+		a.l.Broker.Publish(a.parentConversationID, payload)
+	}
+}
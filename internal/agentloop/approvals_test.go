@@ -0,0 +1,91 @@
+package agentloop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestApprove(t *testing.T) {
+	broker := pubsub.New(nil, slog.Default())
+	sub := broker.SubscribeTopics([]string{pubsub.ConversationTopic("conv-1"), pubsub.ConversationTopic("parent-1")})
+	defer broker.Unsubscribe(sub)
+
+	l := &Loop{Broker: broker, ApprovalTimeout: time.Minute}
+	a := &turnApprover{
+		l:                    l,
+		rules:                []tool.ApprovalRule{{Tool: "bash", Argument: "command", Pattern: "rm|curl"}},
+		run:                  PendingApproval{RunID: "run-1", ConversationID: "conv-1", AgentID: "agent-1"},
+		parentConversationID: "parent-1",
+	}
+	ctx := context.Background()
+
+	if err := a.Approve(ctx, "bash", json.RawMessage(`{"command": "ls"}`)); err != nil {
+		t.Fatalf("Approve of call without matching rule = %v", err)
+	}
+
+	resolve := func(approved bool, reason string) {
+		for {
+			if pending := l.PendingApprovals(); len(pending) == 1 {
+				if err := l.ResolveApproval(pending[0].ID, approved, reason); err != nil {
+					t.Error(err)
+				}
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	go resolve(true, "")
+	if err := a.Approve(ctx, "bash", json.RawMessage(`{"command": "curl example.com"}`)); err != nil {
+		t.Errorf("Approve of approved call = %v", err)
+	}
+	go resolve(false, "not on prod")
+	err := a.Approve(ctx, "bash", json.RawMessage(`{"command": "rm -rf /"}`))
+	if !errors.Is(err, tool.ErrToolCallDenied) || err.Error() != "tool call denied: not on prod" {
+		t.Errorf("Approve of denied call = %v, want ErrToolCallDenied with reason", err)
+	}
+
+	// Both conversations get a request and a resolution per call.
+	var requested, resolved int
+	for range 8 {
+		switch (<-sub.C).Payload.(type) {
+		case *pubsub.Event_ApprovalRequested:
+			requested++
+		case *pubsub.Event_ApprovalResolved:
+			resolved++
+		}
+	}
+	if requested != 4 || resolved != 4 {
+		t.Errorf("got %d requested and %d resolved events, want 4 each", requested, resolved)
+	}
+
+	if err := l.ResolveApproval("unknown", true, ""); !errors.Is(err, ErrApprovalNotFound) {
+		t.Errorf("ResolveApproval of unknown approval = %v, want ErrApprovalNotFound", err)
+	}
+}
+
+func TestApproveTimeout(t *testing.T) {
+	l := &Loop{Broker: pubsub.New(nil, slog.Default()), ApprovalTimeout: 10 * time.Millisecond}
+	a := &turnApprover{l: l, rules: []tool.ApprovalRule{{Tool: "notify:*"}}, run: PendingApproval{ConversationID: "conv-1"}}
+
+	err := a.Approve(context.Background(), "notify:ops", json.RawMessage(`{}`))
+	if !errors.Is(err, tool.ErrToolCallDenied) {
+		t.Errorf("Approve of expired call = %v, want ErrToolCallDenied", err)
+	}
+	if pending := l.PendingApprovals(); len(pending) != 0 {
+		t.Errorf("PendingApprovals after expiry = %v, want none", pending)
+	}
+
+	// Calls of stopped turns return the turn's stop cause.
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrCancelled)
+	if err := a.Approve(ctx, "notify:ops", json.RawMessage(`{}`)); !errors.Is(err, ErrCancelled) {
+		t.Errorf("Approve in cancelled turn = %v, want ErrCancelled", err)
+	}
+}
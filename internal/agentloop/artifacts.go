@@ -0,0 +1,37 @@
+package agentloop
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// runArtifacts stores the artifacts tools register on a run in the
+// artifacts table, so they can be listed per run or conversation.
+type runArtifacts struct {
+	queries *store.Queries
+	runID   string
+	convID  string
+}
+
+// RecordArtifact implements tool.ArtifactRecorder.
+func (r *runArtifacts) RecordArtifact(ctx context.Context, call tool.ToolCall, a tool.Artifact) error {
+	return r.queries.CreateArtifact(ctx, store.CreateArtifactParams{
+		ID:             uuid.NewString(),
+		RunID:          r.runID,
+		ConversationID: r.convID,
+		CallID:         call.ID,
+		ToolName:       call.Name,
+		Kind:           a.Kind,
+		Name:           a.Name,
+		Uri:            a.URI,
+		MediaType:      a.MediaType,
+		Size:           a.Size,
+		BlobID:         store.NewNullString(a.BlobID),
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,85 @@
+package agentloop
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestRunArtifacts(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	agent, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: `["deploy"]`, EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", Title: "Deploy", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(fixture, []byte(`{"rules": [{"steps": [
+		{"tool_calls": [{"name": "deploy", "arguments": {}}]},
+		{"text": "Deployed."}
+	]}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := openrouter.LoadMockFixture(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := tool.NewRegistry()
+	registry.Register(&tool.Tool{
+		Name:       "deploy",
+		Parameters: json.RawMessage(`{"type": "object"}`),
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			if err := tool.RecordArtifact(ctx, tool.Artifact{Kind: tool.ArtifactKindURL, Name: "Preview", URI: "https://preview.example.com"}); err != nil {
+				return "", err
+			}
+			return "Deployed to https://preview.example.com", nil
+		},
+	})
+	l := &Loop{
+		Queries:      queries,
+		ORClient:     openrouter.NewMockClient(f),
+		ToolExecutor: tool.NewExecutor(registry, nil, nil, nil),
+		Broker:       pubsub.New(nil, slog.New(slog.DiscardHandler)),
+		DefaultModel: "mock",
+	}
+
+	history, _, err := l.StartTurn(ctx, conv.ID, "Deploy the site")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.RunTurn(ctx, TurnOpts{Conv: conv, Agent: agent, UserContent: "Deploy the site", History: history, RunID: "run-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	artifacts, err := queries.ListArtifactsByRun(ctx, store.ListArtifactsByRunParams{ConversationID: conv.ID, RunID: "run-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 1 {
+		t.Fatalf("got %d artifacts, want 1", len(artifacts))
+	}
+	if a := artifacts[0]; a.ToolName != "deploy" || a.CallID == "" || a.Kind != tool.ArtifactKindURL || a.Uri != "https://preview.example.com" {
+		t.Errorf("artifact = %+v, want preview URL of the deploy call", a)
+	}
+}
@@ -0,0 +1,103 @@
+package agentloop
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+)
+
+// ItemTypeAttachment items are files attached to a user message. Only the turn
+// of the message sends their contents to the LLM; later turns get a note with
+// their name, see attachmentNote.
+const ItemTypeAttachment = "attachment"
+
+// tokensPerAttachment is the assumed size of an attachment in tokens. It
+// depends on the model, e.g. how it tiles images or extracts text from PDFs,
+// so it isn't estimated from the file size.
+const tokensPerAttachment = 2_000
+
+// Attachment is a file attached to a user message. Its contents are stored in
+// the blob with BlobID, which the caller creates before StartTurn.
+type Attachment struct {
+	// ID identifies the attachment in the message's items.
+	ID        string
+	BlobID    string
+	Name      string
+	MediaType string
+	Data      []byte
+}
+
+// imageTypes are the image types multimodal models accept.
+var imageTypes = []string{"image/png", "image/jpeg", "image/webp", "image/gif"}
+
+// SupportedAttachmentType reports whether files of a media type can be sent
+// to the LLM: images, PDFs and text files.
+func SupportedAttachmentType(mediaType string) bool {
+	return attachmentKind(mediaType) != ""
+}
+
+// attachmentKind returns the type of content part a file of a media type is
+// sent as, or "" if it's not supported.
+func attachmentKind(mediaType string) string {
+	mediaType, _, _ = mime.ParseMediaType(mediaType)
+	switch {
+	case strings.HasPrefix(mediaType, "image/"):
+		for _, t := range imageTypes {
+			if mediaType == t {
+				return "input_image"
+			}
+		}
+		return ""
+	case mediaType == "application/pdf":
+		return "input_file"
+	case strings.HasPrefix(mediaType, "text/"), mediaType == "application/json":
+		return "input_text"
+	}
+	return ""
+}
+
+// attachmentParts returns the content parts of attachments. Images are sent
+// as input_image and PDFs as input_file, both as data URLs. Text files are
+// inlined, so they work with any model.
+func attachmentParts(attachments []Attachment) []openrouter.ContentPart {
+	parts := make([]openrouter.ContentPart, 0, len(attachments))
+	for _, a := range attachments {
+		dataURL := "data:" + a.MediaType + ";base64," + base64.StdEncoding.EncodeToString(a.Data)
+		switch attachmentKind(a.MediaType) {
+		case "input_image":
+			parts = append(parts, openrouter.ContentPart{Type: "input_image", ImageURL: dataURL})
+		case "input_file":
+			parts = append(parts, openrouter.ContentPart{Type: "input_file", Filename: a.Name, FileData: dataURL})
+		case "input_text":
+			parts = append(parts, openrouter.ContentPart{
+				Type: "input_text",
+				Text: fmt.Sprintf("Attached file %s:\n\n%s", a.Name, a.Data),
+			})
+		}
+	}
+	return parts
+}
+
+// attachmentItems returns the message items of attachments.
+func attachmentItems(attachments []Attachment) []StoredItem {
+	items := make([]StoredItem, len(attachments))
+	for i, a := range attachments {
+		items[i] = StoredItem{
+			Type:         ItemTypeAttachment,
+			AttachmentID: a.ID,
+			Name:         a.Name,
+			MediaType:    a.MediaType,
+			Size:         int64(len(a.Data)),
+		}
+	}
+	return items
+}
+
+// attachmentNote tells the LLM about a file attached to an earlier message,
+// whose contents it no longer gets.
+func attachmentNote(item StoredItem) string {
+	return fmt.Sprintf("[Attached file %s (%s), contents not shown]", item.Name, item.MediaType)
+}
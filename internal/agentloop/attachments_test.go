@@ -0,0 +1,105 @@
+package agentloop
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestAttachmentParts(t *testing.T) {
+	parts := attachmentParts([]Attachment{
+		{Name: "chart.png", MediaType: "image/png", Data: []byte("png")},
+		{Name: "report.pdf", MediaType: "application/pdf", Data: []byte("pdf")},
+		{Name: "notes.md", MediaType: "text/markdown", Data: []byte("# Notes")},
+	})
+	if len(parts) != 3 {
+		t.Fatalf("got %d parts, want 3", len(parts))
+	}
+	if p := parts[0]; p.Type != "input_image" || p.ImageURL != "data:image/png;base64,cG5n" {
+		t.Errorf("image part = %+v", p)
+	}
+	if p := parts[1]; p.Type != "input_file" || p.Filename != "report.pdf" || p.FileData != "data:application/pdf;base64,cGRm" {
+		t.Errorf("file part = %+v", p)
+	}
+	if p := parts[2]; p.Type != "input_text" || !strings.Contains(p.Text, "notes.md") || !strings.HasSuffix(p.Text, "# Notes") {
+		t.Errorf("text part = %+v", p)
+	}
+
+	for mediaType, want := range map[string]bool{
+		"image/jpeg":                true,
+		"text/csv; charset=utf-8":   true,
+		"image/svg+xml":             false,
+		"application/zip":           false,
+		"application/octet-stream":  false,
+		"application/json":          true,
+		"application/pdf; foo=bar":  true,
+		"application/vnd.ms-excel":  false,
+		"video/mp4":                 false,
+		"text/html; charset=latin1": true,
+	} {
+		if got := SupportedAttachmentType(mediaType); got != want {
+			t.Errorf("SupportedAttachmentType(%q) = %v, want %v", mediaType, got, want)
+		}
+	}
+}
+
+func TestStartTurnAttachments(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateBlob(ctx, store.CreateBlobParams{ID: "blob-1", Kind: "attachment", ConversationID: store.NewNullString("conv-1"), Name: "chart.png", ContentType: "image/png", Size: 3, CreatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Loop{Queries: queries, Broker: pubsub.New(nil, slog.Default())}
+	_, msgID, err := l.StartTurn(ctx, "conv-1", "What does this show?", Attachment{
+		ID:        "att-1",
+		BlobID:    "blob-1",
+		Name:      "chart.png",
+		MediaType: "image/png",
+		Data:      []byte("png"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attachments, err := queries.ListAttachmentsByConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attachments) != 1 || attachments[0].MessageID != msgID || attachments[0].BlobID != "blob-1" {
+		t.Errorf("attachments = %+v, want blob-1 of message %s", attachments, msgID)
+	}
+
+	// Later turns get a note instead of the image.
+	msgs, err := queries.GetMessagesByConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs, err := BuildHistoryInputs(msgs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "What does this show?\n\n[Attached file chart.png (image/png), contents not shown]"
+	if len(inputs) != 1 || len(inputs[0].Content) != 1 || inputs[0].Content[0].Text != want {
+		t.Errorf("history inputs = %+v, want text %q", inputs, want)
+	}
+}
@@ -0,0 +1,134 @@
+package agentloop
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+type capturerKey struct{}
+
+// capturer stores the LLM exchanges of a turn whose agent or conversation
+// has an active LLM capture. Its methods do nothing on a nil capturer, which
+// turns that aren't captured have.
+type capturer struct {
+	capture store.LlmCapture
+	runID   string
+	convID  string
+	agentID string
+	round   int
+	current *openrouter.Capture
+}
+
+func withCapturer(ctx context.Context, c *capturer) context.Context {
+	return context.WithValue(ctx, capturerKey{}, c)
+}
+
+func capturerFrom(ctx context.Context) *capturer {
+	c, _ := ctx.Value(capturerKey{}).(*capturer)
+	return c
+}
+
+// newCapturer returns a capturer if the agent or conversation of a turn has
+// an active capture, or nil.
+func (l *Loop) newCapturer(ctx context.Context, runID string, conv store.Conversation, agent store.Agent) *capturer {
+	capture, err := l.Queries.GetActiveLLMCapture(ctx, store.GetActiveLLMCaptureParams{
+		AgentID:        sql.NullString{String: agent.ID, Valid: true},
+		ConversationID: sql.NullString{String: conv.ID, Valid: true},
+		ExpiresAt:      time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			l.logger().Error("failed to get LLM capture", "conversation_id", conv.ID, "error", err)
+		}
+		return nil
+	}
+	return &capturer{capture: capture, runID: runID, convID: conv.ID, agentID: agent.ID}
+}
+
+// startRound returns a context that captures the LLM request of a
+// round-trip.
+func (c *capturer) startRound(ctx context.Context) context.Context {
+	if c == nil {
+		return ctx
+	}
+	c.round++
+	c.current = &openrouter.Capture{}
+	return openrouter.WithCapture(ctx, c.current)
+}
+
+// saveRound stores the exchange of the current round-trip, with the secrets
+// known to the tool executor and sensitive headers redacted.
+func (l *Loop) saveRound(ctx context.Context, c *capturer, roundErr error) {
+	if c == nil || c.current == nil {
+		return
+	}
+	e := c.current.Exchange()
+	c.current = nil
+	if e.URL == "" {
+		// The request wasn't sent, e.g. because the turn was cancelled.
+		return
+	}
+
+	headers := make(map[string]string, len(e.ResponseHeaders))
+	for name, values := range e.ResponseHeaders {
+		value := strings.Join(values, ", ")
+		if tool.IsSensitiveHeader(name) {
+			value = tool.RedactedValue
+		}
+		headers[name] = value
+	}
+	headersJSON, _ := json.Marshal(headers)
+
+	var errMsg string
+	if roundErr != nil {
+		errMsg = roundErr.Error()
+	}
+	var truncated int64
+	if e.Truncated {
+		truncated = 1
+	}
+	now := time.Now().UTC()
+	ctx = context.WithoutCancel(ctx)
+	err := l.Queries.CreateLLMExchange(ctx, store.CreateLLMExchangeParams{
+		ID:              uuid.NewString(),
+		CaptureID:       c.capture.ID,
+		RunID:           c.runID,
+		ConversationID:  c.convID,
+		AgentID:         c.agentID,
+		Round:           int64(c.round),
+		Url:             e.URL,
+		KeyID:           e.KeyID,
+		RequestBody:     l.ToolExecutor.RedactSecrets(ctx, e.RequestBody),
+		StatusCode:      int64(e.StatusCode),
+		ResponseHeaders: string(headersJSON),
+		ResponseBody:    l.ToolExecutor.RedactSecrets(ctx, e.ResponseBody),
+		Truncated:       truncated,
+		Error:           errMsg,
+		CreatedAt:       now.Format(time.RFC3339),
+		ExpiresAt:       now.Add(time.Duration(c.capture.RetentionSeconds) * time.Second).Format(time.RFC3339),
+	})
+	if err != nil {
+		l.logger().Error("failed to store LLM exchange", "run_id", c.runID, "error", err)
+	}
+}
+
+// PruneCaptures deletes expired LLM captures and captured exchanges whose
+// retention has passed. It's meant to run as a scheduler job.
+func (l *Loop) PruneCaptures(ctx context.Context) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := l.Queries.DeleteExpiredLLMCaptures(ctx, now); err != nil {
+		return err
+	}
+	_, err := l.Queries.DeleteExpiredLLMExchanges(ctx, now)
+	return err
+}
@@ -0,0 +1,116 @@
+package agentloop
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestCaptureLLMExchanges(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	t.Setenv("BLIPPY_TEST_TOKEN", "tok-3f9a2b7c")
+	now := time.Now().UTC()
+	agent, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: `["BLIPPY_TEST_TOKEN"]`, CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", Title: "Weather", CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(fixture, []byte(`{"rules": [{"steps": [
+		{"tool_calls": [{"name": "fetch", "arguments": {"url": "https://example.com"}}]},
+		{"text": "It's sunny."}
+	]}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := openrouter.LoadMockFixture(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &Loop{
+		Queries:      queries,
+		ORClient:     openrouter.NewMockClient(f),
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       pubsub.New(nil, slog.New(slog.DiscardHandler)),
+		DefaultModel: "mock",
+	}
+	runTurn := func(runID string) {
+		t.Helper()
+		content := "What's the weather? My token is tok-3f9a2b7c."
+		history, _, err := l.StartTurn(ctx, conv.ID, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := l.RunTurn(ctx, TurnOpts{Conv: conv, Agent: agent, UserContent: content, History: history, RunID: runID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Turns aren't captured without an active capture.
+	runTurn("run-1")
+	if exchanges, err := queries.ListLLMExchangesByConversation(ctx, conv.ID); err != nil || len(exchanges) != 0 {
+		t.Fatalf("exchanges = %d, %v, want none", len(exchanges), err)
+	}
+
+	if _, err := queries.CreateLLMCapture(ctx, store.CreateLLMCaptureParams{
+		ID:               "capture-1",
+		AgentID:          sql.NullString{String: agent.ID, Valid: true},
+		RetentionSeconds: 3600,
+		ExpiresAt:        now.Add(time.Hour).Format(time.RFC3339),
+		CreatedAt:        now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	runTurn("run-2")
+
+	exchanges, err := queries.ListLLMExchangesByRun(ctx, "run-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exchanges) != 2 {
+		t.Fatalf("got %d exchanges, want 2", len(exchanges))
+	}
+	for i, e := range exchanges {
+		if e.CaptureID != "capture-1" || e.Round != int64(i+1) || e.StatusCode != 200 || !strings.HasSuffix(e.Url, "/responses") {
+			t.Errorf("exchange %d = %+v", i, e)
+		}
+		if !strings.Contains(e.RequestBody, `"model":"mock"`) || !strings.Contains(e.ResponseBody, "response.completed") {
+			t.Errorf("exchange %d bodies = %s, %s", i, e.RequestBody, e.ResponseBody)
+		}
+		if strings.Contains(e.RequestBody, "tok-3f9a2b7c") || !strings.Contains(e.RequestBody, tool.RedactedValue) {
+			t.Errorf("exchange %d request body isn't redacted: %s", i, e.RequestBody)
+		}
+	}
+	// The second request includes the tool call of the first.
+	if !strings.Contains(exchanges[1].RequestBody, "function_call") {
+		t.Errorf("second request = %s, want tool call", exchanges[1].RequestBody)
+	}
+
+	// Exchanges are kept for the capture's retention.
+	if err := l.PruneCaptures(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if exchanges, _ := queries.ListLLMExchangesByRun(ctx, "run-2"); len(exchanges) != 2 {
+		t.Errorf("got %d exchanges after pruning, want 2", len(exchanges))
+	}
+}
@@ -0,0 +1,92 @@
+package agentloop
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// MessageStatusInProgress is the status of the assistant message of a turn
+// that is still running. Its items are checkpointed after each LLM
+// round-trip, so a crash only loses the round-trip in flight.
+const MessageStatusInProgress = "in_progress"
+
+// checkpoint is the assistant message a turn's items are checkpointed to,
+// produced by run runID. It has no message until the first save.
+type checkpoint struct {
+	runID     string
+	msgID     string
+	createdAt string
+}
+
+// save stores items as the turn's in-progress message, creating it on the
+// first call.
+func (cp *checkpoint) save(ctx context.Context, queries *store.Queries, convID string, items []StoredItem) error {
+	itemsJSON, err := EncodeItems(items)
+	if err != nil {
+		return fmt.Errorf("encode items: %w", err)
+	}
+	if cp.msgID != "" {
+		return queries.UpdateMessageItems(ctx, store.UpdateMessageItemsParams{
+			ID:     cp.msgID,
+			Items:  itemsJSON,
+			Status: MessageStatusInProgress,
+		})
+	}
+
+	msgID := uuid.NewString()
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateMessage(ctx, store.CreateMessageParams{
+		ID:             msgID,
+		ConversationID: convID,
+		Role:           "assistant",
+		Items:          itemsJSON,
+		Status:         MessageStatusInProgress,
+		RunID:          cp.runID,
+		CreatedAt:      createdAt,
+	}); err != nil {
+		return fmt.Errorf("create assistant message: %w", err)
+	}
+	cp.msgID = msgID
+	cp.createdAt = createdAt
+	return nil
+}
+
+// fail marks the turn's in-progress message as failed, if there is one, for
+// turns that end with an error before their output is stored.
+func (cp *checkpoint) fail(ctx context.Context, queries *store.Queries, logger *slog.Logger, items []StoredItem) {
+	if cp.msgID == "" {
+		return
+	}
+	itemsJSON, err := EncodeItems(items)
+	if err == nil {
+		err = queries.UpdateMessageItems(context.WithoutCancel(ctx), store.UpdateMessageItemsParams{
+			ID:     cp.msgID,
+			Items:  itemsJSON,
+			Status: MessageStatusFailed,
+		})
+	}
+	if err != nil {
+		logger.Error("failed to mark checkpointed message as failed", "message_id", cp.msgID, "error", err)
+	}
+}
+
+// RecoverCheckpoints marks the in-progress messages of conversations without
+// an unexpired lease as interrupted. Their turns were cut off by a crash, and
+// the checkpointed items are kept. It has the signature of a scheduler job,
+// and must only run when the broker uses leases.
+func (l *Loop) RecoverCheckpoints(ctx context.Context) error {
+	n, err := l.Queries.InterruptUnleasedMessages(ctx, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		l.logger().Warn("marked checkpointed messages of cut off turns as interrupted", "count", n)
+	}
+	return nil
+}
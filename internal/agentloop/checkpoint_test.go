@@ -0,0 +1,91 @@
+package agentloop
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	message := func() store.Message {
+		t.Helper()
+		msgs, err := queries.GetMessagesByConversation(ctx, "conv-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(msgs) != 1 {
+			t.Fatalf("got %d messages, want 1", len(msgs))
+		}
+		return msgs[0]
+	}
+
+	cp := &checkpoint{}
+	items := []StoredItem{{Type: ItemTypeText, Text: "Looking"}}
+	if err := cp.save(ctx, queries, "conv-1", items); err != nil {
+		t.Fatal(err)
+	}
+	items = append(items, StoredItem{Type: ItemTypeToolExecution, CallID: "call-1", Name: "fetch_url", Input: "{}", Result: "ok"})
+	if err := cp.save(ctx, queries, "conv-1", items); err != nil {
+		t.Fatal(err)
+	}
+	msg := message()
+	if msg.ID != cp.msgID || msg.Status != MessageStatusInProgress {
+		t.Errorf("message = %s (%s), want %s (in_progress)", msg.ID, msg.Status, cp.msgID)
+	}
+	got, err := DecodeItems(msg.Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("got %d checkpointed items, want 2", len(got))
+	}
+
+	// The turn's lease is held, so it's still running.
+	leases := pubsub.NewStoreLeases(queries)
+	if ok, err := leases.Acquire(ctx, "conv-1"); err != nil || !ok {
+		t.Fatalf("Acquire = %v, %v", ok, err)
+	}
+	l := &Loop{Queries: queries}
+	if err := l.RecoverCheckpoints(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if msg := message(); msg.Status != MessageStatusInProgress {
+		t.Errorf("status with lease = %q, want in_progress", msg.Status)
+	}
+
+	if err := leases.Release(ctx, "conv-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RecoverCheckpoints(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if msg := message(); msg.Status != MessageStatusInterrupted {
+		t.Errorf("status without lease = %q, want interrupted", msg.Status)
+	}
+
+	cp.fail(ctx, queries, slog.Default(), items)
+	if msg := message(); msg.Status != MessageStatusFailed {
+		t.Errorf("status after fail = %q, want failed", msg.Status)
+	}
+}
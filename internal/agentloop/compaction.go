@@ -0,0 +1,145 @@
+package agentloop
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+const (
+	// maxTranscriptBytes limits the transcript of messages summarized in a
+	// single request; longer histories are summarized in parts.
+	maxTranscriptBytes = 120_000
+	// maxTranscriptItemBytes limits each tool call and result in a
+	// transcript, which are rarely needed in full for a summary.
+	maxTranscriptItemBytes = 2000
+)
+
+// compactHistory returns the history window of a turn, starting with the
+// conversation summary if it has one. When the messages after the summary
+// don't fit budget, the oldest are added to the summary, which is stored
+// for following turns. It compacts to half the budget, so the next turns
+// don't need to summarize again. If summarizing fails, or compaction is
+// disabled, the oldest messages are left out instead.
+func (l *Loop) compactHistory(ctx context.Context, conv store.Conversation, history []store.Message, model string, budget int) (historyWindow, error) {
+	summary := conv.Summary
+	start := summarizedMessages(history, conv.SummaryMessageID)
+	if start == 0 {
+		summary = ""
+	}
+
+	w, err := l.windowHistory(ctx, history[start:], budget-summaryTokens(summary))
+	if err != nil || w.dropped == 0 || l.CompactionDisabled {
+		w.summary = summary
+		return w, err
+	}
+
+	compacted, err := l.windowHistory(ctx, history[start:], (budget-summaryTokens(summary))/2)
+	if err != nil {
+		return historyWindow{}, err
+	}
+	end := start + compacted.dropped
+	newSummary, err := l.summarize(ctx, cmp.Or(l.SummaryModel, model), summary, history[start:end])
+	if err != nil {
+		l.logger().Warn("failed to summarize history", "conversation_id", conv.ID, "error", err)
+		w.summary = summary
+		return w, nil
+	}
+	if err := l.Queries.UpdateConversationSummary(ctx, store.UpdateConversationSummaryParams{
+		Summary:          newSummary,
+		SummaryMessageID: history[end-1].ID,
+		ID:               conv.ID,
+	}); err != nil {
+		l.logger().Warn("failed to store history summary", "conversation_id", conv.ID, "error", err)
+	}
+	l.logger().Debug("summarized oldest messages of history", "conversation_id", conv.ID, "summarized", end)
+
+	w, err = l.windowHistory(ctx, history[end:], budget-summaryTokens(newSummary))
+	w.summary = newSummary
+	return w, err
+}
+
+// summarizedMessages returns the number of oldest messages of history that
+// the summary through message msgID covers, or 0 if it isn't in history.
+func summarizedMessages(history []store.Message, msgID string) int {
+	if msgID == "" {
+		return 0
+	}
+	for i, msg := range history {
+		if msg.ID == msgID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// summarize adds the messages to summary, in parts of at most
+// maxTranscriptBytes.
+func (l *Loop) summarize(ctx context.Context, model, summary string, msgs []store.Message) (string, error) {
+	var transcript strings.Builder
+	for i, msg := range msgs {
+		inputs, err := l.historyInputs(msg)
+		if err != nil {
+			return "", err
+		}
+		WriteTranscript(&transcript, inputs)
+		if transcript.Len() < maxTranscriptBytes && i < len(msgs)-1 {
+			continue
+		}
+		if summary, err = l.ORClient.Summarize(ctx, model, summary, transcript.String()); err != nil {
+			return "", err
+		}
+		transcript.Reset()
+	}
+	return summary, nil
+}
+
+// WriteTranscript writes inputs as a plain text transcript, e.g. for summarizing.
+func WriteTranscript(sb *strings.Builder, inputs []openrouter.Input) {
+	for _, in := range inputs {
+		switch in.Type {
+		case "message":
+			role := "User"
+			if in.Role == "assistant" {
+				role = "Assistant"
+			}
+			for _, c := range in.Content {
+				fmt.Fprintf(sb, "%s: %s\n\n", role, c.Text)
+			}
+		case "function_call":
+			fmt.Fprintf(sb, "Assistant called tool %s with: %s\n", in.Name, truncate(RedactSealedArgs(in.Arguments), maxTranscriptItemBytes))
+		case "function_call_output":
+			fmt.Fprintf(sb, "Tool result: %s\n\n", truncate(in.Output, maxTranscriptItemBytes))
+		}
+	}
+}
+
+// summaryInput returns the input item with the conversation summary.
+func summaryInput(summary string) openrouter.Input {
+	return openrouter.Input{
+		Type: "message",
+		Role: "system",
+		Content: []openrouter.ContentPart{
+			{Type: "input_text", Text: "## Summary of the earlier conversation\n" + summary},
+		},
+	}
+}
+
+// summaryTokens estimates the tokens summary takes up as input.
+func summaryTokens(summary string) int {
+	if summary == "" {
+		return 0
+	}
+	return EstimateTokens([]openrouter.Input{summaryInput(summary)})
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,83 @@
+package agentloop
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestCompactHistory(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	conv, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each message is 4 + 30/3 = 14 tokens.
+	for i, role := range []string{"user", "assistant", "user", "assistant", "user", "assistant"} {
+		items, err := EncodeItems([]StoredItem{{Type: ItemTypeText, Text: fmt.Sprintf("%s message %d%s", role, i, strings.Repeat(".", 30-len(role)-10))}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateMessage(ctx, store.CreateMessageParams{ID: fmt.Sprintf("msg-%d", i), ConversationID: "conv-1", Role: role, Items: items, Status: "completed", CreatedAt: fmt.Sprintf("2025-01-01T00:00:0%dZ", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	history, err := queries.GetMessagesByConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget := 14 * 4
+
+	l := &Loop{Queries: queries, ORClient: openrouter.NewMockClient(&openrouter.MockFixture{Default: "Summary."}), CompactionDisabled: true}
+	w, err := l.compactHistory(ctx, conv, history, "mock", budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.dropped != 2 || w.summary != "" {
+		t.Errorf("without compaction: dropped %d, summary %q; want 2 dropped and no summary", w.dropped, w.summary)
+	}
+
+	// The 4 oldest messages are summarized, compacting to half the budget.
+	l.CompactionDisabled = false
+	w, err = l.compactHistory(ctx, conv, history, "mock", budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.dropped != 0 || w.summary != "Summary." || len(w.inputs) != 2 {
+		t.Errorf("with compaction: dropped %d, summary %q, %d inputs; want none dropped, summary and 2 inputs", w.dropped, w.summary, len(w.inputs))
+	}
+	conv, err = queries.GetConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conv.Summary != "Summary." || conv.SummaryMessageID != "msg-3" {
+		t.Errorf("stored summary %q through %q, want summary through msg-3", conv.Summary, conv.SummaryMessageID)
+	}
+
+	// Following turns use the stored summary without summarizing again.
+	l.ORClient = nil
+	w, err = l.compactHistory(ctx, conv, history, "mock", budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.dropped != 0 || w.summary != "Summary." || len(w.inputs) != 2 {
+		t.Errorf("with stored summary: dropped %d, summary %q, %d inputs; want none dropped, summary and 2 inputs", w.dropped, w.summary, len(w.inputs))
+	}
+}
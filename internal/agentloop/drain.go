@@ -0,0 +1,302 @@
+package agentloop
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message statuses.
+const (
+	MessageStatusCompleted   = "completed"
+	MessageStatusInterrupted = "interrupted"
+	// MessageStatusFailed is the status of messages of turns stopped because
+	// they didn't converge. They end with an error item.
+	MessageStatusFailed = "failed"
+	// MessageStatusCancelled is the status of messages of turns cancelled
+	// with CancelRun.
+	MessageStatusCancelled = "cancelled"
+	// MessageStatusTimedOut is the status of messages of turns that ran
+	// longer than their deadline, see ErrTimedOut.
+	MessageStatusTimedOut = "timed_out"
+)
+
+var (
+	// ErrInterrupted is returned by RunTurn when the turn was cancelled by
+	// Drain. The output produced so far is stored as an interrupted message.
+	ErrInterrupted = errors.New("turn interrupted by server shutdown")
+	// ErrShuttingDown is returned by RunTurn when Drain has been called.
+	ErrShuttingDown = errors.New("server is shutting down")
+)
+
+// turns tracks the turns in progress, so they can be listed, cancelled, and
+// drained on shutdown.
+type turns struct {
+	mu       sync.Mutex
+	runs     map[string]*run
+	hooks    map[int]context.CancelFunc
+	nextHook int
+	wg       sync.WaitGroup
+	draining bool
+	// ended is closed when a run ends or draining starts, to wake up runs
+	// waiting for a slot, and replaced by the next waiter.
+	ended chan struct{}
+	// waiting holds the runs waiting for a slot, which take free slots in
+	// order of priority and arrival.
+	waiting map[*waiter]struct{}
+	nextSeq uint64
+}
+
+// waiter is a run waiting for a slot of its agent.
+type waiter struct {
+	agentID  string
+	priority int
+	seq      uint64
+}
+
+// before reports whether w gets a slot before o.
+func (w *waiter) before(o *waiter) bool {
+	if w.priority != o.priority {
+		return w.priority > o.priority
+	}
+	return w.seq < o.seq
+}
+
+type run struct {
+	info   ActiveRun
+	cancel context.CancelCauseFunc
+	// limited runs count towards their agent's concurrency limit.
+	limited bool
+}
+
+// begin registers a turn. The returned context is cancelled with
+// ErrInterrupted if the turn is still running when draining times out, or
+// with ErrCancelled by CancelRun, and end must be called when the turn
+// returns. A run ID is generated if info has none.
+func (t *turns) begin(ctx context.Context, info ActiveRun) (_ context.Context, end func(), _ error) {
+	return t.beginLimited(ctx, info, 0)
+}
+
+// beginLimited is like begin, but if limit is greater than 0, the turn
+// counts towards its agent's concurrency limit: it waits until fewer than
+// limit runs of the agent that count towards it are active, or ctx is done.
+//
+// Waiting runs take free slots in order of info.Priority, then arrival.
+func (t *turns) beginLimited(ctx context.Context, info ActiveRun, limit int) (_ context.Context, end func(), _ error) {
+	t.mu.Lock()
+	var w *waiter
+	if limit > 0 {
+		if t.waiting == nil {
+			t.waiting = make(map[*waiter]struct{})
+		}
+		w = &waiter{agentID: info.AgentID, priority: info.Priority, seq: t.nextSeq}
+		t.nextSeq++
+		t.waiting[w] = struct{}{}
+	}
+	for {
+		if t.draining {
+			t.leave(w)
+			t.mu.Unlock()
+			return nil, nil, ErrShuttingDown
+		}
+		if limit <= 0 || t.limitedRuns(info.AgentID) < limit && !t.outranked(w) {
+			break
+		}
+		if t.ended == nil {
+			t.ended = make(chan struct{})
+		}
+		ended := t.ended
+		t.mu.Unlock()
+
+		select {
+		case <-ended:
+		case <-ctx.Done():
+			t.mu.Lock()
+			t.leave(w)
+			t.mu.Unlock()
+			return nil, nil, fmt.Errorf("wait for a run slot: %w", cmp.Or(stopCause(ctx), ctx.Err()))
+		}
+		t.mu.Lock()
+	}
+	// Runs waiting behind this one may get a slot too, if more are free.
+	t.leave(w)
+	defer t.mu.Unlock()
+
+	if t.runs == nil {
+		t.runs = make(map[string]*run)
+	}
+	if info.ID == "" {
+		info.ID = uuid.NewString()
+	}
+	if info.StartedAt.IsZero() {
+		info.StartedAt = time.Now()
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	t.runs[info.ID] = &run{info: info, cancel: cancel, limited: limit > 0}
+	t.wg.Add(1)
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.runs, info.ID)
+		t.wake()
+		t.mu.Unlock()
+		cancel(nil)
+		t.wg.Done()
+	}, nil
+}
+
+// limitedRuns returns the number of active runs of an agent that count
+// towards its concurrency limit. t.mu must be held.
+func (t *turns) limitedRuns(agentID string) int {
+	var n int
+	for _, r := range t.runs {
+		if r.limited && r.info.AgentID == agentID {
+			n++
+		}
+	}
+	return n
+}
+
+// outranked reports whether another run of w's agent waits for a slot and
+// gets it before w. t.mu must be held.
+func (t *turns) outranked(w *waiter) bool {
+	for o := range t.waiting {
+		if o != w && o.agentID == w.agentID && o.before(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// leave removes w, if not nil, from the waiting runs, and wakes up the
+// others, which may be next. t.mu must be held.
+func (t *turns) leave(w *waiter) {
+	if w == nil {
+		return
+	}
+	delete(t.waiting, w)
+	t.wake()
+}
+
+// wake wakes up runs waiting for a slot. t.mu must be held.
+func (t *turns) wake() {
+	if t.ended != nil {
+		close(t.ended)
+		t.ended = nil
+	}
+}
+
+// beginHooks registers the post-turn hooks of a turn, running in the
+// background. Unlike turns, they can't be listed or cancelled, but they're
+// waited for by Drain, and cancelled when draining times out.
+func (t *turns) beginHooks(ctx context.Context) (_ context.Context, end func(), _ error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.draining {
+		return nil, nil, ErrShuttingDown
+	}
+	if t.hooks == nil {
+		t.hooks = make(map[int]context.CancelFunc)
+	}
+	t.nextHook++
+	id := t.nextHook
+
+	ctx, cancel := context.WithCancel(ctx)
+	t.hooks[id] = cancel
+	t.wg.Add(1)
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.hooks, id)
+		t.mu.Unlock()
+		cancel()
+		t.wg.Done()
+	}, nil
+}
+
+// Drain stops new turns from starting, and waits for turns in progress to
+// finish until ctx is done, along with their post-turn hooks. Turns still
+// running then are interrupted, hooks are cancelled, and Drain waits for the
+// turns' partial output to be stored. Returns the number of interrupted
+// turns.
+func (l *Loop) Drain(ctx context.Context) int {
+	t := &l.turns
+	t.mu.Lock()
+	t.draining = true
+	t.wake()
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return 0
+	case <-ctx.Done():
+	}
+
+	t.mu.Lock()
+	n := len(t.runs)
+	for _, r := range t.runs {
+		r.cancel(ErrInterrupted)
+	}
+	for _, cancel := range t.hooks {
+		cancel()
+	}
+	t.mu.Unlock()
+
+	<-done
+	return n
+}
+
+// ShuttingDown reports whether Drain has been called, so new turns are
+// rejected.
+func (l *Loop) ShuttingDown() bool {
+	l.turns.mu.Lock()
+	defer l.turns.mu.Unlock()
+	return l.turns.draining
+}
+
+// stopCause returns ErrInterrupted, ErrCancelled or ErrTimedOut if a turn's
+// context was cancelled by Drain, CancelRun or its deadline, or nil
+// otherwise.
+func stopCause(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	if errors.Is(cause, ErrInterrupted) || errors.Is(cause, ErrCancelled) || errors.Is(cause, ErrTimedOut) {
+		return cause
+	}
+	return nil
+}
+
+// ActiveTurns returns the number of turns in progress.
+func (l *Loop) ActiveTurns() int {
+	l.turns.mu.Lock()
+	defer l.turns.mu.Unlock()
+	return len(l.turns.runs)
+}
+
+// WaitIdle waits until no turns are in progress, or ctx is done. Unlike
+// Drain, it doesn't stop new turns from starting.
+func (l *Loop) WaitIdle(ctx context.Context) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for l.ActiveTurns() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package agentloop
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	l := &Loop{}
+
+	// A turn that finishes on its own is waited for.
+	_, endQuick, err := l.turns.begin(context.Background(), ActiveRun{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A turn that only stops when cancelled is interrupted.
+	slowCtx, endSlow, err := l.turns.begin(context.Background(), ActiveRun{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		endQuick()
+	}()
+	slowDone := make(chan bool, 1)
+	go func() {
+		<-slowCtx.Done()
+		slowDone <- errors.Is(stopCause(slowCtx), ErrInterrupted)
+		endSlow()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if n := l.Drain(ctx); n != 1 {
+		t.Errorf("Drain interrupted %d turns, want 1", n)
+	}
+	if !<-slowDone {
+		t.Error("slow turn wasn't cancelled with ErrInterrupted")
+	}
+
+	if !l.ShuttingDown() {
+		t.Error("ShuttingDown = false after Drain")
+	}
+	if _, _, err := l.turns.begin(context.Background(), ActiveRun{}); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("begin after Drain: got %v, want ErrShuttingDown", err)
+	}
+}
+
+func TestDrainWithoutTurns(t *testing.T) {
+	l := &Loop{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if n := l.Drain(ctx); n != 0 {
+		t.Errorf("Drain interrupted %d turns, want 0", n)
+	}
+}
+
+func TestWaitIdle(t *testing.T) {
+	l := &Loop{}
+	_, end, err := l.turns.begin(context.Background(), ActiveRun{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := l.ActiveTurns(); n != 1 {
+		t.Errorf("ActiveTurns = %d, want 1", n)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.WaitIdle(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitIdle with active turn: got %v, want deadline exceeded", err)
+	}
+
+	end()
+	if err := l.WaitIdle(context.Background()); err != nil {
+		t.Errorf("WaitIdle without turns: %v", err)
+	}
+	// Unlike Drain, new turns can still start.
+	if _, _, err := l.turns.begin(context.Background(), ActiveRun{}); err != nil {
+		t.Errorf("begin after WaitIdle: %v", err)
+	}
+}
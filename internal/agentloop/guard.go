@@ -0,0 +1,95 @@
+package agentloop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+const (
+	// DefaultMaxIterations is the default limit of LLM round-trips per turn.
+	DefaultMaxIterations = 50
+	// DefaultMaxRepeatedToolCalls is the default number of times a tool may
+	// be called with the same arguments in a turn.
+	DefaultMaxRepeatedToolCalls = 5
+	// DefaultMaxTurnDuration is the default wall-clock limit of a turn.
+	DefaultMaxTurnDuration = time.Hour
+)
+
+var (
+	// ErrMaxIterations is returned by RunTurn when the LLM keeps calling
+	// tools for more round-trips than allowed.
+	ErrMaxIterations = errors.New("turn exceeded the maximum number of LLM round-trips")
+	// ErrLoopDetected is returned by RunTurn when the LLM keeps calling a
+	// tool with the same arguments.
+	ErrLoopDetected = errors.New("loop detected")
+)
+
+// guard stops turns that don't converge. It isn't safe for concurrent use.
+type guard struct {
+	maxIterations int
+	maxRepeats    int
+
+	iterations int
+	calls      map[string]int
+}
+
+func (l *Loop) newGuard() *guard {
+	g := &guard{
+		maxIterations: l.MaxIterations,
+		maxRepeats:    l.MaxRepeatedToolCalls,
+		calls:         make(map[string]int),
+	}
+	if g.maxIterations <= 0 {
+		g.maxIterations = DefaultMaxIterations
+	}
+	if g.maxRepeats <= 0 {
+		g.maxRepeats = DefaultMaxRepeatedToolCalls
+	}
+	return g
+}
+
+// iterate counts an LLM round-trip, returning ErrMaxIterations if there are
+// too many.
+func (g *guard) iterate() error {
+	g.iterations++
+	if g.iterations > g.maxIterations {
+		return fmt.Errorf("%w (%d)", ErrMaxIterations, g.maxIterations)
+	}
+	return nil
+}
+
+// checkCalls counts the function calls of an LLM response, returning
+// ErrLoopDetected if one was made with the same arguments too often.
+func (g *guard) checkCalls(output []openrouter.OutputItem) error {
+	for _, item := range output {
+		if item.Type != "function_call" {
+			continue
+		}
+		key := item.Name + "\x00" + item.Arguments
+		g.calls[key]++
+		if n := g.calls[key]; n >= g.maxRepeats {
+			return fmt.Errorf("%w: %s was called %d times with the same arguments", ErrLoopDetected, tool.DecodeToolName(item.Name), n)
+		}
+	}
+	return nil
+}
+
+// withTurnDeadline returns ctx with the MaxTurnDuration deadline, which
+// cancels it with cause ErrTimedOut. Contexts with a deadline, e.g. of runs
+// with a maximum duration, keep it.
+func (l *Loop) withTurnDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || l.MaxTurnDuration <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, l.MaxTurnDuration, ErrTimedOut)
+}
+
+// aborted reports whether err stopped a turn whose output was stored.
+func aborted(err error) bool {
+	return errors.Is(err, ErrInterrupted) || errors.Is(err, ErrCancelled) || errors.Is(err, ErrTimedOut) || errors.Is(err, ErrMaxIterations) || errors.Is(err, ErrLoopDetected)
+}
@@ -0,0 +1,69 @@
+package agentloop
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+)
+
+func TestGuard(t *testing.T) {
+	g := (&Loop{MaxIterations: 2, MaxRepeatedToolCalls: 3}).newGuard()
+	for range 2 {
+		if err := g.iterate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.iterate(); !errors.Is(err, ErrMaxIterations) {
+		t.Errorf("third iteration: got %v, want ErrMaxIterations", err)
+	}
+
+	call := func(args string) []openrouter.OutputItem {
+		return []openrouter.OutputItem{
+			{Type: "message"},
+			{Type: "function_call", Name: "fetch_url", Arguments: args},
+		}
+	}
+	for _, args := range []string{`{"url":"a"}`, `{"url":"b"}`, `{"url":"a"}`} {
+		if err := g.checkCalls(call(args)); err != nil {
+			t.Fatalf("call with %s: %v", args, err)
+		}
+	}
+	err := g.checkCalls(call(`{"url":"a"}`))
+	if !errors.Is(err, ErrLoopDetected) || !aborted(err) {
+		t.Errorf("third identical call: got %v, want ErrLoopDetected", err)
+	}
+}
+
+func TestGuardDefaults(t *testing.T) {
+	g := (&Loop{}).newGuard()
+	if g.maxIterations != DefaultMaxIterations || g.maxRepeats != DefaultMaxRepeatedToolCalls {
+		t.Errorf("limits = %d, %d; want defaults", g.maxIterations, g.maxRepeats)
+	}
+}
+
+func TestWithTurnDeadline(t *testing.T) {
+	ctx, cancel := (&Loop{MaxTurnDuration: time.Nanosecond}).withTurnDeadline(context.Background())
+	defer cancel()
+	<-ctx.Done()
+	if cause := stopCause(ctx); !errors.Is(cause, ErrTimedOut) {
+		t.Errorf("stop cause after turn deadline = %v, want ErrTimedOut", cause)
+	}
+
+	runCtx, cancelRun := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelRun()
+	want, _ := runCtx.Deadline()
+	ctx, cancel = (&Loop{MaxTurnDuration: time.Nanosecond}).withTurnDeadline(runCtx)
+	defer cancel()
+	if got, _ := ctx.Deadline(); !got.Equal(want) {
+		t.Errorf("deadline of run turn = %v, want the run's %v", got, want)
+	}
+
+	ctx, cancel = (&Loop{}).withTurnDeadline(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("turn without MaxTurnDuration has a deadline")
+	}
+}
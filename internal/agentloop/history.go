@@ -0,0 +1,111 @@
+package agentloop
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// DefaultMaxHistoryTokens is the default token budget of the conversation
+// history and user message of a turn. It leaves room for instructions, tools
+// and output within the 128k context of common models.
+const DefaultMaxHistoryTokens = 100_000
+
+const (
+	// bytesPerToken is the assumed average number of bytes per token. It
+	// errs on the side of overestimating, e.g. for non-English text.
+	bytesPerToken = 3
+	// tokensPerInput is the assumed overhead of an input item, such as its
+	// role and type.
+	tokensPerInput = 4
+)
+
+// EstimateTokens estimates the number of tokens inputs take up in a request,
+// without a model-specific tokenizer.
+func EstimateTokens(inputs []openrouter.Input) int {
+	var bytes, n int
+	for _, in := range inputs {
+		for _, c := range in.Content {
+			if c.ImageURL != "" || c.FileData != "" {
+				n += tokensPerAttachment
+			}
+			bytes += len(c.Text)
+		}
+		bytes += len(in.Name) + len(in.Arguments) + len(in.Output)
+		n += tokensPerInput
+	}
+	return n + (bytes+bytesPerToken-1)/bytesPerToken
+}
+
+// historyWindow holds the input of the conversation history that fits the
+// token budget of a turn.
+type historyWindow struct {
+	inputs []openrouter.Input
+	// dropped is the number of oldest messages left out.
+	dropped int
+	// summary summarizes the messages before the window, see compactHistory.
+	// dropped doesn't count them.
+	summary string
+}
+
+// windowHistory returns the inputs of the newest messages of history that
+// fit budget tokens, dropping the oldest first. The window starts at a user
+// message if it has one, so it doesn't open with an answer to a missing
+// question. Token counts are estimated once and stored with the message.
+func (l *Loop) windowHistory(ctx context.Context, history []store.Message, budget int) (historyWindow, error) {
+	inputs := make([][]openrouter.Input, len(history))
+	start, total := len(history), 0
+	for i := len(history) - 1; i >= 0; i-- {
+		msg := history[i]
+		tokens := int(msg.TokenCount)
+		if tokens == 0 {
+			msgInputs, err := l.historyInputs(msg)
+			if err != nil {
+				return historyWindow{}, err
+			}
+			inputs[i] = msgInputs
+			tokens = EstimateTokens(msgInputs)
+			if err := l.Queries.UpdateMessageTokenCount(ctx, store.UpdateMessageTokenCountParams{
+				TokenCount: int64(tokens),
+				ID:         msg.ID,
+			}); err != nil {
+				l.logger().Warn("failed to store message token count", "message_id", msg.ID, "error", err)
+			}
+		}
+		if total+tokens > budget {
+			break
+		}
+		total += tokens
+		start = i
+	}
+	for i := start; i < len(history); i++ {
+		if history[i].Role == "user" {
+			start = i
+			break
+		}
+	}
+
+	w := historyWindow{dropped: start}
+	for i := start; i < len(history); i++ {
+		msgInputs := inputs[i]
+		if msgInputs == nil {
+			var err error
+			if msgInputs, err = l.historyInputs(history[i]); err != nil {
+				return historyWindow{}, err
+			}
+		}
+		w.inputs = append(w.inputs, msgInputs...)
+	}
+	return w, nil
+}
+
+// droppedHistoryNote returns the instructions telling the LLM that the oldest
+// n messages of the conversation were left out, if any.
+func droppedHistoryNote(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\n## Conversation history\nThe %d oldest messages of this conversation are left out to fit the context window.", n)
+}
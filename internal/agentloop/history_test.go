@@ -0,0 +1,102 @@
+package agentloop
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens(nil); got != 0 {
+		t.Errorf("EstimateTokens(nil) = %d, want 0", got)
+	}
+	inputs := []openrouter.Input{
+		{Type: "message", Role: "user", Content: []openrouter.ContentPart{{Type: "input_text", Text: strings.Repeat("a", 30)}}},
+		{Type: "function_call", Name: "bash", Arguments: `{"cmd":"ls"}`},
+	}
+	// 2 inputs of 4 tokens, and 30+4+12 bytes of 3 per token, rounded up.
+	if got := EstimateTokens(inputs); got != 24 {
+		t.Errorf("EstimateTokens = %d, want 24", got)
+	}
+}
+
+func TestWindowHistory(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	// Each message is 4 + 30/3 = 14 tokens.
+	for i, role := range []string{"user", "assistant", "user", "assistant", "user", "assistant"} {
+		items, err := EncodeItems([]StoredItem{{Type: ItemTypeText, Text: fmt.Sprintf("%s message %d%s", role, i, strings.Repeat(".", 30-len(role)-10))}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateMessage(ctx, store.CreateMessageParams{ID: fmt.Sprintf("msg-%d", i), ConversationID: "conv-1", Role: role, Items: items, Status: "completed", CreatedAt: fmt.Sprintf("2025-01-01T00:00:0%dZ", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	history, err := queries.GetMessagesByConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Loop{Queries: queries}
+	tests := []struct {
+		budget      int
+		wantDropped int
+	}{
+		{budget: 1000, wantDropped: 0},
+		{budget: 14 * 4, wantDropped: 2},
+		// 3 messages fit, but the window starts at a user message.
+		{budget: 14 * 3, wantDropped: 4},
+		{budget: 10, wantDropped: 6},
+	}
+	for _, tt := range tests {
+		w, err := l.windowHistory(ctx, history, tt.budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.dropped != tt.wantDropped {
+			t.Errorf("budget %d: dropped %d, want %d", tt.budget, w.dropped, tt.wantDropped)
+		}
+		if n := len(history) - tt.wantDropped; len(w.inputs) != n {
+			t.Errorf("budget %d: got %d inputs, want %d", tt.budget, len(w.inputs), n)
+		}
+	}
+
+	// Token counts are stored, and reset when the items change.
+	history, err = queries.GetMessagesByConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history[0].TokenCount != 14 {
+		t.Errorf("stored token count = %d, want 14", history[0].TokenCount)
+	}
+	if err := queries.UpdateMessageItems(ctx, store.UpdateMessageItemsParams{ID: "msg-0", Items: history[0].Items, Status: "completed"}); err != nil {
+		t.Fatal(err)
+	}
+	history, err = queries.GetMessagesByConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history[0].TokenCount != 0 {
+		t.Errorf("token count after update = %d, want 0", history[0].TokenCount)
+	}
+}
@@ -0,0 +1,133 @@
+package agentloop
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// MemoryHookName is the name of the hook that distills facts from turns into
+// the agent's MEMORY.md, which is loaded into the instructions of agents that
+// enable it.
+const MemoryHookName = "memory"
+
+// MemoryIndexPath is the agent file path of MEMORY.md.
+const MemoryIndexPath = "memories/MEMORY.md"
+
+// DefaultHookTimeout is how long the post-turn hooks of a turn may run.
+const DefaultHookTimeout = 2 * time.Minute
+
+// Hook is run after turns of agents that enable it. config is the hook's
+// configuration for the agent, or nil if it has none.
+type Hook func(ctx context.Context, turn TurnResult, config json.RawMessage) error
+
+// TurnResult describes a finished turn, for post-turn hooks.
+type TurnResult struct {
+	RunID       string
+	Kind        string
+	Conv        store.Conversation
+	Agent       store.Agent
+	Model       string
+	UserContent string
+	// Response is the assistant's text response, empty if the turn failed.
+	Response string
+	// Status is one of the RunStatus constants, and Err the turn's error.
+	Status       string
+	Err          error
+	InputTokens  int64
+	OutputTokens int64
+	StartedAt    time.Time
+	FinishedAt   time.Time
+}
+
+// AgentHook is a hook enabled for an agent. Agents store them as a JSON
+// array.
+type AgentHook struct {
+	Name   string          `json:"name"`
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// DecodeAgentHooks decodes the hooks of an agent.
+func DecodeAgentHooks(s string) ([]AgentHook, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var hooks []AgentHook
+	if err := json.Unmarshal([]byte(s), &hooks); err != nil {
+		return nil, fmt.Errorf("decode hooks: %w", err)
+	}
+	return hooks, nil
+}
+
+// hasHook reports whether the agent enables the named hook.
+func hasHook(agent store.Agent, name string) bool {
+	hooks, _ := DecodeAgentHooks(agent.Hooks)
+	return slices.ContainsFunc(hooks, func(h AgentHook) bool { return h.Name == name })
+}
+
+// AddHook registers a post-turn hook, which agents enable by name. Hooks
+// must be added before turns run.
+func (l *Loop) AddHook(name string, hook Hook) {
+	if l.hooks == nil {
+		l.hooks = make(map[string]Hook)
+	}
+	l.hooks[name] = hook
+}
+
+// HookNames returns the names of the registered hooks, sorted.
+func (l *Loop) HookNames() []string {
+	names := make([]string, 0, len(l.hooks))
+	for name := range l.hooks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// startHooks runs the hooks enabled for the turn's agent in the background.
+// Like turns, they're waited for by Drain, and hooks of turns that end while
+// draining don't run.
+func (l *Loop) startHooks(ctx context.Context, turn TurnResult) {
+	if turn.Kind == RunKindEval {
+		return
+	}
+	hooks, err := DecodeAgentHooks(turn.Agent.Hooks)
+	if err != nil {
+		l.logger().Error("failed to run hooks", "agent_id", turn.Agent.ID, "error", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	ctx, end, err := l.turns.beginHooks(context.WithoutCancel(ctx))
+	if err != nil {
+		l.logger().Warn("skipped hooks", "run_id", turn.RunID, "error", err)
+		return
+	}
+	go func() {
+		defer end()
+		ctx, cancel := context.WithTimeout(ctx, cmp.Or(l.HookTimeout, DefaultHookTimeout))
+		defer cancel()
+		l.runHooks(ctx, hooks, turn)
+	}()
+}
+
+// runHooks runs hooks in order. A failing hook doesn't stop the others.
+func (l *Loop) runHooks(ctx context.Context, hooks []AgentHook, turn TurnResult) {
+	for _, h := range hooks {
+		hook, ok := l.hooks[h.Name]
+		if !ok {
+			l.logger().Warn("unknown hook enabled", "hook", h.Name, "agent_id", turn.Agent.ID)
+			continue
+		}
+		if err := hook(ctx, turn, h.Config); err != nil {
+			l.logger().Error("hook failed", "hook", h.Name, "run_id", turn.RunID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package agentloop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestStartHooks(t *testing.T) {
+	l := &Loop{}
+	calls := make(chan string, 3)
+	l.AddHook("first", func(ctx context.Context, turn TurnResult, config json.RawMessage) error {
+		calls <- "first:" + string(config)
+		return errors.New("fails, but doesn't stop later hooks")
+	})
+	l.AddHook("second", func(ctx context.Context, turn TurnResult, config json.RawMessage) error {
+		calls <- "second:" + turn.Status
+		return nil
+	})
+
+	agent := store.Agent{ID: "agent-1", Hooks: `[{"name":"first","config":{"url":"x"}},{"name":"unknown"},{"name":"second"}]`}
+	l.startHooks(context.Background(), TurnResult{RunID: "run-1", Agent: agent, Status: RunStatusCompleted})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if n := l.Drain(ctx); n != 0 {
+		t.Errorf("Drain interrupted %d turns, want 0", n)
+	}
+	close(calls)
+
+	var got []string
+	for c := range calls {
+		got = append(got, c)
+	}
+	want := []string{`first:{"url":"x"}`, "second:completed"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("hook calls = %q, want %q", got, want)
+	}
+
+	// Hooks don't start once the loop is draining.
+	l.startHooks(context.Background(), TurnResult{RunID: "run-2", Agent: agent})
+	if len(l.turns.hooks) != 0 {
+		t.Errorf("hooks started while draining")
+	}
+}
+
+func TestHasHook(t *testing.T) {
+	agent := store.Agent{Hooks: `[{"name":"usage"},{"name":"memory"}]`}
+	if !hasHook(agent, MemoryHookName) {
+		t.Error("hasHook(memory) = false, want true")
+	}
+	if hasHook(agent, "webhook") || hasHook(store.Agent{}, MemoryHookName) {
+		t.Error("hasHook of hook that isn't enabled = true, want false")
+	}
+}
@@ -0,0 +1,108 @@
+package agentloop
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ItemsSchemaVersion is the version of the message items envelope written by
+// EncodeItems. Bump it when item types or fields change in a way older
+// readers can't handle, so they fail loudly instead of misreading items.
+const ItemsSchemaVersion = 1
+
+// Item types.
+const (
+	ItemTypeText          = "text"
+	ItemTypeToolExecution = "tool_execution"
+	// ItemTypeError items explain why a turn was stopped. They aren't part
+	// of the history sent to the LLM.
+	ItemTypeError = "error"
+)
+
+// ErrUnsupportedItemsVersion is returned when decoding items written by a
+// newer version of Blippy.
+var ErrUnsupportedItemsVersion = errors.New("unsupported message items schema version")
+
+// ItemsEnvelope is the stored form of a message's items.
+type ItemsEnvelope struct {
+	SchemaVersion int          `json:"schema_version"`
+	Items         []StoredItem `json:"items"`
+}
+
+// Validate checks that an item has a known type and the fields it requires.
+func (item StoredItem) Validate() error {
+	switch item.Type {
+	case ItemTypeText:
+		if item.Name != "" || item.Input != "" || item.Result != "" {
+			return errors.New("text item has tool execution fields")
+		}
+	case ItemTypeError:
+		if item.Text == "" || item.Name != "" || item.Input != "" || item.Result != "" {
+			return errors.New("error item must only have text")
+		}
+	case ItemTypeAttachment:
+		if item.AttachmentID == "" {
+			return errors.New("attachment item has no attachment ID")
+		}
+		if item.Text != "" || item.Input != "" || item.Result != "" {
+			return errors.New("attachment item has text or tool execution fields")
+		}
+	case ItemTypeToolExecution:
+		if item.Name == "" {
+			return errors.New("tool execution item has no name")
+		}
+		if item.Text != "" {
+			return errors.New("tool execution item has text")
+		}
+	default:
+		return fmt.Errorf("unknown item type %q", item.Type)
+	}
+	return nil
+}
+
+// EncodeItems validates items and encodes them in a versioned envelope for
+// storage.
+func EncodeItems(items []StoredItem) (string, error) {
+	for i, item := range items {
+		if err := item.Validate(); err != nil {
+			return "", fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	if items == nil {
+		items = []StoredItem{}
+	}
+
+	b, err := json.Marshal(ItemsEnvelope{SchemaVersion: ItemsSchemaVersion, Items: items})
+	if err != nil {
+		return "", fmt.Errorf("marshal items: %w", err)
+	}
+	return string(b), nil
+}
+
+// DecodeItems decodes stored message items. Legacy rows holding a bare JSON
+// array are read as well.
+func DecodeItems(s string) ([]StoredItem, error) {
+	b := bytes.TrimSpace([]byte(s))
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	if b[0] == '[' {
+		var items []StoredItem
+		if err := json.Unmarshal(b, &items); err != nil {
+			return nil, fmt.Errorf("unmarshal legacy items: %w", err)
+		}
+		return items, nil
+	}
+
+	var env ItemsEnvelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, fmt.Errorf("unmarshal items: %w", err)
+	}
+	if env.SchemaVersion < 1 || env.SchemaVersion > ItemsSchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedItemsVersion, env.SchemaVersion)
+	}
+	return env.Items, nil
+}
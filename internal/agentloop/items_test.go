@@ -0,0 +1,83 @@
+package agentloop
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestEncodeDecodeItems(t *testing.T) {
+	items := []StoredItem{
+		{Type: ItemTypeText, Text: "Checking the weather."},
+		{Type: ItemTypeToolExecution, Name: "fetch", Input: `{"url":"https://example.com"}`, Result: "sunny", CallID: "call_1"},
+	}
+	encoded, err := EncodeItems(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeItems(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[0] != items[0] || decoded[1] != items[1] {
+		t.Errorf("round trip = %+v, want %+v", decoded, items)
+	}
+
+	if _, err := EncodeItems([]StoredItem{{Type: "reasoning"}}); err == nil {
+		t.Error("encoding unknown item type succeeded")
+	}
+	if _, err := EncodeItems([]StoredItem{{Type: ItemTypeToolExecution}}); err == nil {
+		t.Error("encoding tool execution without name succeeded")
+	}
+
+	legacy, err := DecodeItems(`[{"type":"text","text":"hi"}]`)
+	if err != nil || len(legacy) != 1 || legacy[0].Text != "hi" {
+		t.Errorf("legacy items = %+v, %v", legacy, err)
+	}
+
+	if _, err := DecodeItems(`{"schema_version":99,"items":[]}`); !errors.Is(err, ErrUnsupportedItemsVersion) {
+		t.Errorf("decoding future version: got %v, want ErrUnsupportedItemsVersion", err)
+	}
+}
+
+// TestItemsMigration checks that legacy rows are wrapped in the envelope.
+func TestItemsMigration(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.OpenUnmigrated(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := store.Migrate(db, 11); err != nil {
+		t.Fatal(err)
+	}
+
+	now := "2025-01-01T00:00:00Z"
+	for _, stmt := range []string{
+		`INSERT INTO agents (id, name, created_at, updated_at) VALUES ('a', 'Agent', '` + now + `', '` + now + `')`,
+		`INSERT INTO conversations (id, agent_id, created_at, updated_at) VALUES ('c', 'a', '` + now + `', '` + now + `')`,
+		`INSERT INTO messages (id, conversation_id, role, items, created_at) VALUES ('m', 'c', 'user', '[{"type":"text","text":"hi"}]', '` + now + `')`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Migrate(db, -1); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := store.New(db).GetMessagesByConversation(ctx, "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Items[0] != '{' {
+		t.Fatalf("messages = %+v, want migrated envelope", msgs)
+	}
+	items, err := DecodeItems(msgs[0].Items)
+	if err != nil || len(items) != 1 || items[0].Text != "hi" {
+		t.Errorf("migrated items = %+v, %v", items, err)
+	}
+}
@@ -0,0 +1,204 @@
+package agentloop
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// maxFoundTools is the number of tools find_tool loads for a query.
+const maxFoundTools = 5
+
+// maxIndexSummaryLen limits the length of a tool's summary in the tool
+// index.
+const maxIndexSummaryLen = 120
+
+// lazyTools holds the tool definitions of a turn with more tools than
+// Loop.LazyToolThreshold. Only find_tool and the tools it loaded are sent to
+// the LLM; the others are listed in a compact index in the instructions. It's
+// safe for concurrent use, since tools run concurrently.
+type lazyTools struct {
+	defs []map[string]any // all tool definitions, in order
+
+	mu     sync.Mutex
+	loaded map[string]bool
+}
+
+type lazyToolsKey struct{}
+
+// deferTools replaces the tool definitions of a request that has more than
+// LazyToolThreshold of them by find_tool, and adds the tool index to its
+// instructions. Tools called in the request's history stay loaded. It returns
+// nil if the tools are sent as is.
+func (l *Loop) deferTools(orReq *openrouter.ResponseRequest) *lazyTools {
+	if l.LazyToolThreshold <= 0 || len(orReq.Tools) <= l.LazyToolThreshold {
+		return nil
+	}
+	lt := &lazyTools{defs: orReq.Tools, loaded: make(map[string]bool)}
+	for _, input := range orReq.Input {
+		if input.Type == "function_call" {
+			lt.loaded[input.Name] = true
+		}
+	}
+	orReq.Instructions += lt.index()
+	lt.apply(orReq)
+	return lt
+}
+
+// withLazyTools returns a context with the lazy tools of a turn, which may be
+// nil, as the tool.ToolLoader for find_tool.
+func withLazyTools(ctx context.Context, lt *lazyTools) context.Context {
+	var loader tool.ToolLoader
+	if lt != nil {
+		loader = lt
+	}
+	ctx = tool.WithToolLoader(ctx, loader)
+	return context.WithValue(ctx, lazyToolsKey{}, lt)
+}
+
+func lazyToolsFrom(ctx context.Context) *lazyTools {
+	lt, _ := ctx.Value(lazyToolsKey{}).(*lazyTools)
+	return lt
+}
+
+// index returns the instructions listing the tools that can be loaded.
+func (lt *lazyTools) index() string {
+	var sb strings.Builder
+	sb.WriteString("\n\n## Tool index\nMore tools are available than are loaded. Load the tools you need with " + tool.FindToolName + " before calling them:\n")
+	for _, def := range lt.defs {
+		name, description := toolInfo(def)
+		sb.WriteString("- " + name)
+		if summary := toolSummary(description); summary != "" {
+			sb.WriteString(": " + summary)
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// apply sets the tools of the request to find_tool and the loaded tools. It
+// does nothing on a nil lazyTools, whose turn sends all tools.
+func (lt *lazyTools) apply(orReq *openrouter.ResponseRequest) {
+	if lt == nil {
+		return
+	}
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	find := tool.NewFindTool()
+	tools := []map[string]any{{
+		"type":        "function",
+		"name":        find.Name,
+		"description": find.Description,
+		"parameters":  find.Parameters,
+	}}
+	for _, def := range lt.defs {
+		if name, _ := toolInfo(def); lt.loaded[name] {
+			tools = append(tools, def)
+		}
+	}
+	orReq.Tools = tools
+}
+
+// LoadTools implements tool.ToolLoader. It loads the named tools and the
+// tools best matching query.
+func (lt *lazyTools) LoadTools(names []string, query string) (string, error) {
+	var found, unknown []string
+	for _, name := range names {
+		if lt.has(name) {
+			found = append(found, name)
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+	if query != "" {
+		for _, name := range lt.search(query) {
+			if !slices.Contains(found, name) {
+				found = append(found, name)
+			}
+		}
+	}
+
+	lt.mu.Lock()
+	for _, name := range found {
+		lt.loaded[name] = true
+	}
+	lt.mu.Unlock()
+
+	var sb strings.Builder
+	if len(found) > 0 {
+		sb.WriteString("Loaded tools, which can be called from your next step:\n")
+		for _, def := range lt.defs {
+			if name, description := toolInfo(def); slices.Contains(found, name) {
+				fmt.Fprintf(&sb, "- %s: %s\n", name, toolSummary(description))
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		fmt.Fprintf(&sb, "Unknown tools: %s. Use names from the tool index.\n", strings.Join(unknown, ", "))
+	}
+	if len(found) == 0 && query != "" {
+		fmt.Fprintf(&sb, "No tools match %q.\n", query)
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func (lt *lazyTools) has(name string) bool {
+	return slices.ContainsFunc(lt.defs, func(def map[string]any) bool {
+		n, _ := toolInfo(def)
+		return n == name
+	})
+}
+
+// search returns the names of the tools whose name and description contain
+// the most words of query, at most maxFoundTools of them.
+func (lt *lazyTools) search(query string) []string {
+	words := strings.Fields(strings.ToLower(query))
+	type match struct {
+		name  string
+		score int
+	}
+	var matches []match
+	for _, def := range lt.defs {
+		name, description := toolInfo(def)
+		text := strings.ToLower(name + " " + strings.ReplaceAll(name, "_", " ") + " " + description)
+		var score int
+		for _, w := range words {
+			if strings.Contains(text, w) {
+				score++
+			}
+		}
+		if score > 0 {
+			matches = append(matches, match{name, score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int { return b.score - a.score })
+
+	var names []string
+	for _, m := range matches[:min(len(matches), maxFoundTools)] {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// toolInfo returns the name and description of a tool definition.
+func toolInfo(def map[string]any) (name, description string) {
+	name, _ = def["name"].(string)
+	description, _ = def["description"].(string)
+	return name, description
+}
+
+// toolSummary returns the first sentence of a tool's description, shortened
+// to maxIndexSummaryLen bytes.
+func toolSummary(description string) string {
+	s, _, _ := strings.Cut(strings.TrimSpace(description), "\n")
+	if i := strings.Index(s, ". "); i >= 0 {
+		s = s[:i+1]
+	}
+	return truncate(s, maxIndexSummaryLen)
+}
@@ -0,0 +1,90 @@
+package agentloop
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestDeferTools(t *testing.T) {
+	def := func(name, description string) map[string]any {
+		return map[string]any{"type": "function", "name": name, "description": description, "parameters": json.RawMessage(`{}`)}
+	}
+	newReq := func() *openrouter.ResponseRequest {
+		return &openrouter.ResponseRequest{
+			Instructions: "Be helpful.",
+			Input: []openrouter.Input{
+				{Type: "function_call", Name: "fetch_url", CallID: "c1"},
+				{Type: "function_call_output", CallID: "c1", Output: "ok"},
+			},
+			Tools: []map[string]any{
+				def("fetch_url", "Fetch a URL. Returns the body."),
+				def("memory_view", "View a memory file."),
+				def("notify__ops", "Send a notification to the ops channel."),
+			},
+		}
+	}
+	names := func(orReq *openrouter.ResponseRequest) []string {
+		var names []string
+		for _, d := range orReq.Tools {
+			name, _ := toolInfo(d)
+			names = append(names, name)
+		}
+		return names
+	}
+
+	if lt := (&Loop{LazyToolThreshold: 3}).deferTools(newReq()); lt != nil {
+		t.Error("tools deferred at the threshold")
+	}
+
+	orReq := newReq()
+	lt := (&Loop{LazyToolThreshold: 2}).deferTools(orReq)
+	if lt == nil {
+		t.Fatal("tools not deferred above the threshold")
+	}
+	if got := strings.Join(names(orReq), ","); got != "find_tool,fetch_url" {
+		t.Errorf("tools = %s, want find_tool and the tool called in the history", got)
+	}
+	for _, want := range []string{"Be helpful.", "## Tool index", "- fetch_url: Fetch a URL.\n", "- notify__ops: Send a notification to the ops channel."} {
+		if !strings.Contains(orReq.Instructions, want) {
+			t.Errorf("instructions don't contain %q:\n%s", want, orReq.Instructions)
+		}
+	}
+
+	// find_tool is executed with the turn's loader from the context.
+	ctx := withLazyTools(context.Background(), lt)
+	out, err := tool.NewExecutor(tool.NewRegistry(), nil, nil, nil).ProcessOutput(ctx, []openrouter.OutputItem{
+		{Type: "function_call", Name: tool.FindToolName, CallID: "c2", Arguments: `{"names":["memory_view","nope"]}`},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out[1].Output; !strings.Contains(got, "- memory_view: View a memory file.") || !strings.Contains(got, "Unknown tools: nope.") {
+		t.Errorf("find_tool output = %q", got)
+	}
+
+	if got, err := lt.LoadTools(nil, "send notification"); err != nil || !strings.Contains(got, "notify__ops") {
+		t.Errorf("LoadTools by query = %q, %v", got, err)
+	}
+	if got, _ := lt.LoadTools(nil, "weather"); got != `No tools match "weather".` {
+		t.Errorf("LoadTools without matches = %q", got)
+	}
+
+	lazyToolsFrom(ctx).apply(orReq)
+	if got := strings.Join(names(orReq), ","); got != "find_tool,fetch_url,memory_view,notify__ops" {
+		t.Errorf("tools after loading = %s", got)
+	}
+
+	// Subagent turns without lazy tools don't inherit the loader.
+	ctx = withLazyTools(ctx, nil)
+	out, _ = tool.NewExecutor(tool.NewRegistry(), nil, nil, nil).ProcessOutput(ctx, []openrouter.OutputItem{
+		{Type: "function_call", Name: tool.FindToolName, CallID: "c3", Arguments: `{"query":"memory"}`},
+	}, nil)
+	if got := out[1].Output; got != "Error: tool not found: find_tool" {
+		t.Errorf("find_tool output without loader = %q", got)
+	}
+}
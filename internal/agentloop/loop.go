@@ -1,15 +1,22 @@
 package agentloop
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/locale"
 	"github.com/dstotijn/blippy/internal/openrouter"
 	"github.com/dstotijn/blippy/internal/pubsub"
 	"github.com/dstotijn/blippy/internal/store"
@@ -23,6 +30,65 @@ type Loop struct {
 	ToolExecutor *tool.Executor
 	Broker       *pubsub.Broker
 	DefaultModel string
+	// TitleModel generates conversation titles for agents without a title
+	// model. Defaults to DefaultModel.
+	TitleModel string
+	// ProgressInterval is how often TurnProgress events are published during
+	// a turn. Defaults to DefaultProgressInterval.
+	ProgressInterval time.Duration
+	// MaxIterations limits the LLM round-trips of a turn. Defaults to
+	// DefaultMaxIterations.
+	MaxIterations int
+	// MaxRepeatedToolCalls limits how often a tool may be called with the
+	// same arguments in a turn. Defaults to DefaultMaxRepeatedToolCalls.
+	MaxRepeatedToolCalls int
+	// MaxTurnDuration limits how long a turn may run, if its context has no
+	// deadline. Turns that take longer are stopped like runs exceeding their
+	// maximum duration, with ErrTimedOut. 0 means no limit.
+	MaxTurnDuration time.Duration
+	// MaxHistoryTokens is the estimated token budget of the conversation
+	// history and user message of a turn; the oldest messages that don't fit
+	// are left out. Defaults to DefaultMaxHistoryTokens.
+	MaxHistoryTokens int
+	// CompactionDisabled leaves out the oldest messages of the history that
+	// don't fit MaxHistoryTokens, instead of summarizing them.
+	CompactionDisabled bool
+	// SummaryModel summarizes conversation history. Defaults to the model of
+	// the turn.
+	SummaryModel string
+	// FallbackModels answer the LLM requests of turns, in order, if the
+	// turn's model keeps failing with errors that are retried.
+	FallbackModels []string
+	// HookTimeout limits how long the post-turn hooks of a turn may run.
+	// Defaults to DefaultHookTimeout.
+	HookTimeout time.Duration
+	// LazyToolThreshold is the number of tools above which a turn sends only
+	// find_tool and a compact tool index, instead of all tool definitions.
+	// 0 sends all tools.
+	LazyToolThreshold int
+	// ApprovalTimeout is how long a tool call matching an approval rule of
+	// its agent waits for approval before it's denied. Defaults to
+	// DefaultApprovalTimeout.
+	ApprovalTimeout time.Duration
+	// Cipher encrypts the sensitive tool arguments in stored messages, see
+	// tool.Tool.SensitiveArgs. Nil stores them as plaintext.
+	Cipher *encryption.Cipher
+	// RecordTurns enables recording the LLM responses and tool results of
+	// turns, for ReplayTurn.
+	RecordTurns bool
+	// Logger defaults to slog.Default.
+	Logger *slog.Logger
+
+	turns     turns
+	approvals approvals
+	hooks     map[string]Hook
+}
+
+func (l *Loop) logger() *slog.Logger {
+	if l.Logger != nil {
+		return l.Logger
+	}
+	return slog.Default()
 }
 
 // TurnOpts configures a single agent turn.
@@ -34,78 +100,193 @@ type TurnOpts struct {
 	ModelOverride     string          // optional: overrides agent model
 	ExtraInstructions string          // prepended to system prompt
 	Depth             int             // for recursion tracking
+	// Attachments are the files attached to UserContent, stored by
+	// StartTurn. Their contents are only sent to the LLM in this turn.
+	Attachments []Attachment
+	// Autonomous turns run without a user present, e.g. for triggers and
+	// webhooks, and instruct the agent to work without asking questions.
+	Autonomous bool
+	// Kind is one of the RunKind constants, RunKindAutonomous if empty.
+	Kind string
+	// Priority overrides the DefaultPriority of the turn's kind, if greater
+	// than 0.
+	Priority int
+	// Vars are the run's variables, see tool.WithRunVars. They're listed in
+	// the instructions.
+	Vars map[string]string
+	// RunID identifies the run for CancelRun. Generated if empty.
+	RunID string
+	// ParentConversationID is the conversation of the turn that called the
+	// agent and waits for it, if any. The turn's live output is forwarded to
+	// it.
+	ParentConversationID string
+	// OutputSchema is a JSON Schema the turn's final answer must match. It's
+	// sent as the structured output format of the turn's LLM requests.
+	OutputSchema json.RawMessage
 }
 
-// TextDelta represents a chunk of streamed text from the LLM.
-type TextDelta struct {
-	Content string
-}
-
-// ToolResult represents the outcome of a single tool execution.
-type ToolResult struct {
-	Name   string
-	Input  string
-	Result string
-}
-
-// MessageDone signals that a message has been persisted.
-type MessageDone struct {
-	MessageID string
-	Role      string
-	ItemsJSON string
-	CreatedAt string
-}
-
-// TurnStarted signals that a new agent turn has begun.
-type TurnStarted struct{}
+// autonomousInstructions is prepended to agent system prompts to ensure
+// the agent works without user interaction during scheduled/webhook runs.
+const autonomousInstructions = `You are running autonomously without user interaction. A user is NOT present and cannot respond to questions or provide feedback.
+
+CRITICAL: You must complete the task independently:
+- Do NOT ask clarifying questions or request user input
+- Make reasonable assumptions when details are ambiguous
+- Use your available tools to accomplish the task
+- If a tool call fails, immediately retry with a corrected approach - do not just explain what you would do
+- Keep working until the task is complete or truly impossible
+- Only stop with a text response when you have finished the task or cannot proceed
+
+`
+
+// ErrConversationBusy is returned by StartTurn when the conversation already
+// has an active turn.
+var ErrConversationBusy = errors.New("conversation is already processing")
+
+// errStream wraps errors of LLM requests.
+var errStream = errors.New("stream error")
+
+// Run statuses of pubsub.RunFinished.
+const (
+	RunStatusCompleted   = "completed"
+	RunStatusFailed      = "failed"
+	RunStatusInterrupted = "interrupted"
+	RunStatusCancelled   = "cancelled"
+	RunStatusTimedOut    = "timed_out"
+)
 
-// TurnDone signals that the agent turn has completed.
-type TurnDone struct {
-	Title string
+// RunStatusOf returns the run status of a turn that returned err.
+func RunStatusOf(err error) string {
+	switch {
+	case err == nil:
+		return RunStatusCompleted
+	case errors.Is(err, ErrInterrupted), errors.Is(err, ErrShuttingDown):
+		return RunStatusInterrupted
+	case errors.Is(err, ErrCancelled):
+		return RunStatusCancelled
+	case errors.Is(err, ErrTimedOut):
+		return RunStatusTimedOut
+	}
+	return RunStatusFailed
 }
 
-// Error signals that an error occurred during processing.
-type Error struct {
-	Message string
+// ErrorCodeOf returns the API error code of a turn's error.
+func ErrorCodeOf(err error) apierror.ErrorCode {
+	var statusErr *openrouter.StatusError
+	switch {
+	case err == nil:
+		return apierror.ErrorCode_ERROR_CODE_UNSPECIFIED
+	case errors.Is(err, ErrConversationBusy):
+		return apierror.ErrorCode_ERROR_CODE_CONVERSATION_BUSY
+	case errors.Is(err, ErrShuttingDown):
+		return apierror.ErrorCode_ERROR_CODE_SHUTTING_DOWN
+	case errors.Is(err, ErrInterrupted):
+		return apierror.ErrorCode_ERROR_CODE_INTERRUPTED
+	case errors.Is(err, ErrCancelled):
+		return apierror.ErrorCode_ERROR_CODE_RUN_CANCELLED
+	case errors.Is(err, ErrTimedOut):
+		return apierror.ErrorCode_ERROR_CODE_RUN_TIMED_OUT
+	case errors.Is(err, ErrRunNotFound):
+		return apierror.ErrorCode_ERROR_CODE_RUN_NOT_FOUND
+	case errors.Is(err, ErrMaxIterations):
+		return apierror.ErrorCode_ERROR_CODE_MAX_ITERATIONS
+	case errors.Is(err, ErrLoopDetected):
+		return apierror.ErrorCode_ERROR_CODE_LOOP_DETECTED
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests:
+		return apierror.ErrorCode_ERROR_CODE_LLM_RATE_LIMITED
+	case errors.Is(err, errStream):
+		return apierror.ErrorCode_ERROR_CODE_LLM_FAILED
+	}
+	return apierror.ErrorCode_ERROR_CODE_INTERNAL
 }
 
-// StoredItem represents an item in the message items JSON array.
+// StoredItem represents an item of a message. Items are stored with
+// EncodeItems.
 type StoredItem struct {
-	Type   string `json:"type"`              // "text" or "tool_execution"
-	Text   string `json:"text,omitempty"`    // for type="text"
-	Name   string `json:"name,omitempty"`    // for type="tool_execution"
+	Type   string `json:"type"`              // ItemTypeText, ItemTypeToolExecution, ItemTypeError or ItemTypeAttachment
+	Text   string `json:"text,omitempty"`    // for type="text" and type="error"
+	Name   string `json:"name,omitempty"`    // for type="tool_execution", file name for type="attachment"
 	Input  string `json:"input,omitempty"`   // for type="tool_execution"
 	Result string `json:"result,omitempty"`  // for type="tool_execution"
 	ID     string `json:"id,omitempty"`      // function call ID
 	CallID string `json:"call_id,omitempty"` // for history reconstruction
+
+	AttachmentID string `json:"attachment_id,omitempty"` // for type="attachment"
+	MediaType    string `json:"media_type,omitempty"`    // for type="attachment"
+	Size         int64  `json:"size,omitempty"`          // for type="attachment"
+}
+
+// StartTurn marks a conversation as busy, persists the user's message and
+// publishes TurnStarted. It returns the conversation's history before the
+// message, for TurnOpts.History, and the message ID. Call it before starting
+// the turn goroutine so the caller can return the ID to the client
+// synchronously; RunTurn clears the busy mark. Returns ErrConversationBusy if
+// the conversation already has an active turn. Attachments are stored with
+// the message, and pass to RunTurn in TurnOpts.Attachments.
+func (l *Loop) StartTurn(ctx context.Context, convID, content string, attachments ...Attachment) (history []store.Message, userMsgID string, err error) {
+	if !l.Broker.SetBusy(convID) {
+		return nil, "", ErrConversationBusy
+	}
+	history, err = l.Queries.GetMessagesByConversation(ctx, convID)
+	if err != nil {
+		l.Broker.ClearBusy(convID)
+		return nil, "", fmt.Errorf("get messages: %w", err)
+	}
+	userMsgID, err = l.saveUserMessage(ctx, convID, content, attachments)
+	if err != nil {
+		l.Broker.ClearBusy(convID)
+		return nil, "", err
+	}
+	l.Broker.Publish(convID, &pubsub.Event_TurnStarted{TurnStarted: &pubsub.TurnStarted{}})
+	return history, userMsgID, nil
 }
 
-// SaveUserMessage persists a user message and publishes a MessageDone event.
-// Returns the message ID. Call this before starting the turn goroutine so the
-// caller can return the ID to the client synchronously.
-func (l *Loop) SaveUserMessage(ctx context.Context, convID, content string) (string, error) {
+// saveUserMessage persists a user message with its attachments and publishes
+// a MessageDone event. Returns the message ID.
+func (l *Loop) saveUserMessage(ctx context.Context, convID, content string, attachments []Attachment) (string, error) {
 	msgID := uuid.NewString()
-	items, _ := json.Marshal([]StoredItem{{Type: "text", Text: content}})
-	itemsStr := string(items)
+	items := append([]StoredItem{{Type: ItemTypeText, Text: content}}, attachmentItems(attachments)...)
+	itemsStr, err := EncodeItems(items)
+	if err != nil {
+		return "", err
+	}
 	createdAt := time.Now().UTC().Format(time.RFC3339)
 
-	_, err := l.Queries.CreateMessage(ctx, store.CreateMessageParams{
-		ID:             msgID,
-		ConversationID: convID,
-		Role:           "user",
-		Items:          itemsStr,
-		CreatedAt:      createdAt,
+	err = l.Queries.InTx(ctx, func(q *store.Queries) error {
+		if _, err := q.CreateMessage(ctx, store.CreateMessageParams{
+			ID:             msgID,
+			ConversationID: convID,
+			Role:           "user",
+			Items:          itemsStr,
+			Status:         MessageStatusCompleted,
+			CreatedAt:      createdAt,
+		}); err != nil {
+			return fmt.Errorf("create user message: %w", err)
+		}
+		for _, a := range attachments {
+			if err := q.CreateAttachment(ctx, store.CreateAttachmentParams{
+				ID:             a.ID,
+				ConversationID: convID,
+				MessageID:      msgID,
+				BlobID:         a.BlobID,
+				CreatedAt:      createdAt,
+			}); err != nil {
+				return fmt.Errorf("create attachment: %w", err)
+			}
+		}
+		return nil
 	})
 	if err != nil {
-		return "", fmt.Errorf("create user message: %w", err)
+		return "", err
 	}
 
-	l.Broker.Publish(convID, MessageDone{
-		MessageID: msgID,
+	l.Broker.Publish(convID, &pubsub.Event_MessageDone{MessageDone: &pubsub.MessageDone{
+		MessageId: msgID,
 		Role:      "user",
-		ItemsJSON: itemsStr,
+		ItemsJson: itemsStr,
+		Status:    MessageStatusCompleted,
 		CreatedAt: createdAt,
-	})
+	}})
 
 	return msgID, nil
 }
@@ -156,68 +337,190 @@ func (l *Loop) prepareTurn(ctx context.Context, opts TurnOpts) (*openrouter.Resp
 		model = opts.ModelOverride
 	}
 
-	// Build input array with optional conversation history
+	// Build input array with as much of the conversation history as fits the
+	// token budget, newest first, after a summary of older messages.
+	userInput := openrouter.Input{
+		Type:    "message",
+		Role:    "user",
+		Content: append([]openrouter.ContentPart{{Type: "input_text", Text: opts.UserContent}}, attachmentParts(opts.Attachments)...),
+	}
+	if opts.UserContent == "" && len(opts.Attachments) > 0 {
+		userInput.Content = userInput.Content[1:]
+	}
+	budget := cmp.Or(l.MaxHistoryTokens, DefaultMaxHistoryTokens) - EstimateTokens([]openrouter.Input{userInput})
+	history, err := l.compactHistory(ctx, opts.Conv, opts.History, model, budget)
+	if err != nil {
+		return nil, nil, err
+	}
+	if history.dropped > 0 {
+		l.logger().Debug("left out oldest messages of history", "conversation_id", opts.Conv.ID, "dropped", history.dropped)
+	}
 	var inputs []openrouter.Input
-	for _, msg := range opts.History {
-		inputs = append(inputs, BuildHistoryInputs(msg)...)
-	}
-	inputs = append(inputs, openrouter.Input{
-		Type: "message",
-		Role: "user",
-		Content: []openrouter.ContentPart{
-			{Type: "input_text", Text: opts.UserContent},
-		},
-	})
+	if history.summary != "" {
+		inputs = append(inputs, summaryInput(history.summary))
+	}
+	inputs = append(inputs, history.inputs...)
+	inputs = append(inputs, userInput)
 
 	// Inject memory guidance if any memory tool is enabled.
 	var memorySection string
 	memoryTools := []string{"memory_view", "memory_create", "memory_edit", "memory_delete"}
-	for _, t := range enabledTools {
-		for _, mt := range memoryTools {
-			if t == mt {
-				var sb strings.Builder
-				sb.WriteString("## Memory\n")
-				sb.WriteString("You have persistent memory across conversations via memory tools.\n")
-				sb.WriteString("MEMORY.md is your index file — it is loaded here at the start of every conversation.\n")
-				sb.WriteString("Keep MEMORY.md concise and use it to reference detailed topic files (e.g. projects/acme.md).\n")
-				sb.WriteString("Always update MEMORY.md when you create or delete other memory files.\n\n")
-
-				file, err := l.Queries.GetAgentFile(ctx, store.GetAgentFileParams{
-					AgentID: opts.Agent.ID,
-					Path:    "memories/MEMORY.md",
-				})
-				if err == nil {
-					sb.WriteString("### MEMORY.md\n")
-					sb.WriteString(file.Content)
-					sb.WriteString("\n\n")
-				}
+	hasMemoryTools := slices.ContainsFunc(enabledTools, func(t string) bool {
+		return slices.Contains(memoryTools, t)
+	})
+	if hasMemoryTools || hasHook(opts.Agent, MemoryHookName) {
+		var sb strings.Builder
+		sb.WriteString("## Memory\n")
+		if hasMemoryTools {
+			sb.WriteString("You have persistent memory across conversations via memory tools.\n")
+			sb.WriteString("MEMORY.md is your index file — it is loaded here at the start of every conversation.\n")
+			sb.WriteString("Keep MEMORY.md concise and use it to reference detailed topic files (e.g. projects/acme.md).\n")
+			sb.WriteString("Always update MEMORY.md when you create or delete other memory files.\n\n")
+		} else {
+			sb.WriteString("Facts remembered from earlier conversations, kept up to date automatically.\n\n")
+		}
 
-				memorySection = sb.String()
-				goto doneMemory
-			}
+		file, err := l.Queries.GetAgentFile(ctx, store.GetAgentFileParams{
+			AgentID: opts.Agent.ID,
+			Path:    MemoryIndexPath,
+		})
+		if err == nil {
+			sb.WriteString("### MEMORY.md\n")
+			sb.WriteString(file.Content)
+			sb.WriteString("\n\n")
 		}
+
+		memorySection = sb.String()
 	}
-doneMemory:
 
 	// Build instructions
-	instructions := opts.ExtraInstructions + memorySection + opts.Agent.SystemPrompt
+	instructions := opts.ExtraInstructions + memorySection + SystemPrompt(opts.Agent) + locale.Instructions(opts.Agent.Locale) + runVarsNote(opts.Vars) + droppedHistoryNote(history.dropped)
+	if opts.Autonomous {
+		instructions = autonomousInstructions + instructions
+	}
+	instructions = l.preamble(ctx, opts.Agent.Locale) + instructions
+
+	var text *openrouter.TextConfig
+	if len(opts.OutputSchema) > 0 {
+		text = openrouter.JSONSchemaText("output", opts.OutputSchema)
+	}
 
 	return &openrouter.ResponseRequest{
 		Model:        model,
 		Input:        inputs,
 		Instructions: instructions,
 		Tools:        tools,
+		Text:         text,
+		FallbackModels: slices.DeleteFunc(slices.Clone(l.FallbackModels), func(m string) bool {
+			return m == model
+		}),
 	}, fsToolRoots, nil
 }
 
-// RunTurn executes the agentic loop, publishing events to the broker.
-// Returns the assistant's text response.
+// RunTurn executes the agentic loop, publishing events to the broker, and
+// RunStarted and RunFinished activity. Returns the assistant's text response.
+// If the turn is interrupted by Drain, cancelled by CancelRun or exceeds a
+// deadline with cause ErrTimedOut, the output so far is stored and the cause
+// is returned.
 func (l *Loop) RunTurn(ctx context.Context, opts TurnOpts) (string, error) {
+	info := ActiveRun{
+		ID:             cmp.Or(opts.RunID, uuid.NewString()),
+		ConversationID: opts.Conv.ID,
+		AgentID:        opts.Agent.ID,
+		AgentName:      opts.Agent.Name,
+		Kind:           cmp.Or(opts.Kind, RunKindAutonomous),
+		Priority:       cmp.Or(opts.Priority, DefaultPriority(cmp.Or(opts.Kind, RunKindAutonomous))),
+		Depth:          opts.Depth,
+		StartedAt:      time.Now(),
+	}
+	l.Broker.PublishActivity(opts.Agent.ID, &pubsub.Event_RunStarted{RunStarted: &pubsub.RunStarted{
+		ConversationId: opts.Conv.ID,
+		AgentId:        opts.Agent.ID,
+		AgentName:      opts.Agent.Name,
+		Depth:          int32(opts.Depth),
+		RunId:          info.ID,
+		Kind:           info.Kind,
+	}})
+
+	result := TurnResult{
+		RunID:       info.ID,
+		Kind:        info.Kind,
+		Conv:        opts.Conv,
+		Agent:       opts.Agent,
+		UserContent: opts.UserContent,
+		StartedAt:   info.StartedAt,
+	}
+	tr := &tracer{}
+	response, err := l.runTurn(withTracer(ctx, tr), opts, info, &result)
+
+	finished := &pubsub.RunFinished{
+		ConversationId: opts.Conv.ID,
+		AgentId:        opts.Agent.ID,
+		AgentName:      opts.Agent.Name,
+		Status:         RunStatusOf(err),
+		RunId:          info.ID,
+	}
+	if err != nil {
+		finished.Error = err.Error()
+		finished.ErrorCode = ErrorCodeOf(err)
+	}
+	l.Broker.PublishActivity(opts.Agent.ID, &pubsub.Event_RunFinished{RunFinished: finished})
+
+	result.Response = response
+	result.Status = finished.Status
+	result.Err = err
+	result.FinishedAt = time.Now()
+	l.saveTrace(ctx, tr, result)
+	l.startHooks(ctx, result)
+
+	return response, err
+}
+
+// runTurn runs a turn, recording its model and token usage in result.
+func (l *Loop) runTurn(ctx context.Context, opts TurnOpts, info ActiveRun, result *TurnResult) (string, error) {
 	defer l.Broker.ClearBusy(opts.Conv.ID)
 
+	// Autonomous runs started by other agents aren't limited, so agents
+	// that call themselves can't wait for their own slot.
+	var limit int
+	if opts.Depth == 0 && info.Kind != RunKindInteractive && info.Kind != RunKindReplay {
+		limit = int(opts.Agent.MaxConcurrentRuns)
+	}
+	ctx, end, err := l.turns.beginLimited(ctx, info, limit)
+	if err != nil {
+		l.Broker.Publish(opts.Conv.ID, &pubsub.Event_Error{Error: &pubsub.Error{Message: err.Error(), Code: ErrorCodeOf(err)}})
+		l.Broker.Publish(opts.Conv.ID, &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{}})
+		return "", err
+	}
+	defer end()
+	tracerFrom(ctx).queued(time.Since(info.StartedAt))
+	ctx, cancel := l.withTurnDeadline(ctx)
+	defer cancel()
+
+	progress, stopProgress := l.reportProgress(opts.Conv.ID)
+	defer stopProgress()
+	defer func() {
+		result.InputTokens, result.OutputTokens = progress.usage()
+	}()
+
+	var sub subagent
+	if opts.ParentConversationID != "" {
+		sub = subagent{
+			parentConversationID: opts.ParentConversationID,
+			runID:                info.ID,
+			agentID:              opts.Agent.ID,
+			agentName:            opts.Agent.Name,
+		}
+	}
+	ctx = withSubagent(ctx, sub)
+	defer l.publishSubagentDone(ctx, opts.Conv.ID)
+
 	// Set context values for tool execution
 	ctx = tool.WithConversationID(ctx, opts.Conv.ID)
 	ctx = tool.WithAgentID(ctx, opts.Conv.AgentID)
+	ctx = tool.WithRunVars(ctx, opts.Vars)
+	ctx = tool.WithArtifactRecorder(ctx, &runArtifacts{queries: l.Queries, runID: info.ID, convID: opts.Conv.ID})
+	ctx = l.withApprover(ctx, opts, info)
 	if opts.Depth > 0 {
 		ctx = tool.WithDepth(ctx, opts.Depth)
 	}
@@ -232,165 +535,337 @@ func (l *Loop) RunTurn(ctx context.Context, opts TurnOpts) (string, error) {
 
 	orReq, fsToolRoots, err := l.prepareTurn(ctx, opts)
 	if err != nil {
-		l.Broker.Publish(opts.Conv.ID, Error{Message: err.Error()})
-		l.Broker.Publish(opts.Conv.ID, TurnDone{})
+		l.Broker.Publish(opts.Conv.ID, &pubsub.Event_Error{Error: &pubsub.Error{Message: err.Error(), Code: ErrorCodeOf(err)}})
+		l.Broker.Publish(opts.Conv.ID, &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{}})
 		return "", err
 	}
 
 	if len(fsToolRoots) > 0 {
 		ctx = tool.WithFSToolRoots(ctx, fsToolRoots)
 	}
+	ctx = withLazyTools(ctx, l.deferTools(orReq))
+	result.Model = orReq.Model
+
+	var rec *recorder
+	if l.RecordTurns && replayFrom(ctx) == nil {
+		rec = &recorder{rec: Recording{AgentID: opts.Agent.ID, UserContent: opts.UserContent, Model: orReq.Model}}
+		defer l.saveRecording(ctx, info.ID, opts.Conv.ID, rec)
+	}
+	ctx = withRecorder(ctx, rec)
+	if replayFrom(ctx) == nil {
+		ctx = withCapturer(ctx, l.newCapturer(ctx, info.ID, opts.Conv, opts.Agent))
+	}
 
-	response, err := l.runLoop(ctx, opts.Conv, orReq, opts.UserContent, nil)
+	response, err := l.runLoop(ctx, info.ID, opts.Conv, opts.Agent, orReq, opts.UserContent, progress, l.newGuard())
+	if aborted(err) {
+		// Events have been published when storing the partial output.
+		return "", err
+	}
 	if err != nil {
-		l.Broker.Publish(opts.Conv.ID, Error{Message: err.Error()})
-		l.Broker.Publish(opts.Conv.ID, TurnDone{})
+		l.Broker.Publish(opts.Conv.ID, &pubsub.Event_Error{Error: &pubsub.Error{Message: err.Error(), Code: ErrorCodeOf(err)}})
+		l.Broker.Publish(opts.Conv.ID, &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{}})
 		return "", err
 	}
 
 	return response, nil
 }
 
-func (l *Loop) runLoop(ctx context.Context, conv store.Conversation, orReq *openrouter.ResponseRequest, userContent string, priorItems []StoredItem) (string, error) {
-	events, errs := l.ORClient.CreateResponseStream(ctx, orReq)
+// runLoop runs LLM round-trips until the LLM responds without function
+// calls. The turn's items are checkpointed after each round-trip that called
+// tools, and stored with the final status when the turn ends.
+func (l *Loop) runLoop(ctx context.Context, runID string, conv store.Conversation, agent store.Agent, orReq *openrouter.ResponseRequest, userContent string, progress *progress, guard *guard) (string, error) {
+	var items []StoredItem
+	cp := &checkpoint{runID: runID}
+	rec := recorderFrom(ctx)
+	tr := tracerFrom(ctx)
+	capture := capturerFrom(ctx)
 
-	var currentText string
-	var responseID string
+	for {
+		if err := guard.iterate(); err != nil {
+			return l.abortTurn(ctx, conv, agent, userContent, items, cp, err)
+		}
+
+		lazyToolsFrom(ctx).apply(orReq)
+		tr.startRound(orReq)
+		text, resp, err := l.roundTrip(capture.startRound(ctx), conv.ID, orReq)
+		tr.endRound(resp, err)
+		l.saveRound(ctx, capture, err)
+		if text != "" {
+			items = append(items, StoredItem{Type: ItemTypeText, Text: text})
+		}
+		if cause := stopCause(ctx); err != nil && cause != nil {
+			return l.stopTurn(ctx, conv, agent, userContent, items, cp, cause)
+		}
+		if err != nil {
+			cp.fail(ctx, l.Queries, l.logger(), items)
+			return "", err
+		}
+		if resp == nil {
+			return l.finishTurn(ctx, conv, agent, userContent, items, "", cp, MessageStatusCompleted, nil)
+		}
+		progress.addUsage(resp.Usage)
 
+		// Don't run tool calls that go in circles.
+		if err := guard.checkCalls(resp.Output); err != nil {
+			return l.abortTurn(ctx, conv, agent, userContent, items, cp, err)
+		}
+
+		var toolNames []string
+		for _, item := range resp.Output {
+			if item.Type == "function_call" {
+				toolNames = append(toolNames, tool.DecodeToolName(item.Name))
+			}
+		}
+		progress.setTools(toolNames)
+		tr.startTools()
+		toolInputs, err := l.ToolExecutor.ProcessOutput(ctx, resp.Output, func(r tool.ToolResult) {
+			rec.addToolResult(r.CallID, r.Output)
+			tr.addToolCall(r)
+			decodedName := tool.DecodeToolName(r.Name)
+			input, err := sealArgs(l.Cipher, r.Arguments, r.SensitiveArgs)
+			if err != nil {
+				// Better to lose the arguments than to store them in plaintext.
+				l.logger().Error("failed to encrypt sensitive tool arguments", "tool", decodedName, "error", err)
+				input = "{}"
+			}
+			items = append(items, StoredItem{
+				Type:   ItemTypeToolExecution,
+				ID:     r.ID,
+				CallID: r.CallID,
+				Name:   decodedName,
+				Input:  input,
+				Result: r.Output,
+			})
+			// Events are kept in the event log, so they only have the
+			// encrypted values, redacted.
+			l.publishOutput(ctx, conv.ID, &pubsub.Event_ToolResult{ToolResult: &pubsub.ToolResult{
+				Name:      decodedName,
+				Input:     RedactSealedArgs(input),
+				Result:    r.Output,
+				ErrorCode: r.ErrorCode,
+				CallId:    r.CallID,
+			}})
+		})
+		progress.setTools(nil)
+		if cause := stopCause(ctx); err != nil && cause != nil {
+			return l.stopTurn(ctx, conv, agent, userContent, items, cp, cause)
+		}
+		if err != nil {
+			cp.fail(ctx, l.Queries, l.logger(), items)
+			return "", fmt.Errorf("process output: %w", err)
+		}
+
+		if len(toolInputs) == 0 {
+			return l.finishTurn(ctx, conv, agent, userContent, items, resp.ID, cp, MessageStatusCompleted, nil)
+		}
+		orReq.Input = append(orReq.Input, toolInputs...)
+
+		// A failed checkpoint only costs durability, so the turn goes on.
+		if err := cp.save(ctx, l.Queries, conv.ID, items); err != nil {
+			l.logger().Error("failed to checkpoint turn", "conversation_id", conv.ID, "error", err)
+		}
+	}
+}
+
+// roundTrip streams an LLM response, publishing its text deltas. It returns
+// the streamed text, which is also returned on error, and the completed
+// response, which is nil if the stream ended without one.
+func (l *Loop) roundTrip(ctx context.Context, convID string, orReq *openrouter.ResponseRequest) (string, *openrouter.Response, error) {
+	rec := recorderFrom(ctx)
+	rec.startRound()
+	tr := tracerFrom(ctx)
+	events, errs := l.stream(ctx, orReq)
+
+	var text string
+	var resp *openrouter.Response
+	// Function calls being streamed, by output item ID.
+	calls := make(map[string]*openrouter.OutputItem)
 	for {
 		select {
 		case event, ok := <-events:
 			if !ok {
-				// Stream ended — finalize
-				var items []StoredItem
-				items = append(items, priorItems...)
-				if currentText != "" {
-					items = append(items, StoredItem{Type: "text", Text: currentText})
-				}
-				return l.finishTurn(ctx, conv, userContent, items, responseID)
+				return text, resp, nil
 			}
-
-			// Publish text deltas
+			rec.addEvent(event)
+			tr.firstEvent()
 			if event.Type == "response.output_text.delta" && event.Delta != "" {
-				currentText += event.Delta
-				l.Broker.Publish(conv.ID, TextDelta{Content: event.Delta})
+				text += event.Delta
+				l.publishOutput(ctx, convID, &pubsub.Event_TextDelta{TextDelta: &pubsub.TextDelta{Content: event.Delta}})
 			}
-
-			// Handle response completion (may contain function calls)
+			l.publishToolStart(ctx, convID, event, calls)
 			if event.Response != nil {
-				responseID = event.Response.ID
-
-				// Prepare items before ProcessOutput (callback appends to this slice)
-				var items []StoredItem
-				items = append(items, priorItems...)
-				if currentText != "" {
-					items = append(items, StoredItem{Type: "text", Text: currentText})
-				}
-
-				toolInputs, err := l.ToolExecutor.ProcessOutput(ctx, event.Response.Output, func(r tool.ToolResult) {
-					decodedName := tool.DecodeToolName(r.Name)
-					items = append(items, StoredItem{
-						Type:   "tool_execution",
-						ID:     r.ID,
-						CallID: r.CallID,
-						Name:   decodedName,
-						Input:  r.Arguments,
-						Result: r.Output,
-					})
-					l.Broker.Publish(conv.ID, ToolResult{
-						Name:   decodedName,
-						Input:  r.Arguments,
-						Result: r.Output,
-					})
-				})
-				if err != nil {
-					return "", fmt.Errorf("process output: %w", err)
-				}
-
-				if len(toolInputs) > 0 {
-					orReq.Input = append(orReq.Input, toolInputs...)
-					return l.runLoop(ctx, conv, orReq, userContent, items)
-				}
+				resp = event.Response
 			}
 
 		case err := <-errs:
 			if err != nil {
-				return "", fmt.Errorf("stream error: %w", err)
+				rec.setError(err)
+				return text, nil, fmt.Errorf("%w: %w", errStream, err)
 			}
 
 		case <-ctx.Done():
-			return "", ctx.Err()
+			return text, nil, ctx.Err()
 		}
 	}
 }
 
-func (l *Loop) finishTurn(ctx context.Context, conv store.Conversation, userContent string, items []StoredItem, responseID string) (string, error) {
-	if len(items) == 0 {
-		l.Broker.Publish(conv.ID, TurnDone{})
-		return "", nil
+// publishToolStart publishes ToolExecutionStarted events for the function
+// calls of a stream as the LLM writes them, so clients can show a call
+// before it completes and while it runs.
+func (l *Loop) publishToolStart(ctx context.Context, convID string, event openrouter.StreamEvent, calls map[string]*openrouter.OutputItem) {
+	var call *openrouter.OutputItem
+	var delta string
+	switch event.Type {
+	case "response.output_item.added":
+		if event.Item == nil || event.Item.Type != "function_call" {
+			return
+		}
+		call = event.Item
+		calls[call.ID] = call
+	case "response.function_call_arguments.delta":
+		call = calls[event.ItemID]
+		delta = event.Delta
+		if call == nil || delta == "" {
+			return
+		}
+	default:
+		return
 	}
+	l.publishOutput(ctx, convID, &pubsub.Event_ToolExecutionStarted{ToolExecutionStarted: &pubsub.ToolExecutionStarted{
+		CallId:         call.CallID,
+		Name:           tool.DecodeToolName(call.Name),
+		ArgumentsDelta: delta,
+	}})
+}
 
-	// Persist assistant message
-	itemsJSON, err := json.Marshal(items)
-	if err != nil {
-		return "", fmt.Errorf("marshal items: %w", err)
+// stopTurn stores the output of a turn stopped by Drain, CancelRun or its
+// deadline, and returns cause.
+func (l *Loop) stopTurn(ctx context.Context, conv store.Conversation, agent store.Agent, userContent string, items []StoredItem, cp *checkpoint, cause error) (string, error) {
+	status := MessageStatusInterrupted
+	switch {
+	case errors.Is(cause, ErrCancelled):
+		status = MessageStatusCancelled
+	case errors.Is(cause, ErrTimedOut):
+		status = MessageStatusTimedOut
 	}
+	return l.finishTurn(ctx, conv, agent, userContent, items, "", cp, status, cause)
+}
 
-	msgID := uuid.NewString()
-	createdAt := time.Now().UTC().Format(time.RFC3339)
-	_, err = l.Queries.CreateMessage(ctx, store.CreateMessageParams{
-		ID:             msgID,
-		ConversationID: conv.ID,
-		Role:           "assistant",
-		Items:          string(itemsJSON),
-		CreatedAt:      createdAt,
-	})
-	if err != nil {
-		return "", fmt.Errorf("create assistant message: %w", err)
+// abortTurn stores the output of a turn stopped by its guard, followed by an
+// error item with the reason, and returns err.
+func (l *Loop) abortTurn(ctx context.Context, conv store.Conversation, agent store.Agent, userContent string, items []StoredItem, cp *checkpoint, err error) (string, error) {
+	items = append(items, StoredItem{Type: ItemTypeError, Text: err.Error()})
+	return l.finishTurn(ctx, conv, agent, userContent, items, "", cp, MessageStatusFailed, err)
+}
+
+// finishTurn stores the assistant message of a turn, replacing its
+// checkpoint if there is one. Turns that were interrupted or aborted have a
+// cause: they're stored with the output produced so far, and return the
+// cause.
+func (l *Loop) finishTurn(ctx context.Context, conv store.Conversation, agent store.Agent, userContent string, items []StoredItem, responseID string, cp *checkpoint, status string, cause error) (string, error) {
+	if cause != nil {
+		// The turn's context may be cancelled, but the output must be stored.
+		ctx = context.WithoutCancel(ctx)
+		// The response wasn't completed, so it can't be continued from.
+		responseID = ""
 	}
 
-	// Publish message_created event
-	l.Broker.Publish(conv.ID, MessageDone{
-		MessageID: msgID,
-		Role:      "assistant",
-		ItemsJSON: string(itemsJSON),
-		CreatedAt: createdAt,
-	})
+	if len(items) == 0 {
+		if cause != nil {
+			l.Broker.Publish(conv.ID, &pubsub.Event_Error{Error: &pubsub.Error{Message: cause.Error(), Code: ErrorCodeOf(cause)}})
+			l.Broker.Publish(conv.ID, &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{}})
+			return "", cause
+		}
+		l.Broker.Publish(conv.ID, &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{}})
+		return "", nil
+	}
 
-	// Generate title if this is the first turn
+	itemsJSON, err := EncodeItems(items)
+	if err != nil {
+		return "", fmt.Errorf("encode items: %w", err)
+	}
+
+	// Generate title if this is the first completed turn
 	var title string
-	if conv.Title == "" {
+	if conv.Title == "" && status == MessageStatusCompleted && agent.TitleGenerationDisabled == 0 {
 		plainText := PlainTextFromItems(items)
 		if userContent != "" {
-			generated, err := l.ORClient.GenerateTitle(ctx, l.DefaultModel, userContent, plainText)
+			model := cmp.Or(agent.TitleModel, l.TitleModel, l.DefaultModel)
+			generated, err := l.ORClient.GenerateTitle(ctx, model, titlePrompt(agent), userContent, plainText)
 			if err != nil {
-				log.Printf("Failed to generate title: %v", err)
+				l.logger().Error("failed to generate title", "conversation_id", conv.ID, "error", err)
 			} else {
 				title = generated
 			}
 		}
 	}
 
-	// Update conversation with response ID and title
-	now := time.Now().UTC().Format(time.RFC3339)
-	if responseID != "" || title != "" {
+	// Persist the message and update the conversation with response ID and
+	// title atomically, so history and previous_response_id stay consistent.
+	msgID, createdAt := cp.msgID, cp.createdAt
+	if msgID == "" {
+		msgID = uuid.NewString()
+		createdAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	err = l.Queries.InTx(ctx, func(q *store.Queries) error {
+		if cp.msgID != "" {
+			if err := q.UpdateMessageItems(ctx, store.UpdateMessageItemsParams{
+				ID:     msgID,
+				Items:  itemsJSON,
+				Status: status,
+			}); err != nil {
+				return fmt.Errorf("update assistant message: %w", err)
+			}
+		} else if _, err := q.CreateMessage(ctx, store.CreateMessageParams{
+			ID:             msgID,
+			ConversationID: conv.ID,
+			Role:           "assistant",
+			Items:          itemsJSON,
+			Status:         status,
+			RunID:          cp.runID,
+			CreatedAt:      createdAt,
+		}); err != nil {
+			return fmt.Errorf("create assistant message: %w", err)
+		}
+
+		if responseID == "" && title == "" {
+			return nil
+		}
 		newTitle := conv.Title
 		if title != "" {
 			newTitle = title
 		}
-		_, err = l.Queries.UpdateConversation(ctx, store.UpdateConversationParams{
+		if _, err := q.UpdateConversation(ctx, store.UpdateConversationParams{
 			ID:                 conv.ID,
 			Title:              newTitle,
 			PreviousResponseID: responseID,
-			UpdatedAt:          now,
-		})
-		if err != nil {
-			return "", fmt.Errorf("update conversation: %w", err)
+			UpdatedAt:          time.Now().UTC().Format(time.RFC3339),
+		}); err != nil {
+			return fmt.Errorf("update conversation: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Publish message_created event
+	l.Broker.Publish(conv.ID, &pubsub.Event_MessageDone{MessageDone: &pubsub.MessageDone{
+		MessageId: msgID,
+		Role:      "assistant",
+		ItemsJson: itemsJSON,
+		Status:    status,
+		CreatedAt: createdAt,
+	}})
+
+	if cause != nil {
+		l.Broker.Publish(conv.ID, &pubsub.Event_Error{Error: &pubsub.Error{Message: cause.Error(), Code: ErrorCodeOf(cause)}})
+		l.Broker.Publish(conv.ID, &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{}})
+		return "", cause
 	}
 
 	// Publish turn done
-	l.Broker.Publish(conv.ID, TurnDone{Title: title})
+	l.Broker.Publish(conv.ID, &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{Title: title}})
 
 	return PlainTextFromItems(items), nil
 }
@@ -399,7 +874,7 @@ func (l *Loop) finishTurn(ctx context.Context, conv store.Conversation, userCont
 func PlainTextFromItems(items []StoredItem) string {
 	var parts []string
 	for _, item := range items {
-		if item.Type == "text" && item.Text != "" {
+		if item.Type == ItemTypeText && item.Text != "" {
 			parts = append(parts, item.Text)
 		}
 	}
@@ -407,28 +882,33 @@ func PlainTextFromItems(items []StoredItem) string {
 }
 
 // BuildHistoryInputs converts a stored message into OpenRouter input items.
-func BuildHistoryInputs(msg store.Message) []openrouter.Input {
-	var items []StoredItem
-	if msg.Items != "" && msg.Items != "[]" {
-		_ = json.Unmarshal([]byte(msg.Items), &items)
+func BuildHistoryInputs(msg store.Message) ([]openrouter.Input, error) {
+	items, err := DecodeItems(msg.Items)
+	if err != nil {
+		return nil, fmt.Errorf("decode items of message %s: %w", msg.ID, err)
 	}
 
 	if msg.Role == "user" {
 		text := PlainTextFromItems(items)
+		for _, item := range items {
+			if item.Type == ItemTypeAttachment {
+				text = strings.TrimPrefix(text+"\n\n"+attachmentNote(item), "\n\n")
+			}
+		}
 		return []openrouter.Input{{
 			Type: "message",
 			Role: "user",
 			Content: []openrouter.ContentPart{
 				{Type: "input_text", Text: text},
 			},
-		}}
+		}}, nil
 	}
 
 	if msg.Role == "assistant" {
 		var inputs []openrouter.Input
 		for i, item := range items {
 			switch item.Type {
-			case "tool_execution":
+			case ItemTypeToolExecution:
 				callID := item.CallID
 				if callID == "" {
 					callID = fmt.Sprintf("call_%s_%d", msg.ID, i)
@@ -467,8 +947,18 @@ func BuildHistoryInputs(msg store.Message) []openrouter.Input {
 			})
 		}
 
-		return inputs
+		return inputs, nil
 	}
 
-	return nil
+	return nil, nil
+}
+
+// titlePrompt returns the prompt generating the titles of an agent's
+// conversations: its own, or the default one asking for a title in the
+// language of its locale.
+func titlePrompt(agent store.Agent) string {
+	if agent.TitlePrompt != "" || agent.Locale == "" {
+		return agent.TitlePrompt
+	}
+	return openrouter.DefaultTitlePrompt + "\n\nWrite the title in " + locale.LanguageName(agent.Locale) + "."
 }
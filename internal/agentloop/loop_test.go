@@ -0,0 +1,211 @@
+package agentloop
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestStartTurn(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	broker := pubsub.New(nil, slog.Default())
+	sub := broker.Subscribe("conv-1")
+	defer broker.Unsubscribe(sub)
+	l := &Loop{Queries: queries, Broker: broker}
+
+	history, msgID, err := l.StartTurn(ctx, "conv-1", "Hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 0 || msgID == "" {
+		t.Errorf("history = %v, message ID = %q; want no history and an ID", history, msgID)
+	}
+	if e := <-sub.C; e.GetMessageDone().GetMessageId() != msgID {
+		t.Errorf("first event = %v, want user message", e)
+	}
+	if e := <-sub.C; e.GetTurnStarted() == nil {
+		t.Errorf("second event = %v, want turn started", e)
+	}
+
+	if _, _, err := l.StartTurn(ctx, "conv-1", "Again"); !errors.Is(err, ErrConversationBusy) {
+		t.Errorf("StartTurn on busy conversation: got %v, want ErrConversationBusy", err)
+	}
+
+	broker.ClearBusy("conv-1")
+	history, _, err = l.StartTurn(ctx, "conv-1", "Again")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].ID != msgID {
+		t.Errorf("history = %v, want first message", history)
+	}
+}
+
+func TestTitleGeneration(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	f, err := openrouter.LoadMockFixture("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &Loop{
+		Queries:      queries,
+		ORClient:     openrouter.NewMockClient(f),
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       pubsub.New(nil, slog.Default()),
+		DefaultModel: "mock",
+		TitleModel:   "mock-cheap",
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, tt := range []struct {
+		id        string
+		disabled  bool
+		wantTitle string
+	}{
+		{id: "enabled", wantTitle: openrouter.DefaultMockText},
+		{id: "disabled", disabled: true, wantTitle: ""},
+	} {
+		agent, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: tt.id, Name: tt.id, EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", Hooks: "[]", TitleGenerationDisabled: boolToInt(tt.disabled), TitlePrompt: "Title for {user}", CreatedAt: now, UpdatedAt: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		conv, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-" + tt.id, AgentID: agent.ID, CreatedAt: now, UpdatedAt: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		history, _, err := l.StartTurn(ctx, conv.ID, "Hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := l.RunTurn(ctx, TurnOpts{Conv: conv, Agent: agent, UserContent: "Hello", History: history}); err != nil {
+			t.Fatal(err)
+		}
+		conv, err = queries.GetConversation(ctx, conv.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if conv.Title != tt.wantTitle {
+			t.Errorf("%s: title = %q, want %q", tt.id, conv.Title, tt.wantTitle)
+		}
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestToolExecutionStarted(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	agent, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", Title: "Weather", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(fixture, []byte(`{"rules": [{"steps": [
+		{"tool_calls": [{"name": "fetch", "arguments": {"url": "https://example.com/weather"}}]},
+		{"text": "It's sunny."}
+	]}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := openrouter.LoadMockFixture(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker := pubsub.New(nil, slog.New(slog.DiscardHandler))
+	l := &Loop{
+		Queries:      queries,
+		ORClient:     openrouter.NewMockClient(f),
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       broker,
+		DefaultModel: "mock",
+	}
+
+	history, _, err := l.StartTurn(ctx, conv.ID, "What's the weather?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := broker.Subscribe(conv.ID)
+	defer broker.Unsubscribe(sub)
+	if _, err := l.RunTurn(ctx, TurnOpts{Conv: conv, Agent: agent, UserContent: "What's the weather?", History: history}); err != nil {
+		t.Fatal(err)
+	}
+
+	var started []*pubsub.ToolExecutionStarted
+	var result *pubsub.ToolResult
+	for len(sub.C) > 0 {
+		e := <-sub.C
+		if s := e.GetToolExecutionStarted(); s != nil {
+			if result != nil {
+				t.Errorf("ToolExecutionStarted %v after ToolResult", s)
+			}
+			started = append(started, s)
+		}
+		if r := e.GetToolResult(); r != nil {
+			result = r
+		}
+	}
+	// The call is started, then its arguments are streamed in parts.
+	if len(started) < 3 || started[0].Name != "fetch" || started[0].ArgumentsDelta != "" {
+		t.Fatalf("started = %v, want start and argument deltas of fetch", started)
+	}
+	var args string
+	for _, s := range started {
+		if s.CallId != started[0].CallId {
+			t.Errorf("call ID = %q, want %q", s.CallId, started[0].CallId)
+		}
+		args += s.ArgumentsDelta
+	}
+	if args != `{"url":"https://example.com/weather"}` {
+		t.Errorf("arguments = %s", args)
+	}
+	if result == nil || result.CallId != started[0].CallId {
+		t.Errorf("result = %v, want result of call %s", result, started[0].CallId)
+	}
+}
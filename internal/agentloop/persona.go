@@ -0,0 +1,29 @@
+package agentloop
+
+import (
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// SystemPrompt composes the system prompt of an agent: its persona sections
+// as headed sections, leaving out empty ones, followed by its free-form
+// system prompt.
+func SystemPrompt(agent store.Agent) string {
+	sections := []struct{ heading, text string }{
+		{"Role", agent.PersonaRole},
+		{"Goals", agent.PersonaGoals},
+		{"Constraints", agent.PersonaConstraints},
+		{"Style", agent.PersonaStyle},
+	}
+	var sb strings.Builder
+	for _, s := range sections {
+		text := strings.TrimSpace(s.text)
+		if text == "" {
+			continue
+		}
+		sb.WriteString("## " + s.heading + "\n" + text + "\n\n")
+	}
+	sb.WriteString(agent.SystemPrompt)
+	return sb.String()
+}
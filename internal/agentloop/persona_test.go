@@ -0,0 +1,33 @@
+package agentloop
+
+import (
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestSystemPrompt(t *testing.T) {
+	tests := []struct {
+		name  string
+		agent store.Agent
+		want  string
+	}{
+		{
+			name:  "without persona",
+			agent: store.Agent{SystemPrompt: "Be brief."},
+			want:  "Be brief.",
+		},
+		{
+			name:  "with persona",
+			agent: store.Agent{PersonaRole: "You are the on-call assistant.\n", PersonaStyle: "Use bullet points.", PersonaGoals: " ", SystemPrompt: "Be brief."},
+			want:  "## Role\nYou are the on-call assistant.\n\n## Style\nUse bullet points.\n\nBe brief.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SystemPrompt(tt.agent); got != tt.want {
+				t.Errorf("SystemPrompt = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
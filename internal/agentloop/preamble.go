@@ -0,0 +1,38 @@
+package agentloop
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/locale"
+)
+
+// PreambleSetting is the settings key of the instance preamble: guidance for
+// all agents, such as organization policies, prepended to their instructions.
+const PreambleSetting = "instance_preamble"
+
+// MaxPreambleBytes limits the size of the instance preamble, which takes up
+// context in every turn.
+const MaxPreambleBytes = 16 << 10
+
+// preamble returns the instance preamble for the instructions of a turn, with
+// "{date}" replaced by the current date in the agent's locale. Turns go on
+// without it if it can't be loaded.
+func (l *Loop) preamble(ctx context.Context, loc string) string {
+	text, err := l.Queries.GetSetting(ctx, PreambleSetting)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			l.logger().Warn("failed to get instance preamble", "error", err)
+		}
+		return ""
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+	text = locale.ExpandDate(text, loc, time.Now().UTC())
+	return text + "\n\n"
+}
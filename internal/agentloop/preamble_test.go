@@ -0,0 +1,47 @@
+package agentloop
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/locale"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestPreamble(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	l := &Loop{Queries: queries}
+
+	if got := l.preamble(ctx, ""); got != "" {
+		t.Errorf("preamble without setting = %q, want empty", got)
+	}
+
+	now := time.Now().UTC()
+	if err := queries.UpsertSetting(ctx, store.UpsertSettingParams{Key: PreambleSetting, Value: "  Today is {date}.\n", UpdatedAt: now.Format(time.RFC3339)}); err != nil {
+		t.Fatal(err)
+	}
+	got := l.preamble(ctx, "")
+	if !strings.HasPrefix(got, "Today is ") || !strings.Contains(got, now.Format("2 January 2006")) || !strings.HasSuffix(got, ".\n\n") {
+		t.Errorf("preamble = %q, want date and trailing blank line", got)
+	}
+
+	if got := l.preamble(ctx, "de-DE"); !strings.Contains(got, locale.FormatDate("de", now)) {
+		t.Errorf("preamble of German agent = %q, want German date", got)
+	}
+
+	if err := queries.UpsertSetting(ctx, store.UpsertSettingParams{Key: PreambleSetting, Value: " ", UpdatedAt: now.Format(time.RFC3339)}); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.preamble(ctx, ""); got != "" {
+		t.Errorf("blank preamble = %q, want empty", got)
+	}
+}
@@ -0,0 +1,90 @@
+package agentloop
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+)
+
+// DefaultProgressInterval is how often TurnProgress events are published
+// while a turn is active.
+const DefaultProgressInterval = 5 * time.Second
+
+// progress tracks what a turn is doing, for TurnProgress events.
+type progress struct {
+	started time.Time
+
+	mu           sync.Mutex
+	tools        []string
+	inputTokens  int64
+	outputTokens int64
+}
+
+// setTools records the names of the tools being executed, or nil if none.
+func (p *progress) setTools(names []string) {
+	p.mu.Lock()
+	p.tools = names
+	p.mu.Unlock()
+}
+
+// addUsage adds the tokens used for a response.
+func (p *progress) addUsage(u *openrouter.Usage) {
+	if u == nil {
+		return
+	}
+	p.mu.Lock()
+	p.inputTokens += u.InputTokens
+	p.outputTokens += u.OutputTokens
+	p.mu.Unlock()
+}
+
+// usage returns the tokens used so far.
+func (p *progress) usage() (input, output int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inputTokens, p.outputTokens
+}
+
+func (p *progress) event() *pubsub.Event_TurnProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &pubsub.Event_TurnProgress{TurnProgress: &pubsub.TurnProgress{
+		ElapsedMs:    time.Since(p.started).Milliseconds(),
+		Tools:        p.tools,
+		InputTokens:  p.inputTokens,
+		OutputTokens: p.outputTokens,
+	}}
+}
+
+// reportProgress publishes TurnProgress heartbeats for a conversation until
+// the returned stop function is called.
+func (l *Loop) reportProgress(conversationID string) (_ *progress, stop func()) {
+	p := &progress{started: time.Now()}
+	interval := l.ProgressInterval
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				l.Broker.PublishTransient(conversationID, p.event())
+			case <-done:
+				return
+			}
+		}
+	}()
+	return p, func() {
+		close(done)
+		// No heartbeats are published after the turn returns.
+		<-stopped
+	}
+}
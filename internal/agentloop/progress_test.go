@@ -0,0 +1,42 @@
+package agentloop
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+)
+
+func TestReportProgress(t *testing.T) {
+	broker := pubsub.New(nil, slog.Default())
+	sub := broker.Subscribe("conv-1")
+	defer broker.Unsubscribe(sub)
+	l := &Loop{Broker: broker, ProgressInterval: 10 * time.Millisecond}
+
+	p, stop := l.reportProgress("conv-1")
+	p.addUsage(&openrouter.Usage{InputTokens: 100, OutputTokens: 20})
+	p.addUsage(&openrouter.Usage{InputTokens: 150, OutputTokens: 30})
+	p.setTools([]string{"fetch_url"})
+
+	e := <-sub.C
+	got := e.GetTurnProgress()
+	if got == nil || e.Sequence != 0 {
+		t.Fatalf("event = %v, want unsequenced turn progress", e)
+	}
+	if !slices.Equal(got.Tools, []string{"fetch_url"}) || got.InputTokens != 250 || got.OutputTokens != 50 || got.ElapsedMs < 10 {
+		t.Errorf("progress = %v, want fetch_url running after 250+50 tokens", got)
+	}
+
+	stop()
+	// Drain heartbeats published before stopping.
+	for len(sub.C) > 0 {
+		<-sub.C
+	}
+	time.Sleep(30 * time.Millisecond)
+	if n := len(sub.C); n != 0 {
+		t.Errorf("got %d events after stop, want none", n)
+	}
+}
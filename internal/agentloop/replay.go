@@ -0,0 +1,256 @@
+package agentloop
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// RunKindReplay is the kind of turns run by ReplayTurn.
+const RunKindReplay = "replay"
+
+var (
+	// ErrRecordingNotFound is returned by ReplayTurn if the turn wasn't
+	// recorded.
+	ErrRecordingNotFound = errors.New("turn recording not found")
+	// ErrReplayDiverged is returned by ReplayTurn if the replayed turn asks
+	// for more LLM responses than were recorded.
+	ErrReplayDiverged = errors.New("replay diverged from the recorded turn")
+)
+
+// Recording holds what a turn got from outside the loop, so it can be
+// replayed: the LLM's streamed responses and the tool results.
+type Recording struct {
+	AgentID     string          `json:"agent_id"`
+	UserContent string          `json:"user_content"`
+	Model       string          `json:"model"`
+	Rounds      []RecordedRound `json:"rounds"`
+}
+
+// RecordedRound is an LLM round-trip of a recorded turn.
+type RecordedRound struct {
+	Events []openrouter.StreamEvent `json:"events"`
+	// Error is the stream error the round-trip ended with, if any.
+	Error string `json:"error,omitempty"`
+	// ToolResults are the results of the round's tool calls by call ID.
+	ToolResults map[string]string `json:"tool_results,omitempty"`
+}
+
+type recorderKey struct{}
+
+// recorder records a turn. Its methods do nothing on a nil recorder, which
+// turns that aren't recorded have.
+type recorder struct {
+	rec Recording
+}
+
+// withRecorder sets the recorder of a turn. Turns that aren't recorded reset
+// it, so they aren't recorded as part of the turn that called them.
+func withRecorder(ctx context.Context, r *recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+func recorderFrom(ctx context.Context) *recorder {
+	r, _ := ctx.Value(recorderKey{}).(*recorder)
+	return r
+}
+
+func (r *recorder) startRound() {
+	if r != nil {
+		r.rec.Rounds = append(r.rec.Rounds, RecordedRound{})
+	}
+}
+
+func (r *recorder) round() *RecordedRound {
+	return &r.rec.Rounds[len(r.rec.Rounds)-1]
+}
+
+func (r *recorder) addEvent(event openrouter.StreamEvent) {
+	if r != nil {
+		r.round().Events = append(r.round().Events, event)
+	}
+}
+
+func (r *recorder) setError(err error) {
+	if r != nil {
+		r.round().Error = err.Error()
+	}
+}
+
+func (r *recorder) addToolResult(callID, output string) {
+	if r == nil {
+		return
+	}
+	round := r.round()
+	if round.ToolResults == nil {
+		round.ToolResults = make(map[string]string)
+	}
+	round.ToolResults[callID] = output
+}
+
+// saveRecording stores the recording of a turn, if it was recorded.
+func (l *Loop) saveRecording(ctx context.Context, runID, convID string, r *recorder) {
+	if r == nil || len(r.rec.Rounds) == 0 {
+		return
+	}
+	data, err := json.Marshal(r.rec)
+	if err == nil {
+		err = l.Queries.CreateTurnRecording(context.WithoutCancel(ctx), store.CreateTurnRecordingParams{
+			RunID:          runID,
+			ConversationID: convID,
+			Recording:      string(data),
+			CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+	if err != nil {
+		l.logger().Error("failed to store recording", "run_id", runID, "error", err)
+	}
+}
+
+type replayKey struct{}
+
+// replay feeds the recorded rounds of a turn to a replayed turn.
+type replay struct {
+	rounds []RecordedRound
+	next   int
+}
+
+func replayFrom(ctx context.Context) *replay {
+	r, _ := ctx.Value(replayKey{}).(*replay)
+	return r
+}
+
+// stream streams the LLM's response to req, or the next recorded response
+// when replaying.
+func (l *Loop) stream(ctx context.Context, req *openrouter.ResponseRequest) (<-chan openrouter.StreamEvent, <-chan error) {
+	r := replayFrom(ctx)
+	if r == nil {
+		return l.ORClient.CreateResponseStream(ctx, req)
+	}
+
+	events := make(chan openrouter.StreamEvent)
+	errs := make(chan error, 1)
+	if r.next >= len(r.rounds) {
+		errs <- fmt.Errorf("%w: no response recorded for round-trip %d", ErrReplayDiverged, r.next+1)
+		return events, errs
+	}
+	round := r.rounds[r.next]
+	r.next++
+
+	// Events are sent in order, followed by the error, if any, like a live
+	// stream.
+	go func() {
+		for _, event := range round.Events {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if round.Error != "" {
+			errs <- errors.New(round.Error)
+			return
+		}
+		close(events)
+	}()
+	return events, errs
+}
+
+// stubResult returns the recorded result of a tool call when replaying.
+func (r *replay) stubResult(callID string) string {
+	// The round of the tool calls is the last one streamed.
+	if result, ok := r.rounds[r.next-1].ToolResults[callID]; ok {
+		return result
+	}
+	return fmt.Sprintf("Error: %v: no result recorded for tool call %s", ErrReplayDiverged, callID)
+}
+
+// ReplayResult is the outcome of a replayed turn.
+type ReplayResult struct {
+	ConversationID string
+	Response       string
+}
+
+// ReplayTurn runs a recorded turn again, in a new conversation with the
+// agent, using the recorded LLM responses and tool results instead of calling
+// the LLM and executing tools. Storing and publishing the turn's output work
+// as usual, so bugs in them can be reproduced. runID is the recorded run, or
+// empty for the latest recorded turn of conversationID. Hooks don't run for
+// replayed turns.
+func (l *Loop) ReplayTurn(ctx context.Context, runID, conversationID string) (ReplayResult, error) {
+	var row store.TurnRecording
+	var err error
+	if runID != "" {
+		row, err = l.Queries.GetTurnRecording(ctx, runID)
+	} else {
+		row, err = l.Queries.GetLatestTurnRecording(ctx, conversationID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return ReplayResult{}, ErrRecordingNotFound
+	}
+	if err != nil {
+		return ReplayResult{}, fmt.Errorf("get recording: %w", err)
+	}
+	var rec Recording
+	if err := json.Unmarshal([]byte(row.Recording), &rec); err != nil {
+		return ReplayResult{}, fmt.Errorf("decode recording: %w", err)
+	}
+
+	orig, err := l.Queries.GetConversation(ctx, row.ConversationID)
+	if err != nil {
+		return ReplayResult{}, fmt.Errorf("get conversation: %w", err)
+	}
+	agent, err := l.Queries.GetAgent(ctx, rec.AgentID)
+	if err != nil {
+		return ReplayResult{}, fmt.Errorf("get agent: %w", err)
+	}
+
+	// A title keeps the replayed turn from generating one with the LLM.
+	now := time.Now().UTC().Format(time.RFC3339)
+	conv, err := l.Queries.CreateConversation(ctx, store.CreateConversationParams{
+		ID:        uuid.NewString(),
+		AgentID:   agent.ID,
+		Title:     "Replay of " + cmp.Or(orig.Title, orig.ID),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return ReplayResult{}, fmt.Errorf("create conversation: %w", err)
+	}
+	if _, _, err := l.StartTurn(ctx, conv.ID, rec.UserContent); err != nil {
+		return ReplayResult{}, fmt.Errorf("start turn: %w", err)
+	}
+
+	r := &replay{rounds: rec.Rounds}
+	ctx = context.WithValue(ctx, replayKey{}, r)
+	ctx = tool.WithStubResults(ctx, r.stubResult)
+	opts := TurnOpts{
+		Conv:          conv,
+		Agent:         agent,
+		UserContent:   rec.UserContent,
+		ModelOverride: rec.Model,
+		Kind:          RunKindReplay,
+	}
+	info := ActiveRun{
+		ID:             uuid.NewString(),
+		ConversationID: conv.ID,
+		AgentID:        agent.ID,
+		AgentName:      agent.Name,
+		Kind:           RunKindReplay,
+	}
+	response, err := l.runTurn(ctx, opts, info, &TurnResult{})
+	if err != nil {
+		return ReplayResult{ConversationID: conv.ID}, err
+	}
+	return ReplayResult{ConversationID: conv.ID, Response: response}, nil
+}
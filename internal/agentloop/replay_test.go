@@ -0,0 +1,118 @@
+package agentloop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestReplayTurn(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", Title: "Weather", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := Recording{
+		AgentID:     "agent-1",
+		UserContent: "What's the weather?",
+		Model:       "test/model",
+		Rounds: []RecordedRound{
+			{
+				Events: []openrouter.StreamEvent{{Type: "response.completed", Response: &openrouter.Response{
+					ID:     "resp-1",
+					Output: []openrouter.OutputItem{{Type: "function_call", ID: "fc-1", CallID: "call-1", Name: "fetch", Arguments: `{"url":"https://example.com"}`}},
+				}}},
+				ToolResults: map[string]string{"call-1": "Sunny"},
+			},
+			{
+				Events: []openrouter.StreamEvent{
+					{Type: "response.output_text.delta", Delta: "It's sunny."},
+					{Type: "response.completed", Response: &openrouter.Response{ID: "resp-2"}},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := queries.CreateTurnRecording(ctx, store.CreateTurnRecordingParams{RunID: "run-1", ConversationID: "conv-1", Recording: string(data), CreatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Loop{
+		Queries:      queries,
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       pubsub.New(nil, slog.Default()),
+		RecordTurns:  true,
+	}
+
+	result, err := l.ReplayTurn(ctx, "", "conv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Response != "It's sunny." {
+		t.Errorf("response = %q, want recorded text", result.Response)
+	}
+
+	conv, err := queries.GetConversation(ctx, result.ConversationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conv.Title != "Replay of Weather" || conv.PreviousResponseID != "resp-2" {
+		t.Errorf("conversation = %+v, want replay title and last response ID", conv)
+	}
+	msgs, err := queries.GetMessagesByConversation(ctx, conv.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want user and assistant message", len(msgs))
+	}
+	items, err := DecodeItems(msgs[1].Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Result != "Sunny" || items[1].Text != "It's sunny." {
+		t.Errorf("items = %+v, want stubbed tool result and text", items)
+	}
+
+	// Replays aren't recorded themselves.
+	if _, err := queries.GetLatestTurnRecording(ctx, conv.ID); err == nil {
+		t.Error("replayed turn was recorded")
+	}
+
+	// A recording that ends before the turn does diverges.
+	rec.Rounds = rec.Rounds[:1]
+	data, _ = json.Marshal(rec)
+	if err := queries.CreateTurnRecording(ctx, store.CreateTurnRecordingParams{RunID: "run-2", ConversationID: "conv-1", Recording: string(data), CreatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.ReplayTurn(ctx, "run-2", ""); !errors.Is(err, ErrReplayDiverged) {
+		t.Errorf("ReplayTurn of truncated recording: got %v, want ErrReplayDiverged", err)
+	}
+
+	if _, err := l.ReplayTurn(ctx, "run-3", ""); !errors.Is(err, ErrRecordingNotFound) {
+		t.Errorf("ReplayTurn of unknown run: got %v, want ErrRecordingNotFound", err)
+	}
+}
@@ -0,0 +1,101 @@
+package agentloop
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// Run kinds, describing what started a turn.
+const (
+	RunKindInteractive = "interactive"
+	RunKindTrigger     = "trigger"
+	RunKindWebhook     = "webhook"
+	RunKindSubagent    = "subagent"
+	// RunKindEval is for turns running eval cases. They don't run hooks, so
+	// evaluating doesn't change an agent's memory.
+	RunKindEval = "eval"
+	// RunKindAutonomous is for autonomous turns started otherwise, and the
+	// default for turns without a kind.
+	RunKindAutonomous = "autonomous"
+)
+
+// Run priorities. Runs waiting for a slot of their agent's concurrency limit
+// start in order of priority, then in the order they arrived, so a chat or
+// webhook isn't stuck behind a backlog of scheduled runs.
+const (
+	PriorityLow    = 10
+	PriorityNormal = 20
+	PriorityHigh   = 30
+	// MaxPriority is the highest priority runs can be given.
+	MaxPriority = 100
+)
+
+// DefaultPriority returns the priority of runs of a kind: high for
+// interactive turns, normal for webhooks and subagents, and low for triggers,
+// evals and other autonomous runs.
+func DefaultPriority(kind string) int {
+	switch kind {
+	case RunKindInteractive, RunKindReplay:
+		return PriorityHigh
+	case RunKindWebhook, RunKindSubagent:
+		return PriorityNormal
+	}
+	return PriorityLow
+}
+
+var (
+	// ErrCancelled is returned by RunTurn when the turn was cancelled by
+	// CancelRun. The output produced so far is stored as a cancelled message.
+	ErrCancelled = errors.New("run cancelled")
+	// ErrTimedOut is the cause to cancel a turn's context with when it runs
+	// longer than allowed, e.g. with context.WithTimeoutCause. RunTurn then
+	// stores the output produced so far as a timed out message, and returns
+	// ErrTimedOut.
+	ErrTimedOut = errors.New("run exceeded its maximum duration")
+	// ErrRunNotFound is returned by CancelRun for runs that aren't active.
+	ErrRunNotFound = errors.New("run not found")
+)
+
+// ActiveRun describes a turn in progress.
+type ActiveRun struct {
+	ID             string
+	ConversationID string
+	AgentID        string
+	AgentName      string
+	Kind           string
+	// Priority orders runs waiting for a slot, see DefaultPriority.
+	Priority int
+	// Greater than 0 for turns of agents called by other agents.
+	Depth     int
+	StartedAt time.Time
+}
+
+// ActiveRuns returns the turns in progress on this server, oldest first.
+func (l *Loop) ActiveRuns() []ActiveRun {
+	l.turns.mu.Lock()
+	runs := make([]ActiveRun, 0, len(l.turns.runs))
+	for _, r := range l.turns.runs {
+		runs = append(runs, r.info)
+	}
+	l.turns.mu.Unlock()
+
+	slices.SortFunc(runs, func(a, b ActiveRun) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return runs
+}
+
+// CancelRun cancels a turn in progress. The turn stores its output so far
+// and returns ErrCancelled; turns of agents it called are cancelled too.
+func (l *Loop) CancelRun(id string) error {
+	l.turns.mu.Lock()
+	defer l.turns.mu.Unlock()
+
+	r, ok := l.turns.runs[id]
+	if !ok {
+		return ErrRunNotFound
+	}
+	r.cancel(ErrCancelled)
+	return nil
+}
@@ -0,0 +1,187 @@
+package agentloop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/openrouter"
+)
+
+func TestActiveRuns(t *testing.T) {
+	l := &Loop{}
+	started := time.Now()
+	ctx1, end1, err := l.turns.begin(context.Background(), ActiveRun{ID: "run-1", Kind: RunKindTrigger, StartedAt: started})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer end1()
+	_, end2, err := l.turns.begin(context.Background(), ActiveRun{ID: "run-2", Kind: RunKindSubagent, StartedAt: started.Add(-time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs := l.ActiveRuns()
+	if len(runs) != 2 || runs[0].ID != "run-2" || runs[1].ID != "run-1" {
+		t.Fatalf("ActiveRuns = %v, want run-2 and run-1", runs)
+	}
+
+	if err := l.CancelRun("run-1"); err != nil {
+		t.Fatal(err)
+	}
+	<-ctx1.Done()
+	if cause := stopCause(ctx1); !errors.Is(cause, ErrCancelled) {
+		t.Errorf("stop cause = %v, want ErrCancelled", cause)
+	}
+
+	end2()
+	if runs := l.ActiveRuns(); len(runs) != 1 || runs[0].ID != "run-1" {
+		t.Errorf("ActiveRuns after end = %v, want run-1", runs)
+	}
+	if err := l.CancelRun("run-2"); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("CancelRun of ended run: got %v, want ErrRunNotFound", err)
+	}
+}
+
+func TestRunStatusOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, RunStatusCompleted},
+		{errors.New("stream error"), RunStatusFailed},
+		{fmt.Errorf("run turn: %w", ErrInterrupted), RunStatusInterrupted},
+		{ErrShuttingDown, RunStatusInterrupted},
+		{ErrCancelled, RunStatusCancelled},
+		{fmt.Errorf("run turn: %w", ErrTimedOut), RunStatusTimedOut},
+	}
+	for _, tt := range tests {
+		if got := RunStatusOf(tt.err); got != tt.want {
+			t.Errorf("RunStatusOf(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), time.Nanosecond, ErrTimedOut)
+	defer cancel()
+	<-ctx.Done()
+	if cause := stopCause(ctx); !errors.Is(cause, ErrTimedOut) {
+		t.Errorf("stop cause after deadline = %v, want ErrTimedOut", cause)
+	}
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want apierror.ErrorCode
+	}{
+		{nil, apierror.ErrorCode_ERROR_CODE_UNSPECIFIED},
+		{errors.New("boom"), apierror.ErrorCode_ERROR_CODE_INTERNAL},
+		{fmt.Errorf("%w: %w", errStream, errors.New("do request: EOF")), apierror.ErrorCode_ERROR_CODE_LLM_FAILED},
+		{fmt.Errorf("%w: %w", errStream, &openrouter.StatusError{StatusCode: http.StatusTooManyRequests}), apierror.ErrorCode_ERROR_CODE_LLM_RATE_LIMITED},
+		{fmt.Errorf("%w (%d)", ErrMaxIterations, 10), apierror.ErrorCode_ERROR_CODE_MAX_ITERATIONS},
+		{fmt.Errorf("%w: fetch_url was called 3 times", ErrLoopDetected), apierror.ErrorCode_ERROR_CODE_LOOP_DETECTED},
+		{ErrConversationBusy, apierror.ErrorCode_ERROR_CODE_CONVERSATION_BUSY},
+		{fmt.Errorf("run turn: %w", ErrTimedOut), apierror.ErrorCode_ERROR_CODE_RUN_TIMED_OUT},
+	}
+	for _, tt := range tests {
+		if got := ErrorCodeOf(tt.err); got != tt.want {
+			t.Errorf("ErrorCodeOf(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	l := &Loop{}
+	bg := context.Background()
+	_, end1, err := l.turns.beginLimited(bg, ActiveRun{ID: "run-1", AgentID: "agent-1"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Runs that don't count towards the limit, and runs of other agents,
+	// start right away.
+	_, end2, err := l.turns.begin(bg, ActiveRun{ID: "run-2", AgentID: "agent-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer end2()
+	_, end3, err := l.turns.beginLimited(bg, ActiveRun{ID: "run-3", AgentID: "agent-2"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer end3()
+
+	ctx, cancel := context.WithTimeoutCause(bg, 10*time.Millisecond, ErrTimedOut)
+	defer cancel()
+	if _, _, err := l.turns.beginLimited(ctx, ActiveRun{ID: "run-4", AgentID: "agent-1"}, 1); !errors.Is(err, ErrTimedOut) {
+		t.Errorf("beginLimited over the limit: got %v, want ErrTimedOut", err)
+	}
+
+	started := make(chan error)
+	go func() {
+		_, end, err := l.turns.beginLimited(bg, ActiveRun{ID: "run-5", AgentID: "agent-1"}, 1)
+		if err == nil {
+			end()
+		}
+		started <- err
+	}()
+	select {
+	case err := <-started:
+		t.Fatalf("queued run started before a slot was free: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	end1()
+	if err := <-started; err != nil {
+		t.Errorf("queued run: %v", err)
+	}
+}
+
+func TestConcurrencyPriority(t *testing.T) {
+	l := &Loop{}
+	bg := context.Background()
+	_, end, err := l.turns.beginLimited(bg, ActiveRun{ID: "running", AgentID: "agent-1"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Queue a scheduled run, a chat, and another scheduled run. The chat
+	// gets the slot first, then the scheduled runs in order of arrival.
+	var mu sync.Mutex
+	var order []string
+	done := make(chan error)
+	for i, kind := range []string{RunKindTrigger, RunKindInteractive, RunKindTrigger} {
+		id := fmt.Sprintf("%s-%d", kind, i)
+		go func() {
+			_, end, err := l.turns.beginLimited(bg, ActiveRun{ID: id, AgentID: "agent-1", Priority: DefaultPriority(kind)}, 1)
+			if err == nil {
+				mu.Lock()
+				order = append(order, id)
+				mu.Unlock()
+				end()
+			}
+			done <- err
+		}()
+		// Wait until the run is queued, so arrival order is deterministic.
+		for waiting := 0; waiting != i+1; {
+			time.Sleep(time.Millisecond)
+			l.turns.mu.Lock()
+			waiting = len(l.turns.waiting)
+			l.turns.mu.Unlock()
+		}
+	}
+
+	end()
+	for range 3 {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := strings.Join(order, ","), "interactive-1,trigger-0,trigger-2"; got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package agentloop
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// sealArgs encrypts the values of the sensitive properties of a tool call's
+// JSON arguments, for storing them. Each value is replaced by a string with
+// its encrypted JSON. Without a cipher, or if the arguments aren't an object,
+// they're returned as is.
+func sealArgs(cipher *encryption.Cipher, args string, sensitive []string) (string, error) {
+	if !cipher.Enabled() || len(sensitive) == 0 {
+		return args, nil
+	}
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(args), &props); err != nil {
+		return args, nil
+	}
+	sealed := false
+	for _, name := range sensitive {
+		value, ok := props[name]
+		if !ok {
+			continue
+		}
+		enc, err := cipher.Encrypt(string(value))
+		if err != nil {
+			return "", fmt.Errorf("encrypt argument %s: %w", name, err)
+		}
+		props[name], _ = json.Marshal(enc)
+		sealed = true
+	}
+	if !sealed {
+		return args, nil
+	}
+	b, err := json.Marshal(props)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// mapSealedArgs replaces the encrypted property values of stored tool call
+// arguments with the JSON fn returns for them. Arguments without encrypted
+// values are returned as is.
+func mapSealedArgs(args string, fn func(sealed string) (json.RawMessage, error)) (string, error) {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(args), &props); err != nil {
+		return args, nil
+	}
+	mapped := false
+	for name, value := range props {
+		var s string
+		if json.Unmarshal(value, &s) != nil || !encryption.IsEncrypted(s) {
+			continue
+		}
+		v, err := fn(s)
+		if err != nil {
+			return "", fmt.Errorf("argument %s: %w", name, err)
+		}
+		props[name] = v
+		mapped = true
+	}
+	if !mapped {
+		return args, nil
+	}
+	b, err := json.Marshal(props)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// openArgs decrypts the values of stored tool call arguments encrypted by
+// sealArgs.
+func openArgs(cipher *encryption.Cipher, args string) (string, error) {
+	return mapSealedArgs(args, func(sealed string) (json.RawMessage, error) {
+		value, err := cipher.Decrypt(sealed)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(value), nil
+	})
+}
+
+// RedactSealedArgs replaces the encrypted values of stored tool call
+// arguments with tool.RedactedValue, for showing them.
+func RedactSealedArgs(args string) string {
+	redacted, _ := json.Marshal(tool.RedactedValue)
+	s, _ := mapSealedArgs(args, func(string) (json.RawMessage, error) {
+		return redacted, nil
+	})
+	return s
+}
+
+// historyInputs converts a stored message into OpenRouter input items like
+// BuildHistoryInputs, with the sensitive tool arguments decrypted.
+func (l *Loop) historyInputs(msg store.Message) ([]openrouter.Input, error) {
+	inputs, err := BuildHistoryInputs(msg)
+	if err != nil {
+		return nil, err
+	}
+	for i, in := range inputs {
+		if in.Type != "function_call" {
+			continue
+		}
+		if inputs[i].Arguments, err = openArgs(l.Cipher, in.Arguments); err != nil {
+			return nil, fmt.Errorf("decrypt arguments of tool call in message %s: %w", msg.ID, err)
+		}
+	}
+	return inputs, nil
+}
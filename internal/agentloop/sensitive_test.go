@@ -0,0 +1,72 @@
+package agentloop
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestSealArgs(t *testing.T) {
+	cipher, err := encryption.New(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := `{"key":"github","value":{"token":"ghp_secret"}}`
+
+	sealed, err := sealArgs(cipher, args, []string{"value", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sealed, "ghp_secret") || !strings.Contains(sealed, `"key":"github"`) {
+		t.Errorf("sealArgs = %s, want value encrypted and key as is", sealed)
+	}
+	if got := RedactSealedArgs(sealed); got != `{"key":"github","value":"[REDACTED]"}` {
+		t.Errorf("RedactSealedArgs = %s", got)
+	}
+
+	// The history sent to the LLM has the original arguments.
+	items, err := EncodeItems([]StoredItem{{Type: ItemTypeToolExecution, Name: "set_context", Input: sealed, Result: "ok", CallID: "call_1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &Loop{Cipher: cipher}
+	inputs, err := l.historyInputs(store.Message{ID: "msg-1", Role: "assistant", Items: items})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 || inputs[0].Type != "function_call" || inputs[0].Arguments != args {
+		t.Errorf("history inputs = %+v, want function_call with %s", inputs, args)
+	}
+	if _, err := (&Loop{}).historyInputs(store.Message{ID: "msg-1", Role: "assistant", Items: items}); err == nil {
+		t.Error("history without cipher: want error")
+	}
+
+	// Without a cipher, flagged or not, and for arguments that aren't an
+	// object, the arguments are stored and read as plaintext.
+	tests := []struct {
+		name      string
+		cipher    *encryption.Cipher
+		args      string
+		sensitive []string
+	}{
+		{name: "no cipher", args: args, sensitive: []string{"value"}},
+		{name: "nothing sensitive", cipher: cipher, args: args},
+		{name: "not an object", cipher: cipher, args: `"ghp_secret"`, sensitive: []string{"value"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sealArgs(tt.cipher, tt.args, tt.sensitive)
+			if err != nil || got != tt.args {
+				t.Errorf("sealArgs = %s (err %v), want %s", got, err, tt.args)
+			}
+			if opened, err := openArgs(tt.cipher, got); err != nil || opened != tt.args {
+				t.Errorf("openArgs = %s (err %v), want %s", opened, err, tt.args)
+			}
+			if redacted := RedactSealedArgs(got); redacted != tt.args {
+				t.Errorf("RedactSealedArgs = %s, want %s", redacted, tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package agentloop
+
+import (
+	"context"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+)
+
+type subagentKey struct{}
+
+// subagent identifies a subagent turn whose output is forwarded to the
+// conversation of the turn that called it.
+type subagent struct {
+	parentConversationID string
+	runID                string
+	agentID              string
+	agentName            string
+}
+
+// withSubagent sets the subagent turn of ctx. Turns without a parent reset
+// it, so their output isn't forwarded to the parent of the turn that called
+// them.
+func withSubagent(ctx context.Context, s subagent) context.Context {
+	return context.WithValue(ctx, subagentKey{}, s)
+}
+
+// publishOutput publishes live output of a turn, i.e. a text delta or tool
+// result. Output of subagent turns is also forwarded to the parent
+// conversation, as transient SubagentUpdate events.
+func (l *Loop) publishOutput(ctx context.Context, conversationID string, payload pubsub.Payload) {
+	l.Broker.Publish(conversationID, payload)
+
+	parentID, update := newSubagentUpdate(ctx, conversationID)
+	if update == nil {
+		return
+	}
+	switch p := payload.(type) {
+	case *pubsub.Event_TextDelta:
+		update.Update = &pubsub.SubagentUpdate_TextDelta{TextDelta: p.TextDelta}
+	case *pubsub.Event_ToolResult:
+		update.Update = &pubsub.SubagentUpdate_ToolResult{ToolResult: p.ToolResult}
+	default:
+		return
+	}
+	l.Broker.PublishTransient(parentID, &pubsub.Event_SubagentUpdate{SubagentUpdate: update})
+}
+
+// publishSubagentDone tells the parent conversation of a subagent turn that
+// the turn has ended.
+func (l *Loop) publishSubagentDone(ctx context.Context, conversationID string) {
+	if parentID, update := newSubagentUpdate(ctx, conversationID); update != nil {
+		update.Done = true
+		l.Broker.PublishTransient(parentID, &pubsub.Event_SubagentUpdate{SubagentUpdate: update})
+	}
+}
+
+// newSubagentUpdate returns an update without output for a subagent turn,
+// and the conversation to forward it to, or nil if ctx isn't of a subagent
+// turn.
+func newSubagentUpdate(ctx context.Context, conversationID string) (string, *pubsub.SubagentUpdate) {
+	s, _ := ctx.Value(subagentKey{}).(subagent)
+	if s.parentConversationID == "" {
+		return "", nil
+	}
+	return s.parentConversationID, &pubsub.SubagentUpdate{
+		RunId:          s.runID,
+		ConversationId: conversationID,
+		AgentId:        s.agentID,
+		AgentName:      s.agentName,
+	}
+}
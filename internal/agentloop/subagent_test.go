@@ -0,0 +1,47 @@
+package agentloop
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+)
+
+func TestPublishOutput(t *testing.T) {
+	broker := pubsub.New(nil, slog.Default())
+	parent := broker.Subscribe("parent")
+	defer broker.Unsubscribe(parent)
+	child := broker.Subscribe("child")
+	defer broker.Unsubscribe(child)
+	l := &Loop{Broker: broker}
+
+	ctx := withSubagent(context.Background(), subagent{parentConversationID: "parent", runID: "run-1", agentName: "Researcher"})
+	l.publishOutput(ctx, "child", &pubsub.Event_TextDelta{TextDelta: &pubsub.TextDelta{Content: "Hi"}})
+	l.publishSubagentDone(ctx, "child")
+
+	if e := <-child.C; e.GetTextDelta().GetContent() != "Hi" {
+		t.Errorf("child event = %v, want text delta", e)
+	}
+	e := <-parent.C
+	update := e.GetSubagentUpdate()
+	if update.GetRunId() != "run-1" || update.GetConversationId() != "child" || update.GetTextDelta().GetContent() != "Hi" {
+		t.Errorf("parent event = %v, want forwarded text delta", e)
+	}
+	if e.Sequence != 0 {
+		t.Errorf("forwarded event has sequence %d, want 0", e.Sequence)
+	}
+	if e := <-parent.C; !e.GetSubagentUpdate().GetDone() {
+		t.Errorf("parent event = %v, want done update", e)
+	}
+
+	// Turns without a parent don't forward, even when called by a subagent.
+	ctx = withSubagent(ctx, subagent{})
+	l.publishOutput(ctx, "child", &pubsub.Event_TextDelta{TextDelta: &pubsub.TextDelta{Content: "Hi"}})
+	<-child.C
+	select {
+	case e := <-parent.C:
+		t.Errorf("unexpected parent event %v", e)
+	default:
+	}
+}
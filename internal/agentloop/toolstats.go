@@ -0,0 +1,81 @@
+package agentloop
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ToolStats is how an agent used a tool in a set of runs.
+type ToolStats struct {
+	Name string
+	// Enabled is whether the tool is in the agent's enabled tools.
+	Enabled          bool
+	Calls            int64
+	Errors           int64
+	TotalDurationMS  int64
+	TotalResultBytes int64
+}
+
+// ErrorRate is the fraction of calls that failed.
+func (s ToolStats) ErrorRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Calls)
+}
+
+// AvgDurationMS is the average duration of a call.
+func (s ToolStats) AvgDurationMS() int64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalDurationMS / s.Calls
+}
+
+// AvgResultBytes is the average size of a call's result.
+func (s ToolStats) AvgResultBytes() int64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalResultBytes / s.Calls
+}
+
+// AggregateToolStats aggregates the tool calls of traces per tool, most
+// called first. Enabled tools that weren't called are included at the end,
+// as candidates for removal.
+func AggregateToolStats(traces []Trace, enabledTools []string) []ToolStats {
+	byName := make(map[string]*ToolStats)
+	get := func(name string) *ToolStats {
+		s, ok := byName[name]
+		if !ok {
+			s = &ToolStats{Name: name}
+			byName[name] = s
+		}
+		return s
+	}
+	for _, name := range enabledTools {
+		get(name).Enabled = true
+	}
+	for _, t := range traces {
+		for _, r := range t.Rounds {
+			for _, c := range r.ToolCalls {
+				s := get(c.Name)
+				s.Calls++
+				if c.Error {
+					s.Errors++
+				}
+				s.TotalDurationMS += c.DurationMS
+				s.TotalResultBytes += int64(c.ResultBytes)
+			}
+		}
+	}
+
+	stats := make([]ToolStats, 0, len(byName))
+	for _, s := range byName {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b ToolStats) int {
+		return cmp.Or(cmp.Compare(b.Calls, a.Calls), cmp.Compare(a.Name, b.Name))
+	})
+	return stats
+}
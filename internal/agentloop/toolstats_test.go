@@ -0,0 +1,34 @@
+package agentloop
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAggregateToolStats(t *testing.T) {
+	traces := []Trace{
+		{Rounds: []TraceRound{
+			{ToolCalls: []TraceToolCall{
+				{Name: "fetch", DurationMS: 100, ResultBytes: 1000},
+				{Name: "bash", DurationMS: 50, ResultBytes: 10, Error: true},
+			}},
+			{ToolCalls: []TraceToolCall{{Name: "fetch", DurationMS: 300, ResultBytes: 3000, Error: true}}},
+		}},
+		{Rounds: []TraceRound{{ToolCalls: []TraceToolCall{{Name: "fetch", DurationMS: 200, ResultBytes: 2000}}}}},
+	}
+	got := AggregateToolStats(traces, []string{"fetch", "memory_view", "bash"})
+	want := []ToolStats{
+		{Name: "fetch", Enabled: true, Calls: 3, Errors: 1, TotalDurationMS: 600, TotalResultBytes: 6000},
+		{Name: "bash", Enabled: true, Calls: 1, Errors: 1, TotalDurationMS: 50, TotalResultBytes: 10},
+		{Name: "memory_view", Enabled: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("AggregateToolStats = %+v, want %+v", got, want)
+	}
+	if got[0].AvgDurationMS() != 200 || got[0].AvgResultBytes() != 2000 || got[0].ErrorRate() != 1.0/3 {
+		t.Errorf("fetch averages = %d ms, %d bytes, error rate %v", got[0].AvgDurationMS(), got[0].AvgResultBytes(), got[0].ErrorRate())
+	}
+	if got[2].ErrorRate() != 0 || got[2].AvgDurationMS() != 0 {
+		t.Errorf("unused tool averages = %v, %d ms; want 0", got[2].ErrorRate(), got[2].AvgDurationMS())
+	}
+}
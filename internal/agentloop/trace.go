@@ -0,0 +1,179 @@
+package agentloop
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// Trace is the execution trace of a run: where its time and tokens went.
+type Trace struct {
+	// QueuedMS is how long the run waited for a slot of its agent's
+	// concurrency limit.
+	QueuedMS int64        `json:"queued_ms"`
+	Rounds   []TraceRound `json:"rounds"`
+}
+
+// TraceRound is an LLM round-trip of a run, and the tools it called.
+type TraceRound struct {
+	StartedAt time.Time `json:"started_at"`
+	// RequestBytes is the size of the request sent to the LLM, which grows
+	// with the tool results of earlier round-trips.
+	RequestBytes int `json:"request_bytes"`
+	// FirstEventMS is how long the LLM took to start streaming, and
+	// LatencyMS how long until the response was complete.
+	FirstEventMS int64 `json:"first_event_ms"`
+	LatencyMS    int64 `json:"latency_ms"`
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+	// CostUSD is the cost reported by the LLM provider, if any.
+	CostUSD   *float64        `json:"cost_usd,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	ToolCalls []TraceToolCall `json:"tool_calls,omitempty"`
+}
+
+// TraceToolCall is a tool call of a round-trip. Calls of a round-trip run
+// concurrently, so their durations overlap.
+type TraceToolCall struct {
+	Name        string `json:"name"`
+	CallID      string `json:"call_id"`
+	DurationMS  int64  `json:"duration_ms"`
+	ResultBytes int    `json:"result_bytes"`
+	Error       bool   `json:"error,omitempty"`
+}
+
+type tracerKey struct{}
+
+// tracer records the trace of a turn. Its methods do nothing on a nil
+// tracer, which turns that aren't traced have.
+type tracer struct {
+	trace      Trace
+	roundStart time.Time
+	toolsStart time.Time
+}
+
+func withTracer(ctx context.Context, t *tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+func tracerFrom(ctx context.Context) *tracer {
+	t, _ := ctx.Value(tracerKey{}).(*tracer)
+	return t
+}
+
+func (t *tracer) queued(d time.Duration) {
+	if t != nil {
+		t.trace.QueuedMS = d.Milliseconds()
+	}
+}
+
+func (t *tracer) round() *TraceRound {
+	return &t.trace.Rounds[len(t.trace.Rounds)-1]
+}
+
+func (t *tracer) startRound(req *openrouter.ResponseRequest) {
+	if t == nil {
+		return
+	}
+	// The request is encoded again by the client; the size is worth the
+	// cost.
+	data, _ := json.Marshal(req)
+	t.roundStart = time.Now()
+	t.trace.Rounds = append(t.trace.Rounds, TraceRound{StartedAt: t.roundStart.UTC(), RequestBytes: len(data)})
+}
+
+func (t *tracer) firstEvent() {
+	if t != nil && t.round().FirstEventMS == 0 {
+		t.round().FirstEventMS = time.Since(t.roundStart).Milliseconds()
+	}
+}
+
+func (t *tracer) endRound(resp *openrouter.Response, err error) {
+	if t == nil {
+		return
+	}
+	round := t.round()
+	round.LatencyMS = time.Since(t.roundStart).Milliseconds()
+	if err != nil {
+		round.Error = err.Error()
+	}
+	if resp != nil && resp.Usage != nil {
+		round.InputTokens = resp.Usage.InputTokens
+		round.OutputTokens = resp.Usage.OutputTokens
+		round.CostUSD = resp.Usage.Cost
+	}
+}
+
+// cost returns the cost of the run's round-trips as reported by the LLM
+// provider. It reports false if a round-trip that used tokens has no
+// reported cost.
+func (t Trace) cost() (float64, bool) {
+	var cost float64
+	for _, r := range t.Rounds {
+		if r.CostUSD == nil {
+			if r.InputTokens > 0 || r.OutputTokens > 0 {
+				return 0, false
+			}
+			continue
+		}
+		cost += *r.CostUSD
+	}
+	return cost, true
+}
+
+func (t *tracer) startTools() {
+	if t != nil {
+		t.toolsStart = time.Now()
+	}
+}
+
+func (t *tracer) addToolCall(r tool.ToolResult) {
+	if t == nil {
+		return
+	}
+	round := t.round()
+	round.ToolCalls = append(round.ToolCalls, TraceToolCall{
+		Name:        tool.DecodeToolName(r.Name),
+		CallID:      r.CallID,
+		DurationMS:  time.Since(t.toolsStart).Milliseconds(),
+		ResultBytes: len(r.Output),
+		Error:       strings.HasPrefix(r.Output, "Error"),
+	})
+}
+
+// saveTrace stores the trace of a finished run.
+func (l *Loop) saveTrace(ctx context.Context, t *tracer, turn TurnResult) {
+	data, err := json.Marshal(t.trace)
+	if err == nil {
+		var cost sql.NullFloat64
+		cost.Float64, cost.Valid = t.trace.cost()
+		var errMsg string
+		if turn.Err != nil {
+			errMsg = turn.Err.Error()
+		}
+		err = l.Queries.CreateRunTrace(context.WithoutCancel(ctx), store.CreateRunTraceParams{
+			RunID:          turn.RunID,
+			AgentID:        turn.Agent.ID,
+			ConversationID: turn.Conv.ID,
+			Kind:           turn.Kind,
+			Model:          turn.Model,
+			Status:         turn.Status,
+			Error:          errMsg,
+			InputTokens:    turn.InputTokens,
+			OutputTokens:   turn.OutputTokens,
+			CostUsd:        cost,
+			Trace:          string(data),
+			StartedAt:      turn.StartedAt.UTC().Format(time.RFC3339),
+			FinishedAt:     turn.FinishedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if err != nil {
+		l.logger().Error("failed to store trace", "run_id", turn.RunID, "error", err)
+	}
+}
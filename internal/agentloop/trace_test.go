@@ -0,0 +1,86 @@
+package agentloop
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestRunTrace(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	agent, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", Title: "Weather", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(fixture, []byte(`{"rules": [{"steps": [
+		{"tool_calls": [{"name": "fetch", "arguments": {"url": "https://example.com"}}]},
+		{"text": "It's sunny."}
+	]}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := openrouter.LoadMockFixture(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &Loop{
+		Queries:      queries,
+		ORClient:     openrouter.NewMockClient(f),
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       pubsub.New(nil, slog.Default()),
+		DefaultModel: "mock",
+	}
+
+	history, _, err := l.StartTurn(ctx, conv.ID, "What's the weather?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.RunTurn(ctx, TurnOpts{Conv: conv, Agent: agent, UserContent: "What's the weather?", History: history, RunID: "run-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := queries.GetLatestRunTrace(ctx, conv.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.RunID != "run-1" || row.Status != RunStatusCompleted || row.Model != "mock" || row.InputTokens == 0 {
+		t.Errorf("trace = %+v, want completed run with usage", row)
+	}
+	var trace Trace
+	if err := json.Unmarshal([]byte(row.Trace), &trace); err != nil {
+		t.Fatal(err)
+	}
+	if len(trace.Rounds) != 2 {
+		t.Fatalf("got %d rounds, want 2", len(trace.Rounds))
+	}
+	first, second := trace.Rounds[0], trace.Rounds[1]
+	if len(first.ToolCalls) != 1 || first.ToolCalls[0].Name != "fetch" || !first.ToolCalls[0].Error {
+		t.Errorf("first round tool calls = %+v, want failed fetch call", first.ToolCalls)
+	}
+	// The second request includes the tool call and its result.
+	if first.RequestBytes == 0 || second.RequestBytes <= first.RequestBytes || second.InputTokens == 0 {
+		t.Errorf("rounds = %+v, want growing requests with usage", trace.Rounds)
+	}
+}
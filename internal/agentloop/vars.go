@@ -0,0 +1,21 @@
+package agentloop
+
+import (
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// runVarsNote returns the instructions listing the variables of a run, if
+// any.
+func runVarsNote(vars map[string]string) string {
+	if len(vars) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\n## Run variables\nThis run was started with these variables, which are also set as environment variables of bash commands:\n")
+	for _, name := range tool.RunVarNames(vars) {
+		sb.WriteString("- " + name + ": " + vars[name] + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
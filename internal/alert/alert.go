@@ -0,0 +1,233 @@
+// Package alert monitors spend and failures, and sends a notification when
+// a threshold is breached: the spend of the last 24 hours, consecutive
+// failed runs of a trigger, or the error rate of runs.
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/scheduler"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/usage"
+)
+
+const (
+	// DefaultCheckInterval is how often thresholds are checked.
+	DefaultCheckInterval = time.Minute
+	// DefaultErrorRateMinRuns is the number of runs below which the error
+	// rate isn't checked, so a single failure doesn't raise an alert.
+	DefaultErrorRateMinRuns = 10
+)
+
+// Thresholds are the limits that raise an alert when reached. Zero values
+// disable a check.
+type Thresholds struct {
+	// DailySpendUSD is the estimated cost of the runs of the last 24 hours.
+	DailySpendUSD float64
+	// ConsecutiveFailures is the number of latest runs of an enabled
+	// trigger that failed or timed out.
+	ConsecutiveFailures int
+	// ErrorRate is the fraction of the runs of the last 24 hours that failed
+	// or timed out, checked once there are ErrorRateMinRuns runs.
+	ErrorRate        float64
+	ErrorRateMinRuns int
+}
+
+// Enabled reports whether any check is enabled.
+func (t Thresholds) Enabled() bool {
+	return t.DailySpendUSD > 0 || t.ConsecutiveFailures > 0 || t.ErrorRate > 0
+}
+
+// Monitor checks the thresholds and notifies a channel when one is breached.
+// An alert is sent once, and again only after its condition has cleared.
+type Monitor struct {
+	queries    *store.Queries
+	reporter   *usage.Reporter
+	channels   tool.NotificationChannelLister
+	dispatcher tool.NotificationDispatcher
+	channel    string
+	thresholds Thresholds
+	logger     *slog.Logger
+
+	// Interval is how often Check evaluates the thresholds; more frequent
+	// calls are skipped.
+	Interval time.Duration
+
+	mu        sync.Mutex
+	lastCheck time.Time
+	// firing holds the keys of the alerts whose condition still holds.
+	firing map[string]bool
+}
+
+// NewMonitor returns a monitor sending alerts to the notification channel
+// with the given name.
+func NewMonitor(queries *store.Queries, reporter *usage.Reporter, channels tool.NotificationChannelLister, dispatcher tool.NotificationDispatcher, channel string, thresholds Thresholds, logger *slog.Logger) *Monitor {
+	if thresholds.ErrorRateMinRuns <= 0 {
+		thresholds.ErrorRateMinRuns = DefaultErrorRateMinRuns
+	}
+	return &Monitor{
+		queries:    queries,
+		reporter:   reporter,
+		channels:   channels,
+		dispatcher: dispatcher,
+		channel:    channel,
+		thresholds: thresholds,
+		logger:     logger,
+		Interval:   DefaultCheckInterval,
+		firing:     make(map[string]bool),
+	}
+}
+
+// alert is a breached threshold. Its key identifies the condition, so it's
+// only sent once while the condition holds.
+type alert struct {
+	key   string
+	title string
+	body  string
+	url   string
+}
+
+// Check evaluates the thresholds and sends alerts for newly breached ones.
+// It has the signature of a scheduler job.
+func (m *Monitor) Check(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.lastCheck) < m.Interval {
+		return nil
+	}
+	m.lastCheck = now
+
+	alerts, err := m.evaluate(ctx)
+	if err != nil {
+		return err
+	}
+
+	breached := make(map[string]bool, len(alerts))
+	var errs []error
+	for _, a := range alerts {
+		breached[a.key] = true
+		if m.firing[a.key] {
+			continue
+		}
+		m.logger.Warn("alert threshold breached", "alert", a.key, "title", a.title)
+		if err := m.notify(ctx, a); err != nil {
+			errs = append(errs, fmt.Errorf("send alert %s: %w", a.key, err))
+			// Retried on the next check.
+			continue
+		}
+		m.firing[a.key] = true
+	}
+	for key := range m.firing {
+		if !breached[key] {
+			m.logger.Info("alert condition cleared", "alert", key)
+			delete(m.firing, key)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// evaluate returns the alerts for the thresholds that are currently
+// breached.
+func (m *Monitor) evaluate(ctx context.Context) ([]alert, error) {
+	var alerts []alert
+	t := m.thresholds
+
+	if t.DailySpendUSD > 0 || t.ErrorRate > 0 {
+		report, err := m.reporter.Report(ctx, 24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		total := report.Total
+		if t.DailySpendUSD > 0 && total.CostUSD >= t.DailySpendUSD {
+			alerts = append(alerts, alert{
+				key:   "daily_spend",
+				title: "Daily spend threshold reached",
+				body:  fmt.Sprintf("Runs of the last 24 hours cost $%.2f, the threshold is $%.2f.", total.CostUSD, t.DailySpendUSD),
+				url:   "/activity",
+			})
+		}
+		if t.ErrorRate > 0 && total.Runs >= int64(t.ErrorRateMinRuns) {
+			if rate := float64(total.FailedRuns) / float64(total.Runs); rate >= t.ErrorRate {
+				alerts = append(alerts, alert{
+					key:   "error_rate",
+					title: "Error rate threshold reached",
+					body:  fmt.Sprintf("%d of %d runs of the last 24 hours failed (%.0f%%), the threshold is %.0f%%.", total.FailedRuns, total.Runs, rate*100, t.ErrorRate*100),
+					url:   "/activity",
+				})
+			}
+		}
+	}
+
+	if t.ConsecutiveFailures > 0 {
+		triggers, err := m.queries.ListAllTriggers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list triggers: %w", err)
+		}
+		for _, trig := range triggers {
+			if trig.Enabled == 0 {
+				continue
+			}
+			runs, err := m.queries.ListTriggerRuns(ctx, store.ListTriggerRunsParams{
+				TriggerID: trig.ID,
+				Limit:     int64(t.ConsecutiveFailures),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("list runs of trigger %s: %w", trig.ID, err)
+			}
+			if len(runs) < t.ConsecutiveFailures || !allFailed(runs) {
+				continue
+			}
+			body := fmt.Sprintf("The last %d runs of trigger %q failed.", len(runs), trig.Name)
+			if msg := runs[0].ErrorMessage.String; msg != "" {
+				body += " Last error: " + msg
+			}
+			alerts = append(alerts, alert{
+				key:   "trigger_failures:" + trig.ID,
+				title: fmt.Sprintf("Trigger %s keeps failing", trig.Name),
+				body:  body,
+				url:   "/triggers/" + trig.ID,
+			})
+		}
+	}
+
+	return alerts, nil
+}
+
+func allFailed(runs []store.TriggerRun) bool {
+	for _, r := range runs {
+		if r.Status != scheduler.RunStatusFailed && r.Status != scheduler.RunStatusTimedOut {
+			return false
+		}
+	}
+	return true
+}
+
+// notify sends an alert to the monitor's channel.
+func (m *Monitor) notify(ctx context.Context, a alert) error {
+	channel, err := m.channels.GetNotificationChannelByName(ctx, m.channel)
+	if err != nil {
+		return fmt.Errorf("get channel %q: %w", m.channel, err)
+	}
+	// Fields for the default schemas of web push (title, body, url) and SMS
+	// (text) channels.
+	payload, err := json.Marshal(map[string]string{
+		"title": a.title,
+		"body":  a.body,
+		"text":  a.title + ": " + a.body,
+		"url":   a.url,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	_, err = m.dispatcher.DispatchNotification(ctx, *channel, payload)
+	return err
+}
@@ -0,0 +1,102 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/usage"
+)
+
+type fakeChannels struct{ tool.NotificationChannelLister }
+
+func (fakeChannels) GetNotificationChannelByName(ctx context.Context, name string) (*tool.NotificationChannel, error) {
+	return &tool.NotificationChannel{ID: "chan-1", Name: name}, nil
+}
+
+type fakeDispatcher struct{ payloads []map[string]string }
+
+func (d *fakeDispatcher) DispatchNotification(ctx context.Context, channel tool.NotificationChannel, payload json.RawMessage) (string, error) {
+	var p map[string]string
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return "", err
+	}
+	d.payloads = append(d.payloads, p)
+	return "sent", nil
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC()
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{
+		ID: "agent-1", Name: "Ops", EnabledTools: "[]", EnabledNotificationChannels: "[]",
+		EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]",
+		CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{
+		ID: "trigger-1", AgentID: "agent-1", Name: "daily", Prompt: "Report", Enabled: 1,
+		CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	run := func(n int, status string) {
+		t.Helper()
+		if _, err := queries.CreateTriggerRun(ctx, store.CreateTriggerRunParams{
+			ID: fmt.Sprintf("run-%d", n), TriggerID: "trigger-1", Status: status,
+			ErrorMessage: store.NewNullString("boom"),
+			StartedAt:    now.Add(time.Duration(n) * time.Minute).Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dispatcher := &fakeDispatcher{}
+	m := NewMonitor(queries, usage.NewReporter(queries, nil), fakeChannels{}, dispatcher, "ops", Thresholds{ConsecutiveFailures: 2}, slog.Default())
+	m.Interval = 0
+	check := func() {
+		t.Helper()
+		if err := m.Check(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run(1, "completed")
+	run(2, "failed")
+	check()
+	if len(dispatcher.payloads) != 0 {
+		t.Fatalf("alerts after 1 failure = %v, want none", dispatcher.payloads)
+	}
+
+	run(3, "timed_out")
+	check()
+	check()
+	if len(dispatcher.payloads) != 1 || !strings.Contains(dispatcher.payloads[0]["title"], "daily") || dispatcher.payloads[0]["url"] != "/triggers/trigger-1" {
+		t.Fatalf("alerts after 2 failures = %v, want 1 for the trigger", dispatcher.payloads)
+	}
+
+	// The alert is sent again once the trigger recovered and fails again.
+	run(4, "completed")
+	check()
+	run(5, "failed")
+	run(6, "failed")
+	check()
+	if len(dispatcher.payloads) != 2 {
+		t.Errorf("alerts after recovery and 2 more failures = %d, want 2", len(dispatcher.payloads))
+	}
+}
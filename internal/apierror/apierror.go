@@ -0,0 +1,163 @@
+// Package apierror defines the machine-readable error codes of the API.
+// Errors returned by services carry an ErrorDetail with their code, so
+// clients can branch on it instead of parsing error messages.
+package apierror
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+)
+
+// connectCodes maps error codes to the Connect code of their errors.
+var connectCodes = map[ErrorCode]connect.Code{
+	ErrorCode_ERROR_CODE_INVALID_ARGUMENT:       connect.CodeInvalidArgument,
+	ErrorCode_ERROR_CODE_NOT_FOUND:              connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_ALREADY_EXISTS:         connect.CodeAlreadyExists,
+	ErrorCode_ERROR_CODE_PERMISSION_DENIED:      connect.CodePermissionDenied,
+	ErrorCode_ERROR_CODE_UNAUTHENTICATED:        connect.CodeUnauthenticated,
+	ErrorCode_ERROR_CODE_FAILED_PRECONDITION:    connect.CodeFailedPrecondition,
+	ErrorCode_ERROR_CODE_RESOURCE_EXHAUSTED:     connect.CodeResourceExhausted,
+	ErrorCode_ERROR_CODE_UNAVAILABLE:            connect.CodeUnavailable,
+	ErrorCode_ERROR_CODE_INTERNAL:               connect.CodeInternal,
+	ErrorCode_ERROR_CODE_AGENT_NOT_FOUND:        connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND: connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND:      connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_MESSAGE_NOT_FOUND:      connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_RUN_NOT_FOUND:          connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_APPROVAL_NOT_FOUND:     connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_WEBHOOK_NOT_FOUND:      connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_TRIGGER_RUN_NOT_FOUND:  connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_VERSION_CONFLICT:       connect.CodeAborted,
+	ErrorCode_ERROR_CODE_MAINTENANCE_MODE:       connect.CodeUnavailable,
+	ErrorCode_ERROR_CODE_SHUTTING_DOWN:          connect.CodeUnavailable,
+	ErrorCode_ERROR_CODE_CONVERSATION_BUSY:      connect.CodeFailedPrecondition,
+	ErrorCode_ERROR_CODE_MAX_ITERATIONS:         connect.CodeAborted,
+	ErrorCode_ERROR_CODE_LOOP_DETECTED:          connect.CodeAborted,
+	ErrorCode_ERROR_CODE_RUN_CANCELLED:          connect.CodeCanceled,
+	ErrorCode_ERROR_CODE_RUN_TIMED_OUT:          connect.CodeDeadlineExceeded,
+	ErrorCode_ERROR_CODE_INTERRUPTED:            connect.CodeAborted,
+	ErrorCode_ERROR_CODE_LLM_FAILED:             connect.CodeUnavailable,
+	ErrorCode_ERROR_CODE_LLM_RATE_LIMITED:       connect.CodeResourceExhausted,
+	ErrorCode_ERROR_CODE_TOOL_NOT_FOUND:         connect.CodeNotFound,
+	ErrorCode_ERROR_CODE_TOOL_INVALID_ARGUMENTS: connect.CodeInvalidArgument,
+	ErrorCode_ERROR_CODE_TOOL_FAILED:            connect.CodeInternal,
+	ErrorCode_ERROR_CODE_TOOL_CALL_DENIED:       connect.CodePermissionDenied,
+}
+
+// genericCodes maps Connect codes to the error code of errors without a
+// more specific one.
+var genericCodes = map[connect.Code]ErrorCode{
+	connect.CodeInvalidArgument:    ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+	connect.CodeOutOfRange:         ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+	connect.CodeNotFound:           ErrorCode_ERROR_CODE_NOT_FOUND,
+	connect.CodeAlreadyExists:      ErrorCode_ERROR_CODE_ALREADY_EXISTS,
+	connect.CodePermissionDenied:   ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+	connect.CodeUnauthenticated:    ErrorCode_ERROR_CODE_UNAUTHENTICATED,
+	connect.CodeFailedPrecondition: ErrorCode_ERROR_CODE_FAILED_PRECONDITION,
+	connect.CodeAborted:            ErrorCode_ERROR_CODE_FAILED_PRECONDITION,
+	connect.CodeResourceExhausted:  ErrorCode_ERROR_CODE_RESOURCE_EXHAUSTED,
+	connect.CodeUnavailable:        ErrorCode_ERROR_CODE_UNAVAILABLE,
+}
+
+// New returns a Connect error with the Connect code of the error code, and
+// an ErrorDetail with the error code.
+func New(code ErrorCode, err error) *connect.Error {
+	connectCode, ok := connectCodes[code]
+	if !ok {
+		connectCode = connect.CodeUnknown
+	}
+	connectErr := connect.NewError(connectCode, err)
+	addDetail(connectErr, code)
+	return connectErr
+}
+
+// CodeOf returns the error code of an error returned by New, or by an RPC.
+// It's ERROR_CODE_UNSPECIFIED for other errors.
+func CodeOf(err error) ErrorCode {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		return ErrorCode_ERROR_CODE_UNSPECIFIED
+	}
+	for _, d := range connectErr.Details() {
+		msg, err := d.Value()
+		if err != nil {
+			continue
+		}
+		if detail, ok := msg.(*ErrorDetail); ok {
+			return detail.Code
+		}
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+// GenericCode returns the error code of errors with a Connect code that
+// have no more specific one.
+func GenericCode(code connect.Code) ErrorCode {
+	if c, ok := genericCodes[code]; ok {
+		return c
+	}
+	return ErrorCode_ERROR_CODE_INTERNAL
+}
+
+func addDetail(connectErr *connect.Error, code ErrorCode) {
+	detail, err := connect.NewErrorDetail(&ErrorDetail{Code: code})
+	if err != nil {
+		// Only fails if the message can't be marshalled.
+		return
+	}
+	connectErr.AddDetail(detail)
+}
+
+// NewInterceptor returns an interceptor that adds an ErrorDetail with the
+// generic code of their Connect code to errors returned without one, so
+// every error of the API has a code. It must be the outermost interceptor,
+// to see the errors of the others.
+func NewInterceptor() connect.Interceptor {
+	return interceptor{}
+}
+
+type interceptor struct{}
+
+func (interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		if err != nil {
+			return nil, withCode(err)
+		}
+		return res, nil
+	}
+}
+
+func (interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := next(ctx, conn); err != nil {
+			return withCode(err)
+		}
+		return nil
+	}
+}
+
+// withCode returns err as a Connect error with an ErrorDetail. Cancelled
+// requests and exceeded deadlines are the client's doing, so they're left
+// alone.
+func withCode(err error) error {
+	if CodeOf(err) != ErrorCode_ERROR_CODE_UNSPECIFIED || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		connectErr = connect.NewError(connect.CodeUnknown, err)
+	}
+	switch connectErr.Code() {
+	case connect.CodeCanceled, connect.CodeDeadlineExceeded:
+		return err
+	}
+	addDetail(connectErr, GenericCode(connectErr.Code()))
+	return connectErr
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: apierror/apierror.proto
+
+package apierror
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ErrorCode is a machine-readable error code, so clients can branch on
+// errors without parsing messages. Codes are stable; new errors get new
+// codes.
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED ErrorCode = 0
+	// Generic codes of errors without a more specific one. They match the
+	// Connect code of the error.
+	ErrorCode_ERROR_CODE_INVALID_ARGUMENT    ErrorCode = 1
+	ErrorCode_ERROR_CODE_NOT_FOUND           ErrorCode = 2
+	ErrorCode_ERROR_CODE_ALREADY_EXISTS      ErrorCode = 3
+	ErrorCode_ERROR_CODE_PERMISSION_DENIED   ErrorCode = 4
+	ErrorCode_ERROR_CODE_UNAUTHENTICATED     ErrorCode = 5
+	ErrorCode_ERROR_CODE_FAILED_PRECONDITION ErrorCode = 6
+	ErrorCode_ERROR_CODE_RESOURCE_EXHAUSTED  ErrorCode = 7
+	ErrorCode_ERROR_CODE_UNAVAILABLE         ErrorCode = 8
+	ErrorCode_ERROR_CODE_INTERNAL            ErrorCode = 9
+	// Entities that don't exist, or that the caller isn't allowed to access.
+	ErrorCode_ERROR_CODE_AGENT_NOT_FOUND        ErrorCode = 20
+	ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND ErrorCode = 21
+	ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND      ErrorCode = 22
+	ErrorCode_ERROR_CODE_MESSAGE_NOT_FOUND      ErrorCode = 23
+	ErrorCode_ERROR_CODE_RUN_NOT_FOUND          ErrorCode = 24
+	ErrorCode_ERROR_CODE_APPROVAL_NOT_FOUND     ErrorCode = 26
+	ErrorCode_ERROR_CODE_WEBHOOK_NOT_FOUND      ErrorCode = 27
+	ErrorCode_ERROR_CODE_TRIGGER_RUN_NOT_FOUND  ErrorCode = 28
+	// The entity was modified since the version in the request was loaded:
+	// reload it and try again.
+	ErrorCode_ERROR_CODE_VERSION_CONFLICT ErrorCode = 25
+	// The instance doesn't accept new work: retry later.
+	ErrorCode_ERROR_CODE_MAINTENANCE_MODE ErrorCode = 40
+	ErrorCode_ERROR_CODE_SHUTTING_DOWN    ErrorCode = 41
+	// Agent turns.
+	ErrorCode_ERROR_CODE_CONVERSATION_BUSY ErrorCode = 60
+	ErrorCode_ERROR_CODE_MAX_ITERATIONS    ErrorCode = 61
+	ErrorCode_ERROR_CODE_LOOP_DETECTED     ErrorCode = 62
+	ErrorCode_ERROR_CODE_RUN_CANCELLED     ErrorCode = 63
+	ErrorCode_ERROR_CODE_RUN_TIMED_OUT     ErrorCode = 64
+	ErrorCode_ERROR_CODE_INTERRUPTED       ErrorCode = 65
+	// The LLM provider failed or rejected the request.
+	ErrorCode_ERROR_CODE_LLM_FAILED       ErrorCode = 66
+	ErrorCode_ERROR_CODE_LLM_RATE_LIMITED ErrorCode = 67
+	// Tool calls. The error is reported to the LLM as the tool's result.
+	ErrorCode_ERROR_CODE_TOOL_NOT_FOUND         ErrorCode = 80
+	ErrorCode_ERROR_CODE_TOOL_INVALID_ARGUMENTS ErrorCode = 81
+	ErrorCode_ERROR_CODE_TOOL_FAILED            ErrorCode = 82
+	// The call matched an approval rule of the agent and was denied, or
+	// wasn't approved in time.
+	ErrorCode_ERROR_CODE_TOOL_CALL_DENIED ErrorCode = 83
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0:  "ERROR_CODE_UNSPECIFIED",
+		1:  "ERROR_CODE_INVALID_ARGUMENT",
+		2:  "ERROR_CODE_NOT_FOUND",
+		3:  "ERROR_CODE_ALREADY_EXISTS",
+		4:  "ERROR_CODE_PERMISSION_DENIED",
+		5:  "ERROR_CODE_UNAUTHENTICATED",
+		6:  "ERROR_CODE_FAILED_PRECONDITION",
+		7:  "ERROR_CODE_RESOURCE_EXHAUSTED",
+		8:  "ERROR_CODE_UNAVAILABLE",
+		9:  "ERROR_CODE_INTERNAL",
+		20: "ERROR_CODE_AGENT_NOT_FOUND",
+		21: "ERROR_CODE_CONVERSATION_NOT_FOUND",
+		22: "ERROR_CODE_TRIGGER_NOT_FOUND",
+		23: "ERROR_CODE_MESSAGE_NOT_FOUND",
+		24: "ERROR_CODE_RUN_NOT_FOUND",
+		26: "ERROR_CODE_APPROVAL_NOT_FOUND",
+		27: "ERROR_CODE_WEBHOOK_NOT_FOUND",
+		28: "ERROR_CODE_TRIGGER_RUN_NOT_FOUND",
+		25: "ERROR_CODE_VERSION_CONFLICT",
+		40: "ERROR_CODE_MAINTENANCE_MODE",
+		41: "ERROR_CODE_SHUTTING_DOWN",
+		60: "ERROR_CODE_CONVERSATION_BUSY",
+		61: "ERROR_CODE_MAX_ITERATIONS",
+		62: "ERROR_CODE_LOOP_DETECTED",
+		63: "ERROR_CODE_RUN_CANCELLED",
+		64: "ERROR_CODE_RUN_TIMED_OUT",
+		65: "ERROR_CODE_INTERRUPTED",
+		66: "ERROR_CODE_LLM_FAILED",
+		67: "ERROR_CODE_LLM_RATE_LIMITED",
+		80: "ERROR_CODE_TOOL_NOT_FOUND",
+		81: "ERROR_CODE_TOOL_INVALID_ARGUMENTS",
+		82: "ERROR_CODE_TOOL_FAILED",
+		83: "ERROR_CODE_TOOL_CALL_DENIED",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":            0,
+		"ERROR_CODE_INVALID_ARGUMENT":       1,
+		"ERROR_CODE_NOT_FOUND":              2,
+		"ERROR_CODE_ALREADY_EXISTS":         3,
+		"ERROR_CODE_PERMISSION_DENIED":      4,
+		"ERROR_CODE_UNAUTHENTICATED":        5,
+		"ERROR_CODE_FAILED_PRECONDITION":    6,
+		"ERROR_CODE_RESOURCE_EXHAUSTED":     7,
+		"ERROR_CODE_UNAVAILABLE":            8,
+		"ERROR_CODE_INTERNAL":               9,
+		"ERROR_CODE_AGENT_NOT_FOUND":        20,
+		"ERROR_CODE_CONVERSATION_NOT_FOUND": 21,
+		"ERROR_CODE_TRIGGER_NOT_FOUND":      22,
+		"ERROR_CODE_MESSAGE_NOT_FOUND":      23,
+		"ERROR_CODE_RUN_NOT_FOUND":          24,
+		"ERROR_CODE_APPROVAL_NOT_FOUND":     26,
+		"ERROR_CODE_WEBHOOK_NOT_FOUND":      27,
+		"ERROR_CODE_TRIGGER_RUN_NOT_FOUND":  28,
+		"ERROR_CODE_VERSION_CONFLICT":       25,
+		"ERROR_CODE_MAINTENANCE_MODE":       40,
+		"ERROR_CODE_SHUTTING_DOWN":          41,
+		"ERROR_CODE_CONVERSATION_BUSY":      60,
+		"ERROR_CODE_MAX_ITERATIONS":         61,
+		"ERROR_CODE_LOOP_DETECTED":          62,
+		"ERROR_CODE_RUN_CANCELLED":          63,
+		"ERROR_CODE_RUN_TIMED_OUT":          64,
+		"ERROR_CODE_INTERRUPTED":            65,
+		"ERROR_CODE_LLM_FAILED":             66,
+		"ERROR_CODE_LLM_RATE_LIMITED":       67,
+		"ERROR_CODE_TOOL_NOT_FOUND":         80,
+		"ERROR_CODE_TOOL_INVALID_ARGUMENTS": 81,
+		"ERROR_CODE_TOOL_FAILED":            82,
+		"ERROR_CODE_TOOL_CALL_DENIED":       83,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_apierror_apierror_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_apierror_apierror_proto_enumTypes[0]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_apierror_apierror_proto_rawDescGZIP(), []int{0}
+}
+
+// ErrorDetail is attached to every error returned by the API, as a Connect
+// error detail.
+type ErrorDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ErrorCode              `protobuf:"varint,1,opt,name=code,proto3,enum=blippy.apierror.ErrorCode" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	mi := &file_apierror_apierror_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_apierror_apierror_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_apierror_apierror_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErrorDetail) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+var File_apierror_apierror_proto protoreflect.FileDescriptor
+
+const file_apierror_apierror_proto_rawDesc = "" +
+	"\n" +
+	"\x17apierror/apierror.proto\x12\x0fblippy.apierror\"=\n" +
+	"\vErrorDetail\x12.\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x1a.blippy.apierror.ErrorCodeR\x04code*\xa9\b\n" +
+	"\tErrorCode\x12\x1a\n" +
+	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bERROR_CODE_INVALID_ARGUMENT\x10\x01\x12\x18\n" +
+	"\x14ERROR_CODE_NOT_FOUND\x10\x02\x12\x1d\n" +
+	"\x19ERROR_CODE_ALREADY_EXISTS\x10\x03\x12 \n" +
+	"\x1cERROR_CODE_PERMISSION_DENIED\x10\x04\x12\x1e\n" +
+	"\x1aERROR_CODE_UNAUTHENTICATED\x10\x05\x12\"\n" +
+	"\x1eERROR_CODE_FAILED_PRECONDITION\x10\x06\x12!\n" +
+	"\x1dERROR_CODE_RESOURCE_EXHAUSTED\x10\a\x12\x1a\n" +
+	"\x16ERROR_CODE_UNAVAILABLE\x10\b\x12\x17\n" +
+	"\x13ERROR_CODE_INTERNAL\x10\t\x12\x1e\n" +
+	"\x1aERROR_CODE_AGENT_NOT_FOUND\x10\x14\x12%\n" +
+	"!ERROR_CODE_CONVERSATION_NOT_FOUND\x10\x15\x12 \n" +
+	"\x1cERROR_CODE_TRIGGER_NOT_FOUND\x10\x16\x12 \n" +
+	"\x1cERROR_CODE_MESSAGE_NOT_FOUND\x10\x17\x12\x1c\n" +
+	"\x18ERROR_CODE_RUN_NOT_FOUND\x10\x18\x12!\n" +
+	"\x1dERROR_CODE_APPROVAL_NOT_FOUND\x10\x1a\x12 \n" +
+	"\x1cERROR_CODE_WEBHOOK_NOT_FOUND\x10\x1b\x12$\n" +
+	" ERROR_CODE_TRIGGER_RUN_NOT_FOUND\x10\x1c\x12\x1f\n" +
+	"\x1bERROR_CODE_VERSION_CONFLICT\x10\x19\x12\x1f\n" +
+	"\x1bERROR_CODE_MAINTENANCE_MODE\x10(\x12\x1c\n" +
+	"\x18ERROR_CODE_SHUTTING_DOWN\x10)\x12 \n" +
+	"\x1cERROR_CODE_CONVERSATION_BUSY\x10<\x12\x1d\n" +
+	"\x19ERROR_CODE_MAX_ITERATIONS\x10=\x12\x1c\n" +
+	"\x18ERROR_CODE_LOOP_DETECTED\x10>\x12\x1c\n" +
+	"\x18ERROR_CODE_RUN_CANCELLED\x10?\x12\x1c\n" +
+	"\x18ERROR_CODE_RUN_TIMED_OUT\x10@\x12\x1a\n" +
+	"\x16ERROR_CODE_INTERRUPTED\x10A\x12\x19\n" +
+	"\x15ERROR_CODE_LLM_FAILED\x10B\x12\x1f\n" +
+	"\x1bERROR_CODE_LLM_RATE_LIMITED\x10C\x12\x1d\n" +
+	"\x19ERROR_CODE_TOOL_NOT_FOUND\x10P\x12%\n" +
+	"!ERROR_CODE_TOOL_INVALID_ARGUMENTS\x10Q\x12\x1a\n" +
+	"\x16ERROR_CODE_TOOL_FAILED\x10R\x12\x1f\n" +
+	"\x1bERROR_CODE_TOOL_CALL_DENIED\x10SB.Z,github.com/dstotijn/blippy/internal/apierrorb\x06proto3"
+
+var (
+	file_apierror_apierror_proto_rawDescOnce sync.Once
+	file_apierror_apierror_proto_rawDescData []byte
+)
+
+func file_apierror_apierror_proto_rawDescGZIP() []byte {
+	file_apierror_apierror_proto_rawDescOnce.Do(func() {
+		file_apierror_apierror_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apierror_apierror_proto_rawDesc), len(file_apierror_apierror_proto_rawDesc)))
+	})
+	return file_apierror_apierror_proto_rawDescData
+}
+
+var file_apierror_apierror_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_apierror_apierror_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_apierror_apierror_proto_goTypes = []any{
+	(ErrorCode)(0),      // 0: blippy.apierror.ErrorCode
+	(*ErrorDetail)(nil), // 1: blippy.apierror.ErrorDetail
+}
+var file_apierror_apierror_proto_depIdxs = []int32{
+	0, // 0: blippy.apierror.ErrorDetail.code:type_name -> blippy.apierror.ErrorCode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_apierror_apierror_proto_init() }
+func file_apierror_apierror_proto_init() {
+	if File_apierror_apierror_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apierror_apierror_proto_rawDesc), len(file_apierror_apierror_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apierror_apierror_proto_goTypes,
+		DependencyIndexes: file_apierror_apierror_proto_depIdxs,
+		EnumInfos:         file_apierror_apierror_proto_enumTypes,
+		MessageInfos:      file_apierror_apierror_proto_msgTypes,
+	}.Build()
+	File_apierror_apierror_proto = out.File
+	file_apierror_apierror_proto_goTypes = nil
+	file_apierror_apierror_proto_depIdxs = nil
+}
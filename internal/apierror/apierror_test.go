@@ -0,0 +1,63 @@
+package apierror_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/fsroot"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestNew(t *testing.T) {
+	err := apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_BUSY, errors.New("busy"))
+	if err.Code() != connect.CodeFailedPrecondition {
+		t.Errorf("Connect code = %v, want failed_precondition", err.Code())
+	}
+	if got := apierror.CodeOf(err); got != apierror.ErrorCode_ERROR_CODE_CONVERSATION_BUSY {
+		t.Errorf("CodeOf = %v, want ERROR_CODE_CONVERSATION_BUSY", got)
+	}
+	if got := apierror.CodeOf(errors.New("plain")); got != apierror.ErrorCode_ERROR_CODE_UNSPECIFIED {
+		t.Errorf("CodeOf of plain error = %v, want ERROR_CODE_UNSPECIFIED", got)
+	}
+}
+
+// TestInterceptor checks that clients get the specific code of an error, or
+// the generic code of errors without one.
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	path, handler := fsroot.NewFilesystemRootServiceHandler(fsroot.NewService(db), connect.WithInterceptors(apierror.NewInterceptor()))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := fsroot.NewFilesystemRootServiceClient(srv.Client(), srv.URL)
+
+	_, err = client.GetFilesystemRoot(ctx, connect.NewRequest(&fsroot.GetFilesystemRootRequest{Id: "unknown"}))
+	if got := apierror.CodeOf(err); got != apierror.ErrorCode_ERROR_CODE_NOT_FOUND {
+		t.Errorf("Get of unknown root: code = %v, want ERROR_CODE_NOT_FOUND", got)
+	}
+
+	root, err := client.CreateFilesystemRoot(ctx, connect.NewRequest(&fsroot.CreateFilesystemRootRequest{Name: "docs", Path: "/srv/docs"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.UpdateFilesystemRoot(ctx, connect.NewRequest(&fsroot.UpdateFilesystemRootRequest{
+		Id: root.Msg.Id, Name: "docs", Path: "/srv/docs", Version: root.Msg.Version + 1,
+	}))
+	if got := apierror.CodeOf(err); got != apierror.ErrorCode_ERROR_CODE_VERSION_CONFLICT {
+		t.Errorf("stale update: code = %v, want ERROR_CODE_VERSION_CONFLICT", got)
+	}
+}
@@ -0,0 +1,108 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: audit/audit.proto
+
+package audit
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AuditServiceName is the fully-qualified name of the AuditService service.
+	AuditServiceName = "blippy.audit.AuditService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AuditServiceListAuditEntriesProcedure is the fully-qualified name of the AuditService's
+	// ListAuditEntries RPC.
+	AuditServiceListAuditEntriesProcedure = "/blippy.audit.AuditService/ListAuditEntries"
+)
+
+// AuditServiceClient is a client for the blippy.audit.AuditService service.
+type AuditServiceClient interface {
+	ListAuditEntries(context.Context, *connect.Request[ListAuditEntriesRequest]) (*connect.Response[ListAuditEntriesResponse], error)
+}
+
+// NewAuditServiceClient constructs a client for the blippy.audit.AuditService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuditServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AuditServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	auditServiceMethods := File_audit_audit_proto.Services().ByName("AuditService").Methods()
+	return &auditServiceClient{
+		listAuditEntries: connect.NewClient[ListAuditEntriesRequest, ListAuditEntriesResponse](
+			httpClient,
+			baseURL+AuditServiceListAuditEntriesProcedure,
+			connect.WithSchema(auditServiceMethods.ByName("ListAuditEntries")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// auditServiceClient implements AuditServiceClient.
+type auditServiceClient struct {
+	listAuditEntries *connect.Client[ListAuditEntriesRequest, ListAuditEntriesResponse]
+}
+
+// ListAuditEntries calls blippy.audit.AuditService.ListAuditEntries.
+func (c *auditServiceClient) ListAuditEntries(ctx context.Context, req *connect.Request[ListAuditEntriesRequest]) (*connect.Response[ListAuditEntriesResponse], error) {
+	return c.listAuditEntries.CallUnary(ctx, req)
+}
+
+// AuditServiceHandler is an implementation of the blippy.audit.AuditService service.
+type AuditServiceHandler interface {
+	ListAuditEntries(context.Context, *connect.Request[ListAuditEntriesRequest]) (*connect.Response[ListAuditEntriesResponse], error)
+}
+
+// NewAuditServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuditServiceHandler(svc AuditServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	auditServiceMethods := File_audit_audit_proto.Services().ByName("AuditService").Methods()
+	auditServiceListAuditEntriesHandler := connect.NewUnaryHandler(
+		AuditServiceListAuditEntriesProcedure,
+		svc.ListAuditEntries,
+		connect.WithSchema(auditServiceMethods.ByName("ListAuditEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/blippy.audit.AuditService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuditServiceListAuditEntriesProcedure:
+			auditServiceListAuditEntriesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuditServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuditServiceHandler struct{}
+
+func (UnimplementedAuditServiceHandler) ListAuditEntries(context.Context, *connect.Request[ListAuditEntriesRequest]) (*connect.Response[ListAuditEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.audit.AuditService.ListAuditEntries is not implemented"))
+}
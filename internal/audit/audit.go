@@ -0,0 +1,194 @@
+// Package audit records creates, updates and deletes of resources, so
+// changes on a shared instance can be traced to who made them and when.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/dstotijn/blippy/internal/notification"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionImport = "import"
+	ActionRevoke = "revoke"
+)
+
+// AnonymousActor is recorded when the context carries no actor.
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+// WithActor returns a context that attributes changes to actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set with WithActor, or AnonymousActor.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// Entry is a change to record.
+type Entry struct {
+	Actor        string // defaults to the actor in the context
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Procedure    string
+	Details      string // JSON; secrets must already be redacted
+	RemoteAddr   string
+	UserAgent    string
+}
+
+// Record writes an entry to the audit log.
+func Record(ctx context.Context, queries *store.Queries, e Entry) error {
+	if e.Actor == "" {
+		e.Actor = ActorFromContext(ctx)
+	}
+	if e.Details == "" {
+		e.Details = "{}"
+	}
+
+	return queries.CreateAuditEntry(ctx, store.CreateAuditEntryParams{
+		// Version 7 UUIDs are time-ordered, so entries created in the same
+		// second still list in insertion order.
+		ID:           uuid.Must(uuid.NewV7()).String(),
+		Actor:        e.Actor,
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		Procedure:    e.Procedure,
+		Details:      e.Details,
+		RemoteAddr:   e.RemoteAddr,
+		UserAgent:    e.UserAgent,
+		CreatedAt:    time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// actionPrefixes maps RPC method name prefixes to the action they perform.
+// Methods with other prefixes don't change resources and aren't recorded.
+var actionPrefixes = []struct {
+	prefix string
+	action string
+}{
+	{"Create", ActionCreate},
+	{"Update", ActionUpdate},
+	{"Delete", ActionDelete},
+	{"Import", ActionImport},
+	{"Revoke", ActionRevoke},
+}
+
+// entryForProcedure returns the entry for a mutating RPC, e.g.
+// "/blippy.agent.AgentService/UpdateAgent", or false if the procedure
+// doesn't change a resource.
+func entryForProcedure(procedure string) (Entry, bool) {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	// Batch RPCs, e.g. "BatchDeleteConversations", are recorded as a single
+	// entry for the singular resource type.
+	method, batch := strings.CutPrefix(method, "Batch")
+	for _, p := range actionPrefixes {
+		if resource, ok := strings.CutPrefix(method, p.prefix); ok && resource != "" {
+			if batch {
+				resource = strings.TrimSuffix(resource, "s")
+			}
+			// "CreateTriggerFromConversation" creates a trigger.
+			resource, _, _ = strings.Cut(resource, "From")
+			return Entry{
+				Action:       p.action,
+				ResourceType: snakeCase(resource),
+				Procedure:    procedure,
+			}, true
+		}
+	}
+	return Entry{}, false
+}
+
+// snakeCase converts a method name suffix such as "NotificationChannel" to
+// "notification_channel". Initialisms are kept together, so "APIKey" becomes
+// "api_key".
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// messageID returns the value of a message's "id" string field, if any.
+func messageID(msg any) string {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return ""
+	}
+	r := m.ProtoReflect()
+	fd := r.Descriptor().Fields().ByName("id")
+	if fd == nil || fd.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return r.Get(fd).String()
+}
+
+// redactedFields replaces the values of request fields holding secrets.
+var redactedFields = map[string]func(string) string{
+	"config":  notification.RedactConfig,
+	"auth":    func(string) string { return tool.RedactedValue },
+	"p256dh":  func(string) string { return tool.RedactedValue },
+	"secret":  func(string) string { return tool.RedactedValue },
+	"api_key": func(string) string { return tool.RedactedValue },
+}
+
+// requestDetails returns the request as JSON with secrets redacted.
+func requestDetails(msg any) (string, error) {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return "{}", nil
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return "", fmt.Errorf("unmarshal request: %w", err)
+	}
+	for name, redact := range redactedFields {
+		if s, ok := fields[name].(string); ok && s != "" {
+			fields[name] = redact(s)
+		}
+	}
+
+	b, err = json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
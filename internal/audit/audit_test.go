@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/fsroot"
+	"github.com/dstotijn/blippy/internal/notification"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TestInterceptor checks that mutating RPCs are recorded and reads aren't.
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	auditService := NewService(db, slog.Default())
+	path, handler := fsroot.NewFilesystemRootServiceHandler(fsroot.NewService(db), connect.WithInterceptors(auditService.Interceptor()))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := fsroot.NewFilesystemRootServiceClient(srv.Client(), srv.URL)
+
+	root, err := client.CreateFilesystemRoot(ctx, connect.NewRequest(&fsroot.CreateFilesystemRootRequest{Name: "docs", Path: "/srv/docs"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetFilesystemRoot(ctx, connect.NewRequest(&fsroot.GetFilesystemRootRequest{Id: root.Msg.Id})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteFilesystemRoot(ctx, connect.NewRequest(&fsroot.DeleteFilesystemRootRequest{Id: root.Msg.Id})); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := auditService.ListAuditEntries(ctx, connect.NewRequest(&ListAuditEntriesRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := res.Msg.Entries
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	// Newest first.
+	for i, action := range []string{ActionDelete, ActionCreate} {
+		e := entries[i]
+		if e.Action != action || e.ResourceType != "filesystem_root" || e.ResourceId != root.Msg.Id || e.Actor != AnonymousActor {
+			t.Errorf("entry %d = %s %s %q by %s, want %s filesystem_root %q by %s", i, e.Action, e.ResourceType, e.ResourceId, e.Actor, action, root.Msg.Id, AnonymousActor)
+		}
+	}
+	if !strings.Contains(entries[1].Details, `"path":"/srv/docs"`) {
+		t.Errorf("create details = %s, want request fields", entries[1].Details)
+	}
+}
+
+func TestRequestDetailsRedactsSecrets(t *testing.T) {
+	details, err := requestDetails(&notification.CreateNotificationChannelRequest{
+		Name:   "sms",
+		Config: `{"auth_token":"s3cr3t-token"}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(details, "s3cr3t-token") {
+		t.Errorf("details contain secret: %s", details)
+	}
+}
+
+func TestEntryForProcedure(t *testing.T) {
+	e, ok := entryForProcedure("/blippy.notification.NotificationChannelService/UpdateNotificationChannel")
+	if !ok || e.Action != ActionUpdate || e.ResourceType != "notification_channel" {
+		t.Errorf("got %+v, %v", e, ok)
+	}
+	e, ok = entryForProcedure("/blippy.auth.AuthService/RevokeAPIKey")
+	if !ok || e.Action != ActionRevoke || e.ResourceType != "api_key" {
+		t.Errorf("got %+v, %v", e, ok)
+	}
+	e, ok = entryForProcedure("/blippy.conversation.ConversationService/BatchDeleteConversations")
+	if !ok || e.Action != ActionDelete || e.ResourceType != "conversation" {
+		t.Errorf("got %+v, %v", e, ok)
+	}
+	e, ok = entryForProcedure("/blippy.agent.AgentService/ImportAgent")
+	if !ok || e.Action != ActionImport || e.ResourceType != "agent" {
+		t.Errorf("got %+v, %v", e, ok)
+	}
+	e, ok = entryForProcedure("/blippy.trigger.TriggerService/CreateTriggerFromConversation")
+	if !ok || e.Action != ActionCreate || e.ResourceType != "trigger" {
+		t.Errorf("got %+v, %v", e, ok)
+	}
+	if _, ok := entryForProcedure("/blippy.agent.AgentService/ListAgents"); ok {
+		t.Error("ListAgents recorded as mutation")
+	}
+}
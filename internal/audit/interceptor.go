@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// NewInterceptor returns an interceptor that records successful create,
+// update and delete RPCs. Failing to record an entry is logged, but doesn't
+// fail the RPC, since the change has already been made.
+func NewInterceptor(queries *store.Queries, logger *slog.Logger) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			res, err := next(ctx, req)
+			if err != nil {
+				return res, err
+			}
+
+			e, ok := entryForProcedure(req.Spec().Procedure)
+			if !ok {
+				return res, nil
+			}
+
+			// Created resources only have an ID in the response.
+			e.ResourceID = messageID(res.Any())
+			if e.ResourceID == "" {
+				e.ResourceID = messageID(req.Any())
+			}
+			e.RemoteAddr = req.Peer().Addr
+			e.UserAgent = req.Header().Get("User-Agent")
+
+			details, detailsErr := requestDetails(req.Any())
+			if detailsErr != nil {
+				logger.Error("failed to encode audit details", "procedure", e.Procedure, "error", detailsErr)
+			}
+			e.Details = details
+
+			if err := Record(ctx, queries, e); err != nil {
+				logger.Error("failed to record audit entry", "procedure", e.Procedure, "resource_id", e.ResourceID, "error", err)
+			}
+			return res, nil
+		}
+	})
+}
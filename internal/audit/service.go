@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dstotijn/blippy/internal/listing"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+type Service struct {
+	queries *store.Queries
+	logger  *slog.Logger
+}
+
+func NewService(db *sql.DB, logger *slog.Logger) *Service {
+	return &Service{
+		queries: store.New(db),
+		logger:  logger,
+	}
+}
+
+// Interceptor returns an interceptor that records mutating RPCs in the
+// audit log.
+func (s *Service) Interceptor() connect.Interceptor {
+	return NewInterceptor(s.queries, s.logger)
+}
+
+func (s *Service) ListAuditEntries(ctx context.Context, req *connect.Request[ListAuditEntriesRequest]) (*connect.Response[ListAuditEntriesResponse], error) {
+	query, err := listing.Parse(listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Filter:    req.Msg.Filter,
+	}, entryTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListAuditEntriesPage, s.queries.CountAuditEntriesPage)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoEntries := make([]*AuditEntry, len(page.Items))
+	for i, e := range page.Items {
+		protoEntries[i] = toProto(e)
+	}
+
+	return connect.NewResponse(&ListAuditEntriesResponse{
+		Entries:       protoEntries,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}), nil
+}
+
+// entryTable has the fields usable in ListAuditEntries filters and order
+// clauses.
+var entryTable = listing.Table[store.AuditLog]{
+	Fields: listing.Fields[store.AuditLog]{
+		"id":            {Column: "id", Value: func(e store.AuditLog) string { return e.ID }},
+		"actor":         {Column: "actor", Value: func(e store.AuditLog) string { return e.Actor }},
+		"action":        {Column: "action", Value: func(e store.AuditLog) string { return e.Action }},
+		"resource_type": {Column: "resource_type", Value: func(e store.AuditLog) string { return e.ResourceType }},
+		"resource_id":   {Column: "resource_id", Value: func(e store.AuditLog) string { return e.ResourceID }},
+		"procedure":     {Column: "procedure", Value: func(e store.AuditLog) string { return e.Procedure }},
+		"remote_addr":   {Column: "remote_addr", Value: func(e store.AuditLog) string { return e.RemoteAddr }},
+		"created_at":    {Column: "created_at", Value: func(e store.AuditLog) string { return e.CreatedAt }},
+	},
+	Order: "created_at desc, id desc",
+}
+
+func toProto(e store.AuditLog) *AuditEntry {
+	createdAt, _ := time.Parse(time.RFC3339, e.CreatedAt)
+
+	return &AuditEntry{
+		Id:           e.ID,
+		Actor:        e.Actor,
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		ResourceId:   e.ResourceID,
+		Procedure:    e.Procedure,
+		Details:      e.Details,
+		RemoteAddr:   e.RemoteAddr,
+		UserAgent:    e.UserAgent,
+		CreatedAt:    timestamppb.New(createdAt),
+	}
+}
@@ -0,0 +1,284 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: auth/auth.proto
+
+package auth
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AuthServiceName is the fully-qualified name of the AuthService service.
+	AuthServiceName = "blippy.auth.AuthService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AuthServiceLoginProcedure is the fully-qualified name of the AuthService's Login RPC.
+	AuthServiceLoginProcedure = "/blippy.auth.AuthService/Login"
+	// AuthServiceLogoutProcedure is the fully-qualified name of the AuthService's Logout RPC.
+	AuthServiceLogoutProcedure = "/blippy.auth.AuthService/Logout"
+	// AuthServiceGetSessionProcedure is the fully-qualified name of the AuthService's GetSession RPC.
+	AuthServiceGetSessionProcedure = "/blippy.auth.AuthService/GetSession"
+	// AuthServiceGetLoginOptionsProcedure is the fully-qualified name of the AuthService's
+	// GetLoginOptions RPC.
+	AuthServiceGetLoginOptionsProcedure = "/blippy.auth.AuthService/GetLoginOptions"
+	// AuthServiceCreateAPIKeyProcedure is the fully-qualified name of the AuthService's CreateAPIKey
+	// RPC.
+	AuthServiceCreateAPIKeyProcedure = "/blippy.auth.AuthService/CreateAPIKey"
+	// AuthServiceListAPIKeysProcedure is the fully-qualified name of the AuthService's ListAPIKeys RPC.
+	AuthServiceListAPIKeysProcedure = "/blippy.auth.AuthService/ListAPIKeys"
+	// AuthServiceRevokeAPIKeyProcedure is the fully-qualified name of the AuthService's RevokeAPIKey
+	// RPC.
+	AuthServiceRevokeAPIKeyProcedure = "/blippy.auth.AuthService/RevokeAPIKey"
+)
+
+// AuthServiceClient is a client for the blippy.auth.AuthService service.
+type AuthServiceClient interface {
+	// Login exchanges an API key for a session cookie.
+	Login(context.Context, *connect.Request[LoginRequest]) (*connect.Response[LoginResponse], error)
+	Logout(context.Context, *connect.Request[LogoutRequest]) (*connect.Response[LogoutResponse], error)
+	GetSession(context.Context, *connect.Request[GetSessionRequest]) (*connect.Response[GetSessionResponse], error)
+	// GetLoginOptions returns the available login methods. It doesn't require
+	// authentication.
+	GetLoginOptions(context.Context, *connect.Request[GetLoginOptionsRequest]) (*connect.Response[GetLoginOptionsResponse], error)
+	CreateAPIKey(context.Context, *connect.Request[CreateAPIKeyRequest]) (*connect.Response[CreateAPIKeyResponse], error)
+	ListAPIKeys(context.Context, *connect.Request[ListAPIKeysRequest]) (*connect.Response[ListAPIKeysResponse], error)
+	RevokeAPIKey(context.Context, *connect.Request[RevokeAPIKeyRequest]) (*connect.Response[RevokeAPIKeyResponse], error)
+}
+
+// NewAuthServiceClient constructs a client for the blippy.auth.AuthService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AuthServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	authServiceMethods := File_auth_auth_proto.Services().ByName("AuthService").Methods()
+	return &authServiceClient{
+		login: connect.NewClient[LoginRequest, LoginResponse](
+			httpClient,
+			baseURL+AuthServiceLoginProcedure,
+			connect.WithSchema(authServiceMethods.ByName("Login")),
+			connect.WithClientOptions(opts...),
+		),
+		logout: connect.NewClient[LogoutRequest, LogoutResponse](
+			httpClient,
+			baseURL+AuthServiceLogoutProcedure,
+			connect.WithSchema(authServiceMethods.ByName("Logout")),
+			connect.WithClientOptions(opts...),
+		),
+		getSession: connect.NewClient[GetSessionRequest, GetSessionResponse](
+			httpClient,
+			baseURL+AuthServiceGetSessionProcedure,
+			connect.WithSchema(authServiceMethods.ByName("GetSession")),
+			connect.WithClientOptions(opts...),
+		),
+		getLoginOptions: connect.NewClient[GetLoginOptionsRequest, GetLoginOptionsResponse](
+			httpClient,
+			baseURL+AuthServiceGetLoginOptionsProcedure,
+			connect.WithSchema(authServiceMethods.ByName("GetLoginOptions")),
+			connect.WithClientOptions(opts...),
+		),
+		createAPIKey: connect.NewClient[CreateAPIKeyRequest, CreateAPIKeyResponse](
+			httpClient,
+			baseURL+AuthServiceCreateAPIKeyProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CreateAPIKey")),
+			connect.WithClientOptions(opts...),
+		),
+		listAPIKeys: connect.NewClient[ListAPIKeysRequest, ListAPIKeysResponse](
+			httpClient,
+			baseURL+AuthServiceListAPIKeysProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListAPIKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAPIKey: connect.NewClient[RevokeAPIKeyRequest, RevokeAPIKeyResponse](
+			httpClient,
+			baseURL+AuthServiceRevokeAPIKeyProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RevokeAPIKey")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// authServiceClient implements AuthServiceClient.
+type authServiceClient struct {
+	login           *connect.Client[LoginRequest, LoginResponse]
+	logout          *connect.Client[LogoutRequest, LogoutResponse]
+	getSession      *connect.Client[GetSessionRequest, GetSessionResponse]
+	getLoginOptions *connect.Client[GetLoginOptionsRequest, GetLoginOptionsResponse]
+	createAPIKey    *connect.Client[CreateAPIKeyRequest, CreateAPIKeyResponse]
+	listAPIKeys     *connect.Client[ListAPIKeysRequest, ListAPIKeysResponse]
+	revokeAPIKey    *connect.Client[RevokeAPIKeyRequest, RevokeAPIKeyResponse]
+}
+
+// Login calls blippy.auth.AuthService.Login.
+func (c *authServiceClient) Login(ctx context.Context, req *connect.Request[LoginRequest]) (*connect.Response[LoginResponse], error) {
+	return c.login.CallUnary(ctx, req)
+}
+
+// Logout calls blippy.auth.AuthService.Logout.
+func (c *authServiceClient) Logout(ctx context.Context, req *connect.Request[LogoutRequest]) (*connect.Response[LogoutResponse], error) {
+	return c.logout.CallUnary(ctx, req)
+}
+
+// GetSession calls blippy.auth.AuthService.GetSession.
+func (c *authServiceClient) GetSession(ctx context.Context, req *connect.Request[GetSessionRequest]) (*connect.Response[GetSessionResponse], error) {
+	return c.getSession.CallUnary(ctx, req)
+}
+
+// GetLoginOptions calls blippy.auth.AuthService.GetLoginOptions.
+func (c *authServiceClient) GetLoginOptions(ctx context.Context, req *connect.Request[GetLoginOptionsRequest]) (*connect.Response[GetLoginOptionsResponse], error) {
+	return c.getLoginOptions.CallUnary(ctx, req)
+}
+
+// CreateAPIKey calls blippy.auth.AuthService.CreateAPIKey.
+func (c *authServiceClient) CreateAPIKey(ctx context.Context, req *connect.Request[CreateAPIKeyRequest]) (*connect.Response[CreateAPIKeyResponse], error) {
+	return c.createAPIKey.CallUnary(ctx, req)
+}
+
+// ListAPIKeys calls blippy.auth.AuthService.ListAPIKeys.
+func (c *authServiceClient) ListAPIKeys(ctx context.Context, req *connect.Request[ListAPIKeysRequest]) (*connect.Response[ListAPIKeysResponse], error) {
+	return c.listAPIKeys.CallUnary(ctx, req)
+}
+
+// RevokeAPIKey calls blippy.auth.AuthService.RevokeAPIKey.
+func (c *authServiceClient) RevokeAPIKey(ctx context.Context, req *connect.Request[RevokeAPIKeyRequest]) (*connect.Response[RevokeAPIKeyResponse], error) {
+	return c.revokeAPIKey.CallUnary(ctx, req)
+}
+
+// AuthServiceHandler is an implementation of the blippy.auth.AuthService service.
+type AuthServiceHandler interface {
+	// Login exchanges an API key for a session cookie.
+	Login(context.Context, *connect.Request[LoginRequest]) (*connect.Response[LoginResponse], error)
+	Logout(context.Context, *connect.Request[LogoutRequest]) (*connect.Response[LogoutResponse], error)
+	GetSession(context.Context, *connect.Request[GetSessionRequest]) (*connect.Response[GetSessionResponse], error)
+	// GetLoginOptions returns the available login methods. It doesn't require
+	// authentication.
+	GetLoginOptions(context.Context, *connect.Request[GetLoginOptionsRequest]) (*connect.Response[GetLoginOptionsResponse], error)
+	CreateAPIKey(context.Context, *connect.Request[CreateAPIKeyRequest]) (*connect.Response[CreateAPIKeyResponse], error)
+	ListAPIKeys(context.Context, *connect.Request[ListAPIKeysRequest]) (*connect.Response[ListAPIKeysResponse], error)
+	RevokeAPIKey(context.Context, *connect.Request[RevokeAPIKeyRequest]) (*connect.Response[RevokeAPIKeyResponse], error)
+}
+
+// NewAuthServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	authServiceMethods := File_auth_auth_proto.Services().ByName("AuthService").Methods()
+	authServiceLoginHandler := connect.NewUnaryHandler(
+		AuthServiceLoginProcedure,
+		svc.Login,
+		connect.WithSchema(authServiceMethods.ByName("Login")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceLogoutHandler := connect.NewUnaryHandler(
+		AuthServiceLogoutProcedure,
+		svc.Logout,
+		connect.WithSchema(authServiceMethods.ByName("Logout")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceGetSessionHandler := connect.NewUnaryHandler(
+		AuthServiceGetSessionProcedure,
+		svc.GetSession,
+		connect.WithSchema(authServiceMethods.ByName("GetSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceGetLoginOptionsHandler := connect.NewUnaryHandler(
+		AuthServiceGetLoginOptionsProcedure,
+		svc.GetLoginOptions,
+		connect.WithSchema(authServiceMethods.ByName("GetLoginOptions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceCreateAPIKeyHandler := connect.NewUnaryHandler(
+		AuthServiceCreateAPIKeyProcedure,
+		svc.CreateAPIKey,
+		connect.WithSchema(authServiceMethods.ByName("CreateAPIKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListAPIKeysHandler := connect.NewUnaryHandler(
+		AuthServiceListAPIKeysProcedure,
+		svc.ListAPIKeys,
+		connect.WithSchema(authServiceMethods.ByName("ListAPIKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeAPIKeyHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeAPIKeyProcedure,
+		svc.RevokeAPIKey,
+		connect.WithSchema(authServiceMethods.ByName("RevokeAPIKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/blippy.auth.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuthServiceLoginProcedure:
+			authServiceLoginHandler.ServeHTTP(w, r)
+		case AuthServiceLogoutProcedure:
+			authServiceLogoutHandler.ServeHTTP(w, r)
+		case AuthServiceGetSessionProcedure:
+			authServiceGetSessionHandler.ServeHTTP(w, r)
+		case AuthServiceGetLoginOptionsProcedure:
+			authServiceGetLoginOptionsHandler.ServeHTTP(w, r)
+		case AuthServiceCreateAPIKeyProcedure:
+			authServiceCreateAPIKeyHandler.ServeHTTP(w, r)
+		case AuthServiceListAPIKeysProcedure:
+			authServiceListAPIKeysHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeAPIKeyProcedure:
+			authServiceRevokeAPIKeyHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuthServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuthServiceHandler struct{}
+
+func (UnimplementedAuthServiceHandler) Login(context.Context, *connect.Request[LoginRequest]) (*connect.Response[LoginResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.auth.AuthService.Login is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) Logout(context.Context, *connect.Request[LogoutRequest]) (*connect.Response[LogoutResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.auth.AuthService.Logout is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) GetSession(context.Context, *connect.Request[GetSessionRequest]) (*connect.Response[GetSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.auth.AuthService.GetSession is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) GetLoginOptions(context.Context, *connect.Request[GetLoginOptionsRequest]) (*connect.Response[GetLoginOptionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.auth.AuthService.GetLoginOptions is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) CreateAPIKey(context.Context, *connect.Request[CreateAPIKeyRequest]) (*connect.Response[CreateAPIKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.auth.AuthService.CreateAPIKey is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListAPIKeys(context.Context, *connect.Request[ListAPIKeysRequest]) (*connect.Response[ListAPIKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.auth.AuthService.ListAPIKeys is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeAPIKey(context.Context, *connect.Request[RevokeAPIKeyRequest]) (*connect.Response[RevokeAPIKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.auth.AuthService.RevokeAPIKey is not implemented"))
+}
@@ -0,0 +1,352 @@
+// Package auth authenticates API requests with admin-issued API keys, and
+// web UI requests with cookie sessions created by logging in with a key or
+// with an OpenID Connect provider. Keys and session tokens are only stored as
+// SHA-256 hashes.
+package auth
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+const (
+	// KeyPrefix starts every API key, so leaked keys are easy to recognize.
+	KeyPrefix = "blippy_"
+
+	// SessionCookie is the name of the cookie holding the session token.
+	SessionCookie = "blippy_session"
+
+	// SessionTTL is how long a session is valid after logging in.
+	SessionTTL = 30 * 24 * time.Hour
+
+	// displayPrefixLen is the number of key characters stored in the clear,
+	// to tell keys apart in listings.
+	displayPrefixLen = len(KeyPrefix) + 6
+
+	// touchInterval limits how often a key's last used time is written.
+	touchInterval = time.Minute
+)
+
+var (
+	// ErrUnauthenticated is returned when a request has no valid credentials.
+	ErrUnauthenticated = errors.New("missing or invalid credentials")
+	// ErrNotFound is returned when revoking a key that doesn't exist or was
+	// already revoked.
+	ErrNotFound = errors.New("api key not found")
+	// ErrScopeNotAllowed is returned when a key's scope doesn't allow an
+	// operation.
+	ErrScopeNotAllowed = errors.New("api key scope doesn't allow this operation")
+	// ErrAgentNotAllowed is returned when a key is restricted to agents other
+	// than the ones a request is about.
+	ErrAgentNotAllowed = errors.New("api key isn't allowed to access this agent")
+)
+
+// Roles. Admins can manage API keys and read the audit log; members can use
+// everything else.
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+// ValidRole reports whether role is a known role.
+func ValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleMember
+}
+
+// Principal is the authenticated caller of a request: an API key, or a user
+// logged in with OIDC.
+type Principal struct {
+	Role    string
+	APIKey  *store.ApiKey // set if authenticated with an API key
+	Subject string        // OIDC subject; set for OIDC users
+	Name    string
+	Email   string
+	Scope   KeyScope // restrictions of the API key, if any
+}
+
+// Actor returns the audit log actor for the principal.
+func (p Principal) Actor() string {
+	if p.APIKey != nil {
+		return "api_key:" + p.APIKey.Name
+	}
+	return "oidc:" + cmp.Or(p.Email, p.Subject)
+}
+
+func apiKeyPrincipal(key store.ApiKey) Principal {
+	// Keys are issued by admins, and have full access unless restricted.
+	p := Principal{Role: RoleAdmin, APIKey: &key, Name: key.Name, Scope: keyScope(key)}
+	if p.Scope.Restricted() {
+		p.Role = RoleMember
+	}
+	return p
+}
+
+type principalCtxKey struct{}
+
+// withPrincipal returns a context carrying a request's principal.
+func withPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// PrincipalFromContext returns the principal of a request, or false if the
+// request is unauthenticated (e.g. because auth is disabled).
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey{}).(Principal)
+	return p, ok
+}
+
+// hash returns the hex-encoded SHA-256 hash of a key or session token. Both
+// are 256-bit random values, so a fast unsalted hash is sufficient.
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken returns 32 random bytes, base64url-encoded.
+func randomToken() string {
+	b := make([]byte, 32)
+	rand.Read(b) // never returns an error
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// CreateKey creates an API key named name, restricted by scope. The
+// plaintext key is returned once; only its hash is stored.
+func CreateKey(ctx context.Context, queries *store.Queries, name string, scope KeyScope) (store.ApiKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return store.ApiKey{}, "", errors.New("name is required")
+	}
+	if err := scope.Validate(); err != nil {
+		return store.ApiKey{}, "", err
+	}
+	// Store empty lists rather than null.
+	agentIDs, err := json.Marshal(append([]string{}, scope.AgentIDs...))
+	if err != nil {
+		return store.ApiKey{}, "", err
+	}
+	scopes, err := json.Marshal(append([]string{}, scope.Scopes...))
+	if err != nil {
+		return store.ApiKey{}, "", err
+	}
+
+	key := KeyPrefix + randomToken()
+	apiKey, err := queries.CreateAPIKey(ctx, store.CreateAPIKeyParams{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Prefix:    key[:displayPrefixLen],
+		KeyHash:   hash(key),
+		AgentIds:  string(agentIDs),
+		Scopes:    string(scopes),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return store.ApiKey{}, "", err
+	}
+	return apiKey, key, nil
+}
+
+// RevokeKey revokes an API key. Sessions created with it stop working.
+func RevokeKey(ctx context.Context, queries *store.Queries, id string) error {
+	n, err := queries.RevokeAPIKey(ctx, store.RevokeAPIKeyParams{
+		RevokedAt: nullTime(time.Now()),
+		ID:        id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// EnsureKey creates an API key named name if there are no active keys, so a
+// fresh instance can be logged into. The plaintext key is returned if one was
+// created, and is empty otherwise.
+func EnsureKey(ctx context.Context, queries *store.Queries, name string) (string, error) {
+	n, err := queries.CountActiveAPIKeys(ctx)
+	if err != nil {
+		return "", err
+	}
+	if n > 0 {
+		return "", nil
+	}
+	_, key, err := CreateKey(ctx, queries, name, KeyScope{})
+	return key, err
+}
+
+// minConfiguredKeyLen is the minimum length of a configured key after its
+// prefix, so it's as hard to guess as a generated one.
+const minConfiguredKeyLen = 32
+
+// EnsureConfiguredKey stores key, configured by the operator rather than
+// generated, as an unrestricted API key named name, unless it's stored
+// already. It reports whether the stored key was revoked, in which case it
+// stays revoked.
+func EnsureConfiguredKey(ctx context.Context, queries *store.Queries, name, key string) (revoked bool, err error) {
+	if !strings.HasPrefix(key, KeyPrefix) || len(key)-len(KeyPrefix) < minConfiguredKeyLen {
+		return false, fmt.Errorf("key must start with %q followed by at least %d characters", KeyPrefix, minConfiguredKeyLen)
+	}
+	apiKey, err := queries.GetAPIKeyByHash(ctx, hash(key))
+	if err == nil {
+		return apiKey.RevokedAt.Valid, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	_, err = queries.CreateAPIKey(ctx, store.CreateAPIKeyParams{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Prefix:    key[:displayPrefixLen],
+		KeyHash:   hash(key),
+		AgentIds:  "[]",
+		Scopes:    "[]",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	return false, err
+}
+
+// verifyKey returns the active API key matching key.
+func verifyKey(ctx context.Context, queries *store.Queries, key string) (store.ApiKey, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return store.ApiKey{}, ErrUnauthenticated
+	}
+	apiKey, err := queries.GetAPIKeyByHash(ctx, hash(key))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && apiKey.RevokedAt.Valid) {
+		return store.ApiKey{}, ErrUnauthenticated
+	}
+	if err != nil {
+		return store.ApiKey{}, err
+	}
+	return apiKey, nil
+}
+
+// createSession creates a session for a principal, and returns its token.
+func createSession(ctx context.Context, queries *store.Queries, p Principal, now time.Time) (string, error) {
+	// Opportunistically clean up, instead of running a separate job.
+	if err := queries.DeleteExpiredSessions(ctx, now.UTC().Format(time.RFC3339)); err != nil {
+		return "", err
+	}
+
+	params := store.CreateSessionParams{
+		Subject:   p.Subject,
+		Name:      p.Name,
+		Email:     p.Email,
+		Role:      p.Role,
+		CreatedAt: now.UTC().Format(time.RFC3339),
+		ExpiresAt: now.Add(SessionTTL).UTC().Format(time.RFC3339),
+	}
+	if p.APIKey != nil {
+		params.ApiKeyID = sql.NullString{String: p.APIKey.ID, Valid: true}
+	}
+
+	token := randomToken()
+	params.TokenHash = hash(token)
+	if err := queries.CreateSession(ctx, params); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// verifySession returns the principal of a session. Sessions created with an
+// API key end when the key is revoked.
+func verifySession(ctx context.Context, queries *store.Queries, token string, now time.Time) (Principal, error) {
+	session, err := queries.GetSession(ctx, store.GetSessionParams{
+		TokenHash: hash(token),
+		ExpiresAt: now.UTC().Format(time.RFC3339),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Principal{}, ErrUnauthenticated
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if !session.ApiKeyID.Valid {
+		return Principal{
+			Role:    session.Role,
+			Subject: session.Subject,
+			Name:    session.Name,
+			Email:   session.Email,
+		}, nil
+	}
+
+	apiKey, err := queries.GetAPIKey(ctx, session.ApiKeyID.String)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && apiKey.RevokedAt.Valid) {
+		return Principal{}, ErrUnauthenticated
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+	return apiKeyPrincipal(apiKey), nil
+}
+
+// authenticate returns the principal for a request's credentials: an API key
+// as bearer token in the Authorization header, or a session cookie.
+func authenticate(ctx context.Context, queries *store.Queries, header http.Header, now time.Time) (Principal, error) {
+	if token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok {
+		apiKey, err := verifyKey(ctx, queries, strings.TrimSpace(token))
+		if err != nil {
+			return Principal{}, err
+		}
+		return apiKeyPrincipal(apiKey), nil
+	}
+	if token := sessionToken(header); token != "" {
+		return verifySession(ctx, queries, token, now)
+	}
+	return Principal{}, ErrUnauthenticated
+}
+
+// sessionToken returns the session token from a request's cookies.
+func sessionToken(header http.Header) string {
+	r := http.Request{Header: header}
+	c, err := r.Cookie(SessionCookie)
+	if err != nil {
+		return ""
+	}
+	return c.Value
+}
+
+// sessionCookie returns the cookie that stores a session token. An empty
+// token returns a cookie that clears the session.
+func sessionCookie(token string, secure bool, now time.Time) *http.Cookie {
+	c := &http.Cookie{
+		Name:     SessionCookie,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if token == "" {
+		c.MaxAge = -1
+	} else {
+		c.Expires = now.Add(SessionTTL)
+	}
+	return c
+}
+
+// isSecure reports whether a request was made over HTTPS, directly or via a
+// reverse proxy, so cookies can be marked secure.
+func isSecure(header http.Header) bool {
+	return header.Get("X-Forwarded-Proto") == "https" ||
+		strings.HasPrefix(header.Get("Origin"), "https://")
+}
+
+func nullTime(t time.Time) sql.NullString {
+	return sql.NullString{String: t.UTC().Format(time.RFC3339), Valid: true}
+}
@@ -0,0 +1,529 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agent"
+	"github.com/dstotijn/blippy/internal/conversation"
+	"github.com/dstotijn/blippy/internal/notification"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/system"
+)
+
+func newTestClient(t *testing.T) (AuthServiceClient, *store.Queries) {
+	t.Helper()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	service := NewService(db, slog.Default(), Options{})
+	path, handler := NewAuthServiceHandler(service, connect.WithInterceptors(service.Interceptor()))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return NewAuthServiceClient(srv.Client(), srv.URL), store.New(db)
+}
+
+func withBearer[T any](req *connect.Request[T], key string) *connect.Request[T] {
+	req.Header().Set("Authorization", "Bearer "+key)
+	return req
+}
+
+// TestInterceptor checks that RPCs require a valid, unrevoked key or session.
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	client, queries := newTestClient(t)
+
+	key, err := EnsureKey(ctx, queries, "admin")
+	if err != nil || key == "" {
+		t.Fatalf("EnsureKey = %q, %v", key, err)
+	}
+	if again, err := EnsureKey(ctx, queries, "admin"); err != nil || again != "" {
+		t.Fatalf("EnsureKey with active key = %q, %v; want no new key", again, err)
+	}
+
+	_, err = client.GetSession(ctx, connect.NewRequest(&GetSessionRequest{}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("GetSession without credentials: got %v, want unauthenticated", err)
+	}
+	_, err = client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), KeyPrefix+"wrong"))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("GetSession with wrong key: got %v, want unauthenticated", err)
+	}
+
+	res, err := client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.ApiKey.GetName() != "admin" {
+		t.Errorf("session key name = %q, want admin", res.Msg.ApiKey.GetName())
+	}
+
+	// Log in, and use the session cookie instead of the key.
+	_, err = client.Login(ctx, connect.NewRequest(&LoginRequest{ApiKey: "nope"}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("Login with wrong key: got %v, want unauthenticated", err)
+	}
+	login, err := client.Login(ctx, connect.NewRequest(&LoginRequest{ApiKey: key}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies, err := http.ParseSetCookie(login.Header().Get("Set-Cookie"))
+	if err != nil || cookies.Name != SessionCookie || !cookies.HttpOnly {
+		t.Fatalf("session cookie = %+v, %v", cookies, err)
+	}
+	sessionReq := connect.NewRequest(&GetSessionRequest{})
+	sessionReq.Header().Set("Cookie", cookies.String())
+	if _, err := client.GetSession(ctx, sessionReq); err != nil {
+		t.Fatalf("GetSession with session cookie: %v", err)
+	}
+
+	// Revoking the key invalidates it and its sessions.
+	created, err := client.CreateAPIKey(ctx, withBearer(connect.NewRequest(&CreateAPIKeyRequest{Name: "ci"}), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.RevokeAPIKey(ctx, withBearer(connect.NewRequest(&RevokeAPIKeyRequest{Id: login.Msg.ApiKey.Id}), created.Msg.Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), key))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("GetSession with revoked key: got %v, want unauthenticated", err)
+	}
+	sessionReq = connect.NewRequest(&GetSessionRequest{})
+	sessionReq.Header().Set("Cookie", cookies.String())
+	_, err = client.GetSession(ctx, sessionReq)
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("GetSession with revoked key's session: got %v, want unauthenticated", err)
+	}
+
+	if err := RevokeKey(ctx, queries, login.Msg.ApiKey.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("revoking twice: got %v, want ErrNotFound", err)
+	}
+}
+
+// TestConfiguredKey checks that a configured key is stored once, and can't be
+// revived once revoked.
+func TestConfiguredKey(t *testing.T) {
+	ctx := context.Background()
+	client, queries := newTestClient(t)
+
+	if _, err := EnsureConfiguredKey(ctx, queries, "admin", KeyPrefix+"short"); err == nil {
+		t.Error("EnsureConfiguredKey with short key: got no error")
+	}
+	key := KeyPrefix + strings.Repeat("k", minConfiguredKeyLen)
+	for range 2 {
+		if revoked, err := EnsureConfiguredKey(ctx, queries, "admin", key); err != nil || revoked {
+			t.Fatalf("EnsureConfiguredKey = %v, %v", revoked, err)
+		}
+	}
+	if n, err := queries.CountActiveAPIKeys(ctx); err != nil || n != 1 {
+		t.Fatalf("active keys = %d, %v; want 1", n, err)
+	}
+	res, err := client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RevokeKey(ctx, queries, res.Msg.ApiKey.Id); err != nil {
+		t.Fatal(err)
+	}
+	if revoked, err := EnsureConfiguredKey(ctx, queries, "admin", key); err != nil || !revoked {
+		t.Errorf("EnsureConfiguredKey after revoking = %v, %v; want revoked", revoked, err)
+	}
+	_, err = client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), key))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("GetSession with revoked configured key: got %v, want unauthenticated", err)
+	}
+}
+
+// TestRoles checks that members can't call admin RPCs.
+func TestRoles(t *testing.T) {
+	ctx := context.Background()
+	client, queries := newTestClient(t)
+
+	token, err := createSession(ctx, queries, Principal{Role: RoleMember, Subject: "user-1", Email: "ada@example.com"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookie := (&http.Cookie{Name: SessionCookie, Value: token}).String()
+
+	req := connect.NewRequest(&GetSessionRequest{})
+	req.Header().Set("Cookie", cookie)
+	res, err := client.GetSession(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Role != RoleMember || res.Msg.Email != "ada@example.com" || res.Msg.ApiKey != nil {
+		t.Errorf("session = %v", res.Msg)
+	}
+
+	listReq := connect.NewRequest(&ListAPIKeysRequest{})
+	listReq.Header().Set("Cookie", cookie)
+	_, err = client.ListAPIKeys(ctx, listReq)
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("ListAPIKeys as member: got %v, want permission denied", err)
+	}
+}
+
+func TestRoleForGroups(t *testing.T) {
+	roles, err := ParseRoleMapping("blippy-admins=admin, engineering=member")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		groups      []string
+		defaultRole string
+		want        string
+	}{
+		{[]string{"engineering", "blippy-admins"}, "", RoleAdmin},
+		{[]string{"engineering"}, "", RoleMember},
+		{[]string{"sales"}, "", ""},
+		{nil, RoleMember, RoleMember},
+	}
+	for _, tt := range tests {
+		if got := roleForGroups(tt.groups, roles, tt.defaultRole); got != tt.want {
+			t.Errorf("roleForGroups(%v, %q) = %q, want %q", tt.groups, tt.defaultRole, got, tt.want)
+		}
+	}
+
+	for _, s := range []string{"admins", "admins=root", "=admin"} {
+		if _, err := ParseRoleMapping(s); err == nil {
+			t.Errorf("ParseRoleMapping(%q) succeeded", s)
+		}
+	}
+}
+
+// TestKeyScope checks that restricted keys can only access their agents and
+// perform their operations.
+func TestKeyScope(t *testing.T) {
+	ctx := context.Background()
+	client, queries := newTestClient(t)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, id := range []string{"agent-1", "agent-2"} {
+		if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: id, Name: id, EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-" + id, AgentID: id, CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{ID: "trigger-" + id, AgentID: id, Name: id, Vars: "{}", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateTriggerRun(ctx, store.CreateTriggerRunParams{ID: "run-" + id, TriggerID: "trigger-" + id, Status: "completed", StartedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scope := KeyScope{AgentIDs: []string{"agent-1"}, Scopes: []string{ScopeChat, ScopeRead}}
+	_, key, err := CreateKey(ctx, queries, "ci", scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := CreateKey(ctx, queries, "bad", KeyScope{Scopes: []string{"admin"}}); err == nil {
+		t.Error("CreateKey with unknown scope succeeded")
+	}
+
+	// Restricted keys are members, but can still see their own session.
+	res, err := client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Role != RoleMember || len(res.Msg.ApiKey.GetAgentIds()) != 1 {
+		t.Errorf("session = %v", res.Msg)
+	}
+
+	p, err := authenticate(ctx, queries, http.Header{"Authorization": {"Bearer " + key}}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		procedure string
+		msg       any
+		want      error
+	}{
+		{conversation.ConversationServiceGetConversationProcedure, &conversation.GetConversationRequest{Id: "conv-agent-1"}, nil},
+		{conversation.ConversationServiceGetConversationProcedure, &conversation.GetConversationRequest{Id: "conv-agent-2"}, ErrAgentNotAllowed},
+		{conversation.ConversationServiceGetConversationProcedure, &conversation.GetConversationRequest{Id: "unknown"}, ErrAgentNotAllowed},
+		{conversation.ConversationServiceBatchDeleteConversationsProcedure, &conversation.BatchDeleteConversationsRequest{Ids: []string{"conv-agent-1"}}, nil},
+		{conversation.ConversationServiceBatchDeleteConversationsProcedure, &conversation.BatchDeleteConversationsRequest{Ids: []string{"conv-agent-1", "conv-agent-2"}}, ErrAgentNotAllowed},
+		{conversation.ConversationServiceSearchConversationsProcedure, &conversation.SearchConversationsRequest{Query: "plan", AgentId: "agent-1"}, nil},
+		{conversation.ConversationServiceSearchConversationsProcedure, &conversation.SearchConversationsRequest{Query: "plan"}, ErrAgentNotAllowed},
+		{agent.AgentServiceListAgentsProcedure, &agent.ListAgentsRequest{}, ErrAgentNotAllowed},
+		{agent.AgentServiceGetAgentProcedure, &agent.GetAgentRequest{Id: "agent-1"}, nil},
+		{notification.NotificationChannelServiceCreateNotificationChannelProcedure, &notification.CreateNotificationChannelRequest{Type: "telegram", Config: `{"bot_token": "t", "agent_id": "agent-1"}`}, nil},
+		{notification.NotificationChannelServiceCreateNotificationChannelProcedure, &notification.CreateNotificationChannelRequest{Type: "telegram", Config: `{"bot_token": "t", "agent_id": "agent-2"}`}, ErrAgentNotAllowed},
+		// The trigger package imports auth, so GetTriggerRun is checked with
+		// another request with an id field.
+		{"/blippy.trigger.TriggerService/GetTriggerRun", &agent.GetAgentRequest{Id: "run-agent-1"}, nil},
+		{"/blippy.trigger.TriggerService/GetTriggerRun", &agent.GetAgentRequest{Id: "run-agent-2"}, ErrAgentNotAllowed},
+		{"/blippy.trigger.TriggerService/GetTriggerRun", &agent.GetAgentRequest{Id: "unknown"}, ErrAgentNotAllowed},
+		// Another request with a conversation_id field, for the same reason.
+		{"/blippy.trigger.TriggerService/CreateTriggerFromConversation", &system.GetRunTraceRequest{ConversationId: "conv-agent-1"}, nil},
+		{"/blippy.trigger.TriggerService/CreateTriggerFromConversation", &system.GetRunTraceRequest{ConversationId: "conv-agent-2"}, ErrAgentNotAllowed},
+	}
+	for _, tt := range tests {
+		if err := checkAgents(ctx, queries, p, tt.procedure, tt.msg); !errors.Is(err, tt.want) {
+			t.Errorf("checkAgents(%s, %v) = %v, want %v", tt.procedure, tt.msg, err, tt.want)
+		}
+	}
+
+	for procedure, want := range map[string]string{
+		conversation.ConversationServiceChatProcedure:                ScopeChat,
+		conversation.ConversationServiceListConversationsProcedure:   ScopeRead,
+		conversation.ConversationServiceSearchConversationsProcedure: ScopeRead,
+		agent.AgentServiceCreateAgentProcedure:                       ScopeWrite,
+		agent.AgentServiceExportAgentProcedure:                       ScopeRead,
+		agent.AgentServiceImportAgentProcedure:                       ScopeWrite,
+	} {
+		if got := procedureScope(procedure); got != want {
+			t.Errorf("procedureScope(%s) = %q, want %q", procedure, got, want)
+		}
+	}
+
+	// The webhook middleware requires a key with the webhook scope.
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	service := NewService(db, slog.Default(), Options{})
+	_, webhookKey, err := CreateKey(ctx, store.New(db), "webhook", KeyScope{Scopes: []string{ScopeWebhook}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, chatKey, err := CreateKey(ctx, store.New(db), "chat", KeyScope{Scopes: []string{ScopeChat}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := service.Middleware(ScopeWebhook, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for key, want := range map[string]int{"": http.StatusUnauthorized, chatKey: http.StatusForbidden, webhookKey: http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/trigger", nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("webhook with key %q: status %d, want %d", key, rec.Code, want)
+		}
+	}
+
+	// The admin middleware rejects restricted keys, which have the member
+	// role.
+	_, adminKey, err := CreateKey(ctx, store.New(db), "admin", KeyScope{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, readKey, err := CreateKey(ctx, store.New(db), "read", KeyScope{Scopes: []string{ScopeRead}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler = service.AdminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for key, want := range map[string]int{"": http.StatusUnauthorized, readKey: http.StatusForbidden, adminKey: http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/vars", nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("diagnostics with key %q: status %d, want %d", key, rec.Code, want)
+		}
+	}
+}
+
+// TestLockout checks that repeated failures lock out the client, and are
+// recorded in the audit log.
+func TestLockout(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := store.New(db)
+
+	service := NewService(db, slog.Default(), Options{Lockout: LockoutOptions{Threshold: 3, Duration: time.Minute}})
+	path, handler := NewAuthServiceHandler(service, connect.WithInterceptors(service.Interceptor()))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := NewAuthServiceClient(srv.Client(), srv.URL)
+
+	key, err := EnsureKey(ctx, queries, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests without credentials don't count as failures.
+	for range 5 {
+		client.GetSession(ctx, connect.NewRequest(&GetSessionRequest{}))
+	}
+	if _, err := client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), key)); err != nil {
+		t.Fatalf("GetSession after requests without credentials: %v", err)
+	}
+
+	for i := range 3 {
+		_, err := client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), KeyPrefix+"guessed-key"))
+		if connect.CodeOf(err) != connect.CodeUnauthenticated {
+			t.Fatalf("attempt %d: got %v, want unauthenticated", i, err)
+		}
+	}
+	_, err = client.GetSession(ctx, withBearer(connect.NewRequest(&GetSessionRequest{}), key))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("GetSession while locked out: got %v, want resource exhausted", err)
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Meta().Get("Retry-After") == "" {
+		t.Errorf("locked out error has no Retry-After: %v", err)
+	}
+	_, err = client.Login(ctx, connect.NewRequest(&LoginRequest{ApiKey: key}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("Login while locked out: got %v, want resource exhausted", err)
+	}
+
+	entries, err := queries.ListAuditEntries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := make(map[string]int)
+	for _, e := range entries {
+		actions[e.Action]++
+	}
+	// Only the client IP is locked out.
+	if actions[ActionAuthFailure] != 3 || actions[ActionLockout] != 1 {
+		t.Errorf("audit actions = %v, want 3 failures and 1 lockout", actions)
+	}
+
+	// The lockout expires.
+	g := service.guard
+	a := g.newAttempt("", "127.0.0.1:1234", http.Header{})
+	if err := g.check(a, time.Now().Add(2*time.Minute)); err != nil {
+		t.Errorf("check after lockout expired: %v", err)
+	}
+
+	// Failures with the public display prefix of a key, from other clients,
+	// are audited but don't lock out the key.
+	header := http.Header{"Authorization": {"Bearer " + key}}
+	for i := range 5 {
+		attacker := g.newAttempt("", fmt.Sprintf("10.0.0.%d:1234", i), header)
+		g.fail(ctx, attacker, reasonInvalidKey, time.Now())
+	}
+	if _, err := g.authenticate(ctx, g.newAttempt("", "192.0.2.1:1234", header), header, time.Now()); err != nil {
+		t.Errorf("authenticate after failures with the key's prefix: %v", err)
+	}
+	entries, err = queries.ListAuditEntries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := entries[0]; e.Action != ActionAuthFailure || e.ResourceID != presentedPrefix(key) || !strings.Contains(e.Details, `"key_failures":5`) {
+		t.Errorf("last audit entry = %+v, want a failure with 5 key failures", e)
+	}
+}
+
+func TestForwardedIP(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"203.0.113.1"}, "203.0.113.1"},
+		{[]string{"198.51.100.1, 203.0.113.1"}, "203.0.113.1"},
+		{[]string{"198.51.100.1", "2001:db8::1"}, "2001:db8::1"},
+		{[]string{"unknown"}, ""},
+	}
+	for _, tt := range tests {
+		if got := forwardedIP(http.Header{"X-Forwarded-For": tt.values}); got != tt.want {
+			t.Errorf("forwardedIP(%q) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+// TestCSRF checks that mutating RPCs authenticated with a session cookie
+// require the session's CSRF token.
+func TestCSRF(t *testing.T) {
+	ctx := context.Background()
+	client, queries := newTestClient(t)
+
+	key, err := EnsureKey(ctx, queries, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	login, err := client.Login(ctx, connect.NewRequest(&LoginRequest{ApiKey: key}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var session, csrf *http.Cookie
+	for _, c := range login.Header().Values("Set-Cookie") {
+		cookie, err := http.ParseSetCookie(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch cookie.Name {
+		case SessionCookie:
+			session = cookie
+		case CSRFCookie:
+			csrf = cookie
+		}
+	}
+	if session == nil || csrf == nil || csrf.HttpOnly {
+		t.Fatalf("login cookies: session %v, csrf %v", session, csrf)
+	}
+
+	create := func(token string) error {
+		req := connect.NewRequest(&CreateAPIKeyRequest{Name: "ci"})
+		req.Header().Set("Cookie", session.String())
+		if token != "" {
+			req.Header().Set(CSRFHeader, token)
+		}
+		_, err := client.CreateAPIKey(ctx, req)
+		return err
+	}
+	if err := create(""); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("CreateAPIKey without csrf token: got %v, want permission denied", err)
+	}
+	if err := create("forged"); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("CreateAPIKey with wrong csrf token: got %v, want permission denied", err)
+	}
+	if err := create(csrf.Value); err != nil {
+		t.Errorf("CreateAPIKey with csrf token: %v", err)
+	}
+
+	// API keys don't need a CSRF token.
+	_, err = client.CreateAPIKey(ctx, withBearer(connect.NewRequest(&CreateAPIKeyRequest{Name: "bot"}), key))
+	if err != nil {
+		t.Errorf("CreateAPIKey with api key: %v", err)
+	}
+
+	// Sessions without the CSRF cookie get it from GetSession.
+	req := connect.NewRequest(&GetSessionRequest{})
+	req.Header().Set("Cookie", session.String())
+	res, err := client.GetSession(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reissued, err := http.ParseSetCookie(res.Header().Get("Set-Cookie"))
+	if err != nil || reissued.Name != CSRFCookie || reissued.Value != csrf.Value {
+		t.Errorf("GetSession cookie = %v, %v; want csrf cookie", reissued, err)
+	}
+}
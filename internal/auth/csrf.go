@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// CSRFCookie is the name of the cookie holding the CSRF token of a
+	// session. Unlike the session cookie, it's readable by the web UI, which
+	// echoes it in the CSRFHeader of mutating requests.
+	CSRFCookie = "blippy_csrf"
+
+	// CSRFHeader is the request header carrying the CSRF token.
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// ErrCSRF is returned when a mutating request authenticated with a session
+// cookie has a missing or wrong CSRF token.
+var ErrCSRF = errors.New("missing or invalid csrf token")
+
+// csrfToken returns the CSRF token of a session. It's derived from the
+// session token, so it needs no storage, and can't be computed by other
+// sites, which can't read the session cookie.
+func csrfToken(sessionToken string) string {
+	sum := sha256.Sum256([]byte("csrf:" + sessionToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// csrfCookie returns the cookie holding the CSRF token of a session. An empty
+// session token returns a cookie that clears it.
+func csrfCookie(sessionToken string, secure bool, now time.Time) *http.Cookie {
+	c := &http.Cookie{
+		Name:     CSRFCookie,
+		Path:     "/",
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if sessionToken == "" {
+		c.MaxAge = -1
+	} else {
+		c.Value = csrfToken(sessionToken)
+		c.Expires = now.Add(SessionTTL)
+	}
+	return c
+}
+
+// checkCSRF returns ErrCSRF if a request authenticated with a session cookie
+// doesn't carry the session's CSRF token. Requests with an API key in the
+// Authorization header can't be forged by browsers, so they aren't checked.
+func checkCSRF(header http.Header) error {
+	if strings.HasPrefix(header.Get("Authorization"), "Bearer ") {
+		return nil
+	}
+	token := sessionToken(header)
+	if token == "" {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(header.Get(CSRFHeader)), []byte(csrfToken(token))) != 1 {
+		return ErrCSRF
+	}
+	return nil
+}
+
+// csrfCookieValue returns the CSRF cookie sent with a request, if any.
+func csrfCookieValue(header http.Header) string {
+	r := http.Request{Header: header}
+	c, err := r.Cookie(CSRFCookie)
+	if err != nil {
+		return ""
+	}
+	return c.Value
+}
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/audit"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/system"
+)
+
+// publicProcedures can be called without credentials.
+var publicProcedures = map[string]bool{
+	AuthServiceLoginProcedure:           true,
+	AuthServiceLogoutProcedure:          true,
+	AuthServiceGetLoginOptionsProcedure: true,
+}
+
+// adminProcedures can only be called by admins.
+var adminProcedures = map[string]bool{
+	AuthServiceCreateAPIKeyProcedure:                    true,
+	AuthServiceListAPIKeysProcedure:                     true,
+	AuthServiceRevokeAPIKeyProcedure:                    true,
+	audit.AuditServiceListAuditEntriesProcedure:         true,
+	system.SystemServiceUpdateMaintenanceModeProcedure:  true,
+	system.SystemServiceUpdateInstancePreambleProcedure: true,
+	system.SystemServiceGetBrokerStatsProcedure:         true,
+	system.SystemServiceReplayTurnProcedure:             true,
+	system.SystemServiceExportManifestProcedure:         true,
+	system.SystemServiceListProviderKeysProcedure:       true,
+	system.SystemServiceCreateProviderKeyProcedure:      true,
+	system.SystemServiceDeleteProviderKeyProcedure:      true,
+	system.SystemServiceCreateLLMCaptureProcedure:       true,
+	system.SystemServiceListLLMCapturesProcedure:        true,
+	system.SystemServiceDeleteLLMCaptureProcedure:       true,
+	system.SystemServiceListLLMExchangesProcedure:       true,
+}
+
+// interceptor rejects unauthenticated RPCs, except public ones, and RPCs the
+// caller's role doesn't allow. The request's principal is stored in the
+// context, and set as the audit log actor.
+type interceptor struct {
+	queries *store.Queries
+	logger  *slog.Logger
+	guard   *guard
+	now     func() time.Time
+}
+
+// NewInterceptor returns an interceptor that enforces authentication on
+// unary and streaming RPCs, with the default lockout options.
+func NewInterceptor(queries *store.Queries, logger *slog.Logger) connect.Interceptor {
+	return newInterceptor(queries, logger, newGuard(queries, logger, LockoutOptions{
+		Threshold: DefaultLockoutThreshold,
+		Duration:  DefaultLockoutDuration,
+	}))
+}
+
+func newInterceptor(queries *store.Queries, logger *slog.Logger, g *guard) *interceptor {
+	return &interceptor{
+		queries: queries,
+		logger:  logger,
+		guard:   g,
+		now:     time.Now,
+	}
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := i.authenticate(ctx, req.Spec().Procedure, req.Peer().Addr, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		if err := i.checkAgents(ctx, req.Spec().Procedure, req.Any()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.authenticate(ctx, conn.Spec().Procedure, conn.Peer().Addr, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		// Messages are checked as the handler receives them.
+		return next(ctx, &agentCheckingConn{StreamingHandlerConn: conn, ctx: ctx, i: i})
+	}
+}
+
+func (i *interceptor) authenticate(ctx context.Context, procedure, remoteAddr string, header http.Header) (context.Context, error) {
+	if publicProcedures[procedure] {
+		return ctx, nil
+	}
+
+	now := i.now()
+	p, err := i.guard.authenticate(ctx, i.guard.newAttempt(procedure, remoteAddr, header), header, now)
+	if lockedOut := (*LockedOutError)(nil); errors.As(err, &lockedOut) {
+		return nil, lockedOutError(lockedOut)
+	}
+	if errors.Is(err, ErrUnauthenticated) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if err != nil {
+		i.logger.Error("failed to authenticate request", "procedure", procedure, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to authenticate request"))
+	}
+
+	if adminProcedures[procedure] && p.Role != RoleAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("admin role required"))
+	}
+	if !selfProcedures[procedure] && !p.Scope.Allows(procedureScope(procedure)) {
+		return nil, connect.NewError(connect.CodePermissionDenied, ErrScopeNotAllowed)
+	}
+	if procedureScope(procedure) != ScopeRead {
+		if err := checkCSRF(header); err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+	}
+
+	touchKey(ctx, i.queries, i.logger, p, now)
+
+	ctx = withPrincipal(ctx, p)
+	return audit.WithActor(ctx, p.Actor()), nil
+}
+
+// lockedOutError returns a resource exhausted error with a Retry-After
+// header.
+func lockedOutError(err *LockedOutError) error {
+	connectErr := connect.NewError(connect.CodeResourceExhausted, err)
+	connectErr.Meta().Set("Retry-After", retryAfter(err.RetryAfter))
+	return connectErr
+}
+
+func retryAfter(d time.Duration) string {
+	return strconv.Itoa(int(d.Round(time.Second).Seconds()))
+}
+
+// checkAgents rejects requests about agents the caller's key isn't allowed
+// to access.
+func (i *interceptor) checkAgents(ctx context.Context, procedure string, msg any) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	err := checkAgents(ctx, i.queries, p, procedure, msg)
+	if errors.Is(err, ErrAgentNotAllowed) {
+		return connect.NewError(connect.CodePermissionDenied, err)
+	}
+	if err != nil {
+		i.logger.Error("failed to check agent access", "procedure", procedure, "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to check agent access"))
+	}
+	return nil
+}
+
+// agentCheckingConn checks the agents of each message received on a stream.
+type agentCheckingConn struct {
+	connect.StreamingHandlerConn
+	ctx context.Context
+	i   *interceptor
+}
+
+func (c *agentCheckingConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	return c.i.checkAgents(c.ctx, c.Spec().Procedure, msg)
+}
+
+// touchKey records that the principal's API key was used, at most once per
+// touchInterval.
+func touchKey(ctx context.Context, queries *store.Queries, logger *slog.Logger, p Principal, now time.Time) {
+	k := p.APIKey
+	if k == nil || (k.LastUsedAt.Valid && !lastUsedBefore(*k, now.Add(-touchInterval))) {
+		return
+	}
+	err := queries.TouchAPIKey(ctx, store.TouchAPIKeyParams{
+		LastUsedAt: nullTime(now),
+		ID:         k.ID,
+	})
+	if err != nil {
+		logger.Error("failed to update api key last used time", "api_key_id", k.ID, "error", err)
+	}
+}
+
+func lastUsedBefore(apiKey store.ApiKey, t time.Time) bool {
+	lastUsed, err := time.Parse(time.RFC3339, apiKey.LastUsedAt.String)
+	return err != nil || lastUsed.Before(t)
+}
@@ -0,0 +1,298 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/audit"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// Lockout defaults.
+const (
+	DefaultLockoutThreshold = 10
+	DefaultLockoutDuration  = 15 * time.Minute
+)
+
+// maxTrackedSources bounds the memory used for tracking failures. When it's
+// reached, sources that aren't locked out are forgotten first.
+const maxTrackedSources = 10000
+
+// Audit log actions for authentication failures.
+const (
+	ActionAuthFailure = "auth_failure"
+	ActionLockout     = "lockout"
+)
+
+// LockoutOptions configures brute-force protection.
+type LockoutOptions struct {
+	// Threshold is the number of failed attempts from a client IP after
+	// which further attempts from it are rejected for Duration. 0 disables
+	// lockout; failures are still audited.
+	Threshold int
+	// Duration is both the window in which failures are counted and how long
+	// a lockout lasts.
+	Duration time.Duration
+	// TrustProxyHeaders uses the last X-Forwarded-For address as the client
+	// IP, for instances behind a reverse proxy.
+	TrustProxyHeaders bool
+}
+
+// LockedOutError is returned while a client IP is locked out.
+type LockedOutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedOutError) Error() string {
+	return fmt.Sprintf("too many failed authentication attempts, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+// attempt describes an authentication attempt, for tracking and auditing
+// failures.
+type attempt struct {
+	ip         string
+	keyPrefix  string // display prefix of the presented API key, if any
+	procedure  string // RPC procedure or HTTP path
+	remoteAddr string
+	userAgent  string
+}
+
+// sources returns the sources of an attempt whose failures are counted. Only
+// the client IP is locked out: key prefixes are public display prefixes, so
+// locking them out would let anyone who knows one lock out the key. Their
+// failures are counted for the audit log only.
+func (a attempt) sources() []string {
+	sources := []string{"ip:" + a.ip}
+	if a.keyPrefix != "" {
+		sources = append(sources, "key:"+a.keyPrefix)
+	}
+	return sources
+}
+
+// lockable reports whether a source can be locked out.
+func lockable(source string) bool {
+	return strings.HasPrefix(source, "ip:")
+}
+
+type failures struct {
+	count       int
+	since       time.Time
+	lockedUntil time.Time
+}
+
+// guard tracks failed authentication attempts in memory, locks out sources
+// with too many failures, and records failures in the audit log.
+type guard struct {
+	opts    LockoutOptions
+	queries *store.Queries
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	sources map[string]*failures
+}
+
+func newGuard(queries *store.Queries, logger *slog.Logger, opts LockoutOptions) *guard {
+	return &guard{
+		opts:    opts,
+		queries: queries,
+		logger:  logger,
+		sources: make(map[string]*failures),
+	}
+}
+
+// newAttempt describes an attempt from a request's headers and peer
+// address.
+func (g *guard) newAttempt(procedure, remoteAddr string, header http.Header) attempt {
+	a := attempt{
+		ip:         remoteIP(remoteAddr),
+		procedure:  procedure,
+		remoteAddr: remoteAddr,
+		userAgent:  header.Get("User-Agent"),
+	}
+	if g.opts.TrustProxyHeaders {
+		if ip := forwardedIP(header); ip != "" {
+			a.ip = ip
+			a.remoteAddr = ip
+		}
+	}
+	if token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok {
+		a.keyPrefix = presentedPrefix(strings.TrimSpace(token))
+	}
+	return a
+}
+
+// check returns an error if an attempt's client IP is locked out.
+func (g *guard) check(a attempt, now time.Time) *LockedOutError {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if f, ok := g.sources["ip:"+a.ip]; ok && now.Before(f.lockedUntil) {
+		return &LockedOutError{RetryAfter: f.lockedUntil.Sub(now)}
+	}
+	return nil
+}
+
+// fail records a failed attempt in the audit log, and locks out its client IP
+// once it reaches the threshold.
+func (g *guard) fail(ctx context.Context, a attempt, reason string, now time.Time) {
+	g.mu.Lock()
+	var lockouts []string
+	details := map[string]any{"reason": reason}
+	for _, src := range a.sources() {
+		f, ok := g.sources[src]
+		if !ok || now.Sub(f.since) > g.opts.Duration {
+			if !ok && len(g.sources) >= maxTrackedSources {
+				g.prune(now)
+			}
+			f = &failures{since: now}
+			g.sources[src] = f
+		}
+		f.count++
+		if !lockable(src) {
+			details["key_failures"] = f.count
+			continue
+		}
+		details["failures"] = f.count
+		if g.opts.Threshold > 0 && f.count >= g.opts.Threshold && !now.Before(f.lockedUntil) {
+			f.lockedUntil = now.Add(g.opts.Duration)
+			lockouts = append(lockouts, src)
+		}
+	}
+	g.mu.Unlock()
+
+	g.record(ctx, a, ActionAuthFailure, details)
+	for _, src := range lockouts {
+		g.logger.Warn("locking out after failed authentication attempts", "source", src, "duration", g.opts.Duration)
+		g.record(ctx, a, ActionLockout, map[string]any{
+			"source": src,
+			"until":  now.Add(g.opts.Duration).UTC().Format(time.RFC3339),
+		})
+	}
+}
+
+// succeed forgets the failures of an attempt's sources, except for a
+// lockout of its client IP.
+func (g *guard) succeed(a attempt, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, src := range a.sources() {
+		if f, ok := g.sources[src]; ok && !now.Before(f.lockedUntil) {
+			delete(g.sources, src)
+		}
+	}
+}
+
+// prune forgets sources whose failures and lockout have expired, and if that
+// isn't enough, sources that aren't locked out. Must be called with mu held.
+func (g *guard) prune(now time.Time) {
+	for src, f := range g.sources {
+		if now.Sub(f.since) > g.opts.Duration && !now.Before(f.lockedUntil) {
+			delete(g.sources, src)
+		}
+	}
+	for src, f := range g.sources {
+		if len(g.sources) < maxTrackedSources {
+			return
+		}
+		if !now.Before(f.lockedUntil) {
+			delete(g.sources, src)
+		}
+	}
+}
+
+func (g *guard) record(ctx context.Context, a attempt, action string, details map[string]any) {
+	resourceType, resourceID := "client", a.ip
+	if a.keyPrefix != "" {
+		resourceType, resourceID = "api_key", a.keyPrefix
+	}
+	b, _ := json.Marshal(details)
+	err := audit.Record(ctx, g.queries, audit.Entry{
+		Actor:        audit.AnonymousActor,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Procedure:    a.procedure,
+		Details:      string(b),
+		RemoteAddr:   a.remoteAddr,
+		UserAgent:    a.userAgent,
+	})
+	if err != nil {
+		g.logger.Error("failed to record audit entry", "action", action, "error", err)
+	}
+}
+
+// authenticate authenticates a request, unless its client IP is locked out,
+// and tracks the outcome.
+func (g *guard) authenticate(ctx context.Context, a attempt, header http.Header, now time.Time) (Principal, error) {
+	if err := g.check(a, now); err != nil {
+		return Principal{}, err
+	}
+	p, err := authenticate(ctx, g.queries, header, now)
+	switch {
+	case errors.Is(err, ErrUnauthenticated):
+		// Only presented API keys count as failures. Requests without
+		// credentials, such as the web UI checking for a session, and stale
+		// session cookies aren't attempts to guess them.
+		if strings.HasPrefix(header.Get("Authorization"), "Bearer ") {
+			g.fail(ctx, a, reasonInvalidKey, now)
+		}
+	case err == nil:
+		g.succeed(a, now)
+	}
+	return p, err
+}
+
+const reasonInvalidKey = "invalid or revoked api key"
+
+// presentedPrefix returns the display prefix of a presented API key, so
+// failures can be attributed to the key being guessed or retried in the audit
+// log.
+func presentedPrefix(key string) string {
+	if !strings.HasPrefix(key, KeyPrefix) || len(key) < displayPrefixLen {
+		return ""
+	}
+	return key[:displayPrefixLen]
+}
+
+// ClientIP returns the IP address of a request's client: its peer or, with
+// trustProxyHeaders, the last X-Forwarded-For address.
+func ClientIP(r *http.Request, trustProxyHeaders bool) string {
+	if trustProxyHeaders {
+		if ip := forwardedIP(r.Header); ip != "" {
+			return ip
+		}
+	}
+	return remoteIP(r.RemoteAddr)
+}
+
+// remoteIP returns the IP address of a host:port address.
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// forwardedIP returns the last address in X-Forwarded-For, which is the
+// client as seen by the reverse proxy in front of Blippy.
+func forwardedIP(header http.Header) string {
+	values := header.Values("X-Forwarded-For")
+	if len(values) == 0 {
+		return ""
+	}
+	last := values[len(values)-1]
+	ip := strings.TrimSpace(last[strings.LastIndex(last, ",")+1:])
+	if net.ParseIP(ip) == nil {
+		return ""
+	}
+	return ip
+}
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"cmp"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/oidc"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+const (
+	// oidcCookie holds the state, nonce, PKCE verifier and redirect path of
+	// a login in progress.
+	oidcCookie = "blippy_oidc"
+	// oidcLoginTTL is how long a user has to complete a login at the provider.
+	oidcLoginTTL = 10 * time.Minute
+)
+
+// OIDCOptions configures login with an OpenID Connect provider.
+type OIDCOptions struct {
+	Provider *oidc.Provider
+	// RedirectURL is the callback URL registered at the provider. Defaults
+	// to /auth/oidc/callback on the host the login was started from.
+	RedirectURL string
+	// GroupsClaim is the ID token claim listing the user's groups. Defaults
+	// to "groups".
+	GroupsClaim string
+	// Roles maps group names to roles.
+	Roles map[string]string
+	// DefaultRole is the role of users in no mapped group. If empty, they
+	// can't log in.
+	DefaultRole string
+}
+
+// ParseRoleMapping parses a comma-separated list of group=role pairs, e.g.
+// "blippy-admins=admin,engineering=member".
+func ParseRoleMapping(s string) (map[string]string, error) {
+	roles := make(map[string]string)
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		group, role, ok := strings.Cut(pair, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !ok || group == "" {
+			return nil, fmt.Errorf("invalid role mapping %q, expected group=role", pair)
+		}
+		if !ValidRole(role) {
+			return nil, fmt.Errorf("invalid role %q for group %q", role, group)
+		}
+		roles[group] = role
+	}
+	return roles, nil
+}
+
+// roleForGroups returns the most privileged role any of groups maps to, or
+// defaultRole if none do.
+func roleForGroups(groups []string, roles map[string]string, defaultRole string) string {
+	role := ""
+	for _, g := range groups {
+		switch roles[g] {
+		case RoleAdmin:
+			return RoleAdmin
+		case RoleMember:
+			role = RoleMember
+		}
+	}
+	return cmp.Or(role, defaultRole)
+}
+
+// oidcHandler serves /auth/oidc/login, which sends the user to the provider,
+// and /auth/oidc/callback, which creates a session for the returning user.
+type oidcHandler struct {
+	opts    OIDCOptions
+	queries *store.Queries
+	logger  *slog.Logger
+}
+
+func (h *oidcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/auth/oidc/login":
+		h.login(w, r)
+	case "/auth/oidc/callback":
+		h.callback(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *oidcHandler) login(w http.ResponseWriter, r *http.Request) {
+	state, nonce, verifier := oidc.RandomString(), oidc.RandomString(), oidc.RandomString()
+	redirect := base64.RawURLEncoding.EncodeToString([]byte(safeRedirect(r.URL.Query().Get("redirect"))))
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookie,
+		Value:    strings.Join([]string{state, nonce, verifier, redirect}, "."),
+		Path:     "/auth/oidc/",
+		MaxAge:   int(oidcLoginTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   requestIsSecure(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, h.opts.Provider.AuthCodeURL(h.redirectURL(r), state, nonce, verifier), http.StatusFound)
+}
+
+func (h *oidcHandler) callback(w http.ResponseWriter, r *http.Request) {
+	// Clear the login cookie, so it can't be used twice.
+	http.SetCookie(w, &http.Cookie{Name: oidcCookie, Path: "/auth/oidc/", MaxAge: -1})
+
+	if e := r.URL.Query().Get("error"); e != "" {
+		h.fail(w, r, "provider returned error", e)
+		return
+	}
+
+	c, err := r.Cookie(oidcCookie)
+	if err != nil {
+		h.fail(w, r, "login expired", "missing login cookie")
+		return
+	}
+	parts := strings.Split(c.Value, ".")
+	if len(parts) != 4 || parts[0] != r.URL.Query().Get("state") {
+		h.fail(w, r, "login expired", "state mismatch")
+		return
+	}
+	nonce, verifier := parts[1], parts[2]
+	redirect, _ := base64.RawURLEncoding.DecodeString(parts[3])
+
+	rawToken, err := h.opts.Provider.Exchange(r.Context(), h.redirectURL(r), r.URL.Query().Get("code"), verifier)
+	if err != nil {
+		h.fail(w, r, "login failed", err.Error())
+		return
+	}
+	claims, err := h.opts.Provider.Verify(r.Context(), rawToken, nonce)
+	if err != nil {
+		h.fail(w, r, "login failed", err.Error())
+		return
+	}
+
+	groups := claims.Strings(cmp.Or(h.opts.GroupsClaim, "groups"))
+	role := roleForGroups(groups, h.opts.Roles, h.opts.DefaultRole)
+	if role == "" {
+		h.logger.Warn("oidc user has no role", "subject", claims.Subject, "email", claims.Email, "groups", groups)
+		http.Redirect(w, r, "/login?error=forbidden", http.StatusFound)
+		return
+	}
+
+	now := time.Now()
+	token, err := createSession(r.Context(), h.queries, Principal{
+		Role:    role,
+		Subject: claims.Subject,
+		Name:    claims.Name,
+		Email:   claims.Email,
+	}, now)
+	if err != nil {
+		h.logger.Error("failed to create session", "error", err)
+		http.Error(w, "failed to create session", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, sessionCookie(token, requestIsSecure(r), now))
+	http.SetCookie(w, csrfCookie(token, requestIsSecure(r), now))
+	http.Redirect(w, r, safeRedirect(string(redirect)), http.StatusFound)
+}
+
+// fail logs why a login failed, and sends the user back to the login page.
+func (h *oidcHandler) fail(w http.ResponseWriter, r *http.Request, msg, reason string) {
+	h.logger.Warn("oidc login failed", "reason", reason)
+	http.Redirect(w, r, "/login?error="+url.QueryEscape(msg), http.StatusFound)
+}
+
+func (h *oidcHandler) redirectURL(r *http.Request) string {
+	if h.opts.RedirectURL != "" {
+		return h.opts.RedirectURL
+	}
+	scheme := "http"
+	if requestIsSecure(r) {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/auth/oidc/callback"
+}
+
+// safeRedirect returns path if it's a local path, and "/" otherwise, to
+// prevent open redirects.
+func safeRedirect(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return "/"
+	}
+	return path
+}
+
+func requestIsSecure(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
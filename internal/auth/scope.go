@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// Scopes are the operations an API key can be restricted to.
+const (
+	ScopeRead    = "read"    // Get, List and Watch RPCs
+	ScopeWrite   = "write"   // RPCs that create, change or delete resources
+	ScopeChat    = "chat"    // Sending chat messages
+	ScopeWebhook = "webhook" // Triggering agent runs with /webhooks/trigger
+)
+
+// Scopes lists all scopes.
+var Scopes = []string{ScopeRead, ScopeWrite, ScopeChat, ScopeWebhook}
+
+// selfProcedures can be called with any scope, as they only concern the
+// caller's own session.
+var selfProcedures = map[string]bool{
+	AuthServiceGetSessionProcedure: true,
+	AuthServiceLogoutProcedure:     true,
+}
+
+// KeyScope restricts what an API key can access. Restricted keys have the
+// member role. The zero value is unrestricted.
+type KeyScope struct {
+	// AgentIDs are the agents the key can access. Empty allows all agents,
+	// and RPCs that aren't about a specific agent.
+	AgentIDs []string
+	// Scopes are the operations the key can perform. Empty allows all.
+	Scopes []string
+}
+
+// Restricted reports whether the scope restricts anything.
+func (s KeyScope) Restricted() bool {
+	return len(s.AgentIDs) > 0 || len(s.Scopes) > 0
+}
+
+// Allows reports whether the scope allows an operation.
+func (s KeyScope) Allows(scope string) bool {
+	return len(s.Scopes) == 0 || slices.Contains(s.Scopes, scope)
+}
+
+// AllowsAgent reports whether the scope allows access to an agent.
+func (s KeyScope) AllowsAgent(agentID string) bool {
+	return len(s.AgentIDs) == 0 || slices.Contains(s.AgentIDs, agentID)
+}
+
+// Validate checks that all scopes are known, and that agents are given by ID.
+func (s KeyScope) Validate() error {
+	for _, scope := range s.Scopes {
+		if !slices.Contains(Scopes, scope) {
+			return fmt.Errorf("unknown scope %q, expected one of %s", scope, strings.Join(Scopes, ", "))
+		}
+	}
+	if slices.Contains(s.AgentIDs, "") {
+		return errors.New("agent IDs must not be empty")
+	}
+	return nil
+}
+
+// keyScope decodes the scope stored with an API key.
+func keyScope(k store.ApiKey) KeyScope {
+	var s KeyScope
+	// The columns are only written by CreateKey, which encodes them.
+	_ = json.Unmarshal([]byte(cmp.Or(k.AgentIds, "[]")), &s.AgentIDs)
+	_ = json.Unmarshal([]byte(cmp.Or(k.Scopes, "[]")), &s.Scopes)
+	return s
+}
+
+// procedureScope returns the scope needed to call a procedure.
+func procedureScope(procedure string) string {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	switch {
+	case method == "Chat":
+		return ScopeChat
+	case method == "ServerReflectionInfo",
+		strings.HasPrefix(method, "Export"),
+		strings.HasPrefix(method, "Get"),
+		strings.HasPrefix(method, "List"),
+		strings.HasPrefix(method, "Search"),
+		strings.HasPrefix(method, "Watch"):
+		return ScopeRead
+	}
+	return ScopeWrite
+}
+
+// requestAgents returns the agents a request accesses: the agent_id field,
+// and the agent owning the agent, conversation, trigger, trigger run, eval
+// case or eval run the request is about, or the conversations and triggers
+// of a batch request. Returns nil if the request isn't about specific
+// agents, such as listing without an agent filter.
+func requestAgents(ctx context.Context, queries *store.Queries, procedure string, msg any) ([]string, error) {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return nil, nil
+	}
+	r := m.ProtoReflect()
+	field := func(name protoreflect.Name) string {
+		fd := r.Descriptor().Fields().ByName(name)
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.Cardinality() == protoreflect.Repeated {
+			return ""
+		}
+		return r.Get(fd).String()
+	}
+	// ids is the repeated "ids" field of batch requests.
+	var ids []string
+	if fd := r.Descriptor().Fields().ByName("ids"); fd != nil && fd.Kind() == protoreflect.StringKind && fd.IsList() {
+		list := r.Get(fd).List()
+		for i := range list.Len() {
+			ids = append(ids, list.Get(i).String())
+		}
+	}
+
+	var agents []string
+	if id := field("agent_id"); id != "" {
+		agents = append(agents, id)
+	}
+
+	service := procedure[1:max(strings.LastIndex(procedure, "/"), 1)]
+	switch service {
+	case "blippy.agent.AgentService":
+		if id := field("id"); id != "" {
+			agents = append(agents, id)
+		}
+	case "blippy.conversation.ConversationService":
+		if id := cmp.Or(field("conversation_id"), field("id")); id != "" {
+			conv, err := queries.GetConversation(ctx, id)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			// Unknown conversations are attributed to no agent, which
+			// restricted keys can't access.
+			agents = append(agents, conv.AgentID)
+		}
+		for _, id := range ids {
+			conv, err := queries.GetConversation(ctx, id)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			agents = append(agents, conv.AgentID)
+		}
+	case "blippy.trigger.TriggerService":
+		if strings.HasSuffix(procedure, "/GetTriggerRun") {
+			if id := field("id"); id != "" {
+				run, err := queries.GetTriggerRun(ctx, id)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, err
+				}
+				// Unknown runs have no trigger, so they're attributed to
+				// no agent.
+				trigger, err := queries.GetTrigger(ctx, run.TriggerID)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, err
+				}
+				agents = append(agents, trigger.AgentID)
+			}
+		} else if id := field("conversation_id"); id != "" {
+			// Triggers drafted from a conversation are for its agent.
+			conv, err := queries.GetConversation(ctx, id)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			agents = append(agents, conv.AgentID)
+		} else if id := cmp.Or(field("trigger_id"), field("id")); id != "" {
+			trigger, err := queries.GetTrigger(ctx, id)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			agents = append(agents, trigger.AgentID)
+		}
+		for _, id := range ids {
+			trigger, err := queries.GetTrigger(ctx, id)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			agents = append(agents, trigger.AgentID)
+		}
+	case "blippy.notification.NotificationChannelService":
+		// Telegram channels run their agent for messages to their bot.
+		if field("type") == tool.TelegramChannelType {
+			var cfg tool.TelegramConfig
+			if err := json.Unmarshal([]byte(field("config")), &cfg); err == nil && cfg.AgentID != "" {
+				agents = append(agents, cfg.AgentID)
+			}
+		}
+	case "blippy.system.SystemService":
+		if strings.HasSuffix(procedure, "/GetRunTrace") {
+			if id := field("run_id"); id != "" {
+				trace, err := queries.GetRunTrace(ctx, id)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, err
+				}
+				agents = append(agents, trace.AgentID)
+			} else if id := field("conversation_id"); id != "" {
+				conv, err := queries.GetConversation(ctx, id)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, err
+				}
+				agents = append(agents, conv.AgentID)
+			}
+		}
+	case "blippy.eval.EvalService":
+		if id := field("id"); id != "" {
+			var agentID string
+			if strings.HasSuffix(procedure, "EvalRun") {
+				run, err := queries.GetEvalRun(ctx, id)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, err
+				}
+				agentID = run.AgentID
+			} else {
+				c, err := queries.GetEvalCase(ctx, id)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return nil, err
+				}
+				agentID = c.AgentID
+			}
+			agents = append(agents, agentID)
+		}
+	}
+	return agents, nil
+}
+
+// checkAgents returns an error if the principal's key scope doesn't allow a
+// request's agents. Agent-restricted keys can only make requests about their
+// agents.
+func checkAgents(ctx context.Context, queries *store.Queries, p Principal, procedure string, msg any) error {
+	if len(p.Scope.AgentIDs) == 0 || selfProcedures[procedure] {
+		return nil
+	}
+	agents, err := requestAgents(ctx, queries, procedure, msg)
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		return ErrAgentNotAllowed
+	}
+	for _, id := range agents {
+		if !p.Scope.AllowsAgent(id) {
+			return ErrAgentNotAllowed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dstotijn/blippy/internal/audit"
+	"github.com/dstotijn/blippy/internal/listing"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// Options configures the auth service.
+type Options struct {
+	// Disabled allows all RPCs without credentials.
+	Disabled bool
+	// OIDC enables login with an OpenID Connect provider, if set.
+	OIDC *OIDCOptions
+	// Lockout configures brute-force protection for API keys.
+	Lockout LockoutOptions
+}
+
+type Service struct {
+	queries  *store.Queries
+	logger   *slog.Logger
+	disabled bool
+	oidc     *oidcHandler
+	guard    *guard
+}
+
+func NewService(db *sql.DB, logger *slog.Logger, opts Options) *Service {
+	s := &Service{
+		queries:  store.New(db),
+		logger:   logger,
+		disabled: opts.Disabled,
+	}
+	s.guard = newGuard(s.queries, logger, opts.Lockout)
+	if opts.OIDC != nil && !opts.Disabled {
+		s.oidc = &oidcHandler{opts: *opts.OIDC, queries: s.queries, logger: logger}
+	}
+	return s
+}
+
+// Disabled reports whether authentication is disabled.
+func (s *Service) Disabled() bool {
+	return s.disabled
+}
+
+// OIDCHandler returns the handler for /auth/oidc/, or nil if OIDC login isn't
+// configured.
+func (s *Service) OIDCHandler() http.Handler {
+	if s.oidc == nil {
+		return nil
+	}
+	return s.oidc
+}
+
+// Interceptor returns an interceptor that enforces authentication. It must
+// not be used if auth is disabled.
+func (s *Service) Interceptor() connect.Interceptor {
+	return newInterceptor(s.queries, s.logger, s.guard)
+}
+
+// Middleware authenticates plain HTTP requests, such as webhooks, and
+// requires the caller's key to have scope. The principal is stored in the
+// request context, see AllowsAgent. Requests pass through if auth is
+// disabled.
+func (s *Service) Middleware(scope string, next http.Handler) http.Handler {
+	if s.disabled {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		p, err := s.guard.authenticate(r.Context(), s.guard.newAttempt(r.URL.Path, r.RemoteAddr, r.Header), r.Header, now)
+		if lockedOut := (*LockedOutError)(nil); errors.As(err, &lockedOut) {
+			w.Header().Set("Retry-After", retryAfter(lockedOut.RetryAfter))
+			http.Error(w, "Too many failed authentication attempts", http.StatusTooManyRequests)
+			return
+		}
+		if errors.Is(err, ErrUnauthenticated) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Missing or invalid API key", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			s.logger.Error("failed to authenticate request", "path", r.URL.Path, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if !p.Scope.Allows(scope) {
+			http.Error(w, "API key scope doesn't allow this operation", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if err := checkCSRF(r.Header); err != nil {
+				http.Error(w, "Missing or invalid CSRF token", http.StatusForbidden)
+				return
+			}
+		}
+		touchKey(r.Context(), s.queries, s.logger, p, now)
+
+		ctx := audit.WithActor(withPrincipal(r.Context(), p), p.Actor())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// AdminMiddleware is Middleware for routes only admins may use, such as the
+// runtime diagnostics. Requests pass through if auth is disabled.
+func (s *Service) AdminMiddleware(next http.Handler) http.Handler {
+	return s.Middleware(ScopeRead, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := PrincipalFromContext(r.Context()); ok && p.Role != RoleAdmin {
+			http.Error(w, "Admin role required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}))
+}
+
+// AllowsAgent reports whether the principal of a request authenticated by
+// Middleware may access an agent. Requests without a principal, because auth
+// is disabled, are allowed.
+func AllowsAgent(ctx context.Context, agentID string) bool {
+	p, ok := PrincipalFromContext(ctx)
+	return !ok || p.Scope.AllowsAgent(agentID)
+}
+
+// AllowsAllAgents reports whether the principal of a request authenticated
+// by Middleware may access all agents, e.g. to follow their activity.
+func AllowsAllAgents(ctx context.Context) bool {
+	p, ok := PrincipalFromContext(ctx)
+	return !ok || len(p.Scope.AgentIDs) == 0
+}
+
+// AgentIDs returns the agents the principal of a request is restricted to, or
+// nil if it may access all agents.
+func AgentIDs(ctx context.Context) []string {
+	p, _ := PrincipalFromContext(ctx)
+	return p.Scope.AgentIDs
+}
+
+func (s *Service) Login(ctx context.Context, req *connect.Request[LoginRequest]) (*connect.Response[LoginResponse], error) {
+	if s.disabled {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("authentication is disabled"))
+	}
+
+	now := time.Now()
+	a := s.guard.newAttempt(req.Spec().Procedure, req.Peer().Addr, req.Header())
+	a.keyPrefix = presentedPrefix(req.Msg.ApiKey)
+	if err := s.guard.check(a, now); err != nil {
+		return nil, lockedOutError(err)
+	}
+
+	apiKey, err := verifyKey(ctx, s.queries, req.Msg.ApiKey)
+	if errors.Is(err, ErrUnauthenticated) {
+		s.guard.fail(ctx, a, reasonInvalidKey, now)
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid api key"))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.guard.succeed(a, now)
+
+	token, err := createSession(ctx, s.queries, apiKeyPrincipal(apiKey), now)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := connect.NewResponse(&LoginResponse{ApiKey: toProto(apiKey)})
+	res.Header().Add("Set-Cookie", sessionCookie(token, isSecure(req.Header()), now).String())
+	res.Header().Add("Set-Cookie", csrfCookie(token, isSecure(req.Header()), now).String())
+	return res, nil
+}
+
+func (s *Service) Logout(ctx context.Context, req *connect.Request[LogoutRequest]) (*connect.Response[LogoutResponse], error) {
+	if token := sessionToken(req.Header()); token != "" {
+		if err := s.queries.DeleteSession(ctx, hash(token)); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	res := connect.NewResponse(&LogoutResponse{})
+	res.Header().Add("Set-Cookie", sessionCookie("", isSecure(req.Header()), time.Now()).String())
+	res.Header().Add("Set-Cookie", csrfCookie("", isSecure(req.Header()), time.Now()).String())
+	return res, nil
+}
+
+func (s *Service) GetSession(ctx context.Context, req *connect.Request[GetSessionRequest]) (*connect.Response[GetSessionResponse], error) {
+	res := &GetSessionResponse{AuthDisabled: s.disabled}
+	if p, ok := PrincipalFromContext(ctx); ok {
+		res.Role = p.Role
+		res.Name = p.Name
+		res.Email = p.Email
+		if p.APIKey != nil {
+			res.ApiKey = toProto(*p.APIKey)
+		}
+	}
+
+	connectRes := connect.NewResponse(res)
+	// Issue the CSRF cookie if it's missing, e.g. for sessions created before
+	// CSRF tokens were introduced.
+	if token := sessionToken(req.Header()); token != "" && csrfCookieValue(req.Header()) != csrfToken(token) {
+		if _, ok := PrincipalFromContext(ctx); ok {
+			connectRes.Header().Add("Set-Cookie", csrfCookie(token, isSecure(req.Header()), time.Now()).String())
+		}
+	}
+	return connectRes, nil
+}
+
+func (s *Service) GetLoginOptions(ctx context.Context, req *connect.Request[GetLoginOptionsRequest]) (*connect.Response[GetLoginOptionsResponse], error) {
+	return connect.NewResponse(&GetLoginOptionsResponse{
+		OidcEnabled: s.oidc != nil,
+	}), nil
+}
+
+func (s *Service) CreateAPIKey(ctx context.Context, req *connect.Request[CreateAPIKeyRequest]) (*connect.Response[CreateAPIKeyResponse], error) {
+	if req.Msg.Name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+	}
+
+	scope := KeyScope{AgentIDs: req.Msg.AgentIds, Scopes: req.Msg.Scopes}
+	if err := scope.Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	for _, id := range scope.AgentIDs {
+		if _, err := s.queries.GetAgent(ctx, id); errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent %q not found", id))
+		} else if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	apiKey, key, err := CreateKey(ctx, s.queries, req.Msg.Name, scope)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&CreateAPIKeyResponse{
+		ApiKey: toProto(apiKey),
+		Key:    key,
+	}), nil
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context, req *connect.Request[ListAPIKeysRequest]) (*connect.Response[ListAPIKeysResponse], error) {
+	query, err := listing.Parse(listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Filter:    req.Msg.Filter,
+	}, apiKeyTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListAPIKeysPage, s.queries.CountAPIKeysPage)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoKeys := make([]*APIKey, len(page.Items))
+	for i, k := range page.Items {
+		protoKeys[i] = toProto(k)
+	}
+
+	return connect.NewResponse(&ListAPIKeysResponse{
+		ApiKeys:       protoKeys,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}), nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, req *connect.Request[RevokeAPIKeyRequest]) (*connect.Response[RevokeAPIKeyResponse], error) {
+	err := RevokeKey(ctx, s.queries, req.Msg.Id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&RevokeAPIKeyResponse{}), nil
+}
+
+// apiKeyTable has the fields usable in ListAPIKeys filters and order
+// clauses.
+var apiKeyTable = listing.Table[store.ApiKey]{
+	Fields: listing.Fields[store.ApiKey]{
+		"id":           {Column: "id", Value: func(k store.ApiKey) string { return k.ID }},
+		"name":         {Column: "name", Value: func(k store.ApiKey) string { return k.Name }},
+		"prefix":       {Column: "prefix", Value: func(k store.ApiKey) string { return k.Prefix }},
+		"created_at":   {Column: "created_at", Value: func(k store.ApiKey) string { return k.CreatedAt }},
+		"last_used_at": {Column: "COALESCE(last_used_at, '')", Value: func(k store.ApiKey) string { return k.LastUsedAt.String }},
+		"revoked_at":   {Column: "COALESCE(revoked_at, '')", Value: func(k store.ApiKey) string { return k.RevokedAt.String }},
+	},
+	Order: "created_at desc",
+}
+
+func toProto(k store.ApiKey) *APIKey {
+	createdAt, _ := time.Parse(time.RFC3339, k.CreatedAt)
+
+	scope := keyScope(k)
+	apiKey := &APIKey{
+		Id:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		CreatedAt: timestamppb.New(createdAt),
+		AgentIds:  scope.AgentIDs,
+		Scopes:    scope.Scopes,
+	}
+	if k.LastUsedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, k.LastUsedAt.String)
+		apiKey.LastUsedAt = timestamppb.New(t)
+	}
+	if k.RevokedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, k.RevokedAt.String)
+		apiKey.RevokedAt = timestamppb.New(t)
+	}
+	return apiKey
+}
@@ -0,0 +1,155 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/replica"
+)
+
+// ErrNotFound is returned when an object doesn't exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Backend stores the contents of blobs as objects by key.
+type Backend interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns ErrNotFound if the object doesn't exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete succeeds if the object doesn't exist.
+	Delete(ctx context.Context, key string) error
+	// List returns the keys of all objects.
+	List(ctx context.Context) ([]string, error)
+}
+
+// Dir is a backend storing objects as files in a local directory.
+type Dir struct {
+	path string
+}
+
+// NewDir returns a backend storing objects in the directory at path, which
+// is created if it doesn't exist.
+func NewDir(path string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return nil, fmt.Errorf("create blob directory: %w", err)
+	}
+	return &Dir{path: path}, nil
+}
+
+func (d *Dir) file(key string) (string, error) {
+	// Keys starting with a dot are reserved for temporary files.
+	if key == "" || key[0] == '.' || !filepath.IsLocal(key) || strings.ContainsAny(key, `/\`) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(d.path, key), nil
+}
+
+// Put writes an object to a temporary file first, so readers never see
+// partial objects.
+func (d *Dir) Put(ctx context.Context, key string, data []byte) error {
+	name, err := d.file(key)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(d.path, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+func (d *Dir) Get(ctx context.Context, key string) ([]byte, error) {
+	name, err := d.file(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (d *Dir) Delete(ctx context.Context, key string) error {
+	name, err := d.file(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List skips temporary files of writes in progress.
+func (d *Dir) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".tmp-") {
+			keys = append(keys, e.Name())
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// S3 is a backend storing objects in an S3-compatible bucket, under a key
+// prefix.
+type S3 struct {
+	client *replica.S3Client
+	prefix string
+}
+
+// NewS3 returns a backend storing objects with client, with keys prefixed
+// by prefix, e.g. "blobs/".
+func NewS3(client *replica.S3Client, prefix string) *S3 {
+	return &S3{client: client, prefix: prefix}
+}
+
+func (s *S3) Put(ctx context.Context, key string, data []byte) error {
+	return s.client.Put(ctx, s.prefix+key, data)
+}
+
+func (s *S3) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := s.client.Get(ctx, s.prefix+key)
+	if errors.Is(err, replica.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	err := s.client.Delete(ctx, s.prefix+key)
+	if errors.Is(err, replica.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *S3) List(ctx context.Context) ([]string, error) {
+	keys, err := s.client.List(ctx, s.prefix)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, s.prefix)
+	}
+	return keys, nil
+}
@@ -0,0 +1,332 @@
+// Package blob stores files such as message attachments, generated images and
+// exports in a Backend, a local directory or an S3-compatible bucket, with
+// their metadata in the blobs table. Blobs are downloaded with signed URLs,
+// and objects that no longer have a blob are garbage collected.
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// Kinds of blobs.
+const (
+	KindAttachment = "attachment"
+	KindImage      = "image"
+	KindExport     = "export"
+)
+
+const (
+	// DefaultGCInterval is how often CollectGarbage looks for orphaned
+	// objects.
+	DefaultGCInterval = time.Hour
+	// DefaultURLExpiry is how long signed URLs are valid if no expiry is
+	// given.
+	DefaultURLExpiry = time.Hour
+)
+
+// signingKeySetting is the settings key under which the key signing download
+// URLs is stored.
+const signingKeySetting = "blob_signing_key"
+
+// Store stores blobs in a backend.
+type Store struct {
+	queries *store.Queries
+	backend Backend
+	key     []byte
+	logger  *slog.Logger
+
+	// GCInterval is how often CollectGarbage runs; more frequent calls are
+	// skipped.
+	GCInterval time.Duration
+
+	mu     sync.Mutex
+	lastGC time.Time
+}
+
+// NewStore returns a store of blobs in backend. The key signing download
+// URLs is created on first use and stored encrypted with cipher, so URLs stay
+// valid across restarts.
+func NewStore(ctx context.Context, queries *store.Queries, backend Backend, cipher *encryption.Cipher, logger *slog.Logger) (*Store, error) {
+	key, err := loadOrCreateKey(ctx, queries, cipher)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		queries:    queries,
+		backend:    backend,
+		key:        key,
+		logger:     logger,
+		GCInterval: DefaultGCInterval,
+	}, nil
+}
+
+// PutParams describes a blob to store.
+type PutParams struct {
+	Kind string
+	// ConversationID is the conversation the blob belongs to, if any. The
+	// blob is deleted with it.
+	ConversationID string
+	// Name is the file name downloads are saved as.
+	Name        string
+	ContentType string
+	Data        []byte
+	// TTL is how long the blob is kept; 0 keeps it until it's deleted.
+	TTL time.Duration
+}
+
+// Put stores a blob. Its row is created before its object, so an object
+// always has a row while it's being garbage collected.
+func (s *Store) Put(ctx context.Context, p PutParams) (store.Blob, error) {
+	now := time.Now().UTC()
+	sum := sha256.Sum256(p.Data)
+	params := store.CreateBlobParams{
+		ID:             uuid.NewString(),
+		Kind:           p.Kind,
+		ConversationID: store.NewNullString(p.ConversationID),
+		Name:           p.Name,
+		ContentType:    p.ContentType,
+		Size:           int64(len(p.Data)),
+		Sha256:         hex.EncodeToString(sum[:]),
+		CreatedAt:      now.Format(time.RFC3339),
+	}
+	if params.ContentType == "" {
+		params.ContentType = http.DetectContentType(p.Data)
+	}
+	if p.TTL > 0 {
+		params.ExpiresAt = store.NewNullString(now.Add(p.TTL).Format(time.RFC3339))
+	}
+
+	b, err := s.queries.CreateBlob(ctx, params)
+	if err != nil {
+		return store.Blob{}, fmt.Errorf("create blob: %w", err)
+	}
+	if err := s.backend.Put(ctx, b.ID, p.Data); err != nil {
+		if err := s.queries.DeleteBlob(context.WithoutCancel(ctx), b.ID); err != nil {
+			s.logger.Error("failed to delete blob of failed upload", "blob_id", b.ID, "error", err)
+		}
+		return store.Blob{}, fmt.Errorf("store blob: %w", err)
+	}
+	return b, nil
+}
+
+// Get returns a blob and its contents. It returns ErrNotFound if the blob
+// doesn't exist or has expired.
+func (s *Store) Get(ctx context.Context, id string) (store.Blob, []byte, error) {
+	b, err := s.queries.GetBlob(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && expired(b, time.Now()) {
+		return store.Blob{}, nil, ErrNotFound
+	}
+	if err != nil {
+		return store.Blob{}, nil, fmt.Errorf("get blob: %w", err)
+	}
+	data, err := s.backend.Get(ctx, id)
+	if err != nil {
+		return store.Blob{}, nil, err
+	}
+	return b, data, nil
+}
+
+// Delete deletes a blob and its object.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	if err := s.queries.DeleteBlob(ctx, id); err != nil {
+		return fmt.Errorf("delete blob: %w", err)
+	}
+	return s.backend.Delete(ctx, id)
+}
+
+func expired(b store.Blob, now time.Time) bool {
+	if !b.ExpiresAt.Valid {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, b.ExpiresAt.String)
+	return err == nil && !now.Before(t)
+}
+
+// SignedURL returns the path of the download URL of a blob, valid for
+// expiry, or DefaultURLExpiry if expiry is 0. Anyone with the URL can
+// download the blob until then.
+func (s *Store) SignedURL(id string, expiry time.Duration) string {
+	if expiry <= 0 {
+		expiry = DefaultURLExpiry
+	}
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {s.sign(id, expires)},
+	}
+	return "/blobs/" + url.PathEscape(id) + "?" + query.Encode()
+}
+
+func (s *Store) sign(id, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(id + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Handler serves GET /blobs/{id} for signed URLs. It needs no other
+// authentication.
+func (s *Store) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /blobs/{id}", s.serveBlob)
+	return mux
+}
+
+func (s *Store) serveBlob(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	expires := r.URL.Query().Get("expires")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(r.URL.Query().Get("signature")), []byte(s.sign(id, expires))) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	if time.Now().Unix() >= unix {
+		http.Error(w, "URL expired", http.StatusForbidden)
+		return
+	}
+
+	b, data, err := s.Get(r.Context(), id)
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to get blob", "blob_id", id, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	// Only images are shown inline; anything else, like HTML, is downloaded
+	// and sandboxed, so blobs can't run scripts on this origin.
+	disposition := "attachment"
+	if strings.HasPrefix(b.ContentType, "image/") && b.ContentType != "image/svg+xml" {
+		disposition = "inline"
+	}
+	if b.Name != "" {
+		disposition = mime.FormatMediaType(disposition, map[string]string{"filename": b.Name})
+	}
+	w.Header().Set("Content-Type", b.ContentType)
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.FormatInt(max(unix-time.Now().Unix(), 0), 10))
+	w.Header().Set("ETag", `"`+b.Sha256+`"`)
+	w.Write(data)
+}
+
+// CollectGarbage deletes expired blobs, and objects without a blob, such as
+// those of deleted conversations. It has the signature of a scheduler job,
+// and runs at most once per GCInterval.
+func (s *Store) CollectGarbage(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastGC) < s.GCInterval {
+		return nil
+	}
+	s.lastGC = now
+
+	if _, err := s.queries.DeleteExpiredBlobs(ctx, store.NewNullString(now.UTC().Format(time.RFC3339))); err != nil {
+		return fmt.Errorf("delete expired blobs: %w", err)
+	}
+
+	// List objects before blobs: an object's blob is created before it, so
+	// the blobs listed afterwards include those of all listed objects.
+	keys, err := s.backend.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list objects: %w", err)
+	}
+	ids, err := s.queries.ListBlobIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("list blobs: %w", err)
+	}
+	exists := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		exists[id] = true
+	}
+
+	var deleted int
+	var errs []error
+	for _, key := range keys {
+		if exists[key] {
+			continue
+		}
+		if err := s.backend.Delete(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("delete object %s: %w", key, err))
+			continue
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		s.logger.Info("deleted orphaned blob objects", "count", deleted)
+	}
+	return errors.Join(errs...)
+}
+
+func loadOrCreateKey(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher) ([]byte, error) {
+	stored, err := queries.GetSetting(ctx, signingKeySetting)
+	if err == nil {
+		value, err := cipher.Decrypt(stored)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt blob signing key: %w", err)
+		}
+		key, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("decode blob signing key: %w", err)
+		}
+		// Encrypt a key stored before encryption was enabled.
+		if cipher.Enabled() && !encryption.IsEncrypted(stored) {
+			if err := storeKey(ctx, queries, cipher, key); err != nil {
+				return nil, err
+			}
+		}
+		return key, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get blob signing key: %w", err)
+	}
+
+	key := make([]byte, 32)
+	rand.Read(key)
+	if err := storeKey(ctx, queries, cipher, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func storeKey(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher, key []byte) error {
+	value, err := cipher.Encrypt(base64.RawURLEncoding.EncodeToString(key))
+	if err != nil {
+		return fmt.Errorf("encrypt blob signing key: %w", err)
+	}
+	if err := queries.UpsertSetting(ctx, store.UpsertSettingParams{
+		Key:       signingKeySetting,
+		Value:     value,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("store blob signing key: %w", err)
+	}
+	return nil
+}
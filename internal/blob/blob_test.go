@@ -0,0 +1,117 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := store.Open(filepath.Join(dir, "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := store.New(db)
+	backend, err := NewDir(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStore(ctx, queries, backend, nil, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := s.Put(ctx, PutParams{Kind: KindExport, Name: "manifest.json", Data: []byte(`{"agents":[]}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Size != 13 || b.ContentType != "text/plain; charset=utf-8" {
+		t.Errorf("blob = %+v", b)
+	}
+	if _, data, err := s.Get(ctx, b.ID); err != nil || string(data) != `{"agents":[]}` {
+		t.Errorf("Get = %q, %v", data, err)
+	}
+
+	// The signing key is stored, so URLs stay valid for a new store.
+	s2, err := NewStore(ctx, queries, backend, nil, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s2.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+	url := s.SignedURL(b.ID, time.Minute)
+	rec := get(url)
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"agents":[]}` {
+		t.Fatalf("download: status %d, body %q", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename=manifest.json` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+	if rec := get(strings.Replace(url, "signature=", "signature=x", 1)); rec.Code != http.StatusForbidden {
+		t.Errorf("tampered signature: status %d", rec.Code)
+	}
+	if rec := get(s.SignedURL(b.ID, -time.Minute)); rec.Code != http.StatusOK {
+		t.Errorf("default expiry: status %d", rec.Code)
+	}
+	s.key = []byte("other")
+	if rec := get(s.SignedURL(b.ID, time.Minute)); rec.Code != http.StatusForbidden {
+		t.Errorf("other key: status %d", rec.Code)
+	}
+
+	// Expired blobs are gone, and garbage collection deletes their objects
+	// and objects without a blob.
+	expiring, err := s.Put(ctx, PutParams{Kind: KindExport, Data: []byte("x"), TTL: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Put(ctx, "orphan", []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if _, _, err := s.Get(ctx, expiring.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get expired = %v, want ErrNotFound", err)
+	}
+	if err := s.CollectGarbage(ctx); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := backend.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != b.ID {
+		t.Errorf("objects after GC = %v, want [%s]", keys, b.ID)
+	}
+
+	if err := s.Delete(ctx, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Get(ctx, b.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get deleted = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDirInvalidKey(t *testing.T) {
+	d, err := NewDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"", "../x", "a/b", "."} {
+		if err := d.Put(context.Background(), key, nil); err == nil {
+			t.Errorf("Put(%q): expected error", key)
+		}
+	}
+}
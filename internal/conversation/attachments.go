@@ -0,0 +1,127 @@
+package conversation
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/blob"
+)
+
+// Limits of the attachments of a chat message. Together they stay within the
+// API's request size limit, also when sent base64-encoded as JSON.
+const (
+	maxAttachments          = 10
+	maxAttachmentBytes      = 10 << 20
+	maxTotalAttachmentBytes = 20 << 20
+)
+
+// validateAttachments checks the attachments of a chat message, and returns
+// them with their media types, detected if not given.
+func validateAttachments(attachments []*Attachment) ([]agentloop.Attachment, error) {
+	if len(attachments) > maxAttachments {
+		return nil, fmt.Errorf("at most %d attachments are allowed", maxAttachments)
+	}
+	var total int
+	validated := make([]agentloop.Attachment, len(attachments))
+	for i, a := range attachments {
+		name := path.Base(strings.ReplaceAll(a.Name, `\`, "/"))
+		if a.Name == "" || name == "." || name == "/" {
+			return nil, fmt.Errorf("attachment %d has no name", i)
+		}
+		if len(a.Data) == 0 {
+			return nil, fmt.Errorf("attachment %s is empty", name)
+		}
+		if len(a.Data) > maxAttachmentBytes {
+			return nil, fmt.Errorf("attachment %s exceeds %d MiB", name, maxAttachmentBytes>>20)
+		}
+		total += len(a.Data)
+		if total > maxTotalAttachmentBytes {
+			return nil, fmt.Errorf("attachments exceed %d MiB in total", maxTotalAttachmentBytes>>20)
+		}
+
+		mediaType, _, err := mime.ParseMediaType(cmp.Or(a.MediaType, http.DetectContentType(a.Data)))
+		if err != nil || !agentloop.SupportedAttachmentType(mediaType) {
+			return nil, fmt.Errorf("attachment %s has unsupported type %q; images, PDFs and text files are supported", name, cmp.Or(mediaType, a.MediaType))
+		}
+		if strings.HasPrefix(mediaType, "text/") && !utf8.Valid(a.Data) {
+			return nil, fmt.Errorf("attachment %s isn't valid UTF-8 text", name)
+		}
+		validated[i] = agentloop.Attachment{
+			ID:        uuid.NewString(),
+			Name:      name,
+			MediaType: mediaType,
+			Data:      a.Data,
+		}
+	}
+	return validated, nil
+}
+
+// storeAttachments stores the contents of attachments as blobs of the
+// conversation, setting their blob IDs.
+func (s *Service) storeAttachments(ctx context.Context, convID string, attachments []agentloop.Attachment) error {
+	for i, a := range attachments {
+		b, err := s.blobs.Put(ctx, blob.PutParams{
+			Kind:           blob.KindAttachment,
+			ConversationID: convID,
+			Name:           a.Name,
+			ContentType:    a.MediaType,
+			Data:           a.Data,
+		})
+		if err != nil {
+			s.deleteAttachments(context.WithoutCancel(ctx), attachments[:i])
+			return err
+		}
+		attachments[i].BlobID = b.ID
+	}
+	return nil
+}
+
+// deleteAttachments deletes the blobs of attachments that weren't sent.
+func (s *Service) deleteAttachments(ctx context.Context, attachments []agentloop.Attachment) {
+	for _, a := range attachments {
+		if err := s.blobs.Delete(ctx, a.BlobID); err != nil {
+			slog.Error("failed to delete attachment blob", "blob_id", a.BlobID, "error", err)
+		}
+	}
+}
+
+// signAttachments sets the download URLs of the attachment items of msgs,
+// which belong to a conversation.
+func (s *Service) signAttachments(ctx context.Context, convID string, msgs ...*Message) error {
+	var items []*AttachmentItem
+	for _, m := range msgs {
+		for _, item := range m.Items {
+			if a := item.GetAttachment(); a != nil {
+				items = append(items, a)
+			}
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	attachments, err := s.queries.ListAttachmentsByConversation(ctx, convID)
+	if err != nil {
+		return fmt.Errorf("list attachments: %w", err)
+	}
+	blobIDs := make(map[string]string, len(attachments))
+	for _, a := range attachments {
+		blobIDs[a.ID] = a.BlobID
+	}
+	for _, item := range items {
+		if id, ok := blobIDs[item.Id]; ok {
+			item.Url = s.blobs.SignedURL(id, 0)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package conversation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestValidateAttachments(t *testing.T) {
+	attachments, err := validateAttachments([]*Attachment{
+		{Name: `C:\Users\me\notes.txt`, Data: []byte("Buy milk")},
+		{Name: "scan.pdf", MediaType: "application/pdf", Data: []byte("%PDF-1.7")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := attachments[0]; a.Name != "notes.txt" || a.MediaType != "text/plain" || a.ID == "" {
+		t.Errorf("first attachment = %+v, want notes.txt detected as text/plain", a)
+	}
+	if a := attachments[1]; a.MediaType != "application/pdf" {
+		t.Errorf("second attachment = %+v, want application/pdf", a)
+	}
+
+	for _, tt := range []struct {
+		name        string
+		attachments []*Attachment
+		want        string
+	}{
+		{"unsupported", []*Attachment{{Name: "a.zip", MediaType: "application/zip", Data: []byte("PK")}}, "unsupported type"},
+		{"empty", []*Attachment{{Name: "a.txt", Data: nil}}, "empty"},
+		{"no name", []*Attachment{{Data: []byte("hi")}}, "no name"},
+		{"invalid text", []*Attachment{{Name: "a.txt", MediaType: "text/plain", Data: []byte{0xff, 0xfe}}}, "UTF-8"},
+		{"too large", []*Attachment{{Name: "a.txt", Data: bytes.Repeat([]byte("a"), maxAttachmentBytes+1)}}, "exceeds"},
+	} {
+		if _, err := validateAttachments(tt.attachments); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
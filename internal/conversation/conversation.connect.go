@@ -44,9 +44,24 @@ const (
 	// ConversationServiceDeleteConversationProcedure is the fully-qualified name of the
 	// ConversationService's DeleteConversation RPC.
 	ConversationServiceDeleteConversationProcedure = "/blippy.conversation.ConversationService/DeleteConversation"
+	// ConversationServiceBatchDeleteConversationsProcedure is the fully-qualified name of the
+	// ConversationService's BatchDeleteConversations RPC.
+	ConversationServiceBatchDeleteConversationsProcedure = "/blippy.conversation.ConversationService/BatchDeleteConversations"
 	// ConversationServiceGetMessagesProcedure is the fully-qualified name of the ConversationService's
 	// GetMessages RPC.
 	ConversationServiceGetMessagesProcedure = "/blippy.conversation.ConversationService/GetMessages"
+	// ConversationServiceGetConversationCostProcedure is the fully-qualified name of the
+	// ConversationService's GetConversationCost RPC.
+	ConversationServiceGetConversationCostProcedure = "/blippy.conversation.ConversationService/GetConversationCost"
+	// ConversationServiceListRunArtifactsProcedure is the fully-qualified name of the
+	// ConversationService's ListRunArtifacts RPC.
+	ConversationServiceListRunArtifactsProcedure = "/blippy.conversation.ConversationService/ListRunArtifacts"
+	// ConversationServiceGetUsageProcedure is the fully-qualified name of the ConversationService's
+	// GetUsage RPC.
+	ConversationServiceGetUsageProcedure = "/blippy.conversation.ConversationService/GetUsage"
+	// ConversationServiceSearchConversationsProcedure is the fully-qualified name of the
+	// ConversationService's SearchConversations RPC.
+	ConversationServiceSearchConversationsProcedure = "/blippy.conversation.ConversationService/SearchConversations"
 	// ConversationServiceChatProcedure is the fully-qualified name of the ConversationService's Chat
 	// RPC.
 	ConversationServiceChatProcedure = "/blippy.conversation.ConversationService/Chat"
@@ -61,7 +76,14 @@ type ConversationServiceClient interface {
 	GetConversation(context.Context, *connect.Request[GetConversationRequest]) (*connect.Response[Conversation], error)
 	ListConversations(context.Context, *connect.Request[ListConversationsRequest]) (*connect.Response[ListConversationsResponse], error)
 	DeleteConversation(context.Context, *connect.Request[DeleteConversationRequest]) (*connect.Response[Empty], error)
+	// Deletes many conversations in a single transaction.
+	BatchDeleteConversations(context.Context, *connect.Request[BatchDeleteConversationsRequest]) (*connect.Response[Empty], error)
 	GetMessages(context.Context, *connect.Request[GetMessagesRequest]) (*connect.Response[GetMessagesResponse], error)
+	GetConversationCost(context.Context, *connect.Request[GetConversationCostRequest]) (*connect.Response[ConversationCost], error)
+	// Lists the artifacts tool calls registered on the conversation's runs.
+	ListRunArtifacts(context.Context, *connect.Request[ListRunArtifactsRequest]) (*connect.Response[ListRunArtifactsResponse], error)
+	GetUsage(context.Context, *connect.Request[GetUsageRequest]) (*connect.Response[GetUsageResponse], error)
+	SearchConversations(context.Context, *connect.Request[SearchConversationsRequest]) (*connect.Response[SearchConversationsResponse], error)
 	Chat(context.Context, *connect.Request[ChatRequest]) (*connect.Response[ChatResponse], error)
 	WatchEvents(context.Context, *connect.Request[WatchEventsRequest]) (*connect.ServerStreamForClient[WatchEventsEvent], error)
 }
@@ -101,12 +123,42 @@ func NewConversationServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(conversationServiceMethods.ByName("DeleteConversation")),
 			connect.WithClientOptions(opts...),
 		),
+		batchDeleteConversations: connect.NewClient[BatchDeleteConversationsRequest, Empty](
+			httpClient,
+			baseURL+ConversationServiceBatchDeleteConversationsProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("BatchDeleteConversations")),
+			connect.WithClientOptions(opts...),
+		),
 		getMessages: connect.NewClient[GetMessagesRequest, GetMessagesResponse](
 			httpClient,
 			baseURL+ConversationServiceGetMessagesProcedure,
 			connect.WithSchema(conversationServiceMethods.ByName("GetMessages")),
 			connect.WithClientOptions(opts...),
 		),
+		getConversationCost: connect.NewClient[GetConversationCostRequest, ConversationCost](
+			httpClient,
+			baseURL+ConversationServiceGetConversationCostProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("GetConversationCost")),
+			connect.WithClientOptions(opts...),
+		),
+		listRunArtifacts: connect.NewClient[ListRunArtifactsRequest, ListRunArtifactsResponse](
+			httpClient,
+			baseURL+ConversationServiceListRunArtifactsProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ListRunArtifacts")),
+			connect.WithClientOptions(opts...),
+		),
+		getUsage: connect.NewClient[GetUsageRequest, GetUsageResponse](
+			httpClient,
+			baseURL+ConversationServiceGetUsageProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("GetUsage")),
+			connect.WithClientOptions(opts...),
+		),
+		searchConversations: connect.NewClient[SearchConversationsRequest, SearchConversationsResponse](
+			httpClient,
+			baseURL+ConversationServiceSearchConversationsProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("SearchConversations")),
+			connect.WithClientOptions(opts...),
+		),
 		chat: connect.NewClient[ChatRequest, ChatResponse](
 			httpClient,
 			baseURL+ConversationServiceChatProcedure,
@@ -124,13 +176,18 @@ func NewConversationServiceClient(httpClient connect.HTTPClient, baseURL string,
 
 // conversationServiceClient implements ConversationServiceClient.
 type conversationServiceClient struct {
-	createConversation *connect.Client[CreateConversationRequest, Conversation]
-	getConversation    *connect.Client[GetConversationRequest, Conversation]
-	listConversations  *connect.Client[ListConversationsRequest, ListConversationsResponse]
-	deleteConversation *connect.Client[DeleteConversationRequest, Empty]
-	getMessages        *connect.Client[GetMessagesRequest, GetMessagesResponse]
-	chat               *connect.Client[ChatRequest, ChatResponse]
-	watchEvents        *connect.Client[WatchEventsRequest, WatchEventsEvent]
+	createConversation       *connect.Client[CreateConversationRequest, Conversation]
+	getConversation          *connect.Client[GetConversationRequest, Conversation]
+	listConversations        *connect.Client[ListConversationsRequest, ListConversationsResponse]
+	deleteConversation       *connect.Client[DeleteConversationRequest, Empty]
+	batchDeleteConversations *connect.Client[BatchDeleteConversationsRequest, Empty]
+	getMessages              *connect.Client[GetMessagesRequest, GetMessagesResponse]
+	getConversationCost      *connect.Client[GetConversationCostRequest, ConversationCost]
+	listRunArtifacts         *connect.Client[ListRunArtifactsRequest, ListRunArtifactsResponse]
+	getUsage                 *connect.Client[GetUsageRequest, GetUsageResponse]
+	searchConversations      *connect.Client[SearchConversationsRequest, SearchConversationsResponse]
+	chat                     *connect.Client[ChatRequest, ChatResponse]
+	watchEvents              *connect.Client[WatchEventsRequest, WatchEventsEvent]
 }
 
 // CreateConversation calls blippy.conversation.ConversationService.CreateConversation.
@@ -153,11 +210,36 @@ func (c *conversationServiceClient) DeleteConversation(ctx context.Context, req
 	return c.deleteConversation.CallUnary(ctx, req)
 }
 
+// BatchDeleteConversations calls blippy.conversation.ConversationService.BatchDeleteConversations.
+func (c *conversationServiceClient) BatchDeleteConversations(ctx context.Context, req *connect.Request[BatchDeleteConversationsRequest]) (*connect.Response[Empty], error) {
+	return c.batchDeleteConversations.CallUnary(ctx, req)
+}
+
 // GetMessages calls blippy.conversation.ConversationService.GetMessages.
 func (c *conversationServiceClient) GetMessages(ctx context.Context, req *connect.Request[GetMessagesRequest]) (*connect.Response[GetMessagesResponse], error) {
 	return c.getMessages.CallUnary(ctx, req)
 }
 
+// GetConversationCost calls blippy.conversation.ConversationService.GetConversationCost.
+func (c *conversationServiceClient) GetConversationCost(ctx context.Context, req *connect.Request[GetConversationCostRequest]) (*connect.Response[ConversationCost], error) {
+	return c.getConversationCost.CallUnary(ctx, req)
+}
+
+// ListRunArtifacts calls blippy.conversation.ConversationService.ListRunArtifacts.
+func (c *conversationServiceClient) ListRunArtifacts(ctx context.Context, req *connect.Request[ListRunArtifactsRequest]) (*connect.Response[ListRunArtifactsResponse], error) {
+	return c.listRunArtifacts.CallUnary(ctx, req)
+}
+
+// GetUsage calls blippy.conversation.ConversationService.GetUsage.
+func (c *conversationServiceClient) GetUsage(ctx context.Context, req *connect.Request[GetUsageRequest]) (*connect.Response[GetUsageResponse], error) {
+	return c.getUsage.CallUnary(ctx, req)
+}
+
+// SearchConversations calls blippy.conversation.ConversationService.SearchConversations.
+func (c *conversationServiceClient) SearchConversations(ctx context.Context, req *connect.Request[SearchConversationsRequest]) (*connect.Response[SearchConversationsResponse], error) {
+	return c.searchConversations.CallUnary(ctx, req)
+}
+
 // Chat calls blippy.conversation.ConversationService.Chat.
 func (c *conversationServiceClient) Chat(ctx context.Context, req *connect.Request[ChatRequest]) (*connect.Response[ChatResponse], error) {
 	return c.chat.CallUnary(ctx, req)
@@ -175,7 +257,14 @@ type ConversationServiceHandler interface {
 	GetConversation(context.Context, *connect.Request[GetConversationRequest]) (*connect.Response[Conversation], error)
 	ListConversations(context.Context, *connect.Request[ListConversationsRequest]) (*connect.Response[ListConversationsResponse], error)
 	DeleteConversation(context.Context, *connect.Request[DeleteConversationRequest]) (*connect.Response[Empty], error)
+	// Deletes many conversations in a single transaction.
+	BatchDeleteConversations(context.Context, *connect.Request[BatchDeleteConversationsRequest]) (*connect.Response[Empty], error)
 	GetMessages(context.Context, *connect.Request[GetMessagesRequest]) (*connect.Response[GetMessagesResponse], error)
+	GetConversationCost(context.Context, *connect.Request[GetConversationCostRequest]) (*connect.Response[ConversationCost], error)
+	// Lists the artifacts tool calls registered on the conversation's runs.
+	ListRunArtifacts(context.Context, *connect.Request[ListRunArtifactsRequest]) (*connect.Response[ListRunArtifactsResponse], error)
+	GetUsage(context.Context, *connect.Request[GetUsageRequest]) (*connect.Response[GetUsageResponse], error)
+	SearchConversations(context.Context, *connect.Request[SearchConversationsRequest]) (*connect.Response[SearchConversationsResponse], error)
 	Chat(context.Context, *connect.Request[ChatRequest]) (*connect.Response[ChatResponse], error)
 	WatchEvents(context.Context, *connect.Request[WatchEventsRequest], *connect.ServerStream[WatchEventsEvent]) error
 }
@@ -211,12 +300,42 @@ func NewConversationServiceHandler(svc ConversationServiceHandler, opts ...conne
 		connect.WithSchema(conversationServiceMethods.ByName("DeleteConversation")),
 		connect.WithHandlerOptions(opts...),
 	)
+	conversationServiceBatchDeleteConversationsHandler := connect.NewUnaryHandler(
+		ConversationServiceBatchDeleteConversationsProcedure,
+		svc.BatchDeleteConversations,
+		connect.WithSchema(conversationServiceMethods.ByName("BatchDeleteConversations")),
+		connect.WithHandlerOptions(opts...),
+	)
 	conversationServiceGetMessagesHandler := connect.NewUnaryHandler(
 		ConversationServiceGetMessagesProcedure,
 		svc.GetMessages,
 		connect.WithSchema(conversationServiceMethods.ByName("GetMessages")),
 		connect.WithHandlerOptions(opts...),
 	)
+	conversationServiceGetConversationCostHandler := connect.NewUnaryHandler(
+		ConversationServiceGetConversationCostProcedure,
+		svc.GetConversationCost,
+		connect.WithSchema(conversationServiceMethods.ByName("GetConversationCost")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceListRunArtifactsHandler := connect.NewUnaryHandler(
+		ConversationServiceListRunArtifactsProcedure,
+		svc.ListRunArtifacts,
+		connect.WithSchema(conversationServiceMethods.ByName("ListRunArtifacts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceGetUsageHandler := connect.NewUnaryHandler(
+		ConversationServiceGetUsageProcedure,
+		svc.GetUsage,
+		connect.WithSchema(conversationServiceMethods.ByName("GetUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceSearchConversationsHandler := connect.NewUnaryHandler(
+		ConversationServiceSearchConversationsProcedure,
+		svc.SearchConversations,
+		connect.WithSchema(conversationServiceMethods.ByName("SearchConversations")),
+		connect.WithHandlerOptions(opts...),
+	)
 	conversationServiceChatHandler := connect.NewUnaryHandler(
 		ConversationServiceChatProcedure,
 		svc.Chat,
@@ -239,8 +358,18 @@ func NewConversationServiceHandler(svc ConversationServiceHandler, opts ...conne
 			conversationServiceListConversationsHandler.ServeHTTP(w, r)
 		case ConversationServiceDeleteConversationProcedure:
 			conversationServiceDeleteConversationHandler.ServeHTTP(w, r)
+		case ConversationServiceBatchDeleteConversationsProcedure:
+			conversationServiceBatchDeleteConversationsHandler.ServeHTTP(w, r)
 		case ConversationServiceGetMessagesProcedure:
 			conversationServiceGetMessagesHandler.ServeHTTP(w, r)
+		case ConversationServiceGetConversationCostProcedure:
+			conversationServiceGetConversationCostHandler.ServeHTTP(w, r)
+		case ConversationServiceListRunArtifactsProcedure:
+			conversationServiceListRunArtifactsHandler.ServeHTTP(w, r)
+		case ConversationServiceGetUsageProcedure:
+			conversationServiceGetUsageHandler.ServeHTTP(w, r)
+		case ConversationServiceSearchConversationsProcedure:
+			conversationServiceSearchConversationsHandler.ServeHTTP(w, r)
 		case ConversationServiceChatProcedure:
 			conversationServiceChatHandler.ServeHTTP(w, r)
 		case ConversationServiceWatchEventsProcedure:
@@ -270,10 +399,30 @@ func (UnimplementedConversationServiceHandler) DeleteConversation(context.Contex
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.DeleteConversation is not implemented"))
 }
 
+func (UnimplementedConversationServiceHandler) BatchDeleteConversations(context.Context, *connect.Request[BatchDeleteConversationsRequest]) (*connect.Response[Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.BatchDeleteConversations is not implemented"))
+}
+
 func (UnimplementedConversationServiceHandler) GetMessages(context.Context, *connect.Request[GetMessagesRequest]) (*connect.Response[GetMessagesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.GetMessages is not implemented"))
 }
 
+func (UnimplementedConversationServiceHandler) GetConversationCost(context.Context, *connect.Request[GetConversationCostRequest]) (*connect.Response[ConversationCost], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.GetConversationCost is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ListRunArtifacts(context.Context, *connect.Request[ListRunArtifactsRequest]) (*connect.Response[ListRunArtifactsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.ListRunArtifacts is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) GetUsage(context.Context, *connect.Request[GetUsageRequest]) (*connect.Response[GetUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.GetUsage is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) SearchConversations(context.Context, *connect.Request[SearchConversationsRequest]) (*connect.Response[SearchConversationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.SearchConversations is not implemented"))
+}
+
 func (UnimplementedConversationServiceHandler) Chat(context.Context, *connect.Request[ChatRequest]) (*connect.Response[ChatResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.conversation.ConversationService.Chat is not implemented"))
 }
@@ -7,6 +7,7 @@
 package conversation
 
 import (
+	apierror "github.com/dstotijn/blippy/internal/apierror"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -30,8 +31,11 @@ type Conversation struct {
 	PreviousResponseId string                 `protobuf:"bytes,4,opt,name=previous_response_id,json=previousResponseId,proto3" json:"previous_response_id,omitempty"` // OpenResponses chaining
 	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Token usage and cost of the conversation's runs. Only set by
+	// GetConversation.
+	Usage         *Usage `protobuf:"bytes,7,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Conversation) Reset() {
@@ -106,6 +110,102 @@ func (x *Conversation) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Conversation) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type Usage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Runs  int64                  `protobuf:"varint,1,opt,name=runs,proto3" json:"runs,omitempty"`
+	// Runs that failed or timed out.
+	FailedRuns   int64 `protobuf:"varint,2,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	InputTokens  int64 `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens int64 `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	// Cost in US dollars, as reported by OpenRouter, or estimated at the
+	// current prices for runs without a reported cost.
+	CostUsd float64 `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// False if the cost of a run was neither reported nor known, in which
+	// case it doesn't count towards the cost.
+	Priced        bool `protobuf:"varint,6,opt,name=priced,proto3" json:"priced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_conversation_conversation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Usage) GetRuns() int64 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *Usage) GetFailedRuns() int64 {
+	if x != nil {
+		return x.FailedRuns
+	}
+	return 0
+}
+
+func (x *Usage) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *Usage) GetPriced() bool {
+	if x != nil {
+		return x.Priced
+	}
+	return false
+}
+
 type Message struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -113,13 +213,21 @@ type Message struct {
 	Role           string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // "user", "assistant", "system"
 	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	Items          []*MessageItem         `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// "completed", "interrupted" if the turn was cut short by a server
+	// shutdown and the message holds the output produced so far, or "failed"
+	// if the turn was stopped because it didn't converge, after which the
+	// message ends with an error item. "cancelled" if the turn was cancelled
+	// with SystemService.CancelRun, "timed_out" if the run exceeded its maximum
+	// duration. "in_progress" while the turn is still
+	// running: the message holds the output checkpointed so far.
+	Status        string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_conversation_conversation_proto_msgTypes[1]
+	mi := &file_conversation_conversation_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -131,7 +239,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[1]
+	mi := &file_conversation_conversation_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -144,7 +252,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{1}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Message) GetId() string {
@@ -182,12 +290,21 @@ func (x *Message) GetItems() []*MessageItem {
 	return nil
 }
 
+func (x *Message) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 type MessageItem struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Item:
 	//
 	//	*MessageItem_Text
 	//	*MessageItem_ToolExecution
+	//	*MessageItem_Error
+	//	*MessageItem_Attachment
 	Item          isMessageItem_Item `protobuf_oneof:"item"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -195,7 +312,7 @@ type MessageItem struct {
 
 func (x *MessageItem) Reset() {
 	*x = MessageItem{}
-	mi := &file_conversation_conversation_proto_msgTypes[2]
+	mi := &file_conversation_conversation_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -207,7 +324,7 @@ func (x *MessageItem) String() string {
 func (*MessageItem) ProtoMessage() {}
 
 func (x *MessageItem) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[2]
+	mi := &file_conversation_conversation_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -220,7 +337,7 @@ func (x *MessageItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageItem.ProtoReflect.Descriptor instead.
 func (*MessageItem) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{2}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MessageItem) GetItem() isMessageItem_Item {
@@ -248,6 +365,24 @@ func (x *MessageItem) GetToolExecution() *ToolExecutionItem {
 	return nil
 }
 
+func (x *MessageItem) GetError() *ErrorItem {
+	if x != nil {
+		if x, ok := x.Item.(*MessageItem_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *MessageItem) GetAttachment() *AttachmentItem {
+	if x != nil {
+		if x, ok := x.Item.(*MessageItem_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
 type isMessageItem_Item interface {
 	isMessageItem_Item()
 }
@@ -260,10 +395,22 @@ type MessageItem_ToolExecution struct {
 	ToolExecution *ToolExecutionItem `protobuf:"bytes,2,opt,name=tool_execution,json=toolExecution,proto3,oneof"`
 }
 
+type MessageItem_Error struct {
+	Error *ErrorItem `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+type MessageItem_Attachment struct {
+	Attachment *AttachmentItem `protobuf:"bytes,4,opt,name=attachment,proto3,oneof"`
+}
+
 func (*MessageItem_Text) isMessageItem_Item() {}
 
 func (*MessageItem_ToolExecution) isMessageItem_Item() {}
 
+func (*MessageItem_Error) isMessageItem_Item() {}
+
+func (*MessageItem_Attachment) isMessageItem_Item() {}
+
 type TextItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
@@ -273,7 +420,7 @@ type TextItem struct {
 
 func (x *TextItem) Reset() {
 	*x = TextItem{}
-	mi := &file_conversation_conversation_proto_msgTypes[3]
+	mi := &file_conversation_conversation_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -285,7 +432,7 @@ func (x *TextItem) String() string {
 func (*TextItem) ProtoMessage() {}
 
 func (x *TextItem) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[3]
+	mi := &file_conversation_conversation_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +445,7 @@ func (x *TextItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TextItem.ProtoReflect.Descriptor instead.
 func (*TextItem) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{3}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TextItem) GetContent() string {
@@ -308,6 +455,52 @@ func (x *TextItem) GetContent() string {
 	return ""
 }
 
+// ErrorItem explains why a turn was stopped, e.g. because the agent kept
+// calling the same tool. Messages with error items have status "failed".
+type ErrorItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorItem) Reset() {
+	*x = ErrorItem{}
+	mi := &file_conversation_conversation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorItem) ProtoMessage() {}
+
+func (x *ErrorItem) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorItem.ProtoReflect.Descriptor instead.
+func (*ErrorItem) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ErrorItem) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type ToolExecutionItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -319,7 +512,7 @@ type ToolExecutionItem struct {
 
 func (x *ToolExecutionItem) Reset() {
 	*x = ToolExecutionItem{}
-	mi := &file_conversation_conversation_proto_msgTypes[4]
+	mi := &file_conversation_conversation_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -331,7 +524,7 @@ func (x *ToolExecutionItem) String() string {
 func (*ToolExecutionItem) ProtoMessage() {}
 
 func (x *ToolExecutionItem) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[4]
+	mi := &file_conversation_conversation_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -344,7 +537,7 @@ func (x *ToolExecutionItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolExecutionItem.ProtoReflect.Descriptor instead.
 func (*ToolExecutionItem) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{4}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ToolExecutionItem) GetName() string {
@@ -368,6 +561,84 @@ func (x *ToolExecutionItem) GetResult() string {
 	return ""
 }
 
+// AttachmentItem is a file attached to a user message.
+type AttachmentItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MediaType string                 `protobuf:"bytes,3,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Size      int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// Signed download URL, valid for an hour.
+	Url           string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentItem) Reset() {
+	*x = AttachmentItem{}
+	mi := &file_conversation_conversation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentItem) ProtoMessage() {}
+
+func (x *AttachmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentItem.ProtoReflect.Descriptor instead.
+func (*AttachmentItem) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AttachmentItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AttachmentItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttachmentItem) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *AttachmentItem) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AttachmentItem) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
 type CreateConversationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
@@ -377,7 +648,7 @@ type CreateConversationRequest struct {
 
 func (x *CreateConversationRequest) Reset() {
 	*x = CreateConversationRequest{}
-	mi := &file_conversation_conversation_proto_msgTypes[5]
+	mi := &file_conversation_conversation_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -389,7 +660,7 @@ func (x *CreateConversationRequest) String() string {
 func (*CreateConversationRequest) ProtoMessage() {}
 
 func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[5]
+	mi := &file_conversation_conversation_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -402,7 +673,7 @@ func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateConversationRequest.ProtoReflect.Descriptor instead.
 func (*CreateConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{5}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CreateConversationRequest) GetAgentId() string {
@@ -421,7 +692,7 @@ type GetConversationRequest struct {
 
 func (x *GetConversationRequest) Reset() {
 	*x = GetConversationRequest{}
-	mi := &file_conversation_conversation_proto_msgTypes[6]
+	mi := &file_conversation_conversation_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -433,7 +704,7 @@ func (x *GetConversationRequest) String() string {
 func (*GetConversationRequest) ProtoMessage() {}
 
 func (x *GetConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[6]
+	mi := &file_conversation_conversation_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -446,7 +717,7 @@ func (x *GetConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConversationRequest.ProtoReflect.Descriptor instead.
 func (*GetConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{6}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetConversationRequest) GetId() string {
@@ -457,15 +728,23 @@ func (x *GetConversationRequest) GetId() string {
 }
 
 type ListConversationsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	// Maximum number of results to return. 0 returns all results.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Comma-separated fields, each optionally followed by "asc" or "desc".
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Terms joined by "AND", e.g. `title:deploy AND updated_at>=2025-01-01`.
+	Filter        string `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListConversationsRequest) Reset() {
 	*x = ListConversationsRequest{}
-	mi := &file_conversation_conversation_proto_msgTypes[7]
+	mi := &file_conversation_conversation_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -477,7 +756,7 @@ func (x *ListConversationsRequest) String() string {
 func (*ListConversationsRequest) ProtoMessage() {}
 
 func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[7]
+	mi := &file_conversation_conversation_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,7 +769,7 @@ func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConversationsRequest.ProtoReflect.Descriptor instead.
 func (*ListConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{7}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListConversationsRequest) GetAgentId() string {
@@ -500,16 +779,48 @@ func (x *ListConversationsRequest) GetAgentId() string {
 	return ""
 }
 
+func (x *ListConversationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListConversationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListConversationsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListConversationsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type ListConversationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Conversations []*Conversation        `protobuf:"bytes,1,rep,name=conversations,proto3" json:"conversations,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of results matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListConversationsResponse) Reset() {
 	*x = ListConversationsResponse{}
-	mi := &file_conversation_conversation_proto_msgTypes[8]
+	mi := &file_conversation_conversation_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -521,7 +832,7 @@ func (x *ListConversationsResponse) String() string {
 func (*ListConversationsResponse) ProtoMessage() {}
 
 func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[8]
+	mi := &file_conversation_conversation_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -534,7 +845,7 @@ func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConversationsResponse.ProtoReflect.Descriptor instead.
 func (*ListConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{8}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListConversationsResponse) GetConversations() []*Conversation {
@@ -544,6 +855,20 @@ func (x *ListConversationsResponse) GetConversations() []*Conversation {
 	return nil
 }
 
+func (x *ListConversationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListConversationsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
 type DeleteConversationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -553,7 +878,7 @@ type DeleteConversationRequest struct {
 
 func (x *DeleteConversationRequest) Reset() {
 	*x = DeleteConversationRequest{}
-	mi := &file_conversation_conversation_proto_msgTypes[9]
+	mi := &file_conversation_conversation_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -565,7 +890,7 @@ func (x *DeleteConversationRequest) String() string {
 func (*DeleteConversationRequest) ProtoMessage() {}
 
 func (x *DeleteConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[9]
+	mi := &file_conversation_conversation_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,7 +903,7 @@ func (x *DeleteConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteConversationRequest.ProtoReflect.Descriptor instead.
 func (*DeleteConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{9}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteConversationRequest) GetId() string {
@@ -588,6 +913,51 @@ func (x *DeleteConversationRequest) GetId() string {
 	return ""
 }
 
+type BatchDeleteConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 500 conversation IDs. Unknown IDs are ignored.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteConversationsRequest) Reset() {
+	*x = BatchDeleteConversationsRequest{}
+	mi := &file_conversation_conversation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteConversationsRequest) ProtoMessage() {}
+
+func (x *BatchDeleteConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteConversationsRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchDeleteConversationsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 type GetMessagesRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
@@ -597,7 +967,7 @@ type GetMessagesRequest struct {
 
 func (x *GetMessagesRequest) Reset() {
 	*x = GetMessagesRequest{}
-	mi := &file_conversation_conversation_proto_msgTypes[10]
+	mi := &file_conversation_conversation_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -609,7 +979,7 @@ func (x *GetMessagesRequest) String() string {
 func (*GetMessagesRequest) ProtoMessage() {}
 
 func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[10]
+	mi := &file_conversation_conversation_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,7 +992,7 @@ func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
 func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{10}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetMessagesRequest) GetConversationId() string {
@@ -641,7 +1011,7 @@ type GetMessagesResponse struct {
 
 func (x *GetMessagesResponse) Reset() {
 	*x = GetMessagesResponse{}
-	mi := &file_conversation_conversation_proto_msgTypes[11]
+	mi := &file_conversation_conversation_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -653,7 +1023,7 @@ func (x *GetMessagesResponse) String() string {
 func (*GetMessagesResponse) ProtoMessage() {}
 
 func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[11]
+	mi := &file_conversation_conversation_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,7 +1036,7 @@ func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
 func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{11}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetMessagesResponse) GetMessages() []*Message {
@@ -676,29 +1046,1678 @@ func (x *GetMessagesResponse) GetMessages() []*Message {
 	return nil
 }
 
-type ChatRequest struct {
+type GetConversationCostRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	Content        string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ChatRequest) Reset() {
-	*x = ChatRequest{}
-	mi := &file_conversation_conversation_proto_msgTypes[12]
+func (x *GetConversationCostRequest) Reset() {
+	*x = GetConversationCostRequest{}
+	mi := &file_conversation_conversation_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatRequest) String() string {
+func (x *GetConversationCostRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatRequest) ProtoMessage() {}
+func (*GetConversationCostRequest) ProtoMessage() {}
+
+func (x *GetConversationCostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConversationCostRequest.ProtoReflect.Descriptor instead.
+func (*GetConversationCostRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetConversationCostRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+// ConversationCost is the token usage of the runs of a conversation, and its
+// estimated cost in US dollars at the current OpenRouter prices. Runs of
+// subagents are in their own conversations, and not included.
+type ConversationCost struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	InputTokens    int64                  `protobuf:"varint,2,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens   int64                  `protobuf:"varint,3,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CostUsd        float64                `protobuf:"fixed64,4,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// False if the price of a model wasn't known, in which case its runs
+	// don't count towards the cost.
+	Priced        bool           `protobuf:"varint,5,opt,name=priced,proto3" json:"priced,omitempty"`
+	Messages      []*MessageCost `protobuf:"bytes,6,rep,name=messages,proto3" json:"messages,omitempty"`
+	Models        []*ModelCost   `protobuf:"bytes,7,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversationCost) Reset() {
+	*x = ConversationCost{}
+	mi := &file_conversation_conversation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationCost) ProtoMessage() {}
+
+func (x *ConversationCost) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationCost.ProtoReflect.Descriptor instead.
+func (*ConversationCost) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConversationCost) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ConversationCost) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *ConversationCost) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *ConversationCost) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *ConversationCost) GetPriced() bool {
+	if x != nil {
+		return x.Priced
+	}
+	return false
+}
+
+func (x *ConversationCost) GetMessages() []*MessageCost {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ConversationCost) GetModels() []*ModelCost {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+// MessageCost is the usage of the run that produced an assistant message.
+// Runs that ended without output have no message_id.
+type MessageCost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	RunId         string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	InputTokens   int64                  `protobuf:"varint,4,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64                  `protobuf:"varint,5,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CostUsd       float64                `protobuf:"fixed64,6,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	Priced        bool                   `protobuf:"varint,7,opt,name=priced,proto3" json:"priced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageCost) Reset() {
+	*x = MessageCost{}
+	mi := &file_conversation_conversation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageCost) ProtoMessage() {}
+
+func (x *MessageCost) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageCost.ProtoReflect.Descriptor instead.
+func (*MessageCost) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MessageCost) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageCost) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *MessageCost) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *MessageCost) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *MessageCost) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *MessageCost) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *MessageCost) GetPriced() bool {
+	if x != nil {
+		return x.Priced
+	}
+	return false
+}
+
+type ModelCost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Runs          int32                  `protobuf:"varint,2,opt,name=runs,proto3" json:"runs,omitempty"`
+	InputTokens   int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CostUsd       float64                `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	Priced        bool                   `protobuf:"varint,6,opt,name=priced,proto3" json:"priced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelCost) Reset() {
+	*x = ModelCost{}
+	mi := &file_conversation_conversation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelCost) ProtoMessage() {}
+
+func (x *ModelCost) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelCost.ProtoReflect.Descriptor instead.
+func (*ModelCost) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ModelCost) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ModelCost) GetRuns() int32 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *ModelCost) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *ModelCost) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *ModelCost) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *ModelCost) GetPriced() bool {
+	if x != nil {
+		return x.Priced
+	}
+	return false
+}
+
+type ListRunArtifactsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Lists the artifacts of this run only. Defaults to all runs of the
+	// conversation.
+	RunId         string `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunArtifactsRequest) Reset() {
+	*x = ListRunArtifactsRequest{}
+	mi := &file_conversation_conversation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunArtifactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunArtifactsRequest) ProtoMessage() {}
+
+func (x *ListRunArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*ListRunArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListRunArtifactsRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ListRunArtifactsRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type ListRunArtifactsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Artifacts     []*Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunArtifactsResponse) Reset() {
+	*x = ListRunArtifactsResponse{}
+	mi := &file_conversation_conversation_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunArtifactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunArtifactsResponse) ProtoMessage() {}
+
+func (x *ListRunArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ListRunArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListRunArtifactsResponse) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+// Artifact is an output a tool call registered on its run, such as a file it
+// created, a URL it produced or an image it generated.
+type Artifact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RunId string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The call_id and name of the tool call that registered it.
+	CallId   string `protobuf:"bytes,3,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	ToolName string `protobuf:"bytes,4,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	// "file", "url" or "image".
+	Kind string `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Describes the artifact, e.g. "docs:notes/plan.md" for a file in a
+	// filesystem root.
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// Locates the artifact: a URL, a file:// URI, or a signed download URL
+	// for artifacts stored by the server.
+	Uri       string `protobuf:"bytes,7,opt,name=uri,proto3" json:"uri,omitempty"`
+	MediaType string `protobuf:"bytes,8,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	// Size in bytes, or 0 if unknown.
+	Size          int64                  `protobuf:"varint,9,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_conversation_conversation_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Artifact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Artifact) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *Artifact) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *Artifact) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *Artifact) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Artifact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Artifact) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *Artifact) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artifact) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetUsageRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Hours to look back. Defaults to 24 hours.
+	PeriodHours int32 `protobuf:"varint,2,opt,name=period_hours,json=periodHours,proto3" json:"period_hours,omitempty"`
+	// Maximum number of conversations. Defaults to 20, at most 100.
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_conversation_conversation_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetUsageRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetPeriodHours() int32 {
+	if x != nil {
+		return x.PeriodHours
+	}
+	return 0
+}
+
+func (x *GetUsageRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// GetUsageResponse is the usage of an agent's conversations with runs in a
+// period, most expensive first.
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	Conversations []*ConversationUsage   `protobuf:"bytes,3,rep,name=conversations,proto3" json:"conversations,omitempty"`
+	// Usage of all conversations in the period, including those beyond the
+	// page size.
+	Total         *Usage `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_conversation_conversation_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUsageResponse) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetConversations() []*ConversationUsage {
+	if x != nil {
+		return x.Conversations
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetTotal() *Usage {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type ConversationUsage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Title          string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Usage          *Usage                 `protobuf:"bytes,4,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConversationUsage) Reset() {
+	*x = ConversationUsage{}
+	mi := &file_conversation_conversation_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationUsage) ProtoMessage() {}
+
+func (x *ConversationUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationUsage.ProtoReflect.Descriptor instead.
+func (*ConversationUsage) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConversationUsage) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ConversationUsage) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ConversationUsage) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ConversationUsage) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type SearchConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Words to search for in conversation titles and message items, which
+	// must all match. Words are matched by stem, e.g. "migration" also matches
+	// "migrations". Supports "quoted phrases" and prefix* words.
+	Query   string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	// Optional filters on the time the conversation was last updated:
+	// updated_since is inclusive, updated_before exclusive.
+	UpdatedSince  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	// Maximum number of conversations to return. Defaults to 20, at most 100.
+	PageSize      int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchConversationsRequest) Reset() {
+	*x = SearchConversationsRequest{}
+	mi := &file_conversation_conversation_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchConversationsRequest) ProtoMessage() {}
+
+func (x *SearchConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchConversationsRequest.ProtoReflect.Descriptor instead.
+func (*SearchConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SearchConversationsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchConversationsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SearchConversationsRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *SearchConversationsRequest) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *SearchConversationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SearchConversationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Conversations with matches, the most relevant first.
+	Results       []*ConversationSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchConversationsResponse) Reset() {
+	*x = SearchConversationsResponse{}
+	mi := &file_conversation_conversation_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchConversationsResponse) ProtoMessage() {}
+
+func (x *SearchConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchConversationsResponse.ProtoReflect.Descriptor instead.
+func (*SearchConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SearchConversationsResponse) GetResults() []*ConversationSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ConversationSearchResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Conversation *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	// Up to three of the best matching titles and messages.
+	Snippets []*SearchSnippet `protobuf:"bytes,2,rep,name=snippets,proto3" json:"snippets,omitempty"`
+	// BM25 relevance of the best match; lower is more relevant.
+	Rank          float64 `protobuf:"fixed64,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversationSearchResult) Reset() {
+	*x = ConversationSearchResult{}
+	mi := &file_conversation_conversation_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationSearchResult) ProtoMessage() {}
+
+func (x *ConversationSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationSearchResult.ProtoReflect.Descriptor instead.
+func (*ConversationSearchResult) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConversationSearchResult) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+func (x *ConversationSearchResult) GetSnippets() []*SearchSnippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+func (x *ConversationSearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// SearchSnippet is an excerpt of a title or message around the matched
+// words.
+type SearchSnippet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty if the conversation title matched.
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// The excerpt, split into parts that are matched words or the text
+	// between them, so clients can highlight matches without parsing markup.
+	Parts         []*SnippetPart `protobuf:"bytes,2,rep,name=parts,proto3" json:"parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSnippet) Reset() {
+	*x = SearchSnippet{}
+	mi := &file_conversation_conversation_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSnippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSnippet) ProtoMessage() {}
+
+func (x *SearchSnippet) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSnippet.ProtoReflect.Descriptor instead.
+func (*SearchSnippet) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SearchSnippet) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SearchSnippet) GetParts() []*SnippetPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+type SnippetPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Match         bool                   `protobuf:"varint,2,opt,name=match,proto3" json:"match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnippetPart) Reset() {
+	*x = SnippetPart{}
+	mi := &file_conversation_conversation_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnippetPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnippetPart) ProtoMessage() {}
+
+func (x *SnippetPart) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnippetPart.ProtoReflect.Descriptor instead.
+func (*SnippetPart) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SnippetPart) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SnippetPart) GetMatch() bool {
+	if x != nil {
+		return x.Match
+	}
+	return false
+}
+
+type ChatRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Content        string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// Files sent to the agent with the message: images, PDFs or text files.
+	Attachments   []*Attachment `protobuf:"bytes,3,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	mi := &file_conversation_conversation_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ChatRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type Attachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Detected from data if empty.
+	MediaType     string `protobuf:"bytes,2,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Data          []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_conversation_conversation_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *Attachment) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserMessageId string                 `protobuf:"bytes,1,opt,name=user_message_id,json=userMessageId,proto3" json:"user_message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	mi := &file_conversation_conversation_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ChatResponse) GetUserMessageId() string {
+	if x != nil {
+		return x.UserMessageId
+	}
+	return ""
+}
+
+// WatchEvents streaming events
+type WatchEventsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Replays the logged events after this sequence number before streaming
+	// new events, e.g. when reconnecting. 0 only streams new events.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	// Only streams events of these types, named after the fields of
+	// WatchEventsEvent's event, e.g. "message_created" and "done" for list
+	// views that only need to know when turns complete. Gaps are always sent.
+	// Empty streams all events.
+	EventTypes    []string `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_conversation_conversation_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WatchEventsRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+func (x *WatchEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type WatchEventsEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WatchEventsEvent_TextDelta
+	//	*WatchEventsEvent_ToolResult
+	//	*WatchEventsEvent_MessageCreated
+	//	*WatchEventsEvent_Error
+	//	*WatchEventsEvent_Done
+	//	*WatchEventsEvent_TurnStarted
+	//	*WatchEventsEvent_Gap
+	//	*WatchEventsEvent_Progress
+	//	*WatchEventsEvent_Subagent
+	//	*WatchEventsEvent_ApprovalRequested
+	//	*WatchEventsEvent_ApprovalResolved
+	//	*WatchEventsEvent_ToolExecutionStarted
+	Event isWatchEventsEvent_Event `protobuf_oneof:"event"`
+	// Increases monotonically per conversation. 0 for events that aren't
+	// logged, such as the initial TurnStarted of a busy conversation,
+	// TurnProgress and SubagentUpdate.
+	Sequence      int64 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsEvent) Reset() {
+	*x = WatchEventsEvent{}
+	mi := &file_conversation_conversation_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsEvent) ProtoMessage() {}
+
+func (x *WatchEventsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsEvent.ProtoReflect.Descriptor instead.
+func (*WatchEventsEvent) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WatchEventsEvent) GetEvent() isWatchEventsEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetTextDelta() *TextDelta {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_TextDelta); ok {
+			return x.TextDelta
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetToolResult() *ToolResult {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_ToolResult); ok {
+			return x.ToolResult
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetMessageCreated() *MessageCreated {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_MessageCreated); ok {
+			return x.MessageCreated
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetError() *WatchError {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetDone() *TurnDone {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_Done); ok {
+			return x.Done
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetTurnStarted() *TurnStarted {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_TurnStarted); ok {
+			return x.TurnStarted
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetGap() *Gap {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_Gap); ok {
+			return x.Gap
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetProgress() *TurnProgress {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetSubagent() *SubagentUpdate {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_Subagent); ok {
+			return x.Subagent
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetApprovalRequested() *ApprovalRequested {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_ApprovalRequested); ok {
+			return x.ApprovalRequested
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetApprovalResolved() *ApprovalResolved {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_ApprovalResolved); ok {
+			return x.ApprovalResolved
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetToolExecutionStarted() *ToolExecutionStarted {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsEvent_ToolExecutionStarted); ok {
+			return x.ToolExecutionStarted
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type isWatchEventsEvent_Event interface {
+	isWatchEventsEvent_Event()
+}
+
+type WatchEventsEvent_TextDelta struct {
+	TextDelta *TextDelta `protobuf:"bytes,1,opt,name=text_delta,json=textDelta,proto3,oneof"`
+}
+
+type WatchEventsEvent_ToolResult struct {
+	ToolResult *ToolResult `protobuf:"bytes,2,opt,name=tool_result,json=toolResult,proto3,oneof"`
+}
+
+type WatchEventsEvent_MessageCreated struct {
+	MessageCreated *MessageCreated `protobuf:"bytes,3,opt,name=message_created,json=messageCreated,proto3,oneof"`
+}
+
+type WatchEventsEvent_Error struct {
+	Error *WatchError `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
+}
+
+type WatchEventsEvent_Done struct {
+	Done *TurnDone `protobuf:"bytes,5,opt,name=done,proto3,oneof"`
+}
+
+type WatchEventsEvent_TurnStarted struct {
+	TurnStarted *TurnStarted `protobuf:"bytes,6,opt,name=turn_started,json=turnStarted,proto3,oneof"`
+}
+
+type WatchEventsEvent_Gap struct {
+	Gap *Gap `protobuf:"bytes,8,opt,name=gap,proto3,oneof"`
+}
+
+type WatchEventsEvent_Progress struct {
+	Progress *TurnProgress `protobuf:"bytes,9,opt,name=progress,proto3,oneof"`
+}
+
+type WatchEventsEvent_Subagent struct {
+	Subagent *SubagentUpdate `protobuf:"bytes,10,opt,name=subagent,proto3,oneof"`
+}
+
+type WatchEventsEvent_ApprovalRequested struct {
+	ApprovalRequested *ApprovalRequested `protobuf:"bytes,11,opt,name=approval_requested,json=approvalRequested,proto3,oneof"`
+}
+
+type WatchEventsEvent_ApprovalResolved struct {
+	ApprovalResolved *ApprovalResolved `protobuf:"bytes,12,opt,name=approval_resolved,json=approvalResolved,proto3,oneof"`
+}
+
+type WatchEventsEvent_ToolExecutionStarted struct {
+	ToolExecutionStarted *ToolExecutionStarted `protobuf:"bytes,13,opt,name=tool_execution_started,json=toolExecutionStarted,proto3,oneof"`
+}
+
+func (*WatchEventsEvent_TextDelta) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_ToolResult) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_MessageCreated) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_Error) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_Done) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_TurnStarted) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_Gap) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_Progress) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_Subagent) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_ApprovalRequested) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_ApprovalResolved) isWatchEventsEvent_Event() {}
+
+func (*WatchEventsEvent_ToolExecutionStarted) isWatchEventsEvent_Event() {}
+
+// ApprovalRequested is sent when a tool call of the active turn, or of an
+// agent it called, matches an approval rule of its agent. The call waits
+// until it's resolved with SystemService.ResolveApproval, or expires and is
+// denied.
+type ApprovalRequested struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalId string                 `protobuf:"bytes,1,opt,name=approval_id,json=approvalId,proto3" json:"approval_id,omitempty"`
+	RunId      string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The conversation of the call's run; that of a subagent if it isn't the
+	// watched conversation.
+	ConversationId string `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string `protobuf:"bytes,5,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	ToolName       string `protobuf:"bytes,6,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	// JSON arguments of the call.
+	Input string `protobuf:"bytes,7,opt,name=input,proto3" json:"input,omitempty"`
+	// The rule the call matched, e.g. `bash command matches "rm|curl"`.
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalRequested) Reset() {
+	*x = ApprovalRequested{}
+	mi := &file_conversation_conversation_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalRequested) ProtoMessage() {}
+
+func (x *ApprovalRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalRequested.ProtoReflect.Descriptor instead.
+func (*ApprovalRequested) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ApprovalRequested) GetApprovalId() string {
+	if x != nil {
+		return x.ApprovalId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ApprovalResolved is sent when a tool call waiting for approval is
+// approved, denied or expires.
+type ApprovalResolved struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalId string                 `protobuf:"bytes,1,opt,name=approval_id,json=approvalId,proto3" json:"approval_id,omitempty"`
+	Approved   bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	// Why the call was denied, if given.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalResolved) Reset() {
+	*x = ApprovalResolved{}
+	mi := &file_conversation_conversation_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalResolved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalResolved) ProtoMessage() {}
 
-func (x *ChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[12]
+func (x *ApprovalResolved) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,47 +2728,58 @@ func (x *ChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
-func (*ChatRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ApprovalResolved.ProtoReflect.Descriptor instead.
+func (*ApprovalResolved) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ChatRequest) GetConversationId() string {
+func (x *ApprovalResolved) GetApprovalId() string {
 	if x != nil {
-		return x.ConversationId
+		return x.ApprovalId
 	}
 	return ""
 }
 
-func (x *ChatRequest) GetContent() string {
+func (x *ApprovalResolved) GetApproved() bool {
 	if x != nil {
-		return x.Content
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ApprovalResolved) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-type ChatResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserMessageId string                 `protobuf:"bytes,1,opt,name=user_message_id,json=userMessageId,proto3" json:"user_message_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Gap is sent in place of events that were dropped because the client didn't
+// keep up. Clients should reconnect with after_sequence set to
+// resume_sequence - 1, or reload the conversation.
+type Gap struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Missed         int32                  `protobuf:"varint,1,opt,name=missed,proto3" json:"missed,omitempty"`
+	ResumeSequence int64                  `protobuf:"varint,2,opt,name=resume_sequence,json=resumeSequence,proto3" json:"resume_sequence,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ChatResponse) Reset() {
-	*x = ChatResponse{}
-	mi := &file_conversation_conversation_proto_msgTypes[13]
+func (x *Gap) Reset() {
+	*x = Gap{}
+	mi := &file_conversation_conversation_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatResponse) String() string {
+func (x *Gap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatResponse) ProtoMessage() {}
+func (*Gap) ProtoMessage() {}
 
-func (x *ChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[13]
+func (x *Gap) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -760,41 +2790,54 @@ func (x *ChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
-func (*ChatResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use Gap.ProtoReflect.Descriptor instead.
+func (*Gap) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ChatResponse) GetUserMessageId() string {
+func (x *Gap) GetMissed() int32 {
 	if x != nil {
-		return x.UserMessageId
+		return x.Missed
 	}
-	return ""
+	return 0
 }
 
-// WatchEvents streaming events
-type WatchEventsRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *Gap) GetResumeSequence() int64 {
+	if x != nil {
+		return x.ResumeSequence
+	}
+	return 0
 }
 
-func (x *WatchEventsRequest) Reset() {
-	*x = WatchEventsRequest{}
-	mi := &file_conversation_conversation_proto_msgTypes[14]
+// TurnProgress is sent periodically while a turn is active.
+type TurnProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Milliseconds since the turn started.
+	ElapsedMs int64 `protobuf:"varint,1,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	// Names of the tools being executed, if any.
+	Tools []string `protobuf:"bytes,2,rep,name=tools,proto3" json:"tools,omitempty"`
+	// Tokens used in the turn so far, as reported by the LLM provider.
+	InputTokens   int64 `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64 `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TurnProgress) Reset() {
+	*x = TurnProgress{}
+	mi := &file_conversation_conversation_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchEventsRequest) String() string {
+func (x *TurnProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchEventsRequest) ProtoMessage() {}
+func (*TurnProgress) ProtoMessage() {}
 
-func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[14]
+func (x *TurnProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -805,48 +2848,74 @@ func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
-func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use TurnProgress.ProtoReflect.Descriptor instead.
+func (*TurnProgress) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *WatchEventsRequest) GetConversationId() string {
+func (x *TurnProgress) GetElapsedMs() int64 {
 	if x != nil {
-		return x.ConversationId
+		return x.ElapsedMs
 	}
-	return ""
+	return 0
 }
 
-type WatchEventsEvent struct {
+func (x *TurnProgress) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *TurnProgress) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *TurnProgress) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+// SubagentUpdate is live output of an agent called by the active turn, e.g.
+// with call_agent. Updates of a run share its run_id; the last one has done
+// set.
+type SubagentUpdate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Event:
+	RunId string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The subagent's conversation.
+	ConversationId string `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string `protobuf:"bytes,4,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	// Types that are valid to be assigned to Update:
 	//
-	//	*WatchEventsEvent_TextDelta
-	//	*WatchEventsEvent_ToolResult
-	//	*WatchEventsEvent_MessageCreated
-	//	*WatchEventsEvent_Error
-	//	*WatchEventsEvent_Done
-	//	*WatchEventsEvent_TurnStarted
-	Event         isWatchEventsEvent_Event `protobuf_oneof:"event"`
+	//	*SubagentUpdate_TextDelta
+	//	*SubagentUpdate_ToolResult
+	Update        isSubagentUpdate_Update `protobuf_oneof:"update"`
+	Done          bool                    `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WatchEventsEvent) Reset() {
-	*x = WatchEventsEvent{}
-	mi := &file_conversation_conversation_proto_msgTypes[15]
+func (x *SubagentUpdate) Reset() {
+	*x = SubagentUpdate{}
+	mi := &file_conversation_conversation_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchEventsEvent) String() string {
+func (x *SubagentUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchEventsEvent) ProtoMessage() {}
+func (*SubagentUpdate) ProtoMessage() {}
 
-func (x *WatchEventsEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[15]
+func (x *SubagentUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -857,111 +2926,86 @@ func (x *WatchEventsEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WatchEventsEvent.ProtoReflect.Descriptor instead.
-func (*WatchEventsEvent) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SubagentUpdate.ProtoReflect.Descriptor instead.
+func (*SubagentUpdate) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *WatchEventsEvent) GetEvent() isWatchEventsEvent_Event {
+func (x *SubagentUpdate) GetRunId() string {
 	if x != nil {
-		return x.Event
+		return x.RunId
 	}
-	return nil
+	return ""
 }
 
-func (x *WatchEventsEvent) GetTextDelta() *TextDelta {
+func (x *SubagentUpdate) GetConversationId() string {
 	if x != nil {
-		if x, ok := x.Event.(*WatchEventsEvent_TextDelta); ok {
-			return x.TextDelta
-		}
+		return x.ConversationId
 	}
-	return nil
+	return ""
 }
 
-func (x *WatchEventsEvent) GetToolResult() *ToolResult {
+func (x *SubagentUpdate) GetAgentId() string {
 	if x != nil {
-		if x, ok := x.Event.(*WatchEventsEvent_ToolResult); ok {
-			return x.ToolResult
-		}
+		return x.AgentId
 	}
-	return nil
+	return ""
 }
 
-func (x *WatchEventsEvent) GetMessageCreated() *MessageCreated {
+func (x *SubagentUpdate) GetAgentName() string {
 	if x != nil {
-		if x, ok := x.Event.(*WatchEventsEvent_MessageCreated); ok {
-			return x.MessageCreated
-		}
+		return x.AgentName
 	}
-	return nil
+	return ""
 }
 
-func (x *WatchEventsEvent) GetError() *WatchError {
+func (x *SubagentUpdate) GetUpdate() isSubagentUpdate_Update {
 	if x != nil {
-		if x, ok := x.Event.(*WatchEventsEvent_Error); ok {
-			return x.Error
-		}
+		return x.Update
 	}
 	return nil
 }
 
-func (x *WatchEventsEvent) GetDone() *TurnDone {
+func (x *SubagentUpdate) GetTextDelta() *TextDelta {
 	if x != nil {
-		if x, ok := x.Event.(*WatchEventsEvent_Done); ok {
-			return x.Done
+		if x, ok := x.Update.(*SubagentUpdate_TextDelta); ok {
+			return x.TextDelta
 		}
 	}
 	return nil
 }
 
-func (x *WatchEventsEvent) GetTurnStarted() *TurnStarted {
+func (x *SubagentUpdate) GetToolResult() *ToolResult {
 	if x != nil {
-		if x, ok := x.Event.(*WatchEventsEvent_TurnStarted); ok {
-			return x.TurnStarted
+		if x, ok := x.Update.(*SubagentUpdate_ToolResult); ok {
+			return x.ToolResult
 		}
 	}
 	return nil
 }
 
-type isWatchEventsEvent_Event interface {
-	isWatchEventsEvent_Event()
-}
-
-type WatchEventsEvent_TextDelta struct {
-	TextDelta *TextDelta `protobuf:"bytes,1,opt,name=text_delta,json=textDelta,proto3,oneof"`
-}
-
-type WatchEventsEvent_ToolResult struct {
-	ToolResult *ToolResult `protobuf:"bytes,2,opt,name=tool_result,json=toolResult,proto3,oneof"`
-}
-
-type WatchEventsEvent_MessageCreated struct {
-	MessageCreated *MessageCreated `protobuf:"bytes,3,opt,name=message_created,json=messageCreated,proto3,oneof"`
+func (x *SubagentUpdate) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
 }
 
-type WatchEventsEvent_Error struct {
-	Error *WatchError `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
+type isSubagentUpdate_Update interface {
+	isSubagentUpdate_Update()
 }
 
-type WatchEventsEvent_Done struct {
-	Done *TurnDone `protobuf:"bytes,5,opt,name=done,proto3,oneof"`
+type SubagentUpdate_TextDelta struct {
+	TextDelta *TextDelta `protobuf:"bytes,5,opt,name=text_delta,json=textDelta,proto3,oneof"`
 }
 
-type WatchEventsEvent_TurnStarted struct {
-	TurnStarted *TurnStarted `protobuf:"bytes,6,opt,name=turn_started,json=turnStarted,proto3,oneof"`
+type SubagentUpdate_ToolResult struct {
+	ToolResult *ToolResult `protobuf:"bytes,6,opt,name=tool_result,json=toolResult,proto3,oneof"`
 }
 
-func (*WatchEventsEvent_TextDelta) isWatchEventsEvent_Event() {}
-
-func (*WatchEventsEvent_ToolResult) isWatchEventsEvent_Event() {}
-
-func (*WatchEventsEvent_MessageCreated) isWatchEventsEvent_Event() {}
-
-func (*WatchEventsEvent_Error) isWatchEventsEvent_Event() {}
-
-func (*WatchEventsEvent_Done) isWatchEventsEvent_Event() {}
+func (*SubagentUpdate_TextDelta) isSubagentUpdate_Update() {}
 
-func (*WatchEventsEvent_TurnStarted) isWatchEventsEvent_Event() {}
+func (*SubagentUpdate_ToolResult) isSubagentUpdate_Update() {}
 
 type TextDelta struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -972,7 +3016,7 @@ type TextDelta struct {
 
 func (x *TextDelta) Reset() {
 	*x = TextDelta{}
-	mi := &file_conversation_conversation_proto_msgTypes[16]
+	mi := &file_conversation_conversation_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -984,7 +3028,7 @@ func (x *TextDelta) String() string {
 func (*TextDelta) ProtoMessage() {}
 
 func (x *TextDelta) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[16]
+	mi := &file_conversation_conversation_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,7 +3041,7 @@ func (x *TextDelta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TextDelta.ProtoReflect.Descriptor instead.
 func (*TextDelta) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{16}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *TextDelta) GetContent() string {
@@ -1008,17 +3052,21 @@ func (x *TextDelta) GetContent() string {
 }
 
 type ToolResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Input         string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
-	Result        string                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Input  string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Result string                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	// Set if the tool call failed; the result is then the error message.
+	ErrorCode apierror.ErrorCode `protobuf:"varint,4,opt,name=error_code,json=errorCode,proto3,enum=blippy.apierror.ErrorCode" json:"error_code,omitempty"`
+	// Matches the call_id of the call's ToolExecutionStarted events.
+	CallId        string `protobuf:"bytes,5,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ToolResult) Reset() {
 	*x = ToolResult{}
-	mi := &file_conversation_conversation_proto_msgTypes[17]
+	mi := &file_conversation_conversation_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1030,7 +3078,7 @@ func (x *ToolResult) String() string {
 func (*ToolResult) ProtoMessage() {}
 
 func (x *ToolResult) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[17]
+	mi := &file_conversation_conversation_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,7 +3091,7 @@ func (x *ToolResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolResult.ProtoReflect.Descriptor instead.
 func (*ToolResult) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{17}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *ToolResult) GetName() string {
@@ -1067,6 +3115,85 @@ func (x *ToolResult) GetResult() string {
 	return ""
 }
 
+func (x *ToolResult) GetErrorCode() apierror.ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return apierror.ErrorCode(0)
+}
+
+func (x *ToolResult) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+// ToolExecutionStarted is sent when the LLM starts a tool call, and for each
+// part of its JSON arguments as they're streamed, so clients can show the
+// call, e.g. the command being run, until its ToolResult.
+type ToolExecutionStarted struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	CallId string                 `protobuf:"bytes,1,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Appended to the arguments of the call's earlier events; empty when the
+	// call starts.
+	ArgumentsDelta string `protobuf:"bytes,3,opt,name=arguments_delta,json=argumentsDelta,proto3" json:"arguments_delta,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ToolExecutionStarted) Reset() {
+	*x = ToolExecutionStarted{}
+	mi := &file_conversation_conversation_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolExecutionStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolExecutionStarted) ProtoMessage() {}
+
+func (x *ToolExecutionStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_conversation_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolExecutionStarted.ProtoReflect.Descriptor instead.
+func (*ToolExecutionStarted) Descriptor() ([]byte, []int) {
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ToolExecutionStarted) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *ToolExecutionStarted) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolExecutionStarted) GetArgumentsDelta() string {
+	if x != nil {
+		return x.ArgumentsDelta
+	}
+	return ""
+}
+
 type MessageCreated struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -1076,7 +3203,7 @@ type MessageCreated struct {
 
 func (x *MessageCreated) Reset() {
 	*x = MessageCreated{}
-	mi := &file_conversation_conversation_proto_msgTypes[18]
+	mi := &file_conversation_conversation_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1088,7 +3215,7 @@ func (x *MessageCreated) String() string {
 func (*MessageCreated) ProtoMessage() {}
 
 func (x *MessageCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[18]
+	mi := &file_conversation_conversation_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1101,7 +3228,7 @@ func (x *MessageCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageCreated.ProtoReflect.Descriptor instead.
 func (*MessageCreated) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{18}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *MessageCreated) GetMessage() *Message {
@@ -1114,13 +3241,14 @@ func (x *MessageCreated) GetMessage() *Message {
 type WatchError struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Code          apierror.ErrorCode     `protobuf:"varint,2,opt,name=code,proto3,enum=blippy.apierror.ErrorCode" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WatchError) Reset() {
 	*x = WatchError{}
-	mi := &file_conversation_conversation_proto_msgTypes[19]
+	mi := &file_conversation_conversation_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1132,7 +3260,7 @@ func (x *WatchError) String() string {
 func (*WatchError) ProtoMessage() {}
 
 func (x *WatchError) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[19]
+	mi := &file_conversation_conversation_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1145,7 +3273,7 @@ func (x *WatchError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WatchError.ProtoReflect.Descriptor instead.
 func (*WatchError) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{19}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *WatchError) GetMessage() string {
@@ -1155,6 +3283,13 @@ func (x *WatchError) GetMessage() string {
 	return ""
 }
 
+func (x *WatchError) GetCode() apierror.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return apierror.ErrorCode(0)
+}
+
 type TurnDone struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -1164,7 +3299,7 @@ type TurnDone struct {
 
 func (x *TurnDone) Reset() {
 	*x = TurnDone{}
-	mi := &file_conversation_conversation_proto_msgTypes[20]
+	mi := &file_conversation_conversation_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1176,7 +3311,7 @@ func (x *TurnDone) String() string {
 func (*TurnDone) ProtoMessage() {}
 
 func (x *TurnDone) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[20]
+	mi := &file_conversation_conversation_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1189,7 +3324,7 @@ func (x *TurnDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TurnDone.ProtoReflect.Descriptor instead.
 func (*TurnDone) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{20}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *TurnDone) GetTitle() string {
@@ -1207,7 +3342,7 @@ type TurnStarted struct {
 
 func (x *TurnStarted) Reset() {
 	*x = TurnStarted{}
-	mi := &file_conversation_conversation_proto_msgTypes[21]
+	mi := &file_conversation_conversation_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1219,7 +3354,7 @@ func (x *TurnStarted) String() string {
 func (*TurnStarted) ProtoMessage() {}
 
 func (x *TurnStarted) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[21]
+	mi := &file_conversation_conversation_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1232,7 +3367,7 @@ func (x *TurnStarted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TurnStarted.ProtoReflect.Descriptor instead.
 func (*TurnStarted) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{21}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{47}
 }
 
 type Empty struct {
@@ -1243,7 +3378,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_conversation_conversation_proto_msgTypes[22]
+	mi := &file_conversation_conversation_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1255,7 +3390,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_conversation_proto_msgTypes[22]
+	mi := &file_conversation_conversation_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1268,14 +3403,14 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_conversation_conversation_proto_rawDescGZIP(), []int{22}
+	return file_conversation_conversation_proto_rawDescGZIP(), []int{48}
 }
 
 var File_conversation_conversation_proto protoreflect.FileDescriptor
 
 const file_conversation_conversation_proto_rawDesc = "" +
 	"\n" +
-	"\x1fconversation/conversation.proto\x12\x13blippy.conversation\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf7\x01\n" +
+	"\x1fconversation/conversation.proto\x12\x13blippy.conversation\x1a\x17apierror/apierror.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x02\n" +
 	"\fConversation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x14\n" +
@@ -1284,45 +3419,166 @@ const file_conversation_conversation_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc9\x01\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x120\n" +
+	"\x05usage\x18\a \x01(\v2\x1a.blippy.conversation.UsageR\x05usage\"\xb7\x01\n" +
+	"\x05Usage\x12\x12\n" +
+	"\x04runs\x18\x01 \x01(\x03R\x04runs\x12\x1f\n" +
+	"\vfailed_runs\x18\x02 \x01(\x03R\n" +
+	"failedRuns\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\x12\x19\n" +
+	"\bcost_usd\x18\x05 \x01(\x01R\acostUsd\x12\x16\n" +
+	"\x06priced\x18\x06 \x01(\bR\x06priced\"\xe1\x01\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
 	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x12\n" +
 	"\x04role\x18\x03 \x01(\tR\x04role\x129\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
-	"\x05items\x18\a \x03(\v2 .blippy.conversation.MessageItemR\x05items\"\x9b\x01\n" +
+	"\x05items\x18\a \x03(\v2 .blippy.conversation.MessageItemR\x05items\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\"\x9a\x02\n" +
 	"\vMessageItem\x123\n" +
 	"\x04text\x18\x01 \x01(\v2\x1d.blippy.conversation.TextItemH\x00R\x04text\x12O\n" +
-	"\x0etool_execution\x18\x02 \x01(\v2&.blippy.conversation.ToolExecutionItemH\x00R\rtoolExecutionB\x06\n" +
+	"\x0etool_execution\x18\x02 \x01(\v2&.blippy.conversation.ToolExecutionItemH\x00R\rtoolExecution\x126\n" +
+	"\x05error\x18\x03 \x01(\v2\x1e.blippy.conversation.ErrorItemH\x00R\x05error\x12E\n" +
+	"\n" +
+	"attachment\x18\x04 \x01(\v2#.blippy.conversation.AttachmentItemH\x00R\n" +
+	"attachmentB\x06\n" +
 	"\x04item\"$\n" +
 	"\bTextItem\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent\"U\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"%\n" +
+	"\tErrorItem\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"U\n" +
 	"\x11ToolExecutionItem\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05input\x18\x02 \x01(\tR\x05input\x12\x16\n" +
-	"\x06result\x18\x03 \x01(\tR\x06result\"6\n" +
+	"\x06result\x18\x03 \x01(\tR\x06result\"y\n" +
+	"\x0eAttachmentItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x03 \x01(\tR\tmediaType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\"6\n" +
 	"\x19CreateConversationRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\"(\n" +
 	"\x16GetConversationRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa4\x01\n" +
 	"\x18ListConversationsRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\"d\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06filter\x18\x05 \x01(\tR\x06filter\"\xab\x01\n" +
 	"\x19ListConversationsResponse\x12G\n" +
-	"\rconversations\x18\x01 \x03(\v2!.blippy.conversation.ConversationR\rconversations\"+\n" +
+	"\rconversations\x18\x01 \x03(\v2!.blippy.conversation.ConversationR\rconversations\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"+\n" +
 	"\x19DeleteConversationRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"3\n" +
+	"\x1fBatchDeleteConversationsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"=\n" +
 	"\x12GetMessagesRequest\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"O\n" +
 	"\x13GetMessagesResponse\x128\n" +
-	"\bmessages\x18\x01 \x03(\v2\x1c.blippy.conversation.MessageR\bmessages\"P\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1c.blippy.conversation.MessageR\bmessages\"E\n" +
+	"\x1aGetConversationCostRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"\xac\x02\n" +
+	"\x10ConversationCost\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12!\n" +
+	"\finput_tokens\x18\x02 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x03 \x01(\x03R\foutputTokens\x12\x19\n" +
+	"\bcost_usd\x18\x04 \x01(\x01R\acostUsd\x12\x16\n" +
+	"\x06priced\x18\x05 \x01(\bR\x06priced\x12<\n" +
+	"\bmessages\x18\x06 \x03(\v2 .blippy.conversation.MessageCostR\bmessages\x126\n" +
+	"\x06models\x18\a \x03(\v2\x1e.blippy.conversation.ModelCostR\x06models\"\xd4\x01\n" +
+	"\vMessageCost\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12!\n" +
+	"\finput_tokens\x18\x04 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x05 \x01(\x03R\foutputTokens\x12\x19\n" +
+	"\bcost_usd\x18\x06 \x01(\x01R\acostUsd\x12\x16\n" +
+	"\x06priced\x18\a \x01(\bR\x06priced\"\xb0\x01\n" +
+	"\tModelCost\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x12\n" +
+	"\x04runs\x18\x02 \x01(\x05R\x04runs\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\x12\x19\n" +
+	"\bcost_usd\x18\x05 \x01(\x01R\acostUsd\x12\x16\n" +
+	"\x06priced\x18\x06 \x01(\bR\x06priced\"Y\n" +
+	"\x17ListRunArtifactsRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\"W\n" +
+	"\x18ListRunArtifactsResponse\x12;\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x1d.blippy.conversation.ArtifactR\tartifacts\"\x8f\x02\n" +
+	"\bArtifact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12\x17\n" +
+	"\acall_id\x18\x03 \x01(\tR\x06callId\x12\x1b\n" +
+	"\ttool_name\x18\x04 \x01(\tR\btoolName\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x10\n" +
+	"\x03uri\x18\a \x01(\tR\x03uri\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\b \x01(\tR\tmediaType\x12\x12\n" +
+	"\x04size\x18\t \x01(\x03R\x04size\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"l\n" +
+	"\x0fGetUsageRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fperiod_hours\x18\x02 \x01(\x05R\vperiodHours\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xf6\x01\n" +
+	"\x10GetUsageResponse\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12L\n" +
+	"\rconversations\x18\x03 \x03(\v2&.blippy.conversation.ConversationUsageR\rconversations\x120\n" +
+	"\x05total\x18\x04 \x01(\v2\x1a.blippy.conversation.UsageR\x05total\"\x9f\x01\n" +
+	"\x11ConversationUsage\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x120\n" +
+	"\x05usage\x18\x04 \x01(\v2\x1a.blippy.conversation.UsageR\x05usage\"\xee\x01\n" +
+	"\x1aSearchConversationsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12?\n" +
+	"\rupdated_since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\x12A\n" +
+	"\x0eupdated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"f\n" +
+	"\x1bSearchConversationsResponse\x12G\n" +
+	"\aresults\x18\x01 \x03(\v2-.blippy.conversation.ConversationSearchResultR\aresults\"\xb5\x01\n" +
+	"\x18ConversationSearchResult\x12E\n" +
+	"\fconversation\x18\x01 \x01(\v2!.blippy.conversation.ConversationR\fconversation\x12>\n" +
+	"\bsnippets\x18\x02 \x03(\v2\".blippy.conversation.SearchSnippetR\bsnippets\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x01R\x04rank\"f\n" +
+	"\rSearchSnippet\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x126\n" +
+	"\x05parts\x18\x02 \x03(\v2 .blippy.conversation.SnippetPartR\x05parts\"7\n" +
+	"\vSnippetPart\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05match\x18\x02 \x01(\bR\x05match\"\x93\x01\n" +
 	"\vChatRequest\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"6\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12A\n" +
+	"\vattachments\x18\x03 \x03(\v2\x1f.blippy.conversation.AttachmentR\vattachments\"S\n" +
+	"\n" +
+	"Attachment\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x02 \x01(\tR\tmediaType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"6\n" +
 	"\fChatResponse\x12&\n" +
-	"\x0fuser_message_id\x18\x01 \x01(\tR\ruserMessageId\"=\n" +
+	"\x0fuser_message_id\x18\x01 \x01(\tR\ruserMessageId\"\x85\x01\n" +
 	"\x12WatchEventsRequest\x12'\n" +
-	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"\xa5\x03\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\"\x85\a\n" +
 	"\x10WatchEventsEvent\x12?\n" +
 	"\n" +
 	"text_delta\x18\x01 \x01(\v2\x1e.blippy.conversation.TextDeltaH\x00R\ttextDelta\x12B\n" +
@@ -1331,30 +3587,90 @@ const file_conversation_conversation_proto_rawDesc = "" +
 	"\x0fmessage_created\x18\x03 \x01(\v2#.blippy.conversation.MessageCreatedH\x00R\x0emessageCreated\x127\n" +
 	"\x05error\x18\x04 \x01(\v2\x1f.blippy.conversation.WatchErrorH\x00R\x05error\x123\n" +
 	"\x04done\x18\x05 \x01(\v2\x1d.blippy.conversation.TurnDoneH\x00R\x04done\x12E\n" +
-	"\fturn_started\x18\x06 \x01(\v2 .blippy.conversation.TurnStartedH\x00R\vturnStartedB\a\n" +
-	"\x05event\"%\n" +
+	"\fturn_started\x18\x06 \x01(\v2 .blippy.conversation.TurnStartedH\x00R\vturnStarted\x12,\n" +
+	"\x03gap\x18\b \x01(\v2\x18.blippy.conversation.GapH\x00R\x03gap\x12?\n" +
+	"\bprogress\x18\t \x01(\v2!.blippy.conversation.TurnProgressH\x00R\bprogress\x12A\n" +
+	"\bsubagent\x18\n" +
+	" \x01(\v2#.blippy.conversation.SubagentUpdateH\x00R\bsubagent\x12W\n" +
+	"\x12approval_requested\x18\v \x01(\v2&.blippy.conversation.ApprovalRequestedH\x00R\x11approvalRequested\x12T\n" +
+	"\x11approval_resolved\x18\f \x01(\v2%.blippy.conversation.ApprovalResolvedH\x00R\x10approvalResolved\x12a\n" +
+	"\x16tool_execution_started\x18\r \x01(\v2).blippy.conversation.ToolExecutionStartedH\x00R\x14toolExecutionStarted\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x03R\bsequenceB\a\n" +
+	"\x05event\"\xb4\x02\n" +
+	"\x11ApprovalRequested\x12\x1f\n" +
+	"\vapproval_id\x18\x01 \x01(\tR\n" +
+	"approvalId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x05 \x01(\tR\tagentName\x12\x1b\n" +
+	"\ttool_name\x18\x06 \x01(\tR\btoolName\x12\x14\n" +
+	"\x05input\x18\a \x01(\tR\x05input\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"g\n" +
+	"\x10ApprovalResolved\x12\x1f\n" +
+	"\vapproval_id\x18\x01 \x01(\tR\n" +
+	"approvalId\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"F\n" +
+	"\x03Gap\x12\x16\n" +
+	"\x06missed\x18\x01 \x01(\x05R\x06missed\x12'\n" +
+	"\x0fresume_sequence\x18\x02 \x01(\x03R\x0eresumeSequence\"\x8b\x01\n" +
+	"\fTurnProgress\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x01 \x01(\x03R\telapsedMs\x12\x14\n" +
+	"\x05tools\x18\x02 \x03(\tR\x05tools\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\"\xad\x02\n" +
+	"\x0eSubagentUpdate\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x04 \x01(\tR\tagentName\x12?\n" +
+	"\n" +
+	"text_delta\x18\x05 \x01(\v2\x1e.blippy.conversation.TextDeltaH\x00R\ttextDelta\x12B\n" +
+	"\vtool_result\x18\x06 \x01(\v2\x1f.blippy.conversation.ToolResultH\x00R\n" +
+	"toolResult\x12\x12\n" +
+	"\x04done\x18\a \x01(\bR\x04doneB\b\n" +
+	"\x06update\"%\n" +
 	"\tTextDelta\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent\"N\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\xa2\x01\n" +
 	"\n" +
 	"ToolResult\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05input\x18\x02 \x01(\tR\x05input\x12\x16\n" +
-	"\x06result\x18\x03 \x01(\tR\x06result\"H\n" +
+	"\x06result\x18\x03 \x01(\tR\x06result\x129\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\x0e2\x1a.blippy.apierror.ErrorCodeR\terrorCode\x12\x17\n" +
+	"\acall_id\x18\x05 \x01(\tR\x06callId\"l\n" +
+	"\x14ToolExecutionStarted\x12\x17\n" +
+	"\acall_id\x18\x01 \x01(\tR\x06callId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\x0farguments_delta\x18\x03 \x01(\tR\x0eargumentsDelta\"H\n" +
 	"\x0eMessageCreated\x126\n" +
-	"\amessage\x18\x01 \x01(\v2\x1c.blippy.conversation.MessageR\amessage\"&\n" +
+	"\amessage\x18\x01 \x01(\v2\x1c.blippy.conversation.MessageR\amessage\"V\n" +
 	"\n" +
 	"WatchError\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\" \n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12.\n" +
+	"\x04code\x18\x02 \x01(\x0e2\x1a.blippy.apierror.ErrorCodeR\x04code\" \n" +
 	"\bTurnDone\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\"\r\n" +
 	"\vTurnStarted\"\a\n" +
-	"\x05Empty2\xc7\x05\n" +
+	"\x05Empty2\xe8\t\n" +
 	"\x13ConversationService\x12g\n" +
 	"\x12CreateConversation\x12..blippy.conversation.CreateConversationRequest\x1a!.blippy.conversation.Conversation\x12a\n" +
 	"\x0fGetConversation\x12+.blippy.conversation.GetConversationRequest\x1a!.blippy.conversation.Conversation\x12r\n" +
 	"\x11ListConversations\x12-.blippy.conversation.ListConversationsRequest\x1a..blippy.conversation.ListConversationsResponse\x12`\n" +
-	"\x12DeleteConversation\x12..blippy.conversation.DeleteConversationRequest\x1a\x1a.blippy.conversation.Empty\x12`\n" +
-	"\vGetMessages\x12'.blippy.conversation.GetMessagesRequest\x1a(.blippy.conversation.GetMessagesResponse\x12K\n" +
+	"\x12DeleteConversation\x12..blippy.conversation.DeleteConversationRequest\x1a\x1a.blippy.conversation.Empty\x12l\n" +
+	"\x18BatchDeleteConversations\x124.blippy.conversation.BatchDeleteConversationsRequest\x1a\x1a.blippy.conversation.Empty\x12`\n" +
+	"\vGetMessages\x12'.blippy.conversation.GetMessagesRequest\x1a(.blippy.conversation.GetMessagesResponse\x12m\n" +
+	"\x13GetConversationCost\x12/.blippy.conversation.GetConversationCostRequest\x1a%.blippy.conversation.ConversationCost\x12o\n" +
+	"\x10ListRunArtifacts\x12,.blippy.conversation.ListRunArtifactsRequest\x1a-.blippy.conversation.ListRunArtifactsResponse\x12W\n" +
+	"\bGetUsage\x12$.blippy.conversation.GetUsageRequest\x1a%.blippy.conversation.GetUsageResponse\x12x\n" +
+	"\x13SearchConversations\x12/.blippy.conversation.SearchConversationsRequest\x1a0.blippy.conversation.SearchConversationsResponse\x12K\n" +
 	"\x04Chat\x12 .blippy.conversation.ChatRequest\x1a!.blippy.conversation.ChatResponse\x12_\n" +
 	"\vWatchEvents\x12'.blippy.conversation.WatchEventsRequest\x1a%.blippy.conversation.WatchEventsEvent0\x01B2Z0github.com/dstotijn/blippy/internal/conversationb\x06proto3"
 
@@ -1370,68 +3686,135 @@ func file_conversation_conversation_proto_rawDescGZIP() []byte {
 	return file_conversation_conversation_proto_rawDescData
 }
 
-var file_conversation_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_conversation_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_conversation_conversation_proto_goTypes = []any{
-	(*Conversation)(nil),              // 0: blippy.conversation.Conversation
-	(*Message)(nil),                   // 1: blippy.conversation.Message
-	(*MessageItem)(nil),               // 2: blippy.conversation.MessageItem
-	(*TextItem)(nil),                  // 3: blippy.conversation.TextItem
-	(*ToolExecutionItem)(nil),         // 4: blippy.conversation.ToolExecutionItem
-	(*CreateConversationRequest)(nil), // 5: blippy.conversation.CreateConversationRequest
-	(*GetConversationRequest)(nil),    // 6: blippy.conversation.GetConversationRequest
-	(*ListConversationsRequest)(nil),  // 7: blippy.conversation.ListConversationsRequest
-	(*ListConversationsResponse)(nil), // 8: blippy.conversation.ListConversationsResponse
-	(*DeleteConversationRequest)(nil), // 9: blippy.conversation.DeleteConversationRequest
-	(*GetMessagesRequest)(nil),        // 10: blippy.conversation.GetMessagesRequest
-	(*GetMessagesResponse)(nil),       // 11: blippy.conversation.GetMessagesResponse
-	(*ChatRequest)(nil),               // 12: blippy.conversation.ChatRequest
-	(*ChatResponse)(nil),              // 13: blippy.conversation.ChatResponse
-	(*WatchEventsRequest)(nil),        // 14: blippy.conversation.WatchEventsRequest
-	(*WatchEventsEvent)(nil),          // 15: blippy.conversation.WatchEventsEvent
-	(*TextDelta)(nil),                 // 16: blippy.conversation.TextDelta
-	(*ToolResult)(nil),                // 17: blippy.conversation.ToolResult
-	(*MessageCreated)(nil),            // 18: blippy.conversation.MessageCreated
-	(*WatchError)(nil),                // 19: blippy.conversation.WatchError
-	(*TurnDone)(nil),                  // 20: blippy.conversation.TurnDone
-	(*TurnStarted)(nil),               // 21: blippy.conversation.TurnStarted
-	(*Empty)(nil),                     // 22: blippy.conversation.Empty
-	(*timestamppb.Timestamp)(nil),     // 23: google.protobuf.Timestamp
+	(*Conversation)(nil),                    // 0: blippy.conversation.Conversation
+	(*Usage)(nil),                           // 1: blippy.conversation.Usage
+	(*Message)(nil),                         // 2: blippy.conversation.Message
+	(*MessageItem)(nil),                     // 3: blippy.conversation.MessageItem
+	(*TextItem)(nil),                        // 4: blippy.conversation.TextItem
+	(*ErrorItem)(nil),                       // 5: blippy.conversation.ErrorItem
+	(*ToolExecutionItem)(nil),               // 6: blippy.conversation.ToolExecutionItem
+	(*AttachmentItem)(nil),                  // 7: blippy.conversation.AttachmentItem
+	(*CreateConversationRequest)(nil),       // 8: blippy.conversation.CreateConversationRequest
+	(*GetConversationRequest)(nil),          // 9: blippy.conversation.GetConversationRequest
+	(*ListConversationsRequest)(nil),        // 10: blippy.conversation.ListConversationsRequest
+	(*ListConversationsResponse)(nil),       // 11: blippy.conversation.ListConversationsResponse
+	(*DeleteConversationRequest)(nil),       // 12: blippy.conversation.DeleteConversationRequest
+	(*BatchDeleteConversationsRequest)(nil), // 13: blippy.conversation.BatchDeleteConversationsRequest
+	(*GetMessagesRequest)(nil),              // 14: blippy.conversation.GetMessagesRequest
+	(*GetMessagesResponse)(nil),             // 15: blippy.conversation.GetMessagesResponse
+	(*GetConversationCostRequest)(nil),      // 16: blippy.conversation.GetConversationCostRequest
+	(*ConversationCost)(nil),                // 17: blippy.conversation.ConversationCost
+	(*MessageCost)(nil),                     // 18: blippy.conversation.MessageCost
+	(*ModelCost)(nil),                       // 19: blippy.conversation.ModelCost
+	(*ListRunArtifactsRequest)(nil),         // 20: blippy.conversation.ListRunArtifactsRequest
+	(*ListRunArtifactsResponse)(nil),        // 21: blippy.conversation.ListRunArtifactsResponse
+	(*Artifact)(nil),                        // 22: blippy.conversation.Artifact
+	(*GetUsageRequest)(nil),                 // 23: blippy.conversation.GetUsageRequest
+	(*GetUsageResponse)(nil),                // 24: blippy.conversation.GetUsageResponse
+	(*ConversationUsage)(nil),               // 25: blippy.conversation.ConversationUsage
+	(*SearchConversationsRequest)(nil),      // 26: blippy.conversation.SearchConversationsRequest
+	(*SearchConversationsResponse)(nil),     // 27: blippy.conversation.SearchConversationsResponse
+	(*ConversationSearchResult)(nil),        // 28: blippy.conversation.ConversationSearchResult
+	(*SearchSnippet)(nil),                   // 29: blippy.conversation.SearchSnippet
+	(*SnippetPart)(nil),                     // 30: blippy.conversation.SnippetPart
+	(*ChatRequest)(nil),                     // 31: blippy.conversation.ChatRequest
+	(*Attachment)(nil),                      // 32: blippy.conversation.Attachment
+	(*ChatResponse)(nil),                    // 33: blippy.conversation.ChatResponse
+	(*WatchEventsRequest)(nil),              // 34: blippy.conversation.WatchEventsRequest
+	(*WatchEventsEvent)(nil),                // 35: blippy.conversation.WatchEventsEvent
+	(*ApprovalRequested)(nil),               // 36: blippy.conversation.ApprovalRequested
+	(*ApprovalResolved)(nil),                // 37: blippy.conversation.ApprovalResolved
+	(*Gap)(nil),                             // 38: blippy.conversation.Gap
+	(*TurnProgress)(nil),                    // 39: blippy.conversation.TurnProgress
+	(*SubagentUpdate)(nil),                  // 40: blippy.conversation.SubagentUpdate
+	(*TextDelta)(nil),                       // 41: blippy.conversation.TextDelta
+	(*ToolResult)(nil),                      // 42: blippy.conversation.ToolResult
+	(*ToolExecutionStarted)(nil),            // 43: blippy.conversation.ToolExecutionStarted
+	(*MessageCreated)(nil),                  // 44: blippy.conversation.MessageCreated
+	(*WatchError)(nil),                      // 45: blippy.conversation.WatchError
+	(*TurnDone)(nil),                        // 46: blippy.conversation.TurnDone
+	(*TurnStarted)(nil),                     // 47: blippy.conversation.TurnStarted
+	(*Empty)(nil),                           // 48: blippy.conversation.Empty
+	(*timestamppb.Timestamp)(nil),           // 49: google.protobuf.Timestamp
+	(apierror.ErrorCode)(0),                 // 50: blippy.apierror.ErrorCode
 }
 var file_conversation_conversation_proto_depIdxs = []int32{
-	23, // 0: blippy.conversation.Conversation.created_at:type_name -> google.protobuf.Timestamp
-	23, // 1: blippy.conversation.Conversation.updated_at:type_name -> google.protobuf.Timestamp
-	23, // 2: blippy.conversation.Message.created_at:type_name -> google.protobuf.Timestamp
-	2,  // 3: blippy.conversation.Message.items:type_name -> blippy.conversation.MessageItem
-	3,  // 4: blippy.conversation.MessageItem.text:type_name -> blippy.conversation.TextItem
-	4,  // 5: blippy.conversation.MessageItem.tool_execution:type_name -> blippy.conversation.ToolExecutionItem
-	0,  // 6: blippy.conversation.ListConversationsResponse.conversations:type_name -> blippy.conversation.Conversation
-	1,  // 7: blippy.conversation.GetMessagesResponse.messages:type_name -> blippy.conversation.Message
-	16, // 8: blippy.conversation.WatchEventsEvent.text_delta:type_name -> blippy.conversation.TextDelta
-	17, // 9: blippy.conversation.WatchEventsEvent.tool_result:type_name -> blippy.conversation.ToolResult
-	18, // 10: blippy.conversation.WatchEventsEvent.message_created:type_name -> blippy.conversation.MessageCreated
-	19, // 11: blippy.conversation.WatchEventsEvent.error:type_name -> blippy.conversation.WatchError
-	20, // 12: blippy.conversation.WatchEventsEvent.done:type_name -> blippy.conversation.TurnDone
-	21, // 13: blippy.conversation.WatchEventsEvent.turn_started:type_name -> blippy.conversation.TurnStarted
-	1,  // 14: blippy.conversation.MessageCreated.message:type_name -> blippy.conversation.Message
-	5,  // 15: blippy.conversation.ConversationService.CreateConversation:input_type -> blippy.conversation.CreateConversationRequest
-	6,  // 16: blippy.conversation.ConversationService.GetConversation:input_type -> blippy.conversation.GetConversationRequest
-	7,  // 17: blippy.conversation.ConversationService.ListConversations:input_type -> blippy.conversation.ListConversationsRequest
-	9,  // 18: blippy.conversation.ConversationService.DeleteConversation:input_type -> blippy.conversation.DeleteConversationRequest
-	10, // 19: blippy.conversation.ConversationService.GetMessages:input_type -> blippy.conversation.GetMessagesRequest
-	12, // 20: blippy.conversation.ConversationService.Chat:input_type -> blippy.conversation.ChatRequest
-	14, // 21: blippy.conversation.ConversationService.WatchEvents:input_type -> blippy.conversation.WatchEventsRequest
-	0,  // 22: blippy.conversation.ConversationService.CreateConversation:output_type -> blippy.conversation.Conversation
-	0,  // 23: blippy.conversation.ConversationService.GetConversation:output_type -> blippy.conversation.Conversation
-	8,  // 24: blippy.conversation.ConversationService.ListConversations:output_type -> blippy.conversation.ListConversationsResponse
-	22, // 25: blippy.conversation.ConversationService.DeleteConversation:output_type -> blippy.conversation.Empty
-	11, // 26: blippy.conversation.ConversationService.GetMessages:output_type -> blippy.conversation.GetMessagesResponse
-	13, // 27: blippy.conversation.ConversationService.Chat:output_type -> blippy.conversation.ChatResponse
-	15, // 28: blippy.conversation.ConversationService.WatchEvents:output_type -> blippy.conversation.WatchEventsEvent
-	22, // [22:29] is the sub-list for method output_type
-	15, // [15:22] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	49, // 0: blippy.conversation.Conversation.created_at:type_name -> google.protobuf.Timestamp
+	49, // 1: blippy.conversation.Conversation.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: blippy.conversation.Conversation.usage:type_name -> blippy.conversation.Usage
+	49, // 3: blippy.conversation.Message.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: blippy.conversation.Message.items:type_name -> blippy.conversation.MessageItem
+	4,  // 5: blippy.conversation.MessageItem.text:type_name -> blippy.conversation.TextItem
+	6,  // 6: blippy.conversation.MessageItem.tool_execution:type_name -> blippy.conversation.ToolExecutionItem
+	5,  // 7: blippy.conversation.MessageItem.error:type_name -> blippy.conversation.ErrorItem
+	7,  // 8: blippy.conversation.MessageItem.attachment:type_name -> blippy.conversation.AttachmentItem
+	0,  // 9: blippy.conversation.ListConversationsResponse.conversations:type_name -> blippy.conversation.Conversation
+	2,  // 10: blippy.conversation.GetMessagesResponse.messages:type_name -> blippy.conversation.Message
+	18, // 11: blippy.conversation.ConversationCost.messages:type_name -> blippy.conversation.MessageCost
+	19, // 12: blippy.conversation.ConversationCost.models:type_name -> blippy.conversation.ModelCost
+	22, // 13: blippy.conversation.ListRunArtifactsResponse.artifacts:type_name -> blippy.conversation.Artifact
+	49, // 14: blippy.conversation.Artifact.created_at:type_name -> google.protobuf.Timestamp
+	49, // 15: blippy.conversation.GetUsageResponse.since:type_name -> google.protobuf.Timestamp
+	49, // 16: blippy.conversation.GetUsageResponse.until:type_name -> google.protobuf.Timestamp
+	25, // 17: blippy.conversation.GetUsageResponse.conversations:type_name -> blippy.conversation.ConversationUsage
+	1,  // 18: blippy.conversation.GetUsageResponse.total:type_name -> blippy.conversation.Usage
+	1,  // 19: blippy.conversation.ConversationUsage.usage:type_name -> blippy.conversation.Usage
+	49, // 20: blippy.conversation.SearchConversationsRequest.updated_since:type_name -> google.protobuf.Timestamp
+	49, // 21: blippy.conversation.SearchConversationsRequest.updated_before:type_name -> google.protobuf.Timestamp
+	28, // 22: blippy.conversation.SearchConversationsResponse.results:type_name -> blippy.conversation.ConversationSearchResult
+	0,  // 23: blippy.conversation.ConversationSearchResult.conversation:type_name -> blippy.conversation.Conversation
+	29, // 24: blippy.conversation.ConversationSearchResult.snippets:type_name -> blippy.conversation.SearchSnippet
+	30, // 25: blippy.conversation.SearchSnippet.parts:type_name -> blippy.conversation.SnippetPart
+	32, // 26: blippy.conversation.ChatRequest.attachments:type_name -> blippy.conversation.Attachment
+	41, // 27: blippy.conversation.WatchEventsEvent.text_delta:type_name -> blippy.conversation.TextDelta
+	42, // 28: blippy.conversation.WatchEventsEvent.tool_result:type_name -> blippy.conversation.ToolResult
+	44, // 29: blippy.conversation.WatchEventsEvent.message_created:type_name -> blippy.conversation.MessageCreated
+	45, // 30: blippy.conversation.WatchEventsEvent.error:type_name -> blippy.conversation.WatchError
+	46, // 31: blippy.conversation.WatchEventsEvent.done:type_name -> blippy.conversation.TurnDone
+	47, // 32: blippy.conversation.WatchEventsEvent.turn_started:type_name -> blippy.conversation.TurnStarted
+	38, // 33: blippy.conversation.WatchEventsEvent.gap:type_name -> blippy.conversation.Gap
+	39, // 34: blippy.conversation.WatchEventsEvent.progress:type_name -> blippy.conversation.TurnProgress
+	40, // 35: blippy.conversation.WatchEventsEvent.subagent:type_name -> blippy.conversation.SubagentUpdate
+	36, // 36: blippy.conversation.WatchEventsEvent.approval_requested:type_name -> blippy.conversation.ApprovalRequested
+	37, // 37: blippy.conversation.WatchEventsEvent.approval_resolved:type_name -> blippy.conversation.ApprovalResolved
+	43, // 38: blippy.conversation.WatchEventsEvent.tool_execution_started:type_name -> blippy.conversation.ToolExecutionStarted
+	49, // 39: blippy.conversation.ApprovalRequested.expires_at:type_name -> google.protobuf.Timestamp
+	41, // 40: blippy.conversation.SubagentUpdate.text_delta:type_name -> blippy.conversation.TextDelta
+	42, // 41: blippy.conversation.SubagentUpdate.tool_result:type_name -> blippy.conversation.ToolResult
+	50, // 42: blippy.conversation.ToolResult.error_code:type_name -> blippy.apierror.ErrorCode
+	2,  // 43: blippy.conversation.MessageCreated.message:type_name -> blippy.conversation.Message
+	50, // 44: blippy.conversation.WatchError.code:type_name -> blippy.apierror.ErrorCode
+	8,  // 45: blippy.conversation.ConversationService.CreateConversation:input_type -> blippy.conversation.CreateConversationRequest
+	9,  // 46: blippy.conversation.ConversationService.GetConversation:input_type -> blippy.conversation.GetConversationRequest
+	10, // 47: blippy.conversation.ConversationService.ListConversations:input_type -> blippy.conversation.ListConversationsRequest
+	12, // 48: blippy.conversation.ConversationService.DeleteConversation:input_type -> blippy.conversation.DeleteConversationRequest
+	13, // 49: blippy.conversation.ConversationService.BatchDeleteConversations:input_type -> blippy.conversation.BatchDeleteConversationsRequest
+	14, // 50: blippy.conversation.ConversationService.GetMessages:input_type -> blippy.conversation.GetMessagesRequest
+	16, // 51: blippy.conversation.ConversationService.GetConversationCost:input_type -> blippy.conversation.GetConversationCostRequest
+	20, // 52: blippy.conversation.ConversationService.ListRunArtifacts:input_type -> blippy.conversation.ListRunArtifactsRequest
+	23, // 53: blippy.conversation.ConversationService.GetUsage:input_type -> blippy.conversation.GetUsageRequest
+	26, // 54: blippy.conversation.ConversationService.SearchConversations:input_type -> blippy.conversation.SearchConversationsRequest
+	31, // 55: blippy.conversation.ConversationService.Chat:input_type -> blippy.conversation.ChatRequest
+	34, // 56: blippy.conversation.ConversationService.WatchEvents:input_type -> blippy.conversation.WatchEventsRequest
+	0,  // 57: blippy.conversation.ConversationService.CreateConversation:output_type -> blippy.conversation.Conversation
+	0,  // 58: blippy.conversation.ConversationService.GetConversation:output_type -> blippy.conversation.Conversation
+	11, // 59: blippy.conversation.ConversationService.ListConversations:output_type -> blippy.conversation.ListConversationsResponse
+	48, // 60: blippy.conversation.ConversationService.DeleteConversation:output_type -> blippy.conversation.Empty
+	48, // 61: blippy.conversation.ConversationService.BatchDeleteConversations:output_type -> blippy.conversation.Empty
+	15, // 62: blippy.conversation.ConversationService.GetMessages:output_type -> blippy.conversation.GetMessagesResponse
+	17, // 63: blippy.conversation.ConversationService.GetConversationCost:output_type -> blippy.conversation.ConversationCost
+	21, // 64: blippy.conversation.ConversationService.ListRunArtifacts:output_type -> blippy.conversation.ListRunArtifactsResponse
+	24, // 65: blippy.conversation.ConversationService.GetUsage:output_type -> blippy.conversation.GetUsageResponse
+	27, // 66: blippy.conversation.ConversationService.SearchConversations:output_type -> blippy.conversation.SearchConversationsResponse
+	33, // 67: blippy.conversation.ConversationService.Chat:output_type -> blippy.conversation.ChatResponse
+	35, // 68: blippy.conversation.ConversationService.WatchEvents:output_type -> blippy.conversation.WatchEventsEvent
+	57, // [57:69] is the sub-list for method output_type
+	45, // [45:57] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_conversation_conversation_proto_init() }
@@ -1439,17 +3822,29 @@ func file_conversation_conversation_proto_init() {
 	if File_conversation_conversation_proto != nil {
 		return
 	}
-	file_conversation_conversation_proto_msgTypes[2].OneofWrappers = []any{
+	file_conversation_conversation_proto_msgTypes[3].OneofWrappers = []any{
 		(*MessageItem_Text)(nil),
 		(*MessageItem_ToolExecution)(nil),
+		(*MessageItem_Error)(nil),
+		(*MessageItem_Attachment)(nil),
 	}
-	file_conversation_conversation_proto_msgTypes[15].OneofWrappers = []any{
+	file_conversation_conversation_proto_msgTypes[35].OneofWrappers = []any{
 		(*WatchEventsEvent_TextDelta)(nil),
 		(*WatchEventsEvent_ToolResult)(nil),
 		(*WatchEventsEvent_MessageCreated)(nil),
 		(*WatchEventsEvent_Error)(nil),
 		(*WatchEventsEvent_Done)(nil),
 		(*WatchEventsEvent_TurnStarted)(nil),
+		(*WatchEventsEvent_Gap)(nil),
+		(*WatchEventsEvent_Progress)(nil),
+		(*WatchEventsEvent_Subagent)(nil),
+		(*WatchEventsEvent_ApprovalRequested)(nil),
+		(*WatchEventsEvent_ApprovalResolved)(nil),
+		(*WatchEventsEvent_ToolExecutionStarted)(nil),
+	}
+	file_conversation_conversation_proto_msgTypes[40].OneofWrappers = []any{
+		(*SubagentUpdate_TextDelta)(nil),
+		(*SubagentUpdate_ToolResult)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1457,7 +3852,7 @@ func file_conversation_conversation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conversation_conversation_proto_rawDesc), len(file_conversation_conversation_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
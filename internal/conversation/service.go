@@ -3,10 +3,11 @@ package conversation
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
@@ -14,8 +15,13 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/blob"
+	"github.com/dstotijn/blippy/internal/listing"
+	"github.com/dstotijn/blippy/internal/maintenance"
 	"github.com/dstotijn/blippy/internal/pubsub"
 	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/usage"
 )
 
 type Service struct {
@@ -23,14 +29,20 @@ type Service struct {
 	db      *sql.DB
 	broker  *pubsub.Broker
 	loop    *agentloop.Loop
+	maint   *maintenance.Mode
+	usage   *usage.Reporter
+	blobs   *blob.Store
 }
 
-func NewService(db *sql.DB, broker *pubsub.Broker, loop *agentloop.Loop) *Service {
+func NewService(db *sql.DB, broker *pubsub.Broker, loop *agentloop.Loop, maint *maintenance.Mode, blobs *blob.Store) *Service {
 	return &Service{
 		queries: store.New(db),
 		db:      db,
 		broker:  broker,
 		loop:    loop,
+		maint:   maint,
+		usage:   usage.NewReporter(store.New(db), loop.ORClient),
+		blobs:   blobs,
 	}
 }
 
@@ -56,33 +68,74 @@ func (s *Service) GetConversation(ctx context.Context, req *connect.Request[GetC
 	conv, err := s.queries.GetConversation(ctx, req.Msg.Id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("conversation not found"))
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND, errors.New("conversation not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	runs, err := s.queries.ListRunUsageByConversation(ctx, conv.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
-	return connect.NewResponse(toProtoConversation(conv)), nil
+	res := toProtoConversation(conv)
+	res.Usage = &Usage{Priced: true}
+	prices := usage.Prices(ctx, s.loop.ORClient)
+	for _, run := range runs {
+		cost, ok := usage.RunCost(prices, run.Model, run.InputTokens, run.OutputTokens, run.CostUsd)
+		res.Usage.Runs++
+		if run.Status == agentloop.RunStatusFailed || run.Status == agentloop.RunStatusTimedOut {
+			res.Usage.FailedRuns++
+		}
+		res.Usage.InputTokens += run.InputTokens
+		res.Usage.OutputTokens += run.OutputTokens
+		res.Usage.CostUsd += cost
+		res.Usage.Priced = res.Usage.Priced && ok
+	}
+	return connect.NewResponse(res), nil
 }
 
 func (s *Service) ListConversations(ctx context.Context, req *connect.Request[ListConversationsRequest]) (*connect.Response[ListConversationsResponse], error) {
-	var convs []store.Conversation
-	var err error
-
+	listReq := listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Filter:    req.Msg.Filter,
+	}
 	if req.Msg.AgentId != "" {
-		convs, err = s.queries.ListConversations(ctx, req.Msg.AgentId)
-	} else {
-		convs, err = s.queries.ListAllConversations(ctx)
+		listReq.Match = map[string]string{"agent_id": req.Msg.AgentId}
+	}
+	query, err := listing.Parse(listReq, conversationTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListConversationsPage, s.queries.CountConversationsPage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	protoConvs := make([]*Conversation, len(convs))
-	for i, c := range convs {
+	protoConvs := make([]*Conversation, len(page.Items))
+	for i, c := range page.Items {
 		protoConvs[i] = toProtoConversation(c)
 	}
 
-	return connect.NewResponse(&ListConversationsResponse{Conversations: protoConvs}), nil
+	return connect.NewResponse(&ListConversationsResponse{
+		Conversations: protoConvs,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}), nil
+}
+
+// conversationTable has the fields usable in ListConversations filters and
+// order clauses.
+var conversationTable = listing.Table[store.Conversation]{
+	Fields: listing.Fields[store.Conversation]{
+		"id":         {Column: "id", Value: func(c store.Conversation) string { return c.ID }},
+		"agent_id":   {Column: "agent_id", Value: func(c store.Conversation) string { return c.AgentID }},
+		"title":      {Column: "title", Value: func(c store.Conversation) string { return c.Title }},
+		"created_at": {Column: "created_at", Value: func(c store.Conversation) string { return c.CreatedAt }},
+		"updated_at": {Column: "updated_at", Value: func(c store.Conversation) string { return c.UpdatedAt }},
+	},
+	Order: "updated_at desc",
 }
 
 func (s *Service) DeleteConversation(ctx context.Context, req *connect.Request[DeleteConversationRequest]) (*connect.Response[Empty], error) {
@@ -93,6 +146,29 @@ func (s *Service) DeleteConversation(ctx context.Context, req *connect.Request[D
 	return connect.NewResponse(&Empty{}), nil
 }
 
+// maxBatchSize is the maximum number of IDs of a batch request.
+const maxBatchSize = 500
+
+func (s *Service) BatchDeleteConversations(ctx context.Context, req *connect.Request[BatchDeleteConversationsRequest]) (*connect.Response[Empty], error) {
+	if len(req.Msg.Ids) == 0 || len(req.Msg.Ids) > maxBatchSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("between 1 and %d ids are required", maxBatchSize))
+	}
+
+	err := s.queries.InTx(ctx, func(q *store.Queries) error {
+		for _, id := range req.Msg.Ids {
+			if err := q.DeleteConversation(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&Empty{}), nil
+}
+
 func (s *Service) GetMessages(ctx context.Context, req *connect.Request[GetMessagesRequest]) (*connect.Response[GetMessagesResponse], error) {
 	msgs, err := s.queries.GetMessagesByConversation(ctx, req.Msg.ConversationId)
 	if err != nil {
@@ -101,61 +177,310 @@ func (s *Service) GetMessages(ctx context.Context, req *connect.Request[GetMessa
 
 	protoMsgs := make([]*Message, len(msgs))
 	for i, m := range msgs {
-		protoMsgs[i] = toProtoMessage(m)
+		if protoMsgs[i], err = toProtoMessage(m); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	if err := s.signAttachments(ctx, req.Msg.ConversationId, protoMsgs...); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
 	return connect.NewResponse(&GetMessagesResponse{Messages: protoMsgs}), nil
 }
 
-// Chat saves the user message, starts background LLM processing, and returns immediately.
+// GetConversationCost returns the token usage of the conversation's runs per
+// message and per model, with their reported cost, or else priced with the
+// current OpenRouter model prices.
+func (s *Service) GetConversationCost(ctx context.Context, req *connect.Request[GetConversationCostRequest]) (*connect.Response[ConversationCost], error) {
+	if _, err := s.queries.GetConversation(ctx, req.Msg.ConversationId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND, errors.New("conversation not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	runs, err := s.queries.ListRunUsageByConversation(ctx, req.Msg.ConversationId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	msgs, err := s.queries.GetMessagesByConversation(ctx, req.Msg.ConversationId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	msgIDs := make(map[string]string, len(msgs))
+	for _, m := range msgs {
+		if m.RunID != "" {
+			msgIDs[m.RunID] = m.ID
+		}
+	}
+
+	prices := usage.Prices(ctx, s.loop.ORClient)
+	res := &ConversationCost{ConversationId: req.Msg.ConversationId, Priced: true}
+	byModel := make(map[string]*ModelCost)
+	for _, run := range runs {
+		cost, ok := usage.RunCost(prices, run.Model, run.InputTokens, run.OutputTokens, run.CostUsd)
+		res.Messages = append(res.Messages, &MessageCost{
+			MessageId:    msgIDs[run.RunID],
+			RunId:        run.RunID,
+			Model:        run.Model,
+			InputTokens:  run.InputTokens,
+			OutputTokens: run.OutputTokens,
+			CostUsd:      cost,
+			Priced:       ok,
+		})
+
+		mc := byModel[run.Model]
+		if mc == nil {
+			mc = &ModelCost{Model: run.Model, Priced: ok}
+			byModel[run.Model] = mc
+			res.Models = append(res.Models, mc)
+		}
+		mc.Runs++
+		mc.InputTokens += run.InputTokens
+		mc.OutputTokens += run.OutputTokens
+		mc.CostUsd += cost
+
+		res.InputTokens += run.InputTokens
+		res.OutputTokens += run.OutputTokens
+		res.CostUsd += cost
+		res.Priced = res.Priced && ok
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// ListRunArtifacts lists the artifacts tool calls registered on the runs of a
+// conversation, or of one of its runs.
+func (s *Service) ListRunArtifacts(ctx context.Context, req *connect.Request[ListRunArtifactsRequest]) (*connect.Response[ListRunArtifactsResponse], error) {
+	if _, err := s.queries.GetConversation(ctx, req.Msg.ConversationId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND, errors.New("conversation not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var (
+		artifacts []store.Artifact
+		err       error
+	)
+	if req.Msg.RunId != "" {
+		artifacts, err = s.queries.ListArtifactsByRun(ctx, store.ListArtifactsByRunParams{
+			ConversationID: req.Msg.ConversationId,
+			RunID:          req.Msg.RunId,
+		})
+	} else {
+		artifacts, err = s.queries.ListArtifactsByConversation(ctx, req.Msg.ConversationId)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := &ListRunArtifactsResponse{}
+	for _, a := range artifacts {
+		artifact := toProtoArtifact(a)
+		if a.BlobID.Valid {
+			artifact.Uri = s.blobs.SignedURL(a.BlobID.String, 0)
+		}
+		res.Artifacts = append(res.Artifacts, artifact)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// Usage page sizes.
+const (
+	defaultUsagePageSize = 20
+	maxUsagePageSize     = 100
+)
+
+// GetUsage returns the usage of the agent's conversations with runs in a
+// period, most expensive first.
+func (s *Service) GetUsage(ctx context.Context, req *connect.Request[GetUsageRequest]) (*connect.Response[GetUsageResponse], error) {
+	if req.Msg.AgentId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id is required"))
+	}
+	if req.Msg.PeriodHours < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("period_hours must not be negative"))
+	}
+	pageSize := int(req.Msg.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultUsagePageSize
+	}
+	pageSize = min(pageSize, maxUsagePageSize)
+
+	report, err := s.usage.ConversationReport(ctx, time.Duration(req.Msg.PeriodHours)*time.Hour, req.Msg.AgentId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := &GetUsageResponse{
+		Since: timestamppb.New(report.Since),
+		Until: timestamppb.New(report.Until),
+		Total: toProtoUsage(report.Total),
+	}
+	for _, c := range report.Conversations[:min(len(report.Conversations), pageSize)] {
+		conv, err := s.queries.GetConversation(ctx, c.ConversationID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		res.Conversations = append(res.Conversations, &ConversationUsage{
+			ConversationId: c.ConversationID,
+			AgentId:        c.AgentID,
+			Title:          conv.Title,
+			Usage:          toProtoUsage(c.Usage),
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func toProtoUsage(u usage.Usage) *Usage {
+	return &Usage{
+		Runs:         u.Runs,
+		FailedRuns:   u.FailedRuns,
+		InputTokens:  u.InputTokens,
+		OutputTokens: u.OutputTokens,
+		CostUsd:      u.CostUSD,
+		Priced:       u.Priced,
+	}
+}
+
+// Search result limits.
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+	searchSnippets        = 3
+)
+
+// SearchConversations returns the conversations whose title or messages
+// match a full-text query, the most relevant first, with highlighted
+// snippets of the matches.
+func (s *Service) SearchConversations(ctx context.Context, req *connect.Request[SearchConversationsRequest]) (*connect.Response[SearchConversationsResponse], error) {
+	if strings.TrimSpace(req.Msg.Query) == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("query is required"))
+	}
+	pageSize := int(req.Msg.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("page_size must not be negative"))
+	case pageSize == 0:
+		pageSize = defaultSearchPageSize
+	case pageSize > maxSearchPageSize:
+		pageSize = maxSearchPageSize
+	}
+	params := store.SearchConversationsParams{
+		Query:                   req.Msg.Query,
+		AgentID:                 req.Msg.AgentId,
+		Limit:                   pageSize,
+		SnippetsPerConversation: searchSnippets,
+	}
+	if req.Msg.UpdatedSince != nil {
+		params.UpdatedSince = req.Msg.UpdatedSince.AsTime().UTC().Format(time.RFC3339)
+	}
+	if req.Msg.UpdatedBefore != nil {
+		params.UpdatedBefore = req.Msg.UpdatedBefore.AsTime().UTC().Format(time.RFC3339)
+	}
+
+	matches, err := s.queries.SearchConversations(ctx, params)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// Matches are grouped by conversation, in order of relevance.
+	res := &SearchConversationsResponse{}
+	var result *ConversationSearchResult
+	for _, m := range matches {
+		if result == nil || result.Conversation.Id != m.ConversationID {
+			conv, err := s.queries.GetConversation(ctx, m.ConversationID)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			result = &ConversationSearchResult{
+				Conversation: toProtoConversation(conv),
+				Rank:         m.Rank,
+			}
+			res.Results = append(res.Results, result)
+		}
+		result.Snippets = append(result.Snippets, &SearchSnippet{
+			MessageId: m.MessageID,
+			Parts:     toProtoSnippetParts(m.Snippet),
+		})
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// toProtoSnippetParts splits a snippet at its highlight markers.
+func toProtoSnippetParts(snippet string) []*SnippetPart {
+	var parts []*SnippetPart
+	for {
+		before, rest, ok := strings.Cut(snippet, store.HighlightStart)
+		if before != "" {
+			parts = append(parts, &SnippetPart{Text: before})
+		}
+		if !ok {
+			return parts
+		}
+		match, after, _ := strings.Cut(rest, store.HighlightEnd)
+		parts = append(parts, &SnippetPart{Text: match, Match: true})
+		snippet = after
+	}
+}
+
+// Chat saves the user message and its attachments, starts background LLM
+// processing, and returns immediately.
 func (s *Service) Chat(ctx context.Context, req *connect.Request[ChatRequest]) (*connect.Response[ChatResponse], error) {
+	attachments, err := validateAttachments(req.Msg.Attachments)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	// Get conversation
 	conv, err := s.queries.GetConversation(ctx, req.Msg.ConversationId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("conversation not found"))
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND, errors.New("conversation not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	// Check if conversation is already busy
-	if !s.broker.SetBusy(conv.ID) {
-		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("conversation is already processing"))
+	// Don't save a message that won't get a response.
+	if s.loop.ShuttingDown() {
+		return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_SHUTTING_DOWN, agentloop.ErrShuttingDown)
+	}
+	if err := s.maint.Err(); err != nil {
+		return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_MAINTENANCE_MODE, err)
 	}
 
 	// Get agent for system prompt and tools
 	agent, err := s.queries.GetAgent(ctx, conv.AgentID)
 	if err != nil {
-		s.broker.ClearBusy(conv.ID)
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	// Get existing messages for conversation history
-	existingMsgs, err := s.queries.GetMessagesByConversation(ctx, conv.ID)
-	if err != nil {
-		s.broker.ClearBusy(conv.ID)
+	if err := s.storeAttachments(ctx, conv.ID, attachments); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-
-	// Save user message
-	userMsgID, err := s.loop.SaveUserMessage(ctx, conv.ID, req.Msg.Content)
+	existingMsgs, userMsgID, err := s.loop.StartTurn(ctx, conv.ID, req.Msg.Content, attachments...)
+	if err != nil {
+		s.deleteAttachments(context.WithoutCancel(ctx), attachments)
+	}
+	if errors.Is(err, agentloop.ErrConversationBusy) {
+		return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_BUSY, err)
+	}
 	if err != nil {
-		s.broker.ClearBusy(conv.ID)
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	// Publish turn started
-	s.broker.Publish(conv.ID, agentloop.TurnStarted{})
-
 	// Start background processing (not tied to HTTP request)
 	go func() {
 		if _, err := s.loop.RunTurn(context.Background(), agentloop.TurnOpts{
 			Conv:        conv,
 			Agent:       agent,
 			UserContent: req.Msg.Content,
+			Attachments: attachments,
 			History:     existingMsgs,
+			Kind:        agentloop.RunKindInteractive,
 		}); err != nil {
-			log.Printf("Background agent turn error (conv %s): %v", conv.ID, err)
+			slog.Error("background agent turn failed", "conversation_id", conv.ID, "error", err)
 		}
 	}()
 
@@ -169,16 +494,45 @@ func (s *Service) WatchEvents(ctx context.Context, req *connect.Request[WatchEve
 	// Validate conversation exists
 	if _, err := s.queries.GetConversation(ctx, convID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return connect.NewError(connect.CodeNotFound, errors.New("conversation not found"))
+			return apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND, errors.New("conversation not found"))
 		}
 		return connect.NewError(connect.CodeInternal, err)
 	}
 
-	sub := s.broker.Subscribe(convID)
+	var types []string
+	for _, name := range req.Msg.EventTypes {
+		typ, ok := watchEventTypes[name]
+		if !ok {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid event type %q", name))
+		}
+		types = append(types, typ)
+	}
+
+	// Without a sequence to resume from, only new events are streamed.
+	var (
+		sub    *pubsub.Subscription
+		replay []*pubsub.Event
+	)
+	if req.Msg.AfterSequence > 0 {
+		var err error
+		sub, replay, err = s.broker.SubscribeFrom(ctx, convID, req.Msg.AfterSequence, pubsub.OnlyTypes(types...))
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+	} else {
+		sub = s.broker.Subscribe(convID, pubsub.OnlyTypes(types...))
+	}
 	defer s.broker.Unsubscribe(sub)
 
-	// If the conversation is currently busy, send initial TurnStarted event
-	if s.broker.IsBusy(convID) {
+	// Send the response headers right away, so clients know they're
+	// subscribed before they start a turn.
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+
+	// If the conversation is currently busy, send initial TurnStarted event,
+	// unless the client is catching up and gets it from the replay.
+	if req.Msg.AfterSequence == 0 && (len(types) == 0 || slices.Contains(types, "turn_started")) && s.broker.IsBusy(convID) {
 		if err := stream.Send(&WatchEventsEvent{
 			Event: &WatchEventsEvent_TurnStarted{TurnStarted: &TurnStarted{}},
 		}); err != nil {
@@ -186,18 +540,32 @@ func (s *Service) WatchEvents(ctx context.Context, req *connect.Request[WatchEve
 		}
 	}
 
+	send := func(event *pubsub.Event) error {
+		protoEvent, err := toProtoWatchEvent(event.Payload)
+		if err != nil {
+			return err
+		}
+		protoEvent.Sequence = event.Sequence
+		if m := protoEvent.GetMessageCreated().GetMessage(); m != nil {
+			if err := s.signAttachments(ctx, convID, m); err != nil {
+				return err
+			}
+		}
+		return stream.Send(protoEvent)
+	}
+	for _, event := range replay {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+
 	for {
 		select {
 		case event, ok := <-sub.C:
 			if !ok {
 				return nil
 			}
-
-			protoEvent, err := toProtoWatchEvent(event)
-			if err != nil {
-				return err
-			}
-			if err := stream.Send(protoEvent); err != nil {
+			if err := send(event); err != nil {
 				return err
 			}
 
@@ -207,29 +575,60 @@ func (s *Service) WatchEvents(ctx context.Context, req *connect.Request[WatchEve
 	}
 }
 
-func toProtoWatchEvent(event any) (*WatchEventsEvent, error) {
-	switch e := event.(type) {
-	case agentloop.TextDelta:
+// watchEventTypes maps the fields of WatchEventsEvent's event to the types of
+// the broker events they're converted from.
+var watchEventTypes = map[string]string{
+	"text_delta":             "text_delta",
+	"tool_result":            "tool_result",
+	"message_created":        "message_done",
+	"error":                  "error",
+	"done":                   "turn_done",
+	"turn_started":           "turn_started",
+	"gap":                    "gap",
+	"progress":               "turn_progress",
+	"subagent":               "subagent_update",
+	"approval_requested":     "approval_requested",
+	"approval_resolved":      "approval_resolved",
+	"tool_execution_started": "tool_execution_started",
+}
+
+func toProtoWatchEvent(payload pubsub.Payload) (*WatchEventsEvent, error) {
+	switch p := payload.(type) {
+	case *pubsub.Event_TextDelta:
 		return &WatchEventsEvent{
 			Event: &WatchEventsEvent_TextDelta{
-				TextDelta: &TextDelta{Content: e.Content},
+				TextDelta: &TextDelta{Content: p.TextDelta.Content},
 			},
 		}, nil
-	case agentloop.ToolResult:
+	case *pubsub.Event_ToolResult:
+		e := p.ToolResult
 		return &WatchEventsEvent{
 			Event: &WatchEventsEvent_ToolResult{
 				ToolResult: &ToolResult{
-					Name:   e.Name,
-					Input:  e.Input,
-					Result: e.Result,
+					Name:      e.Name,
+					Input:     e.Input,
+					Result:    e.Result,
+					ErrorCode: e.ErrorCode,
+					CallId:    e.CallId,
 				},
 			},
 		}, nil
-	case agentloop.MessageDone:
-		// Parse items JSON to build proto message
-		var items []agentloop.StoredItem
-		if e.ItemsJSON != "" && e.ItemsJSON != "[]" {
-			_ = json.Unmarshal([]byte(e.ItemsJSON), &items)
+	case *pubsub.Event_ToolExecutionStarted:
+		e := p.ToolExecutionStarted
+		return &WatchEventsEvent{
+			Event: &WatchEventsEvent_ToolExecutionStarted{
+				ToolExecutionStarted: &ToolExecutionStarted{
+					CallId:         e.CallId,
+					Name:           e.Name,
+					ArgumentsDelta: e.ArgumentsDelta,
+				},
+			},
+		}, nil
+	case *pubsub.Event_MessageDone:
+		e := p.MessageDone
+		items, err := agentloop.DecodeItems(e.ItemsJson)
+		if err != nil {
+			return nil, err
 		}
 		createdAt, _ := time.Parse(time.RFC3339, e.CreatedAt)
 		protoItems := storedItemsToProto(items)
@@ -238,32 +637,104 @@ func toProtoWatchEvent(event any) (*WatchEventsEvent, error) {
 			Event: &WatchEventsEvent_MessageCreated{
 				MessageCreated: &MessageCreated{
 					Message: &Message{
-						Id:        e.MessageID,
+						Id:        e.MessageId,
 						Role:      e.Role,
 						CreatedAt: timestamppb.New(createdAt),
 						Items:     protoItems,
+						Status:    e.Status,
 					},
 				},
 			},
 		}, nil
-	case agentloop.TurnDone:
+	case *pubsub.Event_TurnDone:
 		return &WatchEventsEvent{
 			Event: &WatchEventsEvent_Done{
-				Done: &TurnDone{Title: e.Title},
+				Done: &TurnDone{Title: p.TurnDone.Title},
 			},
 		}, nil
-	case agentloop.TurnStarted:
+	case *pubsub.Event_TurnStarted:
 		return &WatchEventsEvent{
 			Event: &WatchEventsEvent_TurnStarted{TurnStarted: &TurnStarted{}},
 		}, nil
-	case agentloop.Error:
+	case *pubsub.Event_TurnProgress:
+		e := p.TurnProgress
+		return &WatchEventsEvent{
+			Event: &WatchEventsEvent_Progress{
+				Progress: &TurnProgress{
+					ElapsedMs:    e.ElapsedMs,
+					Tools:        e.Tools,
+					InputTokens:  e.InputTokens,
+					OutputTokens: e.OutputTokens,
+				},
+			},
+		}, nil
+	case *pubsub.Event_SubagentUpdate:
+		e := p.SubagentUpdate
+		update := &SubagentUpdate{
+			RunId:          e.RunId,
+			ConversationId: e.ConversationId,
+			AgentId:        e.AgentId,
+			AgentName:      e.AgentName,
+			Done:           e.Done,
+		}
+		switch u := e.Update.(type) {
+		case *pubsub.SubagentUpdate_TextDelta:
+			update.Update = &SubagentUpdate_TextDelta{TextDelta: &TextDelta{Content: u.TextDelta.Content}}
+		case *pubsub.SubagentUpdate_ToolResult:
+			update.Update = &SubagentUpdate_ToolResult{ToolResult: &ToolResult{
+				Name:      u.ToolResult.Name,
+				Input:     u.ToolResult.Input,
+				Result:    u.ToolResult.Result,
+				ErrorCode: u.ToolResult.ErrorCode,
+				CallId:    u.ToolResult.CallId,
+			}}
+		}
+		return &WatchEventsEvent{
+			Event: &WatchEventsEvent_Subagent{Subagent: update},
+		}, nil
+	case *pubsub.Event_ApprovalRequested:
+		e := p.ApprovalRequested
+		expiresAt, _ := time.Parse(time.RFC3339, e.ExpiresAt)
+		return &WatchEventsEvent{
+			Event: &WatchEventsEvent_ApprovalRequested{
+				ApprovalRequested: &ApprovalRequested{
+					ApprovalId:     e.ApprovalId,
+					RunId:          e.RunId,
+					ConversationId: e.ConversationId,
+					AgentId:        e.AgentId,
+					AgentName:      e.AgentName,
+					ToolName:       e.ToolName,
+					Input:          e.Input,
+					Reason:         e.Reason,
+					ExpiresAt:      timestamppb.New(expiresAt),
+				},
+			},
+		}, nil
+	case *pubsub.Event_ApprovalResolved:
+		e := p.ApprovalResolved
+		return &WatchEventsEvent{
+			Event: &WatchEventsEvent_ApprovalResolved{
+				ApprovalResolved: &ApprovalResolved{
+					ApprovalId: e.ApprovalId,
+					Approved:   e.Approved,
+					Reason:     e.Reason,
+				},
+			},
+		}, nil
+	case *pubsub.Event_Gap:
+		return &WatchEventsEvent{
+			Event: &WatchEventsEvent_Gap{
+				Gap: &Gap{Missed: p.Gap.Missed, ResumeSequence: p.Gap.ResumeSequence},
+			},
+		}, nil
+	case *pubsub.Event_Error:
 		return &WatchEventsEvent{
 			Event: &WatchEventsEvent_Error{
-				Error: &WatchError{Message: e.Message},
+				Error: &WatchError{Message: p.Error.Message, Code: p.Error.Code},
 			},
 		}, nil
 	default:
-		return nil, fmt.Errorf("unknown event type: %T", event)
+		return nil, fmt.Errorf("unknown event type: %T", payload)
 	}
 }
 
@@ -271,22 +742,39 @@ func storedItemsToProto(items []agentloop.StoredItem) []*MessageItem {
 	protoItems := make([]*MessageItem, len(items))
 	for i, item := range items {
 		switch item.Type {
-		case "text":
+		case agentloop.ItemTypeText:
 			protoItems[i] = &MessageItem{
 				Item: &MessageItem_Text{
 					Text: &TextItem{Content: item.Text},
 				},
 			}
-		case "tool_execution":
+		case agentloop.ItemTypeToolExecution:
 			protoItems[i] = &MessageItem{
 				Item: &MessageItem_ToolExecution{
 					ToolExecution: &ToolExecutionItem{
 						Name:   item.Name,
-						Input:  item.Input,
+						Input:  agentloop.RedactSealedArgs(item.Input),
 						Result: item.Result,
 					},
 				},
 			}
+		case agentloop.ItemTypeError:
+			protoItems[i] = &MessageItem{
+				Item: &MessageItem_Error{
+					Error: &ErrorItem{Message: item.Text},
+				},
+			}
+		case agentloop.ItemTypeAttachment:
+			protoItems[i] = &MessageItem{
+				Item: &MessageItem_Attachment{
+					Attachment: &AttachmentItem{
+						Id:        item.AttachmentID,
+						Name:      item.Name,
+						MediaType: item.MediaType,
+						Size:      item.Size,
+					},
+				},
+			}
 		default:
 			protoItems[i] = &MessageItem{}
 		}
@@ -308,12 +796,12 @@ func toProtoConversation(c store.Conversation) *Conversation {
 	}
 }
 
-func toProtoMessage(m store.Message) *Message {
+func toProtoMessage(m store.Message) (*Message, error) {
 	createdAt, _ := time.Parse(time.RFC3339, m.CreatedAt)
 
-	var items []agentloop.StoredItem
-	if m.Items != "" && m.Items != "[]" {
-		_ = json.Unmarshal([]byte(m.Items), &items)
+	items, err := agentloop.DecodeItems(m.Items)
+	if err != nil {
+		return nil, fmt.Errorf("decode items of message %s: %w", m.ID, err)
 	}
 
 	return &Message{
@@ -322,5 +810,23 @@ func toProtoMessage(m store.Message) *Message {
 		Role:           m.Role,
 		CreatedAt:      timestamppb.New(createdAt),
 		Items:          storedItemsToProto(items),
+		Status:         m.Status,
+	}, nil
+}
+
+func toProtoArtifact(a store.Artifact) *Artifact {
+	createdAt, _ := time.Parse(time.RFC3339, a.CreatedAt)
+
+	return &Artifact{
+		Id:        a.ID,
+		RunId:     a.RunID,
+		CallId:    a.CallID,
+		ToolName:  a.ToolName,
+		Kind:      a.Kind,
+		Name:      a.Name,
+		Uri:       a.Uri,
+		MediaType: a.MediaType,
+		Size:      a.Size,
+		CreatedAt: timestamppb.New(createdAt),
 	}
 }
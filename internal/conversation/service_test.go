@@ -0,0 +1,89 @@
+package conversation
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TestWatchEventsReplay checks that logged events are only replayed to
+// clients resuming after a sequence number.
+func TestWatchEventsReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "agent-1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	broker := pubsub.New(pubsub.NewStoreLog(queries, time.Hour), slog.New(slog.DiscardHandler))
+	publish := func(content string) {
+		broker.Publish("conv-1", &pubsub.Event_TextDelta{TextDelta: &pubsub.TextDelta{Content: content}})
+	}
+	publish("old 1")
+	publish("old 2")
+	// A busy conversation gets a turn_started event when watching starts.
+	broker.SetBusy("conv-1")
+
+	svc := NewService(db, broker, &agentloop.Loop{Queries: queries, Broker: broker}, nil, nil)
+	mux := http.NewServeMux()
+	mux.Handle(NewConversationServiceHandler(svc))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := NewConversationServiceClient(srv.Client(), srv.URL)
+
+	watch := func(after int64) *connect.ServerStreamForClient[WatchEventsEvent] {
+		t.Helper()
+		stream, err := client.WatchEvents(ctx, connect.NewRequest(&WatchEventsRequest{ConversationId: "conv-1", AfterSequence: after}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { stream.Close() })
+		return stream
+	}
+	receive := func(stream *connect.ServerStreamForClient[WatchEventsEvent]) *WatchEventsEvent {
+		t.Helper()
+		if !stream.Receive() {
+			t.Fatalf("stream ended: %v", stream.Err())
+		}
+		return stream.Msg()
+	}
+
+	// New watchers only get new events.
+	stream := watch(0)
+	if e := receive(stream); e.GetTurnStarted() == nil {
+		t.Fatalf("first event = %v, want turn_started", e)
+	}
+	publish("new")
+	if e := receive(stream); e.GetTextDelta().GetContent() != "new" || e.Sequence != 3 {
+		t.Errorf("event after subscribing = %v, want the new text delta", e)
+	}
+
+	// Resuming watchers get the events they missed first.
+	stream = watch(1)
+	for _, want := range []string{"old 2", "new"} {
+		if e := receive(stream); e.GetTextDelta().GetContent() != want {
+			t.Errorf("replayed event = %v, want text delta %q", e, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Package demo seeds an empty instance with example agents, a notification
+// channel, a trigger and a sample conversation, so new users see a working
+// system right away.
+package demo
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/manifest"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+//go:embed demo.json
+var demoManifest []byte
+
+// conversationID is the ID of the sample conversation.
+const conversationID = "demo-welcome"
+
+// Seed creates the demo data if the instance has no agents yet. Returns
+// whether anything was created. Channel configs are encrypted with cipher,
+// which may be nil.
+func Seed(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher) (bool, error) {
+	agents, err := queries.ListAgents(ctx)
+	if err != nil {
+		return false, fmt.Errorf("list agents: %w", err)
+	}
+	if len(agents) > 0 {
+		return false, nil
+	}
+
+	var m manifest.Manifest
+	dec := json.NewDecoder(bytes.NewReader(demoManifest))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return false, fmt.Errorf("parse demo manifest: %w", err)
+	}
+
+	err = queries.InTx(ctx, func(q *store.Queries) error {
+		if _, err := manifest.Import(ctx, q, cipher, &m); err != nil {
+			return err
+		}
+		return createConversation(ctx, q)
+	})
+	if err != nil {
+		return false, fmt.Errorf("seed demo data: %w", err)
+	}
+	return true, nil
+}
+
+// createConversation creates a sample conversation with the assistant.
+func createConversation(ctx context.Context, q *store.Queries) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := q.CreateConversation(ctx, store.CreateConversationParams{
+		ID:        conversationID,
+		AgentID:   "demo-assistant",
+		Title:     "Welcome to Blippy",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		return fmt.Errorf("create conversation: %w", err)
+	}
+
+	messages := []struct {
+		id, role, text string
+	}{
+		{"demo-welcome-1", "user", "What can you do?"},
+		{"demo-welcome-2", "assistant", "I can look things up on the web, remember facts for you across conversations, " +
+			"and send you push notifications. Try asking me to fetch a page and summarize it, or to remember your " +
+			"favorite programming language.\n\nThe News Digest agent shows how agents run on a schedule: enable its " +
+			"\"Morning digest\" trigger to get a summary of Hacker News every weekday at 8:00."},
+	}
+	for _, msg := range messages {
+		items, err := agentloop.EncodeItems([]agentloop.StoredItem{{Type: agentloop.ItemTypeText, Text: msg.text}})
+		if err != nil {
+			return err
+		}
+		if _, err := q.CreateMessage(ctx, store.CreateMessageParams{
+			ID:             msg.id,
+			ConversationID: conversationID,
+			Role:           msg.role,
+			Items:          items,
+			Status:         agentloop.MessageStatusCompleted,
+			CreatedAt:      now,
+		}); err != nil {
+			return fmt.Errorf("create message: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package demo
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := store.New(db)
+
+	seeded, err := Seed(ctx, queries, nil)
+	if err != nil || !seeded {
+		t.Fatalf("seed: seeded = %v, err = %v", seeded, err)
+	}
+
+	agents, err := queries.ListAgents(ctx)
+	if err != nil || len(agents) != 2 {
+		t.Errorf("agents = %d, %v; want 2", len(agents), err)
+	}
+	msgs, err := queries.GetMessagesByConversation(ctx, conversationID)
+	if err != nil || len(msgs) != 2 {
+		t.Errorf("messages = %d, %v; want 2", len(msgs), err)
+	}
+
+	// Seeding again does nothing, since agents exist.
+	if seeded, err := Seed(ctx, queries, nil); err != nil || seeded {
+		t.Errorf("second seed: seeded = %v, err = %v", seeded, err)
+	}
+}
@@ -0,0 +1,42 @@
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	c, err := New(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := c.Encrypt(`{"auth_token":"secret"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(enc) {
+		t.Fatalf("Encrypt() = %q, want encrypted value", enc)
+	}
+
+	dec, err := c.Decrypt(enc)
+	if err != nil || dec != `{"auth_token":"secret"}` {
+		t.Fatalf("Decrypt() = %q, %v", dec, err)
+	}
+
+	// Plaintext written before encryption was enabled is readable.
+	if dec, err := c.Decrypt("{}"); err != nil || dec != "{}" {
+		t.Fatalf("Decrypt(plaintext) = %q, %v", dec, err)
+	}
+
+	other, _ := New(bytes.Repeat([]byte{2}, 32))
+	if _, err := other.Decrypt(enc); err == nil {
+		t.Fatal("Decrypt with wrong key succeeded")
+	}
+
+	var none *Cipher
+	if _, err := none.Decrypt(enc); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("Decrypt without key: err = %v, want ErrNoKey", err)
+	}
+}
@@ -0,0 +1,129 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/openrouter"
+)
+
+// Assertion types.
+const (
+	AssertContains    = "contains"
+	AssertNotContains = "not_contains"
+	AssertRegex       = "regex"
+	AssertToolCalled  = "tool_called"
+)
+
+// assertion is the stored form of an Assertion.
+type assertion struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// validateAssertion checks that an assertion has a known type and a usable
+// value.
+func validateAssertion(a assertion) error {
+	switch a.Type {
+	case AssertContains, AssertNotContains, AssertToolCalled:
+	case AssertRegex:
+		if _, err := regexp.Compile(a.Value); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", a.Value, err)
+		}
+	default:
+		return fmt.Errorf("unknown assertion type %q", a.Type)
+	}
+	if a.Value == "" {
+		return fmt.Errorf("%s assertion has no value", a.Type)
+	}
+	return nil
+}
+
+// check returns why an assertion doesn't hold for an answer and the tools
+// called to give it, or "" if it holds.
+func check(a assertion, response string, toolsCalled []string) string {
+	switch a.Type {
+	case AssertContains:
+		if !strings.Contains(strings.ToLower(response), strings.ToLower(a.Value)) {
+			return fmt.Sprintf("answer doesn't contain %q", a.Value)
+		}
+	case AssertNotContains:
+		if strings.Contains(strings.ToLower(response), strings.ToLower(a.Value)) {
+			return fmt.Sprintf("answer contains %q", a.Value)
+		}
+	case AssertRegex:
+		re, err := regexp.Compile(a.Value)
+		if err != nil {
+			return fmt.Sprintf("invalid regex %q: %v", a.Value, err)
+		}
+		if !re.MatchString(response) {
+			return fmt.Sprintf("answer doesn't match %q", a.Value)
+		}
+	case AssertToolCalled:
+		if !slices.Contains(toolsCalled, a.Value) {
+			return fmt.Sprintf("tool %q wasn't called", a.Value)
+		}
+	default:
+		return fmt.Sprintf("unknown assertion type %q", a.Type)
+	}
+	return ""
+}
+
+// judgeInstructions instruct the LLM judge how to grade an answer.
+const judgeInstructions = `You grade the answer of an AI agent to a prompt, using a rubric. Be strict: only give a high score if the answer meets the rubric.
+
+Reply with only a JSON object, without any other text:
+{"score": <number from 0 to 1>, "reasoning": "<one or two sentences>"}`
+
+// verdict is the LLM judge's grade of an answer.
+type verdict struct {
+	Score     float64 `json:"score"`
+	Reasoning string  `json:"reasoning"`
+}
+
+// judge grades an answer with an LLM, using a rubric.
+func judge(ctx context.Context, orClient *openrouter.Client, model, rubric, prompt, response string) (verdict, error) {
+	input := fmt.Sprintf("Rubric:\n%s\n\nPrompt:\n%s\n\nAnswer:\n%s", rubric, prompt, response)
+	resp, err := orClient.CreateResponse(ctx, &openrouter.ResponseRequest{
+		Model:        model,
+		Instructions: judgeInstructions,
+		Input: []openrouter.Input{{
+			Type:    "message",
+			Role:    "user",
+			Content: []openrouter.ContentPart{{Type: "input_text", Text: input}},
+		}},
+	})
+	if err != nil {
+		return verdict{}, fmt.Errorf("create response: %w", err)
+	}
+
+	var text strings.Builder
+	for _, item := range resp.Output {
+		if item.Type == "message" {
+			for _, part := range item.Content {
+				text.WriteString(part.Text)
+			}
+		}
+	}
+	return parseVerdict(text.String())
+}
+
+// parseVerdict parses the judge's reply, which models tend to wrap in a
+// Markdown code block or a sentence.
+func parseVerdict(s string) (verdict, error) {
+	start, end := strings.Index(s, "{"), strings.LastIndex(s, "}")
+	if start < 0 || end < start {
+		return verdict{}, errors.New("judge replied without a JSON object")
+	}
+	var v verdict
+	if err := json.Unmarshal([]byte(s[start:end+1]), &v); err != nil {
+		return verdict{}, fmt.Errorf("parse judge reply: %w", err)
+	}
+	v.Score = min(max(v.Score, 0), 1)
+	return v, nil
+}
@@ -0,0 +1,284 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: eval/eval.proto
+
+package eval
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// EvalServiceName is the fully-qualified name of the EvalService service.
+	EvalServiceName = "blippy.eval.EvalService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// EvalServiceCreateEvalCaseProcedure is the fully-qualified name of the EvalService's
+	// CreateEvalCase RPC.
+	EvalServiceCreateEvalCaseProcedure = "/blippy.eval.EvalService/CreateEvalCase"
+	// EvalServiceListEvalCasesProcedure is the fully-qualified name of the EvalService's ListEvalCases
+	// RPC.
+	EvalServiceListEvalCasesProcedure = "/blippy.eval.EvalService/ListEvalCases"
+	// EvalServiceUpdateEvalCaseProcedure is the fully-qualified name of the EvalService's
+	// UpdateEvalCase RPC.
+	EvalServiceUpdateEvalCaseProcedure = "/blippy.eval.EvalService/UpdateEvalCase"
+	// EvalServiceDeleteEvalCaseProcedure is the fully-qualified name of the EvalService's
+	// DeleteEvalCase RPC.
+	EvalServiceDeleteEvalCaseProcedure = "/blippy.eval.EvalService/DeleteEvalCase"
+	// EvalServiceRunEvalsProcedure is the fully-qualified name of the EvalService's RunEvals RPC.
+	EvalServiceRunEvalsProcedure = "/blippy.eval.EvalService/RunEvals"
+	// EvalServiceGetEvalRunProcedure is the fully-qualified name of the EvalService's GetEvalRun RPC.
+	EvalServiceGetEvalRunProcedure = "/blippy.eval.EvalService/GetEvalRun"
+	// EvalServiceListEvalRunsProcedure is the fully-qualified name of the EvalService's ListEvalRuns
+	// RPC.
+	EvalServiceListEvalRunsProcedure = "/blippy.eval.EvalService/ListEvalRuns"
+)
+
+// EvalServiceClient is a client for the blippy.eval.EvalService service.
+type EvalServiceClient interface {
+	CreateEvalCase(context.Context, *connect.Request[CreateEvalCaseRequest]) (*connect.Response[EvalCase], error)
+	ListEvalCases(context.Context, *connect.Request[ListEvalCasesRequest]) (*connect.Response[ListEvalCasesResponse], error)
+	UpdateEvalCase(context.Context, *connect.Request[UpdateEvalCaseRequest]) (*connect.Response[EvalCase], error)
+	DeleteEvalCase(context.Context, *connect.Request[DeleteEvalCaseRequest]) (*connect.Response[DeleteEvalCaseResponse], error)
+	// Starts running eval cases in the background, and returns the run.
+	// Poll GetEvalRun for the results.
+	RunEvals(context.Context, *connect.Request[RunEvalsRequest]) (*connect.Response[EvalRun], error)
+	GetEvalRun(context.Context, *connect.Request[GetEvalRunRequest]) (*connect.Response[EvalRun], error)
+	ListEvalRuns(context.Context, *connect.Request[ListEvalRunsRequest]) (*connect.Response[ListEvalRunsResponse], error)
+}
+
+// NewEvalServiceClient constructs a client for the blippy.eval.EvalService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewEvalServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) EvalServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	evalServiceMethods := File_eval_eval_proto.Services().ByName("EvalService").Methods()
+	return &evalServiceClient{
+		createEvalCase: connect.NewClient[CreateEvalCaseRequest, EvalCase](
+			httpClient,
+			baseURL+EvalServiceCreateEvalCaseProcedure,
+			connect.WithSchema(evalServiceMethods.ByName("CreateEvalCase")),
+			connect.WithClientOptions(opts...),
+		),
+		listEvalCases: connect.NewClient[ListEvalCasesRequest, ListEvalCasesResponse](
+			httpClient,
+			baseURL+EvalServiceListEvalCasesProcedure,
+			connect.WithSchema(evalServiceMethods.ByName("ListEvalCases")),
+			connect.WithClientOptions(opts...),
+		),
+		updateEvalCase: connect.NewClient[UpdateEvalCaseRequest, EvalCase](
+			httpClient,
+			baseURL+EvalServiceUpdateEvalCaseProcedure,
+			connect.WithSchema(evalServiceMethods.ByName("UpdateEvalCase")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteEvalCase: connect.NewClient[DeleteEvalCaseRequest, DeleteEvalCaseResponse](
+			httpClient,
+			baseURL+EvalServiceDeleteEvalCaseProcedure,
+			connect.WithSchema(evalServiceMethods.ByName("DeleteEvalCase")),
+			connect.WithClientOptions(opts...),
+		),
+		runEvals: connect.NewClient[RunEvalsRequest, EvalRun](
+			httpClient,
+			baseURL+EvalServiceRunEvalsProcedure,
+			connect.WithSchema(evalServiceMethods.ByName("RunEvals")),
+			connect.WithClientOptions(opts...),
+		),
+		getEvalRun: connect.NewClient[GetEvalRunRequest, EvalRun](
+			httpClient,
+			baseURL+EvalServiceGetEvalRunProcedure,
+			connect.WithSchema(evalServiceMethods.ByName("GetEvalRun")),
+			connect.WithClientOptions(opts...),
+		),
+		listEvalRuns: connect.NewClient[ListEvalRunsRequest, ListEvalRunsResponse](
+			httpClient,
+			baseURL+EvalServiceListEvalRunsProcedure,
+			connect.WithSchema(evalServiceMethods.ByName("ListEvalRuns")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// evalServiceClient implements EvalServiceClient.
+type evalServiceClient struct {
+	createEvalCase *connect.Client[CreateEvalCaseRequest, EvalCase]
+	listEvalCases  *connect.Client[ListEvalCasesRequest, ListEvalCasesResponse]
+	updateEvalCase *connect.Client[UpdateEvalCaseRequest, EvalCase]
+	deleteEvalCase *connect.Client[DeleteEvalCaseRequest, DeleteEvalCaseResponse]
+	runEvals       *connect.Client[RunEvalsRequest, EvalRun]
+	getEvalRun     *connect.Client[GetEvalRunRequest, EvalRun]
+	listEvalRuns   *connect.Client[ListEvalRunsRequest, ListEvalRunsResponse]
+}
+
+// CreateEvalCase calls blippy.eval.EvalService.CreateEvalCase.
+func (c *evalServiceClient) CreateEvalCase(ctx context.Context, req *connect.Request[CreateEvalCaseRequest]) (*connect.Response[EvalCase], error) {
+	return c.createEvalCase.CallUnary(ctx, req)
+}
+
+// ListEvalCases calls blippy.eval.EvalService.ListEvalCases.
+func (c *evalServiceClient) ListEvalCases(ctx context.Context, req *connect.Request[ListEvalCasesRequest]) (*connect.Response[ListEvalCasesResponse], error) {
+	return c.listEvalCases.CallUnary(ctx, req)
+}
+
+// UpdateEvalCase calls blippy.eval.EvalService.UpdateEvalCase.
+func (c *evalServiceClient) UpdateEvalCase(ctx context.Context, req *connect.Request[UpdateEvalCaseRequest]) (*connect.Response[EvalCase], error) {
+	return c.updateEvalCase.CallUnary(ctx, req)
+}
+
+// DeleteEvalCase calls blippy.eval.EvalService.DeleteEvalCase.
+func (c *evalServiceClient) DeleteEvalCase(ctx context.Context, req *connect.Request[DeleteEvalCaseRequest]) (*connect.Response[DeleteEvalCaseResponse], error) {
+	return c.deleteEvalCase.CallUnary(ctx, req)
+}
+
+// RunEvals calls blippy.eval.EvalService.RunEvals.
+func (c *evalServiceClient) RunEvals(ctx context.Context, req *connect.Request[RunEvalsRequest]) (*connect.Response[EvalRun], error) {
+	return c.runEvals.CallUnary(ctx, req)
+}
+
+// GetEvalRun calls blippy.eval.EvalService.GetEvalRun.
+func (c *evalServiceClient) GetEvalRun(ctx context.Context, req *connect.Request[GetEvalRunRequest]) (*connect.Response[EvalRun], error) {
+	return c.getEvalRun.CallUnary(ctx, req)
+}
+
+// ListEvalRuns calls blippy.eval.EvalService.ListEvalRuns.
+func (c *evalServiceClient) ListEvalRuns(ctx context.Context, req *connect.Request[ListEvalRunsRequest]) (*connect.Response[ListEvalRunsResponse], error) {
+	return c.listEvalRuns.CallUnary(ctx, req)
+}
+
+// EvalServiceHandler is an implementation of the blippy.eval.EvalService service.
+type EvalServiceHandler interface {
+	CreateEvalCase(context.Context, *connect.Request[CreateEvalCaseRequest]) (*connect.Response[EvalCase], error)
+	ListEvalCases(context.Context, *connect.Request[ListEvalCasesRequest]) (*connect.Response[ListEvalCasesResponse], error)
+	UpdateEvalCase(context.Context, *connect.Request[UpdateEvalCaseRequest]) (*connect.Response[EvalCase], error)
+	DeleteEvalCase(context.Context, *connect.Request[DeleteEvalCaseRequest]) (*connect.Response[DeleteEvalCaseResponse], error)
+	// Starts running eval cases in the background, and returns the run.
+	// Poll GetEvalRun for the results.
+	RunEvals(context.Context, *connect.Request[RunEvalsRequest]) (*connect.Response[EvalRun], error)
+	GetEvalRun(context.Context, *connect.Request[GetEvalRunRequest]) (*connect.Response[EvalRun], error)
+	ListEvalRuns(context.Context, *connect.Request[ListEvalRunsRequest]) (*connect.Response[ListEvalRunsResponse], error)
+}
+
+// NewEvalServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewEvalServiceHandler(svc EvalServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	evalServiceMethods := File_eval_eval_proto.Services().ByName("EvalService").Methods()
+	evalServiceCreateEvalCaseHandler := connect.NewUnaryHandler(
+		EvalServiceCreateEvalCaseProcedure,
+		svc.CreateEvalCase,
+		connect.WithSchema(evalServiceMethods.ByName("CreateEvalCase")),
+		connect.WithHandlerOptions(opts...),
+	)
+	evalServiceListEvalCasesHandler := connect.NewUnaryHandler(
+		EvalServiceListEvalCasesProcedure,
+		svc.ListEvalCases,
+		connect.WithSchema(evalServiceMethods.ByName("ListEvalCases")),
+		connect.WithHandlerOptions(opts...),
+	)
+	evalServiceUpdateEvalCaseHandler := connect.NewUnaryHandler(
+		EvalServiceUpdateEvalCaseProcedure,
+		svc.UpdateEvalCase,
+		connect.WithSchema(evalServiceMethods.ByName("UpdateEvalCase")),
+		connect.WithHandlerOptions(opts...),
+	)
+	evalServiceDeleteEvalCaseHandler := connect.NewUnaryHandler(
+		EvalServiceDeleteEvalCaseProcedure,
+		svc.DeleteEvalCase,
+		connect.WithSchema(evalServiceMethods.ByName("DeleteEvalCase")),
+		connect.WithHandlerOptions(opts...),
+	)
+	evalServiceRunEvalsHandler := connect.NewUnaryHandler(
+		EvalServiceRunEvalsProcedure,
+		svc.RunEvals,
+		connect.WithSchema(evalServiceMethods.ByName("RunEvals")),
+		connect.WithHandlerOptions(opts...),
+	)
+	evalServiceGetEvalRunHandler := connect.NewUnaryHandler(
+		EvalServiceGetEvalRunProcedure,
+		svc.GetEvalRun,
+		connect.WithSchema(evalServiceMethods.ByName("GetEvalRun")),
+		connect.WithHandlerOptions(opts...),
+	)
+	evalServiceListEvalRunsHandler := connect.NewUnaryHandler(
+		EvalServiceListEvalRunsProcedure,
+		svc.ListEvalRuns,
+		connect.WithSchema(evalServiceMethods.ByName("ListEvalRuns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/blippy.eval.EvalService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EvalServiceCreateEvalCaseProcedure:
+			evalServiceCreateEvalCaseHandler.ServeHTTP(w, r)
+		case EvalServiceListEvalCasesProcedure:
+			evalServiceListEvalCasesHandler.ServeHTTP(w, r)
+		case EvalServiceUpdateEvalCaseProcedure:
+			evalServiceUpdateEvalCaseHandler.ServeHTTP(w, r)
+		case EvalServiceDeleteEvalCaseProcedure:
+			evalServiceDeleteEvalCaseHandler.ServeHTTP(w, r)
+		case EvalServiceRunEvalsProcedure:
+			evalServiceRunEvalsHandler.ServeHTTP(w, r)
+		case EvalServiceGetEvalRunProcedure:
+			evalServiceGetEvalRunHandler.ServeHTTP(w, r)
+		case EvalServiceListEvalRunsProcedure:
+			evalServiceListEvalRunsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedEvalServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedEvalServiceHandler struct{}
+
+func (UnimplementedEvalServiceHandler) CreateEvalCase(context.Context, *connect.Request[CreateEvalCaseRequest]) (*connect.Response[EvalCase], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.eval.EvalService.CreateEvalCase is not implemented"))
+}
+
+func (UnimplementedEvalServiceHandler) ListEvalCases(context.Context, *connect.Request[ListEvalCasesRequest]) (*connect.Response[ListEvalCasesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.eval.EvalService.ListEvalCases is not implemented"))
+}
+
+func (UnimplementedEvalServiceHandler) UpdateEvalCase(context.Context, *connect.Request[UpdateEvalCaseRequest]) (*connect.Response[EvalCase], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.eval.EvalService.UpdateEvalCase is not implemented"))
+}
+
+func (UnimplementedEvalServiceHandler) DeleteEvalCase(context.Context, *connect.Request[DeleteEvalCaseRequest]) (*connect.Response[DeleteEvalCaseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.eval.EvalService.DeleteEvalCase is not implemented"))
+}
+
+func (UnimplementedEvalServiceHandler) RunEvals(context.Context, *connect.Request[RunEvalsRequest]) (*connect.Response[EvalRun], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.eval.EvalService.RunEvals is not implemented"))
+}
+
+func (UnimplementedEvalServiceHandler) GetEvalRun(context.Context, *connect.Request[GetEvalRunRequest]) (*connect.Response[EvalRun], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.eval.EvalService.GetEvalRun is not implemented"))
+}
+
+func (UnimplementedEvalServiceHandler) ListEvalRuns(context.Context, *connect.Request[ListEvalRunsRequest]) (*connect.Response[ListEvalRunsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.eval.EvalService.ListEvalRuns is not implemented"))
+}
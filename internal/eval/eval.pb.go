@@ -0,0 +1,1145 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: eval/eval.proto
+
+package eval
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Assertion is a check on an agent's final answer.
+type Assertion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "contains", "not_contains" (case-insensitive substrings), "regex" or
+	// "tool_called" (the name of a tool the agent must call).
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assertion) Reset() {
+	*x = Assertion{}
+	mi := &file_eval_eval_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assertion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assertion) ProtoMessage() {}
+
+func (x *Assertion) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assertion.ProtoReflect.Descriptor instead.
+func (*Assertion) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Assertion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Assertion) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// EvalCase is a test case of an agent: a prompt, and what the answer must
+// satisfy.
+type EvalCase struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AgentId    string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Prompt     string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Assertions []*Assertion           `protobuf:"bytes,5,rep,name=assertions,proto3" json:"assertions,omitempty"`
+	// Optional rubric an LLM judge grades the answer with, from 0 to 1.
+	Rubric        string                 `protobuf:"bytes,6,opt,name=rubric,proto3" json:"rubric,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalCase) Reset() {
+	*x = EvalCase{}
+	mi := &file_eval_eval_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalCase) ProtoMessage() {}
+
+func (x *EvalCase) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalCase.ProtoReflect.Descriptor instead.
+func (*EvalCase) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EvalCase) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EvalCase) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *EvalCase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EvalCase) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *EvalCase) GetAssertions() []*Assertion {
+	if x != nil {
+		return x.Assertions
+	}
+	return nil
+}
+
+func (x *EvalCase) GetRubric() string {
+	if x != nil {
+		return x.Rubric
+	}
+	return ""
+}
+
+func (x *EvalCase) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *EvalCase) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateEvalCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prompt        string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Assertions    []*Assertion           `protobuf:"bytes,4,rep,name=assertions,proto3" json:"assertions,omitempty"`
+	Rubric        string                 `protobuf:"bytes,5,opt,name=rubric,proto3" json:"rubric,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEvalCaseRequest) Reset() {
+	*x = CreateEvalCaseRequest{}
+	mi := &file_eval_eval_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEvalCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEvalCaseRequest) ProtoMessage() {}
+
+func (x *CreateEvalCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEvalCaseRequest.ProtoReflect.Descriptor instead.
+func (*CreateEvalCaseRequest) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateEvalCaseRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CreateEvalCaseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateEvalCaseRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *CreateEvalCaseRequest) GetAssertions() []*Assertion {
+	if x != nil {
+		return x.Assertions
+	}
+	return nil
+}
+
+func (x *CreateEvalCaseRequest) GetRubric() string {
+	if x != nil {
+		return x.Rubric
+	}
+	return ""
+}
+
+type ListEvalCasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvalCasesRequest) Reset() {
+	*x = ListEvalCasesRequest{}
+	mi := &file_eval_eval_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvalCasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvalCasesRequest) ProtoMessage() {}
+
+func (x *ListEvalCasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvalCasesRequest.ProtoReflect.Descriptor instead.
+func (*ListEvalCasesRequest) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListEvalCasesRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ListEvalCasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cases         []*EvalCase            `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvalCasesResponse) Reset() {
+	*x = ListEvalCasesResponse{}
+	mi := &file_eval_eval_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvalCasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvalCasesResponse) ProtoMessage() {}
+
+func (x *ListEvalCasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvalCasesResponse.ProtoReflect.Descriptor instead.
+func (*ListEvalCasesResponse) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListEvalCasesResponse) GetCases() []*EvalCase {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+type UpdateEvalCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prompt        string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Assertions    []*Assertion           `protobuf:"bytes,4,rep,name=assertions,proto3" json:"assertions,omitempty"`
+	Rubric        string                 `protobuf:"bytes,5,opt,name=rubric,proto3" json:"rubric,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEvalCaseRequest) Reset() {
+	*x = UpdateEvalCaseRequest{}
+	mi := &file_eval_eval_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEvalCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEvalCaseRequest) ProtoMessage() {}
+
+func (x *UpdateEvalCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEvalCaseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEvalCaseRequest) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateEvalCaseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEvalCaseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateEvalCaseRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *UpdateEvalCaseRequest) GetAssertions() []*Assertion {
+	if x != nil {
+		return x.Assertions
+	}
+	return nil
+}
+
+func (x *UpdateEvalCaseRequest) GetRubric() string {
+	if x != nil {
+		return x.Rubric
+	}
+	return ""
+}
+
+type DeleteEvalCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEvalCaseRequest) Reset() {
+	*x = DeleteEvalCaseRequest{}
+	mi := &file_eval_eval_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEvalCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEvalCaseRequest) ProtoMessage() {}
+
+func (x *DeleteEvalCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEvalCaseRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEvalCaseRequest) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteEvalCaseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteEvalCaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEvalCaseResponse) Reset() {
+	*x = DeleteEvalCaseResponse{}
+	mi := &file_eval_eval_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEvalCaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEvalCaseResponse) ProtoMessage() {}
+
+func (x *DeleteEvalCaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEvalCaseResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEvalCaseResponse) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{7}
+}
+
+// EvalResult is the outcome of an eval case in a run.
+type EvalResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CaseId   string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	CaseName string                 `protobuf:"bytes,2,opt,name=case_name,json=caseName,proto3" json:"case_name,omitempty"`
+	// The conversation the case ran in.
+	ConversationId string `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Response       string `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
+	// Whether all assertions held and the judge's score is at least 0.5.
+	Passed bool `protobuf:"varint,5,opt,name=passed,proto3" json:"passed,omitempty"`
+	// The fraction of checks passed, with the judge's score counting as one
+	// check.
+	Score float64 `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	// The assertions that didn't hold.
+	Failures       []string `protobuf:"bytes,7,rep,name=failures,proto3" json:"failures,omitempty"`
+	JudgeReasoning string   `protobuf:"bytes,8,opt,name=judge_reasoning,json=judgeReasoning,proto3" json:"judge_reasoning,omitempty"`
+	// Why the case couldn't be run or judged, if it couldn't.
+	Error         string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalResult) Reset() {
+	*x = EvalResult{}
+	mi := &file_eval_eval_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalResult) ProtoMessage() {}
+
+func (x *EvalResult) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalResult.ProtoReflect.Descriptor instead.
+func (*EvalResult) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EvalResult) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *EvalResult) GetCaseName() string {
+	if x != nil {
+		return x.CaseName
+	}
+	return ""
+}
+
+func (x *EvalResult) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *EvalResult) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *EvalResult) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *EvalResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *EvalResult) GetFailures() []string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *EvalResult) GetJudgeReasoning() string {
+	if x != nil {
+		return x.JudgeReasoning
+	}
+	return ""
+}
+
+func (x *EvalResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// EvalRun is a run of an agent's eval cases.
+type EvalRun struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AgentId string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// The system prompt and model evaluated.
+	SystemPrompt string `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	Model        string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	// Whether a candidate prompt or model was evaluated instead of the
+	// agent's own.
+	Candidate bool `protobuf:"varint,5,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	// "running", "completed", "failed" or "interrupted".
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// The average score of the cases.
+	Score         float64                `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"`
+	Passed        int32                  `protobuf:"varint,8,opt,name=passed,proto3" json:"passed,omitempty"`
+	Total         int32                  `protobuf:"varint,9,opt,name=total,proto3" json:"total,omitempty"`
+	Results       []*EvalResult          `protobuf:"bytes,10,rep,name=results,proto3" json:"results,omitempty"`
+	Error         string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // zero value while running
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalRun) Reset() {
+	*x = EvalRun{}
+	mi := &file_eval_eval_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalRun) ProtoMessage() {}
+
+func (x *EvalRun) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalRun.ProtoReflect.Descriptor instead.
+func (*EvalRun) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EvalRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EvalRun) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *EvalRun) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *EvalRun) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EvalRun) GetCandidate() bool {
+	if x != nil {
+		return x.Candidate
+	}
+	return false
+}
+
+func (x *EvalRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EvalRun) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *EvalRun) GetPassed() int32 {
+	if x != nil {
+		return x.Passed
+	}
+	return 0
+}
+
+func (x *EvalRun) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *EvalRun) GetResults() []*EvalResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *EvalRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *EvalRun) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *EvalRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type RunEvalsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// The cases to run; all cases of the agent if empty.
+	CaseIds []string `protobuf:"bytes,2,rep,name=case_ids,json=caseIds,proto3" json:"case_ids,omitempty"`
+	// Optional candidate system prompt to evaluate instead of the agent's.
+	SystemPrompt string `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	// Optional candidate model to evaluate instead of the agent's.
+	Model         string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunEvalsRequest) Reset() {
+	*x = RunEvalsRequest{}
+	mi := &file_eval_eval_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunEvalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunEvalsRequest) ProtoMessage() {}
+
+func (x *RunEvalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunEvalsRequest.ProtoReflect.Descriptor instead.
+func (*RunEvalsRequest) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RunEvalsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RunEvalsRequest) GetCaseIds() []string {
+	if x != nil {
+		return x.CaseIds
+	}
+	return nil
+}
+
+func (x *RunEvalsRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *RunEvalsRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type GetEvalRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEvalRunRequest) Reset() {
+	*x = GetEvalRunRequest{}
+	mi := &file_eval_eval_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEvalRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEvalRunRequest) ProtoMessage() {}
+
+func (x *GetEvalRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEvalRunRequest.ProtoReflect.Descriptor instead.
+func (*GetEvalRunRequest) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetEvalRunRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListEvalRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvalRunsRequest) Reset() {
+	*x = ListEvalRunsRequest{}
+	mi := &file_eval_eval_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvalRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvalRunsRequest) ProtoMessage() {}
+
+func (x *ListEvalRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvalRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListEvalRunsRequest) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListEvalRunsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ListEvalRunsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first, without per-case results.
+	Runs          []*EvalRun `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvalRunsResponse) Reset() {
+	*x = ListEvalRunsResponse{}
+	mi := &file_eval_eval_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvalRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvalRunsResponse) ProtoMessage() {}
+
+func (x *ListEvalRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eval_eval_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvalRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListEvalRunsResponse) Descriptor() ([]byte, []int) {
+	return file_eval_eval_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListEvalRunsResponse) GetRuns() []*EvalRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+var File_eval_eval_proto protoreflect.FileDescriptor
+
+const file_eval_eval_proto_rawDesc = "" +
+	"\n" +
+	"\x0feval/eval.proto\x12\vblippy.eval\x1a\x1fgoogle/protobuf/timestamp.proto\"5\n" +
+	"\tAssertion\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xa7\x02\n" +
+	"\bEvalCase\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x126\n" +
+	"\n" +
+	"assertions\x18\x05 \x03(\v2\x16.blippy.eval.AssertionR\n" +
+	"assertions\x12\x16\n" +
+	"\x06rubric\x18\x06 \x01(\tR\x06rubric\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xae\x01\n" +
+	"\x15CreateEvalCaseRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x126\n" +
+	"\n" +
+	"assertions\x18\x04 \x03(\v2\x16.blippy.eval.AssertionR\n" +
+	"assertions\x12\x16\n" +
+	"\x06rubric\x18\x05 \x01(\tR\x06rubric\"1\n" +
+	"\x14ListEvalCasesRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"D\n" +
+	"\x15ListEvalCasesResponse\x12+\n" +
+	"\x05cases\x18\x01 \x03(\v2\x15.blippy.eval.EvalCaseR\x05cases\"\xa3\x01\n" +
+	"\x15UpdateEvalCaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x126\n" +
+	"\n" +
+	"assertions\x18\x04 \x03(\v2\x16.blippy.eval.AssertionR\n" +
+	"assertions\x12\x16\n" +
+	"\x06rubric\x18\x05 \x01(\tR\x06rubric\"'\n" +
+	"\x15DeleteEvalCaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteEvalCaseResponse\"\x90\x02\n" +
+	"\n" +
+	"EvalResult\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x1b\n" +
+	"\tcase_name\x18\x02 \x01(\tR\bcaseName\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\tR\x0econversationId\x12\x1a\n" +
+	"\bresponse\x18\x04 \x01(\tR\bresponse\x12\x16\n" +
+	"\x06passed\x18\x05 \x01(\bR\x06passed\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x12\x1a\n" +
+	"\bfailures\x18\a \x03(\tR\bfailures\x12'\n" +
+	"\x0fjudge_reasoning\x18\b \x01(\tR\x0ejudgeReasoning\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\xaa\x03\n" +
+	"\aEvalRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12#\n" +
+	"\rsystem_prompt\x18\x03 \x01(\tR\fsystemPrompt\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x1c\n" +
+	"\tcandidate\x18\x05 \x01(\bR\tcandidate\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05score\x18\a \x01(\x01R\x05score\x12\x16\n" +
+	"\x06passed\x18\b \x01(\x05R\x06passed\x12\x14\n" +
+	"\x05total\x18\t \x01(\x05R\x05total\x121\n" +
+	"\aresults\x18\n" +
+	" \x03(\v2\x17.blippy.eval.EvalResultR\aresults\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vfinished_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\x82\x01\n" +
+	"\x0fRunEvalsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x19\n" +
+	"\bcase_ids\x18\x02 \x03(\tR\acaseIds\x12#\n" +
+	"\rsystem_prompt\x18\x03 \x01(\tR\fsystemPrompt\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\"#\n" +
+	"\x11GetEvalRunRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x13ListEvalRunsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"@\n" +
+	"\x14ListEvalRunsResponse\x12(\n" +
+	"\x04runs\x18\x01 \x03(\v2\x14.blippy.eval.EvalRunR\x04runs2\xb3\x04\n" +
+	"\vEvalService\x12K\n" +
+	"\x0eCreateEvalCase\x12\".blippy.eval.CreateEvalCaseRequest\x1a\x15.blippy.eval.EvalCase\x12V\n" +
+	"\rListEvalCases\x12!.blippy.eval.ListEvalCasesRequest\x1a\".blippy.eval.ListEvalCasesResponse\x12K\n" +
+	"\x0eUpdateEvalCase\x12\".blippy.eval.UpdateEvalCaseRequest\x1a\x15.blippy.eval.EvalCase\x12Y\n" +
+	"\x0eDeleteEvalCase\x12\".blippy.eval.DeleteEvalCaseRequest\x1a#.blippy.eval.DeleteEvalCaseResponse\x12>\n" +
+	"\bRunEvals\x12\x1c.blippy.eval.RunEvalsRequest\x1a\x14.blippy.eval.EvalRun\x12B\n" +
+	"\n" +
+	"GetEvalRun\x12\x1e.blippy.eval.GetEvalRunRequest\x1a\x14.blippy.eval.EvalRun\x12S\n" +
+	"\fListEvalRuns\x12 .blippy.eval.ListEvalRunsRequest\x1a!.blippy.eval.ListEvalRunsResponseB*Z(github.com/dstotijn/blippy/internal/evalb\x06proto3"
+
+var (
+	file_eval_eval_proto_rawDescOnce sync.Once
+	file_eval_eval_proto_rawDescData []byte
+)
+
+func file_eval_eval_proto_rawDescGZIP() []byte {
+	file_eval_eval_proto_rawDescOnce.Do(func() {
+		file_eval_eval_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_eval_eval_proto_rawDesc), len(file_eval_eval_proto_rawDesc)))
+	})
+	return file_eval_eval_proto_rawDescData
+}
+
+var file_eval_eval_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_eval_eval_proto_goTypes = []any{
+	(*Assertion)(nil),              // 0: blippy.eval.Assertion
+	(*EvalCase)(nil),               // 1: blippy.eval.EvalCase
+	(*CreateEvalCaseRequest)(nil),  // 2: blippy.eval.CreateEvalCaseRequest
+	(*ListEvalCasesRequest)(nil),   // 3: blippy.eval.ListEvalCasesRequest
+	(*ListEvalCasesResponse)(nil),  // 4: blippy.eval.ListEvalCasesResponse
+	(*UpdateEvalCaseRequest)(nil),  // 5: blippy.eval.UpdateEvalCaseRequest
+	(*DeleteEvalCaseRequest)(nil),  // 6: blippy.eval.DeleteEvalCaseRequest
+	(*DeleteEvalCaseResponse)(nil), // 7: blippy.eval.DeleteEvalCaseResponse
+	(*EvalResult)(nil),             // 8: blippy.eval.EvalResult
+	(*EvalRun)(nil),                // 9: blippy.eval.EvalRun
+	(*RunEvalsRequest)(nil),        // 10: blippy.eval.RunEvalsRequest
+	(*GetEvalRunRequest)(nil),      // 11: blippy.eval.GetEvalRunRequest
+	(*ListEvalRunsRequest)(nil),    // 12: blippy.eval.ListEvalRunsRequest
+	(*ListEvalRunsResponse)(nil),   // 13: blippy.eval.ListEvalRunsResponse
+	(*timestamppb.Timestamp)(nil),  // 14: google.protobuf.Timestamp
+}
+var file_eval_eval_proto_depIdxs = []int32{
+	0,  // 0: blippy.eval.EvalCase.assertions:type_name -> blippy.eval.Assertion
+	14, // 1: blippy.eval.EvalCase.created_at:type_name -> google.protobuf.Timestamp
+	14, // 2: blippy.eval.EvalCase.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: blippy.eval.CreateEvalCaseRequest.assertions:type_name -> blippy.eval.Assertion
+	1,  // 4: blippy.eval.ListEvalCasesResponse.cases:type_name -> blippy.eval.EvalCase
+	0,  // 5: blippy.eval.UpdateEvalCaseRequest.assertions:type_name -> blippy.eval.Assertion
+	8,  // 6: blippy.eval.EvalRun.results:type_name -> blippy.eval.EvalResult
+	14, // 7: blippy.eval.EvalRun.created_at:type_name -> google.protobuf.Timestamp
+	14, // 8: blippy.eval.EvalRun.finished_at:type_name -> google.protobuf.Timestamp
+	9,  // 9: blippy.eval.ListEvalRunsResponse.runs:type_name -> blippy.eval.EvalRun
+	2,  // 10: blippy.eval.EvalService.CreateEvalCase:input_type -> blippy.eval.CreateEvalCaseRequest
+	3,  // 11: blippy.eval.EvalService.ListEvalCases:input_type -> blippy.eval.ListEvalCasesRequest
+	5,  // 12: blippy.eval.EvalService.UpdateEvalCase:input_type -> blippy.eval.UpdateEvalCaseRequest
+	6,  // 13: blippy.eval.EvalService.DeleteEvalCase:input_type -> blippy.eval.DeleteEvalCaseRequest
+	10, // 14: blippy.eval.EvalService.RunEvals:input_type -> blippy.eval.RunEvalsRequest
+	11, // 15: blippy.eval.EvalService.GetEvalRun:input_type -> blippy.eval.GetEvalRunRequest
+	12, // 16: blippy.eval.EvalService.ListEvalRuns:input_type -> blippy.eval.ListEvalRunsRequest
+	1,  // 17: blippy.eval.EvalService.CreateEvalCase:output_type -> blippy.eval.EvalCase
+	4,  // 18: blippy.eval.EvalService.ListEvalCases:output_type -> blippy.eval.ListEvalCasesResponse
+	1,  // 19: blippy.eval.EvalService.UpdateEvalCase:output_type -> blippy.eval.EvalCase
+	7,  // 20: blippy.eval.EvalService.DeleteEvalCase:output_type -> blippy.eval.DeleteEvalCaseResponse
+	9,  // 21: blippy.eval.EvalService.RunEvals:output_type -> blippy.eval.EvalRun
+	9,  // 22: blippy.eval.EvalService.GetEvalRun:output_type -> blippy.eval.EvalRun
+	13, // 23: blippy.eval.EvalService.ListEvalRuns:output_type -> blippy.eval.ListEvalRunsResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_eval_eval_proto_init() }
+func file_eval_eval_proto_init() {
+	if File_eval_eval_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_eval_eval_proto_rawDesc), len(file_eval_eval_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_eval_eval_proto_goTypes,
+		DependencyIndexes: file_eval_eval_proto_depIdxs,
+		MessageInfos:      file_eval_eval_proto_msgTypes,
+	}.Build()
+	File_eval_eval_proto = out.File
+	file_eval_eval_proto_goTypes = nil
+	file_eval_eval_proto_depIdxs = nil
+}
@@ -0,0 +1,464 @@
+package eval
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// Eval run statuses.
+const (
+	RunStatusRunning     = "running"
+	RunStatusCompleted   = "completed"
+	RunStatusFailed      = "failed"
+	RunStatusInterrupted = "interrupted"
+)
+
+// passingScore is the judge score an answer needs to pass.
+const passingScore = 0.5
+
+type Service struct {
+	queries      *store.Queries
+	runner       *runner.Runner
+	orClient     *openrouter.Client
+	defaultModel string
+	judgeModel   string
+
+	// runs tracks eval runs in progress.
+	runs sync.WaitGroup
+}
+
+// NewService creates an eval service. Rubrics are graded with judgeModel, or
+// the model evaluated if empty.
+func NewService(db *sql.DB, agentRunner *runner.Runner, orClient *openrouter.Client, defaultModel, judgeModel string) *Service {
+	return &Service{
+		queries:      store.New(db),
+		runner:       agentRunner,
+		orClient:     orClient,
+		defaultModel: defaultModel,
+		judgeModel:   judgeModel,
+	}
+}
+
+// InterruptRunning marks eval runs left running, e.g. by a crash, as
+// interrupted.
+func (s *Service) InterruptRunning(ctx context.Context) (int64, error) {
+	return s.queries.InterruptRunningEvalRuns(ctx, store.InterruptRunningEvalRunsParams{
+		Error:      "interrupted by server restart",
+		FinishedAt: sql.NullString{String: time.Now().UTC().Format(time.RFC3339), Valid: true},
+	})
+}
+
+// Wait waits for eval runs in progress to finish.
+func (s *Service) Wait() {
+	s.runs.Wait()
+}
+
+func (s *Service) CreateEvalCase(ctx context.Context, req *connect.Request[CreateEvalCaseRequest]) (*connect.Response[EvalCase], error) {
+	assertions, err := marshalAssertions(req.Msg.Name, req.Msg.Prompt, req.Msg.Assertions)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := s.queries.GetAgent(ctx, req.Msg.AgentId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	c, err := s.queries.CreateEvalCase(ctx, store.CreateEvalCaseParams{
+		ID:         uuid.NewString(),
+		AgentID:    req.Msg.AgentId,
+		Name:       req.Msg.Name,
+		Prompt:     req.Msg.Prompt,
+		Assertions: assertions,
+		Rubric:     req.Msg.Rubric,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(toProtoCase(c)), nil
+}
+
+func (s *Service) ListEvalCases(ctx context.Context, req *connect.Request[ListEvalCasesRequest]) (*connect.Response[ListEvalCasesResponse], error) {
+	cases, err := s.queries.ListEvalCasesByAgent(ctx, req.Msg.AgentId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &ListEvalCasesResponse{Cases: make([]*EvalCase, len(cases))}
+	for i, c := range cases {
+		resp.Cases[i] = toProtoCase(c)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Service) UpdateEvalCase(ctx context.Context, req *connect.Request[UpdateEvalCaseRequest]) (*connect.Response[EvalCase], error) {
+	assertions, err := marshalAssertions(req.Msg.Name, req.Msg.Prompt, req.Msg.Assertions)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	c, err := s.queries.UpdateEvalCase(ctx, store.UpdateEvalCaseParams{
+		Name:       req.Msg.Name,
+		Prompt:     req.Msg.Prompt,
+		Assertions: assertions,
+		Rubric:     req.Msg.Rubric,
+		UpdatedAt:  time.Now().UTC().Format(time.RFC3339),
+		ID:         req.Msg.Id,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("eval case not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(toProtoCase(c)), nil
+}
+
+func (s *Service) DeleteEvalCase(ctx context.Context, req *connect.Request[DeleteEvalCaseRequest]) (*connect.Response[DeleteEvalCaseResponse], error) {
+	n, err := s.queries.DeleteEvalCase(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if n == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("eval case not found"))
+	}
+
+	return connect.NewResponse(&DeleteEvalCaseResponse{}), nil
+}
+
+func (s *Service) RunEvals(ctx context.Context, req *connect.Request[RunEvalsRequest]) (*connect.Response[EvalRun], error) {
+	agent, err := s.queries.GetAgent(ctx, req.Msg.AgentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	cases, err := s.queries.ListEvalCasesByAgent(ctx, agent.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if len(req.Msg.CaseIds) > 0 {
+		byID := make(map[string]store.EvalCase, len(cases))
+		for _, c := range cases {
+			byID[c.ID] = c
+		}
+		cases = cases[:0:0]
+		for _, id := range req.Msg.CaseIds {
+			c, ok := byID[id]
+			if !ok {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("eval case %s not found for agent", id))
+			}
+			cases = append(cases, c)
+		}
+	}
+	if len(cases) == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("agent has no eval cases"))
+	}
+
+	candidate := req.Msg.SystemPrompt != "" || req.Msg.Model != ""
+	run, err := s.queries.CreateEvalRun(ctx, store.CreateEvalRunParams{
+		ID:           uuid.NewString(),
+		AgentID:      agent.ID,
+		SystemPrompt: cmp.Or(req.Msg.SystemPrompt, agent.SystemPrompt),
+		Model:        cmp.Or(req.Msg.Model, agent.Model, s.defaultModel),
+		Candidate:    boolToInt(candidate),
+		Status:       RunStatusRunning,
+		Total:        int64(len(cases)),
+		CreatedAt:    time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// The run outlives the request.
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		s.run(context.WithoutCancel(ctx), run, req.Msg, cases)
+	}()
+
+	return connect.NewResponse(toProtoRun(run, true)), nil
+}
+
+func (s *Service) GetEvalRun(ctx context.Context, req *connect.Request[GetEvalRunRequest]) (*connect.Response[EvalRun], error) {
+	run, err := s.queries.GetEvalRun(ctx, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("eval run not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(toProtoRun(run, true)), nil
+}
+
+func (s *Service) ListEvalRuns(ctx context.Context, req *connect.Request[ListEvalRunsRequest]) (*connect.Response[ListEvalRunsResponse], error) {
+	runs, err := s.queries.ListEvalRunsByAgent(ctx, req.Msg.AgentId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &ListEvalRunsResponse{Runs: make([]*EvalRun, len(runs))}
+	for i, r := range runs {
+		resp.Runs[i] = toProtoRun(r, false)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// result is the stored form of an EvalResult.
+type result struct {
+	CaseID         string   `json:"case_id"`
+	CaseName       string   `json:"case_name"`
+	ConversationID string   `json:"conversation_id,omitempty"`
+	Response       string   `json:"response"`
+	Passed         bool     `json:"passed"`
+	Score          float64  `json:"score"`
+	Failures       []string `json:"failures,omitempty"`
+	JudgeReasoning string   `json:"judge_reasoning,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// run runs eval cases one at a time, and stores their results.
+func (s *Service) run(ctx context.Context, run store.EvalRun, req *RunEvalsRequest, cases []store.EvalCase) {
+	results := make([]result, len(cases))
+	var passed int64
+	var total float64
+	for i, c := range cases {
+		results[i] = s.runCase(ctx, run, req, c)
+		if results[i].Passed {
+			passed++
+		}
+		total += results[i].Score
+	}
+
+	status := RunStatusCompleted
+	var errMsg string
+	data, err := json.Marshal(results)
+	if err != nil {
+		status, errMsg, data = RunStatusFailed, err.Error(), []byte("[]")
+	}
+	err = s.queries.FinishEvalRun(ctx, store.FinishEvalRunParams{
+		Status:     status,
+		Score:      total / float64(len(cases)),
+		Passed:     passed,
+		Results:    string(data),
+		Error:      errMsg,
+		FinishedAt: sql.NullString{String: time.Now().UTC().Format(time.RFC3339), Valid: true},
+		ID:         run.ID,
+	})
+	if err != nil {
+		slog.Error("failed to store eval run results", "eval_run_id", run.ID, "error", err)
+	}
+}
+
+// runCase runs an eval case in a new conversation with the agent, and checks
+// the answer.
+func (s *Service) runCase(ctx context.Context, run store.EvalRun, req *RunEvalsRequest, c store.EvalCase) result {
+	res := result{CaseID: c.ID, CaseName: c.Name}
+	runResult, err := s.runner.Run(ctx, runner.RunOpts{
+		AgentID:      run.AgentID,
+		Prompt:       c.Prompt,
+		Model:        req.Model,
+		SystemPrompt: req.SystemPrompt,
+		Title:        "Eval: " + c.Name,
+		Kind:         agentloop.RunKindEval,
+	})
+	if runResult != nil {
+		res.ConversationID = runResult.ConversationID
+		res.Response = runResult.Response
+	}
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	var assertions []assertion
+	if err := json.Unmarshal([]byte(c.Assertions), &assertions); err != nil {
+		res.Error = fmt.Sprintf("decode assertions: %v", err)
+		return res
+	}
+	toolsCalled, err := s.toolsCalled(ctx, res.ConversationID)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	checks, ok := len(assertions), 0
+	for _, a := range assertions {
+		if failure := check(a, res.Response, toolsCalled); failure != "" {
+			res.Failures = append(res.Failures, failure)
+		} else {
+			ok++
+		}
+	}
+	points := float64(ok)
+
+	if c.Rubric != "" {
+		checks++
+		v, err := judge(ctx, s.orClient, cmp.Or(s.judgeModel, run.Model), c.Rubric, c.Prompt, res.Response)
+		if err != nil {
+			res.Error = fmt.Sprintf("judge: %v", err)
+		} else {
+			res.JudgeReasoning = v.Reasoning
+			points += v.Score
+			if v.Score < passingScore {
+				res.Failures = append(res.Failures, fmt.Sprintf("judge scored %.2f", v.Score))
+			}
+		}
+	}
+
+	res.Score = 1
+	if checks > 0 {
+		res.Score = points / float64(checks)
+	}
+	res.Passed = len(res.Failures) == 0 && res.Error == ""
+	return res
+}
+
+// toolsCalled returns the names of the tools called in a conversation.
+func (s *Service) toolsCalled(ctx context.Context, convID string) ([]string, error) {
+	msgs, err := s.queries.GetMessagesByConversation(ctx, convID)
+	if err != nil {
+		return nil, fmt.Errorf("get messages: %w", err)
+	}
+	var names []string
+	for _, m := range msgs {
+		if m.Role != "assistant" {
+			continue
+		}
+		items, err := agentloop.DecodeItems(m.Items)
+		if err != nil {
+			return nil, fmt.Errorf("decode items: %w", err)
+		}
+		for _, item := range items {
+			if item.Type == agentloop.ItemTypeToolExecution {
+				names = append(names, item.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// marshalAssertions validates an eval case, and encodes its assertions for
+// storage.
+func marshalAssertions(name, prompt string, protoAssertions []*Assertion) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", errors.New("name is required")
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return "", errors.New("prompt is required")
+	}
+	assertions := make([]assertion, len(protoAssertions))
+	for i, a := range protoAssertions {
+		assertions[i] = assertion{Type: a.Type, Value: a.Value}
+		if err := validateAssertion(assertions[i]); err != nil {
+			return "", err
+		}
+	}
+	data, err := json.Marshal(assertions)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func toProtoCase(c store.EvalCase) *EvalCase {
+	createdAt, _ := time.Parse(time.RFC3339, c.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, c.UpdatedAt)
+
+	proto := &EvalCase{
+		Id:        c.ID,
+		AgentId:   c.AgentID,
+		Name:      c.Name,
+		Prompt:    c.Prompt,
+		Rubric:    c.Rubric,
+		CreatedAt: timestamppb.New(createdAt),
+		UpdatedAt: timestamppb.New(updatedAt),
+	}
+
+	var assertions []assertion
+	_ = json.Unmarshal([]byte(c.Assertions), &assertions)
+	for _, a := range assertions {
+		proto.Assertions = append(proto.Assertions, &Assertion{Type: a.Type, Value: a.Value})
+	}
+
+	return proto
+}
+
+// toProtoRun converts an eval run, with its per-case results if
+// withResults is set.
+func toProtoRun(r store.EvalRun, withResults bool) *EvalRun {
+	createdAt, _ := time.Parse(time.RFC3339, r.CreatedAt)
+
+	proto := &EvalRun{
+		Id:           r.ID,
+		AgentId:      r.AgentID,
+		SystemPrompt: r.SystemPrompt,
+		Model:        r.Model,
+		Candidate:    r.Candidate == 1,
+		Status:       r.Status,
+		Score:        r.Score,
+		Passed:       int32(r.Passed),
+		Total:        int32(r.Total),
+		Error:        r.Error,
+		CreatedAt:    timestamppb.New(createdAt),
+	}
+
+	if r.FinishedAt.Valid {
+		finishedAt, _ := time.Parse(time.RFC3339, r.FinishedAt.String)
+		proto.FinishedAt = timestamppb.New(finishedAt)
+	}
+
+	if withResults {
+		var results []result
+		_ = json.Unmarshal([]byte(r.Results), &results)
+		for _, res := range results {
+			proto.Results = append(proto.Results, &EvalResult{
+				CaseId:         res.CaseID,
+				CaseName:       res.CaseName,
+				ConversationId: res.ConversationID,
+				Response:       res.Response,
+				Passed:         res.Passed,
+				Score:          res.Score,
+				Failures:       res.Failures,
+				JudgeReasoning: res.JudgeReasoning,
+				Error:          res.Error,
+			})
+		}
+	}
+
+	return proto
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
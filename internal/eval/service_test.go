@@ -0,0 +1,133 @@
+package eval
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestRunEvals(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", SystemPrompt: "Be brief.", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The judge's requests aren't streamed, so it gets the default text.
+	fixture := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(fixture, []byte(`{
+		"rules": [{"match": "weather", "steps": [{"text": "It's sunny."}]}],
+		"default": "{\"score\": 0.8, \"reasoning\": \"Answers the question.\"}"
+	}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := openrouter.LoadMockFixture(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orClient := openrouter.NewMockClient(f)
+	broker := pubsub.New(nil, slog.Default())
+	loop := &agentloop.Loop{
+		Queries:      queries,
+		ORClient:     orClient,
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       broker,
+		DefaultModel: "mock",
+	}
+	svc := NewService(db, runner.New(queries, broker, loop, nil), orClient, "mock", "")
+
+	weather, err := svc.CreateEvalCase(ctx, connect.NewRequest(&CreateEvalCaseRequest{
+		AgentId:    "agent-1",
+		Name:       "Weather",
+		Prompt:     "What's the weather?",
+		Assertions: []*Assertion{{Type: AssertContains, Value: "SUNNY"}, {Type: AssertNotContains, Value: "rain"}},
+		Rubric:     "Tells the weather.",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.CreateEvalCase(ctx, connect.NewRequest(&CreateEvalCaseRequest{
+		AgentId:    "agent-1",
+		Name:       "Tools",
+		Prompt:     "Fetch example.com",
+		Assertions: []*Assertion{{Type: AssertToolCalled, Value: "fetch"}},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.CreateEvalCase(ctx, connect.NewRequest(&CreateEvalCaseRequest{
+		AgentId:    "agent-1",
+		Name:       "Invalid",
+		Prompt:     "Hi",
+		Assertions: []*Assertion{{Type: AssertRegex, Value: "("}},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("CreateEvalCase with invalid regex: got %v, want InvalidArgument", err)
+	}
+
+	started, err := svc.RunEvals(ctx, connect.NewRequest(&RunEvalsRequest{AgentId: "agent-1", SystemPrompt: "Be very brief."}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started.Msg.Status != RunStatusRunning || !started.Msg.Candidate || started.Msg.Total != 2 {
+		t.Errorf("started run = %+v, want running candidate run of 2 cases", started.Msg)
+	}
+	svc.Wait()
+
+	run, err := svc.GetEvalRun(ctx, connect.NewRequest(&GetEvalRunRequest{Id: started.Msg.Id}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Msg.Status != RunStatusCompleted || run.Msg.Passed != 1 || len(run.Msg.Results) != 2 {
+		t.Fatalf("run = %+v, want 1 of 2 cases passed", run.Msg)
+	}
+	res := run.Msg.Results[0]
+	if res.CaseId != weather.Msg.Id || !res.Passed || res.Score < 0.9 || res.JudgeReasoning != "Answers the question." {
+		t.Errorf("weather result = %+v, want passed with judge reasoning", res)
+	}
+	if res := run.Msg.Results[1]; res.Passed || res.Score != 0 || len(res.Failures) != 1 {
+		t.Errorf("tools result = %+v, want failed tool_called assertion", res)
+	}
+	if run.Msg.Score < 0.45 || run.Msg.Score > 0.5 {
+		t.Errorf("score = %v, want average of case scores", run.Msg.Score)
+	}
+
+	runs, err := svc.ListEvalRuns(ctx, connect.NewRequest(&ListEvalRunsRequest{AgentId: "agent-1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs.Msg.Runs) != 1 || runs.Msg.Runs[0].SystemPrompt != "Be very brief." {
+		t.Errorf("runs = %+v, want the candidate run", runs.Msg.Runs)
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	v, err := parseVerdict("```json\n{\"score\": 1.5, \"reasoning\": \"Great.\"}\n```")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Score != 1 || v.Reasoning != "Great." {
+		t.Errorf("verdict = %+v, want clamped score", v)
+	}
+	if _, err := parseVerdict("Looks good to me."); err == nil {
+		t.Error("parseVerdict without JSON: got no error")
+	}
+}
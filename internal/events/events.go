@@ -0,0 +1,275 @@
+// Package events serves broker events as server-sent events, for clients
+// that don't speak Connect, such as curl or home automation scripts.
+package events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/auth"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// maxTopics limits the topics a single stream can follow.
+const maxTopics = 20
+
+// keepAliveInterval is how often a comment is sent on idle streams, so
+// proxies don't close them.
+const keepAliveInterval = 30 * time.Second
+
+// Handler streams the events of the topics given by "topic" query parameters
+// as text/event-stream. Event data is a pubsub.Event as JSON, with the field
+// names of the proto file, and the event name is its type. "type" query
+// parameters limit the stream to events of those types. Topics are
+// "conversation:<id>" for a conversation's events, and "agent:<id>",
+// "agent:*" and "instance" for activity. Event IDs
+// are cursors of the last sequence number per conversation topic, so
+// reconnecting clients that send Last-Event-ID get the events they missed.
+// Slow clients get a "gap" event in place of dropped events; for conversation
+// topics the stream then ends, so they reconnect and replay them. Activity
+// isn't replayed.
+type Handler struct {
+	queries *store.Queries
+	broker  *pubsub.Broker
+	logger  *slog.Logger
+}
+
+// NewHandler returns a Handler.
+func NewHandler(queries *store.Queries, broker *pubsub.Broker, logger *slog.Logger) *Handler {
+	return &Handler{queries: queries, broker: broker, logger: logger}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	topics, err := parseTopics(r.URL.Query()["topic"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	types := r.URL.Query()["type"]
+	for _, typ := range types {
+		if !pubsub.IsEventType(typ) {
+			http.Error(w, fmt.Sprintf("Invalid event type %q", typ), http.StatusBadRequest)
+			return
+		}
+	}
+	var convTopics, activityTopics []string
+	for _, topic := range topics {
+		if strings.HasPrefix(topic, pubsub.ConversationTopicPrefix) {
+			convTopics = append(convTopics, topic)
+		} else {
+			activityTopics = append(activityTopics, topic)
+		}
+	}
+	cursor := parseCursor(r.Header.Get("Last-Event-ID"))
+	maps.DeleteFunc(cursor, func(topic string, _ int64) bool {
+		return !slices.Contains(convTopics, topic)
+	})
+
+	for _, topic := range activityTopics {
+		agentID, ok := strings.CutPrefix(topic, pubsub.AgentTopicPrefix)
+		if ok && agentID != "*" {
+			if !auth.AllowsAgent(r.Context(), agentID) {
+				http.Error(w, "API key isn't allowed to access this agent", http.StatusForbidden)
+				return
+			}
+			continue
+		}
+		if !auth.AllowsAllAgents(r.Context()) {
+			http.Error(w, fmt.Sprintf("API key isn't allowed to follow topic %q", topic), http.StatusForbidden)
+			return
+		}
+	}
+	for _, topic := range convTopics {
+		convID := strings.TrimPrefix(topic, pubsub.ConversationTopicPrefix)
+		conv, err := h.queries.GetConversation(r.Context(), convID)
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, fmt.Sprintf("Conversation %q not found", convID), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			h.logger.Error("failed to get conversation", "conversation_id", convID, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if !auth.AllowsAgent(r.Context(), conv.AgentID) {
+			http.Error(w, "API key isn't allowed to access this agent", http.StatusForbidden)
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var replay []*pubsub.Event
+	events := make(chan *pubsub.Event)
+	for _, topic := range convTopics {
+		convID := strings.TrimPrefix(topic, pubsub.ConversationTopicPrefix)
+		sub, missed, err := h.broker.SubscribeFrom(ctx, convID, cursor[topic], pubsub.OnlyTypes(types...))
+		if err != nil {
+			h.logger.Error("failed to subscribe to events", "topic", topic, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		defer h.broker.Unsubscribe(sub)
+		replay = append(replay, missed...)
+		go forward(ctx, sub, events)
+	}
+	if len(activityTopics) > 0 {
+		sub := h.broker.SubscribeTopics(activityTopics, pubsub.OnlyTypes(types...))
+		defer h.broker.Unsubscribe(sub)
+		go forward(ctx, sub, events)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Disables response buffering in nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for _, e := range replay {
+		if err := h.write(w, cursor, e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case e := <-events:
+			if err := h.write(w, cursor, e); err != nil {
+				return
+			}
+			flusher.Flush()
+			// After a gap in conversation events, the stream ends so the
+			// client reconnects with the cursor before the gap, replaying the
+			// missed events. Activity can't be replayed.
+			if _, ok := e.Payload.(*pubsub.Event_Gap); ok && strings.HasPrefix(e.Topic, pubsub.ConversationTopicPrefix) {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forward sends a subscription's events to events until ctx is done.
+func forward(ctx context.Context, sub *pubsub.Subscription, events chan<- *pubsub.Event) {
+	for {
+		select {
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// write writes an event, and advances the cursor for conversation events.
+func (h *Handler) write(w http.ResponseWriter, cursor map[string]int64, e *pubsub.Event) error {
+	data, err := pubsub.MarshalJSON(e)
+	if err != nil {
+		h.logger.Error("failed to encode event", "topic", e.Topic, "error", err)
+		return nil
+	}
+	if strings.HasPrefix(e.Topic, pubsub.ConversationTopicPrefix) && e.Sequence > cursor[e.Topic] {
+		cursor[e.Topic] = e.Sequence
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", formatCursor(cursor), e.Type(), data)
+	return err
+}
+
+// parseTopics validates and deduplicates topics.
+func parseTopics(values []string) ([]string, error) {
+	var topics []string
+	for _, v := range values {
+		for topic := range strings.SplitSeq(v, ",") {
+			topic = strings.TrimSpace(topic)
+			if !validTopic(topic) {
+				return nil, fmt.Errorf("invalid topic %q, expected conversation:<id>, agent:<id>, agent:* or instance", topic)
+			}
+			if !slices.Contains(topics, topic) {
+				topics = append(topics, topic)
+			}
+		}
+	}
+	if len(topics) == 0 {
+		return nil, errors.New("at least one topic is required")
+	}
+	if len(topics) > maxTopics {
+		return nil, fmt.Errorf("at most %d topics are allowed", maxTopics)
+	}
+	return topics, nil
+}
+
+// validTopic reports whether a topic can be followed.
+func validTopic(topic string) bool {
+	if topic == pubsub.InstanceTopic {
+		return true
+	}
+	if id, ok := strings.CutPrefix(topic, pubsub.ConversationTopicPrefix); ok {
+		return id != "" && !strings.Contains(id, "*")
+	}
+	if id, ok := strings.CutPrefix(topic, pubsub.AgentTopicPrefix); ok {
+		return id == "*" || id != "" && !strings.Contains(id, "*")
+	}
+	return false
+}
+
+// parseCursor parses an event ID of the form "topic=seq topic=seq".
+// Malformed entries are ignored.
+func parseCursor(s string) map[string]int64 {
+	cursor := make(map[string]int64)
+	for entry := range strings.FieldsSeq(s) {
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			continue
+		}
+		seq, err := strconv.ParseInt(entry[i+1:], 10, 64)
+		if err != nil || seq < 0 {
+			continue
+		}
+		cursor[entry[:i]] = seq
+	}
+	return cursor
+}
+
+// formatCursor formats a cursor as an event ID.
+func formatCursor(cursor map[string]int64) string {
+	entries := make([]string, 0, len(cursor))
+	for topic, seq := range cursor {
+		entries = append(entries, topic+"="+strconv.FormatInt(seq, 10))
+	}
+	slices.Sort(entries)
+	return strings.Join(entries, " ")
+}
@@ -0,0 +1,158 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/store"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// readEvent reads the next event from a stream, skipping comments. It
+// returns the event ID, name and data.
+func readEvent(t *testing.T, r *bufio.Reader) (id, name, data string) {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && data != "":
+			return id, name, data
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	broker := pubsub.New(pubsub.NewStoreLog(queries, time.Hour), slog.Default())
+	srv := httptest.NewServer(NewHandler(queries, broker, slog.Default()))
+	defer srv.Close()
+
+	for _, q := range []string{"", "?topic=bogus", "?topic=agent:a*", "?topic=conversation:*", "?topic=conversation:conv-1&type=bogus"} {
+		res, err := http.Get(srv.URL + q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET %q: status %d, want 400", q, res.StatusCode)
+		}
+	}
+	res, err := http.Get(srv.URL + "?topic=conversation:unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown conversation: status %d, want 404", res.StatusCode)
+	}
+
+	broker.Publish("conv-1", &pubsub.Event_TurnStarted{TurnStarted: &pubsub.TurnStarted{}})
+	broker.Publish("conv-1", &pubsub.Event_TextDelta{TextDelta: &pubsub.TextDelta{Content: "Hello"}})
+
+	// Resume after the first event.
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"?topic=conversation:conv-1", nil)
+	req.Header.Set("Last-Event-ID", "conversation:conv-1=1")
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	r := bufio.NewReader(res.Body)
+
+	id, name, data := readEvent(t, r)
+	want := `{"topic":"conversation:conv-1","sequence":"2","text_delta":{"content":"Hello"}}`
+	if id != "conversation:conv-1=2" || name != "text_delta" || compact(t, data) != want {
+		t.Errorf("replayed event = %q, %q, %s", id, name, data)
+	}
+
+	broker.Publish("conv-1", &pubsub.Event_TurnDone{TurnDone: &pubsub.TurnDone{Title: "Greeting"}})
+	id, name, data = readEvent(t, r)
+	var e pubsub.Event
+	if err := protojson.Unmarshal([]byte(data), &e); err != nil {
+		t.Fatal(err)
+	}
+	if id != "conversation:conv-1=3" || name != "turn_done" || e.Topic != "conversation:conv-1" || e.GetTurnDone().GetTitle() != "Greeting" {
+		t.Errorf("live event = %q, %q, %s", id, name, data)
+	}
+}
+
+// compact removes insignificant space from JSON, which protojson adds
+// randomly.
+func compact(t *testing.T, data string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestCursor(t *testing.T) {
+	cursor := parseCursor("conversation:b=7 conversation:a=3 bogus conversation:c=-1")
+	if len(cursor) != 2 || cursor["conversation:a"] != 3 || cursor["conversation:b"] != 7 {
+		t.Errorf("parseCursor = %v", cursor)
+	}
+	if got := formatCursor(cursor); got != "conversation:a=3 conversation:b=7" {
+		t.Errorf("formatCursor = %q", got)
+	}
+}
+
+func TestActivity(t *testing.T) {
+	broker := pubsub.New(nil, slog.Default())
+	srv := httptest.NewServer(NewHandler(nil, broker, slog.Default()))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "?topic=agent:agent-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	// Headers are sent after subscribing.
+	r := bufio.NewReader(res.Body)
+
+	broker.PublishActivity("agent-2", &pubsub.Event_RunStarted{RunStarted: &pubsub.RunStarted{AgentId: "agent-2"}})
+	broker.PublishActivity("agent-1", &pubsub.Event_RunFinished{RunFinished: &pubsub.RunFinished{AgentId: "agent-1", Status: agentloop.RunStatusCompleted}})
+	id, name, data := readEvent(t, r)
+	if id != "" || name != "run_finished" || !strings.Contains(compact(t, data), `"topic":"agent:agent-1"`) {
+		t.Errorf("activity event = %q, %q, %s", id, name, data)
+	}
+}
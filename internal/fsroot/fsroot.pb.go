@@ -30,6 +30,7 @@ type FilesystemRoot struct {
 	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"` // Incremented on every update
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,6 +107,13 @@ func (x *FilesystemRoot) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *FilesystemRoot) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type CreateFilesystemRootRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -296,6 +304,7 @@ type UpdateFilesystemRootRequest struct {
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
 	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"` // Version the update is based on; fails with ABORTED if stale
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -358,6 +367,13 @@ func (x *UpdateFilesystemRootRequest) GetDescription() string {
 	return ""
 }
 
+func (x *UpdateFilesystemRootRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type DeleteFilesystemRootRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -442,7 +458,7 @@ var File_fsroot_fsroot_proto protoreflect.FileDescriptor
 
 const file_fsroot_fsroot_proto_rawDesc = "" +
 	"\n" +
-	"\x13fsroot/fsroot.proto\x12\rblippy.fsroot\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x01\n" +
+	"\x13fsroot/fsroot.proto\x12\rblippy.fsroot\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfa\x01\n" +
 	"\x0eFilesystemRoot\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -451,7 +467,8 @@ const file_fsroot_fsroot_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"g\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\"g\n" +
 	"\x1bCreateFilesystemRootRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x12 \n" +
@@ -460,12 +477,13 @@ const file_fsroot_fsroot_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x1c\n" +
 	"\x1aListFilesystemRootsRequest\"R\n" +
 	"\x1bListFilesystemRootsResponse\x123\n" +
-	"\x05roots\x18\x01 \x03(\v2\x1d.blippy.fsroot.FilesystemRootR\x05roots\"w\n" +
+	"\x05roots\x18\x01 \x03(\v2\x1d.blippy.fsroot.FilesystemRootR\x05roots\"\x91\x01\n" +
 	"\x1bUpdateFilesystemRootRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
 	"\x04path\x18\x03 \x01(\tR\x04path\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\"-\n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\"-\n" +
 	"\x1bDeleteFilesystemRootRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\a\n" +
 	"\x05Empty2\x82\x04\n" +
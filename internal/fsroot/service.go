@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dstotijn/blippy/internal/apierror"
 	"github.com/dstotijn/blippy/internal/store"
 )
 
@@ -68,6 +69,10 @@ func (s *Service) ListFilesystemRoots(ctx context.Context, req *connect.Request[
 }
 
 func (s *Service) UpdateFilesystemRoot(ctx context.Context, req *connect.Request[UpdateFilesystemRootRequest]) (*connect.Response[FilesystemRoot], error) {
+	if req.Msg.Version == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("version is required"))
+	}
+
 	now := time.Now().UTC()
 
 	root, err := s.queries.UpdateFilesystemRoot(ctx, store.UpdateFilesystemRootParams{
@@ -76,9 +81,13 @@ func (s *Service) UpdateFilesystemRoot(ctx context.Context, req *connect.Request
 		Path:        req.Msg.Path,
 		Description: req.Msg.Description,
 		UpdatedAt:   now.Format(time.RFC3339),
+		Version:     req.Msg.Version,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if _, err := s.queries.GetFilesystemRoot(ctx, req.Msg.Id); err == nil {
+				return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_VERSION_CONFLICT, errors.New("filesystem root was modified since it was loaded; reload and try again"))
+			}
 			return nil, connect.NewError(connect.CodeNotFound, errors.New("filesystem root not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
@@ -106,5 +115,6 @@ func toProtoFilesystemRoot(r store.FilesystemRoot) *FilesystemRoot {
 		Description: r.Description,
 		CreatedAt:   timestamppb.New(createdAt),
 		UpdatedAt:   timestamppb.New(updatedAt),
+		Version:     r.Version,
 	}
 }
@@ -0,0 +1,57 @@
+package fsroot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TestUpdateFilesystemRootVersion checks that updates with a stale version
+// are rejected without changing the root.
+func TestUpdateFilesystemRootVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	svc := NewService(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateFilesystemRoot(ctx, store.CreateFilesystemRootParams{ID: "root-1", Name: "docs", Path: "/srv/docs", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.UpdateFilesystemRoot(ctx, connect.NewRequest(&UpdateFilesystemRootRequest{Id: "root-1", Name: "docs", Path: "/srv/other"})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("update without version: err = %v, want invalid argument", err)
+	}
+
+	res, err := svc.UpdateFilesystemRoot(ctx, connect.NewRequest(&UpdateFilesystemRootRequest{Id: "root-1", Name: "docs", Path: "/srv/first", Version: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Version != 2 {
+		t.Errorf("version after update = %d, want 2", res.Msg.Version)
+	}
+
+	if _, err := svc.UpdateFilesystemRoot(ctx, connect.NewRequest(&UpdateFilesystemRootRequest{Id: "root-1", Name: "docs", Path: "/srv/second", Version: 1})); connect.CodeOf(err) != connect.CodeAborted {
+		t.Errorf("update with stale version: err = %v, want aborted", err)
+	}
+	root, err := queries.GetFilesystemRoot(ctx, "root-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Path != "/srv/first" || root.Version != 2 {
+		t.Errorf("root after stale update = %q (version %d), want %q (version 2)", root.Path, root.Version, "/srv/first")
+	}
+
+	if _, err := svc.UpdateFilesystemRoot(ctx, connect.NewRequest(&UpdateFilesystemRootRequest{Id: "missing", Name: "x", Path: "/x", Version: 1})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("update of missing root: err = %v, want not found", err)
+	}
+}
@@ -0,0 +1,275 @@
+// Package hooks implements the built-in post-turn hooks, which agents enable
+// by name to post-process their turns: distilling memories, recording usage,
+// calling webhooks and notifying about failed runs.
+package hooks
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// Names of the built-in hooks.
+const (
+	NameMemory      = agentloop.MemoryHookName
+	NameUsage       = "usage"
+	NameWebhook     = "webhook"
+	NameNotifyError = "notify_error"
+)
+
+// Register adds the built-in hooks to a loop.
+func Register(l *agentloop.Loop, queries *store.Queries, orClient *openrouter.Client, channels tool.NotificationChannelLister, dispatcher tool.NotificationDispatcher) {
+	l.AddHook(NameMemory, Memory(queries, orClient))
+	l.AddHook(NameUsage, Usage(queries))
+	l.AddHook(NameWebhook, Webhook(http.DefaultClient))
+	l.AddHook(NameNotifyError, NotifyError(channels, dispatcher))
+}
+
+// noMemoryChanges is the LLM's reply when a turn has nothing to remember.
+const noMemoryChanges = "NO_CHANGES"
+
+const memoryPrompt = `You maintain MEMORY.md, the long-term memory of an AI agent. It is loaded into the agent's instructions at the start of every conversation.
+
+Update it with durable facts from the exchange below that will be useful in future conversations, such as user preferences, decisions and project details. Leave out anything temporary or only relevant to this exchange. Keep it concise, merge duplicates and remove facts the exchange shows to be outdated.
+
+Reply with the complete updated MEMORY.md, or with only ` + noMemoryChanges + ` if nothing is worth remembering.
+
+## Current MEMORY.md
+%s
+
+## Exchange
+User: %s
+Assistant: %s`
+
+// Memory returns a hook that distills durable facts from completed turns
+// into the agent's MEMORY.md. The optional config {"model": "..."} sets the
+// model to use, which defaults to the turn's model.
+func Memory(queries *store.Queries, orClient *openrouter.Client) agentloop.Hook {
+	return func(ctx context.Context, turn agentloop.TurnResult, config json.RawMessage) error {
+		if turn.Status != agentloop.RunStatusCompleted || turn.UserContent == "" || turn.Response == "" {
+			return nil
+		}
+		var cfg struct {
+			Model string `json:"model"`
+		}
+		if err := decodeConfig(config, &cfg); err != nil {
+			return err
+		}
+
+		var current string
+		file, err := queries.GetAgentFile(ctx, store.GetAgentFileParams{AgentID: turn.Agent.ID, Path: agentloop.MemoryIndexPath})
+		if err == nil {
+			current = file.Content
+		}
+
+		resp, err := orClient.CreateResponse(ctx, &openrouter.ResponseRequest{
+			Model: cmp.Or(cfg.Model, turn.Model),
+			Input: []openrouter.Input{{
+				Type:    "message",
+				Role:    "user",
+				Content: []openrouter.ContentPart{{Type: "input_text", Text: fmt.Sprintf(memoryPrompt, current, turn.UserContent, turn.Response)}},
+			}},
+		})
+		if err != nil {
+			return fmt.Errorf("distill memory: %w", err)
+		}
+		updated := strings.TrimSpace(responseText(resp))
+		if updated == "" || updated == noMemoryChanges || updated == strings.TrimSpace(current) {
+			return nil
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		if _, err := queries.UpsertAgentFile(ctx, store.UpsertAgentFileParams{
+			AgentID:   turn.Agent.ID,
+			Path:      agentloop.MemoryIndexPath,
+			Content:   updated,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}); err != nil {
+			return fmt.Errorf("store memory: %w", err)
+		}
+		return nil
+	}
+}
+
+// Usage returns a hook that records the token usage of turns.
+func Usage(queries *store.Queries) agentloop.Hook {
+	return func(ctx context.Context, turn agentloop.TurnResult, _ json.RawMessage) error {
+		return queries.CreateTurnUsage(ctx, store.CreateTurnUsageParams{
+			RunID:          turn.RunID,
+			AgentID:        turn.Agent.ID,
+			ConversationID: turn.Conv.ID,
+			Kind:           turn.Kind,
+			Model:          turn.Model,
+			Status:         turn.Status,
+			InputTokens:    turn.InputTokens,
+			OutputTokens:   turn.OutputTokens,
+			StartedAt:      turn.StartedAt.UTC().Format(time.RFC3339),
+			FinishedAt:     turn.FinishedAt.UTC().Format(time.RFC3339),
+		})
+	}
+}
+
+// WebhookPayload is the JSON body the webhook hook posts.
+type WebhookPayload struct {
+	RunID          string `json:"run_id"`
+	Kind           string `json:"kind"`
+	AgentID        string `json:"agent_id"`
+	AgentName      string `json:"agent_name"`
+	ConversationID string `json:"conversation_id"`
+	Status         string `json:"status"`
+	Response       string `json:"response,omitempty"`
+	Error          string `json:"error,omitempty"`
+	InputTokens    int64  `json:"input_tokens"`
+	OutputTokens   int64  `json:"output_tokens"`
+	StartedAt      string `json:"started_at"`
+	FinishedAt     string `json:"finished_at"`
+}
+
+// Webhook returns a hook that posts a WebhookPayload for each turn to the
+// URL of its config {"url": "..."}.
+func Webhook(client *http.Client) agentloop.Hook {
+	return func(ctx context.Context, turn agentloop.TurnResult, config json.RawMessage) error {
+		var cfg struct {
+			URL string `json:"url"`
+		}
+		if err := decodeConfig(config, &cfg); err != nil {
+			return err
+		}
+		if cfg.URL == "" {
+			return errors.New("webhook hook has no url")
+		}
+
+		payload := WebhookPayload{
+			RunID:          turn.RunID,
+			Kind:           turn.Kind,
+			AgentID:        turn.Agent.ID,
+			AgentName:      turn.Agent.Name,
+			ConversationID: turn.Conv.ID,
+			Status:         turn.Status,
+			Response:       turn.Response,
+			InputTokens:    turn.InputTokens,
+			OutputTokens:   turn.OutputTokens,
+			StartedAt:      turn.StartedAt.UTC().Format(time.RFC3339),
+			FinishedAt:     turn.FinishedAt.UTC().Format(time.RFC3339),
+		}
+		if turn.Err != nil {
+			payload.Error = turn.Err.Error()
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("marshal payload: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("post webhook: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, b)
+		}
+		return nil
+	}
+}
+
+// NotifyError returns a hook that sends a notification to the agent's
+// notification channels when a turn fails or times out. The optional config
+// {"channel_ids": [...]} selects other channels.
+func NotifyError(channels tool.NotificationChannelLister, dispatcher tool.NotificationDispatcher) agentloop.Hook {
+	return func(ctx context.Context, turn agentloop.TurnResult, config json.RawMessage) error {
+		if turn.Status != agentloop.RunStatusFailed && turn.Status != agentloop.RunStatusTimedOut {
+			return nil
+		}
+		var cfg struct {
+			ChannelIDs []string `json:"channel_ids"`
+		}
+		if err := decodeConfig(config, &cfg); err != nil {
+			return err
+		}
+		ids := cfg.ChannelIDs
+		if len(ids) == 0 {
+			_ = json.Unmarshal([]byte(turn.Agent.EnabledNotificationChannels), &ids)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		chans, err := channels.ListNotificationChannelsByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("list channels: %w", err)
+		}
+
+		title := fmt.Sprintf("%s run failed", cmp.Or(turn.Agent.Name, "Agent"))
+		if turn.Status == agentloop.RunStatusTimedOut {
+			title = fmt.Sprintf("%s run timed out", cmp.Or(turn.Agent.Name, "Agent"))
+		}
+		var body string
+		if turn.Err != nil {
+			body = turn.Err.Error()
+		}
+		// Fields for the default schemas of web push (title, body, url) and
+		// SMS (text) channels.
+		payload, err := json.Marshal(map[string]string{
+			"title": title,
+			"body":  body,
+			"text":  strings.TrimSuffix(title+": "+body, ": "),
+			"url":   "/agents/" + turn.Agent.ID + "/" + turn.Conv.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal payload: %w", err)
+		}
+
+		ctx = tool.WithConversationID(ctx, turn.Conv.ID)
+		var errs []error
+		for _, c := range chans {
+			if _, err := dispatcher.DispatchNotification(ctx, c, payload); err != nil {
+				errs = append(errs, fmt.Errorf("notify %s: %w", c.Name, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// decodeConfig decodes a hook's config into v, if it has one.
+func decodeConfig(config json.RawMessage, v any) error {
+	if len(config) == 0 || string(config) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(config, v); err != nil {
+		return fmt.Errorf("invalid hook config: %w", err)
+	}
+	return nil
+}
+
+// responseText returns the text of a response's message output.
+func responseText(resp *openrouter.Response) string {
+	var sb strings.Builder
+	for _, item := range resp.Output {
+		if item.Type != "message" {
+			continue
+		}
+		for _, part := range item.Content {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,152 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func testTurn() agentloop.TurnResult {
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return agentloop.TurnResult{
+		RunID:        "run-1",
+		Kind:         agentloop.RunKindTrigger,
+		Conv:         store.Conversation{ID: "conv-1"},
+		Agent:        store.Agent{ID: "agent-1", Name: "Reporter", EnabledNotificationChannels: `["chan-1"]`},
+		Model:        "test/model",
+		Response:     "Done.",
+		Status:       agentloop.RunStatusCompleted,
+		InputTokens:  100,
+		OutputTokens: 20,
+		StartedAt:    started,
+		FinishedAt:   started.Add(time.Minute),
+	}
+}
+
+func TestUsage(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Usage(queries)(ctx, testTurn(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var model, status string
+	var input, output int64
+	if err := db.QueryRowContext(ctx, "SELECT model, status, input_tokens, output_tokens FROM turn_usage WHERE run_id = 'run-1'").Scan(&model, &status, &input, &output); err != nil {
+		t.Fatal(err)
+	}
+	if model != "test/model" || status != agentloop.RunStatusCompleted || input != 100 || output != 20 {
+		t.Errorf("usage = %s %s %d %d, want test/model completed 100 20", model, status, input, output)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var got WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		if got.Status == agentloop.RunStatusFailed {
+			http.Error(w, "nope", http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	hook := Webhook(srv.Client())
+	config := json.RawMessage(`{"url":"` + srv.URL + `"}`)
+	if err := hook(context.Background(), testTurn(), config); err != nil {
+		t.Fatal(err)
+	}
+	if got.RunID != "run-1" || got.AgentName != "Reporter" || got.Response != "Done." || got.StartedAt != "2025-01-02T03:04:05Z" {
+		t.Errorf("payload = %+v", got)
+	}
+
+	turn := testTurn()
+	turn.Status = agentloop.RunStatusFailed
+	turn.Err = errors.New("stream error")
+	if err := hook(context.Background(), turn, config); err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+	if got.Error != "stream error" {
+		t.Errorf("payload error = %q, want stream error", got.Error)
+	}
+
+	if err := hook(context.Background(), testTurn(), nil); err == nil {
+		t.Error("expected error without url")
+	}
+}
+
+type fakeChannels struct {
+	tool.NotificationChannelLister
+}
+
+func (fakeChannels) ListNotificationChannelsByIDs(ctx context.Context, ids []string) ([]tool.NotificationChannel, error) {
+	channels := make([]tool.NotificationChannel, len(ids))
+	for i, id := range ids {
+		channels[i] = tool.NotificationChannel{ID: id, Name: id}
+	}
+	return channels, nil
+}
+
+type fakeDispatcher struct {
+	sent map[string]json.RawMessage
+}
+
+func (d *fakeDispatcher) DispatchNotification(ctx context.Context, channel tool.NotificationChannel, payload json.RawMessage) (string, error) {
+	d.sent[channel.ID] = payload
+	return "sent", nil
+}
+
+func TestNotifyError(t *testing.T) {
+	d := &fakeDispatcher{sent: make(map[string]json.RawMessage)}
+	hook := NotifyError(fakeChannels{}, d)
+	ctx := context.Background()
+
+	if err := hook(ctx, testTurn(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.sent) != 0 {
+		t.Fatalf("notified about completed turn: %v", d.sent)
+	}
+
+	turn := testTurn()
+	turn.Status = agentloop.RunStatusTimedOut
+	turn.Err = agentloop.ErrTimedOut
+	if err := hook(ctx, turn, nil); err != nil {
+		t.Fatal(err)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(d.sent["chan-1"], &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload["title"] != "Reporter run timed out" || payload["url"] != "/agents/agent-1/conv-1" {
+		t.Errorf("payload = %v", payload)
+	}
+
+	if err := hook(ctx, turn, json.RawMessage(`{"channel_ids":["chan-2"]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.sent["chan-2"]; !ok {
+		t.Error("configured channel wasn't notified")
+	}
+}
@@ -0,0 +1,55 @@
+package listener
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blippy.sock")
+
+	l, err := Unix(path, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", fi.Mode().Perm())
+	}
+
+	if _, err := Unix(path, 0o600); err == nil {
+		t.Error("Unix succeeded on a socket in use")
+	}
+
+	// Leave a stale socket file behind, as a killed process would.
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+	l, err = Unix(path, 0o600)
+	if err != nil {
+		t.Fatalf("Unix with stale socket: %v", err)
+	}
+	l.Close()
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Unix(file, 0o600); err == nil {
+		t.Error("Unix succeeded on a regular file")
+	}
+}
+
+func TestSystemdNotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	t.Setenv("LISTEN_FDS", "1")
+	listeners, err := Systemd()
+	if err != nil || listeners != nil {
+		t.Errorf("Systemd for another process = %v, %v; want nil", listeners, err)
+	}
+}
@@ -0,0 +1,292 @@
+// Package listing implements pagination, sorting and filtering for list RPCs.
+// Requests are translated to SQL, so only the rows of a page are read.
+//
+// Filters are terms joined by "AND", each comparing a field to a value:
+//
+//	name:deploy AND created_at>=2025-01-01
+//
+// Supported operators are "=", "!=", "<", "<=", ">", ">=" (string
+// comparison, which orders RFC3339 UTC timestamps correctly) and ":"
+// (case-insensitive substring match). Values containing spaces can be
+// double-quoted.
+//
+// Order is a comma-separated list of fields, each optionally followed by
+// "asc" or "desc", e.g. "updated_at desc, name". Results are ordered by "id"
+// last, so pages are stable; page tokens hold the order values of the last
+// result of a page.
+package listing
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// MaxPageSize is the largest page size accepted. Larger values are capped.
+const MaxPageSize = 1000
+
+// ErrInvalidPageToken is returned when a page token can't be decoded or was
+// issued for a different filter or order.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Request holds the list parameters shared by list RPCs.
+type Request struct {
+	PageSize  int32 // 0 returns all results
+	PageToken string
+	OrderBy   string
+	Filter    string
+	// Match holds fields that must equal a value, set from parameters of
+	// the RPC itself, such as an agent ID.
+	Match map[string]string
+}
+
+// Page is a page of results.
+type Page[T any] struct {
+	Items         []T
+	NextPageToken string // empty on the last page
+	TotalSize     int32  // number of results matching the filter
+}
+
+// Field is a field usable in filters and order clauses.
+type Field[T any] struct {
+	// Column is the SQL expression of the field. It must not be NULL, so
+	// nullable columns are wrapped in COALESCE.
+	Column string
+	// Value returns the field of an item, as stored in page tokens.
+	Value func(T) string
+}
+
+// Fields maps field names to fields. They must include "id".
+type Fields[T any] map[string]Field[T]
+
+// Table describes how a resource is listed.
+type Table[T any] struct {
+	Fields Fields[T]
+	// Order is the default order clause.
+	Order string
+}
+
+// Query is a parsed list request.
+type Query[T any] struct {
+	fields Fields[T]
+	// where and args are the conditions of the filter and matches.
+	where []string
+	args  []any
+	keys  []orderKey
+	// after holds the order values of the last result of the previous page.
+	after []string
+	size  int
+	hash  string
+}
+
+type orderKey struct {
+	field string
+	desc  bool
+}
+
+type pageToken struct {
+	After []string `json:"a"`
+	Hash  string   `json:"h"`
+}
+
+var termRe = regexp.MustCompile(`^\s*([a-z_]+)\s*(!=|<=|>=|=|<|>|:)\s*(.*?)\s*$`)
+
+var andRe = regexp.MustCompile(`(?i)\s+AND\s+`)
+
+// Parse validates a list request for a table.
+func Parse[T any](req Request, table Table[T]) (*Query[T], error) {
+	q := &Query[T]{fields: table.Fields, hash: requestHash(req)}
+	if err := q.parseFilter(req.Filter); err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(req.Match)) {
+		f, ok := table.Fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown match field %q", name)
+		}
+		q.where = append(q.where, f.Column+" = ?")
+		q.args = append(q.args, req.Match[name])
+	}
+	if err := q.parseOrder(cmp.Or(strings.TrimSpace(req.OrderBy), table.Order)); err != nil {
+		return nil, err
+	}
+
+	if req.PageToken != "" {
+		token, err := decodeToken(req.PageToken)
+		if err != nil || token.Hash != q.hash || len(token.After) != len(q.keys) {
+			return nil, ErrInvalidPageToken
+		}
+		q.after = token.After
+	}
+	if req.PageSize > 0 {
+		q.size = int(min(req.PageSize, MaxPageSize))
+	}
+	return q, nil
+}
+
+func (q *Query[T]) parseFilter(filter string) error {
+	if strings.TrimSpace(filter) == "" {
+		return nil
+	}
+	for _, term := range andRe.Split(strings.TrimSpace(filter), -1) {
+		m := termRe.FindStringSubmatch(term)
+		if m == nil {
+			return fmt.Errorf("invalid filter term %q", term)
+		}
+		name, op, value := m[1], m[2], m[3]
+		f, ok := q.fields[name]
+		if !ok {
+			return fmt.Errorf("unknown filter field %q", name)
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		if op == ":" {
+			q.where = append(q.where, "instr(lower("+f.Column+"), lower(?)) > 0")
+		} else {
+			q.where = append(q.where, f.Column+" "+op+" ?")
+		}
+		q.args = append(q.args, value)
+	}
+	return nil
+}
+
+func (q *Query[T]) parseOrder(orderBy string) error {
+	var parts []string
+	if orderBy != "" {
+		parts = strings.Split(orderBy, ",")
+	}
+	for _, part := range parts {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return fmt.Errorf("invalid order clause %q", strings.TrimSpace(part))
+		}
+		if _, ok := q.fields[words[0]]; !ok {
+			return fmt.Errorf("unknown order field %q", words[0])
+		}
+		k := orderKey{field: words[0]}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				k.desc = true
+			default:
+				return fmt.Errorf("invalid order direction %q", words[1])
+			}
+		}
+		q.keys = append(q.keys, k)
+	}
+	if !slices.ContainsFunc(q.keys, func(k orderKey) bool { return k.field == "id" }) {
+		q.keys = append(q.keys, orderKey{field: "id"})
+	}
+	return nil
+}
+
+// Fetch reads the page of a query: fetch selects the rows of a
+// store.PageQuery and count counts the rows matching its condition.
+func Fetch[T any](ctx context.Context, q *Query[T], fetch func(context.Context, store.PageQuery) ([]T, error), count func(context.Context, store.PageQuery) (int64, error)) (Page[T], error) {
+	total, err := count(ctx, store.PageQuery{Where: strings.Join(q.where, " AND "), Args: q.args})
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	where, args := slices.Clip(q.where), slices.Clip(q.args)
+	if q.after != nil {
+		cond, condArgs := q.afterCondition()
+		where = append(where, cond)
+		args = append(args, condArgs...)
+	}
+	var order []string
+	for _, k := range q.keys {
+		dir := "ASC"
+		if k.desc {
+			dir = "DESC"
+		}
+		order = append(order, q.fields[k.field].Column+" "+dir)
+	}
+	pq := store.PageQuery{
+		Where:   strings.Join(where, " AND "),
+		Args:    args,
+		OrderBy: strings.Join(order, ", "),
+	}
+	if q.size > 0 {
+		// One more row tells whether there's a next page.
+		pq.Limit = q.size + 1
+	}
+	items, err := fetch(ctx, pq)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	page := Page[T]{Items: items, TotalSize: int32(total)}
+	if q.size > 0 && len(items) > q.size {
+		page.Items = items[:q.size]
+		last := page.Items[q.size-1]
+		token := pageToken{Hash: q.hash}
+		for _, k := range q.keys {
+			token.After = append(token.After, q.fields[k.field].Value(last))
+		}
+		page.NextPageToken = encodeToken(token)
+	}
+	return page, nil
+}
+
+// afterCondition matches the rows after q.after in the order of q.keys.
+func (q *Query[T]) afterCondition() (string, []any) {
+	var (
+		ors  []string
+		args []any
+	)
+	for i, k := range q.keys {
+		var ands []string
+		for j := range i {
+			ands = append(ands, q.fields[q.keys[j].field].Column+" = ?")
+			args = append(args, q.after[j])
+		}
+		op := ">"
+		if k.desc {
+			op = "<"
+		}
+		ands = append(ands, q.fields[k.field].Column+" "+op+" ?")
+		args = append(args, q.after[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// requestHash ties a page token to the filter, order and matches it was
+// issued for.
+func requestHash(req Request) string {
+	h := fnv.New64a()
+	h.Write([]byte(req.Filter + "\x00" + req.OrderBy))
+	for _, name := range slices.Sorted(maps.Keys(req.Match)) {
+		h.Write([]byte("\x00" + name + "=" + req.Match[name]))
+	}
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+func encodeToken(t pageToken) string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeToken(s string) (pageToken, error) {
+	var t pageToken
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, err
+	}
+	err = json.Unmarshal(b, &t)
+	return t, err
+}
@@ -0,0 +1,117 @@
+package listing
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+var table = Table[store.Agent]{
+	Fields: Fields[store.Agent]{
+		"id":         {Column: "id", Value: func(a store.Agent) string { return a.ID }},
+		"name":       {Column: "name", Value: func(a store.Agent) string { return a.Name }},
+		"model":      {Column: "model", Value: func(a store.Agent) string { return a.Model }},
+		"created_at": {Column: "created_at", Value: func(a store.Agent) string { return a.CreatedAt }},
+	},
+	Order: "created_at desc",
+}
+
+func names(agents []store.Agent) []string {
+	var out []string
+	for _, a := range agents {
+		out = append(out, a.Name)
+	}
+	return out
+}
+
+func TestFetch(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	for i, a := range []struct{ name, model, createdAt string }{
+		{"deploy prod", "a", "2025-01-03T00:00:00Z"},
+		{"backup", "b", "2025-01-01T00:00:00Z"},
+		{"deploy staging", "a", "2025-01-02T00:00:00Z"},
+		{"Deploy docs", "b", "2025-01-02T00:00:00Z"},
+	} {
+		if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: string(rune('a' + i)), Name: a.name, Model: a.model, EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: a.createdAt, UpdatedAt: a.createdAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	list := func(req Request) Page[store.Agent] {
+		t.Helper()
+		q, err := Parse(req, table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page, err := Fetch(ctx, q, queries.ListAgentsPage, queries.CountAgentsPage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return page
+	}
+
+	page := list(Request{Filter: `name:DEPLOY AND created_at>="2025-01-02T00:00:00Z"`, OrderBy: "created_at asc"})
+	// Ties are ordered by ID.
+	if got, want := names(page.Items), []string{"deploy staging", "Deploy docs", "deploy prod"}; !slices.Equal(got, want) {
+		t.Fatalf("items = %v, want %v", got, want)
+	}
+	if page.TotalSize != 3 || page.NextPageToken != "" {
+		t.Fatalf("total = %d, next token = %q", page.TotalSize, page.NextPageToken)
+	}
+
+	// Pages of the default order continue after ties.
+	req := Request{PageSize: 2}
+	var all []string
+	for {
+		page := list(req)
+		if page.TotalSize != 4 {
+			t.Fatalf("total = %d, want 4", page.TotalSize)
+		}
+		all = append(all, names(page.Items)...)
+		if page.NextPageToken == "" {
+			break
+		}
+		req.PageToken = page.NextPageToken
+	}
+	if want := []string{"deploy prod", "deploy staging", "Deploy docs", "backup"}; !slices.Equal(all, want) {
+		t.Fatalf("pages = %v, want %v", all, want)
+	}
+
+	req = Request{PageSize: 1, OrderBy: "model desc, name", Match: map[string]string{"model": "b"}}
+	page = list(req)
+	if got := names(page.Items); !slices.Equal(got, []string{"Deploy docs"}) || page.TotalSize != 2 {
+		t.Fatalf("first page of model b = %v (total %d)", got, page.TotalSize)
+	}
+	req.PageToken = page.NextPageToken
+	page = list(req)
+	if got := names(page.Items); !slices.Equal(got, []string{"backup"}) || page.NextPageToken != "" {
+		t.Fatalf("second page of model b = %v (next %q)", got, page.NextPageToken)
+	}
+
+	// A token can't be reused with a different order or match.
+	for _, other := range []Request{
+		{PageToken: req.PageToken, OrderBy: "name desc", Match: req.Match},
+		{PageToken: req.PageToken, OrderBy: req.OrderBy, Match: map[string]string{"model": "a"}},
+		{PageToken: "garbage"},
+	} {
+		if _, err := Parse(other, table); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("Parse(%+v) = %v, want ErrInvalidPageToken", other, err)
+		}
+	}
+
+	for _, bad := range []Request{{Filter: "size>3"}, {Filter: "name"}, {OrderBy: "name sideways"}, {OrderBy: "name,"}} {
+		if _, err := Parse(bad, table); err == nil {
+			t.Errorf("Parse(%+v) succeeded, want error", bad)
+		}
+	}
+}
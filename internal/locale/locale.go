@@ -0,0 +1,128 @@
+// Package locale handles the locales of agents: BCP 47 language tags that
+// set the language agents respond in and how dates in prompts and titles are
+// formatted.
+package locale
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// Parse validates a locale and returns it in canonical form, e.g. "de-DE"
+// for "de_de". The empty locale, for English, is returned as is.
+func Parse(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid locale %q: %w", s, err)
+	}
+	return tag.String(), nil
+}
+
+// LanguageName returns the English name of a locale's language, e.g.
+// "German (Germany)" for "de-DE", or "" for the empty locale.
+func LanguageName(locale string) string {
+	tag, err := language.Parse(locale)
+	if locale == "" || err != nil {
+		return ""
+	}
+	return display.English.Tags().Name(tag)
+}
+
+// Instructions returns instructions for an agent to respond in the language
+// of its locale, or "" for the empty locale.
+func Instructions(locale string) string {
+	name := LanguageName(locale)
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n\n## Language\nRespond in %s, unless the user writes in another language. Format dates and numbers as is customary for locale %s.", name, locale)
+}
+
+// dateNames are the names of weekdays, starting with Sunday, and months in a
+// language, and the format of a date with them.
+type dateNames struct {
+	weekdays [7]string
+	months   [12]string
+	// format has "{weekday}", "{day}", "{month}" and "{year}" placeholders.
+	format string
+}
+
+// dates holds the date names of languages by base language. Dates in other
+// languages are formatted as 2006-01-02.
+var dates = map[string]dateNames{
+	"en": {
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		format:   "{weekday}, {day} {month} {year}",
+	},
+	"de": {
+		weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		months:   [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		format:   "{weekday}, {day}. {month} {year}",
+	},
+	"nl": {
+		weekdays: [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		months:   [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		format:   "{weekday} {day} {month} {year}",
+	},
+	"fr": {
+		weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		months:   [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		format:   "{weekday} {day} {month} {year}",
+	},
+	"es": {
+		weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		months:   [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		format:   "{weekday}, {day} de {month} de {year}",
+	},
+	"it": {
+		weekdays: [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		months:   [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		format:   "{weekday} {day} {month} {year}",
+	},
+	"pt": {
+		weekdays: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		months:   [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		format:   "{weekday}, {day} de {month} de {year}",
+	},
+}
+
+// FormatDate formats the date of t in a locale, e.g. "Montag, 2. Januar 2006"
+// for "de-DE". The empty locale formats it in English.
+func FormatDate(locale string, t time.Time) string {
+	base := "en"
+	if locale != "" {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return t.Format(time.DateOnly)
+		}
+		b, _ := tag.Base()
+		base = b.String()
+	}
+	names, ok := dates[base]
+	if !ok {
+		return t.Format(time.DateOnly)
+	}
+	return strings.NewReplacer(
+		"{weekday}", names.weekdays[t.Weekday()],
+		"{day}", strconv.Itoa(t.Day()),
+		"{month}", names.months[t.Month()-1],
+		"{year}", strconv.Itoa(t.Year()),
+	).Replace(names.format)
+}
+
+// ExpandDate replaces "{date}" in s with the date of t in a locale.
+func ExpandDate(s, locale string, t time.Time) string {
+	if !strings.Contains(s, "{date}") {
+		return s
+	}
+	return strings.ReplaceAll(s, "{date}", FormatDate(locale, t))
+}
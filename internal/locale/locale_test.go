@@ -0,0 +1,53 @@
+package locale
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]string{
+		"":      "",
+		"de_de": "de-DE",
+		"nl":    "nl",
+		"EN-gb": "en-GB",
+	} {
+		if got, err := Parse(in); err != nil || got != want {
+			t.Errorf("Parse(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"xx-YY", "German", "de-"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	d := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	for locale, want := range map[string]string{
+		"":      "Monday, 3 March 2025",
+		"en-US": "Monday, 3 March 2025",
+		"de-DE": "Montag, 3. März 2025",
+		"nl":    "maandag 3 maart 2025",
+		"es":    "lunes, 3 de marzo de 2025",
+		"ja":    "2025-03-03",
+	} {
+		if got := FormatDate(locale, d); got != want {
+			t.Errorf("FormatDate(%q) = %q, want %q", locale, got, want)
+		}
+	}
+	if got := ExpandDate("Report of {date}", "fr", d); got != "Report of lundi 3 mars 2025" {
+		t.Errorf("ExpandDate = %q", got)
+	}
+}
+
+func TestInstructions(t *testing.T) {
+	if got := Instructions(""); got != "" {
+		t.Errorf("Instructions of empty locale = %q, want none", got)
+	}
+	if got := Instructions("de-DE"); !strings.Contains(got, "Respond in German (Germany), ") {
+		t.Errorf("Instructions(de-DE) = %q", got)
+	}
+}
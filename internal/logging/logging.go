@@ -0,0 +1,113 @@
+// Package logging sets up the structured logger: its format, its level, and
+// the levels of individual modules, e.g. LOG_LEVEL=info,scheduler=debug.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Log formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ModuleKey is the attribute that names the module of a logger. Loggers
+// created with Module log at the module's level, if it has one.
+const ModuleKey = "module"
+
+// Config is the format and levels of a logger.
+type Config struct {
+	Format string // FormatText or FormatJSON
+	Level  slog.Level
+	// Modules are the levels of modules that don't log at Level.
+	Modules map[string]slog.Level
+}
+
+// ParseLevels parses a comma-separated list of a default level and module
+// levels, e.g. "info,scheduler=debug,auth=warn". The default level may be
+// omitted, and is then info.
+func ParseLevels(s string) (slog.Level, map[string]slog.Level, error) {
+	level := slog.LevelInfo
+	modules := make(map[string]slog.Level)
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		module, levelText, ok := strings.Cut(part, "=")
+		if !ok {
+			module, levelText = "", part
+		}
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(levelText)); err != nil {
+			return 0, nil, fmt.Errorf("invalid level %q", levelText)
+		}
+		if module == "" {
+			level = l
+			continue
+		}
+		modules[strings.TrimSpace(module)] = l
+	}
+	return level, modules, nil
+}
+
+// New returns a logger writing to w in the configured format and levels.
+func New(w io.Writer, cfg Config) (*slog.Logger, error) {
+	// The base handler lets through the lowest level, and filtering is done
+	// per module by handler.
+	minLevel := cfg.Level
+	for _, l := range cfg.Modules {
+		minLevel = min(minLevel, l)
+	}
+	opts := &slog.HandlerOptions{Level: minLevel}
+
+	var base slog.Handler
+	switch cfg.Format {
+	case FormatText, "":
+		base = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		base = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+	return slog.New(&handler{Handler: base, level: cfg.Level, modules: cfg.Modules}), nil
+}
+
+// Module returns a logger for a module, whose records have its name as the
+// ModuleKey attribute.
+func Module(logger *slog.Logger, name string) *slog.Logger {
+	return logger.With(ModuleKey, name)
+}
+
+// handler filters records by the level of the module of its logger.
+type handler struct {
+	slog.Handler
+	level   slog.Level
+	modules map[string]slog.Level
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.Handler.Enabled(ctx, level)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	level := h.level
+	for _, a := range attrs {
+		if a.Key != ModuleKey {
+			continue
+		}
+		if l, ok := h.modules[a.Value.String()]; ok {
+			level = l
+		}
+	}
+	return &handler{Handler: h.Handler.WithAttrs(attrs), level: level, modules: h.modules}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{Handler: h.Handler.WithGroup(name), level: h.level, modules: h.modules}
+}
@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseLevels(t *testing.T) {
+	level, modules, err := ParseLevels("warn, scheduler=debug,auth=error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level != slog.LevelWarn {
+		t.Errorf("level = %v, want WARN", level)
+	}
+	if modules["scheduler"] != slog.LevelDebug || modules["auth"] != slog.LevelError {
+		t.Errorf("modules = %v", modules)
+	}
+
+	level, modules, err = ParseLevels("")
+	if err != nil || level != slog.LevelInfo || len(modules) != 0 {
+		t.Errorf("empty: level %v, modules %v, err %v", level, modules, err)
+	}
+
+	if _, _, err := ParseLevels("scheduler=loud"); err == nil {
+		t.Error("expected error for invalid level")
+	}
+}
+
+func TestModuleLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{
+		Format:  FormatJSON,
+		Level:   slog.LevelWarn,
+		Modules: map[string]slog.Level{"scheduler": slog.LevelDebug},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("dropped")
+	Module(logger, "auth").Info("dropped")
+	Module(logger, "scheduler").Debug("kept", "job", "cleanup")
+	logger.Warn("kept")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d records, want 2:\n%s", len(lines), buf.String())
+	}
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatalf("decode record: %v", err)
+	}
+	if rec["msg"] != "kept" || rec[ModuleKey] != "scheduler" || rec["job"] != "cleanup" {
+		t.Errorf("record = %v", rec)
+	}
+}
+
+func TestNewUnknownFormat(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, Config{Format: "xml"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
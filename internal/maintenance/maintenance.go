@@ -0,0 +1,81 @@
+// Package maintenance holds the instance's maintenance mode. While it's
+// enabled, the scheduler is paused and new chat messages, webhook runs and
+// notification replies are rejected, so active turns can finish before a
+// backup or upgrade.
+package maintenance
+
+import (
+	"sync"
+	"time"
+)
+
+// Status is the maintenance mode state.
+type Status struct {
+	Enabled bool
+	Reason  string
+	Since   time.Time
+}
+
+// Mode is the maintenance mode switch. A nil Mode is never enabled.
+type Mode struct {
+	mu     sync.Mutex
+	status Status
+}
+
+// Enable puts the instance in maintenance mode. Reason is shown to users
+// whose requests are rejected. Enabling again only updates the reason.
+func (m *Mode) Enable(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.status.Enabled {
+		m.status.Since = time.Now().UTC()
+	}
+	m.status.Enabled = true
+	m.status.Reason = reason
+}
+
+// Disable takes the instance out of maintenance mode.
+func (m *Mode) Disable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = Status{}
+}
+
+// Status returns the current state.
+func (m *Mode) Status() Status {
+	if m == nil {
+		return Status{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+// Enabled reports whether the instance is in maintenance mode.
+func (m *Mode) Enabled() bool {
+	return m.Status().Enabled
+}
+
+// Err returns an *Error if the instance is in maintenance mode, for rejecting
+// requests that would start new work.
+func (m *Mode) Err() error {
+	s := m.Status()
+	if !s.Enabled {
+		return nil
+	}
+	return &Error{Reason: s.Reason}
+}
+
+// Error is returned for work rejected during maintenance.
+type Error struct {
+	Reason string
+}
+
+func (e *Error) Error() string {
+	msg := "Blippy is down for maintenance, please try again later"
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
+}
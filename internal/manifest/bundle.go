@@ -0,0 +1,433 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/locale"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// BundleVersion is the agent bundle format written by ExportBundle.
+const BundleVersion = 1
+
+// Bundle is a single agent and its cron triggers, for moving agents between
+// instances and keeping them in version control. Unlike a manifest, a bundle
+// has no IDs: notification channels and filesystem roots are referred to by
+// name, and triggers are matched by name when importing.
+type Bundle struct {
+	Version  int             `json:"version"`
+	Agent    BundleAgent     `json:"agent"`
+	Triggers []BundleTrigger `json:"triggers,omitempty"`
+}
+
+// BundleAgent is the agent of a bundle. Hook configs are copied as is.
+type BundleAgent struct {
+	Name                    string                 `json:"name"`
+	Description             string                 `json:"description,omitempty"`
+	SystemPrompt            string                 `json:"system_prompt,omitempty"`
+	Model                   string                 `json:"model,omitempty"`
+	Persona                 *Persona               `json:"persona,omitempty"`
+	EnabledTools            []string               `json:"enabled_tools,omitempty"`
+	NotificationChannels    []string               `json:"notification_channels,omitempty"`
+	FilesystemRoots         []BundleFilesystemRoot `json:"filesystem_roots,omitempty"`
+	ForwardedHostEnvVars    []string               `json:"forwarded_host_env_vars,omitempty"`
+	Hooks                   json.RawMessage        `json:"hooks,omitempty"`
+	ApprovalRules           json.RawMessage        `json:"approval_rules,omitempty"`
+	MaxConcurrentRuns       int64                  `json:"max_concurrent_runs,omitempty"`
+	TitleGenerationDisabled bool                   `json:"title_generation_disabled,omitempty"`
+	TitlePrompt             string                 `json:"title_prompt,omitempty"`
+	TitleModel              string                 `json:"title_model,omitempty"`
+	Locale                  string                 `json:"locale,omitempty"`
+}
+
+// BundleFilesystemRoot is a filesystem root enabled for a bundle's agent.
+type BundleFilesystemRoot struct {
+	Name         string   `json:"name"`
+	EnabledTools []string `json:"enabled_tools,omitempty"`
+}
+
+// BundleTrigger is a cron trigger of a bundle's agent.
+type BundleTrigger struct {
+	Name               string            `json:"name"`
+	Prompt             string            `json:"prompt"`
+	CronExpr           string            `json:"cron_expr"`
+	Enabled            bool              `json:"enabled"`
+	Model              string            `json:"model,omitempty"`
+	ConversationTitle  string            `json:"conversation_title,omitempty"`
+	MaxDurationSeconds int64             `json:"max_duration_seconds,omitempty"`
+	Priority           int64             `json:"priority,omitempty"`
+	Vars               map[string]string `json:"vars,omitempty"`
+}
+
+// BundleResult is the outcome of importing a bundle.
+type BundleResult struct {
+	AgentID string
+	// Created reports whether a new agent was created.
+	Created bool
+	// Warnings lists notification channels and filesystem roots of the
+	// bundle that don't exist, and were left out of the agent.
+	Warnings []string
+}
+
+// storedFSRoot is the stored form of an agent's enabled filesystem root.
+type storedFSRoot struct {
+	RootID       string   `json:"root_id"`
+	EnabledTools []string `json:"enabled_tools"`
+}
+
+// ExportBundle reads an agent and its cron triggers as a bundle. Channels
+// and roots that no longer exist are left out. The cipher decrypts trigger
+// vars and may be nil if they're stored as plaintext.
+func ExportBundle(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher, agentID string) (*Bundle, error) {
+	a, err := queries.GetAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := queries.ListNotificationChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list notification channels: %w", err)
+	}
+	channelNames := make(map[string]string, len(channels))
+	for _, c := range channels {
+		channelNames[c.ID] = c.Name
+	}
+	roots, err := queries.ListFilesystemRoots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list filesystem roots: %w", err)
+	}
+	rootNames := make(map[string]string, len(roots))
+	for _, r := range roots {
+		rootNames[r.ID] = r.Name
+	}
+
+	exported := toAgent(a)
+	agent := BundleAgent{
+		Name:                    a.Name,
+		Description:             a.Description,
+		SystemPrompt:            a.SystemPrompt,
+		Model:                   a.Model,
+		Persona:                 exported.Persona,
+		ApprovalRules:           exported.ApprovalRules,
+		MaxConcurrentRuns:       a.MaxConcurrentRuns,
+		TitleGenerationDisabled: a.TitleGenerationDisabled != 0,
+		TitlePrompt:             a.TitlePrompt,
+		TitleModel:              a.TitleModel,
+		Locale:                  a.Locale,
+	}
+	_ = json.Unmarshal([]byte(a.EnabledTools), &agent.EnabledTools)
+	_ = json.Unmarshal([]byte(a.ForwardedHostEnvVars), &agent.ForwardedHostEnvVars)
+	if string(exported.Hooks) != "[]" {
+		agent.Hooks = exported.Hooks
+	}
+
+	var channelIDs []string
+	_ = json.Unmarshal([]byte(a.EnabledNotificationChannels), &channelIDs)
+	for _, id := range channelIDs {
+		if name, ok := channelNames[id]; ok {
+			agent.NotificationChannels = append(agent.NotificationChannels, name)
+		}
+	}
+	var fsRoots []storedFSRoot
+	_ = json.Unmarshal([]byte(a.EnabledFilesystemRoots), &fsRoots)
+	for _, r := range fsRoots {
+		if name, ok := rootNames[r.RootID]; ok {
+			agent.FilesystemRoots = append(agent.FilesystemRoots, BundleFilesystemRoot{Name: name, EnabledTools: r.EnabledTools})
+		}
+	}
+
+	b := &Bundle{Version: BundleVersion, Agent: agent}
+	triggers, err := queries.ListTriggersByAgent(ctx, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("list triggers: %w", err)
+	}
+	for _, t := range triggers {
+		if t.CronExpr.String == "" {
+			continue // One-shot triggers are pending runs, not configuration.
+		}
+		exported, err := toTrigger(cipher, t)
+		if err != nil {
+			return nil, err
+		}
+		b.Triggers = append(b.Triggers, BundleTrigger{
+			Name:               exported.Name,
+			Prompt:             exported.Prompt,
+			CronExpr:           exported.CronExpr,
+			Enabled:            exported.Enabled,
+			Model:              exported.Model,
+			ConversationTitle:  exported.ConversationTitle,
+			MaxDurationSeconds: exported.MaxDurationSeconds,
+			Priority:           exported.Priority,
+			Vars:               exported.Vars,
+		})
+	}
+	return b, nil
+}
+
+// Validate checks the version of a bundle, its approval rules and its
+// triggers.
+func (b *Bundle) Validate() error {
+	if b.Version != BundleVersion {
+		return fmt.Errorf("unsupported bundle version %d", b.Version)
+	}
+	if b.Agent.Name == "" {
+		return errors.New("agent name is required")
+	}
+	if b.Agent.MaxConcurrentRuns < 0 {
+		return errors.New("max_concurrent_runs must not be negative")
+	}
+	rules, err := tool.DecodeApprovalRules(string(b.Agent.ApprovalRules))
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("approval rule: %w", err)
+		}
+	}
+	if _, err := locale.Parse(b.Agent.Locale); err != nil {
+		return err
+	}
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	names := make(map[string]bool, len(b.Triggers))
+	for _, t := range b.Triggers {
+		if t.Name == "" || t.CronExpr == "" {
+			return errors.New("trigger name and cron_expr are required")
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate trigger %q", t.Name)
+		}
+		names[t.Name] = true
+		if _, err := parser.Parse(t.CronExpr); err != nil {
+			return fmt.Errorf("trigger %q: invalid cron expression: %w", t.Name, err)
+		}
+		if err := tool.ValidateRunVars(t.Vars); err != nil {
+			return fmt.Errorf("trigger %q: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// ImportBundle creates an agent from a bundle, or updates the agent with
+// agentID if it's set, in a single transaction. Triggers of the agent with
+// the name of a bundle trigger are updated, other bundle triggers are
+// created, and triggers that aren't in the bundle are left alone. Trigger
+// vars are encrypted with cipher, which may be nil.
+func ImportBundle(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher, b *Bundle, agentID string) (BundleResult, error) {
+	if err := b.Validate(); err != nil {
+		return BundleResult{}, err
+	}
+	a := b.Agent
+
+	res := BundleResult{AgentID: agentID, Created: agentID == ""}
+	err := queries.InTx(ctx, func(q *store.Queries) error {
+		res.Warnings = nil
+		now := time.Now().UTC().Format(time.RFC3339)
+
+		createdAt := now
+		if !res.Created {
+			existing, err := q.GetAgent(ctx, agentID)
+			if err != nil {
+				return err
+			}
+			createdAt = existing.CreatedAt
+		} else {
+			res.AgentID = uuid.NewString()
+		}
+
+		channelIDs, err := resolveChannels(ctx, q, a.NotificationChannels, &res)
+		if err != nil {
+			return err
+		}
+		fsRoots, err := resolveRoots(ctx, q, a.FilesystemRoots, &res)
+		if err != nil {
+			return err
+		}
+
+		var persona Persona
+		if a.Persona != nil {
+			persona = *a.Persona
+		}
+		loc, _ := locale.Parse(a.Locale) // checked by Validate
+		if err := q.UpsertAgent(ctx, store.UpsertAgentParams{
+			ID:                          res.AgentID,
+			Name:                        a.Name,
+			Description:                 a.Description,
+			SystemPrompt:                a.SystemPrompt,
+			EnabledTools:                marshalList(a.EnabledTools),
+			EnabledNotificationChannels: marshalList(channelIDs),
+			EnabledFilesystemRoots:      marshalList(fsRoots),
+			Model:                       a.Model,
+			ForwardedHostEnvVars:        marshalList(a.ForwardedHostEnvVars),
+			Hooks:                       compactJSON(a.Hooks, "[]"),
+			MaxConcurrentRuns:           a.MaxConcurrentRuns,
+			TitleGenerationDisabled:     boolToInt(a.TitleGenerationDisabled),
+			TitlePrompt:                 a.TitlePrompt,
+			TitleModel:                  a.TitleModel,
+			PersonaRole:                 persona.Role,
+			PersonaGoals:                persona.Goals,
+			PersonaConstraints:          persona.Constraints,
+			PersonaStyle:                persona.Style,
+			ApprovalRules:               compactJSON(a.ApprovalRules, "[]"),
+			Locale:                      loc,
+			CreatedAt:                   createdAt,
+			UpdatedAt:                   now,
+		}); err != nil {
+			return err
+		}
+
+		existing, err := q.ListTriggersByAgent(ctx, res.AgentID)
+		if err != nil {
+			return err
+		}
+		parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+		for _, t := range b.Triggers {
+			schedule, err := parser.Parse(t.CronExpr)
+			if err != nil {
+				return err
+			}
+			id, triggerCreatedAt := uuid.NewString(), now
+			for _, e := range existing {
+				if e.Name == t.Name && e.CronExpr.String != "" {
+					id, triggerCreatedAt = e.ID, e.CreatedAt
+					break
+				}
+			}
+			vars, err := tool.EncodeRunVars(cipher, t.Vars)
+			if err != nil {
+				return err
+			}
+			if err := q.UpsertTrigger(ctx, store.UpsertTriggerParams{
+				ID:                 id,
+				AgentID:            res.AgentID,
+				Name:               t.Name,
+				Prompt:             t.Prompt,
+				CronExpr:           store.NewNullString(t.CronExpr),
+				Enabled:            boolToInt(t.Enabled),
+				NextRunAt:          store.NewNullString(schedule.Next(time.Now()).UTC().Format(time.RFC3339)),
+				Model:              t.Model,
+				ConversationTitle:  t.ConversationTitle,
+				MaxDurationSeconds: t.MaxDurationSeconds,
+				Priority:           t.Priority,
+				Vars:               vars,
+				CreatedAt:          triggerCreatedAt,
+				UpdatedAt:          now,
+			}); err != nil {
+				return fmt.Errorf("trigger %q: %w", t.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return BundleResult{}, err
+	}
+	return res, nil
+}
+
+func resolveChannels(ctx context.Context, q *store.Queries, names []string, res *BundleResult) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	channels, err := q.ListNotificationChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(channels, func(c store.NotificationChannel) bool { return c.Name == name })
+		if i < 0 {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("notification channel %q doesn't exist", name))
+			continue
+		}
+		ids = append(ids, channels[i].ID)
+	}
+	return ids, nil
+}
+
+func resolveRoots(ctx context.Context, q *store.Queries, bundled []BundleFilesystemRoot, res *BundleResult) ([]storedFSRoot, error) {
+	if len(bundled) == 0 {
+		return nil, nil
+	}
+	roots, err := q.ListFilesystemRoots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored := make([]storedFSRoot, 0, len(bundled))
+	for _, b := range bundled {
+		i := slices.IndexFunc(roots, func(r store.FilesystemRoot) bool { return r.Name == b.Name })
+		if i < 0 {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("filesystem root %q doesn't exist", b.Name))
+			continue
+		}
+		stored = append(stored, storedFSRoot{RootID: roots[i].ID, EnabledTools: b.EnabledTools})
+	}
+	return stored, nil
+}
+
+// MarshalBundle encodes a bundle as indented JSON, or as YAML if format is
+// "yaml".
+func MarshalBundle(b *Bundle, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil || format != "yaml" {
+		return data, err
+	}
+	// Going through a node keeps the field order of the JSON encoding.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// UnmarshalBundle decodes a JSON or YAML bundle.
+func UnmarshalBundle(data []byte) (*Bundle, error) {
+	// JSON is valid YAML, so both are decoded as YAML and converted to JSON
+	// to honor the JSON field names and raw hook configs.
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	var b Bundle
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&b); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	return &b, nil
+}
+
+// clearStyle resets the flow and quoting styles of a node parsed from JSON,
+// so it's written as block YAML.
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+// marshalList encodes a list property for storage, with nil stored as an
+// empty array.
+func marshalList[T any](v []T) string {
+	if v == nil {
+		return "[]"
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,105 @@
+package manifest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TestBundle copies an agent to an instance where its channel has another
+// ID and its filesystem root doesn't exist, through YAML.
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	src := openQueries(t)
+
+	now := "2025-01-01T00:00:00Z"
+	if _, err := src.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+		ID: "chan-1", Name: "ops", Type: "ntfy", Config: `{"topic":"ops"}`, QuietHoursMode: "defer", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.CreateFilesystemRoot(ctx, store.CreateFilesystemRootParams{
+		ID: "root-1", Name: "docs", Path: "/srv/docs", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.CreateAgent(ctx, store.CreateAgentParams{
+		ID: "agent-1", Name: "Ops", SystemPrompt: "You run ops.\nBe brief.", EnabledTools: `["fetch"]`,
+		EnabledNotificationChannels: `["chan-1"]`, EnabledFilesystemRoots: `[{"root_id":"root-1","enabled_tools":["fs_view"]}]`,
+		ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []store.CreateTriggerParams{
+		{ID: "trigger-1", AgentID: "agent-1", Name: "daily", Prompt: "Report", Enabled: 1, CronExpr: store.NewNullString("0 9 * * *"), Vars: `{"team":"core"}`, CreatedAt: now, UpdatedAt: now},
+		{ID: "trigger-2", AgentID: "agent-1", Name: "once", Prompt: "Remind me", Enabled: 1, NextRunAt: store.NewNullString(now), CreatedAt: now, UpdatedAt: now},
+	} {
+		if _, err := src.CreateTrigger(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := ExportBundle(ctx, src, nil, "agent-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := MarshalBundle(b, "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"version: 1\n", "    - ops\n", "name: docs", "system_prompt: |-\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("bundle doesn't contain %q:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "once") || strings.Contains(string(data), "chan-1") {
+		t.Errorf("bundle contains one-shot trigger or channel ID:\n%s", data)
+	}
+
+	dst := openQueries(t)
+	if _, err := dst.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+		ID: "chan-2", Name: "ops", Type: "ntfy", Config: `{"topic":"ops"}`, QuietHoursMode: "defer", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	imported, err := UnmarshalBundle(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := ImportBundle(ctx, dst, nil, imported, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Created || len(res.Warnings) != 1 || !strings.Contains(res.Warnings[0], `"docs"`) {
+		t.Errorf("result = %+v", res)
+	}
+	a, err := dst.GetAgent(ctx, res.AgentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.SystemPrompt != "You run ops.\nBe brief." || a.EnabledNotificationChannels != `["chan-2"]` || a.EnabledFilesystemRoots != "[]" {
+		t.Errorf("agent = %+v", a)
+	}
+
+	// Importing into the agent again updates its trigger by name.
+	imported.Triggers[0].CronExpr = "0 10 * * *"
+	if _, err := ImportBundle(ctx, dst, nil, imported, res.AgentID); err != nil {
+		t.Fatal(err)
+	}
+	triggers, err := dst.ListTriggersByAgent(ctx, res.AgentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triggers) != 1 || triggers[0].CronExpr.String != "0 10 * * *" || triggers[0].Vars != `{"team":"core"}` {
+		t.Errorf("triggers = %+v", triggers)
+	}
+
+	if _, err := UnmarshalBundle([]byte(`{"version": 1, "agent": {"name": "Ops"}, "extra": true}`)); err == nil {
+		t.Error("bundle with unknown field was decoded")
+	}
+	if err := (&Bundle{Version: 2, Agent: BundleAgent{Name: "Ops"}}).Validate(); err == nil {
+		t.Error("bundle of unknown version is valid")
+	}
+}
@@ -0,0 +1,579 @@
+// Package manifest exports and imports the configuration of a blippy
+// instance (agents, cron triggers, notification channels and filesystem
+// roots) as a single JSON document.
+//
+// Entities are identified by ID, so importing a manifest is idempotent: new
+// entities are created, changed ones are updated and unchanged ones are left
+// alone. Credentials in channel configs are exported as redacted
+// placeholders; on import, placeholders keep the secret stored for the
+// channel. Runtime data (conversations, agent memory files, trigger runs,
+// one-shot triggers) is not part of a manifest.
+package manifest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/locale"
+	"github.com/dstotijn/blippy/internal/notification"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// FormatVersion is the manifest format written by Export.
+const FormatVersion = 1
+
+// Manifest is the exported configuration of an instance.
+type Manifest struct {
+	Version              int                   `json:"version"`
+	Agents               []Agent               `json:"agents"`
+	Triggers             []Trigger             `json:"triggers"`
+	NotificationChannels []NotificationChannel `json:"notification_channels"`
+	FilesystemRoots      []FilesystemRoot      `json:"filesystem_roots"`
+}
+
+// Agent is an exported agent. List properties are kept in their stored JSON
+// form.
+type Agent struct {
+	ID                          string          `json:"id"`
+	Name                        string          `json:"name"`
+	Description                 string          `json:"description,omitempty"`
+	SystemPrompt                string          `json:"system_prompt,omitempty"`
+	Model                       string          `json:"model,omitempty"`
+	EnabledTools                json.RawMessage `json:"enabled_tools"`
+	EnabledNotificationChannels json.RawMessage `json:"enabled_notification_channels"`
+	EnabledFilesystemRoots      json.RawMessage `json:"enabled_filesystem_roots"`
+	ForwardedHostEnvVars        json.RawMessage `json:"forwarded_host_env_vars"`
+	Hooks                       json.RawMessage `json:"hooks"`
+	MaxConcurrentRuns           int64           `json:"max_concurrent_runs,omitempty"`
+	TitleGenerationDisabled     bool            `json:"title_generation_disabled,omitempty"`
+	TitlePrompt                 string          `json:"title_prompt,omitempty"`
+	TitleModel                  string          `json:"title_model,omitempty"`
+	Persona                     *Persona        `json:"persona,omitempty"`
+	ApprovalRules               json.RawMessage `json:"approval_rules,omitempty"`
+	Locale                      string          `json:"locale,omitempty"`
+}
+
+// Persona holds the persona sections of an exported agent.
+type Persona struct {
+	Role        string `json:"role,omitempty"`
+	Goals       string `json:"goals,omitempty"`
+	Constraints string `json:"constraints,omitempty"`
+	Style       string `json:"style,omitempty"`
+}
+
+// Trigger is an exported cron trigger.
+type Trigger struct {
+	ID                string `json:"id"`
+	AgentID           string `json:"agent_id"`
+	Name              string `json:"name"`
+	Prompt            string `json:"prompt"`
+	CronExpr          string `json:"cron_expr"`
+	Enabled           bool   `json:"enabled"`
+	Model             string `json:"model,omitempty"`
+	ConversationTitle string `json:"conversation_title,omitempty"`
+	// MaxDurationSeconds limits how long runs may take, 0 for the default.
+	MaxDurationSeconds int64 `json:"max_duration_seconds,omitempty"`
+	// Priority orders runs waiting for the agent's concurrency limit, 0 for
+	// the default.
+	Priority int64 `json:"priority,omitempty"`
+	// Vars are the variables of the trigger's runs.
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// NotificationChannel is an exported notification channel. Secret values in
+// Config are replaced with tool.RedactedValue.
+type NotificationChannel struct {
+	ID                 string          `json:"id"`
+	Name               string          `json:"name"`
+	Type               string          `json:"type"`
+	Config             json.RawMessage `json:"config"`
+	Description        string          `json:"description,omitempty"`
+	JSONSchema         string          `json:"json_schema,omitempty"`
+	QuietHoursStart    string          `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd      string          `json:"quiet_hours_end,omitempty"`
+	QuietHoursTimezone string          `json:"quiet_hours_timezone,omitempty"`
+	QuietHoursMode     string          `json:"quiet_hours_mode,omitempty"`
+	MaxPerHour         int64           `json:"max_per_hour,omitempty"`
+	DigestSchedule     string          `json:"digest_schedule,omitempty"`
+}
+
+// FilesystemRoot is an exported filesystem root.
+type FilesystemRoot struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+}
+
+// Result summarizes an import.
+type Result struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	// Warnings lists channels created with redacted placeholders instead of
+	// secret values, which must be set before the channel can be used.
+	Warnings []string
+}
+
+// Export reads the instance configuration. The cipher decrypts channel
+// configs and trigger vars, and may be nil if they're stored as plaintext.
+func Export(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher) (*Manifest, error) {
+	m := &Manifest{
+		Version:              FormatVersion,
+		Agents:               []Agent{},
+		Triggers:             []Trigger{},
+		NotificationChannels: []NotificationChannel{},
+		FilesystemRoots:      []FilesystemRoot{},
+	}
+
+	agents, err := queries.ListAgents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list agents: %w", err)
+	}
+	for _, a := range agents {
+		m.Agents = append(m.Agents, toAgent(a))
+	}
+
+	triggers, err := queries.ListAllTriggers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list triggers: %w", err)
+	}
+	for _, t := range triggers {
+		if t.CronExpr.String == "" {
+			continue // One-shot triggers are pending runs, not configuration.
+		}
+		exported, err := toTrigger(cipher, t)
+		if err != nil {
+			return nil, err
+		}
+		m.Triggers = append(m.Triggers, exported)
+	}
+
+	channels, err := queries.ListNotificationChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list notification channels: %w", err)
+	}
+	for _, c := range channels {
+		if c.Config, err = cipher.Decrypt(c.Config); err != nil {
+			return nil, fmt.Errorf("decrypt config of channel %q: %w", c.Name, err)
+		}
+		m.NotificationChannels = append(m.NotificationChannels, toChannel(c))
+	}
+
+	roots, err := queries.ListFilesystemRoots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list filesystem roots: %w", err)
+	}
+	for _, r := range roots {
+		m.FilesystemRoots = append(m.FilesystemRoots, toRoot(r))
+	}
+
+	return m, nil
+}
+
+// Import creates or updates the entities in m in a single transaction.
+// Channel configs and trigger vars are encrypted with cipher, which may be
+// nil.
+func Import(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher, m *Manifest) (Result, error) {
+	if m.Version != FormatVersion {
+		return Result{}, fmt.Errorf("unsupported manifest version %d", m.Version)
+	}
+
+	var res Result
+	err := queries.InTx(ctx, func(q *store.Queries) error {
+		res = Result{}
+		now := time.Now().UTC().Format(time.RFC3339)
+
+		// Roots and channels first, so agents can refer to them.
+		for _, r := range m.FilesystemRoots {
+			if err := importRoot(ctx, q, r, now, &res); err != nil {
+				return fmt.Errorf("filesystem root %q: %w", r.Name, err)
+			}
+		}
+		for _, c := range m.NotificationChannels {
+			if err := importChannel(ctx, q, cipher, c, now, &res); err != nil {
+				return fmt.Errorf("notification channel %q: %w", c.Name, err)
+			}
+		}
+		for _, a := range m.Agents {
+			if err := importAgent(ctx, q, a, now, &res); err != nil {
+				return fmt.Errorf("agent %q: %w", a.Name, err)
+			}
+		}
+		for _, t := range m.Triggers {
+			if err := importTrigger(ctx, q, cipher, t, now, &res); err != nil {
+				return fmt.Errorf("trigger %q: %w", t.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
+
+func importRoot(ctx context.Context, q *store.Queries, r FilesystemRoot, now string, res *Result) error {
+	if r.ID == "" || r.Name == "" || r.Path == "" {
+		return errors.New("id, name and path are required")
+	}
+
+	existing, err := q.GetFilesystemRoot(ctx, r.ID)
+	found, err := exists(err)
+	if err != nil {
+		return err
+	}
+	if found && toRoot(existing) == r {
+		res.Unchanged++
+		return nil
+	}
+
+	if err := q.UpsertFilesystemRoot(ctx, store.UpsertFilesystemRootParams{
+		ID:          r.ID,
+		Name:        r.Name,
+		Path:        r.Path,
+		Description: r.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		return err
+	}
+	count(res, found)
+	return nil
+}
+
+func importChannel(ctx context.Context, q *store.Queries, cipher *encryption.Cipher, c NotificationChannel, now string, res *Result) error {
+	if c.ID == "" || c.Name == "" || c.Type == "" {
+		return errors.New("id, name and type are required")
+	}
+
+	if c.QuietHoursMode == "" {
+		c.QuietHoursMode = notification.QuietHoursModeDefer
+	}
+
+	existing, err := q.GetNotificationChannel(ctx, c.ID)
+	found, err := exists(err)
+	if err != nil {
+		return err
+	}
+
+	config := compactJSON(c.Config, "{}")
+	if found {
+		if existing.Config, err = cipher.Decrypt(existing.Config); err != nil {
+			return fmt.Errorf("decrypt existing config: %w", err)
+		}
+		config = notification.RestoreSecrets(config, existing.Config)
+
+		// Compare unredacted configs, so changed secrets are detected.
+		have, want := toChannel(existing), c
+		have.Config, want.Config = json.RawMessage(existing.Config), json.RawMessage(config)
+		if equalJSON(have, want) {
+			res.Unchanged++
+			return nil
+		}
+	}
+	if strings.Contains(config, tool.RedactedValue) {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("notification channel %q has redacted secrets; set them before use", c.Name))
+	}
+	if config, err = cipher.Encrypt(config); err != nil {
+		return fmt.Errorf("encrypt config: %w", err)
+	}
+
+	if err := q.UpsertNotificationChannel(ctx, store.UpsertNotificationChannelParams{
+		ID:                 c.ID,
+		Name:               c.Name,
+		Type:               c.Type,
+		Config:             config,
+		Description:        c.Description,
+		JsonSchema:         c.JSONSchema,
+		QuietHoursStart:    c.QuietHoursStart,
+		QuietHoursEnd:      c.QuietHoursEnd,
+		QuietHoursTimezone: c.QuietHoursTimezone,
+		QuietHoursMode:     c.QuietHoursMode,
+		MaxPerHour:         c.MaxPerHour,
+		DigestSchedule:     c.DigestSchedule,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		return err
+	}
+	count(res, found)
+	return nil
+}
+
+func importAgent(ctx context.Context, q *store.Queries, a Agent, now string, res *Result) error {
+	if a.ID == "" || a.Name == "" {
+		return errors.New("id and name are required")
+	}
+	rules, err := tool.DecodeApprovalRules(string(a.ApprovalRules))
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("approval rule: %w", err)
+		}
+	}
+	loc, err := locale.Parse(a.Locale)
+	if err != nil {
+		return err
+	}
+
+	existing, err := q.GetAgent(ctx, a.ID)
+	found, err := exists(err)
+	if err != nil {
+		return err
+	}
+	if found && equalJSON(toAgent(existing), a) {
+		res.Unchanged++
+		return nil
+	}
+
+	var persona Persona
+	if a.Persona != nil {
+		persona = *a.Persona
+	}
+	if err := q.UpsertAgent(ctx, store.UpsertAgentParams{
+		ID:                          a.ID,
+		Name:                        a.Name,
+		Description:                 a.Description,
+		SystemPrompt:                a.SystemPrompt,
+		EnabledTools:                compactJSON(a.EnabledTools, "[]"),
+		EnabledNotificationChannels: compactJSON(a.EnabledNotificationChannels, "[]"),
+		EnabledFilesystemRoots:      compactJSON(a.EnabledFilesystemRoots, "[]"),
+		Model:                       a.Model,
+		ForwardedHostEnvVars:        compactJSON(a.ForwardedHostEnvVars, "[]"),
+		Hooks:                       compactJSON(a.Hooks, "[]"),
+		MaxConcurrentRuns:           a.MaxConcurrentRuns,
+		TitleGenerationDisabled:     boolToInt(a.TitleGenerationDisabled),
+		TitlePrompt:                 a.TitlePrompt,
+		TitleModel:                  a.TitleModel,
+		PersonaRole:                 persona.Role,
+		PersonaGoals:                persona.Goals,
+		PersonaConstraints:          persona.Constraints,
+		PersonaStyle:                persona.Style,
+		ApprovalRules:               compactJSON(a.ApprovalRules, "[]"),
+		Locale:                      loc,
+		CreatedAt:                   now,
+		UpdatedAt:                   now,
+	}); err != nil {
+		return err
+	}
+	count(res, found)
+	return nil
+}
+
+func importTrigger(ctx context.Context, q *store.Queries, cipher *encryption.Cipher, t Trigger, now string, res *Result) error {
+	if t.ID == "" || t.AgentID == "" || t.Name == "" || t.CronExpr == "" {
+		return errors.New("id, agent_id, name and cron_expr are required")
+	}
+
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, err := parser.Parse(t.CronExpr)
+	if err != nil {
+		return fmt.Errorf("invalid cron expression: %w", err)
+	}
+	if err := tool.ValidateRunVars(t.Vars); err != nil {
+		return err
+	}
+
+	existing, err := q.GetTrigger(ctx, t.ID)
+	found, err := exists(err)
+	if err != nil {
+		return err
+	}
+	if found {
+		current, err := toTrigger(cipher, existing)
+		if err != nil {
+			return err
+		}
+		if equalJSON(current, t) {
+			res.Unchanged++
+			return nil
+		}
+	}
+
+	vars, err := tool.EncodeRunVars(cipher, t.Vars)
+	if err != nil {
+		return err
+	}
+
+	var enabled int64
+	if t.Enabled {
+		enabled = 1
+	}
+	if err := q.UpsertTrigger(ctx, store.UpsertTriggerParams{
+		ID:                 t.ID,
+		AgentID:            t.AgentID,
+		Name:               t.Name,
+		Prompt:             t.Prompt,
+		CronExpr:           store.NewNullString(t.CronExpr),
+		Enabled:            enabled,
+		NextRunAt:          store.NewNullString(schedule.Next(time.Now()).UTC().Format(time.RFC3339)),
+		Model:              t.Model,
+		ConversationTitle:  t.ConversationTitle,
+		MaxDurationSeconds: t.MaxDurationSeconds,
+		Priority:           t.Priority,
+		Vars:               vars,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		return err
+	}
+	count(res, found)
+	return nil
+}
+
+func toAgent(a store.Agent) Agent {
+	// Agents without a persona are exported without one.
+	var persona *Persona
+	p := Persona{
+		Role:        a.PersonaRole,
+		Goals:       a.PersonaGoals,
+		Constraints: a.PersonaConstraints,
+		Style:       a.PersonaStyle,
+	}
+	if p != (Persona{}) {
+		persona = &p
+	}
+	return Agent{
+		ID:                          a.ID,
+		Name:                        a.Name,
+		Description:                 a.Description,
+		SystemPrompt:                a.SystemPrompt,
+		Model:                       a.Model,
+		EnabledTools:                json.RawMessage(compactJSON([]byte(a.EnabledTools), "[]")),
+		EnabledNotificationChannels: json.RawMessage(compactJSON([]byte(a.EnabledNotificationChannels), "[]")),
+		EnabledFilesystemRoots:      json.RawMessage(compactJSON([]byte(a.EnabledFilesystemRoots), "[]")),
+		ForwardedHostEnvVars:        json.RawMessage(compactJSON([]byte(a.ForwardedHostEnvVars), "[]")),
+		Hooks:                       json.RawMessage(compactJSON([]byte(a.Hooks), "[]")),
+		MaxConcurrentRuns:           a.MaxConcurrentRuns,
+		TitleGenerationDisabled:     a.TitleGenerationDisabled != 0,
+		TitlePrompt:                 a.TitlePrompt,
+		TitleModel:                  a.TitleModel,
+		Persona:                     persona,
+		ApprovalRules:               approvalRules(a.ApprovalRules),
+		Locale:                      a.Locale,
+	}
+}
+
+// toTrigger converts a stored trigger, decrypting its vars with cipher.
+func toTrigger(cipher *encryption.Cipher, t store.Trigger) (Trigger, error) {
+	vars, err := tool.DecodeRunVars(cipher, t.Vars)
+	if err != nil {
+		return Trigger{}, fmt.Errorf("trigger %q: %w", t.Name, err)
+	}
+	if len(vars) == 0 {
+		vars = nil
+	}
+	return Trigger{
+		ID:                 t.ID,
+		AgentID:            t.AgentID,
+		Name:               t.Name,
+		Prompt:             t.Prompt,
+		CronExpr:           t.CronExpr.String,
+		Enabled:            t.Enabled != 0,
+		Model:              t.Model,
+		ConversationTitle:  t.ConversationTitle,
+		MaxDurationSeconds: t.MaxDurationSeconds,
+		Priority:           t.Priority,
+		Vars:               vars,
+	}, nil
+}
+
+func toChannel(c store.NotificationChannel) NotificationChannel {
+	return NotificationChannel{
+		ID:                 c.ID,
+		Name:               c.Name,
+		Type:               c.Type,
+		Config:             json.RawMessage(compactJSON([]byte(notification.RedactConfig(c.Config)), "{}")),
+		Description:        c.Description,
+		JSONSchema:         c.JsonSchema,
+		QuietHoursStart:    c.QuietHoursStart,
+		QuietHoursEnd:      c.QuietHoursEnd,
+		QuietHoursTimezone: c.QuietHoursTimezone,
+		QuietHoursMode:     c.QuietHoursMode,
+		MaxPerHour:         c.MaxPerHour,
+		DigestSchedule:     c.DigestSchedule,
+	}
+}
+
+func toRoot(r store.FilesystemRoot) FilesystemRoot {
+	return FilesystemRoot{
+		ID:          r.ID,
+		Name:        r.Name,
+		Path:        r.Path,
+		Description: r.Description,
+	}
+}
+
+// exists reports whether a Get query found a row.
+func exists(err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func count(res *Result, updated bool) {
+	if updated {
+		res.Updated++
+	} else {
+		res.Created++
+	}
+}
+
+// compactJSON returns b without insignificant whitespace, or def if b is
+// empty or invalid.
+// approvalRules returns the stored approval rules of an agent, or nil if it
+// has none, so they're left out of the manifest.
+func approvalRules(s string) json.RawMessage {
+	if rules, _ := tool.DecodeApprovalRules(s); len(rules) == 0 {
+		return nil
+	}
+	return json.RawMessage(compactJSON([]byte(s), "[]"))
+}
+
+func compactJSON(b []byte, def string) string {
+	var v any
+	if len(b) == 0 || json.Unmarshal(b, &v) != nil {
+		return def
+	}
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+// equalJSON compares two values by their normalized JSON encoding, so
+// embedded raw JSON differing only in whitespace or key order is equal.
+func equalJSON(a, b any) bool {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return compactJSON(ab, "a") == compactJSON(bb, "b")
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func openQueries(t *testing.T) *store.Queries {
+	t.Helper()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return store.New(db)
+}
+
+// TestExportImport copies the configuration between instances and checks
+// that importing the same manifest again changes nothing.
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	src := openQueries(t)
+
+	now := "2025-01-01T00:00:00Z"
+	if _, err := src.CreateAgent(ctx, store.CreateAgentParams{
+		ID: "agent-1", Name: "Ops", EnabledTools: `["fetch"]`, EnabledNotificationChannels: `["chan-1"]`,
+		EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+		ID: "chan-1", Name: "sms", Type: "sms", QuietHoursMode: "defer", CreatedAt: now, UpdatedAt: now,
+		Config: `{"account_sid":"AC1","auth_token":"super-secret-token","from":"+1","to":["+2"]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.CreateTrigger(ctx, store.CreateTriggerParams{
+		ID: "trigger-1", AgentID: "agent-1", Name: "daily", Prompt: "Report", Enabled: 1,
+		CronExpr: store.NewNullString("0 9 * * *"), CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Export(ctx, src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(m)
+	if strings.Contains(string(b), "super-secret-token") {
+		t.Fatal("export contains secret value")
+	}
+
+	dst := openQueries(t)
+	res, err := Import(ctx, dst, nil, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Created != 3 || len(res.Warnings) != 1 {
+		t.Fatalf("first import = %+v, want 3 created and 1 warning", res)
+	}
+
+	res, err = Import(ctx, dst, nil, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Unchanged != 3 || res.Created+res.Updated != 0 {
+		t.Fatalf("second import = %+v, want 3 unchanged", res)
+	}
+
+	// Re-importing into the source keeps the stored secret.
+	if _, err := Import(ctx, src, nil, m); err != nil {
+		t.Fatal(err)
+	}
+	c, err := src.GetNotificationChannel(ctx, "chan-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(c.Config, "super-secret-token") || strings.Contains(c.Config, tool.RedactedValue) {
+		t.Fatalf("config after import = %s, want stored secret", c.Config)
+	}
+}
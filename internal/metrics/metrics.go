@@ -0,0 +1,95 @@
+// Package metrics serves metrics in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+)
+
+// Metric types.
+const (
+	TypeCounter = "counter"
+	TypeGauge   = "gauge"
+)
+
+// Metric is a metric with one or more samples.
+type Metric struct {
+	Name    string
+	Help    string
+	Type    string // TypeCounter or TypeGauge
+	Samples []Sample
+}
+
+// Sample is a value of a metric, optionally with labels.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Collector returns the current values of metrics.
+type Collector func() []Metric
+
+// Handler returns a handler that serves the metrics of collectors.
+func Handler(collectors ...Collector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		for _, collect := range collectors {
+			for _, m := range collect() {
+				write(bw, m)
+			}
+		}
+		bw.Flush()
+	})
+}
+
+// write writes a metric in the text exposition format.
+func write(w *bufio.Writer, m Metric) {
+	fmt.Fprintf(w, "# HELP %s %s\n", m.Name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(m.Help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", m.Name, m.Type)
+	for _, s := range m.Samples {
+		w.WriteString(m.Name)
+		if len(s.Labels) > 0 {
+			labels := make([]string, 0, len(s.Labels))
+			for _, name := range slices.Sorted(maps.Keys(s.Labels)) {
+				labels = append(labels, name+"="+strconv.Quote(s.Labels[name]))
+			}
+			w.WriteString("{" + strings.Join(labels, ",") + "}")
+		}
+		w.WriteString(" " + strconv.FormatFloat(s.Value, 'g', -1, 64) + "\n")
+	}
+}
+
+// Broker returns a Collector of the broker's Stats.
+func Broker(b *pubsub.Broker) Collector {
+	return func() []Metric {
+		stats := b.Stats()
+		var buffered int
+		for _, sub := range stats.Subscriptions {
+			buffered += sub.Buffered
+		}
+		return []Metric{
+			gauge("blippy_broker_subscriptions", "Number of event subscriptions, e.g. open chat views.", float64(len(stats.Subscriptions))),
+			gauge("blippy_broker_buffered_events", "Number of events waiting to be received by subscribers.", float64(buffered)),
+			gauge("blippy_broker_busy_conversations", "Number of conversations with an active agent turn.", float64(len(stats.BusyConversations))),
+			counter("blippy_broker_events_published_total", "Number of events published on this server.", float64(stats.PublishedEvents)),
+			counter("blippy_broker_events_relayed_total", "Number of events received from other replicas.", float64(stats.RelayedEvents)),
+			counter("blippy_broker_events_dropped_total", "Number of events dropped because subscribers didn't keep up.", float64(stats.DroppedEvents)),
+		}
+	}
+}
+
+func gauge(name, help string, value float64) Metric {
+	return Metric{Name: name, Help: help, Type: TypeGauge, Samples: []Sample{{Value: value}}}
+}
+
+func counter(name, help string, value float64) Metric {
+	return Metric{Name: name, Help: help, Type: TypeCounter, Samples: []Sample{{Value: value}}}
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/pubsub"
+)
+
+func TestHandler(t *testing.T) {
+	broker := pubsub.New(nil, slog.Default())
+	sub := broker.Subscribe("conv-1")
+	defer broker.Unsubscribe(sub)
+	broker.Publish("conv-1", &pubsub.Event_TurnStarted{TurnStarted: &pubsub.TurnStarted{}})
+
+	labeled := func() []Metric {
+		return []Metric{{
+			Name: "test_info", Help: "A test\nmetric.", Type: TypeGauge,
+			Samples: []Sample{{Labels: map[string]string{"version": `1."0"`, "arch": "amd64"}, Value: 1}},
+		}}
+	}
+
+	rec := httptest.NewRecorder()
+	Handler(Broker(broker), labeled).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, want := range []string{
+		"# TYPE blippy_broker_subscriptions gauge\nblippy_broker_subscriptions 1\n",
+		"blippy_broker_buffered_events 1\n",
+		"# TYPE blippy_broker_events_published_total counter\nblippy_broker_events_published_total 1\n",
+		"blippy_broker_events_dropped_total 0\n",
+		"# HELP test_info A test\\nmetric.\n",
+		`test_info{arch="amd64",version="1.\"0\""} 1` + "\n",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics missing %q:\n%s", want, body)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("content type = %q, want text/plain", ct)
+	}
+}
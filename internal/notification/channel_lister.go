@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/dstotijn/blippy/internal/encryption"
 	"github.com/dstotijn/blippy/internal/store"
 	"github.com/dstotijn/blippy/internal/tool"
 )
@@ -12,11 +13,13 @@ import (
 // Implements tool.NotificationChannelLister.
 type ChannelLister struct {
 	queries *store.Queries
+	cipher  *encryption.Cipher
 }
 
-// NewChannelLister creates a new ChannelLister.
-func NewChannelLister(queries *store.Queries) *ChannelLister {
-	return &ChannelLister{queries: queries}
+// NewChannelLister creates a new ChannelLister. The cipher decrypts channel
+// configs and may be nil if they're stored as plaintext.
+func NewChannelLister(queries *store.Queries, cipher *encryption.Cipher) *ChannelLister {
+	return &ChannelLister{queries: queries, cipher: cipher}
 }
 
 // ListNotificationChannelsByIDs returns channels matching the given IDs.
@@ -35,16 +38,13 @@ func (l *ChannelLister) ListNotificationChannelsByIDs(ctx context.Context, ids [
 
 	var result []tool.NotificationChannel
 	for _, c := range allChannels {
-		if idSet[c.ID] {
-			result = append(result, tool.NotificationChannel{
-				ID:          c.ID,
-				Name:        c.Name,
-				Description: c.Description,
-				JSONSchema:  c.JsonSchema,
-				Type:        c.Type,
-				Config:      c.Config,
-			})
+		if !idSet[c.ID] {
+			continue
 		}
+		if c, err = decryptChannel(l.cipher, c); err != nil {
+			return nil, err
+		}
+		result = append(result, toToolChannel(c))
 	}
 	return result, nil
 }
@@ -55,12 +55,38 @@ func (l *ChannelLister) GetNotificationChannelByName(ctx context.Context, name s
 	if err != nil {
 		return nil, fmt.Errorf("channel not found: %w", err)
 	}
-	return &tool.NotificationChannel{
-		ID:          channel.ID,
-		Name:        channel.Name,
-		Description: channel.Description,
-		JSONSchema:  channel.JsonSchema,
-		Type:        channel.Type,
-		Config:      channel.Config,
-	}, nil
+	if channel, err = decryptChannel(l.cipher, channel); err != nil {
+		return nil, err
+	}
+	c := toToolChannel(channel)
+	return &c, nil
+}
+
+// ListNotificationSecrets returns the credentials configured across all
+// channels, for redaction from tool results.
+func (l *ChannelLister) ListNotificationSecrets(ctx context.Context) ([]string, error) {
+	channels, err := l.queries.ListNotificationChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list channels: %w", err)
+	}
+
+	var secrets []string
+	for _, c := range channels {
+		if c, err = decryptChannel(l.cipher, c); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, tool.NotificationConfigSecrets(c.Config)...)
+	}
+	return secrets, nil
+}
+
+func toToolChannel(c store.NotificationChannel) tool.NotificationChannel {
+	return tool.NotificationChannel{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		JSONSchema:  c.JsonSchema,
+		Type:        c.Type,
+		Config:      c.Config,
+	}
 }
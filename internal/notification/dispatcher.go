@@ -0,0 +1,518 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/webpush"
+)
+
+// digestTextKeys are payload properties that are merged when combining
+// queued notifications into a digest, in order of preference.
+var digestTextKeys = []string{"text", "message", "body", "content"}
+
+// deliveryRetention is how long delivery records are kept. Besides hourly
+// throttling, they are used to route replies back to conversations.
+const deliveryRetention = 30 * 24 * time.Hour
+
+// Queued notifications that fail to send are retried with exponential
+// backoff, starting at flushRetryDelay and capped at maxFlushRetryDelay.
+// After maxFlushAttempts failed attempts, they're dropped.
+const (
+	maxFlushAttempts   = 6
+	flushRetryDelay    = time.Minute
+	maxFlushRetryDelay = time.Hour
+)
+
+// Dispatcher applies per-channel quiet hours, hourly rate limits and digest
+// schedules before sending notifications. Notifications that can't be sent
+// right away are queued and later delivered as a single digest by FlushQueue.
+// Implements tool.NotificationDispatcher.
+type Dispatcher struct {
+	queries *store.Queries
+	cipher  *encryption.Cipher
+	webPush *webpush.Sender
+	logger  *slog.Logger
+}
+
+// NewDispatcher creates a new Dispatcher. The web push sender is optional;
+// without it, web_push channels fail to deliver.
+func NewDispatcher(queries *store.Queries, cipher *encryption.Cipher, webPush *webpush.Sender, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{queries: queries, cipher: cipher, webPush: webPush, logger: logger}
+}
+
+// DispatchNotification sends, defers, queues or drops a notification based on
+// the channel's delivery settings. Notifications to group channels are routed
+// to one of their channels.
+func (d *Dispatcher) DispatchNotification(ctx context.Context, channel tool.NotificationChannel, payload json.RawMessage) (string, error) {
+	c, err := d.queries.GetNotificationChannel(ctx, channel.ID)
+	if err != nil {
+		return "", fmt.Errorf("get channel: %w", err)
+	}
+	if c, err = decryptChannel(d.cipher, c); err != nil {
+		return "", err
+	}
+
+	if c.Type == tool.GroupChannelType {
+		return d.route(ctx, c, payload)
+	}
+	msg, _, err := d.dispatch(ctx, c, payload)
+	return msg, err
+}
+
+// route tries the channels a group routes a notification to in order, until
+// one of them accepts it. Groups aren't nested, and their own delivery
+// settings don't apply.
+func (d *Dispatcher) route(ctx context.Context, group store.NotificationChannel, payload json.RawMessage) (string, error) {
+	cfg, err := tool.ParseGroupConfig(group.Config)
+	if err != nil {
+		return fmt.Sprintf("Notification not sent: %s", err.Error()), nil
+	}
+	names, severity := cfg.ChannelsFor(payload)
+	if len(names) == 0 {
+		return fmt.Sprintf("Notification not sent: the %s group has no channels for severity %q", group.Name, severity), nil
+	}
+
+	var failures []string
+	for _, name := range names {
+		c, err := d.queries.GetNotificationChannelByName(ctx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			failures = append(failures, fmt.Sprintf("%s: channel not found", name))
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("get channel: %w", err)
+		}
+		if c.Type == tool.GroupChannelType {
+			failures = append(failures, fmt.Sprintf("%s: groups can't be nested", name))
+			continue
+		}
+		if c, err = decryptChannel(d.cipher, c); err != nil {
+			return "", err
+		}
+
+		msg, ok, err := d.dispatch(ctx, c, payload)
+		if err != nil {
+			d.logger.Error("failed to dispatch routed notification", "group_id", group.ID, "channel_id", c.ID, "error", err)
+			msg = err.Error()
+		}
+		if !ok || err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", name, msg))
+			continue
+		}
+
+		msg = fmt.Sprintf("Routed to %s: %s", name, msg)
+		if len(failures) > 0 {
+			msg += "\nChannels tried before:\n- " + strings.Join(failures, "\n- ")
+		}
+		return msg, nil
+	}
+
+	return "Notification not sent: no channel of the " + group.Name + " group accepted it:\n- " + strings.Join(failures, "\n- "), nil
+}
+
+// dispatch applies a channel's delivery settings to a notification. It
+// reports whether the notification was accepted, i.e. sent, deferred or
+// queued rather than dropped or failed.
+func (d *Dispatcher) dispatch(ctx context.Context, c store.NotificationChannel, payload json.RawMessage) (string, bool, error) {
+	now := time.Now().UTC()
+	convID := tool.GetConversationID(ctx)
+
+	quiet, err := parseQuietHours(c.QuietHoursStart, c.QuietHoursEnd, c.QuietHoursTimezone, c.QuietHoursMode)
+	if err != nil {
+		d.logger.Warn("ignoring invalid quiet hours", "channel_id", c.ID, "error", err)
+		quiet = nil
+	}
+
+	inQuietHours := quiet != nil && quiet.contains(now)
+	if inQuietHours && quiet.mode == QuietHoursModeDrop {
+		return "Notification dropped: channel is in quiet hours", false, nil
+	}
+
+	if c.DigestSchedule != "" {
+		next, err := nextDigest(c.DigestSchedule, now)
+		if err != nil {
+			d.logger.Warn("ignoring invalid digest schedule", "channel_id", c.ID, "error", err)
+		} else {
+			if err := d.enqueue(ctx, c.ID, convID, payload, next); err != nil {
+				return "", false, err
+			}
+			return fmt.Sprintf("Notification queued for the next digest at %s", next.Format(time.RFC3339)), true, nil
+		}
+	}
+
+	if inQuietHours {
+		deliverAfter := quiet.endAfter(now)
+		if err := d.enqueue(ctx, c.ID, convID, payload, deliverAfter); err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("Notification deferred until %s (channel is in quiet hours)", deliverAfter.Format(time.RFC3339)), true, nil
+	}
+
+	throttled, err := d.throttled(ctx, c, now)
+	if err != nil {
+		return "", false, err
+	}
+	if throttled {
+		if err := d.enqueue(ctx, c.ID, convID, payload, now); err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("Notification queued: channel has reached its limit of %d per hour, it will be included in a digest", c.MaxPerHour), true, nil
+	}
+
+	if err := d.send(ctx, c, convID, payload, now); err != nil {
+		return fmt.Sprintf("Failed to send: %s", err.Error()), false, nil
+	}
+
+	return "Notification sent successfully", true, nil
+}
+
+// FlushQueue delivers queued notifications whose channels are outside quiet
+// hours and below their hourly limit. Notifications queued for the same
+// channel are combined into a single digest message. Digests that fail to send
+// are retried with backoff, until their notifications are dropped.
+func (d *Dispatcher) FlushQueue(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	if err := d.queries.DeleteNotificationDeliveriesBefore(ctx, now.Add(-deliveryRetention).Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("prune deliveries: %w", err)
+	}
+
+	queued, err := d.queries.ListDueQueuedNotifications(ctx, now.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("list queued notifications: %w", err)
+	}
+
+	// Group by channel, preserving queue order.
+	var channelIDs []string
+	byChannel := make(map[string][]store.QueuedNotification)
+	for _, n := range queued {
+		if _, ok := byChannel[n.ChannelID]; !ok {
+			channelIDs = append(channelIDs, n.ChannelID)
+		}
+		byChannel[n.ChannelID] = append(byChannel[n.ChannelID], n)
+	}
+
+	for _, channelID := range channelIDs {
+		if err := d.flushChannel(ctx, channelID, byChannel[channelID], now); err != nil {
+			d.logger.Error("failed to flush queued notifications", "channel_id", channelID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) flushChannel(ctx context.Context, channelID string, queued []store.QueuedNotification, now time.Time) error {
+	c, err := d.queries.GetNotificationChannel(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+	if c, err = decryptChannel(d.cipher, c); err != nil {
+		return err
+	}
+
+	quiet, err := parseQuietHours(c.QuietHoursStart, c.QuietHoursEnd, c.QuietHoursTimezone, c.QuietHoursMode)
+	if err == nil && quiet != nil && quiet.contains(now) {
+		return nil
+	}
+
+	throttled, err := d.throttled(ctx, c, now)
+	if err != nil {
+		return err
+	}
+	if throttled {
+		return nil
+	}
+
+	// Replies to a digest are routed to the conversation of the most recent
+	// notification in it.
+	var convID string
+	payloads := make([]json.RawMessage, len(queued))
+	for i, n := range queued {
+		payloads[i] = json.RawMessage(n.Payload)
+		if n.ConversationID.Valid {
+			convID = n.ConversationID.String
+		}
+	}
+
+	if err := d.send(ctx, c, convID, digestPayload(payloads), now); err != nil {
+		if err := d.retry(ctx, c.ID, queued, now); err != nil {
+			return err
+		}
+		return fmt.Errorf("send digest: %w", err)
+	}
+
+	return d.queries.InTx(ctx, func(q *store.Queries) error {
+		for _, n := range queued {
+			if err := q.DeleteQueuedNotification(ctx, n.ID); err != nil {
+				return fmt.Errorf("delete queued notification: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// retry reschedules queued notifications after a failed attempt to send them,
+// and drops the ones that reached maxFlushAttempts.
+func (d *Dispatcher) retry(ctx context.Context, channelID string, queued []store.QueuedNotification, now time.Time) error {
+	var dropped []store.QueuedNotification
+	err := d.queries.InTx(ctx, func(q *store.Queries) error {
+		for _, n := range queued {
+			attempts := n.Attempts + 1
+			if attempts >= maxFlushAttempts {
+				if err := q.DeleteQueuedNotification(ctx, n.ID); err != nil {
+					return fmt.Errorf("delete queued notification: %w", err)
+				}
+				dropped = append(dropped, n)
+				continue
+			}
+			if err := q.RetryQueuedNotification(ctx, store.RetryQueuedNotificationParams{
+				DeliverAfter: now.Add(flushBackoff(attempts)).Format(time.RFC3339),
+				ID:           n.ID,
+			}); err != nil {
+				return fmt.Errorf("reschedule queued notification: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, n := range dropped {
+		d.logger.Warn("dropped queued notification after failed attempts",
+			"channel_id", channelID,
+			"notification_id", n.ID,
+			"attempts", n.Attempts+1,
+			"queued_at", n.CreatedAt,
+		)
+	}
+	return nil
+}
+
+// flushBackoff returns the delay before the next attempt to send a queued
+// notification, after the given number of failed attempts.
+func flushBackoff(attempts int64) time.Duration {
+	delay := flushRetryDelay
+	for i := int64(1); i < attempts && delay < maxFlushRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxFlushRetryDelay)
+}
+
+func (d *Dispatcher) throttled(ctx context.Context, c store.NotificationChannel, now time.Time) (bool, error) {
+	if c.MaxPerHour <= 0 {
+		return false, nil
+	}
+	count, err := d.queries.CountNotificationDeliveriesSince(ctx, store.CountNotificationDeliveriesSinceParams{
+		ChannelID:   c.ID,
+		DeliveredAt: now.Add(-time.Hour).Format(time.RFC3339),
+	})
+	if err != nil {
+		return false, fmt.Errorf("count deliveries: %w", err)
+	}
+	return count >= c.MaxPerHour, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, c store.NotificationChannel, convID string, payload json.RawMessage, now time.Time) error {
+	deliveryID := uuid.NewString()
+	var sent []tool.SentMessage
+	if err := d.deliver(tool.WithSentMessages(tool.WithNotificationID(ctx, deliveryID), &sent), c, convID, payload); err != nil {
+		return err
+	}
+	if err := d.queries.CreateNotificationDelivery(ctx, store.CreateNotificationDeliveryParams{
+		ID:             deliveryID,
+		ChannelID:      c.ID,
+		ConversationID: store.NewNullString(convID),
+		DeliveredAt:    now.Format(time.RFC3339),
+	}); err != nil {
+		d.logger.Error("failed to record notification delivery", "channel_id", c.ID, "error", err)
+		return nil
+	}
+	// Replies to the chat messages the notification was sent as are routed
+	// to its conversation, see webhook.TelegramHandler and
+	// webhook.SlackHandler.
+	if convID == "" {
+		return nil
+	}
+	for _, m := range sent {
+		if err := d.queries.CreateNotificationMessage(ctx, store.CreateNotificationMessageParams{
+			ChannelID:  c.ID,
+			ChatID:     m.ChatID,
+			MessageID:  m.MessageID,
+			DeliveryID: deliveryID,
+		}); err != nil {
+			d.logger.Error("failed to record notification message", "channel_id", c.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// deliver sends a payload using the channel's transport. Web push needs
+// server state (keys, subscriptions), so it's handled here rather than by
+// tool.SendNotification. Web push notifications are only sent to the
+// subscriptions allowed to access the agent of the conversation.
+func (d *Dispatcher) deliver(ctx context.Context, c store.NotificationChannel, convID string, payload json.RawMessage) error {
+	if c.Type != "web_push" {
+		return tool.SendNotification(ctx, toToolChannel(c), payload)
+	}
+
+	if d.webPush == nil {
+		return fmt.Errorf("web push is not configured")
+	}
+	var msg webpush.Message
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return fmt.Errorf("parse payload: %w", err)
+	}
+	if msg.Title == "" {
+		msg.Title = c.Name
+	}
+	// Digests are flushed without the context of the agent that sent them.
+	agentID := tool.GetAgentID(ctx)
+	if agentID == "" && convID != "" {
+		conv, err := d.queries.GetConversation(ctx, convID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("get conversation: %w", err)
+		}
+		agentID = conv.AgentID
+	}
+	return d.webPush.Notify(ctx, agentID, msg)
+}
+
+// RunFinished notifies the web push channels enabled for an agent that one of
+// its autonomous runs finished, applying their delivery settings. Agents
+// without web push channels don't notify. Implements runner.RunNotifier.
+func (d *Dispatcher) RunFinished(ctx context.Context, agent store.Agent, convID, response string, runErr error) {
+	msg := webpush.Message{
+		Title: agent.Name + " finished a run",
+		Body:  truncate(response, 200),
+		URL:   "/agents/" + agent.ID + "/" + convID,
+	}
+	if runErr != nil {
+		msg.Title = agent.Name + " failed a run"
+		msg.Body = truncate(runErr.Error(), 200)
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		d.logger.Error("failed to encode run notification", "error", err)
+		return
+	}
+
+	var channelIDs []string
+	if err := json.Unmarshal([]byte(agent.EnabledNotificationChannels), &channelIDs); err != nil {
+		d.logger.Error("failed to parse enabled notification channels", "agent_id", agent.ID, "error", err)
+		return
+	}
+	ctx = tool.WithConversationID(tool.WithAgentID(ctx, agent.ID), convID)
+	for _, id := range channelIDs {
+		c, err := d.queries.GetNotificationChannel(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			d.logger.Error("failed to get notification channel", "channel_id", id, "error", err)
+			continue
+		}
+		if c.Type != "web_push" {
+			continue
+		}
+		if c, err = decryptChannel(d.cipher, c); err != nil {
+			d.logger.Error("failed to decrypt notification channel", "channel_id", id, "error", err)
+			continue
+		}
+		msg, ok, err := d.dispatch(ctx, c, payload)
+		if err != nil {
+			d.logger.Error("failed to send run notification", "channel_id", id, "conversation_id", convID, "error", err)
+		} else if !ok {
+			d.logger.Warn("run notification not sent", "channel_id", id, "conversation_id", convID, "reason", msg)
+		}
+	}
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return s
+}
+
+func (d *Dispatcher) enqueue(ctx context.Context, channelID, convID string, payload json.RawMessage, deliverAfter time.Time) error {
+	if err := d.queries.CreateQueuedNotification(ctx, store.CreateQueuedNotificationParams{
+		ID:             uuid.NewString(),
+		ChannelID:      channelID,
+		ConversationID: store.NewNullString(convID),
+		Payload:        string(payload),
+		DeliverAfter:   deliverAfter.Format(time.RFC3339),
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("queue notification: %w", err)
+	}
+	return nil
+}
+
+// nextDigest returns the next time a digest is due for the given cron
+// schedule.
+func nextDigest(schedule string, now time.Time) (time.Time, error) {
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	sched, err := parser.Parse(schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(now).UTC(), nil
+}
+
+// digestPayload combines payloads into one. If every payload is an object
+// with a common text property (e.g. "text"), the first payload is used as a
+// base and that property is replaced with all texts joined together.
+// Otherwise the payloads are wrapped in a "notifications" array.
+func digestPayload(payloads []json.RawMessage) json.RawMessage {
+	if len(payloads) == 1 {
+		return payloads[0]
+	}
+
+	objects := make([]map[string]any, 0, len(payloads))
+	for _, p := range payloads {
+		var obj map[string]any
+		if err := json.Unmarshal(p, &obj); err != nil {
+			objects = nil
+			break
+		}
+		objects = append(objects, obj)
+	}
+
+	if objects != nil {
+		for _, key := range digestTextKeys {
+			texts := make([]string, 0, len(objects))
+			for _, obj := range objects {
+				s, ok := obj[key].(string)
+				if !ok {
+					break
+				}
+				texts = append(texts, s)
+			}
+			if len(texts) != len(objects) {
+				continue
+			}
+
+			combined := objects[0]
+			combined[key] = fmt.Sprintf("%d notifications:\n\n%s", len(texts), strings.Join(texts, "\n\n"))
+			if b, err := json.Marshal(combined); err == nil {
+				return b
+			}
+		}
+	}
+
+	b, _ := json.Marshal(map[string]any{"notifications": payloads})
+	return b
+}
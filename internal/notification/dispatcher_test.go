@@ -0,0 +1,404 @@
+package notification
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/webpush"
+)
+
+func TestDispatcherRoutesGroupBySeverity(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(failing.Close)
+	var received []string
+	working := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received = append(received, string(b))
+	}))
+	t.Cleanup(working.Close)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	create := func(name, channelType, config string) store.NotificationChannel {
+		t.Helper()
+		c, err := queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+			ID:        name,
+			Name:      name,
+			Type:      channelType,
+			Config:    config,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			t.Fatalf("create channel %s: %v", name, err)
+		}
+		return c
+	}
+	create("pager", "http_request", `{"url":"`+failing.URL+`"}`)
+	create("chat", "http_request", `{"url":"`+working.URL+`"}`)
+	create("nested", tool.GroupChannelType, `{"default":["chat"]}`)
+	group := create("ops", tool.GroupChannelType, `{"routes":[{"severity":"critical","channels":["missing","nested","pager","chat"]}]}`)
+
+	d := NewDispatcher(queries, nil, nil, slog.New(slog.DiscardHandler))
+	out, err := d.DispatchNotification(ctx, toToolChannel(group), json.RawMessage(`{"severity":"Critical","text":"disk full"}`))
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if !strings.HasPrefix(out, "Routed to chat: Notification sent successfully") {
+		t.Errorf("unexpected output: %s", out)
+	}
+	for _, name := range []string{"missing", "nested", "pager"} {
+		if !strings.Contains(out, "- "+name+": ") {
+			t.Errorf("output doesn't list failed channel %s: %s", name, out)
+		}
+	}
+	if len(received) != 1 || !strings.Contains(received[0], "disk full") {
+		t.Errorf("chat channel received %q", received)
+	}
+
+	out, err = d.DispatchNotification(ctx, toToolChannel(group), json.RawMessage(`{"severity":"info","text":"fyi"}`))
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if !strings.HasPrefix(out, "Notification not sent") || len(received) != 1 {
+		t.Errorf("notification without a route was delivered: %s", out)
+	}
+}
+
+// TestRunFinished checks that run notifications are sent to the web push
+// channels enabled for the agent, and only to the subscriptions allowed to
+// access it.
+func TestRunFinished(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	logger := slog.New(slog.DiscardHandler)
+
+	var (
+		mu     sync.Mutex
+		pushed []string
+	)
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		pushed = append(pushed, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(pushService.Close)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for path, agentIDs := range map[string]string{"/all": `[]`, "/agent-1": `["agent-1"]`, "/agent-2": `["agent-2"]`} {
+		key, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := queries.UpsertWebPushSubscription(ctx, store.UpsertWebPushSubscriptionParams{
+			ID:        path,
+			Endpoint:  pushService.URL + path,
+			P256dh:    base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+			Auth:      base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+			CreatedAt: now,
+			AgentIds:  agentIDs,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{ID: "push", Name: "push", Type: "web_push", Config: "{}", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	var agents []store.Agent
+	for id, channels := range map[string]string{"agent-1": `["push"]`, "agent-2": `[]`} {
+		a, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: id, Name: id, EnabledTools: "[]", EnabledNotificationChannels: channels, EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-" + id, AgentID: id, CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		agents = append(agents, a)
+	}
+
+	sender, err := webpush.NewSender(ctx, queries, nil, "mailto:ops@example.com", logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDispatcher(queries, nil, sender, logger)
+	for _, a := range agents {
+		d.RunFinished(ctx, a, "conv-"+a.ID, "done", nil)
+	}
+
+	slices.Sort(pushed)
+	if want := []string{"/agent-1", "/all"}; !slices.Equal(pushed, want) {
+		t.Errorf("pushed to %v, want %v", pushed, want)
+	}
+}
+
+func TestNextDigest(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		name     string
+		schedule string
+		now      string
+		want     string
+		wantErr  bool
+	}{
+		{name: "later today", schedule: "0 9 * * *", now: "2026-03-10T08:30:00Z", want: "2026-03-10T09:00:00Z"},
+		{name: "tomorrow", schedule: "0 9 * * *", now: "2026-03-10T09:30:00Z", want: "2026-03-11T09:00:00Z"},
+		{name: "due now is the next one", schedule: "0 9 * * *", now: "2026-03-10T09:00:00Z", want: "2026-03-11T09:00:00Z"},
+		{name: "next year", schedule: "0 0 1 * *", now: "2026-12-31T23:59:00Z", want: "2027-01-01T00:00:00Z"},
+		{name: "skips short months", schedule: "0 9 31 * *", now: "2026-02-01T00:00:00Z", want: "2026-03-31T09:00:00Z"},
+		{name: "leap day", schedule: "0 9 29 2 *", now: "2026-03-01T00:00:00Z", want: "2028-02-29T09:00:00Z"},
+		{name: "day of week", schedule: "0 9 * * 1", now: "2026-10-15T12:00:00Z", want: "2026-10-19T09:00:00Z"},
+		{name: "sunday", schedule: "0 9 * * 0", now: "2026-10-15T12:00:00Z", want: "2026-10-18T09:00:00Z"},
+		// With both set, either the day of month or the day of week matches.
+		{name: "day of month or week", schedule: "0 9 20 * 1", now: "2026-10-15T12:00:00Z", want: "2026-10-19T09:00:00Z"},
+		// The schedule is in the time zone of now; the result is in UTC.
+		{name: "time zone", schedule: "0 9 * * *", now: "2026-03-10T08:30:00+02:00", want: "2026-03-10T07:00:00Z"},
+		{name: "empty", schedule: "", wantErr: true},
+		{name: "garbage", schedule: "daily", wantErr: true},
+		{name: "too few fields", schedule: "0 9 * *", wantErr: true},
+		{name: "seconds field", schedule: "0 0 9 * * *", wantErr: true},
+		{name: "descriptor", schedule: "@daily", wantErr: true},
+		{name: "month out of range", schedule: "0 9 1 13 *", wantErr: true},
+		{name: "day of week out of range", schedule: "0 9 * * 8", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			if tt.now != "" {
+				now = at(tt.now)
+			}
+			got, err := nextDigest(tt.schedule, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("nextDigest(%q) = %v, want error", tt.schedule, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("nextDigest(%q): %v", tt.schedule, err)
+			}
+			if got.Format(time.RFC3339) != tt.want {
+				t.Errorf("nextDigest(%q, %s) = %s, want %s", tt.schedule, tt.now, got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+// TestDispatcherDigest checks that notifications to a channel with a digest
+// schedule are queued until the scheduled time, and then sent as one
+// combined payload.
+func TestDispatcherDigest(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received = append(received, string(b))
+	}))
+	t.Cleanup(server.Close)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	c, err := queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+		ID:             "digest",
+		Name:           "digest",
+		Type:           "http_request",
+		Config:         `{"url":"` + server.URL + `"}`,
+		DigestSchedule: "0 9 * * *",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		t.Fatalf("create channel: %v", err)
+	}
+
+	d := NewDispatcher(queries, nil, nil, slog.New(slog.DiscardHandler))
+	for _, text := range []string{"build failed", "build fixed"} {
+		out, err := d.DispatchNotification(ctx, toToolChannel(c), json.RawMessage(`{"text":"`+text+`"}`))
+		if err != nil {
+			t.Fatalf("dispatch: %v", err)
+		}
+		if !strings.HasPrefix(out, "Notification queued for the next digest at ") {
+			t.Errorf("unexpected output: %s", out)
+		}
+	}
+
+	// Nothing is sent before the scheduled time.
+	if err := d.FlushQueue(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(received) != 0 {
+		t.Fatalf("sent before the digest was due: %q", received)
+	}
+	next, err := nextDigest(c.DigestSchedule, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, err := queries.ListDueQueuedNotifications(ctx, next.Format(time.RFC3339))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 2 {
+		t.Fatalf("queued %d notifications due at %s, want 2", len(queued), next.Format(time.RFC3339))
+	}
+
+	// Once the scheduled time has come, both are sent in one payload.
+	past := time.Now().UTC().Add(-time.Minute).Format(time.RFC3339)
+	if _, err := db.ExecContext(ctx, "UPDATE queued_notifications SET deliver_after = ?", past); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.FlushQueue(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	want := `{"text":"2 notifications:\n\nbuild failed\n\nbuild fixed"}`
+	if len(received) != 1 || received[0] != want {
+		t.Errorf("received %q, want one digest %s", received, want)
+	}
+	if queued, _ := queries.ListDueQueuedNotifications(ctx, next.Format(time.RFC3339)); len(queued) != 0 {
+		t.Errorf("%d notifications left in the queue", len(queued))
+	}
+}
+
+func TestFlushBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int64
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 5, want: 16 * time.Minute},
+		{attempts: 7, want: time.Hour},
+		{attempts: 100, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := flushBackoff(tt.attempts); got != tt.want {
+			t.Errorf("flushBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// TestFlushQueueRetries checks that queued notifications that fail to send
+// are retried with backoff, and dropped after maxFlushAttempts.
+func TestFlushQueueRetries(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	var requests int
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(failing.Close)
+
+	now := time.Now().UTC()
+	if _, err := queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+		ID:        "pager",
+		Name:      "pager",
+		Type:      "http_request",
+		Config:    `{"url":"` + failing.URL + `"}`,
+		CreatedAt: now.Format(time.RFC3339),
+		UpdatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("create channel: %v", err)
+	}
+	if err := queries.CreateQueuedNotification(ctx, store.CreateQueuedNotificationParams{
+		ID:           "queued-1",
+		ChannelID:    "pager",
+		Payload:      `{"text":"disk full"}`,
+		DeliverAfter: now.Format(time.RFC3339),
+		CreatedAt:    now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("queue notification: %v", err)
+	}
+
+	var logs strings.Builder
+	d := NewDispatcher(queries, nil, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+	for attempt := int64(1); attempt <= maxFlushAttempts; attempt++ {
+		start := time.Now().UTC().Truncate(time.Second)
+		if err := d.FlushQueue(ctx); err != nil {
+			t.Fatalf("flush: %v", err)
+		}
+		if requests != int(attempt) {
+			t.Fatalf("attempt %d: sent %d requests", attempt, requests)
+		}
+
+		// Nothing is due until the backoff has passed.
+		queued, err := queries.ListDueQueuedNotifications(ctx, time.Now().UTC().Add(24*time.Hour).Format(time.RFC3339))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attempt == maxFlushAttempts {
+			if len(queued) != 0 {
+				t.Fatalf("notification kept after %d attempts: %+v", attempt, queued)
+			}
+			break
+		}
+		if len(queued) != 1 || queued[0].Attempts != attempt {
+			t.Fatalf("attempt %d: queue = %+v", attempt, queued)
+		}
+		deliverAfter, err := time.Parse(time.RFC3339, queued[0].DeliverAfter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delay := deliverAfter.Sub(start); delay < flushBackoff(attempt) || delay > flushBackoff(attempt)+time.Second {
+			t.Errorf("attempt %d: retried after %v, want %v", attempt, delay, flushBackoff(attempt))
+		}
+		if err := d.FlushQueue(ctx); err != nil || requests != int(attempt) {
+			t.Fatalf("attempt %d: retried before the backoff passed (err %v)", attempt, err)
+		}
+
+		if _, err := db.ExecContext(ctx, "UPDATE queued_notifications SET deliver_after = ?", start.Format(time.RFC3339)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !strings.Contains(logs.String(), "dropped queued notification after failed attempts") || !strings.Contains(logs.String(), "notification_id=queued-1") {
+		t.Errorf("drop wasn't logged: %s", logs.String())
+	}
+}
@@ -48,6 +48,15 @@ const (
 	// NotificationChannelServiceDeleteNotificationChannelProcedure is the fully-qualified name of the
 	// NotificationChannelService's DeleteNotificationChannel RPC.
 	NotificationChannelServiceDeleteNotificationChannelProcedure = "/blippy.notification.NotificationChannelService/DeleteNotificationChannel"
+	// NotificationChannelServiceGetWebPushConfigProcedure is the fully-qualified name of the
+	// NotificationChannelService's GetWebPushConfig RPC.
+	NotificationChannelServiceGetWebPushConfigProcedure = "/blippy.notification.NotificationChannelService/GetWebPushConfig"
+	// NotificationChannelServiceCreateWebPushSubscriptionProcedure is the fully-qualified name of the
+	// NotificationChannelService's CreateWebPushSubscription RPC.
+	NotificationChannelServiceCreateWebPushSubscriptionProcedure = "/blippy.notification.NotificationChannelService/CreateWebPushSubscription"
+	// NotificationChannelServiceDeleteWebPushSubscriptionProcedure is the fully-qualified name of the
+	// NotificationChannelService's DeleteWebPushSubscription RPC.
+	NotificationChannelServiceDeleteWebPushSubscriptionProcedure = "/blippy.notification.NotificationChannelService/DeleteWebPushSubscription"
 )
 
 // NotificationChannelServiceClient is a client for the
@@ -58,6 +67,9 @@ type NotificationChannelServiceClient interface {
 	ListNotificationChannels(context.Context, *connect.Request[ListNotificationChannelsRequest]) (*connect.Response[ListNotificationChannelsResponse], error)
 	UpdateNotificationChannel(context.Context, *connect.Request[UpdateNotificationChannelRequest]) (*connect.Response[NotificationChannel], error)
 	DeleteNotificationChannel(context.Context, *connect.Request[DeleteNotificationChannelRequest]) (*connect.Response[Empty], error)
+	GetWebPushConfig(context.Context, *connect.Request[GetWebPushConfigRequest]) (*connect.Response[WebPushConfig], error)
+	CreateWebPushSubscription(context.Context, *connect.Request[CreateWebPushSubscriptionRequest]) (*connect.Response[Empty], error)
+	DeleteWebPushSubscription(context.Context, *connect.Request[DeleteWebPushSubscriptionRequest]) (*connect.Response[Empty], error)
 }
 
 // NewNotificationChannelServiceClient constructs a client for the
@@ -102,6 +114,24 @@ func NewNotificationChannelServiceClient(httpClient connect.HTTPClient, baseURL
 			connect.WithSchema(notificationChannelServiceMethods.ByName("DeleteNotificationChannel")),
 			connect.WithClientOptions(opts...),
 		),
+		getWebPushConfig: connect.NewClient[GetWebPushConfigRequest, WebPushConfig](
+			httpClient,
+			baseURL+NotificationChannelServiceGetWebPushConfigProcedure,
+			connect.WithSchema(notificationChannelServiceMethods.ByName("GetWebPushConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		createWebPushSubscription: connect.NewClient[CreateWebPushSubscriptionRequest, Empty](
+			httpClient,
+			baseURL+NotificationChannelServiceCreateWebPushSubscriptionProcedure,
+			connect.WithSchema(notificationChannelServiceMethods.ByName("CreateWebPushSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebPushSubscription: connect.NewClient[DeleteWebPushSubscriptionRequest, Empty](
+			httpClient,
+			baseURL+NotificationChannelServiceDeleteWebPushSubscriptionProcedure,
+			connect.WithSchema(notificationChannelServiceMethods.ByName("DeleteWebPushSubscription")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -112,6 +142,9 @@ type notificationChannelServiceClient struct {
 	listNotificationChannels  *connect.Client[ListNotificationChannelsRequest, ListNotificationChannelsResponse]
 	updateNotificationChannel *connect.Client[UpdateNotificationChannelRequest, NotificationChannel]
 	deleteNotificationChannel *connect.Client[DeleteNotificationChannelRequest, Empty]
+	getWebPushConfig          *connect.Client[GetWebPushConfigRequest, WebPushConfig]
+	createWebPushSubscription *connect.Client[CreateWebPushSubscriptionRequest, Empty]
+	deleteWebPushSubscription *connect.Client[DeleteWebPushSubscriptionRequest, Empty]
 }
 
 // CreateNotificationChannel calls
@@ -144,6 +177,23 @@ func (c *notificationChannelServiceClient) DeleteNotificationChannel(ctx context
 	return c.deleteNotificationChannel.CallUnary(ctx, req)
 }
 
+// GetWebPushConfig calls blippy.notification.NotificationChannelService.GetWebPushConfig.
+func (c *notificationChannelServiceClient) GetWebPushConfig(ctx context.Context, req *connect.Request[GetWebPushConfigRequest]) (*connect.Response[WebPushConfig], error) {
+	return c.getWebPushConfig.CallUnary(ctx, req)
+}
+
+// CreateWebPushSubscription calls
+// blippy.notification.NotificationChannelService.CreateWebPushSubscription.
+func (c *notificationChannelServiceClient) CreateWebPushSubscription(ctx context.Context, req *connect.Request[CreateWebPushSubscriptionRequest]) (*connect.Response[Empty], error) {
+	return c.createWebPushSubscription.CallUnary(ctx, req)
+}
+
+// DeleteWebPushSubscription calls
+// blippy.notification.NotificationChannelService.DeleteWebPushSubscription.
+func (c *notificationChannelServiceClient) DeleteWebPushSubscription(ctx context.Context, req *connect.Request[DeleteWebPushSubscriptionRequest]) (*connect.Response[Empty], error) {
+	return c.deleteWebPushSubscription.CallUnary(ctx, req)
+}
+
 // NotificationChannelServiceHandler is an implementation of the
 // blippy.notification.NotificationChannelService service.
 type NotificationChannelServiceHandler interface {
@@ -152,6 +202,9 @@ type NotificationChannelServiceHandler interface {
 	ListNotificationChannels(context.Context, *connect.Request[ListNotificationChannelsRequest]) (*connect.Response[ListNotificationChannelsResponse], error)
 	UpdateNotificationChannel(context.Context, *connect.Request[UpdateNotificationChannelRequest]) (*connect.Response[NotificationChannel], error)
 	DeleteNotificationChannel(context.Context, *connect.Request[DeleteNotificationChannelRequest]) (*connect.Response[Empty], error)
+	GetWebPushConfig(context.Context, *connect.Request[GetWebPushConfigRequest]) (*connect.Response[WebPushConfig], error)
+	CreateWebPushSubscription(context.Context, *connect.Request[CreateWebPushSubscriptionRequest]) (*connect.Response[Empty], error)
+	DeleteWebPushSubscription(context.Context, *connect.Request[DeleteWebPushSubscriptionRequest]) (*connect.Response[Empty], error)
 }
 
 // NewNotificationChannelServiceHandler builds an HTTP handler from the service implementation. It
@@ -191,6 +244,24 @@ func NewNotificationChannelServiceHandler(svc NotificationChannelServiceHandler,
 		connect.WithSchema(notificationChannelServiceMethods.ByName("DeleteNotificationChannel")),
 		connect.WithHandlerOptions(opts...),
 	)
+	notificationChannelServiceGetWebPushConfigHandler := connect.NewUnaryHandler(
+		NotificationChannelServiceGetWebPushConfigProcedure,
+		svc.GetWebPushConfig,
+		connect.WithSchema(notificationChannelServiceMethods.ByName("GetWebPushConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notificationChannelServiceCreateWebPushSubscriptionHandler := connect.NewUnaryHandler(
+		NotificationChannelServiceCreateWebPushSubscriptionProcedure,
+		svc.CreateWebPushSubscription,
+		connect.WithSchema(notificationChannelServiceMethods.ByName("CreateWebPushSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notificationChannelServiceDeleteWebPushSubscriptionHandler := connect.NewUnaryHandler(
+		NotificationChannelServiceDeleteWebPushSubscriptionProcedure,
+		svc.DeleteWebPushSubscription,
+		connect.WithSchema(notificationChannelServiceMethods.ByName("DeleteWebPushSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/blippy.notification.NotificationChannelService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case NotificationChannelServiceCreateNotificationChannelProcedure:
@@ -203,6 +274,12 @@ func NewNotificationChannelServiceHandler(svc NotificationChannelServiceHandler,
 			notificationChannelServiceUpdateNotificationChannelHandler.ServeHTTP(w, r)
 		case NotificationChannelServiceDeleteNotificationChannelProcedure:
 			notificationChannelServiceDeleteNotificationChannelHandler.ServeHTTP(w, r)
+		case NotificationChannelServiceGetWebPushConfigProcedure:
+			notificationChannelServiceGetWebPushConfigHandler.ServeHTTP(w, r)
+		case NotificationChannelServiceCreateWebPushSubscriptionProcedure:
+			notificationChannelServiceCreateWebPushSubscriptionHandler.ServeHTTP(w, r)
+		case NotificationChannelServiceDeleteWebPushSubscriptionProcedure:
+			notificationChannelServiceDeleteWebPushSubscriptionHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -231,3 +308,15 @@ func (UnimplementedNotificationChannelServiceHandler) UpdateNotificationChannel(
 func (UnimplementedNotificationChannelServiceHandler) DeleteNotificationChannel(context.Context, *connect.Request[DeleteNotificationChannelRequest]) (*connect.Response[Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.notification.NotificationChannelService.DeleteNotificationChannel is not implemented"))
 }
+
+func (UnimplementedNotificationChannelServiceHandler) GetWebPushConfig(context.Context, *connect.Request[GetWebPushConfigRequest]) (*connect.Response[WebPushConfig], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.notification.NotificationChannelService.GetWebPushConfig is not implemented"))
+}
+
+func (UnimplementedNotificationChannelServiceHandler) CreateWebPushSubscription(context.Context, *connect.Request[CreateWebPushSubscriptionRequest]) (*connect.Response[Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.notification.NotificationChannelService.CreateWebPushSubscription is not implemented"))
+}
+
+func (UnimplementedNotificationChannelServiceHandler) DeleteWebPushSubscription(context.Context, *connect.Request[DeleteWebPushSubscriptionRequest]) (*connect.Response[Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.notification.NotificationChannelService.DeleteWebPushSubscription is not implemented"))
+}
@@ -23,17 +23,24 @@ const (
 )
 
 type NotificationChannel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                               // e.g., "email", "slack", "webhook"
-	Config        string                 `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                           // JSON-encoded configuration
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                 // Guidance for LLM on when to use this channel
-	JsonSchema    string                 `protobuf:"bytes,6,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"` // JSON Schema for the message payload
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type               string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                               // e.g., "email", "slack", "webhook"
+	Config             string                 `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                           // JSON-encoded configuration
+	Description        string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                 // Guidance for LLM on when to use this channel
+	JsonSchema         string                 `protobuf:"bytes,6,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"` // JSON Schema for the message payload
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	QuietHoursStart    string                 `protobuf:"bytes,9,opt,name=quiet_hours_start,json=quietHoursStart,proto3" json:"quiet_hours_start,omitempty"`           // "HH:MM", empty disables quiet hours
+	QuietHoursEnd      string                 `protobuf:"bytes,10,opt,name=quiet_hours_end,json=quietHoursEnd,proto3" json:"quiet_hours_end,omitempty"`                // "HH:MM", may be earlier than start to wrap midnight
+	QuietHoursTimezone string                 `protobuf:"bytes,11,opt,name=quiet_hours_timezone,json=quietHoursTimezone,proto3" json:"quiet_hours_timezone,omitempty"` // IANA time zone, defaults to UTC
+	QuietHoursMode     string                 `protobuf:"bytes,12,opt,name=quiet_hours_mode,json=quietHoursMode,proto3" json:"quiet_hours_mode,omitempty"`             // "defer" or "drop"
+	MaxPerHour         int32                  `protobuf:"varint,13,opt,name=max_per_hour,json=maxPerHour,proto3" json:"max_per_hour,omitempty"`                        // 0 means unlimited
+	DigestSchedule     string                 `protobuf:"bytes,14,opt,name=digest_schedule,json=digestSchedule,proto3" json:"digest_schedule,omitempty"`               // Cron expression; when set, notifications are batched and sent as one digest per run
+	Version            int64                  `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`                                                  // Incremented on every update
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *NotificationChannel) Reset() {
@@ -122,15 +129,70 @@ func (x *NotificationChannel) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *NotificationChannel) GetQuietHoursStart() string {
+	if x != nil {
+		return x.QuietHoursStart
+	}
+	return ""
+}
+
+func (x *NotificationChannel) GetQuietHoursEnd() string {
+	if x != nil {
+		return x.QuietHoursEnd
+	}
+	return ""
+}
+
+func (x *NotificationChannel) GetQuietHoursTimezone() string {
+	if x != nil {
+		return x.QuietHoursTimezone
+	}
+	return ""
+}
+
+func (x *NotificationChannel) GetQuietHoursMode() string {
+	if x != nil {
+		return x.QuietHoursMode
+	}
+	return ""
+}
+
+func (x *NotificationChannel) GetMaxPerHour() int32 {
+	if x != nil {
+		return x.MaxPerHour
+	}
+	return 0
+}
+
+func (x *NotificationChannel) GetDigestSchedule() string {
+	if x != nil {
+		return x.DigestSchedule
+	}
+	return ""
+}
+
+func (x *NotificationChannel) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type CreateNotificationChannelRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Config        string                 `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	JsonSchema    string                 `protobuf:"bytes,5,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type               string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Config             string                 `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	Description        string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	JsonSchema         string                 `protobuf:"bytes,5,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
+	QuietHoursStart    string                 `protobuf:"bytes,6,opt,name=quiet_hours_start,json=quietHoursStart,proto3" json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd      string                 `protobuf:"bytes,7,opt,name=quiet_hours_end,json=quietHoursEnd,proto3" json:"quiet_hours_end,omitempty"`
+	QuietHoursTimezone string                 `protobuf:"bytes,8,opt,name=quiet_hours_timezone,json=quietHoursTimezone,proto3" json:"quiet_hours_timezone,omitempty"`
+	QuietHoursMode     string                 `protobuf:"bytes,9,opt,name=quiet_hours_mode,json=quietHoursMode,proto3" json:"quiet_hours_mode,omitempty"`
+	MaxPerHour         int32                  `protobuf:"varint,10,opt,name=max_per_hour,json=maxPerHour,proto3" json:"max_per_hour,omitempty"`
+	DigestSchedule     string                 `protobuf:"bytes,11,opt,name=digest_schedule,json=digestSchedule,proto3" json:"digest_schedule,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *CreateNotificationChannelRequest) Reset() {
@@ -198,6 +260,48 @@ func (x *CreateNotificationChannelRequest) GetJsonSchema() string {
 	return ""
 }
 
+func (x *CreateNotificationChannelRequest) GetQuietHoursStart() string {
+	if x != nil {
+		return x.QuietHoursStart
+	}
+	return ""
+}
+
+func (x *CreateNotificationChannelRequest) GetQuietHoursEnd() string {
+	if x != nil {
+		return x.QuietHoursEnd
+	}
+	return ""
+}
+
+func (x *CreateNotificationChannelRequest) GetQuietHoursTimezone() string {
+	if x != nil {
+		return x.QuietHoursTimezone
+	}
+	return ""
+}
+
+func (x *CreateNotificationChannelRequest) GetQuietHoursMode() string {
+	if x != nil {
+		return x.QuietHoursMode
+	}
+	return ""
+}
+
+func (x *CreateNotificationChannelRequest) GetMaxPerHour() int32 {
+	if x != nil {
+		return x.MaxPerHour
+	}
+	return 0
+}
+
+func (x *CreateNotificationChannelRequest) GetDigestSchedule() string {
+	if x != nil {
+		return x.DigestSchedule
+	}
+	return ""
+}
+
 type GetNotificationChannelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -243,7 +347,15 @@ func (x *GetNotificationChannelRequest) GetId() string {
 }
 
 type ListNotificationChannelsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of results to return. 0 returns all results.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Comma-separated fields, each optionally followed by "asc" or "desc".
+	OrderBy string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Terms joined by "AND", e.g. `name:deploy AND created_at>=2025-01-01`.
+	Filter        string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -278,9 +390,41 @@ func (*ListNotificationChannelsRequest) Descriptor() ([]byte, []int) {
 	return file_notification_notification_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *ListNotificationChannelsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNotificationChannelsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListNotificationChannelsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListNotificationChannelsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type ListNotificationChannelsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Channels      []*NotificationChannel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Channels []*NotificationChannel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of results matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -322,16 +466,37 @@ func (x *ListNotificationChannelsResponse) GetChannels() []*NotificationChannel
 	return nil
 }
 
+func (x *ListNotificationChannelsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListNotificationChannelsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
 type UpdateNotificationChannelRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Config        string                 `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	JsonSchema    string                 `protobuf:"bytes,6,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type               string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Config             string                 `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	Description        string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	JsonSchema         string                 `protobuf:"bytes,6,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
+	QuietHoursStart    string                 `protobuf:"bytes,7,opt,name=quiet_hours_start,json=quietHoursStart,proto3" json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd      string                 `protobuf:"bytes,8,opt,name=quiet_hours_end,json=quietHoursEnd,proto3" json:"quiet_hours_end,omitempty"`
+	QuietHoursTimezone string                 `protobuf:"bytes,9,opt,name=quiet_hours_timezone,json=quietHoursTimezone,proto3" json:"quiet_hours_timezone,omitempty"`
+	QuietHoursMode     string                 `protobuf:"bytes,10,opt,name=quiet_hours_mode,json=quietHoursMode,proto3" json:"quiet_hours_mode,omitempty"`
+	MaxPerHour         int32                  `protobuf:"varint,11,opt,name=max_per_hour,json=maxPerHour,proto3" json:"max_per_hour,omitempty"`
+	DigestSchedule     string                 `protobuf:"bytes,12,opt,name=digest_schedule,json=digestSchedule,proto3" json:"digest_schedule,omitempty"`
+	Version            int64                  `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"` // Version the update is based on; fails with ABORTED if stale
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *UpdateNotificationChannelRequest) Reset() {
@@ -406,6 +571,55 @@ func (x *UpdateNotificationChannelRequest) GetJsonSchema() string {
 	return ""
 }
 
+func (x *UpdateNotificationChannelRequest) GetQuietHoursStart() string {
+	if x != nil {
+		return x.QuietHoursStart
+	}
+	return ""
+}
+
+func (x *UpdateNotificationChannelRequest) GetQuietHoursEnd() string {
+	if x != nil {
+		return x.QuietHoursEnd
+	}
+	return ""
+}
+
+func (x *UpdateNotificationChannelRequest) GetQuietHoursTimezone() string {
+	if x != nil {
+		return x.QuietHoursTimezone
+	}
+	return ""
+}
+
+func (x *UpdateNotificationChannelRequest) GetQuietHoursMode() string {
+	if x != nil {
+		return x.QuietHoursMode
+	}
+	return ""
+}
+
+func (x *UpdateNotificationChannelRequest) GetMaxPerHour() int32 {
+	if x != nil {
+		return x.MaxPerHour
+	}
+	return 0
+}
+
+func (x *UpdateNotificationChannelRequest) GetDigestSchedule() string {
+	if x != nil {
+		return x.DigestSchedule
+	}
+	return ""
+}
+
+func (x *UpdateNotificationChannelRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type DeleteNotificationChannelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -450,6 +664,190 @@ func (x *DeleteNotificationChannelRequest) GetId() string {
 	return ""
 }
 
+type GetWebPushConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebPushConfigRequest) Reset() {
+	*x = GetWebPushConfigRequest{}
+	mi := &file_notification_notification_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebPushConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebPushConfigRequest) ProtoMessage() {}
+
+func (x *GetWebPushConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebPushConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetWebPushConfigRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{7}
+}
+
+type WebPushConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     string                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // VAPID public key (base64url), used as applicationServerKey
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebPushConfig) Reset() {
+	*x = WebPushConfig{}
+	mi := &file_notification_notification_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebPushConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebPushConfig) ProtoMessage() {}
+
+func (x *WebPushConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebPushConfig.ProtoReflect.Descriptor instead.
+func (*WebPushConfig) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WebPushConfig) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+type CreateWebPushSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	P256Dh        string                 `protobuf:"bytes,2,opt,name=p256dh,proto3" json:"p256dh,omitempty"` // Client public key (base64url)
+	Auth          string                 `protobuf:"bytes,3,opt,name=auth,proto3" json:"auth,omitempty"`     // Client auth secret (base64url)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebPushSubscriptionRequest) Reset() {
+	*x = CreateWebPushSubscriptionRequest{}
+	mi := &file_notification_notification_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebPushSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebPushSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateWebPushSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebPushSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebPushSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateWebPushSubscriptionRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *CreateWebPushSubscriptionRequest) GetP256Dh() string {
+	if x != nil {
+		return x.P256Dh
+	}
+	return ""
+}
+
+func (x *CreateWebPushSubscriptionRequest) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+type DeleteWebPushSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebPushSubscriptionRequest) Reset() {
+	*x = DeleteWebPushSubscriptionRequest{}
+	mi := &file_notification_notification_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebPushSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebPushSubscriptionRequest) ProtoMessage() {}
+
+func (x *DeleteWebPushSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebPushSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebPushSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteWebPushSubscriptionRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -458,7 +856,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_notification_notification_proto_msgTypes[7]
+	mi := &file_notification_notification_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -470,7 +868,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_notification_proto_msgTypes[7]
+	mi := &file_notification_notification_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -483,14 +881,14 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_notification_notification_proto_rawDescGZIP(), []int{7}
+	return file_notification_notification_proto_rawDescGZIP(), []int{11}
 }
 
 var File_notification_notification_proto protoreflect.FileDescriptor
 
 const file_notification_notification_proto_rawDesc = "" +
 	"\n" +
-	"\x1fnotification/notification.proto\x12\x13blippy.notification\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x02\n" +
+	"\x1fnotification/notification.proto\x12\x13blippy.notification\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x04\n" +
 	"\x13NotificationChannel\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -502,19 +900,44 @@ const file_notification_notification_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa5\x01\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12*\n" +
+	"\x11quiet_hours_start\x18\t \x01(\tR\x0fquietHoursStart\x12&\n" +
+	"\x0fquiet_hours_end\x18\n" +
+	" \x01(\tR\rquietHoursEnd\x120\n" +
+	"\x14quiet_hours_timezone\x18\v \x01(\tR\x12quietHoursTimezone\x12(\n" +
+	"\x10quiet_hours_mode\x18\f \x01(\tR\x0equietHoursMode\x12 \n" +
+	"\fmax_per_hour\x18\r \x01(\x05R\n" +
+	"maxPerHour\x12'\n" +
+	"\x0fdigest_schedule\x18\x0e \x01(\tR\x0edigestSchedule\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x03R\aversion\"\xa0\x03\n" +
 	" CreateNotificationChannelRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
 	"\x06config\x18\x03 \x01(\tR\x06config\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
 	"\vjson_schema\x18\x05 \x01(\tR\n" +
-	"jsonSchema\"/\n" +
+	"jsonSchema\x12*\n" +
+	"\x11quiet_hours_start\x18\x06 \x01(\tR\x0fquietHoursStart\x12&\n" +
+	"\x0fquiet_hours_end\x18\a \x01(\tR\rquietHoursEnd\x120\n" +
+	"\x14quiet_hours_timezone\x18\b \x01(\tR\x12quietHoursTimezone\x12(\n" +
+	"\x10quiet_hours_mode\x18\t \x01(\tR\x0equietHoursMode\x12 \n" +
+	"\fmax_per_hour\x18\n" +
+	" \x01(\x05R\n" +
+	"maxPerHour\x12'\n" +
+	"\x0fdigest_schedule\x18\v \x01(\tR\x0edigestSchedule\"/\n" +
 	"\x1dGetNotificationChannelRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
-	"\x1fListNotificationChannelsRequest\"h\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x90\x01\n" +
+	"\x1fListNotificationChannelsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\"\xaf\x01\n" +
 	" ListNotificationChannelsResponse\x12D\n" +
-	"\bchannels\x18\x01 \x03(\v2(.blippy.notification.NotificationChannelR\bchannels\"\xb5\x01\n" +
+	"\bchannels\x18\x01 \x03(\v2(.blippy.notification.NotificationChannelR\bchannels\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\xca\x03\n" +
 	" UpdateNotificationChannelRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -522,16 +945,38 @@ const file_notification_notification_proto_rawDesc = "" +
 	"\x06config\x18\x04 \x01(\tR\x06config\x12 \n" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1f\n" +
 	"\vjson_schema\x18\x06 \x01(\tR\n" +
-	"jsonSchema\"2\n" +
+	"jsonSchema\x12*\n" +
+	"\x11quiet_hours_start\x18\a \x01(\tR\x0fquietHoursStart\x12&\n" +
+	"\x0fquiet_hours_end\x18\b \x01(\tR\rquietHoursEnd\x120\n" +
+	"\x14quiet_hours_timezone\x18\t \x01(\tR\x12quietHoursTimezone\x12(\n" +
+	"\x10quiet_hours_mode\x18\n" +
+	" \x01(\tR\x0equietHoursMode\x12 \n" +
+	"\fmax_per_hour\x18\v \x01(\x05R\n" +
+	"maxPerHour\x12'\n" +
+	"\x0fdigest_schedule\x18\f \x01(\tR\x0edigestSchedule\x12\x18\n" +
+	"\aversion\x18\r \x01(\x03R\aversion\"2\n" +
 	" DeleteNotificationChannelRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\a\n" +
-	"\x05Empty2\x8a\x05\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x19\n" +
+	"\x17GetWebPushConfigRequest\".\n" +
+	"\rWebPushConfig\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\tR\tpublicKey\"j\n" +
+	" CreateWebPushSubscriptionRequest\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06p256dh\x18\x02 \x01(\tR\x06p256dh\x12\x12\n" +
+	"\x04auth\x18\x03 \x01(\tR\x04auth\">\n" +
+	" DeleteWebPushSubscriptionRequest\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\"\a\n" +
+	"\x05Empty2\xd0\a\n" +
 	"\x1aNotificationChannelService\x12|\n" +
 	"\x19CreateNotificationChannel\x125.blippy.notification.CreateNotificationChannelRequest\x1a(.blippy.notification.NotificationChannel\x12v\n" +
 	"\x16GetNotificationChannel\x122.blippy.notification.GetNotificationChannelRequest\x1a(.blippy.notification.NotificationChannel\x12\x87\x01\n" +
 	"\x18ListNotificationChannels\x124.blippy.notification.ListNotificationChannelsRequest\x1a5.blippy.notification.ListNotificationChannelsResponse\x12|\n" +
 	"\x19UpdateNotificationChannel\x125.blippy.notification.UpdateNotificationChannelRequest\x1a(.blippy.notification.NotificationChannel\x12n\n" +
-	"\x19DeleteNotificationChannel\x125.blippy.notification.DeleteNotificationChannelRequest\x1a\x1a.blippy.notification.EmptyB2Z0github.com/dstotijn/blippy/internal/notificationb\x06proto3"
+	"\x19DeleteNotificationChannel\x125.blippy.notification.DeleteNotificationChannelRequest\x1a\x1a.blippy.notification.Empty\x12d\n" +
+	"\x10GetWebPushConfig\x12,.blippy.notification.GetWebPushConfigRequest\x1a\".blippy.notification.WebPushConfig\x12n\n" +
+	"\x19CreateWebPushSubscription\x125.blippy.notification.CreateWebPushSubscriptionRequest\x1a\x1a.blippy.notification.Empty\x12n\n" +
+	"\x19DeleteWebPushSubscription\x125.blippy.notification.DeleteWebPushSubscriptionRequest\x1a\x1a.blippy.notification.EmptyB2Z0github.com/dstotijn/blippy/internal/notificationb\x06proto3"
 
 var (
 	file_notification_notification_proto_rawDescOnce sync.Once
@@ -545,7 +990,7 @@ func file_notification_notification_proto_rawDescGZIP() []byte {
 	return file_notification_notification_proto_rawDescData
 }
 
-var file_notification_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_notification_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_notification_notification_proto_goTypes = []any{
 	(*NotificationChannel)(nil),              // 0: blippy.notification.NotificationChannel
 	(*CreateNotificationChannelRequest)(nil), // 1: blippy.notification.CreateNotificationChannelRequest
@@ -554,28 +999,38 @@ var file_notification_notification_proto_goTypes = []any{
 	(*ListNotificationChannelsResponse)(nil), // 4: blippy.notification.ListNotificationChannelsResponse
 	(*UpdateNotificationChannelRequest)(nil), // 5: blippy.notification.UpdateNotificationChannelRequest
 	(*DeleteNotificationChannelRequest)(nil), // 6: blippy.notification.DeleteNotificationChannelRequest
-	(*Empty)(nil),                            // 7: blippy.notification.Empty
-	(*timestamppb.Timestamp)(nil),            // 8: google.protobuf.Timestamp
+	(*GetWebPushConfigRequest)(nil),          // 7: blippy.notification.GetWebPushConfigRequest
+	(*WebPushConfig)(nil),                    // 8: blippy.notification.WebPushConfig
+	(*CreateWebPushSubscriptionRequest)(nil), // 9: blippy.notification.CreateWebPushSubscriptionRequest
+	(*DeleteWebPushSubscriptionRequest)(nil), // 10: blippy.notification.DeleteWebPushSubscriptionRequest
+	(*Empty)(nil),                            // 11: blippy.notification.Empty
+	(*timestamppb.Timestamp)(nil),            // 12: google.protobuf.Timestamp
 }
 var file_notification_notification_proto_depIdxs = []int32{
-	8, // 0: blippy.notification.NotificationChannel.created_at:type_name -> google.protobuf.Timestamp
-	8, // 1: blippy.notification.NotificationChannel.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 2: blippy.notification.ListNotificationChannelsResponse.channels:type_name -> blippy.notification.NotificationChannel
-	1, // 3: blippy.notification.NotificationChannelService.CreateNotificationChannel:input_type -> blippy.notification.CreateNotificationChannelRequest
-	2, // 4: blippy.notification.NotificationChannelService.GetNotificationChannel:input_type -> blippy.notification.GetNotificationChannelRequest
-	3, // 5: blippy.notification.NotificationChannelService.ListNotificationChannels:input_type -> blippy.notification.ListNotificationChannelsRequest
-	5, // 6: blippy.notification.NotificationChannelService.UpdateNotificationChannel:input_type -> blippy.notification.UpdateNotificationChannelRequest
-	6, // 7: blippy.notification.NotificationChannelService.DeleteNotificationChannel:input_type -> blippy.notification.DeleteNotificationChannelRequest
-	0, // 8: blippy.notification.NotificationChannelService.CreateNotificationChannel:output_type -> blippy.notification.NotificationChannel
-	0, // 9: blippy.notification.NotificationChannelService.GetNotificationChannel:output_type -> blippy.notification.NotificationChannel
-	4, // 10: blippy.notification.NotificationChannelService.ListNotificationChannels:output_type -> blippy.notification.ListNotificationChannelsResponse
-	0, // 11: blippy.notification.NotificationChannelService.UpdateNotificationChannel:output_type -> blippy.notification.NotificationChannel
-	7, // 12: blippy.notification.NotificationChannelService.DeleteNotificationChannel:output_type -> blippy.notification.Empty
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	12, // 0: blippy.notification.NotificationChannel.created_at:type_name -> google.protobuf.Timestamp
+	12, // 1: blippy.notification.NotificationChannel.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: blippy.notification.ListNotificationChannelsResponse.channels:type_name -> blippy.notification.NotificationChannel
+	1,  // 3: blippy.notification.NotificationChannelService.CreateNotificationChannel:input_type -> blippy.notification.CreateNotificationChannelRequest
+	2,  // 4: blippy.notification.NotificationChannelService.GetNotificationChannel:input_type -> blippy.notification.GetNotificationChannelRequest
+	3,  // 5: blippy.notification.NotificationChannelService.ListNotificationChannels:input_type -> blippy.notification.ListNotificationChannelsRequest
+	5,  // 6: blippy.notification.NotificationChannelService.UpdateNotificationChannel:input_type -> blippy.notification.UpdateNotificationChannelRequest
+	6,  // 7: blippy.notification.NotificationChannelService.DeleteNotificationChannel:input_type -> blippy.notification.DeleteNotificationChannelRequest
+	7,  // 8: blippy.notification.NotificationChannelService.GetWebPushConfig:input_type -> blippy.notification.GetWebPushConfigRequest
+	9,  // 9: blippy.notification.NotificationChannelService.CreateWebPushSubscription:input_type -> blippy.notification.CreateWebPushSubscriptionRequest
+	10, // 10: blippy.notification.NotificationChannelService.DeleteWebPushSubscription:input_type -> blippy.notification.DeleteWebPushSubscriptionRequest
+	0,  // 11: blippy.notification.NotificationChannelService.CreateNotificationChannel:output_type -> blippy.notification.NotificationChannel
+	0,  // 12: blippy.notification.NotificationChannelService.GetNotificationChannel:output_type -> blippy.notification.NotificationChannel
+	4,  // 13: blippy.notification.NotificationChannelService.ListNotificationChannels:output_type -> blippy.notification.ListNotificationChannelsResponse
+	0,  // 14: blippy.notification.NotificationChannelService.UpdateNotificationChannel:output_type -> blippy.notification.NotificationChannel
+	11, // 15: blippy.notification.NotificationChannelService.DeleteNotificationChannel:output_type -> blippy.notification.Empty
+	8,  // 16: blippy.notification.NotificationChannelService.GetWebPushConfig:output_type -> blippy.notification.WebPushConfig
+	11, // 17: blippy.notification.NotificationChannelService.CreateWebPushSubscription:output_type -> blippy.notification.Empty
+	11, // 18: blippy.notification.NotificationChannelService.DeleteWebPushSubscription:output_type -> blippy.notification.Empty
+	11, // [11:19] is the sub-list for method output_type
+	3,  // [3:11] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_notification_notification_proto_init() }
@@ -589,7 +1044,7 @@ func file_notification_notification_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_notification_proto_rawDesc), len(file_notification_notification_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+)
+
+// Quiet hours modes.
+const (
+	QuietHoursModeDefer = "defer" // queue notifications until the window ends
+	QuietHoursModeDrop  = "drop"  // discard notifications sent during the window
+)
+
+// quietHours is a daily window, in a given time zone, during which a channel
+// does not deliver notifications.
+type quietHours struct {
+	start time.Duration // offset from midnight
+	end   time.Duration // offset from midnight; may be before start to wrap midnight
+	loc   *time.Location
+	mode  string
+}
+
+// parseQuietHours parses a channel's quiet hours settings. It returns nil
+// when no window is configured.
+func parseQuietHours(start, end, timezone, mode string) (*quietHours, error) {
+	switch mode {
+	case "":
+		mode = QuietHoursModeDefer
+	case QuietHoursModeDefer, QuietHoursModeDrop:
+	default:
+		return nil, fmt.Errorf("invalid quiet hours mode %q (must be %q or %q)", mode, QuietHoursModeDefer, QuietHoursModeDrop)
+	}
+
+	if start == "" && end == "" {
+		return nil, nil
+	}
+	if start == "" || end == "" {
+		return nil, fmt.Errorf("quiet hours need both a start and an end time")
+	}
+
+	q := &quietHours{loc: time.UTC, mode: mode}
+
+	var err error
+	if q.start, err = parseClock(start); err != nil {
+		return nil, fmt.Errorf("invalid quiet hours start: %w", err)
+	}
+	if q.end, err = parseClock(end); err != nil {
+		return nil, fmt.Errorf("invalid quiet hours end: %w", err)
+	}
+	if q.start == q.end {
+		return nil, fmt.Errorf("quiet hours start and end must differ")
+	}
+
+	if timezone != "" {
+		if q.loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("invalid quiet hours timezone: %w", err)
+		}
+	}
+
+	return q, nil
+}
+
+// parseClock parses an "HH:MM" time of day.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// contains reports whether t falls inside the quiet window.
+func (q *quietHours) contains(t time.Time) bool {
+	local := t.In(q.loc)
+	offset := local.Sub(midnight(local))
+	if q.start < q.end {
+		return offset >= q.start && offset < q.end
+	}
+	// Window wraps midnight, e.g. 22:00-07:00.
+	return offset >= q.start || offset < q.end
+}
+
+// endAfter returns the first end of the quiet window after t.
+func (q *quietHours) endAfter(t time.Time) time.Time {
+	local := t.In(q.loc)
+	end := midnight(local).Add(q.end)
+	if !end.After(local) {
+		end = midnight(local.AddDate(0, 0, 1)).Add(q.end)
+	}
+	return end.UTC()
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
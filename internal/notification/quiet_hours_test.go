@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestQuietHoursWrapsMidnight(t *testing.T) {
+	q, err := parseQuietHours("22:00", "07:00", "Europe/Amsterdam", "")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if q.mode != QuietHoursModeDefer {
+		t.Fatalf("expected default mode %q, got %q", QuietHoursModeDefer, q.mode)
+	}
+
+	loc, _ := time.LoadLocation("Europe/Amsterdam")
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2025, 1, 10, 21, 59, 0, 0, loc), false},
+		{time.Date(2025, 1, 10, 22, 0, 0, 0, loc), true},
+		{time.Date(2025, 1, 11, 3, 0, 0, 0, loc), true},
+		{time.Date(2025, 1, 11, 7, 0, 0, 0, loc), false},
+		{time.Date(2025, 1, 11, 12, 0, 0, 0, loc), false},
+	}
+	for _, tt := range tests {
+		if got := q.contains(tt.at); got != tt.want {
+			t.Errorf("contains(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+
+	end := q.endAfter(time.Date(2025, 1, 10, 23, 0, 0, 0, loc))
+	if want := time.Date(2025, 1, 11, 7, 0, 0, 0, loc); !end.Equal(want) {
+		t.Fatalf("endAfter = %s, want %s", end, want)
+	}
+}
+
+func TestParseQuietHoursInvalid(t *testing.T) {
+	tests := []struct{ start, end, tz, mode string }{
+		{"22:00", "", "", ""},
+		{"25:00", "07:00", "", ""},
+		{"22:00", "22:00", "", ""},
+		{"22:00", "07:00", "Not/AZone", ""},
+		{"", "", "", "snooze"},
+	}
+	for _, tt := range tests {
+		if _, err := parseQuietHours(tt.start, tt.end, tt.tz, tt.mode); err == nil {
+			t.Errorf("parseQuietHours(%q, %q, %q, %q): expected error", tt.start, tt.end, tt.tz, tt.mode)
+		}
+	}
+}
+
+func TestDigestPayload(t *testing.T) {
+	got := digestPayload([]json.RawMessage{
+		json.RawMessage(`{"text":"disk full","channel":"#ops"}`),
+		json.RawMessage(`{"text":"disk still full","channel":"#ops"}`),
+	})
+	var obj map[string]string
+	if err := json.Unmarshal(got, &obj); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if want := "2 notifications:\n\ndisk full\n\ndisk still full"; obj["text"] != want {
+		t.Fatalf("text = %q, want %q", obj["text"], want)
+	}
+	if obj["channel"] != "#ops" {
+		t.Fatalf("channel = %q, want %q", obj["channel"], "#ops")
+	}
+
+	got = digestPayload([]json.RawMessage{
+		json.RawMessage(`{"level":1}`),
+		json.RawMessage(`{"level":2}`),
+	})
+	if want := `{"notifications":[{"level":1},{"level":2}]}`; string(got) != want {
+		t.Fatalf("digest = %s, want %s", got, want)
+	}
+}
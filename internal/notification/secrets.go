@@ -0,0 +1,119 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// RedactConfig replaces credentials and the values of sensitive headers in
+// a channel config, so credentials aren't exposed through the API.
+func RedactConfig(configJSON string) string {
+	var cfg map[string]any
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return configJSON
+	}
+
+	for _, key := range tool.SecretConfigKeys {
+		if s, ok := cfg[key].(string); ok && s != "" {
+			cfg[key] = tool.RedactedValue
+		}
+	}
+	if headers, ok := cfg["headers"].(map[string]any); ok {
+		for name, value := range headers {
+			if s, ok := value.(string); ok && s != "" && tool.IsSensitiveHeader(name) {
+				headers[name] = tool.RedactedValue
+			}
+		}
+	}
+
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return configJSON
+	}
+	return string(b)
+}
+
+// RestoreSecrets replaces redacted values in an updated config with the
+// values from the stored config, so clients can send back a config they
+// received from the API without losing credentials.
+func RestoreSecrets(configJSON, storedJSON string) string {
+	var cfg, stored map[string]any
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return configJSON
+	}
+	_ = json.Unmarshal([]byte(storedJSON), &stored)
+
+	changed := false
+	for _, key := range tool.SecretConfigKeys {
+		if cfg[key] == tool.RedactedValue {
+			cfg[key] = stored[key]
+			changed = true
+		}
+	}
+	if headers, ok := cfg["headers"].(map[string]any); ok {
+		storedHeaders, _ := stored["headers"].(map[string]any)
+		for name, value := range headers {
+			if value == tool.RedactedValue {
+				headers[name] = storedHeaders[name]
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return configJSON
+	}
+
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return configJSON
+	}
+	return string(b)
+}
+
+// decryptChannel returns the channel with its config decrypted.
+func decryptChannel(cipher *encryption.Cipher, c store.NotificationChannel) (store.NotificationChannel, error) {
+	config, err := cipher.Decrypt(c.Config)
+	if err != nil {
+		return c, fmt.Errorf("decrypt config of channel %q: %w", c.Name, err)
+	}
+	c.Config = config
+	return c, nil
+}
+
+// EncryptStoredConfigs encrypts channel configs that are stored as plaintext,
+// e.g. because they were written before encryption was enabled. Returns the
+// number of configs encrypted.
+func EncryptStoredConfigs(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher) (int, error) {
+	if !cipher.Enabled() {
+		return 0, nil
+	}
+
+	channels, err := queries.ListNotificationChannels(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list channels: %w", err)
+	}
+
+	n := 0
+	for _, c := range channels {
+		if encryption.IsEncrypted(c.Config) {
+			continue
+		}
+		config, err := cipher.Encrypt(c.Config)
+		if err != nil {
+			return n, err
+		}
+		if err := queries.UpdateNotificationChannelConfig(ctx, store.UpdateNotificationChannelConfigParams{
+			Config: config,
+			ID:     c.ID,
+		}); err != nil {
+			return n, fmt.Errorf("update config of channel %q: %w", c.Name, err)
+		}
+		n++
+	}
+	return n, nil
+}
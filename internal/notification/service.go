@@ -3,6 +3,7 @@ package notification
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -10,35 +11,80 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/listing"
 	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/webpush"
 )
 
 type Service struct {
 	queries *store.Queries
+	cipher  *encryption.Cipher
+	webPush *webpush.Sender
+
+	// AgentScope returns the agents the caller of an RPC is restricted to,
+	// or nil if it may access all agents. Push subscriptions are only
+	// notified about these agents. Without it, subscriptions aren't
+	// restricted.
+	AgentScope func(context.Context) []string
 }
 
-func NewService(db *sql.DB) *Service {
+// NewService creates a new Service. Channel configs are encrypted with cipher,
+// which may be nil to store them as plaintext.
+func NewService(db *sql.DB, cipher *encryption.Cipher, webPush *webpush.Sender) *Service {
 	return &Service{
 		queries: store.New(db),
+		cipher:  cipher,
+		webPush: webPush,
 	}
 }
 
 func (s *Service) CreateNotificationChannel(ctx context.Context, req *connect.Request[CreateNotificationChannelRequest]) (*connect.Response[NotificationChannel], error) {
+	if err := validateDelivery(req.Msg.QuietHoursStart, req.Msg.QuietHoursEnd, req.Msg.QuietHoursTimezone, req.Msg.QuietHoursMode, req.Msg.MaxPerHour, req.Msg.DigestSchedule); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := validateJSONSchema(req.Msg.JsonSchema); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := validateGroup(req.Msg.Type, req.Msg.Config, req.Msg.QuietHoursStart, req.Msg.MaxPerHour, req.Msg.DigestSchedule); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := s.validateTelegram(ctx, req.Msg.Type, req.Msg.Config); err != nil {
+		return nil, err
+	}
+	if err := validateSlack(req.Msg.Type, req.Msg.Config); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	config, err := s.cipher.Encrypt(req.Msg.Config)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	now := time.Now().UTC()
 
 	channel, err := s.queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
-		ID:          uuid.NewString(),
-		Name:        req.Msg.Name,
-		Type:        req.Msg.Type,
-		Config:      req.Msg.Config,
-		Description: req.Msg.Description,
-		JsonSchema:  req.Msg.JsonSchema,
-		CreatedAt:   now.Format(time.RFC3339),
-		UpdatedAt:   now.Format(time.RFC3339),
+		ID:                 uuid.NewString(),
+		Name:               req.Msg.Name,
+		Type:               req.Msg.Type,
+		Config:             config,
+		Description:        req.Msg.Description,
+		JsonSchema:         req.Msg.JsonSchema,
+		QuietHoursStart:    req.Msg.QuietHoursStart,
+		QuietHoursEnd:      req.Msg.QuietHoursEnd,
+		QuietHoursTimezone: req.Msg.QuietHoursTimezone,
+		QuietHoursMode:     quietHoursMode(req.Msg.QuietHoursMode),
+		MaxPerHour:         int64(req.Msg.MaxPerHour),
+		DigestSchedule:     req.Msg.DigestSchedule,
+		CreatedAt:          now.Format(time.RFC3339),
+		UpdatedAt:          now.Format(time.RFC3339),
 	})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	channel.Config = req.Msg.Config
 
 	return connect.NewResponse(toProtoNotificationChannel(channel)), nil
 }
@@ -51,42 +97,124 @@ func (s *Service) GetNotificationChannel(ctx context.Context, req *connect.Reque
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	if channel, err = decryptChannel(s.cipher, channel); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
 	return connect.NewResponse(toProtoNotificationChannel(channel)), nil
 }
 
 func (s *Service) ListNotificationChannels(ctx context.Context, req *connect.Request[ListNotificationChannelsRequest]) (*connect.Response[ListNotificationChannelsResponse], error) {
-	channels, err := s.queries.ListNotificationChannels(ctx)
+	query, err := listing.Parse(listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Filter:    req.Msg.Filter,
+	}, channelTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListNotificationChannelsPage, s.queries.CountNotificationChannelsPage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	protoChannels := make([]*NotificationChannel, len(channels))
-	for i, c := range channels {
+	protoChannels := make([]*NotificationChannel, len(page.Items))
+	for i, c := range page.Items {
+		if c, err = decryptChannel(s.cipher, c); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 		protoChannels[i] = toProtoNotificationChannel(c)
 	}
 
-	return connect.NewResponse(&ListNotificationChannelsResponse{Channels: protoChannels}), nil
+	return connect.NewResponse(&ListNotificationChannelsResponse{
+		Channels:      protoChannels,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}), nil
+}
+
+// channelTable has the fields usable in ListNotificationChannels filters and
+// order clauses.
+var channelTable = listing.Table[store.NotificationChannel]{
+	Fields: listing.Fields[store.NotificationChannel]{
+		"id":          {Column: "id", Value: func(c store.NotificationChannel) string { return c.ID }},
+		"name":        {Column: "name", Value: func(c store.NotificationChannel) string { return c.Name }},
+		"type":        {Column: "type", Value: func(c store.NotificationChannel) string { return c.Type }},
+		"description": {Column: "description", Value: func(c store.NotificationChannel) string { return c.Description }},
+		"created_at":  {Column: "created_at", Value: func(c store.NotificationChannel) string { return c.CreatedAt }},
+		"updated_at":  {Column: "updated_at", Value: func(c store.NotificationChannel) string { return c.UpdatedAt }},
+	},
+	Order: "created_at desc",
 }
 
 func (s *Service) UpdateNotificationChannel(ctx context.Context, req *connect.Request[UpdateNotificationChannelRequest]) (*connect.Response[NotificationChannel], error) {
+	if req.Msg.Version == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("version is required"))
+	}
+
+	if err := validateDelivery(req.Msg.QuietHoursStart, req.Msg.QuietHoursEnd, req.Msg.QuietHoursTimezone, req.Msg.QuietHoursMode, req.Msg.MaxPerHour, req.Msg.DigestSchedule); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := validateJSONSchema(req.Msg.JsonSchema); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := validateGroup(req.Msg.Type, req.Msg.Config, req.Msg.QuietHoursStart, req.Msg.MaxPerHour, req.Msg.DigestSchedule); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := s.validateTelegram(ctx, req.Msg.Type, req.Msg.Config); err != nil {
+		return nil, err
+	}
+	if err := validateSlack(req.Msg.Type, req.Msg.Config); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	existing, err := s.queries.GetNotificationChannel(ctx, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("notification channel not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if existing.Version != req.Msg.Version {
+		return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_VERSION_CONFLICT, errStaleChannel)
+	}
+	if existing, err = decryptChannel(s.cipher, existing); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	config := RestoreSecrets(req.Msg.Config, existing.Config)
+	encryptedConfig, err := s.cipher.Encrypt(config)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	now := time.Now().UTC()
 
 	channel, err := s.queries.UpdateNotificationChannel(ctx, store.UpdateNotificationChannelParams{
-		ID:          req.Msg.Id,
-		Name:        req.Msg.Name,
-		Type:        req.Msg.Type,
-		Config:      req.Msg.Config,
-		Description: req.Msg.Description,
-		JsonSchema:  req.Msg.JsonSchema,
-		UpdatedAt:   now.Format(time.RFC3339),
+		ID:                 req.Msg.Id,
+		Name:               req.Msg.Name,
+		Type:               req.Msg.Type,
+		Config:             encryptedConfig,
+		Description:        req.Msg.Description,
+		JsonSchema:         req.Msg.JsonSchema,
+		QuietHoursStart:    req.Msg.QuietHoursStart,
+		QuietHoursEnd:      req.Msg.QuietHoursEnd,
+		QuietHoursTimezone: req.Msg.QuietHoursTimezone,
+		QuietHoursMode:     quietHoursMode(req.Msg.QuietHoursMode),
+		MaxPerHour:         int64(req.Msg.MaxPerHour),
+		DigestSchedule:     req.Msg.DigestSchedule,
+		UpdatedAt:          now.Format(time.RFC3339),
+		Version:            req.Msg.Version,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("notification channel not found"))
+			// Modified or deleted after the version check above.
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_VERSION_CONFLICT, errStaleChannel)
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	channel.Config = config
 
 	return connect.NewResponse(toProtoNotificationChannel(channel)), nil
 }
@@ -104,13 +232,104 @@ func toProtoNotificationChannel(c store.NotificationChannel) *NotificationChanne
 	updatedAt, _ := time.Parse(time.RFC3339, c.UpdatedAt)
 
 	return &NotificationChannel{
-		Id:          c.ID,
-		Name:        c.Name,
-		Type:        c.Type,
-		Config:      c.Config,
-		Description: c.Description,
-		JsonSchema:  c.JsonSchema,
-		CreatedAt:   timestamppb.New(createdAt),
-		UpdatedAt:   timestamppb.New(updatedAt),
+		Id:                 c.ID,
+		Name:               c.Name,
+		Type:               c.Type,
+		Config:             RedactConfig(c.Config),
+		Description:        c.Description,
+		JsonSchema:         c.JsonSchema,
+		CreatedAt:          timestamppb.New(createdAt),
+		UpdatedAt:          timestamppb.New(updatedAt),
+		QuietHoursStart:    c.QuietHoursStart,
+		QuietHoursEnd:      c.QuietHoursEnd,
+		QuietHoursTimezone: c.QuietHoursTimezone,
+		QuietHoursMode:     c.QuietHoursMode,
+		MaxPerHour:         int32(c.MaxPerHour),
+		DigestSchedule:     c.DigestSchedule,
+		Version:            c.Version,
+	}
+}
+
+var errStaleChannel = errors.New("notification channel was modified since it was loaded; reload and try again")
+
+// validateJSONSchema checks that a channel's payload schema is a JSON object.
+func validateJSONSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+	var v map[string]any
+	if err := json.Unmarshal([]byte(schema), &v); err != nil {
+		return errors.New("invalid JSON schema: " + err.Error())
+	}
+	return nil
+}
+
+// validateDelivery checks a channel's quiet hours, throttling and digest
+// settings.
+func validateDelivery(start, end, timezone, mode string, maxPerHour int32, digestSchedule string) error {
+	if _, err := parseQuietHours(start, end, timezone, mode); err != nil {
+		return err
+	}
+	if maxPerHour < 0 {
+		return errors.New("max per hour must not be negative")
+	}
+	if digestSchedule != "" {
+		if _, err := nextDigest(digestSchedule, time.Now()); err != nil {
+			return errors.New("invalid digest schedule: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// validateGroup checks the config of group channels, which have no delivery
+// settings of their own: those of the channels they route to apply.
+func validateGroup(channelType, config, quietHoursStart string, maxPerHour int32, digestSchedule string) error {
+	if channelType != tool.GroupChannelType {
+		return nil
+	}
+	if _, err := tool.ParseGroupConfig(config); err != nil {
+		return err
+	}
+	if quietHoursStart != "" || maxPerHour != 0 || digestSchedule != "" {
+		return errors.New("group channels don't have delivery settings; set them on the channels they route to")
+	}
+	return nil
+}
+
+// validateSlack checks the config of Slack channels.
+func validateSlack(channelType, config string) error {
+	if channelType != tool.SlackChannelType {
+		return nil
+	}
+	_, err := tool.ParseSlackConfig(config)
+	return err
+}
+
+// validateTelegram checks the config of Telegram channels, and that the agent
+// answering messages to their bot exists.
+func (s *Service) validateTelegram(ctx context.Context, channelType, config string) error {
+	if channelType != tool.TelegramChannelType {
+		return nil
+	}
+	cfg, err := tool.ParseTelegramConfig(config)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if cfg.AgentID == "" {
+		return nil
+	}
+	if _, err := s.queries.GetAgent(ctx, cfg.AgentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+func quietHoursMode(mode string) string {
+	if mode == "" {
+		return QuietHoursModeDefer
 	}
+	return mode
 }
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TestUpdateNotificationChannelVersion checks that updates with a stale
+// version are rejected without changing the channel.
+func TestUpdateNotificationChannelVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	svc := NewService(db, nil, nil)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{ID: "channel-1", Name: "alerts", Type: "webhook", Config: `{"url":"https://example.com/hook"}`, Description: "original", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	update := func(description string, version int64) (*connect.Response[NotificationChannel], error) {
+		return svc.UpdateNotificationChannel(ctx, connect.NewRequest(&UpdateNotificationChannelRequest{
+			Id:          "channel-1",
+			Name:        "alerts",
+			Type:        "webhook",
+			Config:      `{"url":"https://example.com/hook"}`,
+			Description: description,
+			Version:     version,
+		}))
+	}
+
+	if _, err := update("no version", 0); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("update without version: err = %v, want invalid argument", err)
+	}
+
+	res, err := update("first tab", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Version != 2 {
+		t.Errorf("version after update = %d, want 2", res.Msg.Version)
+	}
+
+	if _, err := update("second tab", 1); connect.CodeOf(err) != connect.CodeAborted {
+		t.Errorf("update with stale version: err = %v, want aborted", err)
+	}
+	channel, err := queries.GetNotificationChannel(ctx, "channel-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel.Description != "first tab" || channel.Version != 2 {
+		t.Errorf("channel after stale update = %q (version %d), want %q (version 2)", channel.Description, channel.Version, "first tab")
+	}
+}
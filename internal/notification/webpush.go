@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func (s *Service) GetWebPushConfig(ctx context.Context, req *connect.Request[GetWebPushConfigRequest]) (*connect.Response[WebPushConfig], error) {
+	if s.webPush == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("web push is not configured"))
+	}
+
+	return connect.NewResponse(&WebPushConfig{PublicKey: s.webPush.PublicKey()}), nil
+}
+
+func (s *Service) CreateWebPushSubscription(ctx context.Context, req *connect.Request[CreateWebPushSubscriptionRequest]) (*connect.Response[Empty], error) {
+	if req.Msg.Endpoint == "" || req.Msg.P256Dh == "" || req.Msg.Auth == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("endpoint, p256dh and auth are required"))
+	}
+
+	agentIDs := []string{}
+	if s.AgentScope != nil {
+		if ids := s.AgentScope(ctx); len(ids) > 0 {
+			agentIDs = ids
+		}
+	}
+	b, err := json.Marshal(agentIDs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := s.queries.UpsertWebPushSubscription(ctx, store.UpsertWebPushSubscriptionParams{
+		ID:        uuid.NewString(),
+		Endpoint:  req.Msg.Endpoint,
+		P256dh:    req.Msg.P256Dh,
+		Auth:      req.Msg.Auth,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		AgentIds:  string(b),
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&Empty{}), nil
+}
+
+func (s *Service) DeleteWebPushSubscription(ctx context.Context, req *connect.Request[DeleteWebPushSubscriptionRequest]) (*connect.Response[Empty], error) {
+	if err := s.queries.DeleteWebPushSubscription(ctx, req.Msg.Endpoint); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&Empty{}), nil
+}
@@ -0,0 +1,184 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// fakeProvider is an identity provider that issues an ID token for any
+// authorization code whose PKCE verifier matches.
+type fakeProvider struct {
+	srv    *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]any
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeProvider{key: key}
+
+	var challenge string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.srv.URL,
+			"authorization_endpoint": f.srv.URL + "/authorize",
+			"token_endpoint":         f.srv.URL + "/token",
+			"jwks_uri":               f.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		challenge = r.URL.Query().Get("code_challenge")
+		http.Redirect(w, r, r.URL.Query().Get("redirect_uri")+"?code=abc&state="+r.URL.Query().Get("state"), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != "abc" || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": f.sign(t, f.claims)})
+	})
+	f.srv = httptest.NewServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeProvider) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, f.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeProvider(t)
+	f.claims = map[string]any{
+		"iss":    f.srv.URL,
+		"aud":    "blippy",
+		"sub":    "user-1",
+		"email":  "ada@example.com",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"nonce":  "n1",
+		"groups": []string{"admins", "staff"},
+	}
+
+	p, err := Discover(ctx, Config{Issuer: f.srv.URL, ClientID: "blippy", ClientSecret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := RandomString()
+	authURL := p.AuthCodeURL("http://blippy.test/callback", "s1", "n1", verifier)
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	callback, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := callback.Query().Get("state"); got != "s1" {
+		t.Fatalf("state = %q, want s1", got)
+	}
+
+	if _, err := p.Exchange(ctx, "http://blippy.test/callback", "abc", "wrong"); err == nil {
+		t.Error("Exchange with wrong verifier succeeded")
+	}
+	idToken, err := p.Exchange(ctx, "http://blippy.test/callback", callback.Query().Get("code"), verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := p.Verify(ctx, idToken, "n1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "user-1" || claims.Email != "ada@example.com" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if groups := claims.Strings("groups"); len(groups) != 2 || groups[0] != "admins" {
+		t.Errorf("groups = %v", groups)
+	}
+
+	if _, err := p.Verify(ctx, idToken, "other"); err == nil {
+		t.Error("Verify with wrong nonce succeeded")
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeProvider(t)
+	p, err := Discover(ctx, Config{Issuer: f.srv.URL, ClientID: "blippy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"iss": f.srv.URL,
+			"aud": []string{"blippy"},
+			"sub": "user-1",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	if _, err := p.Verify(ctx, f.sign(t, valid()), ""); err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+
+	tests := map[string]func(map[string]any){
+		"expired":      func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"wrong issuer": func(c map[string]any) { c["iss"] = "https://evil.example" },
+		"wrong aud":    func(c map[string]any) { c["aud"] = "other" },
+		"no subject":   func(c map[string]any) { delete(c, "sub") },
+	}
+	for name, modify := range tests {
+		claims := valid()
+		modify(claims)
+		if _, err := p.Verify(ctx, f.sign(t, claims), ""); err == nil {
+			t.Errorf("%s: Verify succeeded", name)
+		}
+	}
+
+	tampered := f.sign(t, valid())
+	tampered = tampered[:len(tampered)-4] + "AAAA"
+	if _, err := p.Verify(ctx, tampered, ""); err == nil {
+		t.Error("tampered signature: Verify succeeded")
+	}
+}
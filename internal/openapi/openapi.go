@@ -0,0 +1,270 @@
+// Package openapi generates an OpenAPI 3.1 description of ConnectRPC services
+// from their protobuf descriptors. Unary RPCs are described as the JSON POST
+// endpoints the Connect protocol exposes them as, using the protobuf JSON
+// mapping for request and response bodies.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Info describes the API.
+type Info struct {
+	Title   string
+	Version string
+	// ServerURL is the base URL the services are mounted on, e.g. "/api".
+	ServerURL string
+}
+
+// Generate returns the OpenAPI document for the given fully-qualified service
+// names, which must be registered in the global registry.
+func Generate(info Info, services []string) ([]byte, error) {
+	g := &generator{schemas: make(map[string]any)}
+
+	paths := make(map[string]any)
+	var tags []any
+	for _, name := range services {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("find service %q: %w", name, err)
+		}
+		sd, ok := d.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a service", name)
+		}
+		tags = append(tags, map[string]any{"name": name})
+
+		methods := sd.Methods()
+		for i := range methods.Len() {
+			m := methods.Get(i)
+			paths["/"+name+"/"+string(m.Name())] = map[string]any{"post": g.operation(sd, m)}
+		}
+	}
+
+	doc := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   info.Title,
+			"version": info.Version,
+		},
+		"servers": []any{map[string]any{"url": info.ServerURL}},
+		"tags":    tags,
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []any{map[string]any{"apiKey": []any{}}},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Handler serves the OpenAPI document for the given services. The document
+// is generated once, so errors surface when the handler is created.
+func Handler(info Info, services []string) (http.Handler, error) {
+	doc, err := Generate(info, services)
+	if err != nil {
+		return nil, err
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(doc)
+	}), nil
+}
+
+type generator struct {
+	schemas map[string]any
+}
+
+func (g *generator) operation(sd protoreflect.ServiceDescriptor, m protoreflect.MethodDescriptor) map[string]any {
+	contentType := "application/json"
+	var description string
+	switch {
+	case m.IsStreamingClient() && m.IsStreamingServer():
+		contentType = "application/connect+json"
+		description = "Bidirectional streaming RPC; requires the Connect streaming protocol or gRPC over HTTP/2."
+	case m.IsStreamingClient():
+		contentType = "application/connect+json"
+		description = "Client streaming RPC; requires the Connect streaming protocol or gRPC."
+	case m.IsStreamingServer():
+		contentType = "application/connect+json"
+		description = "Server streaming RPC; the response is a stream of enveloped messages in the Connect streaming protocol."
+	}
+
+	op := map[string]any{
+		"operationId": string(sd.Name()) + "_" + string(m.Name()),
+		"tags":        []any{string(sd.FullName())},
+		"parameters": []any{map[string]any{
+			"name":     "Connect-Protocol-Version",
+			"in":       "header",
+			"required": contentType == "application/json",
+			"schema":   map[string]any{"type": "string", "const": "1"},
+		}},
+		"requestBody": map[string]any{
+			"required": true,
+			"content": map[string]any{
+				contentType: map[string]any{"schema": g.ref(m.Input())},
+			},
+		},
+		"responses": map[string]any{
+			"200": map[string]any{
+				"description": "Success",
+				"content": map[string]any{
+					contentType: map[string]any{"schema": g.ref(m.Output())},
+				},
+			},
+			"default": map[string]any{
+				"description": "Error",
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": g.connectError()},
+				},
+			},
+		},
+	}
+	if description != "" {
+		op["description"] = description
+	}
+	return op
+}
+
+// ref returns a reference to the schema of md, adding it and the messages it
+// uses to the components.
+func (g *generator) ref(md protoreflect.MessageDescriptor) map[string]any {
+	if s, ok := wellKnown(md); ok {
+		return s
+	}
+
+	name := string(md.FullName())
+	if _, ok := g.schemas[name]; !ok {
+		// Reserve the name first, so recursive messages terminate.
+		g.schemas[name] = nil
+		g.schemas[name] = g.message(md)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func (g *generator) message(md protoreflect.MessageDescriptor) map[string]any {
+	props := make(map[string]any)
+	fields := md.Fields()
+	for i := range fields.Len() {
+		f := fields.Get(i)
+		props[f.JSONName()] = g.field(f)
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+func (g *generator) field(f protoreflect.FieldDescriptor) map[string]any {
+	if f.IsMap() {
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": g.singular(f.MapValue()),
+		}
+	}
+	if f.IsList() {
+		return map[string]any{"type": "array", "items": g.singular(f)}
+	}
+	return g.singular(f)
+}
+
+// singular returns the schema of a single value of f, following the protobuf
+// JSON mapping.
+func (g *generator) singular(f protoreflect.FieldDescriptor) map[string]any {
+	switch f.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are encoded as strings, as JSON numbers can't hold
+		// them exactly.
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := f.Enum().Values()
+		names := make([]any, values.Len())
+		for i := range values.Len() {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.ref(f.Message())
+	}
+	return map[string]any{}
+}
+
+// wellKnown returns the schema of well-known types that have a special JSON
+// mapping.
+func wellKnown(md protoreflect.MessageDescriptor) (map[string]any, bool) {
+	name := string(md.FullName())
+	if !strings.HasPrefix(name, "google.protobuf.") {
+		return nil, false
+	}
+	switch strings.TrimPrefix(name, "google.protobuf.") {
+	case "Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}, true
+	case "Duration":
+		return map[string]any{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`}, true
+	case "FieldMask":
+		return map[string]any{"type": "string"}, true
+	case "Struct", "Any":
+		return map[string]any{"type": "object"}, true
+	case "Value":
+		return map[string]any{}, true
+	case "ListValue":
+		return map[string]any{"type": "array"}, true
+	case "Empty":
+		return map[string]any{"type": "object", "additionalProperties": false}, true
+	case "BoolValue":
+		return map[string]any{"type": "boolean"}, true
+	case "Int32Value", "UInt32Value":
+		return map[string]any{"type": "integer"}, true
+	case "Int64Value", "UInt64Value":
+		return map[string]any{"type": "string"}, true
+	case "FloatValue", "DoubleValue":
+		return map[string]any{"type": "number"}, true
+	case "StringValue":
+		return map[string]any{"type": "string"}, true
+	case "BytesValue":
+		return map[string]any{"type": "string", "format": "byte"}, true
+	}
+	return nil, false
+}
+
+// connectError returns the schema of a Connect error response body.
+func (g *generator) connectError() map[string]any {
+	const name = "connect.error"
+	if _, ok := g.schemas[name]; !ok {
+		g.schemas[name] = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "string", "examples": []any{"not_found"}},
+				"message": map[string]any{"type": "string"},
+				"details": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+			},
+		}
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/system"
+)
+
+func TestGenerate(t *testing.T) {
+	b, err := Generate(Info{Title: "Test", Version: "1", ServerURL: "/api"}, []string{system.SystemServiceName})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := doc.Paths["/blippy.system.SystemService/GetSystemStats"]["post"]; !ok {
+		t.Errorf("missing GetSystemStats operation, paths = %v", doc.Paths)
+	}
+
+	table, ok := doc.Components.Schemas["blippy.system.TableStats"]
+	if !ok {
+		t.Fatal("missing TableStats schema")
+	}
+	if got := table.Properties["rowCount"]["type"]; got != "string" {
+		t.Errorf("rowCount type = %v, want string (int64)", got)
+	}
+	if got := table.Properties["oldestCreatedAt"]["format"]; got != "date-time" {
+		t.Errorf("oldestCreatedAt format = %v, want date-time", got)
+	}
+
+	if _, err := Generate(Info{}, []string{"blippy.DoesNotExist"}); err == nil {
+		t.Error("Generate with unknown service succeeded")
+	}
+}
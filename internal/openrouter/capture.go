@@ -0,0 +1,117 @@
+package openrouter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxCaptureBytes caps the size of each captured body.
+const maxCaptureBytes = 1 << 20
+
+// Exchange is the raw HTTP exchange of a request to OpenRouter. The API key
+// isn't included, only its ID.
+type Exchange struct {
+	URL         string
+	KeyID       string
+	RequestBody string
+	// StatusCode is 0 if no response was received.
+	StatusCode      int
+	ResponseHeaders http.Header
+	// ResponseBody is the body as received, i.e. the event stream of
+	// streaming requests, up to where it was read.
+	ResponseBody string
+	// Truncated reports whether a body was cut off at 1 MiB.
+	Truncated bool
+}
+
+// Capture records the exchange of a request made with a context from
+// WithCapture, for debugging malformed requests and provider quirks. It's
+// safe to read while the request is still streaming.
+type Capture struct {
+	mu       sync.Mutex
+	exchange Exchange
+	body     strings.Builder
+}
+
+type captureKey struct{}
+
+// WithCapture returns a context that makes the client record the exchange
+// of the request made with it in c.
+func WithCapture(ctx context.Context, c *Capture) context.Context {
+	return context.WithValue(ctx, captureKey{}, c)
+}
+
+// captureFrom returns the capture of a request, or nil. The methods of a nil
+// capture do nothing.
+func captureFrom(ctx context.Context) *Capture {
+	c, _ := ctx.Value(captureKey{}).(*Capture)
+	return c
+}
+
+// Exchange returns what was captured so far.
+func (c *Capture) Exchange() Exchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.exchange
+	e.ResponseBody = c.body.String()
+	return e
+}
+
+func (c *Capture) setRequest(req *http.Request, key *poolKey) {
+	if c == nil {
+		return
+	}
+	var body []byte
+	if req.GetBody != nil {
+		if r, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(io.LimitReader(r, maxCaptureBytes+1))
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exchange.URL = req.URL.String()
+	c.exchange.KeyID = key.stats.ID
+	if len(body) > maxCaptureBytes {
+		body = body[:maxCaptureBytes]
+		c.exchange.Truncated = true
+	}
+	c.exchange.RequestBody = string(body)
+}
+
+// setResponse records the status and headers of a response, and tees its
+// body into the capture as it's read.
+func (c *Capture) setResponse(resp *http.Response) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.exchange.StatusCode = resp.StatusCode
+	c.exchange.ResponseHeaders = resp.Header.Clone()
+	c.mu.Unlock()
+	resp.Body = &captureBody{ReadCloser: resp.Body, capture: c}
+}
+
+func (c *Capture) write(p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	room := maxCaptureBytes - c.body.Len()
+	if len(p) > room {
+		p = p[:max(room, 0)]
+		c.exchange.Truncated = true
+	}
+	c.body.Write(p)
+}
+
+type captureBody struct {
+	io.ReadCloser
+	capture *Capture
+}
+
+func (b *captureBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.capture.write(p[:n])
+	return n, err
+}
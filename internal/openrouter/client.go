@@ -2,12 +2,14 @@ package openrouter
 
 import (
 	"bufio"
-	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,8 +18,9 @@ import (
 const baseURL = "https://openrouter.ai/api/v1"
 
 type Client struct {
-	apiKey     string
+	keys       *keyPool
 	httpClient *http.Client
+	retry      RetryPolicy
 
 	modelsMu      sync.Mutex
 	modelsCache   []Model
@@ -32,13 +35,56 @@ type Model struct {
 	CompletionPricing string
 }
 
+// Cost returns the cost in US dollars of a request to the model with the
+// given token usage. It reports false if the model's pricing is unknown.
+func (m Model) Cost(inputTokens, outputTokens int64) (float64, bool) {
+	prompt, err := strconv.ParseFloat(m.PromptPricing, 64)
+	if err != nil {
+		return 0, false
+	}
+	completion, err := strconv.ParseFloat(m.CompletionPricing, 64)
+	if err != nil {
+		return 0, false
+	}
+	return float64(inputTokens)*prompt + float64(outputTokens)*completion, true
+}
+
 func NewClient(apiKey string) *Client {
+	return NewClientWithKeys([]Key{{Secret: apiKey, Weight: 1}})
+}
+
+// NewClientWithKeys creates a client that spreads its requests over multiple
+// API keys, e.g. to stay within their rate limits.
+func NewClientWithKeys(keys []Key) *Client {
 	return &Client{
-		apiKey:     apiKey,
+		keys:       newKeyPool(keys),
 		httpClient: &http.Client{},
+		retry:      DefaultRetryPolicy,
 	}
 }
 
+// do sends a request authenticated with the next API key, and counts it
+// towards the key's usage.
+func (c *Client) do(req *http.Request) (*http.Response, *poolKey, error) {
+	key := c.keys.next()
+	resp, err := c.doWithKey(req, key)
+	return resp, key, err
+}
+
+func (c *Client) doWithKey(req *http.Request, key *poolKey) (*http.Response, error) {
+	req.Header.Set("Authorization", "Bearer "+key.secret)
+	capture := captureFrom(req.Context())
+	capture.setRequest(req, key)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.keys.recordStatus(key, 0)
+		return nil, err
+	}
+	c.keys.recordStatus(key, resp.StatusCode)
+	capture.setResponse(resp)
+	return resp, nil
+}
+
 type ResponseRequest struct {
 	Model              string           `json:"model"`
 	Input              []Input          `json:"input"`
@@ -46,6 +92,32 @@ type ResponseRequest struct {
 	PreviousResponseID string           `json:"previous_response_id,omitempty"`
 	Stream             bool             `json:"stream,omitempty"`
 	Tools              []map[string]any `json:"tools,omitempty"`
+	// Text configures the format of text output, e.g. structured output
+	// matching a JSON schema.
+	Text *TextConfig `json:"text,omitempty"`
+	// FallbackModels are tried in order if requests to Model keep failing
+	// with errors that are retried.
+	FallbackModels []string `json:"-"`
+}
+
+// TextConfig configures the text output of a response.
+type TextConfig struct {
+	Format TextFormat `json:"format"`
+}
+
+// TextFormat is the format of text output: "text", or "json_schema" for
+// structured output matching Schema.
+type TextFormat struct {
+	Type   string          `json:"type"`
+	Name   string          `json:"name,omitempty"`
+	Schema json.RawMessage `json:"schema,omitempty"`
+	Strict bool            `json:"strict,omitempty"`
+}
+
+// JSONSchemaText returns a text config for structured output matching
+// schema. Strict mode isn't set, as it only supports a subset of JSON Schema.
+func JSONSchemaText(name string, schema json.RawMessage) *TextConfig {
+	return &TextConfig{Format: TextFormat{Type: "json_schema", Name: name, Schema: schema}}
 }
 
 type Input struct {
@@ -63,14 +135,30 @@ type Input struct {
 
 // ContentPart represents a content element in a message
 type ContentPart struct {
-	Type string `json:"type"` // "input_text" or "output_text"
-	Text string `json:"text"`
+	Type string `json:"type"` // "input_text", "output_text", "input_image" or "input_file"
+	Text string `json:"text,omitempty"`
+	// ImageURL is the URL or data URL of an input_image.
+	ImageURL string `json:"image_url,omitempty"`
+	// Filename and FileData, a data URL, are the file of an input_file.
+	Filename string `json:"filename,omitempty"`
+	FileData string `json:"file_data,omitempty"`
 }
 
 type Response struct {
 	ID     string         `json:"id"`
 	Output []OutputItem   `json:"output"`
 	Error  *ResponseError `json:"error,omitempty"`
+	Usage  *Usage         `json:"usage,omitempty"`
+}
+
+// Usage is the number of tokens used for a response.
+type Usage struct {
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+	TotalTokens  int64 `json:"total_tokens"`
+	// Cost is the cost of the response in US dollars (credits), if the
+	// provider reported it.
+	Cost *float64 `json:"cost,omitempty"`
 }
 
 type OutputItem struct {
@@ -82,6 +170,23 @@ type OutputItem struct {
 	Arguments string        `json:"arguments,omitempty"` // function args as JSON string
 }
 
+// StatusError is returned for responses with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the delay the response asked for with Retry-After.
+	RetryAfter time.Duration
+}
+
+func newStatusError(resp *http.Response) *StatusError {
+	body, _ := io.ReadAll(resp.Body)
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
 type ResponseError struct {
 	Message string `json:"message"`
 	Code    string `json:"code"`
@@ -95,37 +200,26 @@ type StreamEvent struct {
 	Name           string    `json:"name,omitempty"`            // function name
 	CallID         string    `json:"call_id,omitempty"`         // function call ID
 	ArgumentsDelta string    `json:"arguments_delta,omitempty"` // streaming args
+	// Item is the output item of "response.output_item.added" and
+	// "response.output_item.done" events.
+	Item *OutputItem `json:"item,omitempty"`
+	// ItemID is the ID of the output item of "response.*.delta" events,
+	// e.g. the function call of "response.function_call_arguments.delta".
+	ItemID string `json:"item_id,omitempty"`
 }
 
 func (c *Client) CreateResponse(ctx context.Context, req *ResponseRequest) (*Response, error) {
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/responses", bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
-
-	resp, err := c.httpClient.Do(httpReq)
+	resp, key, err := c.send(ctx, req, "")
 	if err != nil {
-		return nil, fmt.Errorf("do request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
-	}
-
 	var response Response
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
+	c.keys.recordUsage(key, response.Usage)
 
 	return &response, nil
 }
@@ -140,35 +234,13 @@ func (c *Client) CreateResponseStream(ctx context.Context, req *ResponseRequest)
 		defer close(events)
 		defer close(errs)
 
-		body, err := json.Marshal(req)
-		if err != nil {
-			errs <- fmt.Errorf("marshal request: %w", err)
-			return
-		}
-
-		httpReq, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/responses", bytes.NewReader(body))
+		resp, key, err := c.send(ctx, req, "text/event-stream")
 		if err != nil {
-			errs <- fmt.Errorf("create request: %w", err)
-			return
-		}
-
-		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
-		httpReq.Header.Set("Accept", "text/event-stream")
-
-		resp, err := c.httpClient.Do(httpReq)
-		if err != nil {
-			errs <- fmt.Errorf("do request: %w", err)
+			errs <- err
 			return
 		}
 		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			errs <- fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
-			return
-		}
-
 		scanner := bufio.NewScanner(resp.Body)
 		for scanner.Scan() {
 			line := scanner.Text()
@@ -185,6 +257,9 @@ func (c *Client) CreateResponseStream(ctx context.Context, req *ResponseRequest)
 			if err := json.Unmarshal([]byte(data), &event); err != nil {
 				continue // skip malformed events
 			}
+			if event.Type == "response.completed" && event.Response != nil {
+				c.keys.recordUsage(key, event.Response.Usage)
+			}
 
 			select {
 			case events <- event:
@@ -214,17 +289,15 @@ func (c *Client) ListModels(ctx context.Context) ([]Model, error) {
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, _, err := c.do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+		return nil, newStatusError(resp)
 	}
 
 	var result struct {
@@ -257,14 +330,77 @@ func (c *Client) ListModels(ctx context.Context) ([]Model, error) {
 	return models, nil
 }
 
-// GenerateTitle generates a brief conversation title from the first exchange.
-func (c *Client) GenerateTitle(ctx context.Context, model, userMessage, assistantResponse string) (string, error) {
-	prompt := fmt.Sprintf(`Generate a brief title (3-6 words) for this conversation:
+// ErrInvalidAPIKey is returned by CheckKey if OpenRouter rejects the key.
+var ErrInvalidAPIKey = errors.New("invalid API key")
 
-User: %s
-Assistant: %s
+// KeyInfo describes an OpenRouter API key.
+type KeyInfo struct {
+	Label string
+	// Limit is the key's credit limit, or nil if it has none.
+	Limit *float64
+	Usage float64
+}
 
-Reply with only the title, no quotes or explanation.`, userMessage, assistantResponse)
+// CheckKey validates the next API key of the client with OpenRouter and
+// returns its details.
+func (c *Client) CheckKey(ctx context.Context) (*KeyInfo, error) {
+	return c.checkKey(ctx, c.keys.next())
+}
+
+// CheckNewKey validates an API key that isn't one of the client's yet, e.g.
+// before adding it.
+func (c *Client) CheckNewKey(ctx context.Context, secret string) (*KeyInfo, error) {
+	return c.checkKey(ctx, &poolKey{secret: secret})
+}
+
+func (c *Client) checkKey(ctx context.Context, key *poolKey) (*KeyInfo, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/key", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.doWithKey(httpReq, key)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrInvalidAPIKey
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp)
+	}
+
+	var result struct {
+		Data struct {
+			Label string   `json:"label"`
+			Limit *float64 `json:"limit"`
+			Usage float64  `json:"usage"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &KeyInfo{Label: result.Data.Label, Limit: result.Data.Limit, Usage: result.Data.Usage}, nil
+}
+
+// DefaultTitlePrompt is the prompt generating conversation titles. "{user}"
+// and "{assistant}" are replaced with the first exchange.
+const DefaultTitlePrompt = `Generate a brief title (3-6 words) for this conversation:
+
+User: {user}
+Assistant: {assistant}
+
+Reply with only the title, no quotes or explanation.`
+
+// GenerateTitle generates a brief conversation title from the first exchange,
+// with prompt, or DefaultTitlePrompt if empty.
+func (c *Client) GenerateTitle(ctx context.Context, model, prompt, userMessage, assistantResponse string) (string, error) {
+	if prompt == "" {
+		prompt = DefaultTitlePrompt
+	}
+	prompt = strings.NewReplacer("{user}", userMessage, "{assistant}", assistantResponse).Replace(prompt)
 
 	req := &ResponseRequest{
 		Model: model,
@@ -292,3 +428,52 @@ Reply with only the title, no quotes or explanation.`, userMessage, assistantRes
 
 	return "", fmt.Errorf("no title in response")
 }
+
+// summaryPrompt is the prompt summarizing conversation history. "{summary}"
+// is replaced with the summary so far and "{transcript}" with the messages to
+// add to it.
+const summaryPrompt = `Summarize the earlier part of a conversation between a user and an AI assistant, so the assistant can continue it without the full history.
+
+Keep facts, decisions, open tasks, names, IDs and results of tool calls that may be needed later. Leave out pleasantries and details that no longer matter. Write in the third person, in at most a few paragraphs.
+
+Summary so far:
+{summary}
+
+Messages to add:
+{transcript}
+
+Reply with only the updated summary.`
+
+// Summarize updates summary, which may be empty, with a transcript of the
+// conversation messages that follow it.
+func (c *Client) Summarize(ctx context.Context, model, summary, transcript string) (string, error) {
+	prompt := strings.NewReplacer("{summary}", cmp.Or(summary, "(none)"), "{transcript}", transcript).Replace(summaryPrompt)
+
+	req := &ResponseRequest{
+		Model: model,
+		Input: []Input{
+			{
+				Type: "message",
+				Role: "user",
+				Content: []ContentPart{
+					{Type: "input_text", Text: prompt},
+				},
+			},
+		},
+	}
+
+	resp, err := c.CreateResponse(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("create response: %w", err)
+	}
+
+	for _, item := range resp.Output {
+		if item.Type == "message" && len(item.Content) > 0 {
+			if text := strings.TrimSpace(item.Content[0].Text); text != "" {
+				return text, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no summary in response")
+}
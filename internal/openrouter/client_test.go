@@ -0,0 +1,34 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestModelCost(t *testing.T) {
+	m := Model{ID: "a", PromptPricing: "0.000001", CompletionPricing: "0.000002"}
+	cost, ok := m.Cost(1000, 500)
+	if !ok || math.Abs(cost-0.002) > 1e-12 {
+		t.Errorf("Cost = %v, %v; want 0.002, true", cost, ok)
+	}
+	if _, ok := (Model{ID: "b"}).Cost(1000, 500); ok {
+		t.Error("Cost of model without pricing reported as priced")
+	}
+}
+
+func TestJSONSchemaText(t *testing.T) {
+	req := ResponseRequest{Model: "a", Text: JSONSchemaText("output", json.RawMessage(`{"type":"object"}`))}
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `"text":{"format":{"type":"json_schema","name":"output","schema":{"type":"object"}}}`
+	if !strings.Contains(string(b), want) {
+		t.Errorf("request = %s, want %s", b, want)
+	}
+	if b, _ := json.Marshal(ResponseRequest{Model: "a"}); strings.Contains(string(b), `"text"`) {
+		t.Errorf("request without text config = %s", b)
+	}
+}
@@ -0,0 +1,237 @@
+package openrouter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrKeyNotFound is returned by RemoveKey for unknown key IDs.
+	ErrKeyNotFound = errors.New("API key not found")
+	// ErrLastKey is returned by RemoveKey for the only key of a client.
+	ErrLastKey = errors.New("cannot remove the last API key")
+)
+
+// Key is an OpenRouter API key. Requests are spread over a client's keys in
+// proportion to their weights.
+type Key struct {
+	Secret string
+	Weight int
+}
+
+// ParseKeys parses comma-separated API keys, each optionally followed by
+// ":<weight>", e.g. "sk-or-v1-a:3,sk-or-v1-b". The weight defaults to 1.
+func ParseKeys(s string) ([]Key, error) {
+	var keys []Key
+	seen := make(map[string]bool)
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		secret, weight, hasWeight := strings.Cut(part, ":")
+		k := Key{Secret: secret, Weight: 1}
+		if hasWeight {
+			w, err := strconv.Atoi(weight)
+			if err != nil || w < 1 {
+				return nil, fmt.Errorf("invalid weight %q of API key %s", weight, KeyHint(secret))
+			}
+			k.Weight = w
+		}
+		if seen[secret] {
+			return nil, fmt.Errorf("duplicate API key %s", KeyHint(secret))
+		}
+		seen[secret] = true
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no API keys")
+	}
+	return keys, nil
+}
+
+// KeyID returns the ID of a key in KeyStats: a prefix of its SHA-256 hash,
+// so keys can be referred to without revealing them.
+func KeyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:6])
+}
+
+// KeyHint returns the last characters of a key, for recognizing it.
+func KeyHint(secret string) string {
+	if len(secret) <= 8 {
+		return "…"
+	}
+	return "…" + secret[len(secret)-4:]
+}
+
+// KeyStats is the usage of an API key since it was added to the client, e.g.
+// since the server started.
+type KeyStats struct {
+	ID     string
+	Hint   string
+	Weight int
+	// Requests counts all requests made with the key, including failed ones.
+	Requests int64
+	// Failures counts requests that failed or had an unexpected status.
+	Failures int64
+	// RateLimited counts failures with status 429.
+	RateLimited  int64
+	InputTokens  int64
+	OutputTokens int64
+	AddedAt      time.Time
+	LastUsedAt   time.Time
+}
+
+type poolKey struct {
+	secret string
+	stats  KeyStats
+	// current is the key's smooth weighted round-robin counter.
+	current int
+}
+
+// keyPool selects the API key of each request with smooth weighted
+// round-robin, as nginx does: keys are interleaved rather than used in runs,
+// so a key with weight 2 of 3 is used for every other request and then some.
+type keyPool struct {
+	mu   sync.Mutex
+	keys []*poolKey
+}
+
+func newKeyPool(keys []Key) *keyPool {
+	p := &keyPool{}
+	for _, k := range keys {
+		p.add(k)
+	}
+	return p
+}
+
+// next returns the key for a request and counts the request.
+func (p *keyPool) next() *poolKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var best *poolKey
+	total := 0
+	for _, k := range p.keys {
+		k.current += k.stats.Weight
+		total += k.stats.Weight
+		if best == nil || k.current > best.current {
+			best = k
+		}
+	}
+	if best == nil {
+		return &poolKey{}
+	}
+	best.current -= total
+	best.stats.Requests++
+	best.stats.LastUsedAt = time.Now().UTC()
+	return best
+}
+
+// recordStatus counts failed requests, with a status of 0 for requests that
+// got no response.
+func (p *keyPool) recordStatus(k *poolKey, status int) {
+	if status == http.StatusOK {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k.stats.Failures++
+	if status == http.StatusTooManyRequests {
+		k.stats.RateLimited++
+	}
+}
+
+func (p *keyPool) recordUsage(k *poolKey, u *Usage) {
+	if u == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k.stats.InputTokens += u.InputTokens
+	k.stats.OutputTokens += u.OutputTokens
+}
+
+// add adds a key, or updates the weight of a key that's already in the pool.
+func (p *keyPool) add(k Key) KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	weight := max(k.Weight, 1)
+	for _, pk := range p.keys {
+		if pk.secret == k.Secret {
+			pk.stats.Weight = weight
+			return pk.stats
+		}
+	}
+	pk := &poolKey{
+		secret: k.Secret,
+		stats: KeyStats{
+			ID:      KeyID(k.Secret),
+			Hint:    KeyHint(k.Secret),
+			Weight:  weight,
+			AddedAt: time.Now().UTC(),
+		},
+	}
+	p.keys = append(p.keys, pk)
+	return pk.stats
+}
+
+func (p *keyPool) remove(id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, pk := range p.keys {
+		if pk.stats.ID != id {
+			continue
+		}
+		if len(p.keys) == 1 {
+			return ErrLastKey
+		}
+		p.keys = append(p.keys[:i:i], p.keys[i+1:]...)
+		// Restart the rotation, so the remaining keys' counters, which sum
+		// to the removed key's negated counter, don't skew it.
+		for _, pk := range p.keys {
+			pk.current = 0
+		}
+		return nil
+	}
+	return ErrKeyNotFound
+}
+
+func (p *keyPool) stats() []KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]KeyStats, len(p.keys))
+	for i, pk := range p.keys {
+		stats[i] = pk.stats
+	}
+	return stats
+}
+
+// AddKey adds an API key to the client, without interrupting requests in
+// flight. Adding a key the client already has updates its weight.
+func (c *Client) AddKey(k Key) KeyStats {
+	return c.keys.add(k)
+}
+
+// RemoveKey removes the API key with an ID, e.g. after it leaked. Requests
+// in flight with the key aren't interrupted.
+func (c *Client) RemoveKey(id string) error {
+	return c.keys.remove(id)
+}
+
+// KeyStats returns the client's API keys with their usage, in the order they
+// were added.
+func (c *Client) KeyStats() []KeyStats {
+	return c.keys.stats()
+}
@@ -0,0 +1,97 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("sk-or-v1-aaaa:3, sk-or-v1-bbbb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Key{{Secret: "sk-or-v1-aaaa", Weight: 3}, {Secret: "sk-or-v1-bbbb", Weight: 1}}
+	if len(keys) != len(want) || keys[0] != want[0] || keys[1] != want[1] {
+		t.Errorf("ParseKeys = %+v, want %+v", keys, want)
+	}
+
+	for _, s := range []string{"", " , ", "sk-or-v1-aaaa:0", "sk-or-v1-aaaa:x", "sk-or-v1-aaaa,sk-or-v1-aaaa"} {
+		if _, err := ParseKeys(s); err == nil {
+			t.Errorf("ParseKeys(%q) succeeded, want error", s)
+		}
+	}
+}
+
+type keyRecorder struct {
+	keys   []string
+	status int
+}
+
+func (r *keyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.keys = append(r.keys, strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+	body := `{"id": "resp_1", "output": [], "usage": {"input_tokens": 10, "output_tokens": 5}}`
+	return &http.Response{
+		StatusCode: r.status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestClientKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	rec := &keyRecorder{status: http.StatusOK}
+	c := NewClientWithKeys([]Key{{Secret: "key-a-0001", Weight: 2}, {Secret: "key-b-0002", Weight: 1}})
+	c.httpClient = &http.Client{Transport: rec}
+
+	for range 6 {
+		if _, err := c.CreateResponse(ctx, &ResponseRequest{Model: "m"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Smooth weighted round-robin interleaves the keys.
+	want := "key-a-0001,key-b-0002,key-a-0001,key-a-0001,key-b-0002,key-a-0001"
+	if got := strings.Join(rec.keys, ","); got != want {
+		t.Errorf("keys = %s, want %s", got, want)
+	}
+
+	stats := c.KeyStats()
+	if stats[0].Requests != 4 || stats[0].InputTokens != 40 || stats[0].OutputTokens != 20 {
+		t.Errorf("stats of key a = %+v", stats[0])
+	}
+	if stats[1].ID != KeyID("key-b-0002") || stats[1].Hint != "…0002" || stats[1].Requests != 2 {
+		t.Errorf("stats of key b = %+v", stats[1])
+	}
+
+	// Rotate: add a new key and remove the old ones.
+	c.AddKey(Key{Secret: "key-c-0003"})
+	if err := c.RemoveKey(KeyID("key-a-0001")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RemoveKey(KeyID("key-b-0002")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RemoveKey(KeyID("key-c-0003")); !errors.Is(err, ErrLastKey) {
+		t.Errorf("removing last key: err = %v, want ErrLastKey", err)
+	}
+	if err := c.RemoveKey("unknown"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("removing unknown key: err = %v, want ErrKeyNotFound", err)
+	}
+
+	rec.keys = nil
+	rec.status = http.StatusTooManyRequests
+	c.SetRetryPolicy(RetryPolicy{})
+	if _, err := c.CreateResponse(ctx, &ResponseRequest{Model: "m"}); err == nil {
+		t.Error("CreateResponse succeeded with status 429")
+	}
+	if len(rec.keys) != 1 || rec.keys[0] != "key-c-0003" {
+		t.Errorf("keys after rotation = %v, want [key-c-0003]", rec.keys)
+	}
+	stats = c.KeyStats()
+	if len(stats) != 1 || stats[0].Failures != 1 || stats[0].RateLimited != 1 {
+		t.Errorf("stats after rotation = %+v", stats)
+	}
+}
@@ -0,0 +1,301 @@
+package openrouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMockText is the text of mock responses to requests no rule matches.
+const DefaultMockText = "This is a mock response."
+
+// MockFixture scripts the responses of a mock client, for developing and
+// testing without network access or an API key.
+type MockFixture struct {
+	// Models are returned by ListModels; defaults to a single "mock" model.
+	Models []MockModel `json:"models"`
+	// Rules are matched in order against the last user message of streaming
+	// requests, i.e. agent turns. The first matching rule responds.
+	Rules []MockRule `json:"rules"`
+	// Default is the text of responses to requests no rule matches, and to
+	// non-streaming requests such as title generation. Defaults to
+	// DefaultMockText.
+	Default string `json:"default"`
+}
+
+// MockModel is a model listed by a mock client.
+type MockModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// MockRule scripts the responses to turns with matching user messages.
+type MockRule struct {
+	// Match is a regular expression; empty matches any message.
+	Match string `json:"match"`
+	// Steps are the responses to the LLM round-trips of a turn, in order.
+	// The last step is repeated for further round-trips.
+	Steps []MockStep `json:"steps"`
+
+	re *regexp.Regexp
+}
+
+// MockStep is a scripted response.
+type MockStep struct {
+	// Text is streamed word by word.
+	Text string `json:"text,omitempty"`
+	// ToolCalls are function calls, with the tool names sent to the LLM.
+	ToolCalls []MockToolCall `json:"tool_calls,omitempty"`
+	// Error makes the request fail with this message and status 500.
+	Error string `json:"error,omitempty"`
+	// DelayMS delays the response, e.g. to test timeouts.
+	DelayMS int `json:"delay_ms,omitempty"`
+}
+
+// MockToolCall is a scripted function call.
+type MockToolCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// LoadMockFixture reads a mock fixture from a JSON file. Without a path, it
+// returns a fixture that responds to everything with DefaultMockText.
+func LoadMockFixture(path string) (*MockFixture, error) {
+	var f MockFixture
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read mock fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parse mock fixture: %w", err)
+		}
+	}
+	for i := range f.Rules {
+		re, err := regexp.Compile(f.Rules[i].Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match of rule %d: %w", i+1, err)
+		}
+		f.Rules[i].re = re
+		if len(f.Rules[i].Steps) == 0 {
+			return nil, fmt.Errorf("rule %d has no steps", i+1)
+		}
+	}
+	return &f, nil
+}
+
+// NewMockClient returns a client that responds with the scripted responses
+// of a fixture instead of calling OpenRouter.
+func NewMockClient(f *MockFixture) *Client {
+	return &Client{
+		keys:       newKeyPool([]Key{{Secret: "mock", Weight: 1}}),
+		httpClient: &http.Client{Transport: &mockTransport{fixture: f}},
+	}
+}
+
+// mockTransport serves the OpenRouter endpoints the client uses.
+type mockTransport struct {
+	fixture *MockFixture
+	nextID  atomic.Int64
+}
+
+func (t *mockTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/models"):
+		return t.models()
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/key"):
+		return mockResponse(http.StatusOK, "application/json", []byte(`{"data": {"label": "mock", "limit": null, "usage": 0}}`)), nil
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/responses"):
+		var req ResponseRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return mockResponse(http.StatusBadRequest, "text/plain", []byte(err.Error())), nil
+		}
+		return t.respond(r, &req)
+	default:
+		return mockResponse(http.StatusNotFound, "text/plain", []byte("not found")), nil
+	}
+}
+
+func (t *mockTransport) models() (*http.Response, error) {
+	models := t.fixture.Models
+	if len(models) == 0 {
+		models = []MockModel{{ID: "mock", Name: "Mock"}}
+	}
+	type pricing struct {
+		Prompt     string `json:"prompt"`
+		Completion string `json:"completion"`
+	}
+	type model struct {
+		ID      string  `json:"id"`
+		Name    string  `json:"name"`
+		Pricing pricing `json:"pricing"`
+	}
+	var result struct {
+		Data []model `json:"data"`
+	}
+	for _, m := range models {
+		result.Data = append(result.Data, model{ID: m.ID, Name: m.Name, Pricing: pricing{"0", "0"}})
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return mockResponse(http.StatusOK, "application/json", body), nil
+}
+
+func (t *mockTransport) respond(r *http.Request, req *ResponseRequest) (*http.Response, error) {
+	step := t.step(req)
+	if step.DelayMS > 0 {
+		select {
+		case <-time.After(time.Duration(step.DelayMS) * time.Millisecond):
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+	}
+	if step.Error != "" {
+		return mockResponse(http.StatusInternalServerError, "text/plain", []byte(step.Error)), nil
+	}
+
+	resp := &Response{
+		ID: fmt.Sprintf("mock-resp-%d", t.nextID.Add(1)),
+		Usage: &Usage{
+			InputTokens:  int64(len(req.Instructions)+inputLength(req.Input)) / 4,
+			OutputTokens: int64(len(step.Text)) / 4,
+		},
+	}
+	resp.Usage.TotalTokens = resp.Usage.InputTokens + resp.Usage.OutputTokens
+	if step.Text != "" {
+		resp.Output = append(resp.Output, OutputItem{
+			Type:    "message",
+			Content: []ContentPart{{Type: "output_text", Text: step.Text}},
+		})
+	}
+	for _, call := range step.ToolCalls {
+		id := t.nextID.Add(1)
+		args := "{}"
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, call.Arguments); err == nil {
+			args = buf.String()
+		}
+		resp.Output = append(resp.Output, OutputItem{
+			Type:      "function_call",
+			ID:        fmt.Sprintf("mock-fc-%d", id),
+			CallID:    fmt.Sprintf("mock-call-%d", id),
+			Name:      call.Name,
+			Arguments: args,
+		})
+	}
+
+	if !req.Stream {
+		body, err := json.Marshal(resp)
+		if err != nil {
+			return nil, err
+		}
+		return mockResponse(http.StatusOK, "application/json", body), nil
+	}
+
+	var events []StreamEvent
+	for _, word := range strings.SplitAfter(step.Text, " ") {
+		if word != "" {
+			events = append(events, StreamEvent{Type: "response.output_text.delta", Delta: word})
+		}
+	}
+	for _, item := range resp.Output {
+		if item.Type != "function_call" {
+			continue
+		}
+		added := item
+		added.Arguments = ""
+		events = append(events, StreamEvent{Type: "response.output_item.added", Item: &added})
+		for part := range slices.Chunk([]byte(item.Arguments), 16) {
+			events = append(events, StreamEvent{Type: "response.function_call_arguments.delta", ItemID: item.ID, Delta: string(part)})
+		}
+	}
+	events = append(events, StreamEvent{Type: "response.completed", Response: resp})
+
+	var body bytes.Buffer
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&body, "data: %s\n\n", data)
+	}
+	body.WriteString("data: [DONE]\n\n")
+	return mockResponse(http.StatusOK, "text/event-stream", body.Bytes()), nil
+}
+
+// step returns the scripted response to a request: the step of the first
+// matching rule for the number of round-trips the turn has made so far.
+func (t *mockTransport) step(req *ResponseRequest) MockStep {
+	def := MockStep{Text: t.fixture.Default}
+	if def.Text == "" {
+		def.Text = DefaultMockText
+	}
+	if !req.Stream {
+		return def
+	}
+
+	message, roundTrips := lastUserMessage(req.Input)
+	for _, rule := range t.fixture.Rules {
+		if rule.re != nil && rule.re.MatchString(message) {
+			return rule.Steps[min(roundTrips, len(rule.Steps)-1)]
+		}
+	}
+	return def
+}
+
+// lastUserMessage returns the text of the last user message of a request's
+// input, and the number of round-trips with function calls after it.
+func lastUserMessage(input []Input) (string, int) {
+	last := -1
+	for i, in := range input {
+		if in.Role == "user" {
+			last = i
+		}
+	}
+	if last < 0 {
+		return "", 0
+	}
+
+	var text strings.Builder
+	for _, part := range input[last].Content {
+		text.WriteString(part.Text)
+	}
+	// Each round-trip's function calls are echoed as a run of function_call
+	// items, followed by their outputs.
+	var roundTrips int
+	for i := last + 1; i < len(input); i++ {
+		if input[i].Type == "function_call" && input[i-1].Type != "function_call" {
+			roundTrips++
+		}
+	}
+	return text.String(), roundTrips
+}
+
+func inputLength(input []Input) int {
+	var n int
+	for _, in := range input {
+		for _, part := range in.Content {
+			n += len(part.Text)
+		}
+		n += len(in.Arguments) + len(in.Output)
+	}
+	return n
+}
+
+func mockResponse(status int, contentType string, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {contentType}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
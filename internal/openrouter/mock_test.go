@@ -0,0 +1,97 @@
+package openrouter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMockClient(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	fixture := `{
+		"rules": [
+			{"match": "(?i)weather", "steps": [
+				{"tool_calls": [{"name": "fetch", "arguments": {"url": "https://example.com"}}]},
+				{"text": "It's sunny."}
+			]},
+			{"match": "fail", "steps": [{"error": "overloaded"}]}
+		],
+		"default": "Hi there."
+	}`
+	if err := os.WriteFile(path, []byte(fixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := LoadMockFixture(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewMockClient(f)
+
+	stream := func(input []Input) (string, *Response, error) {
+		events, errs := c.CreateResponseStream(ctx, &ResponseRequest{Model: "mock", Input: input, Stream: true})
+		var text string
+		var resp *Response
+		for {
+			select {
+			case e, ok := <-events:
+				if !ok {
+					return text, resp, nil
+				}
+				text += e.Delta
+				if e.Response != nil {
+					resp = e.Response
+				}
+			case err := <-errs:
+				return text, resp, err
+			}
+		}
+	}
+
+	user := Input{Type: "message", Role: "user", Content: []ContentPart{{Type: "input_text", Text: "What's the Weather?"}}}
+	_, resp, err := stream([]Input{user})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp == nil || len(resp.Output) != 1 || resp.Output[0].Name != "fetch" || resp.Output[0].Arguments != `{"url":"https://example.com"}` {
+		t.Fatalf("first round-trip = %+v, want fetch call", resp)
+	}
+
+	call := resp.Output[0]
+	text, _, err := stream([]Input{
+		user,
+		{Type: "function_call", CallID: call.CallID, Name: call.Name, Arguments: call.Arguments},
+		{Type: "function_call_output", CallID: call.CallID, Output: "Sunny"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "It's sunny." {
+		t.Errorf("second round-trip text = %q, want scripted text", text)
+	}
+
+	user.Content[0].Text = "Hello"
+	if text, _, err := stream([]Input{user}); err != nil || text != "Hi there." {
+		t.Errorf("unmatched message: got %q, %v, want default text", text, err)
+	}
+
+	user.Content[0].Text = "Please fail"
+	if _, _, err := stream([]Input{user}); err == nil {
+		t.Error("scripted error: got no error")
+	}
+
+	title, err := c.GenerateTitle(ctx, "mock", "", "What's the weather?", "It's sunny.")
+	if err != nil || title == "" {
+		t.Errorf("GenerateTitle = %q, %v, want default text", title, err)
+	}
+
+	models, err := c.ListModels(ctx)
+	if err != nil || len(models) != 1 || models[0].ID != "mock" {
+		t.Errorf("ListModels = %+v, %v, want mock model", models, err)
+	}
+
+	if key, err := c.CheckKey(ctx); err != nil || key.Limit != nil {
+		t.Errorf("CheckKey = %+v, %v, want unlimited key", key, err)
+	}
+}
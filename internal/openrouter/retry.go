@@ -0,0 +1,148 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how LLM requests that fail with a rate limit, a
+// server error or a network error are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, per
+	// model. 0 disables retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. It doubles with
+	// each retry, with jitter, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest delay before a retry. Responses asking to
+	// retry later than that with Retry-After aren't retried.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of clients created with NewClient
+// and NewClientWithKeys.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+}
+
+// SetRetryPolicy sets how the client retries failed LLM requests.
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// backoff returns the delay before retry n, counting from 0.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.InitialBackoff << min(n, 30)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryable reports whether a request that failed with status, or with a
+// network error if status is 0, may succeed when sent again.
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfter returns the delay a response asks for with its Retry-After
+// header, in seconds or as a date, or 0 if it has none.
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// send posts a responses request and returns the response if its status is
+// 200 OK, with the key it was sent with. Failures are retried with the
+// client's retry policy; once the retries of the request's model are used up,
+// its FallbackModels are tried in order. Streams aren't retried once they've
+// started.
+func (c *Client) send(ctx context.Context, req *ResponseRequest, accept string) (*http.Response, *poolKey, error) {
+	var lastErr error
+	for _, model := range append([]string{req.Model}, req.FallbackModels...) {
+		r := *req
+		r.Model = model
+		body, err := json.Marshal(&r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal request: %w", err)
+		}
+
+		for attempt := 0; ; attempt++ {
+			resp, key, status, err := c.post(ctx, body, accept)
+			if err == nil {
+				return resp, key, nil
+			}
+			lastErr = err
+			if ctx.Err() != nil {
+				return nil, nil, err
+			}
+			if !retryable(status) {
+				return nil, nil, err
+			}
+			if attempt >= c.retry.MaxRetries {
+				break
+			}
+
+			delay := c.retry.backoff(attempt)
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+				if statusErr.RetryAfter > c.retry.MaxBackoff {
+					break
+				}
+				delay = statusErr.RetryAfter
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, nil, err
+			}
+		}
+	}
+	return nil, nil, lastErr
+}
+
+// post sends a request body to the responses endpoint. It returns the status
+// of failed responses, or 0 for network errors.
+func (c *Client) post(ctx context.Context, body []byte, accept string) (*http.Response, *poolKey, int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/responses", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if accept != "" {
+		httpReq.Header.Set("Accept", accept)
+	}
+
+	resp, key, err := c.do(httpReq)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("do request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		statusErr := newStatusError(resp)
+		statusErr.RetryAfter = retryAfter(resp, time.Now())
+		return nil, nil, resp.StatusCode, statusErr
+	}
+	return resp, key, resp.StatusCode, nil
+}
@@ -0,0 +1,120 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedTransport responds to requests with the next of its statuses, and
+// records the model of each request.
+type scriptedTransport struct {
+	statuses   []int
+	retryAfter string
+	models     []string
+}
+
+func (s *scriptedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var req ResponseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+	s.models = append(s.models, req.Model)
+
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	if status == 0 {
+		return nil, errors.New("connection reset")
+	}
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"id": "resp_1", "output": []}`)),
+	}
+	if status != http.StatusOK && s.retryAfter != "" {
+		resp.Header.Set("Retry-After", s.retryAfter)
+	}
+	return resp, nil
+}
+
+func newScriptedClient(transport *scriptedTransport) *Client {
+	c := NewClient("key")
+	c.httpClient = &http.Client{Transport: transport}
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+	return c
+}
+
+func TestClientRetry(t *testing.T) {
+	ctx := context.Background()
+
+	// Rate limits, server and network errors are retried.
+	transport := &scriptedTransport{statuses: []int{http.StatusTooManyRequests, 0, http.StatusOK}}
+	if _, err := newScriptedClient(transport).CreateResponse(ctx, &ResponseRequest{Model: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(transport.models, ","); got != "a,a,a" {
+		t.Errorf("models = %s, want 3 attempts with a", got)
+	}
+
+	// Other errors aren't.
+	transport = &scriptedTransport{statuses: []int{http.StatusBadRequest}}
+	_, err := newScriptedClient(transport).CreateResponse(ctx, &ResponseRequest{Model: "a", FallbackModels: []string{"b"}})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest || len(transport.models) != 1 {
+		t.Errorf("err = %v after %d attempts, want status 400 after 1", err, len(transport.models))
+	}
+
+	// Fallback models are tried after the retries of the model are used up.
+	transport = &scriptedTransport{statuses: []int{502, 502, 502, 503, http.StatusOK}}
+	events, errs := newScriptedClient(transport).CreateResponseStream(ctx, &ResponseRequest{Model: "a", FallbackModels: []string{"b"}})
+	for range events {
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(transport.models, ","); got != "a,a,a,b,b" {
+		t.Errorf("models = %s, want a 3 times, then b", got)
+	}
+
+	// Retry-After beyond the maximum backoff isn't waited for.
+	transport = &scriptedTransport{statuses: []int{http.StatusTooManyRequests, http.StatusOK}, retryAfter: "120"}
+	_, err = newScriptedClient(transport).CreateResponse(ctx, &ResponseRequest{Model: "a"})
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter != 2*time.Minute || len(transport.models) != 1 {
+		t.Errorf("err = %v after %d attempts, want status 429 with Retry-After after 1", err, len(transport.models))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for v, want := range map[string]time.Duration{
+		"":                              0,
+		"3":                             3 * time.Second,
+		"Wed, 01 Jan 2025 12:00:10 GMT": 10 * time.Second,
+		"Wed, 01 Jan 2025 11:00:00 GMT": 0,
+		"soon":                          0,
+	} {
+		resp := &http.Response{Header: http.Header{}}
+		if v != "" {
+			resp.Header.Set("Retry-After", v)
+		}
+		if got := retryAfter(resp, now); got != want {
+			t.Errorf("retryAfter(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for n, upper := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if d := p.backoff(n); d < upper/2 || d > upper {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", n, d, upper/2, upper)
+		}
+	}
+}
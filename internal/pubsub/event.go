@@ -0,0 +1,74 @@
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// payloadOneof returns the descriptor of the Event payload. It can't be a
+// package variable, as the descriptor is built in the generated init.
+func payloadOneof() protoreflect.OneofDescriptor {
+	return (&Event{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+}
+
+var (
+	// jsonMarshal uses the field names of the proto file, e.g. "text_delta".
+	jsonMarshal = protojson.MarshalOptions{UseProtoNames: true}
+	// jsonUnmarshal ignores fields of newer versions.
+	jsonUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Type returns the name of the event's payload field, e.g. "text_delta", or
+// "" if it has none.
+func (e *Event) Type() string {
+	fd := e.ProtoReflect().WhichOneof(payloadOneof())
+	if fd == nil {
+		return ""
+	}
+	return string(fd.Name())
+}
+
+// IsEventType reports whether typ is the type of an event payload, see
+// Event.Type.
+func IsEventType(typ string) bool {
+	return payloadOneof().Fields().ByName(protoreflect.Name(typ)) != nil
+}
+
+// MarshalJSON encodes an event as JSON, with the field names of the proto
+// file.
+func MarshalJSON(e *Event) ([]byte, error) {
+	return jsonMarshal.Marshal(e)
+}
+
+// marshalPayload encodes an event's payload as JSON, returning its type.
+func marshalPayload(e *Event) (string, []byte, error) {
+	m := e.ProtoReflect()
+	fd := m.WhichOneof(payloadOneof())
+	if fd == nil {
+		return "", nil, errors.New("event has no payload")
+	}
+	data, err := jsonMarshal.Marshal(m.Get(fd).Message().Interface())
+	if err != nil {
+		return "", nil, err
+	}
+	return string(fd.Name()), data, nil
+}
+
+// unmarshalPayload decodes a payload encoded by marshalPayload into an event.
+func unmarshalPayload(typ string, data []byte) (*Event, error) {
+	fd := payloadOneof().Fields().ByName(protoreflect.Name(typ))
+	if fd == nil {
+		return nil, fmt.Errorf("unknown event type %q", typ)
+	}
+	e := &Event{}
+	m := e.ProtoReflect()
+	v := m.NewField(fd)
+	if err := jsonUnmarshal.Unmarshal(data, v.Message().Interface()); err != nil {
+		return nil, err
+	}
+	m.Set(fd, v)
+	return e, nil
+}
@@ -0,0 +1,158 @@
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+const (
+	// DefaultLeaseTTL is how long a lease is valid without being renewed.
+	DefaultLeaseTTL = time.Minute
+	// leaseRenewInterval is how often leases are renewed and expired leases
+	// are recovered.
+	leaseRenewInterval = DefaultLeaseTTL / 4
+	// leaseTimeout limits lease operations of SetBusy and friends, which
+	// have no context.
+	leaseTimeout = 5 * time.Second
+)
+
+// Leases records which conversations have an active turn, durably and
+// shared by replicas. Leases expire unless renewed, so the leases of a
+// server that crashed are recovered.
+type Leases interface {
+	// Acquire leases a conversation, reporting false if another holder has
+	// an unexpired lease on it.
+	Acquire(ctx context.Context, conversationID string) (bool, error)
+	// Release releases a lease acquired by Acquire.
+	Release(ctx context.Context, conversationID string) error
+	// Held reports whether a conversation has an unexpired lease.
+	Held(ctx context.Context, conversationID string) (bool, error)
+	// Renew extends all leases acquired by Acquire.
+	Renew(ctx context.Context) error
+	// Recover deletes expired leases, returning their conversation IDs.
+	Recover(ctx context.Context) ([]string, error)
+}
+
+// StoreLeases is a Leases backed by the conversation_leases table. Each
+// StoreLeases is a separate holder.
+type StoreLeases struct {
+	queries *store.Queries
+	holder  string
+	ttl     time.Duration
+}
+
+// NewStoreLeases returns Leases stored in the database, valid for
+// DefaultLeaseTTL.
+func NewStoreLeases(queries *store.Queries) *StoreLeases {
+	return &StoreLeases{queries: queries, holder: uuid.NewString(), ttl: DefaultLeaseTTL}
+}
+
+func (l *StoreLeases) Acquire(ctx context.Context, conversationID string) (bool, error) {
+	now := time.Now().UTC()
+	n, err := l.queries.AcquireConversationLease(ctx, store.AcquireConversationLeaseParams{
+		ConversationID: conversationID,
+		Holder:         l.holder,
+		AcquiredAt:     now.Format(time.RFC3339),
+		ExpiresAt:      now.Add(l.ttl).Format(time.RFC3339),
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (l *StoreLeases) Release(ctx context.Context, conversationID string) error {
+	return l.queries.ReleaseConversationLease(ctx, store.ReleaseConversationLeaseParams{
+		ConversationID: conversationID,
+		Holder:         l.holder,
+	})
+}
+
+func (l *StoreLeases) Held(ctx context.Context, conversationID string) (bool, error) {
+	n, err := l.queries.IsConversationLeased(ctx, store.IsConversationLeasedParams{
+		ConversationID: conversationID,
+		ExpiresAt:      time.Now().UTC().Format(time.RFC3339),
+	})
+	return n > 0, err
+}
+
+func (l *StoreLeases) Renew(ctx context.Context) error {
+	return l.queries.RenewConversationLeases(ctx, store.RenewConversationLeasesParams{
+		ExpiresAt: time.Now().Add(l.ttl).UTC().Format(time.RFC3339),
+		Holder:    l.holder,
+	})
+}
+
+func (l *StoreLeases) Recover(ctx context.Context) ([]string, error) {
+	return l.queries.DeleteExpiredConversationLeases(ctx, time.Now().UTC().Format(time.RFC3339))
+}
+
+// UseLeases makes the broker lease the conversations it marks as busy. Call
+// it before marking conversations as busy, and run MaintainLeases.
+func (b *Broker) UseLeases(leases Leases) {
+	b.mu.Lock()
+	b.leases = leases
+	b.mu.Unlock()
+}
+
+// MaintainLeases renews the broker's leases and recovers expired ones until
+// ctx is done. Recovered conversations get an error and a TurnDone event, so
+// clients stop waiting for a turn that was cut off.
+func (b *Broker) MaintainLeases(ctx context.Context) {
+	b.mu.RLock()
+	leases := b.leases
+	b.mu.RUnlock()
+	if leases == nil {
+		return
+	}
+
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+	for {
+		// Renew first, so this server's leases aren't recovered after a
+		// stall.
+		if err := leases.Renew(ctx); err != nil && ctx.Err() == nil {
+			b.logger.Error("failed to renew conversation leases", "error", err)
+		}
+		b.recoverLeases(ctx, leases)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// recoverLeases ends the turns of conversations with expired leases.
+func (b *Broker) recoverLeases(ctx context.Context, leases Leases) {
+	convIDs, err := leases.Recover(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			b.logger.Error("failed to recover expired conversation leases", "error", err)
+		}
+		return
+	}
+	for _, convID := range convIDs {
+		// A turn of this server whose lease expired, e.g. because renewing
+		// failed, is still running.
+		b.mu.RLock()
+		_, running := b.busy[convID]
+		b.mu.RUnlock()
+		if running {
+			continue
+		}
+		b.logger.Warn("recovered expired conversation lease, turn was cut off", "conversation", convID)
+		b.Publish(convID, &Event_Error{Error: &Error{Message: "agent turn was cut off by a server restart"}})
+		b.Publish(convID, &Event_TurnDone{TurnDone: &TurnDone{}})
+	}
+}
+
+// leaseContext returns a context for lease operations without a caller
+// context.
+func leaseContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), leaseTimeout)
+}
@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// Log persists published events, so subscribers can replay them.
+type Log interface {
+	// Append stores an event.
+	Append(ctx context.Context, conversationID string, e *Event) error
+	// After returns the events of a conversation with a sequence number
+	// greater than seq, in order.
+	After(ctx context.Context, conversationID string, seq int64) ([]*Event, error)
+	// LastSeq returns the sequence number of a conversation's last event, or
+	// 0 if it has none.
+	LastSeq(ctx context.Context, conversationID string) (int64, error)
+}
+
+// StoreLog is a Log backed by the events table. Payloads are stored as JSON,
+// with the name of their Event field as type.
+type StoreLog struct {
+	queries   *store.Queries
+	retention time.Duration
+}
+
+// NewStoreLog returns a Log that stores events in the database for
+// retention. Events are pruned by Prune.
+func NewStoreLog(queries *store.Queries, retention time.Duration) *StoreLog {
+	return &StoreLog{queries: queries, retention: retention}
+}
+
+func (l *StoreLog) Append(ctx context.Context, conversationID string, e *Event) error {
+	typ, data, err := marshalPayload(e)
+	if err != nil {
+		return err
+	}
+	return l.queries.CreateEvent(ctx, store.CreateEventParams{
+		ConversationID: conversationID,
+		Seq:            e.Sequence,
+		Type:           typ,
+		Payload:        string(data),
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func (l *StoreLog) After(ctx context.Context, conversationID string, seq int64) ([]*Event, error) {
+	rows, err := l.queries.ListEventsAfter(ctx, store.ListEventsAfterParams{
+		ConversationID: conversationID,
+		Seq:            seq,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, len(rows))
+	for i, row := range rows {
+		e, err := unmarshalPayload(row.Type, []byte(row.Payload))
+		if err != nil {
+			return nil, fmt.Errorf("decode event %d: %w", row.Seq, err)
+		}
+		e.Topic = ConversationTopic(conversationID)
+		e.Sequence = row.Seq
+		events[i] = e
+	}
+	return events, nil
+}
+
+func (l *StoreLog) LastSeq(ctx context.Context, conversationID string) (int64, error) {
+	return l.queries.GetLastEventSeq(ctx, conversationID)
+}
+
+// Prune deletes events older than the retention period. Each conversation's
+// last event is kept, so its sequence numbers keep increasing. It has the
+// signature of a scheduler job.
+func (l *StoreLog) Prune(ctx context.Context) error {
+	before := time.Now().Add(-l.retention).UTC().Format(time.RFC3339)
+	return l.queries.DeleteEventsBefore(ctx, before)
+}
@@ -1,99 +1,565 @@
+// Package pubsub delivers agent events to subscribers, such as the web UI
+// watching a conversation or an activity feed.
+//
+// Events are published to topics: a conversation's events, such as text
+// deltas, to "conversation:<id>", and activity, such as runs starting and
+// finishing, to "agent:<id>" and "instance". Conversation events get a
+// sequence number per conversation and, with a Log, are persisted so
+// subscribers can replay the events they missed.
+//
+// Events are protobuf messages (see proto/pubsub/pubsub.proto), so they can be
+// stored, relayed and streamed to clients in other languages as they are.
 package pubsub
 
-import "sync"
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InstanceTopic receives the activity of all agents.
+const InstanceTopic = "instance"
+
+// Topic prefixes.
+const (
+	ConversationTopicPrefix = "conversation:"
+	AgentTopicPrefix        = "agent:"
+)
+
+// ConversationTopic returns the topic of a conversation's events.
+func ConversationTopic(conversationID string) string {
+	return ConversationTopicPrefix + conversationID
+}
+
+// AgentTopic returns the topic of an agent's activity.
+func AgentTopic(agentID string) string {
+	return AgentTopicPrefix + agentID
+}
+
+// Payload is the payload of an Event, e.g. *Event_TextDelta.
+type Payload = isEvent_Payload
 
 // Broker manages per-topic event subscriptions.
 type Broker struct {
-	mu   sync.RWMutex
-	subs map[string]map[*Subscription]struct{}
-	busy map[string]struct{}
+	log     Log
+	logger  *slog.Logger
+	backend Backend
+
+	mu          sync.RWMutex
+	subs        map[*Subscription]struct{}
+	convs       map[string]*conversation
+	activitySeq int64
+	busy        map[string]struct{}
+	leases      Leases
+
+	// Counters, for Stats.
+	published int64
+	relayed   int64
+	dropped   int64
 }
 
-// Subscription receives events for a single conversation.
+// subscriptionBuffer is the capacity of a subscription's channel. The last
+// slot is reserved for Gap events.
+const subscriptionBuffer = 256
+
+// Subscription receives the events of topics matching its patterns. Events
+// are shared between subscriptions, and must not be modified.
 type Subscription struct {
-	conversationID string
-	C              <-chan any
-	ch             chan any
+	patterns  []string
+	C         <-chan *Event
+	ch        chan *Event
+	createdAt time.Time
+	dropped   int64
+	// types are the event types sent to the subscription, or nil for all.
+	types map[string]bool
+
+	// gap counts the events dropped since the last Gap event was sent, with
+	// the topic of the first one. Guarded by the broker's mu.
+	gap      *Gap
+	gapTopic string
+}
+
+// matches reports whether any of the subscription's patterns matches any of
+// the topics. Patterns ending in "*" match topics with the prefix before it,
+// so "agent:*" matches the activity of all agents, and "*" matches all
+// topics.
+func (s *Subscription) matches(topics ...string) bool {
+	for _, p := range s.patterns {
+		prefix, wildcard := strings.CutSuffix(p, "*")
+		for _, t := range topics {
+			if t == p || (wildcard && strings.HasPrefix(t, prefix)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// accepts reports whether an event passes the subscription's type filter.
+// Gap events always do.
+func (s *Subscription) accepts(e *Event) bool {
+	if s.types == nil {
+		return true
+	}
+	if _, ok := e.Payload.(*Event_Gap); ok {
+		return true
+	}
+	return s.types[e.Type()]
 }
 
-// New creates a new Broker.
-func New() *Broker {
+// SubscribeOption configures a Subscription.
+type SubscribeOption func(*Subscription)
+
+// OnlyTypes limits the events of a subscription to those of the given types,
+// see Event.Type, e.g. "message_done" and "turn_done" for clients that only
+// need to know when turns complete. Gap events are always sent. Without
+// types, all events are sent.
+func OnlyTypes(types ...string) SubscribeOption {
+	return func(s *Subscription) {
+		if len(types) == 0 {
+			return
+		}
+		s.types = make(map[string]bool, len(types))
+		for _, typ := range types {
+			s.types[typ] = true
+		}
+	}
+}
+
+// New creates a new Broker. Conversation events are persisted to log, if not
+// nil; otherwise they're only delivered to current subscribers.
+func New(log Log, logger *slog.Logger) *Broker {
 	return &Broker{
-		subs: make(map[string]map[*Subscription]struct{}),
-		busy: make(map[string]struct{}),
+		log:    log,
+		logger: logger,
+		subs:   make(map[*Subscription]struct{}),
+		convs:  make(map[string]*conversation),
+		busy:   make(map[string]struct{}),
 	}
 }
 
 // Subscribe returns a Subscription that receives events for the given conversation.
-func (b *Broker) Subscribe(conversationID string) *Subscription {
-	ch := make(chan any, 256)
+func (b *Broker) Subscribe(conversationID string, opts ...SubscribeOption) *Subscription {
+	return b.SubscribeTopics([]string{ConversationTopic(conversationID)}, opts...)
+}
+
+// SubscribeTopics returns a Subscription that receives the events of topics
+// matching any of the patterns, see Subscription. Events aren't replayed.
+func (b *Broker) SubscribeTopics(patterns []string, opts ...SubscribeOption) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe(patterns, opts)
+}
+
+// SubscribeFrom returns a Subscription that receives events for the given
+// conversation, and the logged events after seq that were published before
+// subscribing. Together, they have no gaps or duplicates. Replayed events are
+// filtered like the subscription's.
+func (b *Broker) SubscribeFrom(ctx context.Context, conversationID string, seq int64, opts ...SubscribeOption) (*Subscription, []*Event, error) {
+	// Holding the conversation's mu, all events up to last are logged and
+	// later ones are sent to the subscription.
+	c := b.acquire(conversationID)
+	c.mu.Lock()
+	err := b.loadSeq(conversationID, c)
+	last := c.seq
+	b.mu.Lock()
+	sub := b.subscribe([]string{ConversationTopic(conversationID)}, opts)
+	b.mu.Unlock()
+	c.mu.Unlock()
+	b.release(conversationID, c)
+	if err != nil {
+		b.Unsubscribe(sub)
+		return nil, nil, err
+	}
+	if b.log == nil || seq >= last {
+		return sub, nil, nil
+	}
+
+	events, err := b.log.After(ctx, conversationID, seq)
+	if err != nil {
+		b.Unsubscribe(sub)
+		return nil, nil, err
+	}
+	// Events published since subscribing are also delivered on the channel.
+	for i, e := range events {
+		if e.Sequence > last {
+			events = events[:i]
+			break
+		}
+	}
+	events = slices.DeleteFunc(events, func(e *Event) bool { return !sub.accepts(e) })
+	return sub, events, nil
+}
+
+// subscribe must be called with mu held.
+func (b *Broker) subscribe(patterns []string, opts []SubscribeOption) *Subscription {
+	ch := make(chan *Event, subscriptionBuffer)
 	sub := &Subscription{
-		conversationID: conversationID,
-		C:              ch,
-		ch:             ch,
+		patterns:  slices.Clone(patterns),
+		C:         ch,
+		ch:        ch,
+		createdAt: time.Now(),
 	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// conversation sequences the events of a conversation. Its mu is held while
+// an event is sequenced, logged and sent, so events are logged and delivered
+// in order without holding the broker's mu while they're written to the log.
+type conversation struct {
+	mu sync.Mutex
+	// seq is the sequence number of the last event.
+	seq    int64
+	loaded bool
+	// refs counts the callers using the conversation. Guarded by the
+	// broker's mu.
+	refs int
+}
 
+// acquire returns the state of a conversation, which must be released with
+// release.
+func (b *Broker) acquire(conversationID string) *conversation {
 	b.mu.Lock()
-	if b.subs[conversationID] == nil {
-		b.subs[conversationID] = make(map[*Subscription]struct{})
+	defer b.mu.Unlock()
+	c, ok := b.convs[conversationID]
+	if !ok {
+		c = &conversation{}
+		b.convs[conversationID] = c
 	}
-	b.subs[conversationID][sub] = struct{}{}
-	b.mu.Unlock()
+	c.refs++
+	return c
+}
 
-	return sub
+func (b *Broker) release(conversationID string, c *conversation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c.refs--
+	b.evict(conversationID, c)
+}
+
+// evict forgets the state of a conversation that's unused and has no active
+// turn; its last sequence number is loaded from the log again when needed.
+// Without a log, sequence numbers are only kept in memory, so the state is
+// kept. Must be called with mu held.
+func (b *Broker) evict(conversationID string, c *conversation) {
+	if _, busy := b.busy[conversationID]; busy || c.refs > 0 || b.log == nil {
+		return
+	}
+	delete(b.convs, conversationID)
+}
+
+// loadSeq loads the sequence number of a conversation's last event from the
+// log the first time. Must be called with c.mu held.
+func (b *Broker) loadSeq(conversationID string, c *conversation) error {
+	if c.loaded || b.log == nil {
+		return nil
+	}
+	seq, err := b.log.LastSeq(context.Background(), conversationID)
+	if err != nil {
+		return err
+	}
+	// Events of other replicas may have been relayed already.
+	c.seq = max(c.seq, seq)
+	c.loaded = true
+	return nil
 }
 
 // Unsubscribe removes a subscription and closes its channel.
 func (b *Broker) Unsubscribe(sub *Subscription) {
 	b.mu.Lock()
-	if subs, ok := b.subs[sub.conversationID]; ok {
-		delete(subs, sub)
-		if len(subs) == 0 {
-			delete(b.subs, sub.conversationID)
+	delete(b.subs, sub)
+	b.mu.Unlock()
+
+	close(sub.ch)
+}
+
+// Publish appends an event to the log, and sends it to all subscribers of
+// the conversation. Non-blocking: drops the event for slow subscribers, who
+// get a Gap event and can replay it from the log. Events of a conversation
+// are published one at a time, but the log is written without blocking
+// publishers and subscribers of other conversations.
+func (b *Broker) Publish(conversationID string, payload Payload) {
+	c := b.acquire(conversationID)
+	defer b.release(conversationID, c)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &Event{Topic: ConversationTopic(conversationID), Payload: payload}
+	if err := b.loadSeq(conversationID, c); err != nil {
+		b.logger.Error("failed to load last event sequence", "conversation_id", conversationID, "error", err)
+	} else {
+		c.seq++
+		e.Sequence = c.seq
+		if b.log != nil {
+			if err := b.log.Append(context.Background(), conversationID, e); err != nil {
+				b.logger.Error("failed to log event", "conversation_id", conversationID, "seq", e.Sequence, "error", err)
+			}
 		}
 	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.published++
+	b.send(e, e.Topic)
+	if b.backend != nil {
+		b.backend.Publish(e)
+	}
+}
+
+// PublishTransient sends an event to all subscribers of the conversation,
+// without logging or sequencing it, for events that are only of interest
+// while they happen, such as TurnProgress. Dropped events aren't reported
+// with a Gap event.
+func (b *Broker) PublishTransient(conversationID string, payload Payload) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e := &Event{Topic: ConversationTopic(conversationID), Payload: payload}
+	b.published++
+	b.send(e, e.Topic)
+	if b.backend != nil {
+		b.backend.Publish(e)
+	}
+}
+
+// PublishActivity sends an activity event to the subscribers of the agent's
+// topic and the instance topic. Activity isn't logged.
+func (b *Broker) PublishActivity(agentID string, payload Payload) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.activitySeq++
+	e := &Event{Topic: AgentTopic(agentID), Sequence: b.activitySeq, Payload: payload}
+	b.published++
+	b.send(e, e.Topic, InstanceTopic)
+	if b.backend != nil {
+		b.backend.Publish(e)
+	}
+}
+
+// Backend relays events between the brokers of blippy replicas, so clients
+// can follow conversations that run on another replica.
+type Backend interface {
+	// Publish queues an event for the other replicas. It must not block.
+	Publish(e *Event)
+	// Run sends queued events, and delivers the events of other replicas
+	// until ctx is done.
+	Run(ctx context.Context, deliver func(*Event)) error
+}
+
+// Relay relays published events to and from other replicas with backend,
+// until ctx is done. Events of other replicas are delivered to subscribers,
+// but not logged, as the replica that published them logged them.
+func (b *Broker) Relay(ctx context.Context, backend Backend) error {
+	b.mu.Lock()
+	b.backend = backend
 	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.backend = nil
+		b.mu.Unlock()
+	}()
+	return backend.Run(ctx, b.deliver)
+}
 
-	close(sub.ch)
+// deliver sends an event of another replica to subscribers.
+func (b *Broker) deliver(e *Event) {
+	convID, ok := strings.CutPrefix(e.Topic, ConversationTopicPrefix)
+	if !ok {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.relayed++
+		b.send(e, e.Topic, InstanceTopic)
+		return
+	}
+
+	c := b.acquire(convID)
+	defer b.release(convID, c)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Keeps sequence numbers increasing if the conversation moves here.
+	c.seq = max(c.seq, e.Sequence)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.relayed++
+	b.send(e, e.Topic)
 }
 
-// Publish sends an event to all subscribers of the conversation.
-// Non-blocking: drops the event for slow subscribers.
-func (b *Broker) Publish(conversationID string, event any) {
-	b.mu.RLock()
-	for sub := range b.subs[conversationID] {
-		select {
-		case sub.ch <- event:
-		default:
+// send delivers an event once to each subscription matching any of the
+// topics. Must be called with mu held.
+func (b *Broker) send(e *Event, topics ...string) {
+	for sub := range b.subs {
+		if sub.matches(topics...) && sub.accepts(e) && !sub.send(e) {
+			b.dropped++
 		}
 	}
-	b.mu.RUnlock()
+}
+
+// send delivers an event without blocking, reporting whether it was. If the
+// subscriber is too slow, the event is dropped and counted, and a Gap event is
+// sent once there's room, using the reserved slot. Must be called with the
+// broker's mu held.
+func (s *Subscription) send(e *Event) bool {
+	s.sendGap()
+	if len(s.ch) < cap(s.ch)-1 {
+		s.ch <- e
+		return true
+	}
+	// Events without a sequence number can't be replayed, so a Gap event
+	// wouldn't help.
+	if e.Sequence == 0 {
+		s.dropped++
+		return false
+	}
+	if s.gap == nil {
+		s.gap = &Gap{ResumeSequence: e.Sequence}
+		s.gapTopic = e.Topic
+	}
+	s.gap.Missed++
+	s.dropped++
+	s.sendGap()
+	return false
+}
+
+// sendGap sends the pending Gap event, if any and if there's room.
+func (s *Subscription) sendGap() {
+	if s.gap == nil || len(s.ch) == cap(s.ch) {
+		return
+	}
+	s.ch <- &Event{Topic: s.gapTopic, Payload: &Event_Gap{Gap: s.gap}}
+	s.gap = nil
+	s.gapTopic = ""
+}
+
+// Stats is a snapshot of a broker's state, for debugging.
+type Stats struct {
+	Subscriptions []SubscriptionStats
+	// BusyConversations are the IDs of conversations with an active turn.
+	BusyConversations []string
+	// PublishedEvents counts the events published on this replica, and
+	// RelayedEvents those received from other replicas.
+	PublishedEvents int64
+	RelayedEvents   int64
+	// DroppedEvents counts events dropped for slow subscribers.
+	DroppedEvents int64
+}
+
+// SubscriptionStats describes a subscription.
+type SubscriptionStats struct {
+	Patterns  []string
+	CreatedAt time.Time
+	// Buffered is the number of events waiting to be received, of Capacity.
+	Buffered int
+	Capacity int
+	// Dropped counts the events the subscriber missed.
+	Dropped int64
+}
+
+// Stats returns a snapshot of the broker's state. Subscriptions are ordered
+// by creation, and busy conversations by ID.
+func (b *Broker) Stats() Stats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := Stats{
+		PublishedEvents: b.published,
+		RelayedEvents:   b.relayed,
+		DroppedEvents:   b.dropped,
+	}
+	for sub := range b.subs {
+		stats.Subscriptions = append(stats.Subscriptions, SubscriptionStats{
+			Patterns:  slices.Clone(sub.patterns),
+			CreatedAt: sub.createdAt,
+			Buffered:  len(sub.ch),
+			Capacity:  cap(sub.ch),
+			Dropped:   sub.dropped,
+		})
+	}
+	slices.SortFunc(stats.Subscriptions, func(a, b SubscriptionStats) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	stats.BusyConversations = slices.Sorted(maps.Keys(b.busy))
+	return stats
 }
 
 // SetBusy marks a conversation as having an active turn.
-// Returns false if the conversation is already busy.
+// Returns false if the conversation is already busy, also on another replica
+// when the broker uses leases. If leases can't be acquired because of an
+// error, the conversation is only marked as busy on this server.
 func (b *Broker) SetBusy(conversationID string) bool {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	if _, ok := b.busy[conversationID]; ok {
+		b.mu.Unlock()
 		return false
 	}
 	b.busy[conversationID] = struct{}{}
-	return true
+	leases := b.leases
+	b.mu.Unlock()
+
+	if leases == nil {
+		return true
+	}
+	ctx, cancel := leaseContext()
+	defer cancel()
+	ok, err := leases.Acquire(ctx, conversationID)
+	if err != nil {
+		b.logger.Error("failed to acquire conversation lease", "conversation", conversationID, "error", err)
+		return true
+	}
+	if !ok {
+		b.mu.Lock()
+		delete(b.busy, conversationID)
+		b.mu.Unlock()
+	}
+	return ok
 }
 
 // ClearBusy unmarks a conversation as busy.
 func (b *Broker) ClearBusy(conversationID string) {
 	b.mu.Lock()
+	_, ok := b.busy[conversationID]
 	delete(b.busy, conversationID)
+	if c, found := b.convs[conversationID]; found {
+		b.evict(conversationID, c)
+	}
+	leases := b.leases
 	b.mu.Unlock()
+
+	if !ok || leases == nil {
+		return
+	}
+	ctx, cancel := leaseContext()
+	defer cancel()
+	if err := leases.Release(ctx, conversationID); err != nil {
+		b.logger.Error("failed to release conversation lease", "conversation", conversationID, "error", err)
+	}
 }
 
-// IsBusy checks if a conversation has an active turn.
+// IsBusy checks if a conversation has an active turn, also on another
+// replica when the broker uses leases.
 func (b *Broker) IsBusy(conversationID string) bool {
 	b.mu.RLock()
-	defer b.mu.RUnlock()
 	_, ok := b.busy[conversationID]
-	return ok
+	leases := b.leases
+	b.mu.RUnlock()
+
+	if ok || leases == nil {
+		return ok
+	}
+	ctx, cancel := leaseContext()
+	defer cancel()
+	held, err := leases.Held(ctx, conversationID)
+	if err != nil {
+		b.logger.Error("failed to check conversation lease", "conversation", conversationID, "error", err)
+	}
+	return held
 }
@@ -0,0 +1,1660 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pubsub/pubsub.proto
+
+package pubsub
+
+import (
+	apierror "github.com/dstotijn/blippy/internal/apierror"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Event is an event published to a topic. Its payload is one of the known
+// event types, so clients in any language can decode the stream. Field names
+// are stable: new event types get new fields.
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "conversation:<id>" for conversation events, "agent:<id>" for activity.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Increases monotonically per conversation for conversation events, and
+	// per server for activity. 0 for events that couldn't be sequenced,
+	// transient events such as turn_progress, and gaps.
+	Sequence int64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_TextDelta
+	//	*Event_ToolResult
+	//	*Event_MessageDone
+	//	*Event_TurnStarted
+	//	*Event_TurnDone
+	//	*Event_Error
+	//	*Event_RunStarted
+	//	*Event_RunFinished
+	//	*Event_TriggerFired
+	//	*Event_Gap
+	//	*Event_TurnProgress
+	//	*Event_SubagentUpdate
+	//	*Event_ApprovalRequested
+	//	*Event_ApprovalResolved
+	//	*Event_ToolExecutionStarted
+	Payload       isEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Event) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Event) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Event) GetPayload() isEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetTextDelta() *TextDelta {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TextDelta); ok {
+			return x.TextDelta
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetToolResult() *ToolResult {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ToolResult); ok {
+			return x.ToolResult
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetMessageDone() *MessageDone {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_MessageDone); ok {
+			return x.MessageDone
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTurnStarted() *TurnStarted {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TurnStarted); ok {
+			return x.TurnStarted
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTurnDone() *TurnDone {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TurnDone); ok {
+			return x.TurnDone
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetRunStarted() *RunStarted {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_RunStarted); ok {
+			return x.RunStarted
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetRunFinished() *RunFinished {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_RunFinished); ok {
+			return x.RunFinished
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTriggerFired() *TriggerFired {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TriggerFired); ok {
+			return x.TriggerFired
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetGap() *Gap {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Gap); ok {
+			return x.Gap
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTurnProgress() *TurnProgress {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TurnProgress); ok {
+			return x.TurnProgress
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSubagentUpdate() *SubagentUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SubagentUpdate); ok {
+			return x.SubagentUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetApprovalRequested() *ApprovalRequested {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ApprovalRequested); ok {
+			return x.ApprovalRequested
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetApprovalResolved() *ApprovalResolved {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ApprovalResolved); ok {
+			return x.ApprovalResolved
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetToolExecutionStarted() *ToolExecutionStarted {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ToolExecutionStarted); ok {
+			return x.ToolExecutionStarted
+		}
+	}
+	return nil
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_TextDelta struct {
+	// Conversation events.
+	TextDelta *TextDelta `protobuf:"bytes,3,opt,name=text_delta,json=textDelta,proto3,oneof"`
+}
+
+type Event_ToolResult struct {
+	ToolResult *ToolResult `protobuf:"bytes,4,opt,name=tool_result,json=toolResult,proto3,oneof"`
+}
+
+type Event_MessageDone struct {
+	MessageDone *MessageDone `protobuf:"bytes,5,opt,name=message_done,json=messageDone,proto3,oneof"`
+}
+
+type Event_TurnStarted struct {
+	TurnStarted *TurnStarted `protobuf:"bytes,6,opt,name=turn_started,json=turnStarted,proto3,oneof"`
+}
+
+type Event_TurnDone struct {
+	TurnDone *TurnDone `protobuf:"bytes,7,opt,name=turn_done,json=turnDone,proto3,oneof"`
+}
+
+type Event_Error struct {
+	Error *Error `protobuf:"bytes,8,opt,name=error,proto3,oneof"`
+}
+
+type Event_RunStarted struct {
+	// Activity.
+	RunStarted *RunStarted `protobuf:"bytes,9,opt,name=run_started,json=runStarted,proto3,oneof"`
+}
+
+type Event_RunFinished struct {
+	RunFinished *RunFinished `protobuf:"bytes,10,opt,name=run_finished,json=runFinished,proto3,oneof"`
+}
+
+type Event_TriggerFired struct {
+	TriggerFired *TriggerFired `protobuf:"bytes,11,opt,name=trigger_fired,json=triggerFired,proto3,oneof"`
+}
+
+type Event_Gap struct {
+	// Sent to subscribers in place of events they missed.
+	Gap *Gap `protobuf:"bytes,12,opt,name=gap,proto3,oneof"`
+}
+
+type Event_TurnProgress struct {
+	// Conversation events that aren't logged.
+	TurnProgress *TurnProgress `protobuf:"bytes,13,opt,name=turn_progress,json=turnProgress,proto3,oneof"`
+}
+
+type Event_SubagentUpdate struct {
+	SubagentUpdate *SubagentUpdate `protobuf:"bytes,14,opt,name=subagent_update,json=subagentUpdate,proto3,oneof"`
+}
+
+type Event_ApprovalRequested struct {
+	// Conversation events of tool calls matching an approval rule.
+	ApprovalRequested *ApprovalRequested `protobuf:"bytes,15,opt,name=approval_requested,json=approvalRequested,proto3,oneof"`
+}
+
+type Event_ApprovalResolved struct {
+	ApprovalResolved *ApprovalResolved `protobuf:"bytes,16,opt,name=approval_resolved,json=approvalResolved,proto3,oneof"`
+}
+
+type Event_ToolExecutionStarted struct {
+	// Conversation event of a tool call the LLM is writing or that is
+	// running.
+	ToolExecutionStarted *ToolExecutionStarted `protobuf:"bytes,17,opt,name=tool_execution_started,json=toolExecutionStarted,proto3,oneof"`
+}
+
+func (*Event_TextDelta) isEvent_Payload() {}
+
+func (*Event_ToolResult) isEvent_Payload() {}
+
+func (*Event_MessageDone) isEvent_Payload() {}
+
+func (*Event_TurnStarted) isEvent_Payload() {}
+
+func (*Event_TurnDone) isEvent_Payload() {}
+
+func (*Event_Error) isEvent_Payload() {}
+
+func (*Event_RunStarted) isEvent_Payload() {}
+
+func (*Event_RunFinished) isEvent_Payload() {}
+
+func (*Event_TriggerFired) isEvent_Payload() {}
+
+func (*Event_Gap) isEvent_Payload() {}
+
+func (*Event_TurnProgress) isEvent_Payload() {}
+
+func (*Event_SubagentUpdate) isEvent_Payload() {}
+
+func (*Event_ApprovalRequested) isEvent_Payload() {}
+
+func (*Event_ApprovalResolved) isEvent_Payload() {}
+
+func (*Event_ToolExecutionStarted) isEvent_Payload() {}
+
+// ApprovalRequested is published when a tool call matches an approval rule
+// of its agent. The call waits until it's resolved with ResolveApproval or
+// expires, and is denied then.
+type ApprovalRequested struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalId string                 `protobuf:"bytes,1,opt,name=approval_id,json=approvalId,proto3" json:"approval_id,omitempty"`
+	RunId      string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The conversation of the run, which differs from the event's for calls
+	// of subagents.
+	ConversationId string `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string `protobuf:"bytes,5,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	ToolName       string `protobuf:"bytes,6,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	// JSON arguments of the call.
+	Input string `protobuf:"bytes,7,opt,name=input,proto3" json:"input,omitempty"`
+	// The rule the call matched, e.g. `bash command matches "rm|curl"`.
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// RFC 3339.
+	ExpiresAt     string `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalRequested) Reset() {
+	*x = ApprovalRequested{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalRequested) ProtoMessage() {}
+
+func (x *ApprovalRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalRequested.ProtoReflect.Descriptor instead.
+func (*ApprovalRequested) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApprovalRequested) GetApprovalId() string {
+	if x != nil {
+		return x.ApprovalId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+// ApprovalResolved is published when a tool call waiting for approval is
+// approved, denied or expires.
+type ApprovalResolved struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalId string                 `protobuf:"bytes,1,opt,name=approval_id,json=approvalId,proto3" json:"approval_id,omitempty"`
+	Approved   bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	// Why the call was denied, if given.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalResolved) Reset() {
+	*x = ApprovalResolved{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalResolved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalResolved) ProtoMessage() {}
+
+func (x *ApprovalResolved) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalResolved.ProtoReflect.Descriptor instead.
+func (*ApprovalResolved) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ApprovalResolved) GetApprovalId() string {
+	if x != nil {
+		return x.ApprovalId
+	}
+	return ""
+}
+
+func (x *ApprovalResolved) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ApprovalResolved) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// SubagentUpdate is live output of a subagent turn, forwarded to the
+// conversation whose turn called the agent.
+type SubagentUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	RunId string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The subagent's conversation.
+	ConversationId string `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string `protobuf:"bytes,4,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*SubagentUpdate_TextDelta
+	//	*SubagentUpdate_ToolResult
+	Update isSubagentUpdate_Update `protobuf_oneof:"update"`
+	// Set on the last update of a run, which has no output.
+	Done          bool `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubagentUpdate) Reset() {
+	*x = SubagentUpdate{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubagentUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubagentUpdate) ProtoMessage() {}
+
+func (x *SubagentUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubagentUpdate.ProtoReflect.Descriptor instead.
+func (*SubagentUpdate) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SubagentUpdate) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *SubagentUpdate) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SubagentUpdate) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SubagentUpdate) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *SubagentUpdate) GetUpdate() isSubagentUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *SubagentUpdate) GetTextDelta() *TextDelta {
+	if x != nil {
+		if x, ok := x.Update.(*SubagentUpdate_TextDelta); ok {
+			return x.TextDelta
+		}
+	}
+	return nil
+}
+
+func (x *SubagentUpdate) GetToolResult() *ToolResult {
+	if x != nil {
+		if x, ok := x.Update.(*SubagentUpdate_ToolResult); ok {
+			return x.ToolResult
+		}
+	}
+	return nil
+}
+
+func (x *SubagentUpdate) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type isSubagentUpdate_Update interface {
+	isSubagentUpdate_Update()
+}
+
+type SubagentUpdate_TextDelta struct {
+	TextDelta *TextDelta `protobuf:"bytes,5,opt,name=text_delta,json=textDelta,proto3,oneof"`
+}
+
+type SubagentUpdate_ToolResult struct {
+	ToolResult *ToolResult `protobuf:"bytes,6,opt,name=tool_result,json=toolResult,proto3,oneof"`
+}
+
+func (*SubagentUpdate_TextDelta) isSubagentUpdate_Update() {}
+
+func (*SubagentUpdate_ToolResult) isSubagentUpdate_Update() {}
+
+// TextDelta is a chunk of streamed text from the LLM.
+type TextDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextDelta) Reset() {
+	*x = TextDelta{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextDelta) ProtoMessage() {}
+
+func (x *TextDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextDelta.ProtoReflect.Descriptor instead.
+func (*TextDelta) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TextDelta) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// ToolResult is the outcome of a single tool execution.
+type ToolResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Input  string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Result string                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	// Set if the tool call failed; the result is then the error message.
+	ErrorCode     apierror.ErrorCode `protobuf:"varint,4,opt,name=error_code,json=errorCode,proto3,enum=blippy.apierror.ErrorCode" json:"error_code,omitempty"`
+	CallId        string             `protobuf:"bytes,5,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolResult) Reset() {
+	*x = ToolResult{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolResult) ProtoMessage() {}
+
+func (x *ToolResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolResult.ProtoReflect.Descriptor instead.
+func (*ToolResult) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ToolResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolResult) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ToolResult) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ToolResult) GetErrorCode() apierror.ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return apierror.ErrorCode(0)
+}
+
+func (x *ToolResult) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+// ToolExecutionStarted is published when the LLM starts a tool call, and
+// for each part of the call's arguments as the LLM streams them. The call
+// runs once the LLM's response is complete, until a ToolResult with the same
+// call_id.
+type ToolExecutionStarted struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	CallId string                 `protobuf:"bytes,1,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The next part of the JSON arguments; empty when the call starts.
+	ArgumentsDelta string `protobuf:"bytes,3,opt,name=arguments_delta,json=argumentsDelta,proto3" json:"arguments_delta,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ToolExecutionStarted) Reset() {
+	*x = ToolExecutionStarted{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolExecutionStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolExecutionStarted) ProtoMessage() {}
+
+func (x *ToolExecutionStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolExecutionStarted.ProtoReflect.Descriptor instead.
+func (*ToolExecutionStarted) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ToolExecutionStarted) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *ToolExecutionStarted) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolExecutionStarted) GetArgumentsDelta() string {
+	if x != nil {
+		return x.ArgumentsDelta
+	}
+	return ""
+}
+
+// MessageDone signals that a message has been persisted.
+type MessageDone struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Role      string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// JSON array of the message's items.
+	ItemsJson string `protobuf:"bytes,3,opt,name=items_json,json=itemsJson,proto3" json:"items_json,omitempty"`
+	Status    string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC 3339.
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageDone) Reset() {
+	*x = MessageDone{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDone) ProtoMessage() {}
+
+func (x *MessageDone) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageDone.ProtoReflect.Descriptor instead.
+func (*MessageDone) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MessageDone) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageDone) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *MessageDone) GetItemsJson() string {
+	if x != nil {
+		return x.ItemsJson
+	}
+	return ""
+}
+
+func (x *MessageDone) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MessageDone) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// TurnStarted signals that a new agent turn has begun.
+type TurnStarted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TurnStarted) Reset() {
+	*x = TurnStarted{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TurnStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnStarted) ProtoMessage() {}
+
+func (x *TurnStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnStarted.ProtoReflect.Descriptor instead.
+func (*TurnStarted) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{8}
+}
+
+// TurnDone signals that the agent turn has completed.
+type TurnDone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TurnDone) Reset() {
+	*x = TurnDone{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TurnDone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnDone) ProtoMessage() {}
+
+func (x *TurnDone) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnDone.ProtoReflect.Descriptor instead.
+func (*TurnDone) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TurnDone) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+// Error signals that an error occurred during processing.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Code          apierror.ErrorCode     `protobuf:"varint,2,opt,name=code,proto3,enum=blippy.apierror.ErrorCode" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetCode() apierror.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return apierror.ErrorCode(0)
+}
+
+// TurnProgress is published periodically while a turn is active, so clients
+// can show what the agent is doing and notice stuck turns.
+type TurnProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Milliseconds since the turn started.
+	ElapsedMs int64 `protobuf:"varint,1,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	// Names of the tools being executed, if any.
+	Tools []string `protobuf:"bytes,2,rep,name=tools,proto3" json:"tools,omitempty"`
+	// Tokens used in the turn so far, as reported by the LLM provider.
+	InputTokens   int64 `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64 `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TurnProgress) Reset() {
+	*x = TurnProgress{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TurnProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnProgress) ProtoMessage() {}
+
+func (x *TurnProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnProgress.ProtoReflect.Descriptor instead.
+func (*TurnProgress) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TurnProgress) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *TurnProgress) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *TurnProgress) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *TurnProgress) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+// RunStarted is published when an agent starts a turn.
+type RunStarted struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string                 `protobuf:"bytes,3,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	// Greater than 0 for turns of agents called by other agents.
+	Depth int32 `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Identifies the run for SystemService.CancelRun.
+	RunId string `protobuf:"bytes,5,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// What started the run: "interactive", "trigger", "webhook", "subagent",
+	// "eval", "replay" or "autonomous".
+	Kind          string `protobuf:"bytes,6,opt,name=kind,proto3" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunStarted) Reset() {
+	*x = RunStarted{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunStarted) ProtoMessage() {}
+
+func (x *RunStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunStarted.ProtoReflect.Descriptor instead.
+func (*RunStarted) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RunStarted) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RunStarted) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RunStarted) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *RunStarted) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *RunStarted) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *RunStarted) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+// RunFinished is published when an agent's turn ends.
+type RunFinished struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string                 `protobuf:"bytes,3,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	// "completed", "failed", "interrupted", "cancelled" or "timed_out".
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error  string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	RunId  string `protobuf:"bytes,6,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Set if the run failed or was stopped.
+	ErrorCode     apierror.ErrorCode `protobuf:"varint,7,opt,name=error_code,json=errorCode,proto3,enum=blippy.apierror.ErrorCode" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunFinished) Reset() {
+	*x = RunFinished{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunFinished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunFinished) ProtoMessage() {}
+
+func (x *RunFinished) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunFinished.ProtoReflect.Descriptor instead.
+func (*RunFinished) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RunFinished) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RunFinished) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RunFinished) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *RunFinished) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RunFinished) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RunFinished) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *RunFinished) GetErrorCode() apierror.ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return apierror.ErrorCode(0)
+}
+
+// TriggerFired is published when a trigger starts an agent run.
+type TriggerFired struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId      string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	TriggerName    string                 `protobuf:"bytes,2,opt,name=trigger_name,json=triggerName,proto3" json:"trigger_name,omitempty"`
+	AgentId        string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,4,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TriggerFired) Reset() {
+	*x = TriggerFired{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerFired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerFired) ProtoMessage() {}
+
+func (x *TriggerFired) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerFired.ProtoReflect.Descriptor instead.
+func (*TriggerFired) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TriggerFired) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *TriggerFired) GetTriggerName() string {
+	if x != nil {
+		return x.TriggerName
+	}
+	return ""
+}
+
+func (x *TriggerFired) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TriggerFired) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+// Gap is sent in place of events that were dropped because the subscriber
+// didn't keep up. Subscribers should re-fetch state, e.g. by replaying the
+// events of the gap's topic after resume_sequence - 1.
+type Gap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of events that were dropped.
+	Missed int32 `protobuf:"varint,1,opt,name=missed,proto3" json:"missed,omitempty"`
+	// Sequence number of the first missed event.
+	ResumeSequence int64 `protobuf:"varint,2,opt,name=resume_sequence,json=resumeSequence,proto3" json:"resume_sequence,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Gap) Reset() {
+	*x = Gap{}
+	mi := &file_pubsub_pubsub_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gap) ProtoMessage() {}
+
+func (x *Gap) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_pubsub_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gap.ProtoReflect.Descriptor instead.
+func (*Gap) Descriptor() ([]byte, []int) {
+	return file_pubsub_pubsub_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Gap) GetMissed() int32 {
+	if x != nil {
+		return x.Missed
+	}
+	return 0
+}
+
+func (x *Gap) GetResumeSequence() int64 {
+	if x != nil {
+		return x.ResumeSequence
+	}
+	return 0
+}
+
+var File_pubsub_pubsub_proto protoreflect.FileDescriptor
+
+const file_pubsub_pubsub_proto_rawDesc = "" +
+	"\n" +
+	"\x13pubsub/pubsub.proto\x12\rblippy.pubsub\x1a\x17apierror/apierror.proto\"\x9e\b\n" +
+	"\x05Event\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\x129\n" +
+	"\n" +
+	"text_delta\x18\x03 \x01(\v2\x18.blippy.pubsub.TextDeltaH\x00R\ttextDelta\x12<\n" +
+	"\vtool_result\x18\x04 \x01(\v2\x19.blippy.pubsub.ToolResultH\x00R\n" +
+	"toolResult\x12?\n" +
+	"\fmessage_done\x18\x05 \x01(\v2\x1a.blippy.pubsub.MessageDoneH\x00R\vmessageDone\x12?\n" +
+	"\fturn_started\x18\x06 \x01(\v2\x1a.blippy.pubsub.TurnStartedH\x00R\vturnStarted\x126\n" +
+	"\tturn_done\x18\a \x01(\v2\x17.blippy.pubsub.TurnDoneH\x00R\bturnDone\x12,\n" +
+	"\x05error\x18\b \x01(\v2\x14.blippy.pubsub.ErrorH\x00R\x05error\x12<\n" +
+	"\vrun_started\x18\t \x01(\v2\x19.blippy.pubsub.RunStartedH\x00R\n" +
+	"runStarted\x12?\n" +
+	"\frun_finished\x18\n" +
+	" \x01(\v2\x1a.blippy.pubsub.RunFinishedH\x00R\vrunFinished\x12B\n" +
+	"\rtrigger_fired\x18\v \x01(\v2\x1b.blippy.pubsub.TriggerFiredH\x00R\ftriggerFired\x12&\n" +
+	"\x03gap\x18\f \x01(\v2\x12.blippy.pubsub.GapH\x00R\x03gap\x12B\n" +
+	"\rturn_progress\x18\r \x01(\v2\x1b.blippy.pubsub.TurnProgressH\x00R\fturnProgress\x12H\n" +
+	"\x0fsubagent_update\x18\x0e \x01(\v2\x1d.blippy.pubsub.SubagentUpdateH\x00R\x0esubagentUpdate\x12Q\n" +
+	"\x12approval_requested\x18\x0f \x01(\v2 .blippy.pubsub.ApprovalRequestedH\x00R\x11approvalRequested\x12N\n" +
+	"\x11approval_resolved\x18\x10 \x01(\v2\x1f.blippy.pubsub.ApprovalResolvedH\x00R\x10approvalResolved\x12[\n" +
+	"\x16tool_execution_started\x18\x11 \x01(\v2#.blippy.pubsub.ToolExecutionStartedH\x00R\x14toolExecutionStartedB\t\n" +
+	"\apayload\"\x98\x02\n" +
+	"\x11ApprovalRequested\x12\x1f\n" +
+	"\vapproval_id\x18\x01 \x01(\tR\n" +
+	"approvalId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x05 \x01(\tR\tagentName\x12\x1b\n" +
+	"\ttool_name\x18\x06 \x01(\tR\btoolName\x12\x14\n" +
+	"\x05input\x18\a \x01(\tR\x05input\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\tR\texpiresAt\"g\n" +
+	"\x10ApprovalResolved\x12\x1f\n" +
+	"\vapproval_id\x18\x01 \x01(\tR\n" +
+	"approvalId\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xa1\x02\n" +
+	"\x0eSubagentUpdate\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x04 \x01(\tR\tagentName\x129\n" +
+	"\n" +
+	"text_delta\x18\x05 \x01(\v2\x18.blippy.pubsub.TextDeltaH\x00R\ttextDelta\x12<\n" +
+	"\vtool_result\x18\x06 \x01(\v2\x19.blippy.pubsub.ToolResultH\x00R\n" +
+	"toolResult\x12\x12\n" +
+	"\x04done\x18\a \x01(\bR\x04doneB\b\n" +
+	"\x06update\"%\n" +
+	"\tTextDelta\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\xa2\x01\n" +
+	"\n" +
+	"ToolResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\tR\x06result\x129\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\x0e2\x1a.blippy.apierror.ErrorCodeR\terrorCode\x12\x17\n" +
+	"\acall_id\x18\x05 \x01(\tR\x06callId\"l\n" +
+	"\x14ToolExecutionStarted\x12\x17\n" +
+	"\acall_id\x18\x01 \x01(\tR\x06callId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\x0farguments_delta\x18\x03 \x01(\tR\x0eargumentsDelta\"\x96\x01\n" +
+	"\vMessageDone\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"items_json\x18\x03 \x01(\tR\titemsJson\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\r\n" +
+	"\vTurnStarted\" \n" +
+	"\bTurnDone\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\"Q\n" +
+	"\x05Error\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12.\n" +
+	"\x04code\x18\x02 \x01(\x0e2\x1a.blippy.apierror.ErrorCodeR\x04code\"\x8b\x01\n" +
+	"\fTurnProgress\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x01 \x01(\x03R\telapsedMs\x12\x14\n" +
+	"\x05tools\x18\x02 \x03(\tR\x05tools\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\"\xb0\x01\n" +
+	"\n" +
+	"RunStarted\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x03 \x01(\tR\tagentName\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x12\x15\n" +
+	"\x06run_id\x18\x05 \x01(\tR\x05runId\x12\x12\n" +
+	"\x04kind\x18\x06 \x01(\tR\x04kind\"\xf0\x01\n" +
+	"\vRunFinished\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x03 \x01(\tR\tagentName\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x15\n" +
+	"\x06run_id\x18\x06 \x01(\tR\x05runId\x129\n" +
+	"\n" +
+	"error_code\x18\a \x01(\x0e2\x1a.blippy.apierror.ErrorCodeR\terrorCode\"\x94\x01\n" +
+	"\fTriggerFired\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\x12!\n" +
+	"\ftrigger_name\x18\x02 \x01(\tR\vtriggerName\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12'\n" +
+	"\x0fconversation_id\x18\x04 \x01(\tR\x0econversationId\"F\n" +
+	"\x03Gap\x12\x16\n" +
+	"\x06missed\x18\x01 \x01(\x05R\x06missed\x12'\n" +
+	"\x0fresume_sequence\x18\x02 \x01(\x03R\x0eresumeSequenceB,Z*github.com/dstotijn/blippy/internal/pubsubb\x06proto3"
+
+var (
+	file_pubsub_pubsub_proto_rawDescOnce sync.Once
+	file_pubsub_pubsub_proto_rawDescData []byte
+)
+
+func file_pubsub_pubsub_proto_rawDescGZIP() []byte {
+	file_pubsub_pubsub_proto_rawDescOnce.Do(func() {
+		file_pubsub_pubsub_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pubsub_pubsub_proto_rawDesc), len(file_pubsub_pubsub_proto_rawDesc)))
+	})
+	return file_pubsub_pubsub_proto_rawDescData
+}
+
+var file_pubsub_pubsub_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_pubsub_pubsub_proto_goTypes = []any{
+	(*Event)(nil),                // 0: blippy.pubsub.Event
+	(*ApprovalRequested)(nil),    // 1: blippy.pubsub.ApprovalRequested
+	(*ApprovalResolved)(nil),     // 2: blippy.pubsub.ApprovalResolved
+	(*SubagentUpdate)(nil),       // 3: blippy.pubsub.SubagentUpdate
+	(*TextDelta)(nil),            // 4: blippy.pubsub.TextDelta
+	(*ToolResult)(nil),           // 5: blippy.pubsub.ToolResult
+	(*ToolExecutionStarted)(nil), // 6: blippy.pubsub.ToolExecutionStarted
+	(*MessageDone)(nil),          // 7: blippy.pubsub.MessageDone
+	(*TurnStarted)(nil),          // 8: blippy.pubsub.TurnStarted
+	(*TurnDone)(nil),             // 9: blippy.pubsub.TurnDone
+	(*Error)(nil),                // 10: blippy.pubsub.Error
+	(*TurnProgress)(nil),         // 11: blippy.pubsub.TurnProgress
+	(*RunStarted)(nil),           // 12: blippy.pubsub.RunStarted
+	(*RunFinished)(nil),          // 13: blippy.pubsub.RunFinished
+	(*TriggerFired)(nil),         // 14: blippy.pubsub.TriggerFired
+	(*Gap)(nil),                  // 15: blippy.pubsub.Gap
+	(apierror.ErrorCode)(0),      // 16: blippy.apierror.ErrorCode
+}
+var file_pubsub_pubsub_proto_depIdxs = []int32{
+	4,  // 0: blippy.pubsub.Event.text_delta:type_name -> blippy.pubsub.TextDelta
+	5,  // 1: blippy.pubsub.Event.tool_result:type_name -> blippy.pubsub.ToolResult
+	7,  // 2: blippy.pubsub.Event.message_done:type_name -> blippy.pubsub.MessageDone
+	8,  // 3: blippy.pubsub.Event.turn_started:type_name -> blippy.pubsub.TurnStarted
+	9,  // 4: blippy.pubsub.Event.turn_done:type_name -> blippy.pubsub.TurnDone
+	10, // 5: blippy.pubsub.Event.error:type_name -> blippy.pubsub.Error
+	12, // 6: blippy.pubsub.Event.run_started:type_name -> blippy.pubsub.RunStarted
+	13, // 7: blippy.pubsub.Event.run_finished:type_name -> blippy.pubsub.RunFinished
+	14, // 8: blippy.pubsub.Event.trigger_fired:type_name -> blippy.pubsub.TriggerFired
+	15, // 9: blippy.pubsub.Event.gap:type_name -> blippy.pubsub.Gap
+	11, // 10: blippy.pubsub.Event.turn_progress:type_name -> blippy.pubsub.TurnProgress
+	3,  // 11: blippy.pubsub.Event.subagent_update:type_name -> blippy.pubsub.SubagentUpdate
+	1,  // 12: blippy.pubsub.Event.approval_requested:type_name -> blippy.pubsub.ApprovalRequested
+	2,  // 13: blippy.pubsub.Event.approval_resolved:type_name -> blippy.pubsub.ApprovalResolved
+	6,  // 14: blippy.pubsub.Event.tool_execution_started:type_name -> blippy.pubsub.ToolExecutionStarted
+	4,  // 15: blippy.pubsub.SubagentUpdate.text_delta:type_name -> blippy.pubsub.TextDelta
+	5,  // 16: blippy.pubsub.SubagentUpdate.tool_result:type_name -> blippy.pubsub.ToolResult
+	16, // 17: blippy.pubsub.ToolResult.error_code:type_name -> blippy.apierror.ErrorCode
+	16, // 18: blippy.pubsub.Error.code:type_name -> blippy.apierror.ErrorCode
+	16, // 19: blippy.pubsub.RunFinished.error_code:type_name -> blippy.apierror.ErrorCode
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_pubsub_pubsub_proto_init() }
+func file_pubsub_pubsub_proto_init() {
+	if File_pubsub_pubsub_proto != nil {
+		return
+	}
+	file_pubsub_pubsub_proto_msgTypes[0].OneofWrappers = []any{
+		(*Event_TextDelta)(nil),
+		(*Event_ToolResult)(nil),
+		(*Event_MessageDone)(nil),
+		(*Event_TurnStarted)(nil),
+		(*Event_TurnDone)(nil),
+		(*Event_Error)(nil),
+		(*Event_RunStarted)(nil),
+		(*Event_RunFinished)(nil),
+		(*Event_TriggerFired)(nil),
+		(*Event_Gap)(nil),
+		(*Event_TurnProgress)(nil),
+		(*Event_SubagentUpdate)(nil),
+		(*Event_ApprovalRequested)(nil),
+		(*Event_ApprovalResolved)(nil),
+		(*Event_ToolExecutionStarted)(nil),
+	}
+	file_pubsub_pubsub_proto_msgTypes[3].OneofWrappers = []any{
+		(*SubagentUpdate_TextDelta)(nil),
+		(*SubagentUpdate_ToolResult)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pubsub_pubsub_proto_rawDesc), len(file_pubsub_pubsub_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pubsub_pubsub_proto_goTypes,
+		DependencyIndexes: file_pubsub_pubsub_proto_depIdxs,
+		MessageInfos:      file_pubsub_pubsub_proto_msgTypes,
+	}.Build()
+	File_pubsub_pubsub_proto = out.File
+	file_pubsub_pubsub_proto_goTypes = nil
+	file_pubsub_pubsub_proto_depIdxs = nil
+}
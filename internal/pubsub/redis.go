@@ -0,0 +1,297 @@
+package pubsub
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultRedisChannel is the Redis channel events are relayed on.
+const DefaultRedisChannel = "blippy:events"
+
+const (
+	// redisQueueSize limits the events waiting to be sent to Redis.
+	redisQueueSize = 1024
+	// redisDialTimeout limits connecting to Redis.
+	redisDialTimeout = 5 * time.Second
+	// redisRetryDelay is how long to wait before reconnecting.
+	redisRetryDelay = time.Second
+)
+
+// RedisBackend is a Backend that relays events with Redis pub/sub. It speaks
+// just enough of the Redis protocol to publish and subscribe.
+type RedisBackend struct {
+	addr     string
+	tls      *tls.Config
+	username string
+	password string
+	channel  string
+	logger   *slog.Logger
+
+	// node identifies this replica, to ignore its own events.
+	node  string
+	queue chan *Event
+}
+
+// redisMessage is an event as relayed on the Redis channel.
+type redisMessage struct {
+	Node string `json:"node"`
+	// Event is the protobuf encoded event.
+	Event []byte `json:"event"`
+}
+
+// NewRedisBackend returns a Backend for the Redis server at rawURL, of the
+// form redis://[[user]:password@]host[:port], or rediss:// for TLS.
+func NewRedisBackend(rawURL string, logger *slog.Logger) (*RedisBackend, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	b := &RedisBackend{
+		addr:    u.Host,
+		channel: DefaultRedisChannel,
+		logger:  logger,
+		node:    uuid.NewString(),
+		queue:   make(chan *Event, redisQueueSize),
+	}
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		b.tls = &tls.Config{ServerName: u.Hostname()}
+	default:
+		return nil, fmt.Errorf("invalid Redis URL scheme %q, expected redis or rediss", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("invalid Redis URL: missing host")
+	}
+	if u.Port() == "" {
+		b.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		b.username = u.User.Username()
+		b.password, _ = u.User.Password()
+	}
+	return b, nil
+}
+
+func (b *RedisBackend) Publish(e *Event) {
+	select {
+	case b.queue <- e:
+	default:
+		b.logger.Warn("dropped event for Redis, queue is full", "topic", e.Topic, "seq", e.Sequence)
+	}
+}
+
+func (b *RedisBackend) Run(ctx context.Context, deliver func(*Event)) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.retry(ctx, "publish", b.publish)
+	}()
+	b.retry(ctx, "subscribe", func(ctx context.Context) error {
+		return b.subscribe(ctx, deliver)
+	})
+	<-done
+	return ctx.Err()
+}
+
+// retry runs fn until ctx is done, reconnecting after errors.
+func (b *RedisBackend) retry(ctx context.Context, name string, fn func(context.Context) error) {
+	for ctx.Err() == nil {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			b.logger.Error("Redis connection failed", "op", name, "error", err)
+		}
+		select {
+		case <-time.After(redisRetryDelay):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// publish sends queued events until ctx is done or the connection fails.
+func (b *RedisBackend) publish(ctx context.Context) error {
+	conn, err := b.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for {
+		select {
+		case e := <-b.queue:
+			msg, err := b.encode(e)
+			if err != nil {
+				b.logger.Error("failed to encode event for Redis", "topic", e.Topic, "error", err)
+				continue
+			}
+			if _, err := conn.do("PUBLISH", b.channel, string(msg)); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// subscribe delivers the events of other replicas until ctx is done or the
+// connection fails.
+func (b *RedisBackend) subscribe(ctx context.Context, deliver func(*Event)) error {
+	conn, err := b.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if _, err := conn.do("SUBSCRIBE", b.channel); err != nil {
+		return err
+	}
+	for {
+		v, err := conn.read()
+		if err != nil {
+			return err
+		}
+		// Messages are arrays of "message", the channel and the payload.
+		parts, ok := v.([]any)
+		if !ok || len(parts) != 3 || parts[0] != "message" {
+			continue
+		}
+		payload, _ := parts[2].(string)
+		e, ok, err := b.decode([]byte(payload))
+		if err != nil {
+			b.logger.Error("failed to decode event from Redis", "error", err)
+			continue
+		}
+		if ok {
+			deliver(e)
+		}
+	}
+}
+
+func (b *RedisBackend) encode(e *Event) ([]byte, error) {
+	data, err := proto.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(redisMessage{Node: b.node, Event: data})
+}
+
+// decode decodes a message, reporting false for this replica's own events.
+func (b *RedisBackend) decode(data []byte) (*Event, bool, error) {
+	var msg redisMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, false, err
+	}
+	if msg.Node == b.node {
+		return nil, false, nil
+	}
+	e := &Event{}
+	if err := proto.Unmarshal(msg.Event, e); err != nil {
+		return nil, false, err
+	}
+	return e, true, nil
+}
+
+// dial connects and authenticates to Redis.
+func (b *RedisBackend) dial(ctx context.Context) (*redisConn, error) {
+	dialer := &net.Dialer{Timeout: redisDialTimeout}
+	var c net.Conn
+	var err error
+	if b.tls != nil {
+		c, err = (&tls.Dialer{NetDialer: dialer, Config: b.tls}).DialContext(ctx, "tcp", b.addr)
+	} else {
+		c, err = dialer.DialContext(ctx, "tcp", b.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: c, r: bufio.NewReader(c)}
+	if b.password != "" {
+		args := []string{"AUTH", b.password}
+		if b.username != "" {
+			args = []string{"AUTH", b.username, b.password}
+		}
+		if _, err := conn.do(args...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("authenticate: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// redisConn is a connection speaking the Redis serialization protocol.
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// do sends a command and reads its reply.
+func (c *redisConn) do(args ...string) (any, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c, sb.String()); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+// read reads a reply. Error replies are returned as errors; arrays as []any
+// of strings, int64s, nils and arrays.
+func (c *redisConn) read() (any, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty Redis reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]any, n)
+		for i := range values {
+			if values[i], err = c.read(); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("unexpected Redis reply %q", line)
+}
@@ -0,0 +1,166 @@
+package reflection
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Service implements gRPC server reflection for the given services, so tools
+// like grpcurl and buf curl can list and call them without the proto files.
+// Descriptors are resolved from the global registry, which generated code
+// registers with on init.
+type Service struct {
+	services []string
+}
+
+// NewService returns a reflection service listing the given fully-qualified
+// service names, e.g. agent.AgentServiceName. The reflection service itself
+// is always listed.
+func NewService(services ...string) *Service {
+	names := append([]string{ServerReflectionName}, services...)
+	sort.Strings(names)
+	return &Service{services: names}
+}
+
+// Services returns the listed service names.
+func (s *Service) Services() []string {
+	return s.services
+}
+
+func (s *Service) ServerReflectionInfo(ctx context.Context, stream *connect.BidiStream[ServerReflectionRequest, ServerReflectionResponse]) error {
+	// Files already sent on this stream, so dependencies are sent only once.
+	sent := make(map[string]bool)
+
+	for {
+		req, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		res := &ServerReflectionResponse{
+			ValidHost:       req.GetHost(),
+			OriginalRequest: req,
+		}
+
+		switch r := req.GetMessageRequest().(type) {
+		case *ServerReflectionRequest_FileByFilename:
+			fd, err := protoregistry.GlobalFiles.FindFileByPath(r.FileByFilename)
+			res.MessageResponse = fileResponse(fd, err, sent)
+		case *ServerReflectionRequest_FileContainingSymbol:
+			d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(r.FileContainingSymbol))
+			var fd protoreflect.FileDescriptor
+			if err == nil {
+				fd = d.ParentFile()
+			}
+			res.MessageResponse = fileResponse(fd, err, sent)
+		case *ServerReflectionRequest_FileContainingExtension:
+			ext := r.FileContainingExtension
+			xt, err := protoregistry.GlobalTypes.FindExtensionByNumber(
+				protoreflect.FullName(ext.GetContainingType()),
+				protoreflect.FieldNumber(ext.GetExtensionNumber()),
+			)
+			var fd protoreflect.FileDescriptor
+			if err == nil {
+				fd = xt.TypeDescriptor().ParentFile()
+			}
+			res.MessageResponse = fileResponse(fd, err, sent)
+		case *ServerReflectionRequest_AllExtensionNumbersOfType:
+			name := protoreflect.FullName(r.AllExtensionNumbersOfType)
+			if _, err := protoregistry.GlobalTypes.FindMessageByName(name); err != nil {
+				res.MessageResponse = errorResponse(err)
+				break
+			}
+			var numbers []int32
+			protoregistry.GlobalTypes.RangeExtensionsByMessage(name, func(xt protoreflect.ExtensionType) bool {
+				numbers = append(numbers, int32(xt.TypeDescriptor().Number()))
+				return true
+			})
+			res.MessageResponse = &ServerReflectionResponse_AllExtensionNumbersResponse{
+				AllExtensionNumbersResponse: &ExtensionNumberResponse{
+					BaseTypeName:    string(name),
+					ExtensionNumber: numbers,
+				},
+			}
+		case *ServerReflectionRequest_ListServices:
+			list := &ListServiceResponse{}
+			for _, name := range s.services {
+				list.Service = append(list.Service, &ServiceResponse{Name: name})
+			}
+			res.MessageResponse = &ServerReflectionResponse_ListServicesResponse{ListServicesResponse: list}
+		default:
+			res.MessageResponse = &ServerReflectionResponse_ErrorResponse{
+				ErrorResponse: &ErrorResponse{
+					ErrorCode:    int32(connect.CodeInvalidArgument),
+					ErrorMessage: "invalid message request",
+				},
+			}
+		}
+
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// fileResponse returns fd and the dependencies not yet sent on the stream,
+// serialized as FileDescriptorProto messages.
+func fileResponse(fd protoreflect.FileDescriptor, err error, sent map[string]bool) isServerReflectionResponse_MessageResponse {
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	var files [][]byte
+	var add func(fd protoreflect.FileDescriptor, requested bool) error
+	add = func(fd protoreflect.FileDescriptor, requested bool) error {
+		// The requested file is always sent, as the client asked for it.
+		if sent[fd.Path()] && !requested {
+			return nil
+		}
+		sent[fd.Path()] = true
+
+		b, err := proto.Marshal(protodesc.ToFileDescriptorProto(fd))
+		if err != nil {
+			return err
+		}
+		files = append(files, b)
+
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			if err := add(imports.Get(i).FileDescriptor, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := add(fd, true); err != nil {
+		return errorResponse(err)
+	}
+
+	return &ServerReflectionResponse_FileDescriptorResponse{
+		FileDescriptorResponse: &FileDescriptorResponse{FileDescriptorProto: files},
+	}
+}
+
+func errorResponse(err error) *ServerReflectionResponse_ErrorResponse {
+	code := connect.CodeInternal
+	if errors.Is(err, protoregistry.NotFound) {
+		code = connect.CodeNotFound
+	}
+	return &ServerReflectionResponse_ErrorResponse{
+		ErrorResponse: &ErrorResponse{
+			ErrorCode:    int32(code),
+			ErrorMessage: err.Error(),
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package reflection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestServerReflectionInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(NewServerReflectionHandler(NewService()))
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	client := NewServerReflectionClient(srv.Client(), srv.URL, connect.WithGRPC())
+	stream := client.ServerReflectionInfo(context.Background())
+	t.Cleanup(func() { stream.CloseRequest() })
+
+	call := func(req *ServerReflectionRequest) *ServerReflectionResponse {
+		t.Helper()
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		res, err := stream.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	res := call(&ServerReflectionRequest{MessageRequest: &ServerReflectionRequest_ListServices{}})
+	services := res.GetListServicesResponse().GetService()
+	if len(services) != 1 || services[0].GetName() != ServerReflectionName {
+		t.Errorf("services = %v", services)
+	}
+
+	res = call(&ServerReflectionRequest{MessageRequest: &ServerReflectionRequest_FileContainingSymbol{
+		FileContainingSymbol: ServerReflectionName,
+	}})
+	files := res.GetFileDescriptorResponse().GetFileDescriptorProto()
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+	var fd descriptorpb.FileDescriptorProto
+	if err := proto.Unmarshal(files[0], &fd); err != nil {
+		t.Fatal(err)
+	}
+	if fd.GetName() != "reflection/reflection.proto" || fd.GetService()[0].GetName() != "ServerReflection" {
+		t.Errorf("file = %s, services = %v", fd.GetName(), fd.GetService())
+	}
+
+	res = call(&ServerReflectionRequest{MessageRequest: &ServerReflectionRequest_FileContainingSymbol{
+		FileContainingSymbol: "blippy.DoesNotExist",
+	}})
+	if code := res.GetErrorResponse().GetErrorCode(); code != int32(connect.CodeNotFound) {
+		t.Errorf("error code = %d, want %d", code, connect.CodeNotFound)
+	}
+
+	res = call(&ServerReflectionRequest{MessageRequest: &ServerReflectionRequest_FileByFilename{
+		FileByFilename: "google/protobuf/timestamp.proto",
+	}})
+	if n := len(res.GetFileDescriptorResponse().GetFileDescriptorProto()); n != 1 {
+		t.Errorf("got %d files for timestamp.proto, want 1", n)
+	}
+}
@@ -0,0 +1,208 @@
+// Package replica replicates the SQLite database to an S3-compatible bucket
+// by uploading periodic snapshots, and restores the latest snapshot when
+// the database is missing, e.g. on a fresh ephemeral host.
+package replica
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// snapshotSuffix is the file extension of uploaded snapshots.
+const snapshotSuffix = ".db.gz"
+
+// snapshotTimeFormat names snapshots so they sort chronologically.
+const snapshotTimeFormat = "20060102T150405Z"
+
+// Replicator uploads database snapshots to a bucket.
+type Replicator struct {
+	db       *sql.DB
+	client   *S3Client
+	prefix   string
+	interval time.Duration
+	retain   int
+	logger   *slog.Logger
+}
+
+// Options configure a Replicator.
+type Options struct {
+	Prefix   string        // key prefix, e.g. "blippy/"
+	Interval time.Duration // time between snapshots
+	Retain   int           // number of snapshots to keep; 0 keeps all
+}
+
+// New creates a Replicator.
+func New(db *sql.DB, client *S3Client, opts Options, logger *slog.Logger) *Replicator {
+	return &Replicator{
+		db:       db,
+		client:   client,
+		prefix:   opts.Prefix,
+		interval: opts.Interval,
+		retain:   opts.Retain,
+		logger:   logger,
+	}
+}
+
+// Run uploads a snapshot every interval until ctx is cancelled, then
+// uploads a final snapshot so no changes are lost on shutdown.
+func (r *Replicator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := r.Snapshot(ctx); err != nil {
+				r.logger.Error("failed to replicate database", "error", err)
+			}
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			if _, err := r.Snapshot(shutdownCtx); err != nil {
+				r.logger.Error("failed to replicate database on shutdown", "error", err)
+			}
+			cancel()
+			return
+		}
+	}
+}
+
+// Snapshot uploads a consistent copy of the database and prunes old
+// snapshots. Returns the key of the uploaded snapshot.
+func (r *Replicator) Snapshot(ctx context.Context) (string, error) {
+	dir, err := os.MkdirTemp("", "blippy-snapshot-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	// VACUUM INTO writes a transactionally consistent copy without blocking
+	// writers for longer than a read transaction.
+	path := filepath.Join(dir, "snapshot.db")
+	if _, err := r.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return "", fmt.Errorf("create snapshot: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	key := r.prefix + time.Now().UTC().Format(snapshotTimeFormat) + snapshotSuffix
+	if err := r.client.Put(ctx, key, buf.Bytes()); err != nil {
+		return "", fmt.Errorf("upload snapshot: %w", err)
+	}
+	r.logger.Info("replicated database", "key", key, "size", buf.Len())
+
+	if err := r.prune(ctx); err != nil {
+		r.logger.Error("failed to prune old snapshots", "error", err)
+	}
+	return key, nil
+}
+
+// prune deletes all but the newest retain snapshots.
+func (r *Replicator) prune(ctx context.Context) error {
+	if r.retain <= 0 {
+		return nil
+	}
+	keys, err := snapshotKeys(ctx, r.client, r.prefix)
+	if err != nil {
+		return err
+	}
+	if len(keys) <= r.retain {
+		return nil
+	}
+
+	var errs []error
+	for _, key := range keys[:len(keys)-r.retain] {
+		if err := r.client.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Restore downloads the newest snapshot to path. It does nothing and
+// returns false if path already exists or the bucket has no snapshots.
+func Restore(ctx context.Context, client *S3Client, prefix, path string) (bool, error) {
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	keys, err := snapshotKeys(ctx, client, prefix)
+	if err != nil {
+		return false, err
+	}
+	if len(keys) == 0 {
+		return false, nil
+	}
+	key := keys[len(keys)-1]
+
+	data, err := client.Get(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("download snapshot %s: %w", key, err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("decompress snapshot %s: %w", key, err)
+	}
+	defer zr.Close()
+
+	// Write to a temporary file first, so a failed restore doesn't leave a
+	// partial database behind.
+	tmp := path + ".restore"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return false, err
+	}
+	if _, err := f.ReadFrom(zr); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return false, fmt.Errorf("decompress snapshot %s: %w", key, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return false, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// snapshotKeys returns the keys of all snapshots, oldest first.
+func snapshotKeys(ctx context.Context, client *S3Client, prefix string) ([]string, error) {
+	all, err := client.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+
+	var keys []string
+	for _, key := range all {
+		name := strings.TrimPrefix(key, prefix)
+		if strings.HasSuffix(name, snapshotSuffix) && !strings.Contains(name, "/") {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
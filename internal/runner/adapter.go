@@ -1,6 +1,11 @@
 package runner
 
-import "context"
+import (
+	"context"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/tool"
+)
 
 // Adapter wraps Runner to implement tool.AgentCaller.
 type Adapter struct {
@@ -20,9 +25,42 @@ func (a *Adapter) RunAgent(ctx context.Context, agentID, prompt string, depth in
 		Depth:   depth,
 		Model:   model,
 		Title:   title,
+		Kind:    agentloop.RunKindSubagent,
+
+		ParentConversationID: tool.GetConversationID(ctx),
 	})
 	if err != nil {
 		return "", err
 	}
 	return result.Response, nil
 }
+
+// SpawnAgent implements tool.AgentSpawner.
+func (a *Adapter) SpawnAgent(ctx context.Context, agentID, prompt string, depth int, model, title string) (string, error) {
+	run, err := a.runner.Spawn(ctx, RunOpts{
+		AgentID: agentID,
+		Prompt:  prompt,
+		Depth:   depth,
+		Model:   model,
+		Title:   title,
+		Kind:    agentloop.RunKindSubagent,
+	})
+	if err != nil {
+		return "", err
+	}
+	return run.ID, nil
+}
+
+// AgentRun implements tool.AgentSpawner.
+func (a *Adapter) AgentRun(ctx context.Context, runID string) (tool.AgentRunState, error) {
+	run, err := a.runner.SpawnedRun(runID)
+	if err != nil {
+		return tool.AgentRunState{}, err
+	}
+	return tool.AgentRunState{
+		Status:         run.Status,
+		ConversationID: run.ConversationID,
+		Response:       run.Response,
+		Error:          run.Error,
+	}, nil
+}
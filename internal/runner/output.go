@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// ErrInvalidOutput is returned by Run when the final answer doesn't match
+// RunOpts.OutputSchema, also after a corrective turn.
+var ErrInvalidOutput = errors.New("final answer doesn't match the output schema")
+
+// outputInstructions asks the agent to end a run with a final answer that
+// matches schema.
+func outputInstructions(schema json.RawMessage) string {
+	return fmt.Sprintf(`## Final Answer
+Your final answer is processed by a program. Once you're done, reply with only a JSON value that matches this JSON Schema, without any other text:
+
+%s
+
+`, schema)
+}
+
+// correctionPrompt asks the agent to fix a final answer that didn't match
+// the output schema.
+func correctionPrompt(problems []string) string {
+	return "Your final answer doesn't match the required JSON Schema:\n- " +
+		strings.Join(problems, "\n- ") +
+		"\n\nReply with only the corrected JSON value, without any other text."
+}
+
+// ValidateOutputSchema checks that schema is a JSON Schema object, so runs
+// with a malformed schema are rejected before they start.
+func ValidateOutputSchema(schema json.RawMessage) error {
+	var s map[string]any
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return fmt.Errorf("output schema must be a JSON object: %w", err)
+	}
+	return nil
+}
+
+// parseOutput extracts the JSON value of a final answer, and validates it
+// against schema. It returns the problems found if the answer isn't valid.
+func parseOutput(schema json.RawMessage, response string) (json.RawMessage, []string) {
+	value := extractJSON(response)
+	if value == "" {
+		return nil, []string{"(root): the answer contains no JSON value"}
+	}
+	problems, err := tool.ValidateJSONSchema(schema, json.RawMessage(value))
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return json.RawMessage(value), nil
+}
+
+// extractJSON returns the JSON value in a final answer, which models tend to
+// wrap in a Markdown code block or a sentence, or "" if there is none.
+func extractJSON(s string) string {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "```"); ok {
+		// Skip the info string, e.g. "json".
+		if i := strings.IndexByte(rest, '\n'); i >= 0 {
+			rest = rest[i+1:]
+		}
+		s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(rest), "```"))
+	}
+	if json.Valid([]byte(s)) {
+		return s
+	}
+
+	for _, delims := range [][2]string{{"{", "}"}, {"[", "]"}} {
+		start, end := strings.Index(s, delims[0]), strings.LastIndex(s, delims[1])
+		if start >= 0 && end > start && json.Valid([]byte(s[start:end+1])) {
+			return s[start : end+1]
+		}
+	}
+	return ""
+}
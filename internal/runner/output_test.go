@@ -0,0 +1,34 @@
+package runner
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseOutput(t *testing.T) {
+	schema := json.RawMessage(`{"type": "object", "properties": {"score": {"type": "integer"}}, "required": ["score"]}`)
+
+	tests := []struct {
+		name     string
+		response string
+		want     string
+		problems bool
+	}{
+		{"plain", `{"score": 3}`, `{"score": 3}`, false},
+		{"code block", "```json\n{\"score\": 3}\n```", `{"score": 3}`, false},
+		{"sentence", `Here is the result: {"score": 3}. Done!`, `{"score": 3}`, false},
+		{"schema mismatch", `{"score": "high"}`, "", true},
+		{"no JSON", "I couldn't score this.", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, problems := parseOutput(schema, tt.response)
+			if (problems != nil) != tt.problems {
+				t.Fatalf("problems = %q, want problems: %v", problems, tt.problems)
+			}
+			if string(output) != tt.want {
+				t.Errorf("output = %s, want %s", output, tt.want)
+			}
+		})
+	}
+}
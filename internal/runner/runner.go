@@ -1,37 +1,46 @@
 package runner
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/locale"
 	"github.com/dstotijn/blippy/internal/pubsub"
 	"github.com/dstotijn/blippy/internal/store"
 	"github.com/dstotijn/blippy/internal/tool"
 )
 
-// autonomousInstructions is prepended to agent system prompts to ensure
-// the agent works without user interaction during scheduled/webhook runs.
-const autonomousInstructions = `You are running autonomously without user interaction. A user is NOT present and cannot respond to questions or provide feedback.
+// ErrConversationBusy is returned when a conversation is already processing
+// a turn.
+var ErrConversationBusy = agentloop.ErrConversationBusy
 
-CRITICAL: You must complete the task independently:
-- Do NOT ask clarifying questions or request user input
-- Make reasonable assumptions when details are ambiguous
-- Use your available tools to accomplish the task
-- If a tool call fails, immediately retry with a corrected approach - do not just explain what you would do
-- Keep working until the task is complete or truly impossible
-- Only stop with a text response when you have finished the task or cannot proceed
+// RunNotifier is told when a top-level autonomous run finishes, so users who
+// aren't watching the conversation can be alerted.
+type RunNotifier interface {
+	RunFinished(ctx context.Context, agent store.Agent, convID, response string, runErr error)
+}
 
-`
+// DefaultMaxRunDuration is the default wall-clock limit of autonomous runs.
+const DefaultMaxRunDuration = time.Hour
 
 // Runner executes agent conversations without streaming.
 type Runner struct {
-	queries *store.Queries
-	broker  *pubsub.Broker
-	loop    *agentloop.Loop
+	// MaxRunDuration limits how long runs started with Run and Spawn may
+	// take, unless RunOpts.MaxDuration is set. 0 means no limit.
+	MaxRunDuration time.Duration
+
+	queries  *store.Queries
+	broker   *pubsub.Broker
+	loop     *agentloop.Loop
+	notifier RunNotifier
+	spawned  spawnedRuns
 }
 
 // RunOpts configures a single agent run.
@@ -41,38 +50,91 @@ type RunOpts struct {
 	Depth   int
 	Model   string
 	Title   string
+	// SystemPrompt overrides the agent's system prompt, e.g. to evaluate a
+	// candidate prompt. Its persona sections still apply.
+	SystemPrompt string
+	// Kind is the agentloop.RunKind constant describing what started the
+	// run, agentloop.RunKindAutonomous if empty.
+	Kind string
+	// ParentConversationID is the conversation of the turn waiting for the
+	// run, if any, which gets the run's live output.
+	ParentConversationID string
+	// MaxDuration overrides Runner.MaxRunDuration for the run. Runs that take
+	// longer are stopped, their output so far is stored, and they fail with
+	// agentloop.ErrTimedOut.
+	MaxDuration time.Duration
+	// Priority overrides the agentloop.DefaultPriority of the run's kind, if
+	// greater than 0, for the order in which runs waiting for a slot of the
+	// agent's concurrency limit start.
+	Priority int
+	// OutputSchema is an optional JSON Schema the run's final answer must
+	// match. The agent is instructed to answer with JSON, and gets one
+	// corrective turn if its answer doesn't match; otherwise the run fails
+	// with ErrInvalidOutput. The parsed answer is RunResult.Output.
+	OutputSchema json.RawMessage
+
+	// Vars are variables of the run, exposed to tools (see
+	// tool.WithRunVars), and replacing "{{NAME}}" placeholders in Prompt.
+	Vars map[string]string
+
+	// TriggerID and TriggerName identify the trigger that started the run,
+	// if any, for the TriggerFired activity.
+	TriggerID   string
+	TriggerName string
 }
 
 // RunResult contains the outcome of an agent run.
 type RunResult struct {
 	ConversationID string
 	Response       string
+	// Output is the final answer parsed as JSON, for runs with an
+	// OutputSchema.
+	Output json.RawMessage
 }
 
-// New creates a new Runner.
-func New(queries *store.Queries, broker *pubsub.Broker, loop *agentloop.Loop) *Runner {
+// New creates a new Runner. The notifier is optional.
+func New(queries *store.Queries, broker *pubsub.Broker, loop *agentloop.Loop, notifier RunNotifier) *Runner {
 	return &Runner{
-		queries: queries,
-		broker:  broker,
-		loop:    loop,
+		queries:  queries,
+		broker:   broker,
+		loop:     loop,
+		notifier: notifier,
 	}
 }
 
 // Run executes a conversation with an agent and returns the final response.
 func (r *Runner) Run(ctx context.Context, opts RunOpts) (*RunResult, error) {
+	opts.Prompt = tool.ExpandRunVars(opts.Prompt, opts.Vars)
+	agent, conv, err := r.startRun(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+	return r.runTurn(ctx, opts, agent, conv, "")
+}
+
+// startRun creates the conversation of a run, and starts its turn. "{date}"
+// in the prompt and title of opts is replaced with the current date in the
+// agent's locale.
+func (r *Runner) startRun(ctx context.Context, opts *RunOpts) (store.Agent, store.Conversation, error) {
 	// Check depth limit
 	if opts.Depth > tool.DefaultMaxDepth {
-		return nil, fmt.Errorf("max depth exceeded: %d > %d", opts.Depth, tool.DefaultMaxDepth)
+		return store.Agent{}, store.Conversation{}, fmt.Errorf("max depth exceeded: %d > %d", opts.Depth, tool.DefaultMaxDepth)
 	}
 
 	// Fetch agent from database
 	agent, err := r.queries.GetAgent(ctx, opts.AgentID)
 	if err != nil {
-		return nil, fmt.Errorf("get agent: %w", err)
+		return store.Agent{}, store.Conversation{}, fmt.Errorf("get agent: %w", err)
+	}
+	if opts.SystemPrompt != "" {
+		agent.SystemPrompt = opts.SystemPrompt
 	}
 
-	// Create new conversation
 	now := time.Now().UTC()
+	opts.Prompt = locale.ExpandDate(opts.Prompt, agent.Locale, now)
+	opts.Title = locale.ExpandDate(opts.Title, agent.Locale, now)
+
+	// Create new conversation
 	conv, err := r.queries.CreateConversation(ctx, store.CreateConversationParams{
 		ID:                 uuid.NewString(),
 		AgentID:            opts.AgentID,
@@ -82,27 +144,132 @@ func (r *Runner) Run(ctx context.Context, opts RunOpts) (*RunResult, error) {
 		UpdatedAt:          now.Format(time.RFC3339),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("create conversation: %w", err)
+		return store.Agent{}, store.Conversation{}, fmt.Errorf("create conversation: %w", err)
 	}
 
-	// Mark conversation as busy and publish turn started
-	r.broker.SetBusy(conv.ID)
-	r.broker.Publish(conv.ID, agentloop.TurnStarted{})
+	if opts.TriggerID != "" {
+		r.broker.PublishActivity(agent.ID, &pubsub.Event_TriggerFired{TriggerFired: &pubsub.TriggerFired{
+			TriggerId:      opts.TriggerID,
+			TriggerName:    opts.TriggerName,
+			AgentId:        agent.ID,
+			ConversationId: conv.ID,
+		}})
+	}
 
-	// Save user message
-	if _, err := r.loop.SaveUserMessage(ctx, conv.ID, opts.Prompt); err != nil {
-		r.broker.ClearBusy(conv.ID)
-		return nil, fmt.Errorf("save user message: %w", err)
+	if _, _, err := r.loop.StartTurn(ctx, conv.ID, opts.Prompt); err != nil {
+		return store.Agent{}, store.Conversation{}, fmt.Errorf("start turn: %w", err)
+	}
+	return agent, conv, nil
+}
+
+// runTurn runs the turn of a run started with startRun.
+func (r *Runner) runTurn(ctx context.Context, opts RunOpts, agent store.Agent, conv store.Conversation, runID string) (*RunResult, error) {
+	turnCtx := ctx
+	if d := cmp.Or(opts.MaxDuration, r.MaxRunDuration); d > 0 {
+		var cancel context.CancelFunc
+		turnCtx, cancel = context.WithTimeoutCause(ctx, d, agentloop.ErrTimedOut)
+		defer cancel()
+	}
+
+	turnOpts := agentloop.TurnOpts{
+		Conv:          conv,
+		Agent:         agent,
+		UserContent:   opts.Prompt,
+		ModelOverride: opts.Model,
+		Depth:         opts.Depth,
+		Autonomous:    true,
+		Kind:          opts.Kind,
+		Priority:      opts.Priority,
+		Vars:          opts.Vars,
+		RunID:         runID,
+
+		ParentConversationID: opts.ParentConversationID,
+	}
+	if len(opts.OutputSchema) > 0 {
+		turnOpts.ExtraInstructions = outputInstructions(opts.OutputSchema)
+		turnOpts.OutputSchema = opts.OutputSchema
 	}
 
 	// Execute agentic loop
+	response, err := r.loop.RunTurn(turnCtx, turnOpts)
+	var output json.RawMessage
+	if err == nil && len(opts.OutputSchema) > 0 {
+		output, response, err = r.structuredOutput(turnCtx, opts.OutputSchema, turnOpts, response)
+	}
+	// Eval runs are checked by the eval that started them instead.
+	if r.notifier != nil && opts.Depth == 0 && opts.Kind != agentloop.RunKindEval {
+		r.notifier.RunFinished(ctx, agent, conv.ID, response, err)
+	}
+	if err != nil {
+		// The conversation holds the output so far, e.g. if interrupted.
+		return &RunResult{ConversationID: conv.ID, Response: response}, fmt.Errorf("run turn: %w", err)
+	}
+
+	return &RunResult{
+		ConversationID: conv.ID,
+		Response:       response,
+		Output:         output,
+	}, nil
+}
+
+// structuredOutput parses the final answer of a run with an output schema.
+// If it doesn't match, the agent is asked to correct it in another turn of
+// the conversation. It returns the parsed answer and the final response.
+func (r *Runner) structuredOutput(ctx context.Context, schema json.RawMessage, turnOpts agentloop.TurnOpts, response string) (json.RawMessage, string, error) {
+	output, problems := parseOutput(schema, response)
+	if problems == nil {
+		return output, response, nil
+	}
+
+	// The first turn updated the conversation, e.g. with a title.
+	conv, err := r.queries.GetConversation(ctx, turnOpts.Conv.ID)
+	if err != nil {
+		return nil, response, fmt.Errorf("get conversation: %w", err)
+	}
+	prompt := correctionPrompt(problems)
+	history, _, err := r.loop.StartTurn(ctx, conv.ID, prompt)
+	if err != nil {
+		return nil, response, fmt.Errorf("start correction turn: %w", err)
+	}
+	turnOpts.Conv = conv
+	turnOpts.UserContent = prompt
+	turnOpts.History = history
+	turnOpts.RunID = ""
+	response, err = r.loop.RunTurn(ctx, turnOpts)
+	if err != nil {
+		return nil, response, err
+	}
+
+	output, problems = parseOutput(schema, response)
+	if problems != nil {
+		return nil, response, fmt.Errorf("%w: %s", ErrInvalidOutput, strings.Join(problems, "; "))
+	}
+	return output, response, nil
+}
+
+// Continue adds a user message to an existing conversation and runs a turn.
+// It fails if the conversation is already processing.
+func (r *Runner) Continue(ctx context.Context, convID, prompt string) (*RunResult, error) {
+	conv, err := r.queries.GetConversation(ctx, convID)
+	if err != nil {
+		return nil, fmt.Errorf("get conversation: %w", err)
+	}
+
+	agent, err := r.queries.GetAgent(ctx, conv.AgentID)
+	if err != nil {
+		return nil, fmt.Errorf("get agent: %w", err)
+	}
+
+	history, _, err := r.loop.StartTurn(ctx, conv.ID, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("start turn: %w", err)
+	}
+
 	response, err := r.loop.RunTurn(ctx, agentloop.TurnOpts{
-		Conv:              conv,
-		Agent:             agent,
-		UserContent:       opts.Prompt,
-		ModelOverride:     opts.Model,
-		ExtraInstructions: autonomousInstructions,
-		Depth:             opts.Depth,
+		Conv:        conv,
+		Agent:       agent,
+		UserContent: prompt,
+		History:     history,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("run turn: %w", err)
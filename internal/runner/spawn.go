@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// RunStatusRunning is the status of spawned runs that haven't finished. The
+// statuses of finished runs are the agentloop.RunStatus constants.
+const RunStatusRunning = "running"
+
+// spawnRetention is how long the results of finished spawned runs are kept.
+const spawnRetention = time.Hour
+
+// ErrSpawnedRunNotFound is returned by SpawnedRun for unknown runs, and runs
+// that finished more than an hour ago or before a server restart.
+var ErrSpawnedRunNotFound = errors.New("spawned run not found")
+
+// SpawnedRun is the state of a run started with Spawn.
+type SpawnedRun struct {
+	ID             string
+	AgentID        string
+	ConversationID string
+	Status         string
+	// Response is the final response of completed runs.
+	Response string
+	// Error explains why a run didn't complete.
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// spawnedRuns holds the state of spawned runs in memory.
+type spawnedRuns struct {
+	mu   sync.Mutex
+	runs map[string]*SpawnedRun
+}
+
+func (s *spawnedRuns) add(run *SpawnedRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.runs == nil {
+		s.runs = make(map[string]*SpawnedRun)
+	}
+	// Forget runs whose results had time to be checked.
+	for id, r := range s.runs {
+		if r.Status != RunStatusRunning && time.Since(r.FinishedAt) > spawnRetention {
+			delete(s.runs, id)
+		}
+	}
+	s.runs[run.ID] = run
+}
+
+func (s *spawnedRuns) finish(id string, result *RunResult, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.runs[id]
+	if !ok {
+		return
+	}
+	run.Status = agentloop.RunStatusOf(err)
+	run.FinishedAt = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+	} else if result != nil {
+		run.Response = result.Response
+	}
+}
+
+func (s *spawnedRuns) get(id string) (SpawnedRun, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.runs[id]
+	if !ok {
+		return SpawnedRun{}, false
+	}
+	return *run, true
+}
+
+// Spawn starts a run in the background and returns its state, without
+// waiting for it to finish. Its ID is also the run ID for
+// agentloop.Loop.CancelRun. The run isn't cancelled with ctx, and its state
+// can be checked with SpawnedRun.
+func (r *Runner) Spawn(ctx context.Context, opts RunOpts) (SpawnedRun, error) {
+	opts.Prompt = tool.ExpandRunVars(opts.Prompt, opts.Vars)
+	agent, conv, err := r.startRun(ctx, &opts)
+	if err != nil {
+		return SpawnedRun{}, err
+	}
+
+	run := &SpawnedRun{
+		ID:             uuid.NewString(),
+		AgentID:        agent.ID,
+		ConversationID: conv.ID,
+		Status:         RunStatusRunning,
+		StartedAt:      time.Now(),
+	}
+	state := *run
+	r.spawned.add(run)
+
+	// The run outlives the turn that spawned it.
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		result, err := r.runTurn(ctx, opts, agent, conv, run.ID)
+		r.spawned.finish(run.ID, result, err)
+	}()
+
+	return state, nil
+}
+
+// SpawnedRun returns the state of a run started with Spawn.
+func (r *Runner) SpawnedRun(id string) (SpawnedRun, error) {
+	run, ok := r.spawned.get(id)
+	if !ok {
+		return SpawnedRun{}, ErrSpawnedRunNotFound
+	}
+	return run, nil
+}
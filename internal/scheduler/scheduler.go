@@ -3,42 +3,81 @@ package scheduler
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
 	"sync"
 	"time"
 
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/maintenance"
 	"github.com/dstotijn/blippy/internal/runner"
 	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
 	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
 )
 
 const tickInterval = 10 * time.Second
 
+// Trigger run statuses.
+const (
+	RunStatusRunning     = "running"
+	RunStatusCompleted   = "completed"
+	RunStatusFailed      = "failed"
+	RunStatusInterrupted = "interrupted"
+	RunStatusCancelled   = "cancelled"
+	RunStatusTimedOut    = "timed_out"
+)
+
+// Job is a periodic background task run on every scheduler tick.
+type Job func(ctx context.Context) error
+
+type namedJob struct {
+	name string
+	fn   Job
+}
+
 // Scheduler manages trigger execution.
 type Scheduler struct {
+	// Cipher decrypts the run variables of triggers. Nil reads them as
+	// plaintext.
+	Cipher *encryption.Cipher
+
 	db      *sql.DB
 	queries *store.Queries
 	runner  *runner.Runner
+	maint   *maintenance.Mode
+	jobs    []namedJob
+
+	mu       sync.Mutex
+	lastTick time.Time
 
-	mu     sync.Mutex
 	stop   chan struct{}
 	done   chan struct{}
 	logger *slog.Logger
 }
 
-// New creates a new Scheduler.
-func New(db *sql.DB, queries *store.Queries, runner *runner.Runner, logger *slog.Logger) *Scheduler {
+// New creates a new Scheduler. Triggers and jobs are paused while maint is
+// enabled.
+func New(db *sql.DB, queries *store.Queries, runner *runner.Runner, maint *maintenance.Mode, logger *slog.Logger) *Scheduler {
 	return &Scheduler{
 		db:      db,
 		queries: queries,
 		runner:  runner,
+		maint:   maint,
 		stop:    make(chan struct{}),
 		done:    make(chan struct{}),
 		logger:  logger,
 	}
 }
 
+// AddJob registers a job to run on every tick, after due triggers have been
+// executed. Must be called before Start.
+func (s *Scheduler) AddJob(name string, fn Job) {
+	s.jobs = append(s.jobs, namedJob{name: name, fn: fn})
+}
+
 // Start begins the scheduler tick loop.
 func (s *Scheduler) Start(ctx context.Context) {
 	go s.run(ctx)
@@ -53,6 +92,17 @@ func (s *Scheduler) Stop() {
 func (s *Scheduler) run(ctx context.Context) {
 	defer close(s.done)
 
+	// Runs still marked as running were cut off by a crash or kill.
+	n, err := s.queries.InterruptRunningTriggerRuns(ctx, store.InterruptRunningTriggerRunsParams{
+		ErrorMessage: sql.NullString{String: "interrupted by server restart", Valid: true},
+		FinishedAt:   sql.NullString{String: time.Now().UTC().Format(time.RFC3339), Valid: true},
+	})
+	if err != nil {
+		s.logger.Error("failed to mark interrupted trigger runs", "error", err)
+	} else if n > 0 {
+		s.logger.Warn("marked trigger runs left running as interrupted", "count", n)
+	}
+
 	// Initial sync of cron triggers
 	if err := s.syncCronTriggers(ctx); err != nil {
 		s.logger.Error("failed to sync cron triggers on startup", "error", err)
@@ -68,13 +118,34 @@ func (s *Scheduler) run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			s.mu.Lock()
+			s.lastTick = time.Now().UTC()
+			s.mu.Unlock()
+
+			// Due triggers run on the first tick after maintenance ends.
+			if s.maint.Enabled() {
+				continue
+			}
+
 			if err := s.tick(ctx); err != nil {
 				s.logger.Error("scheduler tick error", "error", err)
 			}
+			if ctx.Err() != nil {
+				return
+			}
+			s.runJobs(ctx)
 		}
 	}
 }
 
+// LastTick returns when the scheduler last started a tick, or the zero time
+// if it hasn't ticked yet.
+func (s *Scheduler) LastTick() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastTick
+}
+
 func (s *Scheduler) syncCronTriggers(ctx context.Context) error {
 	triggers, err := s.queries.ListAllTriggers(ctx)
 	if err != nil {
@@ -99,8 +170,8 @@ func (s *Scheduler) syncCronTriggers(ctx context.Context) error {
 		nextRun := schedule.Next(now)
 		if err := s.queries.UpdateTriggerNextRun(ctx, store.UpdateTriggerNextRunParams{
 			ID:        trigger.ID,
-			NextRunAt: sql.NullString{String: nextRun.Format(time.RFC3339), Valid: true},
-			UpdatedAt: now.Format(time.RFC3339),
+			NextRunAt: sql.NullString{String: nextRun.UTC().Format(time.RFC3339), Valid: true},
+			UpdatedAt: now.UTC().Format(time.RFC3339),
 		}); err != nil {
 			s.logger.Error("failed to update trigger next run", "trigger_id", trigger.ID, "error", err)
 		}
@@ -110,15 +181,19 @@ func (s *Scheduler) syncCronTriggers(ctx context.Context) error {
 }
 
 func (s *Scheduler) tick(ctx context.Context) error {
-	now := time.Now()
-	nowStr := now.Format(time.RFC3339)
+	nowStr := time.Now().UTC().Format(time.RFC3339)
 
 	triggers, err := s.queries.GetDueTriggers(ctx, sql.NullString{String: nowStr, Valid: true})
 	if err != nil {
 		return err
 	}
+	s.logger.Debug("scheduler tick", "due_triggers", len(triggers))
 
 	for _, trigger := range triggers {
+		// Don't start runs after shutdown or maintenance has begun.
+		if ctx.Err() != nil || s.maint.Enabled() {
+			return nil
+		}
 		if err := s.executeTrigger(ctx, trigger); err != nil {
 			s.logger.Error("failed to execute trigger", "trigger_id", trigger.ID, "error", err)
 		}
@@ -127,53 +202,52 @@ func (s *Scheduler) tick(ctx context.Context) error {
 	return nil
 }
 
-func (s *Scheduler) executeTrigger(ctx context.Context, trigger store.Trigger) error {
-	now := time.Now()
-	nowStr := now.Format(time.RFC3339)
-	runID := uuid.NewString()
-
-	// Create trigger run record
-	_, err := s.queries.CreateTriggerRun(ctx, store.CreateTriggerRunParams{
-		ID:        runID,
-		TriggerID: trigger.ID,
-		Status:    "running",
-		StartedAt: nowStr,
-	})
-	if err != nil {
-		return err
+func (s *Scheduler) runJobs(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.logger.Debug("running scheduler job", "job", job.name)
+		if err := job.fn(ctx); err != nil {
+			s.logger.Error("scheduler job error", "job", job.name, "error", err)
+		}
 	}
+}
 
-	// Execute the agent run
-	result, runErr := s.runner.Run(ctx, runner.RunOpts{
-		AgentID: trigger.AgentID,
-		Prompt:  trigger.Prompt,
-		Depth:   0,
-		Model:   trigger.Model,
-		Title:   trigger.ConversationTitle,
-	})
-
-	// Update trigger run with result
-	finishedAt := time.Now().Format(time.RFC3339)
-	status := "completed"
-	var errorMessage sql.NullString
-	var conversationID sql.NullString
+// RunTrigger runs a trigger now, in the background, without changing its
+// schedule. It returns the ID of the trigger run.
+func (s *Scheduler) RunTrigger(ctx context.Context, triggerID string) (string, error) {
+	return s.RunTriggerInput(ctx, triggerID, "")
+}
 
-	if runErr != nil {
-		status = "failed"
-		errorMessage = sql.NullString{String: runErr.Error(), Valid: true}
-	} else if result != nil {
-		conversationID = sql.NullString{String: result.ConversationID, Valid: result.ConversationID != ""}
+// RunTriggerInput runs a trigger like RunTrigger, with input, such as the
+// body of a webhook request, appended to its prompt.
+func (s *Scheduler) RunTriggerInput(ctx context.Context, triggerID, input string) (string, error) {
+	if err := s.maint.Err(); err != nil {
+		return "", err
 	}
+	trigger, err := s.queries.GetTrigger(ctx, triggerID)
+	if err != nil {
+		return "", err
+	}
+	if input != "" {
+		trigger.Prompt += "\n\n" + input
+	}
+	runID, err := s.createRun(ctx, trigger)
+	if err != nil {
+		return "", err
+	}
+	go s.runTrigger(context.WithoutCancel(ctx), trigger, runID)
+	return runID, nil
+}
 
-	if err := s.queries.UpdateTriggerRun(ctx, store.UpdateTriggerRunParams{
-		ID:             runID,
-		Status:         status,
-		ErrorMessage:   errorMessage,
-		ConversationID: conversationID,
-		FinishedAt:     sql.NullString{String: finishedAt, Valid: true},
-	}); err != nil {
-		s.logger.Error("failed to update trigger run", "run_id", runID, "error", err)
+func (s *Scheduler) executeTrigger(ctx context.Context, trigger store.Trigger) error {
+	// The run isn't cancelled when shutdown begins, but drained by the agent
+	// loop, which interrupts it if it takes too long. Its result must still
+	// be recorded.
+	ctx = context.WithoutCancel(ctx)
+	runID, err := s.createRun(ctx, trigger)
+	if err != nil {
+		return err
 	}
+	conversationID := s.runTrigger(ctx, trigger, runID)
 
 	// Handle cron vs one-shot triggers
 	if trigger.CronExpr.Valid && trigger.CronExpr.String != "" {
@@ -186,8 +260,8 @@ func (s *Scheduler) executeTrigger(ctx context.Context, trigger store.Trigger) e
 			nextRun := schedule.Next(time.Now())
 			if err := s.queries.UpdateTriggerNextRun(ctx, store.UpdateTriggerNextRunParams{
 				ID:        trigger.ID,
-				NextRunAt: sql.NullString{String: nextRun.Format(time.RFC3339), Valid: true},
-				UpdatedAt: time.Now().Format(time.RFC3339),
+				NextRunAt: sql.NullString{String: nextRun.UTC().Format(time.RFC3339), Valid: true},
+				UpdatedAt: time.Now().UTC().Format(time.RFC3339),
 			}); err != nil {
 				s.logger.Error("failed to update trigger next run", "trigger_id", trigger.ID, "error", err)
 			}
@@ -199,9 +273,102 @@ func (s *Scheduler) executeTrigger(ctx context.Context, trigger store.Trigger) e
 		}
 	}
 
-	if conversationID.Valid {
-		s.logger.Info("trigger execution completed", "trigger_id", trigger.ID, "run_id", runID, "conversation_id", conversationID.String)
+	if conversationID != "" {
+		s.logger.Info("trigger execution completed", "trigger_id", trigger.ID, "run_id", runID, "conversation_id", conversationID)
 	}
 
 	return nil
 }
+
+// createRun records the start of a trigger run, and returns its ID.
+func (s *Scheduler) createRun(ctx context.Context, trigger store.Trigger) (string, error) {
+	runID := uuid.NewString()
+	_, err := s.queries.CreateTriggerRun(ctx, store.CreateTriggerRunParams{
+		ID:        runID,
+		TriggerID: trigger.ID,
+		Status:    RunStatusRunning,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+	return runID, nil
+}
+
+// PruneRuns returns a job deleting finished trigger runs that started more
+// than retention ago.
+func (s *Scheduler) PruneRuns(retention time.Duration) Job {
+	return func(ctx context.Context) error {
+		before := time.Now().Add(-retention).UTC().Format(time.RFC3339)
+		n, err := s.queries.DeleteTriggerRunsBefore(ctx, before)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			s.logger.Debug("pruned trigger runs", "count", n)
+		}
+		return nil
+	}
+}
+
+// runTrigger runs the agent of a trigger, and records the result of the
+// trigger run. It returns the ID of the run's conversation, if one was
+// created.
+func (s *Scheduler) runTrigger(ctx context.Context, trigger store.Trigger, runID string) string {
+	vars, runErr := tool.DecodeRunVars(s.Cipher, trigger.Vars)
+
+	// Execute the agent run
+	var result *runner.RunResult
+	if runErr == nil {
+		result, runErr = s.runner.Run(ctx, runner.RunOpts{
+			AgentID: trigger.AgentID,
+			Prompt:  trigger.Prompt,
+			Depth:   0,
+			Model:   trigger.Model,
+			Title:   trigger.ConversationTitle,
+			Kind:    agentloop.RunKindTrigger,
+
+			MaxDuration: time.Duration(trigger.MaxDurationSeconds) * time.Second,
+			Priority:    int(trigger.Priority),
+			Vars:        vars,
+
+			TriggerID:   trigger.ID,
+			TriggerName: trigger.Name,
+		})
+	}
+
+	// Update trigger run with result
+	finishedAt := time.Now().UTC().Format(time.RFC3339)
+	status := RunStatusCompleted
+	var errorMessage sql.NullString
+	var conversationID sql.NullString
+
+	if runErr != nil {
+		status = RunStatusFailed
+		if errors.Is(runErr, agentloop.ErrInterrupted) || errors.Is(runErr, agentloop.ErrShuttingDown) {
+			status = RunStatusInterrupted
+		}
+		if errors.Is(runErr, agentloop.ErrCancelled) {
+			status = RunStatusCancelled
+		}
+		if errors.Is(runErr, agentloop.ErrTimedOut) {
+			status = RunStatusTimedOut
+		}
+		errorMessage = sql.NullString{String: runErr.Error(), Valid: true}
+	}
+	if result != nil {
+		conversationID = sql.NullString{String: result.ConversationID, Valid: result.ConversationID != ""}
+	}
+
+	if err := s.queries.UpdateTriggerRun(ctx, store.UpdateTriggerRunParams{
+		ID:             runID,
+		Status:         status,
+		ErrorMessage:   errorMessage,
+		ConversationID: conversationID,
+		FinishedAt:     sql.NullString{String: finishedAt, Valid: true},
+	}); err != nil {
+		s.logger.Error("failed to update trigger run", "run_id", runID, "error", err)
+	}
+
+	return conversationID.String
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TestPruneRuns checks that finished trigger runs are deleted once they're
+// older than the retention, and running ones are kept.
+func TestPruneRuns(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC()
+	at := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "agent-1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: at(0), UpdatedAt: at(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{ID: "trigger-1", AgentID: "agent-1", Name: "trigger-1", Vars: "{}", CreatedAt: at(0), UpdatedAt: at(0)}); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []store.CreateTriggerRunParams{
+		{ID: "old-completed", Status: RunStatusCompleted, StartedAt: at(-48 * time.Hour), FinishedAt: store.NewNullString(at(-48 * time.Hour))},
+		{ID: "old-failed", Status: RunStatusFailed, StartedAt: at(-25 * time.Hour), FinishedAt: store.NewNullString(at(-25 * time.Hour))},
+		{ID: "old-running", Status: RunStatusRunning, StartedAt: at(-48 * time.Hour)},
+		{ID: "recent", Status: RunStatusCompleted, StartedAt: at(-23 * time.Hour), FinishedAt: store.NewNullString(at(-23 * time.Hour))},
+	} {
+		p.TriggerID = "trigger-1"
+		if _, err := queries.CreateTriggerRun(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := New(db, queries, nil, nil, slog.New(slog.DiscardHandler))
+	if err := s.PruneRuns(24 * time.Hour)(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := queries.ListTriggerRuns(ctx, store.ListTriggerRunsParams{TriggerID: "trigger-1", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, r := range runs {
+		ids = append(ids, r.ID)
+	}
+	if want := []string{"recent", "old-running"}; !slices.Equal(ids, want) {
+		t.Errorf("runs after pruning = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/system"
+)
+
+// Server-wide limits, set on the http.Server with Configure. There's no
+// server-wide read or write timeout, as those would also end streaming RPCs
+// such as WatchEvents; unary requests get per-request deadlines instead.
+const (
+	readHeaderTimeout = 10 * time.Second
+	idleTimeout       = 2 * time.Minute
+	maxHeaderBytes    = 64 << 10
+)
+
+// Per-route limits.
+const (
+	// bodyReadTimeout is how long a client has to send a request body.
+	bodyReadTimeout = 30 * time.Second
+	// unaryWriteTimeout bounds handling and writing the response of unary
+	// RPCs and web UI requests.
+	unaryWriteTimeout = 2 * time.Minute
+	// maxAPIBodyBytes limits unary request bodies and streamed messages. It
+	// leaves room for the attachments of chat messages.
+	maxAPIBodyBytes = 32 << 20
+	// maxWebhookBodyBytes limits webhook payloads, which are small JSON
+	// documents.
+	maxWebhookBodyBytes = 256 << 10
+)
+
+// Configure sets timeouts and header limits on srv.
+func Configure(srv *http.Server) {
+	srv.ReadHeaderTimeout = readHeaderTimeout
+	srv.IdleTimeout = idleTimeout
+	srv.MaxHeaderBytes = maxHeaderBytes
+}
+
+// limitBody reads the request body up front, within bodyReadTimeout and up
+// to maxBytes, so the handler runs without a read deadline. Requests with a
+// larger body get a 413 response.
+func limitBody(maxBytes int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.ContentLength > maxBytes {
+			tooLarge(w, maxBytes)
+			return
+		}
+
+		// Deadlines aren't supported by all response writers, e.g. in tests.
+		rc := http.NewResponseController(w)
+		_ = rc.SetReadDeadline(time.Now().Add(bodyReadTimeout))
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+		_ = rc.SetReadDeadline(time.Time{})
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				tooLarge(w, maxBytes)
+				return
+			}
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func tooLarge(w http.ResponseWriter, maxBytes int64) {
+	http.Error(w, fmt.Sprintf("Request body exceeds %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
+}
+
+// writeTimeout sets a deadline for writing the response.
+func writeTimeout(timeout time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// longUnaryWriteTimeouts are the write deadlines of unary RPCs that wait
+// longer than unaryWriteTimeout by design, by procedure.
+var longUnaryWriteTimeouts = map[string]time.Duration{
+	system.SystemServiceUpdateMaintenanceModeProcedure: system.MaxMaintenanceWait + unaryWriteTimeout,
+}
+
+// unaryWriteTimeoutOf returns the write deadline of a unary procedure.
+func unaryWriteTimeoutOf(procedure string) time.Duration {
+	if d, ok := longUnaryWriteTimeouts[procedure]; ok {
+		return d
+	}
+	return unaryWriteTimeout
+}
+
+// unaryLimits applies body limits and write deadlines to unary Connect
+// requests. Streaming requests, including all gRPC requests, are passed
+// through as is; their message size is limited by connect.WithReadMaxBytes.
+func unaryLimits(next http.Handler) http.Handler {
+	unary := limitBody(maxAPIBodyBytes, writeTimeout(unaryWriteTimeout, next))
+	long := make(map[string]http.Handler, len(longUnaryWriteTimeouts))
+	for procedure := range longUnaryWriteTimeouts {
+		long[procedure] = limitBody(maxAPIBodyBytes, writeTimeout(unaryWriteTimeoutOf(procedure), next))
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isUnary(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if h, ok := long[r.URL.Path]; ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+		unary.ServeHTTP(w, r)
+	})
+}
+
+// isUnary reports whether r is a unary Connect request: a GET, or a POST
+// with an unenveloped JSON or protobuf body.
+func isUnary(r *http.Request) bool {
+	if r.Method == http.MethodGet {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "application/json" || mediaType == "application/proto"
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/system"
+)
+
+func TestLimitBody(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	})
+	h := limitBody(8, echo)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("small")))
+	if rec.Code != http.StatusOK || rec.Body.String() != "small" {
+		t.Errorf("small body: got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("far too large")))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: got %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	// Without a content length, the limit is enforced while reading.
+	req := httptest.NewRequest(http.MethodPost, "/", io.MultiReader(strings.NewReader("far too "), strings.NewReader("large")))
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large chunked body: got %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestIsUnary(t *testing.T) {
+	tests := []struct {
+		method      string
+		contentType string
+		want        bool
+	}{
+		{http.MethodGet, "", true},
+		{http.MethodPost, "application/json", true},
+		{http.MethodPost, "application/proto", true},
+		{http.MethodPost, "application/json; charset=utf-8", true},
+		{http.MethodPost, "application/connect+json", false},
+		{http.MethodPost, "application/grpc", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/", nil)
+		r.Header.Set("Content-Type", tt.contentType)
+		if got := isUnary(r); got != tt.want {
+			t.Errorf("%s %q: isUnary = %v, want %v", tt.method, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestUnaryWriteTimeoutOf(t *testing.T) {
+	// UpdateMaintenanceMode waits for active turns before it responds, so
+	// its response must be writable after the longest wait.
+	if d := unaryWriteTimeoutOf(system.SystemServiceUpdateMaintenanceModeProcedure); d <= system.MaxMaintenanceWait {
+		t.Errorf("write timeout of UpdateMaintenanceMode = %s, want more than the maximum wait of %s", d, system.MaxMaintenanceWait)
+	}
+	if d := unaryWriteTimeoutOf(system.SystemServiceGetMaintenanceModeProcedure); d != unaryWriteTimeout {
+		t.Errorf("write timeout of GetMaintenanceMode = %s, want %s", d, unaryWriteTimeout)
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
 
 	"connectrpc.com/connect"
@@ -8,10 +9,20 @@ import (
 	"golang.org/x/net/http2/h2c"
 
 	"github.com/dstotijn/blippy/internal/agent"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/audit"
+	"github.com/dstotijn/blippy/internal/auth"
 	"github.com/dstotijn/blippy/internal/conversation"
+	"github.com/dstotijn/blippy/internal/diagnostics"
+	"github.com/dstotijn/blippy/internal/eval"
+	"github.com/dstotijn/blippy/internal/events"
 	"github.com/dstotijn/blippy/internal/fsroot"
 	"github.com/dstotijn/blippy/internal/notification"
+	"github.com/dstotijn/blippy/internal/openapi"
+	"github.com/dstotijn/blippy/internal/reflection"
+	"github.com/dstotijn/blippy/internal/system"
 	"github.com/dstotijn/blippy/internal/trigger"
+	"github.com/dstotijn/blippy/internal/version"
 	"github.com/dstotijn/blippy/internal/webhook"
 	"github.com/dstotijn/blippy/web"
 )
@@ -26,11 +37,36 @@ func New(
 	triggerService *trigger.Service,
 	notificationService *notification.Service,
 	fsrootService *fsroot.Service,
+	auditService *audit.Service,
+	authService *auth.Service,
+	systemService *system.Service,
+	evalService *eval.Service,
 	webhookHandler *webhook.Handler,
+	replyHandler *webhook.ReplyHandler,
+	triggerWebhookHandler *webhook.TriggerWebhookHandler,
+	telegramHandler *webhook.TelegramHandler,
+	slackHandler *webhook.SlackHandler,
+	eventsHandler *events.Handler,
+	metricsHandler http.Handler,
+	blobHandler http.Handler,
+	statusHandler http.Handler,
 ) (*Server, error) {
 	mux := http.NewServeMux()
 
-	opts := []connect.HandlerOption{connect.WithCompressMinBytes(1024)}
+	// Error codes are added outermost, so errors of the other interceptors
+	// get them too. Authenticate before auditing, so entries are attributed
+	// to the caller.
+	interceptors := []connect.Interceptor{apierror.NewInterceptor()}
+	if !authService.Disabled() {
+		interceptors = append(interceptors, authService.Interceptor())
+	}
+	interceptors = append(interceptors, auditService.Interceptor())
+
+	opts := []connect.HandlerOption{
+		connect.WithCompressMinBytes(1024),
+		connect.WithReadMaxBytes(maxAPIBodyBytes),
+		connect.WithInterceptors(interceptors...),
+	}
 
 	apiMux := http.NewServeMux()
 
@@ -49,30 +85,116 @@ func New(
 	fsrootPath, fsrootHandler := fsroot.NewFilesystemRootServiceHandler(fsrootService, opts...)
 	apiMux.Handle(fsrootPath, fsrootHandler)
 
-	mux.Handle("/api/", http.StripPrefix("/api", apiMux))
+	auditPath, auditHandler := audit.NewAuditServiceHandler(auditService, opts...)
+	apiMux.Handle(auditPath, auditHandler)
+
+	authPath, authHandler := auth.NewAuthServiceHandler(authService, opts...)
+	apiMux.Handle(authPath, authHandler)
+
+	systemPath, systemHandler := system.NewSystemServiceHandler(systemService, opts...)
+	apiMux.Handle(systemPath, systemHandler)
+
+	evalPath, evalHandler := eval.NewEvalServiceHandler(evalService, opts...)
+	apiMux.Handle(evalPath, evalHandler)
+
+	// Server reflection and an OpenAPI description, for tools like grpcurl,
+	// buf curl and OpenAPI client generators.
+	services := []string{
+		agent.AgentServiceName,
+		conversation.ConversationServiceName,
+		trigger.TriggerServiceName,
+		notification.NotificationChannelServiceName,
+		fsroot.FilesystemRootServiceName,
+		audit.AuditServiceName,
+		auth.AuthServiceName,
+		system.SystemServiceName,
+		eval.EvalServiceName,
+	}
+
+	reflectionPath, reflectionHandler := reflection.NewServerReflectionHandler(reflection.NewService(services...), opts...)
+	apiMux.Handle(reflectionPath, reflectionHandler)
+
+	openAPIHandler, err := openapi.Handler(openapi.Info{
+		Title:     "Blippy API",
+		Version:   version.Get(),
+		ServerURL: "/api",
+	}, services)
+	if err != nil {
+		return nil, fmt.Errorf("generate OpenAPI description: %w", err)
+	}
+	apiMux.Handle("GET /openapi.json", authService.Middleware(auth.ScopeRead, openAPIHandler))
+
+	mux.Handle("/api/", http.StripPrefix("/api", unaryLimits(apiMux)))
+
+	// Server-sent events for clients that don't speak Connect. Streams are
+	// long-lived, so they aren't subject to the unary limits.
+	mux.Handle("GET /api/events", authService.Middleware(auth.ScopeRead, eventsHandler))
+
+	// Prometheus metrics, scraped with an API key with the read scope.
+	mux.Handle("GET /metrics", authService.Middleware(auth.ScopeRead, metricsHandler))
+
+	// Blob downloads, authenticated by the signature of their URL.
+	mux.Handle("GET /blobs/", writeTimeout(unaryWriteTimeout, blobHandler))
+
+	// Public status page, if enabled.
+	if statusHandler != nil {
+		mux.Handle("GET /status", writeTimeout(unaryWriteTimeout, statusHandler))
+		mux.Handle("GET /status.json", writeTimeout(unaryWriteTimeout, statusHandler))
+	}
+
+	// Runtime profiles and variables, for admins only.
+	mux.Handle("/debug/", authService.AdminMiddleware(diagnostics.Handler()))
+
+	// OIDC login redirects
+	if h := authService.OIDCHandler(); h != nil {
+		mux.Handle("/auth/oidc/", writeTimeout(unaryWriteTimeout, h))
+	}
+
+	// Deprecated generic webhook endpoint, if enabled. Authenticated with an
+	// API key with the webhook scope. Runs can take minutes, so there's no
+	// write deadline.
+	if webhookHandler != nil {
+		mux.Handle("/webhooks/trigger", limitBody(maxWebhookBodyBytes, authService.Middleware(auth.ScopeWebhook, webhookHandler)))
+	}
+
+	// Notification reply endpoint, authenticated with an API key with the
+	// webhook scope.
+	mux.Handle("/webhooks/notification-reply", limitBody(maxWebhookBodyBytes, authService.Middleware(auth.ScopeWebhook, replyHandler)))
+
+	// Trigger webhooks, authenticated by the token in their URL (and
+	// optionally a signature), so they can be called by other services. The
+	// trigger runs in the background, so they respond right away.
+	mux.Handle("POST "+webhook.TriggerWebhookPath+"{token}", limitBody(maxWebhookBodyBytes, writeTimeout(unaryWriteTimeout, triggerWebhookHandler)))
+
+	// Telegram bot updates of Telegram channels, authenticated by the secret
+	// token the webhook was registered with. Messages are answered in the
+	// background.
+	mux.Handle("POST "+webhook.TelegramPath+"{channelID}", limitBody(maxWebhookBodyBytes, writeTimeout(unaryWriteTimeout, telegramHandler)))
 
-	// Webhook trigger endpoint
-	mux.Handle("/webhooks/trigger", webhookHandler)
+	// Slack app events of Slack channels, authenticated by the app's request
+	// signature. Replies in notification threads are answered in the
+	// background.
+	mux.Handle("POST "+webhook.SlackPath+"{channelID}", limitBody(maxWebhookBodyBytes, writeTimeout(unaryWriteTimeout, slackHandler)))
 
 	// Web UI (catch-all for SPA)
 	webHandler, err := web.AppHandler()
 	if err != nil {
 		return nil, err
 	}
-	mux.Handle("/", webHandler)
+	mux.Handle("/", writeTimeout(unaryWriteTimeout, webHandler))
 
 	return &Server{mux: mux}, nil
 }
 
 func (s *Server) Handler() http.Handler {
-	return h2c.NewHandler(corsMiddleware(s.mux), &http2.Server{})
+	return h2c.NewHandler(hstsMiddleware(corsMiddleware(s.mux)), &http2.Server{})
 }
 
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Connect-Protocol-Version")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Connect-Protocol-Version")
 		w.Header().Set("Access-Control-Expose-Headers", "Connect-Protocol-Version")
 
 		if r.Method == http.MethodOptions {
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedirectToHTTPS(t *testing.T) {
+	tests := []struct {
+		port   string
+		target string
+		want   string
+	}{
+		{"443", "http://blippy.example/agents?x=1", "https://blippy.example/agents?x=1"},
+		{"443", "http://blippy.example:8080/", "https://blippy.example/"},
+		{"8443", "http://blippy.example/", "https://blippy.example:8443/"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		RedirectToHTTPS(tt.port).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != tt.want {
+			t.Errorf("%s on port %s: got %d %q, want %q", tt.target, tt.port, rec.Code, rec.Header().Get("Location"), tt.want)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	RedirectToHTTPS("443").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "http://blippy.example/api/x", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("POST: got %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
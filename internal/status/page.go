@@ -0,0 +1,61 @@
+package status
+
+import (
+	"fmt"
+	"html/template"
+	"time"
+)
+
+var pageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"ago": ago,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="60">
+<title>Blippy status: {{.Health}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 40rem; margin: 2rem auto; padding: 0 1rem; color: #111; }
+h1 { font-size: 1.5rem; }
+.ok { color: #15803d; } .degraded { color: #b45309; } .down { color: #b91c1c; }
+table { width: 100%; border-collapse: collapse; }
+th, td { text-align: left; padding: 0.4rem 0.2rem; border-bottom: 1px solid #e5e7eb; }
+small { color: #6b7280; }
+</style>
+</head>
+<body>
+<h1>Blippy is <span class="{{.Health}}">{{.Health}}</span></h1>
+{{range .Problems}}<p class="{{$.Health}}">{{.}}</p>
+{{end}}
+<h2>Scheduler</h2>
+<p>{{if .Scheduler.Paused}}Paused for maintenance{{else}}Running{{end}}, last tick {{ago .Scheduler.LastTickAt .CheckedAt}}</p>
+<h2>Triggers</h2>
+{{if .Triggers}}<table>
+<tr><th>Trigger</th><th>Last success</th><th>Next run</th></tr>
+{{range .Triggers}}<tr><td>{{.Name}}</td><td>{{ago .LastSuccessAt $.CheckedAt}}</td><td>{{with .NextRunAt}}{{.Format "2006-01-02 15:04 MST"}}{{else}}none{{end}}</td></tr>
+{{end}}</table>
+{{else}}<p>No enabled triggers</p>
+{{end}}
+<p><small>Checked at {{.CheckedAt.Format "2006-01-02 15:04:05 MST"}}</small></p>
+</body>
+</html>
+`))
+
+// ago describes how long before now t was, e.g. "5m ago", or "never" if t
+// is nil.
+func ago(t *time.Time, now time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	switch d := now.Sub(*t); {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
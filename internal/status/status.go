@@ -0,0 +1,176 @@
+// Package status serves a public, read-only status page of the instance: its
+// health, the scheduler and the last successful run of each enabled trigger,
+// as HTML at /status and JSON at /status.json. It needs no authentication, so
+// it shows nothing beyond trigger names and times.
+package status
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/scheduler"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// Health of the instance.
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthDown     = "down"
+)
+
+const (
+	// maxSchedulerLag is how long the scheduler may go without a tick before
+	// the instance is degraded.
+	maxSchedulerLag = time.Minute
+	// cacheTTL is how long a status is reused, to bound the load
+	// unauthenticated clients can put on the database.
+	cacheTTL = 5 * time.Second
+	// checkTimeout bounds how long a check may take.
+	checkTimeout = 5 * time.Second
+)
+
+// Status is the status of the instance.
+type Status struct {
+	Health string `json:"health"`
+	// Problems explains why the instance isn't healthy.
+	Problems  []string  `json:"problems"`
+	Scheduler Scheduler `json:"scheduler"`
+	Triggers  []Trigger `json:"triggers"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Scheduler is the status of the scheduler.
+type Scheduler struct {
+	// Paused is true during maintenance.
+	Paused     bool       `json:"paused"`
+	LastTickAt *time.Time `json:"last_tick_at"`
+}
+
+// Trigger is the status of an enabled trigger.
+type Trigger struct {
+	Name          string     `json:"name"`
+	NextRunAt     *time.Time `json:"next_run_at"`
+	LastSuccessAt *time.Time `json:"last_success_at"`
+}
+
+// Handler serves the status page.
+type Handler struct {
+	db        *sql.DB
+	queries   *store.Queries
+	scheduler *scheduler.Scheduler
+	maint     *maintenance.Mode
+	logger    *slog.Logger
+
+	mu     sync.Mutex
+	cached *Status
+}
+
+// New returns a handler serving the status of the instance.
+func New(db *sql.DB, sched *scheduler.Scheduler, maint *maintenance.Mode, logger *slog.Logger) *Handler {
+	return &Handler{
+		db:        db,
+		queries:   store.New(db),
+		scheduler: sched,
+		maint:     maint,
+		logger:    logger,
+	}
+}
+
+// Check returns the status of the instance, reusing a status checked less
+// than a few seconds ago.
+func (h *Handler) Check(ctx context.Context) *Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now().UTC()
+	if h.cached != nil && now.Sub(h.cached.CheckedAt) < cacheTTL {
+		return h.cached
+	}
+
+	s := &Status{
+		Health:    HealthOK,
+		Problems:  []string{},
+		Triggers:  []Trigger{},
+		CheckedAt: now,
+	}
+	problem := func(health, msg string) {
+		if health == HealthDown || s.Health == HealthOK {
+			s.Health = health
+		}
+		s.Problems = append(s.Problems, msg)
+	}
+
+	if h.maint.Enabled() {
+		s.Scheduler.Paused = true
+		problem(HealthDegraded, "under maintenance")
+	}
+	if lastTick := h.scheduler.LastTick(); !lastTick.IsZero() {
+		s.Scheduler.LastTickAt = &lastTick
+		if now.Sub(lastTick) > maxSchedulerLag {
+			problem(HealthDegraded, "scheduler is behind")
+		}
+	}
+
+	// The status is shared with other requests, so it doesn't depend on
+	// this one being canceled.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), checkTimeout)
+	defer cancel()
+	triggers, err := h.queries.EnabledTriggerStatuses(ctx)
+	if err == nil {
+		err = h.db.PingContext(ctx)
+	}
+	if err != nil {
+		// The error may contain details that aren't public.
+		h.logger.Error("failed to check status", "error", err)
+		problem(HealthDown, "database is unavailable")
+	}
+	for _, t := range triggers {
+		s.Triggers = append(s.Triggers, Trigger{
+			Name:          t.Name,
+			NextRunAt:     parseTime(t.NextRunAt),
+			LastSuccessAt: parseTime(t.LastSuccessAt),
+		})
+	}
+
+	h.cached = s
+	return s
+}
+
+func parseTime(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// ServeHTTP serves GET /status and GET /status.json. Both respond with 503
+// Service Unavailable if the instance is down, for uptime monitors.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s := h.Check(r.Context())
+	code := http.StatusOK
+	if s.Health == HealthDown {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.URL.Path == "/status.json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(s)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.WriteHeader(code)
+	if err := pageTemplate.Execute(w, s); err != nil {
+		h.logger.Error("failed to render status page", "error", err)
+	}
+}
@@ -0,0 +1,89 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/scheduler"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	q := store.New(db)
+
+	const now = "2025-01-01T00:00:00Z"
+	if _, err := q.CreateAgent(ctx, store.CreateAgentParams{ID: "a1", Name: "a1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", Hooks: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tr := range []store.CreateTriggerParams{
+		{ID: "t1", Name: "Digest", Enabled: 1, NextRunAt: store.NewNullString("2025-01-02T07:00:00Z")},
+		{ID: "t2", Name: "Disabled", Enabled: 0},
+		{ID: "t3", Name: "Backup", Enabled: 1},
+	} {
+		tr.AgentID, tr.Prompt, tr.CreatedAt, tr.UpdatedAt = "a1", "secret prompt", now, now
+		if _, err := q.CreateTrigger(ctx, tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range []store.CreateTriggerRunParams{
+		{ID: "r1", Status: "completed", StartedAt: "2025-01-01T06:59:00Z", FinishedAt: store.NewNullString("2025-01-01T07:00:00Z")},
+		{ID: "r2", Status: "failed", StartedAt: "2025-01-01T07:59:00Z", FinishedAt: store.NewNullString("2025-01-01T08:00:00Z")},
+	} {
+		r.TriggerID = "t1"
+		if _, err := q.CreateTriggerRun(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	maint := &maintenance.Mode{}
+	maint.Enable("upgrade")
+	h := New(db, scheduler.New(db, q, nil, maint, slog.Default()), maint, slog.Default())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	var s Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Health != HealthDegraded || !s.Scheduler.Paused {
+		t.Errorf("health = %s, paused = %v, want degraded and paused", s.Health, s.Scheduler.Paused)
+	}
+	if len(s.Triggers) != 2 || s.Triggers[0].Name != "Backup" || s.Triggers[0].LastSuccessAt != nil {
+		t.Fatalf("triggers = %+v", s.Triggers)
+	}
+	if got := s.Triggers[1]; got.Name != "Digest" || got.LastSuccessAt == nil || !got.LastSuccessAt.Equal(time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("trigger = %+v, want last success of completed run", got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if body := rec.Body.String(); !strings.Contains(body, "Digest") || strings.Contains(body, "secret prompt") {
+		t.Errorf("page = %s", body)
+	}
+
+	// A closed database is down, once the cached status is stale.
+	db.Close()
+	h.cached.CheckedAt = h.cached.CheckedAt.Add(-cacheTTL)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status.json", nil))
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), `"health":"down"`) {
+		t.Errorf("closed database: status %d, body %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,229 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// downSuffix marks the file that reverts a migration, e.g.
+// "005_notification_delivery.down.sql" reverts "005_notification_delivery.sql".
+const downSuffix = ".down.sql"
+
+// migration is an embedded schema migration. Its version is the numeric
+// prefix of the file name.
+type migration struct {
+	version int
+	name    string // up file name, recorded in _migrations
+	down    string // down file name, empty if irreversible
+}
+
+// LatestSchemaVersion returns the version of the newest embedded migration.
+func LatestSchemaVersion() (int, error) {
+	all, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	if len(all) == 0 {
+		return 0, nil
+	}
+	return all[len(all)-1].version, nil
+}
+
+// SchemaVersion returns the version of the newest migration applied to the
+// database, or 0 if none are applied.
+func SchemaVersion(db *sql.DB) (int, error) {
+	if err := createMigrationsTable(db); err != nil {
+		return 0, err
+	}
+
+	rows, err := db.Query("SELECT name FROM _migrations")
+	if err != nil {
+		return 0, fmt.Errorf("list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	version := 0
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return 0, err
+		}
+		v, err := migrationVersion(name)
+		if err != nil {
+			return 0, err
+		}
+		version = max(version, v)
+	}
+	return version, rows.Err()
+}
+
+// PendingMigrations returns the names of embedded migrations that aren't
+// applied to the database, oldest first.
+func PendingMigrations(db *sql.DB) ([]string, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	all, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, m := range all {
+		if !applied[m.name] {
+			pending = append(pending, m.name)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies or reverts migrations until the database is at the given
+// schema version. A negative version migrates to the latest version.
+func Migrate(db *sql.DB, to int) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	all, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	if to < 0 && len(all) > 0 {
+		to = all[len(all)-1].version
+	}
+
+	// Revert newest first, then apply oldest first.
+	for i := len(all) - 1; i >= 0; i-- {
+		m := all[i]
+		if m.version <= to || !applied[m.name] {
+			continue
+		}
+		if m.down == "" {
+			return fmt.Errorf("migration %s is not reversible", m.name)
+		}
+		content, err := migrations.ReadFile("migrations/" + m.down)
+		if err != nil {
+			return fmt.Errorf("read migration %s: %w", m.down, err)
+		}
+		if err := execMigration(db, m.down, string(content), "DELETE FROM _migrations WHERE name = ?", m.name); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range all {
+		if m.version > to || applied[m.name] {
+			continue
+		}
+		content, err := migrations.ReadFile("migrations/" + m.name)
+		if err != nil {
+			return fmt.Errorf("read migration %s: %w", m.name, err)
+		}
+		if err := execMigration(db, m.name, string(content), "INSERT INTO _migrations (name) VALUES (?)", m.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appliedMigrations returns the names of the applied migrations.
+func appliedMigrations(db *sql.DB) (map[string]bool, error) {
+	if err := createMigrationsTable(db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT name FROM _migrations")
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		applied[name] = true
+	}
+	return applied, rows.Err()
+}
+
+func createMigrationsTable(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS _migrations (
+			name TEXT PRIMARY KEY,
+			applied_at TEXT NOT NULL DEFAULT (datetime('now'))
+		)
+	`); err != nil {
+		return fmt.Errorf("create migrations table: %w", err)
+	}
+	return nil
+}
+
+// loadMigrations returns the embedded migrations sorted by version.
+func loadMigrations() ([]migration, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+
+	downs := make(map[string]bool)
+	var all []migration
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, downSuffix) {
+			downs[name] = true
+			continue
+		}
+		version, err := migrationVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, migration{version: version, name: name})
+	}
+
+	for i, m := range all {
+		down := strings.TrimSuffix(m.name, ".sql") + downSuffix
+		if downs[down] {
+			all[i].down = down
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].version < all[j].version })
+	return all, nil
+}
+
+func migrationVersion(name string) (int, error) {
+	prefix, _, _ := strings.Cut(name, "_")
+	version, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("invalid migration name %q: missing version prefix", name)
+	}
+	return version, nil
+}
+
+// execMigration executes a migration file and updates _migrations in a
+// single transaction, so a failing migration leaves no partial schema
+// changes.
+func execMigration(db *sql.DB, file, content, record, name string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin migration %s: %w", file, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(content); err != nil {
+		return fmt.Errorf("execute migration %s: %w", file, err)
+	}
+	if _, err := tx.Exec(record, name); err != nil {
+		return fmt.Errorf("record migration %s: %w", file, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestMigrateRoundTrip reverts all migrations and applies them again, so
+// every down file is exercised against the schema its up file creates.
+func TestMigrateRoundTrip(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, to := range []int{0, latest, 1, -1} {
+		if err := Migrate(db, to); err != nil {
+			t.Fatalf("migrate to %d: %v", to, err)
+		}
+		want := to
+		if to < 0 {
+			want = latest
+		}
+		got, err := SchemaVersion(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("schema version after migrating to %d = %d, want %d", to, got, want)
+		}
+	}
+}
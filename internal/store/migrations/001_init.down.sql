@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_triggers_next_run;
+DROP INDEX IF EXISTS idx_messages_conversation_id;
+DROP INDEX IF EXISTS idx_conversations_agent_id;
+
+DROP TABLE IF EXISTS notification_channels;
+DROP TABLE IF EXISTS trigger_runs;
+DROP TABLE IF EXISTS triggers;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS conversations;
+DROP TABLE IF EXISTS agents;
@@ -0,0 +1,3 @@
+ALTER TABLE agents DROP COLUMN enabled_filesystem_roots;
+
+DROP TABLE IF EXISTS filesystem_roots;
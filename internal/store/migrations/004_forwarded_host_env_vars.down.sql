@@ -0,0 +1 @@
+ALTER TABLE agents DROP COLUMN forwarded_host_env_vars;
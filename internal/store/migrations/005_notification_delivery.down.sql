@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_queued_notifications_deliver_after;
+DROP TABLE IF EXISTS queued_notifications;
+
+DROP INDEX IF EXISTS idx_notification_deliveries_channel;
+DROP TABLE IF EXISTS notification_deliveries;
+
+ALTER TABLE notification_channels DROP COLUMN max_per_hour;
+ALTER TABLE notification_channels DROP COLUMN quiet_hours_mode;
+ALTER TABLE notification_channels DROP COLUMN quiet_hours_timezone;
+ALTER TABLE notification_channels DROP COLUMN quiet_hours_end;
+ALTER TABLE notification_channels DROP COLUMN quiet_hours_start;
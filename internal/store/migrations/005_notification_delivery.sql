@@ -0,0 +1,23 @@
+ALTER TABLE notification_channels ADD COLUMN quiet_hours_start TEXT NOT NULL DEFAULT '';
+ALTER TABLE notification_channels ADD COLUMN quiet_hours_end TEXT NOT NULL DEFAULT '';
+ALTER TABLE notification_channels ADD COLUMN quiet_hours_timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE notification_channels ADD COLUMN quiet_hours_mode TEXT NOT NULL DEFAULT 'defer';
+ALTER TABLE notification_channels ADD COLUMN max_per_hour INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id TEXT PRIMARY KEY,
+    channel_id TEXT NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    delivered_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_channel ON notification_deliveries(channel_id, delivered_at);
+
+CREATE TABLE IF NOT EXISTS queued_notifications (
+    id TEXT PRIMARY KEY,
+    channel_id TEXT NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    payload TEXT NOT NULL,
+    deliver_after TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_queued_notifications_deliver_after ON queued_notifications(deliver_after);
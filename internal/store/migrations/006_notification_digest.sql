@@ -0,0 +1 @@
+ALTER TABLE notification_channels ADD COLUMN digest_schedule TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE queued_notifications DROP COLUMN conversation_id;
+ALTER TABLE notification_deliveries DROP COLUMN conversation_id;
@@ -0,0 +1,2 @@
+ALTER TABLE notification_deliveries ADD COLUMN conversation_id TEXT REFERENCES conversations(id) ON DELETE SET NULL;
+ALTER TABLE queued_notifications ADD COLUMN conversation_id TEXT REFERENCES conversations(id) ON DELETE SET NULL;
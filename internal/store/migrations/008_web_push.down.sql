@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS web_push_subscriptions;
+DROP TABLE IF EXISTS settings;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_trigger_runs_trigger_started;
+DROP INDEX IF EXISTS idx_triggers_agent_created;
+
+DROP INDEX IF EXISTS idx_messages_conversation_created;
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_id ON messages(conversation_id);
+
+DROP INDEX IF EXISTS idx_conversations_updated;
+DROP INDEX IF EXISTS idx_conversations_agent_updated;
+CREATE INDEX IF NOT EXISTS idx_conversations_agent_id ON conversations(agent_id);
@@ -0,0 +1,44 @@
+-- Timestamps are compared as strings, which only orders correctly when all
+-- values use the same offset. Normalize values written with a local offset
+-- to UTC.
+UPDATE agents SET
+    created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at),
+    updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE strftime('%Y-%m-%dT%H:%M:%SZ', created_at) IS NOT NULL
+  AND strftime('%Y-%m-%dT%H:%M:%SZ', updated_at) IS NOT NULL;
+
+UPDATE conversations SET
+    created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at),
+    updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE strftime('%Y-%m-%dT%H:%M:%SZ', created_at) IS NOT NULL
+  AND strftime('%Y-%m-%dT%H:%M:%SZ', updated_at) IS NOT NULL;
+
+UPDATE messages SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE strftime('%Y-%m-%dT%H:%M:%SZ', created_at) IS NOT NULL;
+
+UPDATE triggers SET
+    created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at),
+    updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE strftime('%Y-%m-%dT%H:%M:%SZ', created_at) IS NOT NULL
+  AND strftime('%Y-%m-%dT%H:%M:%SZ', updated_at) IS NOT NULL;
+
+UPDATE triggers SET next_run_at = strftime('%Y-%m-%dT%H:%M:%SZ', next_run_at)
+WHERE strftime('%Y-%m-%dT%H:%M:%SZ', next_run_at) IS NOT NULL;
+
+UPDATE trigger_runs SET started_at = strftime('%Y-%m-%dT%H:%M:%SZ', started_at)
+WHERE strftime('%Y-%m-%dT%H:%M:%SZ', started_at) IS NOT NULL;
+
+UPDATE trigger_runs SET finished_at = strftime('%Y-%m-%dT%H:%M:%SZ', finished_at)
+WHERE strftime('%Y-%m-%dT%H:%M:%SZ', finished_at) IS NOT NULL;
+
+-- Replace single-column indexes with ones that also cover the sort order of
+-- the list queries.
+DROP INDEX IF EXISTS idx_conversations_agent_id;
+CREATE INDEX IF NOT EXISTS idx_conversations_agent_updated ON conversations(agent_id, updated_at);
+CREATE INDEX IF NOT EXISTS idx_conversations_updated ON conversations(updated_at);
+
+DROP INDEX IF EXISTS idx_messages_conversation_id;
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_created ON messages(conversation_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_triggers_agent_created ON triggers(agent_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_trigger_runs_trigger_started ON trigger_runs(trigger_id, started_at);
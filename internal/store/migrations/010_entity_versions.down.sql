@@ -0,0 +1,4 @@
+ALTER TABLE filesystem_roots DROP COLUMN version;
+ALTER TABLE notification_channels DROP COLUMN version;
+ALTER TABLE triggers DROP COLUMN version;
+ALTER TABLE agents DROP COLUMN version;
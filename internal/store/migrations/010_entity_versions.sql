@@ -0,0 +1,6 @@
+-- Versions for optimistic locking: updates must name the version they were
+-- based on, and fail if the row changed since.
+ALTER TABLE agents ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE triggers ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE notification_channels ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE filesystem_roots ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,16 @@
+-- Audit log of mutations, for instances shared by multiple people.
+CREATE TABLE audit_log (
+    id TEXT PRIMARY KEY,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    resource_type TEXT NOT NULL,
+    resource_id TEXT NOT NULL DEFAULT '',
+    procedure TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '{}',
+    remote_addr TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_audit_log_created ON audit_log(created_at);
+CREATE INDEX idx_audit_log_resource ON audit_log(resource_type, resource_id, created_at);
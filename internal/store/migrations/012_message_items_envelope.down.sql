@@ -0,0 +1,3 @@
+UPDATE messages
+SET items = json_extract(items, '$.items')
+WHERE json_valid(items) AND json_type(items) = 'object';
@@ -0,0 +1,5 @@
+-- Wrap message items in a versioned envelope, so readers can detect items
+-- written by a newer schema instead of misreading them.
+UPDATE messages
+SET items = json_object('schema_version', 1, 'items', json(items))
+WHERE json_valid(items) AND json_type(items) = 'array';
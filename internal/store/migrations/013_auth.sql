@@ -0,0 +1,21 @@
+-- API keys issued by admins. Only a SHA-256 hash of each key is stored.
+CREATE TABLE api_keys (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    created_at TEXT NOT NULL,
+    last_used_at TEXT,
+    revoked_at TEXT
+);
+
+-- Cookie sessions for the web UI, created by logging in with an API key.
+-- Only a SHA-256 hash of each session token is stored.
+CREATE TABLE sessions (
+    token_hash TEXT PRIMARY KEY,
+    api_key_id TEXT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_sessions_expires ON sessions(expires_at);
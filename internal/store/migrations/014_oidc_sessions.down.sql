@@ -0,0 +1,16 @@
+CREATE TABLE sessions_old (
+    token_hash TEXT PRIMARY KEY,
+    api_key_id TEXT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
+
+-- OIDC sessions can't be represented without an API key, so they're dropped.
+INSERT INTO sessions_old (token_hash, api_key_id, created_at, expires_at)
+SELECT token_hash, api_key_id, created_at, expires_at FROM sessions
+WHERE api_key_id IS NOT NULL;
+
+DROP TABLE sessions;
+ALTER TABLE sessions_old RENAME TO sessions;
+
+CREATE INDEX idx_sessions_expires ON sessions(expires_at);
@@ -0,0 +1,20 @@
+-- Sessions belong to either an API key, or a user logged in with OIDC. For
+-- OIDC sessions, the user's identity and role are stored with the session.
+CREATE TABLE sessions_new (
+    token_hash TEXT PRIMARY KEY,
+    api_key_id TEXT REFERENCES api_keys(id) ON DELETE CASCADE,
+    subject TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    role TEXT NOT NULL DEFAULT 'admin',
+    created_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
+
+INSERT INTO sessions_new (token_hash, api_key_id, created_at, expires_at)
+SELECT token_hash, api_key_id, created_at, expires_at FROM sessions;
+
+DROP TABLE sessions;
+ALTER TABLE sessions_new RENAME TO sessions;
+
+CREATE INDEX idx_sessions_expires ON sessions(expires_at);
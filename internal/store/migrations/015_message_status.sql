@@ -0,0 +1,3 @@
+-- Assistant messages cut short by a server shutdown are stored with status
+-- 'interrupted', holding the output produced so far.
+ALTER TABLE messages ADD COLUMN status TEXT NOT NULL DEFAULT 'completed';
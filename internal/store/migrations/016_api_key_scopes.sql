@@ -0,0 +1,4 @@
+-- API keys can be restricted to agents and operations. Empty lists mean no
+-- restriction, so existing keys keep full access.
+ALTER TABLE api_keys ADD COLUMN agent_ids TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE api_keys ADD COLUMN scopes TEXT NOT NULL DEFAULT '[]';
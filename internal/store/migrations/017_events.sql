@@ -0,0 +1,13 @@
+-- Events published to conversation subscribers, so reconnecting and slow
+-- subscribers can replay what they missed. Sequence numbers increase
+-- monotonically per conversation.
+CREATE TABLE IF NOT EXISTS events (
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (conversation_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
@@ -0,0 +1,11 @@
+-- Leases on conversations with an active agent turn, so replicas don't run
+-- turns on the same conversation and turns cut off by a crash are noticed
+-- once their lease expires. Leases are renewed while the turn runs.
+CREATE TABLE IF NOT EXISTS conversation_leases (
+    conversation_id TEXT PRIMARY KEY REFERENCES conversations(id) ON DELETE CASCADE,
+    holder TEXT NOT NULL,
+    acquired_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_leases_expires_at ON conversation_leases(expires_at);
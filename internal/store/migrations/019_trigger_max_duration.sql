@@ -0,0 +1,3 @@
+-- Maximum wall-clock duration of a trigger's runs in seconds, or 0 for the
+-- server default.
+ALTER TABLE triggers ADD COLUMN max_duration_seconds INTEGER NOT NULL DEFAULT 0;
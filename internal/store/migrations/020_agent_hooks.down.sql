@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS turn_usage;
+ALTER TABLE agents DROP COLUMN hooks;
@@ -0,0 +1,20 @@
+-- Post-turn hooks enabled for an agent, as a JSON array of {"name", "config"}
+-- objects.
+ALTER TABLE agents ADD COLUMN hooks TEXT NOT NULL DEFAULT '[]';
+
+-- Token usage of agent turns, recorded by the usage hook. Rows outlive their
+-- conversation, so usage can still be reported after it's deleted.
+CREATE TABLE IF NOT EXISTS turn_usage (
+    run_id TEXT PRIMARY KEY,
+    agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+    conversation_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    model TEXT NOT NULL,
+    status TEXT NOT NULL,
+    input_tokens INTEGER NOT NULL,
+    output_tokens INTEGER NOT NULL,
+    started_at TEXT NOT NULL,
+    finished_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_turn_usage_agent_id ON turn_usage(agent_id, started_at);
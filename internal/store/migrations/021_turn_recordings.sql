@@ -0,0 +1,10 @@
+-- Recorded LLM responses and tool results of turns, so they can be replayed
+-- for debugging. Only written when turn recording is enabled.
+CREATE TABLE IF NOT EXISTS turn_recordings (
+    run_id TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    recording TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_turn_recordings_conversation_id ON turn_recordings(conversation_id, created_at);
@@ -0,0 +1,3 @@
+-- Maximum number of autonomous runs of an agent at the same time, or 0 for
+-- no limit. Extra runs wait for a slot.
+ALTER TABLE agents ADD COLUMN max_concurrent_runs INTEGER NOT NULL DEFAULT 0;
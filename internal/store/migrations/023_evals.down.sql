@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS eval_runs;
+DROP TABLE IF EXISTS eval_cases;
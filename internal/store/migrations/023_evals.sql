@@ -0,0 +1,34 @@
+-- Evaluation test cases of agents. Assertions is a JSON array of checks on
+-- the agent's final answer; rubric, if set, is graded by an LLM judge.
+CREATE TABLE IF NOT EXISTS eval_cases (
+    id TEXT PRIMARY KEY,
+    agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    assertions TEXT NOT NULL DEFAULT '[]',
+    rubric TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_cases_agent_id ON eval_cases(agent_id);
+
+-- Runs of an agent's eval cases, with the prompt and model evaluated, and
+-- the per-case results as JSON, for score history.
+CREATE TABLE IF NOT EXISTS eval_runs (
+    id TEXT PRIMARY KEY,
+    agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+    system_prompt TEXT NOT NULL,
+    model TEXT NOT NULL,
+    candidate INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    score REAL NOT NULL DEFAULT 0,
+    passed INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL DEFAULT 0,
+    results TEXT NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    finished_at TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_runs_agent_id ON eval_runs(agent_id, created_at);
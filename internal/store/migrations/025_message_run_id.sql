@@ -0,0 +1,3 @@
+-- The run that produced an assistant message, to attribute the token usage
+-- of its run trace to it. Empty for user messages and older messages.
+ALTER TABLE messages ADD COLUMN run_id TEXT NOT NULL DEFAULT '';
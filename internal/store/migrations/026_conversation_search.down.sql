@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS messages_search_ad;
+DROP TRIGGER IF EXISTS messages_search_au;
+DROP TRIGGER IF EXISTS messages_search_ai;
+DROP TRIGGER IF EXISTS conversations_search_au;
+DROP TRIGGER IF EXISTS conversations_search_ai;
+DROP TRIGGER IF EXISTS search_documents_au;
+DROP TRIGGER IF EXISTS search_documents_ad;
+DROP TRIGGER IF EXISTS search_documents_ai;
+DROP TABLE IF EXISTS search_index;
+DROP TABLE IF EXISTS search_documents;
@@ -0,0 +1,80 @@
+-- Full-text search over conversation titles and the text of message items.
+-- search_documents holds the searchable text of each title (with an empty
+-- message_id) and message, kept in sync by triggers, and search_index is its
+-- FTS5 index. The text of items is that of text and error items, and the
+-- input and result of tool executions, of both the items envelope and the
+-- legacy array format.
+CREATE TABLE IF NOT EXISTS search_documents (
+    id INTEGER PRIMARY KEY,
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    message_id TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    UNIQUE (conversation_id, message_id)
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS search_index USING fts5(
+    content,
+    content = 'search_documents',
+    content_rowid = 'id',
+    tokenize = 'porter unicode61'
+);
+
+CREATE TRIGGER IF NOT EXISTS search_documents_ai AFTER INSERT ON search_documents BEGIN
+    INSERT INTO search_index (rowid, content) VALUES (NEW.id, NEW.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_documents_ad AFTER DELETE ON search_documents BEGIN
+    INSERT INTO search_index (search_index, rowid, content) VALUES ('delete', OLD.id, OLD.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_documents_au AFTER UPDATE ON search_documents BEGIN
+    INSERT INTO search_index (search_index, rowid, content) VALUES ('delete', OLD.id, OLD.content);
+    INSERT INTO search_index (rowid, content) VALUES (NEW.id, NEW.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS conversations_search_ai AFTER INSERT ON conversations BEGIN
+    INSERT INTO search_documents (conversation_id, message_id, content) VALUES (NEW.id, '', NEW.title);
+END;
+
+CREATE TRIGGER IF NOT EXISTS conversations_search_au AFTER UPDATE OF title ON conversations BEGIN
+    UPDATE search_documents SET content = NEW.title
+    WHERE conversation_id = NEW.id AND message_id = '';
+END;
+
+CREATE TRIGGER IF NOT EXISTS messages_search_ai AFTER INSERT ON messages BEGIN
+    INSERT INTO search_documents (conversation_id, message_id, content)
+    SELECT NEW.conversation_id, NEW.id, COALESCE(group_concat(concat_ws(' ', j.value ->> '$.text', j.value ->> '$.input', j.value ->> '$.result'), char(10)), '')
+    FROM json_each(
+        CASE WHEN json_valid(NEW.items) THEN NEW.items ELSE '[]' END,
+        CASE WHEN json_valid(NEW.items) THEN CASE json_type(NEW.items) WHEN 'object' THEN '$.items' ELSE '$' END ELSE '$' END
+    ) AS j;
+END;
+
+CREATE TRIGGER IF NOT EXISTS messages_search_au AFTER UPDATE OF items ON messages BEGIN
+    UPDATE search_documents SET content = (
+        SELECT COALESCE(group_concat(concat_ws(' ', j.value ->> '$.text', j.value ->> '$.input', j.value ->> '$.result'), char(10)), '')
+        FROM json_each(
+            CASE WHEN json_valid(NEW.items) THEN NEW.items ELSE '[]' END,
+            CASE WHEN json_valid(NEW.items) THEN CASE json_type(NEW.items) WHEN 'object' THEN '$.items' ELSE '$' END ELSE '$' END
+        ) AS j
+    )
+    WHERE conversation_id = NEW.conversation_id AND message_id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS messages_search_ad AFTER DELETE ON messages BEGIN
+    DELETE FROM search_documents WHERE conversation_id = OLD.conversation_id AND message_id = OLD.id;
+END;
+
+-- Index existing conversations and messages.
+INSERT INTO search_documents (conversation_id, message_id, content)
+SELECT id, '', title FROM conversations;
+
+INSERT INTO search_documents (conversation_id, message_id, content)
+SELECT m.conversation_id, m.id, (
+    SELECT COALESCE(group_concat(concat_ws(' ', j.value ->> '$.text', j.value ->> '$.input', j.value ->> '$.result'), char(10)), '')
+    FROM json_each(
+        CASE WHEN json_valid(m.items) THEN m.items ELSE '[]' END,
+        CASE WHEN json_valid(m.items) THEN CASE json_type(m.items) WHEN 'object' THEN '$.items' ELSE '$' END ELSE '$' END
+    ) AS j
+)
+FROM messages AS m;
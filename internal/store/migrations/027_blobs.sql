@@ -0,0 +1,18 @@
+-- Files in the blob store (BLOB_DIR or BLOB_S3_BUCKET), such as message
+-- attachments, generated images and exports. The object key is the ID.
+-- Blobs of a conversation are deleted with it, and blobs with an expiry after
+-- it; their objects are then garbage collected.
+CREATE TABLE IF NOT EXISTS blobs (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    conversation_id TEXT REFERENCES conversations(id) ON DELETE CASCADE,
+    name TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    sha256 TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    expires_at TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_blobs_conversation_id ON blobs(conversation_id);
+CREATE INDEX IF NOT EXISTS idx_blobs_expires_at ON blobs(expires_at) WHERE expires_at IS NOT NULL;
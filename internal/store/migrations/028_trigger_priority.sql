@@ -0,0 +1,3 @@
+-- Priority of a trigger's runs while waiting for a slot of the agent's
+-- concurrency limit, or 0 for the default of scheduled runs.
+ALTER TABLE triggers ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Estimated number of tokens a message takes up in the history sent to the
+-- LLM, or 0 if it hasn't been estimated yet. Reset when the items change.
+ALTER TABLE messages ADD COLUMN token_count INTEGER NOT NULL DEFAULT 0;
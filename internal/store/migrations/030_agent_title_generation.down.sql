@@ -0,0 +1,3 @@
+ALTER TABLE agents DROP COLUMN title_model;
+ALTER TABLE agents DROP COLUMN title_prompt;
+ALTER TABLE agents DROP COLUMN title_generation_disabled;
@@ -0,0 +1,5 @@
+-- Title generation of an agent's conversations: disabled, or generated with a
+-- custom prompt and model instead of the server defaults if not empty.
+ALTER TABLE agents ADD COLUMN title_generation_disabled INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE agents ADD COLUMN title_prompt TEXT NOT NULL DEFAULT '';
+ALTER TABLE agents ADD COLUMN title_model TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE run_traces DROP COLUMN cost_usd;
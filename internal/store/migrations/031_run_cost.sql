@@ -0,0 +1,4 @@
+-- Cost of a run in US dollars as reported by the LLM provider, or NULL if it
+-- wasn't reported for all its round-trips, in which case it's estimated
+-- from the model's prices.
+ALTER TABLE run_traces ADD COLUMN cost_usd REAL;
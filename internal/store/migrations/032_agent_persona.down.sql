@@ -0,0 +1,4 @@
+ALTER TABLE agents DROP COLUMN persona_style;
+ALTER TABLE agents DROP COLUMN persona_constraints;
+ALTER TABLE agents DROP COLUMN persona_goals;
+ALTER TABLE agents DROP COLUMN persona_role;
@@ -0,0 +1,6 @@
+-- Persona sections of an agent, composed with its system prompt at turn time
+-- so they can be edited, compared and shared separately.
+ALTER TABLE agents ADD COLUMN persona_role TEXT NOT NULL DEFAULT '';
+ALTER TABLE agents ADD COLUMN persona_goals TEXT NOT NULL DEFAULT '';
+ALTER TABLE agents ADD COLUMN persona_constraints TEXT NOT NULL DEFAULT '';
+ALTER TABLE agents ADD COLUMN persona_style TEXT NOT NULL DEFAULT '';
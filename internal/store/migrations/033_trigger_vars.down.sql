@@ -0,0 +1 @@
+ALTER TABLE triggers DROP COLUMN vars;
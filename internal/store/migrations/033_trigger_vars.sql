@@ -0,0 +1,2 @@
+-- Variables of a trigger's runs, as a JSON object of names to values.
+ALTER TABLE triggers ADD COLUMN vars TEXT NOT NULL DEFAULT '{}';
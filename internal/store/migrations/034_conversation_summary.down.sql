@@ -0,0 +1,2 @@
+ALTER TABLE conversations DROP COLUMN summary;
+ALTER TABLE conversations DROP COLUMN summary_message_id;
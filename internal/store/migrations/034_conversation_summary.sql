@@ -0,0 +1,4 @@
+-- Summary of the oldest messages of a conversation that no longer fit the
+-- history of a turn, through summary_message_id.
+ALTER TABLE conversations ADD COLUMN summary TEXT NOT NULL DEFAULT '';
+ALTER TABLE conversations ADD COLUMN summary_message_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE agents DROP COLUMN approval_rules;
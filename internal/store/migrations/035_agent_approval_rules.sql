@@ -0,0 +1,2 @@
+-- JSON array of rules whose matching tool calls wait for approval.
+ALTER TABLE agents ADD COLUMN approval_rules TEXT NOT NULL DEFAULT '[]';
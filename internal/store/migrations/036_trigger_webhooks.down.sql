@@ -0,0 +1 @@
+DROP TABLE IF EXISTS trigger_webhooks;
@@ -0,0 +1,13 @@
+-- Webhooks that run a trigger, authenticated by the token in their URL, of
+-- which only the hash is stored, and optionally by an HMAC signature with
+-- secret (encrypted with ENCRYPTION_KEY, if set). allowed_ips is a JSON array
+-- of IP addresses and CIDR ranges.
+CREATE TABLE IF NOT EXISTS trigger_webhooks (
+    trigger_id TEXT PRIMARY KEY REFERENCES triggers(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    secret TEXT NOT NULL DEFAULT '',
+    signature_scheme TEXT NOT NULL DEFAULT '',
+    allowed_ips TEXT NOT NULL DEFAULT '[]',
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_trigger_runs_started;
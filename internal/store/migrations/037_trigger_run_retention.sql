@@ -0,0 +1,2 @@
+-- Index for pruning trigger runs older than TRIGGER_RUN_RETENTION.
+CREATE INDEX IF NOT EXISTS idx_trigger_runs_started ON trigger_runs(started_at);
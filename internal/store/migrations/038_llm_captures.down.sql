@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS llm_exchanges;
+DROP TABLE IF EXISTS llm_captures;
@@ -0,0 +1,37 @@
+-- Debug capture of LLM calls. While an llm_captures row of an agent or
+-- conversation hasn't expired, the raw request and response bodies of its
+-- turns' LLM calls are stored in llm_exchanges, with secrets redacted, for
+-- retention_seconds.
+CREATE TABLE IF NOT EXISTS llm_captures (
+    id TEXT PRIMARY KEY,
+    agent_id TEXT REFERENCES agents(id) ON DELETE CASCADE,
+    conversation_id TEXT REFERENCES conversations(id) ON DELETE CASCADE,
+    retention_seconds INTEGER NOT NULL,
+    expires_at TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+-- response_headers is a JSON object of header names to values. status_code
+-- is 0 if no response was received.
+CREATE TABLE IF NOT EXISTS llm_exchanges (
+    id TEXT PRIMARY KEY,
+    capture_id TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    agent_id TEXT NOT NULL,
+    round INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    key_id TEXT NOT NULL,
+    request_body TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    response_headers TEXT NOT NULL DEFAULT '{}',
+    response_body TEXT NOT NULL,
+    truncated INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_exchanges_conversation ON llm_exchanges(conversation_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_llm_exchanges_run ON llm_exchanges(run_id, round);
+CREATE INDEX IF NOT EXISTS idx_llm_exchanges_expires ON llm_exchanges(expires_at);
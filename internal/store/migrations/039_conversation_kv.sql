@@ -0,0 +1,10 @@
+-- Small values a conversation's turns stash with the set_context tool, such
+-- as IDs and URLs, kept until the conversation is deleted.
+CREATE TABLE IF NOT EXISTS conversation_kv (
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (conversation_id, key)
+);
@@ -0,0 +1,9 @@
+-- The conversation of each chat with the bot of a Telegram channel, which
+-- the channel's agent continues with each message from the chat.
+CREATE TABLE IF NOT EXISTS telegram_chats (
+    channel_id TEXT NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    chat_id TEXT NOT NULL,
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (channel_id, chat_id)
+);
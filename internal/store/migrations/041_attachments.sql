@@ -0,0 +1,11 @@
+-- Files attached to user messages. Their contents are blobs of kind
+-- "attachment"; the message items refer to them by attachment ID.
+CREATE TABLE IF NOT EXISTS attachments (
+    id TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    message_id TEXT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    blob_id TEXT NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_conversation_id ON attachments(conversation_id);
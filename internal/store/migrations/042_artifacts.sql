@@ -0,0 +1,18 @@
+-- Outputs of tool calls, such as files they created, registered on the run
+-- that made the call. Artifacts stored as blobs refer to them by blob ID.
+CREATE TABLE IF NOT EXISTS artifacts (
+    id TEXT PRIMARY KEY,
+    run_id TEXT NOT NULL,
+    conversation_id TEXT NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    call_id TEXT NOT NULL DEFAULT '',
+    tool_name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    uri TEXT NOT NULL DEFAULT '',
+    media_type TEXT NOT NULL DEFAULT '',
+    size INTEGER NOT NULL DEFAULT 0,
+    blob_id TEXT REFERENCES blobs(id) ON DELETE SET NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_artifacts_conversation_id ON artifacts(conversation_id, run_id);
@@ -0,0 +1,2 @@
+-- Locale of an agent, as a BCP 47 language tag; empty for English.
+ALTER TABLE agents ADD COLUMN locale TEXT NOT NULL DEFAULT '';
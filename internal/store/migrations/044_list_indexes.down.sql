@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_api_keys_created;
+DROP INDEX IF EXISTS idx_notification_channels_created;
+DROP INDEX IF EXISTS idx_triggers_created;
+DROP INDEX IF EXISTS idx_agents_created;
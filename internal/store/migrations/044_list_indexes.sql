@@ -0,0 +1,6 @@
+-- Cover the default order of list RPCs that read pages from the database,
+-- with the ID that breaks ties between pages.
+CREATE INDEX IF NOT EXISTS idx_agents_created ON agents(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_triggers_created ON triggers(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_notification_channels_created ON notification_channels(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_created ON api_keys(created_at, id);
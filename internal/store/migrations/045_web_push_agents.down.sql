@@ -0,0 +1 @@
+ALTER TABLE web_push_subscriptions DROP COLUMN agent_ids;
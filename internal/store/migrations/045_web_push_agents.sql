@@ -0,0 +1,3 @@
+-- Agents a push subscription is notified about, from the API key restrictions
+-- of the user who subscribed; an empty list allows all agents.
+ALTER TABLE web_push_subscriptions ADD COLUMN agent_ids TEXT NOT NULL DEFAULT '[]';
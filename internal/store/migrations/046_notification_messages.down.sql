@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notification_messages_delivery;
+DROP TABLE IF EXISTS notification_messages;
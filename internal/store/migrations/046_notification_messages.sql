@@ -0,0 +1,11 @@
+-- Chat messages notifications were sent as, e.g. on Telegram and Slack, so
+-- replies to them are routed back to the conversation of the notification.
+CREATE TABLE IF NOT EXISTS notification_messages (
+    channel_id TEXT NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    chat_id TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    delivery_id TEXT NOT NULL REFERENCES notification_deliveries(id) ON DELETE CASCADE,
+    PRIMARY KEY (channel_id, chat_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_messages_delivery ON notification_messages(delivery_id);
@@ -0,0 +1 @@
+ALTER TABLE queued_notifications DROP COLUMN attempts;
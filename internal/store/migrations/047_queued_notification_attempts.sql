@@ -0,0 +1,3 @@
+-- Failed deliveries of queued notifications are retried with backoff, up to
+-- a limit.
+ALTER TABLE queued_notifications ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
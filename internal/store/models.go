@@ -20,6 +20,18 @@ type Agent struct {
 	UpdatedAt                   string
 	EnabledFilesystemRoots      string
 	ForwardedHostEnvVars        string
+	Version                     int64
+	Hooks                       string
+	MaxConcurrentRuns           int64
+	TitleGenerationDisabled     int64
+	TitlePrompt                 string
+	TitleModel                  string
+	PersonaRole                 string
+	PersonaGoals                string
+	PersonaConstraints          string
+	PersonaStyle                string
+	ApprovalRules               string
+	Locale                      string
 }
 
 type AgentFile struct {
@@ -30,6 +42,66 @@ type AgentFile struct {
 	UpdatedAt string
 }
 
+type ApiKey struct {
+	ID         string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	CreatedAt  string
+	LastUsedAt sql.NullString
+	RevokedAt  sql.NullString
+	AgentIds   string
+	Scopes     string
+}
+
+type Artifact struct {
+	ID             string
+	RunID          string
+	ConversationID string
+	CallID         string
+	ToolName       string
+	Kind           string
+	Name           string
+	Uri            string
+	MediaType      string
+	Size           int64
+	BlobID         sql.NullString
+	CreatedAt      string
+}
+
+type Attachment struct {
+	ID             string
+	ConversationID string
+	MessageID      string
+	BlobID         string
+	CreatedAt      string
+}
+
+type AuditLog struct {
+	ID           string
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Procedure    string
+	Details      string
+	RemoteAddr   string
+	UserAgent    string
+	CreatedAt    string
+}
+
+type Blob struct {
+	ID             string
+	Kind           string
+	ConversationID sql.NullString
+	Name           string
+	ContentType    string
+	Size           int64
+	Sha256         string
+	CreatedAt      string
+	ExpiresAt      sql.NullString
+}
+
 type Conversation struct {
 	ID                 string
 	AgentID            string
@@ -37,6 +109,58 @@ type Conversation struct {
 	PreviousResponseID string
 	CreatedAt          string
 	UpdatedAt          string
+	Summary            string
+	SummaryMessageID   string
+}
+
+type ConversationKv struct {
+	ConversationID string
+	Key            string
+	Value          string
+	CreatedAt      string
+	UpdatedAt      string
+}
+
+type ConversationLease struct {
+	ConversationID string
+	Holder         string
+	AcquiredAt     string
+	ExpiresAt      string
+}
+
+type EvalCase struct {
+	ID         string
+	AgentID    string
+	Name       string
+	Prompt     string
+	Assertions string
+	Rubric     string
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+type EvalRun struct {
+	ID           string
+	AgentID      string
+	SystemPrompt string
+	Model        string
+	Candidate    int64
+	Status       string
+	Score        float64
+	Passed       int64
+	Total        int64
+	Results      string
+	Error        string
+	CreatedAt    string
+	FinishedAt   sql.NullString
+}
+
+type Event struct {
+	ConversationID string
+	Seq            int64
+	Type           string
+	Payload        string
+	CreatedAt      string
 }
 
 type FilesystemRoot struct {
@@ -46,6 +170,35 @@ type FilesystemRoot struct {
 	Description string
 	CreatedAt   string
 	UpdatedAt   string
+	Version     int64
+}
+
+type LlmCapture struct {
+	ID               string
+	AgentID          sql.NullString
+	ConversationID   sql.NullString
+	RetentionSeconds int64
+	ExpiresAt        string
+	CreatedAt        string
+}
+
+type LlmExchange struct {
+	ID              string
+	CaptureID       string
+	RunID           string
+	ConversationID  string
+	AgentID         string
+	Round           int64
+	Url             string
+	KeyID           string
+	RequestBody     string
+	StatusCode      int64
+	ResponseHeaders string
+	ResponseBody    string
+	Truncated       int64
+	Error           string
+	CreatedAt       string
+	ExpiresAt       string
 }
 
 type Message struct {
@@ -54,31 +207,116 @@ type Message struct {
 	Role           string
 	Items          string
 	CreatedAt      string
+	Status         string
+	RunID          string
+	TokenCount     int64
 }
 
 type NotificationChannel struct {
-	ID          string
-	Name        string
-	Type        string
-	Config      string
-	Description string
-	JsonSchema  string
-	CreatedAt   string
-	UpdatedAt   string
+	ID                 string
+	Name               string
+	Type               string
+	Config             string
+	Description        string
+	JsonSchema         string
+	CreatedAt          string
+	UpdatedAt          string
+	QuietHoursStart    string
+	QuietHoursEnd      string
+	QuietHoursTimezone string
+	QuietHoursMode     string
+	MaxPerHour         int64
+	DigestSchedule     string
+	Version            int64
+}
+
+type NotificationDelivery struct {
+	ID             string
+	ChannelID      string
+	DeliveredAt    string
+	ConversationID sql.NullString
+}
+
+type NotificationMessage struct {
+	ChannelID  string
+	ChatID     string
+	MessageID  string
+	DeliveryID string
+}
+
+type QueuedNotification struct {
+	ID             string
+	ChannelID      string
+	Payload        string
+	DeliverAfter   string
+	CreatedAt      string
+	ConversationID sql.NullString
+	Attempts       int64
+}
+
+type RunTrace struct {
+	RunID          string
+	AgentID        string
+	ConversationID string
+	Kind           string
+	Model          string
+	Status         string
+	Error          string
+	InputTokens    int64
+	OutputTokens   int64
+	Trace          string
+	StartedAt      string
+	FinishedAt     string
+	CostUsd        sql.NullFloat64
+}
+
+type SearchDocument struct {
+	ID             int64
+	ConversationID string
+	MessageID      string
+	Content        string
+}
+
+type Session struct {
+	TokenHash string
+	ApiKeyID  sql.NullString
+	Subject   string
+	Name      string
+	Email     string
+	Role      string
+	CreatedAt string
+	ExpiresAt string
+}
+
+type Setting struct {
+	Key       string
+	Value     string
+	UpdatedAt string
+}
+
+type TelegramChat struct {
+	ChannelID      string
+	ChatID         string
+	ConversationID string
+	CreatedAt      string
 }
 
 type Trigger struct {
-	ID                string
-	AgentID           string
-	Name              string
-	Prompt            string
-	CronExpr          sql.NullString
-	Enabled           int64
-	NextRunAt         sql.NullString
-	Model             string
-	ConversationTitle string
-	CreatedAt         string
-	UpdatedAt         string
+	ID                 string
+	AgentID            string
+	Name               string
+	Prompt             string
+	CronExpr           sql.NullString
+	Enabled            int64
+	NextRunAt          sql.NullString
+	Model              string
+	ConversationTitle  string
+	CreatedAt          string
+	UpdatedAt          string
+	Version            int64
+	MaxDurationSeconds int64
+	Priority           int64
+	Vars               string
 }
 
 type TriggerRun struct {
@@ -90,3 +328,42 @@ type TriggerRun struct {
 	StartedAt      string
 	FinishedAt     sql.NullString
 }
+
+type TriggerWebhook struct {
+	TriggerID       string
+	TokenHash       string
+	Secret          string
+	SignatureScheme string
+	AllowedIps      string
+	CreatedAt       string
+	UpdatedAt       string
+}
+
+type TurnRecording struct {
+	RunID          string
+	ConversationID string
+	Recording      string
+	CreatedAt      string
+}
+
+type TurnUsage struct {
+	RunID          string
+	AgentID        string
+	ConversationID string
+	Kind           string
+	Model          string
+	Status         string
+	InputTokens    int64
+	OutputTokens   int64
+	StartedAt      string
+	FinishedAt     string
+}
+
+type WebPushSubscription struct {
+	ID        string
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	CreatedAt string
+	AgentIds  string
+}
@@ -0,0 +1,259 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+)
+
+// PageQuery selects a page of the rows of a table, for list RPCs with
+// filters and orders that sqlc queries can't express. It's built by
+// listing.Fetch from column expressions set in code, never from user input,
+// which is only passed as Args.
+type PageQuery struct {
+	// Where is a condition with ? placeholders for Args, "" for all rows.
+	Where string
+	Args  []any
+	// OrderBy is an ORDER BY clause without the keywords.
+	OrderBy string
+	// Limit is the maximum number of rows; 0 selects all.
+	Limit int
+}
+
+func (p PageQuery) build(query string) (string, []any) {
+	args := slices.Clip(p.Args)
+	if p.Where != "" {
+		query += " WHERE " + p.Where
+	}
+	if p.OrderBy != "" {
+		query += " ORDER BY " + p.OrderBy
+	}
+	if p.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, p.Limit)
+	}
+	return query, args
+}
+
+func selectPage[T any](ctx context.Context, db DBTX, query string, p PageQuery, scan func(*sql.Rows, *T) error) ([]T, error) {
+	query, args := p.build(query)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []T
+	for rows.Next() {
+		var i T
+		if err := scan(rows, &i); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// countPage counts the rows of a table matching p.Where.
+func countPage(ctx context.Context, db DBTX, table string, p PageQuery) (int64, error) {
+	query, args := PageQuery{Where: p.Where, Args: p.Args}.build("SELECT COUNT(*) FROM " + table)
+	var n int64
+	err := db.QueryRowContext(ctx, query, args...).Scan(&n)
+	return n, err
+}
+
+func (q *Queries) ListAgentsPage(ctx context.Context, p PageQuery) ([]Agent, error) {
+	return selectPage(ctx, q.db, "SELECT id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars, version, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale FROM agents", p, func(rows *sql.Rows, i *Agent) error {
+		return rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.SystemPrompt,
+			&i.EnabledTools,
+			&i.EnabledNotificationChannels,
+			&i.Model,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EnabledFilesystemRoots,
+			&i.ForwardedHostEnvVars,
+			&i.Version,
+			&i.Hooks,
+			&i.MaxConcurrentRuns,
+			&i.TitleGenerationDisabled,
+			&i.TitlePrompt,
+			&i.TitleModel,
+			&i.PersonaRole,
+			&i.PersonaGoals,
+			&i.PersonaConstraints,
+			&i.PersonaStyle,
+			&i.ApprovalRules,
+			&i.Locale,
+		)
+	})
+}
+
+func (q *Queries) CountAgentsPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "agents", p)
+}
+
+func (q *Queries) ListConversationsPage(ctx context.Context, p PageQuery) ([]Conversation, error) {
+	return selectPage(ctx, q.db, "SELECT id, agent_id, title, previous_response_id, created_at, updated_at, summary, summary_message_id FROM conversations", p, func(rows *sql.Rows, i *Conversation) error {
+		return rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.Title,
+			&i.PreviousResponseID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Summary,
+			&i.SummaryMessageID,
+		)
+	})
+}
+
+func (q *Queries) CountConversationsPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "conversations", p)
+}
+
+func (q *Queries) ListTriggersPage(ctx context.Context, p PageQuery) ([]Trigger, error) {
+	return selectPage(ctx, q.db, "SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars FROM triggers", p, func(rows *sql.Rows, i *Trigger) error {
+		return rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.Name,
+			&i.Prompt,
+			&i.CronExpr,
+			&i.Enabled,
+			&i.NextRunAt,
+			&i.Model,
+			&i.ConversationTitle,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.MaxDurationSeconds,
+			&i.Priority,
+			&i.Vars,
+		)
+	})
+}
+
+func (q *Queries) CountTriggersPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "triggers", p)
+}
+
+func (q *Queries) ListTriggerRunsPage(ctx context.Context, p PageQuery) ([]TriggerRun, error) {
+	return selectPage(ctx, q.db, "SELECT id, trigger_id, conversation_id, status, error_message, started_at, finished_at FROM trigger_runs", p, func(rows *sql.Rows, i *TriggerRun) error {
+		return rows.Scan(
+			&i.ID,
+			&i.TriggerID,
+			&i.ConversationID,
+			&i.Status,
+			&i.ErrorMessage,
+			&i.StartedAt,
+			&i.FinishedAt,
+		)
+	})
+}
+
+func (q *Queries) CountTriggerRunsPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "trigger_runs", p)
+}
+
+func (q *Queries) ListNotificationChannelsPage(ctx context.Context, p PageQuery) ([]NotificationChannel, error) {
+	return selectPage(ctx, q.db, "SELECT id, name, type, config, description, json_schema, created_at, updated_at, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, version FROM notification_channels", p, func(rows *sql.Rows, i *NotificationChannel) error {
+		return rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.Config,
+			&i.Description,
+			&i.JsonSchema,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.QuietHoursTimezone,
+			&i.QuietHoursMode,
+			&i.MaxPerHour,
+			&i.DigestSchedule,
+			&i.Version,
+		)
+	})
+}
+
+func (q *Queries) CountNotificationChannelsPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "notification_channels", p)
+}
+
+func (q *Queries) ListAuditEntriesPage(ctx context.Context, p PageQuery) ([]AuditLog, error) {
+	return selectPage(ctx, q.db, "SELECT id, actor, action, resource_type, resource_id, procedure, details, remote_addr, user_agent, created_at FROM audit_log", p, func(rows *sql.Rows, i *AuditLog) error {
+		return rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.Procedure,
+			&i.Details,
+			&i.RemoteAddr,
+			&i.UserAgent,
+			&i.CreatedAt,
+		)
+	})
+}
+
+func (q *Queries) CountAuditEntriesPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "audit_log", p)
+}
+
+func (q *Queries) ListAPIKeysPage(ctx context.Context, p PageQuery) ([]ApiKey, error) {
+	return selectPage(ctx, q.db, "SELECT id, name, prefix, key_hash, created_at, last_used_at, revoked_at, agent_ids, scopes FROM api_keys", p, func(rows *sql.Rows, i *ApiKey) error {
+		return rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.AgentIds,
+			&i.Scopes,
+		)
+	})
+}
+
+func (q *Queries) CountAPIKeysPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "api_keys", p)
+}
+
+func (q *Queries) ListLLMExchangesPage(ctx context.Context, p PageQuery) ([]LlmExchange, error) {
+	return selectPage(ctx, q.db, "SELECT id, capture_id, run_id, conversation_id, agent_id, round, url, key_id, request_body, status_code, response_headers, response_body, truncated, error, created_at, expires_at FROM llm_exchanges", p, func(rows *sql.Rows, i *LlmExchange) error {
+		return rows.Scan(
+			&i.ID,
+			&i.CaptureID,
+			&i.RunID,
+			&i.ConversationID,
+			&i.AgentID,
+			&i.Round,
+			&i.Url,
+			&i.KeyID,
+			&i.RequestBody,
+			&i.StatusCode,
+			&i.ResponseHeaders,
+			&i.ResponseBody,
+			&i.Truncated,
+			&i.Error,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		)
+	})
+}
+
+func (q *Queries) CountLLMExchangesPage(ctx context.Context, p PageQuery) (int64, error) {
+	return countPage(ctx, q.db, "llm_exchanges", p)
+}
@@ -1,6 +1,6 @@
 -- name: CreateAgent :one
-INSERT INTO agents (id, name, description, system_prompt, enabled_tools, enabled_notification_channels, enabled_filesystem_roots, model, forwarded_host_env_vars, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO agents (id, name, description, system_prompt, enabled_tools, enabled_notification_channels, enabled_filesystem_roots, model, forwarded_host_env_vars, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetAgent :one
@@ -11,8 +11,8 @@ SELECT * FROM agents ORDER BY created_at DESC;
 
 -- name: UpdateAgent :one
 UPDATE agents
-SET name = ?, description = ?, system_prompt = ?, enabled_tools = ?, enabled_notification_channels = ?, enabled_filesystem_roots = ?, model = ?, forwarded_host_env_vars = ?, updated_at = ?
-WHERE id = ?
+SET name = ?, description = ?, system_prompt = ?, enabled_tools = ?, enabled_notification_channels = ?, enabled_filesystem_roots = ?, model = ?, forwarded_host_env_vars = ?, hooks = ?, max_concurrent_runs = ?, title_generation_disabled = ?, title_prompt = ?, title_model = ?, persona_role = ?, persona_goals = ?, persona_constraints = ?, persona_style = ?, approval_rules = ?, locale = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ?
 RETURNING *;
 
 -- name: DeleteAgent :exec
@@ -26,34 +26,42 @@ RETURNING *;
 -- name: GetConversation :one
 SELECT * FROM conversations WHERE id = ?;
 
--- name: ListConversations :many
-SELECT * FROM conversations WHERE agent_id = ? ORDER BY updated_at DESC;
-
--- name: ListAllConversations :many
-SELECT * FROM conversations ORDER BY updated_at DESC;
-
 -- name: UpdateConversation :one
 UPDATE conversations
 SET title = ?, previous_response_id = ?, updated_at = ?
 WHERE id = ?
 RETURNING *;
 
+-- name: UpdateConversationSummary :exec
+UPDATE conversations SET summary = ?, summary_message_id = ? WHERE id = ?;
+
 -- name: DeleteConversation :exec
 DELETE FROM conversations WHERE id = ?;
 
 -- name: CreateMessage :one
-INSERT INTO messages (id, conversation_id, role, items, created_at)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO messages (id, conversation_id, role, items, status, run_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetMessagesByConversation :many
 SELECT * FROM messages WHERE conversation_id = ? ORDER BY created_at ASC;
 
+-- name: UpdateMessageItems :exec
+UPDATE messages SET items = ?, status = ?, token_count = 0 WHERE id = ?;
+
+-- name: UpdateMessageTokenCount :exec
+UPDATE messages SET token_count = ? WHERE id = ?;
+
+-- name: InterruptUnleasedMessages :execrows
+UPDATE messages SET status = 'interrupted'
+WHERE status = 'in_progress'
+AND conversation_id NOT IN (SELECT conversation_id FROM conversation_leases WHERE expires_at > ?);
+
 -- Triggers
 
 -- name: CreateTrigger :one
-INSERT INTO triggers (id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO triggers (id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, max_duration_seconds, priority, vars, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetTrigger :one
@@ -66,8 +74,8 @@ SELECT * FROM triggers WHERE agent_id = ? ORDER BY created_at DESC;
 SELECT * FROM triggers ORDER BY created_at DESC;
 
 -- name: UpdateTrigger :one
-UPDATE triggers SET name = ?, prompt = ?, cron_expr = ?, enabled = ?, next_run_at = ?, updated_at = ?
-WHERE id = ? RETURNING *;
+UPDATE triggers SET name = ?, prompt = ?, cron_expr = ?, enabled = ?, next_run_at = ?, max_duration_seconds = ?, priority = ?, vars = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ? RETURNING *;
 
 -- name: DeleteTrigger :exec
 DELETE FROM triggers WHERE id = ?;
@@ -78,6 +86,17 @@ SELECT * FROM triggers WHERE enabled = 1 AND next_run_at <= ? ORDER BY next_run_
 -- name: UpdateTriggerNextRun :exec
 UPDATE triggers SET next_run_at = ?, updated_at = ? WHERE id = ?;
 
+-- name: UpdateTriggerEnabled :one
+UPDATE triggers SET enabled = ?, next_run_at = ?, updated_at = ?, version = version + 1
+WHERE id = ? RETURNING *;
+
+-- name: UpdateTriggerAgent :one
+UPDATE triggers SET agent_id = ?, updated_at = ?, version = version + 1
+WHERE id = ? RETURNING *;
+
+-- name: UpdateTriggerVars :exec
+UPDATE triggers SET vars = ? WHERE id = ?;
+
 -- Trigger Runs
 
 -- name: CreateTriggerRun :one
@@ -89,14 +108,54 @@ RETURNING *;
 UPDATE trigger_runs SET status = ?, error_message = ?, conversation_id = ?, finished_at = ?
 WHERE id = ?;
 
+-- name: InterruptRunningTriggerRuns :execrows
+UPDATE trigger_runs SET status = 'interrupted', error_message = ?, finished_at = ?
+WHERE status = 'running';
+
 -- name: ListTriggerRuns :many
 SELECT * FROM trigger_runs WHERE trigger_id = ? ORDER BY started_at DESC LIMIT ?;
 
+-- name: GetTriggerRun :one
+SELECT * FROM trigger_runs WHERE id = ?;
+
+-- name: DeleteTriggerRunsBefore :execrows
+DELETE FROM trigger_runs WHERE status != 'running' AND started_at < ?;
+
+-- Trigger Webhooks
+
+-- name: CreateTriggerWebhook :one
+INSERT INTO trigger_webhooks (trigger_id, token_hash, secret, signature_scheme, allowed_ips, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (trigger_id) DO UPDATE SET
+    token_hash = excluded.token_hash, secret = excluded.secret, signature_scheme = excluded.signature_scheme,
+    allowed_ips = excluded.allowed_ips, created_at = excluded.created_at, updated_at = excluded.updated_at
+RETURNING *;
+
+-- name: GetTriggerWebhook :one
+SELECT * FROM trigger_webhooks WHERE trigger_id = ?;
+
+-- name: GetTriggerWebhookByTokenHash :one
+SELECT * FROM trigger_webhooks WHERE token_hash = ?;
+
+-- name: UpdateTriggerWebhook :one
+UPDATE trigger_webhooks SET secret = ?, signature_scheme = ?, allowed_ips = ?, updated_at = ?
+WHERE trigger_id = ?
+RETURNING *;
+
+-- name: DeleteTriggerWebhook :execrows
+DELETE FROM trigger_webhooks WHERE trigger_id = ?;
+
+-- name: ListTriggerWebhooks :many
+SELECT * FROM trigger_webhooks ORDER BY trigger_id;
+
+-- name: UpdateTriggerWebhookSecret :exec
+UPDATE trigger_webhooks SET secret = ? WHERE trigger_id = ?;
+
 -- Notification Channels
 
 -- name: CreateNotificationChannel :one
-INSERT INTO notification_channels (id, name, type, config, description, json_schema, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO notification_channels (id, name, type, config, description, json_schema, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetNotificationChannelByName :one
@@ -109,12 +168,52 @@ SELECT * FROM notification_channels WHERE id = ?;
 SELECT * FROM notification_channels ORDER BY created_at DESC;
 
 -- name: UpdateNotificationChannel :one
-UPDATE notification_channels SET name = ?, type = ?, config = ?, description = ?, json_schema = ?, updated_at = ?
-WHERE id = ? RETURNING *;
+UPDATE notification_channels SET name = ?, type = ?, config = ?, description = ?, json_schema = ?, quiet_hours_start = ?, quiet_hours_end = ?, quiet_hours_timezone = ?, quiet_hours_mode = ?, max_per_hour = ?, digest_schedule = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ? RETURNING *;
 
 -- name: DeleteNotificationChannel :exec
 DELETE FROM notification_channels WHERE id = ?;
 
+-- Notification Deliveries
+
+-- name: CreateNotificationDelivery :exec
+INSERT INTO notification_deliveries (id, channel_id, conversation_id, delivered_at)
+VALUES (?, ?, ?, ?);
+
+-- name: GetNotificationDelivery :one
+SELECT * FROM notification_deliveries WHERE id = ?;
+
+-- name: CountNotificationDeliveriesSince :one
+SELECT COUNT(*) FROM notification_deliveries WHERE channel_id = ? AND delivered_at >= ?;
+
+-- name: DeleteNotificationDeliveriesBefore :exec
+DELETE FROM notification_deliveries WHERE delivered_at < ?;
+
+-- Notification Messages
+
+-- name: CreateNotificationMessage :exec
+INSERT INTO notification_messages (channel_id, chat_id, message_id, delivery_id)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (channel_id, chat_id, message_id) DO NOTHING;
+
+-- name: GetNotificationMessage :one
+SELECT * FROM notification_messages WHERE channel_id = ? AND chat_id = ? AND message_id = ?;
+
+-- Queued Notifications
+
+-- name: CreateQueuedNotification :exec
+INSERT INTO queued_notifications (id, channel_id, conversation_id, payload, deliver_after, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListDueQueuedNotifications :many
+SELECT * FROM queued_notifications WHERE deliver_after <= ? ORDER BY created_at ASC;
+
+-- name: DeleteQueuedNotification :exec
+DELETE FROM queued_notifications WHERE id = ?;
+
+-- name: RetryQueuedNotification :exec
+UPDATE queued_notifications SET attempts = attempts + 1, deliver_after = ? WHERE id = ?;
+
 -- Filesystem Roots
 
 -- name: CreateFilesystemRoot :one
@@ -132,8 +231,8 @@ SELECT * FROM filesystem_roots WHERE name = ?;
 SELECT * FROM filesystem_roots ORDER BY created_at DESC;
 
 -- name: UpdateFilesystemRoot :one
-UPDATE filesystem_roots SET name = ?, path = ?, description = ?, updated_at = ?
-WHERE id = ? RETURNING *;
+UPDATE filesystem_roots SET name = ?, path = ?, description = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ? RETURNING *;
 
 -- name: DeleteFilesystemRoot :exec
 DELETE FROM filesystem_roots WHERE id = ?;
@@ -156,3 +255,361 @@ ORDER BY path ASC;
 
 -- name: DeleteAgentFile :exec
 DELETE FROM agent_files WHERE agent_id = ? AND path = ?;
+
+-- Conversation Context
+
+-- name: UpsertConversationKV :exec
+INSERT INTO conversation_kv (conversation_id, key, value, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (conversation_id, key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at;
+
+-- name: GetConversationKV :one
+SELECT * FROM conversation_kv WHERE conversation_id = ? AND key = ?;
+
+-- name: ListConversationKV :many
+SELECT * FROM conversation_kv WHERE conversation_id = ? ORDER BY key;
+
+-- name: DeleteConversationKV :execrows
+DELETE FROM conversation_kv WHERE conversation_id = ? AND key = ?;
+
+-- Telegram Chats
+
+-- name: UpsertTelegramChat :exec
+INSERT INTO telegram_chats (channel_id, chat_id, conversation_id, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (channel_id, chat_id) DO UPDATE SET conversation_id = excluded.conversation_id, created_at = excluded.created_at;
+
+-- name: GetTelegramChat :one
+SELECT * FROM telegram_chats WHERE channel_id = ? AND chat_id = ?;
+
+-- name: DeleteTelegramChat :exec
+DELETE FROM telegram_chats WHERE channel_id = ? AND chat_id = ?;
+
+-- Settings
+
+-- name: GetSetting :one
+SELECT value FROM settings WHERE key = ?;
+
+-- name: GetSettingEntry :one
+SELECT key, value, updated_at FROM settings WHERE key = ?;
+
+-- name: UpsertSetting :exec
+INSERT INTO settings (key, value, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT (key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at;
+
+-- Web Push Subscriptions
+
+-- name: UpsertWebPushSubscription :exec
+INSERT INTO web_push_subscriptions (id, endpoint, p256dh, auth, created_at, agent_ids)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (endpoint) DO UPDATE SET p256dh = excluded.p256dh, auth = excluded.auth, agent_ids = excluded.agent_ids;
+
+-- name: ListWebPushSubscriptions :many
+SELECT * FROM web_push_subscriptions ORDER BY created_at ASC;
+
+-- name: DeleteWebPushSubscription :exec
+DELETE FROM web_push_subscriptions WHERE endpoint = ?;
+
+-- name: UpsertAgent :exec
+INSERT INTO agents (id, name, description, system_prompt, enabled_tools, enabled_notification_channels, enabled_filesystem_roots, model, forwarded_host_env_vars, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    name = excluded.name, description = excluded.description, system_prompt = excluded.system_prompt,
+    enabled_tools = excluded.enabled_tools, enabled_notification_channels = excluded.enabled_notification_channels,
+    enabled_filesystem_roots = excluded.enabled_filesystem_roots, model = excluded.model,
+    forwarded_host_env_vars = excluded.forwarded_host_env_vars, hooks = excluded.hooks, max_concurrent_runs = excluded.max_concurrent_runs,
+    title_generation_disabled = excluded.title_generation_disabled, title_prompt = excluded.title_prompt, title_model = excluded.title_model,
+    persona_role = excluded.persona_role, persona_goals = excluded.persona_goals, persona_constraints = excluded.persona_constraints, persona_style = excluded.persona_style, approval_rules = excluded.approval_rules, locale = excluded.locale,
+    updated_at = excluded.updated_at, version = agents.version + 1;
+
+-- name: UpsertTrigger :exec
+INSERT INTO triggers (id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, max_duration_seconds, priority, vars, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    agent_id = excluded.agent_id, name = excluded.name, prompt = excluded.prompt, cron_expr = excluded.cron_expr,
+    enabled = excluded.enabled, next_run_at = excluded.next_run_at, model = excluded.model,
+    conversation_title = excluded.conversation_title, max_duration_seconds = excluded.max_duration_seconds, priority = excluded.priority, vars = excluded.vars,
+    updated_at = excluded.updated_at, version = triggers.version + 1;
+
+-- name: UpsertNotificationChannel :exec
+INSERT INTO notification_channels (id, name, type, config, description, json_schema, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    name = excluded.name, type = excluded.type, config = excluded.config, description = excluded.description,
+    json_schema = excluded.json_schema, quiet_hours_start = excluded.quiet_hours_start,
+    quiet_hours_end = excluded.quiet_hours_end, quiet_hours_timezone = excluded.quiet_hours_timezone,
+    quiet_hours_mode = excluded.quiet_hours_mode, max_per_hour = excluded.max_per_hour,
+    digest_schedule = excluded.digest_schedule, updated_at = excluded.updated_at,
+    version = notification_channels.version + 1;
+
+-- name: UpsertFilesystemRoot :exec
+INSERT INTO filesystem_roots (id, name, path, description, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    name = excluded.name, path = excluded.path, description = excluded.description,
+    updated_at = excluded.updated_at, version = filesystem_roots.version + 1;
+
+-- name: UpdateNotificationChannelConfig :exec
+UPDATE notification_channels SET config = ? WHERE id = ?;
+
+-- Audit Log
+
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (id, actor, action, resource_type, resource_id, procedure, details, remote_addr, user_agent, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListAuditEntries :many
+SELECT * FROM audit_log ORDER BY created_at DESC, id DESC;
+
+-- API Keys
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (id, name, prefix, key_hash, agent_ids, scopes, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetAPIKey :one
+SELECT * FROM api_keys WHERE id = ?;
+
+-- name: GetAPIKeyByHash :one
+SELECT * FROM api_keys WHERE key_hash = ?;
+
+-- name: ListAPIKeys :many
+SELECT * FROM api_keys ORDER BY created_at DESC;
+
+-- name: CountActiveAPIKeys :one
+SELECT COUNT(*) FROM api_keys WHERE revoked_at IS NULL;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys SET last_used_at = ? WHERE id = ?;
+
+-- Sessions
+
+-- name: CreateSession :exec
+INSERT INTO sessions (token_hash, api_key_id, subject, name, email, role, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetSession :one
+SELECT * FROM sessions WHERE token_hash = ? AND expires_at > ?;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions WHERE token_hash = ?;
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions WHERE expires_at <= ?;
+
+-- Events
+
+-- name: CreateEvent :exec
+INSERT INTO events (conversation_id, seq, type, payload, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListEventsAfter :many
+SELECT * FROM events WHERE conversation_id = ? AND seq > ? ORDER BY seq;
+
+-- name: GetLastEventSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) AS seq FROM events WHERE conversation_id = ?;
+
+-- name: DeleteEventsBefore :exec
+DELETE FROM events
+WHERE created_at < ?
+  AND seq < (SELECT MAX(e.seq) FROM events e WHERE e.conversation_id = events.conversation_id);
+
+-- Conversation leases
+
+-- name: AcquireConversationLease :execrows
+INSERT INTO conversation_leases (conversation_id, holder, acquired_at, expires_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (conversation_id) DO UPDATE
+SET holder = excluded.holder, acquired_at = excluded.acquired_at, expires_at = excluded.expires_at
+WHERE conversation_leases.expires_at <= excluded.acquired_at;
+
+-- name: ReleaseConversationLease :exec
+DELETE FROM conversation_leases WHERE conversation_id = ? AND holder = ?;
+
+-- name: RenewConversationLeases :exec
+UPDATE conversation_leases SET expires_at = ? WHERE holder = ?;
+
+-- name: IsConversationLeased :one
+SELECT CAST(COUNT(*) AS INTEGER) AS leased FROM conversation_leases WHERE conversation_id = ? AND expires_at > ?;
+
+-- name: DeleteExpiredConversationLeases :many
+DELETE FROM conversation_leases WHERE expires_at <= ? RETURNING conversation_id;
+
+-- Turn Usage
+
+-- name: CreateTurnUsage :exec
+INSERT INTO turn_usage (run_id, agent_id, conversation_id, kind, model, status, input_tokens, output_tokens, started_at, finished_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- Turn Recordings
+
+-- name: CreateTurnRecording :exec
+INSERT INTO turn_recordings (run_id, conversation_id, recording, created_at)
+VALUES (?, ?, ?, ?);
+
+-- name: GetTurnRecording :one
+SELECT * FROM turn_recordings WHERE run_id = ?;
+
+-- name: GetLatestTurnRecording :one
+SELECT * FROM turn_recordings WHERE conversation_id = ? ORDER BY created_at DESC, rowid DESC LIMIT 1;
+
+-- Eval Cases
+
+-- name: CreateEvalCase :one
+INSERT INTO eval_cases (id, agent_id, name, prompt, assertions, rubric, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetEvalCase :one
+SELECT * FROM eval_cases WHERE id = ?;
+
+-- name: ListEvalCasesByAgent :many
+SELECT * FROM eval_cases WHERE agent_id = ? ORDER BY created_at ASC, rowid ASC;
+
+-- name: UpdateEvalCase :one
+UPDATE eval_cases SET name = ?, prompt = ?, assertions = ?, rubric = ?, updated_at = ?
+WHERE id = ? RETURNING *;
+
+-- name: DeleteEvalCase :execrows
+DELETE FROM eval_cases WHERE id = ?;
+
+-- Eval Runs
+
+-- name: CreateEvalRun :one
+INSERT INTO eval_runs (id, agent_id, system_prompt, model, candidate, status, total, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetEvalRun :one
+SELECT * FROM eval_runs WHERE id = ?;
+
+-- name: ListEvalRunsByAgent :many
+SELECT * FROM eval_runs WHERE agent_id = ? ORDER BY created_at DESC, rowid DESC;
+
+-- name: FinishEvalRun :exec
+UPDATE eval_runs SET status = ?, score = ?, passed = ?, results = ?, error = ?, finished_at = ?
+WHERE id = ?;
+
+-- name: InterruptRunningEvalRuns :execrows
+UPDATE eval_runs SET status = 'interrupted', error = ?, finished_at = ?
+WHERE status = 'running';
+
+-- Run Traces
+
+-- name: CreateRunTrace :exec
+INSERT INTO run_traces (run_id, agent_id, conversation_id, kind, model, status, error, input_tokens, output_tokens, cost_usd, trace, started_at, finished_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetRunTrace :one
+SELECT * FROM run_traces WHERE run_id = ?;
+
+-- name: GetLatestRunTrace :one
+SELECT * FROM run_traces WHERE conversation_id = ? ORDER BY started_at DESC, rowid DESC LIMIT 1;
+
+-- name: ListRunTracesByAgentSince :many
+SELECT trace FROM run_traces WHERE agent_id = ? AND started_at >= ? ORDER BY started_at ASC;
+
+-- name: ListRunUsageByConversation :many
+SELECT run_id, model, status, input_tokens, output_tokens, cost_usd FROM run_traces
+WHERE conversation_id = ? ORDER BY started_at ASC, rowid ASC;
+
+-- name: SumRunUsageSince :many
+SELECT agent_id, model, status, CAST(COUNT(*) AS INTEGER) AS runs,
+CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+CAST(TOTAL(cost_usd) AS REAL) AS reported_cost_usd,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN input_tokens ELSE 0 END) AS INTEGER) AS unreported_input_tokens,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN output_tokens ELSE 0 END) AS INTEGER) AS unreported_output_tokens
+FROM run_traces WHERE started_at >= ?
+GROUP BY agent_id, model, status ORDER BY agent_id, model, status;
+
+-- name: SumConversationRunUsageSince :many
+SELECT conversation_id, agent_id, model, status, CAST(COUNT(*) AS INTEGER) AS runs,
+CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+CAST(TOTAL(cost_usd) AS REAL) AS reported_cost_usd,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN input_tokens ELSE 0 END) AS INTEGER) AS unreported_input_tokens,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN output_tokens ELSE 0 END) AS INTEGER) AS unreported_output_tokens
+FROM run_traces WHERE started_at >= ?
+GROUP BY conversation_id, agent_id, model, status ORDER BY conversation_id, model, status;
+
+-- Blobs
+
+-- name: CreateBlob :one
+INSERT INTO blobs (id, kind, conversation_id, name, content_type, size, sha256, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetBlob :one
+SELECT * FROM blobs WHERE id = ?;
+
+-- name: ListBlobsByConversation :many
+SELECT * FROM blobs WHERE conversation_id = ? ORDER BY created_at;
+
+-- name: ListBlobIDs :many
+SELECT id FROM blobs;
+
+-- name: DeleteBlob :exec
+DELETE FROM blobs WHERE id = ?;
+
+-- name: DeleteExpiredBlobs :execrows
+DELETE FROM blobs WHERE expires_at IS NOT NULL AND expires_at <= ?;
+
+-- Attachments
+
+-- name: CreateAttachment :exec
+INSERT INTO attachments (id, conversation_id, message_id, blob_id, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListAttachmentsByConversation :many
+SELECT * FROM attachments WHERE conversation_id = ? ORDER BY created_at;
+
+-- Artifacts
+
+-- name: CreateArtifact :exec
+INSERT INTO artifacts (id, run_id, conversation_id, call_id, tool_name, kind, name, uri, media_type, size, blob_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListArtifactsByConversation :many
+SELECT * FROM artifacts WHERE conversation_id = ? ORDER BY created_at, rowid;
+
+-- name: ListArtifactsByRun :many
+SELECT * FROM artifacts WHERE conversation_id = ? AND run_id = ? ORDER BY created_at, rowid;
+
+-- LLM Captures
+
+-- name: CreateLLMCapture :one
+INSERT INTO llm_captures (id, agent_id, conversation_id, retention_seconds, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: ListLLMCaptures :many
+SELECT * FROM llm_captures ORDER BY created_at DESC;
+
+-- name: GetActiveLLMCapture :one
+SELECT * FROM llm_captures WHERE (agent_id = ? OR conversation_id = ?) AND expires_at > ?
+ORDER BY retention_seconds DESC LIMIT 1;
+
+-- name: DeleteLLMCapture :execrows
+DELETE FROM llm_captures WHERE id = ?;
+
+-- name: DeleteExpiredLLMCaptures :execrows
+DELETE FROM llm_captures WHERE expires_at <= ?;
+
+-- name: CreateLLMExchange :exec
+INSERT INTO llm_exchanges (id, capture_id, run_id, conversation_id, agent_id, round, url, key_id, request_body, status_code, response_headers, response_body, truncated, error, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListLLMExchangesByConversation :many
+SELECT * FROM llm_exchanges WHERE conversation_id = ? ORDER BY created_at, round;
+
+-- name: ListLLMExchangesByRun :many
+SELECT * FROM llm_exchanges WHERE run_id = ? ORDER BY round;
+
+-- name: DeleteExpiredLLMExchanges :execrows
+DELETE FROM llm_exchanges WHERE expires_at <= ?;
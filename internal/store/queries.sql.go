@@ -10,10 +10,110 @@ import (
 	"database/sql"
 )
 
+const acquireConversationLease = `-- name: AcquireConversationLease :execrows
+
+INSERT INTO conversation_leases (conversation_id, holder, acquired_at, expires_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (conversation_id) DO UPDATE
+SET holder = excluded.holder, acquired_at = excluded.acquired_at, expires_at = excluded.expires_at
+WHERE conversation_leases.expires_at <= excluded.acquired_at
+`
+
+type AcquireConversationLeaseParams struct {
+	ConversationID string
+	Holder         string
+	AcquiredAt     string
+	ExpiresAt      string
+}
+
+// Conversation leases
+func (q *Queries) AcquireConversationLease(ctx context.Context, arg AcquireConversationLeaseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, acquireConversationLease,
+		arg.ConversationID,
+		arg.Holder,
+		arg.AcquiredAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countActiveAPIKeys = `-- name: CountActiveAPIKeys :one
+SELECT COUNT(*) FROM api_keys WHERE revoked_at IS NULL
+`
+
+func (q *Queries) CountActiveAPIKeys(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveAPIKeys)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countNotificationDeliveriesSince = `-- name: CountNotificationDeliveriesSince :one
+SELECT COUNT(*) FROM notification_deliveries WHERE channel_id = ? AND delivered_at >= ?
+`
+
+type CountNotificationDeliveriesSinceParams struct {
+	ChannelID   string
+	DeliveredAt string
+}
+
+func (q *Queries) CountNotificationDeliveriesSince(ctx context.Context, arg CountNotificationDeliveriesSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countNotificationDeliveriesSince, arg.ChannelID, arg.DeliveredAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAPIKey = `-- name: CreateAPIKey :one
+
+INSERT INTO api_keys (id, name, prefix, key_hash, agent_ids, scopes, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, prefix, key_hash, created_at, last_used_at, revoked_at, agent_ids, scopes
+`
+
+type CreateAPIKeyParams struct {
+	ID        string
+	Name      string
+	Prefix    string
+	KeyHash   string
+	AgentIds  string
+	Scopes    string
+	CreatedAt string
+}
+
+// API Keys
+func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, createAPIKey,
+		arg.ID,
+		arg.Name,
+		arg.Prefix,
+		arg.KeyHash,
+		arg.AgentIds,
+		arg.Scopes,
+		arg.CreatedAt,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.AgentIds,
+		&i.Scopes,
+	)
+	return i, err
+}
+
 const createAgent = `-- name: CreateAgent :one
-INSERT INTO agents (id, name, description, system_prompt, enabled_tools, enabled_notification_channels, enabled_filesystem_roots, model, forwarded_host_env_vars, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars
+INSERT INTO agents (id, name, description, system_prompt, enabled_tools, enabled_notification_channels, enabled_filesystem_roots, model, forwarded_host_env_vars, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars, version, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale
 `
 
 type CreateAgentParams struct {
@@ -26,6 +126,17 @@ type CreateAgentParams struct {
 	EnabledFilesystemRoots      string
 	Model                       string
 	ForwardedHostEnvVars        string
+	Hooks                       string
+	MaxConcurrentRuns           int64
+	TitleGenerationDisabled     int64
+	TitlePrompt                 string
+	TitleModel                  string
+	PersonaRole                 string
+	PersonaGoals                string
+	PersonaConstraints          string
+	PersonaStyle                string
+	ApprovalRules               string
+	Locale                      string
 	CreatedAt                   string
 	UpdatedAt                   string
 }
@@ -41,6 +152,17 @@ func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent
 		arg.EnabledFilesystemRoots,
 		arg.Model,
 		arg.ForwardedHostEnvVars,
+		arg.Hooks,
+		arg.MaxConcurrentRuns,
+		arg.TitleGenerationDisabled,
+		arg.TitlePrompt,
+		arg.TitleModel,
+		arg.PersonaRole,
+		arg.PersonaGoals,
+		arg.PersonaConstraints,
+		arg.PersonaStyle,
+		arg.ApprovalRules,
+		arg.Locale,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -57,6 +179,167 @@ func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent
 		&i.UpdatedAt,
 		&i.EnabledFilesystemRoots,
 		&i.ForwardedHostEnvVars,
+		&i.Version,
+		&i.Hooks,
+		&i.MaxConcurrentRuns,
+		&i.TitleGenerationDisabled,
+		&i.TitlePrompt,
+		&i.TitleModel,
+		&i.PersonaRole,
+		&i.PersonaGoals,
+		&i.PersonaConstraints,
+		&i.PersonaStyle,
+		&i.ApprovalRules,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const createArtifact = `-- name: CreateArtifact :exec
+
+INSERT INTO artifacts (id, run_id, conversation_id, call_id, tool_name, kind, name, uri, media_type, size, blob_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateArtifactParams struct {
+	ID             string
+	RunID          string
+	ConversationID string
+	CallID         string
+	ToolName       string
+	Kind           string
+	Name           string
+	Uri            string
+	MediaType      string
+	Size           int64
+	BlobID         sql.NullString
+	CreatedAt      string
+}
+
+// Artifacts
+func (q *Queries) CreateArtifact(ctx context.Context, arg CreateArtifactParams) error {
+	_, err := q.db.ExecContext(ctx, createArtifact,
+		arg.ID,
+		arg.RunID,
+		arg.ConversationID,
+		arg.CallID,
+		arg.ToolName,
+		arg.Kind,
+		arg.Name,
+		arg.Uri,
+		arg.MediaType,
+		arg.Size,
+		arg.BlobID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createAttachment = `-- name: CreateAttachment :exec
+
+INSERT INTO attachments (id, conversation_id, message_id, blob_id, created_at)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateAttachmentParams struct {
+	ID             string
+	ConversationID string
+	MessageID      string
+	BlobID         string
+	CreatedAt      string
+}
+
+// Attachments
+func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentParams) error {
+	_, err := q.db.ExecContext(ctx, createAttachment,
+		arg.ID,
+		arg.ConversationID,
+		arg.MessageID,
+		arg.BlobID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createAuditEntry = `-- name: CreateAuditEntry :exec
+
+INSERT INTO audit_log (id, actor, action, resource_type, resource_id, procedure, details, remote_addr, user_agent, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateAuditEntryParams struct {
+	ID           string
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Procedure    string
+	Details      string
+	RemoteAddr   string
+	UserAgent    string
+	CreatedAt    string
+}
+
+// Audit Log
+func (q *Queries) CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditEntry,
+		arg.ID,
+		arg.Actor,
+		arg.Action,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.Procedure,
+		arg.Details,
+		arg.RemoteAddr,
+		arg.UserAgent,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createBlob = `-- name: CreateBlob :one
+
+INSERT INTO blobs (id, kind, conversation_id, name, content_type, size, sha256, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, kind, conversation_id, name, content_type, size, sha256, created_at, expires_at
+`
+
+type CreateBlobParams struct {
+	ID             string
+	Kind           string
+	ConversationID sql.NullString
+	Name           string
+	ContentType    string
+	Size           int64
+	Sha256         string
+	CreatedAt      string
+	ExpiresAt      sql.NullString
+}
+
+// Blobs
+func (q *Queries) CreateBlob(ctx context.Context, arg CreateBlobParams) (Blob, error) {
+	row := q.db.QueryRowContext(ctx, createBlob,
+		arg.ID,
+		arg.Kind,
+		arg.ConversationID,
+		arg.Name,
+		arg.ContentType,
+		arg.Size,
+		arg.Sha256,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	var i Blob
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.ConversationID,
+		&i.Name,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.CreatedAt,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -64,7 +347,7 @@ func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent
 const createConversation = `-- name: CreateConversation :one
 INSERT INTO conversations (id, agent_id, title, previous_response_id, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, agent_id, title, previous_response_id, created_at, updated_at
+RETURNING id, agent_id, title, previous_response_id, created_at, updated_at, summary, summary_message_id
 `
 
 type CreateConversationParams struct {
@@ -93,15 +376,136 @@ func (q *Queries) CreateConversation(ctx context.Context, arg CreateConversation
 		&i.PreviousResponseID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Summary,
+		&i.SummaryMessageID,
+	)
+	return i, err
+}
+
+const createEvalCase = `-- name: CreateEvalCase :one
+
+INSERT INTO eval_cases (id, agent_id, name, prompt, assertions, rubric, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, agent_id, name, prompt, assertions, rubric, created_at, updated_at
+`
+
+type CreateEvalCaseParams struct {
+	ID         string
+	AgentID    string
+	Name       string
+	Prompt     string
+	Assertions string
+	Rubric     string
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// Eval Cases
+func (q *Queries) CreateEvalCase(ctx context.Context, arg CreateEvalCaseParams) (EvalCase, error) {
+	row := q.db.QueryRowContext(ctx, createEvalCase,
+		arg.ID,
+		arg.AgentID,
+		arg.Name,
+		arg.Prompt,
+		arg.Assertions,
+		arg.Rubric,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i EvalCase
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.Name,
+		&i.Prompt,
+		&i.Assertions,
+		&i.Rubric,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createEvalRun = `-- name: CreateEvalRun :one
+
+INSERT INTO eval_runs (id, agent_id, system_prompt, model, candidate, status, total, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, agent_id, system_prompt, model, candidate, status, score, passed, total, results, error, created_at, finished_at
+`
+
+type CreateEvalRunParams struct {
+	ID           string
+	AgentID      string
+	SystemPrompt string
+	Model        string
+	Candidate    int64
+	Status       string
+	Total        int64
+	CreatedAt    string
+}
+
+// Eval Runs
+func (q *Queries) CreateEvalRun(ctx context.Context, arg CreateEvalRunParams) (EvalRun, error) {
+	row := q.db.QueryRowContext(ctx, createEvalRun,
+		arg.ID,
+		arg.AgentID,
+		arg.SystemPrompt,
+		arg.Model,
+		arg.Candidate,
+		arg.Status,
+		arg.Total,
+		arg.CreatedAt,
+	)
+	var i EvalRun
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.SystemPrompt,
+		&i.Model,
+		&i.Candidate,
+		&i.Status,
+		&i.Score,
+		&i.Passed,
+		&i.Total,
+		&i.Results,
+		&i.Error,
+		&i.CreatedAt,
+		&i.FinishedAt,
 	)
 	return i, err
 }
 
+const createEvent = `-- name: CreateEvent :exec
+
+INSERT INTO events (conversation_id, seq, type, payload, created_at)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateEventParams struct {
+	ConversationID string
+	Seq            int64
+	Type           string
+	Payload        string
+	CreatedAt      string
+}
+
+// Events
+func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error {
+	_, err := q.db.ExecContext(ctx, createEvent,
+		arg.ConversationID,
+		arg.Seq,
+		arg.Type,
+		arg.Payload,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createFilesystemRoot = `-- name: CreateFilesystemRoot :one
 
 INSERT INTO filesystem_roots (id, name, path, description, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, name, path, description, created_at, updated_at
+RETURNING id, name, path, description, created_at, updated_at, version
 `
 
 type CreateFilesystemRootParams struct {
@@ -131,14 +535,99 @@ func (q *Queries) CreateFilesystemRoot(ctx context.Context, arg CreateFilesystem
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const createLLMCapture = `-- name: CreateLLMCapture :one
+
+INSERT INTO llm_captures (id, agent_id, conversation_id, retention_seconds, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, agent_id, conversation_id, retention_seconds, expires_at, created_at
+`
+
+type CreateLLMCaptureParams struct {
+	ID               string
+	AgentID          sql.NullString
+	ConversationID   sql.NullString
+	RetentionSeconds int64
+	ExpiresAt        string
+	CreatedAt        string
+}
+
+// LLM Captures
+func (q *Queries) CreateLLMCapture(ctx context.Context, arg CreateLLMCaptureParams) (LlmCapture, error) {
+	row := q.db.QueryRowContext(ctx, createLLMCapture,
+		arg.ID,
+		arg.AgentID,
+		arg.ConversationID,
+		arg.RetentionSeconds,
+		arg.ExpiresAt,
+		arg.CreatedAt,
+	)
+	var i LlmCapture
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.ConversationID,
+		&i.RetentionSeconds,
+		&i.ExpiresAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
+const createLLMExchange = `-- name: CreateLLMExchange :exec
+INSERT INTO llm_exchanges (id, capture_id, run_id, conversation_id, agent_id, round, url, key_id, request_body, status_code, response_headers, response_body, truncated, error, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateLLMExchangeParams struct {
+	ID              string
+	CaptureID       string
+	RunID           string
+	ConversationID  string
+	AgentID         string
+	Round           int64
+	Url             string
+	KeyID           string
+	RequestBody     string
+	StatusCode      int64
+	ResponseHeaders string
+	ResponseBody    string
+	Truncated       int64
+	Error           string
+	CreatedAt       string
+	ExpiresAt       string
+}
+
+func (q *Queries) CreateLLMExchange(ctx context.Context, arg CreateLLMExchangeParams) error {
+	_, err := q.db.ExecContext(ctx, createLLMExchange,
+		arg.ID,
+		arg.CaptureID,
+		arg.RunID,
+		arg.ConversationID,
+		arg.AgentID,
+		arg.Round,
+		arg.Url,
+		arg.KeyID,
+		arg.RequestBody,
+		arg.StatusCode,
+		arg.ResponseHeaders,
+		arg.ResponseBody,
+		arg.Truncated,
+		arg.Error,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
 const createMessage = `-- name: CreateMessage :one
-INSERT INTO messages (id, conversation_id, role, items, created_at)
-VALUES (?, ?, ?, ?, ?)
-RETURNING id, conversation_id, role, items, created_at
+INSERT INTO messages (id, conversation_id, role, items, status, run_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, conversation_id, role, items, created_at, status, run_id, token_count
 `
 
 type CreateMessageParams struct {
@@ -146,6 +635,8 @@ type CreateMessageParams struct {
 	ConversationID string
 	Role           string
 	Items          string
+	Status         string
+	RunID          string
 	CreatedAt      string
 }
 
@@ -155,6 +646,8 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		arg.ConversationID,
 		arg.Role,
 		arg.Items,
+		arg.Status,
+		arg.RunID,
 		arg.CreatedAt,
 	)
 	var i Message
@@ -164,26 +657,35 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		&i.Role,
 		&i.Items,
 		&i.CreatedAt,
+		&i.Status,
+		&i.RunID,
+		&i.TokenCount,
 	)
 	return i, err
 }
 
 const createNotificationChannel = `-- name: CreateNotificationChannel :one
 
-INSERT INTO notification_channels (id, name, type, config, description, json_schema, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, name, type, config, description, json_schema, created_at, updated_at
+INSERT INTO notification_channels (id, name, type, config, description, json_schema, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, type, config, description, json_schema, created_at, updated_at, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, version
 `
 
 type CreateNotificationChannelParams struct {
-	ID          string
-	Name        string
-	Type        string
-	Config      string
-	Description string
-	JsonSchema  string
-	CreatedAt   string
-	UpdatedAt   string
+	ID                 string
+	Name               string
+	Type               string
+	Config             string
+	Description        string
+	JsonSchema         string
+	QuietHoursStart    string
+	QuietHoursEnd      string
+	QuietHoursTimezone string
+	QuietHoursMode     string
+	MaxPerHour         int64
+	DigestSchedule     string
+	CreatedAt          string
+	UpdatedAt          string
 }
 
 // Notification Channels
@@ -195,6 +697,12 @@ func (q *Queries) CreateNotificationChannel(ctx context.Context, arg CreateNotif
 		arg.Config,
 		arg.Description,
 		arg.JsonSchema,
+		arg.QuietHoursStart,
+		arg.QuietHoursEnd,
+		arg.QuietHoursTimezone,
+		arg.QuietHoursMode,
+		arg.MaxPerHour,
+		arg.DigestSchedule,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -208,68 +716,236 @@ func (q *Queries) CreateNotificationChannel(ctx context.Context, arg CreateNotif
 		&i.JsonSchema,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.QuietHoursStart,
+		&i.QuietHoursEnd,
+		&i.QuietHoursTimezone,
+		&i.QuietHoursMode,
+		&i.MaxPerHour,
+		&i.DigestSchedule,
+		&i.Version,
 	)
 	return i, err
 }
 
-const createTrigger = `-- name: CreateTrigger :one
+const createNotificationDelivery = `-- name: CreateNotificationDelivery :exec
 
-INSERT INTO triggers (id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at
+INSERT INTO notification_deliveries (id, channel_id, conversation_id, delivered_at)
+VALUES (?, ?, ?, ?)
 `
 
-type CreateTriggerParams struct {
-	ID                string
-	AgentID           string
-	Name              string
-	Prompt            string
-	CronExpr          sql.NullString
-	Enabled           int64
-	NextRunAt         sql.NullString
-	Model             string
-	ConversationTitle string
-	CreatedAt         string
-	UpdatedAt         string
+type CreateNotificationDeliveryParams struct {
+	ID             string
+	ChannelID      string
+	ConversationID sql.NullString
+	DeliveredAt    string
 }
 
-// Triggers
-func (q *Queries) CreateTrigger(ctx context.Context, arg CreateTriggerParams) (Trigger, error) {
-	row := q.db.QueryRowContext(ctx, createTrigger,
+// Notification Deliveries
+func (q *Queries) CreateNotificationDelivery(ctx context.Context, arg CreateNotificationDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, createNotificationDelivery,
 		arg.ID,
-		arg.AgentID,
-		arg.Name,
-		arg.Prompt,
-		arg.CronExpr,
-		arg.Enabled,
-		arg.NextRunAt,
-		arg.Model,
-		arg.ConversationTitle,
-		arg.CreatedAt,
-		arg.UpdatedAt,
-	)
-	var i Trigger
-	err := row.Scan(
-		&i.ID,
-		&i.AgentID,
-		&i.Name,
-		&i.Prompt,
-		&i.CronExpr,
-		&i.Enabled,
-		&i.NextRunAt,
-		&i.Model,
-		&i.ConversationTitle,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+		arg.ChannelID,
+		arg.ConversationID,
+		arg.DeliveredAt,
 	)
-	return i, err
+	return err
 }
 
-const createTriggerRun = `-- name: CreateTriggerRun :one
+const createNotificationMessage = `-- name: CreateNotificationMessage :exec
 
-INSERT INTO trigger_runs (id, trigger_id, conversation_id, status, error_message, started_at, finished_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING id, trigger_id, conversation_id, status, error_message, started_at, finished_at
+INSERT INTO notification_messages (channel_id, chat_id, message_id, delivery_id)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (channel_id, chat_id, message_id) DO NOTHING
+`
+
+type CreateNotificationMessageParams struct {
+	ChannelID  string
+	ChatID     string
+	MessageID  string
+	DeliveryID string
+}
+
+// Notification Messages
+func (q *Queries) CreateNotificationMessage(ctx context.Context, arg CreateNotificationMessageParams) error {
+	_, err := q.db.ExecContext(ctx, createNotificationMessage,
+		arg.ChannelID,
+		arg.ChatID,
+		arg.MessageID,
+		arg.DeliveryID,
+	)
+	return err
+}
+
+const createQueuedNotification = `-- name: CreateQueuedNotification :exec
+
+INSERT INTO queued_notifications (id, channel_id, conversation_id, payload, deliver_after, created_at)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateQueuedNotificationParams struct {
+	ID             string
+	ChannelID      string
+	ConversationID sql.NullString
+	Payload        string
+	DeliverAfter   string
+	CreatedAt      string
+}
+
+// Queued Notifications
+func (q *Queries) CreateQueuedNotification(ctx context.Context, arg CreateQueuedNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, createQueuedNotification,
+		arg.ID,
+		arg.ChannelID,
+		arg.ConversationID,
+		arg.Payload,
+		arg.DeliverAfter,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createRunTrace = `-- name: CreateRunTrace :exec
+
+INSERT INTO run_traces (run_id, agent_id, conversation_id, kind, model, status, error, input_tokens, output_tokens, cost_usd, trace, started_at, finished_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateRunTraceParams struct {
+	RunID          string
+	AgentID        string
+	ConversationID string
+	Kind           string
+	Model          string
+	Status         string
+	Error          string
+	InputTokens    int64
+	OutputTokens   int64
+	CostUsd        sql.NullFloat64
+	Trace          string
+	StartedAt      string
+	FinishedAt     string
+}
+
+// Run Traces
+func (q *Queries) CreateRunTrace(ctx context.Context, arg CreateRunTraceParams) error {
+	_, err := q.db.ExecContext(ctx, createRunTrace,
+		arg.RunID,
+		arg.AgentID,
+		arg.ConversationID,
+		arg.Kind,
+		arg.Model,
+		arg.Status,
+		arg.Error,
+		arg.InputTokens,
+		arg.OutputTokens,
+		arg.CostUsd,
+		arg.Trace,
+		arg.StartedAt,
+		arg.FinishedAt,
+	)
+	return err
+}
+
+const createSession = `-- name: CreateSession :exec
+
+INSERT INTO sessions (token_hash, api_key_id, subject, name, email, role, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateSessionParams struct {
+	TokenHash string
+	ApiKeyID  sql.NullString
+	Subject   string
+	Name      string
+	Email     string
+	Role      string
+	CreatedAt string
+	ExpiresAt string
+}
+
+// Sessions
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) error {
+	_, err := q.db.ExecContext(ctx, createSession,
+		arg.TokenHash,
+		arg.ApiKeyID,
+		arg.Subject,
+		arg.Name,
+		arg.Email,
+		arg.Role,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createTrigger = `-- name: CreateTrigger :one
+
+INSERT INTO triggers (id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, max_duration_seconds, priority, vars, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars
+`
+
+type CreateTriggerParams struct {
+	ID                 string
+	AgentID            string
+	Name               string
+	Prompt             string
+	CronExpr           sql.NullString
+	Enabled            int64
+	NextRunAt          sql.NullString
+	Model              string
+	ConversationTitle  string
+	MaxDurationSeconds int64
+	Priority           int64
+	Vars               string
+	CreatedAt          string
+	UpdatedAt          string
+}
+
+// Triggers
+func (q *Queries) CreateTrigger(ctx context.Context, arg CreateTriggerParams) (Trigger, error) {
+	row := q.db.QueryRowContext(ctx, createTrigger,
+		arg.ID,
+		arg.AgentID,
+		arg.Name,
+		arg.Prompt,
+		arg.CronExpr,
+		arg.Enabled,
+		arg.NextRunAt,
+		arg.Model,
+		arg.ConversationTitle,
+		arg.MaxDurationSeconds,
+		arg.Priority,
+		arg.Vars,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i Trigger
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.Name,
+		&i.Prompt,
+		&i.CronExpr,
+		&i.Enabled,
+		&i.NextRunAt,
+		&i.Model,
+		&i.ConversationTitle,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.MaxDurationSeconds,
+		&i.Priority,
+		&i.Vars,
+	)
+	return i, err
+}
+
+const createTriggerRun = `-- name: CreateTriggerRun :one
+
+INSERT INTO trigger_runs (id, trigger_id, conversation_id, status, error_message, started_at, finished_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, trigger_id, conversation_id, status, error_message, started_at, finished_at
 `
 
 type CreateTriggerRunParams struct {
@@ -306,6 +982,110 @@ func (q *Queries) CreateTriggerRun(ctx context.Context, arg CreateTriggerRunPara
 	return i, err
 }
 
+const createTriggerWebhook = `-- name: CreateTriggerWebhook :one
+
+INSERT INTO trigger_webhooks (trigger_id, token_hash, secret, signature_scheme, allowed_ips, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (trigger_id) DO UPDATE SET
+    token_hash = excluded.token_hash, secret = excluded.secret, signature_scheme = excluded.signature_scheme,
+    allowed_ips = excluded.allowed_ips, created_at = excluded.created_at, updated_at = excluded.updated_at
+RETURNING trigger_id, token_hash, secret, signature_scheme, allowed_ips, created_at, updated_at
+`
+
+type CreateTriggerWebhookParams struct {
+	TriggerID       string
+	TokenHash       string
+	Secret          string
+	SignatureScheme string
+	AllowedIps      string
+	CreatedAt       string
+	UpdatedAt       string
+}
+
+// Trigger Webhooks
+func (q *Queries) CreateTriggerWebhook(ctx context.Context, arg CreateTriggerWebhookParams) (TriggerWebhook, error) {
+	row := q.db.QueryRowContext(ctx, createTriggerWebhook,
+		arg.TriggerID,
+		arg.TokenHash,
+		arg.Secret,
+		arg.SignatureScheme,
+		arg.AllowedIps,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TriggerWebhook
+	err := row.Scan(
+		&i.TriggerID,
+		&i.TokenHash,
+		&i.Secret,
+		&i.SignatureScheme,
+		&i.AllowedIps,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createTurnRecording = `-- name: CreateTurnRecording :exec
+
+INSERT INTO turn_recordings (run_id, conversation_id, recording, created_at)
+VALUES (?, ?, ?, ?)
+`
+
+type CreateTurnRecordingParams struct {
+	RunID          string
+	ConversationID string
+	Recording      string
+	CreatedAt      string
+}
+
+// Turn Recordings
+func (q *Queries) CreateTurnRecording(ctx context.Context, arg CreateTurnRecordingParams) error {
+	_, err := q.db.ExecContext(ctx, createTurnRecording,
+		arg.RunID,
+		arg.ConversationID,
+		arg.Recording,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createTurnUsage = `-- name: CreateTurnUsage :exec
+
+INSERT INTO turn_usage (run_id, agent_id, conversation_id, kind, model, status, input_tokens, output_tokens, started_at, finished_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateTurnUsageParams struct {
+	RunID          string
+	AgentID        string
+	ConversationID string
+	Kind           string
+	Model          string
+	Status         string
+	InputTokens    int64
+	OutputTokens   int64
+	StartedAt      string
+	FinishedAt     string
+}
+
+// Turn Usage
+func (q *Queries) CreateTurnUsage(ctx context.Context, arg CreateTurnUsageParams) error {
+	_, err := q.db.ExecContext(ctx, createTurnUsage,
+		arg.RunID,
+		arg.AgentID,
+		arg.ConversationID,
+		arg.Kind,
+		arg.Model,
+		arg.Status,
+		arg.InputTokens,
+		arg.OutputTokens,
+		arg.StartedAt,
+		arg.FinishedAt,
+	)
+	return err
+}
+
 const deleteAgent = `-- name: DeleteAgent :exec
 DELETE FROM agents WHERE id = ?
 `
@@ -329,134 +1109,93 @@ func (q *Queries) DeleteAgentFile(ctx context.Context, arg DeleteAgentFileParams
 	return err
 }
 
-const deleteConversation = `-- name: DeleteConversation :exec
-DELETE FROM conversations WHERE id = ?
+const deleteBlob = `-- name: DeleteBlob :exec
+DELETE FROM blobs WHERE id = ?
 `
 
-func (q *Queries) DeleteConversation(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteConversation, id)
+func (q *Queries) DeleteBlob(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteBlob, id)
 	return err
 }
 
-const deleteFilesystemRoot = `-- name: DeleteFilesystemRoot :exec
-DELETE FROM filesystem_roots WHERE id = ?
+const deleteConversation = `-- name: DeleteConversation :exec
+DELETE FROM conversations WHERE id = ?
 `
 
-func (q *Queries) DeleteFilesystemRoot(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteFilesystemRoot, id)
+func (q *Queries) DeleteConversation(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteConversation, id)
 	return err
 }
 
-const deleteNotificationChannel = `-- name: DeleteNotificationChannel :exec
-DELETE FROM notification_channels WHERE id = ?
+const deleteConversationKV = `-- name: DeleteConversationKV :execrows
+DELETE FROM conversation_kv WHERE conversation_id = ? AND key = ?
 `
 
-func (q *Queries) DeleteNotificationChannel(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteNotificationChannel, id)
-	return err
+type DeleteConversationKVParams struct {
+	ConversationID string
+	Key            string
 }
 
-const deleteTrigger = `-- name: DeleteTrigger :exec
-DELETE FROM triggers WHERE id = ?
-`
-
-func (q *Queries) DeleteTrigger(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteTrigger, id)
-	return err
+func (q *Queries) DeleteConversationKV(ctx context.Context, arg DeleteConversationKVParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteConversationKV, arg.ConversationID, arg.Key)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const getAgent = `-- name: GetAgent :one
-SELECT id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars FROM agents WHERE id = ?
+const deleteEvalCase = `-- name: DeleteEvalCase :execrows
+DELETE FROM eval_cases WHERE id = ?
 `
 
-func (q *Queries) GetAgent(ctx context.Context, id string) (Agent, error) {
-	row := q.db.QueryRowContext(ctx, getAgent, id)
-	var i Agent
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Description,
-		&i.SystemPrompt,
-		&i.EnabledTools,
-		&i.EnabledNotificationChannels,
-		&i.Model,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.EnabledFilesystemRoots,
-		&i.ForwardedHostEnvVars,
-	)
-	return i, err
+func (q *Queries) DeleteEvalCase(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEvalCase, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const getAgentFile = `-- name: GetAgentFile :one
-SELECT agent_id, path, content, created_at, updated_at FROM agent_files WHERE agent_id = ? AND path = ?
+const deleteEventsBefore = `-- name: DeleteEventsBefore :exec
+DELETE FROM events
+WHERE created_at < ?
+  AND seq < (SELECT MAX(e.seq) FROM events e WHERE e.conversation_id = events.conversation_id)
 `
 
-type GetAgentFileParams struct {
-	AgentID string
-	Path    string
-}
-
-func (q *Queries) GetAgentFile(ctx context.Context, arg GetAgentFileParams) (AgentFile, error) {
-	row := q.db.QueryRowContext(ctx, getAgentFile, arg.AgentID, arg.Path)
-	var i AgentFile
-	err := row.Scan(
-		&i.AgentID,
-		&i.Path,
-		&i.Content,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) DeleteEventsBefore(ctx context.Context, createdAt string) error {
+	_, err := q.db.ExecContext(ctx, deleteEventsBefore, createdAt)
+	return err
 }
 
-const getConversation = `-- name: GetConversation :one
-SELECT id, agent_id, title, previous_response_id, created_at, updated_at FROM conversations WHERE id = ?
+const deleteExpiredBlobs = `-- name: DeleteExpiredBlobs :execrows
+DELETE FROM blobs WHERE expires_at IS NOT NULL AND expires_at <= ?
 `
 
-func (q *Queries) GetConversation(ctx context.Context, id string) (Conversation, error) {
-	row := q.db.QueryRowContext(ctx, getConversation, id)
-	var i Conversation
-	err := row.Scan(
-		&i.ID,
-		&i.AgentID,
-		&i.Title,
-		&i.PreviousResponseID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) DeleteExpiredBlobs(ctx context.Context, expiresAt sql.NullString) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredBlobs, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const getDueTriggers = `-- name: GetDueTriggers :many
-SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at FROM triggers WHERE enabled = 1 AND next_run_at <= ? ORDER BY next_run_at ASC
+const deleteExpiredConversationLeases = `-- name: DeleteExpiredConversationLeases :many
+DELETE FROM conversation_leases WHERE expires_at <= ? RETURNING conversation_id
 `
 
-func (q *Queries) GetDueTriggers(ctx context.Context, nextRunAt sql.NullString) ([]Trigger, error) {
-	rows, err := q.db.QueryContext(ctx, getDueTriggers, nextRunAt)
+func (q *Queries) DeleteExpiredConversationLeases(ctx context.Context, expiresAt string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, deleteExpiredConversationLeases, expiresAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Trigger
+	var items []string
 	for rows.Next() {
-		var i Trigger
-		if err := rows.Scan(
-			&i.ID,
-			&i.AgentID,
-			&i.Name,
-			&i.Prompt,
-			&i.CronExpr,
-			&i.Enabled,
-			&i.NextRunAt,
-			&i.Model,
-			&i.ConversationTitle,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var conversationID string
+		if err := rows.Scan(&conversationID); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, conversationID)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -467,174 +1206,1740 @@ func (q *Queries) GetDueTriggers(ctx context.Context, nextRunAt sql.NullString)
 	return items, nil
 }
 
-const getFilesystemRoot = `-- name: GetFilesystemRoot :one
-SELECT id, name, path, description, created_at, updated_at FROM filesystem_roots WHERE id = ?
+const deleteExpiredLLMCaptures = `-- name: DeleteExpiredLLMCaptures :execrows
+DELETE FROM llm_captures WHERE expires_at <= ?
 `
 
-func (q *Queries) GetFilesystemRoot(ctx context.Context, id string) (FilesystemRoot, error) {
-	row := q.db.QueryRowContext(ctx, getFilesystemRoot, id)
-	var i FilesystemRoot
+func (q *Queries) DeleteExpiredLLMCaptures(ctx context.Context, expiresAt string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredLLMCaptures, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExpiredLLMExchanges = `-- name: DeleteExpiredLLMExchanges :execrows
+DELETE FROM llm_exchanges WHERE expires_at <= ?
+`
+
+func (q *Queries) DeleteExpiredLLMExchanges(ctx context.Context, expiresAt string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredLLMExchanges, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions WHERE expires_at <= ?
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, expiresAt string) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredSessions, expiresAt)
+	return err
+}
+
+const deleteFilesystemRoot = `-- name: DeleteFilesystemRoot :exec
+DELETE FROM filesystem_roots WHERE id = ?
+`
+
+func (q *Queries) DeleteFilesystemRoot(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteFilesystemRoot, id)
+	return err
+}
+
+const deleteLLMCapture = `-- name: DeleteLLMCapture :execrows
+DELETE FROM llm_captures WHERE id = ?
+`
+
+func (q *Queries) DeleteLLMCapture(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteLLMCapture, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteNotificationChannel = `-- name: DeleteNotificationChannel :exec
+DELETE FROM notification_channels WHERE id = ?
+`
+
+func (q *Queries) DeleteNotificationChannel(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteNotificationChannel, id)
+	return err
+}
+
+const deleteNotificationDeliveriesBefore = `-- name: DeleteNotificationDeliveriesBefore :exec
+DELETE FROM notification_deliveries WHERE delivered_at < ?
+`
+
+func (q *Queries) DeleteNotificationDeliveriesBefore(ctx context.Context, deliveredAt string) error {
+	_, err := q.db.ExecContext(ctx, deleteNotificationDeliveriesBefore, deliveredAt)
+	return err
+}
+
+const deleteQueuedNotification = `-- name: DeleteQueuedNotification :exec
+DELETE FROM queued_notifications WHERE id = ?
+`
+
+func (q *Queries) DeleteQueuedNotification(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteQueuedNotification, id)
+	return err
+}
+
+const deleteSession = `-- name: DeleteSession :exec
+DELETE FROM sessions WHERE token_hash = ?
+`
+
+func (q *Queries) DeleteSession(ctx context.Context, tokenHash string) error {
+	_, err := q.db.ExecContext(ctx, deleteSession, tokenHash)
+	return err
+}
+
+const deleteTelegramChat = `-- name: DeleteTelegramChat :exec
+DELETE FROM telegram_chats WHERE channel_id = ? AND chat_id = ?
+`
+
+type DeleteTelegramChatParams struct {
+	ChannelID string
+	ChatID    string
+}
+
+func (q *Queries) DeleteTelegramChat(ctx context.Context, arg DeleteTelegramChatParams) error {
+	_, err := q.db.ExecContext(ctx, deleteTelegramChat, arg.ChannelID, arg.ChatID)
+	return err
+}
+
+const deleteTrigger = `-- name: DeleteTrigger :exec
+DELETE FROM triggers WHERE id = ?
+`
+
+func (q *Queries) DeleteTrigger(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteTrigger, id)
+	return err
+}
+
+const deleteTriggerRunsBefore = `-- name: DeleteTriggerRunsBefore :execrows
+DELETE FROM trigger_runs WHERE status != 'running' AND started_at < ?
+`
+
+func (q *Queries) DeleteTriggerRunsBefore(ctx context.Context, startedAt string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTriggerRunsBefore, startedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteTriggerWebhook = `-- name: DeleteTriggerWebhook :execrows
+DELETE FROM trigger_webhooks WHERE trigger_id = ?
+`
+
+func (q *Queries) DeleteTriggerWebhook(ctx context.Context, triggerID string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTriggerWebhook, triggerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWebPushSubscription = `-- name: DeleteWebPushSubscription :exec
+DELETE FROM web_push_subscriptions WHERE endpoint = ?
+`
+
+func (q *Queries) DeleteWebPushSubscription(ctx context.Context, endpoint string) error {
+	_, err := q.db.ExecContext(ctx, deleteWebPushSubscription, endpoint)
+	return err
+}
+
+const finishEvalRun = `-- name: FinishEvalRun :exec
+UPDATE eval_runs SET status = ?, score = ?, passed = ?, results = ?, error = ?, finished_at = ?
+WHERE id = ?
+`
+
+type FinishEvalRunParams struct {
+	Status     string
+	Score      float64
+	Passed     int64
+	Results    string
+	Error      string
+	FinishedAt sql.NullString
+	ID         string
+}
+
+func (q *Queries) FinishEvalRun(ctx context.Context, arg FinishEvalRunParams) error {
+	_, err := q.db.ExecContext(ctx, finishEvalRun,
+		arg.Status,
+		arg.Score,
+		arg.Passed,
+		arg.Results,
+		arg.Error,
+		arg.FinishedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const getAPIKey = `-- name: GetAPIKey :one
+SELECT id, name, prefix, key_hash, created_at, last_used_at, revoked_at, agent_ids, scopes FROM api_keys WHERE id = ?
+`
+
+func (q *Queries) GetAPIKey(ctx context.Context, id string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getAPIKey, id)
+	var i ApiKey
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Path,
-		&i.Description,
+		&i.Prefix,
+		&i.KeyHash,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.AgentIds,
+		&i.Scopes,
 	)
 	return i, err
 }
 
-const getFilesystemRootByName = `-- name: GetFilesystemRootByName :one
-SELECT id, name, path, description, created_at, updated_at FROM filesystem_roots WHERE name = ?
+const getAPIKeyByHash = `-- name: GetAPIKeyByHash :one
+SELECT id, name, prefix, key_hash, created_at, last_used_at, revoked_at, agent_ids, scopes FROM api_keys WHERE key_hash = ?
 `
 
-func (q *Queries) GetFilesystemRootByName(ctx context.Context, name string) (FilesystemRoot, error) {
-	row := q.db.QueryRowContext(ctx, getFilesystemRootByName, name)
-	var i FilesystemRoot
+func (q *Queries) GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getAPIKeyByHash, keyHash)
+	var i ApiKey
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Path,
-		&i.Description,
+		&i.Prefix,
+		&i.KeyHash,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.AgentIds,
+		&i.Scopes,
 	)
 	return i, err
 }
 
-const getMessagesByConversation = `-- name: GetMessagesByConversation :many
-SELECT id, conversation_id, role, items, created_at FROM messages WHERE conversation_id = ? ORDER BY created_at ASC
+const getActiveLLMCapture = `-- name: GetActiveLLMCapture :one
+SELECT id, agent_id, conversation_id, retention_seconds, expires_at, created_at FROM llm_captures WHERE (agent_id = ? OR conversation_id = ?) AND expires_at > ?
+ORDER BY retention_seconds DESC LIMIT 1
 `
 
-func (q *Queries) GetMessagesByConversation(ctx context.Context, conversationID string) ([]Message, error) {
-	rows, err := q.db.QueryContext(ctx, getMessagesByConversation, conversationID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Message
-	for rows.Next() {
-		var i Message
-		if err := rows.Scan(
-			&i.ID,
-			&i.ConversationID,
-			&i.Role,
-			&i.Items,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type GetActiveLLMCaptureParams struct {
+	AgentID        sql.NullString
+	ConversationID sql.NullString
+	ExpiresAt      string
 }
 
-const getNotificationChannel = `-- name: GetNotificationChannel :one
-SELECT id, name, type, config, description, json_schema, created_at, updated_at FROM notification_channels WHERE id = ?
+func (q *Queries) GetActiveLLMCapture(ctx context.Context, arg GetActiveLLMCaptureParams) (LlmCapture, error) {
+	row := q.db.QueryRowContext(ctx, getActiveLLMCapture, arg.AgentID, arg.ConversationID, arg.ExpiresAt)
+	var i LlmCapture
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.ConversationID,
+		&i.RetentionSeconds,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAgent = `-- name: GetAgent :one
+SELECT id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars, version, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale FROM agents WHERE id = ?
 `
 
-func (q *Queries) GetNotificationChannel(ctx context.Context, id string) (NotificationChannel, error) {
-	row := q.db.QueryRowContext(ctx, getNotificationChannel, id)
-	var i NotificationChannel
+func (q *Queries) GetAgent(ctx context.Context, id string) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, getAgent, id)
+	var i Agent
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Type,
-		&i.Config,
 		&i.Description,
-		&i.JsonSchema,
+		&i.SystemPrompt,
+		&i.EnabledTools,
+		&i.EnabledNotificationChannels,
+		&i.Model,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EnabledFilesystemRoots,
+		&i.ForwardedHostEnvVars,
+		&i.Version,
+		&i.Hooks,
+		&i.MaxConcurrentRuns,
+		&i.TitleGenerationDisabled,
+		&i.TitlePrompt,
+		&i.TitleModel,
+		&i.PersonaRole,
+		&i.PersonaGoals,
+		&i.PersonaConstraints,
+		&i.PersonaStyle,
+		&i.ApprovalRules,
+		&i.Locale,
 	)
 	return i, err
 }
 
-const getNotificationChannelByName = `-- name: GetNotificationChannelByName :one
-SELECT id, name, type, config, description, json_schema, created_at, updated_at FROM notification_channels WHERE name = ?
+const getAgentFile = `-- name: GetAgentFile :one
+SELECT agent_id, path, content, created_at, updated_at FROM agent_files WHERE agent_id = ? AND path = ?
 `
 
-func (q *Queries) GetNotificationChannelByName(ctx context.Context, name string) (NotificationChannel, error) {
-	row := q.db.QueryRowContext(ctx, getNotificationChannelByName, name)
-	var i NotificationChannel
+type GetAgentFileParams struct {
+	AgentID string
+	Path    string
+}
+
+func (q *Queries) GetAgentFile(ctx context.Context, arg GetAgentFileParams) (AgentFile, error) {
+	row := q.db.QueryRowContext(ctx, getAgentFile, arg.AgentID, arg.Path)
+	var i AgentFile
+	err := row.Scan(
+		&i.AgentID,
+		&i.Path,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBlob = `-- name: GetBlob :one
+SELECT id, kind, conversation_id, name, content_type, size, sha256, created_at, expires_at FROM blobs WHERE id = ?
+`
+
+func (q *Queries) GetBlob(ctx context.Context, id string) (Blob, error) {
+	row := q.db.QueryRowContext(ctx, getBlob, id)
+	var i Blob
 	err := row.Scan(
 		&i.ID,
+		&i.Kind,
+		&i.ConversationID,
 		&i.Name,
-		&i.Type,
-		&i.Config,
-		&i.Description,
-		&i.JsonSchema,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
 
-const getTrigger = `-- name: GetTrigger :one
-SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at FROM triggers WHERE id = ?
+const getConversation = `-- name: GetConversation :one
+SELECT id, agent_id, title, previous_response_id, created_at, updated_at, summary, summary_message_id FROM conversations WHERE id = ?
 `
 
-func (q *Queries) GetTrigger(ctx context.Context, id string) (Trigger, error) {
-	row := q.db.QueryRowContext(ctx, getTrigger, id)
-	var i Trigger
+func (q *Queries) GetConversation(ctx context.Context, id string) (Conversation, error) {
+	row := q.db.QueryRowContext(ctx, getConversation, id)
+	var i Conversation
 	err := row.Scan(
 		&i.ID,
 		&i.AgentID,
-		&i.Name,
-		&i.Prompt,
-		&i.CronExpr,
-		&i.Enabled,
-		&i.NextRunAt,
-		&i.Model,
-		&i.ConversationTitle,
+		&i.Title,
+		&i.PreviousResponseID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Summary,
+		&i.SummaryMessageID,
 	)
 	return i, err
 }
 
-const listAgentFiles = `-- name: ListAgentFiles :many
-SELECT agent_id, path, created_at, updated_at
-FROM agent_files WHERE agent_id = ? AND path LIKE ?
-ORDER BY path ASC
+const getConversationKV = `-- name: GetConversationKV :one
+SELECT conversation_id, key, value, created_at, updated_at FROM conversation_kv WHERE conversation_id = ? AND key = ?
 `
 
-type ListAgentFilesParams struct {
-	AgentID string
-	Path    string
+type GetConversationKVParams struct {
+	ConversationID string
+	Key            string
 }
 
-type ListAgentFilesRow struct {
-	AgentID   string
-	Path      string
-	CreatedAt string
-	UpdatedAt string
+func (q *Queries) GetConversationKV(ctx context.Context, arg GetConversationKVParams) (ConversationKv, error) {
+	row := q.db.QueryRowContext(ctx, getConversationKV, arg.ConversationID, arg.Key)
+	var i ConversationKv
+	err := row.Scan(
+		&i.ConversationID,
+		&i.Key,
+		&i.Value,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
 }
 
-func (q *Queries) ListAgentFiles(ctx context.Context, arg ListAgentFilesParams) ([]ListAgentFilesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listAgentFiles, arg.AgentID, arg.Path)
+const getDueTriggers = `-- name: GetDueTriggers :many
+SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars FROM triggers WHERE enabled = 1 AND next_run_at <= ? ORDER BY next_run_at ASC
+`
+
+func (q *Queries) GetDueTriggers(ctx context.Context, nextRunAt sql.NullString) ([]Trigger, error) {
+	rows, err := q.db.QueryContext(ctx, getDueTriggers, nextRunAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListAgentFilesRow
+	var items []Trigger
 	for rows.Next() {
-		var i ListAgentFilesRow
+		var i Trigger
 		if err := rows.Scan(
+			&i.ID,
 			&i.AgentID,
-			&i.Path,
+			&i.Name,
+			&i.Prompt,
+			&i.CronExpr,
+			&i.Enabled,
+			&i.NextRunAt,
+			&i.Model,
+			&i.ConversationTitle,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.MaxDurationSeconds,
+			&i.Priority,
+			&i.Vars,
 		); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEvalCase = `-- name: GetEvalCase :one
+SELECT id, agent_id, name, prompt, assertions, rubric, created_at, updated_at FROM eval_cases WHERE id = ?
+`
+
+func (q *Queries) GetEvalCase(ctx context.Context, id string) (EvalCase, error) {
+	row := q.db.QueryRowContext(ctx, getEvalCase, id)
+	var i EvalCase
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.Name,
+		&i.Prompt,
+		&i.Assertions,
+		&i.Rubric,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEvalRun = `-- name: GetEvalRun :one
+SELECT id, agent_id, system_prompt, model, candidate, status, score, passed, total, results, error, created_at, finished_at FROM eval_runs WHERE id = ?
+`
+
+func (q *Queries) GetEvalRun(ctx context.Context, id string) (EvalRun, error) {
+	row := q.db.QueryRowContext(ctx, getEvalRun, id)
+	var i EvalRun
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.SystemPrompt,
+		&i.Model,
+		&i.Candidate,
+		&i.Status,
+		&i.Score,
+		&i.Passed,
+		&i.Total,
+		&i.Results,
+		&i.Error,
+		&i.CreatedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getFilesystemRoot = `-- name: GetFilesystemRoot :one
+SELECT id, name, path, description, created_at, updated_at, version FROM filesystem_roots WHERE id = ?
+`
+
+func (q *Queries) GetFilesystemRoot(ctx context.Context, id string) (FilesystemRoot, error) {
+	row := q.db.QueryRowContext(ctx, getFilesystemRoot, id)
+	var i FilesystemRoot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Path,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getFilesystemRootByName = `-- name: GetFilesystemRootByName :one
+SELECT id, name, path, description, created_at, updated_at, version FROM filesystem_roots WHERE name = ?
+`
+
+func (q *Queries) GetFilesystemRootByName(ctx context.Context, name string) (FilesystemRoot, error) {
+	row := q.db.QueryRowContext(ctx, getFilesystemRootByName, name)
+	var i FilesystemRoot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Path,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getLastEventSeq = `-- name: GetLastEventSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) AS seq FROM events WHERE conversation_id = ?
+`
+
+func (q *Queries) GetLastEventSeq(ctx context.Context, conversationID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLastEventSeq, conversationID)
+	var seq int64
+	err := row.Scan(&seq)
+	return seq, err
+}
+
+const getLatestRunTrace = `-- name: GetLatestRunTrace :one
+SELECT run_id, agent_id, conversation_id, kind, model, status, error, input_tokens, output_tokens, trace, started_at, finished_at, cost_usd FROM run_traces WHERE conversation_id = ? ORDER BY started_at DESC, rowid DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestRunTrace(ctx context.Context, conversationID string) (RunTrace, error) {
+	row := q.db.QueryRowContext(ctx, getLatestRunTrace, conversationID)
+	var i RunTrace
+	err := row.Scan(
+		&i.RunID,
+		&i.AgentID,
+		&i.ConversationID,
+		&i.Kind,
+		&i.Model,
+		&i.Status,
+		&i.Error,
+		&i.InputTokens,
+		&i.OutputTokens,
+		&i.Trace,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CostUsd,
+	)
+	return i, err
+}
+
+const getLatestTurnRecording = `-- name: GetLatestTurnRecording :one
+SELECT run_id, conversation_id, recording, created_at FROM turn_recordings WHERE conversation_id = ? ORDER BY created_at DESC, rowid DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestTurnRecording(ctx context.Context, conversationID string) (TurnRecording, error) {
+	row := q.db.QueryRowContext(ctx, getLatestTurnRecording, conversationID)
+	var i TurnRecording
+	err := row.Scan(
+		&i.RunID,
+		&i.ConversationID,
+		&i.Recording,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMessagesByConversation = `-- name: GetMessagesByConversation :many
+SELECT id, conversation_id, role, items, created_at, status, run_id, token_count FROM messages WHERE conversation_id = ? ORDER BY created_at ASC
+`
+
+func (q *Queries) GetMessagesByConversation(ctx context.Context, conversationID string) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesByConversation, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Message
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.Role,
+			&i.Items,
+			&i.CreatedAt,
+			&i.Status,
+			&i.RunID,
+			&i.TokenCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotificationChannel = `-- name: GetNotificationChannel :one
+SELECT id, name, type, config, description, json_schema, created_at, updated_at, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, version FROM notification_channels WHERE id = ?
+`
+
+func (q *Queries) GetNotificationChannel(ctx context.Context, id string) (NotificationChannel, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationChannel, id)
+	var i NotificationChannel
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Type,
+		&i.Config,
+		&i.Description,
+		&i.JsonSchema,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.QuietHoursStart,
+		&i.QuietHoursEnd,
+		&i.QuietHoursTimezone,
+		&i.QuietHoursMode,
+		&i.MaxPerHour,
+		&i.DigestSchedule,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getNotificationChannelByName = `-- name: GetNotificationChannelByName :one
+SELECT id, name, type, config, description, json_schema, created_at, updated_at, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, version FROM notification_channels WHERE name = ?
+`
+
+func (q *Queries) GetNotificationChannelByName(ctx context.Context, name string) (NotificationChannel, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationChannelByName, name)
+	var i NotificationChannel
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Type,
+		&i.Config,
+		&i.Description,
+		&i.JsonSchema,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.QuietHoursStart,
+		&i.QuietHoursEnd,
+		&i.QuietHoursTimezone,
+		&i.QuietHoursMode,
+		&i.MaxPerHour,
+		&i.DigestSchedule,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getNotificationDelivery = `-- name: GetNotificationDelivery :one
+SELECT id, channel_id, delivered_at, conversation_id FROM notification_deliveries WHERE id = ?
+`
+
+func (q *Queries) GetNotificationDelivery(ctx context.Context, id string) (NotificationDelivery, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationDelivery, id)
+	var i NotificationDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.ChannelID,
+		&i.DeliveredAt,
+		&i.ConversationID,
+	)
+	return i, err
+}
+
+const getNotificationMessage = `-- name: GetNotificationMessage :one
+SELECT channel_id, chat_id, message_id, delivery_id FROM notification_messages WHERE channel_id = ? AND chat_id = ? AND message_id = ?
+`
+
+type GetNotificationMessageParams struct {
+	ChannelID string
+	ChatID    string
+	MessageID string
+}
+
+func (q *Queries) GetNotificationMessage(ctx context.Context, arg GetNotificationMessageParams) (NotificationMessage, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationMessage, arg.ChannelID, arg.ChatID, arg.MessageID)
+	var i NotificationMessage
+	err := row.Scan(
+		&i.ChannelID,
+		&i.ChatID,
+		&i.MessageID,
+		&i.DeliveryID,
+	)
+	return i, err
+}
+
+const getRunTrace = `-- name: GetRunTrace :one
+SELECT run_id, agent_id, conversation_id, kind, model, status, error, input_tokens, output_tokens, trace, started_at, finished_at, cost_usd FROM run_traces WHERE run_id = ?
+`
+
+func (q *Queries) GetRunTrace(ctx context.Context, runID string) (RunTrace, error) {
+	row := q.db.QueryRowContext(ctx, getRunTrace, runID)
+	var i RunTrace
+	err := row.Scan(
+		&i.RunID,
+		&i.AgentID,
+		&i.ConversationID,
+		&i.Kind,
+		&i.Model,
+		&i.Status,
+		&i.Error,
+		&i.InputTokens,
+		&i.OutputTokens,
+		&i.Trace,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CostUsd,
+	)
+	return i, err
+}
+
+const getSession = `-- name: GetSession :one
+SELECT token_hash, api_key_id, subject, name, email, role, created_at, expires_at FROM sessions WHERE token_hash = ? AND expires_at > ?
+`
+
+type GetSessionParams struct {
+	TokenHash string
+	ExpiresAt string
+}
+
+func (q *Queries) GetSession(ctx context.Context, arg GetSessionParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSession, arg.TokenHash, arg.ExpiresAt)
+	var i Session
+	err := row.Scan(
+		&i.TokenHash,
+		&i.ApiKeyID,
+		&i.Subject,
+		&i.Name,
+		&i.Email,
+		&i.Role,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getSetting = `-- name: GetSetting :one
+
+SELECT value FROM settings WHERE key = ?
+`
+
+// Settings
+func (q *Queries) GetSetting(ctx context.Context, key string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getSetting, key)
+	var value string
+	err := row.Scan(&value)
+	return value, err
+}
+
+const getSettingEntry = `-- name: GetSettingEntry :one
+SELECT key, value, updated_at FROM settings WHERE key = ?
+`
+
+func (q *Queries) GetSettingEntry(ctx context.Context, key string) (Setting, error) {
+	row := q.db.QueryRowContext(ctx, getSettingEntry, key)
+	var i Setting
+	err := row.Scan(
+		&i.Key,
+		&i.Value,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTelegramChat = `-- name: GetTelegramChat :one
+SELECT channel_id, chat_id, conversation_id, created_at FROM telegram_chats WHERE channel_id = ? AND chat_id = ?
+`
+
+type GetTelegramChatParams struct {
+	ChannelID string
+	ChatID    string
+}
+
+func (q *Queries) GetTelegramChat(ctx context.Context, arg GetTelegramChatParams) (TelegramChat, error) {
+	row := q.db.QueryRowContext(ctx, getTelegramChat, arg.ChannelID, arg.ChatID)
+	var i TelegramChat
+	err := row.Scan(
+		&i.ChannelID,
+		&i.ChatID,
+		&i.ConversationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTrigger = `-- name: GetTrigger :one
+SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars FROM triggers WHERE id = ?
+`
+
+func (q *Queries) GetTrigger(ctx context.Context, id string) (Trigger, error) {
+	row := q.db.QueryRowContext(ctx, getTrigger, id)
+	var i Trigger
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.Name,
+		&i.Prompt,
+		&i.CronExpr,
+		&i.Enabled,
+		&i.NextRunAt,
+		&i.Model,
+		&i.ConversationTitle,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.MaxDurationSeconds,
+		&i.Priority,
+		&i.Vars,
+	)
+	return i, err
+}
+
+const getTriggerRun = `-- name: GetTriggerRun :one
+SELECT id, trigger_id, conversation_id, status, error_message, started_at, finished_at FROM trigger_runs WHERE id = ?
+`
+
+func (q *Queries) GetTriggerRun(ctx context.Context, id string) (TriggerRun, error) {
+	row := q.db.QueryRowContext(ctx, getTriggerRun, id)
+	var i TriggerRun
+	err := row.Scan(
+		&i.ID,
+		&i.TriggerID,
+		&i.ConversationID,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getTriggerWebhook = `-- name: GetTriggerWebhook :one
+SELECT trigger_id, token_hash, secret, signature_scheme, allowed_ips, created_at, updated_at FROM trigger_webhooks WHERE trigger_id = ?
+`
+
+func (q *Queries) GetTriggerWebhook(ctx context.Context, triggerID string) (TriggerWebhook, error) {
+	row := q.db.QueryRowContext(ctx, getTriggerWebhook, triggerID)
+	var i TriggerWebhook
+	err := row.Scan(
+		&i.TriggerID,
+		&i.TokenHash,
+		&i.Secret,
+		&i.SignatureScheme,
+		&i.AllowedIps,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTriggerWebhookByTokenHash = `-- name: GetTriggerWebhookByTokenHash :one
+SELECT trigger_id, token_hash, secret, signature_scheme, allowed_ips, created_at, updated_at FROM trigger_webhooks WHERE token_hash = ?
+`
+
+func (q *Queries) GetTriggerWebhookByTokenHash(ctx context.Context, tokenHash string) (TriggerWebhook, error) {
+	row := q.db.QueryRowContext(ctx, getTriggerWebhookByTokenHash, tokenHash)
+	var i TriggerWebhook
+	err := row.Scan(
+		&i.TriggerID,
+		&i.TokenHash,
+		&i.Secret,
+		&i.SignatureScheme,
+		&i.AllowedIps,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTurnRecording = `-- name: GetTurnRecording :one
+SELECT run_id, conversation_id, recording, created_at FROM turn_recordings WHERE run_id = ?
+`
+
+func (q *Queries) GetTurnRecording(ctx context.Context, runID string) (TurnRecording, error) {
+	row := q.db.QueryRowContext(ctx, getTurnRecording, runID)
+	var i TurnRecording
+	err := row.Scan(
+		&i.RunID,
+		&i.ConversationID,
+		&i.Recording,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const interruptRunningEvalRuns = `-- name: InterruptRunningEvalRuns :execrows
+UPDATE eval_runs SET status = 'interrupted', error = ?, finished_at = ?
+WHERE status = 'running'
+`
+
+type InterruptRunningEvalRunsParams struct {
+	Error      string
+	FinishedAt sql.NullString
+}
+
+func (q *Queries) InterruptRunningEvalRuns(ctx context.Context, arg InterruptRunningEvalRunsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, interruptRunningEvalRuns, arg.Error, arg.FinishedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const interruptRunningTriggerRuns = `-- name: InterruptRunningTriggerRuns :execrows
+UPDATE trigger_runs SET status = 'interrupted', error_message = ?, finished_at = ?
+WHERE status = 'running'
+`
+
+type InterruptRunningTriggerRunsParams struct {
+	ErrorMessage sql.NullString
+	FinishedAt   sql.NullString
+}
+
+func (q *Queries) InterruptRunningTriggerRuns(ctx context.Context, arg InterruptRunningTriggerRunsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, interruptRunningTriggerRuns, arg.ErrorMessage, arg.FinishedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const interruptUnleasedMessages = `-- name: InterruptUnleasedMessages :execrows
+UPDATE messages SET status = 'interrupted'
+WHERE status = 'in_progress'
+AND conversation_id NOT IN (SELECT conversation_id FROM conversation_leases WHERE expires_at > ?)
+`
+
+func (q *Queries) InterruptUnleasedMessages(ctx context.Context, expiresAt string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, interruptUnleasedMessages, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const isConversationLeased = `-- name: IsConversationLeased :one
+SELECT CAST(COUNT(*) AS INTEGER) AS leased FROM conversation_leases WHERE conversation_id = ? AND expires_at > ?
+`
+
+type IsConversationLeasedParams struct {
+	ConversationID string
+	ExpiresAt      string
+}
+
+func (q *Queries) IsConversationLeased(ctx context.Context, arg IsConversationLeasedParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, isConversationLeased, arg.ConversationID, arg.ExpiresAt)
+	var leased int64
+	err := row.Scan(&leased)
+	return leased, err
+}
+
+const listAPIKeys = `-- name: ListAPIKeys :many
+SELECT id, name, prefix, key_hash, created_at, last_used_at, revoked_at, agent_ids, scopes FROM api_keys ORDER BY created_at DESC
+`
+
+func (q *Queries) ListAPIKeys(ctx context.Context) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, listAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.AgentIds,
+			&i.Scopes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAgentFiles = `-- name: ListAgentFiles :many
+SELECT agent_id, path, created_at, updated_at
+FROM agent_files WHERE agent_id = ? AND path LIKE ?
+ORDER BY path ASC
+`
+
+type ListAgentFilesParams struct {
+	AgentID string
+	Path    string
+}
+
+type ListAgentFilesRow struct {
+	AgentID   string
+	Path      string
+	CreatedAt string
+	UpdatedAt string
+}
+
+func (q *Queries) ListAgentFiles(ctx context.Context, arg ListAgentFilesParams) ([]ListAgentFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAgentFiles, arg.AgentID, arg.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAgentFilesRow
+	for rows.Next() {
+		var i ListAgentFilesRow
+		if err := rows.Scan(
+			&i.AgentID,
+			&i.Path,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAgents = `-- name: ListAgents :many
+SELECT id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars, version, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale FROM agents ORDER BY created_at DESC
+`
+
+func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
+	rows, err := q.db.QueryContext(ctx, listAgents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Agent
+	for rows.Next() {
+		var i Agent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.SystemPrompt,
+			&i.EnabledTools,
+			&i.EnabledNotificationChannels,
+			&i.Model,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EnabledFilesystemRoots,
+			&i.ForwardedHostEnvVars,
+			&i.Version,
+			&i.Hooks,
+			&i.MaxConcurrentRuns,
+			&i.TitleGenerationDisabled,
+			&i.TitlePrompt,
+			&i.TitleModel,
+			&i.PersonaRole,
+			&i.PersonaGoals,
+			&i.PersonaConstraints,
+			&i.PersonaStyle,
+			&i.ApprovalRules,
+			&i.Locale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllTriggers = `-- name: ListAllTriggers :many
+SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars FROM triggers ORDER BY created_at DESC
+`
+
+func (q *Queries) ListAllTriggers(ctx context.Context) ([]Trigger, error) {
+	rows, err := q.db.QueryContext(ctx, listAllTriggers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Trigger
+	for rows.Next() {
+		var i Trigger
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.Name,
+			&i.Prompt,
+			&i.CronExpr,
+			&i.Enabled,
+			&i.NextRunAt,
+			&i.Model,
+			&i.ConversationTitle,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.MaxDurationSeconds,
+			&i.Priority,
+			&i.Vars,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArtifactsByConversation = `-- name: ListArtifactsByConversation :many
+SELECT id, run_id, conversation_id, call_id, tool_name, kind, name, uri, media_type, size, blob_id, created_at FROM artifacts WHERE conversation_id = ? ORDER BY created_at, rowid
+`
+
+func (q *Queries) ListArtifactsByConversation(ctx context.Context, conversationID string) ([]Artifact, error) {
+	rows, err := q.db.QueryContext(ctx, listArtifactsByConversation, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Artifact
+	for rows.Next() {
+		var i Artifact
+		if err := rows.Scan(
+			&i.ID,
+			&i.RunID,
+			&i.ConversationID,
+			&i.CallID,
+			&i.ToolName,
+			&i.Kind,
+			&i.Name,
+			&i.Uri,
+			&i.MediaType,
+			&i.Size,
+			&i.BlobID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArtifactsByRun = `-- name: ListArtifactsByRun :many
+SELECT id, run_id, conversation_id, call_id, tool_name, kind, name, uri, media_type, size, blob_id, created_at FROM artifacts WHERE conversation_id = ? AND run_id = ? ORDER BY created_at, rowid
+`
+
+type ListArtifactsByRunParams struct {
+	ConversationID string
+	RunID          string
+}
+
+func (q *Queries) ListArtifactsByRun(ctx context.Context, arg ListArtifactsByRunParams) ([]Artifact, error) {
+	rows, err := q.db.QueryContext(ctx, listArtifactsByRun, arg.ConversationID, arg.RunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Artifact
+	for rows.Next() {
+		var i Artifact
+		if err := rows.Scan(
+			&i.ID,
+			&i.RunID,
+			&i.ConversationID,
+			&i.CallID,
+			&i.ToolName,
+			&i.Kind,
+			&i.Name,
+			&i.Uri,
+			&i.MediaType,
+			&i.Size,
+			&i.BlobID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttachmentsByConversation = `-- name: ListAttachmentsByConversation :many
+SELECT id, conversation_id, message_id, blob_id, created_at FROM attachments WHERE conversation_id = ? ORDER BY created_at
+`
+
+func (q *Queries) ListAttachmentsByConversation(ctx context.Context, conversationID string) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, listAttachmentsByConversation, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.MessageID,
+			&i.BlobID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEntries = `-- name: ListAuditEntries :many
+SELECT id, actor, action, resource_type, resource_id, procedure, details, remote_addr, user_agent, created_at FROM audit_log ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListAuditEntries(ctx context.Context) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.Procedure,
+			&i.Details,
+			&i.RemoteAddr,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlobIDs = `-- name: ListBlobIDs :many
+SELECT id FROM blobs
+`
+
+func (q *Queries) ListBlobIDs(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listBlobIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlobsByConversation = `-- name: ListBlobsByConversation :many
+SELECT id, kind, conversation_id, name, content_type, size, sha256, created_at, expires_at FROM blobs WHERE conversation_id = ? ORDER BY created_at
+`
+
+func (q *Queries) ListBlobsByConversation(ctx context.Context, conversationID sql.NullString) ([]Blob, error) {
+	rows, err := q.db.QueryContext(ctx, listBlobsByConversation, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Blob
+	for rows.Next() {
+		var i Blob
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.ConversationID,
+			&i.Name,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConversationKV = `-- name: ListConversationKV :many
+SELECT conversation_id, key, value, created_at, updated_at FROM conversation_kv WHERE conversation_id = ? ORDER BY key
+`
+
+func (q *Queries) ListConversationKV(ctx context.Context, conversationID string) ([]ConversationKv, error) {
+	rows, err := q.db.QueryContext(ctx, listConversationKV, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ConversationKv
+	for rows.Next() {
+		var i ConversationKv
+		if err := rows.Scan(
+			&i.ConversationID,
+			&i.Key,
+			&i.Value,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDueQueuedNotifications = `-- name: ListDueQueuedNotifications :many
+SELECT id, channel_id, payload, deliver_after, created_at, conversation_id, attempts FROM queued_notifications WHERE deliver_after <= ? ORDER BY created_at ASC
+`
+
+func (q *Queries) ListDueQueuedNotifications(ctx context.Context, deliverAfter string) ([]QueuedNotification, error) {
+	rows, err := q.db.QueryContext(ctx, listDueQueuedNotifications, deliverAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QueuedNotification
+	for rows.Next() {
+		var i QueuedNotification
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChannelID,
+			&i.Payload,
+			&i.DeliverAfter,
+			&i.CreatedAt,
+			&i.ConversationID,
+			&i.Attempts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvalCasesByAgent = `-- name: ListEvalCasesByAgent :many
+SELECT id, agent_id, name, prompt, assertions, rubric, created_at, updated_at FROM eval_cases WHERE agent_id = ? ORDER BY created_at ASC, rowid ASC
+`
+
+func (q *Queries) ListEvalCasesByAgent(ctx context.Context, agentID string) ([]EvalCase, error) {
+	rows, err := q.db.QueryContext(ctx, listEvalCasesByAgent, agentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EvalCase
+	for rows.Next() {
+		var i EvalCase
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.Name,
+			&i.Prompt,
+			&i.Assertions,
+			&i.Rubric,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvalRunsByAgent = `-- name: ListEvalRunsByAgent :many
+SELECT id, agent_id, system_prompt, model, candidate, status, score, passed, total, results, error, created_at, finished_at FROM eval_runs WHERE agent_id = ? ORDER BY created_at DESC, rowid DESC
+`
+
+func (q *Queries) ListEvalRunsByAgent(ctx context.Context, agentID string) ([]EvalRun, error) {
+	rows, err := q.db.QueryContext(ctx, listEvalRunsByAgent, agentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EvalRun
+	for rows.Next() {
+		var i EvalRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.SystemPrompt,
+			&i.Model,
+			&i.Candidate,
+			&i.Status,
+			&i.Score,
+			&i.Passed,
+			&i.Total,
+			&i.Results,
+			&i.Error,
+			&i.CreatedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventsAfter = `-- name: ListEventsAfter :many
+SELECT conversation_id, seq, type, payload, created_at FROM events WHERE conversation_id = ? AND seq > ? ORDER BY seq
+`
+
+type ListEventsAfterParams struct {
+	ConversationID string
+	Seq            int64
+}
+
+func (q *Queries) ListEventsAfter(ctx context.Context, arg ListEventsAfterParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsAfter, arg.ConversationID, arg.Seq)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ConversationID,
+			&i.Seq,
+			&i.Type,
+			&i.Payload,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFilesystemRoots = `-- name: ListFilesystemRoots :many
+SELECT id, name, path, description, created_at, updated_at, version FROM filesystem_roots ORDER BY created_at DESC
+`
+
+func (q *Queries) ListFilesystemRoots(ctx context.Context) ([]FilesystemRoot, error) {
+	rows, err := q.db.QueryContext(ctx, listFilesystemRoots)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FilesystemRoot
+	for rows.Next() {
+		var i FilesystemRoot
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Path,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLLMCaptures = `-- name: ListLLMCaptures :many
+SELECT id, agent_id, conversation_id, retention_seconds, expires_at, created_at FROM llm_captures ORDER BY created_at DESC
+`
+
+func (q *Queries) ListLLMCaptures(ctx context.Context) ([]LlmCapture, error) {
+	rows, err := q.db.QueryContext(ctx, listLLMCaptures)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LlmCapture
+	for rows.Next() {
+		var i LlmCapture
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.ConversationID,
+			&i.RetentionSeconds,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLLMExchangesByConversation = `-- name: ListLLMExchangesByConversation :many
+SELECT id, capture_id, run_id, conversation_id, agent_id, round, url, key_id, request_body, status_code, response_headers, response_body, truncated, error, created_at, expires_at FROM llm_exchanges WHERE conversation_id = ? ORDER BY created_at, round
+`
+
+func (q *Queries) ListLLMExchangesByConversation(ctx context.Context, conversationID string) ([]LlmExchange, error) {
+	rows, err := q.db.QueryContext(ctx, listLLMExchangesByConversation, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LlmExchange
+	for rows.Next() {
+		var i LlmExchange
+		if err := rows.Scan(
+			&i.ID,
+			&i.CaptureID,
+			&i.RunID,
+			&i.ConversationID,
+			&i.AgentID,
+			&i.Round,
+			&i.Url,
+			&i.KeyID,
+			&i.RequestBody,
+			&i.StatusCode,
+			&i.ResponseHeaders,
+			&i.ResponseBody,
+			&i.Truncated,
+			&i.Error,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLLMExchangesByRun = `-- name: ListLLMExchangesByRun :many
+SELECT id, capture_id, run_id, conversation_id, agent_id, round, url, key_id, request_body, status_code, response_headers, response_body, truncated, error, created_at, expires_at FROM llm_exchanges WHERE run_id = ? ORDER BY round
+`
+
+func (q *Queries) ListLLMExchangesByRun(ctx context.Context, runID string) ([]LlmExchange, error) {
+	rows, err := q.db.QueryContext(ctx, listLLMExchangesByRun, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LlmExchange
+	for rows.Next() {
+		var i LlmExchange
+		if err := rows.Scan(
+			&i.ID,
+			&i.CaptureID,
+			&i.RunID,
+			&i.ConversationID,
+			&i.AgentID,
+			&i.Round,
+			&i.Url,
+			&i.KeyID,
+			&i.RequestBody,
+			&i.StatusCode,
+			&i.ResponseHeaders,
+			&i.ResponseBody,
+			&i.Truncated,
+			&i.Error,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationChannels = `-- name: ListNotificationChannels :many
+SELECT id, name, type, config, description, json_schema, created_at, updated_at, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, version FROM notification_channels ORDER BY created_at DESC
+`
+
+func (q *Queries) ListNotificationChannels(ctx context.Context) ([]NotificationChannel, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationChannels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationChannel
+	for rows.Next() {
+		var i NotificationChannel
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.Config,
+			&i.Description,
+			&i.JsonSchema,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.QuietHoursStart,
+			&i.QuietHoursEnd,
+			&i.QuietHoursTimezone,
+			&i.QuietHoursMode,
+			&i.MaxPerHour,
+			&i.DigestSchedule,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRunTracesByAgentSince = `-- name: ListRunTracesByAgentSince :many
+SELECT trace FROM run_traces WHERE agent_id = ? AND started_at >= ? ORDER BY started_at ASC
+`
+
+type ListRunTracesByAgentSinceParams struct {
+	AgentID   string
+	StartedAt string
+}
+
+func (q *Queries) ListRunTracesByAgentSince(ctx context.Context, arg ListRunTracesByAgentSinceParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listRunTracesByAgentSince, arg.AgentID, arg.StartedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var trace string
+		if err := rows.Scan(&trace); err != nil {
+			return nil, err
+		}
+		items = append(items, trace)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -645,31 +2950,36 @@ func (q *Queries) ListAgentFiles(ctx context.Context, arg ListAgentFilesParams)
 	return items, nil
 }
 
-const listAgents = `-- name: ListAgents :many
-SELECT id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars FROM agents ORDER BY created_at DESC
+const listRunUsageByConversation = `-- name: ListRunUsageByConversation :many
+SELECT run_id, model, status, input_tokens, output_tokens, cost_usd FROM run_traces
+WHERE conversation_id = ? ORDER BY started_at ASC, rowid ASC
 `
 
-func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
-	rows, err := q.db.QueryContext(ctx, listAgents)
+type ListRunUsageByConversationRow struct {
+	RunID        string
+	Model        string
+	Status       string
+	InputTokens  int64
+	OutputTokens int64
+	CostUsd      sql.NullFloat64
+}
+
+func (q *Queries) ListRunUsageByConversation(ctx context.Context, conversationID string) ([]ListRunUsageByConversationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRunUsageByConversation, conversationID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Agent
+	var items []ListRunUsageByConversationRow
 	for rows.Next() {
-		var i Agent
+		var i ListRunUsageByConversationRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.SystemPrompt,
-			&i.EnabledTools,
-			&i.EnabledNotificationChannels,
+			&i.RunID,
 			&i.Model,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.EnabledFilesystemRoots,
-			&i.ForwardedHostEnvVars,
+			&i.Status,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsd,
 		); err != nil {
 			return nil, err
 		}
@@ -684,26 +2994,32 @@ func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
 	return items, nil
 }
 
-const listAllConversations = `-- name: ListAllConversations :many
-SELECT id, agent_id, title, previous_response_id, created_at, updated_at FROM conversations ORDER BY updated_at DESC
+const listTriggerRuns = `-- name: ListTriggerRuns :many
+SELECT id, trigger_id, conversation_id, status, error_message, started_at, finished_at FROM trigger_runs WHERE trigger_id = ? ORDER BY started_at DESC LIMIT ?
 `
 
-func (q *Queries) ListAllConversations(ctx context.Context) ([]Conversation, error) {
-	rows, err := q.db.QueryContext(ctx, listAllConversations)
+type ListTriggerRunsParams struct {
+	TriggerID string
+	Limit     int64
+}
+
+func (q *Queries) ListTriggerRuns(ctx context.Context, arg ListTriggerRunsParams) ([]TriggerRun, error) {
+	rows, err := q.db.QueryContext(ctx, listTriggerRuns, arg.TriggerID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Conversation
+	var items []TriggerRun
 	for rows.Next() {
-		var i Conversation
+		var i TriggerRun
 		if err := rows.Scan(
 			&i.ID,
-			&i.AgentID,
-			&i.Title,
-			&i.PreviousResponseID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.TriggerID,
+			&i.ConversationID,
+			&i.Status,
+			&i.ErrorMessage,
+			&i.StartedAt,
+			&i.FinishedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -718,12 +3034,12 @@ func (q *Queries) ListAllConversations(ctx context.Context) ([]Conversation, err
 	return items, nil
 }
 
-const listAllTriggers = `-- name: ListAllTriggers :many
-SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at FROM triggers ORDER BY created_at DESC
+const listTriggersByAgent = `-- name: ListTriggersByAgent :many
+SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars FROM triggers WHERE agent_id = ? ORDER BY created_at DESC
 `
 
-func (q *Queries) ListAllTriggers(ctx context.Context) ([]Trigger, error) {
-	rows, err := q.db.QueryContext(ctx, listAllTriggers)
+func (q *Queries) ListTriggersByAgent(ctx context.Context, agentID string) ([]Trigger, error) {
+	rows, err := q.db.QueryContext(ctx, listTriggersByAgent, agentID)
 	if err != nil {
 		return nil, err
 	}
@@ -743,6 +3059,10 @@ func (q *Queries) ListAllTriggers(ctx context.Context) ([]Trigger, error) {
 			&i.ConversationTitle,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.MaxDurationSeconds,
+			&i.Priority,
+			&i.Vars,
 		); err != nil {
 			return nil, err
 		}
@@ -757,24 +3077,25 @@ func (q *Queries) ListAllTriggers(ctx context.Context) ([]Trigger, error) {
 	return items, nil
 }
 
-const listConversations = `-- name: ListConversations :many
-SELECT id, agent_id, title, previous_response_id, created_at, updated_at FROM conversations WHERE agent_id = ? ORDER BY updated_at DESC
+const listTriggerWebhooks = `-- name: ListTriggerWebhooks :many
+SELECT trigger_id, token_hash, secret, signature_scheme, allowed_ips, created_at, updated_at FROM trigger_webhooks ORDER BY trigger_id
 `
 
-func (q *Queries) ListConversations(ctx context.Context, agentID string) ([]Conversation, error) {
-	rows, err := q.db.QueryContext(ctx, listConversations, agentID)
+func (q *Queries) ListTriggerWebhooks(ctx context.Context) ([]TriggerWebhook, error) {
+	rows, err := q.db.QueryContext(ctx, listTriggerWebhooks)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Conversation
+	var items []TriggerWebhook
 	for rows.Next() {
-		var i Conversation
+		var i TriggerWebhook
 		if err := rows.Scan(
-			&i.ID,
-			&i.AgentID,
-			&i.Title,
-			&i.PreviousResponseID,
+			&i.TriggerID,
+			&i.TokenHash,
+			&i.Secret,
+			&i.SignatureScheme,
+			&i.AllowedIps,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -791,26 +3112,26 @@ func (q *Queries) ListConversations(ctx context.Context, agentID string) ([]Conv
 	return items, nil
 }
 
-const listFilesystemRoots = `-- name: ListFilesystemRoots :many
-SELECT id, name, path, description, created_at, updated_at FROM filesystem_roots ORDER BY created_at DESC
+const listWebPushSubscriptions = `-- name: ListWebPushSubscriptions :many
+SELECT id, endpoint, p256dh, auth, created_at, agent_ids FROM web_push_subscriptions ORDER BY created_at ASC
 `
 
-func (q *Queries) ListFilesystemRoots(ctx context.Context) ([]FilesystemRoot, error) {
-	rows, err := q.db.QueryContext(ctx, listFilesystemRoots)
+func (q *Queries) ListWebPushSubscriptions(ctx context.Context) ([]WebPushSubscription, error) {
+	rows, err := q.db.QueryContext(ctx, listWebPushSubscriptions)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FilesystemRoot
+	var items []WebPushSubscription
 	for rows.Next() {
-		var i FilesystemRoot
+		var i WebPushSubscription
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.Path,
-			&i.Description,
+			&i.Endpoint,
+			&i.P256dh,
+			&i.Auth,
 			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.AgentIds,
 		); err != nil {
 			return nil, err
 		}
@@ -825,68 +3146,109 @@ func (q *Queries) ListFilesystemRoots(ctx context.Context) ([]FilesystemRoot, er
 	return items, nil
 }
 
-const listNotificationChannels = `-- name: ListNotificationChannels :many
-SELECT id, name, type, config, description, json_schema, created_at, updated_at FROM notification_channels ORDER BY created_at DESC
+const releaseConversationLease = `-- name: ReleaseConversationLease :exec
+DELETE FROM conversation_leases WHERE conversation_id = ? AND holder = ?
 `
 
-func (q *Queries) ListNotificationChannels(ctx context.Context) ([]NotificationChannel, error) {
-	rows, err := q.db.QueryContext(ctx, listNotificationChannels)
+type ReleaseConversationLeaseParams struct {
+	ConversationID string
+	Holder         string
+}
+
+func (q *Queries) ReleaseConversationLease(ctx context.Context, arg ReleaseConversationLeaseParams) error {
+	_, err := q.db.ExecContext(ctx, releaseConversationLease, arg.ConversationID, arg.Holder)
+	return err
+}
+
+const renewConversationLeases = `-- name: RenewConversationLeases :exec
+UPDATE conversation_leases SET expires_at = ? WHERE holder = ?
+`
+
+type RenewConversationLeasesParams struct {
+	ExpiresAt string
+	Holder    string
+}
+
+func (q *Queries) RenewConversationLeases(ctx context.Context, arg RenewConversationLeasesParams) error {
+	_, err := q.db.ExecContext(ctx, renewConversationLeases, arg.ExpiresAt, arg.Holder)
+	return err
+}
+
+const retryQueuedNotification = `-- name: RetryQueuedNotification :exec
+UPDATE queued_notifications SET attempts = attempts + 1, deliver_after = ? WHERE id = ?
+`
+
+type RetryQueuedNotificationParams struct {
+	DeliverAfter string
+	ID           string
+}
+
+func (q *Queries) RetryQueuedNotification(ctx context.Context, arg RetryQueuedNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, retryQueuedNotification, arg.DeliverAfter, arg.ID)
+	return err
+}
+
+const revokeAPIKey = `-- name: RevokeAPIKey :execrows
+UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL
+`
+
+type RevokeAPIKeyParams struct {
+	RevokedAt sql.NullString
+	ID        string
+}
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeAPIKey, arg.RevokedAt, arg.ID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []NotificationChannel
-	for rows.Next() {
-		var i NotificationChannel
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Type,
-			&i.Config,
-			&i.Description,
-			&i.JsonSchema,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
 }
 
-const listTriggerRuns = `-- name: ListTriggerRuns :many
-SELECT id, trigger_id, conversation_id, status, error_message, started_at, finished_at FROM trigger_runs WHERE trigger_id = ? ORDER BY started_at DESC LIMIT ?
+const sumConversationRunUsageSince = `-- name: SumConversationRunUsageSince :many
+SELECT conversation_id, agent_id, model, status, CAST(COUNT(*) AS INTEGER) AS runs,
+CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+CAST(TOTAL(cost_usd) AS REAL) AS reported_cost_usd,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN input_tokens ELSE 0 END) AS INTEGER) AS unreported_input_tokens,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN output_tokens ELSE 0 END) AS INTEGER) AS unreported_output_tokens
+FROM run_traces WHERE started_at >= ?
+GROUP BY conversation_id, agent_id, model, status ORDER BY conversation_id, model, status
 `
 
-type ListTriggerRunsParams struct {
-	TriggerID string
-	Limit     int64
+type SumConversationRunUsageSinceRow struct {
+	ConversationID         string
+	AgentID                string
+	Model                  string
+	Status                 string
+	Runs                   int64
+	InputTokens            int64
+	OutputTokens           int64
+	ReportedCostUsd        float64
+	UnreportedInputTokens  int64
+	UnreportedOutputTokens int64
 }
 
-func (q *Queries) ListTriggerRuns(ctx context.Context, arg ListTriggerRunsParams) ([]TriggerRun, error) {
-	rows, err := q.db.QueryContext(ctx, listTriggerRuns, arg.TriggerID, arg.Limit)
+func (q *Queries) SumConversationRunUsageSince(ctx context.Context, startedAt string) ([]SumConversationRunUsageSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumConversationRunUsageSince, startedAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []TriggerRun
+	var items []SumConversationRunUsageSinceRow
 	for rows.Next() {
-		var i TriggerRun
+		var i SumConversationRunUsageSinceRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.TriggerID,
 			&i.ConversationID,
+			&i.AgentID,
+			&i.Model,
 			&i.Status,
-			&i.ErrorMessage,
-			&i.StartedAt,
-			&i.FinishedAt,
+			&i.Runs,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.ReportedCostUsd,
+			&i.UnreportedInputTokens,
+			&i.UnreportedOutputTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -901,31 +3263,48 @@ func (q *Queries) ListTriggerRuns(ctx context.Context, arg ListTriggerRunsParams
 	return items, nil
 }
 
-const listTriggersByAgent = `-- name: ListTriggersByAgent :many
-SELECT id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at FROM triggers WHERE agent_id = ? ORDER BY created_at DESC
+const sumRunUsageSince = `-- name: SumRunUsageSince :many
+SELECT agent_id, model, status, CAST(COUNT(*) AS INTEGER) AS runs,
+CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+CAST(TOTAL(cost_usd) AS REAL) AS reported_cost_usd,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN input_tokens ELSE 0 END) AS INTEGER) AS unreported_input_tokens,
+CAST(SUM(CASE WHEN cost_usd IS NULL THEN output_tokens ELSE 0 END) AS INTEGER) AS unreported_output_tokens
+FROM run_traces WHERE started_at >= ?
+GROUP BY agent_id, model, status ORDER BY agent_id, model, status
 `
 
-func (q *Queries) ListTriggersByAgent(ctx context.Context, agentID string) ([]Trigger, error) {
-	rows, err := q.db.QueryContext(ctx, listTriggersByAgent, agentID)
+type SumRunUsageSinceRow struct {
+	AgentID                string
+	Model                  string
+	Status                 string
+	Runs                   int64
+	InputTokens            int64
+	OutputTokens           int64
+	ReportedCostUsd        float64
+	UnreportedInputTokens  int64
+	UnreportedOutputTokens int64
+}
+
+func (q *Queries) SumRunUsageSince(ctx context.Context, startedAt string) ([]SumRunUsageSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumRunUsageSince, startedAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Trigger
+	var items []SumRunUsageSinceRow
 	for rows.Next() {
-		var i Trigger
+		var i SumRunUsageSinceRow
 		if err := rows.Scan(
-			&i.ID,
 			&i.AgentID,
-			&i.Name,
-			&i.Prompt,
-			&i.CronExpr,
-			&i.Enabled,
-			&i.NextRunAt,
 			&i.Model,
-			&i.ConversationTitle,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Status,
+			&i.Runs,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.ReportedCostUsd,
+			&i.UnreportedInputTokens,
+			&i.UnreportedOutputTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -940,11 +3319,25 @@ func (q *Queries) ListTriggersByAgent(ctx context.Context, agentID string) ([]Tr
 	return items, nil
 }
 
+const touchAPIKey = `-- name: TouchAPIKey :exec
+UPDATE api_keys SET last_used_at = ? WHERE id = ?
+`
+
+type TouchAPIKeyParams struct {
+	LastUsedAt sql.NullString
+	ID         string
+}
+
+func (q *Queries) TouchAPIKey(ctx context.Context, arg TouchAPIKeyParams) error {
+	_, err := q.db.ExecContext(ctx, touchAPIKey, arg.LastUsedAt, arg.ID)
+	return err
+}
+
 const updateAgent = `-- name: UpdateAgent :one
 UPDATE agents
-SET name = ?, description = ?, system_prompt = ?, enabled_tools = ?, enabled_notification_channels = ?, enabled_filesystem_roots = ?, model = ?, forwarded_host_env_vars = ?, updated_at = ?
-WHERE id = ?
-RETURNING id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars
+SET name = ?, description = ?, system_prompt = ?, enabled_tools = ?, enabled_notification_channels = ?, enabled_filesystem_roots = ?, model = ?, forwarded_host_env_vars = ?, hooks = ?, max_concurrent_runs = ?, title_generation_disabled = ?, title_prompt = ?, title_model = ?, persona_role = ?, persona_goals = ?, persona_constraints = ?, persona_style = ?, approval_rules = ?, locale = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ?
+RETURNING id, name, description, system_prompt, enabled_tools, enabled_notification_channels, model, created_at, updated_at, enabled_filesystem_roots, forwarded_host_env_vars, version, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale
 `
 
 type UpdateAgentParams struct {
@@ -956,8 +3349,20 @@ type UpdateAgentParams struct {
 	EnabledFilesystemRoots      string
 	Model                       string
 	ForwardedHostEnvVars        string
+	Hooks                       string
+	MaxConcurrentRuns           int64
+	TitleGenerationDisabled     int64
+	TitlePrompt                 string
+	TitleModel                  string
+	PersonaRole                 string
+	PersonaGoals                string
+	PersonaConstraints          string
+	PersonaStyle                string
+	ApprovalRules               string
+	Locale                      string
 	UpdatedAt                   string
 	ID                          string
+	Version                     int64
 }
 
 func (q *Queries) UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent, error) {
@@ -970,8 +3375,20 @@ func (q *Queries) UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent
 		arg.EnabledFilesystemRoots,
 		arg.Model,
 		arg.ForwardedHostEnvVars,
+		arg.Hooks,
+		arg.MaxConcurrentRuns,
+		arg.TitleGenerationDisabled,
+		arg.TitlePrompt,
+		arg.TitleModel,
+		arg.PersonaRole,
+		arg.PersonaGoals,
+		arg.PersonaConstraints,
+		arg.PersonaStyle,
+		arg.ApprovalRules,
+		arg.Locale,
 		arg.UpdatedAt,
 		arg.ID,
+		arg.Version,
 	)
 	var i Agent
 	err := row.Scan(
@@ -986,6 +3403,18 @@ func (q *Queries) UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent
 		&i.UpdatedAt,
 		&i.EnabledFilesystemRoots,
 		&i.ForwardedHostEnvVars,
+		&i.Version,
+		&i.Hooks,
+		&i.MaxConcurrentRuns,
+		&i.TitleGenerationDisabled,
+		&i.TitlePrompt,
+		&i.TitleModel,
+		&i.PersonaRole,
+		&i.PersonaGoals,
+		&i.PersonaConstraints,
+		&i.PersonaStyle,
+		&i.ApprovalRules,
+		&i.Locale,
 	)
 	return i, err
 }
@@ -994,7 +3423,7 @@ const updateConversation = `-- name: UpdateConversation :one
 UPDATE conversations
 SET title = ?, previous_response_id = ?, updated_at = ?
 WHERE id = ?
-RETURNING id, agent_id, title, previous_response_id, created_at, updated_at
+RETURNING id, agent_id, title, previous_response_id, created_at, updated_at, summary, summary_message_id
 `
 
 type UpdateConversationParams struct {
@@ -1019,102 +3448,305 @@ func (q *Queries) UpdateConversation(ctx context.Context, arg UpdateConversation
 		&i.PreviousResponseID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Summary,
+		&i.SummaryMessageID,
+	)
+	return i, err
+}
+
+const updateConversationSummary = `-- name: UpdateConversationSummary :exec
+UPDATE conversations SET summary = ?, summary_message_id = ? WHERE id = ?
+`
+
+type UpdateConversationSummaryParams struct {
+	Summary          string
+	SummaryMessageID string
+	ID               string
+}
+
+func (q *Queries) UpdateConversationSummary(ctx context.Context, arg UpdateConversationSummaryParams) error {
+	_, err := q.db.ExecContext(ctx, updateConversationSummary, arg.Summary, arg.SummaryMessageID, arg.ID)
+	return err
+}
+
+const updateEvalCase = `-- name: UpdateEvalCase :one
+UPDATE eval_cases SET name = ?, prompt = ?, assertions = ?, rubric = ?, updated_at = ?
+WHERE id = ? RETURNING id, agent_id, name, prompt, assertions, rubric, created_at, updated_at
+`
+
+type UpdateEvalCaseParams struct {
+	Name       string
+	Prompt     string
+	Assertions string
+	Rubric     string
+	UpdatedAt  string
+	ID         string
+}
+
+func (q *Queries) UpdateEvalCase(ctx context.Context, arg UpdateEvalCaseParams) (EvalCase, error) {
+	row := q.db.QueryRowContext(ctx, updateEvalCase,
+		arg.Name,
+		arg.Prompt,
+		arg.Assertions,
+		arg.Rubric,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	var i EvalCase
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.Name,
+		&i.Prompt,
+		&i.Assertions,
+		&i.Rubric,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateFilesystemRoot = `-- name: UpdateFilesystemRoot :one
+UPDATE filesystem_roots SET name = ?, path = ?, description = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ? RETURNING id, name, path, description, created_at, updated_at, version
+`
+
+type UpdateFilesystemRootParams struct {
+	Name        string
+	Path        string
+	Description string
+	UpdatedAt   string
+	ID          string
+	Version     int64
+}
+
+func (q *Queries) UpdateFilesystemRoot(ctx context.Context, arg UpdateFilesystemRootParams) (FilesystemRoot, error) {
+	row := q.db.QueryRowContext(ctx, updateFilesystemRoot,
+		arg.Name,
+		arg.Path,
+		arg.Description,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.Version,
+	)
+	var i FilesystemRoot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Path,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateMessageItems = `-- name: UpdateMessageItems :exec
+UPDATE messages SET items = ?, status = ?, token_count = 0 WHERE id = ?
+`
+
+type UpdateMessageItemsParams struct {
+	Items  string
+	Status string
+	ID     string
+}
+
+func (q *Queries) UpdateMessageItems(ctx context.Context, arg UpdateMessageItemsParams) error {
+	_, err := q.db.ExecContext(ctx, updateMessageItems, arg.Items, arg.Status, arg.ID)
+	return err
+}
+
+const updateMessageTokenCount = `-- name: UpdateMessageTokenCount :exec
+UPDATE messages SET token_count = ? WHERE id = ?
+`
+
+type UpdateMessageTokenCountParams struct {
+	TokenCount int64
+	ID         string
+}
+
+func (q *Queries) UpdateMessageTokenCount(ctx context.Context, arg UpdateMessageTokenCountParams) error {
+	_, err := q.db.ExecContext(ctx, updateMessageTokenCount, arg.TokenCount, arg.ID)
+	return err
+}
+
+const updateNotificationChannel = `-- name: UpdateNotificationChannel :one
+UPDATE notification_channels SET name = ?, type = ?, config = ?, description = ?, json_schema = ?, quiet_hours_start = ?, quiet_hours_end = ?, quiet_hours_timezone = ?, quiet_hours_mode = ?, max_per_hour = ?, digest_schedule = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ? RETURNING id, name, type, config, description, json_schema, created_at, updated_at, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, version
+`
+
+type UpdateNotificationChannelParams struct {
+	Name               string
+	Type               string
+	Config             string
+	Description        string
+	JsonSchema         string
+	QuietHoursStart    string
+	QuietHoursEnd      string
+	QuietHoursTimezone string
+	QuietHoursMode     string
+	MaxPerHour         int64
+	DigestSchedule     string
+	UpdatedAt          string
+	ID                 string
+	Version            int64
+}
+
+func (q *Queries) UpdateNotificationChannel(ctx context.Context, arg UpdateNotificationChannelParams) (NotificationChannel, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationChannel,
+		arg.Name,
+		arg.Type,
+		arg.Config,
+		arg.Description,
+		arg.JsonSchema,
+		arg.QuietHoursStart,
+		arg.QuietHoursEnd,
+		arg.QuietHoursTimezone,
+		arg.QuietHoursMode,
+		arg.MaxPerHour,
+		arg.DigestSchedule,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.Version,
+	)
+	var i NotificationChannel
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Type,
+		&i.Config,
+		&i.Description,
+		&i.JsonSchema,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.QuietHoursStart,
+		&i.QuietHoursEnd,
+		&i.QuietHoursTimezone,
+		&i.QuietHoursMode,
+		&i.MaxPerHour,
+		&i.DigestSchedule,
+		&i.Version,
 	)
 	return i, err
 }
 
-const updateFilesystemRoot = `-- name: UpdateFilesystemRoot :one
-UPDATE filesystem_roots SET name = ?, path = ?, description = ?, updated_at = ?
-WHERE id = ? RETURNING id, name, path, description, created_at, updated_at
+const updateNotificationChannelConfig = `-- name: UpdateNotificationChannelConfig :exec
+UPDATE notification_channels SET config = ? WHERE id = ?
 `
 
-type UpdateFilesystemRootParams struct {
-	Name        string
-	Path        string
-	Description string
-	UpdatedAt   string
-	ID          string
+type UpdateNotificationChannelConfigParams struct {
+	Config string
+	ID     string
 }
 
-func (q *Queries) UpdateFilesystemRoot(ctx context.Context, arg UpdateFilesystemRootParams) (FilesystemRoot, error) {
-	row := q.db.QueryRowContext(ctx, updateFilesystemRoot,
+func (q *Queries) UpdateNotificationChannelConfig(ctx context.Context, arg UpdateNotificationChannelConfigParams) error {
+	_, err := q.db.ExecContext(ctx, updateNotificationChannelConfig, arg.Config, arg.ID)
+	return err
+}
+
+const updateTrigger = `-- name: UpdateTrigger :one
+UPDATE triggers SET name = ?, prompt = ?, cron_expr = ?, enabled = ?, next_run_at = ?, max_duration_seconds = ?, priority = ?, vars = ?, updated_at = ?, version = version + 1
+WHERE id = ? AND version = ? RETURNING id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars
+`
+
+type UpdateTriggerParams struct {
+	Name               string
+	Prompt             string
+	CronExpr           sql.NullString
+	Enabled            int64
+	NextRunAt          sql.NullString
+	MaxDurationSeconds int64
+	Priority           int64
+	Vars               string
+	UpdatedAt          string
+	ID                 string
+	Version            int64
+}
+
+func (q *Queries) UpdateTrigger(ctx context.Context, arg UpdateTriggerParams) (Trigger, error) {
+	row := q.db.QueryRowContext(ctx, updateTrigger,
 		arg.Name,
-		arg.Path,
-		arg.Description,
+		arg.Prompt,
+		arg.CronExpr,
+		arg.Enabled,
+		arg.NextRunAt,
+		arg.MaxDurationSeconds,
+		arg.Priority,
+		arg.Vars,
 		arg.UpdatedAt,
 		arg.ID,
+		arg.Version,
 	)
-	var i FilesystemRoot
+	var i Trigger
 	err := row.Scan(
 		&i.ID,
+		&i.AgentID,
 		&i.Name,
-		&i.Path,
-		&i.Description,
+		&i.Prompt,
+		&i.CronExpr,
+		&i.Enabled,
+		&i.NextRunAt,
+		&i.Model,
+		&i.ConversationTitle,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.MaxDurationSeconds,
+		&i.Priority,
+		&i.Vars,
 	)
 	return i, err
 }
 
-const updateNotificationChannel = `-- name: UpdateNotificationChannel :one
-UPDATE notification_channels SET name = ?, type = ?, config = ?, description = ?, json_schema = ?, updated_at = ?
-WHERE id = ? RETURNING id, name, type, config, description, json_schema, created_at, updated_at
+const updateTriggerAgent = `-- name: UpdateTriggerAgent :one
+UPDATE triggers SET agent_id = ?, updated_at = ?, version = version + 1
+WHERE id = ? RETURNING id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars
 `
 
-type UpdateNotificationChannelParams struct {
-	Name        string
-	Type        string
-	Config      string
-	Description string
-	JsonSchema  string
-	UpdatedAt   string
-	ID          string
+type UpdateTriggerAgentParams struct {
+	AgentID   string
+	UpdatedAt string
+	ID        string
 }
 
-func (q *Queries) UpdateNotificationChannel(ctx context.Context, arg UpdateNotificationChannelParams) (NotificationChannel, error) {
-	row := q.db.QueryRowContext(ctx, updateNotificationChannel,
-		arg.Name,
-		arg.Type,
-		arg.Config,
-		arg.Description,
-		arg.JsonSchema,
-		arg.UpdatedAt,
-		arg.ID,
-	)
-	var i NotificationChannel
+func (q *Queries) UpdateTriggerAgent(ctx context.Context, arg UpdateTriggerAgentParams) (Trigger, error) {
+	row := q.db.QueryRowContext(ctx, updateTriggerAgent, arg.AgentID, arg.UpdatedAt, arg.ID)
+	var i Trigger
 	err := row.Scan(
 		&i.ID,
+		&i.AgentID,
 		&i.Name,
-		&i.Type,
-		&i.Config,
-		&i.Description,
-		&i.JsonSchema,
+		&i.Prompt,
+		&i.CronExpr,
+		&i.Enabled,
+		&i.NextRunAt,
+		&i.Model,
+		&i.ConversationTitle,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.MaxDurationSeconds,
+		&i.Priority,
+		&i.Vars,
 	)
 	return i, err
 }
 
-const updateTrigger = `-- name: UpdateTrigger :one
-UPDATE triggers SET name = ?, prompt = ?, cron_expr = ?, enabled = ?, next_run_at = ?, updated_at = ?
-WHERE id = ? RETURNING id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at
+const updateTriggerEnabled = `-- name: UpdateTriggerEnabled :one
+UPDATE triggers SET enabled = ?, next_run_at = ?, updated_at = ?, version = version + 1
+WHERE id = ? RETURNING id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, created_at, updated_at, version, max_duration_seconds, priority, vars
 `
 
-type UpdateTriggerParams struct {
-	Name      string
-	Prompt    string
-	CronExpr  sql.NullString
+type UpdateTriggerEnabledParams struct {
 	Enabled   int64
 	NextRunAt sql.NullString
 	UpdatedAt string
 	ID        string
 }
 
-func (q *Queries) UpdateTrigger(ctx context.Context, arg UpdateTriggerParams) (Trigger, error) {
-	row := q.db.QueryRowContext(ctx, updateTrigger,
-		arg.Name,
-		arg.Prompt,
-		arg.CronExpr,
+func (q *Queries) UpdateTriggerEnabled(ctx context.Context, arg UpdateTriggerEnabledParams) (Trigger, error) {
+	row := q.db.QueryRowContext(ctx, updateTriggerEnabled,
 		arg.Enabled,
 		arg.NextRunAt,
 		arg.UpdatedAt,
@@ -1133,6 +3765,10 @@ func (q *Queries) UpdateTrigger(ctx context.Context, arg UpdateTriggerParams) (T
 		&i.ConversationTitle,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.MaxDurationSeconds,
+		&i.Priority,
+		&i.Vars,
 	)
 	return i, err
 }
@@ -1176,6 +3812,135 @@ func (q *Queries) UpdateTriggerRun(ctx context.Context, arg UpdateTriggerRunPara
 	return err
 }
 
+const updateTriggerVars = `-- name: UpdateTriggerVars :exec
+UPDATE triggers SET vars = ? WHERE id = ?
+`
+
+type UpdateTriggerVarsParams struct {
+	Vars string
+	ID   string
+}
+
+func (q *Queries) UpdateTriggerVars(ctx context.Context, arg UpdateTriggerVarsParams) error {
+	_, err := q.db.ExecContext(ctx, updateTriggerVars, arg.Vars, arg.ID)
+	return err
+}
+
+const updateTriggerWebhook = `-- name: UpdateTriggerWebhook :one
+UPDATE trigger_webhooks SET secret = ?, signature_scheme = ?, allowed_ips = ?, updated_at = ?
+WHERE trigger_id = ?
+RETURNING trigger_id, token_hash, secret, signature_scheme, allowed_ips, created_at, updated_at
+`
+
+type UpdateTriggerWebhookParams struct {
+	Secret          string
+	SignatureScheme string
+	AllowedIps      string
+	UpdatedAt       string
+	TriggerID       string
+}
+
+func (q *Queries) UpdateTriggerWebhook(ctx context.Context, arg UpdateTriggerWebhookParams) (TriggerWebhook, error) {
+	row := q.db.QueryRowContext(ctx, updateTriggerWebhook,
+		arg.Secret,
+		arg.SignatureScheme,
+		arg.AllowedIps,
+		arg.UpdatedAt,
+		arg.TriggerID,
+	)
+	var i TriggerWebhook
+	err := row.Scan(
+		&i.TriggerID,
+		&i.TokenHash,
+		&i.Secret,
+		&i.SignatureScheme,
+		&i.AllowedIps,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateTriggerWebhookSecret = `-- name: UpdateTriggerWebhookSecret :exec
+UPDATE trigger_webhooks SET secret = ? WHERE trigger_id = ?
+`
+
+type UpdateTriggerWebhookSecretParams struct {
+	Secret    string
+	TriggerID string
+}
+
+func (q *Queries) UpdateTriggerWebhookSecret(ctx context.Context, arg UpdateTriggerWebhookSecretParams) error {
+	_, err := q.db.ExecContext(ctx, updateTriggerWebhookSecret, arg.Secret, arg.TriggerID)
+	return err
+}
+
+const upsertAgent = `-- name: UpsertAgent :exec
+INSERT INTO agents (id, name, description, system_prompt, enabled_tools, enabled_notification_channels, enabled_filesystem_roots, model, forwarded_host_env_vars, hooks, max_concurrent_runs, title_generation_disabled, title_prompt, title_model, persona_role, persona_goals, persona_constraints, persona_style, approval_rules, locale, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    name = excluded.name, description = excluded.description, system_prompt = excluded.system_prompt,
+    enabled_tools = excluded.enabled_tools, enabled_notification_channels = excluded.enabled_notification_channels,
+    enabled_filesystem_roots = excluded.enabled_filesystem_roots, model = excluded.model,
+    forwarded_host_env_vars = excluded.forwarded_host_env_vars, hooks = excluded.hooks, max_concurrent_runs = excluded.max_concurrent_runs,
+    title_generation_disabled = excluded.title_generation_disabled, title_prompt = excluded.title_prompt, title_model = excluded.title_model,
+    persona_role = excluded.persona_role, persona_goals = excluded.persona_goals, persona_constraints = excluded.persona_constraints, persona_style = excluded.persona_style, approval_rules = excluded.approval_rules, locale = excluded.locale,
+    updated_at = excluded.updated_at, version = agents.version + 1
+`
+
+type UpsertAgentParams struct {
+	ID                          string
+	Name                        string
+	Description                 string
+	SystemPrompt                string
+	EnabledTools                string
+	EnabledNotificationChannels string
+	EnabledFilesystemRoots      string
+	Model                       string
+	ForwardedHostEnvVars        string
+	Hooks                       string
+	MaxConcurrentRuns           int64
+	TitleGenerationDisabled     int64
+	TitlePrompt                 string
+	TitleModel                  string
+	PersonaRole                 string
+	PersonaGoals                string
+	PersonaConstraints          string
+	PersonaStyle                string
+	ApprovalRules               string
+	Locale                      string
+	CreatedAt                   string
+	UpdatedAt                   string
+}
+
+func (q *Queries) UpsertAgent(ctx context.Context, arg UpsertAgentParams) error {
+	_, err := q.db.ExecContext(ctx, upsertAgent,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.SystemPrompt,
+		arg.EnabledTools,
+		arg.EnabledNotificationChannels,
+		arg.EnabledFilesystemRoots,
+		arg.Model,
+		arg.ForwardedHostEnvVars,
+		arg.Hooks,
+		arg.MaxConcurrentRuns,
+		arg.TitleGenerationDisabled,
+		arg.TitlePrompt,
+		arg.TitleModel,
+		arg.PersonaRole,
+		arg.PersonaGoals,
+		arg.PersonaConstraints,
+		arg.PersonaStyle,
+		arg.ApprovalRules,
+		arg.Locale,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
 const upsertAgentFile = `-- name: UpsertAgentFile :one
 
 INSERT INTO agent_files (agent_id, path, content, created_at, updated_at)
@@ -1211,3 +3976,226 @@ func (q *Queries) UpsertAgentFile(ctx context.Context, arg UpsertAgentFileParams
 	)
 	return i, err
 }
+
+const upsertConversationKV = `-- name: UpsertConversationKV :exec
+
+INSERT INTO conversation_kv (conversation_id, key, value, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (conversation_id, key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+`
+
+type UpsertConversationKVParams struct {
+	ConversationID string
+	Key            string
+	Value          string
+	CreatedAt      string
+	UpdatedAt      string
+}
+
+// Conversation Context
+func (q *Queries) UpsertConversationKV(ctx context.Context, arg UpsertConversationKVParams) error {
+	_, err := q.db.ExecContext(ctx, upsertConversationKV,
+		arg.ConversationID,
+		arg.Key,
+		arg.Value,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertFilesystemRoot = `-- name: UpsertFilesystemRoot :exec
+INSERT INTO filesystem_roots (id, name, path, description, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    name = excluded.name, path = excluded.path, description = excluded.description,
+    updated_at = excluded.updated_at, version = filesystem_roots.version + 1
+`
+
+type UpsertFilesystemRootParams struct {
+	ID          string
+	Name        string
+	Path        string
+	Description string
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+func (q *Queries) UpsertFilesystemRoot(ctx context.Context, arg UpsertFilesystemRootParams) error {
+	_, err := q.db.ExecContext(ctx, upsertFilesystemRoot,
+		arg.ID,
+		arg.Name,
+		arg.Path,
+		arg.Description,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertNotificationChannel = `-- name: UpsertNotificationChannel :exec
+INSERT INTO notification_channels (id, name, type, config, description, json_schema, quiet_hours_start, quiet_hours_end, quiet_hours_timezone, quiet_hours_mode, max_per_hour, digest_schedule, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    name = excluded.name, type = excluded.type, config = excluded.config, description = excluded.description,
+    json_schema = excluded.json_schema, quiet_hours_start = excluded.quiet_hours_start,
+    quiet_hours_end = excluded.quiet_hours_end, quiet_hours_timezone = excluded.quiet_hours_timezone,
+    quiet_hours_mode = excluded.quiet_hours_mode, max_per_hour = excluded.max_per_hour,
+    digest_schedule = excluded.digest_schedule, updated_at = excluded.updated_at,
+    version = notification_channels.version + 1
+`
+
+type UpsertNotificationChannelParams struct {
+	ID                 string
+	Name               string
+	Type               string
+	Config             string
+	Description        string
+	JsonSchema         string
+	QuietHoursStart    string
+	QuietHoursEnd      string
+	QuietHoursTimezone string
+	QuietHoursMode     string
+	MaxPerHour         int64
+	DigestSchedule     string
+	CreatedAt          string
+	UpdatedAt          string
+}
+
+func (q *Queries) UpsertNotificationChannel(ctx context.Context, arg UpsertNotificationChannelParams) error {
+	_, err := q.db.ExecContext(ctx, upsertNotificationChannel,
+		arg.ID,
+		arg.Name,
+		arg.Type,
+		arg.Config,
+		arg.Description,
+		arg.JsonSchema,
+		arg.QuietHoursStart,
+		arg.QuietHoursEnd,
+		arg.QuietHoursTimezone,
+		arg.QuietHoursMode,
+		arg.MaxPerHour,
+		arg.DigestSchedule,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertSetting = `-- name: UpsertSetting :exec
+INSERT INTO settings (key, value, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT (key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+`
+
+type UpsertSettingParams struct {
+	Key       string
+	Value     string
+	UpdatedAt string
+}
+
+func (q *Queries) UpsertSetting(ctx context.Context, arg UpsertSettingParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSetting, arg.Key, arg.Value, arg.UpdatedAt)
+	return err
+}
+
+const upsertTelegramChat = `-- name: UpsertTelegramChat :exec
+
+INSERT INTO telegram_chats (channel_id, chat_id, conversation_id, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (channel_id, chat_id) DO UPDATE SET conversation_id = excluded.conversation_id, created_at = excluded.created_at
+`
+
+type UpsertTelegramChatParams struct {
+	ChannelID      string
+	ChatID         string
+	ConversationID string
+	CreatedAt      string
+}
+
+// Telegram Chats
+func (q *Queries) UpsertTelegramChat(ctx context.Context, arg UpsertTelegramChatParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTelegramChat,
+		arg.ChannelID,
+		arg.ChatID,
+		arg.ConversationID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const upsertTrigger = `-- name: UpsertTrigger :exec
+INSERT INTO triggers (id, agent_id, name, prompt, cron_expr, enabled, next_run_at, model, conversation_title, max_duration_seconds, priority, vars, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    agent_id = excluded.agent_id, name = excluded.name, prompt = excluded.prompt, cron_expr = excluded.cron_expr,
+    enabled = excluded.enabled, next_run_at = excluded.next_run_at, model = excluded.model,
+    conversation_title = excluded.conversation_title, max_duration_seconds = excluded.max_duration_seconds, priority = excluded.priority, vars = excluded.vars,
+    updated_at = excluded.updated_at, version = triggers.version + 1
+`
+
+type UpsertTriggerParams struct {
+	ID                 string
+	AgentID            string
+	Name               string
+	Prompt             string
+	CronExpr           sql.NullString
+	Enabled            int64
+	NextRunAt          sql.NullString
+	Model              string
+	ConversationTitle  string
+	MaxDurationSeconds int64
+	Priority           int64
+	Vars               string
+	CreatedAt          string
+	UpdatedAt          string
+}
+
+func (q *Queries) UpsertTrigger(ctx context.Context, arg UpsertTriggerParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTrigger,
+		arg.ID,
+		arg.AgentID,
+		arg.Name,
+		arg.Prompt,
+		arg.CronExpr,
+		arg.Enabled,
+		arg.NextRunAt,
+		arg.Model,
+		arg.ConversationTitle,
+		arg.MaxDurationSeconds,
+		arg.Priority,
+		arg.Vars,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertWebPushSubscription = `-- name: UpsertWebPushSubscription :exec
+
+INSERT INTO web_push_subscriptions (id, endpoint, p256dh, auth, created_at, agent_ids)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (endpoint) DO UPDATE SET p256dh = excluded.p256dh, auth = excluded.auth, agent_ids = excluded.agent_ids
+`
+
+type UpsertWebPushSubscriptionParams struct {
+	ID        string
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	CreatedAt string
+	AgentIds  string
+}
+
+// Web Push Subscriptions
+func (q *Queries) UpsertWebPushSubscription(ctx context.Context, arg UpsertWebPushSubscriptionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertWebPushSubscription,
+		arg.ID,
+		arg.Endpoint,
+		arg.P256dh,
+		arg.Auth,
+		arg.CreatedAt,
+		arg.AgentIds,
+	)
+	return err
+}
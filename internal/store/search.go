@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Snippet highlight markers: matched terms in SearchMatch.Snippet are
+// enclosed by HighlightStart and HighlightEnd.
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+// SearchConversationsParams filters a conversation search. Empty fields
+// don't filter.
+type SearchConversationsParams struct {
+	// Query holds words, which must all match, "quoted phrases" and prefix*
+	// words.
+	Query   string
+	AgentID string
+	// UpdatedSince and UpdatedBefore limit the conversations by their
+	// updated_at time, as RFC 3339 strings.
+	UpdatedSince  string
+	UpdatedBefore string
+	// Limit is the maximum number of conversations; 0 returns all.
+	Limit int
+	// SnippetsPerConversation is the maximum number of matches returned per
+	// conversation.
+	SnippetsPerConversation int
+}
+
+// SearchMatch is a title or message that matched a search.
+type SearchMatch struct {
+	ConversationID string
+	// MessageID is empty if the conversation title matched.
+	MessageID string
+	Snippet   string
+	// Rank is the BM25 relevance of the best match of the conversation;
+	// lower is more relevant.
+	Rank float64
+}
+
+// SearchConversations returns the titles and messages matching a full-text
+// query, grouped by conversation and ordered by the relevance of their best
+// match. Matches of a conversation are ordered by relevance.
+func (q *Queries) SearchConversations(ctx context.Context, arg SearchConversationsParams) ([]SearchMatch, error) {
+	query := ftsQuery(arg.Query)
+	if query == "" {
+		return nil, nil
+	}
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	snippets := max(arg.SnippetsPerConversation, 1)
+
+	rows, err := q.db.QueryContext(ctx, `
+		WITH matches AS (
+			SELECT d.conversation_id, d.message_id,
+				snippet(search_index, 0, ?, ?, '…', 16) AS snippet,
+				bm25(search_index) AS rank
+			FROM search_index
+			JOIN search_documents AS d ON d.id = search_index.rowid
+			JOIN conversations AS c ON c.id = d.conversation_id
+			WHERE search_index MATCH ?
+				AND (? = '' OR c.agent_id = ?)
+				AND (? = '' OR c.updated_at >= ?)
+				AND (? = '' OR c.updated_at < ?)
+		), ranked AS (
+			SELECT *,
+				MIN(rank) OVER (PARTITION BY conversation_id) AS best,
+				ROW_NUMBER() OVER (PARTITION BY conversation_id ORDER BY rank) AS n
+			FROM matches
+		), top AS (
+			SELECT conversation_id, best FROM ranked WHERE n = 1
+			ORDER BY best, conversation_id
+			LIMIT ?
+		)
+		SELECT r.conversation_id, r.message_id, r.snippet, r.best
+		FROM ranked AS r
+		JOIN top AS t ON t.conversation_id = r.conversation_id
+		WHERE r.n <= ?
+		ORDER BY r.best, r.conversation_id, r.n
+	`, HighlightStart, HighlightEnd, query,
+		arg.AgentID, arg.AgentID,
+		arg.UpdatedSince, arg.UpdatedSince,
+		arg.UpdatedBefore, arg.UpdatedBefore,
+		limit, snippets)
+	if err != nil {
+		return nil, fmt.Errorf("search conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []SearchMatch
+	for rows.Next() {
+		var m SearchMatch
+		if err := rows.Scan(&m.ConversationID, &m.MessageID, &m.Snippet, &m.Rank); err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// ftsQuery turns a search query into an FTS5 query that matches all its
+// words and "quoted phrases", quoting them so punctuation isn't parsed as
+// query syntax. Words ending in * match by prefix.
+func ftsQuery(s string) string {
+	var terms []string
+	for i, part := range strings.Split(s, `"`) {
+		if i%2 == 1 {
+			// Quoted phrase.
+			if words := strings.Fields(part); len(words) > 0 {
+				terms = append(terms, quoteFTS(strings.Join(words, " ")))
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			prefix := strings.HasSuffix(word, "*")
+			word = strings.TrimRight(word, "*")
+			if word == "" {
+				continue
+			}
+			term := quoteFTS(word)
+			if prefix {
+				term += "*"
+			}
+			terms = append(terms, term)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+func quoteFTS(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSearchConversations(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	q := New(db)
+
+	for _, id := range []string{"a1", "a2"} {
+		if _, err := q.CreateAgent(ctx, CreateAgentParams{ID: id, Name: id, EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", Hooks: "[]", CreatedAt: "2025-01-01T00:00:00Z", UpdatedAt: "2025-01-01T00:00:00Z"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conv := func(id, agentID, title, updatedAt string) {
+		t.Helper()
+		if _, err := q.CreateConversation(ctx, CreateConversationParams{ID: id, AgentID: agentID, Title: title, CreatedAt: updatedAt, UpdatedAt: updatedAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	msg := func(id, convID, items string) {
+		t.Helper()
+		if _, err := q.CreateMessage(ctx, CreateMessageParams{ID: id, ConversationID: convID, Role: "assistant", Items: items, Status: "completed", CreatedAt: "2025-01-01T00:00:00Z"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conv("c1", "a1", "Database migration plan", "2025-01-01T00:00:00Z")
+	conv("c2", "a1", "Weekly report", "2025-02-01T00:00:00Z")
+	conv("c3", "a2", "Chat", "2025-03-01T00:00:00Z")
+	msg("m1", "c2", `{"schema_version":1,"items":[{"type":"text","text":"Here is the migration plan for the users table."}]}`)
+	msg("m2", "c3", `[{"type":"tool_execution","name":"bash","input":"{}","result":"running migrations"}]`)
+	msg("m3", "c3", `not json`)
+
+	search := func(arg SearchConversationsParams) []SearchMatch {
+		t.Helper()
+		matches, err := q.SearchConversations(ctx, arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return matches
+	}
+	ids := func(matches []SearchMatch) string {
+		var s []string
+		for _, m := range matches {
+			s = append(s, m.ConversationID+"/"+m.MessageID)
+		}
+		return strings.Join(s, ",")
+	}
+
+	matches := search(SearchConversationsParams{Query: "migration plan"})
+	if got := ids(matches); got != "c1/,c2/m1" {
+		t.Errorf("matches = %s, want c1/,c2/m1", got)
+	}
+	if want := HighlightStart + "migration" + HighlightEnd + " " + HighlightStart + "plan" + HighlightEnd; !strings.Contains(matches[1].Snippet, want) {
+		t.Errorf("snippet = %q, want highlighted terms", matches[1].Snippet)
+	}
+
+	// Stemming matches "migrations", and the legacy items format is indexed.
+	if got := ids(search(SearchConversationsParams{Query: "migration", AgentID: "a2"})); got != "c3/m2" {
+		t.Errorf("agent filter: matches = %s", got)
+	}
+	if got := ids(search(SearchConversationsParams{Query: "migration", UpdatedSince: "2025-01-15T00:00:00Z", UpdatedBefore: "2025-02-15T00:00:00Z"})); got != "c2/m1" {
+		t.Errorf("date filter: matches = %s", got)
+	}
+	if got := ids(search(SearchConversationsParams{Query: `"plan for" user*`})); got != "c2/m1" {
+		t.Errorf("phrase and prefix: matches = %s", got)
+	}
+	if got := search(SearchConversationsParams{Query: "migration", Limit: 2, SnippetsPerConversation: 1}); len(got) != 2 || got[0].ConversationID == got[1].ConversationID || got[0].Rank > got[1].Rank {
+		t.Errorf("limit: matches = %+v, want 2 conversations by rank", got)
+	}
+	// Punctuation isn't query syntax.
+	search(SearchConversationsParams{Query: `users' table: (NOT) -`})
+
+	// Updates and deletes are reindexed.
+	if _, err := q.UpdateConversation(ctx, UpdateConversationParams{ID: "c1", Title: "Renamed", UpdatedAt: "2025-01-01T00:00:00Z"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.UpdateMessageItems(ctx, UpdateMessageItemsParams{ID: "m1", Items: `{"schema_version":1,"items":[]}`, Status: "completed"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.DeleteConversation(ctx, "c3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(search(SearchConversationsParams{Query: "migration"})); got != "" {
+		t.Errorf("after updates: matches = %s, want none", got)
+	}
+}
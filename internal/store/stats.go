@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TableStats describes the rows of a table.
+type TableStats struct {
+	Name     string
+	RowCount int64
+	// Oldest and newest created_at values, empty if the table has no
+	// created_at column or no rows.
+	OldestCreatedAt string
+	NewestCreatedAt string
+}
+
+// TableStats returns row counts and created_at ranges for all tables, sorted
+// by name. Internal tables are excluded.
+func (q *Queries) TableStats(ctx context.Context) ([]TableStats, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != '_migrations'
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := make([]TableStats, 0, len(names))
+	for _, name := range names {
+		s := TableStats{Name: name}
+		table := `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+
+		var hasCreatedAt bool
+		if err := q.db.QueryRowContext(ctx, "SELECT COUNT(*) > 0 FROM pragma_table_info(?) WHERE name = 'created_at'", name).Scan(&hasCreatedAt); err != nil {
+			return nil, fmt.Errorf("inspect table %s: %w", name, err)
+		}
+
+		if hasCreatedAt {
+			err = q.db.QueryRowContext(ctx, "SELECT COUNT(*), COALESCE(MIN(created_at), ''), COALESCE(MAX(created_at), '') FROM "+table).
+				Scan(&s.RowCount, &s.OldestCreatedAt, &s.NewestCreatedAt)
+		} else {
+			err = q.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&s.RowCount)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("count rows of %s: %w", name, err)
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, nil
+}
+
+// DatabaseSize returns the size of the main database file in bytes, excluding
+// the write-ahead log.
+func (q *Queries) DatabaseSize(ctx context.Context) (int64, error) {
+	var size int64
+	if err := q.db.QueryRowContext(ctx, "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size); err != nil {
+		return 0, fmt.Errorf("get database size: %w", err)
+	}
+	return size, nil
+}
+
+// TriggerStatus describes the schedule of an enabled trigger.
+type TriggerStatus struct {
+	Name string
+	// NextRunAt is empty if the trigger has no scheduled run.
+	NextRunAt string
+	// LastSuccessAt is when the last completed run finished, empty if none
+	// has.
+	LastSuccessAt string
+}
+
+// EnabledTriggerStatuses returns the statuses of all enabled triggers, sorted
+// by name.
+func (q *Queries) EnabledTriggerStatuses(ctx context.Context) ([]TriggerStatus, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT t.name, COALESCE(t.next_run_at, ''), COALESCE(MAX(r.finished_at), '')
+		FROM triggers t
+		LEFT JOIN trigger_runs r ON r.trigger_id = t.id AND r.status = 'completed'
+		WHERE t.enabled = 1
+		GROUP BY t.id
+		ORDER BY t.name, t.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list trigger statuses: %w", err)
+	}
+	defer rows.Close()
+	var statuses []TriggerStatus
+	for rows.Next() {
+		var s TriggerStatus
+		if err := rows.Scan(&s.Name, &s.NextRunAt, &s.LastSuccessAt); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, rows.Err()
+}
@@ -4,8 +4,9 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
-	"sort"
+	"net/url"
 	"strings"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
@@ -13,80 +14,59 @@ import (
 //go:embed migrations/*.sql
 var migrations embed.FS
 
-func Open(path string) (*sql.DB, error) {
-	// Enable foreign key constraints via DSN so it applies to all pooled
-	// connections (PRAGMA is per-connection in SQLite).
-	if strings.Contains(path, "?") {
-		path += "&_pragma=foreign_keys(1)"
-	} else {
-		path += "?_pragma=foreign_keys(1)"
-	}
+// pragmas are applied via the DSN so they apply to all pooled connections
+// (PRAGMA is per-connection in SQLite). WAL lets readers run concurrently with
+// a writer, and busy_timeout makes writers wait for the lock instead of
+// failing with "database is locked".
+var pragmas = []string{
+	"foreign_keys(1)",
+	"journal_mode(WAL)",
+	"busy_timeout(5000)",
+	"synchronous(NORMAL)",
+}
 
-	db, err := sql.Open("sqlite", path)
+// Open opens the database at path and migrates it to the latest schema
+// version.
+func Open(path string) (*sql.DB, error) {
+	db, err := OpenUnmigrated(path)
 	if err != nil {
-		return nil, fmt.Errorf("open database: %w", err)
-	}
-
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("ping database: %w", err)
+		return nil, err
 	}
 
-	if err := migrate(db); err != nil {
+	if err := Migrate(db, -1); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("run migrations: %w", err)
 	}
 
 	return db, nil
 }
 
-func migrate(db *sql.DB) error {
-	// Create migrations table if it doesn't exist
-	if _, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS _migrations (
-			name TEXT PRIMARY KEY,
-			applied_at TEXT NOT NULL DEFAULT (datetime('now'))
-		)
-	`); err != nil {
-		return fmt.Errorf("create migrations table: %w", err)
+// OpenUnmigrated opens the database at path without running migrations.
+func OpenUnmigrated(path string) (*sql.DB, error) {
+	params := url.Values{"_pragma": pragmas}
+	// Take the write lock when a transaction begins, so transactions that read
+	// before writing don't fail when another connection is writing.
+	params.Set("_txlock", "immediate")
+	if strings.Contains(path, "?") {
+		path += "&" + params.Encode()
+	} else {
+		path += "?" + params.Encode()
 	}
 
-	entries, err := migrations.ReadDir("migrations")
+	db, err := sql.Open("sqlite", path)
 	if err != nil {
-		return fmt.Errorf("read migrations dir: %w", err)
+		return nil, fmt.Errorf("open database: %w", err)
 	}
 
-	// Sort by name to ensure order
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Name() < entries[j].Name()
-	})
-
-	for _, entry := range entries {
-		name := entry.Name()
-
-		// Check if already applied
-		var exists bool
-		err := db.QueryRow("SELECT 1 FROM _migrations WHERE name = ?", name).Scan(&exists)
-		if err == nil {
-			continue // Already applied
-		}
-		if err != sql.ErrNoRows {
-			return fmt.Errorf("check migration %s: %w", name, err)
-		}
+	// SQLite allows a single writer; a small pool keeps readers concurrent
+	// without piling up connections waiting on the write lock.
+	db.SetMaxOpenConns(8)
+	db.SetMaxIdleConns(8)
+	db.SetConnMaxIdleTime(5 * time.Minute)
 
-		// Read and execute migration
-		content, err := migrations.ReadFile("migrations/" + name)
-		if err != nil {
-			return fmt.Errorf("read migration %s: %w", name, err)
-		}
-
-		if _, err := db.Exec(string(content)); err != nil {
-			return fmt.Errorf("execute migration %s: %w", name, err)
-		}
-
-		// Mark as applied
-		if _, err := db.Exec("INSERT INTO _migrations (name) VALUES (?)", name); err != nil {
-			return fmt.Errorf("record migration %s: %w", name, err)
-		}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("ping database: %w", err)
 	}
 
-	return nil
+	return db, nil
 }
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestOpenPragmas checks that the pragmas of the DSN apply to the pooled
+// connections of a file database.
+func TestOpenPragmas(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Hold a connection, so the queries below use another one.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for pragma, want := range map[string]string{
+		"journal_mode": "wal",
+		"foreign_keys": "1",
+		"busy_timeout": "5000",
+	} {
+		var got string
+		if err := db.QueryRowContext(ctx, "PRAGMA "+pragma).Scan(&got); err != nil {
+			t.Fatalf("PRAGMA %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("PRAGMA %s = %q, want %q", pragma, got, want)
+		}
+	}
+}
+
+// TestInTx checks that the writes of a transaction are rolled back together
+// when its callback fails, and committed when it succeeds.
+func TestInTx(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	q := New(db)
+
+	now := "2025-01-01T00:00:00Z"
+	if _, err := q.CreateAgent(ctx, CreateAgentParams{ID: "a1", Name: "a1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", Hooks: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.CreateConversation(ctx, CreateConversationParams{ID: "c1", AgentID: "a1", Title: "original", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	write := func(q *Queries, msgID, title string) error {
+		if _, err := q.CreateMessage(ctx, CreateMessageParams{ID: msgID, ConversationID: "c1", Role: "user", Items: "[]", Status: "complete", CreatedAt: now}); err != nil {
+			return err
+		}
+		_, err := q.UpdateConversation(ctx, UpdateConversationParams{ID: "c1", Title: title, UpdatedAt: now})
+		return err
+	}
+
+	errFailed := errors.New("failed")
+	err = q.InTx(ctx, func(q *Queries) error {
+		if err := write(q, "m1", "rolled back"); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("InTx = %v, want the callback's error", err)
+	}
+	msgs, err := q.GetMessagesByConversation(ctx, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err := q.GetConversation(ctx, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 0 || conv.Title != "original" {
+		t.Errorf("after rollback: %d messages, title %q", len(msgs), conv.Title)
+	}
+
+	if err := q.InTx(ctx, func(q *Queries) error { return write(q, "m2", "committed") }); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err = q.GetMessagesByConversation(ctx, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err = q.GetConversation(ctx, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].ID != "m2" || conv.Title != "committed" {
+		t.Errorf("after commit: %d messages, title %q", len(msgs), conv.Title)
+	}
+}
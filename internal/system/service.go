@@ -0,0 +1,692 @@
+package system
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/blob"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/listing"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/manifest"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/scheduler"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/usage"
+	"github.com/dstotijn/blippy/internal/version"
+)
+
+const (
+	// maxSchedulerLag is how far behind the scheduler may fall before it's
+	// reported as a problem.
+	maxSchedulerLag = time.Minute
+	// MaxMaintenanceWait caps how long UpdateMaintenanceMode waits for
+	// active turns. Its response deadline is set to outlast it.
+	MaxMaintenanceWait = 10 * time.Minute
+	// Defaults and limits of how long LLM captures last and how long their
+	// exchanges are kept.
+	defaultCaptureDuration  = time.Hour
+	maxCaptureDuration      = 24 * time.Hour
+	defaultCaptureRetention = 24 * time.Hour
+	maxCaptureRetention     = 7 * 24 * time.Hour
+)
+
+type Service struct {
+	db        *sql.DB
+	queries   *store.Queries
+	scheduler *scheduler.Scheduler
+	loop      *agentloop.Loop
+	maint     *maintenance.Mode
+	cipher    *encryption.Cipher
+	updates   *version.Checker
+	usage     *usage.Reporter
+	blobs     *blob.Store
+}
+
+// NewService creates a system service. The cipher is optional, as for
+// manifest.Export, and so are the update checker and the blob store, without
+// which manifests can't be downloaded.
+func NewService(db *sql.DB, sched *scheduler.Scheduler, loop *agentloop.Loop, maint *maintenance.Mode, cipher *encryption.Cipher, updates *version.Checker, blobs *blob.Store) *Service {
+	return &Service{
+		db:        db,
+		queries:   store.New(db),
+		scheduler: sched,
+		loop:      loop,
+		maint:     maint,
+		cipher:    cipher,
+		updates:   updates,
+		usage:     usage.NewReporter(store.New(db), loop.ORClient),
+		blobs:     blobs,
+	}
+}
+
+func (s *Service) GetSystemStats(ctx context.Context, req *connect.Request[GetSystemStatsRequest]) (*connect.Response[SystemStats], error) {
+	now := time.Now().UTC()
+	stats := &SystemStats{}
+
+	tables, err := s.queries.TableStats(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	for _, t := range tables {
+		stats.Tables = append(stats.Tables, &TableStats{
+			Name:            t.Name,
+			RowCount:        t.RowCount,
+			OldestCreatedAt: toTimestamp(t.OldestCreatedAt),
+			NewestCreatedAt: toTimestamp(t.NewestCreatedAt),
+		})
+	}
+
+	if stats.DatabaseSizeBytes, err = s.queries.DatabaseSize(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	version, err := store.SchemaVersion(s.db)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	latest, err := store.LatestSchemaVersion()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	stats.SchemaVersion, stats.LatestSchemaVersion = int32(version), int32(latest)
+	if stats.PendingMigrations, err = store.PendingMigrations(s.db); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if n := len(stats.PendingMigrations); n > 0 {
+		stats.Warnings = append(stats.Warnings, fmt.Sprintf("%d migration(s) pending; run \"blippy migrate\"", n))
+	}
+
+	if m := s.maint.Status(); m.Enabled {
+		stats.Warnings = append(stats.Warnings, "maintenance mode is enabled; the scheduler is paused")
+	}
+
+	if lastTick := s.scheduler.LastTick(); !lastTick.IsZero() {
+		stats.SchedulerLastTickAt = timestamppb.New(lastTick)
+		if since := now.Sub(lastTick); since > maxSchedulerLag {
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("scheduler hasn't ticked for %s", since.Round(time.Second)))
+		}
+	}
+
+	due, err := s.queries.GetDueTriggers(ctx, sql.NullString{String: now.Format(time.RFC3339), Valid: true})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	stats.DueTriggers = int32(len(due))
+	if len(due) > 0 {
+		// Due triggers are ordered by next run, oldest first.
+		if nextRun, err := time.Parse(time.RFC3339, due[0].NextRunAt.String); err == nil {
+			lag := now.Sub(nextRun)
+			stats.SchedulerLagSeconds = int64(lag.Seconds())
+			if lag > maxSchedulerLag {
+				stats.Warnings = append(stats.Warnings, fmt.Sprintf("trigger %q is %s overdue", due[0].Name, lag.Round(time.Second)))
+			}
+		}
+	}
+
+	return connect.NewResponse(stats), nil
+}
+
+func (s *Service) GetMaintenanceMode(ctx context.Context, req *connect.Request[GetMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error) {
+	return connect.NewResponse(s.maintenanceMode()), nil
+}
+
+func (s *Service) UpdateMaintenanceMode(ctx context.Context, req *connect.Request[UpdateMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error) {
+	if req.Msg.WaitSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("wait_seconds must not be negative"))
+	}
+
+	if !req.Msg.Enabled {
+		s.maint.Disable()
+		return connect.NewResponse(s.maintenanceMode()), nil
+	}
+
+	s.maint.Enable(req.Msg.Reason)
+
+	if req.Msg.WaitSeconds > 0 {
+		wait := min(time.Duration(req.Msg.WaitSeconds)*time.Second, MaxMaintenanceWait)
+		waitCtx, cancel := context.WithTimeout(ctx, wait)
+		defer cancel()
+		// Turns still active after the wait are reported in the response.
+		_ = s.loop.WaitIdle(waitCtx)
+	}
+
+	return connect.NewResponse(s.maintenanceMode()), nil
+}
+
+func (s *Service) maintenanceMode() *MaintenanceMode {
+	m := s.maint.Status()
+	res := &MaintenanceMode{
+		Enabled:     m.Enabled,
+		Reason:      m.Reason,
+		ActiveTurns: int32(s.loop.ActiveTurns()),
+	}
+	if m.Enabled {
+		res.Since = timestamppb.New(m.Since)
+	}
+	return res
+}
+
+func (s *Service) GetInstancePreamble(ctx context.Context, req *connect.Request[GetInstancePreambleRequest]) (*connect.Response[InstancePreamble], error) {
+	setting, err := s.queries.GetSettingEntry(ctx, agentloop.PreambleSetting)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return connect.NewResponse(&InstancePreamble{}), nil
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&InstancePreamble{
+		Text:      setting.Value,
+		UpdatedAt: toTimestamp(setting.UpdatedAt),
+	}), nil
+}
+
+func (s *Service) UpdateInstancePreamble(ctx context.Context, req *connect.Request[UpdateInstancePreambleRequest]) (*connect.Response[InstancePreamble], error) {
+	text := strings.TrimSpace(req.Msg.Text)
+	if len(text) > agentloop.MaxPreambleBytes {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("text must be at most %d bytes", agentloop.MaxPreambleBytes))
+	}
+	now := time.Now().UTC()
+	if err := s.queries.UpsertSetting(ctx, store.UpsertSettingParams{
+		Key:       agentloop.PreambleSetting,
+		Value:     text,
+		UpdatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&InstancePreamble{
+		Text:      text,
+		UpdatedAt: timestamppb.New(now),
+	}), nil
+}
+
+func toTimestamp(s string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func (s *Service) GetBrokerStats(ctx context.Context, req *connect.Request[GetBrokerStatsRequest]) (*connect.Response[BrokerStats], error) {
+	stats := s.loop.Broker.Stats()
+	res := &BrokerStats{
+		BusyConversationIds: stats.BusyConversations,
+		PublishedEvents:     stats.PublishedEvents,
+		RelayedEvents:       stats.RelayedEvents,
+		DroppedEvents:       stats.DroppedEvents,
+	}
+	for _, sub := range stats.Subscriptions {
+		res.Subscriptions = append(res.Subscriptions, &SubscriptionStats{
+			Topics:    sub.Patterns,
+			CreatedAt: timestamppb.New(sub.CreatedAt),
+			Buffered:  int32(sub.Buffered),
+			Capacity:  int32(sub.Capacity),
+			Dropped:   sub.Dropped,
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *Service) ListActiveRuns(ctx context.Context, req *connect.Request[ListActiveRunsRequest]) (*connect.Response[ListActiveRunsResponse], error) {
+	res := &ListActiveRunsResponse{}
+	for _, run := range s.loop.ActiveRuns() {
+		if req.Msg.AgentId != "" && run.AgentID != req.Msg.AgentId {
+			continue
+		}
+		res.Runs = append(res.Runs, &ActiveRun{
+			Id:             run.ID,
+			ConversationId: run.ConversationID,
+			AgentId:        run.AgentID,
+			AgentName:      run.AgentName,
+			Kind:           run.Kind,
+			Depth:          int32(run.Depth),
+			StartedAt:      timestamppb.New(run.StartedAt),
+			Priority:       int32(run.Priority),
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *Service) CancelRun(ctx context.Context, req *connect.Request[CancelRunRequest]) (*connect.Response[CancelRunResponse], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+	if err := s.loop.CancelRun(req.Msg.Id); err != nil {
+		if errors.Is(err, agentloop.ErrRunNotFound) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_RUN_NOT_FOUND, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&CancelRunResponse{}), nil
+}
+
+func (s *Service) ListPendingApprovals(ctx context.Context, req *connect.Request[ListPendingApprovalsRequest]) (*connect.Response[ListPendingApprovalsResponse], error) {
+	res := &ListPendingApprovalsResponse{}
+	for _, a := range s.loop.PendingApprovals() {
+		if req.Msg.AgentId != "" && a.AgentID != req.Msg.AgentId {
+			continue
+		}
+		res.Approvals = append(res.Approvals, &PendingApproval{
+			Id:             a.ID,
+			RunId:          a.RunID,
+			ConversationId: a.ConversationID,
+			AgentId:        a.AgentID,
+			AgentName:      a.AgentName,
+			ToolName:       a.ToolName,
+			Input:          a.Input,
+			Reason:         a.Reason,
+			RequestedAt:    timestamppb.New(a.RequestedAt),
+			ExpiresAt:      timestamppb.New(a.ExpiresAt),
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *Service) ResolveApproval(ctx context.Context, req *connect.Request[ResolveApprovalRequest]) (*connect.Response[ResolveApprovalResponse], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+	if err := s.loop.ResolveApproval(req.Msg.Id, req.Msg.Approved, req.Msg.Reason); err != nil {
+		if errors.Is(err, agentloop.ErrApprovalNotFound) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_APPROVAL_NOT_FOUND, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&ResolveApprovalResponse{}), nil
+}
+
+func (s *Service) ReplayTurn(ctx context.Context, req *connect.Request[ReplayTurnRequest]) (*connect.Response[ReplayTurnResponse], error) {
+	if req.Msg.RunId == "" && req.Msg.ConversationId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("run_id or conversation_id is required"))
+	}
+	result, err := s.loop.ReplayTurn(ctx, req.Msg.RunId, req.Msg.ConversationId)
+	if result.ConversationID == "" {
+		if errors.Is(err, agentloop.ErrRecordingNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &ReplayTurnResponse{
+		ConversationId: result.ConversationID,
+		Response:       result.Response,
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Service) GetRunTrace(ctx context.Context, req *connect.Request[GetRunTraceRequest]) (*connect.Response[RunTrace], error) {
+	var row store.RunTrace
+	var err error
+	switch {
+	case req.Msg.RunId != "":
+		row, err = s.queries.GetRunTrace(ctx, req.Msg.RunId)
+	case req.Msg.ConversationId != "":
+		row, err = s.queries.GetLatestRunTrace(ctx, req.Msg.ConversationId)
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("run_id or conversation_id is required"))
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("run trace not found"))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var trace agentloop.Trace
+	if err := json.Unmarshal([]byte(row.Trace), &trace); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("decode trace: %w", err))
+	}
+	res := &RunTrace{
+		RunId:          row.RunID,
+		AgentId:        row.AgentID,
+		ConversationId: row.ConversationID,
+		Kind:           row.Kind,
+		Model:          row.Model,
+		Status:         row.Status,
+		Error:          row.Error,
+		InputTokens:    row.InputTokens,
+		OutputTokens:   row.OutputTokens,
+		StartedAt:      toTimestamp(row.StartedAt),
+		FinishedAt:     toTimestamp(row.FinishedAt),
+		QueuedMs:       trace.QueuedMS,
+	}
+	for _, r := range trace.Rounds {
+		round := &TraceRound{
+			StartedAt:    timestamppb.New(r.StartedAt),
+			RequestBytes: int64(r.RequestBytes),
+			FirstEventMs: r.FirstEventMS,
+			LatencyMs:    r.LatencyMS,
+			InputTokens:  r.InputTokens,
+			OutputTokens: r.OutputTokens,
+			Error:        r.Error,
+		}
+		for _, c := range r.ToolCalls {
+			round.ToolCalls = append(round.ToolCalls, &TraceToolCall{
+				Name:        c.Name,
+				CallId:      c.CallID,
+				DurationMs:  c.DurationMS,
+				ResultBytes: int64(c.ResultBytes),
+				Error:       c.Error,
+			})
+		}
+		res.Rounds = append(res.Rounds, round)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *Service) ExportManifest(ctx context.Context, req *connect.Request[ExportManifestRequest]) (*connect.Response[ExportManifestResponse], error) {
+	m, err := manifest.Export(ctx, s.queries, s.cipher)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	data := append(b, '\n')
+
+	if !req.Msg.Download {
+		return connect.NewResponse(&ExportManifestResponse{Manifest: string(data)}), nil
+	}
+	if s.blobs == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blob storage is not configured"))
+	}
+	export, err := s.blobs.Put(ctx, blob.PutParams{
+		Kind:        blob.KindExport,
+		Name:        "blippy-manifest.json",
+		ContentType: "application/json",
+		Data:        data,
+		TTL:         24 * time.Hour,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&ExportManifestResponse{DownloadUrl: s.blobs.SignedURL(export.ID, time.Hour)}), nil
+}
+
+func (s *Service) GetVersion(ctx context.Context, req *connect.Request[GetVersionRequest]) (*connect.Response[Version], error) {
+	v := &Version{Version: version.Get()}
+	if s.updates != nil {
+		v.UpdateCheckEnabled = true
+		if latest := s.updates.Latest(); latest.Version != "" {
+			v.LatestVersion = latest.Version
+			v.ReleaseUrl = latest.URL
+			v.CheckedAt = timestamppb.New(latest.CheckedAt)
+			v.UpdateAvailable = s.updates.UpdateAvailable()
+		}
+	}
+	return connect.NewResponse(v), nil
+}
+
+func (s *Service) GetUsageReport(ctx context.Context, req *connect.Request[GetUsageReportRequest]) (*connect.Response[UsageReport], error) {
+	if req.Msg.PeriodHours < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("period_hours must not be negative"))
+	}
+	report, err := s.usage.Report(ctx, time.Duration(req.Msg.PeriodHours)*time.Hour)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := &UsageReport{
+		Since: timestamppb.New(report.Since),
+		Until: timestamppb.New(report.Until),
+		Total: toProtoUsage(report.Total),
+	}
+	for _, a := range report.Agents {
+		res.Agents = append(res.Agents, &AgentUsage{
+			AgentId:   a.AgentID,
+			AgentName: a.AgentName,
+			Usage:     toProtoUsage(a.Usage),
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func toProtoUsage(u usage.Usage) *Usage {
+	return &Usage{
+		Runs:         u.Runs,
+		FailedRuns:   u.FailedRuns,
+		InputTokens:  u.InputTokens,
+		OutputTokens: u.OutputTokens,
+		CostUsd:      u.CostUSD,
+		Priced:       u.Priced,
+	}
+}
+
+func (s *Service) ListProviderKeys(ctx context.Context, req *connect.Request[ListProviderKeysRequest]) (*connect.Response[ListProviderKeysResponse], error) {
+	res := &ListProviderKeysResponse{}
+	for _, k := range s.loop.ORClient.KeyStats() {
+		res.Keys = append(res.Keys, toProtoProviderKey(k))
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *Service) CreateProviderKey(ctx context.Context, req *connect.Request[CreateProviderKeyRequest]) (*connect.Response[ProviderKey], error) {
+	apiKey := strings.TrimSpace(req.Msg.ApiKey)
+	if apiKey == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("api_key is required"))
+	}
+	if req.Msg.Weight < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("weight must not be negative"))
+	}
+	if _, err := s.loop.ORClient.CheckNewKey(ctx, apiKey); err != nil {
+		if errors.Is(err, openrouter.ErrInvalidAPIKey) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("check API key: %w", err))
+	}
+
+	k := s.loop.ORClient.AddKey(openrouter.Key{Secret: apiKey, Weight: int(req.Msg.Weight)})
+	return connect.NewResponse(toProtoProviderKey(k)), nil
+}
+
+func (s *Service) DeleteProviderKey(ctx context.Context, req *connect.Request[DeleteProviderKeyRequest]) (*connect.Response[DeleteProviderKeyResponse], error) {
+	if err := s.loop.ORClient.RemoveKey(req.Msg.Id); err != nil {
+		switch {
+		case errors.Is(err, openrouter.ErrKeyNotFound):
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		case errors.Is(err, openrouter.ErrLastKey):
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&DeleteProviderKeyResponse{}), nil
+}
+
+func toProtoProviderKey(k openrouter.KeyStats) *ProviderKey {
+	pk := &ProviderKey{
+		Id:           k.ID,
+		Hint:         k.Hint,
+		Weight:       int32(k.Weight),
+		Requests:     k.Requests,
+		Failures:     k.Failures,
+		RateLimited:  k.RateLimited,
+		InputTokens:  k.InputTokens,
+		OutputTokens: k.OutputTokens,
+		AddedAt:      timestamppb.New(k.AddedAt),
+	}
+	if !k.LastUsedAt.IsZero() {
+		pk.LastUsedAt = timestamppb.New(k.LastUsedAt)
+	}
+	return pk
+}
+
+func (s *Service) CreateLLMCapture(ctx context.Context, req *connect.Request[CreateLLMCaptureRequest]) (*connect.Response[LLMCapture], error) {
+	var agentID, convID sql.NullString
+	switch {
+	case req.Msg.AgentId != "" && req.Msg.ConversationId != "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("only one of agent_id and conversation_id may be set"))
+	case req.Msg.AgentId != "":
+		if _, err := s.queries.GetAgent(ctx, req.Msg.AgentId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		agentID = sql.NullString{String: req.Msg.AgentId, Valid: true}
+	case req.Msg.ConversationId != "":
+		if _, err := s.queries.GetConversation(ctx, req.Msg.ConversationId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND, errors.New("conversation not found"))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		convID = sql.NullString{String: req.Msg.ConversationId, Valid: true}
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id or conversation_id is required"))
+	}
+
+	duration, err := captureDuration(req.Msg.DurationSeconds, defaultCaptureDuration, maxCaptureDuration)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("duration_seconds %w", err))
+	}
+	retention, err := captureDuration(req.Msg.RetentionSeconds, defaultCaptureRetention, maxCaptureRetention)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("retention_seconds %w", err))
+	}
+
+	now := time.Now().UTC()
+	capture, err := s.queries.CreateLLMCapture(ctx, store.CreateLLMCaptureParams{
+		ID:               uuid.NewString(),
+		AgentID:          agentID,
+		ConversationID:   convID,
+		RetentionSeconds: int64(retention.Seconds()),
+		ExpiresAt:        now.Add(duration).Format(time.RFC3339),
+		CreatedAt:        now.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(toProtoLLMCapture(capture)), nil
+}
+
+// captureDuration returns a duration in seconds, or def if it's 0.
+func captureDuration(seconds int64, def, limit time.Duration) (time.Duration, error) {
+	switch {
+	case seconds == 0:
+		return def, nil
+	case seconds < 0:
+		return 0, errors.New("must not be negative")
+	case seconds > int64(limit.Seconds()):
+		return 0, fmt.Errorf("must be at most %d", int64(limit.Seconds()))
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (s *Service) ListLLMCaptures(ctx context.Context, req *connect.Request[ListLLMCapturesRequest]) (*connect.Response[ListLLMCapturesResponse], error) {
+	captures, err := s.queries.ListLLMCaptures(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	res := &ListLLMCapturesResponse{}
+	for _, c := range captures {
+		// Expired captures are kept until the next prune.
+		if c.ExpiresAt > now {
+			res.Captures = append(res.Captures, toProtoLLMCapture(c))
+		}
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *Service) DeleteLLMCapture(ctx context.Context, req *connect.Request[DeleteLLMCaptureRequest]) (*connect.Response[DeleteLLMCaptureResponse], error) {
+	n, err := s.queries.DeleteLLMCapture(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if n == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("LLM capture not found"))
+	}
+	return connect.NewResponse(&DeleteLLMCaptureResponse{}), nil
+}
+
+func toProtoLLMCapture(c store.LlmCapture) *LLMCapture {
+	return &LLMCapture{
+		Id:               c.ID,
+		AgentId:          c.AgentID.String,
+		ConversationId:   c.ConversationID.String,
+		RetentionSeconds: c.RetentionSeconds,
+		ExpiresAt:        toTimestamp(c.ExpiresAt),
+		CreatedAt:        toTimestamp(c.CreatedAt),
+	}
+}
+
+func (s *Service) ListLLMExchanges(ctx context.Context, req *connect.Request[ListLLMExchangesRequest]) (*connect.Response[ListLLMExchangesResponse], error) {
+	listReq := listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+	}
+	switch {
+	case req.Msg.RunId != "":
+		listReq.Match = map[string]string{"run_id": req.Msg.RunId}
+	case req.Msg.ConversationId != "":
+		listReq.Match = map[string]string{"conversation_id": req.Msg.ConversationId}
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("run_id or conversation_id is required"))
+	}
+	query, err := listing.Parse(listReq, exchangeTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListLLMExchangesPage, s.queries.CountLLMExchangesPage)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := &ListLLMExchangesResponse{
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}
+	for _, e := range page.Items {
+		var headers map[string]string
+		_ = json.Unmarshal([]byte(e.ResponseHeaders), &headers)
+		res.Exchanges = append(res.Exchanges, &LLMExchange{
+			Id:              e.ID,
+			CaptureId:       e.CaptureID,
+			RunId:           e.RunID,
+			ConversationId:  e.ConversationID,
+			AgentId:         e.AgentID,
+			Round:           int32(e.Round),
+			Url:             e.Url,
+			KeyId:           e.KeyID,
+			RequestBody:     e.RequestBody,
+			StatusCode:      int32(e.StatusCode),
+			ResponseHeaders: headers,
+			ResponseBody:    e.ResponseBody,
+			Truncated:       e.Truncated != 0,
+			Error:           e.Error,
+			CreatedAt:       toTimestamp(e.CreatedAt),
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+// exchangeTable lists LLM exchanges of a run or conversation in the order
+// they were made.
+var exchangeTable = listing.Table[store.LlmExchange]{
+	Fields: listing.Fields[store.LlmExchange]{
+		"id":              {Column: "id", Value: func(e store.LlmExchange) string { return e.ID }},
+		"run_id":          {Column: "run_id", Value: func(e store.LlmExchange) string { return e.RunID }},
+		"conversation_id": {Column: "conversation_id", Value: func(e store.LlmExchange) string { return e.ConversationID }},
+		"round":           {Column: "round", Value: func(e store.LlmExchange) string { return strconv.FormatInt(e.Round, 10) }},
+		"created_at":      {Column: "created_at", Value: func(e store.LlmExchange) string { return e.CreatedAt }},
+	},
+	Order: "created_at, round",
+}
@@ -0,0 +1,280 @@
+package system
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/scheduler"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/version"
+)
+
+func TestGetSystemStats(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{
+		ID: "agent-1", Name: "Ops", EnabledTools: "[]", EnabledNotificationChannels: "[]",
+		EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]",
+		CreatedAt: "2025-01-01T00:00:00Z", UpdatedAt: "2025-01-01T00:00:00Z",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// A trigger that should have run ten minutes ago.
+	if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{
+		ID: "trigger-1", AgentID: "agent-1", Name: "daily", Prompt: "Report", Enabled: 1,
+		NextRunAt: store.NewNullString("2025-01-01T00:00:00Z"),
+		CreatedAt: "2025-01-01T00:00:00Z", UpdatedAt: "2025-01-01T00:00:00Z",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewService(db, scheduler.New(db, queries, nil, nil, slog.Default()), &agentloop.Loop{}, &maintenance.Mode{}, nil, nil, nil)
+	res, err := svc.GetSystemStats(ctx, connect.NewRequest(&GetSystemStatsRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := res.Msg
+
+	var agents *TableStats
+	for _, table := range stats.Tables {
+		if table.Name == "agents" {
+			agents = table
+		}
+	}
+	if agents == nil || agents.RowCount != 1 || agents.OldestCreatedAt.AsTime().Year() != 2025 {
+		t.Errorf("agents stats = %v, want 1 row created in 2025", agents)
+	}
+	if stats.DatabaseSizeBytes <= 0 {
+		t.Errorf("database size = %d, want > 0", stats.DatabaseSizeBytes)
+	}
+	if len(stats.PendingMigrations) != 0 || stats.SchemaVersion != stats.LatestSchemaVersion {
+		t.Errorf("schema version %d of %d, pending %v; want up to date", stats.SchemaVersion, stats.LatestSchemaVersion, stats.PendingMigrations)
+	}
+	if stats.DueTriggers != 1 || stats.SchedulerLagSeconds <= 0 || len(stats.Warnings) != 1 {
+		t.Errorf("due triggers = %d, lag = %ds, warnings = %v; want 1 overdue trigger", stats.DueTriggers, stats.SchedulerLagSeconds, stats.Warnings)
+	}
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	maint := &maintenance.Mode{}
+	svc := NewService(db, scheduler.New(db, store.New(db), nil, maint, slog.Default()), &agentloop.Loop{}, maint, nil, nil, nil)
+
+	res, err := svc.UpdateMaintenanceMode(ctx, connect.NewRequest(&UpdateMaintenanceModeRequest{
+		Enabled:     true,
+		Reason:      "upgrading",
+		WaitSeconds: 1,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Msg.Enabled || res.Msg.Reason != "upgrading" || res.Msg.Since == nil || res.Msg.ActiveTurns != 0 {
+		t.Errorf("maintenance mode = %v, want enabled without active turns", res.Msg)
+	}
+	if err := maint.Err(); err == nil || !strings.Contains(err.Error(), "upgrading") {
+		t.Errorf("Err = %v, want maintenance error with reason", err)
+	}
+
+	stats, err := svc.GetSystemStats(ctx, connect.NewRequest(&GetSystemStatsRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Msg.Warnings) != 1 || !strings.Contains(stats.Msg.Warnings[0], "maintenance") {
+		t.Errorf("warnings = %v, want maintenance warning", stats.Msg.Warnings)
+	}
+
+	if _, err := svc.UpdateMaintenanceMode(ctx, connect.NewRequest(&UpdateMaintenanceModeRequest{})); err != nil {
+		t.Fatal(err)
+	}
+	get, err := svc.GetMaintenanceMode(ctx, connect.NewRequest(&GetMaintenanceModeRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Msg.Enabled || maint.Err() != nil {
+		t.Errorf("maintenance mode still enabled after disabling: %v", get.Msg)
+	}
+}
+
+func TestGetBrokerStats(t *testing.T) {
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	broker := pubsub.New(nil, slog.Default())
+	sub := broker.Subscribe("conv-1")
+	defer broker.Unsubscribe(sub)
+	broker.SetBusy("conv-1")
+	broker.Publish("conv-1", &pubsub.Event_TurnStarted{TurnStarted: &pubsub.TurnStarted{}})
+
+	svc := NewService(db, scheduler.New(db, store.New(db), nil, nil, slog.Default()), &agentloop.Loop{Broker: broker}, &maintenance.Mode{}, nil, nil, nil)
+	res, err := svc.GetBrokerStats(context.Background(), connect.NewRequest(&GetBrokerStatsRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := res.Msg
+	if len(stats.Subscriptions) != 1 || stats.Subscriptions[0].Topics[0] != "conversation:conv-1" || stats.Subscriptions[0].Buffered != 1 {
+		t.Errorf("subscriptions = %v, want conv-1 with 1 buffered event", stats.Subscriptions)
+	}
+	if len(stats.BusyConversationIds) != 1 || stats.PublishedEvents != 1 {
+		t.Errorf("busy = %v, published = %d; want conv-1 and 1", stats.BusyConversationIds, stats.PublishedEvents)
+	}
+}
+
+func TestActiveRuns(t *testing.T) {
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	svc := NewService(db, scheduler.New(db, store.New(db), nil, nil, slog.Default()), &agentloop.Loop{}, &maintenance.Mode{}, nil, nil, nil)
+	res, err := svc.ListActiveRuns(context.Background(), connect.NewRequest(&ListActiveRunsRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Msg.Runs) != 0 {
+		t.Errorf("runs = %v, want none", res.Msg.Runs)
+	}
+
+	_, err = svc.CancelRun(context.Background(), connect.NewRequest(&CancelRunRequest{Id: "unknown"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("CancelRun of unknown run: got %v, want NotFound", err)
+	}
+	_, err = svc.CancelRun(context.Background(), connect.NewRequest(&CancelRunRequest{}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("CancelRun without ID: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestReplayTurn(t *testing.T) {
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	svc := NewService(db, scheduler.New(db, queries, nil, nil, slog.Default()), &agentloop.Loop{Queries: queries}, &maintenance.Mode{}, nil, nil, nil)
+	_, err = svc.ReplayTurn(context.Background(), connect.NewRequest(&ReplayTurnRequest{RunId: "unknown"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("ReplayTurn of unrecorded run: got %v, want NotFound", err)
+	}
+	_, err = svc.ReplayTurn(context.Background(), connect.NewRequest(&ReplayTurnRequest{}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("ReplayTurn without run or conversation: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v1.1.0", "html_url": "https://github.com/dstotijn/blippy/releases/tag/v1.1.0"}`))
+	}))
+	defer srv.Close()
+
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	sched := scheduler.New(db, store.New(db), nil, nil, slog.Default())
+
+	svc := NewService(db, sched, &agentloop.Loop{}, &maintenance.Mode{}, nil, nil, nil)
+	res, err := svc.GetVersion(context.Background(), connect.NewRequest(&GetVersionRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Version == "" || res.Msg.UpdateCheckEnabled || res.Msg.UpdateAvailable {
+		t.Errorf("without checker: %v", res.Msg)
+	}
+
+	updates := version.NewChecker("v1.0.0", srv.URL, slog.Default())
+	if _, err := updates.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	svc = NewService(db, sched, &agentloop.Loop{}, &maintenance.Mode{}, nil, updates, nil)
+	res, err = svc.GetVersion(context.Background(), connect.NewRequest(&GetVersionRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Msg.UpdateCheckEnabled || !res.Msg.UpdateAvailable || res.Msg.LatestVersion != "v1.1.0" || res.Msg.ReleaseUrl == "" || res.Msg.CheckedAt == nil {
+		t.Errorf("with checker: %v", res.Msg)
+	}
+}
+
+func TestLLMCaptures(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]"}); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewService(db, scheduler.New(db, queries, nil, nil, slog.Default()), &agentloop.Loop{Queries: queries}, &maintenance.Mode{}, nil, nil, nil)
+	for _, req := range []*CreateLLMCaptureRequest{
+		{},
+		{AgentId: "agent-1", ConversationId: "conv-1"},
+		{AgentId: "agent-1", DurationSeconds: -1},
+		{AgentId: "agent-1", RetentionSeconds: 8 * 24 * 3600},
+	} {
+		if _, err := svc.CreateLLMCapture(ctx, connect.NewRequest(req)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("CreateLLMCapture(%v): got %v, want InvalidArgument", req, err)
+		}
+	}
+	if _, err := svc.CreateLLMCapture(ctx, connect.NewRequest(&CreateLLMCaptureRequest{ConversationId: "unknown"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("CreateLLMCapture of unknown conversation: got %v, want NotFound", err)
+	}
+
+	capture, err := svc.CreateLLMCapture(ctx, connect.NewRequest(&CreateLLMCaptureRequest{AgentId: "agent-1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capture.Msg.RetentionSeconds != 24*3600 || capture.Msg.ExpiresAt.AsTime().Sub(capture.Msg.CreatedAt.AsTime()) != time.Hour {
+		t.Errorf("capture = %v, want defaults", capture.Msg)
+	}
+	list, err := svc.ListLLMCaptures(ctx, connect.NewRequest(&ListLLMCapturesRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Msg.Captures) != 1 || list.Msg.Captures[0].AgentId != "agent-1" {
+		t.Errorf("captures = %v", list.Msg.Captures)
+	}
+
+	if _, err := svc.DeleteLLMCapture(ctx, connect.NewRequest(&DeleteLLMCaptureRequest{Id: capture.Msg.Id})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.DeleteLLMCapture(ctx, connect.NewRequest(&DeleteLLMCaptureRequest{Id: capture.Msg.Id})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("deleting deleted capture: got %v, want NotFound", err)
+	}
+	if _, err := svc.ListLLMExchanges(ctx, connect.NewRequest(&ListLLMExchangesRequest{})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("ListLLMExchanges without run or conversation: got %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,786 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: system/system.proto
+
+package system
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SystemServiceName is the fully-qualified name of the SystemService service.
+	SystemServiceName = "blippy.system.SystemService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SystemServiceGetSystemStatsProcedure is the fully-qualified name of the SystemService's
+	// GetSystemStats RPC.
+	SystemServiceGetSystemStatsProcedure = "/blippy.system.SystemService/GetSystemStats"
+	// SystemServiceGetMaintenanceModeProcedure is the fully-qualified name of the SystemService's
+	// GetMaintenanceMode RPC.
+	SystemServiceGetMaintenanceModeProcedure = "/blippy.system.SystemService/GetMaintenanceMode"
+	// SystemServiceUpdateMaintenanceModeProcedure is the fully-qualified name of the SystemService's
+	// UpdateMaintenanceMode RPC.
+	SystemServiceUpdateMaintenanceModeProcedure = "/blippy.system.SystemService/UpdateMaintenanceMode"
+	// SystemServiceGetInstancePreambleProcedure is the fully-qualified name of the SystemService's
+	// GetInstancePreamble RPC.
+	SystemServiceGetInstancePreambleProcedure = "/blippy.system.SystemService/GetInstancePreamble"
+	// SystemServiceUpdateInstancePreambleProcedure is the fully-qualified name of the SystemService's
+	// UpdateInstancePreamble RPC.
+	SystemServiceUpdateInstancePreambleProcedure = "/blippy.system.SystemService/UpdateInstancePreamble"
+	// SystemServiceGetBrokerStatsProcedure is the fully-qualified name of the SystemService's
+	// GetBrokerStats RPC.
+	SystemServiceGetBrokerStatsProcedure = "/blippy.system.SystemService/GetBrokerStats"
+	// SystemServiceListActiveRunsProcedure is the fully-qualified name of the SystemService's
+	// ListActiveRuns RPC.
+	SystemServiceListActiveRunsProcedure = "/blippy.system.SystemService/ListActiveRuns"
+	// SystemServiceCancelRunProcedure is the fully-qualified name of the SystemService's CancelRun RPC.
+	SystemServiceCancelRunProcedure = "/blippy.system.SystemService/CancelRun"
+	// SystemServiceListPendingApprovalsProcedure is the fully-qualified name of the SystemService's
+	// ListPendingApprovals RPC.
+	SystemServiceListPendingApprovalsProcedure = "/blippy.system.SystemService/ListPendingApprovals"
+	// SystemServiceResolveApprovalProcedure is the fully-qualified name of the SystemService's
+	// ResolveApproval RPC.
+	SystemServiceResolveApprovalProcedure = "/blippy.system.SystemService/ResolveApproval"
+	// SystemServiceReplayTurnProcedure is the fully-qualified name of the SystemService's ReplayTurn
+	// RPC.
+	SystemServiceReplayTurnProcedure = "/blippy.system.SystemService/ReplayTurn"
+	// SystemServiceGetRunTraceProcedure is the fully-qualified name of the SystemService's GetRunTrace
+	// RPC.
+	SystemServiceGetRunTraceProcedure = "/blippy.system.SystemService/GetRunTrace"
+	// SystemServiceExportManifestProcedure is the fully-qualified name of the SystemService's
+	// ExportManifest RPC.
+	SystemServiceExportManifestProcedure = "/blippy.system.SystemService/ExportManifest"
+	// SystemServiceGetVersionProcedure is the fully-qualified name of the SystemService's GetVersion
+	// RPC.
+	SystemServiceGetVersionProcedure = "/blippy.system.SystemService/GetVersion"
+	// SystemServiceGetUsageReportProcedure is the fully-qualified name of the SystemService's
+	// GetUsageReport RPC.
+	SystemServiceGetUsageReportProcedure = "/blippy.system.SystemService/GetUsageReport"
+	// SystemServiceListProviderKeysProcedure is the fully-qualified name of the SystemService's
+	// ListProviderKeys RPC.
+	SystemServiceListProviderKeysProcedure = "/blippy.system.SystemService/ListProviderKeys"
+	// SystemServiceCreateProviderKeyProcedure is the fully-qualified name of the SystemService's
+	// CreateProviderKey RPC.
+	SystemServiceCreateProviderKeyProcedure = "/blippy.system.SystemService/CreateProviderKey"
+	// SystemServiceDeleteProviderKeyProcedure is the fully-qualified name of the SystemService's
+	// DeleteProviderKey RPC.
+	SystemServiceDeleteProviderKeyProcedure = "/blippy.system.SystemService/DeleteProviderKey"
+	// SystemServiceCreateLLMCaptureProcedure is the fully-qualified name of the SystemService's
+	// CreateLLMCapture RPC.
+	SystemServiceCreateLLMCaptureProcedure = "/blippy.system.SystemService/CreateLLMCapture"
+	// SystemServiceListLLMCapturesProcedure is the fully-qualified name of the SystemService's
+	// ListLLMCaptures RPC.
+	SystemServiceListLLMCapturesProcedure = "/blippy.system.SystemService/ListLLMCaptures"
+	// SystemServiceDeleteLLMCaptureProcedure is the fully-qualified name of the SystemService's
+	// DeleteLLMCapture RPC.
+	SystemServiceDeleteLLMCaptureProcedure = "/blippy.system.SystemService/DeleteLLMCapture"
+	// SystemServiceListLLMExchangesProcedure is the fully-qualified name of the SystemService's
+	// ListLLMExchanges RPC.
+	SystemServiceListLLMExchangesProcedure = "/blippy.system.SystemService/ListLLMExchanges"
+)
+
+// SystemServiceClient is a client for the blippy.system.SystemService service.
+type SystemServiceClient interface {
+	GetSystemStats(context.Context, *connect.Request[GetSystemStatsRequest]) (*connect.Response[SystemStats], error)
+	GetMaintenanceMode(context.Context, *connect.Request[GetMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error)
+	// Admin only. Enabling pauses the scheduler and rejects new chat messages,
+	// webhook runs and notification replies; turns in progress continue.
+	UpdateMaintenanceMode(context.Context, *connect.Request[UpdateMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error)
+	GetInstancePreamble(context.Context, *connect.Request[GetInstancePreambleRequest]) (*connect.Response[InstancePreamble], error)
+	// Admin only.
+	UpdateInstancePreamble(context.Context, *connect.Request[UpdateInstancePreambleRequest]) (*connect.Response[InstancePreamble], error)
+	// Admin only.
+	GetBrokerStats(context.Context, *connect.Request[GetBrokerStatsRequest]) (*connect.Response[BrokerStats], error)
+	// Runs of other replicas aren't listed.
+	ListActiveRuns(context.Context, *connect.Request[ListActiveRunsRequest]) (*connect.Response[ListActiveRunsResponse], error)
+	// Stops a run, storing its output so far, and the runs of agents it
+	// called. Returns NotFound for runs that aren't active on this replica.
+	CancelRun(context.Context, *connect.Request[CancelRunRequest]) (*connect.Response[CancelRunResponse], error)
+	// Tool calls of runs on this replica waiting for approval.
+	ListPendingApprovals(context.Context, *connect.Request[ListPendingApprovalsRequest]) (*connect.Response[ListPendingApprovalsResponse], error)
+	// Approves or denies a tool call waiting for approval. Returns NotFound
+	// for calls that aren't waiting on this replica.
+	ResolveApproval(context.Context, *connect.Request[ResolveApprovalRequest]) (*connect.Response[ResolveApprovalResponse], error)
+	// Admin only. Runs a turn recorded with RECORD_TURNS again in a new
+	// conversation, with the recorded LLM responses and tool results, to
+	// reproduce bugs without calling the LLM or executing tools.
+	ReplayTurn(context.Context, *connect.Request[ReplayTurnRequest]) (*connect.Response[ReplayTurnResponse], error)
+	// Returns the execution trace of a finished run: each LLM round-trip's
+	// request size, latency and token counts, and the tool calls it made.
+	GetRunTrace(context.Context, *connect.Request[GetRunTraceRequest]) (*connect.Response[RunTrace], error)
+	// Admin only. Exports the agents, triggers, notification channels and
+	// filesystem roots, for `blippy export` against a remote server.
+	ExportManifest(context.Context, *connect.Request[ExportManifestRequest]) (*connect.Response[ExportManifestResponse], error)
+	// Returns the running version and, if the update check is enabled,
+	// whether a newer release is available.
+	GetVersion(context.Context, *connect.Request[GetVersionRequest]) (*connect.Response[Version], error)
+	// Returns the runs, failures, token usage and cost of each agent over the
+	// last 24 hours, or the requested period. Agents can get the same report
+	// with the get_usage_report tool.
+	GetUsageReport(context.Context, *connect.Request[GetUsageReportRequest]) (*connect.Response[UsageReport], error)
+	// Admin only. Lists the OpenRouter API keys requests are spread over,
+	// with their usage.
+	ListProviderKeys(context.Context, *connect.Request[ListProviderKeysRequest]) (*connect.Response[ListProviderKeysResponse], error)
+	// Admin only. Adds an OpenRouter API key after checking it with
+	// OpenRouter, or updates the weight of a key that was already added. Keys
+	// are kept in memory on this replica; update OPENROUTER_API_KEY to keep
+	// them after a restart.
+	CreateProviderKey(context.Context, *connect.Request[CreateProviderKeyRequest]) (*connect.Response[ProviderKey], error)
+	// Admin only. Stops using an OpenRouter API key, e.g. after it leaked.
+	// The last key can't be removed.
+	DeleteProviderKey(context.Context, *connect.Request[DeleteProviderKeyRequest]) (*connect.Response[DeleteProviderKeyResponse], error)
+	// Admin only. Starts capturing the raw LLM calls of an agent or
+	// conversation, to debug malformed tool schemas and provider quirks.
+	CreateLLMCapture(context.Context, *connect.Request[CreateLLMCaptureRequest]) (*connect.Response[LLMCapture], error)
+	// Admin only. Lists the captures that haven't expired.
+	ListLLMCaptures(context.Context, *connect.Request[ListLLMCapturesRequest]) (*connect.Response[ListLLMCapturesResponse], error)
+	// Admin only. Stops a capture. Exchanges captured so far are kept.
+	DeleteLLMCapture(context.Context, *connect.Request[DeleteLLMCaptureRequest]) (*connect.Response[DeleteLLMCaptureResponse], error)
+	// Admin only. Lists the captured LLM calls of a conversation or run.
+	ListLLMExchanges(context.Context, *connect.Request[ListLLMExchangesRequest]) (*connect.Response[ListLLMExchangesResponse], error)
+}
+
+// NewSystemServiceClient constructs a client for the blippy.system.SystemService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSystemServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SystemServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	systemServiceMethods := File_system_system_proto.Services().ByName("SystemService").Methods()
+	return &systemServiceClient{
+		getSystemStats: connect.NewClient[GetSystemStatsRequest, SystemStats](
+			httpClient,
+			baseURL+SystemServiceGetSystemStatsProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetSystemStats")),
+			connect.WithClientOptions(opts...),
+		),
+		getMaintenanceMode: connect.NewClient[GetMaintenanceModeRequest, MaintenanceMode](
+			httpClient,
+			baseURL+SystemServiceGetMaintenanceModeProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetMaintenanceMode")),
+			connect.WithClientOptions(opts...),
+		),
+		updateMaintenanceMode: connect.NewClient[UpdateMaintenanceModeRequest, MaintenanceMode](
+			httpClient,
+			baseURL+SystemServiceUpdateMaintenanceModeProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("UpdateMaintenanceMode")),
+			connect.WithClientOptions(opts...),
+		),
+		getInstancePreamble: connect.NewClient[GetInstancePreambleRequest, InstancePreamble](
+			httpClient,
+			baseURL+SystemServiceGetInstancePreambleProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetInstancePreamble")),
+			connect.WithClientOptions(opts...),
+		),
+		updateInstancePreamble: connect.NewClient[UpdateInstancePreambleRequest, InstancePreamble](
+			httpClient,
+			baseURL+SystemServiceUpdateInstancePreambleProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("UpdateInstancePreamble")),
+			connect.WithClientOptions(opts...),
+		),
+		getBrokerStats: connect.NewClient[GetBrokerStatsRequest, BrokerStats](
+			httpClient,
+			baseURL+SystemServiceGetBrokerStatsProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetBrokerStats")),
+			connect.WithClientOptions(opts...),
+		),
+		listActiveRuns: connect.NewClient[ListActiveRunsRequest, ListActiveRunsResponse](
+			httpClient,
+			baseURL+SystemServiceListActiveRunsProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ListActiveRuns")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelRun: connect.NewClient[CancelRunRequest, CancelRunResponse](
+			httpClient,
+			baseURL+SystemServiceCancelRunProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("CancelRun")),
+			connect.WithClientOptions(opts...),
+		),
+		listPendingApprovals: connect.NewClient[ListPendingApprovalsRequest, ListPendingApprovalsResponse](
+			httpClient,
+			baseURL+SystemServiceListPendingApprovalsProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ListPendingApprovals")),
+			connect.WithClientOptions(opts...),
+		),
+		resolveApproval: connect.NewClient[ResolveApprovalRequest, ResolveApprovalResponse](
+			httpClient,
+			baseURL+SystemServiceResolveApprovalProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ResolveApproval")),
+			connect.WithClientOptions(opts...),
+		),
+		replayTurn: connect.NewClient[ReplayTurnRequest, ReplayTurnResponse](
+			httpClient,
+			baseURL+SystemServiceReplayTurnProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ReplayTurn")),
+			connect.WithClientOptions(opts...),
+		),
+		getRunTrace: connect.NewClient[GetRunTraceRequest, RunTrace](
+			httpClient,
+			baseURL+SystemServiceGetRunTraceProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetRunTrace")),
+			connect.WithClientOptions(opts...),
+		),
+		exportManifest: connect.NewClient[ExportManifestRequest, ExportManifestResponse](
+			httpClient,
+			baseURL+SystemServiceExportManifestProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ExportManifest")),
+			connect.WithClientOptions(opts...),
+		),
+		getVersion: connect.NewClient[GetVersionRequest, Version](
+			httpClient,
+			baseURL+SystemServiceGetVersionProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetVersion")),
+			connect.WithClientOptions(opts...),
+		),
+		getUsageReport: connect.NewClient[GetUsageReportRequest, UsageReport](
+			httpClient,
+			baseURL+SystemServiceGetUsageReportProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetUsageReport")),
+			connect.WithClientOptions(opts...),
+		),
+		listProviderKeys: connect.NewClient[ListProviderKeysRequest, ListProviderKeysResponse](
+			httpClient,
+			baseURL+SystemServiceListProviderKeysProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ListProviderKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		createProviderKey: connect.NewClient[CreateProviderKeyRequest, ProviderKey](
+			httpClient,
+			baseURL+SystemServiceCreateProviderKeyProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("CreateProviderKey")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteProviderKey: connect.NewClient[DeleteProviderKeyRequest, DeleteProviderKeyResponse](
+			httpClient,
+			baseURL+SystemServiceDeleteProviderKeyProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("DeleteProviderKey")),
+			connect.WithClientOptions(opts...),
+		),
+		createLLMCapture: connect.NewClient[CreateLLMCaptureRequest, LLMCapture](
+			httpClient,
+			baseURL+SystemServiceCreateLLMCaptureProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("CreateLLMCapture")),
+			connect.WithClientOptions(opts...),
+		),
+		listLLMCaptures: connect.NewClient[ListLLMCapturesRequest, ListLLMCapturesResponse](
+			httpClient,
+			baseURL+SystemServiceListLLMCapturesProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ListLLMCaptures")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteLLMCapture: connect.NewClient[DeleteLLMCaptureRequest, DeleteLLMCaptureResponse](
+			httpClient,
+			baseURL+SystemServiceDeleteLLMCaptureProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("DeleteLLMCapture")),
+			connect.WithClientOptions(opts...),
+		),
+		listLLMExchanges: connect.NewClient[ListLLMExchangesRequest, ListLLMExchangesResponse](
+			httpClient,
+			baseURL+SystemServiceListLLMExchangesProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("ListLLMExchanges")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// systemServiceClient implements SystemServiceClient.
+type systemServiceClient struct {
+	getSystemStats         *connect.Client[GetSystemStatsRequest, SystemStats]
+	getMaintenanceMode     *connect.Client[GetMaintenanceModeRequest, MaintenanceMode]
+	updateMaintenanceMode  *connect.Client[UpdateMaintenanceModeRequest, MaintenanceMode]
+	getInstancePreamble    *connect.Client[GetInstancePreambleRequest, InstancePreamble]
+	updateInstancePreamble *connect.Client[UpdateInstancePreambleRequest, InstancePreamble]
+	getBrokerStats         *connect.Client[GetBrokerStatsRequest, BrokerStats]
+	listActiveRuns         *connect.Client[ListActiveRunsRequest, ListActiveRunsResponse]
+	cancelRun              *connect.Client[CancelRunRequest, CancelRunResponse]
+	listPendingApprovals   *connect.Client[ListPendingApprovalsRequest, ListPendingApprovalsResponse]
+	resolveApproval        *connect.Client[ResolveApprovalRequest, ResolveApprovalResponse]
+	replayTurn             *connect.Client[ReplayTurnRequest, ReplayTurnResponse]
+	getRunTrace            *connect.Client[GetRunTraceRequest, RunTrace]
+	exportManifest         *connect.Client[ExportManifestRequest, ExportManifestResponse]
+	getVersion             *connect.Client[GetVersionRequest, Version]
+	getUsageReport         *connect.Client[GetUsageReportRequest, UsageReport]
+	listProviderKeys       *connect.Client[ListProviderKeysRequest, ListProviderKeysResponse]
+	createProviderKey      *connect.Client[CreateProviderKeyRequest, ProviderKey]
+	deleteProviderKey      *connect.Client[DeleteProviderKeyRequest, DeleteProviderKeyResponse]
+	createLLMCapture       *connect.Client[CreateLLMCaptureRequest, LLMCapture]
+	listLLMCaptures        *connect.Client[ListLLMCapturesRequest, ListLLMCapturesResponse]
+	deleteLLMCapture       *connect.Client[DeleteLLMCaptureRequest, DeleteLLMCaptureResponse]
+	listLLMExchanges       *connect.Client[ListLLMExchangesRequest, ListLLMExchangesResponse]
+}
+
+// GetSystemStats calls blippy.system.SystemService.GetSystemStats.
+func (c *systemServiceClient) GetSystemStats(ctx context.Context, req *connect.Request[GetSystemStatsRequest]) (*connect.Response[SystemStats], error) {
+	return c.getSystemStats.CallUnary(ctx, req)
+}
+
+// GetMaintenanceMode calls blippy.system.SystemService.GetMaintenanceMode.
+func (c *systemServiceClient) GetMaintenanceMode(ctx context.Context, req *connect.Request[GetMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error) {
+	return c.getMaintenanceMode.CallUnary(ctx, req)
+}
+
+// UpdateMaintenanceMode calls blippy.system.SystemService.UpdateMaintenanceMode.
+func (c *systemServiceClient) UpdateMaintenanceMode(ctx context.Context, req *connect.Request[UpdateMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error) {
+	return c.updateMaintenanceMode.CallUnary(ctx, req)
+}
+
+// GetInstancePreamble calls blippy.system.SystemService.GetInstancePreamble.
+func (c *systemServiceClient) GetInstancePreamble(ctx context.Context, req *connect.Request[GetInstancePreambleRequest]) (*connect.Response[InstancePreamble], error) {
+	return c.getInstancePreamble.CallUnary(ctx, req)
+}
+
+// UpdateInstancePreamble calls blippy.system.SystemService.UpdateInstancePreamble.
+func (c *systemServiceClient) UpdateInstancePreamble(ctx context.Context, req *connect.Request[UpdateInstancePreambleRequest]) (*connect.Response[InstancePreamble], error) {
+	return c.updateInstancePreamble.CallUnary(ctx, req)
+}
+
+// GetBrokerStats calls blippy.system.SystemService.GetBrokerStats.
+func (c *systemServiceClient) GetBrokerStats(ctx context.Context, req *connect.Request[GetBrokerStatsRequest]) (*connect.Response[BrokerStats], error) {
+	return c.getBrokerStats.CallUnary(ctx, req)
+}
+
+// ListActiveRuns calls blippy.system.SystemService.ListActiveRuns.
+func (c *systemServiceClient) ListActiveRuns(ctx context.Context, req *connect.Request[ListActiveRunsRequest]) (*connect.Response[ListActiveRunsResponse], error) {
+	return c.listActiveRuns.CallUnary(ctx, req)
+}
+
+// CancelRun calls blippy.system.SystemService.CancelRun.
+func (c *systemServiceClient) CancelRun(ctx context.Context, req *connect.Request[CancelRunRequest]) (*connect.Response[CancelRunResponse], error) {
+	return c.cancelRun.CallUnary(ctx, req)
+}
+
+// ListPendingApprovals calls blippy.system.SystemService.ListPendingApprovals.
+func (c *systemServiceClient) ListPendingApprovals(ctx context.Context, req *connect.Request[ListPendingApprovalsRequest]) (*connect.Response[ListPendingApprovalsResponse], error) {
+	return c.listPendingApprovals.CallUnary(ctx, req)
+}
+
+// ResolveApproval calls blippy.system.SystemService.ResolveApproval.
+func (c *systemServiceClient) ResolveApproval(ctx context.Context, req *connect.Request[ResolveApprovalRequest]) (*connect.Response[ResolveApprovalResponse], error) {
+	return c.resolveApproval.CallUnary(ctx, req)
+}
+
+// ReplayTurn calls blippy.system.SystemService.ReplayTurn.
+func (c *systemServiceClient) ReplayTurn(ctx context.Context, req *connect.Request[ReplayTurnRequest]) (*connect.Response[ReplayTurnResponse], error) {
+	return c.replayTurn.CallUnary(ctx, req)
+}
+
+// GetRunTrace calls blippy.system.SystemService.GetRunTrace.
+func (c *systemServiceClient) GetRunTrace(ctx context.Context, req *connect.Request[GetRunTraceRequest]) (*connect.Response[RunTrace], error) {
+	return c.getRunTrace.CallUnary(ctx, req)
+}
+
+// ExportManifest calls blippy.system.SystemService.ExportManifest.
+func (c *systemServiceClient) ExportManifest(ctx context.Context, req *connect.Request[ExportManifestRequest]) (*connect.Response[ExportManifestResponse], error) {
+	return c.exportManifest.CallUnary(ctx, req)
+}
+
+// GetVersion calls blippy.system.SystemService.GetVersion.
+func (c *systemServiceClient) GetVersion(ctx context.Context, req *connect.Request[GetVersionRequest]) (*connect.Response[Version], error) {
+	return c.getVersion.CallUnary(ctx, req)
+}
+
+// GetUsageReport calls blippy.system.SystemService.GetUsageReport.
+func (c *systemServiceClient) GetUsageReport(ctx context.Context, req *connect.Request[GetUsageReportRequest]) (*connect.Response[UsageReport], error) {
+	return c.getUsageReport.CallUnary(ctx, req)
+}
+
+// ListProviderKeys calls blippy.system.SystemService.ListProviderKeys.
+func (c *systemServiceClient) ListProviderKeys(ctx context.Context, req *connect.Request[ListProviderKeysRequest]) (*connect.Response[ListProviderKeysResponse], error) {
+	return c.listProviderKeys.CallUnary(ctx, req)
+}
+
+// CreateProviderKey calls blippy.system.SystemService.CreateProviderKey.
+func (c *systemServiceClient) CreateProviderKey(ctx context.Context, req *connect.Request[CreateProviderKeyRequest]) (*connect.Response[ProviderKey], error) {
+	return c.createProviderKey.CallUnary(ctx, req)
+}
+
+// DeleteProviderKey calls blippy.system.SystemService.DeleteProviderKey.
+func (c *systemServiceClient) DeleteProviderKey(ctx context.Context, req *connect.Request[DeleteProviderKeyRequest]) (*connect.Response[DeleteProviderKeyResponse], error) {
+	return c.deleteProviderKey.CallUnary(ctx, req)
+}
+
+// CreateLLMCapture calls blippy.system.SystemService.CreateLLMCapture.
+func (c *systemServiceClient) CreateLLMCapture(ctx context.Context, req *connect.Request[CreateLLMCaptureRequest]) (*connect.Response[LLMCapture], error) {
+	return c.createLLMCapture.CallUnary(ctx, req)
+}
+
+// ListLLMCaptures calls blippy.system.SystemService.ListLLMCaptures.
+func (c *systemServiceClient) ListLLMCaptures(ctx context.Context, req *connect.Request[ListLLMCapturesRequest]) (*connect.Response[ListLLMCapturesResponse], error) {
+	return c.listLLMCaptures.CallUnary(ctx, req)
+}
+
+// DeleteLLMCapture calls blippy.system.SystemService.DeleteLLMCapture.
+func (c *systemServiceClient) DeleteLLMCapture(ctx context.Context, req *connect.Request[DeleteLLMCaptureRequest]) (*connect.Response[DeleteLLMCaptureResponse], error) {
+	return c.deleteLLMCapture.CallUnary(ctx, req)
+}
+
+// ListLLMExchanges calls blippy.system.SystemService.ListLLMExchanges.
+func (c *systemServiceClient) ListLLMExchanges(ctx context.Context, req *connect.Request[ListLLMExchangesRequest]) (*connect.Response[ListLLMExchangesResponse], error) {
+	return c.listLLMExchanges.CallUnary(ctx, req)
+}
+
+// SystemServiceHandler is an implementation of the blippy.system.SystemService service.
+type SystemServiceHandler interface {
+	GetSystemStats(context.Context, *connect.Request[GetSystemStatsRequest]) (*connect.Response[SystemStats], error)
+	GetMaintenanceMode(context.Context, *connect.Request[GetMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error)
+	// Admin only. Enabling pauses the scheduler and rejects new chat messages,
+	// webhook runs and notification replies; turns in progress continue.
+	UpdateMaintenanceMode(context.Context, *connect.Request[UpdateMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error)
+	GetInstancePreamble(context.Context, *connect.Request[GetInstancePreambleRequest]) (*connect.Response[InstancePreamble], error)
+	// Admin only.
+	UpdateInstancePreamble(context.Context, *connect.Request[UpdateInstancePreambleRequest]) (*connect.Response[InstancePreamble], error)
+	// Admin only.
+	GetBrokerStats(context.Context, *connect.Request[GetBrokerStatsRequest]) (*connect.Response[BrokerStats], error)
+	// Runs of other replicas aren't listed.
+	ListActiveRuns(context.Context, *connect.Request[ListActiveRunsRequest]) (*connect.Response[ListActiveRunsResponse], error)
+	// Stops a run, storing its output so far, and the runs of agents it
+	// called. Returns NotFound for runs that aren't active on this replica.
+	CancelRun(context.Context, *connect.Request[CancelRunRequest]) (*connect.Response[CancelRunResponse], error)
+	// Tool calls of runs on this replica waiting for approval.
+	ListPendingApprovals(context.Context, *connect.Request[ListPendingApprovalsRequest]) (*connect.Response[ListPendingApprovalsResponse], error)
+	// Approves or denies a tool call waiting for approval. Returns NotFound
+	// for calls that aren't waiting on this replica.
+	ResolveApproval(context.Context, *connect.Request[ResolveApprovalRequest]) (*connect.Response[ResolveApprovalResponse], error)
+	// Admin only. Runs a turn recorded with RECORD_TURNS again in a new
+	// conversation, with the recorded LLM responses and tool results, to
+	// reproduce bugs without calling the LLM or executing tools.
+	ReplayTurn(context.Context, *connect.Request[ReplayTurnRequest]) (*connect.Response[ReplayTurnResponse], error)
+	// Returns the execution trace of a finished run: each LLM round-trip's
+	// request size, latency and token counts, and the tool calls it made.
+	GetRunTrace(context.Context, *connect.Request[GetRunTraceRequest]) (*connect.Response[RunTrace], error)
+	// Admin only. Exports the agents, triggers, notification channels and
+	// filesystem roots, for `blippy export` against a remote server.
+	ExportManifest(context.Context, *connect.Request[ExportManifestRequest]) (*connect.Response[ExportManifestResponse], error)
+	// Returns the running version and, if the update check is enabled,
+	// whether a newer release is available.
+	GetVersion(context.Context, *connect.Request[GetVersionRequest]) (*connect.Response[Version], error)
+	// Returns the runs, failures, token usage and cost of each agent over the
+	// last 24 hours, or the requested period. Agents can get the same report
+	// with the get_usage_report tool.
+	GetUsageReport(context.Context, *connect.Request[GetUsageReportRequest]) (*connect.Response[UsageReport], error)
+	// Admin only. Lists the OpenRouter API keys requests are spread over,
+	// with their usage.
+	ListProviderKeys(context.Context, *connect.Request[ListProviderKeysRequest]) (*connect.Response[ListProviderKeysResponse], error)
+	// Admin only. Adds an OpenRouter API key after checking it with
+	// OpenRouter, or updates the weight of a key that was already added. Keys
+	// are kept in memory on this replica; update OPENROUTER_API_KEY to keep
+	// them after a restart.
+	CreateProviderKey(context.Context, *connect.Request[CreateProviderKeyRequest]) (*connect.Response[ProviderKey], error)
+	// Admin only. Stops using an OpenRouter API key, e.g. after it leaked.
+	// The last key can't be removed.
+	DeleteProviderKey(context.Context, *connect.Request[DeleteProviderKeyRequest]) (*connect.Response[DeleteProviderKeyResponse], error)
+	// Admin only. Starts capturing the raw LLM calls of an agent or
+	// conversation, to debug malformed tool schemas and provider quirks.
+	CreateLLMCapture(context.Context, *connect.Request[CreateLLMCaptureRequest]) (*connect.Response[LLMCapture], error)
+	// Admin only. Lists the captures that haven't expired.
+	ListLLMCaptures(context.Context, *connect.Request[ListLLMCapturesRequest]) (*connect.Response[ListLLMCapturesResponse], error)
+	// Admin only. Stops a capture. Exchanges captured so far are kept.
+	DeleteLLMCapture(context.Context, *connect.Request[DeleteLLMCaptureRequest]) (*connect.Response[DeleteLLMCaptureResponse], error)
+	// Admin only. Lists the captured LLM calls of a conversation or run.
+	ListLLMExchanges(context.Context, *connect.Request[ListLLMExchangesRequest]) (*connect.Response[ListLLMExchangesResponse], error)
+}
+
+// NewSystemServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSystemServiceHandler(svc SystemServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	systemServiceMethods := File_system_system_proto.Services().ByName("SystemService").Methods()
+	systemServiceGetSystemStatsHandler := connect.NewUnaryHandler(
+		SystemServiceGetSystemStatsProcedure,
+		svc.GetSystemStats,
+		connect.WithSchema(systemServiceMethods.ByName("GetSystemStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceGetMaintenanceModeHandler := connect.NewUnaryHandler(
+		SystemServiceGetMaintenanceModeProcedure,
+		svc.GetMaintenanceMode,
+		connect.WithSchema(systemServiceMethods.ByName("GetMaintenanceMode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceUpdateMaintenanceModeHandler := connect.NewUnaryHandler(
+		SystemServiceUpdateMaintenanceModeProcedure,
+		svc.UpdateMaintenanceMode,
+		connect.WithSchema(systemServiceMethods.ByName("UpdateMaintenanceMode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceGetInstancePreambleHandler := connect.NewUnaryHandler(
+		SystemServiceGetInstancePreambleProcedure,
+		svc.GetInstancePreamble,
+		connect.WithSchema(systemServiceMethods.ByName("GetInstancePreamble")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceUpdateInstancePreambleHandler := connect.NewUnaryHandler(
+		SystemServiceUpdateInstancePreambleProcedure,
+		svc.UpdateInstancePreamble,
+		connect.WithSchema(systemServiceMethods.ByName("UpdateInstancePreamble")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceGetBrokerStatsHandler := connect.NewUnaryHandler(
+		SystemServiceGetBrokerStatsProcedure,
+		svc.GetBrokerStats,
+		connect.WithSchema(systemServiceMethods.ByName("GetBrokerStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceListActiveRunsHandler := connect.NewUnaryHandler(
+		SystemServiceListActiveRunsProcedure,
+		svc.ListActiveRuns,
+		connect.WithSchema(systemServiceMethods.ByName("ListActiveRuns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceCancelRunHandler := connect.NewUnaryHandler(
+		SystemServiceCancelRunProcedure,
+		svc.CancelRun,
+		connect.WithSchema(systemServiceMethods.ByName("CancelRun")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceListPendingApprovalsHandler := connect.NewUnaryHandler(
+		SystemServiceListPendingApprovalsProcedure,
+		svc.ListPendingApprovals,
+		connect.WithSchema(systemServiceMethods.ByName("ListPendingApprovals")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceResolveApprovalHandler := connect.NewUnaryHandler(
+		SystemServiceResolveApprovalProcedure,
+		svc.ResolveApproval,
+		connect.WithSchema(systemServiceMethods.ByName("ResolveApproval")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceReplayTurnHandler := connect.NewUnaryHandler(
+		SystemServiceReplayTurnProcedure,
+		svc.ReplayTurn,
+		connect.WithSchema(systemServiceMethods.ByName("ReplayTurn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceGetRunTraceHandler := connect.NewUnaryHandler(
+		SystemServiceGetRunTraceProcedure,
+		svc.GetRunTrace,
+		connect.WithSchema(systemServiceMethods.ByName("GetRunTrace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceExportManifestHandler := connect.NewUnaryHandler(
+		SystemServiceExportManifestProcedure,
+		svc.ExportManifest,
+		connect.WithSchema(systemServiceMethods.ByName("ExportManifest")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceGetVersionHandler := connect.NewUnaryHandler(
+		SystemServiceGetVersionProcedure,
+		svc.GetVersion,
+		connect.WithSchema(systemServiceMethods.ByName("GetVersion")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceGetUsageReportHandler := connect.NewUnaryHandler(
+		SystemServiceGetUsageReportProcedure,
+		svc.GetUsageReport,
+		connect.WithSchema(systemServiceMethods.ByName("GetUsageReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceListProviderKeysHandler := connect.NewUnaryHandler(
+		SystemServiceListProviderKeysProcedure,
+		svc.ListProviderKeys,
+		connect.WithSchema(systemServiceMethods.ByName("ListProviderKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceCreateProviderKeyHandler := connect.NewUnaryHandler(
+		SystemServiceCreateProviderKeyProcedure,
+		svc.CreateProviderKey,
+		connect.WithSchema(systemServiceMethods.ByName("CreateProviderKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceDeleteProviderKeyHandler := connect.NewUnaryHandler(
+		SystemServiceDeleteProviderKeyProcedure,
+		svc.DeleteProviderKey,
+		connect.WithSchema(systemServiceMethods.ByName("DeleteProviderKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceCreateLLMCaptureHandler := connect.NewUnaryHandler(
+		SystemServiceCreateLLMCaptureProcedure,
+		svc.CreateLLMCapture,
+		connect.WithSchema(systemServiceMethods.ByName("CreateLLMCapture")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceListLLMCapturesHandler := connect.NewUnaryHandler(
+		SystemServiceListLLMCapturesProcedure,
+		svc.ListLLMCaptures,
+		connect.WithSchema(systemServiceMethods.ByName("ListLLMCaptures")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceDeleteLLMCaptureHandler := connect.NewUnaryHandler(
+		SystemServiceDeleteLLMCaptureProcedure,
+		svc.DeleteLLMCapture,
+		connect.WithSchema(systemServiceMethods.ByName("DeleteLLMCapture")),
+		connect.WithHandlerOptions(opts...),
+	)
+	systemServiceListLLMExchangesHandler := connect.NewUnaryHandler(
+		SystemServiceListLLMExchangesProcedure,
+		svc.ListLLMExchanges,
+		connect.WithSchema(systemServiceMethods.ByName("ListLLMExchanges")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/blippy.system.SystemService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SystemServiceGetSystemStatsProcedure:
+			systemServiceGetSystemStatsHandler.ServeHTTP(w, r)
+		case SystemServiceGetMaintenanceModeProcedure:
+			systemServiceGetMaintenanceModeHandler.ServeHTTP(w, r)
+		case SystemServiceUpdateMaintenanceModeProcedure:
+			systemServiceUpdateMaintenanceModeHandler.ServeHTTP(w, r)
+		case SystemServiceGetInstancePreambleProcedure:
+			systemServiceGetInstancePreambleHandler.ServeHTTP(w, r)
+		case SystemServiceUpdateInstancePreambleProcedure:
+			systemServiceUpdateInstancePreambleHandler.ServeHTTP(w, r)
+		case SystemServiceGetBrokerStatsProcedure:
+			systemServiceGetBrokerStatsHandler.ServeHTTP(w, r)
+		case SystemServiceListActiveRunsProcedure:
+			systemServiceListActiveRunsHandler.ServeHTTP(w, r)
+		case SystemServiceCancelRunProcedure:
+			systemServiceCancelRunHandler.ServeHTTP(w, r)
+		case SystemServiceListPendingApprovalsProcedure:
+			systemServiceListPendingApprovalsHandler.ServeHTTP(w, r)
+		case SystemServiceResolveApprovalProcedure:
+			systemServiceResolveApprovalHandler.ServeHTTP(w, r)
+		case SystemServiceReplayTurnProcedure:
+			systemServiceReplayTurnHandler.ServeHTTP(w, r)
+		case SystemServiceGetRunTraceProcedure:
+			systemServiceGetRunTraceHandler.ServeHTTP(w, r)
+		case SystemServiceExportManifestProcedure:
+			systemServiceExportManifestHandler.ServeHTTP(w, r)
+		case SystemServiceGetVersionProcedure:
+			systemServiceGetVersionHandler.ServeHTTP(w, r)
+		case SystemServiceGetUsageReportProcedure:
+			systemServiceGetUsageReportHandler.ServeHTTP(w, r)
+		case SystemServiceListProviderKeysProcedure:
+			systemServiceListProviderKeysHandler.ServeHTTP(w, r)
+		case SystemServiceCreateProviderKeyProcedure:
+			systemServiceCreateProviderKeyHandler.ServeHTTP(w, r)
+		case SystemServiceDeleteProviderKeyProcedure:
+			systemServiceDeleteProviderKeyHandler.ServeHTTP(w, r)
+		case SystemServiceCreateLLMCaptureProcedure:
+			systemServiceCreateLLMCaptureHandler.ServeHTTP(w, r)
+		case SystemServiceListLLMCapturesProcedure:
+			systemServiceListLLMCapturesHandler.ServeHTTP(w, r)
+		case SystemServiceDeleteLLMCaptureProcedure:
+			systemServiceDeleteLLMCaptureHandler.ServeHTTP(w, r)
+		case SystemServiceListLLMExchangesProcedure:
+			systemServiceListLLMExchangesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSystemServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSystemServiceHandler struct{}
+
+func (UnimplementedSystemServiceHandler) GetSystemStats(context.Context, *connect.Request[GetSystemStatsRequest]) (*connect.Response[SystemStats], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.GetSystemStats is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) GetMaintenanceMode(context.Context, *connect.Request[GetMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.GetMaintenanceMode is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) UpdateMaintenanceMode(context.Context, *connect.Request[UpdateMaintenanceModeRequest]) (*connect.Response[MaintenanceMode], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.UpdateMaintenanceMode is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) GetInstancePreamble(context.Context, *connect.Request[GetInstancePreambleRequest]) (*connect.Response[InstancePreamble], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.GetInstancePreamble is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) UpdateInstancePreamble(context.Context, *connect.Request[UpdateInstancePreambleRequest]) (*connect.Response[InstancePreamble], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.UpdateInstancePreamble is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) GetBrokerStats(context.Context, *connect.Request[GetBrokerStatsRequest]) (*connect.Response[BrokerStats], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.GetBrokerStats is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ListActiveRuns(context.Context, *connect.Request[ListActiveRunsRequest]) (*connect.Response[ListActiveRunsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ListActiveRuns is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) CancelRun(context.Context, *connect.Request[CancelRunRequest]) (*connect.Response[CancelRunResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.CancelRun is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ListPendingApprovals(context.Context, *connect.Request[ListPendingApprovalsRequest]) (*connect.Response[ListPendingApprovalsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ListPendingApprovals is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ResolveApproval(context.Context, *connect.Request[ResolveApprovalRequest]) (*connect.Response[ResolveApprovalResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ResolveApproval is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ReplayTurn(context.Context, *connect.Request[ReplayTurnRequest]) (*connect.Response[ReplayTurnResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ReplayTurn is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) GetRunTrace(context.Context, *connect.Request[GetRunTraceRequest]) (*connect.Response[RunTrace], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.GetRunTrace is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ExportManifest(context.Context, *connect.Request[ExportManifestRequest]) (*connect.Response[ExportManifestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ExportManifest is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) GetVersion(context.Context, *connect.Request[GetVersionRequest]) (*connect.Response[Version], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.GetVersion is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) GetUsageReport(context.Context, *connect.Request[GetUsageReportRequest]) (*connect.Response[UsageReport], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.GetUsageReport is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ListProviderKeys(context.Context, *connect.Request[ListProviderKeysRequest]) (*connect.Response[ListProviderKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ListProviderKeys is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) CreateProviderKey(context.Context, *connect.Request[CreateProviderKeyRequest]) (*connect.Response[ProviderKey], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.CreateProviderKey is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) DeleteProviderKey(context.Context, *connect.Request[DeleteProviderKeyRequest]) (*connect.Response[DeleteProviderKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.DeleteProviderKey is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) CreateLLMCapture(context.Context, *connect.Request[CreateLLMCaptureRequest]) (*connect.Response[LLMCapture], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.CreateLLMCapture is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ListLLMCaptures(context.Context, *connect.Request[ListLLMCapturesRequest]) (*connect.Response[ListLLMCapturesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ListLLMCaptures is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) DeleteLLMCapture(context.Context, *connect.Request[DeleteLLMCaptureRequest]) (*connect.Response[DeleteLLMCaptureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.DeleteLLMCapture is not implemented"))
+}
+
+func (UnimplementedSystemServiceHandler) ListLLMExchanges(context.Context, *connect.Request[ListLLMExchangesRequest]) (*connect.Response[ListLLMExchangesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.system.SystemService.ListLLMExchanges is not implemented"))
+}
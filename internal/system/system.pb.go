@@ -0,0 +1,3710 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: system/system.proto
+
+package system
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TableStats struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RowCount int64                  `protobuf:"varint,2,opt,name=row_count,json=rowCount,proto3" json:"row_count,omitempty"`
+	// Oldest and newest created_at values; unset if the table has none.
+	OldestCreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=oldest_created_at,json=oldestCreatedAt,proto3" json:"oldest_created_at,omitempty"`
+	NewestCreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=newest_created_at,json=newestCreatedAt,proto3" json:"newest_created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TableStats) Reset() {
+	*x = TableStats{}
+	mi := &file_system_system_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableStats) ProtoMessage() {}
+
+func (x *TableStats) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableStats.ProtoReflect.Descriptor instead.
+func (*TableStats) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TableStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TableStats) GetRowCount() int64 {
+	if x != nil {
+		return x.RowCount
+	}
+	return 0
+}
+
+func (x *TableStats) GetOldestCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OldestCreatedAt
+	}
+	return nil
+}
+
+func (x *TableStats) GetNewestCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NewestCreatedAt
+	}
+	return nil
+}
+
+type GetSystemStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemStatsRequest) Reset() {
+	*x = GetSystemStatsRequest{}
+	mi := &file_system_system_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemStatsRequest) ProtoMessage() {}
+
+func (x *GetSystemStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemStatsRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{1}
+}
+
+type SystemStats struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Tables              []*TableStats          `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	DatabaseSizeBytes   int64                  `protobuf:"varint,2,opt,name=database_size_bytes,json=databaseSizeBytes,proto3" json:"database_size_bytes,omitempty"` // Excludes the write-ahead log
+	SchemaVersion       int32                  `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	LatestSchemaVersion int32                  `protobuf:"varint,4,opt,name=latest_schema_version,json=latestSchemaVersion,proto3" json:"latest_schema_version,omitempty"`
+	PendingMigrations   []string               `protobuf:"bytes,5,rep,name=pending_migrations,json=pendingMigrations,proto3" json:"pending_migrations,omitempty"`
+	// When the scheduler last started a tick; unset if it hasn't ticked yet.
+	SchedulerLastTickAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=scheduler_last_tick_at,json=schedulerLastTickAt,proto3" json:"scheduler_last_tick_at,omitempty"`
+	// Number of enabled triggers whose next run is due.
+	DueTriggers int32 `protobuf:"varint,7,opt,name=due_triggers,json=dueTriggers,proto3" json:"due_triggers,omitempty"`
+	// How long the most overdue trigger has been waiting to run.
+	SchedulerLagSeconds int64 `protobuf:"varint,8,opt,name=scheduler_lag_seconds,json=schedulerLagSeconds,proto3" json:"scheduler_lag_seconds,omitempty"`
+	// Problems worth attention, e.g. pending migrations or a stalled scheduler.
+	Warnings      []string `protobuf:"bytes,9,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemStats) Reset() {
+	*x = SystemStats{}
+	mi := &file_system_system_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStats) ProtoMessage() {}
+
+func (x *SystemStats) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStats.ProtoReflect.Descriptor instead.
+func (*SystemStats) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SystemStats) GetTables() []*TableStats {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *SystemStats) GetDatabaseSizeBytes() int64 {
+	if x != nil {
+		return x.DatabaseSizeBytes
+	}
+	return 0
+}
+
+func (x *SystemStats) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *SystemStats) GetLatestSchemaVersion() int32 {
+	if x != nil {
+		return x.LatestSchemaVersion
+	}
+	return 0
+}
+
+func (x *SystemStats) GetPendingMigrations() []string {
+	if x != nil {
+		return x.PendingMigrations
+	}
+	return nil
+}
+
+func (x *SystemStats) GetSchedulerLastTickAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SchedulerLastTickAt
+	}
+	return nil
+}
+
+func (x *SystemStats) GetDueTriggers() int32 {
+	if x != nil {
+		return x.DueTriggers
+	}
+	return 0
+}
+
+func (x *SystemStats) GetSchedulerLagSeconds() int64 {
+	if x != nil {
+		return x.SchedulerLagSeconds
+	}
+	return 0
+}
+
+func (x *SystemStats) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type MaintenanceMode struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Shown to users whose requests are rejected.
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Since  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	// Agent turns still in progress. Maintenance is safe once this is 0.
+	ActiveTurns   int32 `protobuf:"varint,4,opt,name=active_turns,json=activeTurns,proto3" json:"active_turns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceMode) Reset() {
+	*x = MaintenanceMode{}
+	mi := &file_system_system_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceMode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceMode) ProtoMessage() {}
+
+func (x *MaintenanceMode) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceMode.ProtoReflect.Descriptor instead.
+func (*MaintenanceMode) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MaintenanceMode) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *MaintenanceMode) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MaintenanceMode) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *MaintenanceMode) GetActiveTurns() int32 {
+	if x != nil {
+		return x.ActiveTurns
+	}
+	return 0
+}
+
+type GetMaintenanceModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaintenanceModeRequest) Reset() {
+	*x = GetMaintenanceModeRequest{}
+	mi := &file_system_system_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaintenanceModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaintenanceModeRequest) ProtoMessage() {}
+
+func (x *GetMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*GetMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{4}
+}
+
+type UpdateMaintenanceModeRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Reason  string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// When enabling, how long to wait for active turns to finish before
+	// returning. 0 returns immediately; poll GetMaintenanceMode instead.
+	WaitSeconds   int32 `protobuf:"varint,3,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMaintenanceModeRequest) Reset() {
+	*x = UpdateMaintenanceModeRequest{}
+	mi := &file_system_system_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMaintenanceModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMaintenanceModeRequest) ProtoMessage() {}
+
+func (x *UpdateMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateMaintenanceModeRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpdateMaintenanceModeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateMaintenanceModeRequest) GetWaitSeconds() int32 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+type GetInstancePreambleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstancePreambleRequest) Reset() {
+	*x = GetInstancePreambleRequest{}
+	mi := &file_system_system_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstancePreambleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstancePreambleRequest) ProtoMessage() {}
+
+func (x *GetInstancePreambleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstancePreambleRequest.ProtoReflect.Descriptor instead.
+func (*GetInstancePreambleRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{6}
+}
+
+// InstancePreamble is guidance for all agents of the instance, such as
+// organization policies, prepended to their instructions on every turn.
+// "{date}" is replaced with the current date.
+type InstancePreamble struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstancePreamble) Reset() {
+	*x = InstancePreamble{}
+	mi := &file_system_system_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstancePreamble) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstancePreamble) ProtoMessage() {}
+
+func (x *InstancePreamble) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstancePreamble.ProtoReflect.Descriptor instead.
+func (*InstancePreamble) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InstancePreamble) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *InstancePreamble) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UpdateInstancePreambleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty removes the preamble.
+	Text          string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInstancePreambleRequest) Reset() {
+	*x = UpdateInstancePreambleRequest{}
+	mi := &file_system_system_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInstancePreambleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInstancePreambleRequest) ProtoMessage() {}
+
+func (x *UpdateInstancePreambleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInstancePreambleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateInstancePreambleRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateInstancePreambleRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type GetBrokerStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrokerStatsRequest) Reset() {
+	*x = GetBrokerStatsRequest{}
+	mi := &file_system_system_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrokerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrokerStatsRequest) ProtoMessage() {}
+
+func (x *GetBrokerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrokerStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetBrokerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{9}
+}
+
+type SubscriptionStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Topic patterns, e.g. "conversation:<id>" or "agent:*".
+	Topics    []string               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Events waiting to be received by the subscriber, of capacity.
+	Buffered int32 `protobuf:"varint,3,opt,name=buffered,proto3" json:"buffered,omitempty"`
+	Capacity int32 `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// Events the subscriber missed because it didn't keep up.
+	Dropped       int64 `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriptionStats) Reset() {
+	*x = SubscriptionStats{}
+	mi := &file_system_system_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionStats) ProtoMessage() {}
+
+func (x *SubscriptionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionStats.ProtoReflect.Descriptor instead.
+func (*SubscriptionStats) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscriptionStats) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *SubscriptionStats) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SubscriptionStats) GetBuffered() int32 {
+	if x != nil {
+		return x.Buffered
+	}
+	return 0
+}
+
+func (x *SubscriptionStats) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *SubscriptionStats) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// Event broker state, to debug clients that stop getting updates.
+type BrokerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*SubscriptionStats   `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	// Conversations with an active agent turn.
+	BusyConversationIds []string `protobuf:"bytes,2,rep,name=busy_conversation_ids,json=busyConversationIds,proto3" json:"busy_conversation_ids,omitempty"`
+	// Counted since the server started.
+	PublishedEvents int64 `protobuf:"varint,3,opt,name=published_events,json=publishedEvents,proto3" json:"published_events,omitempty"`
+	RelayedEvents   int64 `protobuf:"varint,4,opt,name=relayed_events,json=relayedEvents,proto3" json:"relayed_events,omitempty"`
+	DroppedEvents   int64 `protobuf:"varint,5,opt,name=dropped_events,json=droppedEvents,proto3" json:"dropped_events,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BrokerStats) Reset() {
+	*x = BrokerStats{}
+	mi := &file_system_system_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrokerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrokerStats) ProtoMessage() {}
+
+func (x *BrokerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrokerStats.ProtoReflect.Descriptor instead.
+func (*BrokerStats) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BrokerStats) GetSubscriptions() []*SubscriptionStats {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *BrokerStats) GetBusyConversationIds() []string {
+	if x != nil {
+		return x.BusyConversationIds
+	}
+	return nil
+}
+
+func (x *BrokerStats) GetPublishedEvents() int64 {
+	if x != nil {
+		return x.PublishedEvents
+	}
+	return 0
+}
+
+func (x *BrokerStats) GetRelayedEvents() int64 {
+	if x != nil {
+		return x.RelayedEvents
+	}
+	return 0
+}
+
+func (x *BrokerStats) GetDroppedEvents() int64 {
+	if x != nil {
+		return x.DroppedEvents
+	}
+	return 0
+}
+
+// ActiveRun is an agent turn in progress on the server.
+type ActiveRun struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string                 `protobuf:"bytes,4,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	// What started the run: "interactive", "trigger", "webhook", "subagent",
+	// "eval", "replay" or "autonomous".
+	Kind string `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Greater than 0 for turns of agents called by other agents.
+	Depth     int32                  `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Priority the run had while waiting for the agent's concurrency limit;
+	// higher starts first.
+	Priority      int32 `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveRun) Reset() {
+	*x = ActiveRun{}
+	mi := &file_system_system_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveRun) ProtoMessage() {}
+
+func (x *ActiveRun) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveRun.ProtoReflect.Descriptor instead.
+func (*ActiveRun) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ActiveRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ActiveRun) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ActiveRun) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ActiveRun) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *ActiveRun) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ActiveRun) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ActiveRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ActiveRun) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type ListActiveRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveRunsRequest) Reset() {
+	*x = ListActiveRunsRequest{}
+	mi := &file_system_system_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveRunsRequest) ProtoMessage() {}
+
+func (x *ListActiveRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListActiveRunsRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListActiveRunsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ListActiveRunsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Runs          []*ActiveRun `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveRunsResponse) Reset() {
+	*x = ListActiveRunsResponse{}
+	mi := &file_system_system_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveRunsResponse) ProtoMessage() {}
+
+func (x *ListActiveRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListActiveRunsResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListActiveRunsResponse) GetRuns() []*ActiveRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type CancelRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRunRequest) Reset() {
+	*x = CancelRunRequest{}
+	mi := &file_system_system_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRunRequest) ProtoMessage() {}
+
+func (x *CancelRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRunRequest.ProtoReflect.Descriptor instead.
+func (*CancelRunRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelRunRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelRunResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRunResponse) Reset() {
+	*x = CancelRunResponse{}
+	mi := &file_system_system_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRunResponse) ProtoMessage() {}
+
+func (x *CancelRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRunResponse.ProtoReflect.Descriptor instead.
+func (*CancelRunResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{16}
+}
+
+// PendingApproval is a tool call waiting for approval, because it matched an
+// approval rule of its agent.
+type PendingApproval struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RunId          string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName      string                 `protobuf:"bytes,5,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	ToolName       string                 `protobuf:"bytes,6,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	// JSON arguments of the call.
+	Input string `protobuf:"bytes,7,opt,name=input,proto3" json:"input,omitempty"`
+	// The rule the call matched, e.g. `bash command matches "rm|curl"`.
+	Reason      string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// When the call is denied if it isn't resolved.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingApproval) Reset() {
+	*x = PendingApproval{}
+	mi := &file_system_system_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingApproval) ProtoMessage() {}
+
+func (x *PendingApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingApproval.ProtoReflect.Descriptor instead.
+func (*PendingApproval) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PendingApproval) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *PendingApproval) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ListPendingApprovalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingApprovalsRequest) Reset() {
+	*x = ListPendingApprovalsRequest{}
+	mi := &file_system_system_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingApprovalsRequest) ProtoMessage() {}
+
+func (x *ListPendingApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListPendingApprovalsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ListPendingApprovalsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Approvals     []*PendingApproval `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingApprovalsResponse) Reset() {
+	*x = ListPendingApprovalsResponse{}
+	mi := &file_system_system_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingApprovalsResponse) ProtoMessage() {}
+
+func (x *ListPendingApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPendingApprovalsResponse) GetApprovals() []*PendingApproval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+type ResolveApprovalRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Approved bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	// Why the call is denied, reported to the agent.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveApprovalRequest) Reset() {
+	*x = ResolveApprovalRequest{}
+	mi := &file_system_system_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveApprovalRequest) ProtoMessage() {}
+
+func (x *ResolveApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveApprovalRequest.ProtoReflect.Descriptor instead.
+func (*ResolveApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResolveApprovalRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResolveApprovalRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ResolveApprovalRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResolveApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveApprovalResponse) Reset() {
+	*x = ResolveApprovalResponse{}
+	mi := &file_system_system_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveApprovalResponse) ProtoMessage() {}
+
+func (x *ResolveApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveApprovalResponse.ProtoReflect.Descriptor instead.
+func (*ResolveApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{21}
+}
+
+type ReplayTurnRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The recorded run to replay.
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Replays the latest recorded turn of the conversation if run_id is empty.
+	ConversationId string `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReplayTurnRequest) Reset() {
+	*x = ReplayTurnRequest{}
+	mi := &file_system_system_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayTurnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayTurnRequest) ProtoMessage() {}
+
+func (x *ReplayTurnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayTurnRequest.ProtoReflect.Descriptor instead.
+func (*ReplayTurnRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReplayTurnRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ReplayTurnRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ReplayTurnResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The new conversation the turn was replayed in.
+	ConversationId string `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Response       string `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	// The error the replayed turn ended with, e.g. when it diverged from the
+	// recording.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayTurnResponse) Reset() {
+	*x = ReplayTurnResponse{}
+	mi := &file_system_system_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayTurnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayTurnResponse) ProtoMessage() {}
+
+func (x *ReplayTurnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayTurnResponse.ProtoReflect.Descriptor instead.
+func (*ReplayTurnResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReplayTurnResponse) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ReplayTurnResponse) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *ReplayTurnResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetRunTraceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	RunId string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Gets the trace of the latest run of the conversation if run_id is empty.
+	ConversationId string `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetRunTraceRequest) Reset() {
+	*x = GetRunTraceRequest{}
+	mi := &file_system_system_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRunTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRunTraceRequest) ProtoMessage() {}
+
+func (x *GetRunTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRunTraceRequest.ProtoReflect.Descriptor instead.
+func (*GetRunTraceRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetRunTraceRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *GetRunTraceRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+// TraceToolCall is a tool call of an LLM round-trip. The calls of a
+// round-trip run concurrently, so their durations overlap.
+type TraceToolCall struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CallId      string                 `protobuf:"bytes,2,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	DurationMs  int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	ResultBytes int64                  `protobuf:"varint,4,opt,name=result_bytes,json=resultBytes,proto3" json:"result_bytes,omitempty"`
+	// Whether the tool returned an error.
+	Error         bool `protobuf:"varint,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceToolCall) Reset() {
+	*x = TraceToolCall{}
+	mi := &file_system_system_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceToolCall) ProtoMessage() {}
+
+func (x *TraceToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceToolCall.ProtoReflect.Descriptor instead.
+func (*TraceToolCall) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TraceToolCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TraceToolCall) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *TraceToolCall) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *TraceToolCall) GetResultBytes() int64 {
+	if x != nil {
+		return x.ResultBytes
+	}
+	return 0
+}
+
+func (x *TraceToolCall) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+// TraceRound is an LLM round-trip of a run.
+type TraceRound struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Size of the request sent to the LLM, which grows with the tool results
+	// of earlier round-trips.
+	RequestBytes int64 `protobuf:"varint,2,opt,name=request_bytes,json=requestBytes,proto3" json:"request_bytes,omitempty"`
+	// Time until the LLM started streaming.
+	FirstEventMs int64 `protobuf:"varint,3,opt,name=first_event_ms,json=firstEventMs,proto3" json:"first_event_ms,omitempty"`
+	// Time until the response was complete.
+	LatencyMs     int64            `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	InputTokens   int64            `protobuf:"varint,5,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64            `protobuf:"varint,6,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	Error         string           `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	ToolCalls     []*TraceToolCall `protobuf:"bytes,8,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceRound) Reset() {
+	*x = TraceRound{}
+	mi := &file_system_system_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceRound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceRound) ProtoMessage() {}
+
+func (x *TraceRound) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceRound.ProtoReflect.Descriptor instead.
+func (*TraceRound) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TraceRound) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *TraceRound) GetRequestBytes() int64 {
+	if x != nil {
+		return x.RequestBytes
+	}
+	return 0
+}
+
+func (x *TraceRound) GetFirstEventMs() int64 {
+	if x != nil {
+		return x.FirstEventMs
+	}
+	return 0
+}
+
+func (x *TraceRound) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *TraceRound) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *TraceRound) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *TraceRound) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TraceRound) GetToolCalls() []*TraceToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+// RunTrace is the execution trace of a finished run.
+type RunTrace struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RunId          string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Kind           string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Model          string                 `protobuf:"bytes,5,opt,name=model,proto3" json:"model,omitempty"`
+	// "completed", "failed", "cancelled", "interrupted" or "timed_out".
+	Status       string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Error        string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	InputTokens  int64                  `protobuf:"varint,8,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens int64                  `protobuf:"varint,9,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	StartedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// Time spent waiting for a slot of the agent's max_concurrent_runs.
+	QueuedMs      int64         `protobuf:"varint,12,opt,name=queued_ms,json=queuedMs,proto3" json:"queued_ms,omitempty"`
+	Rounds        []*TraceRound `protobuf:"bytes,13,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTrace) Reset() {
+	*x = RunTrace{}
+	mi := &file_system_system_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTrace) ProtoMessage() {}
+
+func (x *RunTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTrace.ProtoReflect.Descriptor instead.
+func (*RunTrace) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RunTrace) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *RunTrace) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RunTrace) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RunTrace) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RunTrace) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *RunTrace) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RunTrace) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RunTrace) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *RunTrace) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *RunTrace) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *RunTrace) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *RunTrace) GetQueuedMs() int64 {
+	if x != nil {
+		return x.QueuedMs
+	}
+	return 0
+}
+
+func (x *RunTrace) GetRounds() []*TraceRound {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+type ExportManifestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stores the manifest as a blob, kept for a day, and returns a signed
+	// download_url instead of the manifest.
+	Download      bool `protobuf:"varint,1,opt,name=download,proto3" json:"download,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportManifestRequest) Reset() {
+	*x = ExportManifestRequest{}
+	mi := &file_system_system_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportManifestRequest) ProtoMessage() {}
+
+func (x *ExportManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportManifestRequest.ProtoReflect.Descriptor instead.
+func (*ExportManifestRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportManifestRequest) GetDownload() bool {
+	if x != nil {
+		return x.Download
+	}
+	return false
+}
+
+type ExportManifestResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The instance configuration as JSON, like `blippy export` writes it.
+	// Secret values are redacted.
+	Manifest string `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	// Path of the signed URL to download the manifest from, valid for an
+	// hour, if download was requested.
+	DownloadUrl   string `protobuf:"bytes,2,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportManifestResponse) Reset() {
+	*x = ExportManifestResponse{}
+	mi := &file_system_system_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportManifestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportManifestResponse) ProtoMessage() {}
+
+func (x *ExportManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportManifestResponse.ProtoReflect.Descriptor instead.
+func (*ExportManifestResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportManifestResponse) GetManifest() string {
+	if x != nil {
+		return x.Manifest
+	}
+	return ""
+}
+
+func (x *ExportManifestResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+type GetVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	mi := &file_system_system_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{30}
+}
+
+type Version struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The version of the running binary, e.g. "v1.2.3", or "(devel)".
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Whether the background check for new releases is enabled, with
+	// UPDATE_CHECK=1.
+	UpdateCheckEnabled bool `protobuf:"varint,2,opt,name=update_check_enabled,json=updateCheckEnabled,proto3" json:"update_check_enabled,omitempty"`
+	// The latest release on GitHub, as last checked; empty if the check is
+	// disabled or hasn't succeeded yet.
+	LatestVersion string                 `protobuf:"bytes,3,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	ReleaseUrl    string                 `protobuf:"bytes,4,opt,name=release_url,json=releaseUrl,proto3" json:"release_url,omitempty"`
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// Whether the latest release is newer than the running version.
+	UpdateAvailable bool `protobuf:"varint,6,opt,name=update_available,json=updateAvailable,proto3" json:"update_available,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Version) Reset() {
+	*x = Version{}
+	mi := &file_system_system_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Version) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Version) ProtoMessage() {}
+
+func (x *Version) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Version.ProtoReflect.Descriptor instead.
+func (*Version) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Version) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Version) GetUpdateCheckEnabled() bool {
+	if x != nil {
+		return x.UpdateCheckEnabled
+	}
+	return false
+}
+
+func (x *Version) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *Version) GetReleaseUrl() string {
+	if x != nil {
+		return x.ReleaseUrl
+	}
+	return ""
+}
+
+func (x *Version) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *Version) GetUpdateAvailable() bool {
+	if x != nil {
+		return x.UpdateAvailable
+	}
+	return false
+}
+
+type GetUsageReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How far back to report, in hours. Defaults to 24.
+	PeriodHours   int32 `protobuf:"varint,1,opt,name=period_hours,json=periodHours,proto3" json:"period_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageReportRequest) Reset() {
+	*x = GetUsageReportRequest{}
+	mi := &file_system_system_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageReportRequest) ProtoMessage() {}
+
+func (x *GetUsageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageReportRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageReportRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetUsageReportRequest) GetPeriodHours() int32 {
+	if x != nil {
+		return x.PeriodHours
+	}
+	return 0
+}
+
+// UsageReport is what each agent did and spent in the runs started in a
+// period, from the run traces. Costs are estimated with the current
+// OpenRouter prices.
+type UsageReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	Agents        []*AgentUsage          `protobuf:"bytes,3,rep,name=agents,proto3" json:"agents,omitempty"`
+	Total         *Usage                 `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	mi := &file_system_system_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UsageReport) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *UsageReport) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *UsageReport) GetAgents() []*AgentUsage {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *UsageReport) GetTotal() *Usage {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type AgentUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentName     string                 `protobuf:"bytes,2,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	Usage         *Usage                 `protobuf:"bytes,3,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentUsage) Reset() {
+	*x = AgentUsage{}
+	mi := &file_system_system_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentUsage) ProtoMessage() {}
+
+func (x *AgentUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentUsage.ProtoReflect.Descriptor instead.
+func (*AgentUsage) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AgentUsage) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentUsage) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *AgentUsage) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type Usage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Runs  int64                  `protobuf:"varint,1,opt,name=runs,proto3" json:"runs,omitempty"`
+	// Runs that failed or timed out.
+	FailedRuns   int64   `protobuf:"varint,2,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	InputTokens  int64   `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens int64   `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CostUsd      float64 `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// False if the price of a model wasn't known, in which case its runs
+	// don't count towards the cost.
+	Priced        bool `protobuf:"varint,6,opt,name=priced,proto3" json:"priced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_system_system_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Usage) GetRuns() int64 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *Usage) GetFailedRuns() int64 {
+	if x != nil {
+		return x.FailedRuns
+	}
+	return 0
+}
+
+func (x *Usage) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *Usage) GetPriced() bool {
+	if x != nil {
+		return x.Priced
+	}
+	return false
+}
+
+// ProviderKey is an OpenRouter API key of this replica, with its usage
+// since it was added, e.g. since the server started.
+type ProviderKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A prefix of the key's SHA-256 hash.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The last characters of the key, e.g. "…3f9a".
+	Hint     string `protobuf:"bytes,2,opt,name=hint,proto3" json:"hint,omitempty"`
+	Weight   int32  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Requests int64  `protobuf:"varint,4,opt,name=requests,proto3" json:"requests,omitempty"`
+	// Requests that failed or had an unexpected status, including rate
+	// limited ones.
+	Failures      int64                  `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	RateLimited   int64                  `protobuf:"varint,6,opt,name=rate_limited,json=rateLimited,proto3" json:"rate_limited,omitempty"`
+	InputTokens   int64                  `protobuf:"varint,7,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64                  `protobuf:"varint,8,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderKey) Reset() {
+	*x = ProviderKey{}
+	mi := &file_system_system_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderKey) ProtoMessage() {}
+
+func (x *ProviderKey) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderKey.ProtoReflect.Descriptor instead.
+func (*ProviderKey) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ProviderKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProviderKey) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+func (x *ProviderKey) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ProviderKey) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *ProviderKey) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *ProviderKey) GetRateLimited() int64 {
+	if x != nil {
+		return x.RateLimited
+	}
+	return 0
+}
+
+func (x *ProviderKey) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *ProviderKey) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *ProviderKey) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+func (x *ProviderKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+type ListProviderKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProviderKeysRequest) Reset() {
+	*x = ListProviderKeysRequest{}
+	mi := &file_system_system_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProviderKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProviderKeysRequest) ProtoMessage() {}
+
+func (x *ListProviderKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProviderKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListProviderKeysRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{37}
+}
+
+type ListProviderKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*ProviderKey         `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProviderKeysResponse) Reset() {
+	*x = ListProviderKeysResponse{}
+	mi := &file_system_system_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProviderKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProviderKeysResponse) ProtoMessage() {}
+
+func (x *ListProviderKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProviderKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListProviderKeysResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListProviderKeysResponse) GetKeys() []*ProviderKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type CreateProviderKeyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// Share of requests relative to the other keys. Defaults to 1.
+	Weight        int32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProviderKeyRequest) Reset() {
+	*x = CreateProviderKeyRequest{}
+	mi := &file_system_system_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProviderKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProviderKeyRequest) ProtoMessage() {}
+
+func (x *CreateProviderKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProviderKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateProviderKeyRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateProviderKeyRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *CreateProviderKeyRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type DeleteProviderKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProviderKeyRequest) Reset() {
+	*x = DeleteProviderKeyRequest{}
+	mi := &file_system_system_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProviderKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProviderKeyRequest) ProtoMessage() {}
+
+func (x *DeleteProviderKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProviderKeyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProviderKeyRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteProviderKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProviderKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProviderKeyResponse) Reset() {
+	*x = DeleteProviderKeyResponse{}
+	mi := &file_system_system_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProviderKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProviderKeyResponse) ProtoMessage() {}
+
+func (x *DeleteProviderKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProviderKeyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProviderKeyResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{41}
+}
+
+// LLMCapture makes the raw request and response bodies of the LLM calls of
+// an agent or conversation be stored until it expires, with secrets
+// redacted.
+type LLMCapture struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Set for captures of all conversations of an agent.
+	AgentId        string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConversationId string `protobuf:"bytes,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// How long captured exchanges are kept.
+	RetentionSeconds int64                  `protobuf:"varint,4,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LLMCapture) Reset() {
+	*x = LLMCapture{}
+	mi := &file_system_system_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLMCapture) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLMCapture) ProtoMessage() {}
+
+func (x *LLMCapture) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLMCapture.ProtoReflect.Descriptor instead.
+func (*LLMCapture) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *LLMCapture) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LLMCapture) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *LLMCapture) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *LLMCapture) GetRetentionSeconds() int64 {
+	if x != nil {
+		return x.RetentionSeconds
+	}
+	return 0
+}
+
+func (x *LLMCapture) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LLMCapture) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateLLMCaptureRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of agent_id and conversation_id is required.
+	AgentId        string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// How long to capture. Defaults to an hour, at most a day.
+	DurationSeconds int64 `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// How long to keep captured exchanges. Defaults to a day, at most a week.
+	RetentionSeconds int64 `protobuf:"varint,4,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateLLMCaptureRequest) Reset() {
+	*x = CreateLLMCaptureRequest{}
+	mi := &file_system_system_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLLMCaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLLMCaptureRequest) ProtoMessage() {}
+
+func (x *CreateLLMCaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLLMCaptureRequest.ProtoReflect.Descriptor instead.
+func (*CreateLLMCaptureRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateLLMCaptureRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CreateLLMCaptureRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *CreateLLMCaptureRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *CreateLLMCaptureRequest) GetRetentionSeconds() int64 {
+	if x != nil {
+		return x.RetentionSeconds
+	}
+	return 0
+}
+
+type ListLLMCapturesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLLMCapturesRequest) Reset() {
+	*x = ListLLMCapturesRequest{}
+	mi := &file_system_system_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLLMCapturesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLLMCapturesRequest) ProtoMessage() {}
+
+func (x *ListLLMCapturesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLLMCapturesRequest.ProtoReflect.Descriptor instead.
+func (*ListLLMCapturesRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{44}
+}
+
+type ListLLMCapturesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Captures      []*LLMCapture          `protobuf:"bytes,1,rep,name=captures,proto3" json:"captures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLLMCapturesResponse) Reset() {
+	*x = ListLLMCapturesResponse{}
+	mi := &file_system_system_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLLMCapturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLLMCapturesResponse) ProtoMessage() {}
+
+func (x *ListLLMCapturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLLMCapturesResponse.ProtoReflect.Descriptor instead.
+func (*ListLLMCapturesResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListLLMCapturesResponse) GetCaptures() []*LLMCapture {
+	if x != nil {
+		return x.Captures
+	}
+	return nil
+}
+
+type DeleteLLMCaptureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLLMCaptureRequest) Reset() {
+	*x = DeleteLLMCaptureRequest{}
+	mi := &file_system_system_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLLMCaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLLMCaptureRequest) ProtoMessage() {}
+
+func (x *DeleteLLMCaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLLMCaptureRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLLMCaptureRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteLLMCaptureRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteLLMCaptureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLLMCaptureResponse) Reset() {
+	*x = DeleteLLMCaptureResponse{}
+	mi := &file_system_system_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLLMCaptureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLLMCaptureResponse) ProtoMessage() {}
+
+func (x *DeleteLLMCaptureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLLMCaptureResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLLMCaptureResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{47}
+}
+
+// LLMExchange is a captured LLM call. Bodies are cut off at 1 MiB.
+type LLMExchange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaptureId      string                 `protobuf:"bytes,2,opt,name=capture_id,json=captureId,proto3" json:"capture_id,omitempty"`
+	RunId          string                 `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,4,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// The LLM round-trip of the run, starting at 1.
+	Round int32  `protobuf:"varint,6,opt,name=round,proto3" json:"round,omitempty"`
+	Url   string `protobuf:"bytes,7,opt,name=url,proto3" json:"url,omitempty"`
+	// ID of the OpenRouter API key the request was made with.
+	KeyId       string `protobuf:"bytes,8,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	RequestBody string `protobuf:"bytes,9,opt,name=request_body,json=requestBody,proto3" json:"request_body,omitempty"`
+	// 0 if no response was received.
+	StatusCode      int32             `protobuf:"varint,10,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ResponseHeaders map[string]string `protobuf:"bytes,11,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The response as received, i.e. the event stream of streaming requests.
+	ResponseBody  string                 `protobuf:"bytes,12,opt,name=response_body,json=responseBody,proto3" json:"response_body,omitempty"`
+	Truncated     bool                   `protobuf:"varint,13,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Error         string                 `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LLMExchange) Reset() {
+	*x = LLMExchange{}
+	mi := &file_system_system_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLMExchange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLMExchange) ProtoMessage() {}
+
+func (x *LLMExchange) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLMExchange.ProtoReflect.Descriptor instead.
+func (*LLMExchange) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *LLMExchange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetCaptureId() string {
+	if x != nil {
+		return x.CaptureId
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *LLMExchange) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetRequestBody() string {
+	if x != nil {
+		return x.RequestBody
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *LLMExchange) GetResponseHeaders() map[string]string {
+	if x != nil {
+		return x.ResponseHeaders
+	}
+	return nil
+}
+
+func (x *LLMExchange) GetResponseBody() string {
+	if x != nil {
+		return x.ResponseBody
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *LLMExchange) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LLMExchange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListLLMExchangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of conversation_id and run_id is required.
+	ConversationId string `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	RunId          string `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	PageSize       int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListLLMExchangesRequest) Reset() {
+	*x = ListLLMExchangesRequest{}
+	mi := &file_system_system_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLLMExchangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLLMExchangesRequest) ProtoMessage() {}
+
+func (x *ListLLMExchangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLLMExchangesRequest.ProtoReflect.Descriptor instead.
+func (*ListLLMExchangesRequest) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListLLMExchangesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ListLLMExchangesRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ListLLMExchangesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLLMExchangesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListLLMExchangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exchanges     []*LLMExchange         `protobuf:"bytes,1,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLLMExchangesResponse) Reset() {
+	*x = ListLLMExchangesResponse{}
+	mi := &file_system_system_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLLMExchangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLLMExchangesResponse) ProtoMessage() {}
+
+func (x *ListLLMExchangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_system_system_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLLMExchangesResponse.ProtoReflect.Descriptor instead.
+func (*ListLLMExchangesResponse) Descriptor() ([]byte, []int) {
+	return file_system_system_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListLLMExchangesResponse) GetExchanges() []*LLMExchange {
+	if x != nil {
+		return x.Exchanges
+	}
+	return nil
+}
+
+func (x *ListLLMExchangesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListLLMExchangesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+var File_system_system_proto protoreflect.FileDescriptor
+
+const file_system_system_proto_rawDesc = "" +
+	"\n" +
+	"\x13system/system.proto\x12\rblippy.system\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcd\x01\n" +
+	"\n" +
+	"TableStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\trow_count\x18\x02 \x01(\x03R\browCount\x12F\n" +
+	"\x11oldest_created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0foldestCreatedAt\x12F\n" +
+	"\x11newest_created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0fnewestCreatedAt\"\x17\n" +
+	"\x15GetSystemStatsRequest\"\xbe\x03\n" +
+	"\vSystemStats\x121\n" +
+	"\x06tables\x18\x01 \x03(\v2\x19.blippy.system.TableStatsR\x06tables\x12.\n" +
+	"\x13database_size_bytes\x18\x02 \x01(\x03R\x11databaseSizeBytes\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\x05R\rschemaVersion\x122\n" +
+	"\x15latest_schema_version\x18\x04 \x01(\x05R\x13latestSchemaVersion\x12-\n" +
+	"\x12pending_migrations\x18\x05 \x03(\tR\x11pendingMigrations\x12O\n" +
+	"\x16scheduler_last_tick_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x13schedulerLastTickAt\x12!\n" +
+	"\fdue_triggers\x18\a \x01(\x05R\vdueTriggers\x122\n" +
+	"\x15scheduler_lag_seconds\x18\b \x01(\x03R\x13schedulerLagSeconds\x12\x1a\n" +
+	"\bwarnings\x18\t \x03(\tR\bwarnings\"\x98\x01\n" +
+	"\x0fMaintenanceMode\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12!\n" +
+	"\factive_turns\x18\x04 \x01(\x05R\vactiveTurns\"\x1b\n" +
+	"\x19GetMaintenanceModeRequest\"s\n" +
+	"\x1cUpdateMaintenanceModeRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12!\n" +
+	"\fwait_seconds\x18\x03 \x01(\x05R\vwaitSeconds\"\x1c\n" +
+	"\x1aGetInstancePreambleRequest\"a\n" +
+	"\x10InstancePreamble\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"3\n" +
+	"\x1dUpdateInstancePreambleRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\x17\n" +
+	"\x15GetBrokerStatsRequest\"\xb8\x01\n" +
+	"\x11SubscriptionStats\x12\x16\n" +
+	"\x06topics\x18\x01 \x03(\tR\x06topics\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1a\n" +
+	"\bbuffered\x18\x03 \x01(\x05R\bbuffered\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12\x18\n" +
+	"\adropped\x18\x05 \x01(\x03R\adropped\"\x82\x02\n" +
+	"\vBrokerStats\x12F\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2 .blippy.system.SubscriptionStatsR\rsubscriptions\x122\n" +
+	"\x15busy_conversation_ids\x18\x02 \x03(\tR\x13busyConversationIds\x12)\n" +
+	"\x10published_events\x18\x03 \x01(\x03R\x0fpublishedEvents\x12%\n" +
+	"\x0erelayed_events\x18\x04 \x01(\x03R\rrelayedEvents\x12%\n" +
+	"\x0edropped_events\x18\x05 \x01(\x03R\rdroppedEvents\"\xff\x01\n" +
+	"\tActiveRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x04 \x01(\tR\tagentName\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05depth\x18\x06 \x01(\x05R\x05depth\x129\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\"2\n" +
+	"\x15ListActiveRunsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"F\n" +
+	"\x16ListActiveRunsResponse\x12,\n" +
+	"\x04runs\x18\x01 \x03(\v2\x18.blippy.system.ActiveRunR\x04runs\"\"\n" +
+	"\x10CancelRunRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x13\n" +
+	"\x11CancelRunResponse\"\xe0\x02\n" +
+	"\x0fPendingApproval\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x05 \x01(\tR\tagentName\x12\x1b\n" +
+	"\ttool_name\x18\x06 \x01(\tR\btoolName\x12\x14\n" +
+	"\x05input\x18\a \x01(\tR\x05input\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12=\n" +
+	"\frequested_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"8\n" +
+	"\x1bListPendingApprovalsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\\\n" +
+	"\x1cListPendingApprovalsResponse\x12<\n" +
+	"\tapprovals\x18\x01 \x03(\v2\x1e.blippy.system.PendingApprovalR\tapprovals\"\\\n" +
+	"\x16ResolveApprovalRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x19\n" +
+	"\x17ResolveApprovalResponse\"S\n" +
+	"\x11ReplayTurnRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\"o\n" +
+	"\x12ReplayTurnResponse\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1a\n" +
+	"\bresponse\x18\x02 \x01(\tR\bresponse\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"T\n" +
+	"\x12GetRunTraceRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\"\x96\x01\n" +
+	"\rTraceToolCall\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\acall_id\x18\x02 \x01(\tR\x06callId\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\x12!\n" +
+	"\fresult_bytes\x18\x04 \x01(\x03R\vresultBytes\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\bR\x05error\"\xcc\x02\n" +
+	"\n" +
+	"TraceRound\x129\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12#\n" +
+	"\rrequest_bytes\x18\x02 \x01(\x03R\frequestBytes\x12$\n" +
+	"\x0efirst_event_ms\x18\x03 \x01(\x03R\ffirstEventMs\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\x12!\n" +
+	"\finput_tokens\x18\x05 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x06 \x01(\x03R\foutputTokens\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12;\n" +
+	"\n" +
+	"tool_calls\x18\b \x03(\v2\x1c.blippy.system.TraceToolCallR\ttoolCalls\"\xcd\x03\n" +
+	"\bRunTrace\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\tR\x0econversationId\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05model\x18\x05 \x01(\tR\x05model\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12!\n" +
+	"\finput_tokens\x18\b \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\t \x01(\x03R\foutputTokens\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x1b\n" +
+	"\tqueued_ms\x18\f \x01(\x03R\bqueuedMs\x121\n" +
+	"\x06rounds\x18\r \x03(\v2\x19.blippy.system.TraceRoundR\x06rounds\"3\n" +
+	"\x15ExportManifestRequest\x12\x1a\n" +
+	"\bdownload\x18\x01 \x01(\bR\bdownload\"W\n" +
+	"\x16ExportManifestResponse\x12\x1a\n" +
+	"\bmanifest\x18\x01 \x01(\tR\bmanifest\x12!\n" +
+	"\fdownload_url\x18\x02 \x01(\tR\vdownloadUrl\"\x13\n" +
+	"\x11GetVersionRequest\"\x83\x02\n" +
+	"\aVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x120\n" +
+	"\x14update_check_enabled\x18\x02 \x01(\bR\x12updateCheckEnabled\x12%\n" +
+	"\x0elatest_version\x18\x03 \x01(\tR\rlatestVersion\x12\x1f\n" +
+	"\vrelease_url\x18\x04 \x01(\tR\n" +
+	"releaseUrl\x129\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12)\n" +
+	"\x10update_available\x18\x06 \x01(\bR\x0fupdateAvailable\":\n" +
+	"\x15GetUsageReportRequest\x12!\n" +
+	"\fperiod_hours\x18\x01 \x01(\x05R\vperiodHours\"\xd0\x01\n" +
+	"\vUsageReport\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x121\n" +
+	"\x06agents\x18\x03 \x03(\v2\x19.blippy.system.AgentUsageR\x06agents\x12*\n" +
+	"\x05total\x18\x04 \x01(\v2\x14.blippy.system.UsageR\x05total\"r\n" +
+	"\n" +
+	"AgentUsage\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x02 \x01(\tR\tagentName\x12*\n" +
+	"\x05usage\x18\x03 \x01(\v2\x14.blippy.system.UsageR\x05usage\"\xb7\x01\n" +
+	"\x05Usage\x12\x12\n" +
+	"\x04runs\x18\x01 \x01(\x03R\x04runs\x12\x1f\n" +
+	"\vfailed_runs\x18\x02 \x01(\x03R\n" +
+	"failedRuns\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\x12\x19\n" +
+	"\bcost_usd\x18\x05 \x01(\x01R\acostUsd\x12\x16\n" +
+	"\x06priced\x18\x06 \x01(\bR\x06priced\"\xe1\x02\n" +
+	"\vProviderKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04hint\x18\x02 \x01(\tR\x04hint\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x12\x1a\n" +
+	"\brequests\x18\x04 \x01(\x03R\brequests\x12\x1a\n" +
+	"\bfailures\x18\x05 \x01(\x03R\bfailures\x12!\n" +
+	"\frate_limited\x18\x06 \x01(\x03R\vrateLimited\x12!\n" +
+	"\finput_tokens\x18\a \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\b \x01(\x03R\foutputTokens\x125\n" +
+	"\badded_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\x12<\n" +
+	"\flast_used_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\"\x19\n" +
+	"\x17ListProviderKeysRequest\"J\n" +
+	"\x18ListProviderKeysResponse\x12.\n" +
+	"\x04keys\x18\x01 \x03(\v2\x1a.blippy.system.ProviderKeyR\x04keys\"K\n" +
+	"\x18CreateProviderKeyRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x05R\x06weight\"*\n" +
+	"\x18DeleteProviderKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1b\n" +
+	"\x19DeleteProviderKeyResponse\"\x83\x02\n" +
+	"\n" +
+	"LLMCapture\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\tR\x0econversationId\x12+\n" +
+	"\x11retention_seconds\x18\x04 \x01(\x03R\x10retentionSeconds\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb5\x01\n" +
+	"\x17CreateLLMCaptureRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x03R\x0fdurationSeconds\x12+\n" +
+	"\x11retention_seconds\x18\x04 \x01(\x03R\x10retentionSeconds\"\x18\n" +
+	"\x16ListLLMCapturesRequest\"P\n" +
+	"\x17ListLLMCapturesResponse\x125\n" +
+	"\bcaptures\x18\x01 \x03(\v2\x19.blippy.system.LLMCaptureR\bcaptures\")\n" +
+	"\x17DeleteLLMCaptureRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1a\n" +
+	"\x18DeleteLLMCaptureResponse\"\xce\x04\n" +
+	"\vLLMExchange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"capture_id\x18\x02 \x01(\tR\tcaptureId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x12'\n" +
+	"\x0fconversation_id\x18\x04 \x01(\tR\x0econversationId\x12\x19\n" +
+	"\bagent_id\x18\x05 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05round\x18\x06 \x01(\x05R\x05round\x12\x10\n" +
+	"\x03url\x18\a \x01(\tR\x03url\x12\x15\n" +
+	"\x06key_id\x18\b \x01(\tR\x05keyId\x12!\n" +
+	"\frequest_body\x18\t \x01(\tR\vrequestBody\x12\x1f\n" +
+	"\vstatus_code\x18\n" +
+	" \x01(\x05R\n" +
+	"statusCode\x12Z\n" +
+	"\x10response_headers\x18\v \x03(\v2/.blippy.system.LLMExchange.ResponseHeadersEntryR\x0fresponseHeaders\x12#\n" +
+	"\rresponse_body\x18\f \x01(\tR\fresponseBody\x12\x1c\n" +
+	"\ttruncated\x18\r \x01(\bR\ttruncated\x12\x14\n" +
+	"\x05error\x18\x0e \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1aB\n" +
+	"\x14ResponseHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x95\x01\n" +
+	"\x17ListLLMExchangesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x9b\x01\n" +
+	"\x18ListLLMExchangesResponse\x128\n" +
+	"\texchanges\x18\x01 \x03(\v2\x1a.blippy.system.LLMExchangeR\texchanges\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize2\x8e\x10\n" +
+	"\rSystemService\x12R\n" +
+	"\x0eGetSystemStats\x12$.blippy.system.GetSystemStatsRequest\x1a\x1a.blippy.system.SystemStats\x12^\n" +
+	"\x12GetMaintenanceMode\x12(.blippy.system.GetMaintenanceModeRequest\x1a\x1e.blippy.system.MaintenanceMode\x12d\n" +
+	"\x15UpdateMaintenanceMode\x12+.blippy.system.UpdateMaintenanceModeRequest\x1a\x1e.blippy.system.MaintenanceMode\x12a\n" +
+	"\x13GetInstancePreamble\x12).blippy.system.GetInstancePreambleRequest\x1a\x1f.blippy.system.InstancePreamble\x12g\n" +
+	"\x16UpdateInstancePreamble\x12,.blippy.system.UpdateInstancePreambleRequest\x1a\x1f.blippy.system.InstancePreamble\x12R\n" +
+	"\x0eGetBrokerStats\x12$.blippy.system.GetBrokerStatsRequest\x1a\x1a.blippy.system.BrokerStats\x12]\n" +
+	"\x0eListActiveRuns\x12$.blippy.system.ListActiveRunsRequest\x1a%.blippy.system.ListActiveRunsResponse\x12N\n" +
+	"\tCancelRun\x12\x1f.blippy.system.CancelRunRequest\x1a .blippy.system.CancelRunResponse\x12o\n" +
+	"\x14ListPendingApprovals\x12*.blippy.system.ListPendingApprovalsRequest\x1a+.blippy.system.ListPendingApprovalsResponse\x12`\n" +
+	"\x0fResolveApproval\x12%.blippy.system.ResolveApprovalRequest\x1a&.blippy.system.ResolveApprovalResponse\x12Q\n" +
+	"\n" +
+	"ReplayTurn\x12 .blippy.system.ReplayTurnRequest\x1a!.blippy.system.ReplayTurnResponse\x12I\n" +
+	"\vGetRunTrace\x12!.blippy.system.GetRunTraceRequest\x1a\x17.blippy.system.RunTrace\x12]\n" +
+	"\x0eExportManifest\x12$.blippy.system.ExportManifestRequest\x1a%.blippy.system.ExportManifestResponse\x12F\n" +
+	"\n" +
+	"GetVersion\x12 .blippy.system.GetVersionRequest\x1a\x16.blippy.system.Version\x12R\n" +
+	"\x0eGetUsageReport\x12$.blippy.system.GetUsageReportRequest\x1a\x1a.blippy.system.UsageReport\x12c\n" +
+	"\x10ListProviderKeys\x12&.blippy.system.ListProviderKeysRequest\x1a'.blippy.system.ListProviderKeysResponse\x12X\n" +
+	"\x11CreateProviderKey\x12'.blippy.system.CreateProviderKeyRequest\x1a\x1a.blippy.system.ProviderKey\x12f\n" +
+	"\x11DeleteProviderKey\x12'.blippy.system.DeleteProviderKeyRequest\x1a(.blippy.system.DeleteProviderKeyResponse\x12U\n" +
+	"\x10CreateLLMCapture\x12&.blippy.system.CreateLLMCaptureRequest\x1a\x19.blippy.system.LLMCapture\x12`\n" +
+	"\x0fListLLMCaptures\x12%.blippy.system.ListLLMCapturesRequest\x1a&.blippy.system.ListLLMCapturesResponse\x12c\n" +
+	"\x10DeleteLLMCapture\x12&.blippy.system.DeleteLLMCaptureRequest\x1a'.blippy.system.DeleteLLMCaptureResponse\x12c\n" +
+	"\x10ListLLMExchanges\x12&.blippy.system.ListLLMExchangesRequest\x1a'.blippy.system.ListLLMExchangesResponseB,Z*github.com/dstotijn/blippy/internal/systemb\x06proto3"
+
+var (
+	file_system_system_proto_rawDescOnce sync.Once
+	file_system_system_proto_rawDescData []byte
+)
+
+func file_system_system_proto_rawDescGZIP() []byte {
+	file_system_system_proto_rawDescOnce.Do(func() {
+		file_system_system_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_system_system_proto_rawDesc), len(file_system_system_proto_rawDesc)))
+	})
+	return file_system_system_proto_rawDescData
+}
+
+var file_system_system_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_system_system_proto_goTypes = []any{
+	(*TableStats)(nil),                    // 0: blippy.system.TableStats
+	(*GetSystemStatsRequest)(nil),         // 1: blippy.system.GetSystemStatsRequest
+	(*SystemStats)(nil),                   // 2: blippy.system.SystemStats
+	(*MaintenanceMode)(nil),               // 3: blippy.system.MaintenanceMode
+	(*GetMaintenanceModeRequest)(nil),     // 4: blippy.system.GetMaintenanceModeRequest
+	(*UpdateMaintenanceModeRequest)(nil),  // 5: blippy.system.UpdateMaintenanceModeRequest
+	(*GetInstancePreambleRequest)(nil),    // 6: blippy.system.GetInstancePreambleRequest
+	(*InstancePreamble)(nil),              // 7: blippy.system.InstancePreamble
+	(*UpdateInstancePreambleRequest)(nil), // 8: blippy.system.UpdateInstancePreambleRequest
+	(*GetBrokerStatsRequest)(nil),         // 9: blippy.system.GetBrokerStatsRequest
+	(*SubscriptionStats)(nil),             // 10: blippy.system.SubscriptionStats
+	(*BrokerStats)(nil),                   // 11: blippy.system.BrokerStats
+	(*ActiveRun)(nil),                     // 12: blippy.system.ActiveRun
+	(*ListActiveRunsRequest)(nil),         // 13: blippy.system.ListActiveRunsRequest
+	(*ListActiveRunsResponse)(nil),        // 14: blippy.system.ListActiveRunsResponse
+	(*CancelRunRequest)(nil),              // 15: blippy.system.CancelRunRequest
+	(*CancelRunResponse)(nil),             // 16: blippy.system.CancelRunResponse
+	(*PendingApproval)(nil),               // 17: blippy.system.PendingApproval
+	(*ListPendingApprovalsRequest)(nil),   // 18: blippy.system.ListPendingApprovalsRequest
+	(*ListPendingApprovalsResponse)(nil),  // 19: blippy.system.ListPendingApprovalsResponse
+	(*ResolveApprovalRequest)(nil),        // 20: blippy.system.ResolveApprovalRequest
+	(*ResolveApprovalResponse)(nil),       // 21: blippy.system.ResolveApprovalResponse
+	(*ReplayTurnRequest)(nil),             // 22: blippy.system.ReplayTurnRequest
+	(*ReplayTurnResponse)(nil),            // 23: blippy.system.ReplayTurnResponse
+	(*GetRunTraceRequest)(nil),            // 24: blippy.system.GetRunTraceRequest
+	(*TraceToolCall)(nil),                 // 25: blippy.system.TraceToolCall
+	(*TraceRound)(nil),                    // 26: blippy.system.TraceRound
+	(*RunTrace)(nil),                      // 27: blippy.system.RunTrace
+	(*ExportManifestRequest)(nil),         // 28: blippy.system.ExportManifestRequest
+	(*ExportManifestResponse)(nil),        // 29: blippy.system.ExportManifestResponse
+	(*GetVersionRequest)(nil),             // 30: blippy.system.GetVersionRequest
+	(*Version)(nil),                       // 31: blippy.system.Version
+	(*GetUsageReportRequest)(nil),         // 32: blippy.system.GetUsageReportRequest
+	(*UsageReport)(nil),                   // 33: blippy.system.UsageReport
+	(*AgentUsage)(nil),                    // 34: blippy.system.AgentUsage
+	(*Usage)(nil),                         // 35: blippy.system.Usage
+	(*ProviderKey)(nil),                   // 36: blippy.system.ProviderKey
+	(*ListProviderKeysRequest)(nil),       // 37: blippy.system.ListProviderKeysRequest
+	(*ListProviderKeysResponse)(nil),      // 38: blippy.system.ListProviderKeysResponse
+	(*CreateProviderKeyRequest)(nil),      // 39: blippy.system.CreateProviderKeyRequest
+	(*DeleteProviderKeyRequest)(nil),      // 40: blippy.system.DeleteProviderKeyRequest
+	(*DeleteProviderKeyResponse)(nil),     // 41: blippy.system.DeleteProviderKeyResponse
+	(*LLMCapture)(nil),                    // 42: blippy.system.LLMCapture
+	(*CreateLLMCaptureRequest)(nil),       // 43: blippy.system.CreateLLMCaptureRequest
+	(*ListLLMCapturesRequest)(nil),        // 44: blippy.system.ListLLMCapturesRequest
+	(*ListLLMCapturesResponse)(nil),       // 45: blippy.system.ListLLMCapturesResponse
+	(*DeleteLLMCaptureRequest)(nil),       // 46: blippy.system.DeleteLLMCaptureRequest
+	(*DeleteLLMCaptureResponse)(nil),      // 47: blippy.system.DeleteLLMCaptureResponse
+	(*LLMExchange)(nil),                   // 48: blippy.system.LLMExchange
+	(*ListLLMExchangesRequest)(nil),       // 49: blippy.system.ListLLMExchangesRequest
+	(*ListLLMExchangesResponse)(nil),      // 50: blippy.system.ListLLMExchangesResponse
+	nil,                                   // 51: blippy.system.LLMExchange.ResponseHeadersEntry
+	(*timestamppb.Timestamp)(nil),         // 52: google.protobuf.Timestamp
+}
+var file_system_system_proto_depIdxs = []int32{
+	52, // 0: blippy.system.TableStats.oldest_created_at:type_name -> google.protobuf.Timestamp
+	52, // 1: blippy.system.TableStats.newest_created_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: blippy.system.SystemStats.tables:type_name -> blippy.system.TableStats
+	52, // 3: blippy.system.SystemStats.scheduler_last_tick_at:type_name -> google.protobuf.Timestamp
+	52, // 4: blippy.system.MaintenanceMode.since:type_name -> google.protobuf.Timestamp
+	52, // 5: blippy.system.InstancePreamble.updated_at:type_name -> google.protobuf.Timestamp
+	52, // 6: blippy.system.SubscriptionStats.created_at:type_name -> google.protobuf.Timestamp
+	10, // 7: blippy.system.BrokerStats.subscriptions:type_name -> blippy.system.SubscriptionStats
+	52, // 8: blippy.system.ActiveRun.started_at:type_name -> google.protobuf.Timestamp
+	12, // 9: blippy.system.ListActiveRunsResponse.runs:type_name -> blippy.system.ActiveRun
+	52, // 10: blippy.system.PendingApproval.requested_at:type_name -> google.protobuf.Timestamp
+	52, // 11: blippy.system.PendingApproval.expires_at:type_name -> google.protobuf.Timestamp
+	17, // 12: blippy.system.ListPendingApprovalsResponse.approvals:type_name -> blippy.system.PendingApproval
+	52, // 13: blippy.system.TraceRound.started_at:type_name -> google.protobuf.Timestamp
+	25, // 14: blippy.system.TraceRound.tool_calls:type_name -> blippy.system.TraceToolCall
+	52, // 15: blippy.system.RunTrace.started_at:type_name -> google.protobuf.Timestamp
+	52, // 16: blippy.system.RunTrace.finished_at:type_name -> google.protobuf.Timestamp
+	26, // 17: blippy.system.RunTrace.rounds:type_name -> blippy.system.TraceRound
+	52, // 18: blippy.system.Version.checked_at:type_name -> google.protobuf.Timestamp
+	52, // 19: blippy.system.UsageReport.since:type_name -> google.protobuf.Timestamp
+	52, // 20: blippy.system.UsageReport.until:type_name -> google.protobuf.Timestamp
+	34, // 21: blippy.system.UsageReport.agents:type_name -> blippy.system.AgentUsage
+	35, // 22: blippy.system.UsageReport.total:type_name -> blippy.system.Usage
+	35, // 23: blippy.system.AgentUsage.usage:type_name -> blippy.system.Usage
+	52, // 24: blippy.system.ProviderKey.added_at:type_name -> google.protobuf.Timestamp
+	52, // 25: blippy.system.ProviderKey.last_used_at:type_name -> google.protobuf.Timestamp
+	36, // 26: blippy.system.ListProviderKeysResponse.keys:type_name -> blippy.system.ProviderKey
+	52, // 27: blippy.system.LLMCapture.expires_at:type_name -> google.protobuf.Timestamp
+	52, // 28: blippy.system.LLMCapture.created_at:type_name -> google.protobuf.Timestamp
+	42, // 29: blippy.system.ListLLMCapturesResponse.captures:type_name -> blippy.system.LLMCapture
+	51, // 30: blippy.system.LLMExchange.response_headers:type_name -> blippy.system.LLMExchange.ResponseHeadersEntry
+	52, // 31: blippy.system.LLMExchange.created_at:type_name -> google.protobuf.Timestamp
+	48, // 32: blippy.system.ListLLMExchangesResponse.exchanges:type_name -> blippy.system.LLMExchange
+	1,  // 33: blippy.system.SystemService.GetSystemStats:input_type -> blippy.system.GetSystemStatsRequest
+	4,  // 34: blippy.system.SystemService.GetMaintenanceMode:input_type -> blippy.system.GetMaintenanceModeRequest
+	5,  // 35: blippy.system.SystemService.UpdateMaintenanceMode:input_type -> blippy.system.UpdateMaintenanceModeRequest
+	6,  // 36: blippy.system.SystemService.GetInstancePreamble:input_type -> blippy.system.GetInstancePreambleRequest
+	8,  // 37: blippy.system.SystemService.UpdateInstancePreamble:input_type -> blippy.system.UpdateInstancePreambleRequest
+	9,  // 38: blippy.system.SystemService.GetBrokerStats:input_type -> blippy.system.GetBrokerStatsRequest
+	13, // 39: blippy.system.SystemService.ListActiveRuns:input_type -> blippy.system.ListActiveRunsRequest
+	15, // 40: blippy.system.SystemService.CancelRun:input_type -> blippy.system.CancelRunRequest
+	18, // 41: blippy.system.SystemService.ListPendingApprovals:input_type -> blippy.system.ListPendingApprovalsRequest
+	20, // 42: blippy.system.SystemService.ResolveApproval:input_type -> blippy.system.ResolveApprovalRequest
+	22, // 43: blippy.system.SystemService.ReplayTurn:input_type -> blippy.system.ReplayTurnRequest
+	24, // 44: blippy.system.SystemService.GetRunTrace:input_type -> blippy.system.GetRunTraceRequest
+	28, // 45: blippy.system.SystemService.ExportManifest:input_type -> blippy.system.ExportManifestRequest
+	30, // 46: blippy.system.SystemService.GetVersion:input_type -> blippy.system.GetVersionRequest
+	32, // 47: blippy.system.SystemService.GetUsageReport:input_type -> blippy.system.GetUsageReportRequest
+	37, // 48: blippy.system.SystemService.ListProviderKeys:input_type -> blippy.system.ListProviderKeysRequest
+	39, // 49: blippy.system.SystemService.CreateProviderKey:input_type -> blippy.system.CreateProviderKeyRequest
+	40, // 50: blippy.system.SystemService.DeleteProviderKey:input_type -> blippy.system.DeleteProviderKeyRequest
+	43, // 51: blippy.system.SystemService.CreateLLMCapture:input_type -> blippy.system.CreateLLMCaptureRequest
+	44, // 52: blippy.system.SystemService.ListLLMCaptures:input_type -> blippy.system.ListLLMCapturesRequest
+	46, // 53: blippy.system.SystemService.DeleteLLMCapture:input_type -> blippy.system.DeleteLLMCaptureRequest
+	49, // 54: blippy.system.SystemService.ListLLMExchanges:input_type -> blippy.system.ListLLMExchangesRequest
+	2,  // 55: blippy.system.SystemService.GetSystemStats:output_type -> blippy.system.SystemStats
+	3,  // 56: blippy.system.SystemService.GetMaintenanceMode:output_type -> blippy.system.MaintenanceMode
+	3,  // 57: blippy.system.SystemService.UpdateMaintenanceMode:output_type -> blippy.system.MaintenanceMode
+	7,  // 58: blippy.system.SystemService.GetInstancePreamble:output_type -> blippy.system.InstancePreamble
+	7,  // 59: blippy.system.SystemService.UpdateInstancePreamble:output_type -> blippy.system.InstancePreamble
+	11, // 60: blippy.system.SystemService.GetBrokerStats:output_type -> blippy.system.BrokerStats
+	14, // 61: blippy.system.SystemService.ListActiveRuns:output_type -> blippy.system.ListActiveRunsResponse
+	16, // 62: blippy.system.SystemService.CancelRun:output_type -> blippy.system.CancelRunResponse
+	19, // 63: blippy.system.SystemService.ListPendingApprovals:output_type -> blippy.system.ListPendingApprovalsResponse
+	21, // 64: blippy.system.SystemService.ResolveApproval:output_type -> blippy.system.ResolveApprovalResponse
+	23, // 65: blippy.system.SystemService.ReplayTurn:output_type -> blippy.system.ReplayTurnResponse
+	27, // 66: blippy.system.SystemService.GetRunTrace:output_type -> blippy.system.RunTrace
+	29, // 67: blippy.system.SystemService.ExportManifest:output_type -> blippy.system.ExportManifestResponse
+	31, // 68: blippy.system.SystemService.GetVersion:output_type -> blippy.system.Version
+	33, // 69: blippy.system.SystemService.GetUsageReport:output_type -> blippy.system.UsageReport
+	38, // 70: blippy.system.SystemService.ListProviderKeys:output_type -> blippy.system.ListProviderKeysResponse
+	36, // 71: blippy.system.SystemService.CreateProviderKey:output_type -> blippy.system.ProviderKey
+	41, // 72: blippy.system.SystemService.DeleteProviderKey:output_type -> blippy.system.DeleteProviderKeyResponse
+	42, // 73: blippy.system.SystemService.CreateLLMCapture:output_type -> blippy.system.LLMCapture
+	45, // 74: blippy.system.SystemService.ListLLMCaptures:output_type -> blippy.system.ListLLMCapturesResponse
+	47, // 75: blippy.system.SystemService.DeleteLLMCapture:output_type -> blippy.system.DeleteLLMCaptureResponse
+	50, // 76: blippy.system.SystemService.ListLLMExchanges:output_type -> blippy.system.ListLLMExchangesResponse
+	55, // [55:77] is the sub-list for method output_type
+	33, // [33:55] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
+}
+
+func init() { file_system_system_proto_init() }
+func file_system_system_proto_init() {
+	if File_system_system_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_system_system_proto_rawDesc), len(file_system_system_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   52,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_system_system_proto_goTypes,
+		DependencyIndexes: file_system_system_proto_depIdxs,
+		MessageInfos:      file_system_system_proto_msgTypes,
+	}.Build()
+	File_system_system_proto = out.File
+	file_system_system_proto_goTypes = nil
+	file_system_system_proto_depIdxs = nil
+}
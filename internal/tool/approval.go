@@ -0,0 +1,152 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrToolCallDenied is returned for tool calls that weren't approved.
+var ErrToolCallDenied = errors.New("tool call denied")
+
+// ApprovalRule makes the calls of a tool that match it wait for approval. A
+// call matches if the tool matches and its argument meets all conditions
+// that are set; a rule without conditions matches every call of the tool.
+type ApprovalRule struct {
+	// Tool is the tool's name, e.g. "bash" or "notify:ops". A trailing "*"
+	// matches by prefix, e.g. "notify:*".
+	Tool string `json:"tool"`
+	// Argument is the top-level argument the conditions apply to, e.g.
+	// "command". Empty applies them to the arguments JSON.
+	Argument string `json:"argument,omitempty"`
+	// Pattern is a regular expression the argument must match.
+	Pattern string `json:"pattern,omitempty"`
+	// LargerThanBytes is the size the argument must exceed, if greater than
+	// 0.
+	LargerThanBytes int `json:"larger_than_bytes,omitempty"`
+}
+
+// Validate checks that the rule names a tool and has a valid pattern.
+func (r ApprovalRule) Validate() error {
+	if r.Tool == "" {
+		return errors.New("tool is required")
+	}
+	if _, err := regexp.Compile(r.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if r.LargerThanBytes < 0 {
+		return errors.New("larger_than_bytes must not be negative")
+	}
+	return nil
+}
+
+// Matches reports whether a call of the named tool, with internal name such
+// as "notify:ops", and args matches the rule. Rules with an invalid pattern
+// match every call of the tool, to fail safe.
+func (r ApprovalRule) Matches(name string, args json.RawMessage) bool {
+	if prefix, ok := strings.CutSuffix(r.Tool, "*"); ok {
+		if !strings.HasPrefix(name, prefix) {
+			return false
+		}
+	} else if name != r.Tool {
+		return false
+	}
+
+	value, ok := argumentValue(args, r.Argument)
+	if !ok {
+		return false
+	}
+	if r.Pattern != "" {
+		re, err := regexp.Compile(r.Pattern)
+		if err == nil && !re.MatchString(value) {
+			return false
+		}
+	}
+	return r.LargerThanBytes <= 0 || len(value) > r.LargerThanBytes
+}
+
+// String describes the rule, as the reason a call needs approval.
+func (r ApprovalRule) String() string {
+	s := r.Tool
+	if r.Argument != "" {
+		s += " " + r.Argument
+	}
+	var conds []string
+	if r.Pattern != "" {
+		conds = append(conds, fmt.Sprintf("matches %q", r.Pattern))
+	}
+	if r.LargerThanBytes > 0 {
+		conds = append(conds, fmt.Sprintf("is larger than %d bytes", r.LargerThanBytes))
+	}
+	if len(conds) == 0 {
+		return s + " is called"
+	}
+	return s + " " + strings.Join(conds, " and ")
+}
+
+// argumentValue returns the named top-level argument of a call: its value
+// for strings, and its JSON otherwise. Without a name, it returns the
+// arguments JSON. It returns false if the argument isn't set.
+func argumentValue(args json.RawMessage, name string) (string, bool) {
+	if name == "" {
+		return string(args), true
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(args, &obj); err != nil {
+		return "", false
+	}
+	raw, ok := obj[name]
+	if !ok {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, true
+	}
+	return string(raw), true
+}
+
+// MatchApprovalRules returns the first rule a call matches.
+func MatchApprovalRules(rules []ApprovalRule, name string, args json.RawMessage) (ApprovalRule, bool) {
+	for _, r := range rules {
+		if r.Matches(name, args) {
+			return r, true
+		}
+	}
+	return ApprovalRule{}, false
+}
+
+// DecodeApprovalRules decodes the approval rules of an agent.
+func DecodeApprovalRules(s string) ([]ApprovalRule, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var rules []ApprovalRule
+	if err := json.Unmarshal([]byte(s), &rules); err != nil {
+		return nil, fmt.Errorf("decode approval rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Approver decides whether a tool call may run, waiting for approval if it
+// has to. It returns an error wrapping ErrToolCallDenied for calls that may
+// not run.
+type Approver interface {
+	Approve(ctx context.Context, name string, args json.RawMessage) error
+}
+
+type approverKey struct{}
+
+// WithApprover returns a context with the approver of the current turn. It
+// replaces that of an outer turn, as subagents have their own rules.
+func WithApprover(ctx context.Context, approver Approver) context.Context {
+	return context.WithValue(ctx, approverKey{}, approver)
+}
+
+func getApprover(ctx context.Context) Approver {
+	approver, _ := ctx.Value(approverKey{}).(Approver)
+	return approver
+}
@@ -0,0 +1,46 @@
+package tool
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestApprovalRuleMatches(t *testing.T) {
+	bigPayload, _ := json.Marshal(map[string]string{"text": strings.Repeat("x", 1024)})
+	tests := []struct {
+		name string
+		rule ApprovalRule
+		tool string
+		args string
+		want bool
+	}{
+		{name: "pattern", rule: ApprovalRule{Tool: "bash", Argument: "command", Pattern: `\b(rm|curl)\b`}, tool: "bash", args: `{"command": "rm -rf /tmp/x"}`, want: true},
+		{name: "pattern mismatch", rule: ApprovalRule{Tool: "bash", Argument: "command", Pattern: `\b(rm|curl)\b`}, tool: "bash", args: `{"command": "ls"}`},
+		{name: "other tool", rule: ApprovalRule{Tool: "bash", Argument: "command", Pattern: "rm"}, tool: "fetch", args: `{"command": "rm"}`},
+		{name: "missing argument", rule: ApprovalRule{Tool: "bash", Argument: "command", Pattern: ".*"}, tool: "bash", args: `{}`},
+		{name: "prefix and size", rule: ApprovalRule{Tool: "notify:*", LargerThanBytes: 1024}, tool: "notify:ops", args: string(bigPayload), want: true},
+		{name: "small payload", rule: ApprovalRule{Tool: "notify:*", LargerThanBytes: 1024}, tool: "notify:ops", args: `{"text": "hi"}`},
+		{name: "non-string argument", rule: ApprovalRule{Tool: "fetch", Argument: "headers", Pattern: "Authorization"}, tool: "fetch", args: `{"headers": {"Authorization": "x"}}`, want: true},
+		{name: "no conditions", rule: ApprovalRule{Tool: "fetch"}, tool: "fetch", args: `{}`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.tool, json.RawMessage(tt.args)); got != tt.want {
+				t.Errorf("Matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApprovalRuleValidate(t *testing.T) {
+	if err := (ApprovalRule{Tool: "bash", Pattern: "rm|curl"}).Validate(); err != nil {
+		t.Errorf("Validate of valid rule = %v", err)
+	}
+	if err := (ApprovalRule{Pattern: "rm"}).Validate(); err == nil {
+		t.Error("Validate of rule without tool succeeded")
+	}
+	if err := (ApprovalRule{Tool: "bash", Pattern: "(rm"}).Validate(); err == nil {
+		t.Error("Validate of rule with invalid pattern succeeded")
+	}
+}
@@ -0,0 +1,89 @@
+package tool
+
+import (
+	"context"
+	"errors"
+)
+
+// Artifact kinds.
+const (
+	ArtifactKindFile  = "file"
+	ArtifactKindURL   = "url"
+	ArtifactKindImage = "image"
+)
+
+// Artifact is an output of a tool call, such as a file it created, a URL it
+// produced or an image it generated, which is listed with the run so it isn't
+// buried in the tool result.
+type Artifact struct {
+	// Kind is one of the ArtifactKind constants.
+	Kind string
+	// Name describes the artifact, e.g. a file's path.
+	Name string
+	// URI locates the artifact, e.g. a URL or a file:// URI. Empty for
+	// artifacts stored as blobs.
+	URI       string
+	MediaType string
+	// Size is the artifact's size in bytes, if known.
+	Size int64
+	// BlobID is the blob the artifact is stored as, if any.
+	BlobID string
+}
+
+// Validate checks that the artifact has a known kind, a name, and a URI or
+// blob.
+func (a Artifact) Validate() error {
+	switch a.Kind {
+	case ArtifactKindFile, ArtifactKindURL, ArtifactKindImage:
+	default:
+		return errors.New("unknown artifact kind " + a.Kind)
+	}
+	if a.Name == "" {
+		return errors.New("artifact has no name")
+	}
+	if a.URI == "" && a.BlobID == "" {
+		return errors.New("artifact has no URI or blob")
+	}
+	return nil
+}
+
+// ArtifactRecorder stores the artifacts of the current run, with the tool
+// call that produced them.
+type ArtifactRecorder interface {
+	RecordArtifact(ctx context.Context, call ToolCall, a Artifact) error
+}
+
+// ToolCall identifies the tool call being executed.
+type ToolCall struct {
+	ID   string
+	Name string
+}
+
+type (
+	artifactRecorderKey struct{}
+	toolCallKey         struct{}
+)
+
+// WithArtifactRecorder returns a context with the artifact recorder of the
+// current run.
+func WithArtifactRecorder(ctx context.Context, r ArtifactRecorder) context.Context {
+	return context.WithValue(ctx, artifactRecorderKey{}, r)
+}
+
+func withToolCall(ctx context.Context, call ToolCall) context.Context {
+	return context.WithValue(ctx, toolCallKey{}, call)
+}
+
+// RecordArtifact registers an artifact of the tool call being executed on
+// its run. It does nothing outside runs, e.g. for tools called directly.
+func RecordArtifact(ctx context.Context, a Artifact) error {
+	r, _ := ctx.Value(artifactRecorderKey{}).(ArtifactRecorder)
+	if r == nil {
+		return nil
+	}
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	call, _ := ctx.Value(toolCallKey{}).(ToolCall)
+	return r.RecordArtifact(ctx, call, a)
+}
@@ -1,16 +1,12 @@
 package tool
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strings"
-	"sync"
-
-	sprites "github.com/superfly/sprites-go"
 )
 
 // BashArgs defines the arguments for the bash tool
@@ -18,16 +14,23 @@ type BashArgs struct {
 	Command string `json:"command"`
 }
 
-// NewBashTool creates the bash tool with a Sprites client
-func NewBashTool(apiKey string) *Tool {
-	client := sprites.New(apiKey)
+// Sandbox runs bash commands in an isolated environment, one per agent, so
+// files persist between an agent's commands.
+type Sandbox interface {
+	// Run runs command with bash in the agent's environment, with env
+	// ("NAME=value") added to its environment. A command that exits with a
+	// non-zero status isn't an error.
+	Run(ctx context.Context, agentID, command string, env []string) (SandboxResult, error)
+}
 
-	// Track which sprites we've already created
-	var (
-		createdSprites = make(map[string]bool)
-		mu             sync.Mutex
-	)
+// SandboxResult is the output of a sandboxed command.
+type SandboxResult struct {
+	Stdout, Stderr string
+	ExitCode       int
+}
 
+// NewBashTool creates the bash tool, which runs commands in sandbox.
+func NewBashTool(sandbox Sandbox) *Tool {
 	return &Tool{
 		Name:        "bash",
 		Description: "Run a bash command in a sandboxed environment. Use for file operations, system commands, installing packages, running Python (python3), JavaScript (node), and general shell tasks.",
@@ -51,77 +54,48 @@ func NewBashTool(apiKey string) *Tool {
 				return "", fmt.Errorf("command is required")
 			}
 
-			// Get agent ID from context for sprite naming (one sprite per agent)
+			// Each agent has its own sandbox
 			agentID := GetAgentID(ctx)
 			if agentID == "" {
 				return "", fmt.Errorf("agent ID not found in context")
 			}
 
-			spriteName := "blippy-" + agentID
-
-			// Ensure sprite exists (create if needed)
-			mu.Lock()
-			needsCreate := !createdSprites[spriteName]
-			mu.Unlock()
-
-			if needsCreate {
-				_, err := client.GetSprite(ctx, spriteName)
-				if err != nil {
-					_, err = client.CreateSprite(ctx, spriteName, nil)
-					if err != nil && !strings.Contains(err.Error(), "already exists") {
-						return "", fmt.Errorf("create sprite: %w", err)
-					}
-				}
-				mu.Lock()
-				createdSprites[spriteName] = true
-				mu.Unlock()
+			// Expose the run's variables, and forward host environment
+			// variables configured for this agent, which take precedence.
+			var env []string
+			vars := GetRunVars(ctx)
+			for _, name := range RunVarNames(vars) {
+				env = append(env, name+"="+vars[name])
 			}
-
-			// Execute command
-			sprite := client.Sprite(spriteName)
-			cmd := sprite.CommandContext(ctx, "bash", "-c", a.Command)
-
-			// Forward host environment variables configured for this agent.
-			if names := GetHostEnvVars(ctx); len(names) > 0 {
-				for _, name := range names {
-					if val, ok := os.LookupEnv(name); ok {
-						cmd.Env = append(cmd.Env, name+"="+val)
-					}
+			for _, name := range GetHostEnvVars(ctx) {
+				if val, ok := os.LookupEnv(name); ok {
+					env = append(env, name+"="+val)
 				}
 			}
 
-			var stdout, stderr bytes.Buffer
-			cmd.Stdout = &stdout
-			cmd.Stderr = &stderr
-
-			err := cmd.Run()
-			exitCode := 0
+			res, err := sandbox.Run(ctx, agentID, a.Command, env)
 			if err != nil {
-				if exitErr, ok := err.(*sprites.ExitError); ok {
-					exitCode = exitErr.ExitCode()
-				} else {
-					log.Printf("Bash execution failed: %v", err)
-					return "", fmt.Errorf("execution failed: %w", err)
-				}
+				slog.Error("bash execution failed", "error", err)
+				return "", fmt.Errorf("execution failed: %w", err)
 			}
 
 			// Format output
 			var out strings.Builder
-			if stdout.Len() > 0 {
-				out.WriteString(stdout.String())
-				if !strings.HasSuffix(stdout.String(), "\n") {
+			if res.Stdout != "" {
+				out.WriteString(res.Stdout)
+				if !strings.HasSuffix(res.Stdout, "\n") {
 					out.WriteString("\n")
 				}
 			}
-			if stderr.Len() > 0 {
+			if res.Stderr != "" {
 				out.WriteString("stderr:\n")
-				out.WriteString(stderr.String())
-				if !strings.HasSuffix(stderr.String(), "\n") {
+				out.WriteString(res.Stderr)
+				if !strings.HasSuffix(res.Stderr, "\n") {
 					out.WriteString("\n")
 				}
 			}
-			if exitCode != 0 {
-				out.WriteString(fmt.Sprintf("exit_code: %d", exitCode))
+			if res.ExitCode != 0 {
+				out.WriteString(fmt.Sprintf("exit_code: %d", res.ExitCode))
 			}
 
 			return strings.TrimSpace(out.String()), nil
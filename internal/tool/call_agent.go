@@ -4,8 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 )
 
+// DefaultCallAgentCacheTTL is how long call_agent returns the response of an
+// earlier identical call, if no other TTL is given.
+const DefaultCallAgentCacheTTL = 10 * time.Minute
+
+// cachedResponseMarker starts responses of call_agent taken from its cache.
+const cachedResponseMarker = "[Cached response of an identical call_agent call %s ago; the agent wasn't run again]\n\n"
+
 // AgentCaller is the interface for running subagents.
 type AgentCaller interface {
 	RunAgent(ctx context.Context, agentID, prompt string, depth int, model, title string) (string, error)
@@ -18,11 +27,58 @@ type callAgentArgs struct {
 	Title   string `json:"title,omitempty"`
 }
 
-// NewCallAgentTool creates a tool for synchronous subagent invocation.
-func NewCallAgentTool(caller AgentCaller) *Tool {
+// callAgentKey identifies identical calls of call_agent from a conversation.
+type callAgentKey struct {
+	conversationID string
+	agentID        string
+	model          string
+	prompt         string
+}
+
+type cachedResponse struct {
+	response string
+	at       time.Time
+}
+
+// callAgentCache holds the responses of successful call_agent calls for ttl,
+// so orchestrating agents that repeat a call don't run the agent again.
+type callAgentCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[callAgentKey]cachedResponse
+}
+
+func (c *callAgentCache) get(key callAgentKey, now time.Time) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.Sub(entry.at) >= c.ttl {
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+// put stores a response, and removes expired ones.
+func (c *callAgentCache) put(key callAgentKey, response string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, entry := range c.entries {
+		if now.Sub(entry.at) >= c.ttl {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedResponse{response: response, at: now}
+}
+
+// NewCallAgentTool creates a tool for synchronous subagent invocation. Calls
+// from a conversation with the same agent, model and prompt as a call less
+// than cacheTTL ago return its response, marked as cached; 0 disables this.
+func NewCallAgentTool(caller AgentCaller, cacheTTL time.Duration) *Tool {
+	cache := &callAgentCache{ttl: cacheTTL, entries: make(map[callAgentKey]cachedResponse)}
 	return &Tool{
 		Name:        "call_agent",
-		Description: "Call another agent synchronously and get its response. Use this to delegate tasks to specialized agents.",
+		Description: "Call another agent synchronously and get its response. Use this to delegate tasks to specialized agents. Repeating an identical call shortly after returns the earlier response instead of running the agent again; change the prompt to get a fresh one.",
 		Parameters: json.RawMessage(`{
 			"type": "object",
 			"properties": {
@@ -70,12 +126,28 @@ func NewCallAgentTool(caller AgentCaller) *Tool {
 				return "", fmt.Errorf("max agent depth exceeded (%d)", DefaultMaxDepth)
 			}
 
+			key := callAgentKey{
+				conversationID: GetConversationID(ctx),
+				agentID:        args.AgentID,
+				model:          args.Model,
+				prompt:         args.Prompt,
+			}
+			if cacheTTL > 0 {
+				if entry, ok := cache.get(key, time.Now()); ok {
+					age := time.Since(entry.at).Round(time.Second)
+					return fmt.Sprintf(cachedResponseMarker, age) + entry.response, nil
+				}
+			}
+
 			// Call the subagent
 			response, err := caller.RunAgent(ctx, args.AgentID, args.Prompt, newDepth, args.Model, args.Title)
 			if err != nil {
 				return fmt.Sprintf("Error calling agent: %s", err.Error()), nil
 			}
 
+			if cacheTTL > 0 {
+				cache.put(key, response, time.Now())
+			}
 			return response, nil
 		},
 	}
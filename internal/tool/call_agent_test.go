@@ -0,0 +1,82 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeCaller struct {
+	calls int
+	err   error
+}
+
+func (f *fakeCaller) RunAgent(ctx context.Context, agentID, prompt string, depth int, model, title string) (string, error) {
+	f.calls++
+	return "Findings about " + prompt, f.err
+}
+
+func TestCallAgentCache(t *testing.T) {
+	caller := &fakeCaller{}
+	call := NewCallAgentTool(caller, time.Minute)
+	ctx := WithConversationID(WithAgentID(context.Background(), "agent-1"), "conv-1")
+
+	first, err := call.Handler(ctx, json.RawMessage(`{"prompt": "Rust"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := call.Handler(ctx, json.RawMessage(`{"prompt": "Rust"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caller.calls != 1 || !strings.HasPrefix(second, "[Cached response") || !strings.HasSuffix(second, first) {
+		t.Errorf("second call = %q after %d runs, want cached %q", second, caller.calls, first)
+	}
+
+	// Other prompts and other conversations run the agent.
+	if _, err := call.Handler(ctx, json.RawMessage(`{"prompt": "Go"}`)); err != nil {
+		t.Fatal(err)
+	}
+	other := WithConversationID(ctx, "conv-2")
+	if out, _ := call.Handler(other, json.RawMessage(`{"prompt": "Rust"}`)); strings.HasPrefix(out, "[Cached") || caller.calls != 3 {
+		t.Errorf("call from other conversation = %q after %d runs, want a new run", out, caller.calls)
+	}
+
+	// Failed calls aren't cached.
+	caller.err = errors.New("rate limited")
+	call.Handler(ctx, json.RawMessage(`{"prompt": "Zig"}`))
+	call.Handler(ctx, json.RawMessage(`{"prompt": "Zig"}`))
+	if caller.calls != 5 {
+		t.Errorf("runs = %d, want failed calls to run again", caller.calls)
+	}
+
+	// Without a TTL, every call runs the agent.
+	caller = &fakeCaller{}
+	uncached := NewCallAgentTool(caller, 0)
+	uncached.Handler(ctx, json.RawMessage(`{"prompt": "Rust"}`))
+	uncached.Handler(ctx, json.RawMessage(`{"prompt": "Rust"}`))
+	if caller.calls != 2 {
+		t.Errorf("runs without cache = %d, want 2", caller.calls)
+	}
+}
+
+func TestCallAgentCacheExpiry(t *testing.T) {
+	cache := &callAgentCache{ttl: time.Minute, entries: make(map[callAgentKey]cachedResponse)}
+	now := time.Now()
+	key := callAgentKey{agentID: "agent-1", prompt: "Rust"}
+	cache.put(key, "Findings", now)
+
+	if _, ok := cache.get(key, now.Add(59*time.Second)); !ok {
+		t.Error("response expired within TTL")
+	}
+	if _, ok := cache.get(key, now.Add(time.Minute)); ok {
+		t.Error("response didn't expire after TTL")
+	}
+	cache.put(callAgentKey{prompt: "Go"}, "Other", now.Add(time.Minute))
+	if len(cache.entries) != 1 {
+		t.Errorf("cache has %d entries, want expired entry removed", len(cache.entries))
+	}
+}
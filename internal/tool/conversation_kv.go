@@ -0,0 +1,170 @@
+package tool
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// Limits of conversation context, which is meant for small values such as
+// IDs and URLs, not documents.
+const (
+	maxContextKeyLen   = 64
+	maxContextValueLen = 2048
+	maxContextKeys     = 32
+)
+
+// ContextStore is the interface for conversation context persistence.
+type ContextStore interface {
+	UpsertConversationKV(ctx context.Context, arg store.UpsertConversationKVParams) error
+	GetConversationKV(ctx context.Context, arg store.GetConversationKVParams) (store.ConversationKv, error)
+	ListConversationKV(ctx context.Context, conversationID string) ([]store.ConversationKv, error)
+	DeleteConversationKV(ctx context.Context, arg store.DeleteConversationKVParams) (int64, error)
+}
+
+// NewSetContextTool creates a tool for storing a value in the current
+// conversation, for later turns of it.
+func NewSetContextTool(cs ContextStore) *Tool {
+	return &Tool{
+		Name:        "set_context",
+		Description: fmt.Sprintf("Store a small value (e.g. an ID or URL) under a key in this conversation, so later turns can get it with get_context. Values are only visible in this conversation; use memory for information that should outlive it. An empty value removes the key. Keys are at most %d characters, values at most %d bytes, and a conversation holds at most %d keys.", maxContextKeyLen, maxContextValueLen, maxContextKeys),
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"key": {
+					"type": "string",
+					"description": "Key to store the value under (e.g. \"ticket_id\")"
+				},
+				"value": {
+					"type": "string",
+					"description": "The value to store, or empty to remove the key"
+				}
+			},
+			"required": ["key", "value"]
+		}`),
+		// Values may be credentials, such as tokens in URLs.
+		SensitiveArgs: []string{"value"},
+		Handler: func(ctx context.Context, argsJSON json.RawMessage) (string, error) {
+			var args struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			}
+			if err := json.Unmarshal(argsJSON, &args); err != nil {
+				return "", fmt.Errorf("parse args: %w", err)
+			}
+			if args.Key == "" {
+				return "", fmt.Errorf("key is required")
+			}
+			if len(args.Key) > maxContextKeyLen {
+				return "", fmt.Errorf("key is longer than %d characters", maxContextKeyLen)
+			}
+			if len(args.Value) > maxContextValueLen {
+				return "", fmt.Errorf("value is larger than %d bytes; use memory for larger content", maxContextValueLen)
+			}
+
+			convID := GetConversationID(ctx)
+			if convID == "" {
+				return "", fmt.Errorf("no current conversation in context")
+			}
+
+			if args.Value == "" {
+				n, err := cs.DeleteConversationKV(ctx, store.DeleteConversationKVParams{ConversationID: convID, Key: args.Key})
+				if err != nil {
+					return "", fmt.Errorf("delete value: %w", err)
+				}
+				if n == 0 {
+					return fmt.Sprintf("Key %s was not set.", args.Key), nil
+				}
+				return fmt.Sprintf("Key %s removed.", args.Key), nil
+			}
+
+			existing, err := cs.ListConversationKV(ctx, convID)
+			if err != nil {
+				return "", fmt.Errorf("list values: %w", err)
+			}
+			createdAt := time.Now().UTC().Format(time.RFC3339)
+			found := false
+			for _, kv := range existing {
+				if kv.Key == args.Key {
+					createdAt, found = kv.CreatedAt, true
+					break
+				}
+			}
+			if !found && len(existing) >= maxContextKeys {
+				return "", fmt.Errorf("conversation already has %d keys; remove one first", maxContextKeys)
+			}
+
+			if err := cs.UpsertConversationKV(ctx, store.UpsertConversationKVParams{
+				ConversationID: convID,
+				Key:            args.Key,
+				Value:          args.Value,
+				CreatedAt:      createdAt,
+				UpdatedAt:      time.Now().UTC().Format(time.RFC3339),
+			}); err != nil {
+				return "", fmt.Errorf("store value: %w", err)
+			}
+			return fmt.Sprintf("Key %s set.", args.Key), nil
+		},
+	}
+}
+
+// NewGetContextTool creates a tool for getting values stored with
+// set_context in the current conversation.
+func NewGetContextTool(cs ContextStore) *Tool {
+	return &Tool{
+		Name:        "get_context",
+		Description: "Get a value stored with set_context in this conversation. Without a key, lists all stored keys and values.",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"key": {
+					"type": "string",
+					"description": "Key to get. Omit to list all keys and values."
+				}
+			}
+		}`),
+		Handler: func(ctx context.Context, argsJSON json.RawMessage) (string, error) {
+			var args struct {
+				Key string `json:"key"`
+			}
+			if err := json.Unmarshal(argsJSON, &args); err != nil {
+				return "", fmt.Errorf("parse args: %w", err)
+			}
+
+			convID := GetConversationID(ctx)
+			if convID == "" {
+				return "", fmt.Errorf("no current conversation in context")
+			}
+
+			if args.Key != "" {
+				kv, err := cs.GetConversationKV(ctx, store.GetConversationKVParams{ConversationID: convID, Key: args.Key})
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Sprintf("Key %s is not set.", args.Key), nil
+				}
+				if err != nil {
+					return "", fmt.Errorf("get value: %w", err)
+				}
+				return kv.Value, nil
+			}
+
+			kvs, err := cs.ListConversationKV(ctx, convID)
+			if err != nil {
+				return "", fmt.Errorf("list values: %w", err)
+			}
+			if len(kvs) == 0 {
+				return "No values stored in this conversation.", nil
+			}
+			var sb strings.Builder
+			for _, kv := range kvs {
+				sb.WriteString(fmt.Sprintf("- %s: %s\n", kv.Key, kv.Value))
+			}
+			return sb.String(), nil
+		},
+	}
+}
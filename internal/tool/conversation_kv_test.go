@@ -0,0 +1,83 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestConversationContext(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := "2025-01-01T00:00:00Z"
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"conv-1", "conv-2"} {
+		if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: id, AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	set, get := NewSetContextTool(queries), NewGetContextTool(queries)
+	call := func(convID string, tool *Tool, args map[string]string) (string, error) {
+		t.Helper()
+		b, _ := json.Marshal(args)
+		return tool.Handler(WithConversationID(ctx, convID), b)
+	}
+
+	if _, err := call("conv-1", set, map[string]string{"key": "ticket_id", "value": "OPS-42"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call("conv-1", set, map[string]string{"key": "ticket_id", "value": "OPS-43"}); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := call("conv-1", get, map[string]string{"key": "ticket_id"}); err != nil || out != "OPS-43" {
+		t.Errorf("get = %q, %v, want OPS-43", out, err)
+	}
+	// Values are scoped to their conversation.
+	if out, err := call("conv-2", get, map[string]string{}); err != nil || !strings.Contains(out, "No values") {
+		t.Errorf("list in other conversation = %q, %v", out, err)
+	}
+
+	if _, err := call("conv-1", set, map[string]string{"key": "big", "value": strings.Repeat("x", maxContextValueLen+1)}); err == nil {
+		t.Error("oversized value was stored")
+	}
+	for i := 1; i < maxContextKeys; i++ {
+		if _, err := call("conv-1", set, map[string]string{"key": fmt.Sprintf("k%d", i), "value": "v"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := call("conv-1", set, map[string]string{"key": "one_too_many", "value": "v"}); err == nil {
+		t.Error("key beyond the limit was stored")
+	}
+	// Existing keys can still be updated and removed at the limit.
+	if _, err := call("conv-1", set, map[string]string{"key": "ticket_id", "value": "OPS-44"}); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := call("conv-1", set, map[string]string{"key": "ticket_id", "value": ""}); err != nil || !strings.Contains(out, "removed") {
+		t.Errorf("remove = %q, %v", out, err)
+	}
+	if out, err := call("conv-1", get, map[string]string{"key": "ticket_id"}); err != nil || !strings.Contains(out, "not set") {
+		t.Errorf("get removed = %q, %v", out, err)
+	}
+
+	// Values are deleted with their conversation.
+	if err := queries.DeleteConversation(ctx, "conv-1"); err != nil {
+		t.Fatal(err)
+	}
+	if kvs, err := queries.ListConversationKV(ctx, "conv-1"); err != nil || len(kvs) != 0 {
+		t.Errorf("values after deleting conversation = %d, %v", len(kvs), err)
+	}
+}
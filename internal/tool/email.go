@@ -0,0 +1,207 @@
+package tool
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// emailDefaultSchema is the payload schema for email channels without a
+// custom schema.
+const emailDefaultSchema = `{"type": "object", "properties": {"subject": {"type": "string"}, "body": {"type": "string", "description": "Plain text body of the email."}, "to": {"type": "array", "items": {"type": "string"}, "description": "Recipient addresses. Omit to send to the channel's default recipients."}}, "required": ["subject", "body"]}`
+
+// emailTimeout limits how long sending an email may take, including
+// connecting to the SMTP server.
+const emailTimeout = 30 * time.Second
+
+// emailConfig is the config of email channels.
+type emailConfig struct {
+	Host string `json:"host"`
+	// Port defaults to 465 with TLS "tls", and 587 otherwise.
+	Port int `json:"port"`
+	// TLS is "starttls" (the default), "tls" for implicit TLS, or "none" for
+	// relays on a trusted network.
+	TLS      string `json:"tls"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+	// To are the default recipients.
+	To []string `json:"to"`
+	// AllowedRecipients are the other addresses, or domains as
+	// "@example.com", that payloads may send to.
+	AllowedRecipients []string `json:"allowed_recipients"`
+}
+
+type emailPayload struct {
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+	To      []string `json:"to"`
+}
+
+func sendNotificationEmail(ctx context.Context, configJSON string, payload json.RawMessage) error {
+	var cfg emailConfig
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+	if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return fmt.Errorf("email channel requires host, from and to")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+
+	var msg emailPayload
+	if err := json.Unmarshal(payload, &msg); err != nil || msg.Subject == "" || msg.Body == "" {
+		return fmt.Errorf("payload must have non-empty subject and body properties")
+	}
+	to, err := emailRecipients(cfg, msg.To)
+	if err != nil {
+		return err
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+		if cfg.TLS == "tls" {
+			port = 465
+		}
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+
+	ctx, cancel := context.WithTimeout(ctx, emailTimeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	dialer := &net.Dialer{}
+
+	var conn net.Conn
+	switch cfg.TLS {
+	case "tls":
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: cfg.Host}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	case "", "starttls", "none":
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	default:
+		return fmt.Errorf("unknown tls mode %q, expected starttls, tls or none", cfg.TLS)
+	}
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	defer c.Close()
+
+	if cfg.TLS == "" || cfg.TLS == "starttls" {
+		if err := c.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("authenticate: %w", err)
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt.Address); err != nil {
+			return fmt.Errorf("rcpt to %s: %w", rcpt.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := w.Write(buildEmail(ctx, from, to, msg, time.Now())); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	return c.Quit()
+}
+
+// emailRecipients returns the recipients of a payload: its own, if allowed by
+// the channel, or else the channel's default recipients.
+func emailRecipients(cfg emailConfig, requested []string) ([]*mail.Address, error) {
+	if len(requested) == 0 {
+		requested = cfg.To
+	}
+	to := make([]*mail.Address, 0, len(requested))
+	for _, r := range requested {
+		addr, err := mail.ParseAddress(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", r, err)
+		}
+		if !emailRecipientAllowed(cfg, addr.Address) {
+			return nil, fmt.Errorf("recipient %s is not allowed by the channel", addr.Address)
+		}
+		to = append(to, addr)
+	}
+	return to, nil
+}
+
+func emailRecipientAllowed(cfg emailConfig, address string) bool {
+	for _, allowed := range append(cfg.To, cfg.AllowedRecipients...) {
+		if strings.HasPrefix(allowed, "@") {
+			if strings.HasSuffix(strings.ToLower(address), strings.ToLower(allowed)) {
+				return true
+			}
+			continue
+		}
+		if a, err := mail.ParseAddress(allowed); err == nil && strings.EqualFold(a.Address, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildEmail returns a plain text message with quoted-printable body.
+func buildEmail(ctx context.Context, from *mail.Address, to []*mail.Address, msg emailPayload, now time.Time) []byte {
+	recipients := make([]string, len(to))
+	for i, addr := range to {
+		recipients[i] = addr.String()
+	}
+	// Line breaks in the subject would start new headers.
+	subject := strings.Join(strings.Fields(msg.Subject), " ")
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@%s>\r\n", cmp.Or(GetNotificationID(ctx), uuid.NewString()), domain)
+	if id := GetNotificationID(ctx); id != "" {
+		fmt.Fprintf(&b, "%s: %s\r\n", NotificationIDHeader, id)
+	}
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&b)
+	body := strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n")
+	_, _ = qp.Write([]byte(body))
+	_ = qp.Close()
+	return b.Bytes()
+}
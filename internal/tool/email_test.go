@@ -0,0 +1,100 @@
+package tool
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeSMTP accepts one message without TLS or authentication, and sends the
+// envelope recipients and message data to the returned channel.
+func fakeSMTP(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { fmt.Fprintf(conn, "%s\r\n", s) }
+		reply("220 localhost ESMTP")
+		var out strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				out.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 Go ahead")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					out.WriteString(line)
+				}
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				received <- out.String()
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestSendNotificationEmail(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	host, port, _ := net.SplitHostPort(addr)
+	config := fmt.Sprintf(`{"host": %q, "port": %s, "tls": "none", "from": "Blippy <blippy@example.com>", "to": ["ops@example.com"], "allowed_recipients": ["@example.org"]}`, host, port)
+
+	ctx := WithNotificationID(context.Background(), "delivery-1")
+	payload := json.RawMessage(`{"subject": "Daily report\nBcc: evil@example.net", "body": "All systems go.\nUptime = 100%", "to": ["Lead <lead@example.org>"]}`)
+	if err := SendNotification(ctx, NotificationChannel{Type: "email", Config: config}, payload); err != nil {
+		t.Fatal(err)
+	}
+	msg := <-received
+	for _, want := range []string{
+		"RCPT TO:<lead@example.org>",
+		"From: \"Blippy\" <blippy@example.com>",
+		"Subject: Daily report Bcc: evil@example.net\r\n",
+		"Message-ID: <delivery-1@example.com>",
+		NotificationIDHeader + ": delivery-1",
+		"Uptime =3D 100%",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message doesn't contain %q:\n%s", want, msg)
+		}
+	}
+	if strings.Contains(msg, "ops@example.com") {
+		t.Errorf("message was also sent to the default recipients:\n%s", msg)
+	}
+
+	// Payloads can't send to addresses the channel doesn't allow.
+	payload = json.RawMessage(`{"subject": "Hi", "body": "Hi", "to": ["someone@example.net"]}`)
+	if err := SendNotification(ctx, NotificationChannel{Type: "email", Config: config}, payload); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("err = %v, want recipient not allowed", err)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 
+	"github.com/dstotijn/blippy/internal/apierror"
 	"github.com/dstotijn/blippy/internal/openrouter"
 )
 
@@ -13,6 +15,13 @@ import (
 type NotificationChannelLister interface {
 	ListNotificationChannelsByIDs(ctx context.Context, ids []string) ([]NotificationChannel, error)
 	GetNotificationChannelByName(ctx context.Context, name string) (*NotificationChannel, error)
+	ListNotificationSecrets(ctx context.Context) ([]string, error)
+}
+
+// NotificationDispatcher applies a channel's delivery policy (quiet hours,
+// throttling) before sending a notification. It returns the tool output.
+type NotificationDispatcher interface {
+	DispatchNotification(ctx context.Context, channel NotificationChannel, payload json.RawMessage) (string, error)
 }
 
 // FilesystemRootLister retrieves filesystem roots.
@@ -22,17 +31,20 @@ type FilesystemRootLister interface {
 
 // Executor handles tool execution within a conversation
 type Executor struct {
-	registry           *Registry
-	notificationLister NotificationChannelLister
-	filesystemLister   FilesystemRootLister
+	registry               *Registry
+	notificationLister     NotificationChannelLister
+	notificationDispatcher NotificationDispatcher
+	filesystemLister       FilesystemRootLister
 }
 
-// NewExecutor creates a tool executor
-func NewExecutor(registry *Registry, notificationLister NotificationChannelLister, filesystemLister FilesystemRootLister) *Executor {
+// NewExecutor creates a tool executor. The notification dispatcher is
+// optional; without it, notifications are sent immediately.
+func NewExecutor(registry *Registry, notificationLister NotificationChannelLister, notificationDispatcher NotificationDispatcher, filesystemLister FilesystemRootLister) *Executor {
 	return &Executor{
-		registry:           registry,
-		notificationLister: notificationLister,
-		filesystemLister:   filesystemLister,
+		registry:               registry,
+		notificationLister:     notificationLister,
+		notificationDispatcher: notificationDispatcher,
+		filesystemLister:       filesystemLister,
 	}
 }
 
@@ -43,6 +55,10 @@ type ToolResult struct {
 	Name      string // API-encoded name
 	Arguments string
 	Output    string
+	ErrorCode apierror.ErrorCode // set if the tool returned an error
+	// SensitiveArgs are the argument properties the tool flags as
+	// sensitive, see Tool.SensitiveArgs.
+	SensitiveArgs []string
 }
 
 // ProcessOutput checks response output for function calls and executes them concurrently.
@@ -76,25 +92,39 @@ func (e *Executor) ProcessOutput(ctx context.Context, output []openrouter.Output
 		})
 	}
 
+	// Secret values that must not end up in tool results, which are sent to
+	// the model and persisted.
+	secrets := e.secrets(ctx)
+
 	// Execute tools concurrently
 	type toolOutput struct {
 		index  int
 		call   openrouter.OutputItem
 		output string
+		code   apierror.ErrorCode
 	}
 
 	ch := make(chan toolOutput, len(toolCalls))
+	stub := getStubResults(ctx)
 	for i, call := range toolCalls {
+		if stub != nil {
+			ch <- toolOutput{index: i, call: call, output: stub(call.CallID)}
+			continue
+		}
 		go func(i int, call openrouter.OutputItem) {
 			internalName := DecodeToolName(call.Name)
-			result, err := e.executeTool(ctx, internalName, json.RawMessage(call.Arguments))
+			ctx := withToolCall(ctx, ToolCall{ID: call.CallID, Name: internalName})
+			result, err := e.approveAndExecute(ctx, internalName, json.RawMessage(call.Arguments))
+			var code apierror.ErrorCode
 			if err != nil {
 				result = fmt.Sprintf("Error: %s", err.Error())
+				code = ErrorCodeOf(err)
 			}
 			if result == "" {
 				result = "(no output)"
 			}
-			ch <- toolOutput{index: i, call: call, output: result}
+			result = RedactSecrets(result, secrets)
+			ch <- toolOutput{index: i, call: call, output: result, code: code}
 		}(i, call)
 	}
 
@@ -114,6 +144,9 @@ func (e *Executor) ProcessOutput(ctx context.Context, output []openrouter.Output
 				Name:      r.call.Name,
 				Arguments: r.call.Arguments,
 				Output:    r.output,
+				ErrorCode: r.code,
+
+				SensitiveArgs: e.sensitiveArgs(DecodeToolName(r.call.Name)),
 			})
 		}
 	}
@@ -122,9 +155,54 @@ func (e *Executor) ProcessOutput(ctx context.Context, output []openrouter.Output
 	return inputs, nil
 }
 
+// sensitiveArgs returns the sensitive argument properties of a registry
+// tool. Dynamic tools have none.
+func (e *Executor) sensitiveArgs(name string) []string {
+	if t, ok := e.registry.Get(name); ok {
+		return t.SensitiveArgs
+	}
+	return nil
+}
+
+// secrets returns known secret values: forwarded host env vars and
+// notification channel credentials.
+func (e *Executor) secrets(ctx context.Context) []string {
+	secrets := hostEnvSecrets(ctx)
+	if e.notificationLister != nil {
+		channelSecrets, err := e.notificationLister.ListNotificationSecrets(ctx)
+		if err != nil {
+			slog.Error("failed to list notification secrets", "error", err)
+		}
+		secrets = append(secrets, channelSecrets...)
+	}
+	return secrets
+}
+
+// RedactSecrets replaces the known secret values of a context in s, like
+// tool output is redacted.
+func (e *Executor) RedactSecrets(ctx context.Context, s string) string {
+	return RedactSecrets(s, e.secrets(ctx))
+}
+
+// approveAndExecute runs a tool if the context's approver, if any, allows
+// the call.
+func (e *Executor) approveAndExecute(ctx context.Context, name string, args json.RawMessage) (string, error) {
+	if approver := getApprover(ctx); approver != nil {
+		if err := approver.Approve(ctx, name, args); err != nil {
+			return "", err
+		}
+	}
+	return e.executeTool(ctx, name, args)
+}
+
 // executeTool runs a tool, handling static registry tools, dynamic notification tools,
-// and dynamic filesystem tools.
+// dynamic filesystem tools and find_tool.
 func (e *Executor) executeTool(ctx context.Context, name string, args json.RawMessage) (string, error) {
+	// Handle the meta-tool of turns that load tools lazily
+	if name == FindToolName {
+		return NewFindTool().Handler(ctx, args)
+	}
+
 	// Handle dynamic notification channel tools
 	if strings.HasPrefix(name, "notify:") {
 		channelName := strings.TrimPrefix(name, "notify:")
@@ -137,6 +215,15 @@ func (e *Executor) executeTool(ctx context.Context, name string, args json.RawMe
 			return fmt.Sprintf("Channel '%s' not found", channelName), nil
 		}
 
+		// Let the model self-correct instead of posting malformed payloads
+		if msg := validateNotificationPayload(*channel, args); msg != "" {
+			return msg, nil
+		}
+
+		if e.notificationDispatcher != nil {
+			return e.notificationDispatcher.DispatchNotification(ctx, *channel, args)
+		}
+
 		tool := BuildNotificationTool(*channel)
 		return tool.Handler(ctx, args)
 	}
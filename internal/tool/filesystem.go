@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"mime"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -339,6 +342,16 @@ func BuildFSCreateTool(roots []FilesystemRoot) *Tool {
 				return "", fmt.Errorf("write file: %w", err)
 			}
 
+			if err := RecordArtifact(ctx, Artifact{
+				Kind:      ArtifactKindFile,
+				Name:      root.Name + ":" + p.Path,
+				URI:       (&url.URL{Scheme: "file", Path: resolved}).String(),
+				MediaType: mime.TypeByExtension(filepath.Ext(resolved)),
+				Size:      int64(len(p.FileText)),
+			}); err != nil {
+				slog.Error("failed to record artifact", "tool", "fs_create", "error", err)
+			}
+
 			return "File created successfully.", nil
 		},
 	}
@@ -37,6 +37,33 @@ func TestFSCreateNestedDirs(t *testing.T) {
 	}
 }
 
+type artifactsRecorded []Artifact
+
+func (r *artifactsRecorded) RecordArtifact(ctx context.Context, call ToolCall, a Artifact) error {
+	*r = append(*r, a)
+	return nil
+}
+
+func TestFSCreateArtifact(t *testing.T) {
+	dir := t.TempDir()
+	roots := []FilesystemRoot{{Name: "docs", Path: dir}}
+	var recorded artifactsRecorded
+	ctx := WithArtifactRecorder(context.Background(), &recorded)
+
+	args, _ := json.Marshal(map[string]string{
+		"root":      "docs",
+		"path":      "plan.html",
+		"file_text": "<h1>Plan</h1>",
+	})
+	if _, err := BuildFSCreateTool(roots).Handler(ctx, args); err != nil {
+		t.Fatalf("fs_create failed: %v", err)
+	}
+	want := Artifact{Kind: ArtifactKindFile, Name: "docs:plan.html", URI: "file://" + filepath.Join(dir, "plan.html"), MediaType: "text/html; charset=utf-8", Size: 13}
+	if len(recorded) != 1 || recorded[0] != want {
+		t.Errorf("artifacts = %+v, want %+v", recorded, want)
+	}
+}
+
 func TestFSCreateTraversalBlocked(t *testing.T) {
 	dir := t.TempDir()
 	root := FilesystemRoot{Name: "test", Path: dir, Description: "test"}
@@ -0,0 +1,73 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FindToolName is the name of the meta-tool that loads the definitions of
+// tools listed in a compact tool index. Turns of agents with many tools get
+// it instead of all their tool definitions.
+const FindToolName = "find_tool"
+
+// ToolLoader loads the definitions of tools into the next LLM round-trip of
+// a turn. It returns the tool output.
+type ToolLoader interface {
+	LoadTools(names []string, query string) (string, error)
+}
+
+type toolLoaderKey struct{}
+
+// WithToolLoader returns a context with the tool loader of the current turn.
+// It replaces that of an outer turn, so subagents don't load tools into the
+// turn that called them.
+func WithToolLoader(ctx context.Context, loader ToolLoader) context.Context {
+	return context.WithValue(ctx, toolLoaderKey{}, loader)
+}
+
+func getToolLoader(ctx context.Context) ToolLoader {
+	loader, _ := ctx.Value(toolLoaderKey{}).(ToolLoader)
+	return loader
+}
+
+// NewFindTool creates the find_tool meta-tool, which loads tools with the
+// ToolLoader of the context. The executor handles it; it isn't registered.
+func NewFindTool() *Tool {
+	return &Tool{
+		Name:        FindToolName,
+		Description: "Load the full definitions of tools from the tool index in your instructions, by name or by searching their names and descriptions. Loaded tools can be called from your next step.",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"names": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Names of tools from the tool index to load"
+				},
+				"query": {
+					"type": "string",
+					"description": "Words to search the tool index for; the best matching tools are loaded"
+				}
+			}
+		}`),
+		Handler: func(ctx context.Context, argsJSON json.RawMessage) (string, error) {
+			var args struct {
+				Names []string `json:"names"`
+				Query string   `json:"query"`
+			}
+			if err := json.Unmarshal(argsJSON, &args); err != nil {
+				return "", fmt.Errorf("parse args: %w", err)
+			}
+			if len(args.Names) == 0 && args.Query == "" {
+				return "", fmt.Errorf("names or query is required")
+			}
+
+			loader := getToolLoader(ctx)
+			if loader == nil {
+				return "", &ErrToolNotFound{Name: FindToolName}
+			}
+			return loader.LoadTools(args.Names, args.Query)
+		},
+	}
+}
@@ -2,11 +2,17 @@ package tool
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,12 +26,73 @@ type NotificationChannel struct {
 	Config      string
 }
 
+// DefaultSignatureHeader carries the HMAC signature of signed notifications,
+// unless the channel configures another header.
+const DefaultSignatureHeader = "X-Blippy-Signature"
+
+// SignatureTimestampHeader carries the Unix timestamp included in the
+// signature, so receivers can reject replayed requests.
+const SignatureTimestampHeader = "X-Blippy-Timestamp"
+
+// SignPayload returns the signature of a notification payload, formatted as
+// "sha256=<hex>". The signed message is "<timestamp>.<payload>".
+func SignPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NotificationIDHeader carries the delivery ID on outbound notifications, so
+// receivers can route replies back via the notification reply webhook.
+const NotificationIDHeader = "X-Blippy-Notification-ID"
+
+type notificationIDKey struct{}
+
+// WithNotificationID returns a context with the delivery ID of the
+// notification being sent.
+func WithNotificationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, notificationIDKey{}, id)
+}
+
+// GetNotificationID retrieves the delivery ID of the notification being sent.
+func GetNotificationID(ctx context.Context) string {
+	id, _ := ctx.Value(notificationIDKey{}).(string)
+	return id
+}
+
+// SentMessage is a chat message a notification was sent as, which replies
+// to the notification refer to.
+type SentMessage struct {
+	// ChatID is the Telegram chat or Slack channel the message was sent to.
+	ChatID string
+	// MessageID is the Telegram message ID or the Slack message timestamp.
+	MessageID string
+}
+
+type sentMessagesKey struct{}
+
+// WithSentMessages returns a context in which the chat messages notifications
+// are sent as are appended to msgs, so replies to them can be routed back.
+func WithSentMessages(ctx context.Context, msgs *[]SentMessage) context.Context {
+	return context.WithValue(ctx, sentMessagesKey{}, msgs)
+}
+
+// recordSentMessage records a chat message a notification was sent as, if
+// the context collects them.
+func recordSentMessage(ctx context.Context, m SentMessage) {
+	if msgs, ok := ctx.Value(sentMessagesKey{}).(*[]SentMessage); ok {
+		*msgs = append(*msgs, m)
+	}
+}
+
 // BuildNotificationTool creates a tool definition for a notification channel.
 func BuildNotificationTool(channel NotificationChannel) *Tool {
 	// Use provided schema or default to accepting any JSON
 	schema := channel.JSONSchema
 	if schema == "" {
-		schema = `{"type": "object", "additionalProperties": true}`
+		schema = defaultNotificationSchema(channel)
 	}
 
 	description := channel.Description
@@ -38,24 +105,95 @@ func BuildNotificationTool(channel NotificationChannel) *Tool {
 		Description: description,
 		Parameters:  json.RawMessage(schema),
 		Handler: func(ctx context.Context, argsJSON json.RawMessage) (string, error) {
-			switch channel.Type {
-			case "http_request":
-				return executeNotificationHTTPRequest(ctx, channel.Config, argsJSON)
-			default:
-				return fmt.Sprintf("Unknown channel type: %s", channel.Type), nil
+			if err := SendNotification(ctx, channel, argsJSON); err != nil {
+				return fmt.Sprintf("Failed to send: %s", err.Error()), nil
 			}
+			return "Notification sent successfully", nil
 		},
 	}
 }
 
-func executeNotificationHTTPRequest(ctx context.Context, configJSON string, payload json.RawMessage) (string, error) {
+// webPushDefaultSchema is the payload schema for web push channels without a
+// custom schema.
+const webPushDefaultSchema = `{"type": "object", "properties": {"title": {"type": "string"}, "body": {"type": "string"}, "url": {"type": "string", "description": "Path to open when the notification is clicked, e.g. /agents/<id>"}}, "required": ["title"]}`
+
+// defaultNotificationSchema returns the payload schema for channels that
+// don't define one.
+func defaultNotificationSchema(channel NotificationChannel) string {
+	switch channel.Type {
+	case "sms":
+		return smsDefaultSchema
+	case "email":
+		return emailDefaultSchema
+	case TelegramChannelType:
+		return telegramDefaultSchema
+	case SlackChannelType:
+		return slackDefaultSchema
+	case "web_push":
+		return webPushDefaultSchema
+	case GroupChannelType:
+		return groupDefaultSchema(channel.Config)
+	default:
+		return `{"type": "object", "additionalProperties": true}`
+	}
+}
+
+// validateNotificationPayload checks a payload against the channel's JSON
+// schema. It returns a field-by-field error message for the model, or an
+// empty string if the payload is valid.
+func validateNotificationPayload(channel NotificationChannel, payload json.RawMessage) string {
+	schema := channel.JSONSchema
+	if schema == "" {
+		schema = defaultNotificationSchema(channel)
+	}
+
+	errs, err := ValidateJSONSchema(json.RawMessage(schema), payload)
+	if err != nil || len(errs) == 0 {
+		// An unparseable schema is rejected when the channel is saved; don't
+		// block delivery on it here.
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Notification not sent: the payload does not match the schema of the %s channel:\n", channel.Name)
+	for _, e := range errs {
+		b.WriteString("- " + e + "\n")
+	}
+	b.WriteString("Fix the arguments and call the tool again.")
+	return b.String()
+}
+
+// SendNotification delivers a payload to a channel immediately, bypassing
+// any delivery policy (quiet hours, throttling).
+func SendNotification(ctx context.Context, channel NotificationChannel, payload json.RawMessage) error {
+	switch channel.Type {
+	case "http_request":
+		return sendNotificationHTTPRequest(ctx, channel.Config, payload)
+	case "sms":
+		return sendNotificationSMS(ctx, channel.Config, payload)
+	case "email":
+		return sendNotificationEmail(ctx, channel.Config, payload)
+	case TelegramChannelType:
+		return sendNotificationTelegram(ctx, channel.Config, payload)
+	case SlackChannelType:
+		return sendNotificationSlack(ctx, channel.Config, payload)
+	case GroupChannelType:
+		return fmt.Errorf("group channels are routed by the notification dispatcher")
+	default:
+		return fmt.Errorf("unknown channel type: %s", channel.Type)
+	}
+}
+
+func sendNotificationHTTPRequest(ctx context.Context, configJSON string, payload json.RawMessage) error {
 	var cfg struct {
-		URL     string            `json:"url"`
-		Method  string            `json:"method"`
-		Headers map[string]string `json:"headers"`
+		URL             string            `json:"url"`
+		Method          string            `json:"method"`
+		Headers         map[string]string `json:"headers"`
+		SigningSecret   string            `json:"signing_secret"`
+		SignatureHeader string            `json:"signature_header"`
 	}
 	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
-		return "", fmt.Errorf("parse config: %w", err)
+		return fmt.Errorf("parse config: %w", err)
 	}
 
 	method := cfg.Method
@@ -65,25 +203,33 @@ func executeNotificationHTTPRequest(ctx context.Context, configJSON string, payl
 
 	req, err := http.NewRequestWithContext(ctx, method, cfg.URL, bytes.NewReader(payload))
 	if err != nil {
-		return "", fmt.Errorf("create request: %w", err)
+		return fmt.Errorf("create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if id := GetNotificationID(ctx); id != "" {
+		req.Header.Set(NotificationIDHeader, id)
+	}
 	for key, value := range cfg.Headers {
 		req.Header.Set(key, value)
 	}
+	if cfg.SigningSecret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(cmp.Or(cfg.SignatureHeader, DefaultSignatureHeader), SignPayload(cfg.SigningSecret, timestamp, payload))
+		req.Header.Set(SignatureTimestampHeader, timestamp)
+	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Sprintf("Failed to send: %s", err.Error()), nil
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Sprintf("Failed with status %d: %s", resp.StatusCode, string(body)), nil
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
 	}
 
-	return "Notification sent successfully", nil
+	return nil
 }
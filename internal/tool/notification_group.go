@@ -0,0 +1,113 @@
+package tool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GroupChannelType is the type of channels that route notifications to other
+// channels instead of delivering them.
+const GroupChannelType = "group"
+
+// DefaultSeverityField is the payload property group channels route by,
+// unless the group configures another one.
+const DefaultSeverityField = "severity"
+
+// GroupConfig is the config of a group channel. A notification is routed to
+// the channels of the route matching its severity, or else to Default. The
+// channels are tried in order until one accepts the notification.
+type GroupConfig struct {
+	// SeverityField is the payload property holding the severity.
+	SeverityField string       `json:"severity_field,omitempty"`
+	Routes        []GroupRoute `json:"routes"`
+	// Default are the channels of notifications without a matching route.
+	Default []string `json:"default,omitempty"`
+}
+
+// GroupRoute maps a severity to the names of the channels to try, in order.
+type GroupRoute struct {
+	Severity string   `json:"severity"`
+	Channels []string `json:"channels"`
+}
+
+// ParseGroupConfig parses and validates the config of a group channel.
+func ParseGroupConfig(configJSON string) (GroupConfig, error) {
+	var cfg GroupConfig
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return GroupConfig{}, fmt.Errorf("parse group config: %w", err)
+	}
+	if len(cfg.Routes) == 0 && len(cfg.Default) == 0 {
+		return GroupConfig{}, errors.New("group requires routes or default channels")
+	}
+	seen := make(map[string]bool, len(cfg.Routes))
+	for _, r := range cfg.Routes {
+		key := strings.ToLower(r.Severity)
+		if key == "" {
+			return GroupConfig{}, errors.New("group route requires a severity")
+		}
+		if seen[key] {
+			return GroupConfig{}, fmt.Errorf("duplicate group route for severity %q", r.Severity)
+		}
+		seen[key] = true
+		if len(r.Channels) == 0 {
+			return GroupConfig{}, fmt.Errorf("group route for severity %q requires channels", r.Severity)
+		}
+	}
+	return cfg, nil
+}
+
+// ChannelsFor returns the channels to try for a payload, and its severity.
+// Severities match case-insensitively.
+func (c GroupConfig) ChannelsFor(payload json.RawMessage) (channels []string, severity string) {
+	var obj map[string]any
+	_ = json.Unmarshal(payload, &obj)
+	severity, _ = obj[c.severityField()].(string)
+	for _, r := range c.Routes {
+		if strings.EqualFold(r.Severity, severity) {
+			return r.Channels, severity
+		}
+	}
+	return c.Default, severity
+}
+
+func (c GroupConfig) severityField() string {
+	if c.SeverityField == "" {
+		return DefaultSeverityField
+	}
+	return c.SeverityField
+}
+
+// groupDefaultSchema returns the payload schema for group channels without a
+// custom schema: any object, with the severities of the routes.
+func groupDefaultSchema(configJSON string) string {
+	cfg, err := ParseGroupConfig(configJSON)
+	if err != nil {
+		return `{"type": "object", "additionalProperties": true}`
+	}
+	severities := make([]string, 0, len(cfg.Routes))
+	for _, r := range cfg.Routes {
+		if !slices.Contains(severities, r.Severity) {
+			severities = append(severities, r.Severity)
+		}
+	}
+	severity := map[string]any{
+		"type":        "string",
+		"description": "Severity of the notification, which decides the channels it's sent to",
+	}
+	if len(severities) > 0 {
+		severity["enum"] = severities
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{cfg.severityField(): severity},
+		"additionalProperties": true,
+	}
+	if len(cfg.Default) == 0 {
+		schema["required"] = []string{cfg.severityField()}
+	}
+	b, _ := json.Marshal(schema)
+	return string(b)
+}
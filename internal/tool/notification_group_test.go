@@ -0,0 +1,56 @@
+package tool
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestGroupConfigChannelsFor(t *testing.T) {
+	cfg, err := ParseGroupConfig(`{"severity_field":"level","routes":[{"severity":"critical","channels":["pager","sms"]}],"default":["chat"]}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		payload string
+		want    []string
+	}{
+		{`{"level":"CRITICAL","text":"down"}`, []string{"pager", "sms"}},
+		{`{"level":"warning"}`, []string{"chat"}},
+		{`{"severity":"critical"}`, []string{"chat"}},
+		{`"not an object"`, []string{"chat"}},
+	}
+	for _, tt := range tests {
+		if got, _ := cfg.ChannelsFor(json.RawMessage(tt.payload)); !slices.Equal(got, tt.want) {
+			t.Errorf("ChannelsFor(%s) = %v, want %v", tt.payload, got, tt.want)
+		}
+	}
+}
+
+func TestParseGroupConfigRejectsInvalid(t *testing.T) {
+	for _, config := range []string{
+		`{}`,
+		`{"routes":[{"severity":"","channels":["a"]}]}`,
+		`{"routes":[{"severity":"critical"}]}`,
+		`{"routes":[{"severity":"a","channels":["x"]},{"severity":"A","channels":["y"]}]}`,
+		`not json`,
+	} {
+		if _, err := ParseGroupConfig(config); err == nil {
+			t.Errorf("ParseGroupConfig(%s) succeeded", config)
+		}
+	}
+}
+
+func TestGroupDefaultSchema(t *testing.T) {
+	channel := NotificationChannel{Name: "ops", Type: GroupChannelType, Config: `{"routes":[{"severity":"critical","channels":["pager"]}]}`}
+	if msg := validateNotificationPayload(channel, json.RawMessage(`{"text":"down"}`)); msg == "" {
+		t.Error("payload without severity passed, but the group has no default channels")
+	}
+	if msg := validateNotificationPayload(channel, json.RawMessage(`{"severity":"low"}`)); msg == "" {
+		t.Error("payload with unknown severity passed")
+	}
+	if msg := validateNotificationPayload(channel, json.RawMessage(`{"severity":"critical","text":"down"}`)); msg != "" {
+		t.Errorf("valid payload rejected: %s", msg)
+	}
+}
@@ -0,0 +1,85 @@
+package tool
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSignPayload(t *testing.T) {
+	// HMAC-SHA256 of `1700000000.{"text":"hi"}` with key "whsec_test".
+	got := SignPayload("whsec_test", "1700000000", []byte(`{"text":"hi"}`))
+	if want := "sha256=add2fc7010ee7aa23f65449ce9240cd32763de7515ca26c43cd1e9d447a5361a"; got != want {
+		t.Errorf("SignPayload = %s, want %s", got, want)
+	}
+
+	// The timestamp is part of the signed message.
+	if SignPayload("whsec_test", "1700000001", []byte(`{"text":"hi"}`)) == got {
+		t.Error("signature doesn't depend on the timestamp")
+	}
+}
+
+// TestSendNotificationSigned checks that a receiver can verify the signature
+// headers of a signed http_request notification.
+func TestSendNotificationSigned(t *testing.T) {
+	signature := regexp.MustCompile(`^sha256=[0-9a-f]{64}$`)
+	tests := []struct {
+		name   string
+		config map[string]any
+		header string
+	}{
+		{name: "default header", config: map[string]any{"signing_secret": "whsec_test"}, header: DefaultSignatureHeader},
+		{name: "custom header", config: map[string]any{"signing_secret": "whsec_test", "signature_header": "X-Hub-Signature-256"}, header: "X-Hub-Signature-256"},
+		{name: "unsigned", config: map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verifyErr string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				timestamp := r.Header.Get(SignatureTimestampHeader)
+				if tt.header == "" {
+					if timestamp != "" || r.Header.Get(DefaultSignatureHeader) != "" {
+						verifyErr = "unsigned notification has signature headers"
+					}
+					return
+				}
+
+				sig := r.Header.Get(tt.header)
+				if !signature.MatchString(sig) {
+					verifyErr = "signature " + strconv.Quote(sig) + " isn't formatted as sha256=<hex>"
+					return
+				}
+				ts, err := strconv.ParseInt(timestamp, 10, 64)
+				if err != nil || time.Since(time.Unix(ts, 0)).Abs() > time.Minute {
+					verifyErr = "timestamp " + strconv.Quote(timestamp) + " isn't the current Unix time"
+					return
+				}
+				if !hmac.Equal([]byte(sig), []byte(SignPayload("whsec_test", timestamp, body))) {
+					verifyErr = "signature doesn't match the body"
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			tt.config["url"] = server.URL
+			config, err := json.Marshal(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			channel := NotificationChannel{Type: "http_request", Config: string(config)}
+			if err := SendNotification(context.Background(), channel, json.RawMessage(`{"text":"disk full"}`)); err != nil {
+				t.Fatal(err)
+			}
+			if verifyErr != "" {
+				t.Error(verifyErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+)
+
+// RedactedValue replaces secret values in tool results and API responses.
+const RedactedValue = "[REDACTED]"
+
+// minSecretLen is the minimum length of a value to be redacted. Shorter
+// values are too likely to match unrelated output.
+const minSecretLen = 6
+
+// SecretConfigKeys are notification channel config properties holding
+// credentials.
+var SecretConfigKeys = []string{"signing_secret", "auth_token", "password", "bot_token", "webhook_secret"}
+
+// sensitiveHeaderParts mark HTTP header names whose values are secrets.
+var sensitiveHeaderParts = []string{"auth", "token", "key", "secret", "password", "signature", "cookie"}
+
+// IsSensitiveHeader reports whether a header's value should be treated as a
+// secret.
+func IsSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveHeaderParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationConfigSecrets returns the secret values in a notification
+// channel config: credential properties and the values of sensitive headers.
+func NotificationConfigSecrets(configJSON string) []string {
+	var cfg map[string]any
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return nil
+	}
+
+	var secrets []string
+	for _, key := range SecretConfigKeys {
+		if s, ok := cfg[key].(string); ok && s != "" && s != RedactedValue {
+			secrets = append(secrets, s)
+		}
+	}
+	headers, _ := cfg["headers"].(map[string]any)
+	for name, v := range headers {
+		value, _ := v.(string)
+		if IsSensitiveHeader(name) && value != "" && value != RedactedValue {
+			secrets = append(secrets, value)
+			// Also catch the bare token of "Bearer <token>" style values.
+			if _, token, ok := strings.Cut(value, " "); ok {
+				secrets = append(secrets, token)
+			}
+		}
+	}
+	return secrets
+}
+
+// RedactSecrets replaces all occurrences of the given secret values in s.
+func RedactSecrets(s string, secrets []string) string {
+	// Replace longest values first, so a secret containing another one is
+	// fully redacted.
+	sorted := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLen {
+			sorted = append(sorted, secret)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	for _, secret := range sorted {
+		s = strings.ReplaceAll(s, secret, RedactedValue)
+	}
+	return s
+}
+
+// hostEnvSecrets returns the values of the host env vars forwarded to tools
+// in this context.
+func hostEnvSecrets(ctx context.Context) []string {
+	var secrets []string
+	for _, name := range GetHostEnvVars(ctx) {
+		if val, ok := os.LookupEnv(name); ok && val != "" {
+			secrets = append(secrets, val)
+		}
+	}
+	return secrets
+}
@@ -0,0 +1,138 @@
+package tool
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestIsSensitiveHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "Authorization", want: true},
+		{name: "X-API-Key", want: true},
+		{name: "x-auth-token", want: true},
+		{name: "X-Hub-Signature-256", want: true},
+		{name: "Cookie", want: true},
+		{name: "X-Webhook-Secret", want: true},
+		{name: "Content-Type"},
+		{name: "Accept"},
+		{name: "User-Agent"},
+	}
+	for _, tt := range tests {
+		if got := IsSensitiveHeader(tt.name); got != tt.want {
+			t.Errorf("IsSensitiveHeader(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNotificationConfigSecrets(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name:   "credential properties",
+			config: `{"bot_token":"123:abc","chat_id":"42","url":"https://example.com"}`,
+			want:   []string{"123:abc"},
+		},
+		{
+			name:   "nested sensitive headers",
+			config: `{"url":"https://example.com","headers":{"Authorization":"Bearer tok_123456","X-API-Key":"key_abcdef","Content-Type":"application/json"}}`,
+			want:   []string{"Bearer tok_123456", "tok_123456", "key_abcdef"},
+		},
+		{
+			// Only top-level credential properties and headers are secrets.
+			name:   "nested credential property",
+			config: `{"options":{"password":"hunter22"},"headers":{"Accept":"text/plain"}}`,
+		},
+		{
+			name:   "already redacted",
+			config: `{"password":"[REDACTED]","headers":{"Authorization":"[REDACTED]"}}`,
+		},
+		{
+			name:   "empty and non-string values",
+			config: `{"password":"","auth_token":42,"headers":{"X-Token":""}}`,
+		},
+		{name: "invalid JSON", config: `{"password":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NotificationConfigSecrets(tt.config)
+			slices.Sort(got)
+			want := slices.Clone(tt.want)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("NotificationConfigSecrets = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		secrets []string
+		want    string
+	}{
+		{
+			name:    "all occurrences",
+			s:       "token tok_123456 sent, then tok_123456 again",
+			secrets: []string{"tok_123456"},
+			want:    "token [REDACTED] sent, then [REDACTED] again",
+		},
+		{
+			name:    "in JSON",
+			s:       `{"headers":{"Authorization":"Bearer tok_123456"},"status":200}`,
+			secrets: []string{"tok_123456"},
+			want:    `{"headers":{"Authorization":"Bearer [REDACTED]"},"status":200}`,
+		},
+		{
+			name:    "longest first",
+			s:       "Bearer tok_123456",
+			secrets: []string{"tok_123456", "Bearer tok_123456"},
+			want:    "[REDACTED]",
+		},
+		{
+			name:    "short values untouched",
+			s:       `{"id":"abc","ok":true}`,
+			secrets: []string{"abc", "true"},
+			want:    `{"id":"abc","ok":true}`,
+		},
+		{
+			name: "no secrets",
+			s:    `{"password":"hunter22"}`,
+			want: `{"password":"hunter22"}`,
+		},
+		{
+			name:    "unrelated output untouched",
+			s:       "deployed version 1.2.3 to production",
+			secrets: []string{"tok_123456", ""},
+			want:    "deployed version 1.2.3 to production",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactSecrets(tt.s, tt.secrets); got != tt.want {
+				t.Errorf("RedactSecrets = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostEnvSecrets(t *testing.T) {
+	t.Setenv("BLIPPY_TEST_TOKEN", "ghp_secret")
+	t.Setenv("BLIPPY_TEST_EMPTY", "")
+
+	ctx := WithHostEnvVars(context.Background(), []string{"BLIPPY_TEST_TOKEN", "BLIPPY_TEST_EMPTY", "BLIPPY_TEST_UNSET"})
+	if got := hostEnvSecrets(ctx); !slices.Equal(got, []string{"ghp_secret"}) {
+		t.Errorf("hostEnvSecrets = %q, want [ghp_secret]", got)
+	}
+	if got := hostEnvSecrets(context.Background()); got != nil {
+		t.Errorf("hostEnvSecrets without forwarded vars = %q, want none", got)
+	}
+}
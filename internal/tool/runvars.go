@@ -0,0 +1,103 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+)
+
+// Run variable limits.
+const (
+	MaxRunVars        = 32
+	MaxRunVarValueLen = 4 << 10
+)
+
+var runVarNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// ValidateRunVars returns an error if vars can't be used as run variables:
+// names must be valid env var names, and values not too long.
+func ValidateRunVars(vars map[string]string) error {
+	if len(vars) > MaxRunVars {
+		return fmt.Errorf("at most %d vars are allowed", MaxRunVars)
+	}
+	for name, value := range vars {
+		if !runVarNameRe.MatchString(name) {
+			return fmt.Errorf("invalid var name %q: use letters, digits and underscores, not starting with a digit", name)
+		}
+		if len(value) > MaxRunVarValueLen {
+			return fmt.Errorf("value of var %q must be at most %d bytes", name, MaxRunVarValueLen)
+		}
+	}
+	return nil
+}
+
+// ExpandRunVars replaces "{{NAME}}" in s with the value of run variable NAME.
+// Placeholders of undefined vars are left as is.
+func ExpandRunVars(s string, vars map[string]string) string {
+	if len(vars) == 0 {
+		return s
+	}
+	oldnew := make([]string, 0, 2*len(vars))
+	for name, value := range vars {
+		oldnew = append(oldnew, "{{"+name+"}}", value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(s)
+}
+
+// EncodeRunVars encodes run variables as a JSON object for storing, e.g. the
+// vars of a trigger. Variables are encrypted with cipher, which may be nil,
+// since they can hold credentials; no variables are stored as "{}".
+func EncodeRunVars(cipher *encryption.Cipher, vars map[string]string) (string, error) {
+	if len(vars) == 0 {
+		return "{}", nil
+	}
+	b, err := json.Marshal(vars)
+	if err != nil {
+		return "", err
+	}
+	return cipher.Encrypt(string(b))
+}
+
+// DecodeRunVars decodes run variables stored by EncodeRunVars, or as a
+// plaintext JSON object before encryption was enabled. An empty string has no
+// variables.
+func DecodeRunVars(cipher *encryption.Cipher, s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	s, err := cipher.Decrypt(s)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt vars: %w", err)
+	}
+	var vars map[string]string
+	if err := json.Unmarshal([]byte(s), &vars); err != nil {
+		return nil, fmt.Errorf("parse vars: %w", err)
+	}
+	return vars, nil
+}
+
+// RunVarNames returns the names of vars in sorted order.
+func RunVarNames(vars map[string]string) []string {
+	return slices.Sorted(maps.Keys(vars))
+}
+
+type runVarsKey struct{}
+
+// WithRunVars returns a context with the variables of the current run, such
+// as a branch name passed by a CI webhook. They replace those of an outer
+// run, so subagents don't inherit them.
+func WithRunVars(ctx context.Context, vars map[string]string) context.Context {
+	return context.WithValue(ctx, runVarsKey{}, vars)
+}
+
+// GetRunVars retrieves the variables of the current run from context.
+func GetRunVars(ctx context.Context) map[string]string {
+	vars, _ := ctx.Value(runVarsKey{}).(map[string]string)
+	return vars
+}
@@ -0,0 +1,86 @@
+package tool
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+)
+
+func TestValidateRunVars(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "valid", vars: map[string]string{"BRANCH": "main", "_pr_2": "42"}},
+		{name: "leading digit", vars: map[string]string{"2FA": "x"}, wantErr: true},
+		{name: "dash", vars: map[string]string{"GIT-REF": "x"}, wantErr: true},
+		{name: "long value", vars: map[string]string{"LOG": strings.Repeat("x", MaxRunVarValueLen+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRunVars(tt.vars); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRunVars = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpandRunVars(t *testing.T) {
+	vars := map[string]string{"BRANCH": "main", "PR": "42"}
+	got := ExpandRunVars("Review PR {{PR}} on {{BRANCH}}, not {{OTHER}} or ${BRANCH}.", vars)
+	if want := "Review PR 42 on main, not {{OTHER}} or ${BRANCH}."; got != want {
+		t.Errorf("ExpandRunVars = %q, want %q", got, want)
+	}
+
+	// Run vars of an outer run are replaced, not inherited.
+	ctx := WithRunVars(context.Background(), vars)
+	if got := GetRunVars(WithRunVars(ctx, nil)); got != nil {
+		t.Errorf("GetRunVars of inner run = %v, want none", got)
+	}
+}
+
+func TestEncodeRunVars(t *testing.T) {
+	cipher, err := encryption.New(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars := map[string]string{"TOKEN": "ghp_secret"}
+
+	s, err := EncodeRunVars(cipher, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !encryption.IsEncrypted(s) || strings.Contains(s, "ghp_secret") {
+		t.Errorf("EncodeRunVars = %q, want encrypted", s)
+	}
+	got, err := DecodeRunVars(cipher, s)
+	if err != nil || !maps.Equal(got, vars) {
+		t.Errorf("DecodeRunVars = %v (err %v), want %v", got, err, vars)
+	}
+	if _, err := DecodeRunVars(nil, s); err == nil {
+		t.Error("DecodeRunVars without cipher: want error")
+	}
+
+	// Vars stored before encryption was enabled are read as plaintext.
+	got, err = DecodeRunVars(cipher, `{"TOKEN":"ghp_secret"}`)
+	if err != nil || !maps.Equal(got, vars) {
+		t.Errorf("DecodeRunVars of plaintext = %v (err %v), want %v", got, err, vars)
+	}
+
+	if got, err := DecodeRunVars(cipher, ""); err != nil || got != nil {
+		t.Errorf("DecodeRunVars of empty string = %v (err %v), want none", got, err)
+	}
+	for _, cipher := range []*encryption.Cipher{nil, cipher} {
+		if s, err := EncodeRunVars(cipher, nil); err != nil || s != "{}" {
+			t.Errorf("EncodeRunVars of no vars = %q (err %v), want {}", s, err)
+		}
+	}
+	if s, err := EncodeRunVars(nil, vars); err != nil || s != `{"TOKEN":"ghp_secret"}` {
+		t.Errorf("EncodeRunVars without cipher = %q (err %v)", s, err)
+	}
+}
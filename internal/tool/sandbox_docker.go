@@ -0,0 +1,187 @@
+package tool
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Defaults of Docker sandboxes.
+const (
+	DefaultDockerImage  = "debian:bookworm-slim"
+	DefaultDockerCPUs   = "1"
+	DefaultDockerMemory = "1g"
+	DefaultDockerPids   = 512
+)
+
+// DockerConfig configures a DockerSandbox.
+type DockerConfig struct {
+	// Image is the image containers are created from. It must have bash.
+	// Defaults to DefaultDockerImage.
+	Image string
+	// CPUs and Memory limit containers, in the format of the docker run
+	// --cpus and --memory flags (e.g. "1.5", "512m"). Default to
+	// DefaultDockerCPUs and DefaultDockerMemory; "0" means no limit.
+	CPUs   string
+	Memory string
+	// Pids limits the number of processes in a container. Defaults to
+	// DefaultDockerPids; -1 means no limit.
+	Pids int
+	// Network is the network containers are connected to, as for the docker
+	// run --network flag, e.g. "none" to disable networking. Empty uses
+	// Docker's default bridge network.
+	Network string
+	// Command is the Docker CLI. Defaults to "docker".
+	Command string
+}
+
+// DockerSandbox runs commands in local Docker containers with the Docker
+// CLI, one long-running container per agent. Containers are kept when the
+// server stops, and started again on the next command. They run without
+// capabilities or privilege escalation, with limited resources.
+type DockerSandbox struct {
+	cfg DockerConfig
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewDockerSandbox creates a Docker sandbox.
+func NewDockerSandbox(cfg DockerConfig) *DockerSandbox {
+	cfg.Image = cmp.Or(cfg.Image, DefaultDockerImage)
+	cfg.CPUs = cmp.Or(cfg.CPUs, DefaultDockerCPUs)
+	cfg.Memory = cmp.Or(cfg.Memory, DefaultDockerMemory)
+	cfg.Pids = cmp.Or(cfg.Pids, DefaultDockerPids)
+	cfg.Command = cmp.Or(cfg.Command, "docker")
+	return &DockerSandbox{cfg: cfg, running: make(map[string]bool)}
+}
+
+// Run implements Sandbox.
+func (d *DockerSandbox) Run(ctx context.Context, agentID, command string, env []string) (SandboxResult, error) {
+	name := "blippy-" + agentID
+	if err := d.ensureContainer(ctx, name, agentID); err != nil {
+		return SandboxResult{}, err
+	}
+
+	// Values are passed in a file rather than the CLI's arguments, which
+	// other users of the host can see, or its environment, where names like
+	// DOCKER_HOST would configure the CLI itself.
+	args := []string{"exec"}
+	if len(env) > 0 {
+		envFile, cleanup, err := writeEnvFile(env)
+		if err != nil {
+			return SandboxResult{}, err
+		}
+		defer cleanup()
+		args = append(args, "--env-file", envFile)
+	}
+	args = append(args, name, "bash", "-c", command)
+	cmd := exec.CommandContext(ctx, d.cfg.Command, args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	res := SandboxResult{}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return SandboxResult{}, err
+		}
+		// The container may have been stopped or removed since, which is
+		// checked again on the next command.
+		if strings.HasPrefix(stderr.String(), "Error response from daemon:") {
+			d.mu.Lock()
+			delete(d.running, name)
+			d.mu.Unlock()
+			return SandboxResult{}, fmt.Errorf("container %s: %s", name, strings.TrimSpace(stderr.String()))
+		}
+		res.ExitCode = exitErr.ExitCode()
+	}
+	res.Stdout = stdout.String()
+	res.Stderr = stderr.String()
+	return res, nil
+}
+
+// writeEnvFile writes env to a file only the current user can read, in the
+// format of the docker exec --env-file flag. The returned function removes
+// it.
+func writeEnvFile(env []string) (string, func(), error) {
+	for _, e := range env {
+		if strings.ContainsAny(e, "\r\n") {
+			k, _, _ := strings.Cut(e, "=")
+			return "", nil, fmt.Errorf("value of %s has a line break, which can't be passed to a Docker sandbox", k)
+		}
+	}
+	// MkdirTemp creates the directory with mode 0700.
+	dir, err := os.MkdirTemp("", "blippy-env-")
+	if err != nil {
+		return "", nil, fmt.Errorf("create env file: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	path := filepath.Join(dir, "env")
+	if err := os.WriteFile(path, []byte(strings.Join(env, "\n")+"\n"), 0o600); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("write env file: %w", err)
+	}
+	return path, cleanup, nil
+}
+
+// ensureContainer starts the agent's container, creating it if it doesn't
+// exist.
+func (d *DockerSandbox) ensureContainer(ctx context.Context, name, agentID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.running[name] {
+		return nil
+	}
+
+	state, err := d.docker(ctx, "inspect", "--format", "{{.State.Running}}", name)
+	switch {
+	case err != nil && strings.Contains(err.Error(), "No such"):
+		args := []string{
+			"run", "--detach", "--init", "--name", name, "--label", "blippy.agent_id=" + agentID,
+			"--cap-drop=ALL", "--security-opt=no-new-privileges",
+			"--cpus", d.cfg.CPUs, "--memory", d.cfg.Memory, "--pids-limit", strconv.Itoa(d.cfg.Pids),
+		}
+		if d.cfg.Network != "" {
+			args = append(args, "--network", d.cfg.Network)
+		}
+		args = append(args, d.cfg.Image, "sleep", "infinity")
+		if _, err := d.docker(ctx, args...); err != nil {
+			return fmt.Errorf("create container: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("inspect container: %w", err)
+	case state != "true":
+		if _, err := d.docker(ctx, "start", name); err != nil {
+			return fmt.Errorf("start container: %w", err)
+		}
+	}
+	d.running[name] = true
+	return nil
+}
+
+// docker runs a Docker CLI command, returning its trimmed output. Errors
+// include the command's stderr.
+func (d *DockerSandbox) docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, d.cfg.Command, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,73 @@
+package tool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDockerSandbox(t *testing.T) {
+	// A fake Docker CLI that logs its arguments, knows no containers and
+	// runs exec'd commands on the host.
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "log")
+	script := `#!/bin/sh
+echo "$@" >> ` + logPath + `
+[ -n "$DOCKER_HOST" ] && echo "DOCKER_HOST=$DOCKER_HOST" >> ` + logPath + `
+case "$1" in
+inspect) echo "Error: No such object: $4" >&2; exit 1 ;;
+run) echo container-id ;;
+exec) shift; if [ "$1" = "--env-file" ]; then ls -l "$2" | cut -c1-10 >> ` + logPath + `; set -a; . "$2"; set +a; shift 2; fi; shift; exec "$@" ;;
+esac
+`
+	docker := filepath.Join(dir, "docker")
+	if err := os.WriteFile(docker, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	sandbox := NewDockerSandbox(DockerConfig{Memory: "512m", Network: "none", Command: docker})
+	ctx := context.Background()
+	// DOCKER_HOST is set in the container, not for the Docker CLI.
+	t.Setenv("DOCKER_HOST", "")
+	res, err := sandbox.Run(ctx, "a1", `echo "$GREETING $DOCKER_HOST"; echo oops >&2; exit 3`, []string{"GREETING=hello", "DOCKER_HOST=tcp://evil:2375"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Stdout != "hello tcp://evil:2375\n" || res.Stderr != "oops\n" || res.ExitCode != 3 {
+		t.Errorf("result = %+v", res)
+	}
+	if _, err := sandbox.Run(ctx, "a1", "true", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sandbox.Run(ctx, "a1", "true", []string{"NOTE=two\nlines"}); err == nil {
+		t.Error("value with a line break was passed")
+	}
+
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"inspect --format {{.State.Running}} blippy-a1",
+		"run --detach --init --name blippy-a1 --label blippy.agent_id=a1 --cap-drop=ALL --security-opt=no-new-privileges --cpus 1 --memory 512m --pids-limit 512 --network none " + DefaultDockerImage + " sleep infinity",
+		// Values aren't in the arguments or the CLI's environment.
+		`exec --env-file ENV_FILE blippy-a1 bash -c echo "$GREETING $DOCKER_HOST"; echo oops >&2; exit 3`,
+		"-rw-------",
+		"exec blippy-a1 bash -c true",
+	}
+	// The env file is private and removed after the command.
+	envFile := regexp.MustCompile(`--env-file (\S+)`).FindStringSubmatch(string(log))
+	if envFile == nil {
+		t.Fatalf("docker commands without env file:\n%s", log)
+	}
+	if _, err := os.Stat(envFile[1]); !os.IsNotExist(err) {
+		t.Errorf("env file wasn't removed: %v", err)
+	}
+	got := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(log), envFile[1], "ENV_FILE")), "\n")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("docker commands:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,71 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	sprites "github.com/superfly/sprites-go"
+)
+
+// SpritesSandbox runs commands in Fly Sprites, one sprite per agent.
+type SpritesSandbox struct {
+	client *sprites.Client
+
+	// Track which sprites we've already created
+	mu      sync.Mutex
+	created map[string]bool
+}
+
+// NewSpritesSandbox creates a sandbox with a Sprites client.
+func NewSpritesSandbox(apiKey string) *SpritesSandbox {
+	return &SpritesSandbox{
+		client:  sprites.New(apiKey),
+		created: make(map[string]bool),
+	}
+}
+
+// Run implements Sandbox.
+func (s *SpritesSandbox) Run(ctx context.Context, agentID, command string, env []string) (SandboxResult, error) {
+	spriteName := "blippy-" + agentID
+
+	// Ensure sprite exists (create if needed)
+	s.mu.Lock()
+	needsCreate := !s.created[spriteName]
+	s.mu.Unlock()
+
+	if needsCreate {
+		_, err := s.client.GetSprite(ctx, spriteName)
+		if err != nil {
+			_, err = s.client.CreateSprite(ctx, spriteName, nil)
+			if err != nil && !strings.Contains(err.Error(), "already exists") {
+				return SandboxResult{}, fmt.Errorf("create sprite: %w", err)
+			}
+		}
+		s.mu.Lock()
+		s.created[spriteName] = true
+		s.mu.Unlock()
+	}
+
+	cmd := s.client.Sprite(spriteName).CommandContext(ctx, "bash", "-c", command)
+	cmd.Env = env
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	res := SandboxResult{}
+	if err := cmd.Run(); err != nil {
+		var exitErr *sprites.ExitError
+		if !errors.As(err, &exitErr) {
+			return SandboxResult{}, err
+		}
+		res.ExitCode = exitErr.ExitCode()
+	}
+	res.Stdout = stdout.String()
+	res.Stderr = stderr.String()
+	return res, nil
+}
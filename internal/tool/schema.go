@@ -0,0 +1,214 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateJSONSchema validates a JSON value against a JSON Schema and returns
+// one message per violation, prefixed with the path of the offending field.
+// It supports the subset of keywords commonly used for tool parameters:
+// type, properties, required, additionalProperties, items, enum, const,
+// minLength, maxLength, pattern, minimum, maximum, minItems and maxItems.
+// Unknown keywords are ignored.
+func ValidateJSONSchema(schema, value json.RawMessage) ([]string, error) {
+	var s map[string]any
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+
+	var v any
+	if err := json.Unmarshal(value, &v); err != nil {
+		return []string{fmt.Sprintf("(root): invalid JSON: %s", err.Error())}, nil
+	}
+
+	var errs []string
+	validateValue(s, v, "", &errs)
+	return errs, nil
+}
+
+func validateValue(schema map[string]any, v any, path string, errs *[]string) {
+	add := func(format string, args ...any) {
+		p := path
+		if p == "" {
+			p = "(root)"
+		}
+		*errs = append(*errs, p+": "+fmt.Sprintf(format, args...))
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, v) {
+		add("must be of type %s, got %s", typeString(t), jsonType(v))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !containsValue(enum, v) {
+		add("must be one of %s", formatValues(enum))
+	}
+	if c, ok := schema["const"]; ok && !equalValues(c, v) {
+		add("must be %s", formatValues([]any{c}))
+	}
+
+	switch val := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(val)
+		if min, ok := number(schema["minLength"]); ok && float64(n) < min {
+			add("must be at least %v characters", min)
+		}
+		if max, ok := number(schema["maxLength"]); ok && float64(n) > max {
+			add("must be at most %v characters", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(val) {
+				add("must match pattern %q", pattern)
+			}
+		}
+	case float64:
+		if min, ok := number(schema["minimum"]); ok && val < min {
+			add("must be >= %v", min)
+		}
+		if max, ok := number(schema["maximum"]); ok && val > max {
+			add("must be <= %v", max)
+		}
+	case []any:
+		if min, ok := number(schema["minItems"]); ok && float64(len(val)) < min {
+			add("must have at least %v items", min)
+		}
+		if max, ok := number(schema["maxItems"]); ok && float64(len(val)) > max {
+			add("must have at most %v items", max)
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range val {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case map[string]any:
+		props, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, r := range required {
+				name, _ := r.(string)
+				if _, ok := val[name]; !ok {
+					*errs = append(*errs, joinPath(path, name)+": is required")
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if propSchema, ok := props[k].(map[string]any); ok {
+				validateValue(propSchema, val[k], joinPath(path, k), errs)
+				continue
+			}
+			switch ap := schema["additionalProperties"].(type) {
+			case bool:
+				if !ap && props != nil {
+					*errs = append(*errs, joinPath(path, k)+": is not an allowed property")
+				}
+			case map[string]any:
+				validateValue(ap, val[k], joinPath(path, k), errs)
+			}
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func matchesType(t any, v any) bool {
+	switch t := t.(type) {
+	case string:
+		return matchesTypeName(t, v)
+	case []any:
+		for _, name := range t {
+			if s, ok := name.(string); ok && matchesTypeName(s, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesTypeName(name string, v any) bool {
+	switch name {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return jsonType(v) == name
+	}
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
+
+func typeString(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, n := range list {
+			names = append(names, fmt.Sprint(n))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func number(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func containsValue(list []any, v any) bool {
+	for _, item := range list {
+		if equalValues(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalValues(a, b any) bool {
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return string(aj) == string(bj)
+}
+
+func formatValues(values []any) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		b, _ := json.Marshal(v)
+		parts[i] = string(b)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,63 @@
+package tool
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema := json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"text": {"type": "string", "minLength": 1},
+			"priority": {"type": "string", "enum": ["low", "high"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["text"],
+		"additionalProperties": false
+	}`)
+
+	tests := []struct {
+		name    string
+		payload string
+		want    []string
+	}{
+		{
+			name:    "valid",
+			payload: `{"text": "hello", "priority": "low", "tags": ["a"]}`,
+		},
+		{
+			name:    "missing required",
+			payload: `{"priority": "high"}`,
+			want:    []string{"text: is required"},
+		},
+		{
+			name:    "wrong types and values",
+			payload: `{"text": "", "priority": "urgent", "tags": [1], "extra": true}`,
+			want: []string{
+				"extra: is not an allowed property",
+				`priority: must be one of "low", "high"`,
+				"tags[0]: must be of type string, got number",
+				"text: must be at least 1 characters",
+			},
+		},
+		{
+			name:    "not an object",
+			payload: `"hello"`,
+			want:    []string{"(root): must be of type object, got string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateJSONSchema(schema, json.RawMessage(tt.payload))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SlackChannelType is the type of channels that post messages to a Slack
+// channel with a bot, and route replies in their threads back to the
+// conversation that sent them.
+const SlackChannelType = "slack"
+
+// slackMaxLength is the maximum length of the text of a Slack message.
+// Longer texts are split into numbered parts.
+const slackMaxLength = 40000
+
+// slackDefaultSchema is the payload schema for Slack channels without a
+// custom schema.
+const slackDefaultSchema = `{"type": "object", "properties": {"text": {"type": "string", "description": "Message text, in Slack mrkdwn."}}, "required": ["text"]}`
+
+// slackAPIURL is the base URL of the Slack Web API, a variable so tests can
+// replace it.
+var slackAPIURL = "https://slack.com/api"
+
+// SlackConfig is the config of a Slack channel.
+type SlackConfig struct {
+	// BotToken is the bot's OAuth token, with the chat:write scope.
+	BotToken string `json:"bot_token"`
+	// Channel is the ID of the Slack channel notifications are posted to.
+	Channel string `json:"channel"`
+	// SigningSecret is the signing secret of the Slack app, with which it
+	// signs the events sent to the channel's webhook. Required for replies
+	// in notification threads to be routed back to their conversations.
+	SigningSecret string `json:"signing_secret,omitempty"`
+}
+
+// ParseSlackConfig parses and validates the config of a Slack channel.
+func ParseSlackConfig(configJSON string) (SlackConfig, error) {
+	var cfg SlackConfig
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return SlackConfig{}, fmt.Errorf("parse slack config: %w", err)
+	}
+	if cfg.BotToken == "" {
+		return SlackConfig{}, errors.New("slack channel requires bot_token")
+	}
+	if cfg.Channel == "" {
+		return SlackConfig{}, errors.New("slack channel requires channel")
+	}
+	return cfg, nil
+}
+
+func sendNotificationSlack(ctx context.Context, configJSON string, payload json.RawMessage) error {
+	cfg, err := ParseSlackConfig(configJSON)
+	if err != nil {
+		return err
+	}
+
+	var msg struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(payload, &msg); err != nil || msg.Text == "" {
+		return fmt.Errorf("payload must have a non-empty text property")
+	}
+	return SendSlackMessage(ctx, cfg.BotToken, cfg.Channel, "", msg.Text)
+}
+
+// SendSlackMessage posts a text message to a Slack channel, in the thread of
+// threadTS if it isn't empty. Texts longer than a message are split into
+// numbered parts. The parts are recorded as sent messages, see
+// WithSentMessages.
+func SendSlackMessage(ctx context.Context, botToken, channel, threadTS, text string) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	for _, part := range splitSMS(text, slackMaxLength) {
+		msg := map[string]string{"channel": channel, "text": part}
+		if threadTS != "" {
+			msg["thread_ts"] = threadTS
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal message: %w", err)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackAPIURL+"/chat.postMessage", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("Authorization", "Bearer "+botToken)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("post to slack channel %s: %w", channel, err)
+		}
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("post to slack channel %s: status %d: %s", channel, resp.StatusCode, string(respBody))
+		}
+
+		// Slack reports errors in the body of 200 responses.
+		var posted struct {
+			OK      bool   `json:"ok"`
+			Error   string `json:"error"`
+			Channel string `json:"channel"`
+			TS      string `json:"ts"`
+		}
+		if err := json.Unmarshal(respBody, &posted); err != nil {
+			return fmt.Errorf("post to slack channel %s: parse response: %w", channel, err)
+		}
+		if !posted.OK {
+			return fmt.Errorf("post to slack channel %s: %s", channel, posted.Error)
+		}
+		recordSentMessage(ctx, SentMessage{ChatID: posted.Channel, MessageID: posted.TS})
+	}
+	return nil
+}
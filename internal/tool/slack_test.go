@@ -0,0 +1,48 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendNotificationSlack(t *testing.T) {
+	var posted []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" || r.Header.Get("Authorization") != "Bearer xoxb-1" {
+			w.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+			return
+		}
+		var msg map[string]string
+		json.NewDecoder(r.Body).Decode(&msg)
+		posted = append(posted, msg)
+		w.Write([]byte(`{"ok": true, "channel": "C1", "ts": "1700000000.000100"}`))
+	}))
+	defer srv.Close()
+	slackAPIURL = srv.URL
+	t.Cleanup(func() { slackAPIURL = "https://slack.com/api" })
+
+	var sent []SentMessage
+	ctx := WithSentMessages(context.Background(), &sent)
+	channel := NotificationChannel{Type: SlackChannelType, Config: `{"bot_token": "xoxb-1", "channel": "C1"}`}
+	if err := SendNotification(ctx, channel, json.RawMessage(`{"text": "Deploy failed"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(posted) != 1 || posted[0]["channel"] != "C1" || posted[0]["text"] != "Deploy failed" || posted[0]["thread_ts"] != "" {
+		t.Errorf("posted = %v", posted)
+	}
+	// The message is recorded so replies in its thread can be routed back.
+	if len(sent) != 1 || sent[0] != (SentMessage{ChatID: "C1", MessageID: "1700000000.000100"}) {
+		t.Errorf("sent = %v", sent)
+	}
+
+	channel.Config = `{"bot_token": "xoxb-2", "channel": "C1"}`
+	if err := SendNotification(ctx, channel, json.RawMessage(`{"text": "Hi"}`)); err == nil {
+		t.Error("error response of slack isn't an error")
+	}
+	if _, err := ParseSlackConfig(`{"bot_token": "xoxb-1"}`); err == nil {
+		t.Error("config without channel is valid")
+	}
+}
@@ -0,0 +1,105 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// smsMaxLength is the maximum length of a single message accepted by
+// Twilio. Longer texts are split into numbered parts.
+const smsMaxLength = 1600
+
+// smsDefaultSchema is the payload schema for SMS channels without a custom
+// schema.
+const smsDefaultSchema = `{"type": "object", "properties": {"text": {"type": "string", "description": "Message text. Keep it short; long texts are split into multiple SMS messages."}}, "required": ["text"]}`
+
+const twilioBaseURL = "https://api.twilio.com"
+
+func sendNotificationSMS(ctx context.Context, configJSON string, payload json.RawMessage) error {
+	var cfg struct {
+		AccountSID string   `json:"account_sid"`
+		AuthToken  string   `json:"auth_token"`
+		From       string   `json:"from"`
+		To         []string `json:"to"`
+	}
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+	if cfg.AccountSID == "" || cfg.AuthToken == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return fmt.Errorf("sms channel requires account_sid, auth_token, from and to")
+	}
+
+	var msg struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(payload, &msg); err != nil || msg.Text == "" {
+		return fmt.Errorf("payload must have a non-empty text property")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", twilioBaseURL, url.PathEscape(cfg.AccountSID))
+
+	for _, to := range cfg.To {
+		for _, part := range splitSMS(msg.Text, smsMaxLength) {
+			form := url.Values{"From": {cfg.From}, "To": {to}, "Body": {part}}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+			if err != nil {
+				return fmt.Errorf("create request: %w", err)
+			}
+			req.SetBasicAuth(cfg.AccountSID, cfg.AuthToken)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return fmt.Errorf("send to %s: %w", to, err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode >= 400 {
+				return fmt.Errorf("send to %s: status %d: %s", to, resp.StatusCode, string(body))
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitSMS splits text into parts of at most maxLen characters, breaking on
+// whitespace where possible. Parts are prefixed with "(i/n) " when the text
+// doesn't fit in a single message.
+func splitSMS(text string, maxLen int) []string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= maxLen {
+		return []string{string(runes)}
+	}
+
+	// Reserve room for the "(i/n) " prefix; 10 covers up to 99 parts.
+	limit := maxLen - 10
+	var parts []string
+	for len(runes) > 0 {
+		if len(runes) <= limit {
+			parts = append(parts, string(runes))
+			break
+		}
+		cut := limit
+		for i := limit; i > limit/2; i-- {
+			if runes[i] == ' ' || runes[i] == '\n' {
+				cut = i
+				break
+			}
+		}
+		parts = append(parts, strings.TrimSpace(string(runes[:cut])))
+		runes = []rune(strings.TrimSpace(string(runes[cut:])))
+	}
+
+	for i := range parts {
+		parts[i] = fmt.Sprintf("(%d/%d) %s", i+1, len(parts), parts[i])
+	}
+	return parts
+}
@@ -0,0 +1,29 @@
+package tool
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitSMS(t *testing.T) {
+	if parts := splitSMS("short message", 160); len(parts) != 1 || parts[0] != "short message" {
+		t.Fatalf("unexpected parts: %q", parts)
+	}
+
+	text := strings.Repeat("word ", 100)
+	parts := splitSMS(text, 160)
+	if len(parts) < 4 {
+		t.Fatalf("expected at least 4 parts, got %d", len(parts))
+	}
+	for i, p := range parts {
+		if n := len([]rune(p)); n > 160 {
+			t.Errorf("part %d has %d characters", i, n)
+		}
+		if strings.HasSuffix(p, "wor") {
+			t.Errorf("part %d splits a word: %q", i, p)
+		}
+	}
+	if !strings.HasPrefix(parts[0], "(1/") {
+		t.Errorf("expected numbered parts, got %q", parts[0])
+	}
+}
@@ -0,0 +1,125 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// AgentSpawner is the interface for running subagents in the background.
+type AgentSpawner interface {
+	SpawnAgent(ctx context.Context, agentID, prompt string, depth int, model, title string) (runID string, err error)
+	AgentRun(ctx context.Context, runID string) (AgentRunState, error)
+}
+
+// AgentRunState is the state of a spawned subagent run.
+type AgentRunState struct {
+	// Status is "running" until the run finishes, then "completed",
+	// "failed", "interrupted" or "cancelled".
+	Status         string
+	ConversationID string
+	Response       string
+	Error          string
+}
+
+type checkAgentRunArgs struct {
+	RunID string `json:"run_id"`
+}
+
+// NewSpawnAgentTool creates a tool for asynchronous subagent invocation. The
+// run's result is checked with the tool of NewCheckAgentRunTool.
+func NewSpawnAgentTool(spawner AgentSpawner) *Tool {
+	return &Tool{
+		Name:        "spawn_agent",
+		Description: "Start another agent in the background and get a run ID immediately, without waiting for its response. Use this for long tasks you don't need the result of right away, and check on the run with check_agent_run.",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"agent_id": {
+					"type": "string",
+					"description": "The ID of the agent to start. If omitted, defaults to the current agent."
+				},
+				"prompt": {
+					"type": "string",
+					"description": "The instruction for the agent"
+				},
+				"model": {
+					"type": "string",
+					"description": "Optional model override for this agent run"
+				},
+				"title": {
+					"type": "string",
+					"description": "Optional title for the new conversation. If omitted, a title is auto-generated."
+				}
+			},
+			"required": ["prompt"]
+		}`),
+		Handler: func(ctx context.Context, argsJSON json.RawMessage) (string, error) {
+			var args callAgentArgs
+			if err := json.Unmarshal(argsJSON, &args); err != nil {
+				return "", fmt.Errorf("parse args: %w", err)
+			}
+
+			if args.AgentID == "" {
+				args.AgentID = GetAgentID(ctx)
+				if args.AgentID == "" {
+					return "", fmt.Errorf("agent_id is required (no current agent in context)")
+				}
+			}
+			if args.Prompt == "" {
+				return "", fmt.Errorf("prompt is required")
+			}
+
+			newDepth := GetDepth(ctx) + 1
+			if newDepth > DefaultMaxDepth {
+				return "", fmt.Errorf("max agent depth exceeded (%d)", DefaultMaxDepth)
+			}
+
+			runID, err := spawner.SpawnAgent(ctx, args.AgentID, args.Prompt, newDepth, args.Model, args.Title)
+			if err != nil {
+				return fmt.Sprintf("Error spawning agent: %s", err.Error()), nil
+			}
+			return fmt.Sprintf("Started agent run %s. Check its result with check_agent_run.", runID), nil
+		},
+	}
+}
+
+// NewCheckAgentRunTool creates a tool for checking the state of a run
+// started with spawn_agent.
+func NewCheckAgentRunTool(spawner AgentSpawner) *Tool {
+	return &Tool{
+		Name:        "check_agent_run",
+		Description: "Check the status of an agent run started with spawn_agent, and get its response once it has finished.",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"run_id": {
+					"type": "string",
+					"description": "The run ID returned by spawn_agent"
+				}
+			},
+			"required": ["run_id"]
+		}`),
+		Handler: func(ctx context.Context, argsJSON json.RawMessage) (string, error) {
+			var args checkAgentRunArgs
+			if err := json.Unmarshal(argsJSON, &args); err != nil {
+				return "", fmt.Errorf("parse args: %w", err)
+			}
+			if args.RunID == "" {
+				return "", fmt.Errorf("run_id is required")
+			}
+
+			state, err := spawner.AgentRun(ctx, args.RunID)
+			if err != nil {
+				return fmt.Sprintf("Error checking agent run: %s", err.Error()), nil
+			}
+			switch state.Status {
+			case "running":
+				return fmt.Sprintf("Agent run %s is still running (conversation %s).", args.RunID, state.ConversationID), nil
+			case "completed":
+				return state.Response, nil
+			}
+			return fmt.Sprintf("Agent run %s %s: %s (conversation %s).", args.RunID, state.Status, state.Error, state.ConversationID), nil
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeSpawner struct {
+	depth int
+	runs  map[string]AgentRunState
+}
+
+func (f *fakeSpawner) SpawnAgent(ctx context.Context, agentID, prompt string, depth int, model, title string) (string, error) {
+	f.depth = depth
+	f.runs["run-1"] = AgentRunState{Status: "running", ConversationID: "conv-1"}
+	return "run-1", nil
+}
+
+func (f *fakeSpawner) AgentRun(ctx context.Context, runID string) (AgentRunState, error) {
+	state, ok := f.runs[runID]
+	if !ok {
+		return AgentRunState{}, errors.New("spawned run not found")
+	}
+	return state, nil
+}
+
+func TestSpawnAgent(t *testing.T) {
+	spawner := &fakeSpawner{runs: make(map[string]AgentRunState)}
+	spawn := NewSpawnAgentTool(spawner)
+	check := NewCheckAgentRunTool(spawner)
+	ctx := WithDepth(WithAgentID(context.Background(), "agent-1"), 1)
+
+	out, err := spawn.Handler(ctx, json.RawMessage(`{"prompt": "Research"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "run-1") || spawner.depth != 2 {
+		t.Errorf("spawn output = %q at depth %d, want run-1 at depth 2", out, spawner.depth)
+	}
+
+	out, err = check.Handler(ctx, json.RawMessage(`{"run_id": "run-1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "still running") {
+		t.Errorf("check output = %q, want still running", out)
+	}
+
+	spawner.runs["run-1"] = AgentRunState{Status: "completed", Response: "Findings"}
+	if out, _ := check.Handler(ctx, json.RawMessage(`{"run_id": "run-1"}`)); out != "Findings" {
+		t.Errorf("check output = %q, want response", out)
+	}
+	if out, _ := check.Handler(ctx, json.RawMessage(`{"run_id": "unknown"}`)); !strings.Contains(out, "not found") {
+		t.Errorf("check output for unknown run = %q, want error", out)
+	}
+
+	deep := WithDepth(ctx, DefaultMaxDepth)
+	if _, err := spawn.Handler(deep, json.RawMessage(`{"prompt": "Research"}`)); err == nil {
+		t.Error("spawn beyond max depth succeeded")
+	}
+}
@@ -0,0 +1,138 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// TelegramChannelType is the type of channels that send messages with a
+// Telegram bot, and optionally hand messages sent to the bot to an agent.
+const TelegramChannelType = "telegram"
+
+// telegramMaxLength is the maximum length of a Telegram message. Longer texts
+// are split into numbered parts.
+const telegramMaxLength = 4096
+
+// telegramDefaultSchema is the payload schema for Telegram channels without
+// a custom schema.
+const telegramDefaultSchema = `{"type": "object", "properties": {"text": {"type": "string", "description": "Message text."}}, "required": ["text"]}`
+
+// telegramAPIURL is the base URL of the Telegram Bot API, a variable so tests
+// can replace it.
+var telegramAPIURL = "https://api.telegram.org"
+
+// TelegramConfig is the config of a Telegram channel.
+type TelegramConfig struct {
+	BotToken string `json:"bot_token"`
+	// ChatIDs are the chats notifications are sent to.
+	ChatIDs []string `json:"chat_ids"`
+	// AgentID is the agent that answers messages sent to the bot, through
+	// the channel's webhook. Without it, only replies to notifications are
+	// handled.
+	AgentID string `json:"agent_id,omitempty"`
+	// WebhookSecret is the secret token the webhook was registered with,
+	// which Telegram sends with each update. Required with AgentID, and for
+	// replies to notifications to be routed back to their conversations.
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+	// AllowedChatIDs are the chats besides ChatIDs whose messages the agent
+	// answers, or "*" for any chat.
+	AllowedChatIDs []string `json:"allowed_chat_ids,omitempty"`
+}
+
+// ParseTelegramConfig parses and validates the config of a Telegram channel.
+func ParseTelegramConfig(configJSON string) (TelegramConfig, error) {
+	var cfg TelegramConfig
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		return TelegramConfig{}, fmt.Errorf("parse telegram config: %w", err)
+	}
+	if cfg.BotToken == "" {
+		return TelegramConfig{}, errors.New("telegram channel requires bot_token")
+	}
+	if cfg.AgentID != "" && cfg.WebhookSecret == "" {
+		return TelegramConfig{}, errors.New("telegram channel with agent_id requires webhook_secret")
+	}
+	return cfg, nil
+}
+
+// AllowsChat reports whether the agent answers messages from a chat.
+func (c TelegramConfig) AllowsChat(chatID string) bool {
+	return slices.Contains(c.ChatIDs, chatID) || slices.Contains(c.AllowedChatIDs, chatID) || slices.Contains(c.AllowedChatIDs, "*")
+}
+
+func sendNotificationTelegram(ctx context.Context, configJSON string, payload json.RawMessage) error {
+	cfg, err := ParseTelegramConfig(configJSON)
+	if err != nil {
+		return err
+	}
+	if len(cfg.ChatIDs) == 0 {
+		return fmt.Errorf("telegram channel requires chat_ids to send notifications")
+	}
+
+	var msg struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(payload, &msg); err != nil || msg.Text == "" {
+		return fmt.Errorf("payload must have a non-empty text property")
+	}
+
+	for _, chatID := range cfg.ChatIDs {
+		if err := SendTelegramMessage(ctx, cfg.BotToken, chatID, msg.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendTelegramMessage sends a text message to a chat with a bot. Texts
+// longer than a message are split into numbered parts. The parts are
+// recorded as sent messages, see WithSentMessages.
+func SendTelegramMessage(ctx context.Context, botToken, chatID, text string) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", telegramAPIURL, url.PathEscape(botToken))
+
+	for _, part := range splitSMS(text, telegramMaxLength) {
+		body, err := json.Marshal(map[string]string{"chat_id": chatID, "text": part})
+		if err != nil {
+			return fmt.Errorf("marshal message: %w", err)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			// The error contains the URL, which contains the bot token.
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			return fmt.Errorf("send to chat %s: %w", chatID, err)
+		}
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("send to chat %s: status %d: %s", chatID, resp.StatusCode, string(respBody))
+		}
+
+		var sent struct {
+			Result struct {
+				MessageID int64 `json:"message_id"`
+			} `json:"result"`
+		}
+		if json.Unmarshal(respBody, &sent) == nil && sent.Result.MessageID != 0 {
+			recordSentMessage(ctx, SentMessage{ChatID: chatID, MessageID: strconv.FormatInt(sent.Result.MessageID, 10)})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSendNotificationTelegram(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		sent []map[string]string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot123:abc/sendMessage" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		var msg map[string]string
+		json.NewDecoder(r.Body).Decode(&msg)
+		mu.Lock()
+		sent = append(sent, msg)
+		mu.Unlock()
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer srv.Close()
+	telegramAPIURL = srv.URL
+	t.Cleanup(func() { telegramAPIURL = "https://api.telegram.org" })
+
+	channel := NotificationChannel{Type: TelegramChannelType, Config: `{"bot_token": "123:abc", "chat_ids": ["42", "-100"]}`}
+	text := strings.Repeat("word ", 1000)
+	payload, _ := json.Marshal(map[string]string{"text": text})
+	if err := SendNotification(context.Background(), channel, payload); err != nil {
+		t.Fatal(err)
+	}
+	// The text is split in two parts, sent to both chats.
+	if len(sent) != 4 || sent[0]["chat_id"] != "42" || sent[2]["chat_id"] != "-100" || !strings.HasPrefix(sent[1]["text"], "(2/2) ") {
+		t.Errorf("sent = %d messages: %v", len(sent), sent)
+	}
+
+	if _, err := ParseTelegramConfig(`{"bot_token": "123:abc", "agent_id": "agent"}`); err == nil {
+		t.Error("config with agent but without webhook secret is valid")
+	}
+	cfg := TelegramConfig{ChatIDs: []string{"42"}, AllowedChatIDs: []string{"7"}}
+	if !cfg.AllowsChat("42") || !cfg.AllowsChat("7") || cfg.AllowsChat("8") {
+		t.Errorf("AllowsChat of %+v is wrong", cfg)
+	}
+}
@@ -3,6 +3,9 @@ package tool
 import (
 	"context"
 	"encoding/json"
+	"errors"
+
+	"github.com/dstotijn/blippy/internal/apierror"
 )
 
 // contextKey is a custom type for context keys to avoid collisions
@@ -19,6 +22,14 @@ func WithConversationID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, ConversationIDKey, id)
 }
 
+// GetConversationID retrieves the conversation ID from context
+func GetConversationID(ctx context.Context) string {
+	if id, ok := ctx.Value(ConversationIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
 // WithAgentID returns a context with the agent ID set
 func WithAgentID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, AgentIDKey, id)
@@ -69,12 +80,29 @@ func GetFSToolRoots(ctx context.Context) map[string][]FilesystemRoot {
 	return m
 }
 
+type stubResultsKey struct{}
+
+// WithStubResults returns a context in which Executor.ProcessOutput doesn't
+// execute tools, but returns the result stub returns for each call ID, in
+// call order. It's used to replay turns.
+func WithStubResults(ctx context.Context, stub func(callID string) string) context.Context {
+	return context.WithValue(ctx, stubResultsKey{}, stub)
+}
+
+func getStubResults(ctx context.Context) func(callID string) string {
+	stub, _ := ctx.Value(stubResultsKey{}).(func(string) string)
+	return stub
+}
+
 // Tool defines a callable tool for an agent
 type Tool struct {
 	Name        string          `json:"name"`
 	Description string          `json:"description"`
 	Parameters  json.RawMessage `json:"parameters"` // JSON Schema
 	Handler     Handler         `json:"-"`
+	// SensitiveArgs are the argument properties whose values are encrypted
+	// in stored messages.
+	SensitiveArgs []string `json:"-"`
 }
 
 // Handler executes a tool with given arguments
@@ -138,3 +166,22 @@ type ErrToolNotFound struct {
 func (e *ErrToolNotFound) Error() string {
 	return "tool not found: " + e.Name
 }
+
+// ErrorCodeOf returns the API error code of a tool's error. Tools that fail
+// to decode their arguments have invalid arguments.
+func ErrorCodeOf(err error) apierror.ErrorCode {
+	var (
+		notFound  *ErrToolNotFound
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &notFound):
+		return apierror.ErrorCode_ERROR_CODE_TOOL_NOT_FOUND
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return apierror.ErrorCode_ERROR_CODE_TOOL_INVALID_ARGUMENTS
+	case errors.Is(err, ErrToolCallDenied):
+		return apierror.ErrorCode_ERROR_CODE_TOOL_CALL_DENIED
+	}
+	return apierror.ErrorCode_ERROR_CODE_TOOL_FAILED
+}
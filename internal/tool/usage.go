@@ -0,0 +1,54 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// UsageReporter is the interface for reporting the usage of agents.
+type UsageReporter interface {
+	UsageReport(ctx context.Context, period time.Duration) (string, error)
+}
+
+// NewUsageReportTool creates a tool for reporting the runs, failures, token
+// usage and cost of all agents, e.g. for a daily summary.
+func NewUsageReportTool(reporter UsageReporter) *Tool {
+	return &Tool{
+		Name:        "get_usage_report",
+		Description: "Get a report of the runs, failed runs, token usage and estimated cost in US dollars of each agent over a recent period (default: the last 24 hours).",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"period": {
+					"type": "string",
+					"description": "How far back to report (e.g., '24h', '168h'). Defaults to the last 24 hours."
+				}
+			}
+		}`),
+		Handler: func(ctx context.Context, argsJSON json.RawMessage) (string, error) {
+			var args struct {
+				Period string `json:"period"`
+			}
+			if err := json.Unmarshal(argsJSON, &args); err != nil {
+				return "", fmt.Errorf("parse args: %w", err)
+			}
+
+			// The reporter's default period is used if none is given.
+			var period time.Duration
+			if args.Period != "" {
+				d, err := time.ParseDuration(args.Period)
+				if err != nil {
+					return "", fmt.Errorf("invalid period format: %w", err)
+				}
+				if d <= 0 {
+					return "", fmt.Errorf("period must be positive")
+				}
+				period = d
+			}
+
+			return reporter.UsageReport(ctx, period)
+		},
+	}
+}
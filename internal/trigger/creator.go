@@ -21,7 +21,7 @@ func NewCreator(queries *store.Queries) *Creator {
 
 // CreateTrigger creates a new trigger and returns its ID.
 func (c *Creator) CreateTrigger(ctx context.Context, agentID, name, prompt string, cronExpr *string, nextRunAt time.Time, model, title string) (string, error) {
-	now := time.Now().Format(time.RFC3339)
+	now := time.Now().UTC().Format(time.RFC3339)
 	id := uuid.NewString()
 
 	var cronExprValue string
@@ -36,9 +36,10 @@ func (c *Creator) CreateTrigger(ctx context.Context, agentID, name, prompt strin
 		Prompt:            prompt,
 		CronExpr:          store.NewNullString(cronExprValue),
 		Enabled:           1,
-		NextRunAt:         store.NewNullString(nextRunAt.Format(time.RFC3339)),
+		NextRunAt:         store.NewNullString(nextRunAt.UTC().Format(time.RFC3339)),
 		Model:             model,
 		ConversationTitle: title,
+		Vars:              "{}",
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	})
@@ -0,0 +1,90 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// maxDraftTranscriptBytes limits the transcript a trigger is drafted from.
+// Longer conversations are cut off, as what to repeat is usually clear from
+// their start.
+const maxDraftTranscriptBytes = 60_000
+
+// draftInstructions instruct the LLM how to draft a trigger from a
+// conversation.
+const draftInstructions = `You turn a conversation between a user and an AI agent into a scheduled job: a prompt the agent runs on its own, without the user, to repeat the task of the conversation.
+
+Write the prompt as instructions to the agent, with the details it needs from the conversation (sources, names, formats, who to notify). Don't refer to the conversation itself. Propose how often the job should run as a standard 5-field cron expression in UTC, based on what the user asked for or what suits the task.
+
+Reply with only a JSON object, without any other text:
+{"name": "<short name, 2-5 words>", "prompt": "<the prompt>", "cron_expr": "<cron expression>"}`
+
+// draft is the LLM's draft of a trigger.
+type draft struct {
+	Name     string `json:"name"`
+	Prompt   string `json:"prompt"`
+	CronExpr string `json:"cron_expr"`
+}
+
+// draftTrigger drafts a trigger from the messages of a conversation with an
+// LLM.
+func draftTrigger(ctx context.Context, orClient *openrouter.Client, model string, msgs []store.Message) (draft, error) {
+	var transcript strings.Builder
+	for _, msg := range msgs {
+		inputs, err := agentloop.BuildHistoryInputs(msg)
+		if err != nil {
+			return draft{}, err
+		}
+		agentloop.WriteTranscript(&transcript, inputs)
+		if transcript.Len() >= maxDraftTranscriptBytes {
+			break
+		}
+	}
+
+	resp, err := orClient.CreateResponse(ctx, &openrouter.ResponseRequest{
+		Model:        model,
+		Instructions: draftInstructions,
+		Input: []openrouter.Input{{
+			Type:    "message",
+			Role:    "user",
+			Content: []openrouter.ContentPart{{Type: "input_text", Text: transcript.String()}},
+		}},
+	})
+	if err != nil {
+		return draft{}, fmt.Errorf("create response: %w", err)
+	}
+
+	var text strings.Builder
+	for _, item := range resp.Output {
+		if item.Type == "message" {
+			for _, part := range item.Content {
+				text.WriteString(part.Text)
+			}
+		}
+	}
+	return parseDraft(text.String())
+}
+
+// parseDraft parses the LLM's reply, which models tend to wrap in a Markdown
+// code block or a sentence.
+func parseDraft(s string) (draft, error) {
+	start, end := strings.Index(s, "{"), strings.LastIndex(s, "}")
+	if start < 0 || end < start {
+		return draft{}, errors.New("draft has no JSON object")
+	}
+	var d draft
+	if err := json.Unmarshal([]byte(s[start:end+1]), &d); err != nil {
+		return draft{}, fmt.Errorf("parse draft: %w", err)
+	}
+	if strings.TrimSpace(d.Prompt) == "" {
+		return draft{}, errors.New("draft has no prompt")
+	}
+	return d, nil
+}
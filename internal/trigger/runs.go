@@ -0,0 +1,93 @@
+package trigger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/listing"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func (s *Service) ListTriggerRuns(ctx context.Context, req *connect.Request[ListTriggerRunsRequest]) (*connect.Response[ListTriggerRunsResponse], error) {
+	listReq := listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Filter:    req.Msg.Filter,
+	}
+	if req.Msg.TriggerId != "" {
+		listReq.Match = map[string]string{"trigger_id": req.Msg.TriggerId}
+	}
+	query, err := listing.Parse(listReq, triggerRunTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListTriggerRunsPage, s.queries.CountTriggerRunsPage)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoRuns := make([]*TriggerRun, len(page.Items))
+	for i, r := range page.Items {
+		protoRuns[i] = toProtoTriggerRun(r)
+	}
+
+	return connect.NewResponse(&ListTriggerRunsResponse{
+		TriggerRuns:   protoRuns,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}), nil
+}
+
+// triggerRunTable has the fields usable in ListTriggerRuns filters and order
+// clauses.
+var triggerRunTable = listing.Table[store.TriggerRun]{
+	Fields: listing.Fields[store.TriggerRun]{
+		"id":              {Column: "id", Value: func(r store.TriggerRun) string { return r.ID }},
+		"trigger_id":      {Column: "trigger_id", Value: func(r store.TriggerRun) string { return r.TriggerID }},
+		"status":          {Column: "status", Value: func(r store.TriggerRun) string { return r.Status }},
+		"error_message":   {Column: "COALESCE(error_message, '')", Value: func(r store.TriggerRun) string { return r.ErrorMessage.String }},
+		"conversation_id": {Column: "COALESCE(conversation_id, '')", Value: func(r store.TriggerRun) string { return r.ConversationID.String }},
+		"started_at":      {Column: "started_at", Value: func(r store.TriggerRun) string { return r.StartedAt }},
+		"finished_at":     {Column: "COALESCE(finished_at, '')", Value: func(r store.TriggerRun) string { return r.FinishedAt.String }},
+	},
+	Order: "started_at desc",
+}
+
+func (s *Service) GetTriggerRun(ctx context.Context, req *connect.Request[GetTriggerRunRequest]) (*connect.Response[TriggerRun], error) {
+	run, err := s.queries.GetTriggerRun(ctx, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_TRIGGER_RUN_NOT_FOUND, errors.New("trigger run not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(toProtoTriggerRun(run)), nil
+}
+
+func toProtoTriggerRun(r store.TriggerRun) *TriggerRun {
+	startedAt, _ := time.Parse(time.RFC3339, r.StartedAt)
+
+	proto := &TriggerRun{
+		Id:             r.ID,
+		TriggerId:      r.TriggerID,
+		Status:         r.Status,
+		ErrorMessage:   r.ErrorMessage.String,
+		ConversationId: r.ConversationID.String,
+		StartedAt:      timestamppb.New(startedAt),
+	}
+	if r.FinishedAt.Valid {
+		if finishedAt, err := time.Parse(time.RFC3339, r.FinishedAt.String); err == nil {
+			proto.FinishedAt = timestamppb.New(finishedAt)
+			proto.DurationMs = finishedAt.Sub(startedAt).Milliseconds()
+		}
+	}
+	return proto
+}
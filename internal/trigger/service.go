@@ -1,9 +1,13 @@
 package trigger
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
@@ -11,20 +15,49 @@ import (
 	"github.com/robfig/cron/v3"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/listing"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/scheduler"
 	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
 )
 
 type Service struct {
-	queries *store.Queries
+	queries      *store.Queries
+	scheduler    *scheduler.Scheduler
+	cipher       *encryption.Cipher
+	orClient     *openrouter.Client
+	defaultModel string
 }
 
-func NewService(db *sql.DB) *Service {
+// NewService creates a new Service. The cipher encrypts webhook secrets and
+// run variables, and may be nil to store them as plaintext. Triggers are drafted from
+// conversations with the model of the conversation's agent, or defaultModel.
+func NewService(db *sql.DB, sched *scheduler.Scheduler, cipher *encryption.Cipher, orClient *openrouter.Client, defaultModel string) *Service {
 	return &Service{
-		queries: store.New(db),
+		queries:      store.New(db),
+		scheduler:    sched,
+		cipher:       cipher,
+		orClient:     orClient,
+		defaultModel: defaultModel,
 	}
 }
 
 func (s *Service) CreateTrigger(ctx context.Context, req *connect.Request[CreateTriggerRequest]) (*connect.Response[Trigger], error) {
+	if req.Msg.MaxDurationSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max_duration_seconds must not be negative"))
+	}
+	if req.Msg.Priority < 0 || req.Msg.Priority > agentloop.MaxPriority {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("priority must be between 0 and %d", agentloop.MaxPriority))
+	}
+	vars, err := s.marshalVars(req.Msg.Vars)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	now := time.Now().UTC()
 
 	// Compute next_run_at based on cron_expr or delay
@@ -62,48 +95,165 @@ func (s *Service) CreateTrigger(ctx context.Context, req *connect.Request[Create
 		NextRunAt: nextRunAt,
 		CreatedAt: now.Format(time.RFC3339),
 		UpdatedAt: now.Format(time.RFC3339),
+
+		MaxDurationSeconds: int64(req.Msg.MaxDurationSeconds),
+		Priority:           int64(req.Msg.Priority),
+		Vars:               vars,
 	})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return connect.NewResponse(toProtoTrigger(trigger)), nil
+	resp, err := s.toProtoTrigger(trigger)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Service) CreateTriggerFromConversation(ctx context.Context, req *connect.Request[CreateTriggerFromConversationRequest]) (*connect.Response[Trigger], error) {
+	conv, err := s.queries.GetConversation(ctx, req.Msg.ConversationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND, errors.New("conversation not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	agent, err := s.queries.GetAgent(ctx, conv.AgentID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	msgs, err := s.queries.GetMessagesByConversation(ctx, conv.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if len(msgs) == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("conversation has no messages"))
+	}
+
+	d, err := draftTrigger(ctx, s.orClient, cmp.Or(agent.Model, s.defaultModel), msgs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("draft trigger: %w", err))
+	}
+
+	// A proposed schedule that doesn't parse is left out, to be set when
+	// reviewing the trigger.
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	var cronExpr sql.NullString
+	if req.Msg.CronExpr != "" {
+		if _, err := parser.Parse(req.Msg.CronExpr); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid cron expression: "+err.Error()))
+		}
+		cronExpr = store.NewNullString(req.Msg.CronExpr)
+	} else if _, err := parser.Parse(d.CronExpr); err == nil {
+		cronExpr = store.NewNullString(d.CronExpr)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	trigger, err := s.queries.CreateTrigger(ctx, store.CreateTriggerParams{
+		ID:        uuid.NewString(),
+		AgentID:   conv.AgentID,
+		Name:      cmp.Or(strings.TrimSpace(d.Name), conv.Title, "Scheduled job"),
+		Prompt:    strings.TrimSpace(d.Prompt),
+		CronExpr:  cronExpr,
+		Enabled:   0, // Enabled once reviewed
+		CreatedAt: now,
+		UpdatedAt: now,
+		Vars:      "{}",
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp, err := s.toProtoTrigger(trigger)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(resp), nil
 }
 
 func (s *Service) GetTrigger(ctx context.Context, req *connect.Request[GetTriggerRequest]) (*connect.Response[Trigger], error) {
 	trigger, err := s.queries.GetTrigger(ctx, req.Msg.Id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("trigger not found"))
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND, errors.New("trigger not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return connect.NewResponse(toProtoTrigger(trigger)), nil
+	resp, err := s.toProtoTrigger(trigger)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(resp), nil
 }
 
 func (s *Service) ListTriggers(ctx context.Context, req *connect.Request[ListTriggersRequest]) (*connect.Response[ListTriggersResponse], error) {
-	var triggers []store.Trigger
-	var err error
-
+	listReq := listing.Request{
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Filter:    req.Msg.Filter,
+	}
 	if req.Msg.AgentId != "" {
-		triggers, err = s.queries.ListTriggersByAgent(ctx, req.Msg.AgentId)
-	} else {
-		triggers, err = s.queries.ListAllTriggers(ctx)
+		listReq.Match = map[string]string{"agent_id": req.Msg.AgentId}
 	}
+	query, err := listing.Parse(listReq, triggerTable)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page, err := listing.Fetch(ctx, query, s.queries.ListTriggersPage, s.queries.CountTriggersPage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	protoTriggers := make([]*Trigger, len(triggers))
-	for i, t := range triggers {
-		protoTriggers[i] = toProtoTrigger(t)
+	protoTriggers := make([]*Trigger, len(page.Items))
+	for i, t := range page.Items {
+		if protoTriggers[i], err = s.toProtoTrigger(t); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 	}
 
-	return connect.NewResponse(&ListTriggersResponse{Triggers: protoTriggers}), nil
+	return connect.NewResponse(&ListTriggersResponse{
+		Triggers:      protoTriggers,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}), nil
+}
+
+// triggerTable has the fields usable in ListTriggers filters and order
+// clauses.
+var triggerTable = listing.Table[store.Trigger]{
+	Fields: listing.Fields[store.Trigger]{
+		"id":          {Column: "id", Value: func(t store.Trigger) string { return t.ID }},
+		"agent_id":    {Column: "agent_id", Value: func(t store.Trigger) string { return t.AgentID }},
+		"name":        {Column: "name", Value: func(t store.Trigger) string { return t.Name }},
+		"prompt":      {Column: "prompt", Value: func(t store.Trigger) string { return t.Prompt }},
+		"cron_expr":   {Column: "COALESCE(cron_expr, '')", Value: func(t store.Trigger) string { return t.CronExpr.String }},
+		"enabled":     {Column: "CASE WHEN enabled != 0 THEN 'true' ELSE 'false' END", Value: func(t store.Trigger) string { return strconv.FormatBool(t.Enabled != 0) }},
+		"next_run_at": {Column: "COALESCE(next_run_at, '')", Value: func(t store.Trigger) string { return t.NextRunAt.String }},
+		"model":       {Column: "model", Value: func(t store.Trigger) string { return t.Model }},
+		"created_at":  {Column: "created_at", Value: func(t store.Trigger) string { return t.CreatedAt }},
+		"updated_at":  {Column: "updated_at", Value: func(t store.Trigger) string { return t.UpdatedAt }},
+	},
+	Order: "created_at desc",
 }
 
 func (s *Service) UpdateTrigger(ctx context.Context, req *connect.Request[UpdateTriggerRequest]) (*connect.Response[Trigger], error) {
+	if req.Msg.Version == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("version is required"))
+	}
+	if req.Msg.MaxDurationSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max_duration_seconds must not be negative"))
+	}
+	if req.Msg.Priority < 0 || req.Msg.Priority > agentloop.MaxPriority {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("priority must be between 0 and %d", agentloop.MaxPriority))
+	}
+	vars, err := s.marshalVars(req.Msg.Vars)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	now := time.Now().UTC()
 
 	// Compute next_run_at if cron_expr is provided
@@ -134,15 +284,27 @@ func (s *Service) UpdateTrigger(ctx context.Context, req *connect.Request[Update
 		Enabled:   enabled,
 		NextRunAt: nextRunAt,
 		UpdatedAt: now.Format(time.RFC3339),
+		Version:   req.Msg.Version,
+
+		MaxDurationSeconds: int64(req.Msg.MaxDurationSeconds),
+		Priority:           int64(req.Msg.Priority),
+		Vars:               vars,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("trigger not found"))
+			if _, err := s.queries.GetTrigger(ctx, req.Msg.Id); err == nil {
+				return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_VERSION_CONFLICT, errors.New("trigger was modified since it was loaded; reload and try again"))
+			}
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND, errors.New("trigger not found"))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return connect.NewResponse(toProtoTrigger(trigger)), nil
+	resp, err := s.toProtoTrigger(trigger)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(resp), nil
 }
 
 func (s *Service) DeleteTrigger(ctx context.Context, req *connect.Request[DeleteTriggerRequest]) (*connect.Response[Empty], error) {
@@ -153,7 +315,107 @@ func (s *Service) DeleteTrigger(ctx context.Context, req *connect.Request[Delete
 	return connect.NewResponse(&Empty{}), nil
 }
 
-func toProtoTrigger(t store.Trigger) *Trigger {
+// maxBatchSize is the maximum number of IDs of a batch request.
+const maxBatchSize = 500
+
+func (s *Service) BatchUpdateTriggers(ctx context.Context, req *connect.Request[BatchUpdateTriggersRequest]) (*connect.Response[BatchUpdateTriggersResponse], error) {
+	if len(req.Msg.Ids) == 0 || len(req.Msg.Ids) > maxBatchSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("between 1 and %d ids are required", maxBatchSize))
+	}
+	if req.Msg.Update == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("enabled or agent_id is required"))
+	}
+
+	now := time.Now().UTC()
+	triggers := make([]*Trigger, len(req.Msg.Ids))
+	err := s.queries.InTx(ctx, func(q *store.Queries) error {
+		if agentID, ok := req.Msg.Update.(*BatchUpdateTriggersRequest_AgentId); ok {
+			if _, err := q.GetAgent(ctx, agentID.AgentId); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apierror.New(apierror.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND, errors.New("agent not found"))
+				}
+				return err
+			}
+		}
+
+		for i, id := range req.Msg.Ids {
+			t, err := q.GetTrigger(ctx, id)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apierror.New(apierror.ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND, fmt.Errorf("trigger %s not found", id))
+				}
+				return err
+			}
+
+			switch update := req.Msg.Update.(type) {
+			case *BatchUpdateTriggersRequest_Enabled:
+				// Re-enabled cron triggers are rescheduled from now, like
+				// updated ones; one-shot triggers keep their run time.
+				nextRunAt := t.NextRunAt
+				if update.Enabled && t.CronExpr.Valid {
+					parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+					if schedule, err := parser.Parse(t.CronExpr.String); err == nil {
+						nextRunAt = store.NewNullString(schedule.Next(now).Format(time.RFC3339))
+					}
+				}
+				var enabled int64
+				if update.Enabled {
+					enabled = 1
+				}
+				t, err = q.UpdateTriggerEnabled(ctx, store.UpdateTriggerEnabledParams{
+					Enabled:   enabled,
+					NextRunAt: nextRunAt,
+					UpdatedAt: now.Format(time.RFC3339),
+					ID:        id,
+				})
+			case *BatchUpdateTriggersRequest_AgentId:
+				t, err = q.UpdateTriggerAgent(ctx, store.UpdateTriggerAgentParams{
+					AgentID:   update.AgentId,
+					UpdatedAt: now.Format(time.RFC3339),
+					ID:        id,
+				})
+			}
+			if err != nil {
+				return err
+			}
+			if triggers[i], err = s.toProtoTrigger(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return nil, connectErr
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&BatchUpdateTriggersResponse{Triggers: triggers}), nil
+}
+
+func (s *Service) RunTrigger(ctx context.Context, req *connect.Request[RunTriggerRequest]) (*connect.Response[RunTriggerResponse], error) {
+	runID, err := s.scheduler.RunTrigger(ctx, req.Msg.Id)
+	var maintErr *maintenance.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND, errors.New("trigger not found"))
+	case errors.As(err, &maintErr):
+		return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_MAINTENANCE_MODE, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&RunTriggerResponse{TriggerRunId: runID}), nil
+}
+
+func (s *Service) toProtoTrigger(t store.Trigger) (*Trigger, error) {
+	vars, err := tool.DecodeRunVars(s.cipher, t.Vars)
+	if err != nil {
+		return nil, fmt.Errorf("trigger %s: %w", t.ID, err)
+	}
+
 	createdAt, _ := time.Parse(time.RFC3339, t.CreatedAt)
 	updatedAt, _ := time.Parse(time.RFC3339, t.UpdatedAt)
 
@@ -165,6 +427,11 @@ func toProtoTrigger(t store.Trigger) *Trigger {
 		Enabled:   t.Enabled == 1,
 		CreatedAt: timestamppb.New(createdAt),
 		UpdatedAt: timestamppb.New(updatedAt),
+		Version:   t.Version,
+
+		MaxDurationSeconds: int32(t.MaxDurationSeconds),
+		Priority:           int32(t.Priority),
+		Vars:               toProtoVars(vars),
 	}
 
 	if t.CronExpr.Valid {
@@ -176,5 +443,29 @@ func toProtoTrigger(t store.Trigger) *Trigger {
 		proto.NextRunAt = timestamppb.New(nextRunAt)
 	}
 
-	return proto
+	return proto, nil
+}
+
+// marshalVars validates run variables and encodes them for storing, see
+// tool.EncodeRunVars.
+func (s *Service) marshalVars(vars []*RunVar) (string, error) {
+	m := make(map[string]string, len(vars))
+	for _, v := range vars {
+		if _, ok := m[v.Name]; ok {
+			return "", fmt.Errorf("duplicate var %q", v.Name)
+		}
+		m[v.Name] = v.Value
+	}
+	if err := tool.ValidateRunVars(m); err != nil {
+		return "", err
+	}
+	return tool.EncodeRunVars(s.cipher, m)
+}
+
+func toProtoVars(m map[string]string) []*RunVar {
+	vars := make([]*RunVar, 0, len(m))
+	for _, name := range tool.RunVarNames(m) {
+		vars = append(vars, &RunVar{Name: name, Value: m[name]})
+	}
+	return vars
 }
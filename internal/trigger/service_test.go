@@ -0,0 +1,377 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestBatchUpdateTriggers(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	svc := NewService(db, nil, nil, nil, "")
+
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour).Format(time.RFC3339)
+	for _, id := range []string{"agent-1", "agent-2"} {
+		if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: id, Name: id, EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: past, UpdatedAt: past}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range []store.CreateTriggerParams{
+		{ID: "cron", AgentID: "agent-1", Name: "daily", CronExpr: store.NewNullString("0 9 * * *"), NextRunAt: store.NewNullString(past), Vars: "{}", CreatedAt: past, UpdatedAt: past},
+		{ID: "once", AgentID: "agent-1", Name: "once", NextRunAt: store.NewNullString(past), Vars: "{}", CreatedAt: past, UpdatedAt: past},
+	} {
+		if _, err := queries.CreateTrigger(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := svc.BatchUpdateTriggers(ctx, connect.NewRequest(&BatchUpdateTriggersRequest{
+		Ids:    []string{"cron", "once"},
+		Update: &BatchUpdateTriggersRequest_Enabled{Enabled: true},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron, once := res.Msg.Triggers[0], res.Msg.Triggers[1]
+	if !cron.Enabled || !once.Enabled || cron.Version != 2 {
+		t.Errorf("triggers = %v", res.Msg.Triggers)
+	}
+	// The cron trigger doesn't make up for its missed run.
+	if !cron.NextRunAt.AsTime().After(now) || once.NextRunAt.AsTime().Format(time.RFC3339) != past {
+		t.Errorf("next runs = %v, %v", cron.NextRunAt.AsTime(), once.NextRunAt.AsTime())
+	}
+
+	res, err = svc.BatchUpdateTriggers(ctx, connect.NewRequest(&BatchUpdateTriggersRequest{
+		Ids:    []string{"cron", "once"},
+		Update: &BatchUpdateTriggersRequest_AgentId{AgentId: "agent-2"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Triggers[0].AgentId != "agent-2" || res.Msg.Triggers[1].AgentId != "agent-2" {
+		t.Errorf("triggers = %v", res.Msg.Triggers)
+	}
+
+	// Nothing is updated if a trigger doesn't exist.
+	_, err = svc.BatchUpdateTriggers(ctx, connect.NewRequest(&BatchUpdateTriggersRequest{
+		Ids:    []string{"cron", "unknown"},
+		Update: &BatchUpdateTriggersRequest_Enabled{Enabled: false},
+	}))
+	if apierror.CodeOf(err) != apierror.ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND {
+		t.Errorf("err = %v, want TRIGGER_NOT_FOUND", err)
+	}
+	if tr, _ := queries.GetTrigger(ctx, "cron"); tr.Enabled != 1 {
+		t.Error("trigger was disabled by failed batch")
+	}
+}
+
+func TestCreateTriggerFromConversation(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	// The model wraps its draft in a code block.
+	orClient := openrouter.NewMockClient(&openrouter.MockFixture{
+		Default: "```json\n{\"name\": \"Daily HN digest\", \"prompt\": \"Summarize the top 5 Hacker News stories.\", \"cron_expr\": \"0 8 * * *\"}\n```",
+	})
+	svc := NewService(db, nil, nil, orClient, "mock")
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "Agent", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	for i, text := range []string{"Summarize the top 5 Hacker News stories, every morning", "1. ..."} {
+		items, err := agentloop.EncodeItems([]agentloop.StoredItem{{Type: agentloop.ItemTypeText, Text: text}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateMessage(ctx, store.CreateMessageParams{ID: fmt.Sprintf("msg-%d", i), ConversationID: "conv-1", Role: []string{"user", "assistant"}[i], Items: items, Status: "completed", CreatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := svc.CreateTriggerFromConversation(ctx, connect.NewRequest(&CreateTriggerFromConversationRequest{ConversationId: "conv-1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := res.Msg
+	if tr.AgentId != "agent-1" || tr.Name != "Daily HN digest" || tr.CronExpr != "0 8 * * *" || tr.Enabled || tr.Prompt == "" {
+		t.Errorf("trigger = %v", tr)
+	}
+
+	// The schedule can be given instead of proposed.
+	res, err = svc.CreateTriggerFromConversation(ctx, connect.NewRequest(&CreateTriggerFromConversationRequest{ConversationId: "conv-1", CronExpr: "0 18 * * 1-5"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.CronExpr != "0 18 * * 1-5" {
+		t.Errorf("cron_expr = %q", res.Msg.CronExpr)
+	}
+
+	_, err = svc.CreateTriggerFromConversation(ctx, connect.NewRequest(&CreateTriggerFromConversationRequest{ConversationId: "unknown"}))
+	if apierror.CodeOf(err) != apierror.ErrorCode_ERROR_CODE_CONVERSATION_NOT_FOUND {
+		t.Errorf("err = %v, want CONVERSATION_NOT_FOUND", err)
+	}
+}
+
+// TestEncryptStoredSecrets checks that plaintext webhook secrets and vars are
+// encrypted, and can be read back.
+func TestEncryptStoredSecrets(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "agent-1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	for i, secret := range []string{"whsec_plain", ""} {
+		id := fmt.Sprintf("trigger-%d", i)
+		vars := "{}"
+		if secret != "" {
+			vars = `{"TOKEN":"ghp_plain"}`
+		}
+		if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{ID: id, AgentID: "agent-1", Name: id, Vars: vars, CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateTriggerWebhook(ctx, store.CreateTriggerWebhookParams{TriggerID: id, TokenHash: id, Secret: secret, AllowedIps: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n, err := EncryptStoredSecrets(ctx, queries, nil); err != nil || n != 0 {
+		t.Fatalf("without a cipher: encrypted %d, err %v", n, err)
+	}
+	cipher, err := encryption.New(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Encrypted secrets and vars are left as is.
+	for _, want := range []int{2, 0} {
+		if n, err := EncryptStoredSecrets(ctx, queries, cipher); err != nil || n != want {
+			t.Fatalf("encrypted %d, err %v, want %d", n, err, want)
+		}
+	}
+
+	hook, err := queries.GetTriggerWebhook(ctx, "trigger-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret, err := cipher.Decrypt(hook.Secret); !encryption.IsEncrypted(hook.Secret) || err != nil || secret != "whsec_plain" {
+		t.Errorf("stored secret = %q, decrypted %q (err %v)", hook.Secret, secret, err)
+	}
+	if hook, _ := queries.GetTriggerWebhook(ctx, "trigger-1"); hook.Secret != "" {
+		t.Errorf("empty secret stored as %q", hook.Secret)
+	}
+
+	trigger, err := queries.GetTrigger(ctx, "trigger-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !encryption.IsEncrypted(trigger.Vars) {
+		t.Errorf("stored vars = %q, want encrypted", trigger.Vars)
+	}
+	svc := NewService(db, nil, cipher, nil, "")
+	res, err := svc.GetTrigger(ctx, connect.NewRequest(&GetTriggerRequest{Id: "trigger-0"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars := res.Msg.GetVars(); len(vars) != 1 || vars[0].GetName() != "TOKEN" || vars[0].GetValue() != "ghp_plain" {
+		t.Errorf("GetTrigger vars = %v, want TOKEN=ghp_plain", vars)
+	}
+	if trigger, _ := queries.GetTrigger(ctx, "trigger-1"); trigger.Vars != "{}" {
+		t.Errorf("empty vars stored as %q", trigger.Vars)
+	}
+}
+
+// TestUpdateTriggerVersion checks that updates with a stale version are
+// rejected without changing the trigger.
+func TestUpdateTriggerVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	svc := NewService(db, nil, nil, nil, "")
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "agent-1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{ID: "trigger-1", AgentID: "agent-1", Name: "daily", Prompt: "original", CronExpr: store.NewNullString("0 9 * * *"), Vars: "{}", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	update := func(prompt string, version int64) (*connect.Response[Trigger], error) {
+		return svc.UpdateTrigger(ctx, connect.NewRequest(&UpdateTriggerRequest{Id: "trigger-1", Name: "daily", Prompt: prompt, CronExpr: "0 9 * * *", Version: version}))
+	}
+
+	if _, err := update("no version", 0); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("update without version: err = %v, want invalid argument", err)
+	}
+
+	res, err := update("first tab", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg.Version != 2 {
+		t.Errorf("version after update = %d, want 2", res.Msg.Version)
+	}
+
+	_, err = update("second tab", 1)
+	if connect.CodeOf(err) != connect.CodeAborted || apierror.CodeOf(err) != apierror.ErrorCode_ERROR_CODE_VERSION_CONFLICT {
+		t.Errorf("update with stale version: err = %v, want version conflict", err)
+	}
+	trigger, err := queries.GetTrigger(ctx, "trigger-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trigger.Prompt != "first tab" || trigger.Version != 2 {
+		t.Errorf("trigger after stale update = %q (version %d), want %q (version 2)", trigger.Prompt, trigger.Version, "first tab")
+	}
+}
+
+func TestTriggerRuns(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+	svc := NewService(db, nil, nil, nil, "")
+
+	now := time.Now().UTC()
+	at := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "agent-1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: at(0), UpdatedAt: at(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "conv-1", AgentID: "agent-1", CreatedAt: at(0), UpdatedAt: at(0)}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"trigger-1", "trigger-2"} {
+		if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{ID: id, AgentID: "agent-1", Name: id, Vars: "{}", CreatedAt: at(0), UpdatedAt: at(0)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range []store.CreateTriggerRunParams{
+		{ID: "run-1", TriggerID: "trigger-1", ConversationID: store.NewNullString("conv-1"), Status: "completed", StartedAt: at(-3 * time.Hour), FinishedAt: store.NewNullString(at(-3*time.Hour + 90*time.Second))},
+		{ID: "run-2", TriggerID: "trigger-1", Status: "failed", ErrorMessage: store.NewNullString("model overloaded"), StartedAt: at(-2 * time.Hour), FinishedAt: store.NewNullString(at(-2 * time.Hour))},
+		{ID: "run-3", TriggerID: "trigger-1", Status: "running", StartedAt: at(-time.Minute)},
+		{ID: "run-4", TriggerID: "trigger-2", Status: "completed", StartedAt: at(-time.Hour), FinishedAt: store.NewNullString(at(-time.Hour))},
+	} {
+		if _, err := queries.CreateTriggerRun(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := func(runs []*TriggerRun) []string {
+		var ids []string
+		for _, r := range runs {
+			ids = append(ids, r.Id)
+		}
+		return ids
+	}
+	tests := []struct {
+		name string
+		req  *ListTriggerRunsRequest
+		want []string
+	}{
+		{name: "all, most recent first", req: &ListTriggerRunsRequest{}, want: []string{"run-3", "run-4", "run-2", "run-1"}},
+		{name: "by trigger", req: &ListTriggerRunsRequest{TriggerId: "trigger-1"}, want: []string{"run-3", "run-2", "run-1"}},
+		{name: "filter", req: &ListTriggerRunsRequest{Filter: "status=completed"}, want: []string{"run-4", "run-1"}},
+		{name: "order", req: &ListTriggerRunsRequest{TriggerId: "trigger-1", OrderBy: "started_at asc"}, want: []string{"run-1", "run-2", "run-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := svc.ListTriggerRuns(ctx, connect.NewRequest(tt.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(res.Msg.TriggerRuns); !slices.Equal(got, tt.want) {
+				t.Errorf("runs = %v, want %v", got, tt.want)
+			}
+			if res.Msg.TotalSize != int32(len(tt.want)) {
+				t.Errorf("total_size = %d, want %d", res.Msg.TotalSize, len(tt.want))
+			}
+		})
+	}
+
+	// Pages continue where the previous one ended.
+	res, err := svc.ListTriggerRuns(ctx, connect.NewRequest(&ListTriggerRunsRequest{PageSize: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(res.Msg.TriggerRuns); !slices.Equal(got, []string{"run-3", "run-4", "run-2"}) || res.Msg.NextPageToken == "" {
+		t.Fatalf("first page = %v, next page token %q", got, res.Msg.NextPageToken)
+	}
+	res, err = svc.ListTriggerRuns(ctx, connect.NewRequest(&ListTriggerRunsRequest{PageSize: 3, PageToken: res.Msg.NextPageToken}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(res.Msg.TriggerRuns); !slices.Equal(got, []string{"run-1"}) || res.Msg.NextPageToken != "" {
+		t.Errorf("second page = %v, next page token %q", got, res.Msg.NextPageToken)
+	}
+
+	if _, err := svc.ListTriggerRuns(ctx, connect.NewRequest(&ListTriggerRunsRequest{Filter: "prompt=x"})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("filter on unknown field: err = %v, want InvalidArgument", err)
+	}
+
+	run, err := svc.GetTriggerRun(ctx, connect.NewRequest(&GetTriggerRunRequest{Id: "run-1"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := run.Msg; r.TriggerId != "trigger-1" || r.Status != "completed" || r.ConversationId != "conv-1" || r.DurationMs != 90_000 || r.FinishedAt == nil {
+		t.Errorf("run-1 = %v", r)
+	}
+	run, err = svc.GetTriggerRun(ctx, connect.NewRequest(&GetTriggerRunRequest{Id: "run-2"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := run.Msg; r.Status != "failed" || r.ErrorMessage != "model overloaded" || r.ConversationId != "" {
+		t.Errorf("run-2 = %v", r)
+	}
+	// Running runs have no finish time or duration yet.
+	run, err = svc.GetTriggerRun(ctx, connect.NewRequest(&GetTriggerRunRequest{Id: "run-3"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := run.Msg; r.FinishedAt != nil || r.DurationMs != 0 {
+		t.Errorf("run-3 = %v", r)
+	}
+
+	_, err = svc.GetTriggerRun(ctx, connect.NewRequest(&GetTriggerRunRequest{Id: "unknown"}))
+	if apierror.CodeOf(err) != apierror.ErrorCode_ERROR_CODE_TRIGGER_RUN_NOT_FOUND {
+		t.Errorf("err = %v, want TRIGGER_RUN_NOT_FOUND", err)
+	}
+}
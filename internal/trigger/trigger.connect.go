@@ -35,6 +35,9 @@ const (
 	// TriggerServiceCreateTriggerProcedure is the fully-qualified name of the TriggerService's
 	// CreateTrigger RPC.
 	TriggerServiceCreateTriggerProcedure = "/blippy.trigger.TriggerService/CreateTrigger"
+	// TriggerServiceCreateTriggerFromConversationProcedure is the fully-qualified name of the
+	// TriggerService's CreateTriggerFromConversation RPC.
+	TriggerServiceCreateTriggerFromConversationProcedure = "/blippy.trigger.TriggerService/CreateTriggerFromConversation"
 	// TriggerServiceGetTriggerProcedure is the fully-qualified name of the TriggerService's GetTrigger
 	// RPC.
 	TriggerServiceGetTriggerProcedure = "/blippy.trigger.TriggerService/GetTrigger"
@@ -47,15 +50,57 @@ const (
 	// TriggerServiceDeleteTriggerProcedure is the fully-qualified name of the TriggerService's
 	// DeleteTrigger RPC.
 	TriggerServiceDeleteTriggerProcedure = "/blippy.trigger.TriggerService/DeleteTrigger"
+	// TriggerServiceBatchUpdateTriggersProcedure is the fully-qualified name of the TriggerService's
+	// BatchUpdateTriggers RPC.
+	TriggerServiceBatchUpdateTriggersProcedure = "/blippy.trigger.TriggerService/BatchUpdateTriggers"
+	// TriggerServiceRunTriggerProcedure is the fully-qualified name of the TriggerService's RunTrigger
+	// RPC.
+	TriggerServiceRunTriggerProcedure = "/blippy.trigger.TriggerService/RunTrigger"
+	// TriggerServiceCreateTriggerWebhookProcedure is the fully-qualified name of the TriggerService's
+	// CreateTriggerWebhook RPC.
+	TriggerServiceCreateTriggerWebhookProcedure = "/blippy.trigger.TriggerService/CreateTriggerWebhook"
+	// TriggerServiceGetTriggerWebhookProcedure is the fully-qualified name of the TriggerService's
+	// GetTriggerWebhook RPC.
+	TriggerServiceGetTriggerWebhookProcedure = "/blippy.trigger.TriggerService/GetTriggerWebhook"
+	// TriggerServiceUpdateTriggerWebhookProcedure is the fully-qualified name of the TriggerService's
+	// UpdateTriggerWebhook RPC.
+	TriggerServiceUpdateTriggerWebhookProcedure = "/blippy.trigger.TriggerService/UpdateTriggerWebhook"
+	// TriggerServiceDeleteTriggerWebhookProcedure is the fully-qualified name of the TriggerService's
+	// DeleteTriggerWebhook RPC.
+	TriggerServiceDeleteTriggerWebhookProcedure = "/blippy.trigger.TriggerService/DeleteTriggerWebhook"
+	// TriggerServiceListTriggerRunsProcedure is the fully-qualified name of the TriggerService's
+	// ListTriggerRuns RPC.
+	TriggerServiceListTriggerRunsProcedure = "/blippy.trigger.TriggerService/ListTriggerRuns"
+	// TriggerServiceGetTriggerRunProcedure is the fully-qualified name of the TriggerService's
+	// GetTriggerRun RPC.
+	TriggerServiceGetTriggerRunProcedure = "/blippy.trigger.TriggerService/GetTriggerRun"
 )
 
 // TriggerServiceClient is a client for the blippy.trigger.TriggerService service.
 type TriggerServiceClient interface {
 	CreateTrigger(context.Context, *connect.Request[CreateTriggerRequest]) (*connect.Response[Trigger], error)
+	// Creates a disabled trigger for the conversation's agent, with a name,
+	// prompt and schedule drafted by an LLM from the conversation, to review
+	// before enabling it.
+	CreateTriggerFromConversation(context.Context, *connect.Request[CreateTriggerFromConversationRequest]) (*connect.Response[Trigger], error)
 	GetTrigger(context.Context, *connect.Request[GetTriggerRequest]) (*connect.Response[Trigger], error)
 	ListTriggers(context.Context, *connect.Request[ListTriggersRequest]) (*connect.Response[ListTriggersResponse], error)
 	UpdateTrigger(context.Context, *connect.Request[UpdateTriggerRequest]) (*connect.Response[Trigger], error)
 	DeleteTrigger(context.Context, *connect.Request[DeleteTriggerRequest]) (*connect.Response[Empty], error)
+	// Enables, disables or moves many triggers in a single transaction.
+	BatchUpdateTriggers(context.Context, *connect.Request[BatchUpdateTriggersRequest]) (*connect.Response[BatchUpdateTriggersResponse], error)
+	// Runs a trigger now, in the background, without changing its schedule.
+	RunTrigger(context.Context, *connect.Request[RunTriggerRequest]) (*connect.Response[RunTriggerResponse], error)
+	// Creates the webhook of a trigger, or replaces it with one with a new
+	// token.
+	CreateTriggerWebhook(context.Context, *connect.Request[CreateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error)
+	GetTriggerWebhook(context.Context, *connect.Request[GetTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error)
+	UpdateTriggerWebhook(context.Context, *connect.Request[UpdateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error)
+	DeleteTriggerWebhook(context.Context, *connect.Request[DeleteTriggerWebhookRequest]) (*connect.Response[Empty], error)
+	// Lists the runs of triggers, most recent first. Runs are kept for
+	// TRIGGER_RUN_RETENTION.
+	ListTriggerRuns(context.Context, *connect.Request[ListTriggerRunsRequest]) (*connect.Response[ListTriggerRunsResponse], error)
+	GetTriggerRun(context.Context, *connect.Request[GetTriggerRunRequest]) (*connect.Response[TriggerRun], error)
 }
 
 // NewTriggerServiceClient constructs a client for the blippy.trigger.TriggerService service. By
@@ -75,6 +120,12 @@ func NewTriggerServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(triggerServiceMethods.ByName("CreateTrigger")),
 			connect.WithClientOptions(opts...),
 		),
+		createTriggerFromConversation: connect.NewClient[CreateTriggerFromConversationRequest, Trigger](
+			httpClient,
+			baseURL+TriggerServiceCreateTriggerFromConversationProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("CreateTriggerFromConversation")),
+			connect.WithClientOptions(opts...),
+		),
 		getTrigger: connect.NewClient[GetTriggerRequest, Trigger](
 			httpClient,
 			baseURL+TriggerServiceGetTriggerProcedure,
@@ -99,16 +150,73 @@ func NewTriggerServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(triggerServiceMethods.ByName("DeleteTrigger")),
 			connect.WithClientOptions(opts...),
 		),
+		batchUpdateTriggers: connect.NewClient[BatchUpdateTriggersRequest, BatchUpdateTriggersResponse](
+			httpClient,
+			baseURL+TriggerServiceBatchUpdateTriggersProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("BatchUpdateTriggers")),
+			connect.WithClientOptions(opts...),
+		),
+		runTrigger: connect.NewClient[RunTriggerRequest, RunTriggerResponse](
+			httpClient,
+			baseURL+TriggerServiceRunTriggerProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("RunTrigger")),
+			connect.WithClientOptions(opts...),
+		),
+		createTriggerWebhook: connect.NewClient[CreateTriggerWebhookRequest, TriggerWebhook](
+			httpClient,
+			baseURL+TriggerServiceCreateTriggerWebhookProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("CreateTriggerWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		getTriggerWebhook: connect.NewClient[GetTriggerWebhookRequest, TriggerWebhook](
+			httpClient,
+			baseURL+TriggerServiceGetTriggerWebhookProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("GetTriggerWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		updateTriggerWebhook: connect.NewClient[UpdateTriggerWebhookRequest, TriggerWebhook](
+			httpClient,
+			baseURL+TriggerServiceUpdateTriggerWebhookProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("UpdateTriggerWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTriggerWebhook: connect.NewClient[DeleteTriggerWebhookRequest, Empty](
+			httpClient,
+			baseURL+TriggerServiceDeleteTriggerWebhookProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("DeleteTriggerWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listTriggerRuns: connect.NewClient[ListTriggerRunsRequest, ListTriggerRunsResponse](
+			httpClient,
+			baseURL+TriggerServiceListTriggerRunsProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("ListTriggerRuns")),
+			connect.WithClientOptions(opts...),
+		),
+		getTriggerRun: connect.NewClient[GetTriggerRunRequest, TriggerRun](
+			httpClient,
+			baseURL+TriggerServiceGetTriggerRunProcedure,
+			connect.WithSchema(triggerServiceMethods.ByName("GetTriggerRun")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // triggerServiceClient implements TriggerServiceClient.
 type triggerServiceClient struct {
-	createTrigger *connect.Client[CreateTriggerRequest, Trigger]
-	getTrigger    *connect.Client[GetTriggerRequest, Trigger]
-	listTriggers  *connect.Client[ListTriggersRequest, ListTriggersResponse]
-	updateTrigger *connect.Client[UpdateTriggerRequest, Trigger]
-	deleteTrigger *connect.Client[DeleteTriggerRequest, Empty]
+	createTrigger                 *connect.Client[CreateTriggerRequest, Trigger]
+	createTriggerFromConversation *connect.Client[CreateTriggerFromConversationRequest, Trigger]
+	getTrigger                    *connect.Client[GetTriggerRequest, Trigger]
+	listTriggers                  *connect.Client[ListTriggersRequest, ListTriggersResponse]
+	updateTrigger                 *connect.Client[UpdateTriggerRequest, Trigger]
+	deleteTrigger                 *connect.Client[DeleteTriggerRequest, Empty]
+	batchUpdateTriggers           *connect.Client[BatchUpdateTriggersRequest, BatchUpdateTriggersResponse]
+	runTrigger                    *connect.Client[RunTriggerRequest, RunTriggerResponse]
+	createTriggerWebhook          *connect.Client[CreateTriggerWebhookRequest, TriggerWebhook]
+	getTriggerWebhook             *connect.Client[GetTriggerWebhookRequest, TriggerWebhook]
+	updateTriggerWebhook          *connect.Client[UpdateTriggerWebhookRequest, TriggerWebhook]
+	deleteTriggerWebhook          *connect.Client[DeleteTriggerWebhookRequest, Empty]
+	listTriggerRuns               *connect.Client[ListTriggerRunsRequest, ListTriggerRunsResponse]
+	getTriggerRun                 *connect.Client[GetTriggerRunRequest, TriggerRun]
 }
 
 // CreateTrigger calls blippy.trigger.TriggerService.CreateTrigger.
@@ -116,6 +224,11 @@ func (c *triggerServiceClient) CreateTrigger(ctx context.Context, req *connect.R
 	return c.createTrigger.CallUnary(ctx, req)
 }
 
+// CreateTriggerFromConversation calls blippy.trigger.TriggerService.CreateTriggerFromConversation.
+func (c *triggerServiceClient) CreateTriggerFromConversation(ctx context.Context, req *connect.Request[CreateTriggerFromConversationRequest]) (*connect.Response[Trigger], error) {
+	return c.createTriggerFromConversation.CallUnary(ctx, req)
+}
+
 // GetTrigger calls blippy.trigger.TriggerService.GetTrigger.
 func (c *triggerServiceClient) GetTrigger(ctx context.Context, req *connect.Request[GetTriggerRequest]) (*connect.Response[Trigger], error) {
 	return c.getTrigger.CallUnary(ctx, req)
@@ -136,13 +249,71 @@ func (c *triggerServiceClient) DeleteTrigger(ctx context.Context, req *connect.R
 	return c.deleteTrigger.CallUnary(ctx, req)
 }
 
+// BatchUpdateTriggers calls blippy.trigger.TriggerService.BatchUpdateTriggers.
+func (c *triggerServiceClient) BatchUpdateTriggers(ctx context.Context, req *connect.Request[BatchUpdateTriggersRequest]) (*connect.Response[BatchUpdateTriggersResponse], error) {
+	return c.batchUpdateTriggers.CallUnary(ctx, req)
+}
+
+// RunTrigger calls blippy.trigger.TriggerService.RunTrigger.
+func (c *triggerServiceClient) RunTrigger(ctx context.Context, req *connect.Request[RunTriggerRequest]) (*connect.Response[RunTriggerResponse], error) {
+	return c.runTrigger.CallUnary(ctx, req)
+}
+
+// CreateTriggerWebhook calls blippy.trigger.TriggerService.CreateTriggerWebhook.
+func (c *triggerServiceClient) CreateTriggerWebhook(ctx context.Context, req *connect.Request[CreateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	return c.createTriggerWebhook.CallUnary(ctx, req)
+}
+
+// GetTriggerWebhook calls blippy.trigger.TriggerService.GetTriggerWebhook.
+func (c *triggerServiceClient) GetTriggerWebhook(ctx context.Context, req *connect.Request[GetTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	return c.getTriggerWebhook.CallUnary(ctx, req)
+}
+
+// UpdateTriggerWebhook calls blippy.trigger.TriggerService.UpdateTriggerWebhook.
+func (c *triggerServiceClient) UpdateTriggerWebhook(ctx context.Context, req *connect.Request[UpdateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	return c.updateTriggerWebhook.CallUnary(ctx, req)
+}
+
+// DeleteTriggerWebhook calls blippy.trigger.TriggerService.DeleteTriggerWebhook.
+func (c *triggerServiceClient) DeleteTriggerWebhook(ctx context.Context, req *connect.Request[DeleteTriggerWebhookRequest]) (*connect.Response[Empty], error) {
+	return c.deleteTriggerWebhook.CallUnary(ctx, req)
+}
+
+// ListTriggerRuns calls blippy.trigger.TriggerService.ListTriggerRuns.
+func (c *triggerServiceClient) ListTriggerRuns(ctx context.Context, req *connect.Request[ListTriggerRunsRequest]) (*connect.Response[ListTriggerRunsResponse], error) {
+	return c.listTriggerRuns.CallUnary(ctx, req)
+}
+
+// GetTriggerRun calls blippy.trigger.TriggerService.GetTriggerRun.
+func (c *triggerServiceClient) GetTriggerRun(ctx context.Context, req *connect.Request[GetTriggerRunRequest]) (*connect.Response[TriggerRun], error) {
+	return c.getTriggerRun.CallUnary(ctx, req)
+}
+
 // TriggerServiceHandler is an implementation of the blippy.trigger.TriggerService service.
 type TriggerServiceHandler interface {
 	CreateTrigger(context.Context, *connect.Request[CreateTriggerRequest]) (*connect.Response[Trigger], error)
+	// Creates a disabled trigger for the conversation's agent, with a name,
+	// prompt and schedule drafted by an LLM from the conversation, to review
+	// before enabling it.
+	CreateTriggerFromConversation(context.Context, *connect.Request[CreateTriggerFromConversationRequest]) (*connect.Response[Trigger], error)
 	GetTrigger(context.Context, *connect.Request[GetTriggerRequest]) (*connect.Response[Trigger], error)
 	ListTriggers(context.Context, *connect.Request[ListTriggersRequest]) (*connect.Response[ListTriggersResponse], error)
 	UpdateTrigger(context.Context, *connect.Request[UpdateTriggerRequest]) (*connect.Response[Trigger], error)
 	DeleteTrigger(context.Context, *connect.Request[DeleteTriggerRequest]) (*connect.Response[Empty], error)
+	// Enables, disables or moves many triggers in a single transaction.
+	BatchUpdateTriggers(context.Context, *connect.Request[BatchUpdateTriggersRequest]) (*connect.Response[BatchUpdateTriggersResponse], error)
+	// Runs a trigger now, in the background, without changing its schedule.
+	RunTrigger(context.Context, *connect.Request[RunTriggerRequest]) (*connect.Response[RunTriggerResponse], error)
+	// Creates the webhook of a trigger, or replaces it with one with a new
+	// token.
+	CreateTriggerWebhook(context.Context, *connect.Request[CreateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error)
+	GetTriggerWebhook(context.Context, *connect.Request[GetTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error)
+	UpdateTriggerWebhook(context.Context, *connect.Request[UpdateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error)
+	DeleteTriggerWebhook(context.Context, *connect.Request[DeleteTriggerWebhookRequest]) (*connect.Response[Empty], error)
+	// Lists the runs of triggers, most recent first. Runs are kept for
+	// TRIGGER_RUN_RETENTION.
+	ListTriggerRuns(context.Context, *connect.Request[ListTriggerRunsRequest]) (*connect.Response[ListTriggerRunsResponse], error)
+	GetTriggerRun(context.Context, *connect.Request[GetTriggerRunRequest]) (*connect.Response[TriggerRun], error)
 }
 
 // NewTriggerServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -158,6 +329,12 @@ func NewTriggerServiceHandler(svc TriggerServiceHandler, opts ...connect.Handler
 		connect.WithSchema(triggerServiceMethods.ByName("CreateTrigger")),
 		connect.WithHandlerOptions(opts...),
 	)
+	triggerServiceCreateTriggerFromConversationHandler := connect.NewUnaryHandler(
+		TriggerServiceCreateTriggerFromConversationProcedure,
+		svc.CreateTriggerFromConversation,
+		connect.WithSchema(triggerServiceMethods.ByName("CreateTriggerFromConversation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	triggerServiceGetTriggerHandler := connect.NewUnaryHandler(
 		TriggerServiceGetTriggerProcedure,
 		svc.GetTrigger,
@@ -182,10 +359,60 @@ func NewTriggerServiceHandler(svc TriggerServiceHandler, opts ...connect.Handler
 		connect.WithSchema(triggerServiceMethods.ByName("DeleteTrigger")),
 		connect.WithHandlerOptions(opts...),
 	)
+	triggerServiceBatchUpdateTriggersHandler := connect.NewUnaryHandler(
+		TriggerServiceBatchUpdateTriggersProcedure,
+		svc.BatchUpdateTriggers,
+		connect.WithSchema(triggerServiceMethods.ByName("BatchUpdateTriggers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	triggerServiceRunTriggerHandler := connect.NewUnaryHandler(
+		TriggerServiceRunTriggerProcedure,
+		svc.RunTrigger,
+		connect.WithSchema(triggerServiceMethods.ByName("RunTrigger")),
+		connect.WithHandlerOptions(opts...),
+	)
+	triggerServiceCreateTriggerWebhookHandler := connect.NewUnaryHandler(
+		TriggerServiceCreateTriggerWebhookProcedure,
+		svc.CreateTriggerWebhook,
+		connect.WithSchema(triggerServiceMethods.ByName("CreateTriggerWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	triggerServiceGetTriggerWebhookHandler := connect.NewUnaryHandler(
+		TriggerServiceGetTriggerWebhookProcedure,
+		svc.GetTriggerWebhook,
+		connect.WithSchema(triggerServiceMethods.ByName("GetTriggerWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	triggerServiceUpdateTriggerWebhookHandler := connect.NewUnaryHandler(
+		TriggerServiceUpdateTriggerWebhookProcedure,
+		svc.UpdateTriggerWebhook,
+		connect.WithSchema(triggerServiceMethods.ByName("UpdateTriggerWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	triggerServiceDeleteTriggerWebhookHandler := connect.NewUnaryHandler(
+		TriggerServiceDeleteTriggerWebhookProcedure,
+		svc.DeleteTriggerWebhook,
+		connect.WithSchema(triggerServiceMethods.ByName("DeleteTriggerWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	triggerServiceListTriggerRunsHandler := connect.NewUnaryHandler(
+		TriggerServiceListTriggerRunsProcedure,
+		svc.ListTriggerRuns,
+		connect.WithSchema(triggerServiceMethods.ByName("ListTriggerRuns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	triggerServiceGetTriggerRunHandler := connect.NewUnaryHandler(
+		TriggerServiceGetTriggerRunProcedure,
+		svc.GetTriggerRun,
+		connect.WithSchema(triggerServiceMethods.ByName("GetTriggerRun")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/blippy.trigger.TriggerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TriggerServiceCreateTriggerProcedure:
 			triggerServiceCreateTriggerHandler.ServeHTTP(w, r)
+		case TriggerServiceCreateTriggerFromConversationProcedure:
+			triggerServiceCreateTriggerFromConversationHandler.ServeHTTP(w, r)
 		case TriggerServiceGetTriggerProcedure:
 			triggerServiceGetTriggerHandler.ServeHTTP(w, r)
 		case TriggerServiceListTriggersProcedure:
@@ -194,6 +421,22 @@ func NewTriggerServiceHandler(svc TriggerServiceHandler, opts ...connect.Handler
 			triggerServiceUpdateTriggerHandler.ServeHTTP(w, r)
 		case TriggerServiceDeleteTriggerProcedure:
 			triggerServiceDeleteTriggerHandler.ServeHTTP(w, r)
+		case TriggerServiceBatchUpdateTriggersProcedure:
+			triggerServiceBatchUpdateTriggersHandler.ServeHTTP(w, r)
+		case TriggerServiceRunTriggerProcedure:
+			triggerServiceRunTriggerHandler.ServeHTTP(w, r)
+		case TriggerServiceCreateTriggerWebhookProcedure:
+			triggerServiceCreateTriggerWebhookHandler.ServeHTTP(w, r)
+		case TriggerServiceGetTriggerWebhookProcedure:
+			triggerServiceGetTriggerWebhookHandler.ServeHTTP(w, r)
+		case TriggerServiceUpdateTriggerWebhookProcedure:
+			triggerServiceUpdateTriggerWebhookHandler.ServeHTTP(w, r)
+		case TriggerServiceDeleteTriggerWebhookProcedure:
+			triggerServiceDeleteTriggerWebhookHandler.ServeHTTP(w, r)
+		case TriggerServiceListTriggerRunsProcedure:
+			triggerServiceListTriggerRunsHandler.ServeHTTP(w, r)
+		case TriggerServiceGetTriggerRunProcedure:
+			triggerServiceGetTriggerRunHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -207,6 +450,10 @@ func (UnimplementedTriggerServiceHandler) CreateTrigger(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.CreateTrigger is not implemented"))
 }
 
+func (UnimplementedTriggerServiceHandler) CreateTriggerFromConversation(context.Context, *connect.Request[CreateTriggerFromConversationRequest]) (*connect.Response[Trigger], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.CreateTriggerFromConversation is not implemented"))
+}
+
 func (UnimplementedTriggerServiceHandler) GetTrigger(context.Context, *connect.Request[GetTriggerRequest]) (*connect.Response[Trigger], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.GetTrigger is not implemented"))
 }
@@ -222,3 +469,35 @@ func (UnimplementedTriggerServiceHandler) UpdateTrigger(context.Context, *connec
 func (UnimplementedTriggerServiceHandler) DeleteTrigger(context.Context, *connect.Request[DeleteTriggerRequest]) (*connect.Response[Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.DeleteTrigger is not implemented"))
 }
+
+func (UnimplementedTriggerServiceHandler) BatchUpdateTriggers(context.Context, *connect.Request[BatchUpdateTriggersRequest]) (*connect.Response[BatchUpdateTriggersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.BatchUpdateTriggers is not implemented"))
+}
+
+func (UnimplementedTriggerServiceHandler) RunTrigger(context.Context, *connect.Request[RunTriggerRequest]) (*connect.Response[RunTriggerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.RunTrigger is not implemented"))
+}
+
+func (UnimplementedTriggerServiceHandler) CreateTriggerWebhook(context.Context, *connect.Request[CreateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.CreateTriggerWebhook is not implemented"))
+}
+
+func (UnimplementedTriggerServiceHandler) GetTriggerWebhook(context.Context, *connect.Request[GetTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.GetTriggerWebhook is not implemented"))
+}
+
+func (UnimplementedTriggerServiceHandler) UpdateTriggerWebhook(context.Context, *connect.Request[UpdateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.UpdateTriggerWebhook is not implemented"))
+}
+
+func (UnimplementedTriggerServiceHandler) DeleteTriggerWebhook(context.Context, *connect.Request[DeleteTriggerWebhookRequest]) (*connect.Response[Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.DeleteTriggerWebhook is not implemented"))
+}
+
+func (UnimplementedTriggerServiceHandler) ListTriggerRuns(context.Context, *connect.Request[ListTriggerRunsRequest]) (*connect.Response[ListTriggerRunsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.ListTriggerRuns is not implemented"))
+}
+
+func (UnimplementedTriggerServiceHandler) GetTriggerRun(context.Context, *connect.Request[GetTriggerRunRequest]) (*connect.Response[TriggerRun], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("blippy.trigger.TriggerService.GetTriggerRun is not implemented"))
+}
@@ -23,16 +23,26 @@ const (
 )
 
 type Trigger struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Prompt        string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	CronExpr      string                 `protobuf:"bytes,5,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"` // optional, empty if not set
-	Enabled       bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	NextRunAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"` // optional, zero value if not set
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AgentId   string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Prompt    string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	CronExpr  string                 `protobuf:"bytes,5,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"` // optional, empty if not set
+	Enabled   bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	NextRunAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"` // optional, zero value if not set
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version   int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"` // Incremented on every update
+	// Runs taking longer are stopped and marked "timed_out". 0 uses the
+	// server's MAX_RUN_DURATION.
+	MaxDurationSeconds int32 `protobuf:"varint,11,opt,name=max_duration_seconds,json=maxDurationSeconds,proto3" json:"max_duration_seconds,omitempty"`
+	// Priority of the trigger's runs while waiting for a slot of the agent's
+	// concurrency limit, from 1 to 100; higher starts first. 0 uses the default
+	// of scheduled runs (10), below webhooks (20) and chats (30).
+	Priority int32 `protobuf:"varint,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Variables of the trigger's runs, sorted by name.
+	Vars          []*RunVar `protobuf:"bytes,13,rep,name=vars,proto3" json:"vars,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -130,20 +140,106 @@ func (x *Trigger) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type CreateTriggerRequest struct {
+func (x *Trigger) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Trigger) GetMaxDurationSeconds() int32 {
+	if x != nil {
+		return x.MaxDurationSeconds
+	}
+	return 0
+}
+
+func (x *Trigger) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Trigger) GetVars() []*RunVar {
+	if x != nil {
+		return x.Vars
+	}
+	return nil
+}
+
+// RunVar is a variable of a run, such as a branch name, set as an
+// environment variable of the agent's bash commands and replacing "{{NAME}}"
+// placeholders in the prompt. Names are env var names.
+type RunVar struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Prompt        string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	CronExpr      string                 `protobuf:"bytes,4,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"` // optional, for scheduled triggers
-	Delay         string                 `protobuf:"bytes,5,opt,name=delay,proto3" json:"delay,omitempty"`                       // optional, for one-time delayed triggers (e.g., "5m", "1h")
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *RunVar) Reset() {
+	*x = RunVar{}
+	mi := &file_trigger_trigger_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunVar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunVar) ProtoMessage() {}
+
+func (x *RunVar) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunVar.ProtoReflect.Descriptor instead.
+func (*RunVar) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RunVar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunVar) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type CreateTriggerRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AgentId            string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prompt             string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	CronExpr           string                 `protobuf:"bytes,4,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`                                  // optional, for scheduled triggers
+	Delay              string                 `protobuf:"bytes,5,opt,name=delay,proto3" json:"delay,omitempty"`                                                        // optional, for one-time delayed triggers (e.g., "5m", "1h")
+	MaxDurationSeconds int32                  `protobuf:"varint,6,opt,name=max_duration_seconds,json=maxDurationSeconds,proto3" json:"max_duration_seconds,omitempty"` // optional, 0 uses the server default
+	Priority           int32                  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`                                                 // optional, 0 uses the default of scheduled runs
+	Vars               []*RunVar              `protobuf:"bytes,8,rep,name=vars,proto3" json:"vars,omitempty"`                                                          // optional
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
 func (x *CreateTriggerRequest) Reset() {
 	*x = CreateTriggerRequest{}
-	mi := &file_trigger_trigger_proto_msgTypes[1]
+	mi := &file_trigger_trigger_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -155,7 +251,7 @@ func (x *CreateTriggerRequest) String() string {
 func (*CreateTriggerRequest) ProtoMessage() {}
 
 func (x *CreateTriggerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trigger_trigger_proto_msgTypes[1]
+	mi := &file_trigger_trigger_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +264,7 @@ func (x *CreateTriggerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTriggerRequest.ProtoReflect.Descriptor instead.
 func (*CreateTriggerRequest) Descriptor() ([]byte, []int) {
-	return file_trigger_trigger_proto_rawDescGZIP(), []int{1}
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateTriggerRequest) GetAgentId() string {
@@ -206,6 +302,81 @@ func (x *CreateTriggerRequest) GetDelay() string {
 	return ""
 }
 
+func (x *CreateTriggerRequest) GetMaxDurationSeconds() int32 {
+	if x != nil {
+		return x.MaxDurationSeconds
+	}
+	return 0
+}
+
+func (x *CreateTriggerRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateTriggerRequest) GetVars() []*RunVar {
+	if x != nil {
+		return x.Vars
+	}
+	return nil
+}
+
+type CreateTriggerFromConversationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Optional schedule of the trigger, instead of the one proposed from the
+	// conversation.
+	CronExpr      string `protobuf:"bytes,2,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTriggerFromConversationRequest) Reset() {
+	*x = CreateTriggerFromConversationRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTriggerFromConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTriggerFromConversationRequest) ProtoMessage() {}
+
+func (x *CreateTriggerFromConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTriggerFromConversationRequest.ProtoReflect.Descriptor instead.
+func (*CreateTriggerFromConversationRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTriggerFromConversationRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *CreateTriggerFromConversationRequest) GetCronExpr() string {
+	if x != nil {
+		return x.CronExpr
+	}
+	return ""
+}
+
 type GetTriggerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -215,7 +386,7 @@ type GetTriggerRequest struct {
 
 func (x *GetTriggerRequest) Reset() {
 	*x = GetTriggerRequest{}
-	mi := &file_trigger_trigger_proto_msgTypes[2]
+	mi := &file_trigger_trigger_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -227,7 +398,7 @@ func (x *GetTriggerRequest) String() string {
 func (*GetTriggerRequest) ProtoMessage() {}
 
 func (x *GetTriggerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trigger_trigger_proto_msgTypes[2]
+	mi := &file_trigger_trigger_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,7 +411,7 @@ func (x *GetTriggerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTriggerRequest.ProtoReflect.Descriptor instead.
 func (*GetTriggerRequest) Descriptor() ([]byte, []int) {
-	return file_trigger_trigger_proto_rawDescGZIP(), []int{2}
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetTriggerRequest) GetId() string {
@@ -251,15 +422,23 @@ func (x *GetTriggerRequest) GetId() string {
 }
 
 type ListTriggersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	// Maximum number of results to return. 0 returns all results.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Comma-separated fields, each optionally followed by "asc" or "desc".
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Terms joined by "AND", e.g. `name:deploy AND created_at>=2025-01-01`.
+	Filter        string `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListTriggersRequest) Reset() {
 	*x = ListTriggersRequest{}
-	mi := &file_trigger_trigger_proto_msgTypes[3]
+	mi := &file_trigger_trigger_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -271,7 +450,7 @@ func (x *ListTriggersRequest) String() string {
 func (*ListTriggersRequest) ProtoMessage() {}
 
 func (x *ListTriggersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trigger_trigger_proto_msgTypes[3]
+	mi := &file_trigger_trigger_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,7 +463,7 @@ func (x *ListTriggersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTriggersRequest.ProtoReflect.Descriptor instead.
 func (*ListTriggersRequest) Descriptor() ([]byte, []int) {
-	return file_trigger_trigger_proto_rawDescGZIP(), []int{3}
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListTriggersRequest) GetAgentId() string {
@@ -294,16 +473,48 @@ func (x *ListTriggersRequest) GetAgentId() string {
 	return ""
 }
 
+func (x *ListTriggersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTriggersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTriggersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListTriggersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type ListTriggersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Triggers      []*Trigger             `protobuf:"bytes,1,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Triggers []*Trigger             `protobuf:"bytes,1,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of results matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListTriggersResponse) Reset() {
 	*x = ListTriggersResponse{}
-	mi := &file_trigger_trigger_proto_msgTypes[4]
+	mi := &file_trigger_trigger_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +526,7 @@ func (x *ListTriggersResponse) String() string {
 func (*ListTriggersResponse) ProtoMessage() {}
 
 func (x *ListTriggersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_trigger_trigger_proto_msgTypes[4]
+	mi := &file_trigger_trigger_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +539,7 @@ func (x *ListTriggersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTriggersResponse.ProtoReflect.Descriptor instead.
 func (*ListTriggersResponse) Descriptor() ([]byte, []int) {
-	return file_trigger_trigger_proto_rawDescGZIP(), []int{4}
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListTriggersResponse) GetTriggers() []*Trigger {
@@ -338,20 +549,38 @@ func (x *ListTriggersResponse) GetTriggers() []*Trigger {
 	return nil
 }
 
+func (x *ListTriggersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTriggersResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
 type UpdateTriggerRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Prompt        string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	CronExpr      string                 `protobuf:"bytes,4,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
-	Enabled       bool                   `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prompt             string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	CronExpr           string                 `protobuf:"bytes,4,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	Enabled            bool                   `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Version            int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`                                                   // Version the update is based on; fails with ABORTED if stale
+	MaxDurationSeconds int32                  `protobuf:"varint,7,opt,name=max_duration_seconds,json=maxDurationSeconds,proto3" json:"max_duration_seconds,omitempty"` // 0 uses the server default
+	Priority           int32                  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`                                                 // 0 uses the default of scheduled runs
+	Vars               []*RunVar              `protobuf:"bytes,9,rep,name=vars,proto3" json:"vars,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *UpdateTriggerRequest) Reset() {
 	*x = UpdateTriggerRequest{}
-	mi := &file_trigger_trigger_proto_msgTypes[5]
+	mi := &file_trigger_trigger_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -363,7 +592,7 @@ func (x *UpdateTriggerRequest) String() string {
 func (*UpdateTriggerRequest) ProtoMessage() {}
 
 func (x *UpdateTriggerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trigger_trigger_proto_msgTypes[5]
+	mi := &file_trigger_trigger_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -376,7 +605,7 @@ func (x *UpdateTriggerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTriggerRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTriggerRequest) Descriptor() ([]byte, []int) {
-	return file_trigger_trigger_proto_rawDescGZIP(), []int{5}
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateTriggerRequest) GetId() string {
@@ -414,6 +643,34 @@ func (x *UpdateTriggerRequest) GetEnabled() bool {
 	return false
 }
 
+func (x *UpdateTriggerRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateTriggerRequest) GetMaxDurationSeconds() int32 {
+	if x != nil {
+		return x.MaxDurationSeconds
+	}
+	return 0
+}
+
+func (x *UpdateTriggerRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateTriggerRequest) GetVars() []*RunVar {
+	if x != nil {
+		return x.Vars
+	}
+	return nil
+}
+
 type DeleteTriggerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -423,7 +680,7 @@ type DeleteTriggerRequest struct {
 
 func (x *DeleteTriggerRequest) Reset() {
 	*x = DeleteTriggerRequest{}
-	mi := &file_trigger_trigger_proto_msgTypes[6]
+	mi := &file_trigger_trigger_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -435,7 +692,7 @@ func (x *DeleteTriggerRequest) String() string {
 func (*DeleteTriggerRequest) ProtoMessage() {}
 
 func (x *DeleteTriggerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trigger_trigger_proto_msgTypes[6]
+	mi := &file_trigger_trigger_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -448,7 +705,7 @@ func (x *DeleteTriggerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTriggerRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTriggerRequest) Descriptor() ([]byte, []int) {
-	return file_trigger_trigger_proto_rawDescGZIP(), []int{6}
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteTriggerRequest) GetId() string {
@@ -458,27 +715,34 @@ func (x *DeleteTriggerRequest) GetId() string {
 	return ""
 }
 
-type Empty struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type BatchUpdateTriggersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 500 trigger IDs; the update fails if any doesn't exist.
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*BatchUpdateTriggersRequest_Enabled
+	//	*BatchUpdateTriggersRequest_AgentId
+	Update        isBatchUpdateTriggersRequest_Update `protobuf_oneof:"update"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
-	mi := &file_trigger_trigger_proto_msgTypes[7]
+func (x *BatchUpdateTriggersRequest) Reset() {
+	*x = BatchUpdateTriggersRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Empty) String() string {
+func (x *BatchUpdateTriggersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*BatchUpdateTriggersRequest) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_trigger_trigger_proto_msgTypes[7]
+func (x *BatchUpdateTriggersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,101 +753,1101 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_trigger_trigger_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use BatchUpdateTriggersRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTriggersRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{9}
 }
 
-var File_trigger_trigger_proto protoreflect.FileDescriptor
-
-const file_trigger_trigger_proto_rawDesc = "" +
-	"\n" +
-	"\x15trigger/trigger.proto\x12\x0eblippy.trigger\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc9\x02\n" +
-	"\aTrigger\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
-	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x12\x1b\n" +
-	"\tcron_expr\x18\x05 \x01(\tR\bcronExpr\x12\x18\n" +
-	"\aenabled\x18\x06 \x01(\bR\aenabled\x12:\n" +
-	"\vnext_run_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x129\n" +
-	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x90\x01\n" +
-	"\x14CreateTriggerRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
-	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x12\x1b\n" +
-	"\tcron_expr\x18\x04 \x01(\tR\bcronExpr\x12\x14\n" +
-	"\x05delay\x18\x05 \x01(\tR\x05delay\"#\n" +
-	"\x11GetTriggerRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
-	"\x13ListTriggersRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\"K\n" +
-	"\x14ListTriggersResponse\x123\n" +
-	"\btriggers\x18\x01 \x03(\v2\x17.blippy.trigger.TriggerR\btriggers\"\x89\x01\n" +
-	"\x14UpdateTriggerRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
-	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x12\x1b\n" +
-	"\tcron_expr\x18\x04 \x01(\tR\bcronExpr\x12\x18\n" +
-	"\aenabled\x18\x05 \x01(\bR\aenabled\"&\n" +
-	"\x14DeleteTriggerRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\a\n" +
-	"\x05Empty2\xa3\x03\n" +
-	"\x0eTriggerService\x12N\n" +
-	"\rCreateTrigger\x12$.blippy.trigger.CreateTriggerRequest\x1a\x17.blippy.trigger.Trigger\x12H\n" +
-	"\n" +
-	"GetTrigger\x12!.blippy.trigger.GetTriggerRequest\x1a\x17.blippy.trigger.Trigger\x12Y\n" +
-	"\fListTriggers\x12#.blippy.trigger.ListTriggersRequest\x1a$.blippy.trigger.ListTriggersResponse\x12N\n" +
-	"\rUpdateTrigger\x12$.blippy.trigger.UpdateTriggerRequest\x1a\x17.blippy.trigger.Trigger\x12L\n" +
-	"\rDeleteTrigger\x12$.blippy.trigger.DeleteTriggerRequest\x1a\x15.blippy.trigger.EmptyB-Z+github.com/dstotijn/blippy/internal/triggerb\x06proto3"
-
-var (
-	file_trigger_trigger_proto_rawDescOnce sync.Once
-	file_trigger_trigger_proto_rawDescData []byte
-)
-
-func file_trigger_trigger_proto_rawDescGZIP() []byte {
-	file_trigger_trigger_proto_rawDescOnce.Do(func() {
-		file_trigger_trigger_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_trigger_trigger_proto_rawDesc), len(file_trigger_trigger_proto_rawDesc)))
-	})
-	return file_trigger_trigger_proto_rawDescData
+func (x *BatchUpdateTriggersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
 }
 
-var file_trigger_trigger_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_trigger_trigger_proto_goTypes = []any{
-	(*Trigger)(nil),               // 0: blippy.trigger.Trigger
-	(*CreateTriggerRequest)(nil),  // 1: blippy.trigger.CreateTriggerRequest
-	(*GetTriggerRequest)(nil),     // 2: blippy.trigger.GetTriggerRequest
-	(*ListTriggersRequest)(nil),   // 3: blippy.trigger.ListTriggersRequest
-	(*ListTriggersResponse)(nil),  // 4: blippy.trigger.ListTriggersResponse
-	(*UpdateTriggerRequest)(nil),  // 5: blippy.trigger.UpdateTriggerRequest
-	(*DeleteTriggerRequest)(nil),  // 6: blippy.trigger.DeleteTriggerRequest
-	(*Empty)(nil),                 // 7: blippy.trigger.Empty
-	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+func (x *BatchUpdateTriggersRequest) GetUpdate() isBatchUpdateTriggersRequest_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *BatchUpdateTriggersRequest) GetEnabled() bool {
+	if x != nil {
+		if x, ok := x.Update.(*BatchUpdateTriggersRequest_Enabled); ok {
+			return x.Enabled
+		}
+	}
+	return false
+}
+
+func (x *BatchUpdateTriggersRequest) GetAgentId() string {
+	if x != nil {
+		if x, ok := x.Update.(*BatchUpdateTriggersRequest_AgentId); ok {
+			return x.AgentId
+		}
+	}
+	return ""
+}
+
+type isBatchUpdateTriggersRequest_Update interface {
+	isBatchUpdateTriggersRequest_Update()
+}
+
+type BatchUpdateTriggersRequest_Enabled struct {
+	// Enables or disables the triggers. Enabled cron triggers are
+	// rescheduled from now, so missed runs aren't made up for.
+	Enabled bool `protobuf:"varint,2,opt,name=enabled,proto3,oneof"`
+}
+
+type BatchUpdateTriggersRequest_AgentId struct {
+	// Moves the triggers to another agent.
+	AgentId string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3,oneof"`
+}
+
+func (*BatchUpdateTriggersRequest_Enabled) isBatchUpdateTriggersRequest_Update() {}
+
+func (*BatchUpdateTriggersRequest_AgentId) isBatchUpdateTriggersRequest_Update() {}
+
+type BatchUpdateTriggersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The updated triggers, in the order of the request's IDs.
+	Triggers      []*Trigger `protobuf:"bytes,1,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTriggersResponse) Reset() {
+	*x = BatchUpdateTriggersResponse{}
+	mi := &file_trigger_trigger_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTriggersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTriggersResponse) ProtoMessage() {}
+
+func (x *BatchUpdateTriggersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTriggersResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTriggersResponse) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchUpdateTriggersResponse) GetTriggers() []*Trigger {
+	if x != nil {
+		return x.Triggers
+	}
+	return nil
+}
+
+type RunTriggerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTriggerRequest) Reset() {
+	*x = RunTriggerRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTriggerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTriggerRequest) ProtoMessage() {}
+
+func (x *RunTriggerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTriggerRequest.ProtoReflect.Descriptor instead.
+func (*RunTriggerRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RunTriggerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RunTriggerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The trigger run started.
+	TriggerRunId  string `protobuf:"bytes,1,opt,name=trigger_run_id,json=triggerRunId,proto3" json:"trigger_run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTriggerResponse) Reset() {
+	*x = RunTriggerResponse{}
+	mi := &file_trigger_trigger_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTriggerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTriggerResponse) ProtoMessage() {}
+
+func (x *RunTriggerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTriggerResponse.ProtoReflect.Descriptor instead.
+func (*RunTriggerResponse) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RunTriggerResponse) GetTriggerRunId() string {
+	if x != nil {
+		return x.TriggerRunId
+	}
+	return ""
+}
+
+// TriggerRun is a run of a trigger, by its schedule, RunTrigger or a
+// webhook.
+type TriggerRun struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TriggerId string                 `protobuf:"bytes,2,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	// "running", "completed", "failed", "interrupted", "cancelled" or
+	// "timed_out".
+	Status       string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorMessage string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // empty unless the run failed
+	// The conversation of the run, once it finished. Empty if none was
+	// created or it was deleted.
+	ConversationId string                 `protobuf:"bytes,5,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	StartedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`  // unset while running
+	DurationMs     int64                  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // 0 while running
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TriggerRun) Reset() {
+	*x = TriggerRun{}
+	mi := &file_trigger_trigger_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerRun) ProtoMessage() {}
+
+func (x *TriggerRun) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerRun.ProtoReflect.Descriptor instead.
+func (*TriggerRun) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TriggerRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TriggerRun) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *TriggerRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TriggerRun) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TriggerRun) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *TriggerRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *TriggerRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *TriggerRun) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type ListTriggerRunsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"` // optional filter
+	// Maximum number of results to return. 0 returns all results.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Comma-separated fields, each optionally followed by "asc" or "desc".
+	// Defaults to the most recently started first.
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Terms joined by "AND", e.g. `status=failed AND started_at>=2025-01-01`.
+	Filter        string `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTriggerRunsRequest) Reset() {
+	*x = ListTriggerRunsRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTriggerRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTriggerRunsRequest) ProtoMessage() {}
+
+func (x *ListTriggerRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTriggerRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListTriggerRunsRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTriggerRunsRequest) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *ListTriggerRunsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTriggerRunsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTriggerRunsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListTriggerRunsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type ListTriggerRunsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TriggerRuns []*TriggerRun          `protobuf:"bytes,1,rep,name=trigger_runs,json=triggerRuns,proto3" json:"trigger_runs,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of results matching the filter.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTriggerRunsResponse) Reset() {
+	*x = ListTriggerRunsResponse{}
+	mi := &file_trigger_trigger_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTriggerRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTriggerRunsResponse) ProtoMessage() {}
+
+func (x *ListTriggerRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTriggerRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListTriggerRunsResponse) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTriggerRunsResponse) GetTriggerRuns() []*TriggerRun {
+	if x != nil {
+		return x.TriggerRuns
+	}
+	return nil
+}
+
+func (x *ListTriggerRunsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTriggerRunsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type GetTriggerRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTriggerRunRequest) Reset() {
+	*x = GetTriggerRunRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTriggerRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTriggerRunRequest) ProtoMessage() {}
+
+func (x *GetTriggerRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTriggerRunRequest.ProtoReflect.Descriptor instead.
+func (*GetTriggerRunRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTriggerRunRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// TriggerWebhook runs its trigger when its URL is called, e.g. by GitHub or
+// Stripe. The request body is appended to the trigger's prompt.
+type TriggerWebhook struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	// Path of the webhook URL, "/webhooks/triggers/<token>". Only set when the
+	// webhook is created; the token can't be retrieved again.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Whether requests must be signed with the webhook's secret.
+	HasSecret bool `protobuf:"varint,3,opt,name=has_secret,json=hasSecret,proto3" json:"has_secret,omitempty"`
+	// How requests are signed: "github" (X-Hub-Signature-256, the default),
+	// "stripe" (Stripe-Signature) or "blippy" (X-Blippy-Signature, as sent by
+	// notification channels).
+	SignatureScheme string `protobuf:"bytes,4,opt,name=signature_scheme,json=signatureScheme,proto3" json:"signature_scheme,omitempty"`
+	// IP addresses or CIDR ranges requests must come from. Empty allows all.
+	AllowedIps    []string               `protobuf:"bytes,5,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerWebhook) Reset() {
+	*x = TriggerWebhook{}
+	mi := &file_trigger_trigger_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerWebhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerWebhook) ProtoMessage() {}
+
+func (x *TriggerWebhook) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerWebhook.ProtoReflect.Descriptor instead.
+func (*TriggerWebhook) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TriggerWebhook) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *TriggerWebhook) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TriggerWebhook) GetHasSecret() bool {
+	if x != nil {
+		return x.HasSecret
+	}
+	return false
+}
+
+func (x *TriggerWebhook) GetSignatureScheme() string {
+	if x != nil {
+		return x.SignatureScheme
+	}
+	return ""
+}
+
+func (x *TriggerWebhook) GetAllowedIps() []string {
+	if x != nil {
+		return x.AllowedIps
+	}
+	return nil
+}
+
+func (x *TriggerWebhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TriggerWebhook) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateTriggerWebhookRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId       string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	Secret          string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`                                          // optional
+	SignatureScheme string                 `protobuf:"bytes,3,opt,name=signature_scheme,json=signatureScheme,proto3" json:"signature_scheme,omitempty"` // optional, defaults to "github"
+	AllowedIps      []string               `protobuf:"bytes,4,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`                // optional
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateTriggerWebhookRequest) Reset() {
+	*x = CreateTriggerWebhookRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTriggerWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTriggerWebhookRequest) ProtoMessage() {}
+
+func (x *CreateTriggerWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTriggerWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateTriggerWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateTriggerWebhookRequest) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *CreateTriggerWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateTriggerWebhookRequest) GetSignatureScheme() string {
+	if x != nil {
+		return x.SignatureScheme
+	}
+	return ""
+}
+
+func (x *CreateTriggerWebhookRequest) GetAllowedIps() []string {
+	if x != nil {
+		return x.AllowedIps
+	}
+	return nil
+}
+
+type GetTriggerWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId     string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTriggerWebhookRequest) Reset() {
+	*x = GetTriggerWebhookRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTriggerWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTriggerWebhookRequest) ProtoMessage() {}
+
+func (x *GetTriggerWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTriggerWebhookRequest.ProtoReflect.Descriptor instead.
+func (*GetTriggerWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetTriggerWebhookRequest) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+type UpdateTriggerWebhookRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId       string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	Secret          string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`                               // empty keeps the current secret
+	ClearSecret     bool                   `protobuf:"varint,3,opt,name=clear_secret,json=clearSecret,proto3" json:"clear_secret,omitempty"` // removes the secret, so requests needn't be signed
+	SignatureScheme string                 `protobuf:"bytes,4,opt,name=signature_scheme,json=signatureScheme,proto3" json:"signature_scheme,omitempty"`
+	AllowedIps      []string               `protobuf:"bytes,5,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTriggerWebhookRequest) Reset() {
+	*x = UpdateTriggerWebhookRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTriggerWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTriggerWebhookRequest) ProtoMessage() {}
+
+func (x *UpdateTriggerWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTriggerWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTriggerWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateTriggerWebhookRequest) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *UpdateTriggerWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *UpdateTriggerWebhookRequest) GetClearSecret() bool {
+	if x != nil {
+		return x.ClearSecret
+	}
+	return false
+}
+
+func (x *UpdateTriggerWebhookRequest) GetSignatureScheme() string {
+	if x != nil {
+		return x.SignatureScheme
+	}
+	return ""
+}
+
+func (x *UpdateTriggerWebhookRequest) GetAllowedIps() []string {
+	if x != nil {
+		return x.AllowedIps
+	}
+	return nil
+}
+
+type DeleteTriggerWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId     string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTriggerWebhookRequest) Reset() {
+	*x = DeleteTriggerWebhookRequest{}
+	mi := &file_trigger_trigger_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTriggerWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTriggerWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteTriggerWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTriggerWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTriggerWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteTriggerWebhookRequest) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_trigger_trigger_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_trigger_trigger_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_trigger_trigger_proto_rawDescGZIP(), []int{22}
+}
+
+var File_trigger_trigger_proto protoreflect.FileDescriptor
+
+const file_trigger_trigger_proto_rawDesc = "" +
+	"\n" +
+	"\x15trigger/trigger.proto\x12\x0eblippy.trigger\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x03\n" +
+	"\aTrigger\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x12\x1b\n" +
+	"\tcron_expr\x18\x05 \x01(\tR\bcronExpr\x12\x18\n" +
+	"\aenabled\x18\x06 \x01(\bR\aenabled\x12:\n" +
+	"\vnext_run_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x120\n" +
+	"\x14max_duration_seconds\x18\v \x01(\x05R\x12maxDurationSeconds\x12\x1a\n" +
+	"\bpriority\x18\f \x01(\x05R\bpriority\x12*\n" +
+	"\x04vars\x18\r \x03(\v2\x16.blippy.trigger.RunVarR\x04vars\"2\n" +
+	"\x06RunVar\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x8a\x02\n" +
+	"\x14CreateTriggerRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x12\x1b\n" +
+	"\tcron_expr\x18\x04 \x01(\tR\bcronExpr\x12\x14\n" +
+	"\x05delay\x18\x05 \x01(\tR\x05delay\x120\n" +
+	"\x14max_duration_seconds\x18\x06 \x01(\x05R\x12maxDurationSeconds\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\x05R\bpriority\x12*\n" +
+	"\x04vars\x18\b \x03(\v2\x16.blippy.trigger.RunVarR\x04vars\"l\n" +
+	"$CreateTriggerFromConversationRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1b\n" +
+	"\tcron_expr\x18\x02 \x01(\tR\bcronExpr\"#\n" +
+	"\x11GetTriggerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9f\x01\n" +
+	"\x13ListTriggersRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06filter\x18\x05 \x01(\tR\x06filter\"\x92\x01\n" +
+	"\x14ListTriggersResponse\x123\n" +
+	"\btriggers\x18\x01 \x03(\v2\x17.blippy.trigger.TriggerR\btriggers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\x9d\x02\n" +
+	"\x14UpdateTriggerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x12\x1b\n" +
+	"\tcron_expr\x18\x04 \x01(\tR\bcronExpr\x12\x18\n" +
+	"\aenabled\x18\x05 \x01(\bR\aenabled\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x120\n" +
+	"\x14max_duration_seconds\x18\a \x01(\x05R\x12maxDurationSeconds\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\x12*\n" +
+	"\x04vars\x18\t \x03(\v2\x16.blippy.trigger.RunVarR\x04vars\"&\n" +
+	"\x14DeleteTriggerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"q\n" +
+	"\x1aBatchUpdateTriggersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x1a\n" +
+	"\aenabled\x18\x02 \x01(\bH\x00R\aenabled\x12\x1b\n" +
+	"\bagent_id\x18\x03 \x01(\tH\x00R\aagentIdB\b\n" +
+	"\x06update\"R\n" +
+	"\x1bBatchUpdateTriggersResponse\x123\n" +
+	"\btriggers\x18\x01 \x03(\v2\x17.blippy.trigger.TriggerR\btriggers\"#\n" +
+	"\x11RunTriggerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
+	"\x12RunTriggerResponse\x12$\n" +
+	"\x0etrigger_run_id\x18\x01 \x01(\tR\ftriggerRunId\"\xba\x02\n" +
+	"\n" +
+	"TriggerRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x02 \x01(\tR\ttriggerId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12'\n" +
+	"\x0fconversation_id\x18\x05 \x01(\tR\x0econversationId\x129\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x03R\n" +
+	"durationMs\"\xa6\x01\n" +
+	"\x16ListTriggerRunsRequest\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06filter\x18\x05 \x01(\tR\x06filter\"\x9f\x01\n" +
+	"\x17ListTriggerRunsResponse\x12=\n" +
+	"\ftrigger_runs\x18\x01 \x03(\v2\x1a.blippy.trigger.TriggerRunR\vtriggerRuns\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"&\n" +
+	"\x14GetTriggerRunRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa4\x02\n" +
+	"\x0eTriggerWebhook\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"has_secret\x18\x03 \x01(\bR\thasSecret\x12)\n" +
+	"\x10signature_scheme\x18\x04 \x01(\tR\x0fsignatureScheme\x12\x1f\n" +
+	"\vallowed_ips\x18\x05 \x03(\tR\n" +
+	"allowedIps\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa0\x01\n" +
+	"\x1bCreateTriggerWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12)\n" +
+	"\x10signature_scheme\x18\x03 \x01(\tR\x0fsignatureScheme\x12\x1f\n" +
+	"\vallowed_ips\x18\x04 \x03(\tR\n" +
+	"allowedIps\"9\n" +
+	"\x18GetTriggerWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\"\xc3\x01\n" +
+	"\x1bUpdateTriggerWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12!\n" +
+	"\fclear_secret\x18\x03 \x01(\bR\vclearSecret\x12)\n" +
+	"\x10signature_scheme\x18\x04 \x01(\tR\x0fsignatureScheme\x12\x1f\n" +
+	"\vallowed_ips\x18\x05 \x03(\tR\n" +
+	"allowedIps\"<\n" +
+	"\x1bDeleteTriggerWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\"\a\n" +
+	"\x05Empty2\x94\n" +
+	"\n" +
+	"\x0eTriggerService\x12N\n" +
+	"\rCreateTrigger\x12$.blippy.trigger.CreateTriggerRequest\x1a\x17.blippy.trigger.Trigger\x12n\n" +
+	"\x1dCreateTriggerFromConversation\x124.blippy.trigger.CreateTriggerFromConversationRequest\x1a\x17.blippy.trigger.Trigger\x12H\n" +
+	"\n" +
+	"GetTrigger\x12!.blippy.trigger.GetTriggerRequest\x1a\x17.blippy.trigger.Trigger\x12Y\n" +
+	"\fListTriggers\x12#.blippy.trigger.ListTriggersRequest\x1a$.blippy.trigger.ListTriggersResponse\x12N\n" +
+	"\rUpdateTrigger\x12$.blippy.trigger.UpdateTriggerRequest\x1a\x17.blippy.trigger.Trigger\x12L\n" +
+	"\rDeleteTrigger\x12$.blippy.trigger.DeleteTriggerRequest\x1a\x15.blippy.trigger.Empty\x12n\n" +
+	"\x13BatchUpdateTriggers\x12*.blippy.trigger.BatchUpdateTriggersRequest\x1a+.blippy.trigger.BatchUpdateTriggersResponse\x12S\n" +
+	"\n" +
+	"RunTrigger\x12!.blippy.trigger.RunTriggerRequest\x1a\".blippy.trigger.RunTriggerResponse\x12c\n" +
+	"\x14CreateTriggerWebhook\x12+.blippy.trigger.CreateTriggerWebhookRequest\x1a\x1e.blippy.trigger.TriggerWebhook\x12]\n" +
+	"\x11GetTriggerWebhook\x12(.blippy.trigger.GetTriggerWebhookRequest\x1a\x1e.blippy.trigger.TriggerWebhook\x12c\n" +
+	"\x14UpdateTriggerWebhook\x12+.blippy.trigger.UpdateTriggerWebhookRequest\x1a\x1e.blippy.trigger.TriggerWebhook\x12Z\n" +
+	"\x14DeleteTriggerWebhook\x12+.blippy.trigger.DeleteTriggerWebhookRequest\x1a\x15.blippy.trigger.Empty\x12b\n" +
+	"\x0fListTriggerRuns\x12&.blippy.trigger.ListTriggerRunsRequest\x1a'.blippy.trigger.ListTriggerRunsResponse\x12Q\n" +
+	"\rGetTriggerRun\x12$.blippy.trigger.GetTriggerRunRequest\x1a\x1a.blippy.trigger.TriggerRunB-Z+github.com/dstotijn/blippy/internal/triggerb\x06proto3"
+
+var (
+	file_trigger_trigger_proto_rawDescOnce sync.Once
+	file_trigger_trigger_proto_rawDescData []byte
+)
+
+func file_trigger_trigger_proto_rawDescGZIP() []byte {
+	file_trigger_trigger_proto_rawDescOnce.Do(func() {
+		file_trigger_trigger_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_trigger_trigger_proto_rawDesc), len(file_trigger_trigger_proto_rawDesc)))
+	})
+	return file_trigger_trigger_proto_rawDescData
+}
+
+var file_trigger_trigger_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_trigger_trigger_proto_goTypes = []any{
+	(*Trigger)(nil),                              // 0: blippy.trigger.Trigger
+	(*RunVar)(nil),                               // 1: blippy.trigger.RunVar
+	(*CreateTriggerRequest)(nil),                 // 2: blippy.trigger.CreateTriggerRequest
+	(*CreateTriggerFromConversationRequest)(nil), // 3: blippy.trigger.CreateTriggerFromConversationRequest
+	(*GetTriggerRequest)(nil),                    // 4: blippy.trigger.GetTriggerRequest
+	(*ListTriggersRequest)(nil),                  // 5: blippy.trigger.ListTriggersRequest
+	(*ListTriggersResponse)(nil),                 // 6: blippy.trigger.ListTriggersResponse
+	(*UpdateTriggerRequest)(nil),                 // 7: blippy.trigger.UpdateTriggerRequest
+	(*DeleteTriggerRequest)(nil),                 // 8: blippy.trigger.DeleteTriggerRequest
+	(*BatchUpdateTriggersRequest)(nil),           // 9: blippy.trigger.BatchUpdateTriggersRequest
+	(*BatchUpdateTriggersResponse)(nil),          // 10: blippy.trigger.BatchUpdateTriggersResponse
+	(*RunTriggerRequest)(nil),                    // 11: blippy.trigger.RunTriggerRequest
+	(*RunTriggerResponse)(nil),                   // 12: blippy.trigger.RunTriggerResponse
+	(*TriggerRun)(nil),                           // 13: blippy.trigger.TriggerRun
+	(*ListTriggerRunsRequest)(nil),               // 14: blippy.trigger.ListTriggerRunsRequest
+	(*ListTriggerRunsResponse)(nil),              // 15: blippy.trigger.ListTriggerRunsResponse
+	(*GetTriggerRunRequest)(nil),                 // 16: blippy.trigger.GetTriggerRunRequest
+	(*TriggerWebhook)(nil),                       // 17: blippy.trigger.TriggerWebhook
+	(*CreateTriggerWebhookRequest)(nil),          // 18: blippy.trigger.CreateTriggerWebhookRequest
+	(*GetTriggerWebhookRequest)(nil),             // 19: blippy.trigger.GetTriggerWebhookRequest
+	(*UpdateTriggerWebhookRequest)(nil),          // 20: blippy.trigger.UpdateTriggerWebhookRequest
+	(*DeleteTriggerWebhookRequest)(nil),          // 21: blippy.trigger.DeleteTriggerWebhookRequest
+	(*Empty)(nil),                                // 22: blippy.trigger.Empty
+	(*timestamppb.Timestamp)(nil),                // 23: google.protobuf.Timestamp
 }
 var file_trigger_trigger_proto_depIdxs = []int32{
-	8, // 0: blippy.trigger.Trigger.next_run_at:type_name -> google.protobuf.Timestamp
-	8, // 1: blippy.trigger.Trigger.created_at:type_name -> google.protobuf.Timestamp
-	8, // 2: blippy.trigger.Trigger.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 3: blippy.trigger.ListTriggersResponse.triggers:type_name -> blippy.trigger.Trigger
-	1, // 4: blippy.trigger.TriggerService.CreateTrigger:input_type -> blippy.trigger.CreateTriggerRequest
-	2, // 5: blippy.trigger.TriggerService.GetTrigger:input_type -> blippy.trigger.GetTriggerRequest
-	3, // 6: blippy.trigger.TriggerService.ListTriggers:input_type -> blippy.trigger.ListTriggersRequest
-	5, // 7: blippy.trigger.TriggerService.UpdateTrigger:input_type -> blippy.trigger.UpdateTriggerRequest
-	6, // 8: blippy.trigger.TriggerService.DeleteTrigger:input_type -> blippy.trigger.DeleteTriggerRequest
-	0, // 9: blippy.trigger.TriggerService.CreateTrigger:output_type -> blippy.trigger.Trigger
-	0, // 10: blippy.trigger.TriggerService.GetTrigger:output_type -> blippy.trigger.Trigger
-	4, // 11: blippy.trigger.TriggerService.ListTriggers:output_type -> blippy.trigger.ListTriggersResponse
-	0, // 12: blippy.trigger.TriggerService.UpdateTrigger:output_type -> blippy.trigger.Trigger
-	7, // 13: blippy.trigger.TriggerService.DeleteTrigger:output_type -> blippy.trigger.Empty
-	9, // [9:14] is the sub-list for method output_type
-	4, // [4:9] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	23, // 0: blippy.trigger.Trigger.next_run_at:type_name -> google.protobuf.Timestamp
+	23, // 1: blippy.trigger.Trigger.created_at:type_name -> google.protobuf.Timestamp
+	23, // 2: blippy.trigger.Trigger.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: blippy.trigger.Trigger.vars:type_name -> blippy.trigger.RunVar
+	1,  // 4: blippy.trigger.CreateTriggerRequest.vars:type_name -> blippy.trigger.RunVar
+	0,  // 5: blippy.trigger.ListTriggersResponse.triggers:type_name -> blippy.trigger.Trigger
+	1,  // 6: blippy.trigger.UpdateTriggerRequest.vars:type_name -> blippy.trigger.RunVar
+	0,  // 7: blippy.trigger.BatchUpdateTriggersResponse.triggers:type_name -> blippy.trigger.Trigger
+	23, // 8: blippy.trigger.TriggerRun.started_at:type_name -> google.protobuf.Timestamp
+	23, // 9: blippy.trigger.TriggerRun.finished_at:type_name -> google.protobuf.Timestamp
+	13, // 10: blippy.trigger.ListTriggerRunsResponse.trigger_runs:type_name -> blippy.trigger.TriggerRun
+	23, // 11: blippy.trigger.TriggerWebhook.created_at:type_name -> google.protobuf.Timestamp
+	23, // 12: blippy.trigger.TriggerWebhook.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 13: blippy.trigger.TriggerService.CreateTrigger:input_type -> blippy.trigger.CreateTriggerRequest
+	3,  // 14: blippy.trigger.TriggerService.CreateTriggerFromConversation:input_type -> blippy.trigger.CreateTriggerFromConversationRequest
+	4,  // 15: blippy.trigger.TriggerService.GetTrigger:input_type -> blippy.trigger.GetTriggerRequest
+	5,  // 16: blippy.trigger.TriggerService.ListTriggers:input_type -> blippy.trigger.ListTriggersRequest
+	7,  // 17: blippy.trigger.TriggerService.UpdateTrigger:input_type -> blippy.trigger.UpdateTriggerRequest
+	8,  // 18: blippy.trigger.TriggerService.DeleteTrigger:input_type -> blippy.trigger.DeleteTriggerRequest
+	9,  // 19: blippy.trigger.TriggerService.BatchUpdateTriggers:input_type -> blippy.trigger.BatchUpdateTriggersRequest
+	11, // 20: blippy.trigger.TriggerService.RunTrigger:input_type -> blippy.trigger.RunTriggerRequest
+	18, // 21: blippy.trigger.TriggerService.CreateTriggerWebhook:input_type -> blippy.trigger.CreateTriggerWebhookRequest
+	19, // 22: blippy.trigger.TriggerService.GetTriggerWebhook:input_type -> blippy.trigger.GetTriggerWebhookRequest
+	20, // 23: blippy.trigger.TriggerService.UpdateTriggerWebhook:input_type -> blippy.trigger.UpdateTriggerWebhookRequest
+	21, // 24: blippy.trigger.TriggerService.DeleteTriggerWebhook:input_type -> blippy.trigger.DeleteTriggerWebhookRequest
+	14, // 25: blippy.trigger.TriggerService.ListTriggerRuns:input_type -> blippy.trigger.ListTriggerRunsRequest
+	16, // 26: blippy.trigger.TriggerService.GetTriggerRun:input_type -> blippy.trigger.GetTriggerRunRequest
+	0,  // 27: blippy.trigger.TriggerService.CreateTrigger:output_type -> blippy.trigger.Trigger
+	0,  // 28: blippy.trigger.TriggerService.CreateTriggerFromConversation:output_type -> blippy.trigger.Trigger
+	0,  // 29: blippy.trigger.TriggerService.GetTrigger:output_type -> blippy.trigger.Trigger
+	6,  // 30: blippy.trigger.TriggerService.ListTriggers:output_type -> blippy.trigger.ListTriggersResponse
+	0,  // 31: blippy.trigger.TriggerService.UpdateTrigger:output_type -> blippy.trigger.Trigger
+	22, // 32: blippy.trigger.TriggerService.DeleteTrigger:output_type -> blippy.trigger.Empty
+	10, // 33: blippy.trigger.TriggerService.BatchUpdateTriggers:output_type -> blippy.trigger.BatchUpdateTriggersResponse
+	12, // 34: blippy.trigger.TriggerService.RunTrigger:output_type -> blippy.trigger.RunTriggerResponse
+	17, // 35: blippy.trigger.TriggerService.CreateTriggerWebhook:output_type -> blippy.trigger.TriggerWebhook
+	17, // 36: blippy.trigger.TriggerService.GetTriggerWebhook:output_type -> blippy.trigger.TriggerWebhook
+	17, // 37: blippy.trigger.TriggerService.UpdateTriggerWebhook:output_type -> blippy.trigger.TriggerWebhook
+	22, // 38: blippy.trigger.TriggerService.DeleteTriggerWebhook:output_type -> blippy.trigger.Empty
+	15, // 39: blippy.trigger.TriggerService.ListTriggerRuns:output_type -> blippy.trigger.ListTriggerRunsResponse
+	13, // 40: blippy.trigger.TriggerService.GetTriggerRun:output_type -> blippy.trigger.TriggerRun
+	27, // [27:41] is the sub-list for method output_type
+	13, // [13:27] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_trigger_trigger_proto_init() }
@@ -591,13 +1855,17 @@ func file_trigger_trigger_proto_init() {
 	if File_trigger_trigger_proto != nil {
 		return
 	}
+	file_trigger_trigger_proto_msgTypes[9].OneofWrappers = []any{
+		(*BatchUpdateTriggersRequest_Enabled)(nil),
+		(*BatchUpdateTriggersRequest_AgentId)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trigger_trigger_proto_rawDesc), len(file_trigger_trigger_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,219 @@
+package trigger
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dstotijn/blippy/internal/apierror"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+	"github.com/dstotijn/blippy/internal/webhook"
+)
+
+var errWebhookNotFound = errors.New("trigger has no webhook")
+
+func (s *Service) CreateTriggerWebhook(ctx context.Context, req *connect.Request[CreateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	allowedIPs, err := marshalAllowedIPs(req.Msg.AllowedIps)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := webhook.ValidateSignatureScheme(req.Msg.SignatureScheme); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := s.queries.GetTrigger(ctx, req.Msg.TriggerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_TRIGGER_NOT_FOUND, errors.New("trigger not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	secret, err := s.cipher.Encrypt(req.Msg.Secret)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	token, tokenHash := webhook.NewToken()
+	now := time.Now().UTC().Format(time.RFC3339)
+	hook, err := s.queries.CreateTriggerWebhook(ctx, store.CreateTriggerWebhookParams{
+		TriggerID:       req.Msg.TriggerId,
+		TokenHash:       tokenHash,
+		Secret:          secret,
+		SignatureScheme: req.Msg.SignatureScheme,
+		AllowedIps:      allowedIPs,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := toProtoTriggerWebhook(hook)
+	resp.Path = webhook.TriggerWebhookPath + token
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Service) GetTriggerWebhook(ctx context.Context, req *connect.Request[GetTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	hook, err := s.queries.GetTriggerWebhook(ctx, req.Msg.TriggerId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_WEBHOOK_NOT_FOUND, errWebhookNotFound)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(toProtoTriggerWebhook(hook)), nil
+}
+
+func (s *Service) UpdateTriggerWebhook(ctx context.Context, req *connect.Request[UpdateTriggerWebhookRequest]) (*connect.Response[TriggerWebhook], error) {
+	allowedIPs, err := marshalAllowedIPs(req.Msg.AllowedIps)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := webhook.ValidateSignatureScheme(req.Msg.SignatureScheme); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	existing, err := s.queries.GetTriggerWebhook(ctx, req.Msg.TriggerId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_WEBHOOK_NOT_FOUND, errWebhookNotFound)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	secret := existing.Secret
+	switch {
+	case req.Msg.ClearSecret:
+		secret = ""
+	case req.Msg.Secret != "":
+		if secret, err = s.cipher.Encrypt(req.Msg.Secret); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	hook, err := s.queries.UpdateTriggerWebhook(ctx, store.UpdateTriggerWebhookParams{
+		Secret:          secret,
+		SignatureScheme: req.Msg.SignatureScheme,
+		AllowedIps:      allowedIPs,
+		UpdatedAt:       time.Now().UTC().Format(time.RFC3339),
+		TriggerID:       req.Msg.TriggerId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_WEBHOOK_NOT_FOUND, errWebhookNotFound)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(toProtoTriggerWebhook(hook)), nil
+}
+
+func (s *Service) DeleteTriggerWebhook(ctx context.Context, req *connect.Request[DeleteTriggerWebhookRequest]) (*connect.Response[Empty], error) {
+	n, err := s.queries.DeleteTriggerWebhook(ctx, req.Msg.TriggerId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if n == 0 {
+		return nil, apierror.New(apierror.ErrorCode_ERROR_CODE_WEBHOOK_NOT_FOUND, errWebhookNotFound)
+	}
+
+	return connect.NewResponse(&Empty{}), nil
+}
+
+// toProtoTriggerWebhook converts a webhook without its token, which isn't
+// stored, and secret.
+func toProtoTriggerWebhook(w store.TriggerWebhook) *TriggerWebhook {
+	createdAt, _ := time.Parse(time.RFC3339, w.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, w.UpdatedAt)
+	var allowedIPs []string
+	_ = json.Unmarshal([]byte(w.AllowedIps), &allowedIPs)
+
+	return &TriggerWebhook{
+		TriggerId:       w.TriggerID,
+		HasSecret:       w.Secret != "",
+		SignatureScheme: w.SignatureScheme,
+		AllowedIps:      allowedIPs,
+		CreatedAt:       timestamppb.New(createdAt),
+		UpdatedAt:       timestamppb.New(updatedAt),
+	}
+}
+
+// marshalAllowedIPs validates the allowed IPs of a webhook and returns them
+// as a JSON array.
+func marshalAllowedIPs(ips []string) (string, error) {
+	if _, err := webhook.ParseAllowedIPs(ips); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(append([]string{}, ips...))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// EncryptStoredSecrets encrypts trigger webhook secrets and trigger vars that
+// are stored as plaintext, e.g. because they were written before encryption
+// was enabled. Returns the number of secrets and vars encrypted.
+func EncryptStoredSecrets(ctx context.Context, queries *store.Queries, cipher *encryption.Cipher) (int, error) {
+	if !cipher.Enabled() {
+		return 0, nil
+	}
+
+	hooks, err := queries.ListTriggerWebhooks(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list trigger webhooks: %w", err)
+	}
+
+	n := 0
+	for _, hook := range hooks {
+		if hook.Secret == "" || encryption.IsEncrypted(hook.Secret) {
+			continue
+		}
+		secret, err := cipher.Encrypt(hook.Secret)
+		if err != nil {
+			return n, err
+		}
+		if err := queries.UpdateTriggerWebhookSecret(ctx, store.UpdateTriggerWebhookSecretParams{
+			Secret:    secret,
+			TriggerID: hook.TriggerID,
+		}); err != nil {
+			return n, fmt.Errorf("update secret of webhook of trigger %q: %w", hook.TriggerID, err)
+		}
+		n++
+	}
+
+	triggers, err := queries.ListAllTriggers(ctx)
+	if err != nil {
+		return n, fmt.Errorf("list triggers: %w", err)
+	}
+	for _, t := range triggers {
+		if encryption.IsEncrypted(t.Vars) {
+			continue
+		}
+		vars, err := tool.DecodeRunVars(nil, t.Vars)
+		if err != nil {
+			return n, fmt.Errorf("vars of trigger %q: %w", t.ID, err)
+		}
+		if len(vars) == 0 {
+			continue
+		}
+		encoded, err := tool.EncodeRunVars(cipher, vars)
+		if err != nil {
+			return n, err
+		}
+		if err := queries.UpdateTriggerVars(ctx, store.UpdateTriggerVarsParams{
+			Vars: encoded,
+			ID:   t.ID,
+		}); err != nil {
+			return n, fmt.Errorf("update vars of trigger %q: %w", t.ID, err)
+		}
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,271 @@
+// Package usage reports what agents and conversations did and spent: their
+// runs, failures, token usage and its cost, as reported by the LLM provider
+// or else estimated, from the run traces.
+package usage
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// DefaultPeriod is the period of reports if none is given.
+const DefaultPeriod = 24 * time.Hour
+
+// Report is the usage of the runs started in a period, per agent.
+type Report struct {
+	Since  time.Time
+	Until  time.Time
+	Agents []AgentUsage
+	Total  Usage
+}
+
+// AgentUsage is the usage of an agent's runs.
+type AgentUsage struct {
+	AgentID   string
+	AgentName string
+	Usage
+}
+
+// Usage is the number of runs and their token usage and cost in US dollars.
+// Priced is false if the price of a model wasn't known, in which case its
+// runs don't count towards the cost.
+type Usage struct {
+	Runs         int64
+	FailedRuns   int64
+	InputTokens  int64
+	OutputTokens int64
+	CostUSD      float64
+	Priced       bool
+}
+
+func (u *Usage) add(o Usage) {
+	u.Runs += o.Runs
+	u.FailedRuns += o.FailedRuns
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CostUSD += o.CostUSD
+	u.Priced = u.Priced && o.Priced
+}
+
+// Reporter creates usage reports.
+type Reporter struct {
+	queries  *store.Queries
+	orClient *openrouter.Client
+}
+
+// NewReporter returns a reporter that prices usage with the models of
+// orClient. Without a client, costs are unknown.
+func NewReporter(queries *store.Queries, orClient *openrouter.Client) *Reporter {
+	return &Reporter{queries: queries, orClient: orClient}
+}
+
+// Report returns the usage of the runs started in the period before now, or
+// in the DefaultPeriod if period is 0.
+func (r *Reporter) Report(ctx context.Context, period time.Duration) (Report, error) {
+	if period <= 0 {
+		period = DefaultPeriod
+	}
+	until := time.Now().UTC().Truncate(time.Second)
+	report := Report{
+		Since: until.Add(-period),
+		Until: until,
+		Total: Usage{Priced: true},
+	}
+
+	rows, err := r.queries.SumRunUsageSince(ctx, report.Since.Format(time.RFC3339))
+	if err != nil {
+		return Report{}, fmt.Errorf("sum run usage: %w", err)
+	}
+	agents, err := r.queries.ListAgents(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("list agents: %w", err)
+	}
+	names := make(map[string]string, len(agents))
+	for _, a := range agents {
+		names[a.ID] = a.Name
+	}
+	prices := Prices(ctx, r.orClient)
+
+	var agent *AgentUsage
+	for _, row := range rows {
+		// Rows are ordered by agent.
+		if agent == nil || agent.AgentID != row.AgentID {
+			report.Agents = append(report.Agents, AgentUsage{
+				AgentID:   row.AgentID,
+				AgentName: names[row.AgentID],
+				Usage:     Usage{Priced: true},
+			})
+			agent = &report.Agents[len(report.Agents)-1]
+		}
+		u := groupUsage(prices, row.Model, row.Status, row.Runs, row.InputTokens, row.OutputTokens, row.ReportedCostUsd, row.UnreportedInputTokens, row.UnreportedOutputTokens)
+		agent.add(u)
+		report.Total.add(u)
+	}
+	return report, nil
+}
+
+// groupUsage returns the usage of a group of runs of a model with the same
+// status. Runs without a reported cost are priced with Cost.
+func groupUsage(prices map[string]openrouter.Model, model, status string, runs, inputTokens, outputTokens int64, reportedCost float64, unreportedInputTokens, unreportedOutputTokens int64) Usage {
+	u := Usage{
+		Runs:         runs,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+	}
+	if status == agentloop.RunStatusFailed || status == agentloop.RunStatusTimedOut {
+		u.FailedRuns = runs
+	}
+	u.CostUSD, u.Priced = Cost(prices, model, unreportedInputTokens, unreportedOutputTokens)
+	u.CostUSD += reportedCost
+	return u
+}
+
+// ConversationReport is the usage of the runs started in a period, per
+// conversation.
+type ConversationReport struct {
+	Since time.Time
+	Until time.Time
+	// Conversations are ordered by cost, then tokens, highest first.
+	Conversations []ConversationUsage
+	Total         Usage
+}
+
+// ConversationUsage is the usage of a conversation's runs. Runs of subagents
+// are in their own conversations.
+type ConversationUsage struct {
+	ConversationID string
+	AgentID        string
+	Usage
+}
+
+// ConversationReport returns the usage of the runs started in the period
+// before now, or in the DefaultPeriod if period is 0, per conversation. If
+// agentID isn't empty, only the agent's conversations are included.
+func (r *Reporter) ConversationReport(ctx context.Context, period time.Duration, agentID string) (ConversationReport, error) {
+	if period <= 0 {
+		period = DefaultPeriod
+	}
+	until := time.Now().UTC().Truncate(time.Second)
+	report := ConversationReport{
+		Since: until.Add(-period),
+		Until: until,
+		Total: Usage{Priced: true},
+	}
+
+	rows, err := r.queries.SumConversationRunUsageSince(ctx, report.Since.Format(time.RFC3339))
+	if err != nil {
+		return ConversationReport{}, fmt.Errorf("sum run usage: %w", err)
+	}
+	prices := Prices(ctx, r.orClient)
+
+	var conv *ConversationUsage
+	for _, row := range rows {
+		if agentID != "" && row.AgentID != agentID {
+			continue
+		}
+		// Rows are ordered by conversation.
+		if conv == nil || conv.ConversationID != row.ConversationID {
+			report.Conversations = append(report.Conversations, ConversationUsage{
+				ConversationID: row.ConversationID,
+				AgentID:        row.AgentID,
+				Usage:          Usage{Priced: true},
+			})
+			conv = &report.Conversations[len(report.Conversations)-1]
+		}
+		u := groupUsage(prices, row.Model, row.Status, row.Runs, row.InputTokens, row.OutputTokens, row.ReportedCostUsd, row.UnreportedInputTokens, row.UnreportedOutputTokens)
+		conv.add(u)
+		report.Total.add(u)
+	}
+	slices.SortStableFunc(report.Conversations, func(a, b ConversationUsage) int {
+		return cmp.Or(
+			cmp.Compare(b.CostUSD, a.CostUSD),
+			cmp.Compare(b.InputTokens+b.OutputTokens, a.InputTokens+a.OutputTokens),
+		)
+	})
+	return report, nil
+}
+
+// RunCost returns the cost in US dollars of a run: the cost reported by the
+// LLM provider if there is one, or else its estimate with Cost.
+func RunCost(prices map[string]openrouter.Model, model string, inputTokens, outputTokens int64, reported sql.NullFloat64) (float64, bool) {
+	if reported.Valid {
+		return reported.Float64, true
+	}
+	return Cost(prices, model, inputTokens, outputTokens)
+}
+
+// UsageReport returns the report of the period as text, for the
+// get_usage_report tool. A period of 0 is the DefaultPeriod.
+func (r *Reporter) UsageReport(ctx context.Context, period time.Duration) (string, error) {
+	report, err := r.Report(ctx, period)
+	if err != nil {
+		return "", err
+	}
+	return report.String(), nil
+}
+
+// String formats the report as a plain text summary.
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Usage from %s to %s\n\n", r.Since.Format(time.RFC3339), r.Until.Format(time.RFC3339))
+	if len(r.Agents) == 0 {
+		b.WriteString("No runs.\n")
+		return b.String()
+	}
+	for _, a := range r.Agents {
+		fmt.Fprintf(&b, "- %s (%s): %s\n", a.AgentName, a.AgentID, a.Usage)
+	}
+	fmt.Fprintf(&b, "\nTotal: %s\n", r.Total)
+	return b.String()
+}
+
+// String formats the usage as a one-line summary.
+func (u Usage) String() string {
+	cost := fmt.Sprintf("$%.4f", u.CostUSD)
+	if !u.Priced {
+		cost = "at least " + cost + " (some models have unknown prices)"
+	}
+	return fmt.Sprintf("%d runs, %d failed, %d input and %d output tokens, %s", u.Runs, u.FailedRuns, u.InputTokens, u.OutputTokens, cost)
+}
+
+// Prices returns the models of orClient by ID, for pricing usage with Cost.
+// Costs are estimates, so if the models can't be listed there are no prices
+// rather than an error.
+func Prices(ctx context.Context, orClient *openrouter.Client) map[string]openrouter.Model {
+	prices := make(map[string]openrouter.Model)
+	if orClient == nil {
+		return prices
+	}
+	models, err := orClient.ListModels(ctx)
+	if err != nil {
+		slog.Warn("failed to list models for pricing", "error", err)
+	}
+	for _, m := range models {
+		prices[m.ID] = m
+	}
+	return prices
+}
+
+// Cost returns the cost in US dollars of the token usage of a model. It
+// reports false if the model has no known price. Usage without tokens, e.g.
+// of runs that failed before calling the LLM, costs nothing.
+func Cost(prices map[string]openrouter.Model, model string, inputTokens, outputTokens int64) (float64, bool) {
+	if inputTokens == 0 && outputTokens == 0 {
+		return 0, true
+	}
+	m, ok := prices[model]
+	if !ok {
+		return 0, false
+	}
+	return m.Cost(inputTokens, outputTokens)
+}
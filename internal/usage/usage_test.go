@@ -0,0 +1,158 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC()
+	for _, id := range []string{"agent-1", "agent-2"} {
+		if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{
+			ID: id, Name: "Agent " + id, EnabledTools: "[]", EnabledNotificationChannels: "[]",
+			EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]",
+			CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{
+			ID: "conv-" + id, AgentID: id, CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs := []struct {
+		id, agentID, status string
+		startedAt           time.Time
+		input, output       int64
+	}{
+		{"run-1", "agent-1", "completed", now.Add(-time.Hour), 100, 10},
+		{"run-2", "agent-1", "failed", now.Add(-2 * time.Hour), 50, 0},
+		{"run-3", "agent-2", "timed_out", now.Add(-3 * time.Hour), 0, 0},
+		// Before the period.
+		{"run-4", "agent-2", "completed", now.Add(-48 * time.Hour), 1000, 100},
+	}
+	for _, r := range runs {
+		if err := queries.CreateRunTrace(ctx, store.CreateRunTraceParams{
+			RunID: r.id, AgentID: r.agentID, ConversationID: "conv-" + r.agentID, Kind: "interactive",
+			Model: "test/model", Status: r.status, InputTokens: r.input, OutputTokens: r.output, Trace: "{}",
+			StartedAt: r.startedAt.Format(time.RFC3339), FinishedAt: r.startedAt.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := NewReporter(queries, nil).Report(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Agents) != 2 {
+		t.Fatalf("agents = %+v, want 2", report.Agents)
+	}
+	a1, a2 := report.Agents[0], report.Agents[1]
+	if a1.AgentName != "Agent agent-1" || a1.Runs != 2 || a1.FailedRuns != 1 || a1.InputTokens != 150 || a1.OutputTokens != 10 || a1.Priced {
+		t.Errorf("agent-1 usage = %+v, want 2 runs, 1 failed, 150/10 tokens, unpriced", a1)
+	}
+	// Runs without tokens cost nothing, even with unknown prices.
+	if a2.Runs != 1 || a2.FailedRuns != 1 || a2.InputTokens != 0 || !a2.Priced {
+		t.Errorf("agent-2 usage = %+v, want 1 failed run without tokens, priced", a2)
+	}
+	if report.Total.Runs != 3 || report.Total.FailedRuns != 2 || report.Total.InputTokens != 150 {
+		t.Errorf("total = %+v, want 3 runs, 2 failed, 150 input tokens", report.Total)
+	}
+	if s := report.String(); !strings.Contains(s, "Agent agent-1 (agent-1): 2 runs, 1 failed") || !strings.Contains(s, "at least $0.0000") {
+		t.Errorf("report text = %q", s)
+	}
+}
+
+func TestConversationReport(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC()
+	for _, id := range []string{"agent-1", "agent-2"} {
+		if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{
+			ID: id, Name: "Agent " + id, EnabledTools: "[]", EnabledNotificationChannels: "[]",
+			EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]",
+			CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []struct{ id, agentID string }{{"conv-1", "agent-1"}, {"conv-2", "agent-1"}, {"conv-3", "agent-2"}} {
+		if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{
+			ID: c.id, AgentID: c.agentID, CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs := []struct {
+		id, convID, agentID string
+		input, output       int64
+		cost                sql.NullFloat64
+	}{
+		{"run-1", "conv-1", "agent-1", 100, 10, sql.NullFloat64{Float64: 0.01, Valid: true}},
+		{"run-2", "conv-2", "agent-1", 200, 20, sql.NullFloat64{Float64: 0.02, Valid: true}},
+		{"run-3", "conv-2", "agent-1", 300, 30, sql.NullFloat64{Float64: 0.03, Valid: true}},
+		{"run-4", "conv-3", "agent-2", 400, 40, sql.NullFloat64{}},
+	}
+	for _, r := range runs {
+		if err := queries.CreateRunTrace(ctx, store.CreateRunTraceParams{
+			RunID: r.id, AgentID: r.agentID, ConversationID: r.convID, Kind: "interactive",
+			Model: "test/model", Status: "completed", InputTokens: r.input, OutputTokens: r.output, CostUsd: r.cost, Trace: "{}",
+			StartedAt: now.Format(time.RFC3339), FinishedAt: now.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := NewReporter(queries, nil)
+	report, err := r.ConversationReport(ctx, 0, "agent-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Reported costs don't need a known price.
+	if len(report.Conversations) != 2 || report.Conversations[0].ConversationID != "conv-2" {
+		t.Fatalf("conversations = %+v, want conv-2 first", report.Conversations)
+	}
+	if c := report.Conversations[0]; c.Runs != 2 || c.InputTokens != 500 || c.OutputTokens != 50 || !c.Priced || c.CostUSD < 0.0499 || c.CostUSD > 0.0501 {
+		t.Errorf("conv-2 usage = %+v, want 2 runs, 500/50 tokens, priced at $0.05", c)
+	}
+	if !report.Total.Priced || report.Total.Runs != 3 {
+		t.Errorf("total = %+v, want 3 priced runs", report.Total)
+	}
+
+	// Runs without a reported cost are estimated, and unknown prices leave
+	// them unpriced.
+	report, err = r.ConversationReport(ctx, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Conversations) != 3 || report.Total.Priced || report.Total.Runs != 4 {
+		t.Errorf("report = %+v, want 3 conversations with 4 runs, unpriced", report)
+	}
+
+	cost, ok := RunCost(nil, "test/model", 100, 10, sql.NullFloat64{Float64: 0.5, Valid: true})
+	if cost != 0.5 || !ok {
+		t.Errorf("RunCost with reported cost = %v, %v, want 0.5, true", cost, ok)
+	}
+}
@@ -0,0 +1,198 @@
+// Package version reports the version of the running binary, and checks
+// GitHub releases for newer versions.
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Version is the version of the binary, injected at build time with:
+//
+//	go build -ldflags "-X github.com/dstotijn/blippy/internal/version.Version=v1.2.3"
+//
+// Without it, Get falls back to the module version of the build info.
+var Version string
+
+// DefaultReleasesURL is the GitHub API endpoint of the latest release.
+const DefaultReleasesURL = "https://api.github.com/repos/dstotijn/blippy/releases/latest"
+
+// Get returns the version of the binary, or "(devel)" if it's unknown.
+func Get() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+// Release is the latest release, as last checked.
+type Release struct {
+	Version   string
+	URL       string
+	CheckedAt time.Time
+}
+
+// Checker periodically checks for a newer release than the running version.
+type Checker struct {
+	current    string
+	url        string
+	httpClient *http.Client
+	logger     *slog.Logger
+
+	mu     sync.Mutex
+	latest Release
+}
+
+// NewChecker creates a Checker of releases newer than current, using the
+// GitHub releases endpoint at url.
+func NewChecker(current, url string, logger *slog.Logger) *Checker {
+	return &Checker{
+		current:    current,
+		url:        url,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		logger:     logger,
+	}
+}
+
+// Run checks for a newer release now and every interval until ctx is
+// cancelled, logging when one is available.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		release, err := c.Check(ctx)
+		switch {
+		case err != nil:
+			c.logger.Warn("failed to check for updates", "error", err)
+		case c.UpdateAvailable():
+			c.logger.Info("update available", "version", c.current, "latest", release.Version, "url", release.URL)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check fetches the latest release, and remembers it.
+func (c *Checker) Check(ctx context.Context) (Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return Release{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "blippy/"+c.current)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Release{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Release{}, fmt.Errorf("decode release: %w", err)
+	}
+	if body.TagName == "" {
+		return Release{}, fmt.Errorf("release has no tag")
+	}
+
+	release := Release{Version: body.TagName, URL: body.HTMLURL, CheckedAt: time.Now().UTC()}
+	c.mu.Lock()
+	c.latest = release
+	c.mu.Unlock()
+	return release, nil
+}
+
+// Latest returns the latest release, as last checked. It's zero if no check
+// has succeeded yet.
+func (c *Checker) Latest() Release {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latest
+}
+
+// UpdateAvailable reports whether the latest release is newer than the
+// running version. Development builds are never out of date.
+func (c *Checker) UpdateAvailable() bool {
+	latest := c.Latest()
+	return latest.Version != "" && Newer(latest.Version, c.current)
+}
+
+// Newer reports whether semantic version a is newer than b. Versions are
+// "vMAJOR.MINOR.PATCH", optionally with a pre-release suffix. It's false if
+// either isn't a valid version.
+func Newer(a, b string) bool {
+	va, ok := parse(a)
+	if !ok {
+		return false
+	}
+	vb, ok := parse(b)
+	if !ok {
+		return false
+	}
+	for i := range 3 {
+		if va.nums[i] != vb.nums[i] {
+			return va.nums[i] > vb.nums[i]
+		}
+	}
+	// A release is newer than its pre-releases.
+	switch {
+	case va.pre == vb.pre:
+		return false
+	case va.pre == "":
+		return true
+	case vb.pre == "":
+		return false
+	default:
+		return va.pre > vb.pre
+	}
+}
+
+type semver struct {
+	nums [3]int
+	pre  string
+}
+
+func parse(v string) (semver, bool) {
+	v, ok := strings.CutPrefix(v, "v")
+	if !ok {
+		return semver{}, false
+	}
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return semver{}, false
+	}
+	var s semver
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		s.nums[i] = n
+	}
+	s.pre = pre
+	return s, true
+}
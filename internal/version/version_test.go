@@ -0,0 +1,67 @@
+package version
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.2.3", "v1.2.2", true},
+		{"v1.10.0", "v1.9.9", true},
+		{"v2.0.0", "v1.99.99", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.2.2", "v1.2.3", false},
+		{"v1.2.3", "v1.2.3-rc.1", true},
+		{"v1.2.3-rc.2", "v1.2.3-rc.1", true},
+		{"v1.2.3-rc.1", "v1.2.3", false},
+		{"v1.2.3", "(devel)", false},
+		{"v1.2.3", "v0.0.0-20250101000000-abcdef123456", true},
+		{"latest", "v1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.a, tt.b); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestChecker(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); !strings.HasPrefix(got, "blippy/v1.") {
+			t.Errorf("User-Agent = %q", got)
+		}
+		w.Write([]byte(`{"tag_name": "v1.1.0", "html_url": "https://github.com/dstotijn/blippy/releases/tag/v1.1.0"}`))
+	}))
+	defer srv.Close()
+
+	c := NewChecker("v1.0.0", srv.URL, slog.Default())
+	if c.UpdateAvailable() {
+		t.Error("update available before checking")
+	}
+	release, err := c.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release.Version != "v1.1.0" || release.URL == "" || release.CheckedAt.IsZero() {
+		t.Errorf("release = %+v", release)
+	}
+	if !c.UpdateAvailable() {
+		t.Error("update not available")
+	}
+
+	c = NewChecker("v1.1.0", srv.URL, slog.Default())
+	if _, err := c.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if c.UpdateAvailable() {
+		t.Error("update available for the latest version")
+	}
+}
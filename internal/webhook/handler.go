@@ -2,25 +2,33 @@ package webhook
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/auth"
+	"github.com/dstotijn/blippy/internal/maintenance"
 	"github.com/dstotijn/blippy/internal/runner"
 	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
 )
 
 // Handler handles incoming webhook requests that trigger agent runs.
 type Handler struct {
 	queries *store.Queries
 	runner  *runner.Runner
+	maint   *maintenance.Mode
 	logger  *slog.Logger
 }
 
 // New creates a new webhook Handler.
-func New(queries *store.Queries, runner *runner.Runner, logger *slog.Logger) *Handler {
+func New(queries *store.Queries, runner *runner.Runner, maint *maintenance.Mode, logger *slog.Logger) *Handler {
 	return &Handler{
 		queries: queries,
 		runner:  runner,
+		maint:   maint,
 		logger:  logger,
 	}
 }
@@ -29,21 +37,41 @@ func New(queries *store.Queries, runner *runner.Runner, logger *slog.Logger) *Ha
 type TriggerRequest struct {
 	AgentID string `json:"agent_id"`
 	Prompt  string `json:"prompt"`
+	// OutputSchema is an optional JSON Schema for the agent's final answer,
+	// which is then returned parsed as TriggerResponse.Output.
+	OutputSchema json.RawMessage `json:"output_schema,omitempty"`
+	// Priority optionally overrides the default priority of webhook runs
+	// waiting for the agent's concurrency limit, from 1 to
+	// agentloop.MaxPriority.
+	Priority int `json:"priority,omitempty"`
+	// Vars are variables of the run, such as the branch of a CI build, set
+	// as environment variables of the agent's bash commands and replacing
+	// "{{NAME}}" placeholders in the prompt.
+	Vars map[string]string `json:"vars,omitempty"`
 }
 
 // TriggerResponse is returned after triggering an agent.
 type TriggerResponse struct {
-	ConversationID string `json:"conversation_id"`
-	Response       string `json:"response"`
+	ConversationID string          `json:"conversation_id"`
+	Response       string          `json:"response"`
+	Output         json.RawMessage `json:"output,omitempty"`
 }
 
-// ServeHTTP handles POST /webhooks/trigger requests.
+// ServeHTTP handles POST /webhooks/trigger requests. The endpoint is
+// deprecated and only served with LEGACY_TRIGGER_WEBHOOK=1: trigger webhooks
+// (TriggerWebhookHandler) run a single trigger instead of any agent and
+// prompt.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	if err := h.maint.Err(); err != nil {
+		unavailable(w, err)
+		return
+	}
+
 	var req TriggerRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
@@ -60,6 +88,28 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.Priority < 0 || req.Priority > agentloop.MaxPriority {
+		http.Error(w, fmt.Sprintf("priority must be between 0 and %d", agentloop.MaxPriority), http.StatusBadRequest)
+		return
+	}
+
+	if err := tool.ValidateRunVars(req.Vars); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.OutputSchema) > 0 {
+		if err := runner.ValidateOutputSchema(req.OutputSchema); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !auth.AllowsAgent(r.Context(), req.AgentID) {
+		http.Error(w, "API key isn't allowed to run this agent", http.StatusForbidden)
+		return
+	}
+
 	// Verify agent exists
 	_, err := h.queries.GetAgent(r.Context(), req.AgentID)
 	if err != nil {
@@ -73,7 +123,18 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		AgentID: req.AgentID,
 		Prompt:  req.Prompt,
 		Depth:   0,
+		Kind:    agentloop.RunKindWebhook,
+
+		Priority: req.Priority,
+		Vars:     req.Vars,
+
+		OutputSchema: req.OutputSchema,
 	})
+	if errors.Is(err, runner.ErrInvalidOutput) {
+		h.logger.Warn("webhook trigger returned invalid output", "agent_id", req.AgentID, "conversation_id", result.ConversationID, "error", err)
+		http.Error(w, "Agent run failed: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
 	if err != nil {
 		h.logger.Error("webhook trigger failed", "agent_id", req.AgentID, "error", err)
 		http.Error(w, "Agent run failed: "+err.Error(), http.StatusInternalServerError)
@@ -85,8 +146,16 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	resp := TriggerResponse{
 		ConversationID: result.ConversationID,
 		Response:       result.Response,
+		Output:         result.Output,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
+
+// unavailable rejects a request during maintenance, asking the client to
+// retry later.
+func unavailable(w http.ResponseWriter, err error) {
+	w.Header().Set("Retry-After", "60")
+	http.Error(w, err.Error(), http.StatusServiceUnavailable)
+}
@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/dstotijn/blippy/internal/auth"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// ReplyHandler routes replies to delivered notifications back into the
+// conversation that sent them, as a new user message. Receivers of HTTP
+// notifications post replies with the notification's ID; replies on Telegram
+// and Slack reach their channel's webhook instead, see TelegramHandler and
+// SlackHandler.
+type ReplyHandler struct {
+	queries *store.Queries
+	runner  *runner.Runner
+	maint   *maintenance.Mode
+	logger  *slog.Logger
+}
+
+// NewReplyHandler creates a new ReplyHandler.
+func NewReplyHandler(queries *store.Queries, runner *runner.Runner, maint *maintenance.Mode, logger *slog.Logger) *ReplyHandler {
+	return &ReplyHandler{
+		queries: queries,
+		runner:  runner,
+		maint:   maint,
+		logger:  logger,
+	}
+}
+
+// ReplyRequest is the expected payload for notification reply requests.
+// NotificationID is the value of the X-Blippy-Notification-ID header sent
+// with the original notification.
+type ReplyRequest struct {
+	NotificationID string `json:"notification_id"`
+	Text           string `json:"text"`
+}
+
+// ReplyResponse is returned after the agent has handled a reply.
+type ReplyResponse struct {
+	ConversationID string `json:"conversation_id"`
+	Response       string `json:"response"`
+}
+
+// ServeHTTP handles POST /webhooks/notification-reply requests, authenticated
+// with an API key with the webhook scope.
+func (h *ReplyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.maint.Err(); err != nil {
+		unavailable(w, err)
+		return
+	}
+
+	var req ReplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.NotificationID == "" {
+		http.Error(w, "notification_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.Text == "" {
+		http.Error(w, "text is required", http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.queries.GetNotificationDelivery(r.Context(), req.NotificationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Notification not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get notification delivery", "notification_id", req.NotificationID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	conv, prompt, err := replyTarget(r.Context(), h.queries, delivery, req.Text)
+	if errors.Is(err, errNoConversation) {
+		http.Error(w, "Notification has no originating conversation", http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to get notification reply target", "notification_id", req.NotificationID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !auth.AllowsAgent(r.Context(), conv.AgentID) {
+		http.Error(w, "API key isn't allowed to run this agent", http.StatusForbidden)
+		return
+	}
+
+	convID := conv.ID
+	result, err := h.runner.Continue(r.Context(), convID, prompt)
+	if err != nil {
+		if errors.Is(err, runner.ErrConversationBusy) {
+			http.Error(w, "Conversation is already processing", http.StatusConflict)
+			return
+		}
+		h.logger.Error("notification reply failed", "conversation_id", convID, "error", err)
+		http.Error(w, "Agent run failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("notification reply completed", "notification_id", req.NotificationID, "conversation_id", convID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ReplyResponse{
+		ConversationID: result.ConversationID,
+		Response:       result.Response,
+	})
+}
+
+// errNoConversation is returned for notifications that weren't sent from a
+// conversation, or whose conversation was deleted.
+var errNoConversation = errors.New("notification has no originating conversation")
+
+// replyTarget returns the conversation a notification was sent from, and the
+// prompt a reply to the notification continues it with.
+func replyTarget(ctx context.Context, queries *store.Queries, delivery store.NotificationDelivery, text string) (store.Conversation, string, error) {
+	if !delivery.ConversationID.Valid {
+		return store.Conversation{}, "", errNoConversation
+	}
+	conv, err := queries.GetConversation(ctx, delivery.ConversationID.String)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.Conversation{}, "", errNoConversation
+	}
+	if err != nil {
+		return store.Conversation{}, "", fmt.Errorf("get conversation: %w", err)
+	}
+	channel, err := queries.GetNotificationChannel(ctx, delivery.ChannelID)
+	if err != nil {
+		return store.Conversation{}, "", fmt.Errorf("get notification channel: %w", err)
+	}
+	prompt := fmt.Sprintf("Reply to your notification on the %q channel:\n\n%s", channel.Name, text)
+	return conv, prompt, nil
+}
+
+// chatReply is a reply to a notification that was sent as a chat message.
+type chatReply struct {
+	DeliveryID     string
+	ConversationID string
+	Prompt         string
+}
+
+// lookupChatReply returns the reply a chat message with text is, if the
+// message it replies to was sent by a notification from a conversation.
+// Otherwise it returns nil.
+func lookupChatReply(ctx context.Context, queries *store.Queries, channelID, chatID, repliedToID, text string) (*chatReply, error) {
+	msg, err := queries.GetNotificationMessage(ctx, store.GetNotificationMessageParams{
+		ChannelID: channelID,
+		ChatID:    chatID,
+		MessageID: repliedToID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get notification message: %w", err)
+	}
+	delivery, err := queries.GetNotificationDelivery(ctx, msg.DeliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("get notification delivery: %w", err)
+	}
+	conv, prompt, err := replyTarget(ctx, queries, delivery, text)
+	if errors.Is(err, errNoConversation) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &chatReply{DeliveryID: delivery.ID, ConversationID: conv.ID, Prompt: prompt}, nil
+}
@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// Signature schemes of trigger webhooks: how senders sign request bodies
+// with the webhook's secret.
+const (
+	// SchemeGitHub is an HMAC-SHA256 of the body in X-Hub-Signature-256, as
+	// "sha256=<hex>". It's the default.
+	SchemeGitHub = "github"
+	// SchemeStripe is Stripe's Stripe-Signature header, with a timestamp and
+	// HMAC-SHA256 of "<timestamp>.<body>".
+	SchemeStripe = "stripe"
+	// SchemeBlippy is the X-Blippy-Signature header of notification
+	// channels with a signing secret, see tool.SignPayload.
+	SchemeBlippy = "blippy"
+)
+
+// signatureTolerance is how old the timestamp of a signed request may be,
+// for schemes that sign one.
+const signatureTolerance = 5 * time.Minute
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// ValidateSignatureScheme checks that a scheme is known. Empty is the
+// default, SchemeGitHub.
+func ValidateSignatureScheme(scheme string) error {
+	switch scheme {
+	case "", SchemeGitHub, SchemeStripe, SchemeBlippy:
+		return nil
+	}
+	return fmt.Errorf("unknown signature scheme %q, must be %q, %q or %q", scheme, SchemeGitHub, SchemeStripe, SchemeBlippy)
+}
+
+// verifySignature checks the signature of a request body made with secret.
+func verifySignature(scheme, secret string, header http.Header, body []byte, now time.Time) error {
+	switch scheme {
+	case "", SchemeGitHub:
+		return verifyHMAC(secret, header.Get("X-Hub-Signature-256"), "", body)
+	case SchemeStripe:
+		var timestamp string
+		var sigs []string
+		for part := range strings.SplitSeq(header.Get("Stripe-Signature"), ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch key {
+			case "t":
+				timestamp = value
+			case "v1":
+				sigs = append(sigs, "sha256="+value)
+			}
+		}
+		if err := checkTimestamp(timestamp, now); err != nil {
+			return err
+		}
+		for _, sig := range sigs {
+			if verifyHMAC(secret, sig, timestamp, body) == nil {
+				return nil
+			}
+		}
+		return errInvalidSignature
+	case SchemeBlippy:
+		timestamp := header.Get(tool.SignatureTimestampHeader)
+		if err := checkTimestamp(timestamp, now); err != nil {
+			return err
+		}
+		return verifyHMAC(secret, header.Get(tool.DefaultSignatureHeader), timestamp, body)
+	}
+	return ValidateSignatureScheme(scheme)
+}
+
+// verifyHMAC compares a "sha256=<hex>" signature with the HMAC-SHA256 of the
+// body, prefixed with "<timestamp>." if there's a timestamp.
+func verifyHMAC(secret, signature, timestamp string, body []byte) error {
+	var want string
+	if timestamp != "" {
+		want = tool.SignPayload(secret, timestamp, body)
+	} else {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		want = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// checkTimestamp rejects missing and stale Unix timestamps, so signed
+// requests can't be replayed later.
+func checkTimestamp(timestamp string, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errInvalidSignature
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > signatureTolerance || d < -signatureTolerance {
+		return errors.New("signature timestamp is too old")
+	}
+	return nil
+}
+
+// ParseAllowedIPs parses the IP addresses and CIDR ranges requests to a
+// trigger webhook must come from.
+func ParseAllowedIPs(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if strings.Contains(v, "/") {
+			p, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR range %q", v)
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address %q", v)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ipAllowed reports whether ip is in one of the prefixes, or whether there
+// are none.
+func ipAllowed(prefixes []netip.Prefix, ip string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewToken returns a random trigger webhook token and the hash it's stored
+// by.
+func NewToken() (token, hash string) {
+	b := make([]byte, 32)
+	rand.Read(b) // never returns an error
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token)
+}
+
+// hashToken returns the hex-encoded SHA-256 hash of a token. Tokens are
+// 256-bit random values, so a fast unsalted hash is sufficient.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"action":"opened"}`)
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	staleTS := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	github := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	stripe := func(ts string) string {
+		sig := tool.SignPayload("s3cret", ts, body)[len("sha256="):]
+		return fmt.Sprintf("t=%s,v1=deadbeef,v1=%s", ts, sig)
+	}
+
+	tests := []struct {
+		name    string
+		scheme  string
+		header  http.Header
+		wantErr bool
+	}{
+		{"github", "", http.Header{"X-Hub-Signature-256": {github}}, false},
+		{"github wrong secret", SchemeGitHub, http.Header{"X-Hub-Signature-256": {"sha256=00"}}, true},
+		{"github missing", SchemeGitHub, http.Header{}, true},
+		{"stripe", SchemeStripe, http.Header{"Stripe-Signature": {stripe(ts)}}, false},
+		{"stripe stale", SchemeStripe, http.Header{"Stripe-Signature": {stripe(staleTS)}}, true},
+		{"blippy", SchemeBlippy, http.Header{
+			tool.DefaultSignatureHeader:   {tool.SignPayload("s3cret", ts, body)},
+			tool.SignatureTimestampHeader: {ts},
+		}, false},
+		{"blippy other timestamp", SchemeBlippy, http.Header{
+			tool.DefaultSignatureHeader:   {tool.SignPayload("s3cret", ts, body)},
+			tool.SignatureTimestampHeader: {strconv.FormatInt(now.Unix()+1, 10)},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySignature(tt.scheme, "s3cret", tt.header, body, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllowedIPs(t *testing.T) {
+	prefixes, err := ParseAllowedIPs([]string{"192.30.252.0/22", "2001:db8::1"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for ip, want := range map[string]bool{
+		"192.30.253.10":       true,
+		"::ffff:192.30.254.1": true,
+		"192.30.0.1":          false,
+		"2001:db8::1":         true,
+		"2001:db8::2":         false,
+		"not an ip":           false,
+	} {
+		if got := ipAllowed(prefixes, ip); got != want {
+			t.Errorf("ipAllowed(%s) = %v, want %v", ip, got, want)
+		}
+	}
+	if !ipAllowed(nil, "10.0.0.1") {
+		t.Error("no allowed IPs should allow all")
+	}
+	if _, err := ParseAllowedIPs([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("invalid range accepted")
+	}
+}
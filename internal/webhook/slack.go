@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// SlackPath is the path of Slack channel webhooks, followed by the channel
+// ID. It's the request URL of the Slack app's event subscriptions.
+const SlackPath = "/webhooks/slack/"
+
+// SlackHandler receives the events of the Slack app of a Slack channel.
+// Messages in the thread of a notification continue the conversation that
+// sent the notification, and the answer is posted in the thread.
+type SlackHandler struct {
+	queries *store.Queries
+	cipher  *encryption.Cipher
+	runner  *runner.Runner
+	maint   *maintenance.Mode
+	logger  *slog.Logger
+}
+
+// NewSlackHandler creates a new SlackHandler. The cipher decrypts channel
+// configs and may be nil if they're stored as plaintext.
+func NewSlackHandler(queries *store.Queries, cipher *encryption.Cipher, runner *runner.Runner, maint *maintenance.Mode, logger *slog.Logger) *SlackHandler {
+	return &SlackHandler{
+		queries: queries,
+		cipher:  cipher,
+		runner:  runner,
+		maint:   maint,
+		logger:  logger,
+	}
+}
+
+// slackEvent is the part of a Slack Events API request the handler uses.
+type slackEvent struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Event     struct {
+		Type     string `json:"type"`
+		Subtype  string `json:"subtype"`
+		BotID    string `json:"bot_id"`
+		Channel  string `json:"channel"`
+		Text     string `json:"text"`
+		TS       string `json:"ts"`
+		ThreadTS string `json:"thread_ts"`
+	} `json:"event"`
+}
+
+// ServeHTTP handles POST /webhooks/slack/{channelID} requests. It responds
+// right away and answers replies in the background, as Slack retries events
+// that aren't acknowledged within 3 seconds.
+func (h *SlackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.maint.Err(); err != nil {
+		unavailable(w, err)
+		return
+	}
+
+	channel, err := h.queries.GetNotificationChannel(r.Context(), r.PathValue("channelID"))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && channel.Type != tool.SlackChannelType) {
+		http.Error(w, "Channel not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to get notification channel", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	config, err := h.cipher.Decrypt(channel.Config)
+	if err != nil {
+		h.logger.Error("failed to decrypt channel config", "channel_id", channel.ID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	cfg, err := tool.ParseSlackConfig(config)
+	if err != nil || cfg.SigningSecret == "" {
+		http.Error(w, "Channel doesn't accept events", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		return
+	}
+	if err := verifySlackSignature(cfg.SigningSecret, r.Header, body, time.Now()); err != nil {
+		h.logger.Warn("slack webhook request with invalid signature", "channel_id", channel.ID, "error", err)
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event slackEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	switch event.Type {
+	case "url_verification":
+		// Sent when the request URL is configured in the Slack app.
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, event.Challenge)
+		return
+	case "event_callback":
+	default:
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Retries are of events that are already being answered.
+	if r.Header.Get("X-Slack-Retry-Num") != "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Only messages of people in the thread of a message are replies. The
+	// bot's own messages and edits have a bot ID or subtype.
+	m := event.Event
+	if m.Type != "message" || m.Subtype != "" || m.BotID != "" || m.ThreadTS == "" || m.ThreadTS == m.TS || strings.TrimSpace(m.Text) == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	reply, err := lookupChatReply(r.Context(), h.queries, channel.ID, m.Channel, m.ThreadTS, m.Text)
+	if err != nil {
+		h.logger.Error("failed to look up slack reply", "channel_id", channel.ID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if reply != nil {
+		go h.handleReply(context.WithoutCancel(r.Context()), channel.ID, cfg, m.Channel, m.ThreadTS, reply)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleReply continues the conversation of a notification with a reply to
+// it, and posts the answer in the notification's thread.
+func (h *SlackHandler) handleReply(ctx context.Context, channelID string, cfg tool.SlackConfig, slackChannel, threadTS string, reply *chatReply) {
+	logger := h.logger.With("channel_id", channelID, "slack_channel", slackChannel, "conversation_id", reply.ConversationID)
+	send := func(text string) {
+		if err := tool.SendSlackMessage(ctx, cfg.BotToken, slackChannel, threadTS, text); err != nil {
+			logger.Error("failed to post slack message", "error", err)
+		}
+	}
+
+	result, err := h.runner.Continue(ctx, reply.ConversationID, reply.Prompt)
+	if errors.Is(err, runner.ErrConversationBusy) {
+		send("I'm still working on something else in this conversation. Try again in a moment.")
+		return
+	}
+	if err != nil {
+		logger.Error("slack notification reply failed", "error", err)
+		send("Sorry, I couldn't answer that reply.")
+		return
+	}
+
+	logger.Info("slack notification reply answered")
+	if strings.TrimSpace(result.Response) != "" {
+		send(result.Response)
+	}
+}
+
+// verifySlackSignature checks the X-Slack-Signature header of a request, an
+// HMAC-SHA256 of "v0:<timestamp>:<body>" made with the app's signing secret.
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	if err := checkTimestamp(timestamp, now); err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	want := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(header.Get("X-Slack-Signature")), []byte(want)) {
+		return errInvalidSignature
+	}
+	return nil
+}
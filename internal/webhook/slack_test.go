@@ -0,0 +1,226 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// TestSlackHandler checks the requests that are rejected or ignored before
+// a reply is answered.
+func TestSlackHandler(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, c := range []store.CreateNotificationChannelParams{
+		{ID: "slack", Name: "slack", Type: "slack", Config: `{"bot_token": "xoxb-1", "channel": "C1", "signing_secret": "s3cret"}`},
+		{ID: "notify-only", Name: "notify-only", Type: "slack", Config: `{"bot_token": "xoxb-1", "channel": "C1"}`},
+		{ID: "sms", Name: "sms", Type: "sms", Config: `{}`},
+	} {
+		c.QuietHoursMode, c.CreatedAt, c.UpdatedAt = "defer", now, now
+		if _, err := queries.CreateNotificationChannel(ctx, c); err != nil {
+			t.Fatalf("create channel: %v", err)
+		}
+	}
+
+	// A notification without a conversation, whose replies aren't routed.
+	if err := queries.CreateNotificationDelivery(ctx, store.CreateNotificationDeliveryParams{ID: "d1", ChannelID: "slack", DeliveredAt: now}); err != nil {
+		t.Fatalf("create delivery: %v", err)
+	}
+	if err := queries.CreateNotificationMessage(ctx, store.CreateNotificationMessageParams{ChannelID: "slack", ChatID: "C1", MessageID: "1.000", DeliveryID: "d1"}); err != nil {
+		t.Fatalf("create notification message: %v", err)
+	}
+
+	h := NewSlackHandler(queries, nil, nil, &maintenance.Mode{}, slog.New(slog.DiscardHandler))
+	mux := http.NewServeMux()
+	mux.Handle("POST "+SlackPath+"{channelID}", h)
+
+	sign := func(secret, body string) http.Header {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + ts + ":" + body))
+		header := http.Header{}
+		header.Set("X-Slack-Request-Timestamp", ts)
+		header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		return header
+	}
+
+	tests := []struct {
+		name     string
+		channel  string
+		secret   string
+		body     string
+		want     int
+		wantBody string
+	}{
+		{"unknown channel", "nope", "s3cret", `{}`, http.StatusNotFound, ""},
+		{"other channel type", "sms", "s3cret", `{}`, http.StatusNotFound, ""},
+		{"channel without signing secret", "notify-only", "s3cret", `{}`, http.StatusNotFound, ""},
+		{"bad signature", "slack", "guess", `{"type": "url_verification", "challenge": "abc"}`, http.StatusUnauthorized, ""},
+		{"url verification", "slack", "s3cret", `{"type": "url_verification", "challenge": "abc"}`, http.StatusOK, "abc"},
+		{"message outside thread", "slack", "s3cret", `{"type": "event_callback", "event": {"type": "message", "channel": "C1", "text": "Hi", "ts": "2.000"}}`, http.StatusOK, ""},
+		{"bot message", "slack", "s3cret", `{"type": "event_callback", "event": {"type": "message", "bot_id": "B1", "channel": "C1", "text": "Hi", "ts": "2.000", "thread_ts": "1.000"}}`, http.StatusOK, ""},
+		{"reply to other thread", "slack", "s3cret", `{"type": "event_callback", "event": {"type": "message", "channel": "C1", "text": "Hi", "ts": "2.000", "thread_ts": "0.500"}}`, http.StatusOK, ""},
+		{"reply without conversation", "slack", "s3cret", `{"type": "event_callback", "event": {"type": "message", "channel": "C1", "text": "Hi", "ts": "2.000", "thread_ts": "1.000"}}`, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, SlackPath+tt.channel, strings.NewReader(tt.body))
+			for name, values := range sign(tt.secret, tt.body) {
+				req.Header[name] = values
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body, tt.wantBody)
+			}
+		})
+	}
+
+	reply, err := lookupChatReply(ctx, queries, "slack", "C1", "1.000", "Hi")
+	if err != nil || reply != nil {
+		t.Errorf("lookupChatReply of notification without conversation = %v, %v; want nil", reply, err)
+	}
+	if _, err := queries.GetNotificationMessage(ctx, store.GetNotificationMessageParams{ChannelID: "slack", ChatID: "C1", MessageID: "0.500"}); err != sql.ErrNoRows {
+		t.Errorf("GetNotificationMessage of unknown message: %v", err)
+	}
+}
+
+// TestSlackReplyRouting checks that messages in the thread of a notification
+// continue its conversation. Slack channels have no agent of their own, so
+// messages in other threads don't start a conversation.
+func TestSlackReplyRouting(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "agent-1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("create agent: %v", err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "notifying", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+		ID: "slack", Name: "slack", Type: "slack", Config: `{"bot_token": "xoxb-1", "channel": "C1", "signing_secret": "s3cret"}`,
+		QuietHoursMode: "defer", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create channel: %v", err)
+	}
+	if err := queries.CreateNotificationDelivery(ctx, store.CreateNotificationDeliveryParams{ID: "d1", ChannelID: "slack", ConversationID: store.NewNullString("notifying"), DeliveredAt: now}); err != nil {
+		t.Fatalf("create delivery: %v", err)
+	}
+	if err := queries.CreateNotificationMessage(ctx, store.CreateNotificationMessageParams{ChannelID: "slack", ChatID: "C1", MessageID: "1.000", DeliveryID: "d1"}); err != nil {
+		t.Fatalf("create notification message: %v", err)
+	}
+
+	// Answers are posted to the Web API through the default transport.
+	type post struct{ ThreadTS, Text string }
+	posted := make(chan post, 10)
+	transport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var msg struct {
+			ThreadTS string `json:"thread_ts"`
+			Text     string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&msg)
+		posted <- post{msg.ThreadTS, msg.Text}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"ok": true, "channel": "C1", "ts": "3.000"}`)), Header: http.Header{}}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	broker := pubsub.New(nil, slog.New(slog.DiscardHandler))
+	loop := &agentloop.Loop{
+		Queries:      queries,
+		ORClient:     openrouter.NewMockClient(&openrouter.MockFixture{Default: "It's sunny."}),
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       broker,
+		DefaultModel: "mock",
+	}
+	h := NewSlackHandler(queries, nil, runner.New(queries, broker, loop, nil), &maintenance.Mode{}, slog.New(slog.DiscardHandler))
+
+	send := func(t *testing.T, threadTS string) {
+		t.Helper()
+		body := `{"type": "event_callback", "event": {"type": "message", "channel": "C1", "text": "What's the weather?", "ts": "2.000", "thread_ts": "` + threadTS + `"}}`
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write([]byte("v0:" + ts + ":" + body))
+		req := httptest.NewRequest(http.MethodPost, SlackPath+"slack", strings.NewReader(body))
+		req.SetPathValue("channelID", "slack")
+		req.Header.Set("X-Slack-Request-Timestamp", ts)
+		req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+		}
+	}
+	conversations := func(t *testing.T) int {
+		t.Helper()
+		var n int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM conversations").Scan(&n); err != nil {
+			t.Fatalf("count conversations: %v", err)
+		}
+		return n
+	}
+
+	send(t, "1.000")
+	select {
+	case p := <-posted:
+		if p != (post{"1.000", "It's sunny."}) {
+			t.Errorf("posted %+v, want the answer in the notification's thread", p)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("no answer posted")
+	}
+	msgs, err := queries.GetMessagesByConversation(ctx, "notifying")
+	if err != nil {
+		t.Fatalf("get messages: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Errorf("notifying conversation has %d messages, want the reply and answer", len(msgs))
+	}
+
+	send(t, "0.500")
+	if n := conversations(t); n != 1 {
+		t.Errorf("%d conversations after a message in an unknown thread, want 1", n)
+	}
+	select {
+	case p := <-posted:
+		t.Errorf("posted %+v for a message in an unknown thread", p)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
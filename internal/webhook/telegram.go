@@ -0,0 +1,265 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// TelegramPath is the path of Telegram channel webhooks, followed by the
+// channel ID.
+const TelegramPath = "/webhooks/telegram/"
+
+// telegramSecretHeader holds the secret token a Telegram webhook was
+// registered with.
+const telegramSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// TelegramHandler receives messages sent to the bot of a Telegram channel,
+// and replies with the answer of the channel's agent. Each chat has its own
+// conversation, which is continued with each message from the chat. Replies
+// to notifications sent by the bot continue the conversation that sent the
+// notification instead, also on channels without an agent.
+type TelegramHandler struct {
+	queries *store.Queries
+	cipher  *encryption.Cipher
+	runner  *runner.Runner
+	maint   *maintenance.Mode
+	logger  *slog.Logger
+
+	// chats serializes the messages of each chat, so they're answered in
+	// order and in the same conversation.
+	chats sync.Map
+}
+
+// NewTelegramHandler creates a new TelegramHandler. The cipher decrypts
+// channel configs and may be nil if they're stored as plaintext.
+func NewTelegramHandler(queries *store.Queries, cipher *encryption.Cipher, runner *runner.Runner, maint *maintenance.Mode, logger *slog.Logger) *TelegramHandler {
+	return &TelegramHandler{
+		queries: queries,
+		cipher:  cipher,
+		runner:  runner,
+		maint:   maint,
+		logger:  logger,
+	}
+}
+
+// telegramUpdate is the part of a Telegram update the handler uses.
+type telegramUpdate struct {
+	Message *struct {
+		MessageID int64 `json:"message_id"`
+		Chat      struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		Text           string `json:"text"`
+		ReplyToMessage *struct {
+			MessageID int64 `json:"message_id"`
+		} `json:"reply_to_message"`
+	} `json:"message"`
+}
+
+// ServeHTTP handles POST /webhooks/telegram/{channelID} requests. It responds
+// right away and answers the message in the background, as Telegram retries
+// updates that aren't acknowledged quickly.
+func (h *TelegramHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.maint.Err(); err != nil {
+		unavailable(w, err)
+		return
+	}
+
+	channel, err := h.queries.GetNotificationChannel(r.Context(), r.PathValue("channelID"))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && channel.Type != tool.TelegramChannelType) {
+		http.Error(w, "Channel not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to get notification channel", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	config, err := h.cipher.Decrypt(channel.Config)
+	if err != nil {
+		h.logger.Error("failed to decrypt channel config", "channel_id", channel.ID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	cfg, err := tool.ParseTelegramConfig(config)
+	if err != nil || cfg.WebhookSecret == "" {
+		http.Error(w, "Channel doesn't accept messages", http.StatusNotFound)
+		return
+	}
+	secret := r.Header.Get(telegramSecretHeader)
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(cfg.WebhookSecret)) != 1 {
+		h.logger.Warn("telegram webhook request with invalid secret", "channel_id", channel.ID)
+		http.Error(w, "Invalid secret token", http.StatusUnauthorized)
+		return
+	}
+
+	var update telegramUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// Other updates, such as edited messages and photos, are acknowledged
+	// so they aren't sent again.
+	if update.Message == nil || strings.TrimSpace(update.Message.Text) == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	chatID := strconv.FormatInt(update.Message.Chat.ID, 10)
+	if !cfg.AllowsChat(chatID) {
+		h.logger.Warn("telegram message from chat that isn't allowed", "channel_id", channel.ID, "chat_id", chatID)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if to := update.Message.ReplyToMessage; to != nil {
+		reply, err := lookupChatReply(r.Context(), h.queries, channel.ID, chatID, strconv.FormatInt(to.MessageID, 10), update.Message.Text)
+		if err != nil {
+			h.logger.Error("failed to look up telegram reply", "channel_id", channel.ID, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if reply != nil {
+			go h.handleReply(context.WithoutCancel(r.Context()), channel.ID, cfg, chatID, reply)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	if cfg.AgentID == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	go h.handleMessage(context.WithoutCancel(r.Context()), channel.ID, cfg, chatID, update.Message.Text)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleReply continues the conversation of a notification with a reply to
+// it, and sends the answer to the chat. Replies to the answer continue the
+// conversation too.
+func (h *TelegramHandler) handleReply(ctx context.Context, channelID string, cfg tool.TelegramConfig, chatID string, reply *chatReply) {
+	mu, _ := h.chats.LoadOrStore(channelID+"/"+chatID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	logger := h.logger.With("channel_id", channelID, "chat_id", chatID, "conversation_id", reply.ConversationID)
+	send := func(text string) {
+		var sent []tool.SentMessage
+		if err := tool.SendTelegramMessage(tool.WithSentMessages(ctx, &sent), cfg.BotToken, chatID, text); err != nil {
+			logger.Error("failed to send telegram message", "error", err)
+		}
+		for _, m := range sent {
+			if err := h.queries.CreateNotificationMessage(ctx, store.CreateNotificationMessageParams{
+				ChannelID:  channelID,
+				ChatID:     m.ChatID,
+				MessageID:  m.MessageID,
+				DeliveryID: reply.DeliveryID,
+			}); err != nil {
+				logger.Error("failed to record notification message", "error", err)
+			}
+		}
+	}
+
+	result, err := h.runner.Continue(ctx, reply.ConversationID, reply.Prompt)
+	if errors.Is(err, runner.ErrConversationBusy) {
+		send("I'm still working on something else in this conversation. Try again in a moment.")
+		return
+	}
+	if err != nil {
+		logger.Error("telegram notification reply failed", "error", err)
+		send("Sorry, I couldn't answer that reply.")
+		return
+	}
+
+	logger.Info("telegram notification reply answered")
+	if strings.TrimSpace(result.Response) != "" {
+		send(result.Response)
+	}
+}
+
+// handleMessage runs a turn of the chat's conversation, or starts one, and
+// sends the answer to the chat. "/start" and "/new" start a new conversation
+// with the next message.
+func (h *TelegramHandler) handleMessage(ctx context.Context, channelID string, cfg tool.TelegramConfig, chatID, text string) {
+	mu, _ := h.chats.LoadOrStore(channelID+"/"+chatID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	logger := h.logger.With("channel_id", channelID, "chat_id", chatID)
+	reply := func(text string) {
+		if err := tool.SendTelegramMessage(ctx, cfg.BotToken, chatID, text); err != nil {
+			logger.Error("failed to send telegram message", "error", err)
+		}
+	}
+
+	if command := strings.Fields(text)[0]; command == "/start" || command == "/new" {
+		if err := h.queries.DeleteTelegramChat(ctx, store.DeleteTelegramChatParams{ChannelID: channelID, ChatID: chatID}); err != nil {
+			logger.Error("failed to delete telegram chat", "error", err)
+			return
+		}
+		reply("Started a new conversation. Send a message to begin.")
+		return
+	}
+
+	var result *runner.RunResult
+	chat, err := h.queries.GetTelegramChat(ctx, store.GetTelegramChatParams{ChannelID: channelID, ChatID: chatID})
+	switch {
+	case err == nil:
+		result, err = h.runner.Continue(ctx, chat.ConversationID, text)
+	case errors.Is(err, sql.ErrNoRows):
+		result, err = h.runner.Run(ctx, runner.RunOpts{
+			AgentID: cfg.AgentID,
+			Prompt:  text,
+			Kind:    agentloop.RunKindWebhook,
+			Title:   "Telegram chat " + chatID,
+		})
+		// The conversation exists if the turn failed, so the chat continues
+		// it next time.
+		if result != nil && result.ConversationID != "" {
+			if err := h.queries.UpsertTelegramChat(ctx, store.UpsertTelegramChatParams{
+				ChannelID:      channelID,
+				ChatID:         chatID,
+				ConversationID: result.ConversationID,
+				CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+			}); err != nil {
+				logger.Error("failed to store telegram chat", "error", err)
+			}
+		}
+	}
+	if errors.Is(err, runner.ErrConversationBusy) {
+		reply("I'm still working on something else in this conversation. Try again in a moment.")
+		return
+	}
+	if err != nil {
+		logger.Error("telegram message failed", "error", err)
+		reply("Sorry, I couldn't answer that message.")
+		return
+	}
+
+	logger.Info("telegram message answered", "conversation_id", result.ConversationID)
+	// Telegram rejects empty messages, e.g. of turns that only called tools.
+	if strings.TrimSpace(result.Response) != "" {
+		reply(result.Response)
+	}
+}
@@ -0,0 +1,211 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/agentloop"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/openrouter"
+	"github.com/dstotijn/blippy/internal/pubsub"
+	"github.com/dstotijn/blippy/internal/runner"
+	"github.com/dstotijn/blippy/internal/store"
+	"github.com/dstotijn/blippy/internal/tool"
+)
+
+// TestTelegramHandler checks the requests that are rejected or ignored
+// before the agent runs.
+func TestTelegramHandler(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, c := range []store.CreateNotificationChannelParams{
+		{ID: "bot", Name: "bot", Type: "telegram", Config: `{"bot_token": "123:abc", "chat_ids": ["42"], "agent_id": "agent", "webhook_secret": "s3cret"}`},
+		{ID: "notify-only", Name: "notify-only", Type: "telegram", Config: `{"bot_token": "123:abc", "chat_ids": ["42"]}`},
+		{ID: "sms", Name: "sms", Type: "sms", Config: `{}`},
+	} {
+		c.QuietHoursMode, c.CreatedAt, c.UpdatedAt = "defer", now, now
+		if _, err := queries.CreateNotificationChannel(ctx, c); err != nil {
+			t.Fatalf("create channel: %v", err)
+		}
+	}
+
+	h := NewTelegramHandler(queries, nil, nil, &maintenance.Mode{}, slog.New(slog.DiscardHandler))
+	mux := http.NewServeMux()
+	mux.Handle("POST "+TelegramPath+"{channelID}", h)
+
+	tests := []struct {
+		name    string
+		channel string
+		secret  string
+		body    string
+		want    int
+	}{
+		{"unknown channel", "nope", "s3cret", `{}`, http.StatusNotFound},
+		{"other channel type", "sms", "s3cret", `{}`, http.StatusNotFound},
+		{"channel without agent", "notify-only", "s3cret", `{}`, http.StatusNotFound},
+		{"bad secret", "bot", "guess", `{"message": {"chat": {"id": 42}, "text": "Hi"}}`, http.StatusUnauthorized},
+		{"no message", "bot", "s3cret", `{"edited_message": {"chat": {"id": 42}, "text": "Hi"}}`, http.StatusOK},
+		{"disallowed chat", "bot", "s3cret", `{"message": {"chat": {"id": 7}, "text": "Hi"}}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, TelegramPath+tt.channel, strings.NewReader(tt.body))
+			req.Header.Set(telegramSecretHeader, tt.secret)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+// TestTelegramReplyRouting checks that replies to notifications continue
+// their conversation, and that other replies fall back to the chat's
+// conversation, which is started for the first message.
+func TestTelegramReplyRouting(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent-1", Name: "agent-1", EnabledTools: "[]", EnabledNotificationChannels: "[]", EnabledFilesystemRoots: "[]", ForwardedHostEnvVars: "[]", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("create agent: %v", err)
+	}
+	if _, err := queries.CreateConversation(ctx, store.CreateConversationParams{ID: "notifying", AgentID: "agent-1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("create conversation: %v", err)
+	}
+	if _, err := queries.CreateNotificationChannel(ctx, store.CreateNotificationChannelParams{
+		ID: "bot", Name: "bot", Type: "telegram", Config: `{"bot_token": "123:abc", "chat_ids": ["42"], "agent_id": "agent-1", "webhook_secret": "s3cret"}`,
+		QuietHoursMode: "defer", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create channel: %v", err)
+	}
+	// Message 100 is a notification from a conversation, 101 one without.
+	for _, d := range []struct {
+		id, conversationID, messageID string
+	}{
+		{"d1", "notifying", "100"},
+		{"d2", "", "101"},
+	} {
+		if err := queries.CreateNotificationDelivery(ctx, store.CreateNotificationDeliveryParams{ID: d.id, ChannelID: "bot", ConversationID: store.NewNullString(d.conversationID), DeliveredAt: now}); err != nil {
+			t.Fatalf("create delivery: %v", err)
+		}
+		if err := queries.CreateNotificationMessage(ctx, store.CreateNotificationMessageParams{ChannelID: "bot", ChatID: "42", MessageID: d.messageID, DeliveryID: d.id}); err != nil {
+			t.Fatalf("create notification message: %v", err)
+		}
+	}
+
+	// Answers are sent to the Bot API through the default transport.
+	sent := make(chan string, 10)
+	transport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var msg struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&msg)
+		sent <- msg.Text
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"ok": true, "result": {"message_id": 200}}`)), Header: http.Header{}}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	orClient := openrouter.NewMockClient(&openrouter.MockFixture{Default: "It's sunny."})
+	broker := pubsub.New(nil, slog.New(slog.DiscardHandler))
+	loop := &agentloop.Loop{
+		Queries:      queries,
+		ORClient:     orClient,
+		ToolExecutor: tool.NewExecutor(tool.NewRegistry(), nil, nil, nil),
+		Broker:       broker,
+		DefaultModel: "mock",
+	}
+	h := NewTelegramHandler(queries, nil, runner.New(queries, broker, loop, nil), &maintenance.Mode{}, slog.New(slog.DiscardHandler))
+
+	send := func(t *testing.T, replyTo int64) {
+		t.Helper()
+		body := fmt.Sprintf(`{"message": {"message_id": 300, "chat": {"id": 42}, "text": "What's the weather?", "reply_to_message": {"message_id": %d}}}`, replyTo)
+		req := httptest.NewRequest(http.MethodPost, TelegramPath+"bot", strings.NewReader(body))
+		req.SetPathValue("channelID", "bot")
+		req.Header.Set(telegramSecretHeader, "s3cret")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+		}
+		select {
+		case text := <-sent:
+			if text != "It's sunny." {
+				t.Errorf("sent %q, want the answer", text)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("no answer sent")
+		}
+	}
+	messages := func(t *testing.T, convID string) int {
+		t.Helper()
+		msgs, err := queries.GetMessagesByConversation(ctx, convID)
+		if err != nil {
+			t.Fatalf("get messages: %v", err)
+		}
+		return len(msgs)
+	}
+
+	t.Run("reply to notification", func(t *testing.T) {
+		send(t, 100)
+		if n := messages(t, "notifying"); n != 2 {
+			t.Errorf("notifying conversation has %d messages, want the reply and answer", n)
+		}
+		if _, err := queries.GetTelegramChat(ctx, store.GetTelegramChatParams{ChannelID: "bot", ChatID: "42"}); err != sql.ErrNoRows {
+			t.Errorf("chat conversation started for a notification reply: %v", err)
+		}
+	})
+
+	// Replies to unknown messages, or notifications without a conversation,
+	// are messages to the channel's agent.
+	var chatConvID string
+	for _, replyTo := range []int64{999, 101} {
+		t.Run(fmt.Sprintf("reply to message %d", replyTo), func(t *testing.T) {
+			send(t, replyTo)
+			chat, err := queries.GetTelegramChat(ctx, store.GetTelegramChatParams{ChannelID: "bot", ChatID: "42"})
+			if err != nil {
+				t.Fatalf("get chat: %v", err)
+			}
+			if chatConvID == "" {
+				chatConvID = chat.ConversationID
+			}
+			if chat.ConversationID == "notifying" || chat.ConversationID != chatConvID {
+				t.Errorf("chat conversation = %q, want a new one continued by later messages", chat.ConversationID)
+			}
+		})
+	}
+	if n := messages(t, chatConvID); n != 4 {
+		t.Errorf("chat conversation has %d messages, want 2 turns", n)
+	}
+	if n := messages(t, "notifying"); n != 2 {
+		t.Errorf("notifying conversation has %d messages after other replies", n)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
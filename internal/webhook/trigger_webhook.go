@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/auth"
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// TriggerWebhookPath is the path of trigger webhook URLs, followed by their
+// token.
+const TriggerWebhookPath = "/webhooks/triggers/"
+
+// TriggerRunner starts trigger runs in the background. Implemented by
+// scheduler.Scheduler.
+type TriggerRunner interface {
+	RunTriggerInput(ctx context.Context, triggerID, input string) (string, error)
+}
+
+// TriggerWebhookHandler runs the trigger of a webhook, authenticated by the
+// token in its URL rather than an API key, so services like GitHub and
+// Stripe can call it. Requests can further be restricted to signed ones and
+// to IP ranges.
+type TriggerWebhookHandler struct {
+	queries *store.Queries
+	cipher  *encryption.Cipher
+	runner  TriggerRunner
+	maint   *maintenance.Mode
+	logger  *slog.Logger
+
+	// TrustProxyHeaders uses the last X-Forwarded-For address as the client
+	// IP for allowed IP checks.
+	TrustProxyHeaders bool
+}
+
+// NewTriggerWebhookHandler creates a new TriggerWebhookHandler. The cipher
+// decrypts webhook secrets and may be nil if they're stored as plaintext.
+func NewTriggerWebhookHandler(queries *store.Queries, cipher *encryption.Cipher, runner TriggerRunner, maint *maintenance.Mode, logger *slog.Logger) *TriggerWebhookHandler {
+	return &TriggerWebhookHandler{
+		queries: queries,
+		cipher:  cipher,
+		runner:  runner,
+		maint:   maint,
+		logger:  logger,
+	}
+}
+
+// TriggerWebhookResponse is returned once the trigger run has started. The
+// run continues in the background, so senders with short timeouts don't
+// retry.
+type TriggerWebhookResponse struct {
+	TriggerRunID string `json:"trigger_run_id"`
+}
+
+// ServeHTTP handles POST /webhooks/triggers/{token} requests.
+func (h *TriggerWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.maint.Err(); err != nil {
+		unavailable(w, err)
+		return
+	}
+
+	hook, err := h.queries.GetTriggerWebhookByTokenHash(r.Context(), hashToken(r.PathValue("token")))
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to get trigger webhook", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var allowedIPs []string
+	_ = json.Unmarshal([]byte(hook.AllowedIps), &allowedIPs)
+	prefixes, err := ParseAllowedIPs(allowedIPs)
+	if err != nil {
+		// Allowed IPs are validated when saved, so this doesn't happen unless
+		// the database was edited; fail closed.
+		h.logger.Error("invalid allowed IPs of trigger webhook", "trigger_id", hook.TriggerID, "error", err)
+		http.Error(w, "IP address not allowed", http.StatusForbidden)
+		return
+	}
+	if ip := auth.ClientIP(r, h.TrustProxyHeaders); !ipAllowed(prefixes, ip) {
+		h.logger.Warn("trigger webhook request from disallowed IP", "trigger_id", hook.TriggerID, "ip", ip)
+		http.Error(w, "IP address not allowed", http.StatusForbidden)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	secret, err := h.cipher.Decrypt(hook.Secret)
+	if err != nil {
+		h.logger.Error("failed to decrypt trigger webhook secret", "trigger_id", hook.TriggerID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if secret != "" {
+		if err := verifySignature(hook.SignatureScheme, secret, r.Header, body, time.Now()); err != nil {
+			h.logger.Warn("trigger webhook request with invalid signature", "trigger_id", hook.TriggerID, "error", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	trigger, err := h.queries.GetTrigger(r.Context(), hook.TriggerID)
+	if err != nil {
+		h.logger.Error("failed to get trigger of webhook", "trigger_id", hook.TriggerID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if trigger.Enabled == 0 {
+		http.Error(w, "Trigger is disabled", http.StatusForbidden)
+		return
+	}
+
+	runID, err := h.runner.RunTriggerInput(r.Context(), trigger.ID, webhookInput(body))
+	if err != nil {
+		if err := h.maint.Err(); err != nil {
+			unavailable(w, err)
+			return
+		}
+		h.logger.Error("failed to run trigger of webhook", "trigger_id", trigger.ID, "error", err)
+		http.Error(w, "Failed to run trigger", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("trigger webhook started run", "trigger_id", trigger.ID, "trigger_run_id", runID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(TriggerWebhookResponse{TriggerRunID: runID})
+}
+
+// webhookInput is the text appended to the trigger's prompt for a request
+// body.
+func webhookInput(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	return "The trigger was called by a webhook with this request body:\n\n" + string(body)
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/maintenance"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+type fakeTriggerRunner struct{ inputs []string }
+
+func (r *fakeTriggerRunner) RunTriggerInput(ctx context.Context, triggerID, input string) (string, error) {
+	r.inputs = append(r.inputs, input)
+	return "run-1", nil
+}
+
+func TestTriggerWebhookHandler(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.Open(filepath.Join(t.TempDir(), "blippy.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries := store.New(db)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := queries.CreateAgent(ctx, store.CreateAgentParams{ID: "agent", Name: "agent", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("create agent: %v", err)
+	}
+	if _, err := queries.CreateTrigger(ctx, store.CreateTriggerParams{ID: "trigger", AgentID: "agent", Name: "on push", Prompt: "Review the push.", Enabled: 1, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+	token, tokenHash := NewToken()
+	if _, err := queries.CreateTriggerWebhook(ctx, store.CreateTriggerWebhookParams{
+		TriggerID:  "trigger",
+		TokenHash:  tokenHash,
+		Secret:     "s3cret",
+		AllowedIps: `["192.0.2.0/24"]`,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	runner := &fakeTriggerRunner{}
+	h := NewTriggerWebhookHandler(queries, nil, runner, &maintenance.Mode{}, slog.New(slog.DiscardHandler))
+	mux := http.NewServeMux()
+	mux.Handle("POST "+TriggerWebhookPath+"{token}", h)
+
+	body := `{"ref":"refs/heads/main"}`
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name       string
+		token      string
+		remoteAddr string
+		signature  string
+		want       int
+	}{
+		{"unknown token", "nope", "192.0.2.1:1234", signature, http.StatusNotFound},
+		{"disallowed IP", token, "198.51.100.1:1234", signature, http.StatusForbidden},
+		{"bad signature", token, "192.0.2.1:1234", "sha256=00", http.StatusUnauthorized},
+		{"valid", token, "192.0.2.1:1234", signature, http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, TriggerWebhookPath+tt.token, strings.NewReader(body))
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Hub-Signature-256", tt.signature)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+
+	if len(runner.inputs) != 1 || !strings.Contains(runner.inputs[0], body) {
+		t.Errorf("trigger runs = %q, want one with the request body", runner.inputs)
+	}
+}
@@ -0,0 +1,356 @@
+// Package webpush sends Web Push notifications (RFC 8030) with message
+// encryption (RFC 8291) and VAPID authentication (RFC 8292).
+package webpush
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dstotijn/blippy/internal/encryption"
+	"github.com/dstotijn/blippy/internal/store"
+)
+
+// vapidKeySetting is the settings key under which the VAPID private key is
+// stored, so subscriptions survive restarts.
+const vapidKeySetting = "vapid_private_key"
+
+// recordSize is the aes128gcm record size. Payloads must fit in one record.
+const recordSize = 4096
+
+// ErrSubscriptionGone is returned when the push service reports that a
+// subscription has expired or was revoked.
+var ErrSubscriptionGone = errors.New("push subscription is no longer valid")
+
+// Subscription is a browser push subscription.
+type Subscription struct {
+	Endpoint string
+	P256dh   string // base64url-encoded client public key
+	Auth     string // base64url-encoded client auth secret
+}
+
+// Message is the payload shown by the service worker.
+type Message struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Sender sends Web Push notifications to stored subscriptions.
+type Sender struct {
+	queries *store.Queries
+	key     *ecdsa.PrivateKey
+	subject string
+	client  *http.Client
+	logger  *slog.Logger
+}
+
+// NewSender creates a Sender, loading the VAPID key pair from the database or
+// generating and storing a new one. The subject is a "mailto:" or "https:"
+// URL identifying the sender to push services. The key is stored encrypted
+// with secrets, which may be nil to store it as plaintext.
+func NewSender(ctx context.Context, queries *store.Queries, secrets *encryption.Cipher, subject string, logger *slog.Logger) (*Sender, error) {
+	key, err := loadOrCreateKey(ctx, queries, secrets)
+	if err != nil {
+		return nil, err
+	}
+	return &Sender{
+		queries: queries,
+		key:     key,
+		subject: subject,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		logger:  logger,
+	}, nil
+}
+
+// PublicKey returns the base64url-encoded VAPID public key, used by browsers
+// as the applicationServerKey when subscribing.
+func (s *Sender) PublicKey() string {
+	pub, _ := s.key.PublicKey.ECDH()
+	return base64.RawURLEncoding.EncodeToString(pub.Bytes())
+}
+
+// Notify sends a message about an agent to the subscriptions allowed to
+// access it: those of users whose API key isn't restricted to other agents.
+// Messages that aren't about an agent (empty agentID) are only sent to
+// unrestricted subscriptions. Expired subscriptions are removed.
+func (s *Sender) Notify(ctx context.Context, agentID string, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	subs, err := s.queries.ListWebPushSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("list subscriptions: %w", err)
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		if !allowsAgent(sub, agentID) {
+			continue
+		}
+		err := s.Send(ctx, Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}, payload)
+		if errors.Is(err, ErrSubscriptionGone) {
+			if err := s.queries.DeleteWebPushSubscription(ctx, sub.Endpoint); err != nil {
+				s.logger.Error("failed to delete expired push subscription", "error", err)
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// allowsAgent reports whether a subscription may be notified about an agent.
+func allowsAgent(sub store.WebPushSubscription, agentID string) bool {
+	var agentIDs []string
+	if err := json.Unmarshal([]byte(cmp.Or(sub.AgentIds, "[]")), &agentIDs); err != nil {
+		return false
+	}
+	return len(agentIDs) == 0 || agentID != "" && slices.Contains(agentIDs, agentID)
+}
+
+// Send encrypts and sends a payload to a single subscription.
+func (s *Sender) Send(ctx context.Context, sub Subscription, payload []byte) error {
+	body, err := encrypt(sub, payload)
+	if err != nil {
+		return fmt.Errorf("encrypt payload: %w", err)
+	}
+
+	auth, err := s.vapidAuthorization(sub.Endpoint)
+	if err != nil {
+		return fmt.Errorf("create vapid authorization: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", "86400")
+	req.Header.Set("Urgency", "high")
+	req.Header.Set("Authorization", auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode >= 400:
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("push service returned status %d: %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
+
+// vapidAuthorization builds the "vapid" Authorization header value for the
+// push service hosting the endpoint.
+func (s *Sender) vapidAuthorization(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]any{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": s.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + enc.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+	rawSig := make([]byte, 64)
+	r.FillBytes(rawSig[:32])
+	sig.FillBytes(rawSig[32:])
+
+	return fmt.Sprintf("vapid t=%s.%s, k=%s", signingInput, enc.EncodeToString(rawSig), s.PublicKey()), nil
+}
+
+// encrypt encrypts a payload for a subscription using the aes128gcm content
+// encoding (RFC 8188) with keys derived per RFC 8291.
+func encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	uaPublicBytes, err := decodeKey(sub.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("decode p256dh: %w", err)
+	}
+	authSecret, err := decodeKey(sub.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("decode auth: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse p256dh: %w", err)
+	}
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	prkKey, err := hkdf.Extract(sha256.New, ecdhSecret, authSecret)
+	if err != nil {
+		return nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPublicBytes) + string(asPublicBytes)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	// Single record: payload followed by the last-record delimiter.
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	if len(plaintext)+16 > recordSize {
+		return nil, fmt.Errorf("payload too large (%d bytes)", len(payload))
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	var buf bytes.Buffer
+	buf.Write(salt)
+	binary.Write(&buf, binary.BigEndian, uint32(recordSize))
+	buf.WriteByte(byte(len(asPublicBytes)))
+	buf.Write(asPublicBytes)
+	buf.Write(ciphertext)
+	return buf.Bytes(), nil
+}
+
+// decodeKey decodes base64url keys, with or without padding.
+func decodeKey(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func loadOrCreateKey(ctx context.Context, queries *store.Queries, secrets *encryption.Cipher) (*ecdsa.PrivateKey, error) {
+	stored, err := queries.GetSetting(ctx, vapidKeySetting)
+	if err == nil {
+		value, err := secrets.Decrypt(stored)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt vapid key: %w", err)
+		}
+		b, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("decode vapid key: %w", err)
+		}
+		key, err := privateKeyFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		// Encrypt a key stored before encryption was enabled.
+		if secrets.Enabled() && !encryption.IsEncrypted(stored) {
+			if err := storeKey(ctx, queries, secrets, b); err != nil {
+				return nil, err
+			}
+		}
+		return key, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get vapid key: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate vapid key: %w", err)
+	}
+	if err := storeKey(ctx, queries, secrets, key.D.FillBytes(make([]byte, 32))); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func storeKey(ctx context.Context, queries *store.Queries, secrets *encryption.Cipher, raw []byte) error {
+	value, err := secrets.Encrypt(base64.RawURLEncoding.EncodeToString(raw))
+	if err != nil {
+		return fmt.Errorf("encrypt vapid key: %w", err)
+	}
+	if err := queries.UpsertSetting(ctx, store.UpsertSettingParams{
+		Key:       vapidKeySetting,
+		Value:     value,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("store vapid key: %w", err)
+	}
+	return nil
+}
+
+func privateKeyFromBytes(b []byte) (*ecdsa.PrivateKey, error) {
+	// Validate the scalar before constructing the key.
+	if _, err := ecdh.P256().NewPrivateKey(b); err != nil {
+		return nil, fmt.Errorf("parse vapid key: %w", err)
+	}
+	curve := elliptic.P256()
+	key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(b)}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(b)
+	return key, nil
+}
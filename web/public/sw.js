@@ -0,0 +1,31 @@
+// Service worker for Web Push notifications sent by web_push channels and
+// autonomous agent runs.
+
+self.addEventListener("push", (event) => {
+	const data = event.data ? event.data.json() : {};
+	event.waitUntil(
+		self.registration.showNotification(data.title ?? "Blippy", {
+			body: data.body,
+			icon: "/favicon.svg",
+			data: { url: data.url ?? "/" },
+		}),
+	);
+});
+
+self.addEventListener("notificationclick", (event) => {
+	event.notification.close();
+	const url = event.notification.data?.url ?? "/";
+	event.waitUntil(
+		self.clients
+			.matchAll({ type: "window", includeUncontrolled: true })
+			.then((clients) => {
+				for (const client of clients) {
+					if ("focus" in client) {
+						client.navigate(url);
+						return client.focus();
+					}
+				}
+				return self.clients.openWindow(url);
+			}),
+	);
+});
@@ -0,0 +1,245 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { KeyRound } from "lucide-react";
+import { useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import {
+	Table,
+	TableBody,
+	TableCell,
+	TableHead,
+	TableHeader,
+	TableRow,
+} from "@/components/ui/table";
+import { listAgents } from "@/lib/rpc/agent/agent-AgentService_connectquery";
+import {
+	createAPIKey,
+	getSession,
+	listAPIKeys,
+	revokeAPIKey,
+} from "@/lib/rpc/auth/auth-AuthService_connectquery";
+
+const scopes = [
+	{ value: "read", label: "Read" },
+	{ value: "write", label: "Write" },
+	{ value: "chat", label: "Chat" },
+	{ value: "webhook", label: "Webhook" },
+];
+
+function toggle(values: string[], value: string) {
+	return values.includes(value)
+		? values.filter((v) => v !== value)
+		: [...values, value];
+}
+
+export function ApiKeysCard() {
+	const { data: session } = useQuery(getSession, {});
+	const { data, refetch } = useQuery(
+		listAPIKeys,
+		{},
+		{ enabled: session?.role === "admin" },
+	);
+	const { data: agentsData } = useQuery(
+		listAgents,
+		{},
+		{ enabled: session?.role === "admin" },
+	);
+	const createMutation = useMutation(createAPIKey);
+	const revokeMutation = useMutation(revokeAPIKey);
+	const [name, setName] = useState("");
+	const [agentIds, setAgentIds] = useState<string[]>([]);
+	const [keyScopes, setKeyScopes] = useState<string[]>([]);
+	const [createdKey, setCreatedKey] = useState("");
+
+	// Only admins can manage keys. Without auth, there are no keys to manage.
+	if (session?.role !== "admin") {
+		return null;
+	}
+
+	const handleCreate = async (e: React.FormEvent) => {
+		e.preventDefault();
+		try {
+			const res = await createMutation.mutateAsync({
+				name: name.trim(),
+				agentIds,
+				scopes: keyScopes,
+			});
+			setCreatedKey(res.key);
+			setName("");
+			setAgentIds([]);
+			setKeyScopes([]);
+			refetch();
+		} catch {
+			toast.error("Failed to create API key");
+		}
+	};
+
+	const agentName = (id: string) =>
+		agentsData?.agents.find((a) => a.id === id)?.name ?? id;
+
+	const handleRevoke = async (id: string) => {
+		if (!confirm("Are you sure you want to revoke this API key?")) return;
+		try {
+			await revokeMutation.mutateAsync({ id });
+			toast.success("API key revoked");
+			refetch();
+		} catch {
+			toast.error("Failed to revoke API key");
+		}
+	};
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<KeyRound className="h-4 w-4" />
+					API Keys
+				</CardTitle>
+				<CardDescription>
+					Keys for logging in and calling the API with an{" "}
+					<code className="text-xs">Authorization: Bearer</code> header
+				</CardDescription>
+			</CardHeader>
+			<CardContent className="space-y-4">
+				<form onSubmit={handleCreate} className="space-y-3">
+					<div className="flex gap-2">
+						<Input
+							placeholder="Key name"
+							value={name}
+							onChange={(e) => setName(e.target.value)}
+						/>
+						<Button
+							type="submit"
+							disabled={!name.trim() || createMutation.isPending}
+						>
+							Create
+						</Button>
+					</div>
+					<div className="space-y-2">
+						<p className="text-sm text-muted-foreground">
+							Scopes (none selected allows all; restricted keys can't manage
+							keys or read the audit log)
+						</p>
+						<div className="flex flex-wrap gap-4">
+							{scopes.map((scope) => (
+								<div key={scope.value} className="flex items-center space-x-2">
+									<Checkbox
+										id={`scope-${scope.value}`}
+										checked={keyScopes.includes(scope.value)}
+										onCheckedChange={() =>
+											setKeyScopes(toggle(keyScopes, scope.value))
+										}
+									/>
+									<label
+										htmlFor={`scope-${scope.value}`}
+										className="text-sm leading-none"
+									>
+										{scope.label}
+									</label>
+								</div>
+							))}
+						</div>
+					</div>
+					{agentsData && agentsData.agents.length > 0 && (
+						<div className="space-y-2">
+							<p className="text-sm text-muted-foreground">
+								Agents (none selected allows all)
+							</p>
+							<div className="flex flex-wrap gap-4">
+								{agentsData.agents.map((agent) => (
+									<div key={agent.id} className="flex items-center space-x-2">
+										<Checkbox
+											id={`agent-${agent.id}`}
+											checked={agentIds.includes(agent.id)}
+											onCheckedChange={() =>
+												setAgentIds(toggle(agentIds, agent.id))
+											}
+										/>
+										<label
+											htmlFor={`agent-${agent.id}`}
+											className="text-sm leading-none"
+										>
+											{agent.name}
+										</label>
+									</div>
+								))}
+							</div>
+						</div>
+					)}
+				</form>
+
+				{createdKey && (
+					<div className="space-y-1 rounded-lg border border-yellow-500/50 bg-yellow-500/10 p-4 text-sm">
+						<p>Copy this key now. It won't be shown again.</p>
+						<code className="block break-all font-mono">{createdKey}</code>
+					</div>
+				)}
+
+				<Table>
+					<TableHeader>
+						<TableRow>
+							<TableHead>Name</TableHead>
+							<TableHead>Key</TableHead>
+							<TableHead>Scopes</TableHead>
+							<TableHead>Agents</TableHead>
+							<TableHead>Last used</TableHead>
+							<TableHead />
+						</TableRow>
+					</TableHeader>
+					<TableBody>
+						{data?.apiKeys.map((key) => (
+							<TableRow key={key.id}>
+								<TableCell>
+									{key.name}
+									{key.id === session.apiKey?.id && (
+										<span className="text-muted-foreground"> (current)</span>
+									)}
+								</TableCell>
+								<TableCell className="font-mono text-sm">
+									{key.prefix}…
+								</TableCell>
+								<TableCell className="text-muted-foreground">
+									{key.scopes.length > 0 ? key.scopes.join(", ") : "All"}
+								</TableCell>
+								<TableCell className="text-muted-foreground">
+									{key.agentIds.length > 0
+										? key.agentIds.map(agentName).join(", ")
+										: "All"}
+								</TableCell>
+								<TableCell className="text-muted-foreground">
+									{key.lastUsedAt
+										? timestampDate(key.lastUsedAt).toLocaleString()
+										: "Never"}
+								</TableCell>
+								<TableCell className="text-right">
+									{key.revokedAt ? (
+										<span className="text-muted-foreground">Revoked</span>
+									) : (
+										<Button
+											variant="outline"
+											size="sm"
+											onClick={() => handleRevoke(key.id)}
+											disabled={revokeMutation.isPending}
+										>
+											Revoke
+										</Button>
+									)}
+								</TableCell>
+							</TableRow>
+						))}
+					</TableBody>
+				</Table>
+			</CardContent>
+		</Card>
+	);
+}
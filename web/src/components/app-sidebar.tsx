@@ -1,5 +1,18 @@
+import { useMutation, useQuery } from "@connectrpc/connect-query";
 import { Link, useRouterState } from "@tanstack/react-router";
-import { Bell, Bot, Clock, HardDrive, Moon, Plus, Sun } from "lucide-react";
+import {
+	Activity,
+	ArrowUpCircle,
+	Bell,
+	Bot,
+	Clock,
+	HardDrive,
+	LogOut,
+	Moon,
+	Plus,
+	Settings,
+	Sun,
+} from "lucide-react";
 import { BlippyLogo } from "@/components/blippy-logo";
 import { useTheme } from "@/components/theme-provider";
 import { Button } from "@/components/ui/button";
@@ -15,6 +28,11 @@ import {
 	SidebarMenuButton,
 	SidebarMenuItem,
 } from "@/components/ui/sidebar";
+import {
+	getSession,
+	logout,
+} from "@/lib/rpc/auth/auth-AuthService_connectquery";
+import { getVersion } from "@/lib/rpc/system/system-SystemService_connectquery";
 
 export function AppSidebar() {
 	const { theme, setTheme } = useTheme();
@@ -30,6 +48,19 @@ export function AppSidebar() {
 		setTheme(theme === "dark" ? "light" : theme === "light" ? "dark" : "light");
 	};
 
+	const { data: session } = useQuery(getSession, {});
+	const logoutMutation = useMutation(logout);
+	const { data: version } = useQuery(
+		getVersion,
+		{},
+		{ refetchInterval: 60 * 60_000 },
+	);
+
+	const handleLogout = async () => {
+		await logoutMutation.mutateAsync({});
+		window.location.assign("/login");
+	};
+
 	return (
 		<Sidebar>
 			<SidebarHeader>
@@ -74,6 +105,14 @@ export function AppSidebar() {
 					<SidebarGroupLabel>Automation</SidebarGroupLabel>
 					<SidebarGroupContent>
 						<SidebarMenu>
+							<SidebarMenuItem>
+								<SidebarMenuButton asChild isActive={isActive("/activity")}>
+									<Link to="/activity">
+										<Activity className="size-4" />
+										<span>Activity</span>
+									</Link>
+								</SidebarMenuButton>
+							</SidebarMenuItem>
 							<SidebarMenuItem>
 								<SidebarMenuButton asChild isActive={isActive("/triggers")}>
 									<Link to="/triggers">
@@ -108,6 +147,28 @@ export function AppSidebar() {
 
 			<SidebarFooter className="mt-auto pb-[env(safe-area-inset-bottom)] md:pb-2">
 				<SidebarMenu>
+					{version?.updateAvailable && (
+						<SidebarMenuItem>
+							<SidebarMenuButton asChild>
+								<a
+									href={version.releaseUrl}
+									target="_blank"
+									rel="noreferrer"
+								>
+									<ArrowUpCircle className="size-4" />
+									<span>Update available: {version.latestVersion}</span>
+								</a>
+							</SidebarMenuButton>
+						</SidebarMenuItem>
+					)}
+					<SidebarMenuItem>
+						<SidebarMenuButton asChild isActive={isActive("/settings")}>
+							<Link to="/settings">
+								<Settings className="size-4" />
+								<span>Settings</span>
+							</Link>
+						</SidebarMenuButton>
+					</SidebarMenuItem>
 					<SidebarMenuItem>
 						<SidebarMenuButton asChild>
 							<Button
@@ -124,6 +185,21 @@ export function AppSidebar() {
 							</Button>
 						</SidebarMenuButton>
 					</SidebarMenuItem>
+					{session && !session.authDisabled && (
+						<SidebarMenuItem>
+							<SidebarMenuButton asChild>
+								<Button
+									variant="ghost"
+									className="w-full justify-start"
+									onClick={handleLogout}
+									disabled={logoutMutation.isPending}
+								>
+									<LogOut className="size-4" />
+									<span>Log Out</span>
+								</Button>
+							</SidebarMenuButton>
+						</SidebarMenuItem>
+					)}
 				</SidebarMenu>
 			</SidebarFooter>
 		</Sidebar>
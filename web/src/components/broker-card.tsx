@@ -0,0 +1,93 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useQuery } from "@connectrpc/connect-query";
+import { Radio } from "lucide-react";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import {
+	Table,
+	TableBody,
+	TableCell,
+	TableHead,
+	TableHeader,
+	TableRow,
+} from "@/components/ui/table";
+import { getSession } from "@/lib/rpc/auth/auth-AuthService_connectquery";
+import { getBrokerStats } from "@/lib/rpc/system/system-SystemService_connectquery";
+
+export function BrokerCard() {
+	const { data: session } = useQuery(getSession, {});
+	const canView = session?.role === "admin" || session?.authDisabled;
+	const { data } = useQuery(
+		getBrokerStats,
+		{},
+		{ enabled: canView, refetchInterval: 5_000 },
+	);
+
+	if (!canView || !data) {
+		return null;
+	}
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<Radio className="h-4 w-4" />
+					Live Updates
+				</CardTitle>
+				<CardDescription>
+					Clients subscribed to events. Subscribers that drop events don't
+					keep up and miss updates
+				</CardDescription>
+			</CardHeader>
+			<CardContent className="space-y-4">
+				<p className="text-sm text-muted-foreground">
+					Published: {data.publishedEvents.toString()} · Relayed:{" "}
+					{data.relayedEvents.toString()} · Dropped:{" "}
+					{data.droppedEvents.toString()} · Busy conversations:{" "}
+					{data.busyConversationIds.length}
+				</p>
+				{data.subscriptions.length > 0 && (
+					<Table>
+						<TableHeader>
+							<TableRow>
+								<TableHead>Topics</TableHead>
+								<TableHead>Since</TableHead>
+								<TableHead className="text-right">Buffered</TableHead>
+								<TableHead className="text-right">Dropped</TableHead>
+							</TableRow>
+						</TableHeader>
+						<TableBody>
+							{data.subscriptions.map((sub, i) => (
+								// Subscriptions have no ID; the list is ordered by creation.
+								// biome-ignore lint/suspicious/noArrayIndexKey: see above
+								<TableRow key={i}>
+									<TableCell className="font-mono text-sm">
+										{sub.topics.join(", ")}
+									</TableCell>
+									<TableCell className="text-muted-foreground">
+										{sub.createdAt
+											? timestampDate(sub.createdAt).toLocaleString()
+											: "—"}
+									</TableCell>
+									<TableCell className="text-right tabular-nums">
+										{sub.buffered}/{sub.capacity}
+									</TableCell>
+									<TableCell
+										className={`text-right tabular-nums ${sub.dropped > 0n ? "text-destructive" : ""}`}
+									>
+										{sub.dropped.toString()}
+									</TableCell>
+								</TableRow>
+							))}
+						</TableBody>
+					</Table>
+				)}
+			</CardContent>
+		</Card>
+	);
+}
@@ -0,0 +1,47 @@
+import { Button } from "@/components/ui/button";
+import type { ApprovalRequested } from "@/lib/rpc/conversation/conversation_pb";
+
+export function PendingApprovals({
+	approvals,
+	onResolve,
+}: {
+	approvals: ApprovalRequested[];
+	onResolve: (approvalId: string, approved: boolean) => void;
+}) {
+	return (
+		<div className="space-y-2">
+			{approvals.map((approval) => (
+				<div
+					key={approval.approvalId}
+					className="rounded-md border border-amber-500/50 bg-amber-500/5 px-3 py-2 text-xs"
+				>
+					<div className="font-medium">
+						{approval.agentName || "The agent"} wants to call{" "}
+						<code>{approval.toolName}</code>
+					</div>
+					<p className="mt-1 text-muted-foreground">
+						Needs approval because {approval.reason}.
+					</p>
+					<pre className="mt-2 max-h-40 overflow-auto whitespace-pre-wrap break-all rounded bg-muted p-2">
+						{approval.input}
+					</pre>
+					<div className="mt-2 flex gap-2">
+						<Button
+							size="sm"
+							onClick={() => onResolve(approval.approvalId, true)}
+						>
+							Approve
+						</Button>
+						<Button
+							size="sm"
+							variant="outline"
+							onClick={() => onResolve(approval.approvalId, false)}
+						>
+							Deny
+						</Button>
+					</div>
+				</div>
+			))}
+		</div>
+	);
+}
@@ -0,0 +1,35 @@
+export interface SubagentRun {
+	runId: string;
+	agentName: string;
+	text: string;
+	toolCalls: number;
+}
+
+// Number of trailing characters of a subagent's text to show.
+const maxTextLength = 300;
+
+export function SubagentActivity({ runs }: { runs: SubagentRun[] }) {
+	return (
+		<div className="space-y-2">
+			{runs.map((run) => (
+				<div
+					key={run.runId}
+					className="rounded-md border border-dashed px-3 py-2 text-xs text-muted-foreground"
+				>
+					<div className="font-medium text-foreground">
+						{run.agentName || "Subagent"} is working
+						{run.toolCalls > 0 &&
+							` · ${run.toolCalls} tool call${run.toolCalls === 1 ? "" : "s"}`}
+					</div>
+					{run.text && (
+						<p className="mt-1 whitespace-pre-wrap">
+							{run.text.length > maxTextLength
+								? `…${run.text.slice(-maxTextLength)}`
+								: run.text}
+						</p>
+					)}
+				</div>
+			))}
+		</div>
+	);
+}
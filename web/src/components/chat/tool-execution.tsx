@@ -1,4 +1,4 @@
-import { Check, ChevronDown, Copy, Terminal } from "lucide-react";
+import { Check, ChevronDown, Copy, Loader2, Terminal } from "lucide-react";
 import { useState } from "react";
 import { Button } from "@/components/ui/button";
 import {
@@ -12,9 +12,16 @@ interface ToolExecutionProps {
 	name: string;
 	input?: string;
 	result?: string;
+	// Shows a spinner while the call is being streamed or executed.
+	running?: boolean;
 }
 
-export function ToolExecution({ name, input, result }: ToolExecutionProps) {
+export function ToolExecution({
+	name,
+	input,
+	result,
+	running,
+}: ToolExecutionProps) {
 	const [isOpen, setIsOpen] = useState(true);
 	const [copied, setCopied] = useState(false);
 
@@ -44,7 +51,11 @@ export function ToolExecution({ name, input, result }: ToolExecutionProps) {
 						className="flex w-full items-center justify-between p-3 text-left"
 					>
 						<div className="flex items-center gap-2 text-muted-foreground">
-							<Terminal className="h-4 w-4" />
+							{running ? (
+								<Loader2 className="h-4 w-4 animate-spin" />
+							) : (
+								<Terminal className="h-4 w-4" />
+							)}
 							<span className="font-medium">{name}</span>
 						</div>
 						<ChevronDown
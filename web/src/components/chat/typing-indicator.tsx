@@ -1,9 +1,29 @@
-export function TypingIndicator() {
+import type { TurnProgress } from "@/lib/rpc/conversation/conversation_pb";
+
+export function TypingIndicator({ progress }: { progress?: TurnProgress }) {
 	return (
 		<div className="flex items-center gap-1 py-1">
 			<div className="h-2 w-2 animate-bounce rounded-full bg-muted-foreground [animation-delay:-0.3s]" />
 			<div className="h-2 w-2 animate-bounce rounded-full bg-muted-foreground [animation-delay:-0.15s]" />
 			<div className="h-2 w-2 animate-bounce rounded-full bg-muted-foreground" />
+			{progress && (
+				<span className="ml-2 text-xs text-muted-foreground">
+					{formatProgress(progress)}
+				</span>
+			)}
 		</div>
 	);
 }
+
+function formatProgress(progress: TurnProgress) {
+	const parts = [];
+	if (progress.tools.length > 0) {
+		parts.push(`Running ${progress.tools.join(", ")}`);
+	}
+	parts.push(`${Math.round(Number(progress.elapsedMs) / 1000)}s`);
+	const tokens = progress.inputTokens + progress.outputTokens;
+	if (tokens > 0n) {
+		parts.push(`${tokens.toLocaleString()} tokens`);
+	}
+	return parts.join(" · ");
+}
@@ -0,0 +1,63 @@
+import { Link } from "@tanstack/react-router";
+import { MessageSquare } from "lucide-react";
+import type {
+	ConversationSearchResult,
+	SearchSnippet,
+} from "@/lib/rpc/conversation/conversation_pb";
+
+interface ConversationSearchResultsProps {
+	results: ConversationSearchResult[];
+	agentId: string;
+}
+
+export function ConversationSearchResults({
+	results,
+	agentId,
+}: ConversationSearchResultsProps) {
+	return (
+		<ul className="divide-y rounded-md border">
+			{results.map(({ conversation, snippets }) =>
+				conversation ? (
+					<li key={conversation.id} className="space-y-1 p-3">
+						<Link
+							to="/agents/$agentId/$conversationId"
+							params={{ agentId, conversationId: conversation.id }}
+							className="flex items-center gap-2 font-medium hover:underline"
+						>
+							<MessageSquare className="h-4 w-4 shrink-0 text-muted-foreground" />
+							<span className="truncate">
+								{conversation.title ||
+									`Conversation ${conversation.id.slice(0, 8)}`}
+							</span>
+						</Link>
+						{snippets
+							.filter((s) => s.messageId !== "")
+							.map((snippet) => (
+								<Snippet key={snippet.messageId} snippet={snippet} />
+							))}
+					</li>
+				) : null,
+			)}
+		</ul>
+	);
+}
+
+function Snippet({ snippet }: { snippet: SearchSnippet }) {
+	return (
+		<p className="line-clamp-2 text-sm text-muted-foreground">
+			{snippet.parts.map((part, i) =>
+				part.match ? (
+					// biome-ignore lint/suspicious/noArrayIndexKey: parts are static
+					<mark
+						key={i}
+						className="rounded-sm bg-yellow-200 dark:bg-yellow-800"
+					>
+						{part.text}
+					</mark>
+				) : (
+					part.text
+				),
+			)}
+		</p>
+	);
+}
@@ -69,6 +69,8 @@ function useBreadcrumbs() {
 		} else if (segments[1]) {
 			breadcrumbs.push({ label: "Channel" });
 		}
+	} else if (segments[0] === "settings") {
+		breadcrumbs.push({ label: "Settings" });
 	}
 
 	return breadcrumbs;
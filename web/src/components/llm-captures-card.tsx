@@ -0,0 +1,157 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { Bug } from "lucide-react";
+import { useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import {
+	Select,
+	SelectContent,
+	SelectItem,
+	SelectTrigger,
+	SelectValue,
+} from "@/components/ui/select";
+import {
+	Table,
+	TableBody,
+	TableCell,
+	TableHead,
+	TableHeader,
+	TableRow,
+} from "@/components/ui/table";
+import { listAgents } from "@/lib/rpc/agent/agent-AgentService_connectquery";
+import { getSession } from "@/lib/rpc/auth/auth-AuthService_connectquery";
+import {
+	createLLMCapture,
+	deleteLLMCapture,
+	listLLMCaptures,
+} from "@/lib/rpc/system/system-SystemService_connectquery";
+
+export function LLMCapturesCard() {
+	const { data: session } = useQuery(getSession, {});
+	const canManage = session?.role === "admin" || session?.authDisabled;
+	const { data, refetch } = useQuery(
+		listLLMCaptures,
+		{},
+		{ enabled: canManage, refetchInterval: 30_000 },
+	);
+	const { data: agentsData } = useQuery(
+		listAgents,
+		{},
+		{ enabled: canManage },
+	);
+	const createMutation = useMutation(createLLMCapture);
+	const deleteMutation = useMutation(deleteLLMCapture);
+	const [agentId, setAgentId] = useState("");
+
+	if (!canManage || !data) {
+		return null;
+	}
+
+	const agentName = (id: string) =>
+		agentsData?.agents.find((a) => a.id === id)?.name ?? id;
+
+	const handleStart = async (e: React.FormEvent) => {
+		e.preventDefault();
+		try {
+			await createMutation.mutateAsync({ agentId });
+			setAgentId("");
+			toast.success("Capture started");
+			refetch();
+		} catch {
+			toast.error("Failed to start capture");
+		}
+	};
+
+	const handleStop = async (id: string) => {
+		try {
+			await deleteMutation.mutateAsync({ id });
+			toast.success("Capture stopped");
+			refetch();
+		} catch {
+			toast.error("Failed to stop capture");
+		}
+	};
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<Bug className="h-4 w-4" />
+					LLM Captures
+				</CardTitle>
+				<CardDescription>
+					Stores the raw LLM requests and responses of an agent for an hour,
+					with secrets redacted, to debug tool schemas and provider errors
+				</CardDescription>
+			</CardHeader>
+			<CardContent className="space-y-4">
+				{data.captures.length > 0 && (
+					<Table>
+						<TableHeader>
+							<TableRow>
+								<TableHead>Agent or conversation</TableHead>
+								<TableHead>Until</TableHead>
+								<TableHead />
+							</TableRow>
+						</TableHeader>
+						<TableBody>
+							{data.captures.map((capture) => (
+								<TableRow key={capture.id}>
+									<TableCell>
+										{capture.agentId
+											? agentName(capture.agentId)
+											: capture.conversationId}
+									</TableCell>
+									<TableCell className="text-muted-foreground">
+										{capture.expiresAt
+											? timestampDate(capture.expiresAt).toLocaleString()
+											: "—"}
+									</TableCell>
+									<TableCell className="text-right">
+										<Button
+											variant="ghost"
+											size="sm"
+											onClick={() => handleStop(capture.id)}
+											disabled={deleteMutation.isPending}
+										>
+											Stop
+										</Button>
+									</TableCell>
+								</TableRow>
+							))}
+						</TableBody>
+					</Table>
+				)}
+				<form onSubmit={handleStart} className="flex gap-2">
+					<Select value={agentId} onValueChange={setAgentId}>
+						<SelectTrigger aria-label="Agent">
+							<SelectValue placeholder="Select an agent" />
+						</SelectTrigger>
+						<SelectContent>
+							{agentsData?.agents.map((agent) => (
+								<SelectItem key={agent.id} value={agent.id}>
+									{agent.name}
+								</SelectItem>
+							))}
+						</SelectContent>
+					</Select>
+					<Button
+						type="submit"
+						variant="outline"
+						disabled={!agentId || createMutation.isPending}
+					>
+						Start
+					</Button>
+				</form>
+			</CardContent>
+		</Card>
+	);
+}
@@ -0,0 +1,108 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { Wrench } from "lucide-react";
+import { useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { getSession } from "@/lib/rpc/auth/auth-AuthService_connectquery";
+import {
+	getMaintenanceMode,
+	updateMaintenanceMode,
+} from "@/lib/rpc/system/system-SystemService_connectquery";
+
+export function MaintenanceCard() {
+	const { data: session } = useQuery(getSession, {});
+	const canManage = session?.role === "admin" || session?.authDisabled;
+	const { data, refetch } = useQuery(
+		getMaintenanceMode,
+		{},
+		{ enabled: canManage, refetchInterval: 5_000 },
+	);
+	const updateMutation = useMutation(updateMaintenanceMode);
+	const [reason, setReason] = useState("");
+
+	if (!canManage || !data) {
+		return null;
+	}
+
+	const handleToggle = async (e: React.FormEvent) => {
+		e.preventDefault();
+		try {
+			const res = await updateMutation.mutateAsync({
+				enabled: !data.enabled,
+				reason: reason.trim(),
+				// Give running turns a moment to finish before reporting back.
+				waitSeconds: data.enabled ? 0 : 30,
+			});
+			if (res.enabled && res.activeTurns > 0) {
+				toast.warning(`${res.activeTurns} agent turn(s) still running`);
+			} else {
+				toast.success(
+					res.enabled
+						? "Maintenance mode enabled"
+						: "Maintenance mode disabled",
+				);
+			}
+			setReason("");
+			refetch();
+		} catch {
+			toast.error("Failed to update maintenance mode");
+		}
+	};
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<Wrench className="h-4 w-4" />
+					Maintenance Mode
+				</CardTitle>
+				<CardDescription>
+					Pauses triggers and rejects new chat messages and webhook runs, so
+					running turns can finish before a backup or upgrade
+				</CardDescription>
+			</CardHeader>
+			<CardContent className="space-y-4">
+				{data.enabled && (
+					<div className="space-y-1 rounded-lg border border-yellow-500/50 bg-yellow-500/10 p-4 text-sm">
+						<p>
+							Enabled
+							{data.since &&
+								` since ${timestampDate(data.since).toLocaleString()}`}
+							{data.reason && `: ${data.reason}`}
+						</p>
+						<p className="text-muted-foreground">
+							{data.activeTurns > 0
+								? `Waiting for ${data.activeTurns} agent turn(s) to finish`
+								: "No agent turns running; safe to proceed"}
+						</p>
+					</div>
+				)}
+				<form onSubmit={handleToggle} className="flex gap-2">
+					{!data.enabled && (
+						<Input
+							placeholder="Reason shown to users (optional)"
+							value={reason}
+							onChange={(e) => setReason(e.target.value)}
+						/>
+					)}
+					<Button
+						type="submit"
+						variant={data.enabled ? "default" : "outline"}
+						disabled={updateMutation.isPending}
+					>
+						{data.enabled ? "Disable" : "Enable"}
+					</Button>
+				</form>
+			</CardContent>
+		</Card>
+	);
+}
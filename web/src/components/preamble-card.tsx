@@ -0,0 +1,90 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { ScrollText } from "lucide-react";
+import { useEffect, useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import { Textarea } from "@/components/ui/textarea";
+import { getSession } from "@/lib/rpc/auth/auth-AuthService_connectquery";
+import {
+	getInstancePreamble,
+	updateInstancePreamble,
+} from "@/lib/rpc/system/system-SystemService_connectquery";
+
+export function PreambleCard() {
+	const { data: session } = useQuery(getSession, {});
+	const canManage = session?.role === "admin" || session?.authDisabled;
+	const { data, refetch } = useQuery(
+		getInstancePreamble,
+		{},
+		{ enabled: canManage },
+	);
+	const updateMutation = useMutation(updateInstancePreamble);
+	const [text, setText] = useState("");
+
+	useEffect(() => {
+		if (data) {
+			setText(data.text);
+		}
+	}, [data]);
+
+	if (!canManage || !data) {
+		return null;
+	}
+
+	const handleSubmit = async (e: React.FormEvent) => {
+		e.preventDefault();
+		try {
+			await updateMutation.mutateAsync({ text });
+			toast.success("Preamble saved");
+			refetch();
+		} catch {
+			toast.error("Failed to save preamble");
+		}
+	};
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<ScrollText className="h-4 w-4" />
+					Instance Preamble
+				</CardTitle>
+				<CardDescription>
+					Guidance for all agents, such as organization policies, prepended to
+					their instructions on every turn. {"{date}"} is replaced with the
+					current date
+				</CardDescription>
+			</CardHeader>
+			<CardContent>
+				<form onSubmit={handleSubmit} className="space-y-2">
+					<Textarea
+						rows={5}
+						placeholder="Today is {date}. Follow the ACME data handling policy..."
+						value={text}
+						onChange={(e) => setText(e.target.value)}
+					/>
+					<div className="flex items-center justify-between gap-2">
+						<p className="text-sm text-muted-foreground">
+							{data.updatedAt &&
+								`Updated ${timestampDate(data.updatedAt).toLocaleString()}`}
+						</p>
+						<Button
+							type="submit"
+							disabled={updateMutation.isPending || text === data.text}
+						>
+							Save
+						</Button>
+					</div>
+				</form>
+			</CardContent>
+		</Card>
+	);
+}
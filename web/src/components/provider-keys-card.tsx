@@ -0,0 +1,166 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { KeyRound } from "lucide-react";
+import { useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import {
+	Table,
+	TableBody,
+	TableCell,
+	TableHead,
+	TableHeader,
+	TableRow,
+} from "@/components/ui/table";
+import { getSession } from "@/lib/rpc/auth/auth-AuthService_connectquery";
+import {
+	createProviderKey,
+	deleteProviderKey,
+	listProviderKeys,
+} from "@/lib/rpc/system/system-SystemService_connectquery";
+
+export function ProviderKeysCard() {
+	const { data: session } = useQuery(getSession, {});
+	const canManage = session?.role === "admin" || session?.authDisabled;
+	const { data, refetch } = useQuery(
+		listProviderKeys,
+		{},
+		{ enabled: canManage, refetchInterval: 10_000 },
+	);
+	const createMutation = useMutation(createProviderKey);
+	const deleteMutation = useMutation(deleteProviderKey);
+	const [apiKey, setApiKey] = useState("");
+	const [weight, setWeight] = useState("1");
+
+	if (!canManage || !data) {
+		return null;
+	}
+
+	const handleAdd = async (e: React.FormEvent) => {
+		e.preventDefault();
+		try {
+			await createMutation.mutateAsync({
+				apiKey: apiKey.trim(),
+				weight: Number.parseInt(weight, 10) || 1,
+			});
+			setApiKey("");
+			setWeight("1");
+			toast.success("API key added");
+			refetch();
+		} catch {
+			toast.error("Failed to add API key");
+		}
+	};
+
+	const handleRemove = async (id: string) => {
+		if (!confirm("Stop using this API key?")) return;
+		try {
+			await deleteMutation.mutateAsync({ id });
+			toast.success("API key removed");
+			refetch();
+		} catch {
+			toast.error("Failed to remove API key");
+		}
+	};
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<KeyRound className="h-4 w-4" />
+					OpenRouter Keys
+				</CardTitle>
+				<CardDescription>
+					Requests are spread over the keys by weight. Changes apply until the
+					server restarts; update OPENROUTER_API_KEY to keep them
+				</CardDescription>
+			</CardHeader>
+			<CardContent className="space-y-4">
+				<Table>
+					<TableHeader>
+						<TableRow>
+							<TableHead>Key</TableHead>
+							<TableHead className="text-right">Weight</TableHead>
+							<TableHead className="text-right">Requests</TableHead>
+							<TableHead className="text-right">Failures</TableHead>
+							<TableHead className="text-right">Tokens</TableHead>
+							<TableHead>Last used</TableHead>
+							<TableHead />
+						</TableRow>
+					</TableHeader>
+					<TableBody>
+						{data.keys.map((key) => (
+							<TableRow key={key.id}>
+								<TableCell className="font-mono text-sm">{key.hint}</TableCell>
+								<TableCell className="text-right tabular-nums">
+									{key.weight}
+								</TableCell>
+								<TableCell className="text-right tabular-nums">
+									{key.requests.toString()}
+								</TableCell>
+								<TableCell
+									className={`text-right tabular-nums ${key.failures > 0n ? "text-destructive" : ""}`}
+									title={`${key.rateLimited} rate limited`}
+								>
+									{key.failures.toString()}
+								</TableCell>
+								<TableCell className="text-right tabular-nums">
+									{(key.inputTokens + key.outputTokens).toString()}
+								</TableCell>
+								<TableCell className="text-muted-foreground">
+									{key.lastUsedAt
+										? timestampDate(key.lastUsedAt).toLocaleString()
+										: "—"}
+								</TableCell>
+								<TableCell className="text-right">
+									{data.keys.length > 1 && (
+										<Button
+											variant="ghost"
+											size="sm"
+											onClick={() => handleRemove(key.id)}
+											disabled={deleteMutation.isPending}
+										>
+											Remove
+										</Button>
+									)}
+								</TableCell>
+							</TableRow>
+						))}
+					</TableBody>
+				</Table>
+				<form onSubmit={handleAdd} className="flex gap-2">
+					<Input
+						type="password"
+						placeholder="sk-or-v1-..."
+						value={apiKey}
+						onChange={(e) => setApiKey(e.target.value)}
+						autoComplete="off"
+					/>
+					<Input
+						type="number"
+						min={1}
+						className="w-20"
+						value={weight}
+						onChange={(e) => setWeight(e.target.value)}
+						aria-label="Weight"
+					/>
+					<Button
+						type="submit"
+						variant="outline"
+						disabled={!apiKey.trim() || createMutation.isPending}
+					>
+						Add
+					</Button>
+				</form>
+			</CardContent>
+		</Card>
+	);
+}
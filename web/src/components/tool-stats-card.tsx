@@ -0,0 +1,84 @@
+import { useQuery } from "@connectrpc/connect-query";
+import { Wrench } from "lucide-react";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import {
+	Table,
+	TableBody,
+	TableCell,
+	TableHead,
+	TableHeader,
+	TableRow,
+} from "@/components/ui/table";
+import { getAgentToolStats } from "@/lib/rpc/agent/agent-AgentService_connectquery";
+
+export function ToolStatsCard({ agentId }: { agentId: string }) {
+	const { data } = useQuery(getAgentToolStats, { agentId });
+
+	if (!data || data.tools.length === 0) {
+		return null;
+	}
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<Wrench className="h-4 w-4" />
+					Tool Usage
+				</CardTitle>
+				<CardDescription>
+					Tool calls of the last week ({data.runs.toString()} runs). Enabled
+					tools that aren't called still add their schemas to every turn
+				</CardDescription>
+			</CardHeader>
+			<CardContent>
+				<Table>
+					<TableHeader>
+						<TableRow>
+							<TableHead>Tool</TableHead>
+							<TableHead className="text-right">Calls</TableHead>
+							<TableHead className="text-right">Errors</TableHead>
+							<TableHead className="text-right">Avg. time</TableHead>
+							<TableHead className="text-right">Avg. result</TableHead>
+						</TableRow>
+					</TableHeader>
+					<TableBody>
+						{data.tools.map((t) => (
+							<TableRow key={t.name}>
+								<TableCell className="font-mono text-sm">
+									{t.name}
+									{!t.enabled && (
+										<span className="ml-2 text-xs text-muted-foreground">
+											not enabled
+										</span>
+									)}
+								</TableCell>
+								<TableCell
+									className={`text-right tabular-nums ${t.calls === 0n ? "text-muted-foreground" : ""}`}
+								>
+									{t.calls.toString()}
+								</TableCell>
+								<TableCell
+									className={`text-right tabular-nums ${t.errorRate > 0.2 ? "text-destructive" : ""}`}
+								>
+									{Math.round(t.errorRate * 100)}%
+								</TableCell>
+								<TableCell className="text-right tabular-nums">
+									{t.avgDurationMs.toString()} ms
+								</TableCell>
+								<TableCell className="text-right tabular-nums">
+									{t.avgResultBytes.toString()} B
+								</TableCell>
+							</TableRow>
+						))}
+					</TableBody>
+				</Table>
+			</CardContent>
+		</Card>
+	);
+}
@@ -0,0 +1,110 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useQuery } from "@connectrpc/connect-query";
+import { Link } from "@tanstack/react-router";
+import { History } from "lucide-react";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import {
+	Table,
+	TableBody,
+	TableCell,
+	TableHead,
+	TableHeader,
+	TableRow,
+} from "@/components/ui/table";
+import { listTriggerRuns } from "@/lib/rpc/trigger/trigger-TriggerService_connectquery";
+
+function formatDuration(ms: bigint) {
+	const seconds = Number(ms) / 1000;
+	if (seconds < 60) {
+		return `${seconds}s`;
+	}
+	return `${Math.floor(seconds / 60)}m ${Math.round(seconds % 60)}s`;
+}
+
+export function TriggerRunsCard({
+	triggerId,
+	agentId,
+}: {
+	triggerId: string;
+	agentId: string;
+}) {
+	const { data } = useQuery(
+		listTriggerRuns,
+		{ triggerId, pageSize: 20 },
+		{ refetchInterval: 10_000 },
+	);
+
+	if (!data || data.triggerRuns.length === 0) {
+		return null;
+	}
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<History className="h-4 w-4" />
+					Recent Runs
+				</CardTitle>
+				<CardDescription>
+					The last {data.triggerRuns.length} of {data.totalSize} runs
+				</CardDescription>
+			</CardHeader>
+			<CardContent>
+				<Table>
+					<TableHeader>
+						<TableRow>
+							<TableHead>Started</TableHead>
+							<TableHead>Status</TableHead>
+							<TableHead className="text-right">Duration</TableHead>
+							<TableHead>Error</TableHead>
+						</TableRow>
+					</TableHeader>
+					<TableBody>
+						{data.triggerRuns.map((run) => (
+							<TableRow key={run.id}>
+								<TableCell>
+									{run.conversationId ? (
+										<Link
+											to="/agents/$agentId/$conversationId"
+											params={{ agentId, conversationId: run.conversationId }}
+											className="hover:underline"
+										>
+											{run.startedAt
+												? timestampDate(run.startedAt).toLocaleString()
+												: "—"}
+										</Link>
+									) : run.startedAt ? (
+										timestampDate(run.startedAt).toLocaleString()
+									) : (
+										"—"
+									)}
+								</TableCell>
+								<TableCell
+									className={
+										run.status === "completed" || run.status === "running"
+											? ""
+											: "text-destructive"
+									}
+								>
+									{run.status}
+								</TableCell>
+								<TableCell className="text-right tabular-nums">
+									{run.finishedAt ? formatDuration(run.durationMs) : "—"}
+								</TableCell>
+								<TableCell className="max-w-xs truncate text-muted-foreground">
+									{run.errorMessage || "—"}
+								</TableCell>
+							</TableRow>
+						))}
+					</TableBody>
+				</Table>
+			</CardContent>
+		</Card>
+	);
+}
@@ -0,0 +1,225 @@
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { Webhook } from "lucide-react";
+import { useEffect, useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+	Select,
+	SelectContent,
+	SelectItem,
+	SelectTrigger,
+	SelectValue,
+} from "@/components/ui/select";
+import {
+	createTriggerWebhook,
+	deleteTriggerWebhook,
+	getTriggerWebhook,
+	updateTriggerWebhook,
+} from "@/lib/rpc/trigger/trigger-TriggerService_connectquery";
+
+function parseIPs(value: string) {
+	return value
+		.split(",")
+		.map((ip) => ip.trim())
+		.filter(Boolean);
+}
+
+export function TriggerWebhookCard({ triggerId }: { triggerId: string }) {
+	// Triggers without a webhook fail with WEBHOOK_NOT_FOUND, which keeps the
+	// data of the last successful fetch, e.g. before the webhook was deleted.
+	const { data, error, refetch } = useQuery(
+		getTriggerWebhook,
+		{ triggerId },
+		{ retry: false },
+	);
+	const webhook = error ? undefined : data;
+	const createMutation = useMutation(createTriggerWebhook);
+	const updateMutation = useMutation(updateTriggerWebhook);
+	const deleteMutation = useMutation(deleteTriggerWebhook);
+
+	const [secret, setSecret] = useState("");
+	const [signatureScheme, setSignatureScheme] = useState("github");
+	const [allowedIps, setAllowedIps] = useState("");
+	const [createdUrl, setCreatedUrl] = useState("");
+
+	useEffect(() => {
+		if (webhook) {
+			setSignatureScheme(webhook.signatureScheme || "github");
+			setAllowedIps(webhook.allowedIps.join(", "));
+		}
+	}, [webhook]);
+
+	const handleCreate = async () => {
+		if (
+			webhook &&
+			!confirm("Create a new URL? The current URL stops working.")
+		) {
+			return;
+		}
+		try {
+			const res = await createMutation.mutateAsync({
+				triggerId,
+				secret,
+				signatureScheme,
+				allowedIps: parseIPs(allowedIps),
+			});
+			setCreatedUrl(window.location.origin + res.path);
+			setSecret("");
+			refetch();
+		} catch {
+			toast.error("Failed to create webhook");
+		}
+	};
+
+	const handleSave = async () => {
+		try {
+			await updateMutation.mutateAsync({
+				triggerId,
+				secret,
+				signatureScheme,
+				allowedIps: parseIPs(allowedIps),
+			});
+			setSecret("");
+			toast.success("Webhook updated");
+			refetch();
+		} catch {
+			toast.error("Failed to update webhook");
+		}
+	};
+
+	const handleRemoveSecret = async () => {
+		try {
+			await updateMutation.mutateAsync({
+				triggerId,
+				clearSecret: true,
+				signatureScheme,
+				allowedIps: parseIPs(allowedIps),
+			});
+			toast.success("Secret removed");
+			refetch();
+		} catch {
+			toast.error("Failed to remove secret");
+		}
+	};
+
+	const handleDelete = async () => {
+		if (!confirm("Are you sure you want to delete this webhook?")) return;
+		try {
+			await deleteMutation.mutateAsync({ triggerId });
+			setCreatedUrl("");
+			toast.success("Webhook deleted");
+			refetch();
+		} catch {
+			toast.error("Failed to delete webhook");
+		}
+	};
+
+	return (
+		<Card>
+			<CardHeader>
+				<CardTitle className="flex items-center gap-2 text-base">
+					<Webhook className="h-4 w-4" />
+					Webhook
+				</CardTitle>
+				<CardDescription>
+					A URL that runs this trigger, e.g. for GitHub or Stripe. The request
+					body is appended to the prompt.
+				</CardDescription>
+			</CardHeader>
+			<CardContent className="space-y-4">
+				{createdUrl && (
+					<div className="space-y-1 rounded-lg border border-yellow-500/50 bg-yellow-500/10 p-4 text-sm">
+						<p>Copy this URL now. It won't be shown again.</p>
+						<code className="block break-all font-mono">{createdUrl}</code>
+					</div>
+				)}
+
+				<div className="space-y-2">
+					<Label htmlFor="webhookSecret">Secret</Label>
+					<Input
+						id="webhookSecret"
+						type="password"
+						value={secret}
+						onChange={(e) => setSecret(e.target.value)}
+						placeholder={
+							webhook?.hasSecret ? "Leave empty to keep the secret" : ""
+						}
+						autoComplete="off"
+					/>
+					<p className="text-xs text-muted-foreground">
+						Requires requests to be signed with this secret (optional)
+					</p>
+				</div>
+
+				<div className="space-y-2">
+					<Label htmlFor="signatureScheme">Signature</Label>
+					<Select value={signatureScheme} onValueChange={setSignatureScheme}>
+						<SelectTrigger id="signatureScheme">
+							<SelectValue />
+						</SelectTrigger>
+						<SelectContent>
+							<SelectItem value="github">GitHub (X-Hub-Signature-256)</SelectItem>
+							<SelectItem value="stripe">Stripe (Stripe-Signature)</SelectItem>
+							<SelectItem value="blippy">Blippy (X-Blippy-Signature)</SelectItem>
+						</SelectContent>
+					</Select>
+				</div>
+
+				<div className="space-y-2">
+					<Label htmlFor="allowedIps">Allowed IPs</Label>
+					<Input
+						id="allowedIps"
+						value={allowedIps}
+						onChange={(e) => setAllowedIps(e.target.value)}
+						placeholder="192.30.252.0/22, 2001:db8::1"
+					/>
+					<p className="text-xs text-muted-foreground">
+						Comma-separated addresses or CIDR ranges; empty allows all
+					</p>
+				</div>
+
+				<div className="flex gap-2">
+					<Button
+						variant={webhook ? "outline" : "default"}
+						onClick={handleCreate}
+						disabled={createMutation.isPending}
+					>
+						{webhook ? "New URL" : "Create Webhook"}
+					</Button>
+					{webhook && (
+						<>
+							<Button onClick={handleSave} disabled={updateMutation.isPending}>
+								Save
+							</Button>
+							{webhook.hasSecret && (
+								<Button
+									variant="outline"
+									onClick={handleRemoveSecret}
+									disabled={updateMutation.isPending}
+								>
+									Remove Secret
+								</Button>
+							)}
+							<Button
+								variant="destructive"
+								onClick={handleDelete}
+								disabled={deleteMutation.isPending}
+							>
+								Delete
+							</Button>
+						</>
+					)}
+				</div>
+			</CardContent>
+		</Card>
+	);
+}
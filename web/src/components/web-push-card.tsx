@@ -0,0 +1,120 @@
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { BellRing } from "lucide-react";
+import { useEffect, useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import {
+	createWebPushSubscription,
+	deleteWebPushSubscription,
+	getWebPushConfig,
+} from "@/lib/rpc/notification/notification-NotificationChannelService_connectquery";
+
+const supported =
+	typeof window !== "undefined" &&
+	"serviceWorker" in navigator &&
+	"PushManager" in window;
+
+function urlBase64ToUint8Array(base64: string) {
+	const padded = base64.padEnd(base64.length + ((4 - (base64.length % 4)) % 4), "=");
+	const raw = atob(padded.replace(/-/g, "+").replace(/_/g, "/"));
+	return Uint8Array.from(raw, (c) => c.charCodeAt(0));
+}
+
+export function WebPushCard() {
+	const { data: config } = useQuery(getWebPushConfig, {}, { enabled: supported });
+	const createMutation = useMutation(createWebPushSubscription);
+	const deleteMutation = useMutation(deleteWebPushSubscription);
+	const [subscription, setSubscription] = useState<PushSubscription | null>(
+		null,
+	);
+
+	useEffect(() => {
+		if (!supported) return;
+		navigator.serviceWorker
+			.register("/sw.js")
+			.then((reg) => reg.pushManager.getSubscription())
+			.then(setSubscription)
+			.catch(() => setSubscription(null));
+	}, []);
+
+	const handleEnable = async () => {
+		if (!config) return;
+		try {
+			const permission = await Notification.requestPermission();
+			if (permission !== "granted") {
+				toast.error("Notification permission denied");
+				return;
+			}
+			const reg = await navigator.serviceWorker.ready;
+			const sub = await reg.pushManager.subscribe({
+				userVisibleOnly: true,
+				applicationServerKey: urlBase64ToUint8Array(config.publicKey),
+			});
+			const json = sub.toJSON();
+			await createMutation.mutateAsync({
+				endpoint: sub.endpoint,
+				p256dh: json.keys?.p256dh ?? "",
+				auth: json.keys?.auth ?? "",
+			});
+			setSubscription(sub);
+			toast.success("Browser notifications enabled");
+		} catch {
+			toast.error("Failed to enable browser notifications");
+		}
+	};
+
+	const handleDisable = async () => {
+		if (!subscription) return;
+		try {
+			await deleteMutation.mutateAsync({ endpoint: subscription.endpoint });
+			await subscription.unsubscribe();
+			setSubscription(null);
+			toast.success("Browser notifications disabled");
+		} catch {
+			toast.error("Failed to disable browser notifications");
+		}
+	};
+
+	if (!supported) {
+		return null;
+	}
+
+	return (
+		<Card>
+			<CardHeader className="flex flex-row items-center justify-between gap-4">
+				<div className="space-y-1">
+					<CardTitle className="flex items-center gap-2 text-base">
+						<BellRing className="h-4 w-4" />
+						Browser Notifications
+					</CardTitle>
+					<CardDescription>
+						Get notified in this browser when autonomous runs finish, and
+						receive messages from web push channels
+					</CardDescription>
+				</div>
+				{subscription ? (
+					<Button
+						variant="outline"
+						onClick={handleDisable}
+						disabled={deleteMutation.isPending}
+					>
+						Disable
+					</Button>
+				) : (
+					<Button
+						onClick={handleEnable}
+						disabled={!config || createMutation.isPending}
+					>
+						Enable
+					</Button>
+				)}
+			</CardHeader>
+		</Card>
+	);
+}
@@ -1,5 +1,54 @@
+import { Code, ConnectError, type Interceptor } from "@connectrpc/connect";
 import { createConnectTransport } from "@connectrpc/connect-web";
+import { ErrorCode, ErrorDetailSchema } from "@/lib/rpc/apierror/apierror_pb";
+
+// redirectToLogin sends the user to the login page when their session is
+// missing or expired.
+const redirectToLogin: Interceptor = (next) => async (req) => {
+	try {
+		return await next(req);
+	} catch (err) {
+		if (
+			ConnectError.from(err).code === Code.Unauthenticated &&
+			window.location.pathname !== "/login"
+		) {
+			const redirect = window.location.pathname + window.location.search;
+			window.location.assign(`/login?redirect=${encodeURIComponent(redirect)}`);
+		}
+		throw err;
+	}
+};
+
+// csrfToken returns the session's CSRF token, which the server sets as a
+// cookie readable by the web UI.
+function csrfToken() {
+	const match = document.cookie.match(/(?:^|;\s*)blippy_csrf=([^;]*)/);
+	return match ? decodeURIComponent(match[1]) : "";
+}
+
+// sendCSRFToken echoes the CSRF token, which the server requires on mutating
+// requests authenticated with the session cookie.
+const sendCSRFToken: Interceptor = (next) => async (req) => {
+	const token = csrfToken();
+	if (token) {
+		req.header.set("X-CSRF-Token", token);
+	}
+	return await next(req);
+};
 
 export const transport = createConnectTransport({
 	baseUrl: "/api",
+	interceptors: [redirectToLogin, sendCSRFToken],
 });
+
+// errorCode returns the machine-readable code of an API error.
+export function errorCode(err: unknown) {
+	const [detail] = ConnectError.from(err).findDetails(ErrorDetailSchema);
+	return detail?.code ?? ErrorCode.UNSPECIFIED;
+}
+
+// isStaleVersionError reports whether an update failed because the entity was
+// modified since it was loaded.
+export function isStaleVersionError(err: unknown) {
+	return errorCode(err) === ErrorCode.VERSION_CONFLICT;
+}
@@ -33,3 +33,27 @@ export const deleteAgent = AgentService.method.deleteAgent;
  * @generated from rpc blippy.agent.AgentService.ListModels
  */
 export const listModels = AgentService.method.listModels;
+
+/**
+ * GetAgentToolStats aggregates the tool calls of an agent's runs from
+ * their traces, to find unused and failing tools.
+ *
+ * @generated from rpc blippy.agent.AgentService.GetAgentToolStats
+ */
+export const getAgentToolStats = AgentService.method.getAgentToolStats;
+
+/**
+ * ExportAgent serializes an agent and its cron triggers, to move it
+ * between instances or keep it in version control.
+ *
+ * @generated from rpc blippy.agent.AgentService.ExportAgent
+ */
+export const exportAgent = AgentService.method.exportAgent;
+
+/**
+ * ImportAgent creates or updates an agent from a bundle. Triggers are
+ * matched by name.
+ *
+ * @generated from rpc blippy.agent.AgentService.ImportAgent
+ */
+export const importAgent = AgentService.method.importAgent;
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file agent/agent.proto.
  */
 export const file_agent_agent: GenFile = /*@__PURE__*/
-  fileDesc("ChFhZ2VudC9hZ2VudC5wcm90bxIMYmxpcHB5LmFnZW50Ij0KE0FnZW50RmlsZXN5c3RlbVJvb3QSDwoHcm9vdF9pZBgBIAEoCRIVCg1lbmFibGVkX3Rvb2xzGAIgAygJIuACCgVBZ2VudBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEhMKC2Rlc2NyaXB0aW9uGAMgASgJEhUKDXN5c3RlbV9wcm9tcHQYBCABKAkSFQoNZW5hYmxlZF90b29scxgFIAMoCRIlCh1lbmFibGVkX25vdGlmaWNhdGlvbl9jaGFubmVscxgGIAMoCRIuCgpjcmVhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBINCgVtb2RlbBgJIAEoCRJDChhlbmFibGVkX2ZpbGVzeXN0ZW1fcm9vdHMYCiADKAsyIS5ibGlwcHkuYWdlbnQuQWdlbnRGaWxlc3lzdGVtUm9vdBIfChdmb3J3YXJkZWRfaG9zdF9lbnZfdmFycxgLIAMoCSKBAgoSQ3JlYXRlQWdlbnRSZXF1ZXN0EgwKBG5hbWUYASABKAkSEwoLZGVzY3JpcHRpb24YAiABKAkSFQoNc3lzdGVtX3Byb21wdBgDIAEoCRIVCg1lbmFibGVkX3Rvb2xzGAQgAygJEiUKHWVuYWJsZWRfbm90aWZpY2F0aW9uX2NoYW5uZWxzGAUgAygJEg0KBW1vZGVsGAYgASgJEkMKGGVuYWJsZWRfZmlsZXN5c3RlbV9yb290cxgHIAMoCzIhLmJsaXBweS5hZ2VudC5BZ2VudEZpbGVzeXN0ZW1Sb290Eh8KF2ZvcndhcmRlZF9ob3N0X2Vudl92YXJzGAggAygJIh0KD0dldEFnZW50UmVxdWVzdBIKCgJpZBgBIAEoCSITChFMaXN0QWdlbnRzUmVxdWVzdCI5ChJMaXN0QWdlbnRzUmVzcG9uc2USIwoGYWdlbnRzGAEgAygLMhMuYmxpcHB5LmFnZW50LkFnZW50Io0CChJVcGRhdGVBZ2VudFJlcXVlc3QSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRITCgtkZXNjcmlwdGlvbhgDIAEoCRIVCg1zeXN0ZW1fcHJvbXB0GAQgASgJEhUKDWVuYWJsZWRfdG9vbHMYBSADKAkSJQodZW5hYmxlZF9ub3RpZmljYXRpb25fY2hhbm5lbHMYBiADKAkSDQoFbW9kZWwYByABKAkSQwoYZW5hYmxlZF9maWxlc3lzdGVtX3Jvb3RzGAggAygLMiEuYmxpcHB5LmFnZW50LkFnZW50RmlsZXN5c3RlbVJvb3QSHwoXZm9yd2FyZGVkX2hvc3RfZW52X3ZhcnMYCSADKAkiIAoSRGVsZXRlQWdlbnRSZXF1ZXN0EgoKAmlkGAEgASgJIgcKBUVtcHR5IlUKBU1vZGVsEgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSFgoOcHJvbXB0X3ByaWNpbmcYAyABKAkSGgoSY29tcGxldGlvbl9wcmljaW5nGAQgASgJIhMKEUxpc3RNb2RlbHNSZXF1ZXN0IjkKEkxpc3RNb2RlbHNSZXNwb25zZRIjCgZtb2RlbHMYASADKAsyEy5ibGlwcHkuYWdlbnQuTW9kZWwywgMKDEFnZW50U2VydmljZRJECgtDcmVhdGVBZ2VudBIgLmJsaXBweS5hZ2VudC5DcmVhdGVBZ2VudFJlcXVlc3QaEy5ibGlwcHkuYWdlbnQuQWdlbnQSPgoIR2V0QWdlbnQSHS5ibGlwcHkuYWdlbnQuR2V0QWdlbnRSZXF1ZXN0GhMuYmxpcHB5LmFnZW50LkFnZW50Ek8KCkxpc3RBZ2VudHMSHy5ibGlwcHkuYWdlbnQuTGlzdEFnZW50c1JlcXVlc3QaIC5ibGlwcHkuYWdlbnQuTGlzdEFnZW50c1Jlc3BvbnNlEkQKC1VwZGF0ZUFnZW50EiAuYmxpcHB5LmFnZW50LlVwZGF0ZUFnZW50UmVxdWVzdBoTLmJsaXBweS5hZ2VudC5BZ2VudBJECgtEZWxldGVBZ2VudBIgLmJsaXBweS5hZ2VudC5EZWxldGVBZ2VudFJlcXVlc3QaEy5ibGlwcHkuYWdlbnQuRW1wdHkSTwoKTGlzdE1vZGVscxIfLmJsaXBweS5hZ2VudC5MaXN0TW9kZWxzUmVxdWVzdBogLmJsaXBweS5hZ2VudC5MaXN0TW9kZWxzUmVzcG9uc2VCK1opZ2l0aHViLmNvbS9kc3RvdGlqbi9ibGlwcHkvaW50ZXJuYWwvYWdlbnRiBnByb3RvMw", [file_google_protobuf_timestamp]);
+  fileDesc("ChFhZ2VudC9hZ2VudC5wcm90bxIMYmxpcHB5LmFnZW50Ij0KE0FnZW50RmlsZXN5c3RlbVJvb3QSDwoHcm9vdF9pZBgBIAEoCRIVCg1lbmFibGVkX3Rvb2xzGAIgAygJIikKCUFnZW50SG9vaxIMCgRuYW1lGAEgASgJEg4KBmNvbmZpZxgCIAEoCSLwBAoFQWdlbnQSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRITCgtkZXNjcmlwdGlvbhgDIAEoCRIVCg1zeXN0ZW1fcHJvbXB0GAQgASgJEhUKDWVuYWJsZWRfdG9vbHMYBSADKAkSJQodZW5hYmxlZF9ub3RpZmljYXRpb25fY2hhbm5lbHMYBiADKAkSLgoKY3JlYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDQoFbW9kZWwYCSABKAkSQwoYZW5hYmxlZF9maWxlc3lzdGVtX3Jvb3RzGAogAygLMiEuYmxpcHB5LmFnZW50LkFnZW50RmlsZXN5c3RlbVJvb3QSHwoXZm9yd2FyZGVkX2hvc3RfZW52X3ZhcnMYCyADKAkSDwoHdmVyc2lvbhgMIAEoAxImCgVob29rcxgNIAMoCzIXLmJsaXBweS5hZ2VudC5BZ2VudEhvb2sSGwoTbWF4X2NvbmN1cnJlbnRfcnVucxgOIAEoBRIhChl0aXRsZV9nZW5lcmF0aW9uX2Rpc2FibGVkGA8gASgIEhQKDHRpdGxlX3Byb21wdBgQIAEoCRITCgt0aXRsZV9tb2RlbBgRIAEoCRImCgdwZXJzb25hGBIgASgLMhUuYmxpcHB5LmFnZW50LlBlcnNvbmESMgoOYXBwcm92YWxfcnVsZXMYEyADKAsyGi5ibGlwcHkuYWdlbnQuQXBwcm92YWxSdWxlEg4KBmxvY2FsZRgUIAEoCSJaCgxBcHByb3ZhbFJ1bGUSDAoEdG9vbBgBIAEoCRIQCghhcmd1bWVudBgCIAEoCRIPCgdwYXR0ZXJuGAMgASgJEhkKEWxhcmdlcl90aGFuX2J5dGVzGAQgASgFIkoKB1BlcnNvbmESDAoEcm9sZRgBIAEoCRINCgVnb2FscxgCIAEoCRITCgtjb25zdHJhaW50cxgDIAEoCRINCgVzdHlsZRgEIAEoCSKABAoSQ3JlYXRlQWdlbnRSZXF1ZXN0EgwKBG5hbWUYASABKAkSEwoLZGVzY3JpcHRpb24YAiABKAkSFQoNc3lzdGVtX3Byb21wdBgDIAEoCRIVCg1lbmFibGVkX3Rvb2xzGAQgAygJEiUKHWVuYWJsZWRfbm90aWZpY2F0aW9uX2NoYW5uZWxzGAUgAygJEg0KBW1vZGVsGAYgASgJEkMKGGVuYWJsZWRfZmlsZXN5c3RlbV9yb290cxgHIAMoCzIhLmJsaXBweS5hZ2VudC5BZ2VudEZpbGVzeXN0ZW1Sb290Eh8KF2ZvcndhcmRlZF9ob3N0X2Vudl92YXJzGAggAygJEiYKBWhvb2tzGAkgAygLMhcuYmxpcHB5LmFnZW50LkFnZW50SG9vaxIbChNtYXhfY29uY3VycmVudF9ydW5zGAogASgFEiEKGXRpdGxlX2dlbmVyYXRpb25fZGlzYWJsZWQYCyABKAgSFAoMdGl0bGVfcHJvbXB0GAwgASgJEhMKC3RpdGxlX21vZGVsGA0gASgJEiYKB3BlcnNvbmEYDiABKAsyFS5ibGlwcHkuYWdlbnQuUGVyc29uYRIyCg5hcHByb3ZhbF9ydWxlcxgPIAMoCzIaLmJsaXBweS5hZ2VudC5BcHByb3ZhbFJ1bGUSDgoGbG9jYWxlGBAgASgJIh0KD0dldEFnZW50UmVxdWVzdBIKCgJpZBgBIAEoCSJcChFMaXN0QWdlbnRzUmVxdWVzdBIRCglwYWdlX3NpemUYASABKAUSEgoKcGFnZV90b2tlbhgCIAEoCRIQCghvcmRlcl9ieRgDIAEoCRIOCgZmaWx0ZXIYBCABKAkiZgoSTGlzdEFnZW50c1Jlc3BvbnNlEiMKBmFnZW50cxgBIAMoCzITLmJsaXBweS5hZ2VudC5BZ2VudBIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkSEgoKdG90YWxfc2l6ZRgDIAEoBSKdBAoSVXBkYXRlQWdlbnRSZXF1ZXN0EgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSEwoLZGVzY3JpcHRpb24YAyABKAkSFQoNc3lzdGVtX3Byb21wdBgEIAEoCRIVCg1lbmFibGVkX3Rvb2xzGAUgAygJEiUKHWVuYWJsZWRfbm90aWZpY2F0aW9uX2NoYW5uZWxzGAYgAygJEg0KBW1vZGVsGAcgASgJEkMKGGVuYWJsZWRfZmlsZXN5c3RlbV9yb290cxgIIAMoCzIhLmJsaXBweS5hZ2VudC5BZ2VudEZpbGVzeXN0ZW1Sb290Eh8KF2ZvcndhcmRlZF9ob3N0X2Vudl92YXJzGAkgAygJEg8KB3ZlcnNpb24YCiABKAMSJgoFaG9va3MYCyADKAsyFy5ibGlwcHkuYWdlbnQuQWdlbnRIb29rEhsKE21heF9jb25jdXJyZW50X3J1bnMYDCABKAUSIQoZdGl0bGVfZ2VuZXJhdGlvbl9kaXNhYmxlZBgNIAEoCBIUCgx0aXRsZV9wcm9tcHQYDiABKAkSEwoLdGl0bGVfbW9kZWwYDyABKAkSJgoHcGVyc29uYRgQIAEoCzIVLmJsaXBweS5hZ2VudC5QZXJzb25hEjIKDmFwcHJvdmFsX3J1bGVzGBEgAygLMhouYmxpcHB5LmFnZW50LkFwcHJvdmFsUnVsZRIOCgZsb2NhbGUYEiABKAkiIAoSRGVsZXRlQWdlbnRSZXF1ZXN0EgoKAmlkGAEgASgJIgcKBUVtcHR5IlUKBU1vZGVsEgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSFgoOcHJvbXB0X3ByaWNpbmcYAyABKAkSGgoSY29tcGxldGlvbl9wcmljaW5nGAQgASgJIhMKEUxpc3RNb2RlbHNSZXF1ZXN0IjkKEkxpc3RNb2RlbHNSZXNwb25zZRIjCgZtb2RlbHMYASADKAsyEy5ibGlwcHkuYWdlbnQuTW9kZWwiQgoYR2V0QWdlbnRUb29sU3RhdHNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEhQKDHBlcmlvZF9ob3VycxgCIAEoBSKQAQoJVG9vbFN0YXRzEgwKBG5hbWUYASABKAkSDwoHZW5hYmxlZBgCIAEoCBINCgVjYWxscxgDIAEoAxIOCgZlcnJvcnMYBCABKAMSEgoKZXJyb3JfcmF0ZRgFIAEoARIXCg9hdmdfZHVyYXRpb25fbXMYBiABKAMSGAoQYXZnX3Jlc3VsdF9ieXRlcxgHIAEoAyKnAQoZR2V0QWdlbnRUb29sU3RhdHNSZXNwb25zZRIpCgVzaW5jZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASKQoFdW50aWwYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEgwKBHJ1bnMYAyABKAMSJgoFdG9vbHMYBCADKAsyFy5ibGlwcHkuYWdlbnQuVG9vbFN0YXRzIjAKEkV4cG9ydEFnZW50UmVxdWVzdBIKCgJpZBgBIAEoCRIOCgZmb3JtYXQYAiABKAkiJQoTRXhwb3J0QWdlbnRSZXNwb25zZRIOCgZidW5kbGUYASABKAkiMAoSSW1wb3J0QWdlbnRSZXF1ZXN0Eg4KBmJ1bmRsZRgBIAEoCRIKCgJpZBgCIAEoCSJLChNJbXBvcnRBZ2VudFJlc3BvbnNlEiIKBWFnZW50GAEgASgLMhMuYmxpcHB5LmFnZW50LkFnZW50EhAKCHdhcm5pbmdzGAIgAygJMtAFCgxBZ2VudFNlcnZpY2USRAoLQ3JlYXRlQWdlbnQSIC5ibGlwcHkuYWdlbnQuQ3JlYXRlQWdlbnRSZXF1ZXN0GhMuYmxpcHB5LmFnZW50LkFnZW50Ej4KCEdldEFnZW50Eh0uYmxpcHB5LmFnZW50LkdldEFnZW50UmVxdWVzdBoTLmJsaXBweS5hZ2VudC5BZ2VudBJPCgpMaXN0QWdlbnRzEh8uYmxpcHB5LmFnZW50Lkxpc3RBZ2VudHNSZXF1ZXN0GiAuYmxpcHB5LmFnZW50Lkxpc3RBZ2VudHNSZXNwb25zZRJECgtVcGRhdGVBZ2VudBIgLmJsaXBweS5hZ2VudC5VcGRhdGVBZ2VudFJlcXVlc3QaEy5ibGlwcHkuYWdlbnQuQWdlbnQSRAoLRGVsZXRlQWdlbnQSIC5ibGlwcHkuYWdlbnQuRGVsZXRlQWdlbnRSZXF1ZXN0GhMuYmxpcHB5LmFnZW50LkVtcHR5Ek8KCkxpc3RNb2RlbHMSHy5ibGlwcHkuYWdlbnQuTGlzdE1vZGVsc1JlcXVlc3QaIC5ibGlwcHkuYWdlbnQuTGlzdE1vZGVsc1Jlc3BvbnNlEmQKEUdldEFnZW50VG9vbFN0YXRzEiYuYmxpcHB5LmFnZW50LkdldEFnZW50VG9vbFN0YXRzUmVxdWVzdBonLmJsaXBweS5hZ2VudC5HZXRBZ2VudFRvb2xTdGF0c1Jlc3BvbnNlElIKC0V4cG9ydEFnZW50EiAuYmxpcHB5LmFnZW50LkV4cG9ydEFnZW50UmVxdWVzdBohLmJsaXBweS5hZ2VudC5FeHBvcnRBZ2VudFJlc3BvbnNlElIKC0ltcG9ydEFnZW50EiAuYmxpcHB5LmFnZW50LkltcG9ydEFnZW50UmVxdWVzdBohLmJsaXBweS5hZ2VudC5JbXBvcnRBZ2VudFJlc3BvbnNlQitaKWdpdGh1Yi5jb20vZHN0b3Rpam4vYmxpcHB5L2ludGVybmFsL2FnZW50YgZwcm90bzM", [file_google_protobuf_timestamp]);
 
 /**
  * @generated from message blippy.agent.AgentFilesystemRoot
@@ -36,6 +36,33 @@ export type AgentFilesystemRoot = Message<"blippy.agent.AgentFilesystemRoot"> &
 export const AgentFilesystemRootSchema: GenMessage<AgentFilesystemRoot> = /*@__PURE__*/
   messageDesc(file_agent_agent, 0);
 
+/**
+ * A post-turn hook enabled for an agent, e.g. "memory", "usage", "webhook"
+ * or "notify_error".
+ *
+ * @generated from message blippy.agent.AgentHook
+ */
+export type AgentHook = Message<"blippy.agent.AgentHook"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Hook-specific JSON object, optional
+   *
+   * @generated from field: string config = 2;
+   */
+  config: string;
+};
+
+/**
+ * Describes the message blippy.agent.AgentHook.
+ * Use `create(AgentHookSchema)` to create a new message.
+ */
+export const AgentHookSchema: GenMessage<AgentHook> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 1);
+
 /**
  * @generated from message blippy.agent.Agent
  */
@@ -96,6 +123,74 @@ export type Agent = Message<"blippy.agent.Agent"> & {
    * @generated from field: repeated string forwarded_host_env_vars = 11;
    */
   forwardedHostEnvVars: string[];
+
+  /**
+   * Incremented on every update
+   *
+   * @generated from field: int64 version = 12;
+   */
+  version: bigint;
+
+  /**
+   * Run in order after each turn
+   *
+   * @generated from field: repeated blippy.agent.AgentHook hooks = 13;
+   */
+  hooks: AgentHook[];
+
+  /**
+   * Maximum number of autonomous runs (triggers, webhooks) at the same time;
+   * extra runs wait for a slot. 0 means no limit.
+   *
+   * @generated from field: int32 max_concurrent_runs = 14;
+   */
+  maxConcurrentRuns: number;
+
+  /**
+   * Conversation titles are generated after the first turn unless disabled,
+   * e.g. for trigger runs that don't need one.
+   *
+   * @generated from field: bool title_generation_disabled = 15;
+   */
+  titleGenerationDisabled: boolean;
+
+  /**
+   * Prompt generating titles instead of the server default. "{user}" and
+   * "{assistant}" are replaced with the first exchange.
+   *
+   * @generated from field: string title_prompt = 16;
+   */
+  titlePrompt: string;
+
+  /**
+   * Model generating titles instead of the server default, e.g. a cheap one.
+   *
+   * @generated from field: string title_model = 17;
+   */
+  titleModel: string;
+
+  /**
+   * Sections composed with the system prompt at turn time, before it.
+   *
+   * @generated from field: blippy.agent.Persona persona = 18;
+   */
+  persona?: Persona;
+
+  /**
+   * Tool calls matching any of these rules wait for approval.
+   *
+   * @generated from field: repeated blippy.agent.ApprovalRule approval_rules = 19;
+   */
+  approvalRules: ApprovalRule[];
+
+  /**
+   * BCP 47 language tag, e.g. "de-DE" or "nl". The agent is told to respond
+   * in its language, and titles and "{date}" placeholders use it. Empty uses
+   * English.
+   *
+   * @generated from field: string locale = 20;
+   */
+  locale: string;
 };
 
 /**
@@ -103,7 +198,96 @@ export type Agent = Message<"blippy.agent.Agent"> & {
  * Use `create(AgentSchema)` to create a new message.
  */
 export const AgentSchema: GenMessage<Agent> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 1);
+  messageDesc(file_agent_agent, 2);
+
+/**
+ * ApprovalRule makes the tool calls of an agent that match it wait for
+ * approval, so only risky calls pause its runs. A call matches if its tool
+ * matches and its argument meets all conditions that are set; a rule without
+ * conditions matches every call of the tool.
+ *
+ * @generated from message blippy.agent.ApprovalRule
+ */
+export type ApprovalRule = Message<"blippy.agent.ApprovalRule"> & {
+  /**
+   * Tool name, e.g. "bash" or "notify:ops". A trailing "*" matches by
+   * prefix, e.g. "notify:*".
+   *
+   * @generated from field: string tool = 1;
+   */
+  tool: string;
+
+  /**
+   * Top-level argument the conditions apply to, e.g. "command". Empty
+   * applies them to the JSON of all arguments.
+   *
+   * @generated from field: string argument = 2;
+   */
+  argument: string;
+
+  /**
+   * Regular expression the argument must match, e.g. "rm|curl".
+   *
+   * @generated from field: string pattern = 3;
+   */
+  pattern: string;
+
+  /**
+   * Size in bytes the argument must exceed, e.g. 1024, if greater than 0.
+   *
+   * @generated from field: int32 larger_than_bytes = 4;
+   */
+  largerThanBytes: number;
+};
+
+/**
+ * Describes the message blippy.agent.ApprovalRule.
+ * Use `create(ApprovalRuleSchema)` to create a new message.
+ */
+export const ApprovalRuleSchema: GenMessage<ApprovalRule> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 3);
+
+/**
+ * Persona holds the structured sections of an agent's instructions. Empty
+ * sections are left out; the system prompt follows them, for anything else.
+ *
+ * @generated from message blippy.agent.Persona
+ */
+export type Persona = Message<"blippy.agent.Persona"> & {
+  /**
+   * Who the agent is, e.g. "You are the on-call assistant of the platform
+   * team."
+   *
+   * @generated from field: string role = 1;
+   */
+  role: string;
+
+  /**
+   * @generated from field: string goals = 2;
+   */
+  goals: string;
+
+  /**
+   * What the agent must or must not do.
+   *
+   * @generated from field: string constraints = 3;
+   */
+  constraints: string;
+
+  /**
+   * Tone and format of its answers.
+   *
+   * @generated from field: string style = 4;
+   */
+  style: string;
+};
+
+/**
+ * Describes the message blippy.agent.Persona.
+ * Use `create(PersonaSchema)` to create a new message.
+ */
+export const PersonaSchema: GenMessage<Persona> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 4);
 
 /**
  * @generated from message blippy.agent.CreateAgentRequest
@@ -148,6 +332,46 @@ export type CreateAgentRequest = Message<"blippy.agent.CreateAgentRequest"> & {
    * @generated from field: repeated string forwarded_host_env_vars = 8;
    */
   forwardedHostEnvVars: string[];
+
+  /**
+   * @generated from field: repeated blippy.agent.AgentHook hooks = 9;
+   */
+  hooks: AgentHook[];
+
+  /**
+   * @generated from field: int32 max_concurrent_runs = 10;
+   */
+  maxConcurrentRuns: number;
+
+  /**
+   * @generated from field: bool title_generation_disabled = 11;
+   */
+  titleGenerationDisabled: boolean;
+
+  /**
+   * @generated from field: string title_prompt = 12;
+   */
+  titlePrompt: string;
+
+  /**
+   * @generated from field: string title_model = 13;
+   */
+  titleModel: string;
+
+  /**
+   * @generated from field: blippy.agent.Persona persona = 14;
+   */
+  persona?: Persona;
+
+  /**
+   * @generated from field: repeated blippy.agent.ApprovalRule approval_rules = 15;
+   */
+  approvalRules: ApprovalRule[];
+
+  /**
+   * @generated from field: string locale = 16;
+   */
+  locale: string;
 };
 
 /**
@@ -155,7 +379,7 @@ export type CreateAgentRequest = Message<"blippy.agent.CreateAgentRequest"> & {
  * Use `create(CreateAgentRequestSchema)` to create a new message.
  */
 export const CreateAgentRequestSchema: GenMessage<CreateAgentRequest> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 2);
+  messageDesc(file_agent_agent, 5);
 
 /**
  * @generated from message blippy.agent.GetAgentRequest
@@ -172,12 +396,39 @@ export type GetAgentRequest = Message<"blippy.agent.GetAgentRequest"> & {
  * Use `create(GetAgentRequestSchema)` to create a new message.
  */
 export const GetAgentRequestSchema: GenMessage<GetAgentRequest> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 3);
+  messageDesc(file_agent_agent, 6);
 
 /**
  * @generated from message blippy.agent.ListAgentsRequest
  */
 export type ListAgentsRequest = Message<"blippy.agent.ListAgentsRequest"> & {
+  /**
+   * Maximum number of results to return. 0 returns all results.
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * Token from a previous response's next_page_token.
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+
+  /**
+   * Comma-separated fields, each optionally followed by "asc" or "desc".
+   *
+   * @generated from field: string order_by = 3;
+   */
+  orderBy: string;
+
+  /**
+   * Terms joined by "AND", e.g. `name:deploy AND created_at>=2025-01-01`.
+   *
+   * @generated from field: string filter = 4;
+   */
+  filter: string;
 };
 
 /**
@@ -185,7 +436,7 @@ export type ListAgentsRequest = Message<"blippy.agent.ListAgentsRequest"> & {
  * Use `create(ListAgentsRequestSchema)` to create a new message.
  */
 export const ListAgentsRequestSchema: GenMessage<ListAgentsRequest> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 4);
+  messageDesc(file_agent_agent, 7);
 
 /**
  * @generated from message blippy.agent.ListAgentsResponse
@@ -195,6 +446,20 @@ export type ListAgentsResponse = Message<"blippy.agent.ListAgentsResponse"> & {
    * @generated from field: repeated blippy.agent.Agent agents = 1;
    */
   agents: Agent[];
+
+  /**
+   * Token for the next page; empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Number of results matching the filter.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
 };
 
 /**
@@ -202,7 +467,7 @@ export type ListAgentsResponse = Message<"blippy.agent.ListAgentsResponse"> & {
  * Use `create(ListAgentsResponseSchema)` to create a new message.
  */
 export const ListAgentsResponseSchema: GenMessage<ListAgentsResponse> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 5);
+  messageDesc(file_agent_agent, 8);
 
 /**
  * @generated from message blippy.agent.UpdateAgentRequest
@@ -252,6 +517,53 @@ export type UpdateAgentRequest = Message<"blippy.agent.UpdateAgentRequest"> & {
    * @generated from field: repeated string forwarded_host_env_vars = 9;
    */
   forwardedHostEnvVars: string[];
+
+  /**
+   * Version the update is based on; fails with ABORTED if stale
+   *
+   * @generated from field: int64 version = 10;
+   */
+  version: bigint;
+
+  /**
+   * @generated from field: repeated blippy.agent.AgentHook hooks = 11;
+   */
+  hooks: AgentHook[];
+
+  /**
+   * @generated from field: int32 max_concurrent_runs = 12;
+   */
+  maxConcurrentRuns: number;
+
+  /**
+   * @generated from field: bool title_generation_disabled = 13;
+   */
+  titleGenerationDisabled: boolean;
+
+  /**
+   * @generated from field: string title_prompt = 14;
+   */
+  titlePrompt: string;
+
+  /**
+   * @generated from field: string title_model = 15;
+   */
+  titleModel: string;
+
+  /**
+   * @generated from field: blippy.agent.Persona persona = 16;
+   */
+  persona?: Persona;
+
+  /**
+   * @generated from field: repeated blippy.agent.ApprovalRule approval_rules = 17;
+   */
+  approvalRules: ApprovalRule[];
+
+  /**
+   * @generated from field: string locale = 18;
+   */
+  locale: string;
 };
 
 /**
@@ -259,7 +571,7 @@ export type UpdateAgentRequest = Message<"blippy.agent.UpdateAgentRequest"> & {
  * Use `create(UpdateAgentRequestSchema)` to create a new message.
  */
 export const UpdateAgentRequestSchema: GenMessage<UpdateAgentRequest> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 6);
+  messageDesc(file_agent_agent, 9);
 
 /**
  * @generated from message blippy.agent.DeleteAgentRequest
@@ -276,7 +588,7 @@ export type DeleteAgentRequest = Message<"blippy.agent.DeleteAgentRequest"> & {
  * Use `create(DeleteAgentRequestSchema)` to create a new message.
  */
 export const DeleteAgentRequestSchema: GenMessage<DeleteAgentRequest> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 7);
+  messageDesc(file_agent_agent, 10);
 
 /**
  * @generated from message blippy.agent.Empty
@@ -289,7 +601,7 @@ export type Empty = Message<"blippy.agent.Empty"> & {
  * Use `create(EmptySchema)` to create a new message.
  */
 export const EmptySchema: GenMessage<Empty> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 8);
+  messageDesc(file_agent_agent, 11);
 
 /**
  * @generated from message blippy.agent.Model
@@ -321,7 +633,7 @@ export type Model = Message<"blippy.agent.Model"> & {
  * Use `create(ModelSchema)` to create a new message.
  */
 export const ModelSchema: GenMessage<Model> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 9);
+  messageDesc(file_agent_agent, 12);
 
 /**
  * @generated from message blippy.agent.ListModelsRequest
@@ -334,7 +646,7 @@ export type ListModelsRequest = Message<"blippy.agent.ListModelsRequest"> & {
  * Use `create(ListModelsRequestSchema)` to create a new message.
  */
 export const ListModelsRequestSchema: GenMessage<ListModelsRequest> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 10);
+  messageDesc(file_agent_agent, 13);
 
 /**
  * @generated from message blippy.agent.ListModelsResponse
@@ -351,7 +663,213 @@ export type ListModelsResponse = Message<"blippy.agent.ListModelsResponse"> & {
  * Use `create(ListModelsResponseSchema)` to create a new message.
  */
 export const ListModelsResponseSchema: GenMessage<ListModelsResponse> = /*@__PURE__*/
-  messageDesc(file_agent_agent, 11);
+  messageDesc(file_agent_agent, 14);
+
+/**
+ * @generated from message blippy.agent.GetAgentToolStatsRequest
+ */
+export type GetAgentToolStatsRequest = Message<"blippy.agent.GetAgentToolStatsRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * How far back to aggregate, in hours. Defaults to 168 (a week).
+   *
+   * @generated from field: int32 period_hours = 2;
+   */
+  periodHours: number;
+};
+
+/**
+ * Describes the message blippy.agent.GetAgentToolStatsRequest.
+ * Use `create(GetAgentToolStatsRequestSchema)` to create a new message.
+ */
+export const GetAgentToolStatsRequestSchema: GenMessage<GetAgentToolStatsRequest> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 15);
+
+/**
+ * ToolStats is how an agent used a tool in the runs of a period.
+ *
+ * @generated from message blippy.agent.ToolStats
+ */
+export type ToolStats = Message<"blippy.agent.ToolStats"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Whether the tool is in the agent's enabled tools. Enabled tools without
+   * calls are candidates for removal, as their schemas are sent every turn.
+   *
+   * @generated from field: bool enabled = 2;
+   */
+  enabled: boolean;
+
+  /**
+   * @generated from field: int64 calls = 3;
+   */
+  calls: bigint;
+
+  /**
+   * @generated from field: int64 errors = 4;
+   */
+  errors: bigint;
+
+  /**
+   * @generated from field: double error_rate = 5;
+   */
+  errorRate: number;
+
+  /**
+   * @generated from field: int64 avg_duration_ms = 6;
+   */
+  avgDurationMs: bigint;
+
+  /**
+   * @generated from field: int64 avg_result_bytes = 7;
+   */
+  avgResultBytes: bigint;
+};
+
+/**
+ * Describes the message blippy.agent.ToolStats.
+ * Use `create(ToolStatsSchema)` to create a new message.
+ */
+export const ToolStatsSchema: GenMessage<ToolStats> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 16);
+
+/**
+ * @generated from message blippy.agent.GetAgentToolStatsResponse
+ */
+export type GetAgentToolStatsResponse = Message<"blippy.agent.GetAgentToolStatsResponse"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp since = 1;
+   */
+  since?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp until = 2;
+   */
+  until?: Timestamp;
+
+  /**
+   * @generated from field: int64 runs = 3;
+   */
+  runs: bigint;
+
+  /**
+   * Most called first, followed by enabled tools that weren't called.
+   *
+   * @generated from field: repeated blippy.agent.ToolStats tools = 4;
+   */
+  tools: ToolStats[];
+};
+
+/**
+ * Describes the message blippy.agent.GetAgentToolStatsResponse.
+ * Use `create(GetAgentToolStatsResponseSchema)` to create a new message.
+ */
+export const GetAgentToolStatsResponseSchema: GenMessage<GetAgentToolStatsResponse> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 17);
+
+/**
+ * @generated from message blippy.agent.ExportAgentRequest
+ */
+export type ExportAgentRequest = Message<"blippy.agent.ExportAgentRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * "json" (the default) or "yaml".
+   *
+   * @generated from field: string format = 2;
+   */
+  format: string;
+};
+
+/**
+ * Describes the message blippy.agent.ExportAgentRequest.
+ * Use `create(ExportAgentRequestSchema)` to create a new message.
+ */
+export const ExportAgentRequestSchema: GenMessage<ExportAgentRequest> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 18);
+
+/**
+ * @generated from message blippy.agent.ExportAgentResponse
+ */
+export type ExportAgentResponse = Message<"blippy.agent.ExportAgentResponse"> & {
+  /**
+   * The agent and its cron triggers as a versioned bundle. Notification
+   * channels and filesystem roots are referred to by name, so the bundle can
+   * be imported on another instance.
+   *
+   * @generated from field: string bundle = 1;
+   */
+  bundle: string;
+};
+
+/**
+ * Describes the message blippy.agent.ExportAgentResponse.
+ * Use `create(ExportAgentResponseSchema)` to create a new message.
+ */
+export const ExportAgentResponseSchema: GenMessage<ExportAgentResponse> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 19);
+
+/**
+ * @generated from message blippy.agent.ImportAgentRequest
+ */
+export type ImportAgentRequest = Message<"blippy.agent.ImportAgentRequest"> & {
+  /**
+   * A JSON or YAML bundle, as returned by ExportAgent.
+   *
+   * @generated from field: string bundle = 1;
+   */
+  bundle: string;
+
+  /**
+   * The agent to update; a new agent is created if empty.
+   *
+   * @generated from field: string id = 2;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.agent.ImportAgentRequest.
+ * Use `create(ImportAgentRequestSchema)` to create a new message.
+ */
+export const ImportAgentRequestSchema: GenMessage<ImportAgentRequest> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 20);
+
+/**
+ * @generated from message blippy.agent.ImportAgentResponse
+ */
+export type ImportAgentResponse = Message<"blippy.agent.ImportAgentResponse"> & {
+  /**
+   * @generated from field: blippy.agent.Agent agent = 1;
+   */
+  agent?: Agent;
+
+  /**
+   * Notification channels and filesystem roots of the bundle that don't
+   * exist on this instance, and were left out of the agent.
+   *
+   * @generated from field: repeated string warnings = 2;
+   */
+  warnings: string[];
+};
+
+/**
+ * Describes the message blippy.agent.ImportAgentResponse.
+ * Use `create(ImportAgentResponseSchema)` to create a new message.
+ */
+export const ImportAgentResponseSchema: GenMessage<ImportAgentResponse> = /*@__PURE__*/
+  messageDesc(file_agent_agent, 21);
 
 /**
  * @generated from service blippy.agent.AgentService
@@ -405,6 +923,39 @@ export const AgentService: GenService<{
     input: typeof ListModelsRequestSchema;
     output: typeof ListModelsResponseSchema;
   },
+  /**
+   * GetAgentToolStats aggregates the tool calls of an agent's runs from
+   * their traces, to find unused and failing tools.
+   *
+   * @generated from rpc blippy.agent.AgentService.GetAgentToolStats
+   */
+  getAgentToolStats: {
+    methodKind: "unary";
+    input: typeof GetAgentToolStatsRequestSchema;
+    output: typeof GetAgentToolStatsResponseSchema;
+  },
+  /**
+   * ExportAgent serializes an agent and its cron triggers, to move it
+   * between instances or keep it in version control.
+   *
+   * @generated from rpc blippy.agent.AgentService.ExportAgent
+   */
+  exportAgent: {
+    methodKind: "unary";
+    input: typeof ExportAgentRequestSchema;
+    output: typeof ExportAgentResponseSchema;
+  },
+  /**
+   * ImportAgent creates or updates an agent from a bundle. Triggers are
+   * matched by name.
+   *
+   * @generated from rpc blippy.agent.AgentService.ImportAgent
+   */
+  importAgent: {
+    methodKind: "unary";
+    input: typeof ImportAgentRequestSchema;
+    output: typeof ImportAgentResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_agent_agent, 0);
 
@@ -0,0 +1,233 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file apierror/apierror.proto (package blippy.apierror, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file apierror/apierror.proto.
+ */
+export const file_apierror_apierror: GenFile = /*@__PURE__*/
+  fileDesc("ChdhcGllcnJvci9hcGllcnJvci5wcm90bxIPYmxpcHB5LmFwaWVycm9yIjcKC0Vycm9yRGV0YWlsEigKBGNvZGUYASABKA4yGi5ibGlwcHkuYXBpZXJyb3IuRXJyb3JDb2RlKqkICglFcnJvckNvZGUSGgoWRVJST1JfQ09ERV9VTlNQRUNJRklFRBAAEh8KG0VSUk9SX0NPREVfSU5WQUxJRF9BUkdVTUVOVBABEhgKFEVSUk9SX0NPREVfTk9UX0ZPVU5EEAISHQoZRVJST1JfQ09ERV9BTFJFQURZX0VYSVNUUxADEiAKHEVSUk9SX0NPREVfUEVSTUlTU0lPTl9ERU5JRUQQBBIeChpFUlJPUl9DT0RFX1VOQVVUSEVOVElDQVRFRBAFEiIKHkVSUk9SX0NPREVfRkFJTEVEX1BSRUNPTkRJVElPThAGEiEKHUVSUk9SX0NPREVfUkVTT1VSQ0VfRVhIQVVTVEVEEAcSGgoWRVJST1JfQ09ERV9VTkFWQUlMQUJMRRAIEhcKE0VSUk9SX0NPREVfSU5URVJOQUwQCRIeChpFUlJPUl9DT0RFX0FHRU5UX05PVF9GT1VORBAUEiUKIUVSUk9SX0NPREVfQ09OVkVSU0FUSU9OX05PVF9GT1VORBAVEiAKHEVSUk9SX0NPREVfVFJJR0dFUl9OT1RfRk9VTkQQFhIgChxFUlJPUl9DT0RFX01FU1NBR0VfTk9UX0ZPVU5EEBcSHAoYRVJST1JfQ09ERV9SVU5fTk9UX0ZPVU5EEBgSIQodRVJST1JfQ09ERV9BUFBST1ZBTF9OT1RfRk9VTkQQGhIgChxFUlJPUl9DT0RFX1dFQkhPT0tfTk9UX0ZPVU5EEBsSJAogRVJST1JfQ09ERV9UUklHR0VSX1JVTl9OT1RfRk9VTkQQHBIfChtFUlJPUl9DT0RFX1ZFUlNJT05fQ09ORkxJQ1QQGRIfChtFUlJPUl9DT0RFX01BSU5URU5BTkNFX01PREUQKBIcChhFUlJPUl9DT0RFX1NIVVRUSU5HX0RPV04QKRIgChxFUlJPUl9DT0RFX0NPTlZFUlNBVElPTl9CVVNZEDwSHQoZRVJST1JfQ09ERV9NQVhfSVRFUkFUSU9OUxA9EhwKGEVSUk9SX0NPREVfTE9PUF9ERVRFQ1RFRBA+EhwKGEVSUk9SX0NPREVfUlVOX0NBTkNFTExFRBA/EhwKGEVSUk9SX0NPREVfUlVOX1RJTUVEX09VVBBAEhoKFkVSUk9SX0NPREVfSU5URVJSVVBURUQQQRIZChVFUlJPUl9DT0RFX0xMTV9GQUlMRUQQQhIfChtFUlJPUl9DT0RFX0xMTV9SQVRFX0xJTUlURUQQQxIdChlFUlJPUl9DT0RFX1RPT0xfTk9UX0ZPVU5EEFASJQohRVJST1JfQ09ERV9UT09MX0lOVkFMSURfQVJHVU1FTlRTEFESGgoWRVJST1JfQ09ERV9UT09MX0ZBSUxFRBBSEh8KG0VSUk9SX0NPREVfVE9PTF9DQUxMX0RFTklFRBBTQi5aLGdpdGh1Yi5jb20vZHN0b3Rpam4vYmxpcHB5L2ludGVybmFsL2FwaWVycm9yYgZwcm90bzM");
+
+/**
+ * ErrorDetail is attached to every error returned by the API, as a Connect
+ * error detail.
+ *
+ * @generated from message blippy.apierror.ErrorDetail
+ */
+export type ErrorDetail = Message<"blippy.apierror.ErrorDetail"> & {
+  /**
+   * @generated from field: blippy.apierror.ErrorCode code = 1;
+   */
+  code: ErrorCode;
+};
+
+/**
+ * Describes the message blippy.apierror.ErrorDetail.
+ * Use `create(ErrorDetailSchema)` to create a new message.
+ */
+export const ErrorDetailSchema: GenMessage<ErrorDetail> = /*@__PURE__*/
+  messageDesc(file_apierror_apierror, 0);
+
+/**
+ * ErrorCode is a machine-readable error code, so clients can branch on
+ * errors without parsing messages. Codes are stable; new errors get new
+ * codes.
+ *
+ * @generated from enum blippy.apierror.ErrorCode
+ */
+export enum ErrorCode {
+  /**
+   * @generated from enum value: ERROR_CODE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Generic codes of errors without a more specific one. They match the
+   * Connect code of the error.
+   *
+   * @generated from enum value: ERROR_CODE_INVALID_ARGUMENT = 1;
+   */
+  INVALID_ARGUMENT = 1,
+
+  /**
+   * @generated from enum value: ERROR_CODE_NOT_FOUND = 2;
+   */
+  NOT_FOUND = 2,
+
+  /**
+   * @generated from enum value: ERROR_CODE_ALREADY_EXISTS = 3;
+   */
+  ALREADY_EXISTS = 3,
+
+  /**
+   * @generated from enum value: ERROR_CODE_PERMISSION_DENIED = 4;
+   */
+  PERMISSION_DENIED = 4,
+
+  /**
+   * @generated from enum value: ERROR_CODE_UNAUTHENTICATED = 5;
+   */
+  UNAUTHENTICATED = 5,
+
+  /**
+   * @generated from enum value: ERROR_CODE_FAILED_PRECONDITION = 6;
+   */
+  FAILED_PRECONDITION = 6,
+
+  /**
+   * @generated from enum value: ERROR_CODE_RESOURCE_EXHAUSTED = 7;
+   */
+  RESOURCE_EXHAUSTED = 7,
+
+  /**
+   * @generated from enum value: ERROR_CODE_UNAVAILABLE = 8;
+   */
+  UNAVAILABLE = 8,
+
+  /**
+   * @generated from enum value: ERROR_CODE_INTERNAL = 9;
+   */
+  INTERNAL = 9,
+
+  /**
+   * Entities that don't exist, or that the caller isn't allowed to access.
+   *
+   * @generated from enum value: ERROR_CODE_AGENT_NOT_FOUND = 20;
+   */
+  AGENT_NOT_FOUND = 20,
+
+  /**
+   * @generated from enum value: ERROR_CODE_CONVERSATION_NOT_FOUND = 21;
+   */
+  CONVERSATION_NOT_FOUND = 21,
+
+  /**
+   * @generated from enum value: ERROR_CODE_TRIGGER_NOT_FOUND = 22;
+   */
+  TRIGGER_NOT_FOUND = 22,
+
+  /**
+   * @generated from enum value: ERROR_CODE_MESSAGE_NOT_FOUND = 23;
+   */
+  MESSAGE_NOT_FOUND = 23,
+
+  /**
+   * @generated from enum value: ERROR_CODE_RUN_NOT_FOUND = 24;
+   */
+  RUN_NOT_FOUND = 24,
+
+  /**
+   * @generated from enum value: ERROR_CODE_APPROVAL_NOT_FOUND = 26;
+   */
+  APPROVAL_NOT_FOUND = 26,
+
+  /**
+   * @generated from enum value: ERROR_CODE_WEBHOOK_NOT_FOUND = 27;
+   */
+  WEBHOOK_NOT_FOUND = 27,
+
+  /**
+   * @generated from enum value: ERROR_CODE_TRIGGER_RUN_NOT_FOUND = 28;
+   */
+  TRIGGER_RUN_NOT_FOUND = 28,
+
+  /**
+   * The entity was modified since the version in the request was loaded:
+   * reload it and try again.
+   *
+   * @generated from enum value: ERROR_CODE_VERSION_CONFLICT = 25;
+   */
+  VERSION_CONFLICT = 25,
+
+  /**
+   * The instance doesn't accept new work: retry later.
+   *
+   * @generated from enum value: ERROR_CODE_MAINTENANCE_MODE = 40;
+   */
+  MAINTENANCE_MODE = 40,
+
+  /**
+   * @generated from enum value: ERROR_CODE_SHUTTING_DOWN = 41;
+   */
+  SHUTTING_DOWN = 41,
+
+  /**
+   * Agent turns.
+   *
+   * @generated from enum value: ERROR_CODE_CONVERSATION_BUSY = 60;
+   */
+  CONVERSATION_BUSY = 60,
+
+  /**
+   * @generated from enum value: ERROR_CODE_MAX_ITERATIONS = 61;
+   */
+  MAX_ITERATIONS = 61,
+
+  /**
+   * @generated from enum value: ERROR_CODE_LOOP_DETECTED = 62;
+   */
+  LOOP_DETECTED = 62,
+
+  /**
+   * @generated from enum value: ERROR_CODE_RUN_CANCELLED = 63;
+   */
+  RUN_CANCELLED = 63,
+
+  /**
+   * @generated from enum value: ERROR_CODE_RUN_TIMED_OUT = 64;
+   */
+  RUN_TIMED_OUT = 64,
+
+  /**
+   * @generated from enum value: ERROR_CODE_INTERRUPTED = 65;
+   */
+  INTERRUPTED = 65,
+
+  /**
+   * The LLM provider failed or rejected the request.
+   *
+   * @generated from enum value: ERROR_CODE_LLM_FAILED = 66;
+   */
+  LLM_FAILED = 66,
+
+  /**
+   * @generated from enum value: ERROR_CODE_LLM_RATE_LIMITED = 67;
+   */
+  LLM_RATE_LIMITED = 67,
+
+  /**
+   * Tool calls. The error is reported to the LLM as the tool's result.
+   *
+   * @generated from enum value: ERROR_CODE_TOOL_NOT_FOUND = 80;
+   */
+  TOOL_NOT_FOUND = 80,
+
+  /**
+   * @generated from enum value: ERROR_CODE_TOOL_INVALID_ARGUMENTS = 81;
+   */
+  TOOL_INVALID_ARGUMENTS = 81,
+
+  /**
+   * @generated from enum value: ERROR_CODE_TOOL_FAILED = 82;
+   */
+  TOOL_FAILED = 82,
+
+  /**
+   * The call matched an approval rule of the agent and was denied, or
+   * wasn't approved in time.
+   *
+   * @generated from enum value: ERROR_CODE_TOOL_CALL_DENIED = 83;
+   */
+  TOOL_CALL_DENIED = 83,
+}
+
+/**
+ * Describes the enum blippy.apierror.ErrorCode.
+ */
+export const ErrorCodeSchema: GenEnum<ErrorCode> = /*@__PURE__*/
+  enumDesc(file_apierror_apierror, 0);
+
@@ -0,0 +1,10 @@
+// @generated by protoc-gen-connect-query v2.2.0 with parameter "target=ts"
+// @generated from file audit/audit.proto (package blippy.audit, syntax proto3)
+/* eslint-disable */
+
+import { AuditService } from "./audit_pb";
+
+/**
+ * @generated from rpc blippy.audit.AuditService.ListAuditEntries
+ */
+export const listAuditEntries = AuditService.method.listAuditEntries;
@@ -0,0 +1,179 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file audit/audit.proto (package blippy.audit, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file audit/audit.proto.
+ */
+export const file_audit_audit: GenFile = /*@__PURE__*/
+  fileDesc("ChFhdWRpdC9hdWRpdC5wcm90bxIMYmxpcHB5LmF1ZGl0IuABCgpBdWRpdEVudHJ5EgoKAmlkGAEgASgJEg0KBWFjdG9yGAIgASgJEg4KBmFjdGlvbhgDIAEoCRIVCg1yZXNvdXJjZV90eXBlGAQgASgJEhMKC3Jlc291cmNlX2lkGAUgASgJEhEKCXByb2NlZHVyZRgGIAEoCRIPCgdkZXRhaWxzGAcgASgJEhMKC3JlbW90ZV9hZGRyGAggASgJEhIKCnVzZXJfYWdlbnQYCSABKAkSLgoKY3JlYXRlZF9hdBgKIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiYgoXTGlzdEF1ZGl0RW50cmllc1JlcXVlc3QSEQoJcGFnZV9zaXplGAEgASgFEhIKCnBhZ2VfdG9rZW4YAiABKAkSEAoIb3JkZXJfYnkYAyABKAkSDgoGZmlsdGVyGAQgASgJInIKGExpc3RBdWRpdEVudHJpZXNSZXNwb25zZRIpCgdlbnRyaWVzGAEgAygLMhguYmxpcHB5LmF1ZGl0LkF1ZGl0RW50cnkSFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJEhIKCnRvdGFsX3NpemUYAyABKAUycQoMQXVkaXRTZXJ2aWNlEmEKEExpc3RBdWRpdEVudHJpZXMSJS5ibGlwcHkuYXVkaXQuTGlzdEF1ZGl0RW50cmllc1JlcXVlc3QaJi5ibGlwcHkuYXVkaXQuTGlzdEF1ZGl0RW50cmllc1Jlc3BvbnNlQitaKWdpdGh1Yi5jb20vZHN0b3Rpam4vYmxpcHB5L2ludGVybmFsL2F1ZGl0YgZwcm90bzM", [file_google_protobuf_timestamp]);
+
+/**
+ * AuditEntry records a create, update or delete of a resource.
+ *
+ * @generated from message blippy.audit.AuditEntry
+ */
+export type AuditEntry = Message<"blippy.audit.AuditEntry"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * Who made the change, e.g. "anonymous" or "cli"
+   *
+   * @generated from field: string actor = 2;
+   */
+  actor: string;
+
+  /**
+   * "create", "update", "delete" or "import"
+   *
+   * @generated from field: string action = 3;
+   */
+  action: string;
+
+  /**
+   * e.g. "agent", "trigger", "notification_channel"
+   *
+   * @generated from field: string resource_type = 4;
+   */
+  resourceType: string;
+
+  /**
+   * Empty if the resource has no ID
+   *
+   * @generated from field: string resource_id = 5;
+   */
+  resourceId: string;
+
+  /**
+   * RPC procedure or command that made the change
+   *
+   * @generated from field: string procedure = 6;
+   */
+  procedure: string;
+
+  /**
+   * JSON request, with secrets redacted
+   *
+   * @generated from field: string details = 7;
+   */
+  details: string;
+
+  /**
+   * @generated from field: string remote_addr = 8;
+   */
+  remoteAddr: string;
+
+  /**
+   * @generated from field: string user_agent = 9;
+   */
+  userAgent: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 10;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.audit.AuditEntry.
+ * Use `create(AuditEntrySchema)` to create a new message.
+ */
+export const AuditEntrySchema: GenMessage<AuditEntry> = /*@__PURE__*/
+  messageDesc(file_audit_audit, 0);
+
+/**
+ * @generated from message blippy.audit.ListAuditEntriesRequest
+ */
+export type ListAuditEntriesRequest = Message<"blippy.audit.ListAuditEntriesRequest"> & {
+  /**
+   * Maximum number of results to return. 0 returns all results.
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * Token from a previous response's next_page_token.
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+
+  /**
+   * Comma-separated fields, each optionally followed by "asc" or "desc".
+   * Defaults to newest first.
+   *
+   * @generated from field: string order_by = 3;
+   */
+  orderBy: string;
+
+  /**
+   * Terms joined by "AND", e.g. `resource_type=agent AND action=delete`.
+   *
+   * @generated from field: string filter = 4;
+   */
+  filter: string;
+};
+
+/**
+ * Describes the message blippy.audit.ListAuditEntriesRequest.
+ * Use `create(ListAuditEntriesRequestSchema)` to create a new message.
+ */
+export const ListAuditEntriesRequestSchema: GenMessage<ListAuditEntriesRequest> = /*@__PURE__*/
+  messageDesc(file_audit_audit, 1);
+
+/**
+ * @generated from message blippy.audit.ListAuditEntriesResponse
+ */
+export type ListAuditEntriesResponse = Message<"blippy.audit.ListAuditEntriesResponse"> & {
+  /**
+   * @generated from field: repeated blippy.audit.AuditEntry entries = 1;
+   */
+  entries: AuditEntry[];
+
+  /**
+   * Token for the next page; empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Number of results matching the filter.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
+};
+
+/**
+ * Describes the message blippy.audit.ListAuditEntriesResponse.
+ * Use `create(ListAuditEntriesResponseSchema)` to create a new message.
+ */
+export const ListAuditEntriesResponseSchema: GenMessage<ListAuditEntriesResponse> = /*@__PURE__*/
+  messageDesc(file_audit_audit, 2);
+
+/**
+ * @generated from service blippy.audit.AuditService
+ */
+export const AuditService: GenService<{
+  /**
+   * @generated from rpc blippy.audit.AuditService.ListAuditEntries
+   */
+  listAuditEntries: {
+    methodKind: "unary";
+    input: typeof ListAuditEntriesRequestSchema;
+    output: typeof ListAuditEntriesResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_audit_audit, 0);
+
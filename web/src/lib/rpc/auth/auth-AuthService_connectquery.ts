@@ -0,0 +1,45 @@
+// @generated by protoc-gen-connect-query v2.2.0 with parameter "target=ts"
+// @generated from file auth/auth.proto (package blippy.auth, syntax proto3)
+/* eslint-disable */
+
+import { AuthService } from "./auth_pb";
+
+/**
+ * Login exchanges an API key for a session cookie.
+ *
+ * @generated from rpc blippy.auth.AuthService.Login
+ */
+export const login = AuthService.method.login;
+
+/**
+ * @generated from rpc blippy.auth.AuthService.Logout
+ */
+export const logout = AuthService.method.logout;
+
+/**
+ * @generated from rpc blippy.auth.AuthService.GetSession
+ */
+export const getSession = AuthService.method.getSession;
+
+/**
+ * GetLoginOptions returns the available login methods. It doesn't require
+ * authentication.
+ *
+ * @generated from rpc blippy.auth.AuthService.GetLoginOptions
+ */
+export const getLoginOptions = AuthService.method.getLoginOptions;
+
+/**
+ * @generated from rpc blippy.auth.AuthService.CreateAPIKey
+ */
+export const createAPIKey = AuthService.method.createAPIKey;
+
+/**
+ * @generated from rpc blippy.auth.AuthService.ListAPIKeys
+ */
+export const listAPIKeys = AuthService.method.listAPIKeys;
+
+/**
+ * @generated from rpc blippy.auth.AuthService.RevokeAPIKey
+ */
+export const revokeAPIKey = AuthService.method.revokeAPIKey;
@@ -0,0 +1,451 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file auth/auth.proto (package blippy.auth, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file auth/auth.proto.
+ */
+export const file_auth_auth: GenFile = /*@__PURE__*/
+  fileDesc("Cg9hdXRoL2F1dGgucHJvdG8SC2JsaXBweS5hdXRoIucBCgZBUElLZXkSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIOCgZwcmVmaXgYAyABKAkSLgoKY3JlYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMbGFzdF91c2VkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgpyZXZva2VkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIRCglhZ2VudF9pZHMYByADKAkSDgoGc2NvcGVzGAggAygJIh8KDExvZ2luUmVxdWVzdBIPCgdhcGlfa2V5GAEgASgJIjUKDUxvZ2luUmVzcG9uc2USJAoHYXBpX2tleRgBIAEoCzITLmJsaXBweS5hdXRoLkFQSUtleSIPCg1Mb2dvdXRSZXF1ZXN0IhAKDkxvZ291dFJlc3BvbnNlIhMKEUdldFNlc3Npb25SZXF1ZXN0InwKEkdldFNlc3Npb25SZXNwb25zZRIVCg1hdXRoX2Rpc2FibGVkGAEgASgIEiQKB2FwaV9rZXkYAiABKAsyEy5ibGlwcHkuYXV0aC5BUElLZXkSDAoEcm9sZRgDIAEoCRIMCgRuYW1lGAQgASgJEg0KBWVtYWlsGAUgASgJIhgKFkdldExvZ2luT3B0aW9uc1JlcXVlc3QiLwoXR2V0TG9naW5PcHRpb25zUmVzcG9uc2USFAoMb2lkY19lbmFibGVkGAEgASgIIkYKE0NyZWF0ZUFQSUtleVJlcXVlc3QSDAoEbmFtZRgBIAEoCRIRCglhZ2VudF9pZHMYAiADKAkSDgoGc2NvcGVzGAMgAygJIkkKFENyZWF0ZUFQSUtleVJlc3BvbnNlEiQKB2FwaV9rZXkYASABKAsyEy5ibGlwcHkuYXV0aC5BUElLZXkSCwoDa2V5GAIgASgJIl0KEkxpc3RBUElLZXlzUmVxdWVzdBIRCglwYWdlX3NpemUYASABKAUSEgoKcGFnZV90b2tlbhgCIAEoCRIQCghvcmRlcl9ieRgDIAEoCRIOCgZmaWx0ZXIYBCABKAkiaQoTTGlzdEFQSUtleXNSZXNwb25zZRIlCghhcGlfa2V5cxgBIAMoCzITLmJsaXBweS5hdXRoLkFQSUtleRIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkSEgoKdG90YWxfc2l6ZRgDIAEoBSIhChNSZXZva2VBUElLZXlSZXF1ZXN0EgoKAmlkGAEgASgJIhYKFFJldm9rZUFQSUtleVJlc3BvbnNlMrkECgtBdXRoU2VydmljZRI+CgVMb2dpbhIZLmJsaXBweS5hdXRoLkxvZ2luUmVxdWVzdBoaLmJsaXBweS5hdXRoLkxvZ2luUmVzcG9uc2USQQoGTG9nb3V0EhouYmxpcHB5LmF1dGguTG9nb3V0UmVxdWVzdBobLmJsaXBweS5hdXRoLkxvZ291dFJlc3BvbnNlEk0KCkdldFNlc3Npb24SHi5ibGlwcHkuYXV0aC5HZXRTZXNzaW9uUmVxdWVzdBofLmJsaXBweS5hdXRoLkdldFNlc3Npb25SZXNwb25zZRJcCg9HZXRMb2dpbk9wdGlvbnMSIy5ibGlwcHkuYXV0aC5HZXRMb2dpbk9wdGlvbnNSZXF1ZXN0GiQuYmxpcHB5LmF1dGguR2V0TG9naW5PcHRpb25zUmVzcG9uc2USUwoMQ3JlYXRlQVBJS2V5EiAuYmxpcHB5LmF1dGguQ3JlYXRlQVBJS2V5UmVxdWVzdBohLmJsaXBweS5hdXRoLkNyZWF0ZUFQSUtleVJlc3BvbnNlElAKC0xpc3RBUElLZXlzEh8uYmxpcHB5LmF1dGguTGlzdEFQSUtleXNSZXF1ZXN0GiAuYmxpcHB5LmF1dGguTGlzdEFQSUtleXNSZXNwb25zZRJTCgxSZXZva2VBUElLZXkSIC5ibGlwcHkuYXV0aC5SZXZva2VBUElLZXlSZXF1ZXN0GiEuYmxpcHB5LmF1dGguUmV2b2tlQVBJS2V5UmVzcG9uc2VCKlooZ2l0aHViLmNvbS9kc3RvdGlqbi9ibGlwcHkvaW50ZXJuYWwvYXV0aGIGcHJvdG8z", [file_google_protobuf_timestamp]);
+
+/**
+ * APIKey is an admin-issued key for the API. The key itself is only returned
+ * once, when it's created.
+ *
+ * @generated from message blippy.auth.APIKey
+ */
+export type APIKey = Message<"blippy.auth.APIKey"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * First characters of the key, to tell keys apart
+   *
+   * @generated from field: string prefix = 3;
+   */
+  prefix: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_used_at = 5;
+   */
+  lastUsedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp revoked_at = 6;
+   */
+  revokedAt?: Timestamp;
+
+  /**
+   * Agents the key can access; empty allows all agents.
+   *
+   * @generated from field: repeated string agent_ids = 7;
+   */
+  agentIds: string[];
+
+  /**
+   * Operations the key can perform: "read", "write", "chat" and "webhook".
+   * Empty allows all. Keys with agents or scopes set have the member role.
+   *
+   * @generated from field: repeated string scopes = 8;
+   */
+  scopes: string[];
+};
+
+/**
+ * Describes the message blippy.auth.APIKey.
+ * Use `create(APIKeySchema)` to create a new message.
+ */
+export const APIKeySchema: GenMessage<APIKey> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 0);
+
+/**
+ * @generated from message blippy.auth.LoginRequest
+ */
+export type LoginRequest = Message<"blippy.auth.LoginRequest"> & {
+  /**
+   * @generated from field: string api_key = 1;
+   */
+  apiKey: string;
+};
+
+/**
+ * Describes the message blippy.auth.LoginRequest.
+ * Use `create(LoginRequestSchema)` to create a new message.
+ */
+export const LoginRequestSchema: GenMessage<LoginRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 1);
+
+/**
+ * @generated from message blippy.auth.LoginResponse
+ */
+export type LoginResponse = Message<"blippy.auth.LoginResponse"> & {
+  /**
+   * @generated from field: blippy.auth.APIKey api_key = 1;
+   */
+  apiKey?: APIKey;
+};
+
+/**
+ * Describes the message blippy.auth.LoginResponse.
+ * Use `create(LoginResponseSchema)` to create a new message.
+ */
+export const LoginResponseSchema: GenMessage<LoginResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 2);
+
+/**
+ * @generated from message blippy.auth.LogoutRequest
+ */
+export type LogoutRequest = Message<"blippy.auth.LogoutRequest"> & {
+};
+
+/**
+ * Describes the message blippy.auth.LogoutRequest.
+ * Use `create(LogoutRequestSchema)` to create a new message.
+ */
+export const LogoutRequestSchema: GenMessage<LogoutRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 3);
+
+/**
+ * @generated from message blippy.auth.LogoutResponse
+ */
+export type LogoutResponse = Message<"blippy.auth.LogoutResponse"> & {
+};
+
+/**
+ * Describes the message blippy.auth.LogoutResponse.
+ * Use `create(LogoutResponseSchema)` to create a new message.
+ */
+export const LogoutResponseSchema: GenMessage<LogoutResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 4);
+
+/**
+ * @generated from message blippy.auth.GetSessionRequest
+ */
+export type GetSessionRequest = Message<"blippy.auth.GetSessionRequest"> & {
+};
+
+/**
+ * Describes the message blippy.auth.GetSessionRequest.
+ * Use `create(GetSessionRequestSchema)` to create a new message.
+ */
+export const GetSessionRequestSchema: GenMessage<GetSessionRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 5);
+
+/**
+ * @generated from message blippy.auth.GetSessionResponse
+ */
+export type GetSessionResponse = Message<"blippy.auth.GetSessionResponse"> & {
+  /**
+   * True if auth is disabled on the server.
+   *
+   * @generated from field: bool auth_disabled = 1;
+   */
+  authDisabled: boolean;
+
+  /**
+   * The key the request was authenticated with. Unset if auth is disabled or
+   * the user logged in with OIDC.
+   *
+   * @generated from field: blippy.auth.APIKey api_key = 2;
+   */
+  apiKey?: APIKey;
+
+  /**
+   * "admin" or "member"
+   *
+   * @generated from field: string role = 3;
+   */
+  role: string;
+
+  /**
+   * @generated from field: string name = 4;
+   */
+  name: string;
+
+  /**
+   * Only set for OIDC users
+   *
+   * @generated from field: string email = 5;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message blippy.auth.GetSessionResponse.
+ * Use `create(GetSessionResponseSchema)` to create a new message.
+ */
+export const GetSessionResponseSchema: GenMessage<GetSessionResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 6);
+
+/**
+ * @generated from message blippy.auth.GetLoginOptionsRequest
+ */
+export type GetLoginOptionsRequest = Message<"blippy.auth.GetLoginOptionsRequest"> & {
+};
+
+/**
+ * Describes the message blippy.auth.GetLoginOptionsRequest.
+ * Use `create(GetLoginOptionsRequestSchema)` to create a new message.
+ */
+export const GetLoginOptionsRequestSchema: GenMessage<GetLoginOptionsRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 7);
+
+/**
+ * @generated from message blippy.auth.GetLoginOptionsResponse
+ */
+export type GetLoginOptionsResponse = Message<"blippy.auth.GetLoginOptionsResponse"> & {
+  /**
+   * True if users can log in with an OpenID Connect provider, by navigating
+   * to /auth/oidc/login.
+   *
+   * @generated from field: bool oidc_enabled = 1;
+   */
+  oidcEnabled: boolean;
+};
+
+/**
+ * Describes the message blippy.auth.GetLoginOptionsResponse.
+ * Use `create(GetLoginOptionsResponseSchema)` to create a new message.
+ */
+export const GetLoginOptionsResponseSchema: GenMessage<GetLoginOptionsResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 8);
+
+/**
+ * @generated from message blippy.auth.CreateAPIKeyRequest
+ */
+export type CreateAPIKeyRequest = Message<"blippy.auth.CreateAPIKeyRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: repeated string agent_ids = 2;
+   */
+  agentIds: string[];
+
+  /**
+   * @generated from field: repeated string scopes = 3;
+   */
+  scopes: string[];
+};
+
+/**
+ * Describes the message blippy.auth.CreateAPIKeyRequest.
+ * Use `create(CreateAPIKeyRequestSchema)` to create a new message.
+ */
+export const CreateAPIKeyRequestSchema: GenMessage<CreateAPIKeyRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 9);
+
+/**
+ * @generated from message blippy.auth.CreateAPIKeyResponse
+ */
+export type CreateAPIKeyResponse = Message<"blippy.auth.CreateAPIKeyResponse"> & {
+  /**
+   * @generated from field: blippy.auth.APIKey api_key = 1;
+   */
+  apiKey?: APIKey;
+
+  /**
+   * The plaintext key. It can't be retrieved again.
+   *
+   * @generated from field: string key = 2;
+   */
+  key: string;
+};
+
+/**
+ * Describes the message blippy.auth.CreateAPIKeyResponse.
+ * Use `create(CreateAPIKeyResponseSchema)` to create a new message.
+ */
+export const CreateAPIKeyResponseSchema: GenMessage<CreateAPIKeyResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 10);
+
+/**
+ * @generated from message blippy.auth.ListAPIKeysRequest
+ */
+export type ListAPIKeysRequest = Message<"blippy.auth.ListAPIKeysRequest"> & {
+  /**
+   * Maximum number of results to return. 0 returns all results.
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * Token from a previous response's next_page_token.
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+
+  /**
+   * Comma-separated fields, each optionally followed by "asc" or "desc".
+   * Defaults to newest first.
+   *
+   * @generated from field: string order_by = 3;
+   */
+  orderBy: string;
+
+  /**
+   * Terms joined by "AND", e.g. `name=ci`.
+   *
+   * @generated from field: string filter = 4;
+   */
+  filter: string;
+};
+
+/**
+ * Describes the message blippy.auth.ListAPIKeysRequest.
+ * Use `create(ListAPIKeysRequestSchema)` to create a new message.
+ */
+export const ListAPIKeysRequestSchema: GenMessage<ListAPIKeysRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 11);
+
+/**
+ * @generated from message blippy.auth.ListAPIKeysResponse
+ */
+export type ListAPIKeysResponse = Message<"blippy.auth.ListAPIKeysResponse"> & {
+  /**
+   * @generated from field: repeated blippy.auth.APIKey api_keys = 1;
+   */
+  apiKeys: APIKey[];
+
+  /**
+   * Token for the next page; empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Number of results matching the filter.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
+};
+
+/**
+ * Describes the message blippy.auth.ListAPIKeysResponse.
+ * Use `create(ListAPIKeysResponseSchema)` to create a new message.
+ */
+export const ListAPIKeysResponseSchema: GenMessage<ListAPIKeysResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 12);
+
+/**
+ * @generated from message blippy.auth.RevokeAPIKeyRequest
+ */
+export type RevokeAPIKeyRequest = Message<"blippy.auth.RevokeAPIKeyRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.auth.RevokeAPIKeyRequest.
+ * Use `create(RevokeAPIKeyRequestSchema)` to create a new message.
+ */
+export const RevokeAPIKeyRequestSchema: GenMessage<RevokeAPIKeyRequest> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 13);
+
+/**
+ * @generated from message blippy.auth.RevokeAPIKeyResponse
+ */
+export type RevokeAPIKeyResponse = Message<"blippy.auth.RevokeAPIKeyResponse"> & {
+};
+
+/**
+ * Describes the message blippy.auth.RevokeAPIKeyResponse.
+ * Use `create(RevokeAPIKeyResponseSchema)` to create a new message.
+ */
+export const RevokeAPIKeyResponseSchema: GenMessage<RevokeAPIKeyResponse> = /*@__PURE__*/
+  messageDesc(file_auth_auth, 14);
+
+/**
+ * @generated from service blippy.auth.AuthService
+ */
+export const AuthService: GenService<{
+  /**
+   * Login exchanges an API key for a session cookie.
+   *
+   * @generated from rpc blippy.auth.AuthService.Login
+   */
+  login: {
+    methodKind: "unary";
+    input: typeof LoginRequestSchema;
+    output: typeof LoginResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.auth.AuthService.Logout
+   */
+  logout: {
+    methodKind: "unary";
+    input: typeof LogoutRequestSchema;
+    output: typeof LogoutResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.auth.AuthService.GetSession
+   */
+  getSession: {
+    methodKind: "unary";
+    input: typeof GetSessionRequestSchema;
+    output: typeof GetSessionResponseSchema;
+  },
+  /**
+   * GetLoginOptions returns the available login methods. It doesn't require
+   * authentication.
+   *
+   * @generated from rpc blippy.auth.AuthService.GetLoginOptions
+   */
+  getLoginOptions: {
+    methodKind: "unary";
+    input: typeof GetLoginOptionsRequestSchema;
+    output: typeof GetLoginOptionsResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.auth.AuthService.CreateAPIKey
+   */
+  createAPIKey: {
+    methodKind: "unary";
+    input: typeof CreateAPIKeyRequestSchema;
+    output: typeof CreateAPIKeyResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.auth.AuthService.ListAPIKeys
+   */
+  listAPIKeys: {
+    methodKind: "unary";
+    input: typeof ListAPIKeysRequestSchema;
+    output: typeof ListAPIKeysResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.auth.AuthService.RevokeAPIKey
+   */
+  revokeAPIKey: {
+    methodKind: "unary";
+    input: typeof RevokeAPIKeyRequestSchema;
+    output: typeof RevokeAPIKeyResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_auth_auth, 0);
+
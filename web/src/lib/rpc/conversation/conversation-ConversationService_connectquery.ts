@@ -24,11 +24,40 @@ export const listConversations = ConversationService.method.listConversations;
  */
 export const deleteConversation = ConversationService.method.deleteConversation;
 
+/**
+ * Deletes many conversations in a single transaction.
+ *
+ * @generated from rpc blippy.conversation.ConversationService.BatchDeleteConversations
+ */
+export const batchDeleteConversations = ConversationService.method.batchDeleteConversations;
+
 /**
  * @generated from rpc blippy.conversation.ConversationService.GetMessages
  */
 export const getMessages = ConversationService.method.getMessages;
 
+/**
+ * @generated from rpc blippy.conversation.ConversationService.GetConversationCost
+ */
+export const getConversationCost = ConversationService.method.getConversationCost;
+
+/**
+ * Lists the artifacts tool calls registered on the conversation's runs.
+ *
+ * @generated from rpc blippy.conversation.ConversationService.ListRunArtifacts
+ */
+export const listRunArtifacts = ConversationService.method.listRunArtifacts;
+
+/**
+ * @generated from rpc blippy.conversation.ConversationService.GetUsage
+ */
+export const getUsage = ConversationService.method.getUsage;
+
+/**
+ * @generated from rpc blippy.conversation.ConversationService.SearchConversations
+ */
+export const searchConversations = ConversationService.method.searchConversations;
+
 /**
  * @generated from rpc blippy.conversation.ConversationService.Chat
  */
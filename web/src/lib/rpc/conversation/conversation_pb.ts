@@ -6,13 +6,15 @@ import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegen
 import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { ErrorCode } from "../apierror/apierror_pb";
+import { file_apierror_apierror } from "../apierror/apierror_pb";
 import type { Message as Message$1 } from "@bufbuild/protobuf";
 
 /**
  * Describes the file conversation/conversation.proto.
  */
 export const file_conversation_conversation: GenFile = /*@__PURE__*/
-  fileDesc("Ch9jb252ZXJzYXRpb24vY29udmVyc2F0aW9uLnByb3RvEhNibGlwcHkuY29udmVyc2F0aW9uIrkBCgxDb252ZXJzYXRpb24SCgoCaWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSDQoFdGl0bGUYAyABKAkSHAoUcHJldmlvdXNfcmVzcG9uc2VfaWQYBCABKAkSLgoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAinQEKB01lc3NhZ2USCgoCaWQYASABKAkSFwoPY29udmVyc2F0aW9uX2lkGAIgASgJEgwKBHJvbGUYAyABKAkSLgoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoFaXRlbXMYByADKAsyIC5ibGlwcHkuY29udmVyc2F0aW9uLk1lc3NhZ2VJdGVtIoYBCgtNZXNzYWdlSXRlbRItCgR0ZXh0GAEgASgLMh0uYmxpcHB5LmNvbnZlcnNhdGlvbi5UZXh0SXRlbUgAEkAKDnRvb2xfZXhlY3V0aW9uGAIgASgLMiYuYmxpcHB5LmNvbnZlcnNhdGlvbi5Ub29sRXhlY3V0aW9uSXRlbUgAQgYKBGl0ZW0iGwoIVGV4dEl0ZW0SDwoHY29udGVudBgBIAEoCSJAChFUb29sRXhlY3V0aW9uSXRlbRIMCgRuYW1lGAEgASgJEg0KBWlucHV0GAIgASgJEg4KBnJlc3VsdBgDIAEoCSItChlDcmVhdGVDb252ZXJzYXRpb25SZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIiQKFkdldENvbnZlcnNhdGlvblJlcXVlc3QSCgoCaWQYASABKAkiLAoYTGlzdENvbnZlcnNhdGlvbnNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIlUKGUxpc3RDb252ZXJzYXRpb25zUmVzcG9uc2USOAoNY29udmVyc2F0aW9ucxgBIAMoCzIhLmJsaXBweS5jb252ZXJzYXRpb24uQ29udmVyc2F0aW9uIicKGURlbGV0ZUNvbnZlcnNhdGlvblJlcXVlc3QSCgoCaWQYASABKAkiLQoSR2V0TWVzc2FnZXNSZXF1ZXN0EhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCSJFChNHZXRNZXNzYWdlc1Jlc3BvbnNlEi4KCG1lc3NhZ2VzGAEgAygLMhwuYmxpcHB5LmNvbnZlcnNhdGlvbi5NZXNzYWdlIjcKC0NoYXRSZXF1ZXN0EhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCRIPCgdjb250ZW50GAIgASgJIicKDENoYXRSZXNwb25zZRIXCg91c2VyX21lc3NhZ2VfaWQYASABKAkiLQoSV2F0Y2hFdmVudHNSZXF1ZXN0EhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCSLkAgoQV2F0Y2hFdmVudHNFdmVudBI0Cgp0ZXh0X2RlbHRhGAEgASgLMh4uYmxpcHB5LmNvbnZlcnNhdGlvbi5UZXh0RGVsdGFIABI2Cgt0b29sX3Jlc3VsdBgCIAEoCzIfLmJsaXBweS5jb252ZXJzYXRpb24uVG9vbFJlc3VsdEgAEj4KD21lc3NhZ2VfY3JlYXRlZBgDIAEoCzIjLmJsaXBweS5jb252ZXJzYXRpb24uTWVzc2FnZUNyZWF0ZWRIABIwCgVlcnJvchgEIAEoCzIfLmJsaXBweS5jb252ZXJzYXRpb24uV2F0Y2hFcnJvckgAEi0KBGRvbmUYBSABKAsyHS5ibGlwcHkuY29udmVyc2F0aW9uLlR1cm5Eb25lSAASOAoMdHVybl9zdGFydGVkGAYgASgLMiAuYmxpcHB5LmNvbnZlcnNhdGlvbi5UdXJuU3RhcnRlZEgAQgcKBWV2ZW50IhwKCVRleHREZWx0YRIPCgdjb250ZW50GAEgASgJIjkKClRvb2xSZXN1bHQSDAoEbmFtZRgBIAEoCRINCgVpbnB1dBgCIAEoCRIOCgZyZXN1bHQYAyABKAkiPwoOTWVzc2FnZUNyZWF0ZWQSLQoHbWVzc2FnZRgBIAEoCzIcLmJsaXBweS5jb252ZXJzYXRpb24uTWVzc2FnZSIdCgpXYXRjaEVycm9yEg8KB21lc3NhZ2UYASABKAkiGQoIVHVybkRvbmUSDQoFdGl0bGUYASABKAkiDQoLVHVyblN0YXJ0ZWQiBwoFRW1wdHkyxwUKE0NvbnZlcnNhdGlvblNlcnZpY2USZwoSQ3JlYXRlQ29udmVyc2F0aW9uEi4uYmxpcHB5LmNvbnZlcnNhdGlvbi5DcmVhdGVDb252ZXJzYXRpb25SZXF1ZXN0GiEuYmxpcHB5LmNvbnZlcnNhdGlvbi5Db252ZXJzYXRpb24SYQoPR2V0Q29udmVyc2F0aW9uEisuYmxpcHB5LmNvbnZlcnNhdGlvbi5HZXRDb252ZXJzYXRpb25SZXF1ZXN0GiEuYmxpcHB5LmNvbnZlcnNhdGlvbi5Db252ZXJzYXRpb24ScgoRTGlzdENvbnZlcnNhdGlvbnMSLS5ibGlwcHkuY29udmVyc2F0aW9uLkxpc3RDb252ZXJzYXRpb25zUmVxdWVzdBouLmJsaXBweS5jb252ZXJzYXRpb24uTGlzdENvbnZlcnNhdGlvbnNSZXNwb25zZRJgChJEZWxldGVDb252ZXJzYXRpb24SLi5ibGlwcHkuY29udmVyc2F0aW9uLkRlbGV0ZUNvbnZlcnNhdGlvblJlcXVlc3QaGi5ibGlwcHkuY29udmVyc2F0aW9uLkVtcHR5EmAKC0dldE1lc3NhZ2VzEicuYmxpcHB5LmNvbnZlcnNhdGlvbi5HZXRNZXNzYWdlc1JlcXVlc3QaKC5ibGlwcHkuY29udmVyc2F0aW9uLkdldE1lc3NhZ2VzUmVzcG9uc2USSwoEQ2hhdBIgLmJsaXBweS5jb252ZXJzYXRpb24uQ2hhdFJlcXVlc3QaIS5ibGlwcHkuY29udmVyc2F0aW9uLkNoYXRSZXNwb25zZRJfCgtXYXRjaEV2ZW50cxInLmJsaXBweS5jb252ZXJzYXRpb24uV2F0Y2hFdmVudHNSZXF1ZXN0GiUuYmxpcHB5LmNvbnZlcnNhdGlvbi5XYXRjaEV2ZW50c0V2ZW50MAFCMlowZ2l0aHViLmNvbS9kc3RvdGlqbi9ibGlwcHkvaW50ZXJuYWwvY29udmVyc2F0aW9uYgZwcm90bzM", [file_google_protobuf_timestamp]);
+  fileDesc("Ch9jb252ZXJzYXRpb24vY29udmVyc2F0aW9uLnByb3RvEhNibGlwcHkuY29udmVyc2F0aW9uIuQBCgxDb252ZXJzYXRpb24SCgoCaWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSDQoFdGl0bGUYAyABKAkSHAoUcHJldmlvdXNfcmVzcG9uc2VfaWQYBCABKAkSLgoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASKQoFdXNhZ2UYByABKAsyGi5ibGlwcHkuY29udmVyc2F0aW9uLlVzYWdlInkKBVVzYWdlEgwKBHJ1bnMYASABKAMSEwoLZmFpbGVkX3J1bnMYAiABKAMSFAoMaW5wdXRfdG9rZW5zGAMgASgDEhUKDW91dHB1dF90b2tlbnMYBCABKAMSEAoIY29zdF91c2QYBSABKAESDgoGcHJpY2VkGAYgASgIIq0BCgdNZXNzYWdlEgoKAmlkGAEgASgJEhcKD2NvbnZlcnNhdGlvbl9pZBgCIAEoCRIMCgRyb2xlGAMgASgJEi4KCmNyZWF0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi8KBWl0ZW1zGAcgAygLMiAuYmxpcHB5LmNvbnZlcnNhdGlvbi5NZXNzYWdlSXRlbRIOCgZzdGF0dXMYCCABKAki8gEKC01lc3NhZ2VJdGVtEi0KBHRleHQYASABKAsyHS5ibGlwcHkuY29udmVyc2F0aW9uLlRleHRJdGVtSAASQAoOdG9vbF9leGVjdXRpb24YAiABKAsyJi5ibGlwcHkuY29udmVyc2F0aW9uLlRvb2xFeGVjdXRpb25JdGVtSAASLwoFZXJyb3IYAyABKAsyHi5ibGlwcHkuY29udmVyc2F0aW9uLkVycm9ySXRlbUgAEjkKCmF0dGFjaG1lbnQYBCABKAsyIy5ibGlwcHkuY29udmVyc2F0aW9uLkF0dGFjaG1lbnRJdGVtSABCBgoEaXRlbSIbCghUZXh0SXRlbRIPCgdjb250ZW50GAEgASgJIhwKCUVycm9ySXRlbRIPCgdtZXNzYWdlGAEgASgJIkAKEVRvb2xFeGVjdXRpb25JdGVtEgwKBG5hbWUYASABKAkSDQoFaW5wdXQYAiABKAkSDgoGcmVzdWx0GAMgASgJIlkKDkF0dGFjaG1lbnRJdGVtEgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSEgoKbWVkaWFfdHlwZRgDIAEoCRIMCgRzaXplGAQgASgDEgsKA3VybBgFIAEoCSItChlDcmVhdGVDb252ZXJzYXRpb25SZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIiQKFkdldENvbnZlcnNhdGlvblJlcXVlc3QSCgoCaWQYASABKAkidQoYTGlzdENvbnZlcnNhdGlvbnNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEhEKCXBhZ2Vfc2l6ZRgCIAEoBRISCgpwYWdlX3Rva2VuGAMgASgJEhAKCG9yZGVyX2J5GAQgASgJEg4KBmZpbHRlchgFIAEoCSKCAQoZTGlzdENvbnZlcnNhdGlvbnNSZXNwb25zZRI4Cg1jb252ZXJzYXRpb25zGAEgAygLMiEuYmxpcHB5LmNvbnZlcnNhdGlvbi5Db252ZXJzYXRpb24SFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJEhIKCnRvdGFsX3NpemUYAyABKAUiJwoZRGVsZXRlQ29udmVyc2F0aW9uUmVxdWVzdBIKCgJpZBgBIAEoCSIuCh9CYXRjaERlbGV0ZUNvbnZlcnNhdGlvbnNSZXF1ZXN0EgsKA2lkcxgBIAMoCSItChJHZXRNZXNzYWdlc1JlcXVlc3QSFwoPY29udmVyc2F0aW9uX2lkGAEgASgJIkUKE0dldE1lc3NhZ2VzUmVzcG9uc2USLgoIbWVzc2FnZXMYASADKAsyHC5ibGlwcHkuY29udmVyc2F0aW9uLk1lc3NhZ2UiNQoaR2V0Q29udmVyc2F0aW9uQ29zdFJlcXVlc3QSFwoPY29udmVyc2F0aW9uX2lkGAEgASgJIt4BChBDb252ZXJzYXRpb25Db3N0EhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCRIUCgxpbnB1dF90b2tlbnMYAiABKAMSFQoNb3V0cHV0X3Rva2VucxgDIAEoAxIQCghjb3N0X3VzZBgEIAEoARIOCgZwcmljZWQYBSABKAgSMgoIbWVzc2FnZXMYBiADKAsyIC5ibGlwcHkuY29udmVyc2F0aW9uLk1lc3NhZ2VDb3N0Ei4KBm1vZGVscxgHIAMoCzIeLmJsaXBweS5jb252ZXJzYXRpb24uTW9kZWxDb3N0Io8BCgtNZXNzYWdlQ29zdBISCgptZXNzYWdlX2lkGAEgASgJEg4KBnJ1bl9pZBgCIAEoCRINCgVtb2RlbBgDIAEoCRIUCgxpbnB1dF90b2tlbnMYBCABKAMSFQoNb3V0cHV0X3Rva2VucxgFIAEoAxIQCghjb3N0X3VzZBgGIAEoARIOCgZwcmljZWQYByABKAgidwoJTW9kZWxDb3N0Eg0KBW1vZGVsGAEgASgJEgwKBHJ1bnMYAiABKAUSFAoMaW5wdXRfdG9rZW5zGAMgASgDEhUKDW91dHB1dF90b2tlbnMYBCABKAMSEAoIY29zdF91c2QYBSABKAESDgoGcHJpY2VkGAYgASgIIkIKF0xpc3RSdW5BcnRpZmFjdHNSZXF1ZXN0EhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCRIOCgZydW5faWQYAiABKAkiTAoYTGlzdFJ1bkFydGlmYWN0c1Jlc3BvbnNlEjAKCWFydGlmYWN0cxgBIAMoCzIdLmJsaXBweS5jb252ZXJzYXRpb24uQXJ0aWZhY3QixQEKCEFydGlmYWN0EgoKAmlkGAEgASgJEg4KBnJ1bl9pZBgCIAEoCRIPCgdjYWxsX2lkGAMgASgJEhEKCXRvb2xfbmFtZRgEIAEoCRIMCgRraW5kGAUgASgJEgwKBG5hbWUYBiABKAkSCwoDdXJpGAcgASgJEhIKCm1lZGlhX3R5cGUYCCABKAkSDAoEc2l6ZRgJIAEoAxIuCgpjcmVhdGVkX2F0GAogASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJMCg9HZXRVc2FnZVJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkSFAoMcGVyaW9kX2hvdXJzGAIgASgFEhEKCXBhZ2Vfc2l6ZRgDIAEoBSLSAQoQR2V0VXNhZ2VSZXNwb25zZRIpCgVzaW5jZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASKQoFdW50aWwYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEj0KDWNvbnZlcnNhdGlvbnMYAyADKAsyJi5ibGlwcHkuY29udmVyc2F0aW9uLkNvbnZlcnNhdGlvblVzYWdlEikKBXRvdGFsGAQgASgLMhouYmxpcHB5LmNvbnZlcnNhdGlvbi5Vc2FnZSJ4ChFDb252ZXJzYXRpb25Vc2FnZRIXCg9jb252ZXJzYXRpb25faWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSDQoFdGl0bGUYAyABKAkSKQoFdXNhZ2UYBCABKAsyGi5ibGlwcHkuY29udmVyc2F0aW9uLlVzYWdlIrcBChpTZWFyY2hDb252ZXJzYXRpb25zUmVxdWVzdBINCgVxdWVyeRgBIAEoCRIQCghhZ2VudF9pZBgCIAEoCRIxCg11cGRhdGVkX3NpbmNlGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIyCg51cGRhdGVkX2JlZm9yZRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJcGFnZV9zaXplGAUgASgFIl0KG1NlYXJjaENvbnZlcnNhdGlvbnNSZXNwb25zZRI+CgdyZXN1bHRzGAEgAygLMi0uYmxpcHB5LmNvbnZlcnNhdGlvbi5Db252ZXJzYXRpb25TZWFyY2hSZXN1bHQilwEKGENvbnZlcnNhdGlvblNlYXJjaFJlc3VsdBI3Cgxjb252ZXJzYXRpb24YASABKAsyIS5ibGlwcHkuY29udmVyc2F0aW9uLkNvbnZlcnNhdGlvbhI0CghzbmlwcGV0cxgCIAMoCzIiLmJsaXBweS5jb252ZXJzYXRpb24uU2VhcmNoU25pcHBldBIMCgRyYW5rGAMgASgBIlQKDVNlYXJjaFNuaXBwZXQSEgoKbWVzc2FnZV9pZBgBIAEoCRIvCgVwYXJ0cxgCIAMoCzIgLmJsaXBweS5jb252ZXJzYXRpb24uU25pcHBldFBhcnQiKgoLU25pcHBldFBhcnQSDAoEdGV4dBgBIAEoCRINCgVtYXRjaBgCIAEoCCJtCgtDaGF0UmVxdWVzdBIXCg9jb252ZXJzYXRpb25faWQYASABKAkSDwoHY29udGVudBgCIAEoCRI0CgthdHRhY2htZW50cxgDIAMoCzIfLmJsaXBweS5jb252ZXJzYXRpb24uQXR0YWNobWVudCI8CgpBdHRhY2htZW50EgwKBG5hbWUYASABKAkSEgoKbWVkaWFfdHlwZRgCIAEoCRIMCgRkYXRhGAMgASgMIicKDENoYXRSZXNwb25zZRIXCg91c2VyX21lc3NhZ2VfaWQYASABKAkiWgoSV2F0Y2hFdmVudHNSZXF1ZXN0EhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCRIWCg5hZnRlcl9zZXF1ZW5jZRgCIAEoAxITCgtldmVudF90eXBlcxgDIAMoCSLmBQoQV2F0Y2hFdmVudHNFdmVudBI0Cgp0ZXh0X2RlbHRhGAEgASgLMh4uYmxpcHB5LmNvbnZlcnNhdGlvbi5UZXh0RGVsdGFIABI2Cgt0b29sX3Jlc3VsdBgCIAEoCzIfLmJsaXBweS5jb252ZXJzYXRpb24uVG9vbFJlc3VsdEgAEj4KD21lc3NhZ2VfY3JlYXRlZBgDIAEoCzIjLmJsaXBweS5jb252ZXJzYXRpb24uTWVzc2FnZUNyZWF0ZWRIABIwCgVlcnJvchgEIAEoCzIfLmJsaXBweS5jb252ZXJzYXRpb24uV2F0Y2hFcnJvckgAEi0KBGRvbmUYBSABKAsyHS5ibGlwcHkuY29udmVyc2F0aW9uLlR1cm5Eb25lSAASOAoMdHVybl9zdGFydGVkGAYgASgLMiAuYmxpcHB5LmNvbnZlcnNhdGlvbi5UdXJuU3RhcnRlZEgAEicKA2dhcBgIIAEoCzIYLmJsaXBweS5jb252ZXJzYXRpb24uR2FwSAASNQoIcHJvZ3Jlc3MYCSABKAsyIS5ibGlwcHkuY29udmVyc2F0aW9uLlR1cm5Qcm9ncmVzc0gAEjcKCHN1YmFnZW50GAogASgLMiMuYmxpcHB5LmNvbnZlcnNhdGlvbi5TdWJhZ2VudFVwZGF0ZUgAEkQKEmFwcHJvdmFsX3JlcXVlc3RlZBgLIAEoCzImLmJsaXBweS5jb252ZXJzYXRpb24uQXBwcm92YWxSZXF1ZXN0ZWRIABJCChFhcHByb3ZhbF9yZXNvbHZlZBgMIAEoCzIlLmJsaXBweS5jb252ZXJzYXRpb24uQXBwcm92YWxSZXNvbHZlZEgAEksKFnRvb2xfZXhlY3V0aW9uX3N0YXJ0ZWQYDSABKAsyKS5ibGlwcHkuY29udmVyc2F0aW9uLlRvb2xFeGVjdXRpb25TdGFydGVkSAASEAoIc2VxdWVuY2UYByABKANCBwoFZXZlbnQi2QEKEUFwcHJvdmFsUmVxdWVzdGVkEhMKC2FwcHJvdmFsX2lkGAEgASgJEg4KBnJ1bl9pZBgCIAEoCRIXCg9jb252ZXJzYXRpb25faWQYAyABKAkSEAoIYWdlbnRfaWQYBCABKAkSEgoKYWdlbnRfbmFtZRgFIAEoCRIRCgl0b29sX25hbWUYBiABKAkSDQoFaW5wdXQYByABKAkSDgoGcmVhc29uGAggASgJEi4KCmV4cGlyZXNfYXQYCSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIkkKEEFwcHJvdmFsUmVzb2x2ZWQSEwoLYXBwcm92YWxfaWQYASABKAkSEAoIYXBwcm92ZWQYAiABKAgSDgoGcmVhc29uGAMgASgJIi4KA0dhcBIOCgZtaXNzZWQYASABKAUSFwoPcmVzdW1lX3NlcXVlbmNlGAIgASgDIl4KDFR1cm5Qcm9ncmVzcxISCgplbGFwc2VkX21zGAEgASgDEg0KBXRvb2xzGAIgAygJEhQKDGlucHV0X3Rva2VucxgDIAEoAxIVCg1vdXRwdXRfdG9rZW5zGAQgASgDIuUBCg5TdWJhZ2VudFVwZGF0ZRIOCgZydW5faWQYASABKAkSFwoPY29udmVyc2F0aW9uX2lkGAIgASgJEhAKCGFnZW50X2lkGAMgASgJEhIKCmFnZW50X25hbWUYBCABKAkSNAoKdGV4dF9kZWx0YRgFIAEoCzIeLmJsaXBweS5jb252ZXJzYXRpb24uVGV4dERlbHRhSAASNgoLdG9vbF9yZXN1bHQYBiABKAsyHy5ibGlwcHkuY29udmVyc2F0aW9uLlRvb2xSZXN1bHRIABIMCgRkb25lGAcgASgIQggKBnVwZGF0ZSIcCglUZXh0RGVsdGESDwoHY29udGVudBgBIAEoCSJ6CgpUb29sUmVzdWx0EgwKBG5hbWUYASABKAkSDQoFaW5wdXQYAiABKAkSDgoGcmVzdWx0GAMgASgJEi4KCmVycm9yX2NvZGUYBCABKA4yGi5ibGlwcHkuYXBpZXJyb3IuRXJyb3JDb2RlEg8KB2NhbGxfaWQYBSABKAkiTgoUVG9vbEV4ZWN1dGlvblN0YXJ0ZWQSDwoHY2FsbF9pZBgBIAEoCRIMCgRuYW1lGAIgASgJEhcKD2FyZ3VtZW50c19kZWx0YRgDIAEoCSI/Cg5NZXNzYWdlQ3JlYXRlZBItCgdtZXNzYWdlGAEgASgLMhwuYmxpcHB5LmNvbnZlcnNhdGlvbi5NZXNzYWdlIkcKCldhdGNoRXJyb3ISDwoHbWVzc2FnZRgBIAEoCRIoCgRjb2RlGAIgASgOMhouYmxpcHB5LmFwaWVycm9yLkVycm9yQ29kZSIZCghUdXJuRG9uZRINCgV0aXRsZRgBIAEoCSINCgtUdXJuU3RhcnRlZCIHCgVFbXB0eTLoCQoTQ29udmVyc2F0aW9uU2VydmljZRJnChJDcmVhdGVDb252ZXJzYXRpb24SLi5ibGlwcHkuY29udmVyc2F0aW9uLkNyZWF0ZUNvbnZlcnNhdGlvblJlcXVlc3QaIS5ibGlwcHkuY29udmVyc2F0aW9uLkNvbnZlcnNhdGlvbhJhCg9HZXRDb252ZXJzYXRpb24SKy5ibGlwcHkuY29udmVyc2F0aW9uLkdldENvbnZlcnNhdGlvblJlcXVlc3QaIS5ibGlwcHkuY29udmVyc2F0aW9uLkNvbnZlcnNhdGlvbhJyChFMaXN0Q29udmVyc2F0aW9ucxItLmJsaXBweS5jb252ZXJzYXRpb24uTGlzdENvbnZlcnNhdGlvbnNSZXF1ZXN0Gi4uYmxpcHB5LmNvbnZlcnNhdGlvbi5MaXN0Q29udmVyc2F0aW9uc1Jlc3BvbnNlEmAKEkRlbGV0ZUNvbnZlcnNhdGlvbhIuLmJsaXBweS5jb252ZXJzYXRpb24uRGVsZXRlQ29udmVyc2F0aW9uUmVxdWVzdBoaLmJsaXBweS5jb252ZXJzYXRpb24uRW1wdHkSbAoYQmF0Y2hEZWxldGVDb252ZXJzYXRpb25zEjQuYmxpcHB5LmNvbnZlcnNhdGlvbi5CYXRjaERlbGV0ZUNvbnZlcnNhdGlvbnNSZXF1ZXN0GhouYmxpcHB5LmNvbnZlcnNhdGlvbi5FbXB0eRJgCgtHZXRNZXNzYWdlcxInLmJsaXBweS5jb252ZXJzYXRpb24uR2V0TWVzc2FnZXNSZXF1ZXN0GiguYmxpcHB5LmNvbnZlcnNhdGlvbi5HZXRNZXNzYWdlc1Jlc3BvbnNlEm0KE0dldENvbnZlcnNhdGlvbkNvc3QSLy5ibGlwcHkuY29udmVyc2F0aW9uLkdldENvbnZlcnNhdGlvbkNvc3RSZXF1ZXN0GiUuYmxpcHB5LmNvbnZlcnNhdGlvbi5Db252ZXJzYXRpb25Db3N0Em8KEExpc3RSdW5BcnRpZmFjdHMSLC5ibGlwcHkuY29udmVyc2F0aW9uLkxpc3RSdW5BcnRpZmFjdHNSZXF1ZXN0Gi0uYmxpcHB5LmNvbnZlcnNhdGlvbi5MaXN0UnVuQXJ0aWZhY3RzUmVzcG9uc2USVwoIR2V0VXNhZ2USJC5ibGlwcHkuY29udmVyc2F0aW9uLkdldFVzYWdlUmVxdWVzdBolLmJsaXBweS5jb252ZXJzYXRpb24uR2V0VXNhZ2VSZXNwb25zZRJ4ChNTZWFyY2hDb252ZXJzYXRpb25zEi8uYmxpcHB5LmNvbnZlcnNhdGlvbi5TZWFyY2hDb252ZXJzYXRpb25zUmVxdWVzdBowLmJsaXBweS5jb252ZXJzYXRpb24uU2VhcmNoQ29udmVyc2F0aW9uc1Jlc3BvbnNlEksKBENoYXQSIC5ibGlwcHkuY29udmVyc2F0aW9uLkNoYXRSZXF1ZXN0GiEuYmxpcHB5LmNvbnZlcnNhdGlvbi5DaGF0UmVzcG9uc2USXwoLV2F0Y2hFdmVudHMSJy5ibGlwcHkuY29udmVyc2F0aW9uLldhdGNoRXZlbnRzUmVxdWVzdBolLmJsaXBweS5jb252ZXJzYXRpb24uV2F0Y2hFdmVudHNFdmVudDABQjJaMGdpdGh1Yi5jb20vZHN0b3Rpam4vYmxpcHB5L2ludGVybmFsL2NvbnZlcnNhdGlvbmIGcHJvdG8z", [file_apierror_apierror, file_google_protobuf_timestamp]);
 
 /**
  * @generated from message blippy.conversation.Conversation
@@ -49,6 +51,14 @@ export type Conversation = Message$1<"blippy.conversation.Conversation"> & {
    * @generated from field: google.protobuf.Timestamp updated_at = 6;
    */
   updatedAt?: Timestamp;
+
+  /**
+   * Token usage and cost of the conversation's runs. Only set by
+   * GetConversation.
+   *
+   * @generated from field: blippy.conversation.Usage usage = 7;
+   */
+  usage?: Usage;
 };
 
 /**
@@ -58,6 +68,56 @@ export type Conversation = Message$1<"blippy.conversation.Conversation"> & {
 export const ConversationSchema: GenMessage<Conversation> = /*@__PURE__*/
   messageDesc(file_conversation_conversation, 0);
 
+/**
+ * @generated from message blippy.conversation.Usage
+ */
+export type Usage = Message$1<"blippy.conversation.Usage"> & {
+  /**
+   * @generated from field: int64 runs = 1;
+   */
+  runs: bigint;
+
+  /**
+   * Runs that failed or timed out.
+   *
+   * @generated from field: int64 failed_runs = 2;
+   */
+  failedRuns: bigint;
+
+  /**
+   * @generated from field: int64 input_tokens = 3;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 4;
+   */
+  outputTokens: bigint;
+
+  /**
+   * Cost in US dollars, as reported by OpenRouter, or estimated at the
+   * current prices for runs without a reported cost.
+   *
+   * @generated from field: double cost_usd = 5;
+   */
+  costUsd: number;
+
+  /**
+   * False if the cost of a run was neither reported nor known, in which
+   * case it doesn't count towards the cost.
+   *
+   * @generated from field: bool priced = 6;
+   */
+  priced: boolean;
+};
+
+/**
+ * Describes the message blippy.conversation.Usage.
+ * Use `create(UsageSchema)` to create a new message.
+ */
+export const UsageSchema: GenMessage<Usage> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 1);
+
 /**
  * @generated from message blippy.conversation.Message
  */
@@ -88,6 +148,19 @@ export type Message = Message$1<"blippy.conversation.Message"> & {
    * @generated from field: repeated blippy.conversation.MessageItem items = 7;
    */
   items: MessageItem[];
+
+  /**
+   * "completed", "interrupted" if the turn was cut short by a server
+   * shutdown and the message holds the output produced so far, or "failed"
+   * if the turn was stopped because it didn't converge, after which the
+   * message ends with an error item. "cancelled" if the turn was cancelled
+   * with SystemService.CancelRun, "timed_out" if the run exceeded its maximum
+   * duration. "in_progress" while the turn is still
+   * running: the message holds the output checkpointed so far.
+   *
+   * @generated from field: string status = 8;
+   */
+  status: string;
 };
 
 /**
@@ -95,7 +168,7 @@ export type Message = Message$1<"blippy.conversation.Message"> & {
  * Use `create(MessageSchema)` to create a new message.
  */
 export const MessageSchema: GenMessage<Message> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 1);
+  messageDesc(file_conversation_conversation, 2);
 
 /**
  * @generated from message blippy.conversation.MessageItem
@@ -116,6 +189,18 @@ export type MessageItem = Message$1<"blippy.conversation.MessageItem"> & {
      */
     value: ToolExecutionItem;
     case: "toolExecution";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.ErrorItem error = 3;
+     */
+    value: ErrorItem;
+    case: "error";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.AttachmentItem attachment = 4;
+     */
+    value: AttachmentItem;
+    case: "attachment";
   } | { case: undefined; value?: undefined };
 };
 
@@ -124,7 +209,7 @@ export type MessageItem = Message$1<"blippy.conversation.MessageItem"> & {
  * Use `create(MessageItemSchema)` to create a new message.
  */
 export const MessageItemSchema: GenMessage<MessageItem> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 2);
+  messageDesc(file_conversation_conversation, 3);
 
 /**
  * @generated from message blippy.conversation.TextItem
@@ -141,7 +226,27 @@ export type TextItem = Message$1<"blippy.conversation.TextItem"> & {
  * Use `create(TextItemSchema)` to create a new message.
  */
 export const TextItemSchema: GenMessage<TextItem> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 3);
+  messageDesc(file_conversation_conversation, 4);
+
+/**
+ * ErrorItem explains why a turn was stopped, e.g. because the agent kept
+ * calling the same tool. Messages with error items have status "failed".
+ *
+ * @generated from message blippy.conversation.ErrorItem
+ */
+export type ErrorItem = Message$1<"blippy.conversation.ErrorItem"> & {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message blippy.conversation.ErrorItem.
+ * Use `create(ErrorItemSchema)` to create a new message.
+ */
+export const ErrorItemSchema: GenMessage<ErrorItem> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 5);
 
 /**
  * @generated from message blippy.conversation.ToolExecutionItem
@@ -168,7 +273,48 @@ export type ToolExecutionItem = Message$1<"blippy.conversation.ToolExecutionItem
  * Use `create(ToolExecutionItemSchema)` to create a new message.
  */
 export const ToolExecutionItemSchema: GenMessage<ToolExecutionItem> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 4);
+  messageDesc(file_conversation_conversation, 6);
+
+/**
+ * AttachmentItem is a file attached to a user message.
+ *
+ * @generated from message blippy.conversation.AttachmentItem
+ */
+export type AttachmentItem = Message$1<"blippy.conversation.AttachmentItem"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string media_type = 3;
+   */
+  mediaType: string;
+
+  /**
+   * @generated from field: int64 size = 4;
+   */
+  size: bigint;
+
+  /**
+   * Signed download URL, valid for an hour.
+   *
+   * @generated from field: string url = 5;
+   */
+  url: string;
+};
+
+/**
+ * Describes the message blippy.conversation.AttachmentItem.
+ * Use `create(AttachmentItemSchema)` to create a new message.
+ */
+export const AttachmentItemSchema: GenMessage<AttachmentItem> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 7);
 
 /**
  * @generated from message blippy.conversation.CreateConversationRequest
@@ -185,7 +331,7 @@ export type CreateConversationRequest = Message$1<"blippy.conversation.CreateCon
  * Use `create(CreateConversationRequestSchema)` to create a new message.
  */
 export const CreateConversationRequestSchema: GenMessage<CreateConversationRequest> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 5);
+  messageDesc(file_conversation_conversation, 8);
 
 /**
  * @generated from message blippy.conversation.GetConversationRequest
@@ -202,7 +348,7 @@ export type GetConversationRequest = Message$1<"blippy.conversation.GetConversat
  * Use `create(GetConversationRequestSchema)` to create a new message.
  */
 export const GetConversationRequestSchema: GenMessage<GetConversationRequest> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 6);
+  messageDesc(file_conversation_conversation, 9);
 
 /**
  * @generated from message blippy.conversation.ListConversationsRequest
@@ -214,6 +360,34 @@ export type ListConversationsRequest = Message$1<"blippy.conversation.ListConver
    * @generated from field: string agent_id = 1;
    */
   agentId: string;
+
+  /**
+   * Maximum number of results to return. 0 returns all results.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize: number;
+
+  /**
+   * Token from a previous response's next_page_token.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken: string;
+
+  /**
+   * Comma-separated fields, each optionally followed by "asc" or "desc".
+   *
+   * @generated from field: string order_by = 4;
+   */
+  orderBy: string;
+
+  /**
+   * Terms joined by "AND", e.g. `title:deploy AND updated_at>=2025-01-01`.
+   *
+   * @generated from field: string filter = 5;
+   */
+  filter: string;
 };
 
 /**
@@ -221,7 +395,7 @@ export type ListConversationsRequest = Message$1<"blippy.conversation.ListConver
  * Use `create(ListConversationsRequestSchema)` to create a new message.
  */
 export const ListConversationsRequestSchema: GenMessage<ListConversationsRequest> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 7);
+  messageDesc(file_conversation_conversation, 10);
 
 /**
  * @generated from message blippy.conversation.ListConversationsResponse
@@ -231,6 +405,20 @@ export type ListConversationsResponse = Message$1<"blippy.conversation.ListConve
    * @generated from field: repeated blippy.conversation.Conversation conversations = 1;
    */
   conversations: Conversation[];
+
+  /**
+   * Token for the next page; empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Number of results matching the filter.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
 };
 
 /**
@@ -238,7 +426,7 @@ export type ListConversationsResponse = Message$1<"blippy.conversation.ListConve
  * Use `create(ListConversationsResponseSchema)` to create a new message.
  */
 export const ListConversationsResponseSchema: GenMessage<ListConversationsResponse> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 8);
+  messageDesc(file_conversation_conversation, 11);
 
 /**
  * @generated from message blippy.conversation.DeleteConversationRequest
@@ -255,7 +443,26 @@ export type DeleteConversationRequest = Message$1<"blippy.conversation.DeleteCon
  * Use `create(DeleteConversationRequestSchema)` to create a new message.
  */
 export const DeleteConversationRequestSchema: GenMessage<DeleteConversationRequest> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 9);
+  messageDesc(file_conversation_conversation, 12);
+
+/**
+ * @generated from message blippy.conversation.BatchDeleteConversationsRequest
+ */
+export type BatchDeleteConversationsRequest = Message$1<"blippy.conversation.BatchDeleteConversationsRequest"> & {
+  /**
+   * At most 500 conversation IDs. Unknown IDs are ignored.
+   *
+   * @generated from field: repeated string ids = 1;
+   */
+  ids: string[];
+};
+
+/**
+ * Describes the message blippy.conversation.BatchDeleteConversationsRequest.
+ * Use `create(BatchDeleteConversationsRequestSchema)` to create a new message.
+ */
+export const BatchDeleteConversationsRequestSchema: GenMessage<BatchDeleteConversationsRequest> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 13);
 
 /**
  * @generated from message blippy.conversation.GetMessagesRequest
@@ -272,7 +479,7 @@ export type GetMessagesRequest = Message$1<"blippy.conversation.GetMessagesReque
  * Use `create(GetMessagesRequestSchema)` to create a new message.
  */
 export const GetMessagesRequestSchema: GenMessage<GetMessagesRequest> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 10);
+  messageDesc(file_conversation_conversation, 14);
 
 /**
  * @generated from message blippy.conversation.GetMessagesResponse
@@ -289,154 +496,1029 @@ export type GetMessagesResponse = Message$1<"blippy.conversation.GetMessagesResp
  * Use `create(GetMessagesResponseSchema)` to create a new message.
  */
 export const GetMessagesResponseSchema: GenMessage<GetMessagesResponse> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 11);
+  messageDesc(file_conversation_conversation, 15);
 
 /**
- * @generated from message blippy.conversation.ChatRequest
+ * @generated from message blippy.conversation.GetConversationCostRequest
  */
-export type ChatRequest = Message$1<"blippy.conversation.ChatRequest"> & {
+export type GetConversationCostRequest = Message$1<"blippy.conversation.GetConversationCostRequest"> & {
   /**
    * @generated from field: string conversation_id = 1;
    */
   conversationId: string;
-
-  /**
-   * @generated from field: string content = 2;
-   */
-  content: string;
 };
 
 /**
- * Describes the message blippy.conversation.ChatRequest.
- * Use `create(ChatRequestSchema)` to create a new message.
+ * Describes the message blippy.conversation.GetConversationCostRequest.
+ * Use `create(GetConversationCostRequestSchema)` to create a new message.
  */
-export const ChatRequestSchema: GenMessage<ChatRequest> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 12);
+export const GetConversationCostRequestSchema: GenMessage<GetConversationCostRequest> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 16);
 
 /**
- * @generated from message blippy.conversation.ChatResponse
+ * ConversationCost is the token usage of the runs of a conversation, and its
+ * estimated cost in US dollars at the current OpenRouter prices. Runs of
+ * subagents are in their own conversations, and not included.
+ *
+ * @generated from message blippy.conversation.ConversationCost
  */
-export type ChatResponse = Message$1<"blippy.conversation.ChatResponse"> & {
+export type ConversationCost = Message$1<"blippy.conversation.ConversationCost"> & {
   /**
-   * @generated from field: string user_message_id = 1;
+   * @generated from field: string conversation_id = 1;
    */
-  userMessageId: string;
-};
+  conversationId: string;
 
-/**
- * Describes the message blippy.conversation.ChatResponse.
- * Use `create(ChatResponseSchema)` to create a new message.
- */
-export const ChatResponseSchema: GenMessage<ChatResponse> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 13);
+  /**
+   * @generated from field: int64 input_tokens = 2;
+   */
+  inputTokens: bigint;
 
-/**
- * WatchEvents streaming events
- *
- * @generated from message blippy.conversation.WatchEventsRequest
- */
-export type WatchEventsRequest = Message$1<"blippy.conversation.WatchEventsRequest"> & {
   /**
-   * @generated from field: string conversation_id = 1;
+   * @generated from field: int64 output_tokens = 3;
    */
-  conversationId: string;
-};
+  outputTokens: bigint;
 
-/**
- * Describes the message blippy.conversation.WatchEventsRequest.
- * Use `create(WatchEventsRequestSchema)` to create a new message.
- */
-export const WatchEventsRequestSchema: GenMessage<WatchEventsRequest> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 14);
+  /**
+   * @generated from field: double cost_usd = 4;
+   */
+  costUsd: number;
 
-/**
- * @generated from message blippy.conversation.WatchEventsEvent
- */
-export type WatchEventsEvent = Message$1<"blippy.conversation.WatchEventsEvent"> & {
   /**
-   * @generated from oneof blippy.conversation.WatchEventsEvent.event
+   * False if the price of a model wasn't known, in which case its runs
+   * don't count towards the cost.
+   *
+   * @generated from field: bool priced = 5;
    */
-  event: {
-    /**
-     * @generated from field: blippy.conversation.TextDelta text_delta = 1;
-     */
-    value: TextDelta;
-    case: "textDelta";
-  } | {
-    /**
-     * @generated from field: blippy.conversation.ToolResult tool_result = 2;
-     */
-    value: ToolResult;
-    case: "toolResult";
-  } | {
-    /**
-     * @generated from field: blippy.conversation.MessageCreated message_created = 3;
-     */
-    value: MessageCreated;
-    case: "messageCreated";
-  } | {
-    /**
-     * @generated from field: blippy.conversation.WatchError error = 4;
-     */
-    value: WatchError;
-    case: "error";
-  } | {
-    /**
-     * @generated from field: blippy.conversation.TurnDone done = 5;
-     */
-    value: TurnDone;
-    case: "done";
-  } | {
-    /**
-     * @generated from field: blippy.conversation.TurnStarted turn_started = 6;
-     */
-    value: TurnStarted;
-    case: "turnStarted";
-  } | { case: undefined; value?: undefined };
-};
+  priced: boolean;
 
-/**
- * Describes the message blippy.conversation.WatchEventsEvent.
- * Use `create(WatchEventsEventSchema)` to create a new message.
- */
-export const WatchEventsEventSchema: GenMessage<WatchEventsEvent> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 15);
+  /**
+   * @generated from field: repeated blippy.conversation.MessageCost messages = 6;
+   */
+  messages: MessageCost[];
 
-/**
- * @generated from message blippy.conversation.TextDelta
- */
-export type TextDelta = Message$1<"blippy.conversation.TextDelta"> & {
   /**
-   * @generated from field: string content = 1;
+   * @generated from field: repeated blippy.conversation.ModelCost models = 7;
    */
-  content: string;
+  models: ModelCost[];
 };
 
 /**
- * Describes the message blippy.conversation.TextDelta.
- * Use `create(TextDeltaSchema)` to create a new message.
+ * Describes the message blippy.conversation.ConversationCost.
+ * Use `create(ConversationCostSchema)` to create a new message.
  */
-export const TextDeltaSchema: GenMessage<TextDelta> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 16);
+export const ConversationCostSchema: GenMessage<ConversationCost> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 17);
 
 /**
- * @generated from message blippy.conversation.ToolResult
+ * MessageCost is the usage of the run that produced an assistant message.
+ * Runs that ended without output have no message_id.
+ *
+ * @generated from message blippy.conversation.MessageCost
  */
-export type ToolResult = Message$1<"blippy.conversation.ToolResult"> & {
+export type MessageCost = Message$1<"blippy.conversation.MessageCost"> & {
   /**
-   * @generated from field: string name = 1;
+   * @generated from field: string message_id = 1;
    */
-  name: string;
+  messageId: string;
 
   /**
-   * @generated from field: string input = 2;
+   * @generated from field: string run_id = 2;
    */
-  input: string;
+  runId: string;
 
   /**
-   * @generated from field: string result = 3;
+   * @generated from field: string model = 3;
+   */
+  model: string;
+
+  /**
+   * @generated from field: int64 input_tokens = 4;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 5;
+   */
+  outputTokens: bigint;
+
+  /**
+   * @generated from field: double cost_usd = 6;
+   */
+  costUsd: number;
+
+  /**
+   * @generated from field: bool priced = 7;
+   */
+  priced: boolean;
+};
+
+/**
+ * Describes the message blippy.conversation.MessageCost.
+ * Use `create(MessageCostSchema)` to create a new message.
+ */
+export const MessageCostSchema: GenMessage<MessageCost> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 18);
+
+/**
+ * @generated from message blippy.conversation.ModelCost
+ */
+export type ModelCost = Message$1<"blippy.conversation.ModelCost"> & {
+  /**
+   * @generated from field: string model = 1;
+   */
+  model: string;
+
+  /**
+   * @generated from field: int32 runs = 2;
+   */
+  runs: number;
+
+  /**
+   * @generated from field: int64 input_tokens = 3;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 4;
+   */
+  outputTokens: bigint;
+
+  /**
+   * @generated from field: double cost_usd = 5;
+   */
+  costUsd: number;
+
+  /**
+   * @generated from field: bool priced = 6;
+   */
+  priced: boolean;
+};
+
+/**
+ * Describes the message blippy.conversation.ModelCost.
+ * Use `create(ModelCostSchema)` to create a new message.
+ */
+export const ModelCostSchema: GenMessage<ModelCost> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 19);
+
+/**
+ * @generated from message blippy.conversation.ListRunArtifactsRequest
+ */
+export type ListRunArtifactsRequest = Message$1<"blippy.conversation.ListRunArtifactsRequest"> & {
+  /**
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * Lists the artifacts of this run only. Defaults to all runs of the
+   * conversation.
+   *
+   * @generated from field: string run_id = 2;
+   */
+  runId: string;
+};
+
+/**
+ * Describes the message blippy.conversation.ListRunArtifactsRequest.
+ * Use `create(ListRunArtifactsRequestSchema)` to create a new message.
+ */
+export const ListRunArtifactsRequestSchema: GenMessage<ListRunArtifactsRequest> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 20);
+
+/**
+ * @generated from message blippy.conversation.ListRunArtifactsResponse
+ */
+export type ListRunArtifactsResponse = Message$1<"blippy.conversation.ListRunArtifactsResponse"> & {
+  /**
+   * Oldest first.
+   *
+   * @generated from field: repeated blippy.conversation.Artifact artifacts = 1;
+   */
+  artifacts: Artifact[];
+};
+
+/**
+ * Describes the message blippy.conversation.ListRunArtifactsResponse.
+ * Use `create(ListRunArtifactsResponseSchema)` to create a new message.
+ */
+export const ListRunArtifactsResponseSchema: GenMessage<ListRunArtifactsResponse> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 21);
+
+/**
+ * Artifact is an output a tool call registered on its run, such as a file it
+ * created, a URL it produced or an image it generated.
+ *
+ * @generated from message blippy.conversation.Artifact
+ */
+export type Artifact = Message$1<"blippy.conversation.Artifact"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string run_id = 2;
+   */
+  runId: string;
+
+  /**
+   * The call_id and name of the tool call that registered it.
+   *
+   * @generated from field: string call_id = 3;
+   */
+  callId: string;
+
+  /**
+   * @generated from field: string tool_name = 4;
+   */
+  toolName: string;
+
+  /**
+   * "file", "url" or "image".
+   *
+   * @generated from field: string kind = 5;
+   */
+  kind: string;
+
+  /**
+   * Describes the artifact, e.g. "docs:notes/plan.md" for a file in a
+   * filesystem root.
+   *
+   * @generated from field: string name = 6;
+   */
+  name: string;
+
+  /**
+   * Locates the artifact: a URL, a file:// URI, or a signed download URL
+   * for artifacts stored by the server.
+   *
+   * @generated from field: string uri = 7;
+   */
+  uri: string;
+
+  /**
+   * @generated from field: string media_type = 8;
+   */
+  mediaType: string;
+
+  /**
+   * Size in bytes, or 0 if unknown.
+   *
+   * @generated from field: int64 size = 9;
+   */
+  size: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 10;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.conversation.Artifact.
+ * Use `create(ArtifactSchema)` to create a new message.
+ */
+export const ArtifactSchema: GenMessage<Artifact> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 22);
+
+/**
+ * @generated from message blippy.conversation.GetUsageRequest
+ */
+export type GetUsageRequest = Message$1<"blippy.conversation.GetUsageRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * Hours to look back. Defaults to 24 hours.
+   *
+   * @generated from field: int32 period_hours = 2;
+   */
+  periodHours: number;
+
+  /**
+   * Maximum number of conversations. Defaults to 20, at most 100.
+   *
+   * @generated from field: int32 page_size = 3;
+   */
+  pageSize: number;
+};
+
+/**
+ * Describes the message blippy.conversation.GetUsageRequest.
+ * Use `create(GetUsageRequestSchema)` to create a new message.
+ */
+export const GetUsageRequestSchema: GenMessage<GetUsageRequest> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 23);
+
+/**
+ * GetUsageResponse is the usage of an agent's conversations with runs in a
+ * period, most expensive first.
+ *
+ * @generated from message blippy.conversation.GetUsageResponse
+ */
+export type GetUsageResponse = Message$1<"blippy.conversation.GetUsageResponse"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp since = 1;
+   */
+  since?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp until = 2;
+   */
+  until?: Timestamp;
+
+  /**
+   * @generated from field: repeated blippy.conversation.ConversationUsage conversations = 3;
+   */
+  conversations: ConversationUsage[];
+
+  /**
+   * Usage of all conversations in the period, including those beyond the
+   * page size.
+   *
+   * @generated from field: blippy.conversation.Usage total = 4;
+   */
+  total?: Usage;
+};
+
+/**
+ * Describes the message blippy.conversation.GetUsageResponse.
+ * Use `create(GetUsageResponseSchema)` to create a new message.
+ */
+export const GetUsageResponseSchema: GenMessage<GetUsageResponse> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 24);
+
+/**
+ * @generated from message blippy.conversation.ConversationUsage
+ */
+export type ConversationUsage = Message$1<"blippy.conversation.ConversationUsage"> & {
+  /**
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string title = 3;
+   */
+  title: string;
+
+  /**
+   * @generated from field: blippy.conversation.Usage usage = 4;
+   */
+  usage?: Usage;
+};
+
+/**
+ * Describes the message blippy.conversation.ConversationUsage.
+ * Use `create(ConversationUsageSchema)` to create a new message.
+ */
+export const ConversationUsageSchema: GenMessage<ConversationUsage> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 25);
+
+/**
+ * @generated from message blippy.conversation.SearchConversationsRequest
+ */
+export type SearchConversationsRequest = Message$1<"blippy.conversation.SearchConversationsRequest"> & {
+  /**
+   * Words to search for in conversation titles and message items, which
+   * must all match. Words are matched by stem, e.g. "migration" also matches
+   * "migrations". Supports "quoted phrases" and prefix* words.
+   *
+   * @generated from field: string query = 1;
+   */
+  query: string;
+
+  /**
+   * optional filter
+   *
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * Optional filters on the time the conversation was last updated:
+   * updated_since is inclusive, updated_before exclusive.
+   *
+   * @generated from field: google.protobuf.Timestamp updated_since = 3;
+   */
+  updatedSince?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_before = 4;
+   */
+  updatedBefore?: Timestamp;
+
+  /**
+   * Maximum number of conversations to return. Defaults to 20, at most 100.
+   *
+   * @generated from field: int32 page_size = 5;
+   */
+  pageSize: number;
+};
+
+/**
+ * Describes the message blippy.conversation.SearchConversationsRequest.
+ * Use `create(SearchConversationsRequestSchema)` to create a new message.
+ */
+export const SearchConversationsRequestSchema: GenMessage<SearchConversationsRequest> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 26);
+
+/**
+ * @generated from message blippy.conversation.SearchConversationsResponse
+ */
+export type SearchConversationsResponse = Message$1<"blippy.conversation.SearchConversationsResponse"> & {
+  /**
+   * Conversations with matches, the most relevant first.
+   *
+   * @generated from field: repeated blippy.conversation.ConversationSearchResult results = 1;
+   */
+  results: ConversationSearchResult[];
+};
+
+/**
+ * Describes the message blippy.conversation.SearchConversationsResponse.
+ * Use `create(SearchConversationsResponseSchema)` to create a new message.
+ */
+export const SearchConversationsResponseSchema: GenMessage<SearchConversationsResponse> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 27);
+
+/**
+ * @generated from message blippy.conversation.ConversationSearchResult
+ */
+export type ConversationSearchResult = Message$1<"blippy.conversation.ConversationSearchResult"> & {
+  /**
+   * @generated from field: blippy.conversation.Conversation conversation = 1;
+   */
+  conversation?: Conversation;
+
+  /**
+   * Up to three of the best matching titles and messages.
+   *
+   * @generated from field: repeated blippy.conversation.SearchSnippet snippets = 2;
+   */
+  snippets: SearchSnippet[];
+
+  /**
+   * BM25 relevance of the best match; lower is more relevant.
+   *
+   * @generated from field: double rank = 3;
+   */
+  rank: number;
+};
+
+/**
+ * Describes the message blippy.conversation.ConversationSearchResult.
+ * Use `create(ConversationSearchResultSchema)` to create a new message.
+ */
+export const ConversationSearchResultSchema: GenMessage<ConversationSearchResult> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 28);
+
+/**
+ * SearchSnippet is an excerpt of a title or message around the matched
+ * words.
+ *
+ * @generated from message blippy.conversation.SearchSnippet
+ */
+export type SearchSnippet = Message$1<"blippy.conversation.SearchSnippet"> & {
+  /**
+   * Empty if the conversation title matched.
+   *
+   * @generated from field: string message_id = 1;
+   */
+  messageId: string;
+
+  /**
+   * The excerpt, split into parts that are matched words or the text
+   * between them, so clients can highlight matches without parsing markup.
+   *
+   * @generated from field: repeated blippy.conversation.SnippetPart parts = 2;
+   */
+  parts: SnippetPart[];
+};
+
+/**
+ * Describes the message blippy.conversation.SearchSnippet.
+ * Use `create(SearchSnippetSchema)` to create a new message.
+ */
+export const SearchSnippetSchema: GenMessage<SearchSnippet> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 29);
+
+/**
+ * @generated from message blippy.conversation.SnippetPart
+ */
+export type SnippetPart = Message$1<"blippy.conversation.SnippetPart"> & {
+  /**
+   * @generated from field: string text = 1;
+   */
+  text: string;
+
+  /**
+   * @generated from field: bool match = 2;
+   */
+  match: boolean;
+};
+
+/**
+ * Describes the message blippy.conversation.SnippetPart.
+ * Use `create(SnippetPartSchema)` to create a new message.
+ */
+export const SnippetPartSchema: GenMessage<SnippetPart> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 30);
+
+/**
+ * @generated from message blippy.conversation.ChatRequest
+ */
+export type ChatRequest = Message$1<"blippy.conversation.ChatRequest"> & {
+  /**
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string content = 2;
+   */
+  content: string;
+
+  /**
+   * Files sent to the agent with the message: images, PDFs or text files.
+   *
+   * @generated from field: repeated blippy.conversation.Attachment attachments = 3;
+   */
+  attachments: Attachment[];
+};
+
+/**
+ * Describes the message blippy.conversation.ChatRequest.
+ * Use `create(ChatRequestSchema)` to create a new message.
+ */
+export const ChatRequestSchema: GenMessage<ChatRequest> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 31);
+
+/**
+ * @generated from message blippy.conversation.Attachment
+ */
+export type Attachment = Message$1<"blippy.conversation.Attachment"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Detected from data if empty.
+   *
+   * @generated from field: string media_type = 2;
+   */
+  mediaType: string;
+
+  /**
+   * @generated from field: bytes data = 3;
+   */
+  data: Uint8Array;
+};
+
+/**
+ * Describes the message blippy.conversation.Attachment.
+ * Use `create(AttachmentSchema)` to create a new message.
+ */
+export const AttachmentSchema: GenMessage<Attachment> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 32);
+
+/**
+ * @generated from message blippy.conversation.ChatResponse
+ */
+export type ChatResponse = Message$1<"blippy.conversation.ChatResponse"> & {
+  /**
+   * @generated from field: string user_message_id = 1;
+   */
+  userMessageId: string;
+};
+
+/**
+ * Describes the message blippy.conversation.ChatResponse.
+ * Use `create(ChatResponseSchema)` to create a new message.
+ */
+export const ChatResponseSchema: GenMessage<ChatResponse> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 33);
+
+/**
+ * WatchEvents streaming events
+ *
+ * @generated from message blippy.conversation.WatchEventsRequest
+ */
+export type WatchEventsRequest = Message$1<"blippy.conversation.WatchEventsRequest"> & {
+  /**
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * Replays the logged events after this sequence number before streaming
+   * new events, e.g. when reconnecting. 0 only streams new events.
+   *
+   * @generated from field: int64 after_sequence = 2;
+   */
+  afterSequence: bigint;
+
+  /**
+   * Only streams events of these types, named after the fields of
+   * WatchEventsEvent's event, e.g. "message_created" and "done" for list
+   * views that only need to know when turns complete. Gaps are always sent.
+   * Empty streams all events.
+   *
+   * @generated from field: repeated string event_types = 3;
+   */
+  eventTypes: string[];
+};
+
+/**
+ * Describes the message blippy.conversation.WatchEventsRequest.
+ * Use `create(WatchEventsRequestSchema)` to create a new message.
+ */
+export const WatchEventsRequestSchema: GenMessage<WatchEventsRequest> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 34);
+
+/**
+ * @generated from message blippy.conversation.WatchEventsEvent
+ */
+export type WatchEventsEvent = Message$1<"blippy.conversation.WatchEventsEvent"> & {
+  /**
+   * @generated from oneof blippy.conversation.WatchEventsEvent.event
+   */
+  event: {
+    /**
+     * @generated from field: blippy.conversation.TextDelta text_delta = 1;
+     */
+    value: TextDelta;
+    case: "textDelta";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.ToolResult tool_result = 2;
+     */
+    value: ToolResult;
+    case: "toolResult";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.MessageCreated message_created = 3;
+     */
+    value: MessageCreated;
+    case: "messageCreated";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.WatchError error = 4;
+     */
+    value: WatchError;
+    case: "error";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.TurnDone done = 5;
+     */
+    value: TurnDone;
+    case: "done";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.TurnStarted turn_started = 6;
+     */
+    value: TurnStarted;
+    case: "turnStarted";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.Gap gap = 8;
+     */
+    value: Gap;
+    case: "gap";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.TurnProgress progress = 9;
+     */
+    value: TurnProgress;
+    case: "progress";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.SubagentUpdate subagent = 10;
+     */
+    value: SubagentUpdate;
+    case: "subagent";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.ApprovalRequested approval_requested = 11;
+     */
+    value: ApprovalRequested;
+    case: "approvalRequested";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.ApprovalResolved approval_resolved = 12;
+     */
+    value: ApprovalResolved;
+    case: "approvalResolved";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.ToolExecutionStarted tool_execution_started = 13;
+     */
+    value: ToolExecutionStarted;
+    case: "toolExecutionStarted";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Increases monotonically per conversation. 0 for events that aren't
+   * logged, such as the initial TurnStarted of a busy conversation,
+   * TurnProgress and SubagentUpdate.
+   *
+   * @generated from field: int64 sequence = 7;
+   */
+  sequence: bigint;
+};
+
+/**
+ * Describes the message blippy.conversation.WatchEventsEvent.
+ * Use `create(WatchEventsEventSchema)` to create a new message.
+ */
+export const WatchEventsEventSchema: GenMessage<WatchEventsEvent> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 35);
+
+/**
+ * ApprovalRequested is sent when a tool call of the active turn, or of an
+ * agent it called, matches an approval rule of its agent. The call waits
+ * until it's resolved with SystemService.ResolveApproval, or expires and is
+ * denied.
+ *
+ * @generated from message blippy.conversation.ApprovalRequested
+ */
+export type ApprovalRequested = Message$1<"blippy.conversation.ApprovalRequested"> & {
+  /**
+   * @generated from field: string approval_id = 1;
+   */
+  approvalId: string;
+
+  /**
+   * @generated from field: string run_id = 2;
+   */
+  runId: string;
+
+  /**
+   * The conversation of the call's run; that of a subagent if it isn't the
+   * watched conversation.
+   *
+   * @generated from field: string conversation_id = 3;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 4;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 5;
+   */
+  agentName: string;
+
+  /**
+   * @generated from field: string tool_name = 6;
+   */
+  toolName: string;
+
+  /**
+   * JSON arguments of the call.
+   *
+   * @generated from field: string input = 7;
+   */
+  input: string;
+
+  /**
+   * The rule the call matched, e.g. `bash command matches "rm|curl"`.
+   *
+   * @generated from field: string reason = 8;
+   */
+  reason: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 9;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.conversation.ApprovalRequested.
+ * Use `create(ApprovalRequestedSchema)` to create a new message.
+ */
+export const ApprovalRequestedSchema: GenMessage<ApprovalRequested> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 36);
+
+/**
+ * ApprovalResolved is sent when a tool call waiting for approval is
+ * approved, denied or expires.
+ *
+ * @generated from message blippy.conversation.ApprovalResolved
+ */
+export type ApprovalResolved = Message$1<"blippy.conversation.ApprovalResolved"> & {
+  /**
+   * @generated from field: string approval_id = 1;
+   */
+  approvalId: string;
+
+  /**
+   * @generated from field: bool approved = 2;
+   */
+  approved: boolean;
+
+  /**
+   * Why the call was denied, if given.
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message blippy.conversation.ApprovalResolved.
+ * Use `create(ApprovalResolvedSchema)` to create a new message.
+ */
+export const ApprovalResolvedSchema: GenMessage<ApprovalResolved> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 37);
+
+/**
+ * Gap is sent in place of events that were dropped because the client didn't
+ * keep up. Clients should reconnect with after_sequence set to
+ * resume_sequence - 1, or reload the conversation.
+ *
+ * @generated from message blippy.conversation.Gap
+ */
+export type Gap = Message$1<"blippy.conversation.Gap"> & {
+  /**
+   * @generated from field: int32 missed = 1;
+   */
+  missed: number;
+
+  /**
+   * @generated from field: int64 resume_sequence = 2;
+   */
+  resumeSequence: bigint;
+};
+
+/**
+ * Describes the message blippy.conversation.Gap.
+ * Use `create(GapSchema)` to create a new message.
+ */
+export const GapSchema: GenMessage<Gap> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 38);
+
+/**
+ * TurnProgress is sent periodically while a turn is active.
+ *
+ * @generated from message blippy.conversation.TurnProgress
+ */
+export type TurnProgress = Message$1<"blippy.conversation.TurnProgress"> & {
+  /**
+   * Milliseconds since the turn started.
+   *
+   * @generated from field: int64 elapsed_ms = 1;
+   */
+  elapsedMs: bigint;
+
+  /**
+   * Names of the tools being executed, if any.
+   *
+   * @generated from field: repeated string tools = 2;
+   */
+  tools: string[];
+
+  /**
+   * Tokens used in the turn so far, as reported by the LLM provider.
+   *
+   * @generated from field: int64 input_tokens = 3;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 4;
+   */
+  outputTokens: bigint;
+};
+
+/**
+ * Describes the message blippy.conversation.TurnProgress.
+ * Use `create(TurnProgressSchema)` to create a new message.
+ */
+export const TurnProgressSchema: GenMessage<TurnProgress> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 39);
+
+/**
+ * SubagentUpdate is live output of an agent called by the active turn, e.g.
+ * with call_agent. Updates of a run share its run_id; the last one has done
+ * set.
+ *
+ * @generated from message blippy.conversation.SubagentUpdate
+ */
+export type SubagentUpdate = Message$1<"blippy.conversation.SubagentUpdate"> & {
+  /**
+   * @generated from field: string run_id = 1;
+   */
+  runId: string;
+
+  /**
+   * The subagent's conversation.
+   *
+   * @generated from field: string conversation_id = 2;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 3;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 4;
+   */
+  agentName: string;
+
+  /**
+   * @generated from oneof blippy.conversation.SubagentUpdate.update
+   */
+  update: {
+    /**
+     * @generated from field: blippy.conversation.TextDelta text_delta = 5;
+     */
+    value: TextDelta;
+    case: "textDelta";
+  } | {
+    /**
+     * @generated from field: blippy.conversation.ToolResult tool_result = 6;
+     */
+    value: ToolResult;
+    case: "toolResult";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * @generated from field: bool done = 7;
+   */
+  done: boolean;
+};
+
+/**
+ * Describes the message blippy.conversation.SubagentUpdate.
+ * Use `create(SubagentUpdateSchema)` to create a new message.
+ */
+export const SubagentUpdateSchema: GenMessage<SubagentUpdate> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 40);
+
+/**
+ * @generated from message blippy.conversation.TextDelta
+ */
+export type TextDelta = Message$1<"blippy.conversation.TextDelta"> & {
+  /**
+   * @generated from field: string content = 1;
+   */
+  content: string;
+};
+
+/**
+ * Describes the message blippy.conversation.TextDelta.
+ * Use `create(TextDeltaSchema)` to create a new message.
+ */
+export const TextDeltaSchema: GenMessage<TextDelta> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 41);
+
+/**
+ * @generated from message blippy.conversation.ToolResult
+ */
+export type ToolResult = Message$1<"blippy.conversation.ToolResult"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string input = 2;
+   */
+  input: string;
+
+  /**
+   * @generated from field: string result = 3;
    */
   result: string;
+
+  /**
+   * Set if the tool call failed; the result is then the error message.
+   *
+   * @generated from field: blippy.apierror.ErrorCode error_code = 4;
+   */
+  errorCode: ErrorCode;
+
+  /**
+   * Matches the call_id of the call's ToolExecutionStarted events.
+   *
+   * @generated from field: string call_id = 5;
+   */
+  callId: string;
 };
 
 /**
@@ -444,7 +1526,41 @@ export type ToolResult = Message$1<"blippy.conversation.ToolResult"> & {
  * Use `create(ToolResultSchema)` to create a new message.
  */
 export const ToolResultSchema: GenMessage<ToolResult> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 17);
+  messageDesc(file_conversation_conversation, 42);
+
+/**
+ * ToolExecutionStarted is sent when the LLM starts a tool call, and for each
+ * part of its JSON arguments as they're streamed, so clients can show the
+ * call, e.g. the command being run, until its ToolResult.
+ *
+ * @generated from message blippy.conversation.ToolExecutionStarted
+ */
+export type ToolExecutionStarted = Message$1<"blippy.conversation.ToolExecutionStarted"> & {
+  /**
+   * @generated from field: string call_id = 1;
+   */
+  callId: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * Appended to the arguments of the call's earlier events; empty when the
+   * call starts.
+   *
+   * @generated from field: string arguments_delta = 3;
+   */
+  argumentsDelta: string;
+};
+
+/**
+ * Describes the message blippy.conversation.ToolExecutionStarted.
+ * Use `create(ToolExecutionStartedSchema)` to create a new message.
+ */
+export const ToolExecutionStartedSchema: GenMessage<ToolExecutionStarted> = /*@__PURE__*/
+  messageDesc(file_conversation_conversation, 43);
 
 /**
  * @generated from message blippy.conversation.MessageCreated
@@ -461,7 +1577,7 @@ export type MessageCreated = Message$1<"blippy.conversation.MessageCreated"> & {
  * Use `create(MessageCreatedSchema)` to create a new message.
  */
 export const MessageCreatedSchema: GenMessage<MessageCreated> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 18);
+  messageDesc(file_conversation_conversation, 44);
 
 /**
  * @generated from message blippy.conversation.WatchError
@@ -471,6 +1587,11 @@ export type WatchError = Message$1<"blippy.conversation.WatchError"> & {
    * @generated from field: string message = 1;
    */
   message: string;
+
+  /**
+   * @generated from field: blippy.apierror.ErrorCode code = 2;
+   */
+  code: ErrorCode;
 };
 
 /**
@@ -478,7 +1599,7 @@ export type WatchError = Message$1<"blippy.conversation.WatchError"> & {
  * Use `create(WatchErrorSchema)` to create a new message.
  */
 export const WatchErrorSchema: GenMessage<WatchError> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 19);
+  messageDesc(file_conversation_conversation, 45);
 
 /**
  * @generated from message blippy.conversation.TurnDone
@@ -495,7 +1616,7 @@ export type TurnDone = Message$1<"blippy.conversation.TurnDone"> & {
  * Use `create(TurnDoneSchema)` to create a new message.
  */
 export const TurnDoneSchema: GenMessage<TurnDone> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 20);
+  messageDesc(file_conversation_conversation, 46);
 
 /**
  * @generated from message blippy.conversation.TurnStarted
@@ -508,7 +1629,7 @@ export type TurnStarted = Message$1<"blippy.conversation.TurnStarted"> & {
  * Use `create(TurnStartedSchema)` to create a new message.
  */
 export const TurnStartedSchema: GenMessage<TurnStarted> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 21);
+  messageDesc(file_conversation_conversation, 47);
 
 /**
  * @generated from message blippy.conversation.Empty
@@ -521,7 +1642,7 @@ export type Empty = Message$1<"blippy.conversation.Empty"> & {
  * Use `create(EmptySchema)` to create a new message.
  */
 export const EmptySchema: GenMessage<Empty> = /*@__PURE__*/
-  messageDesc(file_conversation_conversation, 22);
+  messageDesc(file_conversation_conversation, 48);
 
 /**
  * @generated from service blippy.conversation.ConversationService
@@ -559,6 +1680,16 @@ export const ConversationService: GenService<{
     input: typeof DeleteConversationRequestSchema;
     output: typeof EmptySchema;
   },
+  /**
+   * Deletes many conversations in a single transaction.
+   *
+   * @generated from rpc blippy.conversation.ConversationService.BatchDeleteConversations
+   */
+  batchDeleteConversations: {
+    methodKind: "unary";
+    input: typeof BatchDeleteConversationsRequestSchema;
+    output: typeof EmptySchema;
+  },
   /**
    * @generated from rpc blippy.conversation.ConversationService.GetMessages
    */
@@ -567,6 +1698,40 @@ export const ConversationService: GenService<{
     input: typeof GetMessagesRequestSchema;
     output: typeof GetMessagesResponseSchema;
   },
+  /**
+   * @generated from rpc blippy.conversation.ConversationService.GetConversationCost
+   */
+  getConversationCost: {
+    methodKind: "unary";
+    input: typeof GetConversationCostRequestSchema;
+    output: typeof ConversationCostSchema;
+  },
+  /**
+   * Lists the artifacts tool calls registered on the conversation's runs.
+   *
+   * @generated from rpc blippy.conversation.ConversationService.ListRunArtifacts
+   */
+  listRunArtifacts: {
+    methodKind: "unary";
+    input: typeof ListRunArtifactsRequestSchema;
+    output: typeof ListRunArtifactsResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.conversation.ConversationService.GetUsage
+   */
+  getUsage: {
+    methodKind: "unary";
+    input: typeof GetUsageRequestSchema;
+    output: typeof GetUsageResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.conversation.ConversationService.SearchConversations
+   */
+  searchConversations: {
+    methodKind: "unary";
+    input: typeof SearchConversationsRequestSchema;
+    output: typeof SearchConversationsResponseSchema;
+  },
   /**
    * @generated from rpc blippy.conversation.ConversationService.Chat
    */
@@ -0,0 +1,43 @@
+// @generated by protoc-gen-connect-query v2.2.0 with parameter "target=ts"
+// @generated from file eval/eval.proto (package blippy.eval, syntax proto3)
+/* eslint-disable */
+
+import { EvalService } from "./eval_pb";
+
+/**
+ * @generated from rpc blippy.eval.EvalService.CreateEvalCase
+ */
+export const createEvalCase = EvalService.method.createEvalCase;
+
+/**
+ * @generated from rpc blippy.eval.EvalService.ListEvalCases
+ */
+export const listEvalCases = EvalService.method.listEvalCases;
+
+/**
+ * @generated from rpc blippy.eval.EvalService.UpdateEvalCase
+ */
+export const updateEvalCase = EvalService.method.updateEvalCase;
+
+/**
+ * @generated from rpc blippy.eval.EvalService.DeleteEvalCase
+ */
+export const deleteEvalCase = EvalService.method.deleteEvalCase;
+
+/**
+ * Starts running eval cases in the background, and returns the run.
+ * Poll GetEvalRun for the results.
+ *
+ * @generated from rpc blippy.eval.EvalService.RunEvals
+ */
+export const runEvals = EvalService.method.runEvals;
+
+/**
+ * @generated from rpc blippy.eval.EvalService.GetEvalRun
+ */
+export const getEvalRun = EvalService.method.getEvalRun;
+
+/**
+ * @generated from rpc blippy.eval.EvalService.ListEvalRuns
+ */
+export const listEvalRuns = EvalService.method.listEvalRuns;
@@ -0,0 +1,557 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file eval/eval.proto (package blippy.eval, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file eval/eval.proto.
+ */
+export const file_eval_eval: GenFile = /*@__PURE__*/
+  fileDesc("Cg9ldmFsL2V2YWwucHJvdG8SC2JsaXBweS5ldmFsIigKCUFzc2VydGlvbhIMCgR0eXBlGAEgASgJEg0KBXZhbHVlGAIgASgJIuIBCghFdmFsQ2FzZRIKCgJpZBgBIAEoCRIQCghhZ2VudF9pZBgCIAEoCRIMCgRuYW1lGAMgASgJEg4KBnByb21wdBgEIAEoCRIqCgphc3NlcnRpb25zGAUgAygLMhYuYmxpcHB5LmV2YWwuQXNzZXJ0aW9uEg4KBnJ1YnJpYxgGIAEoCRIuCgpjcmVhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCKDAQoVQ3JlYXRlRXZhbENhc2VSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEgwKBG5hbWUYAiABKAkSDgoGcHJvbXB0GAMgASgJEioKCmFzc2VydGlvbnMYBCADKAsyFi5ibGlwcHkuZXZhbC5Bc3NlcnRpb24SDgoGcnVicmljGAUgASgJIigKFExpc3RFdmFsQ2FzZXNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIj0KFUxpc3RFdmFsQ2FzZXNSZXNwb25zZRIkCgVjYXNlcxgBIAMoCzIVLmJsaXBweS5ldmFsLkV2YWxDYXNlIn0KFVVwZGF0ZUV2YWxDYXNlUmVxdWVzdBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEg4KBnByb21wdBgDIAEoCRIqCgphc3NlcnRpb25zGAQgAygLMhYuYmxpcHB5LmV2YWwuQXNzZXJ0aW9uEg4KBnJ1YnJpYxgFIAEoCSIjChVEZWxldGVFdmFsQ2FzZVJlcXVlc3QSCgoCaWQYASABKAkiGAoWRGVsZXRlRXZhbENhc2VSZXNwb25zZSK0AQoKRXZhbFJlc3VsdBIPCgdjYXNlX2lkGAEgASgJEhEKCWNhc2VfbmFtZRgCIAEoCRIXCg9jb252ZXJzYXRpb25faWQYAyABKAkSEAoIcmVzcG9uc2UYBCABKAkSDgoGcGFzc2VkGAUgASgIEg0KBXNjb3JlGAYgASgBEhAKCGZhaWx1cmVzGAcgAygJEhcKD2p1ZGdlX3JlYXNvbmluZxgIIAEoCRINCgVlcnJvchgJIAEoCSK4AgoHRXZhbFJ1bhIKCgJpZBgBIAEoCRIQCghhZ2VudF9pZBgCIAEoCRIVCg1zeXN0ZW1fcHJvbXB0GAMgASgJEg0KBW1vZGVsGAQgASgJEhEKCWNhbmRpZGF0ZRgFIAEoCBIOCgZzdGF0dXMYBiABKAkSDQoFc2NvcmUYByABKAESDgoGcGFzc2VkGAggASgFEg0KBXRvdGFsGAkgASgFEigKB3Jlc3VsdHMYCiADKAsyFy5ibGlwcHkuZXZhbC5FdmFsUmVzdWx0Eg0KBWVycm9yGAsgASgJEi4KCmNyZWF0ZWRfYXQYDCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi8KC2ZpbmlzaGVkX2F0GA0gASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJbCg9SdW5FdmFsc1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkSEAoIY2FzZV9pZHMYAiADKAkSFQoNc3lzdGVtX3Byb21wdBgDIAEoCRINCgVtb2RlbBgEIAEoCSIfChFHZXRFdmFsUnVuUmVxdWVzdBIKCgJpZBgBIAEoCSInChNMaXN0RXZhbFJ1bnNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIjoKFExpc3RFdmFsUnVuc1Jlc3BvbnNlEiIKBHJ1bnMYASADKAsyFC5ibGlwcHkuZXZhbC5FdmFsUnVuMrMECgtFdmFsU2VydmljZRJLCg5DcmVhdGVFdmFsQ2FzZRIiLmJsaXBweS5ldmFsLkNyZWF0ZUV2YWxDYXNlUmVxdWVzdBoVLmJsaXBweS5ldmFsLkV2YWxDYXNlElYKDUxpc3RFdmFsQ2FzZXMSIS5ibGlwcHkuZXZhbC5MaXN0RXZhbENhc2VzUmVxdWVzdBoiLmJsaXBweS5ldmFsLkxpc3RFdmFsQ2FzZXNSZXNwb25zZRJLCg5VcGRhdGVFdmFsQ2FzZRIiLmJsaXBweS5ldmFsLlVwZGF0ZUV2YWxDYXNlUmVxdWVzdBoVLmJsaXBweS5ldmFsLkV2YWxDYXNlElkKDkRlbGV0ZUV2YWxDYXNlEiIuYmxpcHB5LmV2YWwuRGVsZXRlRXZhbENhc2VSZXF1ZXN0GiMuYmxpcHB5LmV2YWwuRGVsZXRlRXZhbENhc2VSZXNwb25zZRI+CghSdW5FdmFscxIcLmJsaXBweS5ldmFsLlJ1bkV2YWxzUmVxdWVzdBoULmJsaXBweS5ldmFsLkV2YWxSdW4SQgoKR2V0RXZhbFJ1bhIeLmJsaXBweS5ldmFsLkdldEV2YWxSdW5SZXF1ZXN0GhQuYmxpcHB5LmV2YWwuRXZhbFJ1bhJTCgxMaXN0RXZhbFJ1bnMSIC5ibGlwcHkuZXZhbC5MaXN0RXZhbFJ1bnNSZXF1ZXN0GiEuYmxpcHB5LmV2YWwuTGlzdEV2YWxSdW5zUmVzcG9uc2VCKlooZ2l0aHViLmNvbS9kc3RvdGlqbi9ibGlwcHkvaW50ZXJuYWwvZXZhbGIGcHJvdG8z", [file_google_protobuf_timestamp]);
+
+/**
+ * Assertion is a check on an agent's final answer.
+ *
+ * @generated from message blippy.eval.Assertion
+ */
+export type Assertion = Message<"blippy.eval.Assertion"> & {
+  /**
+   * "contains", "not_contains" (case-insensitive substrings), "regex" or
+   * "tool_called" (the name of a tool the agent must call).
+   *
+   * @generated from field: string type = 1;
+   */
+  type: string;
+
+  /**
+   * @generated from field: string value = 2;
+   */
+  value: string;
+};
+
+/**
+ * Describes the message blippy.eval.Assertion.
+ * Use `create(AssertionSchema)` to create a new message.
+ */
+export const AssertionSchema: GenMessage<Assertion> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 0);
+
+/**
+ * EvalCase is a test case of an agent: a prompt, and what the answer must
+ * satisfy.
+ *
+ * @generated from message blippy.eval.EvalCase
+ */
+export type EvalCase = Message<"blippy.eval.EvalCase"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string name = 3;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string prompt = 4;
+   */
+  prompt: string;
+
+  /**
+   * @generated from field: repeated blippy.eval.Assertion assertions = 5;
+   */
+  assertions: Assertion[];
+
+  /**
+   * Optional rubric an LLM judge grades the answer with, from 0 to 1.
+   *
+   * @generated from field: string rubric = 6;
+   */
+  rubric: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 8;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.eval.EvalCase.
+ * Use `create(EvalCaseSchema)` to create a new message.
+ */
+export const EvalCaseSchema: GenMessage<EvalCase> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 1);
+
+/**
+ * @generated from message blippy.eval.CreateEvalCaseRequest
+ */
+export type CreateEvalCaseRequest = Message<"blippy.eval.CreateEvalCaseRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string prompt = 3;
+   */
+  prompt: string;
+
+  /**
+   * @generated from field: repeated blippy.eval.Assertion assertions = 4;
+   */
+  assertions: Assertion[];
+
+  /**
+   * @generated from field: string rubric = 5;
+   */
+  rubric: string;
+};
+
+/**
+ * Describes the message blippy.eval.CreateEvalCaseRequest.
+ * Use `create(CreateEvalCaseRequestSchema)` to create a new message.
+ */
+export const CreateEvalCaseRequestSchema: GenMessage<CreateEvalCaseRequest> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 2);
+
+/**
+ * @generated from message blippy.eval.ListEvalCasesRequest
+ */
+export type ListEvalCasesRequest = Message<"blippy.eval.ListEvalCasesRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message blippy.eval.ListEvalCasesRequest.
+ * Use `create(ListEvalCasesRequestSchema)` to create a new message.
+ */
+export const ListEvalCasesRequestSchema: GenMessage<ListEvalCasesRequest> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 3);
+
+/**
+ * @generated from message blippy.eval.ListEvalCasesResponse
+ */
+export type ListEvalCasesResponse = Message<"blippy.eval.ListEvalCasesResponse"> & {
+  /**
+   * @generated from field: repeated blippy.eval.EvalCase cases = 1;
+   */
+  cases: EvalCase[];
+};
+
+/**
+ * Describes the message blippy.eval.ListEvalCasesResponse.
+ * Use `create(ListEvalCasesResponseSchema)` to create a new message.
+ */
+export const ListEvalCasesResponseSchema: GenMessage<ListEvalCasesResponse> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 4);
+
+/**
+ * @generated from message blippy.eval.UpdateEvalCaseRequest
+ */
+export type UpdateEvalCaseRequest = Message<"blippy.eval.UpdateEvalCaseRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string prompt = 3;
+   */
+  prompt: string;
+
+  /**
+   * @generated from field: repeated blippy.eval.Assertion assertions = 4;
+   */
+  assertions: Assertion[];
+
+  /**
+   * @generated from field: string rubric = 5;
+   */
+  rubric: string;
+};
+
+/**
+ * Describes the message blippy.eval.UpdateEvalCaseRequest.
+ * Use `create(UpdateEvalCaseRequestSchema)` to create a new message.
+ */
+export const UpdateEvalCaseRequestSchema: GenMessage<UpdateEvalCaseRequest> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 5);
+
+/**
+ * @generated from message blippy.eval.DeleteEvalCaseRequest
+ */
+export type DeleteEvalCaseRequest = Message<"blippy.eval.DeleteEvalCaseRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.eval.DeleteEvalCaseRequest.
+ * Use `create(DeleteEvalCaseRequestSchema)` to create a new message.
+ */
+export const DeleteEvalCaseRequestSchema: GenMessage<DeleteEvalCaseRequest> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 6);
+
+/**
+ * @generated from message blippy.eval.DeleteEvalCaseResponse
+ */
+export type DeleteEvalCaseResponse = Message<"blippy.eval.DeleteEvalCaseResponse"> & {
+};
+
+/**
+ * Describes the message blippy.eval.DeleteEvalCaseResponse.
+ * Use `create(DeleteEvalCaseResponseSchema)` to create a new message.
+ */
+export const DeleteEvalCaseResponseSchema: GenMessage<DeleteEvalCaseResponse> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 7);
+
+/**
+ * EvalResult is the outcome of an eval case in a run.
+ *
+ * @generated from message blippy.eval.EvalResult
+ */
+export type EvalResult = Message<"blippy.eval.EvalResult"> & {
+  /**
+   * @generated from field: string case_id = 1;
+   */
+  caseId: string;
+
+  /**
+   * @generated from field: string case_name = 2;
+   */
+  caseName: string;
+
+  /**
+   * The conversation the case ran in.
+   *
+   * @generated from field: string conversation_id = 3;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string response = 4;
+   */
+  response: string;
+
+  /**
+   * Whether all assertions held and the judge's score is at least 0.5.
+   *
+   * @generated from field: bool passed = 5;
+   */
+  passed: boolean;
+
+  /**
+   * The fraction of checks passed, with the judge's score counting as one
+   * check.
+   *
+   * @generated from field: double score = 6;
+   */
+  score: number;
+
+  /**
+   * The assertions that didn't hold.
+   *
+   * @generated from field: repeated string failures = 7;
+   */
+  failures: string[];
+
+  /**
+   * @generated from field: string judge_reasoning = 8;
+   */
+  judgeReasoning: string;
+
+  /**
+   * Why the case couldn't be run or judged, if it couldn't.
+   *
+   * @generated from field: string error = 9;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message blippy.eval.EvalResult.
+ * Use `create(EvalResultSchema)` to create a new message.
+ */
+export const EvalResultSchema: GenMessage<EvalResult> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 8);
+
+/**
+ * EvalRun is a run of an agent's eval cases.
+ *
+ * @generated from message blippy.eval.EvalRun
+ */
+export type EvalRun = Message<"blippy.eval.EvalRun"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * The system prompt and model evaluated.
+   *
+   * @generated from field: string system_prompt = 3;
+   */
+  systemPrompt: string;
+
+  /**
+   * @generated from field: string model = 4;
+   */
+  model: string;
+
+  /**
+   * Whether a candidate prompt or model was evaluated instead of the
+   * agent's own.
+   *
+   * @generated from field: bool candidate = 5;
+   */
+  candidate: boolean;
+
+  /**
+   * "running", "completed", "failed" or "interrupted".
+   *
+   * @generated from field: string status = 6;
+   */
+  status: string;
+
+  /**
+   * The average score of the cases.
+   *
+   * @generated from field: double score = 7;
+   */
+  score: number;
+
+  /**
+   * @generated from field: int32 passed = 8;
+   */
+  passed: number;
+
+  /**
+   * @generated from field: int32 total = 9;
+   */
+  total: number;
+
+  /**
+   * @generated from field: repeated blippy.eval.EvalResult results = 10;
+   */
+  results: EvalResult[];
+
+  /**
+   * @generated from field: string error = 11;
+   */
+  error: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 12;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * zero value while running
+   *
+   * @generated from field: google.protobuf.Timestamp finished_at = 13;
+   */
+  finishedAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.eval.EvalRun.
+ * Use `create(EvalRunSchema)` to create a new message.
+ */
+export const EvalRunSchema: GenMessage<EvalRun> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 9);
+
+/**
+ * @generated from message blippy.eval.RunEvalsRequest
+ */
+export type RunEvalsRequest = Message<"blippy.eval.RunEvalsRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * The cases to run; all cases of the agent if empty.
+   *
+   * @generated from field: repeated string case_ids = 2;
+   */
+  caseIds: string[];
+
+  /**
+   * Optional candidate system prompt to evaluate instead of the agent's.
+   *
+   * @generated from field: string system_prompt = 3;
+   */
+  systemPrompt: string;
+
+  /**
+   * Optional candidate model to evaluate instead of the agent's.
+   *
+   * @generated from field: string model = 4;
+   */
+  model: string;
+};
+
+/**
+ * Describes the message blippy.eval.RunEvalsRequest.
+ * Use `create(RunEvalsRequestSchema)` to create a new message.
+ */
+export const RunEvalsRequestSchema: GenMessage<RunEvalsRequest> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 10);
+
+/**
+ * @generated from message blippy.eval.GetEvalRunRequest
+ */
+export type GetEvalRunRequest = Message<"blippy.eval.GetEvalRunRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.eval.GetEvalRunRequest.
+ * Use `create(GetEvalRunRequestSchema)` to create a new message.
+ */
+export const GetEvalRunRequestSchema: GenMessage<GetEvalRunRequest> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 11);
+
+/**
+ * @generated from message blippy.eval.ListEvalRunsRequest
+ */
+export type ListEvalRunsRequest = Message<"blippy.eval.ListEvalRunsRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message blippy.eval.ListEvalRunsRequest.
+ * Use `create(ListEvalRunsRequestSchema)` to create a new message.
+ */
+export const ListEvalRunsRequestSchema: GenMessage<ListEvalRunsRequest> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 12);
+
+/**
+ * @generated from message blippy.eval.ListEvalRunsResponse
+ */
+export type ListEvalRunsResponse = Message<"blippy.eval.ListEvalRunsResponse"> & {
+  /**
+   * Newest first, without per-case results.
+   *
+   * @generated from field: repeated blippy.eval.EvalRun runs = 1;
+   */
+  runs: EvalRun[];
+};
+
+/**
+ * Describes the message blippy.eval.ListEvalRunsResponse.
+ * Use `create(ListEvalRunsResponseSchema)` to create a new message.
+ */
+export const ListEvalRunsResponseSchema: GenMessage<ListEvalRunsResponse> = /*@__PURE__*/
+  messageDesc(file_eval_eval, 13);
+
+/**
+ * EvalService regression-tests agents with eval cases.
+ *
+ * @generated from service blippy.eval.EvalService
+ */
+export const EvalService: GenService<{
+  /**
+   * @generated from rpc blippy.eval.EvalService.CreateEvalCase
+   */
+  createEvalCase: {
+    methodKind: "unary";
+    input: typeof CreateEvalCaseRequestSchema;
+    output: typeof EvalCaseSchema;
+  },
+  /**
+   * @generated from rpc blippy.eval.EvalService.ListEvalCases
+   */
+  listEvalCases: {
+    methodKind: "unary";
+    input: typeof ListEvalCasesRequestSchema;
+    output: typeof ListEvalCasesResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.eval.EvalService.UpdateEvalCase
+   */
+  updateEvalCase: {
+    methodKind: "unary";
+    input: typeof UpdateEvalCaseRequestSchema;
+    output: typeof EvalCaseSchema;
+  },
+  /**
+   * @generated from rpc blippy.eval.EvalService.DeleteEvalCase
+   */
+  deleteEvalCase: {
+    methodKind: "unary";
+    input: typeof DeleteEvalCaseRequestSchema;
+    output: typeof DeleteEvalCaseResponseSchema;
+  },
+  /**
+   * Starts running eval cases in the background, and returns the run.
+   * Poll GetEvalRun for the results.
+   *
+   * @generated from rpc blippy.eval.EvalService.RunEvals
+   */
+  runEvals: {
+    methodKind: "unary";
+    input: typeof RunEvalsRequestSchema;
+    output: typeof EvalRunSchema;
+  },
+  /**
+   * @generated from rpc blippy.eval.EvalService.GetEvalRun
+   */
+  getEvalRun: {
+    methodKind: "unary";
+    input: typeof GetEvalRunRequestSchema;
+    output: typeof EvalRunSchema;
+  },
+  /**
+   * @generated from rpc blippy.eval.EvalService.ListEvalRuns
+   */
+  listEvalRuns: {
+    methodKind: "unary";
+    input: typeof ListEvalRunsRequestSchema;
+    output: typeof ListEvalRunsResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_eval_eval, 0);
+
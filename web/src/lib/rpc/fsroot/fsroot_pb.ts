@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file fsroot/fsroot.proto.
  */
 export const file_fsroot_fsroot: GenFile = /*@__PURE__*/
-  fileDesc("ChNmc3Jvb3QvZnNyb290LnByb3RvEg1ibGlwcHkuZnNyb290Iq0BCg5GaWxlc3lzdGVtUm9vdBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEgwKBHBhdGgYAyABKAkSEwoLZGVzY3JpcHRpb24YBCABKAkSLgoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiTgobQ3JlYXRlRmlsZXN5c3RlbVJvb3RSZXF1ZXN0EgwKBG5hbWUYASABKAkSDAoEcGF0aBgCIAEoCRITCgtkZXNjcmlwdGlvbhgDIAEoCSImChhHZXRGaWxlc3lzdGVtUm9vdFJlcXVlc3QSCgoCaWQYASABKAkiHAoaTGlzdEZpbGVzeXN0ZW1Sb290c1JlcXVlc3QiSwobTGlzdEZpbGVzeXN0ZW1Sb290c1Jlc3BvbnNlEiwKBXJvb3RzGAEgAygLMh0uYmxpcHB5LmZzcm9vdC5GaWxlc3lzdGVtUm9vdCJaChtVcGRhdGVGaWxlc3lzdGVtUm9vdFJlcXVlc3QSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIMCgRwYXRoGAMgASgJEhMKC2Rlc2NyaXB0aW9uGAQgASgJIikKG0RlbGV0ZUZpbGVzeXN0ZW1Sb290UmVxdWVzdBIKCgJpZBgBIAEoCSIHCgVFbXB0eTKCBAoVRmlsZXN5c3RlbVJvb3RTZXJ2aWNlEmEKFENyZWF0ZUZpbGVzeXN0ZW1Sb290EiouYmxpcHB5LmZzcm9vdC5DcmVhdGVGaWxlc3lzdGVtUm9vdFJlcXVlc3QaHS5ibGlwcHkuZnNyb290LkZpbGVzeXN0ZW1Sb290ElsKEUdldEZpbGVzeXN0ZW1Sb290EicuYmxpcHB5LmZzcm9vdC5HZXRGaWxlc3lzdGVtUm9vdFJlcXVlc3QaHS5ibGlwcHkuZnNyb290LkZpbGVzeXN0ZW1Sb290EmwKE0xpc3RGaWxlc3lzdGVtUm9vdHMSKS5ibGlwcHkuZnNyb290Lkxpc3RGaWxlc3lzdGVtUm9vdHNSZXF1ZXN0GiouYmxpcHB5LmZzcm9vdC5MaXN0RmlsZXN5c3RlbVJvb3RzUmVzcG9uc2USYQoUVXBkYXRlRmlsZXN5c3RlbVJvb3QSKi5ibGlwcHkuZnNyb290LlVwZGF0ZUZpbGVzeXN0ZW1Sb290UmVxdWVzdBodLmJsaXBweS5mc3Jvb3QuRmlsZXN5c3RlbVJvb3QSWAoURGVsZXRlRmlsZXN5c3RlbVJvb3QSKi5ibGlwcHkuZnNyb290LkRlbGV0ZUZpbGVzeXN0ZW1Sb290UmVxdWVzdBoULmJsaXBweS5mc3Jvb3QuRW1wdHlCLFoqZ2l0aHViLmNvbS9kc3RvdGlqbi9ibGlwcHkvaW50ZXJuYWwvZnNyb290YgZwcm90bzM", [file_google_protobuf_timestamp]);
+  fileDesc("ChNmc3Jvb3QvZnNyb290LnByb3RvEg1ibGlwcHkuZnNyb290Ir4BCg5GaWxlc3lzdGVtUm9vdBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEgwKBHBhdGgYAyABKAkSEwoLZGVzY3JpcHRpb24YBCABKAkSLgoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDwoHdmVyc2lvbhgHIAEoAyJOChtDcmVhdGVGaWxlc3lzdGVtUm9vdFJlcXVlc3QSDAoEbmFtZRgBIAEoCRIMCgRwYXRoGAIgASgJEhMKC2Rlc2NyaXB0aW9uGAMgASgJIiYKGEdldEZpbGVzeXN0ZW1Sb290UmVxdWVzdBIKCgJpZBgBIAEoCSIcChpMaXN0RmlsZXN5c3RlbVJvb3RzUmVxdWVzdCJLChtMaXN0RmlsZXN5c3RlbVJvb3RzUmVzcG9uc2USLAoFcm9vdHMYASADKAsyHS5ibGlwcHkuZnNyb290LkZpbGVzeXN0ZW1Sb290ImsKG1VwZGF0ZUZpbGVzeXN0ZW1Sb290UmVxdWVzdBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEgwKBHBhdGgYAyABKAkSEwoLZGVzY3JpcHRpb24YBCABKAkSDwoHdmVyc2lvbhgFIAEoAyIpChtEZWxldGVGaWxlc3lzdGVtUm9vdFJlcXVlc3QSCgoCaWQYASABKAkiBwoFRW1wdHkyggQKFUZpbGVzeXN0ZW1Sb290U2VydmljZRJhChRDcmVhdGVGaWxlc3lzdGVtUm9vdBIqLmJsaXBweS5mc3Jvb3QuQ3JlYXRlRmlsZXN5c3RlbVJvb3RSZXF1ZXN0Gh0uYmxpcHB5LmZzcm9vdC5GaWxlc3lzdGVtUm9vdBJbChFHZXRGaWxlc3lzdGVtUm9vdBInLmJsaXBweS5mc3Jvb3QuR2V0RmlsZXN5c3RlbVJvb3RSZXF1ZXN0Gh0uYmxpcHB5LmZzcm9vdC5GaWxlc3lzdGVtUm9vdBJsChNMaXN0RmlsZXN5c3RlbVJvb3RzEikuYmxpcHB5LmZzcm9vdC5MaXN0RmlsZXN5c3RlbVJvb3RzUmVxdWVzdBoqLmJsaXBweS5mc3Jvb3QuTGlzdEZpbGVzeXN0ZW1Sb290c1Jlc3BvbnNlEmEKFFVwZGF0ZUZpbGVzeXN0ZW1Sb290EiouYmxpcHB5LmZzcm9vdC5VcGRhdGVGaWxlc3lzdGVtUm9vdFJlcXVlc3QaHS5ibGlwcHkuZnNyb290LkZpbGVzeXN0ZW1Sb290ElgKFERlbGV0ZUZpbGVzeXN0ZW1Sb290EiouYmxpcHB5LmZzcm9vdC5EZWxldGVGaWxlc3lzdGVtUm9vdFJlcXVlc3QaFC5ibGlwcHkuZnNyb290LkVtcHR5QixaKmdpdGh1Yi5jb20vZHN0b3Rpam4vYmxpcHB5L2ludGVybmFsL2Zzcm9vdGIGcHJvdG8z", [file_google_protobuf_timestamp]);
 
 /**
  * @generated from message blippy.fsroot.FilesystemRoot
@@ -47,6 +47,13 @@ export type FilesystemRoot = Message<"blippy.fsroot.FilesystemRoot"> & {
    * @generated from field: google.protobuf.Timestamp updated_at = 6;
    */
   updatedAt?: Timestamp;
+
+  /**
+   * Incremented on every update
+   *
+   * @generated from field: int64 version = 7;
+   */
+  version: bigint;
 };
 
 /**
@@ -153,6 +160,13 @@ export type UpdateFilesystemRootRequest = Message<"blippy.fsroot.UpdateFilesyste
    * @generated from field: string description = 4;
    */
   description: string;
+
+  /**
+   * Version the update is based on; fails with ABORTED if stale
+   *
+   * @generated from field: int64 version = 5;
+   */
+  version: bigint;
 };
 
 /**
@@ -28,3 +28,18 @@ export const updateNotificationChannel = NotificationChannelService.method.updat
  * @generated from rpc blippy.notification.NotificationChannelService.DeleteNotificationChannel
  */
 export const deleteNotificationChannel = NotificationChannelService.method.deleteNotificationChannel;
+
+/**
+ * @generated from rpc blippy.notification.NotificationChannelService.GetWebPushConfig
+ */
+export const getWebPushConfig = NotificationChannelService.method.getWebPushConfig;
+
+/**
+ * @generated from rpc blippy.notification.NotificationChannelService.CreateWebPushSubscription
+ */
+export const createWebPushSubscription = NotificationChannelService.method.createWebPushSubscription;
+
+/**
+ * @generated from rpc blippy.notification.NotificationChannelService.DeleteWebPushSubscription
+ */
+export const deleteWebPushSubscription = NotificationChannelService.method.deleteWebPushSubscription;
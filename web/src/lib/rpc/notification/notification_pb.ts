@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file notification/notification.proto.
  */
 export const file_notification_notification: GenFile = /*@__PURE__*/
-  fileDesc("Ch9ub3RpZmljYXRpb24vbm90aWZpY2F0aW9uLnByb3RvEhNibGlwcHkubm90aWZpY2F0aW9uItcBChNOb3RpZmljYXRpb25DaGFubmVsEgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSDAoEdHlwZRgDIAEoCRIOCgZjb25maWcYBCABKAkSEwoLZGVzY3JpcHRpb24YBSABKAkSEwoLanNvbl9zY2hlbWEYBiABKAkSLgoKY3JlYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAieAogQ3JlYXRlTm90aWZpY2F0aW9uQ2hhbm5lbFJlcXVlc3QSDAoEbmFtZRgBIAEoCRIMCgR0eXBlGAIgASgJEg4KBmNvbmZpZxgDIAEoCRITCgtkZXNjcmlwdGlvbhgEIAEoCRITCgtqc29uX3NjaGVtYRgFIAEoCSIrCh1HZXROb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBIKCgJpZBgBIAEoCSIhCh9MaXN0Tm90aWZpY2F0aW9uQ2hhbm5lbHNSZXF1ZXN0Il4KIExpc3ROb3RpZmljYXRpb25DaGFubmVsc1Jlc3BvbnNlEjoKCGNoYW5uZWxzGAEgAygLMiguYmxpcHB5Lm5vdGlmaWNhdGlvbi5Ob3RpZmljYXRpb25DaGFubmVsIoQBCiBVcGRhdGVOb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEgwKBHR5cGUYAyABKAkSDgoGY29uZmlnGAQgASgJEhMKC2Rlc2NyaXB0aW9uGAUgASgJEhMKC2pzb25fc2NoZW1hGAYgASgJIi4KIERlbGV0ZU5vdGlmaWNhdGlvbkNoYW5uZWxSZXF1ZXN0EgoKAmlkGAEgASgJIgcKBUVtcHR5MooFChpOb3RpZmljYXRpb25DaGFubmVsU2VydmljZRJ8ChlDcmVhdGVOb3RpZmljYXRpb25DaGFubmVsEjUuYmxpcHB5Lm5vdGlmaWNhdGlvbi5DcmVhdGVOb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBooLmJsaXBweS5ub3RpZmljYXRpb24uTm90aWZpY2F0aW9uQ2hhbm5lbBJ2ChZHZXROb3RpZmljYXRpb25DaGFubmVsEjIuYmxpcHB5Lm5vdGlmaWNhdGlvbi5HZXROb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBooLmJsaXBweS5ub3RpZmljYXRpb24uTm90aWZpY2F0aW9uQ2hhbm5lbBKHAQoYTGlzdE5vdGlmaWNhdGlvbkNoYW5uZWxzEjQuYmxpcHB5Lm5vdGlmaWNhdGlvbi5MaXN0Tm90aWZpY2F0aW9uQ2hhbm5lbHNSZXF1ZXN0GjUuYmxpcHB5Lm5vdGlmaWNhdGlvbi5MaXN0Tm90aWZpY2F0aW9uQ2hhbm5lbHNSZXNwb25zZRJ8ChlVcGRhdGVOb3RpZmljYXRpb25DaGFubmVsEjUuYmxpcHB5Lm5vdGlmaWNhdGlvbi5VcGRhdGVOb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBooLmJsaXBweS5ub3RpZmljYXRpb24uTm90aWZpY2F0aW9uQ2hhbm5lbBJuChlEZWxldGVOb3RpZmljYXRpb25DaGFubmVsEjUuYmxpcHB5Lm5vdGlmaWNhdGlvbi5EZWxldGVOb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBoaLmJsaXBweS5ub3RpZmljYXRpb24uRW1wdHlCMlowZ2l0aHViLmNvbS9kc3RvdGlqbi9ibGlwcHkvaW50ZXJuYWwvbm90aWZpY2F0aW9uYgZwcm90bzM", [file_google_protobuf_timestamp]);
+  fileDesc("Ch9ub3RpZmljYXRpb24vbm90aWZpY2F0aW9uLnByb3RvEhNibGlwcHkubm90aWZpY2F0aW9uIoMDChNOb3RpZmljYXRpb25DaGFubmVsEgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSDAoEdHlwZRgDIAEoCRIOCgZjb25maWcYBCABKAkSEwoLZGVzY3JpcHRpb24YBSABKAkSEwoLanNvbl9zY2hlbWEYBiABKAkSLgoKY3JlYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASGQoRcXVpZXRfaG91cnNfc3RhcnQYCSABKAkSFwoPcXVpZXRfaG91cnNfZW5kGAogASgJEhwKFHF1aWV0X2hvdXJzX3RpbWV6b25lGAsgASgJEhgKEHF1aWV0X2hvdXJzX21vZGUYDCABKAkSFAoMbWF4X3Blcl9ob3VyGA0gASgFEhcKD2RpZ2VzdF9zY2hlZHVsZRgOIAEoCRIPCgd2ZXJzaW9uGA8gASgDIpMCCiBDcmVhdGVOb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBIMCgRuYW1lGAEgASgJEgwKBHR5cGUYAiABKAkSDgoGY29uZmlnGAMgASgJEhMKC2Rlc2NyaXB0aW9uGAQgASgJEhMKC2pzb25fc2NoZW1hGAUgASgJEhkKEXF1aWV0X2hvdXJzX3N0YXJ0GAYgASgJEhcKD3F1aWV0X2hvdXJzX2VuZBgHIAEoCRIcChRxdWlldF9ob3Vyc190aW1lem9uZRgIIAEoCRIYChBxdWlldF9ob3Vyc19tb2RlGAkgASgJEhQKDG1heF9wZXJfaG91chgKIAEoBRIXCg9kaWdlc3Rfc2NoZWR1bGUYCyABKAkiKwodR2V0Tm90aWZpY2F0aW9uQ2hhbm5lbFJlcXVlc3QSCgoCaWQYASABKAkiagofTGlzdE5vdGlmaWNhdGlvbkNoYW5uZWxzUmVxdWVzdBIRCglwYWdlX3NpemUYASABKAUSEgoKcGFnZV90b2tlbhgCIAEoCRIQCghvcmRlcl9ieRgDIAEoCRIOCgZmaWx0ZXIYBCABKAkiiwEKIExpc3ROb3RpZmljYXRpb25DaGFubmVsc1Jlc3BvbnNlEjoKCGNoYW5uZWxzGAEgAygLMiguYmxpcHB5Lm5vdGlmaWNhdGlvbi5Ob3RpZmljYXRpb25DaGFubmVsEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCRISCgp0b3RhbF9zaXplGAMgASgFIrACCiBVcGRhdGVOb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEgwKBHR5cGUYAyABKAkSDgoGY29uZmlnGAQgASgJEhMKC2Rlc2NyaXB0aW9uGAUgASgJEhMKC2pzb25fc2NoZW1hGAYgASgJEhkKEXF1aWV0X2hvdXJzX3N0YXJ0GAcgASgJEhcKD3F1aWV0X2hvdXJzX2VuZBgIIAEoCRIcChRxdWlldF9ob3Vyc190aW1lem9uZRgJIAEoCRIYChBxdWlldF9ob3Vyc19tb2RlGAogASgJEhQKDG1heF9wZXJfaG91chgLIAEoBRIXCg9kaWdlc3Rfc2NoZWR1bGUYDCABKAkSDwoHdmVyc2lvbhgNIAEoAyIuCiBEZWxldGVOb3RpZmljYXRpb25DaGFubmVsUmVxdWVzdBIKCgJpZBgBIAEoCSIZChdHZXRXZWJQdXNoQ29uZmlnUmVxdWVzdCIjCg1XZWJQdXNoQ29uZmlnEhIKCnB1YmxpY19rZXkYASABKAkiUgogQ3JlYXRlV2ViUHVzaFN1YnNjcmlwdGlvblJlcXVlc3QSEAoIZW5kcG9pbnQYASABKAkSDgoGcDI1NmRoGAIgASgJEgwKBGF1dGgYAyABKAkiNAogRGVsZXRlV2ViUHVzaFN1YnNjcmlwdGlvblJlcXVlc3QSEAoIZW5kcG9pbnQYASABKAkiBwoFRW1wdHky0AcKGk5vdGlmaWNhdGlvbkNoYW5uZWxTZXJ2aWNlEnwKGUNyZWF0ZU5vdGlmaWNhdGlvbkNoYW5uZWwSNS5ibGlwcHkubm90aWZpY2F0aW9uLkNyZWF0ZU5vdGlmaWNhdGlvbkNoYW5uZWxSZXF1ZXN0GiguYmxpcHB5Lm5vdGlmaWNhdGlvbi5Ob3RpZmljYXRpb25DaGFubmVsEnYKFkdldE5vdGlmaWNhdGlvbkNoYW5uZWwSMi5ibGlwcHkubm90aWZpY2F0aW9uLkdldE5vdGlmaWNhdGlvbkNoYW5uZWxSZXF1ZXN0GiguYmxpcHB5Lm5vdGlmaWNhdGlvbi5Ob3RpZmljYXRpb25DaGFubmVsEocBChhMaXN0Tm90aWZpY2F0aW9uQ2hhbm5lbHMSNC5ibGlwcHkubm90aWZpY2F0aW9uLkxpc3ROb3RpZmljYXRpb25DaGFubmVsc1JlcXVlc3QaNS5ibGlwcHkubm90aWZpY2F0aW9uLkxpc3ROb3RpZmljYXRpb25DaGFubmVsc1Jlc3BvbnNlEnwKGVVwZGF0ZU5vdGlmaWNhdGlvbkNoYW5uZWwSNS5ibGlwcHkubm90aWZpY2F0aW9uLlVwZGF0ZU5vdGlmaWNhdGlvbkNoYW5uZWxSZXF1ZXN0GiguYmxpcHB5Lm5vdGlmaWNhdGlvbi5Ob3RpZmljYXRpb25DaGFubmVsEm4KGURlbGV0ZU5vdGlmaWNhdGlvbkNoYW5uZWwSNS5ibGlwcHkubm90aWZpY2F0aW9uLkRlbGV0ZU5vdGlmaWNhdGlvbkNoYW5uZWxSZXF1ZXN0GhouYmxpcHB5Lm5vdGlmaWNhdGlvbi5FbXB0eRJkChBHZXRXZWJQdXNoQ29uZmlnEiwuYmxpcHB5Lm5vdGlmaWNhdGlvbi5HZXRXZWJQdXNoQ29uZmlnUmVxdWVzdBoiLmJsaXBweS5ub3RpZmljYXRpb24uV2ViUHVzaENvbmZpZxJuChlDcmVhdGVXZWJQdXNoU3Vic2NyaXB0aW9uEjUuYmxpcHB5Lm5vdGlmaWNhdGlvbi5DcmVhdGVXZWJQdXNoU3Vic2NyaXB0aW9uUmVxdWVzdBoaLmJsaXBweS5ub3RpZmljYXRpb24uRW1wdHkSbgoZRGVsZXRlV2ViUHVzaFN1YnNjcmlwdGlvbhI1LmJsaXBweS5ub3RpZmljYXRpb24uRGVsZXRlV2ViUHVzaFN1YnNjcmlwdGlvblJlcXVlc3QaGi5ibGlwcHkubm90aWZpY2F0aW9uLkVtcHR5QjJaMGdpdGh1Yi5jb20vZHN0b3Rpam4vYmxpcHB5L2ludGVybmFsL25vdGlmaWNhdGlvbmIGcHJvdG8z", [file_google_protobuf_timestamp]);
 
 /**
  * @generated from message blippy.notification.NotificationChannel
@@ -65,6 +65,55 @@ export type NotificationChannel = Message<"blippy.notification.NotificationChann
    * @generated from field: google.protobuf.Timestamp updated_at = 8;
    */
   updatedAt?: Timestamp;
+
+  /**
+   * "HH:MM", empty disables quiet hours
+   *
+   * @generated from field: string quiet_hours_start = 9;
+   */
+  quietHoursStart: string;
+
+  /**
+   * "HH:MM", may be earlier than start to wrap midnight
+   *
+   * @generated from field: string quiet_hours_end = 10;
+   */
+  quietHoursEnd: string;
+
+  /**
+   * IANA time zone, defaults to UTC
+   *
+   * @generated from field: string quiet_hours_timezone = 11;
+   */
+  quietHoursTimezone: string;
+
+  /**
+   * "defer" or "drop"
+   *
+   * @generated from field: string quiet_hours_mode = 12;
+   */
+  quietHoursMode: string;
+
+  /**
+   * 0 means unlimited
+   *
+   * @generated from field: int32 max_per_hour = 13;
+   */
+  maxPerHour: number;
+
+  /**
+   * Cron expression; when set, notifications are batched and sent as one digest per run
+   *
+   * @generated from field: string digest_schedule = 14;
+   */
+  digestSchedule: string;
+
+  /**
+   * Incremented on every update
+   *
+   * @generated from field: int64 version = 15;
+   */
+  version: bigint;
 };
 
 /**
@@ -102,6 +151,36 @@ export type CreateNotificationChannelRequest = Message<"blippy.notification.Crea
    * @generated from field: string json_schema = 5;
    */
   jsonSchema: string;
+
+  /**
+   * @generated from field: string quiet_hours_start = 6;
+   */
+  quietHoursStart: string;
+
+  /**
+   * @generated from field: string quiet_hours_end = 7;
+   */
+  quietHoursEnd: string;
+
+  /**
+   * @generated from field: string quiet_hours_timezone = 8;
+   */
+  quietHoursTimezone: string;
+
+  /**
+   * @generated from field: string quiet_hours_mode = 9;
+   */
+  quietHoursMode: string;
+
+  /**
+   * @generated from field: int32 max_per_hour = 10;
+   */
+  maxPerHour: number;
+
+  /**
+   * @generated from field: string digest_schedule = 11;
+   */
+  digestSchedule: string;
 };
 
 /**
@@ -132,6 +211,33 @@ export const GetNotificationChannelRequestSchema: GenMessage<GetNotificationChan
  * @generated from message blippy.notification.ListNotificationChannelsRequest
  */
 export type ListNotificationChannelsRequest = Message<"blippy.notification.ListNotificationChannelsRequest"> & {
+  /**
+   * Maximum number of results to return. 0 returns all results.
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * Token from a previous response's next_page_token.
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+
+  /**
+   * Comma-separated fields, each optionally followed by "asc" or "desc".
+   *
+   * @generated from field: string order_by = 3;
+   */
+  orderBy: string;
+
+  /**
+   * Terms joined by "AND", e.g. `name:deploy AND created_at>=2025-01-01`.
+   *
+   * @generated from field: string filter = 4;
+   */
+  filter: string;
 };
 
 /**
@@ -149,6 +255,20 @@ export type ListNotificationChannelsResponse = Message<"blippy.notification.List
    * @generated from field: repeated blippy.notification.NotificationChannel channels = 1;
    */
   channels: NotificationChannel[];
+
+  /**
+   * Token for the next page; empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Number of results matching the filter.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
 };
 
 /**
@@ -191,6 +311,43 @@ export type UpdateNotificationChannelRequest = Message<"blippy.notification.Upda
    * @generated from field: string json_schema = 6;
    */
   jsonSchema: string;
+
+  /**
+   * @generated from field: string quiet_hours_start = 7;
+   */
+  quietHoursStart: string;
+
+  /**
+   * @generated from field: string quiet_hours_end = 8;
+   */
+  quietHoursEnd: string;
+
+  /**
+   * @generated from field: string quiet_hours_timezone = 9;
+   */
+  quietHoursTimezone: string;
+
+  /**
+   * @generated from field: string quiet_hours_mode = 10;
+   */
+  quietHoursMode: string;
+
+  /**
+   * @generated from field: int32 max_per_hour = 11;
+   */
+  maxPerHour: number;
+
+  /**
+   * @generated from field: string digest_schedule = 12;
+   */
+  digestSchedule: string;
+
+  /**
+   * Version the update is based on; fails with ABORTED if stale
+   *
+   * @generated from field: int64 version = 13;
+   */
+  version: bigint;
 };
 
 /**
@@ -217,6 +374,86 @@ export type DeleteNotificationChannelRequest = Message<"blippy.notification.Dele
 export const DeleteNotificationChannelRequestSchema: GenMessage<DeleteNotificationChannelRequest> = /*@__PURE__*/
   messageDesc(file_notification_notification, 6);
 
+/**
+ * @generated from message blippy.notification.GetWebPushConfigRequest
+ */
+export type GetWebPushConfigRequest = Message<"blippy.notification.GetWebPushConfigRequest"> & {
+};
+
+/**
+ * Describes the message blippy.notification.GetWebPushConfigRequest.
+ * Use `create(GetWebPushConfigRequestSchema)` to create a new message.
+ */
+export const GetWebPushConfigRequestSchema: GenMessage<GetWebPushConfigRequest> = /*@__PURE__*/
+  messageDesc(file_notification_notification, 7);
+
+/**
+ * @generated from message blippy.notification.WebPushConfig
+ */
+export type WebPushConfig = Message<"blippy.notification.WebPushConfig"> & {
+  /**
+   * VAPID public key (base64url), used as applicationServerKey
+   *
+   * @generated from field: string public_key = 1;
+   */
+  publicKey: string;
+};
+
+/**
+ * Describes the message blippy.notification.WebPushConfig.
+ * Use `create(WebPushConfigSchema)` to create a new message.
+ */
+export const WebPushConfigSchema: GenMessage<WebPushConfig> = /*@__PURE__*/
+  messageDesc(file_notification_notification, 8);
+
+/**
+ * @generated from message blippy.notification.CreateWebPushSubscriptionRequest
+ */
+export type CreateWebPushSubscriptionRequest = Message<"blippy.notification.CreateWebPushSubscriptionRequest"> & {
+  /**
+   * @generated from field: string endpoint = 1;
+   */
+  endpoint: string;
+
+  /**
+   * Client public key (base64url)
+   *
+   * @generated from field: string p256dh = 2;
+   */
+  p256dh: string;
+
+  /**
+   * Client auth secret (base64url)
+   *
+   * @generated from field: string auth = 3;
+   */
+  auth: string;
+};
+
+/**
+ * Describes the message blippy.notification.CreateWebPushSubscriptionRequest.
+ * Use `create(CreateWebPushSubscriptionRequestSchema)` to create a new message.
+ */
+export const CreateWebPushSubscriptionRequestSchema: GenMessage<CreateWebPushSubscriptionRequest> = /*@__PURE__*/
+  messageDesc(file_notification_notification, 9);
+
+/**
+ * @generated from message blippy.notification.DeleteWebPushSubscriptionRequest
+ */
+export type DeleteWebPushSubscriptionRequest = Message<"blippy.notification.DeleteWebPushSubscriptionRequest"> & {
+  /**
+   * @generated from field: string endpoint = 1;
+   */
+  endpoint: string;
+};
+
+/**
+ * Describes the message blippy.notification.DeleteWebPushSubscriptionRequest.
+ * Use `create(DeleteWebPushSubscriptionRequestSchema)` to create a new message.
+ */
+export const DeleteWebPushSubscriptionRequestSchema: GenMessage<DeleteWebPushSubscriptionRequest> = /*@__PURE__*/
+  messageDesc(file_notification_notification, 10);
+
 /**
  * @generated from message blippy.notification.Empty
  */
@@ -228,7 +465,7 @@ export type Empty = Message<"blippy.notification.Empty"> & {
  * Use `create(EmptySchema)` to create a new message.
  */
 export const EmptySchema: GenMessage<Empty> = /*@__PURE__*/
-  messageDesc(file_notification_notification, 7);
+  messageDesc(file_notification_notification, 11);
 
 /**
  * NotificationChannelService manages notification channels for triggers.
@@ -276,6 +513,30 @@ export const NotificationChannelService: GenService<{
     input: typeof DeleteNotificationChannelRequestSchema;
     output: typeof EmptySchema;
   },
+  /**
+   * @generated from rpc blippy.notification.NotificationChannelService.GetWebPushConfig
+   */
+  getWebPushConfig: {
+    methodKind: "unary";
+    input: typeof GetWebPushConfigRequestSchema;
+    output: typeof WebPushConfigSchema;
+  },
+  /**
+   * @generated from rpc blippy.notification.NotificationChannelService.CreateWebPushSubscription
+   */
+  createWebPushSubscription: {
+    methodKind: "unary";
+    input: typeof CreateWebPushSubscriptionRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc blippy.notification.NotificationChannelService.DeleteWebPushSubscription
+   */
+  deleteWebPushSubscription: {
+    methodKind: "unary";
+    input: typeof DeleteWebPushSubscriptionRequestSchema;
+    output: typeof EmptySchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_notification_notification, 0);
 
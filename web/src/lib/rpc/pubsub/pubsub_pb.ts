@@ -0,0 +1,723 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file pubsub/pubsub.proto (package blippy.pubsub, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { ErrorCode } from "../apierror/apierror_pb";
+import { file_apierror_apierror } from "../apierror/apierror_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pubsub/pubsub.proto.
+ */
+export const file_pubsub_pubsub: GenFile = /*@__PURE__*/
+  fileDesc("ChNwdWJzdWIvcHVic3ViLnByb3RvEg1ibGlwcHkucHVic3ViIsYGCgVFdmVudBINCgV0b3BpYxgBIAEoCRIQCghzZXF1ZW5jZRgCIAEoAxIuCgp0ZXh0X2RlbHRhGAMgASgLMhguYmxpcHB5LnB1YnN1Yi5UZXh0RGVsdGFIABIwCgt0b29sX3Jlc3VsdBgEIAEoCzIZLmJsaXBweS5wdWJzdWIuVG9vbFJlc3VsdEgAEjIKDG1lc3NhZ2VfZG9uZRgFIAEoCzIaLmJsaXBweS5wdWJzdWIuTWVzc2FnZURvbmVIABIyCgx0dXJuX3N0YXJ0ZWQYBiABKAsyGi5ibGlwcHkucHVic3ViLlR1cm5TdGFydGVkSAASLAoJdHVybl9kb25lGAcgASgLMhcuYmxpcHB5LnB1YnN1Yi5UdXJuRG9uZUgAEiUKBWVycm9yGAggASgLMhQuYmxpcHB5LnB1YnN1Yi5FcnJvckgAEjAKC3J1bl9zdGFydGVkGAkgASgLMhkuYmxpcHB5LnB1YnN1Yi5SdW5TdGFydGVkSAASMgoMcnVuX2ZpbmlzaGVkGAogASgLMhouYmxpcHB5LnB1YnN1Yi5SdW5GaW5pc2hlZEgAEjQKDXRyaWdnZXJfZmlyZWQYCyABKAsyGy5ibGlwcHkucHVic3ViLlRyaWdnZXJGaXJlZEgAEiEKA2dhcBgMIAEoCzISLmJsaXBweS5wdWJzdWIuR2FwSAASNAoNdHVybl9wcm9ncmVzcxgNIAEoCzIbLmJsaXBweS5wdWJzdWIuVHVyblByb2dyZXNzSAASOAoPc3ViYWdlbnRfdXBkYXRlGA4gASgLMh0uYmxpcHB5LnB1YnN1Yi5TdWJhZ2VudFVwZGF0ZUgAEj4KEmFwcHJvdmFsX3JlcXVlc3RlZBgPIAEoCzIgLmJsaXBweS5wdWJzdWIuQXBwcm92YWxSZXF1ZXN0ZWRIABI8ChFhcHByb3ZhbF9yZXNvbHZlZBgQIAEoCzIfLmJsaXBweS5wdWJzdWIuQXBwcm92YWxSZXNvbHZlZEgAEkUKFnRvb2xfZXhlY3V0aW9uX3N0YXJ0ZWQYESABKAsyIy5ibGlwcHkucHVic3ViLlRvb2xFeGVjdXRpb25TdGFydGVkSABCCQoHcGF5bG9hZCK9AQoRQXBwcm92YWxSZXF1ZXN0ZWQSEwoLYXBwcm92YWxfaWQYASABKAkSDgoGcnVuX2lkGAIgASgJEhcKD2NvbnZlcnNhdGlvbl9pZBgDIAEoCRIQCghhZ2VudF9pZBgEIAEoCRISCgphZ2VudF9uYW1lGAUgASgJEhEKCXRvb2xfbmFtZRgGIAEoCRINCgVpbnB1dBgHIAEoCRIOCgZyZWFzb24YCCABKAkSEgoKZXhwaXJlc19hdBgJIAEoCSJJChBBcHByb3ZhbFJlc29sdmVkEhMKC2FwcHJvdmFsX2lkGAEgASgJEhAKCGFwcHJvdmVkGAIgASgIEg4KBnJlYXNvbhgDIAEoCSLZAQoOU3ViYWdlbnRVcGRhdGUSDgoGcnVuX2lkGAEgASgJEhcKD2NvbnZlcnNhdGlvbl9pZBgCIAEoCRIQCghhZ2VudF9pZBgDIAEoCRISCgphZ2VudF9uYW1lGAQgASgJEi4KCnRleHRfZGVsdGEYBSABKAsyGC5ibGlwcHkucHVic3ViLlRleHREZWx0YUgAEjAKC3Rvb2xfcmVzdWx0GAYgASgLMhkuYmxpcHB5LnB1YnN1Yi5Ub29sUmVzdWx0SAASDAoEZG9uZRgHIAEoCEIICgZ1cGRhdGUiHAoJVGV4dERlbHRhEg8KB2NvbnRlbnQYASABKAkiegoKVG9vbFJlc3VsdBIMCgRuYW1lGAEgASgJEg0KBWlucHV0GAIgASgJEg4KBnJlc3VsdBgDIAEoCRIuCgplcnJvcl9jb2RlGAQgASgOMhouYmxpcHB5LmFwaWVycm9yLkVycm9yQ29kZRIPCgdjYWxsX2lkGAUgASgJIk4KFFRvb2xFeGVjdXRpb25TdGFydGVkEg8KB2NhbGxfaWQYASABKAkSDAoEbmFtZRgCIAEoCRIXCg9hcmd1bWVudHNfZGVsdGEYAyABKAkiZwoLTWVzc2FnZURvbmUSEgoKbWVzc2FnZV9pZBgBIAEoCRIMCgRyb2xlGAIgASgJEhIKCml0ZW1zX2pzb24YAyABKAkSDgoGc3RhdHVzGAQgASgJEhIKCmNyZWF0ZWRfYXQYBSABKAkiDQoLVHVyblN0YXJ0ZWQiGQoIVHVybkRvbmUSDQoFdGl0bGUYASABKAkiQgoFRXJyb3ISDwoHbWVzc2FnZRgBIAEoCRIoCgRjb2RlGAIgASgOMhouYmxpcHB5LmFwaWVycm9yLkVycm9yQ29kZSJeCgxUdXJuUHJvZ3Jlc3MSEgoKZWxhcHNlZF9tcxgBIAEoAxINCgV0b29scxgCIAMoCRIUCgxpbnB1dF90b2tlbnMYAyABKAMSFQoNb3V0cHV0X3Rva2VucxgEIAEoAyJ4CgpSdW5TdGFydGVkEhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCRIQCghhZ2VudF9pZBgCIAEoCRISCgphZ2VudF9uYW1lGAMgASgJEg0KBWRlcHRoGAQgASgFEg4KBnJ1bl9pZBgFIAEoCRIMCgRraW5kGAYgASgJIqsBCgtSdW5GaW5pc2hlZBIXCg9jb252ZXJzYXRpb25faWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSEgoKYWdlbnRfbmFtZRgDIAEoCRIOCgZzdGF0dXMYBCABKAkSDQoFZXJyb3IYBSABKAkSDgoGcnVuX2lkGAYgASgJEi4KCmVycm9yX2NvZGUYByABKA4yGi5ibGlwcHkuYXBpZXJyb3IuRXJyb3JDb2RlImMKDFRyaWdnZXJGaXJlZBISCgp0cmlnZ2VyX2lkGAEgASgJEhQKDHRyaWdnZXJfbmFtZRgCIAEoCRIQCghhZ2VudF9pZBgDIAEoCRIXCg9jb252ZXJzYXRpb25faWQYBCABKAkiLgoDR2FwEg4KBm1pc3NlZBgBIAEoBRIXCg9yZXN1bWVfc2VxdWVuY2UYAiABKANCLFoqZ2l0aHViLmNvbS9kc3RvdGlqbi9ibGlwcHkvaW50ZXJuYWwvcHVic3ViYgZwcm90bzM", [file_apierror_apierror]);
+
+/**
+ * Event is an event published to a topic. Its payload is one of the known
+ * event types, so clients in any language can decode the stream. Field names
+ * are stable: new event types get new fields.
+ *
+ * @generated from message blippy.pubsub.Event
+ */
+export type Event = Message<"blippy.pubsub.Event"> & {
+  /**
+   * "conversation:<id>" for conversation events, "agent:<id>" for activity.
+   *
+   * @generated from field: string topic = 1;
+   */
+  topic: string;
+
+  /**
+   * Increases monotonically per conversation for conversation events, and
+   * per server for activity. 0 for events that couldn't be sequenced,
+   * transient events such as turn_progress, and gaps.
+   *
+   * @generated from field: int64 sequence = 2;
+   */
+  sequence: bigint;
+
+  /**
+   * @generated from oneof blippy.pubsub.Event.payload
+   */
+  payload: {
+    /**
+     * Conversation events.
+     *
+     * @generated from field: blippy.pubsub.TextDelta text_delta = 3;
+     */
+    value: TextDelta;
+    case: "textDelta";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.ToolResult tool_result = 4;
+     */
+    value: ToolResult;
+    case: "toolResult";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.MessageDone message_done = 5;
+     */
+    value: MessageDone;
+    case: "messageDone";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.TurnStarted turn_started = 6;
+     */
+    value: TurnStarted;
+    case: "turnStarted";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.TurnDone turn_done = 7;
+     */
+    value: TurnDone;
+    case: "turnDone";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.Error error = 8;
+     */
+    value: Error;
+    case: "error";
+  } | {
+    /**
+     * Activity.
+     *
+     * @generated from field: blippy.pubsub.RunStarted run_started = 9;
+     */
+    value: RunStarted;
+    case: "runStarted";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.RunFinished run_finished = 10;
+     */
+    value: RunFinished;
+    case: "runFinished";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.TriggerFired trigger_fired = 11;
+     */
+    value: TriggerFired;
+    case: "triggerFired";
+  } | {
+    /**
+     * Sent to subscribers in place of events they missed.
+     *
+     * @generated from field: blippy.pubsub.Gap gap = 12;
+     */
+    value: Gap;
+    case: "gap";
+  } | {
+    /**
+     * Conversation events that aren't logged.
+     *
+     * @generated from field: blippy.pubsub.TurnProgress turn_progress = 13;
+     */
+    value: TurnProgress;
+    case: "turnProgress";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.SubagentUpdate subagent_update = 14;
+     */
+    value: SubagentUpdate;
+    case: "subagentUpdate";
+  } | {
+    /**
+     * Conversation events of tool calls matching an approval rule.
+     *
+     * @generated from field: blippy.pubsub.ApprovalRequested approval_requested = 15;
+     */
+    value: ApprovalRequested;
+    case: "approvalRequested";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.ApprovalResolved approval_resolved = 16;
+     */
+    value: ApprovalResolved;
+    case: "approvalResolved";
+  } | {
+    /**
+     * Conversation event of a tool call the LLM is writing or that is
+     * running.
+     *
+     * @generated from field: blippy.pubsub.ToolExecutionStarted tool_execution_started = 17;
+     */
+    value: ToolExecutionStarted;
+    case: "toolExecutionStarted";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message blippy.pubsub.Event.
+ * Use `create(EventSchema)` to create a new message.
+ */
+export const EventSchema: GenMessage<Event> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 0);
+
+/**
+ * ApprovalRequested is published when a tool call matches an approval rule
+ * of its agent. The call waits until it's resolved with ResolveApproval or
+ * expires, and is denied then.
+ *
+ * @generated from message blippy.pubsub.ApprovalRequested
+ */
+export type ApprovalRequested = Message<"blippy.pubsub.ApprovalRequested"> & {
+  /**
+   * @generated from field: string approval_id = 1;
+   */
+  approvalId: string;
+
+  /**
+   * @generated from field: string run_id = 2;
+   */
+  runId: string;
+
+  /**
+   * The conversation of the run, which differs from the event's for calls
+   * of subagents.
+   *
+   * @generated from field: string conversation_id = 3;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 4;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 5;
+   */
+  agentName: string;
+
+  /**
+   * @generated from field: string tool_name = 6;
+   */
+  toolName: string;
+
+  /**
+   * JSON arguments of the call.
+   *
+   * @generated from field: string input = 7;
+   */
+  input: string;
+
+  /**
+   * The rule the call matched, e.g. `bash command matches "rm|curl"`.
+   *
+   * @generated from field: string reason = 8;
+   */
+  reason: string;
+
+  /**
+   * RFC 3339.
+   *
+   * @generated from field: string expires_at = 9;
+   */
+  expiresAt: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.ApprovalRequested.
+ * Use `create(ApprovalRequestedSchema)` to create a new message.
+ */
+export const ApprovalRequestedSchema: GenMessage<ApprovalRequested> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 1);
+
+/**
+ * ApprovalResolved is published when a tool call waiting for approval is
+ * approved, denied or expires.
+ *
+ * @generated from message blippy.pubsub.ApprovalResolved
+ */
+export type ApprovalResolved = Message<"blippy.pubsub.ApprovalResolved"> & {
+  /**
+   * @generated from field: string approval_id = 1;
+   */
+  approvalId: string;
+
+  /**
+   * @generated from field: bool approved = 2;
+   */
+  approved: boolean;
+
+  /**
+   * Why the call was denied, if given.
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.ApprovalResolved.
+ * Use `create(ApprovalResolvedSchema)` to create a new message.
+ */
+export const ApprovalResolvedSchema: GenMessage<ApprovalResolved> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 2);
+
+/**
+ * SubagentUpdate is live output of a subagent turn, forwarded to the
+ * conversation whose turn called the agent.
+ *
+ * @generated from message blippy.pubsub.SubagentUpdate
+ */
+export type SubagentUpdate = Message<"blippy.pubsub.SubagentUpdate"> & {
+  /**
+   * @generated from field: string run_id = 1;
+   */
+  runId: string;
+
+  /**
+   * The subagent's conversation.
+   *
+   * @generated from field: string conversation_id = 2;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 3;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 4;
+   */
+  agentName: string;
+
+  /**
+   * @generated from oneof blippy.pubsub.SubagentUpdate.update
+   */
+  update: {
+    /**
+     * @generated from field: blippy.pubsub.TextDelta text_delta = 5;
+     */
+    value: TextDelta;
+    case: "textDelta";
+  } | {
+    /**
+     * @generated from field: blippy.pubsub.ToolResult tool_result = 6;
+     */
+    value: ToolResult;
+    case: "toolResult";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Set on the last update of a run, which has no output.
+   *
+   * @generated from field: bool done = 7;
+   */
+  done: boolean;
+};
+
+/**
+ * Describes the message blippy.pubsub.SubagentUpdate.
+ * Use `create(SubagentUpdateSchema)` to create a new message.
+ */
+export const SubagentUpdateSchema: GenMessage<SubagentUpdate> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 3);
+
+/**
+ * TextDelta is a chunk of streamed text from the LLM.
+ *
+ * @generated from message blippy.pubsub.TextDelta
+ */
+export type TextDelta = Message<"blippy.pubsub.TextDelta"> & {
+  /**
+   * @generated from field: string content = 1;
+   */
+  content: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.TextDelta.
+ * Use `create(TextDeltaSchema)` to create a new message.
+ */
+export const TextDeltaSchema: GenMessage<TextDelta> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 4);
+
+/**
+ * ToolResult is the outcome of a single tool execution.
+ *
+ * @generated from message blippy.pubsub.ToolResult
+ */
+export type ToolResult = Message<"blippy.pubsub.ToolResult"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string input = 2;
+   */
+  input: string;
+
+  /**
+   * @generated from field: string result = 3;
+   */
+  result: string;
+
+  /**
+   * Set if the tool call failed; the result is then the error message.
+   *
+   * @generated from field: blippy.apierror.ErrorCode error_code = 4;
+   */
+  errorCode: ErrorCode;
+
+  /**
+   * @generated from field: string call_id = 5;
+   */
+  callId: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.ToolResult.
+ * Use `create(ToolResultSchema)` to create a new message.
+ */
+export const ToolResultSchema: GenMessage<ToolResult> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 5);
+
+/**
+ * ToolExecutionStarted is published when the LLM starts a tool call, and
+ * for each part of the call's arguments as the LLM streams them. The call
+ * runs once the LLM's response is complete, until a ToolResult with the same
+ * call_id.
+ *
+ * @generated from message blippy.pubsub.ToolExecutionStarted
+ */
+export type ToolExecutionStarted = Message<"blippy.pubsub.ToolExecutionStarted"> & {
+  /**
+   * @generated from field: string call_id = 1;
+   */
+  callId: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * The next part of the JSON arguments; empty when the call starts.
+   *
+   * @generated from field: string arguments_delta = 3;
+   */
+  argumentsDelta: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.ToolExecutionStarted.
+ * Use `create(ToolExecutionStartedSchema)` to create a new message.
+ */
+export const ToolExecutionStartedSchema: GenMessage<ToolExecutionStarted> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 6);
+
+/**
+ * MessageDone signals that a message has been persisted.
+ *
+ * @generated from message blippy.pubsub.MessageDone
+ */
+export type MessageDone = Message<"blippy.pubsub.MessageDone"> & {
+  /**
+   * @generated from field: string message_id = 1;
+   */
+  messageId: string;
+
+  /**
+   * @generated from field: string role = 2;
+   */
+  role: string;
+
+  /**
+   * JSON array of the message's items.
+   *
+   * @generated from field: string items_json = 3;
+   */
+  itemsJson: string;
+
+  /**
+   * @generated from field: string status = 4;
+   */
+  status: string;
+
+  /**
+   * RFC 3339.
+   *
+   * @generated from field: string created_at = 5;
+   */
+  createdAt: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.MessageDone.
+ * Use `create(MessageDoneSchema)` to create a new message.
+ */
+export const MessageDoneSchema: GenMessage<MessageDone> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 7);
+
+/**
+ * TurnStarted signals that a new agent turn has begun.
+ *
+ * @generated from message blippy.pubsub.TurnStarted
+ */
+export type TurnStarted = Message<"blippy.pubsub.TurnStarted"> & {
+};
+
+/**
+ * Describes the message blippy.pubsub.TurnStarted.
+ * Use `create(TurnStartedSchema)` to create a new message.
+ */
+export const TurnStartedSchema: GenMessage<TurnStarted> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 8);
+
+/**
+ * TurnDone signals that the agent turn has completed.
+ *
+ * @generated from message blippy.pubsub.TurnDone
+ */
+export type TurnDone = Message<"blippy.pubsub.TurnDone"> & {
+  /**
+   * @generated from field: string title = 1;
+   */
+  title: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.TurnDone.
+ * Use `create(TurnDoneSchema)` to create a new message.
+ */
+export const TurnDoneSchema: GenMessage<TurnDone> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 9);
+
+/**
+ * Error signals that an error occurred during processing.
+ *
+ * @generated from message blippy.pubsub.Error
+ */
+export type Error = Message<"blippy.pubsub.Error"> & {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message: string;
+
+  /**
+   * @generated from field: blippy.apierror.ErrorCode code = 2;
+   */
+  code: ErrorCode;
+};
+
+/**
+ * Describes the message blippy.pubsub.Error.
+ * Use `create(ErrorSchema)` to create a new message.
+ */
+export const ErrorSchema: GenMessage<Error> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 10);
+
+/**
+ * TurnProgress is published periodically while a turn is active, so clients
+ * can show what the agent is doing and notice stuck turns.
+ *
+ * @generated from message blippy.pubsub.TurnProgress
+ */
+export type TurnProgress = Message<"blippy.pubsub.TurnProgress"> & {
+  /**
+   * Milliseconds since the turn started.
+   *
+   * @generated from field: int64 elapsed_ms = 1;
+   */
+  elapsedMs: bigint;
+
+  /**
+   * Names of the tools being executed, if any.
+   *
+   * @generated from field: repeated string tools = 2;
+   */
+  tools: string[];
+
+  /**
+   * Tokens used in the turn so far, as reported by the LLM provider.
+   *
+   * @generated from field: int64 input_tokens = 3;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 4;
+   */
+  outputTokens: bigint;
+};
+
+/**
+ * Describes the message blippy.pubsub.TurnProgress.
+ * Use `create(TurnProgressSchema)` to create a new message.
+ */
+export const TurnProgressSchema: GenMessage<TurnProgress> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 11);
+
+/**
+ * RunStarted is published when an agent starts a turn.
+ *
+ * @generated from message blippy.pubsub.RunStarted
+ */
+export type RunStarted = Message<"blippy.pubsub.RunStarted"> & {
+  /**
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 3;
+   */
+  agentName: string;
+
+  /**
+   * Greater than 0 for turns of agents called by other agents.
+   *
+   * @generated from field: int32 depth = 4;
+   */
+  depth: number;
+
+  /**
+   * Identifies the run for SystemService.CancelRun.
+   *
+   * @generated from field: string run_id = 5;
+   */
+  runId: string;
+
+  /**
+   * What started the run: "interactive", "trigger", "webhook", "subagent",
+   * "eval", "replay" or "autonomous".
+   *
+   * @generated from field: string kind = 6;
+   */
+  kind: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.RunStarted.
+ * Use `create(RunStartedSchema)` to create a new message.
+ */
+export const RunStartedSchema: GenMessage<RunStarted> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 12);
+
+/**
+ * RunFinished is published when an agent's turn ends.
+ *
+ * @generated from message blippy.pubsub.RunFinished
+ */
+export type RunFinished = Message<"blippy.pubsub.RunFinished"> & {
+  /**
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 3;
+   */
+  agentName: string;
+
+  /**
+   * "completed", "failed", "interrupted", "cancelled" or "timed_out".
+   *
+   * @generated from field: string status = 4;
+   */
+  status: string;
+
+  /**
+   * @generated from field: string error = 5;
+   */
+  error: string;
+
+  /**
+   * @generated from field: string run_id = 6;
+   */
+  runId: string;
+
+  /**
+   * Set if the run failed or was stopped.
+   *
+   * @generated from field: blippy.apierror.ErrorCode error_code = 7;
+   */
+  errorCode: ErrorCode;
+};
+
+/**
+ * Describes the message blippy.pubsub.RunFinished.
+ * Use `create(RunFinishedSchema)` to create a new message.
+ */
+export const RunFinishedSchema: GenMessage<RunFinished> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 13);
+
+/**
+ * TriggerFired is published when a trigger starts an agent run.
+ *
+ * @generated from message blippy.pubsub.TriggerFired
+ */
+export type TriggerFired = Message<"blippy.pubsub.TriggerFired"> & {
+  /**
+   * @generated from field: string trigger_id = 1;
+   */
+  triggerId: string;
+
+  /**
+   * @generated from field: string trigger_name = 2;
+   */
+  triggerName: string;
+
+  /**
+   * @generated from field: string agent_id = 3;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string conversation_id = 4;
+   */
+  conversationId: string;
+};
+
+/**
+ * Describes the message blippy.pubsub.TriggerFired.
+ * Use `create(TriggerFiredSchema)` to create a new message.
+ */
+export const TriggerFiredSchema: GenMessage<TriggerFired> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 14);
+
+/**
+ * Gap is sent in place of events that were dropped because the subscriber
+ * didn't keep up. Subscribers should re-fetch state, e.g. by replaying the
+ * events of the gap's topic after resume_sequence - 1.
+ *
+ * @generated from message blippy.pubsub.Gap
+ */
+export type Gap = Message<"blippy.pubsub.Gap"> & {
+  /**
+   * Number of events that were dropped.
+   *
+   * @generated from field: int32 missed = 1;
+   */
+  missed: number;
+
+  /**
+   * Sequence number of the first missed event.
+   *
+   * @generated from field: int64 resume_sequence = 2;
+   */
+  resumeSequence: bigint;
+};
+
+/**
+ * Describes the message blippy.pubsub.Gap.
+ * Use `create(GapSchema)` to create a new message.
+ */
+export const GapSchema: GenMessage<Gap> = /*@__PURE__*/
+  messageDesc(file_pubsub_pubsub, 15);
+
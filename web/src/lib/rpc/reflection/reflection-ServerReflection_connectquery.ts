@@ -0,0 +1,5 @@
+// @generated by protoc-gen-connect-query v2.2.0 with parameter "target=ts"
+// @generated from file reflection/reflection.proto (package grpc.reflection.v1, syntax proto3)
+/* eslint-disable */
+
+import { ServerReflection } from "./reflection_pb";
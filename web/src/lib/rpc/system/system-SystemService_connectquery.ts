@@ -0,0 +1,169 @@
+// @generated by protoc-gen-connect-query v2.2.0 with parameter "target=ts"
+// @generated from file system/system.proto (package blippy.system, syntax proto3)
+/* eslint-disable */
+
+import { SystemService } from "./system_pb";
+
+/**
+ * @generated from rpc blippy.system.SystemService.GetSystemStats
+ */
+export const getSystemStats = SystemService.method.getSystemStats;
+
+/**
+ * @generated from rpc blippy.system.SystemService.GetMaintenanceMode
+ */
+export const getMaintenanceMode = SystemService.method.getMaintenanceMode;
+
+/**
+ * Admin only. Enabling pauses the scheduler and rejects new chat messages,
+ * webhook runs and notification replies; turns in progress continue.
+ *
+ * @generated from rpc blippy.system.SystemService.UpdateMaintenanceMode
+ */
+export const updateMaintenanceMode = SystemService.method.updateMaintenanceMode;
+
+/**
+ * @generated from rpc blippy.system.SystemService.GetInstancePreamble
+ */
+export const getInstancePreamble = SystemService.method.getInstancePreamble;
+
+/**
+ * Admin only.
+ *
+ * @generated from rpc blippy.system.SystemService.UpdateInstancePreamble
+ */
+export const updateInstancePreamble = SystemService.method.updateInstancePreamble;
+
+/**
+ * Admin only.
+ *
+ * @generated from rpc blippy.system.SystemService.GetBrokerStats
+ */
+export const getBrokerStats = SystemService.method.getBrokerStats;
+
+/**
+ * Runs of other replicas aren't listed.
+ *
+ * @generated from rpc blippy.system.SystemService.ListActiveRuns
+ */
+export const listActiveRuns = SystemService.method.listActiveRuns;
+
+/**
+ * Stops a run, storing its output so far, and the runs of agents it
+ * called. Returns NotFound for runs that aren't active on this replica.
+ *
+ * @generated from rpc blippy.system.SystemService.CancelRun
+ */
+export const cancelRun = SystemService.method.cancelRun;
+
+/**
+ * Tool calls of runs on this replica waiting for approval.
+ *
+ * @generated from rpc blippy.system.SystemService.ListPendingApprovals
+ */
+export const listPendingApprovals = SystemService.method.listPendingApprovals;
+
+/**
+ * Approves or denies a tool call waiting for approval. Returns NotFound
+ * for calls that aren't waiting on this replica.
+ *
+ * @generated from rpc blippy.system.SystemService.ResolveApproval
+ */
+export const resolveApproval = SystemService.method.resolveApproval;
+
+/**
+ * Admin only. Runs a turn recorded with RECORD_TURNS again in a new
+ * conversation, with the recorded LLM responses and tool results, to
+ * reproduce bugs without calling the LLM or executing tools.
+ *
+ * @generated from rpc blippy.system.SystemService.ReplayTurn
+ */
+export const replayTurn = SystemService.method.replayTurn;
+
+/**
+ * Returns the execution trace of a finished run: each LLM round-trip's
+ * request size, latency and token counts, and the tool calls it made.
+ *
+ * @generated from rpc blippy.system.SystemService.GetRunTrace
+ */
+export const getRunTrace = SystemService.method.getRunTrace;
+
+/**
+ * Admin only. Exports the agents, triggers, notification channels and
+ * filesystem roots, for `blippy export` against a remote server.
+ *
+ * @generated from rpc blippy.system.SystemService.ExportManifest
+ */
+export const exportManifest = SystemService.method.exportManifest;
+
+/**
+ * Returns the running version and, if the update check is enabled,
+ * whether a newer release is available.
+ *
+ * @generated from rpc blippy.system.SystemService.GetVersion
+ */
+export const getVersion = SystemService.method.getVersion;
+
+/**
+ * Returns the runs, failures, token usage and cost of each agent over the
+ * last 24 hours, or the requested period. Agents can get the same report
+ * with the get_usage_report tool.
+ *
+ * @generated from rpc blippy.system.SystemService.GetUsageReport
+ */
+export const getUsageReport = SystemService.method.getUsageReport;
+
+/**
+ * Admin only. Lists the OpenRouter API keys requests are spread over,
+ * with their usage.
+ *
+ * @generated from rpc blippy.system.SystemService.ListProviderKeys
+ */
+export const listProviderKeys = SystemService.method.listProviderKeys;
+
+/**
+ * Admin only. Adds an OpenRouter API key after checking it with
+ * OpenRouter, or updates the weight of a key that was already added. Keys
+ * are kept in memory on this replica; update OPENROUTER_API_KEY to keep
+ * them after a restart.
+ *
+ * @generated from rpc blippy.system.SystemService.CreateProviderKey
+ */
+export const createProviderKey = SystemService.method.createProviderKey;
+
+/**
+ * Admin only. Stops using an OpenRouter API key, e.g. after it leaked.
+ * The last key can't be removed.
+ *
+ * @generated from rpc blippy.system.SystemService.DeleteProviderKey
+ */
+export const deleteProviderKey = SystemService.method.deleteProviderKey;
+
+/**
+ * Admin only. Starts capturing the raw LLM calls of an agent or
+ * conversation, to debug malformed tool schemas and provider quirks.
+ *
+ * @generated from rpc blippy.system.SystemService.CreateLLMCapture
+ */
+export const createLLMCapture = SystemService.method.createLLMCapture;
+
+/**
+ * Admin only. Lists the captures that haven't expired.
+ *
+ * @generated from rpc blippy.system.SystemService.ListLLMCaptures
+ */
+export const listLLMCaptures = SystemService.method.listLLMCaptures;
+
+/**
+ * Admin only. Stops a capture. Exchanges captured so far are kept.
+ *
+ * @generated from rpc blippy.system.SystemService.DeleteLLMCapture
+ */
+export const deleteLLMCapture = SystemService.method.deleteLLMCapture;
+
+/**
+ * Admin only. Lists the captured LLM calls of a conversation or run.
+ *
+ * @generated from rpc blippy.system.SystemService.ListLLMExchanges
+ */
+export const listLLMExchanges = SystemService.method.listLLMExchanges;
@@ -0,0 +1,1856 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file system/system.proto (package blippy.system, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file system/system.proto.
+ */
+export const file_system_system: GenFile = /*@__PURE__*/
+  fileDesc("ChNzeXN0ZW0vc3lzdGVtLnByb3RvEg1ibGlwcHkuc3lzdGVtIpsBCgpUYWJsZVN0YXRzEgwKBG5hbWUYASABKAkSEQoJcm93X2NvdW50GAIgASgDEjUKEW9sZGVzdF9jcmVhdGVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBI1ChFuZXdlc3RfY3JlYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiFwoVR2V0U3lzdGVtU3RhdHNSZXF1ZXN0IqsCCgtTeXN0ZW1TdGF0cxIpCgZ0YWJsZXMYASADKAsyGS5ibGlwcHkuc3lzdGVtLlRhYmxlU3RhdHMSGwoTZGF0YWJhc2Vfc2l6ZV9ieXRlcxgCIAEoAxIWCg5zY2hlbWFfdmVyc2lvbhgDIAEoBRIdChVsYXRlc3Rfc2NoZW1hX3ZlcnNpb24YBCABKAUSGgoScGVuZGluZ19taWdyYXRpb25zGAUgAygJEjoKFnNjaGVkdWxlcl9sYXN0X3RpY2tfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhQKDGR1ZV90cmlnZ2VycxgHIAEoBRIdChVzY2hlZHVsZXJfbGFnX3NlY29uZHMYCCABKAMSEAoId2FybmluZ3MYCSADKAkicwoPTWFpbnRlbmFuY2VNb2RlEg8KB2VuYWJsZWQYASABKAgSDgoGcmVhc29uGAIgASgJEikKBXNpbmNlGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIUCgxhY3RpdmVfdHVybnMYBCABKAUiGwoZR2V0TWFpbnRlbmFuY2VNb2RlUmVxdWVzdCJVChxVcGRhdGVNYWludGVuYW5jZU1vZGVSZXF1ZXN0Eg8KB2VuYWJsZWQYASABKAgSDgoGcmVhc29uGAIgASgJEhQKDHdhaXRfc2Vjb25kcxgDIAEoBSIcChpHZXRJbnN0YW5jZVByZWFtYmxlUmVxdWVzdCJQChBJbnN0YW5jZVByZWFtYmxlEgwKBHRleHQYASABKAkSLgoKdXBkYXRlZF9hdBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiLQodVXBkYXRlSW5zdGFuY2VQcmVhbWJsZVJlcXVlc3QSDAoEdGV4dBgBIAEoCSIXChVHZXRCcm9rZXJTdGF0c1JlcXVlc3QiiAEKEVN1YnNjcmlwdGlvblN0YXRzEg4KBnRvcGljcxgBIAMoCRIuCgpjcmVhdGVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIQCghidWZmZXJlZBgDIAEoBRIQCghjYXBhY2l0eRgEIAEoBRIPCgdkcm9wcGVkGAUgASgDIq8BCgtCcm9rZXJTdGF0cxI3Cg1zdWJzY3JpcHRpb25zGAEgAygLMiAuYmxpcHB5LnN5c3RlbS5TdWJzY3JpcHRpb25TdGF0cxIdChVidXN5X2NvbnZlcnNhdGlvbl9pZHMYAiADKAkSGAoQcHVibGlzaGVkX2V2ZW50cxgDIAEoAxIWCg5yZWxheWVkX2V2ZW50cxgEIAEoAxIWCg5kcm9wcGVkX2V2ZW50cxgFIAEoAyK1AQoJQWN0aXZlUnVuEgoKAmlkGAEgASgJEhcKD2NvbnZlcnNhdGlvbl9pZBgCIAEoCRIQCghhZ2VudF9pZBgDIAEoCRISCgphZ2VudF9uYW1lGAQgASgJEgwKBGtpbmQYBSABKAkSDQoFZGVwdGgYBiABKAUSLgoKc3RhcnRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEAoIcHJpb3JpdHkYCCABKAUiKQoVTGlzdEFjdGl2ZVJ1bnNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIkAKFkxpc3RBY3RpdmVSdW5zUmVzcG9uc2USJgoEcnVucxgBIAMoCzIYLmJsaXBweS5zeXN0ZW0uQWN0aXZlUnVuIh4KEENhbmNlbFJ1blJlcXVlc3QSCgoCaWQYASABKAkiEwoRQ2FuY2VsUnVuUmVzcG9uc2UigAIKD1BlbmRpbmdBcHByb3ZhbBIKCgJpZBgBIAEoCRIOCgZydW5faWQYAiABKAkSFwoPY29udmVyc2F0aW9uX2lkGAMgASgJEhAKCGFnZW50X2lkGAQgASgJEhIKCmFnZW50X25hbWUYBSABKAkSEQoJdG9vbF9uYW1lGAYgASgJEg0KBWlucHV0GAcgASgJEg4KBnJlYXNvbhgIIAEoCRIwCgxyZXF1ZXN0ZWRfYXQYCSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCmV4cGlyZXNfYXQYCiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIi8KG0xpc3RQZW5kaW5nQXBwcm92YWxzUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSJRChxMaXN0UGVuZGluZ0FwcHJvdmFsc1Jlc3BvbnNlEjEKCWFwcHJvdmFscxgBIAMoCzIeLmJsaXBweS5zeXN0ZW0uUGVuZGluZ0FwcHJvdmFsIkYKFlJlc29sdmVBcHByb3ZhbFJlcXVlc3QSCgoCaWQYASABKAkSEAoIYXBwcm92ZWQYAiABKAgSDgoGcmVhc29uGAMgASgJIhkKF1Jlc29sdmVBcHByb3ZhbFJlc3BvbnNlIjwKEVJlcGxheVR1cm5SZXF1ZXN0Eg4KBnJ1bl9pZBgBIAEoCRIXCg9jb252ZXJzYXRpb25faWQYAiABKAkiTgoSUmVwbGF5VHVyblJlc3BvbnNlEhcKD2NvbnZlcnNhdGlvbl9pZBgBIAEoCRIQCghyZXNwb25zZRgCIAEoCRINCgVlcnJvchgDIAEoCSI9ChJHZXRSdW5UcmFjZVJlcXVlc3QSDgoGcnVuX2lkGAEgASgJEhcKD2NvbnZlcnNhdGlvbl9pZBgCIAEoCSJoCg1UcmFjZVRvb2xDYWxsEgwKBG5hbWUYASABKAkSDwoHY2FsbF9pZBgCIAEoCRITCgtkdXJhdGlvbl9tcxgDIAEoAxIUCgxyZXN1bHRfYnl0ZXMYBCABKAMSDQoFZXJyb3IYBSABKAgi7QEKClRyYWNlUm91bmQSLgoKc3RhcnRlZF9hdBgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASFQoNcmVxdWVzdF9ieXRlcxgCIAEoAxIWCg5maXJzdF9ldmVudF9tcxgDIAEoAxISCgpsYXRlbmN5X21zGAQgASgDEhQKDGlucHV0X3Rva2VucxgFIAEoAxIVCg1vdXRwdXRfdG9rZW5zGAYgASgDEg0KBWVycm9yGAcgASgJEjAKCnRvb2xfY2FsbHMYCCADKAsyHC5ibGlwcHkuc3lzdGVtLlRyYWNlVG9vbENhbGwizQIKCFJ1blRyYWNlEg4KBnJ1bl9pZBgBIAEoCRIQCghhZ2VudF9pZBgCIAEoCRIXCg9jb252ZXJzYXRpb25faWQYAyABKAkSDAoEa2luZBgEIAEoCRINCgVtb2RlbBgFIAEoCRIOCgZzdGF0dXMYBiABKAkSDQoFZXJyb3IYByABKAkSFAoMaW5wdXRfdG9rZW5zGAggASgDEhUKDW91dHB1dF90b2tlbnMYCSABKAMSLgoKc3RhcnRlZF9hdBgKIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoLZmluaXNoZWRfYXQYCyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhEKCXF1ZXVlZF9tcxgMIAEoAxIpCgZyb3VuZHMYDSADKAsyGS5ibGlwcHkuc3lzdGVtLlRyYWNlUm91bmQiKQoVRXhwb3J0TWFuaWZlc3RSZXF1ZXN0EhAKCGRvd25sb2FkGAEgASgIIkAKFkV4cG9ydE1hbmlmZXN0UmVzcG9uc2USEAoIbWFuaWZlc3QYASABKAkSFAoMZG93bmxvYWRfdXJsGAIgASgJIhMKEUdldFZlcnNpb25SZXF1ZXN0Iq8BCgdWZXJzaW9uEg8KB3ZlcnNpb24YASABKAkSHAoUdXBkYXRlX2NoZWNrX2VuYWJsZWQYAiABKAgSFgoObGF0ZXN0X3ZlcnNpb24YAyABKAkSEwoLcmVsZWFzZV91cmwYBCABKAkSLgoKY2hlY2tlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASGAoQdXBkYXRlX2F2YWlsYWJsZRgGIAEoCCItChVHZXRVc2FnZVJlcG9ydFJlcXVlc3QSFAoMcGVyaW9kX2hvdXJzGAEgASgFIrMBCgtVc2FnZVJlcG9ydBIpCgVzaW5jZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASKQoFdW50aWwYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEikKBmFnZW50cxgDIAMoCzIZLmJsaXBweS5zeXN0ZW0uQWdlbnRVc2FnZRIjCgV0b3RhbBgEIAEoCzIULmJsaXBweS5zeXN0ZW0uVXNhZ2UiVwoKQWdlbnRVc2FnZRIQCghhZ2VudF9pZBgBIAEoCRISCgphZ2VudF9uYW1lGAIgASgJEiMKBXVzYWdlGAMgASgLMhQuYmxpcHB5LnN5c3RlbS5Vc2FnZSJ5CgVVc2FnZRIMCgRydW5zGAEgASgDEhMKC2ZhaWxlZF9ydW5zGAIgASgDEhQKDGlucHV0X3Rva2VucxgDIAEoAxIVCg1vdXRwdXRfdG9rZW5zGAQgASgDEhAKCGNvc3RfdXNkGAUgASgBEg4KBnByaWNlZBgGIAEoCCL+AQoLUHJvdmlkZXJLZXkSCgoCaWQYASABKAkSDAoEaGludBgCIAEoCRIOCgZ3ZWlnaHQYAyABKAUSEAoIcmVxdWVzdHMYBCABKAMSEAoIZmFpbHVyZXMYBSABKAMSFAoMcmF0ZV9saW1pdGVkGAYgASgDEhQKDGlucHV0X3Rva2VucxgHIAEoAxIVCg1vdXRwdXRfdG9rZW5zGAggASgDEiwKCGFkZGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIwCgxsYXN0X3VzZWRfYXQYCiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIhkKF0xpc3RQcm92aWRlcktleXNSZXF1ZXN0IkQKGExpc3RQcm92aWRlcktleXNSZXNwb25zZRIoCgRrZXlzGAEgAygLMhouYmxpcHB5LnN5c3RlbS5Qcm92aWRlcktleSI7ChhDcmVhdGVQcm92aWRlcktleVJlcXVlc3QSDwoHYXBpX2tleRgBIAEoCRIOCgZ3ZWlnaHQYAiABKAUiJgoYRGVsZXRlUHJvdmlkZXJLZXlSZXF1ZXN0EgoKAmlkGAEgASgJIhsKGURlbGV0ZVByb3ZpZGVyS2V5UmVzcG9uc2UivgEKCkxMTUNhcHR1cmUSCgoCaWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSFwoPY29udmVyc2F0aW9uX2lkGAMgASgJEhkKEXJldGVudGlvbl9zZWNvbmRzGAQgASgDEi4KCmV4cGlyZXNfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCmNyZWF0ZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wInkKF0NyZWF0ZUxMTUNhcHR1cmVSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEhcKD2NvbnZlcnNhdGlvbl9pZBgCIAEoCRIYChBkdXJhdGlvbl9zZWNvbmRzGAMgASgDEhkKEXJldGVudGlvbl9zZWNvbmRzGAQgASgDIhgKFkxpc3RMTE1DYXB0dXJlc1JlcXVlc3QiRgoXTGlzdExMTUNhcHR1cmVzUmVzcG9uc2USKwoIY2FwdHVyZXMYASADKAsyGS5ibGlwcHkuc3lzdGVtLkxMTUNhcHR1cmUiJQoXRGVsZXRlTExNQ2FwdHVyZVJlcXVlc3QSCgoCaWQYASABKAkiGgoYRGVsZXRlTExNQ2FwdHVyZVJlc3BvbnNlIqsDCgtMTE1FeGNoYW5nZRIKCgJpZBgBIAEoCRISCgpjYXB0dXJlX2lkGAIgASgJEg4KBnJ1bl9pZBgDIAEoCRIXCg9jb252ZXJzYXRpb25faWQYBCABKAkSEAoIYWdlbnRfaWQYBSABKAkSDQoFcm91bmQYBiABKAUSCwoDdXJsGAcgASgJEg4KBmtleV9pZBgIIAEoCRIUCgxyZXF1ZXN0X2JvZHkYCSABKAkSEwoLc3RhdHVzX2NvZGUYCiABKAUSSQoQcmVzcG9uc2VfaGVhZGVycxgLIAMoCzIvLmJsaXBweS5zeXN0ZW0uTExNRXhjaGFuZ2UuUmVzcG9uc2VIZWFkZXJzRW50cnkSFQoNcmVzcG9uc2VfYm9keRgMIAEoCRIRCgl0cnVuY2F0ZWQYDSABKAgSDQoFZXJyb3IYDiABKAkSLgoKY3JlYXRlZF9hdBgPIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAaNgoUUmVzcG9uc2VIZWFkZXJzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJpChdMaXN0TExNRXhjaGFuZ2VzUmVxdWVzdBIXCg9jb252ZXJzYXRpb25faWQYASABKAkSDgoGcnVuX2lkGAIgASgJEhEKCXBhZ2Vfc2l6ZRgDIAEoBRISCgpwYWdlX3Rva2VuGAQgASgJInYKGExpc3RMTE1FeGNoYW5nZXNSZXNwb25zZRItCglleGNoYW5nZXMYASADKAsyGi5ibGlwcHkuc3lzdGVtLkxMTUV4Y2hhbmdlEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCRISCgp0b3RhbF9zaXplGAMgASgFMo4QCg1TeXN0ZW1TZXJ2aWNlElIKDkdldFN5c3RlbVN0YXRzEiQuYmxpcHB5LnN5c3RlbS5HZXRTeXN0ZW1TdGF0c1JlcXVlc3QaGi5ibGlwcHkuc3lzdGVtLlN5c3RlbVN0YXRzEl4KEkdldE1haW50ZW5hbmNlTW9kZRIoLmJsaXBweS5zeXN0ZW0uR2V0TWFpbnRlbmFuY2VNb2RlUmVxdWVzdBoeLmJsaXBweS5zeXN0ZW0uTWFpbnRlbmFuY2VNb2RlEmQKFVVwZGF0ZU1haW50ZW5hbmNlTW9kZRIrLmJsaXBweS5zeXN0ZW0uVXBkYXRlTWFpbnRlbmFuY2VNb2RlUmVxdWVzdBoeLmJsaXBweS5zeXN0ZW0uTWFpbnRlbmFuY2VNb2RlEmEKE0dldEluc3RhbmNlUHJlYW1ibGUSKS5ibGlwcHkuc3lzdGVtLkdldEluc3RhbmNlUHJlYW1ibGVSZXF1ZXN0Gh8uYmxpcHB5LnN5c3RlbS5JbnN0YW5jZVByZWFtYmxlEmcKFlVwZGF0ZUluc3RhbmNlUHJlYW1ibGUSLC5ibGlwcHkuc3lzdGVtLlVwZGF0ZUluc3RhbmNlUHJlYW1ibGVSZXF1ZXN0Gh8uYmxpcHB5LnN5c3RlbS5JbnN0YW5jZVByZWFtYmxlElIKDkdldEJyb2tlclN0YXRzEiQuYmxpcHB5LnN5c3RlbS5HZXRCcm9rZXJTdGF0c1JlcXVlc3QaGi5ibGlwcHkuc3lzdGVtLkJyb2tlclN0YXRzEl0KDkxpc3RBY3RpdmVSdW5zEiQuYmxpcHB5LnN5c3RlbS5MaXN0QWN0aXZlUnVuc1JlcXVlc3QaJS5ibGlwcHkuc3lzdGVtLkxpc3RBY3RpdmVSdW5zUmVzcG9uc2USTgoJQ2FuY2VsUnVuEh8uYmxpcHB5LnN5c3RlbS5DYW5jZWxSdW5SZXF1ZXN0GiAuYmxpcHB5LnN5c3RlbS5DYW5jZWxSdW5SZXNwb25zZRJvChRMaXN0UGVuZGluZ0FwcHJvdmFscxIqLmJsaXBweS5zeXN0ZW0uTGlzdFBlbmRpbmdBcHByb3ZhbHNSZXF1ZXN0GisuYmxpcHB5LnN5c3RlbS5MaXN0UGVuZGluZ0FwcHJvdmFsc1Jlc3BvbnNlEmAKD1Jlc29sdmVBcHByb3ZhbBIlLmJsaXBweS5zeXN0ZW0uUmVzb2x2ZUFwcHJvdmFsUmVxdWVzdBomLmJsaXBweS5zeXN0ZW0uUmVzb2x2ZUFwcHJvdmFsUmVzcG9uc2USUQoKUmVwbGF5VHVybhIgLmJsaXBweS5zeXN0ZW0uUmVwbGF5VHVyblJlcXVlc3QaIS5ibGlwcHkuc3lzdGVtLlJlcGxheVR1cm5SZXNwb25zZRJJCgtHZXRSdW5UcmFjZRIhLmJsaXBweS5zeXN0ZW0uR2V0UnVuVHJhY2VSZXF1ZXN0GhcuYmxpcHB5LnN5c3RlbS5SdW5UcmFjZRJdCg5FeHBvcnRNYW5pZmVzdBIkLmJsaXBweS5zeXN0ZW0uRXhwb3J0TWFuaWZlc3RSZXF1ZXN0GiUuYmxpcHB5LnN5c3RlbS5FeHBvcnRNYW5pZmVzdFJlc3BvbnNlEkYKCkdldFZlcnNpb24SIC5ibGlwcHkuc3lzdGVtLkdldFZlcnNpb25SZXF1ZXN0GhYuYmxpcHB5LnN5c3RlbS5WZXJzaW9uElIKDkdldFVzYWdlUmVwb3J0EiQuYmxpcHB5LnN5c3RlbS5HZXRVc2FnZVJlcG9ydFJlcXVlc3QaGi5ibGlwcHkuc3lzdGVtLlVzYWdlUmVwb3J0EmMKEExpc3RQcm92aWRlcktleXMSJi5ibGlwcHkuc3lzdGVtLkxpc3RQcm92aWRlcktleXNSZXF1ZXN0GicuYmxpcHB5LnN5c3RlbS5MaXN0UHJvdmlkZXJLZXlzUmVzcG9uc2USWAoRQ3JlYXRlUHJvdmlkZXJLZXkSJy5ibGlwcHkuc3lzdGVtLkNyZWF0ZVByb3ZpZGVyS2V5UmVxdWVzdBoaLmJsaXBweS5zeXN0ZW0uUHJvdmlkZXJLZXkSZgoRRGVsZXRlUHJvdmlkZXJLZXkSJy5ibGlwcHkuc3lzdGVtLkRlbGV0ZVByb3ZpZGVyS2V5UmVxdWVzdBooLmJsaXBweS5zeXN0ZW0uRGVsZXRlUHJvdmlkZXJLZXlSZXNwb25zZRJVChBDcmVhdGVMTE1DYXB0dXJlEiYuYmxpcHB5LnN5c3RlbS5DcmVhdGVMTE1DYXB0dXJlUmVxdWVzdBoZLmJsaXBweS5zeXN0ZW0uTExNQ2FwdHVyZRJgCg9MaXN0TExNQ2FwdHVyZXMSJS5ibGlwcHkuc3lzdGVtLkxpc3RMTE1DYXB0dXJlc1JlcXVlc3QaJi5ibGlwcHkuc3lzdGVtLkxpc3RMTE1DYXB0dXJlc1Jlc3BvbnNlEmMKEERlbGV0ZUxMTUNhcHR1cmUSJi5ibGlwcHkuc3lzdGVtLkRlbGV0ZUxMTUNhcHR1cmVSZXF1ZXN0GicuYmxpcHB5LnN5c3RlbS5EZWxldGVMTE1DYXB0dXJlUmVzcG9uc2USYwoQTGlzdExMTUV4Y2hhbmdlcxImLmJsaXBweS5zeXN0ZW0uTGlzdExMTUV4Y2hhbmdlc1JlcXVlc3QaJy5ibGlwcHkuc3lzdGVtLkxpc3RMTE1FeGNoYW5nZXNSZXNwb25zZUIsWipnaXRodWIuY29tL2RzdG90aWpuL2JsaXBweS9pbnRlcm5hbC9zeXN0ZW1iBnByb3RvMw", [file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message blippy.system.TableStats
+ */
+export type TableStats = Message<"blippy.system.TableStats"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: int64 row_count = 2;
+   */
+  rowCount: bigint;
+
+  /**
+   * Oldest and newest created_at values; unset if the table has none.
+   *
+   * @generated from field: google.protobuf.Timestamp oldest_created_at = 3;
+   */
+  oldestCreatedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp newest_created_at = 4;
+   */
+  newestCreatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.system.TableStats.
+ * Use `create(TableStatsSchema)` to create a new message.
+ */
+export const TableStatsSchema: GenMessage<TableStats> = /*@__PURE__*/
+  messageDesc(file_system_system, 0);
+
+/**
+ * @generated from message blippy.system.GetSystemStatsRequest
+ */
+export type GetSystemStatsRequest = Message<"blippy.system.GetSystemStatsRequest"> & {
+};
+
+/**
+ * Describes the message blippy.system.GetSystemStatsRequest.
+ * Use `create(GetSystemStatsRequestSchema)` to create a new message.
+ */
+export const GetSystemStatsRequestSchema: GenMessage<GetSystemStatsRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 1);
+
+/**
+ * @generated from message blippy.system.SystemStats
+ */
+export type SystemStats = Message<"blippy.system.SystemStats"> & {
+  /**
+   * @generated from field: repeated blippy.system.TableStats tables = 1;
+   */
+  tables: TableStats[];
+
+  /**
+   * Excludes the write-ahead log
+   *
+   * @generated from field: int64 database_size_bytes = 2;
+   */
+  databaseSizeBytes: bigint;
+
+  /**
+   * @generated from field: int32 schema_version = 3;
+   */
+  schemaVersion: number;
+
+  /**
+   * @generated from field: int32 latest_schema_version = 4;
+   */
+  latestSchemaVersion: number;
+
+  /**
+   * @generated from field: repeated string pending_migrations = 5;
+   */
+  pendingMigrations: string[];
+
+  /**
+   * When the scheduler last started a tick; unset if it hasn't ticked yet.
+   *
+   * @generated from field: google.protobuf.Timestamp scheduler_last_tick_at = 6;
+   */
+  schedulerLastTickAt?: Timestamp;
+
+  /**
+   * Number of enabled triggers whose next run is due.
+   *
+   * @generated from field: int32 due_triggers = 7;
+   */
+  dueTriggers: number;
+
+  /**
+   * How long the most overdue trigger has been waiting to run.
+   *
+   * @generated from field: int64 scheduler_lag_seconds = 8;
+   */
+  schedulerLagSeconds: bigint;
+
+  /**
+   * Problems worth attention, e.g. pending migrations or a stalled scheduler.
+   *
+   * @generated from field: repeated string warnings = 9;
+   */
+  warnings: string[];
+};
+
+/**
+ * Describes the message blippy.system.SystemStats.
+ * Use `create(SystemStatsSchema)` to create a new message.
+ */
+export const SystemStatsSchema: GenMessage<SystemStats> = /*@__PURE__*/
+  messageDesc(file_system_system, 2);
+
+/**
+ * @generated from message blippy.system.MaintenanceMode
+ */
+export type MaintenanceMode = Message<"blippy.system.MaintenanceMode"> & {
+  /**
+   * @generated from field: bool enabled = 1;
+   */
+  enabled: boolean;
+
+  /**
+   * Shown to users whose requests are rejected.
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp since = 3;
+   */
+  since?: Timestamp;
+
+  /**
+   * Agent turns still in progress. Maintenance is safe once this is 0.
+   *
+   * @generated from field: int32 active_turns = 4;
+   */
+  activeTurns: number;
+};
+
+/**
+ * Describes the message blippy.system.MaintenanceMode.
+ * Use `create(MaintenanceModeSchema)` to create a new message.
+ */
+export const MaintenanceModeSchema: GenMessage<MaintenanceMode> = /*@__PURE__*/
+  messageDesc(file_system_system, 3);
+
+/**
+ * @generated from message blippy.system.GetMaintenanceModeRequest
+ */
+export type GetMaintenanceModeRequest = Message<"blippy.system.GetMaintenanceModeRequest"> & {
+};
+
+/**
+ * Describes the message blippy.system.GetMaintenanceModeRequest.
+ * Use `create(GetMaintenanceModeRequestSchema)` to create a new message.
+ */
+export const GetMaintenanceModeRequestSchema: GenMessage<GetMaintenanceModeRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 4);
+
+/**
+ * @generated from message blippy.system.UpdateMaintenanceModeRequest
+ */
+export type UpdateMaintenanceModeRequest = Message<"blippy.system.UpdateMaintenanceModeRequest"> & {
+  /**
+   * @generated from field: bool enabled = 1;
+   */
+  enabled: boolean;
+
+  /**
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+
+  /**
+   * When enabling, how long to wait for active turns to finish before
+   * returning. 0 returns immediately; poll GetMaintenanceMode instead.
+   *
+   * @generated from field: int32 wait_seconds = 3;
+   */
+  waitSeconds: number;
+};
+
+/**
+ * Describes the message blippy.system.UpdateMaintenanceModeRequest.
+ * Use `create(UpdateMaintenanceModeRequestSchema)` to create a new message.
+ */
+export const UpdateMaintenanceModeRequestSchema: GenMessage<UpdateMaintenanceModeRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 5);
+
+/**
+ * @generated from message blippy.system.GetInstancePreambleRequest
+ */
+export type GetInstancePreambleRequest = Message<"blippy.system.GetInstancePreambleRequest"> & {
+};
+
+/**
+ * Describes the message blippy.system.GetInstancePreambleRequest.
+ * Use `create(GetInstancePreambleRequestSchema)` to create a new message.
+ */
+export const GetInstancePreambleRequestSchema: GenMessage<GetInstancePreambleRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 6);
+
+/**
+ * InstancePreamble is guidance for all agents of the instance, such as
+ * organization policies, prepended to their instructions on every turn.
+ * "{date}" is replaced with the current date.
+ *
+ * @generated from message blippy.system.InstancePreamble
+ */
+export type InstancePreamble = Message<"blippy.system.InstancePreamble"> & {
+  /**
+   * @generated from field: string text = 1;
+   */
+  text: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 2;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.system.InstancePreamble.
+ * Use `create(InstancePreambleSchema)` to create a new message.
+ */
+export const InstancePreambleSchema: GenMessage<InstancePreamble> = /*@__PURE__*/
+  messageDesc(file_system_system, 7);
+
+/**
+ * @generated from message blippy.system.UpdateInstancePreambleRequest
+ */
+export type UpdateInstancePreambleRequest = Message<"blippy.system.UpdateInstancePreambleRequest"> & {
+  /**
+   * Empty removes the preamble.
+   *
+   * @generated from field: string text = 1;
+   */
+  text: string;
+};
+
+/**
+ * Describes the message blippy.system.UpdateInstancePreambleRequest.
+ * Use `create(UpdateInstancePreambleRequestSchema)` to create a new message.
+ */
+export const UpdateInstancePreambleRequestSchema: GenMessage<UpdateInstancePreambleRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 8);
+
+/**
+ * @generated from message blippy.system.GetBrokerStatsRequest
+ */
+export type GetBrokerStatsRequest = Message<"blippy.system.GetBrokerStatsRequest"> & {
+};
+
+/**
+ * Describes the message blippy.system.GetBrokerStatsRequest.
+ * Use `create(GetBrokerStatsRequestSchema)` to create a new message.
+ */
+export const GetBrokerStatsRequestSchema: GenMessage<GetBrokerStatsRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 9);
+
+/**
+ * @generated from message blippy.system.SubscriptionStats
+ */
+export type SubscriptionStats = Message<"blippy.system.SubscriptionStats"> & {
+  /**
+   * Topic patterns, e.g. "conversation:<id>" or "agent:*".
+   *
+   * @generated from field: repeated string topics = 1;
+   */
+  topics: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 2;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * Events waiting to be received by the subscriber, of capacity.
+   *
+   * @generated from field: int32 buffered = 3;
+   */
+  buffered: number;
+
+  /**
+   * @generated from field: int32 capacity = 4;
+   */
+  capacity: number;
+
+  /**
+   * Events the subscriber missed because it didn't keep up.
+   *
+   * @generated from field: int64 dropped = 5;
+   */
+  dropped: bigint;
+};
+
+/**
+ * Describes the message blippy.system.SubscriptionStats.
+ * Use `create(SubscriptionStatsSchema)` to create a new message.
+ */
+export const SubscriptionStatsSchema: GenMessage<SubscriptionStats> = /*@__PURE__*/
+  messageDesc(file_system_system, 10);
+
+/**
+ * Event broker state, to debug clients that stop getting updates.
+ *
+ * @generated from message blippy.system.BrokerStats
+ */
+export type BrokerStats = Message<"blippy.system.BrokerStats"> & {
+  /**
+   * @generated from field: repeated blippy.system.SubscriptionStats subscriptions = 1;
+   */
+  subscriptions: SubscriptionStats[];
+
+  /**
+   * Conversations with an active agent turn.
+   *
+   * @generated from field: repeated string busy_conversation_ids = 2;
+   */
+  busyConversationIds: string[];
+
+  /**
+   * Counted since the server started.
+   *
+   * @generated from field: int64 published_events = 3;
+   */
+  publishedEvents: bigint;
+
+  /**
+   * @generated from field: int64 relayed_events = 4;
+   */
+  relayedEvents: bigint;
+
+  /**
+   * @generated from field: int64 dropped_events = 5;
+   */
+  droppedEvents: bigint;
+};
+
+/**
+ * Describes the message blippy.system.BrokerStats.
+ * Use `create(BrokerStatsSchema)` to create a new message.
+ */
+export const BrokerStatsSchema: GenMessage<BrokerStats> = /*@__PURE__*/
+  messageDesc(file_system_system, 11);
+
+/**
+ * ActiveRun is an agent turn in progress on the server.
+ *
+ * @generated from message blippy.system.ActiveRun
+ */
+export type ActiveRun = Message<"blippy.system.ActiveRun"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string conversation_id = 2;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 3;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 4;
+   */
+  agentName: string;
+
+  /**
+   * What started the run: "interactive", "trigger", "webhook", "subagent",
+   * "eval", "replay" or "autonomous".
+   *
+   * @generated from field: string kind = 5;
+   */
+  kind: string;
+
+  /**
+   * Greater than 0 for turns of agents called by other agents.
+   *
+   * @generated from field: int32 depth = 6;
+   */
+  depth: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 7;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * Priority the run had while waiting for the agent's concurrency limit;
+   * higher starts first.
+   *
+   * @generated from field: int32 priority = 8;
+   */
+  priority: number;
+};
+
+/**
+ * Describes the message blippy.system.ActiveRun.
+ * Use `create(ActiveRunSchema)` to create a new message.
+ */
+export const ActiveRunSchema: GenMessage<ActiveRun> = /*@__PURE__*/
+  messageDesc(file_system_system, 12);
+
+/**
+ * @generated from message blippy.system.ListActiveRunsRequest
+ */
+export type ListActiveRunsRequest = Message<"blippy.system.ListActiveRunsRequest"> & {
+  /**
+   * optional filter
+   *
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message blippy.system.ListActiveRunsRequest.
+ * Use `create(ListActiveRunsRequestSchema)` to create a new message.
+ */
+export const ListActiveRunsRequestSchema: GenMessage<ListActiveRunsRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 13);
+
+/**
+ * @generated from message blippy.system.ListActiveRunsResponse
+ */
+export type ListActiveRunsResponse = Message<"blippy.system.ListActiveRunsResponse"> & {
+  /**
+   * Oldest first.
+   *
+   * @generated from field: repeated blippy.system.ActiveRun runs = 1;
+   */
+  runs: ActiveRun[];
+};
+
+/**
+ * Describes the message blippy.system.ListActiveRunsResponse.
+ * Use `create(ListActiveRunsResponseSchema)` to create a new message.
+ */
+export const ListActiveRunsResponseSchema: GenMessage<ListActiveRunsResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 14);
+
+/**
+ * @generated from message blippy.system.CancelRunRequest
+ */
+export type CancelRunRequest = Message<"blippy.system.CancelRunRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.system.CancelRunRequest.
+ * Use `create(CancelRunRequestSchema)` to create a new message.
+ */
+export const CancelRunRequestSchema: GenMessage<CancelRunRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 15);
+
+/**
+ * @generated from message blippy.system.CancelRunResponse
+ */
+export type CancelRunResponse = Message<"blippy.system.CancelRunResponse"> & {
+};
+
+/**
+ * Describes the message blippy.system.CancelRunResponse.
+ * Use `create(CancelRunResponseSchema)` to create a new message.
+ */
+export const CancelRunResponseSchema: GenMessage<CancelRunResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 16);
+
+/**
+ * PendingApproval is a tool call waiting for approval, because it matched an
+ * approval rule of its agent.
+ *
+ * @generated from message blippy.system.PendingApproval
+ */
+export type PendingApproval = Message<"blippy.system.PendingApproval"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string run_id = 2;
+   */
+  runId: string;
+
+  /**
+   * @generated from field: string conversation_id = 3;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 4;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 5;
+   */
+  agentName: string;
+
+  /**
+   * @generated from field: string tool_name = 6;
+   */
+  toolName: string;
+
+  /**
+   * JSON arguments of the call.
+   *
+   * @generated from field: string input = 7;
+   */
+  input: string;
+
+  /**
+   * The rule the call matched, e.g. `bash command matches "rm|curl"`.
+   *
+   * @generated from field: string reason = 8;
+   */
+  reason: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp requested_at = 9;
+   */
+  requestedAt?: Timestamp;
+
+  /**
+   * When the call is denied if it isn't resolved.
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 10;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.system.PendingApproval.
+ * Use `create(PendingApprovalSchema)` to create a new message.
+ */
+export const PendingApprovalSchema: GenMessage<PendingApproval> = /*@__PURE__*/
+  messageDesc(file_system_system, 17);
+
+/**
+ * @generated from message blippy.system.ListPendingApprovalsRequest
+ */
+export type ListPendingApprovalsRequest = Message<"blippy.system.ListPendingApprovalsRequest"> & {
+  /**
+   * optional filter
+   *
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message blippy.system.ListPendingApprovalsRequest.
+ * Use `create(ListPendingApprovalsRequestSchema)` to create a new message.
+ */
+export const ListPendingApprovalsRequestSchema: GenMessage<ListPendingApprovalsRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 18);
+
+/**
+ * @generated from message blippy.system.ListPendingApprovalsResponse
+ */
+export type ListPendingApprovalsResponse = Message<"blippy.system.ListPendingApprovalsResponse"> & {
+  /**
+   * Oldest first.
+   *
+   * @generated from field: repeated blippy.system.PendingApproval approvals = 1;
+   */
+  approvals: PendingApproval[];
+};
+
+/**
+ * Describes the message blippy.system.ListPendingApprovalsResponse.
+ * Use `create(ListPendingApprovalsResponseSchema)` to create a new message.
+ */
+export const ListPendingApprovalsResponseSchema: GenMessage<ListPendingApprovalsResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 19);
+
+/**
+ * @generated from message blippy.system.ResolveApprovalRequest
+ */
+export type ResolveApprovalRequest = Message<"blippy.system.ResolveApprovalRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: bool approved = 2;
+   */
+  approved: boolean;
+
+  /**
+   * Why the call is denied, reported to the agent.
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message blippy.system.ResolveApprovalRequest.
+ * Use `create(ResolveApprovalRequestSchema)` to create a new message.
+ */
+export const ResolveApprovalRequestSchema: GenMessage<ResolveApprovalRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 20);
+
+/**
+ * @generated from message blippy.system.ResolveApprovalResponse
+ */
+export type ResolveApprovalResponse = Message<"blippy.system.ResolveApprovalResponse"> & {
+};
+
+/**
+ * Describes the message blippy.system.ResolveApprovalResponse.
+ * Use `create(ResolveApprovalResponseSchema)` to create a new message.
+ */
+export const ResolveApprovalResponseSchema: GenMessage<ResolveApprovalResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 21);
+
+/**
+ * @generated from message blippy.system.ReplayTurnRequest
+ */
+export type ReplayTurnRequest = Message<"blippy.system.ReplayTurnRequest"> & {
+  /**
+   * The recorded run to replay.
+   *
+   * @generated from field: string run_id = 1;
+   */
+  runId: string;
+
+  /**
+   * Replays the latest recorded turn of the conversation if run_id is empty.
+   *
+   * @generated from field: string conversation_id = 2;
+   */
+  conversationId: string;
+};
+
+/**
+ * Describes the message blippy.system.ReplayTurnRequest.
+ * Use `create(ReplayTurnRequestSchema)` to create a new message.
+ */
+export const ReplayTurnRequestSchema: GenMessage<ReplayTurnRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 22);
+
+/**
+ * @generated from message blippy.system.ReplayTurnResponse
+ */
+export type ReplayTurnResponse = Message<"blippy.system.ReplayTurnResponse"> & {
+  /**
+   * The new conversation the turn was replayed in.
+   *
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string response = 2;
+   */
+  response: string;
+
+  /**
+   * The error the replayed turn ended with, e.g. when it diverged from the
+   * recording.
+   *
+   * @generated from field: string error = 3;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message blippy.system.ReplayTurnResponse.
+ * Use `create(ReplayTurnResponseSchema)` to create a new message.
+ */
+export const ReplayTurnResponseSchema: GenMessage<ReplayTurnResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 23);
+
+/**
+ * @generated from message blippy.system.GetRunTraceRequest
+ */
+export type GetRunTraceRequest = Message<"blippy.system.GetRunTraceRequest"> & {
+  /**
+   * @generated from field: string run_id = 1;
+   */
+  runId: string;
+
+  /**
+   * Gets the trace of the latest run of the conversation if run_id is empty.
+   *
+   * @generated from field: string conversation_id = 2;
+   */
+  conversationId: string;
+};
+
+/**
+ * Describes the message blippy.system.GetRunTraceRequest.
+ * Use `create(GetRunTraceRequestSchema)` to create a new message.
+ */
+export const GetRunTraceRequestSchema: GenMessage<GetRunTraceRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 24);
+
+/**
+ * TraceToolCall is a tool call of an LLM round-trip. The calls of a
+ * round-trip run concurrently, so their durations overlap.
+ *
+ * @generated from message blippy.system.TraceToolCall
+ */
+export type TraceToolCall = Message<"blippy.system.TraceToolCall"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string call_id = 2;
+   */
+  callId: string;
+
+  /**
+   * @generated from field: int64 duration_ms = 3;
+   */
+  durationMs: bigint;
+
+  /**
+   * @generated from field: int64 result_bytes = 4;
+   */
+  resultBytes: bigint;
+
+  /**
+   * Whether the tool returned an error.
+   *
+   * @generated from field: bool error = 5;
+   */
+  error: boolean;
+};
+
+/**
+ * Describes the message blippy.system.TraceToolCall.
+ * Use `create(TraceToolCallSchema)` to create a new message.
+ */
+export const TraceToolCallSchema: GenMessage<TraceToolCall> = /*@__PURE__*/
+  messageDesc(file_system_system, 25);
+
+/**
+ * TraceRound is an LLM round-trip of a run.
+ *
+ * @generated from message blippy.system.TraceRound
+ */
+export type TraceRound = Message<"blippy.system.TraceRound"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 1;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * Size of the request sent to the LLM, which grows with the tool results
+   * of earlier round-trips.
+   *
+   * @generated from field: int64 request_bytes = 2;
+   */
+  requestBytes: bigint;
+
+  /**
+   * Time until the LLM started streaming.
+   *
+   * @generated from field: int64 first_event_ms = 3;
+   */
+  firstEventMs: bigint;
+
+  /**
+   * Time until the response was complete.
+   *
+   * @generated from field: int64 latency_ms = 4;
+   */
+  latencyMs: bigint;
+
+  /**
+   * @generated from field: int64 input_tokens = 5;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 6;
+   */
+  outputTokens: bigint;
+
+  /**
+   * @generated from field: string error = 7;
+   */
+  error: string;
+
+  /**
+   * @generated from field: repeated blippy.system.TraceToolCall tool_calls = 8;
+   */
+  toolCalls: TraceToolCall[];
+};
+
+/**
+ * Describes the message blippy.system.TraceRound.
+ * Use `create(TraceRoundSchema)` to create a new message.
+ */
+export const TraceRoundSchema: GenMessage<TraceRound> = /*@__PURE__*/
+  messageDesc(file_system_system, 26);
+
+/**
+ * RunTrace is the execution trace of a finished run.
+ *
+ * @generated from message blippy.system.RunTrace
+ */
+export type RunTrace = Message<"blippy.system.RunTrace"> & {
+  /**
+   * @generated from field: string run_id = 1;
+   */
+  runId: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string conversation_id = 3;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string kind = 4;
+   */
+  kind: string;
+
+  /**
+   * @generated from field: string model = 5;
+   */
+  model: string;
+
+  /**
+   * "completed", "failed", "cancelled", "interrupted" or "timed_out".
+   *
+   * @generated from field: string status = 6;
+   */
+  status: string;
+
+  /**
+   * @generated from field: string error = 7;
+   */
+  error: string;
+
+  /**
+   * @generated from field: int64 input_tokens = 8;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 9;
+   */
+  outputTokens: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 10;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp finished_at = 11;
+   */
+  finishedAt?: Timestamp;
+
+  /**
+   * Time spent waiting for a slot of the agent's max_concurrent_runs.
+   *
+   * @generated from field: int64 queued_ms = 12;
+   */
+  queuedMs: bigint;
+
+  /**
+   * @generated from field: repeated blippy.system.TraceRound rounds = 13;
+   */
+  rounds: TraceRound[];
+};
+
+/**
+ * Describes the message blippy.system.RunTrace.
+ * Use `create(RunTraceSchema)` to create a new message.
+ */
+export const RunTraceSchema: GenMessage<RunTrace> = /*@__PURE__*/
+  messageDesc(file_system_system, 27);
+
+/**
+ * @generated from message blippy.system.ExportManifestRequest
+ */
+export type ExportManifestRequest = Message<"blippy.system.ExportManifestRequest"> & {
+  /**
+   * Stores the manifest as a blob, kept for a day, and returns a signed
+   * download_url instead of the manifest.
+   *
+   * @generated from field: bool download = 1;
+   */
+  download: boolean;
+};
+
+/**
+ * Describes the message blippy.system.ExportManifestRequest.
+ * Use `create(ExportManifestRequestSchema)` to create a new message.
+ */
+export const ExportManifestRequestSchema: GenMessage<ExportManifestRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 28);
+
+/**
+ * @generated from message blippy.system.ExportManifestResponse
+ */
+export type ExportManifestResponse = Message<"blippy.system.ExportManifestResponse"> & {
+  /**
+   * The instance configuration as JSON, like `blippy export` writes it.
+   * Secret values are redacted.
+   *
+   * @generated from field: string manifest = 1;
+   */
+  manifest: string;
+
+  /**
+   * Path of the signed URL to download the manifest from, valid for an
+   * hour, if download was requested.
+   *
+   * @generated from field: string download_url = 2;
+   */
+  downloadUrl: string;
+};
+
+/**
+ * Describes the message blippy.system.ExportManifestResponse.
+ * Use `create(ExportManifestResponseSchema)` to create a new message.
+ */
+export const ExportManifestResponseSchema: GenMessage<ExportManifestResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 29);
+
+/**
+ * @generated from message blippy.system.GetVersionRequest
+ */
+export type GetVersionRequest = Message<"blippy.system.GetVersionRequest"> & {
+};
+
+/**
+ * Describes the message blippy.system.GetVersionRequest.
+ * Use `create(GetVersionRequestSchema)` to create a new message.
+ */
+export const GetVersionRequestSchema: GenMessage<GetVersionRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 30);
+
+/**
+ * @generated from message blippy.system.Version
+ */
+export type Version = Message<"blippy.system.Version"> & {
+  /**
+   * The version of the running binary, e.g. "v1.2.3", or "(devel)".
+   *
+   * @generated from field: string version = 1;
+   */
+  version: string;
+
+  /**
+   * Whether the background check for new releases is enabled, with
+   * UPDATE_CHECK=1.
+   *
+   * @generated from field: bool update_check_enabled = 2;
+   */
+  updateCheckEnabled: boolean;
+
+  /**
+   * The latest release on GitHub, as last checked; empty if the check is
+   * disabled or hasn't succeeded yet.
+   *
+   * @generated from field: string latest_version = 3;
+   */
+  latestVersion: string;
+
+  /**
+   * @generated from field: string release_url = 4;
+   */
+  releaseUrl: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp checked_at = 5;
+   */
+  checkedAt?: Timestamp;
+
+  /**
+   * Whether the latest release is newer than the running version.
+   *
+   * @generated from field: bool update_available = 6;
+   */
+  updateAvailable: boolean;
+};
+
+/**
+ * Describes the message blippy.system.Version.
+ * Use `create(VersionSchema)` to create a new message.
+ */
+export const VersionSchema: GenMessage<Version> = /*@__PURE__*/
+  messageDesc(file_system_system, 31);
+
+/**
+ * @generated from message blippy.system.GetUsageReportRequest
+ */
+export type GetUsageReportRequest = Message<"blippy.system.GetUsageReportRequest"> & {
+  /**
+   * How far back to report, in hours. Defaults to 24.
+   *
+   * @generated from field: int32 period_hours = 1;
+   */
+  periodHours: number;
+};
+
+/**
+ * Describes the message blippy.system.GetUsageReportRequest.
+ * Use `create(GetUsageReportRequestSchema)` to create a new message.
+ */
+export const GetUsageReportRequestSchema: GenMessage<GetUsageReportRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 32);
+
+/**
+ * UsageReport is what each agent did and spent in the runs started in a
+ * period, from the run traces. Costs are estimated with the current
+ * OpenRouter prices.
+ *
+ * @generated from message blippy.system.UsageReport
+ */
+export type UsageReport = Message<"blippy.system.UsageReport"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp since = 1;
+   */
+  since?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp until = 2;
+   */
+  until?: Timestamp;
+
+  /**
+   * @generated from field: repeated blippy.system.AgentUsage agents = 3;
+   */
+  agents: AgentUsage[];
+
+  /**
+   * @generated from field: blippy.system.Usage total = 4;
+   */
+  total?: Usage;
+};
+
+/**
+ * Describes the message blippy.system.UsageReport.
+ * Use `create(UsageReportSchema)` to create a new message.
+ */
+export const UsageReportSchema: GenMessage<UsageReport> = /*@__PURE__*/
+  messageDesc(file_system_system, 33);
+
+/**
+ * @generated from message blippy.system.AgentUsage
+ */
+export type AgentUsage = Message<"blippy.system.AgentUsage"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string agent_name = 2;
+   */
+  agentName: string;
+
+  /**
+   * @generated from field: blippy.system.Usage usage = 3;
+   */
+  usage?: Usage;
+};
+
+/**
+ * Describes the message blippy.system.AgentUsage.
+ * Use `create(AgentUsageSchema)` to create a new message.
+ */
+export const AgentUsageSchema: GenMessage<AgentUsage> = /*@__PURE__*/
+  messageDesc(file_system_system, 34);
+
+/**
+ * @generated from message blippy.system.Usage
+ */
+export type Usage = Message<"blippy.system.Usage"> & {
+  /**
+   * @generated from field: int64 runs = 1;
+   */
+  runs: bigint;
+
+  /**
+   * Runs that failed or timed out.
+   *
+   * @generated from field: int64 failed_runs = 2;
+   */
+  failedRuns: bigint;
+
+  /**
+   * @generated from field: int64 input_tokens = 3;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 4;
+   */
+  outputTokens: bigint;
+
+  /**
+   * @generated from field: double cost_usd = 5;
+   */
+  costUsd: number;
+
+  /**
+   * False if the price of a model wasn't known, in which case its runs
+   * don't count towards the cost.
+   *
+   * @generated from field: bool priced = 6;
+   */
+  priced: boolean;
+};
+
+/**
+ * Describes the message blippy.system.Usage.
+ * Use `create(UsageSchema)` to create a new message.
+ */
+export const UsageSchema: GenMessage<Usage> = /*@__PURE__*/
+  messageDesc(file_system_system, 35);
+
+/**
+ * ProviderKey is an OpenRouter API key of this replica, with its usage
+ * since it was added, e.g. since the server started.
+ *
+ * @generated from message blippy.system.ProviderKey
+ */
+export type ProviderKey = Message<"blippy.system.ProviderKey"> & {
+  /**
+   * A prefix of the key's SHA-256 hash.
+   *
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * The last characters of the key, e.g. "…3f9a".
+   *
+   * @generated from field: string hint = 2;
+   */
+  hint: string;
+
+  /**
+   * @generated from field: int32 weight = 3;
+   */
+  weight: number;
+
+  /**
+   * @generated from field: int64 requests = 4;
+   */
+  requests: bigint;
+
+  /**
+   * Requests that failed or had an unexpected status, including rate
+   * limited ones.
+   *
+   * @generated from field: int64 failures = 5;
+   */
+  failures: bigint;
+
+  /**
+   * @generated from field: int64 rate_limited = 6;
+   */
+  rateLimited: bigint;
+
+  /**
+   * @generated from field: int64 input_tokens = 7;
+   */
+  inputTokens: bigint;
+
+  /**
+   * @generated from field: int64 output_tokens = 8;
+   */
+  outputTokens: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp added_at = 9;
+   */
+  addedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_used_at = 10;
+   */
+  lastUsedAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.system.ProviderKey.
+ * Use `create(ProviderKeySchema)` to create a new message.
+ */
+export const ProviderKeySchema: GenMessage<ProviderKey> = /*@__PURE__*/
+  messageDesc(file_system_system, 36);
+
+/**
+ * @generated from message blippy.system.ListProviderKeysRequest
+ */
+export type ListProviderKeysRequest = Message<"blippy.system.ListProviderKeysRequest"> & {
+};
+
+/**
+ * Describes the message blippy.system.ListProviderKeysRequest.
+ * Use `create(ListProviderKeysRequestSchema)` to create a new message.
+ */
+export const ListProviderKeysRequestSchema: GenMessage<ListProviderKeysRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 37);
+
+/**
+ * @generated from message blippy.system.ListProviderKeysResponse
+ */
+export type ListProviderKeysResponse = Message<"blippy.system.ListProviderKeysResponse"> & {
+  /**
+   * @generated from field: repeated blippy.system.ProviderKey keys = 1;
+   */
+  keys: ProviderKey[];
+};
+
+/**
+ * Describes the message blippy.system.ListProviderKeysResponse.
+ * Use `create(ListProviderKeysResponseSchema)` to create a new message.
+ */
+export const ListProviderKeysResponseSchema: GenMessage<ListProviderKeysResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 38);
+
+/**
+ * @generated from message blippy.system.CreateProviderKeyRequest
+ */
+export type CreateProviderKeyRequest = Message<"blippy.system.CreateProviderKeyRequest"> & {
+  /**
+   * @generated from field: string api_key = 1;
+   */
+  apiKey: string;
+
+  /**
+   * Share of requests relative to the other keys. Defaults to 1.
+   *
+   * @generated from field: int32 weight = 2;
+   */
+  weight: number;
+};
+
+/**
+ * Describes the message blippy.system.CreateProviderKeyRequest.
+ * Use `create(CreateProviderKeyRequestSchema)` to create a new message.
+ */
+export const CreateProviderKeyRequestSchema: GenMessage<CreateProviderKeyRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 39);
+
+/**
+ * @generated from message blippy.system.DeleteProviderKeyRequest
+ */
+export type DeleteProviderKeyRequest = Message<"blippy.system.DeleteProviderKeyRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.system.DeleteProviderKeyRequest.
+ * Use `create(DeleteProviderKeyRequestSchema)` to create a new message.
+ */
+export const DeleteProviderKeyRequestSchema: GenMessage<DeleteProviderKeyRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 40);
+
+/**
+ * @generated from message blippy.system.DeleteProviderKeyResponse
+ */
+export type DeleteProviderKeyResponse = Message<"blippy.system.DeleteProviderKeyResponse"> & {
+};
+
+/**
+ * Describes the message blippy.system.DeleteProviderKeyResponse.
+ * Use `create(DeleteProviderKeyResponseSchema)` to create a new message.
+ */
+export const DeleteProviderKeyResponseSchema: GenMessage<DeleteProviderKeyResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 41);
+
+/**
+ * LLMCapture makes the raw request and response bodies of the LLM calls of
+ * an agent or conversation be stored until it expires, with secrets
+ * redacted.
+ *
+ * @generated from message blippy.system.LLMCapture
+ */
+export type LLMCapture = Message<"blippy.system.LLMCapture"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * Set for captures of all conversations of an agent.
+   *
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string conversation_id = 3;
+   */
+  conversationId: string;
+
+  /**
+   * How long captured exchanges are kept.
+   *
+   * @generated from field: int64 retention_seconds = 4;
+   */
+  retentionSeconds: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 5;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.system.LLMCapture.
+ * Use `create(LLMCaptureSchema)` to create a new message.
+ */
+export const LLMCaptureSchema: GenMessage<LLMCapture> = /*@__PURE__*/
+  messageDesc(file_system_system, 42);
+
+/**
+ * @generated from message blippy.system.CreateLLMCaptureRequest
+ */
+export type CreateLLMCaptureRequest = Message<"blippy.system.CreateLLMCaptureRequest"> & {
+  /**
+   * One of agent_id and conversation_id is required.
+   *
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string conversation_id = 2;
+   */
+  conversationId: string;
+
+  /**
+   * How long to capture. Defaults to an hour, at most a day.
+   *
+   * @generated from field: int64 duration_seconds = 3;
+   */
+  durationSeconds: bigint;
+
+  /**
+   * How long to keep captured exchanges. Defaults to a day, at most a week.
+   *
+   * @generated from field: int64 retention_seconds = 4;
+   */
+  retentionSeconds: bigint;
+};
+
+/**
+ * Describes the message blippy.system.CreateLLMCaptureRequest.
+ * Use `create(CreateLLMCaptureRequestSchema)` to create a new message.
+ */
+export const CreateLLMCaptureRequestSchema: GenMessage<CreateLLMCaptureRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 43);
+
+/**
+ * @generated from message blippy.system.ListLLMCapturesRequest
+ */
+export type ListLLMCapturesRequest = Message<"blippy.system.ListLLMCapturesRequest"> & {
+};
+
+/**
+ * Describes the message blippy.system.ListLLMCapturesRequest.
+ * Use `create(ListLLMCapturesRequestSchema)` to create a new message.
+ */
+export const ListLLMCapturesRequestSchema: GenMessage<ListLLMCapturesRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 44);
+
+/**
+ * @generated from message blippy.system.ListLLMCapturesResponse
+ */
+export type ListLLMCapturesResponse = Message<"blippy.system.ListLLMCapturesResponse"> & {
+  /**
+   * @generated from field: repeated blippy.system.LLMCapture captures = 1;
+   */
+  captures: LLMCapture[];
+};
+
+/**
+ * Describes the message blippy.system.ListLLMCapturesResponse.
+ * Use `create(ListLLMCapturesResponseSchema)` to create a new message.
+ */
+export const ListLLMCapturesResponseSchema: GenMessage<ListLLMCapturesResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 45);
+
+/**
+ * @generated from message blippy.system.DeleteLLMCaptureRequest
+ */
+export type DeleteLLMCaptureRequest = Message<"blippy.system.DeleteLLMCaptureRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.system.DeleteLLMCaptureRequest.
+ * Use `create(DeleteLLMCaptureRequestSchema)` to create a new message.
+ */
+export const DeleteLLMCaptureRequestSchema: GenMessage<DeleteLLMCaptureRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 46);
+
+/**
+ * @generated from message blippy.system.DeleteLLMCaptureResponse
+ */
+export type DeleteLLMCaptureResponse = Message<"blippy.system.DeleteLLMCaptureResponse"> & {
+};
+
+/**
+ * Describes the message blippy.system.DeleteLLMCaptureResponse.
+ * Use `create(DeleteLLMCaptureResponseSchema)` to create a new message.
+ */
+export const DeleteLLMCaptureResponseSchema: GenMessage<DeleteLLMCaptureResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 47);
+
+/**
+ * LLMExchange is a captured LLM call. Bodies are cut off at 1 MiB.
+ *
+ * @generated from message blippy.system.LLMExchange
+ */
+export type LLMExchange = Message<"blippy.system.LLMExchange"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string capture_id = 2;
+   */
+  captureId: string;
+
+  /**
+   * @generated from field: string run_id = 3;
+   */
+  runId: string;
+
+  /**
+   * @generated from field: string conversation_id = 4;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string agent_id = 5;
+   */
+  agentId: string;
+
+  /**
+   * The LLM round-trip of the run, starting at 1.
+   *
+   * @generated from field: int32 round = 6;
+   */
+  round: number;
+
+  /**
+   * @generated from field: string url = 7;
+   */
+  url: string;
+
+  /**
+   * ID of the OpenRouter API key the request was made with.
+   *
+   * @generated from field: string key_id = 8;
+   */
+  keyId: string;
+
+  /**
+   * @generated from field: string request_body = 9;
+   */
+  requestBody: string;
+
+  /**
+   * 0 if no response was received.
+   *
+   * @generated from field: int32 status_code = 10;
+   */
+  statusCode: number;
+
+  /**
+   * @generated from field: map<string, string> response_headers = 11;
+   */
+  responseHeaders: { [key: string]: string };
+
+  /**
+   * The response as received, i.e. the event stream of streaming requests.
+   *
+   * @generated from field: string response_body = 12;
+   */
+  responseBody: string;
+
+  /**
+   * @generated from field: bool truncated = 13;
+   */
+  truncated: boolean;
+
+  /**
+   * @generated from field: string error = 14;
+   */
+  error: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 15;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.system.LLMExchange.
+ * Use `create(LLMExchangeSchema)` to create a new message.
+ */
+export const LLMExchangeSchema: GenMessage<LLMExchange> = /*@__PURE__*/
+  messageDesc(file_system_system, 48);
+
+/**
+ * @generated from message blippy.system.ListLLMExchangesRequest
+ */
+export type ListLLMExchangesRequest = Message<"blippy.system.ListLLMExchangesRequest"> & {
+  /**
+   * One of conversation_id and run_id is required.
+   *
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: string run_id = 2;
+   */
+  runId: string;
+
+  /**
+   * @generated from field: int32 page_size = 3;
+   */
+  pageSize: number;
+
+  /**
+   * @generated from field: string page_token = 4;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message blippy.system.ListLLMExchangesRequest.
+ * Use `create(ListLLMExchangesRequestSchema)` to create a new message.
+ */
+export const ListLLMExchangesRequestSchema: GenMessage<ListLLMExchangesRequest> = /*@__PURE__*/
+  messageDesc(file_system_system, 49);
+
+/**
+ * @generated from message blippy.system.ListLLMExchangesResponse
+ */
+export type ListLLMExchangesResponse = Message<"blippy.system.ListLLMExchangesResponse"> & {
+  /**
+   * @generated from field: repeated blippy.system.LLMExchange exchanges = 1;
+   */
+  exchanges: LLMExchange[];
+
+  /**
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
+};
+
+/**
+ * Describes the message blippy.system.ListLLMExchangesResponse.
+ * Use `create(ListLLMExchangesResponseSchema)` to create a new message.
+ */
+export const ListLLMExchangesResponseSchema: GenMessage<ListLLMExchangesResponse> = /*@__PURE__*/
+  messageDesc(file_system_system, 50);
+
+/**
+ * @generated from service blippy.system.SystemService
+ */
+export const SystemService: GenService<{
+  /**
+   * @generated from rpc blippy.system.SystemService.GetSystemStats
+   */
+  getSystemStats: {
+    methodKind: "unary";
+    input: typeof GetSystemStatsRequestSchema;
+    output: typeof SystemStatsSchema;
+  },
+  /**
+   * @generated from rpc blippy.system.SystemService.GetMaintenanceMode
+   */
+  getMaintenanceMode: {
+    methodKind: "unary";
+    input: typeof GetMaintenanceModeRequestSchema;
+    output: typeof MaintenanceModeSchema;
+  },
+  /**
+   * Admin only. Enabling pauses the scheduler and rejects new chat messages,
+   * webhook runs and notification replies; turns in progress continue.
+   *
+   * @generated from rpc blippy.system.SystemService.UpdateMaintenanceMode
+   */
+  updateMaintenanceMode: {
+    methodKind: "unary";
+    input: typeof UpdateMaintenanceModeRequestSchema;
+    output: typeof MaintenanceModeSchema;
+  },
+  /**
+   * @generated from rpc blippy.system.SystemService.GetInstancePreamble
+   */
+  getInstancePreamble: {
+    methodKind: "unary";
+    input: typeof GetInstancePreambleRequestSchema;
+    output: typeof InstancePreambleSchema;
+  },
+  /**
+   * Admin only.
+   *
+   * @generated from rpc blippy.system.SystemService.UpdateInstancePreamble
+   */
+  updateInstancePreamble: {
+    methodKind: "unary";
+    input: typeof UpdateInstancePreambleRequestSchema;
+    output: typeof InstancePreambleSchema;
+  },
+  /**
+   * Admin only.
+   *
+   * @generated from rpc blippy.system.SystemService.GetBrokerStats
+   */
+  getBrokerStats: {
+    methodKind: "unary";
+    input: typeof GetBrokerStatsRequestSchema;
+    output: typeof BrokerStatsSchema;
+  },
+  /**
+   * Runs of other replicas aren't listed.
+   *
+   * @generated from rpc blippy.system.SystemService.ListActiveRuns
+   */
+  listActiveRuns: {
+    methodKind: "unary";
+    input: typeof ListActiveRunsRequestSchema;
+    output: typeof ListActiveRunsResponseSchema;
+  },
+  /**
+   * Stops a run, storing its output so far, and the runs of agents it
+   * called. Returns NotFound for runs that aren't active on this replica.
+   *
+   * @generated from rpc blippy.system.SystemService.CancelRun
+   */
+  cancelRun: {
+    methodKind: "unary";
+    input: typeof CancelRunRequestSchema;
+    output: typeof CancelRunResponseSchema;
+  },
+  /**
+   * Tool calls of runs on this replica waiting for approval.
+   *
+   * @generated from rpc blippy.system.SystemService.ListPendingApprovals
+   */
+  listPendingApprovals: {
+    methodKind: "unary";
+    input: typeof ListPendingApprovalsRequestSchema;
+    output: typeof ListPendingApprovalsResponseSchema;
+  },
+  /**
+   * Approves or denies a tool call waiting for approval. Returns NotFound
+   * for calls that aren't waiting on this replica.
+   *
+   * @generated from rpc blippy.system.SystemService.ResolveApproval
+   */
+  resolveApproval: {
+    methodKind: "unary";
+    input: typeof ResolveApprovalRequestSchema;
+    output: typeof ResolveApprovalResponseSchema;
+  },
+  /**
+   * Admin only. Runs a turn recorded with RECORD_TURNS again in a new
+   * conversation, with the recorded LLM responses and tool results, to
+   * reproduce bugs without calling the LLM or executing tools.
+   *
+   * @generated from rpc blippy.system.SystemService.ReplayTurn
+   */
+  replayTurn: {
+    methodKind: "unary";
+    input: typeof ReplayTurnRequestSchema;
+    output: typeof ReplayTurnResponseSchema;
+  },
+  /**
+   * Returns the execution trace of a finished run: each LLM round-trip's
+   * request size, latency and token counts, and the tool calls it made.
+   *
+   * @generated from rpc blippy.system.SystemService.GetRunTrace
+   */
+  getRunTrace: {
+    methodKind: "unary";
+    input: typeof GetRunTraceRequestSchema;
+    output: typeof RunTraceSchema;
+  },
+  /**
+   * Admin only. Exports the agents, triggers, notification channels and
+   * filesystem roots, for `blippy export` against a remote server.
+   *
+   * @generated from rpc blippy.system.SystemService.ExportManifest
+   */
+  exportManifest: {
+    methodKind: "unary";
+    input: typeof ExportManifestRequestSchema;
+    output: typeof ExportManifestResponseSchema;
+  },
+  /**
+   * Returns the running version and, if the update check is enabled,
+   * whether a newer release is available.
+   *
+   * @generated from rpc blippy.system.SystemService.GetVersion
+   */
+  getVersion: {
+    methodKind: "unary";
+    input: typeof GetVersionRequestSchema;
+    output: typeof VersionSchema;
+  },
+  /**
+   * Returns the runs, failures, token usage and cost of each agent over the
+   * last 24 hours, or the requested period. Agents can get the same report
+   * with the get_usage_report tool.
+   *
+   * @generated from rpc blippy.system.SystemService.GetUsageReport
+   */
+  getUsageReport: {
+    methodKind: "unary";
+    input: typeof GetUsageReportRequestSchema;
+    output: typeof UsageReportSchema;
+  },
+  /**
+   * Admin only. Lists the OpenRouter API keys requests are spread over,
+   * with their usage.
+   *
+   * @generated from rpc blippy.system.SystemService.ListProviderKeys
+   */
+  listProviderKeys: {
+    methodKind: "unary";
+    input: typeof ListProviderKeysRequestSchema;
+    output: typeof ListProviderKeysResponseSchema;
+  },
+  /**
+   * Admin only. Adds an OpenRouter API key after checking it with
+   * OpenRouter, or updates the weight of a key that was already added. Keys
+   * are kept in memory on this replica; update OPENROUTER_API_KEY to keep
+   * them after a restart.
+   *
+   * @generated from rpc blippy.system.SystemService.CreateProviderKey
+   */
+  createProviderKey: {
+    methodKind: "unary";
+    input: typeof CreateProviderKeyRequestSchema;
+    output: typeof ProviderKeySchema;
+  },
+  /**
+   * Admin only. Stops using an OpenRouter API key, e.g. after it leaked.
+   * The last key can't be removed.
+   *
+   * @generated from rpc blippy.system.SystemService.DeleteProviderKey
+   */
+  deleteProviderKey: {
+    methodKind: "unary";
+    input: typeof DeleteProviderKeyRequestSchema;
+    output: typeof DeleteProviderKeyResponseSchema;
+  },
+  /**
+   * Admin only. Starts capturing the raw LLM calls of an agent or
+   * conversation, to debug malformed tool schemas and provider quirks.
+   *
+   * @generated from rpc blippy.system.SystemService.CreateLLMCapture
+   */
+  createLLMCapture: {
+    methodKind: "unary";
+    input: typeof CreateLLMCaptureRequestSchema;
+    output: typeof LLMCaptureSchema;
+  },
+  /**
+   * Admin only. Lists the captures that haven't expired.
+   *
+   * @generated from rpc blippy.system.SystemService.ListLLMCaptures
+   */
+  listLLMCaptures: {
+    methodKind: "unary";
+    input: typeof ListLLMCapturesRequestSchema;
+    output: typeof ListLLMCapturesResponseSchema;
+  },
+  /**
+   * Admin only. Stops a capture. Exchanges captured so far are kept.
+   *
+   * @generated from rpc blippy.system.SystemService.DeleteLLMCapture
+   */
+  deleteLLMCapture: {
+    methodKind: "unary";
+    input: typeof DeleteLLMCaptureRequestSchema;
+    output: typeof DeleteLLMCaptureResponseSchema;
+  },
+  /**
+   * Admin only. Lists the captured LLM calls of a conversation or run.
+   *
+   * @generated from rpc blippy.system.SystemService.ListLLMExchanges
+   */
+  listLLMExchanges: {
+    methodKind: "unary";
+    input: typeof ListLLMExchangesRequestSchema;
+    output: typeof ListLLMExchangesResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_system_system, 0);
+
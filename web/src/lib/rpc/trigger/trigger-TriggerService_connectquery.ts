@@ -9,6 +9,15 @@ import { TriggerService } from "./trigger_pb";
  */
 export const createTrigger = TriggerService.method.createTrigger;
 
+/**
+ * Creates a disabled trigger for the conversation's agent, with a name,
+ * prompt and schedule drafted by an LLM from the conversation, to review
+ * before enabling it.
+ *
+ * @generated from rpc blippy.trigger.TriggerService.CreateTriggerFromConversation
+ */
+export const createTriggerFromConversation = TriggerService.method.createTriggerFromConversation;
+
 /**
  * @generated from rpc blippy.trigger.TriggerService.GetTrigger
  */
@@ -28,3 +37,53 @@ export const updateTrigger = TriggerService.method.updateTrigger;
  * @generated from rpc blippy.trigger.TriggerService.DeleteTrigger
  */
 export const deleteTrigger = TriggerService.method.deleteTrigger;
+
+/**
+ * Enables, disables or moves many triggers in a single transaction.
+ *
+ * @generated from rpc blippy.trigger.TriggerService.BatchUpdateTriggers
+ */
+export const batchUpdateTriggers = TriggerService.method.batchUpdateTriggers;
+
+/**
+ * Runs a trigger now, in the background, without changing its schedule.
+ *
+ * @generated from rpc blippy.trigger.TriggerService.RunTrigger
+ */
+export const runTrigger = TriggerService.method.runTrigger;
+
+/**
+ * Creates the webhook of a trigger, or replaces it with one with a new
+ * token.
+ *
+ * @generated from rpc blippy.trigger.TriggerService.CreateTriggerWebhook
+ */
+export const createTriggerWebhook = TriggerService.method.createTriggerWebhook;
+
+/**
+ * @generated from rpc blippy.trigger.TriggerService.GetTriggerWebhook
+ */
+export const getTriggerWebhook = TriggerService.method.getTriggerWebhook;
+
+/**
+ * @generated from rpc blippy.trigger.TriggerService.UpdateTriggerWebhook
+ */
+export const updateTriggerWebhook = TriggerService.method.updateTriggerWebhook;
+
+/**
+ * @generated from rpc blippy.trigger.TriggerService.DeleteTriggerWebhook
+ */
+export const deleteTriggerWebhook = TriggerService.method.deleteTriggerWebhook;
+
+/**
+ * Lists the runs of triggers, most recent first. Runs are kept for
+ * TRIGGER_RUN_RETENTION.
+ *
+ * @generated from rpc blippy.trigger.TriggerService.ListTriggerRuns
+ */
+export const listTriggerRuns = TriggerService.method.listTriggerRuns;
+
+/**
+ * @generated from rpc blippy.trigger.TriggerService.GetTriggerRun
+ */
+export const getTriggerRun = TriggerService.method.getTriggerRun;
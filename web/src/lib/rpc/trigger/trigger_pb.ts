@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file trigger/trigger.proto.
  */
 export const file_trigger_trigger: GenFile = /*@__PURE__*/
-  fileDesc("ChV0cmlnZ2VyL3RyaWdnZXIucHJvdG8SDmJsaXBweS50cmlnZ2VyIvoBCgdUcmlnZ2VyEgoKAmlkGAEgASgJEhAKCGFnZW50X2lkGAIgASgJEgwKBG5hbWUYAyABKAkSDgoGcHJvbXB0GAQgASgJEhEKCWNyb25fZXhwchgFIAEoCRIPCgdlbmFibGVkGAYgASgIEi8KC25leHRfcnVuX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgpjcmVhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJoChRDcmVhdGVUcmlnZ2VyUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCRIMCgRuYW1lGAIgASgJEg4KBnByb21wdBgDIAEoCRIRCgljcm9uX2V4cHIYBCABKAkSDQoFZGVsYXkYBSABKAkiHwoRR2V0VHJpZ2dlclJlcXVlc3QSCgoCaWQYASABKAkiJwoTTGlzdFRyaWdnZXJzUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSJBChRMaXN0VHJpZ2dlcnNSZXNwb25zZRIpCgh0cmlnZ2VycxgBIAMoCzIXLmJsaXBweS50cmlnZ2VyLlRyaWdnZXIiZAoUVXBkYXRlVHJpZ2dlclJlcXVlc3QSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIOCgZwcm9tcHQYAyABKAkSEQoJY3Jvbl9leHByGAQgASgJEg8KB2VuYWJsZWQYBSABKAgiIgoURGVsZXRlVHJpZ2dlclJlcXVlc3QSCgoCaWQYASABKAkiBwoFRW1wdHkyowMKDlRyaWdnZXJTZXJ2aWNlEk4KDUNyZWF0ZVRyaWdnZXISJC5ibGlwcHkudHJpZ2dlci5DcmVhdGVUcmlnZ2VyUmVxdWVzdBoXLmJsaXBweS50cmlnZ2VyLlRyaWdnZXISSAoKR2V0VHJpZ2dlchIhLmJsaXBweS50cmlnZ2VyLkdldFRyaWdnZXJSZXF1ZXN0GhcuYmxpcHB5LnRyaWdnZXIuVHJpZ2dlchJZCgxMaXN0VHJpZ2dlcnMSIy5ibGlwcHkudHJpZ2dlci5MaXN0VHJpZ2dlcnNSZXF1ZXN0GiQuYmxpcHB5LnRyaWdnZXIuTGlzdFRyaWdnZXJzUmVzcG9uc2USTgoNVXBkYXRlVHJpZ2dlchIkLmJsaXBweS50cmlnZ2VyLlVwZGF0ZVRyaWdnZXJSZXF1ZXN0GhcuYmxpcHB5LnRyaWdnZXIuVHJpZ2dlchJMCg1EZWxldGVUcmlnZ2VyEiQuYmxpcHB5LnRyaWdnZXIuRGVsZXRlVHJpZ2dlclJlcXVlc3QaFS5ibGlwcHkudHJpZ2dlci5FbXB0eUItWitnaXRodWIuY29tL2RzdG90aWpuL2JsaXBweS9pbnRlcm5hbC90cmlnZ2VyYgZwcm90bzM", [file_google_protobuf_timestamp]);
+  fileDesc("ChV0cmlnZ2VyL3RyaWdnZXIucHJvdG8SDmJsaXBweS50cmlnZ2VyIuECCgdUcmlnZ2VyEgoKAmlkGAEgASgJEhAKCGFnZW50X2lkGAIgASgJEgwKBG5hbWUYAyABKAkSDgoGcHJvbXB0GAQgASgJEhEKCWNyb25fZXhwchgFIAEoCRIPCgdlbmFibGVkGAYgASgIEi8KC25leHRfcnVuX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgpjcmVhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIPCgd2ZXJzaW9uGAogASgDEhwKFG1heF9kdXJhdGlvbl9zZWNvbmRzGAsgASgFEhAKCHByaW9yaXR5GAwgASgFEiQKBHZhcnMYDSADKAsyFi5ibGlwcHkudHJpZ2dlci5SdW5WYXIiJQoGUnVuVmFyEgwKBG5hbWUYASABKAkSDQoFdmFsdWUYAiABKAkivgEKFENyZWF0ZVRyaWdnZXJSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEgwKBG5hbWUYAiABKAkSDgoGcHJvbXB0GAMgASgJEhEKCWNyb25fZXhwchgEIAEoCRINCgVkZWxheRgFIAEoCRIcChRtYXhfZHVyYXRpb25fc2Vjb25kcxgGIAEoBRIQCghwcmlvcml0eRgHIAEoBRIkCgR2YXJzGAggAygLMhYuYmxpcHB5LnRyaWdnZXIuUnVuVmFyIlIKJENyZWF0ZVRyaWdnZXJGcm9tQ29udmVyc2F0aW9uUmVxdWVzdBIXCg9jb252ZXJzYXRpb25faWQYASABKAkSEQoJY3Jvbl9leHByGAIgASgJIh8KEUdldFRyaWdnZXJSZXF1ZXN0EgoKAmlkGAEgASgJInAKE0xpc3RUcmlnZ2Vyc1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkSEQoJcGFnZV9zaXplGAIgASgFEhIKCnBhZ2VfdG9rZW4YAyABKAkSEAoIb3JkZXJfYnkYBCABKAkSDgoGZmlsdGVyGAUgASgJIm4KFExpc3RUcmlnZ2Vyc1Jlc3BvbnNlEikKCHRyaWdnZXJzGAEgAygLMhcuYmxpcHB5LnRyaWdnZXIuVHJpZ2dlchIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkSEgoKdG90YWxfc2l6ZRgDIAEoBSLLAQoUVXBkYXRlVHJpZ2dlclJlcXVlc3QSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIOCgZwcm9tcHQYAyABKAkSEQoJY3Jvbl9leHByGAQgASgJEg8KB2VuYWJsZWQYBSABKAgSDwoHdmVyc2lvbhgGIAEoAxIcChRtYXhfZHVyYXRpb25fc2Vjb25kcxgHIAEoBRIQCghwcmlvcml0eRgIIAEoBRIkCgR2YXJzGAkgAygLMhYuYmxpcHB5LnRyaWdnZXIuUnVuVmFyIiIKFERlbGV0ZVRyaWdnZXJSZXF1ZXN0EgoKAmlkGAEgASgJIloKGkJhdGNoVXBkYXRlVHJpZ2dlcnNSZXF1ZXN0EgsKA2lkcxgBIAMoCRIRCgdlbmFibGVkGAIgASgISAASEgoIYWdlbnRfaWQYAyABKAlIAEIICgZ1cGRhdGUiSAobQmF0Y2hVcGRhdGVUcmlnZ2Vyc1Jlc3BvbnNlEikKCHRyaWdnZXJzGAEgAygLMhcuYmxpcHB5LnRyaWdnZXIuVHJpZ2dlciIfChFSdW5UcmlnZ2VyUmVxdWVzdBIKCgJpZBgBIAEoCSIsChJSdW5UcmlnZ2VyUmVzcG9uc2USFgoOdHJpZ2dlcl9ydW5faWQYASABKAki4gEKClRyaWdnZXJSdW4SCgoCaWQYASABKAkSEgoKdHJpZ2dlcl9pZBgCIAEoCRIOCgZzdGF0dXMYAyABKAkSFQoNZXJyb3JfbWVzc2FnZRgEIAEoCRIXCg9jb252ZXJzYXRpb25faWQYBSABKAkSLgoKc3RhcnRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoLZmluaXNoZWRfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhMKC2R1cmF0aW9uX21zGAggASgDInUKFkxpc3RUcmlnZ2VyUnVuc1JlcXVlc3QSEgoKdHJpZ2dlcl9pZBgBIAEoCRIRCglwYWdlX3NpemUYAiABKAUSEgoKcGFnZV90b2tlbhgDIAEoCRIQCghvcmRlcl9ieRgEIAEoCRIOCgZmaWx0ZXIYBSABKAkieAoXTGlzdFRyaWdnZXJSdW5zUmVzcG9uc2USMAoMdHJpZ2dlcl9ydW5zGAEgAygLMhouYmxpcHB5LnRyaWdnZXIuVHJpZ2dlclJ1bhIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkSEgoKdG90YWxfc2l6ZRgDIAEoBSIiChRHZXRUcmlnZ2VyUnVuUmVxdWVzdBIKCgJpZBgBIAEoCSLVAQoOVHJpZ2dlcldlYmhvb2sSEgoKdHJpZ2dlcl9pZBgBIAEoCRIMCgRwYXRoGAIgASgJEhIKCmhhc19zZWNyZXQYAyABKAgSGAoQc2lnbmF0dXJlX3NjaGVtZRgEIAEoCRITCgthbGxvd2VkX2lwcxgFIAMoCRIuCgpjcmVhdGVkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJwChtDcmVhdGVUcmlnZ2VyV2ViaG9va1JlcXVlc3QSEgoKdHJpZ2dlcl9pZBgBIAEoCRIOCgZzZWNyZXQYAiABKAkSGAoQc2lnbmF0dXJlX3NjaGVtZRgDIAEoCRITCgthbGxvd2VkX2lwcxgEIAMoCSIuChhHZXRUcmlnZ2VyV2ViaG9va1JlcXVlc3QSEgoKdHJpZ2dlcl9pZBgBIAEoCSKGAQobVXBkYXRlVHJpZ2dlcldlYmhvb2tSZXF1ZXN0EhIKCnRyaWdnZXJfaWQYASABKAkSDgoGc2VjcmV0GAIgASgJEhQKDGNsZWFyX3NlY3JldBgDIAEoCBIYChBzaWduYXR1cmVfc2NoZW1lGAQgASgJEhMKC2FsbG93ZWRfaXBzGAUgAygJIjEKG0RlbGV0ZVRyaWdnZXJXZWJob29rUmVxdWVzdBISCgp0cmlnZ2VyX2lkGAEgASgJIgcKBUVtcHR5MpQKCg5UcmlnZ2VyU2VydmljZRJOCg1DcmVhdGVUcmlnZ2VyEiQuYmxpcHB5LnRyaWdnZXIuQ3JlYXRlVHJpZ2dlclJlcXVlc3QaFy5ibGlwcHkudHJpZ2dlci5UcmlnZ2VyEm4KHUNyZWF0ZVRyaWdnZXJGcm9tQ29udmVyc2F0aW9uEjQuYmxpcHB5LnRyaWdnZXIuQ3JlYXRlVHJpZ2dlckZyb21Db252ZXJzYXRpb25SZXF1ZXN0GhcuYmxpcHB5LnRyaWdnZXIuVHJpZ2dlchJICgpHZXRUcmlnZ2VyEiEuYmxpcHB5LnRyaWdnZXIuR2V0VHJpZ2dlclJlcXVlc3QaFy5ibGlwcHkudHJpZ2dlci5UcmlnZ2VyElkKDExpc3RUcmlnZ2VycxIjLmJsaXBweS50cmlnZ2VyLkxpc3RUcmlnZ2Vyc1JlcXVlc3QaJC5ibGlwcHkudHJpZ2dlci5MaXN0VHJpZ2dlcnNSZXNwb25zZRJOCg1VcGRhdGVUcmlnZ2VyEiQuYmxpcHB5LnRyaWdnZXIuVXBkYXRlVHJpZ2dlclJlcXVlc3QaFy5ibGlwcHkudHJpZ2dlci5UcmlnZ2VyEkwKDURlbGV0ZVRyaWdnZXISJC5ibGlwcHkudHJpZ2dlci5EZWxldGVUcmlnZ2VyUmVxdWVzdBoVLmJsaXBweS50cmlnZ2VyLkVtcHR5Em4KE0JhdGNoVXBkYXRlVHJpZ2dlcnMSKi5ibGlwcHkudHJpZ2dlci5CYXRjaFVwZGF0ZVRyaWdnZXJzUmVxdWVzdBorLmJsaXBweS50cmlnZ2VyLkJhdGNoVXBkYXRlVHJpZ2dlcnNSZXNwb25zZRJTCgpSdW5UcmlnZ2VyEiEuYmxpcHB5LnRyaWdnZXIuUnVuVHJpZ2dlclJlcXVlc3QaIi5ibGlwcHkudHJpZ2dlci5SdW5UcmlnZ2VyUmVzcG9uc2USYwoUQ3JlYXRlVHJpZ2dlcldlYmhvb2sSKy5ibGlwcHkudHJpZ2dlci5DcmVhdGVUcmlnZ2VyV2ViaG9va1JlcXVlc3QaHi5ibGlwcHkudHJpZ2dlci5UcmlnZ2VyV2ViaG9vaxJdChFHZXRUcmlnZ2VyV2ViaG9vaxIoLmJsaXBweS50cmlnZ2VyLkdldFRyaWdnZXJXZWJob29rUmVxdWVzdBoeLmJsaXBweS50cmlnZ2VyLlRyaWdnZXJXZWJob29rEmMKFFVwZGF0ZVRyaWdnZXJXZWJob29rEisuYmxpcHB5LnRyaWdnZXIuVXBkYXRlVHJpZ2dlcldlYmhvb2tSZXF1ZXN0Gh4uYmxpcHB5LnRyaWdnZXIuVHJpZ2dlcldlYmhvb2sSWgoURGVsZXRlVHJpZ2dlcldlYmhvb2sSKy5ibGlwcHkudHJpZ2dlci5EZWxldGVUcmlnZ2VyV2ViaG9va1JlcXVlc3QaFS5ibGlwcHkudHJpZ2dlci5FbXB0eRJiCg9MaXN0VHJpZ2dlclJ1bnMSJi5ibGlwcHkudHJpZ2dlci5MaXN0VHJpZ2dlclJ1bnNSZXF1ZXN0GicuYmxpcHB5LnRyaWdnZXIuTGlzdFRyaWdnZXJSdW5zUmVzcG9uc2USUQoNR2V0VHJpZ2dlclJ1bhIkLmJsaXBweS50cmlnZ2VyLkdldFRyaWdnZXJSdW5SZXF1ZXN0GhouYmxpcHB5LnRyaWdnZXIuVHJpZ2dlclJ1bkItWitnaXRodWIuY29tL2RzdG90aWpuL2JsaXBweS9pbnRlcm5hbC90cmlnZ2VyYgZwcm90bzM", [file_google_protobuf_timestamp]);
 
 /**
  * @generated from message blippy.trigger.Trigger
@@ -66,6 +66,37 @@ export type Trigger = Message<"blippy.trigger.Trigger"> & {
    * @generated from field: google.protobuf.Timestamp updated_at = 9;
    */
   updatedAt?: Timestamp;
+
+  /**
+   * Incremented on every update
+   *
+   * @generated from field: int64 version = 10;
+   */
+  version: bigint;
+
+  /**
+   * Runs taking longer are stopped and marked "timed_out". 0 uses the
+   * server's MAX_RUN_DURATION.
+   *
+   * @generated from field: int32 max_duration_seconds = 11;
+   */
+  maxDurationSeconds: number;
+
+  /**
+   * Priority of the trigger's runs while waiting for a slot of the agent's
+   * concurrency limit, from 1 to 100; higher starts first. 0 uses the default
+   * of scheduled runs (10), below webhooks (20) and chats (30).
+   *
+   * @generated from field: int32 priority = 12;
+   */
+  priority: number;
+
+  /**
+   * Variables of the trigger's runs, sorted by name.
+   *
+   * @generated from field: repeated blippy.trigger.RunVar vars = 13;
+   */
+  vars: RunVar[];
 };
 
 /**
@@ -75,6 +106,32 @@ export type Trigger = Message<"blippy.trigger.Trigger"> & {
 export const TriggerSchema: GenMessage<Trigger> = /*@__PURE__*/
   messageDesc(file_trigger_trigger, 0);
 
+/**
+ * RunVar is a variable of a run, such as a branch name, set as an
+ * environment variable of the agent's bash commands and replacing "{{NAME}}"
+ * placeholders in the prompt. Names are env var names.
+ *
+ * @generated from message blippy.trigger.RunVar
+ */
+export type RunVar = Message<"blippy.trigger.RunVar"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string value = 2;
+   */
+  value: string;
+};
+
+/**
+ * Describes the message blippy.trigger.RunVar.
+ * Use `create(RunVarSchema)` to create a new message.
+ */
+export const RunVarSchema: GenMessage<RunVar> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 1);
+
 /**
  * @generated from message blippy.trigger.CreateTriggerRequest
  */
@@ -107,6 +164,27 @@ export type CreateTriggerRequest = Message<"blippy.trigger.CreateTriggerRequest"
    * @generated from field: string delay = 5;
    */
   delay: string;
+
+  /**
+   * optional, 0 uses the server default
+   *
+   * @generated from field: int32 max_duration_seconds = 6;
+   */
+  maxDurationSeconds: number;
+
+  /**
+   * optional, 0 uses the default of scheduled runs
+   *
+   * @generated from field: int32 priority = 7;
+   */
+  priority: number;
+
+  /**
+   * optional
+   *
+   * @generated from field: repeated blippy.trigger.RunVar vars = 8;
+   */
+  vars: RunVar[];
 };
 
 /**
@@ -114,7 +192,32 @@ export type CreateTriggerRequest = Message<"blippy.trigger.CreateTriggerRequest"
  * Use `create(CreateTriggerRequestSchema)` to create a new message.
  */
 export const CreateTriggerRequestSchema: GenMessage<CreateTriggerRequest> = /*@__PURE__*/
-  messageDesc(file_trigger_trigger, 1);
+  messageDesc(file_trigger_trigger, 2);
+
+/**
+ * @generated from message blippy.trigger.CreateTriggerFromConversationRequest
+ */
+export type CreateTriggerFromConversationRequest = Message<"blippy.trigger.CreateTriggerFromConversationRequest"> & {
+  /**
+   * @generated from field: string conversation_id = 1;
+   */
+  conversationId: string;
+
+  /**
+   * Optional schedule of the trigger, instead of the one proposed from the
+   * conversation.
+   *
+   * @generated from field: string cron_expr = 2;
+   */
+  cronExpr: string;
+};
+
+/**
+ * Describes the message blippy.trigger.CreateTriggerFromConversationRequest.
+ * Use `create(CreateTriggerFromConversationRequestSchema)` to create a new message.
+ */
+export const CreateTriggerFromConversationRequestSchema: GenMessage<CreateTriggerFromConversationRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 3);
 
 /**
  * @generated from message blippy.trigger.GetTriggerRequest
@@ -131,7 +234,7 @@ export type GetTriggerRequest = Message<"blippy.trigger.GetTriggerRequest"> & {
  * Use `create(GetTriggerRequestSchema)` to create a new message.
  */
 export const GetTriggerRequestSchema: GenMessage<GetTriggerRequest> = /*@__PURE__*/
-  messageDesc(file_trigger_trigger, 2);
+  messageDesc(file_trigger_trigger, 4);
 
 /**
  * @generated from message blippy.trigger.ListTriggersRequest
@@ -143,6 +246,34 @@ export type ListTriggersRequest = Message<"blippy.trigger.ListTriggersRequest">
    * @generated from field: string agent_id = 1;
    */
   agentId: string;
+
+  /**
+   * Maximum number of results to return. 0 returns all results.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize: number;
+
+  /**
+   * Token from a previous response's next_page_token.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken: string;
+
+  /**
+   * Comma-separated fields, each optionally followed by "asc" or "desc".
+   *
+   * @generated from field: string order_by = 4;
+   */
+  orderBy: string;
+
+  /**
+   * Terms joined by "AND", e.g. `name:deploy AND created_at>=2025-01-01`.
+   *
+   * @generated from field: string filter = 5;
+   */
+  filter: string;
 };
 
 /**
@@ -150,7 +281,7 @@ export type ListTriggersRequest = Message<"blippy.trigger.ListTriggersRequest">
  * Use `create(ListTriggersRequestSchema)` to create a new message.
  */
 export const ListTriggersRequestSchema: GenMessage<ListTriggersRequest> = /*@__PURE__*/
-  messageDesc(file_trigger_trigger, 3);
+  messageDesc(file_trigger_trigger, 5);
 
 /**
  * @generated from message blippy.trigger.ListTriggersResponse
@@ -160,6 +291,20 @@ export type ListTriggersResponse = Message<"blippy.trigger.ListTriggersResponse"
    * @generated from field: repeated blippy.trigger.Trigger triggers = 1;
    */
   triggers: Trigger[];
+
+  /**
+   * Token for the next page; empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Number of results matching the filter.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
 };
 
 /**
@@ -167,7 +312,7 @@ export type ListTriggersResponse = Message<"blippy.trigger.ListTriggersResponse"
  * Use `create(ListTriggersResponseSchema)` to create a new message.
  */
 export const ListTriggersResponseSchema: GenMessage<ListTriggersResponse> = /*@__PURE__*/
-  messageDesc(file_trigger_trigger, 4);
+  messageDesc(file_trigger_trigger, 6);
 
 /**
  * @generated from message blippy.trigger.UpdateTriggerRequest
@@ -197,6 +342,32 @@ export type UpdateTriggerRequest = Message<"blippy.trigger.UpdateTriggerRequest"
    * @generated from field: bool enabled = 5;
    */
   enabled: boolean;
+
+  /**
+   * Version the update is based on; fails with ABORTED if stale
+   *
+   * @generated from field: int64 version = 6;
+   */
+  version: bigint;
+
+  /**
+   * 0 uses the server default
+   *
+   * @generated from field: int32 max_duration_seconds = 7;
+   */
+  maxDurationSeconds: number;
+
+  /**
+   * 0 uses the default of scheduled runs
+   *
+   * @generated from field: int32 priority = 8;
+   */
+  priority: number;
+
+  /**
+   * @generated from field: repeated blippy.trigger.RunVar vars = 9;
+   */
+  vars: RunVar[];
 };
 
 /**
@@ -204,7 +375,7 @@ export type UpdateTriggerRequest = Message<"blippy.trigger.UpdateTriggerRequest"
  * Use `create(UpdateTriggerRequestSchema)` to create a new message.
  */
 export const UpdateTriggerRequestSchema: GenMessage<UpdateTriggerRequest> = /*@__PURE__*/
-  messageDesc(file_trigger_trigger, 5);
+  messageDesc(file_trigger_trigger, 7);
 
 /**
  * @generated from message blippy.trigger.DeleteTriggerRequest
@@ -221,7 +392,440 @@ export type DeleteTriggerRequest = Message<"blippy.trigger.DeleteTriggerRequest"
  * Use `create(DeleteTriggerRequestSchema)` to create a new message.
  */
 export const DeleteTriggerRequestSchema: GenMessage<DeleteTriggerRequest> = /*@__PURE__*/
-  messageDesc(file_trigger_trigger, 6);
+  messageDesc(file_trigger_trigger, 8);
+
+/**
+ * @generated from message blippy.trigger.BatchUpdateTriggersRequest
+ */
+export type BatchUpdateTriggersRequest = Message<"blippy.trigger.BatchUpdateTriggersRequest"> & {
+  /**
+   * At most 500 trigger IDs; the update fails if any doesn't exist.
+   *
+   * @generated from field: repeated string ids = 1;
+   */
+  ids: string[];
+
+  /**
+   * @generated from oneof blippy.trigger.BatchUpdateTriggersRequest.update
+   */
+  update: {
+    /**
+     * Enables or disables the triggers. Enabled cron triggers are
+     * rescheduled from now, so missed runs aren't made up for.
+     *
+     * @generated from field: bool enabled = 2;
+     */
+    value: boolean;
+    case: "enabled";
+  } | {
+    /**
+     * Moves the triggers to another agent.
+     *
+     * @generated from field: string agent_id = 3;
+     */
+    value: string;
+    case: "agentId";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message blippy.trigger.BatchUpdateTriggersRequest.
+ * Use `create(BatchUpdateTriggersRequestSchema)` to create a new message.
+ */
+export const BatchUpdateTriggersRequestSchema: GenMessage<BatchUpdateTriggersRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 9);
+
+/**
+ * @generated from message blippy.trigger.BatchUpdateTriggersResponse
+ */
+export type BatchUpdateTriggersResponse = Message<"blippy.trigger.BatchUpdateTriggersResponse"> & {
+  /**
+   * The updated triggers, in the order of the request's IDs.
+   *
+   * @generated from field: repeated blippy.trigger.Trigger triggers = 1;
+   */
+  triggers: Trigger[];
+};
+
+/**
+ * Describes the message blippy.trigger.BatchUpdateTriggersResponse.
+ * Use `create(BatchUpdateTriggersResponseSchema)` to create a new message.
+ */
+export const BatchUpdateTriggersResponseSchema: GenMessage<BatchUpdateTriggersResponse> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 10);
+
+/**
+ * @generated from message blippy.trigger.RunTriggerRequest
+ */
+export type RunTriggerRequest = Message<"blippy.trigger.RunTriggerRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.trigger.RunTriggerRequest.
+ * Use `create(RunTriggerRequestSchema)` to create a new message.
+ */
+export const RunTriggerRequestSchema: GenMessage<RunTriggerRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 11);
+
+/**
+ * @generated from message blippy.trigger.RunTriggerResponse
+ */
+export type RunTriggerResponse = Message<"blippy.trigger.RunTriggerResponse"> & {
+  /**
+   * The trigger run started.
+   *
+   * @generated from field: string trigger_run_id = 1;
+   */
+  triggerRunId: string;
+};
+
+/**
+ * Describes the message blippy.trigger.RunTriggerResponse.
+ * Use `create(RunTriggerResponseSchema)` to create a new message.
+ */
+export const RunTriggerResponseSchema: GenMessage<RunTriggerResponse> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 12);
+
+/**
+ * TriggerRun is a run of a trigger, by its schedule, RunTrigger or a
+ * webhook.
+ *
+ * @generated from message blippy.trigger.TriggerRun
+ */
+export type TriggerRun = Message<"blippy.trigger.TriggerRun"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string trigger_id = 2;
+   */
+  triggerId: string;
+
+  /**
+   * "running", "completed", "failed", "interrupted", "cancelled" or
+   * "timed_out".
+   *
+   * @generated from field: string status = 3;
+   */
+  status: string;
+
+  /**
+   * empty unless the run failed
+   *
+   * @generated from field: string error_message = 4;
+   */
+  errorMessage: string;
+
+  /**
+   * The conversation of the run, once it finished. Empty if none was
+   * created or it was deleted.
+   *
+   * @generated from field: string conversation_id = 5;
+   */
+  conversationId: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 6;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * unset while running
+   *
+   * @generated from field: google.protobuf.Timestamp finished_at = 7;
+   */
+  finishedAt?: Timestamp;
+
+  /**
+   * 0 while running
+   *
+   * @generated from field: int64 duration_ms = 8;
+   */
+  durationMs: bigint;
+};
+
+/**
+ * Describes the message blippy.trigger.TriggerRun.
+ * Use `create(TriggerRunSchema)` to create a new message.
+ */
+export const TriggerRunSchema: GenMessage<TriggerRun> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 13);
+
+/**
+ * @generated from message blippy.trigger.ListTriggerRunsRequest
+ */
+export type ListTriggerRunsRequest = Message<"blippy.trigger.ListTriggerRunsRequest"> & {
+  /**
+   * optional filter
+   *
+   * @generated from field: string trigger_id = 1;
+   */
+  triggerId: string;
+
+  /**
+   * Maximum number of results to return. 0 returns all results.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize: number;
+
+  /**
+   * Token from a previous response's next_page_token.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken: string;
+
+  /**
+   * Comma-separated fields, each optionally followed by "asc" or "desc".
+   * Defaults to the most recently started first.
+   *
+   * @generated from field: string order_by = 4;
+   */
+  orderBy: string;
+
+  /**
+   * Terms joined by "AND", e.g. `status=failed AND started_at>=2025-01-01`.
+   *
+   * @generated from field: string filter = 5;
+   */
+  filter: string;
+};
+
+/**
+ * Describes the message blippy.trigger.ListTriggerRunsRequest.
+ * Use `create(ListTriggerRunsRequestSchema)` to create a new message.
+ */
+export const ListTriggerRunsRequestSchema: GenMessage<ListTriggerRunsRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 14);
+
+/**
+ * @generated from message blippy.trigger.ListTriggerRunsResponse
+ */
+export type ListTriggerRunsResponse = Message<"blippy.trigger.ListTriggerRunsResponse"> & {
+  /**
+   * @generated from field: repeated blippy.trigger.TriggerRun trigger_runs = 1;
+   */
+  triggerRuns: TriggerRun[];
+
+  /**
+   * Token for the next page; empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Number of results matching the filter.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
+};
+
+/**
+ * Describes the message blippy.trigger.ListTriggerRunsResponse.
+ * Use `create(ListTriggerRunsResponseSchema)` to create a new message.
+ */
+export const ListTriggerRunsResponseSchema: GenMessage<ListTriggerRunsResponse> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 15);
+
+/**
+ * @generated from message blippy.trigger.GetTriggerRunRequest
+ */
+export type GetTriggerRunRequest = Message<"blippy.trigger.GetTriggerRunRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message blippy.trigger.GetTriggerRunRequest.
+ * Use `create(GetTriggerRunRequestSchema)` to create a new message.
+ */
+export const GetTriggerRunRequestSchema: GenMessage<GetTriggerRunRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 16);
+
+/**
+ * TriggerWebhook runs its trigger when its URL is called, e.g. by GitHub or
+ * Stripe. The request body is appended to the trigger's prompt.
+ *
+ * @generated from message blippy.trigger.TriggerWebhook
+ */
+export type TriggerWebhook = Message<"blippy.trigger.TriggerWebhook"> & {
+  /**
+   * @generated from field: string trigger_id = 1;
+   */
+  triggerId: string;
+
+  /**
+   * Path of the webhook URL, "/webhooks/triggers/<token>". Only set when the
+   * webhook is created; the token can't be retrieved again.
+   *
+   * @generated from field: string path = 2;
+   */
+  path: string;
+
+  /**
+   * Whether requests must be signed with the webhook's secret.
+   *
+   * @generated from field: bool has_secret = 3;
+   */
+  hasSecret: boolean;
+
+  /**
+   * How requests are signed: "github" (X-Hub-Signature-256, the default),
+   * "stripe" (Stripe-Signature) or "blippy" (X-Blippy-Signature, as sent by
+   * notification channels).
+   *
+   * @generated from field: string signature_scheme = 4;
+   */
+  signatureScheme: string;
+
+  /**
+   * IP addresses or CIDR ranges requests must come from. Empty allows all.
+   *
+   * @generated from field: repeated string allowed_ips = 5;
+   */
+  allowedIps: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 7;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message blippy.trigger.TriggerWebhook.
+ * Use `create(TriggerWebhookSchema)` to create a new message.
+ */
+export const TriggerWebhookSchema: GenMessage<TriggerWebhook> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 17);
+
+/**
+ * @generated from message blippy.trigger.CreateTriggerWebhookRequest
+ */
+export type CreateTriggerWebhookRequest = Message<"blippy.trigger.CreateTriggerWebhookRequest"> & {
+  /**
+   * @generated from field: string trigger_id = 1;
+   */
+  triggerId: string;
+
+  /**
+   * optional
+   *
+   * @generated from field: string secret = 2;
+   */
+  secret: string;
+
+  /**
+   * optional, defaults to "github"
+   *
+   * @generated from field: string signature_scheme = 3;
+   */
+  signatureScheme: string;
+
+  /**
+   * optional
+   *
+   * @generated from field: repeated string allowed_ips = 4;
+   */
+  allowedIps: string[];
+};
+
+/**
+ * Describes the message blippy.trigger.CreateTriggerWebhookRequest.
+ * Use `create(CreateTriggerWebhookRequestSchema)` to create a new message.
+ */
+export const CreateTriggerWebhookRequestSchema: GenMessage<CreateTriggerWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 18);
+
+/**
+ * @generated from message blippy.trigger.GetTriggerWebhookRequest
+ */
+export type GetTriggerWebhookRequest = Message<"blippy.trigger.GetTriggerWebhookRequest"> & {
+  /**
+   * @generated from field: string trigger_id = 1;
+   */
+  triggerId: string;
+};
+
+/**
+ * Describes the message blippy.trigger.GetTriggerWebhookRequest.
+ * Use `create(GetTriggerWebhookRequestSchema)` to create a new message.
+ */
+export const GetTriggerWebhookRequestSchema: GenMessage<GetTriggerWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 19);
+
+/**
+ * @generated from message blippy.trigger.UpdateTriggerWebhookRequest
+ */
+export type UpdateTriggerWebhookRequest = Message<"blippy.trigger.UpdateTriggerWebhookRequest"> & {
+  /**
+   * @generated from field: string trigger_id = 1;
+   */
+  triggerId: string;
+
+  /**
+   * empty keeps the current secret
+   *
+   * @generated from field: string secret = 2;
+   */
+  secret: string;
+
+  /**
+   * removes the secret, so requests needn't be signed
+   *
+   * @generated from field: bool clear_secret = 3;
+   */
+  clearSecret: boolean;
+
+  /**
+   * @generated from field: string signature_scheme = 4;
+   */
+  signatureScheme: string;
+
+  /**
+   * @generated from field: repeated string allowed_ips = 5;
+   */
+  allowedIps: string[];
+};
+
+/**
+ * Describes the message blippy.trigger.UpdateTriggerWebhookRequest.
+ * Use `create(UpdateTriggerWebhookRequestSchema)` to create a new message.
+ */
+export const UpdateTriggerWebhookRequestSchema: GenMessage<UpdateTriggerWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 20);
+
+/**
+ * @generated from message blippy.trigger.DeleteTriggerWebhookRequest
+ */
+export type DeleteTriggerWebhookRequest = Message<"blippy.trigger.DeleteTriggerWebhookRequest"> & {
+  /**
+   * @generated from field: string trigger_id = 1;
+   */
+  triggerId: string;
+};
+
+/**
+ * Describes the message blippy.trigger.DeleteTriggerWebhookRequest.
+ * Use `create(DeleteTriggerWebhookRequestSchema)` to create a new message.
+ */
+export const DeleteTriggerWebhookRequestSchema: GenMessage<DeleteTriggerWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_trigger_trigger, 21);
 
 /**
  * @generated from message blippy.trigger.Empty
@@ -234,7 +838,7 @@ export type Empty = Message<"blippy.trigger.Empty"> & {
  * Use `create(EmptySchema)` to create a new message.
  */
 export const EmptySchema: GenMessage<Empty> = /*@__PURE__*/
-  messageDesc(file_trigger_trigger, 7);
+  messageDesc(file_trigger_trigger, 22);
 
 /**
  * TriggerService manages autonomous triggers.
@@ -250,6 +854,18 @@ export const TriggerService: GenService<{
     input: typeof CreateTriggerRequestSchema;
     output: typeof TriggerSchema;
   },
+  /**
+   * Creates a disabled trigger for the conversation's agent, with a name,
+   * prompt and schedule drafted by an LLM from the conversation, to review
+   * before enabling it.
+   *
+   * @generated from rpc blippy.trigger.TriggerService.CreateTriggerFromConversation
+   */
+  createTriggerFromConversation: {
+    methodKind: "unary";
+    input: typeof CreateTriggerFromConversationRequestSchema;
+    output: typeof TriggerSchema;
+  },
   /**
    * @generated from rpc blippy.trigger.TriggerService.GetTrigger
    */
@@ -282,6 +898,80 @@ export const TriggerService: GenService<{
     input: typeof DeleteTriggerRequestSchema;
     output: typeof EmptySchema;
   },
+  /**
+   * Enables, disables or moves many triggers in a single transaction.
+   *
+   * @generated from rpc blippy.trigger.TriggerService.BatchUpdateTriggers
+   */
+  batchUpdateTriggers: {
+    methodKind: "unary";
+    input: typeof BatchUpdateTriggersRequestSchema;
+    output: typeof BatchUpdateTriggersResponseSchema;
+  },
+  /**
+   * Runs a trigger now, in the background, without changing its schedule.
+   *
+   * @generated from rpc blippy.trigger.TriggerService.RunTrigger
+   */
+  runTrigger: {
+    methodKind: "unary";
+    input: typeof RunTriggerRequestSchema;
+    output: typeof RunTriggerResponseSchema;
+  },
+  /**
+   * Creates the webhook of a trigger, or replaces it with one with a new
+   * token.
+   *
+   * @generated from rpc blippy.trigger.TriggerService.CreateTriggerWebhook
+   */
+  createTriggerWebhook: {
+    methodKind: "unary";
+    input: typeof CreateTriggerWebhookRequestSchema;
+    output: typeof TriggerWebhookSchema;
+  },
+  /**
+   * @generated from rpc blippy.trigger.TriggerService.GetTriggerWebhook
+   */
+  getTriggerWebhook: {
+    methodKind: "unary";
+    input: typeof GetTriggerWebhookRequestSchema;
+    output: typeof TriggerWebhookSchema;
+  },
+  /**
+   * @generated from rpc blippy.trigger.TriggerService.UpdateTriggerWebhook
+   */
+  updateTriggerWebhook: {
+    methodKind: "unary";
+    input: typeof UpdateTriggerWebhookRequestSchema;
+    output: typeof TriggerWebhookSchema;
+  },
+  /**
+   * @generated from rpc blippy.trigger.TriggerService.DeleteTriggerWebhook
+   */
+  deleteTriggerWebhook: {
+    methodKind: "unary";
+    input: typeof DeleteTriggerWebhookRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * Lists the runs of triggers, most recent first. Runs are kept for
+   * TRIGGER_RUN_RETENTION.
+   *
+   * @generated from rpc blippy.trigger.TriggerService.ListTriggerRuns
+   */
+  listTriggerRuns: {
+    methodKind: "unary";
+    input: typeof ListTriggerRunsRequestSchema;
+    output: typeof ListTriggerRunsResponseSchema;
+  },
+  /**
+   * @generated from rpc blippy.trigger.TriggerService.GetTriggerRun
+   */
+  getTriggerRun: {
+    methodKind: "unary";
+    input: typeof GetTriggerRunRequestSchema;
+    output: typeof TriggerRunSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_trigger_trigger, 0);
 
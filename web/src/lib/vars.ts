@@ -0,0 +1,20 @@
+import type { RunVar } from "@/lib/rpc/trigger/trigger_pb";
+
+// parseVars parses run variables from "NAME=value" lines, skipping blank
+// lines. Names are validated by the server.
+export function parseVars(text: string) {
+	return text
+		.split("\n")
+		.filter((line) => line.trim() !== "")
+		.map((line) => {
+			const i = line.indexOf("=");
+			return i < 0
+				? { name: line.trim(), value: "" }
+				: { name: line.slice(0, i).trim(), value: line.slice(i + 1) };
+		});
+}
+
+// formatVars formats run variables as "NAME=value" lines.
+export function formatVars(vars: RunVar[]) {
+	return vars.map((v) => `${v.name}=${v.value}`).join("\n");
+}
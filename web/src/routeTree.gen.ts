@@ -24,6 +24,9 @@ import { Route as AgentsAgentIdRouteImport } from './routes/agents/$agentId'
 import { Route as AgentsAgentIdIndexRouteImport } from './routes/agents/$agentId/index'
 import { Route as AgentsAgentIdSettingsRouteImport } from './routes/agents/$agentId/settings'
 import { Route as AgentsAgentIdConversationIdRouteImport } from './routes/agents/$agentId/$conversationId'
+import { Route as SettingsIndexRouteImport } from './routes/settings/index'
+import { Route as LoginRouteImport } from './routes/login'
+import { Route as ActivityRouteImport } from './routes/activity'
 
 const IndexRoute = IndexRouteImport.update({
   id: '/',
@@ -101,6 +104,21 @@ const AgentsAgentIdConversationIdRoute =
     path: '/$conversationId',
     getParentRoute: () => AgentsAgentIdRoute,
   } as any)
+const SettingsIndexRoute = SettingsIndexRouteImport.update({
+  id: '/settings/',
+  path: '/settings/',
+  getParentRoute: () => rootRouteImport,
+} as any)
+const LoginRoute = LoginRouteImport.update({
+  id: '/login',
+  path: '/login',
+  getParentRoute: () => rootRouteImport,
+} as any)
+const ActivityRoute = ActivityRouteImport.update({
+  id: '/activity',
+  path: '/activity',
+  getParentRoute: () => rootRouteImport,
+} as any)
 
 export interface FileRoutesByFullPath {
   '/': typeof IndexRoute
@@ -118,6 +136,9 @@ export interface FileRoutesByFullPath {
   '/agents/$agentId/$conversationId': typeof AgentsAgentIdConversationIdRoute
   '/agents/$agentId/settings': typeof AgentsAgentIdSettingsRoute
   '/agents/$agentId/': typeof AgentsAgentIdIndexRoute
+  '/settings/': typeof SettingsIndexRoute
+  '/login': typeof LoginRoute
+  '/activity': typeof ActivityRoute
 }
 export interface FileRoutesByTo {
   '/': typeof IndexRoute
@@ -134,6 +155,9 @@ export interface FileRoutesByTo {
   '/agents/$agentId/$conversationId': typeof AgentsAgentIdConversationIdRoute
   '/agents/$agentId/settings': typeof AgentsAgentIdSettingsRoute
   '/agents/$agentId': typeof AgentsAgentIdIndexRoute
+  '/settings': typeof SettingsIndexRoute
+  '/login': typeof LoginRoute
+  '/activity': typeof ActivityRoute
 }
 export interface FileRoutesById {
   __root__: typeof rootRouteImport
@@ -152,6 +176,9 @@ export interface FileRoutesById {
   '/agents/$agentId/$conversationId': typeof AgentsAgentIdConversationIdRoute
   '/agents/$agentId/settings': typeof AgentsAgentIdSettingsRoute
   '/agents/$agentId/': typeof AgentsAgentIdIndexRoute
+  '/settings/': typeof SettingsIndexRoute
+  '/login': typeof LoginRoute
+  '/activity': typeof ActivityRoute
 }
 export interface FileRouteTypes {
   fileRoutesByFullPath: FileRoutesByFullPath
@@ -171,6 +198,9 @@ export interface FileRouteTypes {
     | '/agents/$agentId/$conversationId'
     | '/agents/$agentId/settings'
     | '/agents/$agentId/'
+    | '/settings/'
+    | '/login'
+    | '/activity'
   fileRoutesByTo: FileRoutesByTo
   to:
     | '/'
@@ -187,6 +217,9 @@ export interface FileRouteTypes {
     | '/agents/$agentId/$conversationId'
     | '/agents/$agentId/settings'
     | '/agents/$agentId'
+    | '/settings'
+    | '/login'
+    | '/activity'
   id:
     | '__root__'
     | '/'
@@ -204,6 +237,9 @@ export interface FileRouteTypes {
     | '/agents/$agentId/$conversationId'
     | '/agents/$agentId/settings'
     | '/agents/$agentId/'
+    | '/settings/'
+    | '/login'
+    | '/activity'
   fileRoutesById: FileRoutesById
 }
 export interface RootRouteChildren {
@@ -219,10 +255,34 @@ export interface RootRouteChildren {
   NotificationsIndexRoute: typeof NotificationsIndexRoute
   RootsIndexRoute: typeof RootsIndexRoute
   TriggersIndexRoute: typeof TriggersIndexRoute
+  SettingsIndexRoute: typeof SettingsIndexRoute
+  LoginRoute: typeof LoginRoute
+  ActivityRoute: typeof ActivityRoute
 }
 
 declare module '@tanstack/react-router' {
   interface FileRoutesByPath {
+    '/activity': {
+      id: '/activity'
+      path: '/activity'
+      fullPath: '/activity'
+      preLoaderRoute: typeof ActivityRouteImport
+      parentRoute: typeof rootRouteImport
+    }
+    '/login': {
+      id: '/login'
+      path: '/login'
+      fullPath: '/login'
+      preLoaderRoute: typeof LoginRouteImport
+      parentRoute: typeof rootRouteImport
+    }
+    '/settings/': {
+      id: '/settings/'
+      path: '/settings'
+      fullPath: '/settings/'
+      preLoaderRoute: typeof SettingsIndexRouteImport
+      parentRoute: typeof rootRouteImport
+    }
     '/': {
       id: '/'
       path: '/'
@@ -360,6 +420,9 @@ const rootRouteChildren: RootRouteChildren = {
   NotificationsIndexRoute: NotificationsIndexRoute,
   RootsIndexRoute: RootsIndexRoute,
   TriggersIndexRoute: TriggersIndexRoute,
+  SettingsIndexRoute: SettingsIndexRoute,
+  LoginRoute: LoginRoute,
+  ActivityRoute: ActivityRoute,
 }
 export const routeTree = rootRouteImport
   ._addFileChildren(rootRouteChildren)
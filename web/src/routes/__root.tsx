@@ -1,15 +1,30 @@
-import { createRootRoute, Outlet } from "@tanstack/react-router";
+import {
+	createRootRoute,
+	Outlet,
+	useRouterState,
+} from "@tanstack/react-router";
 import { Layout } from "@/components/layout";
 import { ThemeProvider } from "@/components/theme-provider";
 import { Toaster } from "@/components/ui/sonner";
 
-export const Route = createRootRoute({
-	component: () => (
+function Root() {
+	const pathname = useRouterState({ select: (s) => s.location.pathname });
+
+	return (
 		<ThemeProvider defaultTheme="system">
-			<Layout>
+			{/* The login page has no sidebar, since it can't load anything yet. */}
+			{pathname === "/login" ? (
 				<Outlet />
-			</Layout>
+			) : (
+				<Layout>
+					<Outlet />
+				</Layout>
+			)}
 			<Toaster />
 		</ThemeProvider>
-	),
+	);
+}
+
+export const Route = createRootRoute({
+	component: Root,
 });
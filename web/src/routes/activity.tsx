@@ -0,0 +1,147 @@
+import { fromJsonString } from "@bufbuild/protobuf";
+import { createFileRoute, Link } from "@tanstack/react-router";
+import { Activity } from "lucide-react";
+import { useEffect, useRef, useState } from "react";
+import { EmptyState } from "@/components/empty-state";
+import { PageContent } from "@/components/page-content";
+import { Badge } from "@/components/ui/badge";
+import { Card, CardContent } from "@/components/ui/card";
+import { type Event, EventSchema } from "@/lib/rpc/pubsub/pubsub_pb";
+
+export const Route = createFileRoute("/activity")({
+	component: ActivityFeed,
+});
+
+// maxItems limits the activity kept in the feed.
+const maxItems = 100;
+
+interface ActivityItem {
+	key: number;
+	event: Event;
+	receivedAt: Date;
+}
+
+const activityTypes = ["run_started", "run_finished", "trigger_fired", "gap"];
+
+function ActivityFeed() {
+	const [items, setItems] = useState<ActivityItem[]>([]);
+	const [connected, setConnected] = useState(false);
+	// Gaps have no sequence number, so items are keyed by arrival.
+	const nextKey = useRef(0);
+
+	useEffect(() => {
+		const source = new EventSource("/api/events?topic=instance");
+		source.onopen = () => setConnected(true);
+		source.onerror = () => setConnected(false);
+		const onActivity = (e: MessageEvent) => {
+			const event = fromJsonString(EventSchema, e.data, {
+				ignoreUnknownFields: true,
+			});
+			setItems((prev) =>
+				[
+					{
+						key: nextKey.current++,
+						event,
+						receivedAt: new Date(),
+					},
+					...prev,
+				].slice(0, maxItems),
+			);
+		};
+		for (const type of activityTypes) {
+			source.addEventListener(type, onActivity);
+		}
+		return () => source.close();
+	}, []);
+
+	return (
+		<PageContent className="space-y-6">
+			<div className="flex items-center justify-between">
+				<div>
+					<h1 className="text-2xl font-bold tracking-tight">Activity</h1>
+					<p className="text-muted-foreground">
+						Agent runs and triggers as they happen
+					</p>
+				</div>
+				<Badge variant={connected ? "default" : "secondary"}>
+					{connected ? "Live" : "Connecting…"}
+				</Badge>
+			</div>
+
+			{items.length === 0 ? (
+				<EmptyState
+					icon={<Activity />}
+					title="No activity yet"
+					description="Runs will show up here when agents start working"
+				/>
+			) : (
+				<Card>
+					<CardContent className="divide-y p-0">
+						{items.map((item) => (
+							<ActivityRow key={item.key} item={item} />
+						))}
+					</CardContent>
+				</Card>
+			)}
+		</PageContent>
+	);
+}
+
+function ActivityRow({ item }: { item: ActivityItem }) {
+	const { payload } = item.event;
+	let description: string;
+	let agentId = "";
+	let conversationId = "";
+	let error = "";
+	switch (payload.case) {
+		case "runStarted": {
+			const run = payload.value;
+			agentId = run.agentId;
+			conversationId = run.conversationId;
+			description = run.depth
+				? `${run.agentName} was called by another agent`
+				: `${run.agentName} started a run`;
+			break;
+		}
+		case "runFinished": {
+			const run = payload.value;
+			agentId = run.agentId;
+			conversationId = run.conversationId;
+			error = run.error;
+			description = `${run.agentName} finished a run: ${run.status}`;
+			break;
+		}
+		case "triggerFired":
+			agentId = payload.value.agentId;
+			conversationId = payload.value.conversationId;
+			description = `Trigger "${payload.value.triggerName}" fired`;
+			break;
+		case "gap":
+			description = `Missed ${payload.value.missed} events`;
+			break;
+		default:
+			description = "Unknown activity";
+	}
+
+	return (
+		<div className="flex items-center justify-between gap-4 px-4 py-3 text-sm">
+			<div className="min-w-0">
+				{agentId && conversationId ? (
+					<Link
+						to="/agents/$agentId/$conversationId"
+						params={{ agentId, conversationId }}
+						className="hover:underline"
+					>
+						{description}
+					</Link>
+				) : (
+					<span>{description}</span>
+				)}
+				{error && <p className="truncate text-destructive">{error}</p>}
+			</div>
+			<time className="shrink-0 text-muted-foreground">
+				{item.receivedAt.toLocaleTimeString()}
+			</time>
+		</div>
+	);
+}
@@ -1,20 +1,39 @@
-import { createClient } from "@connectrpc/connect";
-import { useQuery, useTransport } from "@connectrpc/connect-query";
-import { createFileRoute } from "@tanstack/react-router";
-import { ArrowUp, Loader2 } from "lucide-react";
+import { ConnectError, createClient } from "@connectrpc/connect";
+import {
+	useMutation,
+	useQuery,
+	useTransport,
+} from "@connectrpc/connect-query";
+import { createFileRoute, useNavigate } from "@tanstack/react-router";
+import { ArrowUp, CalendarClock, Paperclip, Square, X } from "lucide-react";
 import { useEffect, useLayoutEffect, useRef, useState } from "react";
 import ReactMarkdown from "react-markdown";
 import remarkGfm from "remark-gfm";
+import { toast } from "sonner";
 import { MessageActions } from "@/components/chat/message-actions";
+import { PendingApprovals } from "@/components/chat/pending-approvals";
+import {
+	SubagentActivity,
+	type SubagentRun,
+} from "@/components/chat/subagent-activity";
 import { ToolExecution } from "@/components/chat/tool-execution";
 import { TypingIndicator } from "@/components/chat/typing-indicator";
 import { Button } from "@/components/ui/button";
 import { Textarea } from "@/components/ui/textarea";
-import { ConversationService } from "@/lib/rpc/conversation/conversation_pb";
+import { errorCode } from "@/lib/api";
+import { ErrorCode } from "@/lib/rpc/apierror/apierror_pb";
+import {
+	type ApprovalRequested,
+	ConversationService,
+	type TurnProgress,
+} from "@/lib/rpc/conversation/conversation_pb";
 import {
 	getConversation,
+	getConversationCost,
 	getMessages,
 } from "@/lib/rpc/conversation/conversation-ConversationService_connectquery";
+import { SystemService } from "@/lib/rpc/system/system_pb";
+import { createTriggerFromConversation } from "@/lib/rpc/trigger/trigger-TriggerService_connectquery";
 
 export const Route = createFileRoute("/agents/$agentId/$conversationId")({
 	component: ConversationChat,
@@ -30,22 +49,56 @@ interface MessageItemToolExecution {
 	name: string;
 	input?: string;
 	result?: string;
+	callId?: string;
+	// Set while the call is being streamed or executed.
+	running?: boolean;
+}
+
+interface MessageItemError {
+	type: "error";
+	message: string;
+}
+
+interface MessageItemAttachment {
+	type: "attachment";
+	name: string;
+	mediaType: string;
+	// Signed download URL; missing until the message is stored.
+	url?: string;
 }
 
-type MessageItem = MessageItemText | MessageItemToolExecution;
+type MessageItem =
+	| MessageItemText
+	| MessageItemToolExecution
+	| MessageItemError
+	| MessageItemAttachment;
 
 interface Message {
 	id: string;
 	role: string;
+	status?: string;
 	items: MessageItem[];
 }
 
+// Files the API accepts as attachments: images, PDFs and text files.
+const attachmentAccept =
+	"image/png,image/jpeg,image/webp,image/gif,application/pdf,text/*,.md,.csv,.json";
+
+// formatCost formats the estimated cost of a conversation. Costs of models
+// without known prices are missing, so they're marked as a lower bound.
+function formatCost(usd: number, priced: boolean) {
+	const cost = `$${usd.toFixed(usd > 0 && usd < 0.01 ? 4 : 2)}`;
+	return priced ? cost : `≥ ${cost}`;
+}
+
 function MessageBubble({
 	message,
 	isBusy,
+	progress,
 }: {
 	message: Message;
 	isBusy?: boolean;
+	progress?: TurnProgress;
 }) {
 	const isUser = message.role === "user";
 
@@ -55,16 +108,31 @@ function MessageBubble({
 			.filter((item): item is MessageItemText => item.type === "text")
 			.map((item) => item.content)
 			.join("\n\n");
+		const attachments = message.items.filter(
+			(item): item is MessageItemAttachment => item.type === "attachment",
+		);
 
 		return (
 			<div className="group flex flex-col gap-3 items-end">
-				<div className="relative max-w-[80%] rounded-2xl bg-primary px-4 py-2.5 text-primary-foreground">
-					<div className="prose max-w-none **:text-primary-foreground">
-						<ReactMarkdown remarkPlugins={[remarkGfm]}>
-							{textContent}
-						</ReactMarkdown>
+				{attachments.length > 0 && (
+					<div className="flex max-w-[80%] flex-wrap justify-end gap-2">
+						{attachments.map((a, i) => (
+							<AttachmentPreview
+								key={`${message.id}-${i}`}
+								attachment={a}
+							/>
+						))}
 					</div>
-				</div>
+				)}
+				{textContent && (
+					<div className="relative max-w-[80%] rounded-2xl bg-primary px-4 py-2.5 text-primary-foreground">
+						<div className="prose max-w-none **:text-primary-foreground">
+							<ReactMarkdown remarkPlugins={[remarkGfm]}>
+								{textContent}
+							</ReactMarkdown>
+						</div>
+					</div>
+				)}
 			</div>
 		);
 	}
@@ -85,9 +153,20 @@ function MessageBubble({
 							name={item.name}
 							input={item.input}
 							result={item.result}
+							running={item.running}
 						/>
 					);
 				}
+				if (item.type === "error") {
+					return (
+						<div
+							key={key}
+							className="rounded-lg border border-destructive/50 bg-destructive/10 px-3 py-2 text-sm text-destructive"
+						>
+							Stopped: {item.message}
+						</div>
+					);
+				}
 
 				// text item
 				const isLastItem = index === message.items.length - 1;
@@ -101,7 +180,7 @@ function MessageBubble({
 								{item.content}
 							</ReactMarkdown>
 						</div>
-						{isBusy && isLastItem && <TypingIndicator />}
+						{isBusy && isLastItem && <TypingIndicator progress={progress} />}
 						{!isBusy && isLastTextItem && item.content && (
 							<div className="absolute -right-8 top-0">
 								<MessageActions content={item.content} />
@@ -110,23 +189,88 @@ function MessageBubble({
 					</div>
 				);
 			})}
-			{isBusy && message.items.length === 0 && <TypingIndicator />}
+			{isBusy && message.items.length === 0 && (
+				<TypingIndicator progress={progress} />
+			)}
+			{message.status === "interrupted" && (
+				<p className="text-xs text-muted-foreground">
+					Interrupted by server shutdown
+				</p>
+			)}
+			{message.status === "cancelled" && (
+				<p className="text-xs text-muted-foreground">Stopped</p>
+			)}
+			{message.status === "timed_out" && (
+				<p className="text-xs text-muted-foreground">
+					Stopped after exceeding the maximum duration
+				</p>
+			)}
 		</div>
 	);
 }
 
+// AttachmentPreview shows an attached image as a thumbnail, and other files
+// as a link with their name.
+function AttachmentPreview({
+	attachment,
+}: {
+	attachment: MessageItemAttachment;
+}) {
+	if (attachment.url && attachment.mediaType.startsWith("image/")) {
+		return (
+			<a href={attachment.url} target="_blank" rel="noreferrer">
+				<img
+					src={attachment.url}
+					alt={attachment.name}
+					className="max-h-48 max-w-full rounded-lg border object-cover"
+				/>
+			</a>
+		);
+	}
+	const label = (
+		<>
+			<Paperclip className="h-3.5 w-3.5 shrink-0" />
+			<span className="truncate">{attachment.name}</span>
+		</>
+	);
+	const className =
+		"flex max-w-64 items-center gap-1.5 rounded-lg border px-3 py-2 text-sm";
+	return attachment.url ? (
+		<a
+			href={attachment.url}
+			target="_blank"
+			rel="noreferrer"
+			className={`${className} hover:bg-accent`}
+		>
+			{label}
+		</a>
+	) : (
+		<div className={className}>{label}</div>
+	);
+}
+
 function ConversationChat() {
-	const { conversationId } = Route.useParams();
+	const { agentId, conversationId } = Route.useParams();
 	const transport = useTransport();
+	const navigate = useNavigate();
+	const scheduleMutation = useMutation(createTriggerFromConversation);
 
 	const [messages, setMessages] = useState<Message[]>([]);
 	const [input, setInput] = useState("");
+	const [files, setFiles] = useState<File[]>([]);
 	const [isBusy, setIsBusy] = useState(false);
+	// Latest heartbeat of the active turn.
+	const [progress, setProgress] = useState<TurnProgress>();
 	const [streamingItems, setStreamingItems] = useState<MessageItem[]>([]);
+	// Live output of agents called by the active turn.
+	const [subagents, setSubagents] = useState<SubagentRun[]>([]);
+	// Tool calls of the active turn waiting for approval.
+	const [approvals, setApprovals] = useState<ApprovalRequested[]>([]);
 	const [title, setTitle] = useState<string | undefined>();
 	const lastMessageRef = useRef<HTMLDivElement>(null);
 	const messagesContainerRef = useRef<HTMLDivElement>(null);
 	const textareaRef = useRef<HTMLTextAreaElement>(null);
+	const fileInputRef = useRef<HTMLInputElement>(null);
 	const initialLoadDone = useRef(false);
 	const prevMessagesLength = useRef(0);
 
@@ -134,6 +278,10 @@ function ConversationChat() {
 		id: conversationId,
 	});
 	const { data: messagesData } = useQuery(getMessages, { conversationId });
+	const { data: costData, refetch: refetchCost } = useQuery(
+		getConversationCost,
+		{ conversationId },
+	);
 
 	// Load title from conversation
 	useEffect(() => {
@@ -149,6 +297,7 @@ function ConversationChat() {
 				messagesData.messages.map((m) => ({
 					id: m.id,
 					role: m.role,
+					status: m.status,
 					items: m.items.map((protoItem): MessageItem => {
 						if (protoItem.item.case === "text") {
 							return {
@@ -164,6 +313,20 @@ function ConversationChat() {
 								result: protoItem.item.value.result,
 							};
 						}
+						if (protoItem.item.case === "error") {
+							return {
+								type: "error",
+								message: protoItem.item.value.message,
+							};
+						}
+						if (protoItem.item.case === "attachment") {
+							return {
+								type: "attachment",
+								name: protoItem.item.value.name,
+								mediaType: protoItem.item.value.mediaType,
+								url: protoItem.item.value.url || undefined,
+							};
+						}
 						return { type: "text", content: "" };
 					}),
 				})),
@@ -232,125 +395,262 @@ function ConversationChat() {
 		const client = createClient(ConversationService, transport);
 
 		(async () => {
-			try {
-				const stream = client.watchEvents(
-					{ conversationId },
-					{ signal: controller.signal },
-				);
+			const items: MessageItem[] = [];
+			// Sequence of the last event received, so a reconnecting stream
+			// replays what was missed.
+			let lastSequence = 0n;
+
+			while (!controller.signal.aborted) {
+				// Set when events were dropped, to resume without delay.
+				let gap = false;
+				try {
+					const stream = client.watchEvents(
+						{ conversationId, afterSequence: lastSequence },
+						{ signal: controller.signal },
+					);
 
-				const items: MessageItem[] = [];
-
-				for await (const event of stream) {
-					switch (event.event.case) {
-						case "turnStarted":
-							setIsBusy(true);
-							break;
-
-						case "textDelta": {
-							setIsBusy(true);
-							const lastItem = items[items.length - 1];
-							if (lastItem && lastItem.type === "text") {
-								lastItem.content += event.event.value.content;
-							} else {
-								items.push({
-									type: "text",
-									content: event.event.value.content,
-								});
-							}
-							setStreamingItems([...items]);
-							break;
+					events: for await (const event of stream) {
+						if (event.sequence > lastSequence) {
+							lastSequence = event.sequence;
 						}
-
-						case "toolResult":
-							setIsBusy(true);
-							items.push({
-								type: "tool_execution",
-								name: event.event.value.name,
-								input: event.event.value.input,
-								result: event.event.value.result,
-							});
-							setStreamingItems([...items]);
-							break;
-
-						case "messageCreated": {
-							const msg = event.event.value.message;
-							if (!msg) break;
-
-							const messageItems: MessageItem[] = msg.items.map(
-								(protoItem): MessageItem => {
-									if (protoItem.item.case === "text") {
-										return {
-											type: "text",
-											content: protoItem.item.value.content,
-										};
+						switch (event.event.case) {
+							case "gap":
+								// Events were dropped because we didn't keep up;
+								// reconnect to replay them.
+								lastSequence = event.event.value.resumeSequence - 1n;
+								gap = true;
+								break events;
+
+							case "turnStarted":
+								setIsBusy(true);
+								setProgress(undefined);
+								break;
+
+							case "progress":
+								// Not marking the conversation as busy: a heartbeat
+								// can arrive just after the turn is done.
+								setProgress(event.event.value);
+								break;
+
+							case "subagent": {
+								const update = event.event.value;
+								setSubagents((prev) => {
+									const others = prev.filter(
+										(r) => r.runId !== update.runId,
+									);
+									if (update.done) {
+										return others;
 									}
-									if (protoItem.item.case === "toolExecution") {
-										return {
-											type: "tool_execution",
-											name: protoItem.item.value.name,
-											input: protoItem.item.value.input,
-											result: protoItem.item.value.result,
-										};
+									const index = prev.findIndex(
+										(r) => r.runId === update.runId,
+									);
+									const run: SubagentRun =
+										index >= 0
+											? { ...prev[index] }
+											: {
+													runId: update.runId,
+													agentName: update.agentName,
+													text: "",
+													toolCalls: 0,
+												};
+									if (update.update.case === "textDelta") {
+										run.text += update.update.value.content;
+									} else if (update.update.case === "toolResult") {
+										run.toolCalls++;
 									}
-									return { type: "text", content: "" };
-								},
-							);
-
-							const newMessage: Message = {
-								id: msg.id,
-								role: msg.role,
-								items: messageItems,
-							};
+									if (index < 0) {
+										return [...prev, run];
+									}
+									return prev.map((r, i) => (i === index ? run : r));
+								});
+								break;
+							}
 
-							if (msg.role === "assistant") {
-								// Clear streaming items and add final message
-								items.length = 0;
-								setStreamingItems([]);
+							case "approvalRequested": {
+								const approval = event.event.value;
+								setApprovals((prev) => [
+									...prev.filter((a) => a.approvalId !== approval.approvalId),
+									approval,
+								]);
+								break;
 							}
 
-							setMessages((prev) => {
-								// Replace optimistic message or skip if already present
-								const existingIndex = prev.findIndex(
-									(m) => m.id === msg.id || m.id === "pending-user",
+							case "approvalResolved": {
+								const { approvalId } = event.event.value;
+								setApprovals((prev) =>
+									prev.filter((a) => a.approvalId !== approvalId),
 								);
-								if (
-									existingIndex >= 0 &&
-									(prev[existingIndex].id === "pending-user" ||
-										prev[existingIndex].id === msg.id)
-								) {
-									const updated = [...prev];
-									updated[existingIndex] = newMessage;
-									return updated;
+								break;
+							}
+
+							case "textDelta": {
+								setIsBusy(true);
+								const lastItem = items[items.length - 1];
+								if (lastItem && lastItem.type === "text") {
+									lastItem.content += event.event.value.content;
+								} else {
+									items.push({
+										type: "text",
+										content: event.event.value.content,
+									});
 								}
-								return [...prev, newMessage];
-							});
-							break;
-						}
+								setStreamingItems([...items]);
+								break;
+							}
 
-						case "done":
-							setIsBusy(false);
-							if (event.event.value.title) {
-								setTitle(event.event.value.title);
+							case "toolExecutionStarted": {
+								setIsBusy(true);
+								const { callId, name, argumentsDelta } = event.event.value;
+								const call = items.find(
+									(item) =>
+										item.type === "tool_execution" && item.callId === callId,
+								);
+								if (call?.type === "tool_execution") {
+									call.input = (call.input ?? "") + argumentsDelta;
+								} else {
+									items.push({
+										type: "tool_execution",
+										name,
+										input: argumentsDelta,
+										callId,
+										running: true,
+									});
+								}
+								setStreamingItems([...items]);
+								break;
+							}
+
+							case "toolResult": {
+								setIsBusy(true);
+								const { callId, name, input, result } = event.event.value;
+								const index = items.findIndex(
+									(item) =>
+										item.type === "tool_execution" &&
+										callId !== "" &&
+										item.callId === callId,
+								);
+								const done: MessageItem = {
+									type: "tool_execution",
+									name,
+									input,
+									result,
+									callId,
+								};
+								if (index >= 0) {
+									items[index] = done;
+								} else {
+									items.push(done);
+								}
+								setStreamingItems([...items]);
+								break;
 							}
-							// Reset streaming state for next turn
-							items.length = 0;
-							setStreamingItems([]);
-							break;
-
-						case "error":
-							setIsBusy(false);
-							console.error("Watch error:", event.event.value.message);
-							items.length = 0;
-							setStreamingItems([]);
-							break;
+
+							case "messageCreated": {
+								const msg = event.event.value.message;
+								if (!msg) break;
+
+								const messageItems: MessageItem[] = msg.items.map(
+									(protoItem): MessageItem => {
+										if (protoItem.item.case === "text") {
+											return {
+												type: "text",
+												content: protoItem.item.value.content,
+											};
+										}
+										if (protoItem.item.case === "toolExecution") {
+											return {
+												type: "tool_execution",
+												name: protoItem.item.value.name,
+												input: protoItem.item.value.input,
+												result: protoItem.item.value.result,
+											};
+										}
+										if (protoItem.item.case === "error") {
+											return {
+												type: "error",
+												message: protoItem.item.value.message,
+											};
+										}
+										if (protoItem.item.case === "attachment") {
+											return {
+												type: "attachment",
+												name: protoItem.item.value.name,
+												mediaType: protoItem.item.value.mediaType,
+												url: protoItem.item.value.url || undefined,
+											};
+										}
+										return { type: "text", content: "" };
+									},
+								);
+
+								const newMessage: Message = {
+									id: msg.id,
+									role: msg.role,
+									status: msg.status,
+									items: messageItems,
+								};
+
+								if (msg.role === "assistant") {
+									// Clear streaming items and add final message
+									items.length = 0;
+									setStreamingItems([]);
+								}
+
+								setMessages((prev) => {
+									// Replace optimistic message or skip if already present
+									const existingIndex = prev.findIndex(
+										(m) => m.id === msg.id || m.id === "pending-user",
+									);
+									if (
+										existingIndex >= 0 &&
+										(prev[existingIndex].id === "pending-user" ||
+											prev[existingIndex].id === msg.id)
+									) {
+										const updated = [...prev];
+										updated[existingIndex] = newMessage;
+										return updated;
+									}
+									return [...prev, newMessage];
+								});
+								break;
+							}
+
+							case "done":
+								setIsBusy(false);
+								setProgress(undefined);
+								setSubagents([]);
+								setApprovals([]);
+								refetchCost();
+								if (event.event.value.title) {
+									setTitle(event.event.value.title);
+								}
+								// Reset streaming state for next turn
+								items.length = 0;
+								setStreamingItems([]);
+								break;
+
+							case "error":
+								setIsBusy(false);
+								setProgress(undefined);
+								setSubagents([]);
+								setApprovals([]);
+								console.error("Watch error:", event.event.value.message);
+								items.length = 0;
+								setStreamingItems([]);
+								break;
+						}
+					}
+				} catch (err) {
+					// AbortError is expected on cleanup
+					if (err instanceof DOMException && err.name === "AbortError") {
+						return;
 					}
+					console.error("WatchEvents stream error:", err);
 				}
-			} catch (err) {
-				// AbortError is expected on cleanup
-				if (err instanceof DOMException && err.name === "AbortError") {
-					return;
+				// Reconnect after the stream ends, e.g. when the server restarts.
+				if (!gap) {
+					await new Promise((resolve) => setTimeout(resolve, 1000));
 				}
-				console.error("WatchEvents stream error:", err);
 			}
 		})();
 
@@ -379,10 +679,12 @@ function ConversationChat() {
 	}, []);
 
 	const sendMessage = async () => {
-		if (!input.trim() || isBusy) return;
+		if ((!input.trim() && files.length === 0) || isBusy) return;
 
 		const userMessage = input.trim();
+		const attachedFiles = files;
 		setInput("");
+		setFiles([]);
 		if (textareaRef.current) {
 			textareaRef.current.style.height = "auto";
 		}
@@ -393,16 +695,33 @@ function ConversationChat() {
 			{
 				id: "pending-user",
 				role: "user",
-				items: [{ type: "text", content: userMessage }],
+				items: [
+					{ type: "text", content: userMessage },
+					...attachedFiles.map(
+						(file): MessageItemAttachment => ({
+							type: "attachment",
+							name: file.name,
+							mediaType: file.type,
+						}),
+					),
+				],
 			},
 		]);
 		setIsBusy(true);
 
 		try {
 			const client = createClient(ConversationService, transport);
+			const attachments = await Promise.all(
+				attachedFiles.map(async (file) => ({
+					name: file.name,
+					mediaType: file.type,
+					data: new Uint8Array(await file.arrayBuffer()),
+				})),
+			);
 			const resp = await client.chat({
 				conversationId,
 				content: userMessage,
+				attachments,
 			});
 
 			// Patch optimistic message with real ID
@@ -413,12 +732,62 @@ function ConversationChat() {
 			);
 		} catch (err) {
 			console.error("Chat error:", err);
-			// Remove optimistic message on failure
+			toast.error(
+				errorCode(err) === ErrorCode.CONVERSATION_BUSY
+					? "The agent is still responding to a previous message"
+					: ConnectError.from(err).rawMessage,
+			);
+			// Remove optimistic message on failure, and restore the files
 			setMessages((prev) => prev.filter((m) => m.id !== "pending-user"));
+			setFiles(attachedFiles);
 			setIsBusy(false);
 		}
 	};
 
+	// Cancels the conversation's active turn. The stored output and
+	// TurnDone arrive as events.
+	const stopTurn = async () => {
+		try {
+			const client = createClient(SystemService, transport);
+			const { runs } = await client.listActiveRuns({ agentId });
+			for (const run of runs) {
+				if (run.conversationId === conversationId) {
+					await client.cancelRun({ id: run.id });
+				}
+			}
+		} catch (err) {
+			console.error("Stop error:", err);
+		}
+	};
+
+	const resolveApproval = async (id: string, approved: boolean) => {
+		try {
+			const client = createClient(SystemService, transport);
+			await client.resolveApproval({ id, approved });
+		} catch (err) {
+			toast.error(
+				errorCode(err) === ErrorCode.APPROVAL_NOT_FOUND
+					? "The tool call is no longer waiting for approval"
+					: ConnectError.from(err).rawMessage,
+			);
+		}
+	};
+
+	// Drafts a disabled trigger repeating this conversation's task, and opens
+	// it for review.
+	const scheduleConversation = async () => {
+		try {
+			const trigger = await scheduleMutation.mutateAsync({ conversationId });
+			toast.success("Drafted a trigger; review it before enabling it");
+			navigate({
+				to: "/triggers/$triggerId",
+				params: { triggerId: trigger.id },
+			});
+		} catch (err) {
+			toast.error(ConnectError.from(err).rawMessage);
+		}
+	};
+
 	const handleKeyDown = (e: React.KeyboardEvent) => {
 		if (e.key === "Enter" && !e.shiftKey) {
 			e.preventDefault();
@@ -431,6 +800,28 @@ function ConversationChat() {
 			{/* Header */}
 			<div className="flex items-center justify-between border-b px-4 pb-4 pt-4 md:px-6">
 				<h1 className="text-lg font-semibold">{title || "Chat"}</h1>
+				<div className="flex items-center gap-3">
+					{costData && costData.inputTokens + costData.outputTokens > 0n && (
+						<span
+							className="text-xs text-muted-foreground"
+							title={`${costData.inputTokens} input, ${costData.outputTokens} output tokens`}
+						>
+							{formatCost(costData.costUsd, costData.priced)}
+						</span>
+					)}
+					{messages.length > 0 && (
+						<Button
+							variant="outline"
+							size="sm"
+							onClick={scheduleConversation}
+							disabled={isBusy || scheduleMutation.isPending}
+							title="Turn this chat into a scheduled job"
+						>
+							<CalendarClock className="h-4 w-4" />
+							{scheduleMutation.isPending ? "Drafting..." : "Schedule"}
+						</Button>
+					)}
+				</div>
 			</div>
 
 			{/* Messages */}
@@ -465,9 +856,19 @@ function ConversationChat() {
 											items: streamingItems,
 										}}
 										isBusy
+										progress={progress}
 									/>
 								</div>
 							)}
+							{isBusy && subagents.length > 0 && (
+								<SubagentActivity runs={subagents} />
+							)}
+							{isBusy && approvals.length > 0 && (
+								<PendingApprovals
+									approvals={approvals}
+									onResolve={resolveApproval}
+								/>
+							)}
 							{isBusy && streamingItems.length === 0 && messages.length > 0 && (
 								<div ref={lastMessageRef}>
 									<MessageBubble
@@ -477,6 +878,7 @@ function ConversationChat() {
 											items: [],
 										}}
 										isBusy
+										progress={progress}
 									/>
 								</div>
 							)}
@@ -488,7 +890,52 @@ function ConversationChat() {
 			{/* Input */}
 			<div className="shrink-0 pt-2 pb-[env(safe-area-inset-bottom)] md:pb-4 md:px-6">
 				<div className="mx-auto max-w-3xl px-4 md:px-6">
+					{files.length > 0 && (
+						<div className="mb-2 flex flex-wrap gap-2">
+							{files.map((file, i) => (
+								<div
+									key={`${file.name}-${i}`}
+									className="flex max-w-64 items-center gap-1.5 rounded-lg border bg-background px-2 py-1 text-sm"
+								>
+									<Paperclip className="h-3.5 w-3.5 shrink-0" />
+									<span className="truncate">{file.name}</span>
+									<button
+										type="button"
+										onClick={() =>
+											setFiles((prev) => prev.filter((_, j) => j !== i))
+										}
+										className="text-muted-foreground hover:text-foreground"
+									>
+										<X className="h-3.5 w-3.5" />
+										<span className="sr-only">Remove {file.name}</span>
+									</button>
+								</div>
+							))}
+						</div>
+					)}
 					<div className="flex items-end gap-2 rounded-lg border bg-background p-2 shadow-sm">
+						<input
+							ref={fileInputRef}
+							type="file"
+							multiple
+							accept={attachmentAccept}
+							className="hidden"
+							onChange={(e) => {
+								const selected = Array.from(e.target.files ?? []);
+								setFiles((prev) => [...prev, ...selected]);
+								e.target.value = "";
+							}}
+						/>
+						<Button
+							onClick={() => fileInputRef.current?.click()}
+							size="icon"
+							variant="ghost"
+							className="h-9 w-9 shrink-0"
+							title="Attach files"
+						>
+							<Paperclip className="h-4 w-4" />
+							<span className="sr-only">Attach files</span>
+						</Button>
 						<Textarea
 							ref={textareaRef}
 							value={input}
@@ -502,19 +949,27 @@ function ConversationChat() {
 							className="min-h-0 max-h-48 flex-1 resize-none border-0 bg-transparent p-2 shadow-none focus-visible:ring-0"
 							rows={1}
 						/>
-						<Button
-							onClick={sendMessage}
-							disabled={isBusy || !input.trim()}
-							size="icon"
-							className="h-9 w-9 shrink-0"
-						>
-							{isBusy ? (
-								<Loader2 className="h-4 w-4 animate-spin" />
-							) : (
+						{isBusy ? (
+							<Button
+								onClick={stopTurn}
+								size="icon"
+								variant="outline"
+								className="h-9 w-9 shrink-0"
+							>
+								<Square className="h-4 w-4" />
+								<span className="sr-only">Stop</span>
+							</Button>
+						) : (
+							<Button
+								onClick={sendMessage}
+								disabled={!input.trim() && files.length === 0}
+								size="icon"
+								className="h-9 w-9 shrink-0"
+							>
 								<ArrowUp className="h-4 w-4" />
-							)}
-							<span className="sr-only">Send message</span>
-						</Button>
+								<span className="sr-only">Send message</span>
+							</Button>
+						)}
 					</div>
 				</div>
 			</div>
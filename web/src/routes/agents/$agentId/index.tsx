@@ -1,16 +1,20 @@
 import { useMutation, useQuery } from "@connectrpc/connect-query";
 import { createFileRoute, Link, useNavigate } from "@tanstack/react-router";
-import { MessageSquare, Plus, Settings } from "lucide-react";
+import { MessageSquare, Plus, Search, Settings } from "lucide-react";
+import { useState } from "react";
 
+import { ConversationSearchResults } from "@/components/conversation-search-results";
 import { ConversationsTable } from "@/components/conversations-table";
 import { EmptyState } from "@/components/empty-state";
 import { PageContent } from "@/components/page-content";
 import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
 import { Skeleton } from "@/components/ui/skeleton";
 import { getAgent } from "@/lib/rpc/agent/agent-AgentService_connectquery";
 import {
 	createConversation,
 	listConversations,
+	searchConversations,
 } from "@/lib/rpc/conversation/conversation-ConversationService_connectquery";
 
 export const Route = createFileRoute("/agents/$agentId/")({
@@ -23,6 +27,13 @@ function ConversationsPage() {
 	const { data: agent } = useQuery(getAgent, { id: agentId });
 	const { data, isLoading } = useQuery(listConversations, { agentId });
 	const createConvMutation = useMutation(createConversation);
+	const [query, setQuery] = useState("");
+	const [searchQuery, setSearchQuery] = useState("");
+	const { data: searchData, isLoading: isSearching } = useQuery(
+		searchConversations,
+		{ agentId, query: searchQuery },
+		{ enabled: searchQuery !== "" },
+	);
 
 	const conversations = data?.conversations ?? [];
 
@@ -62,7 +73,44 @@ function ConversationsPage() {
 				</div>
 			</div>
 
-			{isLoading ? (
+			<form
+				className="relative max-w-md"
+				onSubmit={(e) => {
+					e.preventDefault();
+					setSearchQuery(query.trim());
+				}}
+			>
+				<Search className="absolute top-1/2 left-2.5 h-4 w-4 -translate-y-1/2 text-muted-foreground" />
+				<Input
+					type="search"
+					value={query}
+					onChange={(e) => {
+						setQuery(e.target.value);
+						if (e.target.value === "") {
+							setSearchQuery("");
+						}
+					}}
+					placeholder="Search conversations"
+					className="pl-8"
+				/>
+			</form>
+
+			{searchQuery !== "" ? (
+				isSearching ? (
+					<Skeleton className="h-10 w-full" />
+				) : searchData?.results.length ? (
+					<ConversationSearchResults
+						results={searchData.results}
+						agentId={agentId}
+					/>
+				) : (
+					<EmptyState
+						icon={<Search />}
+						title="No matching conversations"
+						description={`Nothing matched "${searchQuery}"`}
+					/>
+				)
+			) : isLoading ? (
 				<div className="space-y-2">
 					<Skeleton className="h-10 w-full" />
 					<Skeleton className="h-10 w-full" />
@@ -4,6 +4,7 @@ import { Check, ChevronsUpDown, Trash2, X } from "lucide-react";
 import { useEffect, useState } from "react";
 import { toast } from "sonner";
 import { PageContent } from "@/components/page-content";
+import { ToolStatsCard } from "@/components/tool-stats-card";
 import { Button } from "@/components/ui/button";
 import {
 	Card,
@@ -30,6 +31,7 @@ import {
 } from "@/components/ui/popover";
 import { Skeleton } from "@/components/ui/skeleton";
 import { Textarea } from "@/components/ui/textarea";
+import { isStaleVersionError } from "@/lib/api";
 import {
 	deleteAgent,
 	getAgent,
@@ -51,6 +53,58 @@ const fsTools = [
 	{ name: "fs_insert", label: "Insert" },
 ] as const;
 
+const builtinHooks = [
+	{
+		name: "memory",
+		label: "Auto Memory",
+		description: "Distill facts worth remembering into MEMORY.md",
+	},
+	{
+		name: "usage",
+		label: "Usage Recording",
+		description: "Record the tokens used by each run",
+	},
+	{
+		name: "notify_error",
+		label: "Notify on Error",
+		description: "Notify the agent's channels when a run fails or times out",
+	},
+	{
+		name: "webhook",
+		label: "Webhook",
+		description: "POST the result of each run to a URL",
+	},
+] as const;
+
+interface ApprovalRuleInput {
+	tool: string;
+	argument: string;
+	pattern: string;
+	// Empty means no size condition.
+	largerThanBytes: string;
+}
+
+const emptyPersona = { role: "", goals: "", constraints: "", style: "" };
+
+const personaSections = [
+	{
+		key: "role",
+		label: "Role",
+		placeholder: "You are the on-call assistant of the platform team.",
+	},
+	{ key: "goals", label: "Goals", placeholder: "Keep incidents short." },
+	{
+		key: "constraints",
+		label: "Constraints",
+		placeholder: "Never restart production services without asking.",
+	},
+	{
+		key: "style",
+		label: "Style",
+		placeholder: "Answer in short bullet points.",
+	},
+] as const;
+
 function AgentPage() {
 	const { agentId } = Route.useParams();
 	const navigate = useNavigate();
@@ -62,8 +116,10 @@ function AgentPage() {
 	const deleteMutation = useMutation(deleteAgent);
 
 	const [name, setName] = useState("");
+	const [version, setVersion] = useState(0n);
 	const [description, setDescription] = useState("");
 	const [systemPrompt, setSystemPrompt] = useState("");
+	const [persona, setPersona] = useState(emptyPersona);
 	const [enabledTools, setEnabledTools] = useState<string[]>([]);
 	const [enabledNotificationChannels, setEnabledNotificationChannels] =
 		useState<string[]>([]);
@@ -76,12 +132,29 @@ function AgentPage() {
 		[],
 	);
 	const [newEnvVar, setNewEnvVar] = useState("");
+	const [hooks, setHooks] = useState<{ name: string; config: string }[]>([]);
+	const [approvalRules, setApprovalRules] = useState<ApprovalRuleInput[]>([]);
+	// Empty means no limit.
+	const [maxConcurrentRuns, setMaxConcurrentRuns] = useState("");
+	const [titleGeneration, setTitleGeneration] = useState(true);
+	// Empty uses the server defaults.
+	const [titlePrompt, setTitlePrompt] = useState("");
+	const [titleModel, setTitleModel] = useState("");
+	// Empty is English.
+	const [locale, setLocale] = useState("");
 
 	useEffect(() => {
 		if (agent) {
+			setVersion(agent.version);
 			setName(agent.name);
 			setDescription(agent.description);
 			setSystemPrompt(agent.systemPrompt);
+			setPersona({
+				role: agent.persona?.role ?? "",
+				goals: agent.persona?.goals ?? "",
+				constraints: agent.persona?.constraints ?? "",
+				style: agent.persona?.style ?? "",
+			});
 			setEnabledTools(agent.enabledTools || []);
 			setEnabledNotificationChannels(agent.enabledNotificationChannels || []);
 			setEnabledFilesystemRoots(
@@ -92,26 +165,98 @@ function AgentPage() {
 			);
 			setModel(agent.model);
 			setForwardedHostEnvVars(agent.forwardedHostEnvVars || []);
+			setHooks(
+				agent.hooks?.map((h) => ({ name: h.name, config: h.config })) || [],
+			);
+			setApprovalRules(
+				agent.approvalRules?.map((r) => ({
+					tool: r.tool,
+					argument: r.argument,
+					pattern: r.pattern,
+					largerThanBytes: r.largerThanBytes ? String(r.largerThanBytes) : "",
+				})) || [],
+			);
+			setMaxConcurrentRuns(
+				agent.maxConcurrentRuns ? String(agent.maxConcurrentRuns) : "",
+			);
+			setTitleGeneration(!agent.titleGenerationDisabled);
+			setTitlePrompt(agent.titlePrompt);
+			setTitleModel(agent.titleModel);
+			setLocale(agent.locale);
 		}
 	}, [agent]);
 
+	const toggleHook = (name: string, checked: boolean) => {
+		setHooks((prev) =>
+			checked
+				? [...prev, { name, config: "" }]
+				: prev.filter((h) => h.name !== name),
+		);
+	};
+
+	const webhookUrl = (() => {
+		const config = hooks.find((h) => h.name === "webhook")?.config;
+		try {
+			return config ? (JSON.parse(config).url ?? "") : "";
+		} catch {
+			return "";
+		}
+	})();
+
+	const setWebhookUrl = (url: string) => {
+		setHooks((prev) =>
+			prev.map((h) =>
+				h.name === "webhook" ? { ...h, config: JSON.stringify({ url }) } : h,
+			),
+		);
+	};
+
+	const updateApprovalRule = (
+		index: number,
+		field: keyof ApprovalRuleInput,
+		value: string,
+	) => {
+		setApprovalRules((prev) =>
+			prev.map((r, i) => (i === index ? { ...r, [field]: value } : r)),
+		);
+	};
+
 	const handleSubmit = async (e: React.FormEvent) => {
 		e.preventDefault();
 		try {
-			await updateMutation.mutateAsync({
+			const updated = await updateMutation.mutateAsync({
 				id: agentId,
+				version,
 				name,
 				description,
 				systemPrompt,
+				persona,
 				enabledTools,
 				enabledNotificationChannels,
 				enabledFilesystemRoots,
 				model,
 				forwardedHostEnvVars,
+				hooks,
+				approvalRules: approvalRules.map((r) => ({
+					tool: r.tool.trim(),
+					argument: r.argument.trim(),
+					pattern: r.pattern,
+					largerThanBytes: Number(r.largerThanBytes),
+				})),
+				maxConcurrentRuns: Number(maxConcurrentRuns),
+				titleGenerationDisabled: !titleGeneration,
+				titlePrompt,
+				titleModel,
+				locale: locale.trim(),
 			});
+			setVersion(updated.version);
 			toast.success("Agent updated");
-		} catch {
-			toast.error("Failed to update agent");
+		} catch (err) {
+			toast.error(
+				isStaleVersionError(err)
+					? "Agent was changed elsewhere. Reload to get the latest version."
+					: "Failed to update agent",
+			);
 		}
 	};
 
@@ -138,6 +283,10 @@ function AgentPage() {
 		"memory_delete",
 	];
 	const memoryEnabled = memoryTools.every((t) => enabledTools.includes(t));
+	const spawnTools = ["spawn_agent", "check_agent_run"];
+	const spawnEnabled = spawnTools.every((t) => enabledTools.includes(t));
+	const contextTools = ["set_context", "get_context"];
+	const contextEnabled = contextTools.every((t) => enabledTools.includes(t));
 
 	const toggleTool = (toolName: string) => {
 		setEnabledTools((prev) =>
@@ -155,6 +304,22 @@ function AgentPage() {
 		);
 	};
 
+	const toggleContext = () => {
+		setEnabledTools((prev) =>
+			contextEnabled
+				? prev.filter((t) => !contextTools.includes(t))
+				: [...prev.filter((t) => !contextTools.includes(t)), ...contextTools],
+		);
+	};
+
+	const toggleSpawn = () => {
+		setEnabledTools((prev) =>
+			spawnEnabled
+				? prev.filter((t) => !spawnTools.includes(t))
+				: [...prev.filter((t) => !spawnTools.includes(t)), ...spawnTools],
+		);
+	};
+
 	const toggleNotificationChannel = (channelId: string) => {
 		setEnabledNotificationChannels((prev) =>
 			prev.includes(channelId)
@@ -257,6 +422,36 @@ function AgentPage() {
 							/>
 						</div>
 
+						<div className="space-y-2">
+							<Label>Persona</Label>
+							<p className="text-xs text-muted-foreground">
+								Sections that come before the system prompt. Empty sections
+								are left out
+							</p>
+							{personaSections.map((section) => (
+								<div key={section.key} className="space-y-1">
+									<Label
+										htmlFor={`persona-${section.key}`}
+										className="text-sm font-normal"
+									>
+										{section.label}
+									</Label>
+									<Textarea
+										id={`persona-${section.key}`}
+										value={persona[section.key]}
+										onChange={(e) =>
+											setPersona((prev) => ({
+												...prev,
+												[section.key]: e.target.value,
+											}))
+										}
+										placeholder={section.placeholder}
+										rows={2}
+									/>
+								</div>
+							))}
+						</div>
+
 						<div className="space-y-2">
 							<Label htmlFor="systemPrompt">System Prompt</Label>
 							<Textarea
@@ -399,6 +594,19 @@ function AgentPage() {
 										</span>
 									</label>
 								</div>
+								<div className="flex items-center space-x-2">
+									<Checkbox
+										id="tool-usage"
+										checked={enabledTools.includes("get_usage_report")}
+										onCheckedChange={() => toggleTool("get_usage_report")}
+									/>
+									<label htmlFor="tool-usage" className="text-sm leading-none">
+										Usage Report
+										<span className="ml-2 text-xs text-muted-foreground">
+											— Runs, failures, tokens and cost of all agents
+										</span>
+									</label>
+								</div>
 								<div className="flex items-center space-x-2">
 									<Checkbox
 										id="tool-call"
@@ -412,6 +620,19 @@ function AgentPage() {
 										</span>
 									</label>
 								</div>
+								<div className="flex items-center space-x-2">
+									<Checkbox
+										id="tool-spawn"
+										checked={spawnEnabled}
+										onCheckedChange={toggleSpawn}
+									/>
+									<label htmlFor="tool-spawn" className="text-sm leading-none">
+										Spawn Agent
+										<span className="ml-2 text-xs text-muted-foreground">
+											— Start agents in the background and check on them later
+										</span>
+									</label>
+								</div>
 								<div className="flex items-center space-x-2">
 									<Checkbox
 										id="tool-memory"
@@ -425,6 +646,22 @@ function AgentPage() {
 										</span>
 									</label>
 								</div>
+								<div className="flex items-center space-x-2">
+									<Checkbox
+										id="tool-context"
+										checked={contextEnabled}
+										onCheckedChange={toggleContext}
+									/>
+									<label
+										htmlFor="tool-context"
+										className="text-sm leading-none"
+									>
+										Conversation Context
+										<span className="ml-2 text-xs text-muted-foreground">
+											— Keep values such as IDs between turns
+										</span>
+									</label>
+								</div>
 							</div>
 						</div>
 
@@ -580,6 +817,202 @@ function AgentPage() {
 							)}
 						</div>
 
+						<div className="space-y-2">
+							<Label htmlFor="maxConcurrentRuns">Max Concurrent Runs</Label>
+							<Input
+								id="maxConcurrentRuns"
+								type="number"
+								min={1}
+								value={maxConcurrentRuns}
+								onChange={(e) => setMaxConcurrentRuns(e.target.value)}
+								placeholder="No limit"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Trigger and webhook runs beyond this limit wait for a running one
+								to finish
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="locale">Locale</Label>
+							<Input
+								id="locale"
+								value={locale}
+								onChange={(e) => setLocale(e.target.value)}
+								placeholder="English"
+								className="font-mono"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Language tag such as de-DE or nl. The agent responds in its
+								language, and titles and {"{date}"} placeholders use it
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label>Conversation Titles</Label>
+							<div className="flex items-center space-x-2">
+								<Checkbox
+									id="titleGeneration"
+									checked={titleGeneration}
+									onCheckedChange={(checked) =>
+										setTitleGeneration(checked === true)
+									}
+								/>
+								<label
+									htmlFor="titleGeneration"
+									className="text-sm leading-none"
+								>
+									Generate a title after the first turn
+								</label>
+							</div>
+							{titleGeneration && (
+								<>
+									<Input
+										id="titleModel"
+										value={titleModel}
+										onChange={(e) => setTitleModel(e.target.value)}
+										placeholder="Model (server default)"
+										className="font-mono"
+									/>
+									<Textarea
+										id="titlePrompt"
+										value={titlePrompt}
+										onChange={(e) => setTitlePrompt(e.target.value)}
+										placeholder="Prompt (server default)"
+										rows={4}
+									/>
+									<p className="text-xs text-muted-foreground">
+										{"{user}"} and {"{assistant}"} in the prompt are replaced
+										with the first exchange
+									</p>
+								</>
+							)}
+						</div>
+
+						<div className="space-y-2">
+							<Label>Post-Turn Hooks</Label>
+							<p className="text-xs text-muted-foreground">
+								Run after each turn, in the background
+							</p>
+							<div className="space-y-3">
+								{builtinHooks.map((hook) => (
+									<div key={hook.name} className="space-y-2">
+										<div className="flex items-center space-x-2">
+											<Checkbox
+												id={`hook-${hook.name}`}
+												checked={hooks.some((h) => h.name === hook.name)}
+												onCheckedChange={(checked) =>
+													toggleHook(hook.name, checked === true)
+												}
+											/>
+											<label
+												htmlFor={`hook-${hook.name}`}
+												className="text-sm leading-none"
+											>
+												{hook.label}
+												<span className="ml-2 text-xs text-muted-foreground">
+													— {hook.description}
+												</span>
+											</label>
+										</div>
+										{hook.name === "webhook" &&
+											hooks.some((h) => h.name === "webhook") && (
+												<Input
+													type="url"
+													placeholder="https://example.com/hook"
+													value={webhookUrl}
+													onChange={(e) => setWebhookUrl(e.target.value)}
+													className="ml-6 w-auto"
+												/>
+											)}
+									</div>
+								))}
+							</div>
+						</div>
+
+						<div className="space-y-2">
+							<Label>Approval Rules</Label>
+							<p className="text-xs text-muted-foreground">
+								Tool calls matching a rule wait until they're approved in the
+								chat. A rule matches calls of its tool ("notify:*" for all
+								channels) whose argument, or all arguments if empty, matches the
+								pattern and is larger than the size, where set
+							</p>
+							{approvalRules.map((rule, index) => (
+								// biome-ignore lint/suspicious/noArrayIndexKey: rules have no ID
+								<div key={index} className="flex items-center gap-2">
+									<Input
+										value={rule.tool}
+										onChange={(e) =>
+											updateApprovalRule(index, "tool", e.target.value)
+										}
+										placeholder="Tool, e.g. bash"
+										className="font-mono"
+									/>
+									<Input
+										value={rule.argument}
+										onChange={(e) =>
+											updateApprovalRule(index, "argument", e.target.value)
+										}
+										placeholder="Argument, e.g. command"
+										className="font-mono"
+									/>
+									<Input
+										value={rule.pattern}
+										onChange={(e) =>
+											updateApprovalRule(index, "pattern", e.target.value)
+										}
+										placeholder="Pattern, e.g. rm|curl"
+										className="font-mono"
+									/>
+									<Input
+										type="number"
+										min={0}
+										value={rule.largerThanBytes}
+										onChange={(e) =>
+											updateApprovalRule(
+												index,
+												"largerThanBytes",
+												e.target.value,
+											)
+										}
+										placeholder="Larger than bytes"
+										className="w-40"
+									/>
+									<Button
+										type="button"
+										variant="ghost"
+										size="icon"
+										onClick={() =>
+											setApprovalRules((prev) =>
+												prev.filter((_, i) => i !== index),
+											)
+										}
+									>
+										<X className="h-4 w-4" />
+										<span className="sr-only">Remove rule</span>
+									</Button>
+								</div>
+							))}
+							<Button
+								type="button"
+								variant="secondary"
+								onClick={() =>
+									setApprovalRules((prev) => [
+										...prev,
+										{
+											tool: "",
+											argument: "",
+											pattern: "",
+											largerThanBytes: "",
+										},
+									])
+								}
+							>
+								Add Rule
+							</Button>
+						</div>
+
 						<Button type="submit" disabled={updateMutation.isPending}>
 							{updateMutation.isPending ? "Saving..." : "Save Changes"}
 						</Button>
@@ -587,6 +1020,8 @@ function AgentPage() {
 				</CardContent>
 			</Card>
 
+			<ToolStatsCard agentId={agent.id} />
+
 			<Card className="border-destructive/50">
 				<CardHeader>
 					<CardTitle>Danger Zone</CardTitle>
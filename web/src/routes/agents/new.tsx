@@ -93,6 +93,10 @@ function NewAgent() {
 		"memory_delete",
 	];
 	const memoryEnabled = memoryTools.every((t) => enabledTools.includes(t));
+	const spawnTools = ["spawn_agent", "check_agent_run"];
+	const spawnEnabled = spawnTools.every((t) => enabledTools.includes(t));
+	const contextTools = ["set_context", "get_context"];
+	const contextEnabled = contextTools.every((t) => enabledTools.includes(t));
 
 	const toggleTool = (toolName: string) => {
 		setEnabledTools((prev) =>
@@ -110,6 +114,22 @@ function NewAgent() {
 		);
 	};
 
+	const toggleContext = () => {
+		setEnabledTools((prev) =>
+			contextEnabled
+				? prev.filter((t) => !contextTools.includes(t))
+				: [...prev.filter((t) => !contextTools.includes(t)), ...contextTools],
+		);
+	};
+
+	const toggleSpawn = () => {
+		setEnabledTools((prev) =>
+			spawnEnabled
+				? prev.filter((t) => !spawnTools.includes(t))
+				: [...prev.filter((t) => !spawnTools.includes(t)), ...spawnTools],
+		);
+	};
+
 	const toggleNotificationChannel = (channelId: string) => {
 		setEnabledNotificationChannels((prev) =>
 			prev.includes(channelId)
@@ -321,6 +341,19 @@ function NewAgent() {
 										</span>
 									</label>
 								</div>
+								<div className="flex items-center space-x-2">
+									<Checkbox
+										id="tool-usage"
+										checked={enabledTools.includes("get_usage_report")}
+										onCheckedChange={() => toggleTool("get_usage_report")}
+									/>
+									<label htmlFor="tool-usage" className="text-sm leading-none">
+										Usage Report
+										<span className="ml-2 text-xs text-muted-foreground">
+											— Runs, failures, tokens and cost of all agents
+										</span>
+									</label>
+								</div>
 								<div className="flex items-center space-x-2">
 									<Checkbox
 										id="tool-call"
@@ -334,6 +367,19 @@ function NewAgent() {
 										</span>
 									</label>
 								</div>
+								<div className="flex items-center space-x-2">
+									<Checkbox
+										id="tool-spawn"
+										checked={spawnEnabled}
+										onCheckedChange={toggleSpawn}
+									/>
+									<label htmlFor="tool-spawn" className="text-sm leading-none">
+										Spawn Agent
+										<span className="ml-2 text-xs text-muted-foreground">
+											— Start agents in the background and check on them later
+										</span>
+									</label>
+								</div>
 								<div className="flex items-center space-x-2">
 									<Checkbox
 										id="tool-memory"
@@ -347,6 +393,22 @@ function NewAgent() {
 										</span>
 									</label>
 								</div>
+								<div className="flex items-center space-x-2">
+									<Checkbox
+										id="tool-context"
+										checked={contextEnabled}
+										onCheckedChange={toggleContext}
+									/>
+									<label
+										htmlFor="tool-context"
+										className="text-sm leading-none"
+									>
+										Conversation Context
+										<span className="ml-2 text-xs text-muted-foreground">
+											— Keep values such as IDs between turns
+										</span>
+									</label>
+								</div>
 							</div>
 						</div>
 
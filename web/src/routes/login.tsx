@@ -0,0 +1,109 @@
+import { useMutation, useQuery } from "@connectrpc/connect-query";
+import { createFileRoute } from "@tanstack/react-router";
+import { useState } from "react";
+import { BlippyLogo } from "@/components/blippy-logo";
+import { Button } from "@/components/ui/button";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+	getLoginOptions,
+	login,
+} from "@/lib/rpc/auth/auth-AuthService_connectquery";
+
+export const Route = createFileRoute("/login")({
+	component: Login,
+	validateSearch: (search: Record<string, unknown>) => ({
+		redirect: typeof search.redirect === "string" ? search.redirect : "/",
+		error: typeof search.error === "string" ? search.error : undefined,
+	}),
+});
+
+function Login() {
+	const { redirect, error } = Route.useSearch();
+	const [apiKey, setApiKey] = useState("");
+	const loginMutation = useMutation(login);
+	const { data: options } = useQuery(getLoginOptions, {});
+
+	const handleSubmit = async (e: React.FormEvent) => {
+		e.preventDefault();
+		try {
+			await loginMutation.mutateAsync({ apiKey: apiKey.trim() });
+			// Only allow same-origin paths, to avoid open redirects.
+			const target =
+				redirect.startsWith("/") && !redirect.startsWith("//") ? redirect : "/";
+			// Reload, so queries that failed while logged out are refetched.
+			window.location.assign(target);
+		} catch {
+			// Shown below.
+		}
+	};
+
+	return (
+		<div className="flex min-h-svh items-center justify-center p-4">
+			<Card className="w-full max-w-sm">
+				<CardHeader className="items-center text-center">
+					<BlippyLogo className="mb-2 size-10" />
+					<CardTitle>Log in to Blippy</CardTitle>
+					<CardDescription>
+						Enter an API key. Create one with{" "}
+						<code className="text-xs">blippy apikey create NAME</code>.
+					</CardDescription>
+				</CardHeader>
+				<CardContent className="space-y-4">
+					{error && (
+						<p className="text-sm text-destructive">
+							{error === "forbidden"
+								? "Your account isn't in a group with access to Blippy."
+								: `Single sign-on failed: ${error}`}
+						</p>
+					)}
+					{options?.oidcEnabled && (
+						<>
+							<Button asChild variant="outline" className="w-full">
+								<a
+									href={`/auth/oidc/login?redirect=${encodeURIComponent(redirect)}`}
+								>
+									Log in with SSO
+								</a>
+							</Button>
+							<div className="text-center text-xs text-muted-foreground">
+								or
+							</div>
+						</>
+					)}
+					<form onSubmit={handleSubmit} className="space-y-4">
+						<div className="space-y-2">
+							<Label htmlFor="api-key">API key</Label>
+							<Input
+								id="api-key"
+								type="password"
+								autoComplete="current-password"
+								placeholder="blippy_…"
+								value={apiKey}
+								onChange={(e) => setApiKey(e.target.value)}
+								autoFocus
+							/>
+						</div>
+						{loginMutation.error && (
+							<p className="text-sm text-destructive">Invalid API key</p>
+						)}
+						<Button
+							type="submit"
+							className="w-full"
+							disabled={!apiKey.trim() || loginMutation.isPending}
+						>
+							Log in
+						</Button>
+					</form>
+				</CardContent>
+			</Card>
+		</div>
+	);
+}
@@ -23,6 +23,8 @@ import {
 } from "@/components/ui/select";
 import { Skeleton } from "@/components/ui/skeleton";
 import { Textarea } from "@/components/ui/textarea";
+import { isStaleVersionError } from "@/lib/api";
+import { listAgents } from "@/lib/rpc/agent/agent-AgentService_connectquery";
 import {
 	deleteNotificationChannel,
 	getNotificationChannel,
@@ -41,14 +43,43 @@ function NotificationChannelDetail() {
 	});
 	const updateMutation = useMutation(updateNotificationChannel);
 	const deleteMutation = useMutation(deleteNotificationChannel);
+	const { data: agentsData } = useQuery(listAgents);
 
 	const [name, setName] = useState("");
+	const [version, setVersion] = useState(0n);
 	const [type, setType] = useState("http_request");
 	const [url, setUrl] = useState("");
 	const [method, setMethod] = useState("POST");
 	const [headers, setHeaders] = useState("");
+	const [signingSecret, setSigningSecret] = useState("");
+	const [signatureHeader, setSignatureHeader] = useState("");
+	const [accountSid, setAccountSid] = useState("");
+	const [authToken, setAuthToken] = useState("");
+	const [smsFrom, setSmsFrom] = useState("");
+	const [smsTo, setSmsTo] = useState("");
+	const [smtpHost, setSmtpHost] = useState("");
+	const [smtpPort, setSmtpPort] = useState("");
+	const [smtpTls, setSmtpTls] = useState("starttls");
+	const [smtpUsername, setSmtpUsername] = useState("");
+	const [smtpPassword, setSmtpPassword] = useState("");
+	const [emailFrom, setEmailFrom] = useState("");
+	const [emailTo, setEmailTo] = useState("");
+	const [emailAllowed, setEmailAllowed] = useState("");
+	const [botToken, setBotToken] = useState("");
+	const [chatIds, setChatIds] = useState("");
+	const [botAgentId, setBotAgentId] = useState("none");
+	const [webhookSecret, setWebhookSecret] = useState("");
+	const [allowedChatIds, setAllowedChatIds] = useState("");
+	const [slackChannel, setSlackChannel] = useState("");
+	const [groupConfig, setGroupConfig] = useState("");
 	const [description, setDescription] = useState("");
 	const [jsonSchema, setJsonSchema] = useState("");
+	const [quietHoursStart, setQuietHoursStart] = useState("");
+	const [quietHoursEnd, setQuietHoursEnd] = useState("");
+	const [quietHoursTimezone, setQuietHoursTimezone] = useState("");
+	const [quietHoursMode, setQuietHoursMode] = useState("defer");
+	const [maxPerHour, setMaxPerHour] = useState(0);
+	const [digestSchedule, setDigestSchedule] = useState("");
 
 	const parsedConfig = useMemo(() => {
 		if (!channel?.config) return {};
@@ -61,10 +92,34 @@ function NotificationChannelDetail() {
 
 	useEffect(() => {
 		if (channel) {
+			setVersion(channel.version);
 			setName(channel.name);
 			setType(channel.type);
 			setUrl(parsedConfig.url ?? "");
 			setMethod(parsedConfig.method ?? "POST");
+			setSigningSecret(parsedConfig.signing_secret ?? "");
+			setSignatureHeader(parsedConfig.signature_header ?? "");
+			setAccountSid(parsedConfig.account_sid ?? "");
+			setAuthToken(parsedConfig.auth_token ?? "");
+			setSmsFrom(parsedConfig.from ?? "");
+			setSmsTo((parsedConfig.to ?? []).join(", "));
+			setSmtpHost(parsedConfig.host ?? "");
+			setSmtpPort(parsedConfig.port ? String(parsedConfig.port) : "");
+			setSmtpTls(parsedConfig.tls ?? "starttls");
+			setSmtpUsername(parsedConfig.username ?? "");
+			setSmtpPassword(parsedConfig.password ?? "");
+			setEmailFrom(parsedConfig.from ?? "");
+			setEmailTo((parsedConfig.to ?? []).join(", "));
+			setEmailAllowed((parsedConfig.allowed_recipients ?? []).join(", "));
+			setBotToken(parsedConfig.bot_token ?? "");
+			setChatIds((parsedConfig.chat_ids ?? []).join(", "));
+			setBotAgentId(parsedConfig.agent_id || "none");
+			setWebhookSecret(parsedConfig.webhook_secret ?? "");
+			setAllowedChatIds((parsedConfig.allowed_chat_ids ?? []).join(", "));
+			setSlackChannel(parsedConfig.channel ?? "");
+			setGroupConfig(
+				channel.type === "group" ? JSON.stringify(parsedConfig, null, 2) : "",
+			);
 			// Convert headers object back to "Key: Value" format
 			const hdrs = parsedConfig.headers ?? {};
 			setHeaders(
@@ -74,6 +129,12 @@ function NotificationChannelDetail() {
 			);
 			setDescription(channel.description ?? "");
 			setJsonSchema(channel.jsonSchema ?? "");
+			setQuietHoursStart(channel.quietHoursStart);
+			setQuietHoursEnd(channel.quietHoursEnd);
+			setQuietHoursTimezone(channel.quietHoursTimezone);
+			setQuietHoursMode(channel.quietHoursMode || "defer");
+			setMaxPerHour(channel.maxPerHour);
+			setDigestSchedule(channel.digestSchedule);
 		}
 	}, [channel, parsedConfig]);
 
@@ -93,19 +154,89 @@ function NotificationChannelDetail() {
 			}
 		}
 
-		const config = JSON.stringify({ url, method, headers: headerObj });
+		const config =
+			type === "sms"
+				? JSON.stringify({
+						account_sid: accountSid,
+						auth_token: authToken,
+						from: smsFrom,
+						to: smsTo
+							.split(",")
+							.map((n) => n.trim())
+							.filter(Boolean),
+					})
+				: type === "email"
+					? JSON.stringify({
+							host: smtpHost,
+							port: smtpPort ? Number(smtpPort) : undefined,
+							tls: smtpTls,
+							username: smtpUsername || undefined,
+							password: smtpPassword || undefined,
+							from: emailFrom,
+							to: emailTo
+								.split(",")
+								.map((a) => a.trim())
+								.filter(Boolean),
+							allowed_recipients: emailAllowed
+								.split(",")
+								.map((a) => a.trim())
+								.filter(Boolean),
+						})
+					: type === "telegram"
+						? JSON.stringify({
+								bot_token: botToken,
+								chat_ids: chatIds
+									.split(",")
+									.map((id) => id.trim())
+									.filter(Boolean),
+								agent_id: botAgentId === "none" ? undefined : botAgentId,
+								webhook_secret: webhookSecret || undefined,
+								allowed_chat_ids: allowedChatIds
+									.split(",")
+									.map((id) => id.trim())
+									.filter(Boolean),
+							})
+						: type === "slack"
+							? JSON.stringify({
+									bot_token: botToken,
+									channel: slackChannel,
+									signing_secret: signingSecret || undefined,
+								})
+							: type === "web_push"
+								? "{}"
+								: type === "group"
+									? groupConfig
+									: JSON.stringify({
+											url,
+											method,
+											headers: headerObj,
+											signing_secret: signingSecret || undefined,
+											signature_header: signatureHeader || undefined,
+										});
 		try {
-			await updateMutation.mutateAsync({
+			const updated = await updateMutation.mutateAsync({
 				id: channelId,
+				version,
 				name,
 				type,
 				config,
 				description,
 				jsonSchema,
+				quietHoursStart,
+				quietHoursEnd,
+				quietHoursTimezone,
+				quietHoursMode,
+				maxPerHour,
+				digestSchedule,
 			});
+			setVersion(updated.version);
 			toast.success("Channel updated");
-		} catch {
-			toast.error("Failed to update channel");
+		} catch (err) {
+			toast.error(
+				isStaleVersionError(err)
+					? "Channel was changed elsewhere. Reload to get the latest version."
+					: "Failed to update channel",
+			);
 		}
 	};
 
@@ -187,6 +318,12 @@ function NotificationChannelDetail() {
 								</SelectTrigger>
 								<SelectContent>
 									<SelectItem value="http_request">HTTP Request</SelectItem>
+									<SelectItem value="sms">SMS (Twilio)</SelectItem>
+									<SelectItem value="email">Email (SMTP)</SelectItem>
+									<SelectItem value="telegram">Telegram</SelectItem>
+									<SelectItem value="slack">Slack</SelectItem>
+									<SelectItem value="web_push">Web Push</SelectItem>
+									<SelectItem value="group">Group</SelectItem>
 								</SelectContent>
 							</Select>
 						</div>
@@ -231,9 +368,337 @@ function NotificationChannelDetail() {
 										One header per line in "Key: Value" format
 									</p>
 								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="signingSecret">Signing Secret</Label>
+									<Input
+										id="signingSecret"
+										type="password"
+										value={signingSecret}
+										onChange={(e) => setSigningSecret(e.target.value)}
+										autoComplete="off"
+									/>
+									<p className="text-xs text-muted-foreground">
+										Signs requests with HMAC-SHA256 over "timestamp.body"
+										(optional)
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="signatureHeader">Signature Header</Label>
+									<Input
+										id="signatureHeader"
+										value={signatureHeader}
+										onChange={(e) => setSignatureHeader(e.target.value)}
+										placeholder="X-Blippy-Signature"
+									/>
+								</div>
+							</>
+						)}
+
+						{type === "sms" && (
+							<>
+								<div className="space-y-2">
+									<Label htmlFor="accountSid">Twilio Account SID</Label>
+									<Input
+										id="accountSid"
+										value={accountSid}
+										onChange={(e) => setAccountSid(e.target.value)}
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="authToken">Twilio Auth Token</Label>
+									<Input
+										id="authToken"
+										type="password"
+										value={authToken}
+										onChange={(e) => setAuthToken(e.target.value)}
+										autoComplete="off"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smsFrom">From</Label>
+									<Input
+										id="smsFrom"
+										value={smsFrom}
+										onChange={(e) => setSmsFrom(e.target.value)}
+										placeholder="+15005550006"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smsTo">To</Label>
+									<Input
+										id="smsTo"
+										value={smsTo}
+										onChange={(e) => setSmsTo(e.target.value)}
+										placeholder="+31612345678, +31687654321"
+										required
+									/>
+									<p className="text-xs text-muted-foreground">
+										Comma-separated destination numbers
+									</p>
+								</div>
+							</>
+						)}
+
+						{type === "email" && (
+							<>
+								<div className="grid grid-cols-3 gap-4">
+									<div className="col-span-2 space-y-2">
+										<Label htmlFor="smtpHost">SMTP Host</Label>
+										<Input
+											id="smtpHost"
+											value={smtpHost}
+											onChange={(e) => setSmtpHost(e.target.value)}
+											placeholder="smtp.example.com"
+											required
+										/>
+									</div>
+									<div className="space-y-2">
+										<Label htmlFor="smtpPort">Port</Label>
+										<Input
+											id="smtpPort"
+											type="number"
+											value={smtpPort}
+											onChange={(e) => setSmtpPort(e.target.value)}
+											placeholder={smtpTls === "tls" ? "465" : "587"}
+										/>
+									</div>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smtpTls">TLS</Label>
+									<Select value={smtpTls} onValueChange={setSmtpTls}>
+										<SelectTrigger id="smtpTls">
+											<SelectValue />
+										</SelectTrigger>
+										<SelectContent>
+											<SelectItem value="starttls">STARTTLS</SelectItem>
+											<SelectItem value="tls">Implicit TLS</SelectItem>
+											<SelectItem value="none">None</SelectItem>
+										</SelectContent>
+									</Select>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smtpUsername">Username</Label>
+									<Input
+										id="smtpUsername"
+										value={smtpUsername}
+										onChange={(e) => setSmtpUsername(e.target.value)}
+										autoComplete="off"
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smtpPassword">Password</Label>
+									<Input
+										id="smtpPassword"
+										type="password"
+										value={smtpPassword}
+										onChange={(e) => setSmtpPassword(e.target.value)}
+										autoComplete="off"
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="emailFrom">From</Label>
+									<Input
+										id="emailFrom"
+										value={emailFrom}
+										onChange={(e) => setEmailFrom(e.target.value)}
+										placeholder="Blippy <blippy@example.com>"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="emailTo">To</Label>
+									<Input
+										id="emailTo"
+										value={emailTo}
+										onChange={(e) => setEmailTo(e.target.value)}
+										placeholder="ops@example.com"
+										required
+									/>
+									<p className="text-xs text-muted-foreground">
+										Comma-separated default recipients
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="emailAllowed">Allowed Recipients</Label>
+									<Input
+										id="emailAllowed"
+										value={emailAllowed}
+										onChange={(e) => setEmailAllowed(e.target.value)}
+										placeholder="lead@example.com, @example.org"
+									/>
+									<p className="text-xs text-muted-foreground">
+										Other addresses or @domains that notifications may be sent
+										to
+									</p>
+								</div>
+							</>
+						)}
+
+						{type === "telegram" && (
+							<>
+								<div className="space-y-2">
+									<Label htmlFor="botToken">Bot Token</Label>
+									<Input
+										id="botToken"
+										type="password"
+										value={botToken}
+										onChange={(e) => setBotToken(e.target.value)}
+										autoComplete="off"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="chatIds">Chat IDs</Label>
+									<Input
+										id="chatIds"
+										value={chatIds}
+										onChange={(e) => setChatIds(e.target.value)}
+										placeholder="123456789, -1001234567890"
+									/>
+									<p className="text-xs text-muted-foreground">
+										Comma-separated chats notifications are sent to
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="botAgentId">Answering Agent</Label>
+									<Select value={botAgentId} onValueChange={setBotAgentId}>
+										<SelectTrigger id="botAgentId">
+											<SelectValue />
+										</SelectTrigger>
+										<SelectContent>
+											<SelectItem value="none">None</SelectItem>
+											{(agentsData?.agents ?? []).map((agent) => (
+												<SelectItem key={agent.id} value={agent.id}>
+													{agent.name}
+												</SelectItem>
+											))}
+										</SelectContent>
+									</Select>
+									<p className="text-xs text-muted-foreground">
+										Agent that answers messages sent to the bot
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="webhookSecret">Webhook Secret</Label>
+									<Input
+										id="webhookSecret"
+										type="password"
+										value={webhookSecret}
+										onChange={(e) => setWebhookSecret(e.target.value)}
+										autoComplete="off"
+										required={botAgentId !== "none"}
+									/>
+									<p className="text-xs text-muted-foreground">
+										The secret_token the bot's webhook is registered with.
+										Required for the agent and for replies to notifications
+									</p>
+								</div>
+
+								{botAgentId !== "none" && (
+									<div className="space-y-2">
+										<Label htmlFor="allowedChatIds">Allowed Chat IDs</Label>
+										<Input
+											id="allowedChatIds"
+											value={allowedChatIds}
+											onChange={(e) => setAllowedChatIds(e.target.value)}
+											placeholder="123456789"
+										/>
+										<p className="text-xs text-muted-foreground">
+											Other chats the agent answers, or * for any chat
+										</p>
+									</div>
+								)}
+							</>
+						)}
+
+						{type === "slack" && (
+							<>
+								<div className="space-y-2">
+									<Label htmlFor="botToken">Bot Token</Label>
+									<Input
+										id="botToken"
+										type="password"
+										value={botToken}
+										onChange={(e) => setBotToken(e.target.value)}
+										placeholder="xoxb-..."
+										autoComplete="off"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="slackChannel">Channel ID</Label>
+									<Input
+										id="slackChannel"
+										value={slackChannel}
+										onChange={(e) => setSlackChannel(e.target.value)}
+										placeholder="C0123456789"
+										required
+									/>
+									<p className="text-xs text-muted-foreground">
+										Slack channel notifications are posted to
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="slackSigningSecret">Signing Secret</Label>
+									<Input
+										id="slackSigningSecret"
+										type="password"
+										value={signingSecret}
+										onChange={(e) => setSigningSecret(e.target.value)}
+										autoComplete="off"
+									/>
+									<p className="text-xs text-muted-foreground">
+										The Slack app's signing secret. Replies in notification
+										threads continue the conversation that sent them
+									</p>
+								</div>
 							</>
 						)}
 
+						{type === "group" && (
+							<div className="space-y-2">
+								<Label htmlFor="groupConfig">Routes</Label>
+								<Textarea
+									id="groupConfig"
+									value={groupConfig}
+									onChange={(e) => setGroupConfig(e.target.value)}
+									placeholder={`{
+  "severity_field": "severity",
+  "routes": [
+    { "severity": "critical", "channels": ["pager", "ops-sms"] }
+  ],
+  "default": ["team-chat"]
+}`}
+									rows={8}
+									className="font-mono text-sm"
+									required
+								/>
+								<p className="text-xs text-muted-foreground">
+									Channels are tried in order until one accepts the
+									notification. Set delivery settings on those channels.
+								</p>
+							</div>
+						)}
+
 						<div className="space-y-2">
 							<Label htmlFor="description">Description</Label>
 							<Textarea
@@ -269,6 +734,73 @@ function NotificationChannelDetail() {
 							</p>
 						</div>
 
+						<div className="space-y-2">
+							<Label>Quiet Hours</Label>
+							<div className="grid grid-cols-2 gap-3">
+								<Input
+									id="quietHoursStart"
+									type="time"
+									value={quietHoursStart}
+									onChange={(e) => setQuietHoursStart(e.target.value)}
+								/>
+								<Input
+									id="quietHoursEnd"
+									type="time"
+									value={quietHoursEnd}
+									onChange={(e) => setQuietHoursEnd(e.target.value)}
+								/>
+								<Input
+									id="quietHoursTimezone"
+									value={quietHoursTimezone}
+									onChange={(e) => setQuietHoursTimezone(e.target.value)}
+									placeholder="UTC"
+								/>
+								<Select value={quietHoursMode} onValueChange={setQuietHoursMode}>
+									<SelectTrigger id="quietHoursMode">
+										<SelectValue />
+									</SelectTrigger>
+									<SelectContent>
+										<SelectItem value="defer">Defer until after</SelectItem>
+										<SelectItem value="drop">Drop</SelectItem>
+									</SelectContent>
+								</Select>
+							</div>
+							<p className="text-xs text-muted-foreground">
+								Start, end, time zone and behavior for notifications sent during
+								quiet hours (optional)
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="maxPerHour">Max Notifications per Hour</Label>
+							<Input
+								id="maxPerHour"
+								type="number"
+								min={0}
+								value={maxPerHour}
+								onChange={(e) => setMaxPerHour(Number(e.target.value))}
+							/>
+							<p className="text-xs text-muted-foreground">
+								Extra notifications are combined into a digest. 0 means
+								unlimited
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="digestSchedule">Digest Schedule</Label>
+							<Input
+								id="digestSchedule"
+								value={digestSchedule}
+								onChange={(e) => setDigestSchedule(e.target.value)}
+								placeholder="0 9 * * *"
+								className="font-mono"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Cron expression. When set, notifications are collected and sent
+								as a single digest on this schedule (optional)
+							</p>
+						</div>
+
 						<Button type="submit" disabled={updateMutation.isPending}>
 							{updateMutation.isPending ? "Saving..." : "Save Changes"}
 						</Button>
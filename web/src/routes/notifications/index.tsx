@@ -11,6 +11,7 @@ import {
 	CardTitle,
 } from "@/components/ui/card";
 import { Skeleton } from "@/components/ui/skeleton";
+import { WebPushCard } from "@/components/web-push-card";
 import { listNotificationChannels } from "@/lib/rpc/notification/notification-NotificationChannelService_connectquery";
 
 export const Route = createFileRoute("/notifications/")({
@@ -49,6 +50,8 @@ function NotificationsIndex() {
 				</Button>
 			</div>
 
+			<WebPushCard />
+
 			{isLoading ? (
 				<div className="grid gap-4 sm:grid-cols-2 lg:grid-cols-3">
 					{[...Array(3)].map((_, i) => (
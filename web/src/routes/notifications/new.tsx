@@ -1,4 +1,4 @@
-import { useMutation } from "@connectrpc/connect-query";
+import { useMutation, useQuery } from "@connectrpc/connect-query";
 import { createFileRoute, Link, useNavigate } from "@tanstack/react-router";
 import { useState } from "react";
 import { toast } from "sonner";
@@ -21,6 +21,7 @@ import {
 	SelectValue,
 } from "@/components/ui/select";
 import { Textarea } from "@/components/ui/textarea";
+import { listAgents } from "@/lib/rpc/agent/agent-AgentService_connectquery";
 import { createNotificationChannel } from "@/lib/rpc/notification/notification-NotificationChannelService_connectquery";
 
 export const Route = createFileRoute("/notifications/new")({
@@ -30,14 +31,42 @@ export const Route = createFileRoute("/notifications/new")({
 function NewNotificationChannel() {
 	const navigate = useNavigate();
 	const mutation = useMutation(createNotificationChannel);
+	const { data: agentsData } = useQuery(listAgents);
 
 	const [name, setName] = useState("");
 	const [type, setType] = useState("http_request");
 	const [url, setUrl] = useState("");
 	const [method, setMethod] = useState("POST");
 	const [headers, setHeaders] = useState("");
+	const [signingSecret, setSigningSecret] = useState("");
+	const [signatureHeader, setSignatureHeader] = useState("");
+	const [accountSid, setAccountSid] = useState("");
+	const [authToken, setAuthToken] = useState("");
+	const [smsFrom, setSmsFrom] = useState("");
+	const [smsTo, setSmsTo] = useState("");
+	const [smtpHost, setSmtpHost] = useState("");
+	const [smtpPort, setSmtpPort] = useState("");
+	const [smtpTls, setSmtpTls] = useState("starttls");
+	const [smtpUsername, setSmtpUsername] = useState("");
+	const [smtpPassword, setSmtpPassword] = useState("");
+	const [emailFrom, setEmailFrom] = useState("");
+	const [emailTo, setEmailTo] = useState("");
+	const [emailAllowed, setEmailAllowed] = useState("");
+	const [botToken, setBotToken] = useState("");
+	const [chatIds, setChatIds] = useState("");
+	const [botAgentId, setBotAgentId] = useState("none");
+	const [webhookSecret, setWebhookSecret] = useState("");
+	const [allowedChatIds, setAllowedChatIds] = useState("");
+	const [slackChannel, setSlackChannel] = useState("");
+	const [groupConfig, setGroupConfig] = useState("");
 	const [description, setDescription] = useState("");
 	const [jsonSchema, setJsonSchema] = useState("");
+	const [quietHoursStart, setQuietHoursStart] = useState("");
+	const [quietHoursEnd, setQuietHoursEnd] = useState("");
+	const [quietHoursTimezone, setQuietHoursTimezone] = useState("");
+	const [quietHoursMode, setQuietHoursMode] = useState("defer");
+	const [maxPerHour, setMaxPerHour] = useState(0);
+	const [digestSchedule, setDigestSchedule] = useState("");
 
 	const handleSubmit = async (e: React.FormEvent) => {
 		e.preventDefault();
@@ -55,7 +84,65 @@ function NewNotificationChannel() {
 			}
 		}
 
-		const config = JSON.stringify({ url, method, headers: headerObj });
+		const config =
+			type === "sms"
+				? JSON.stringify({
+						account_sid: accountSid,
+						auth_token: authToken,
+						from: smsFrom,
+						to: smsTo
+							.split(",")
+							.map((n) => n.trim())
+							.filter(Boolean),
+					})
+				: type === "email"
+					? JSON.stringify({
+							host: smtpHost,
+							port: smtpPort ? Number(smtpPort) : undefined,
+							tls: smtpTls,
+							username: smtpUsername || undefined,
+							password: smtpPassword || undefined,
+							from: emailFrom,
+							to: emailTo
+								.split(",")
+								.map((a) => a.trim())
+								.filter(Boolean),
+							allowed_recipients: emailAllowed
+								.split(",")
+								.map((a) => a.trim())
+								.filter(Boolean),
+						})
+					: type === "telegram"
+						? JSON.stringify({
+								bot_token: botToken,
+								chat_ids: chatIds
+									.split(",")
+									.map((id) => id.trim())
+									.filter(Boolean),
+								agent_id: botAgentId === "none" ? undefined : botAgentId,
+								webhook_secret: webhookSecret || undefined,
+								allowed_chat_ids: allowedChatIds
+									.split(",")
+									.map((id) => id.trim())
+									.filter(Boolean),
+							})
+						: type === "slack"
+							? JSON.stringify({
+									bot_token: botToken,
+									channel: slackChannel,
+									signing_secret: signingSecret || undefined,
+								})
+							: type === "web_push"
+								? "{}"
+								: type === "group"
+									? groupConfig
+									: JSON.stringify({
+											url,
+											method,
+											headers: headerObj,
+											signing_secret: signingSecret || undefined,
+											signature_header: signatureHeader || undefined,
+										});
 
 		try {
 			const channel = await mutation.mutateAsync({
@@ -64,6 +151,12 @@ function NewNotificationChannel() {
 				config,
 				description,
 				jsonSchema,
+				quietHoursStart,
+				quietHoursEnd,
+				quietHoursTimezone,
+				quietHoursMode,
+				maxPerHour,
+				digestSchedule,
 			});
 			toast.success("Channel created");
 			navigate({
@@ -115,6 +208,12 @@ function NewNotificationChannel() {
 								</SelectTrigger>
 								<SelectContent>
 									<SelectItem value="http_request">HTTP Request</SelectItem>
+									<SelectItem value="sms">SMS (Twilio)</SelectItem>
+									<SelectItem value="email">Email (SMTP)</SelectItem>
+									<SelectItem value="telegram">Telegram</SelectItem>
+									<SelectItem value="slack">Slack</SelectItem>
+									<SelectItem value="web_push">Web Push</SelectItem>
+									<SelectItem value="group">Group</SelectItem>
 								</SelectContent>
 							</Select>
 						</div>
@@ -162,9 +261,337 @@ function NewNotificationChannel() {
 										One header per line in "Key: Value" format
 									</p>
 								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="signingSecret">Signing Secret</Label>
+									<Input
+										id="signingSecret"
+										type="password"
+										value={signingSecret}
+										onChange={(e) => setSigningSecret(e.target.value)}
+										autoComplete="off"
+									/>
+									<p className="text-xs text-muted-foreground">
+										Signs requests with HMAC-SHA256 over "timestamp.body"
+										(optional)
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="signatureHeader">Signature Header</Label>
+									<Input
+										id="signatureHeader"
+										value={signatureHeader}
+										onChange={(e) => setSignatureHeader(e.target.value)}
+										placeholder="X-Blippy-Signature"
+									/>
+								</div>
+							</>
+						)}
+
+						{type === "sms" && (
+							<>
+								<div className="space-y-2">
+									<Label htmlFor="accountSid">Twilio Account SID</Label>
+									<Input
+										id="accountSid"
+										value={accountSid}
+										onChange={(e) => setAccountSid(e.target.value)}
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="authToken">Twilio Auth Token</Label>
+									<Input
+										id="authToken"
+										type="password"
+										value={authToken}
+										onChange={(e) => setAuthToken(e.target.value)}
+										autoComplete="off"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smsFrom">From</Label>
+									<Input
+										id="smsFrom"
+										value={smsFrom}
+										onChange={(e) => setSmsFrom(e.target.value)}
+										placeholder="+15005550006"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smsTo">To</Label>
+									<Input
+										id="smsTo"
+										value={smsTo}
+										onChange={(e) => setSmsTo(e.target.value)}
+										placeholder="+31612345678, +31687654321"
+										required
+									/>
+									<p className="text-xs text-muted-foreground">
+										Comma-separated destination numbers
+									</p>
+								</div>
 							</>
 						)}
 
+						{type === "email" && (
+							<>
+								<div className="grid grid-cols-3 gap-4">
+									<div className="col-span-2 space-y-2">
+										<Label htmlFor="smtpHost">SMTP Host</Label>
+										<Input
+											id="smtpHost"
+											value={smtpHost}
+											onChange={(e) => setSmtpHost(e.target.value)}
+											placeholder="smtp.example.com"
+											required
+										/>
+									</div>
+									<div className="space-y-2">
+										<Label htmlFor="smtpPort">Port</Label>
+										<Input
+											id="smtpPort"
+											type="number"
+											value={smtpPort}
+											onChange={(e) => setSmtpPort(e.target.value)}
+											placeholder={smtpTls === "tls" ? "465" : "587"}
+										/>
+									</div>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smtpTls">TLS</Label>
+									<Select value={smtpTls} onValueChange={setSmtpTls}>
+										<SelectTrigger id="smtpTls">
+											<SelectValue />
+										</SelectTrigger>
+										<SelectContent>
+											<SelectItem value="starttls">STARTTLS</SelectItem>
+											<SelectItem value="tls">Implicit TLS</SelectItem>
+											<SelectItem value="none">None</SelectItem>
+										</SelectContent>
+									</Select>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smtpUsername">Username</Label>
+									<Input
+										id="smtpUsername"
+										value={smtpUsername}
+										onChange={(e) => setSmtpUsername(e.target.value)}
+										autoComplete="off"
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="smtpPassword">Password</Label>
+									<Input
+										id="smtpPassword"
+										type="password"
+										value={smtpPassword}
+										onChange={(e) => setSmtpPassword(e.target.value)}
+										autoComplete="off"
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="emailFrom">From</Label>
+									<Input
+										id="emailFrom"
+										value={emailFrom}
+										onChange={(e) => setEmailFrom(e.target.value)}
+										placeholder="Blippy <blippy@example.com>"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="emailTo">To</Label>
+									<Input
+										id="emailTo"
+										value={emailTo}
+										onChange={(e) => setEmailTo(e.target.value)}
+										placeholder="ops@example.com"
+										required
+									/>
+									<p className="text-xs text-muted-foreground">
+										Comma-separated default recipients
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="emailAllowed">Allowed Recipients</Label>
+									<Input
+										id="emailAllowed"
+										value={emailAllowed}
+										onChange={(e) => setEmailAllowed(e.target.value)}
+										placeholder="lead@example.com, @example.org"
+									/>
+									<p className="text-xs text-muted-foreground">
+										Other addresses or @domains that notifications may be sent
+										to
+									</p>
+								</div>
+							</>
+						)}
+
+						{type === "telegram" && (
+							<>
+								<div className="space-y-2">
+									<Label htmlFor="botToken">Bot Token</Label>
+									<Input
+										id="botToken"
+										type="password"
+										value={botToken}
+										onChange={(e) => setBotToken(e.target.value)}
+										autoComplete="off"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="chatIds">Chat IDs</Label>
+									<Input
+										id="chatIds"
+										value={chatIds}
+										onChange={(e) => setChatIds(e.target.value)}
+										placeholder="123456789, -1001234567890"
+									/>
+									<p className="text-xs text-muted-foreground">
+										Comma-separated chats notifications are sent to
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="botAgentId">Answering Agent</Label>
+									<Select value={botAgentId} onValueChange={setBotAgentId}>
+										<SelectTrigger id="botAgentId">
+											<SelectValue />
+										</SelectTrigger>
+										<SelectContent>
+											<SelectItem value="none">None</SelectItem>
+											{(agentsData?.agents ?? []).map((agent) => (
+												<SelectItem key={agent.id} value={agent.id}>
+													{agent.name}
+												</SelectItem>
+											))}
+										</SelectContent>
+									</Select>
+									<p className="text-xs text-muted-foreground">
+										Agent that answers messages sent to the bot
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="webhookSecret">Webhook Secret</Label>
+									<Input
+										id="webhookSecret"
+										type="password"
+										value={webhookSecret}
+										onChange={(e) => setWebhookSecret(e.target.value)}
+										autoComplete="off"
+										required={botAgentId !== "none"}
+									/>
+									<p className="text-xs text-muted-foreground">
+										The secret_token the bot's webhook is registered with.
+										Required for the agent and for replies to notifications
+									</p>
+								</div>
+
+								{botAgentId !== "none" && (
+									<div className="space-y-2">
+										<Label htmlFor="allowedChatIds">Allowed Chat IDs</Label>
+										<Input
+											id="allowedChatIds"
+											value={allowedChatIds}
+											onChange={(e) => setAllowedChatIds(e.target.value)}
+											placeholder="123456789"
+										/>
+										<p className="text-xs text-muted-foreground">
+											Other chats the agent answers, or * for any chat
+										</p>
+									</div>
+								)}
+							</>
+						)}
+
+						{type === "slack" && (
+							<>
+								<div className="space-y-2">
+									<Label htmlFor="botToken">Bot Token</Label>
+									<Input
+										id="botToken"
+										type="password"
+										value={botToken}
+										onChange={(e) => setBotToken(e.target.value)}
+										placeholder="xoxb-..."
+										autoComplete="off"
+										required
+									/>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="slackChannel">Channel ID</Label>
+									<Input
+										id="slackChannel"
+										value={slackChannel}
+										onChange={(e) => setSlackChannel(e.target.value)}
+										placeholder="C0123456789"
+										required
+									/>
+									<p className="text-xs text-muted-foreground">
+										Slack channel notifications are posted to
+									</p>
+								</div>
+
+								<div className="space-y-2">
+									<Label htmlFor="slackSigningSecret">Signing Secret</Label>
+									<Input
+										id="slackSigningSecret"
+										type="password"
+										value={signingSecret}
+										onChange={(e) => setSigningSecret(e.target.value)}
+										autoComplete="off"
+									/>
+									<p className="text-xs text-muted-foreground">
+										The Slack app's signing secret. Replies in notification
+										threads continue the conversation that sent them
+									</p>
+								</div>
+							</>
+						)}
+
+						{type === "group" && (
+							<div className="space-y-2">
+								<Label htmlFor="groupConfig">Routes</Label>
+								<Textarea
+									id="groupConfig"
+									value={groupConfig}
+									onChange={(e) => setGroupConfig(e.target.value)}
+									placeholder={`{
+  "severity_field": "severity",
+  "routes": [
+    { "severity": "critical", "channels": ["pager", "ops-sms"] }
+  ],
+  "default": ["team-chat"]
+}`}
+									rows={8}
+									className="font-mono text-sm"
+									required
+								/>
+								<p className="text-xs text-muted-foreground">
+									Channels are tried in order until one accepts the
+									notification. Set delivery settings on those channels.
+								</p>
+							</div>
+						)}
+
 						<div className="space-y-2">
 							<Label htmlFor="description">Description</Label>
 							<Textarea
@@ -200,6 +627,73 @@ function NewNotificationChannel() {
 							</p>
 						</div>
 
+						<div className="space-y-2">
+							<Label>Quiet Hours</Label>
+							<div className="grid grid-cols-2 gap-3">
+								<Input
+									id="quietHoursStart"
+									type="time"
+									value={quietHoursStart}
+									onChange={(e) => setQuietHoursStart(e.target.value)}
+								/>
+								<Input
+									id="quietHoursEnd"
+									type="time"
+									value={quietHoursEnd}
+									onChange={(e) => setQuietHoursEnd(e.target.value)}
+								/>
+								<Input
+									id="quietHoursTimezone"
+									value={quietHoursTimezone}
+									onChange={(e) => setQuietHoursTimezone(e.target.value)}
+									placeholder="UTC"
+								/>
+								<Select value={quietHoursMode} onValueChange={setQuietHoursMode}>
+									<SelectTrigger id="quietHoursMode">
+										<SelectValue />
+									</SelectTrigger>
+									<SelectContent>
+										<SelectItem value="defer">Defer until after</SelectItem>
+										<SelectItem value="drop">Drop</SelectItem>
+									</SelectContent>
+								</Select>
+							</div>
+							<p className="text-xs text-muted-foreground">
+								Start, end, time zone and behavior for notifications sent during
+								quiet hours (optional)
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="maxPerHour">Max Notifications per Hour</Label>
+							<Input
+								id="maxPerHour"
+								type="number"
+								min={0}
+								value={maxPerHour}
+								onChange={(e) => setMaxPerHour(Number(e.target.value))}
+							/>
+							<p className="text-xs text-muted-foreground">
+								Extra notifications are combined into a digest. 0 means
+								unlimited
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="digestSchedule">Digest Schedule</Label>
+							<Input
+								id="digestSchedule"
+								value={digestSchedule}
+								onChange={(e) => setDigestSchedule(e.target.value)}
+								placeholder="0 9 * * *"
+								className="font-mono"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Cron expression. When set, notifications are collected and sent
+								as a single digest on this schedule (optional)
+							</p>
+						</div>
+
 						<div className="flex gap-3">
 							<Button type="submit" disabled={mutation.isPending}>
 								{mutation.isPending ? "Creating..." : "Create Channel"}
@@ -16,6 +16,7 @@ import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Skeleton } from "@/components/ui/skeleton";
 import { Textarea } from "@/components/ui/textarea";
+import { isStaleVersionError } from "@/lib/api";
 import {
 	deleteFilesystemRoot,
 	getFilesystemRoot,
@@ -36,11 +37,13 @@ function RootDetail() {
 	const deleteMutation = useMutation(deleteFilesystemRoot);
 
 	const [name, setName] = useState("");
+	const [version, setVersion] = useState(0n);
 	const [path, setPath] = useState("");
 	const [description, setDescription] = useState("");
 
 	useEffect(() => {
 		if (root) {
+			setVersion(root.version);
 			setName(root.name);
 			setPath(root.path);
 			setDescription(root.description);
@@ -50,10 +53,21 @@ function RootDetail() {
 	const handleSubmit = async (e: React.FormEvent) => {
 		e.preventDefault();
 		try {
-			await updateMutation.mutateAsync({ id: rootId, name, path, description });
+			const updated = await updateMutation.mutateAsync({
+				id: rootId,
+				version,
+				name,
+				path,
+				description,
+			});
+			setVersion(updated.version);
 			toast.success("Root updated");
-		} catch {
-			toast.error("Failed to update root");
+		} catch (err) {
+			toast.error(
+				isStaleVersionError(err)
+					? "Root was changed elsewhere. Reload to get the latest version."
+					: "Failed to update root",
+			);
 		}
 	};
 
@@ -0,0 +1,218 @@
+import { timestampDate } from "@bufbuild/protobuf/wkt";
+import { useQuery } from "@connectrpc/connect-query";
+import { createFileRoute } from "@tanstack/react-router";
+import { AlertTriangle } from "lucide-react";
+import { ApiKeysCard } from "@/components/api-keys-card";
+import { BrokerCard } from "@/components/broker-card";
+import { LLMCapturesCard } from "@/components/llm-captures-card";
+import { MaintenanceCard } from "@/components/maintenance-card";
+import { PageContent } from "@/components/page-content";
+import { PreambleCard } from "@/components/preamble-card";
+import { ProviderKeysCard } from "@/components/provider-keys-card";
+import {
+	Card,
+	CardContent,
+	CardDescription,
+	CardHeader,
+	CardTitle,
+} from "@/components/ui/card";
+import { Skeleton } from "@/components/ui/skeleton";
+import {
+	Table,
+	TableBody,
+	TableCell,
+	TableHead,
+	TableHeader,
+	TableRow,
+} from "@/components/ui/table";
+import {
+	getSystemStats,
+	getVersion,
+} from "@/lib/rpc/system/system-SystemService_connectquery";
+
+export const Route = createFileRoute("/settings/")({
+	component: SettingsIndex,
+});
+
+function formatBytes(bytes: bigint) {
+	const units = ["B", "KB", "MB", "GB", "TB"];
+	let size = Number(bytes);
+	let unit = 0;
+	while (size >= 1024 && unit < units.length - 1) {
+		size /= 1024;
+		unit++;
+	}
+	return `${size.toFixed(unit === 0 ? 0 : 1)} ${units[unit]}`;
+}
+
+function SettingsIndex() {
+	const { data: stats, isLoading, error } = useQuery(
+		getSystemStats,
+		{},
+		{ refetchInterval: 30_000 },
+	);
+	const { data: version } = useQuery(getVersion, {});
+
+	return (
+		<PageContent className="space-y-6">
+			<div>
+				<h1 className="text-2xl font-bold tracking-tight">Settings</h1>
+				<p className="text-muted-foreground">
+					Instance health, database statistics, maintenance and API keys
+				</p>
+			</div>
+
+			{error && (
+				<div className="rounded-lg border border-destructive/50 bg-destructive/10 p-4 text-destructive">
+					Error: {error.message}
+				</div>
+			)}
+
+			{stats && stats.warnings.length > 0 && (
+				<div className="space-y-1 rounded-lg border border-yellow-500/50 bg-yellow-500/10 p-4 text-sm">
+					{stats.warnings.map((warning) => (
+						<p key={warning} className="flex items-center gap-2">
+							<AlertTriangle className="h-4 w-4 shrink-0" />
+							{warning}
+						</p>
+					))}
+				</div>
+			)}
+
+			<div className="grid gap-4 sm:grid-cols-2 lg:grid-cols-4">
+				<Card>
+					<CardHeader>
+						<CardDescription>Version</CardDescription>
+						<CardTitle className="text-2xl">
+							{version ? (
+								version.version
+							) : (
+								<Skeleton className="h-8 w-24" />
+							)}
+						</CardTitle>
+					</CardHeader>
+					{version?.updateCheckEnabled && (
+						<CardContent className="text-sm text-muted-foreground">
+							{version.updateAvailable ? (
+								<a
+									href={version.releaseUrl}
+									target="_blank"
+									rel="noreferrer"
+									className="text-foreground underline"
+								>
+									Update available: {version.latestVersion}
+								</a>
+							) : version.checkedAt ? (
+								`Up to date · Checked: ${timestampDate(version.checkedAt).toLocaleString()}`
+							) : (
+								"Not checked yet"
+							)}
+						</CardContent>
+					)}
+				</Card>
+				<Card>
+					<CardHeader>
+						<CardDescription>Database size</CardDescription>
+						<CardTitle className="text-2xl">
+							{isLoading ? (
+								<Skeleton className="h-8 w-24" />
+							) : (
+								formatBytes(stats?.databaseSizeBytes ?? 0n)
+							)}
+						</CardTitle>
+					</CardHeader>
+				</Card>
+				<Card>
+					<CardHeader>
+						<CardDescription>Schema version</CardDescription>
+						<CardTitle className="text-2xl">
+							{isLoading ? (
+								<Skeleton className="h-8 w-24" />
+							) : (
+								`${stats?.schemaVersion} / ${stats?.latestSchemaVersion}`
+							)}
+						</CardTitle>
+					</CardHeader>
+					{stats && stats.pendingMigrations.length > 0 && (
+						<CardContent className="text-sm text-muted-foreground">
+							Pending: {stats.pendingMigrations.join(", ")}
+						</CardContent>
+					)}
+				</Card>
+				<Card>
+					<CardHeader>
+						<CardDescription>Scheduler</CardDescription>
+						<CardTitle className="text-2xl">
+							{isLoading ? (
+								<Skeleton className="h-8 w-24" />
+							) : (
+								`${stats?.dueTriggers ?? 0} due`
+							)}
+						</CardTitle>
+					</CardHeader>
+					{stats && (
+						<CardContent className="text-sm text-muted-foreground">
+							{stats.schedulerLastTickAt
+								? `Last tick: ${timestampDate(stats.schedulerLastTickAt).toLocaleString()}`
+								: "Not ticked yet"}
+							{stats.schedulerLagSeconds > 0n &&
+								` · Lag: ${stats.schedulerLagSeconds}s`}
+						</CardContent>
+					)}
+				</Card>
+			</div>
+
+			<Card>
+				<CardHeader>
+					<CardTitle>Tables</CardTitle>
+				</CardHeader>
+				<CardContent>
+					<Table>
+						<TableHeader>
+							<TableRow>
+								<TableHead>Table</TableHead>
+								<TableHead className="text-right">Rows</TableHead>
+								<TableHead>Oldest</TableHead>
+								<TableHead>Newest</TableHead>
+							</TableRow>
+						</TableHeader>
+						<TableBody>
+							{stats?.tables.map((table) => (
+								<TableRow key={table.name}>
+									<TableCell className="font-mono text-sm">
+										{table.name}
+									</TableCell>
+									<TableCell className="text-right tabular-nums">
+										{table.rowCount.toString()}
+									</TableCell>
+									<TableCell className="text-muted-foreground">
+										{table.oldestCreatedAt
+											? timestampDate(table.oldestCreatedAt).toLocaleString()
+											: "—"}
+									</TableCell>
+									<TableCell className="text-muted-foreground">
+										{table.newestCreatedAt
+											? timestampDate(table.newestCreatedAt).toLocaleString()
+											: "—"}
+									</TableCell>
+								</TableRow>
+							))}
+						</TableBody>
+					</Table>
+				</CardContent>
+			</Card>
+
+			<MaintenanceCard />
+
+			<PreambleCard />
+
+			<BrokerCard />
+
+			<ProviderKeysCard />
+
+			<LLMCapturesCard />
+
+			<ApiKeysCard />
+		</PageContent>
+	);
+}
@@ -5,6 +5,8 @@ import { Trash2 } from "lucide-react";
 import { useEffect, useState } from "react";
 import { toast } from "sonner";
 import { PageContent } from "@/components/page-content";
+import { TriggerRunsCard } from "@/components/trigger-runs-card";
+import { TriggerWebhookCard } from "@/components/trigger-webhook-card";
 import { Button } from "@/components/ui/button";
 import {
 	Card,
@@ -18,12 +20,14 @@ import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Skeleton } from "@/components/ui/skeleton";
 import { Textarea } from "@/components/ui/textarea";
+import { isStaleVersionError } from "@/lib/api";
 import { getAgent } from "@/lib/rpc/agent/agent-AgentService_connectquery";
 import {
 	deleteTrigger,
 	getTrigger,
 	updateTrigger,
 } from "@/lib/rpc/trigger/trigger-TriggerService_connectquery";
+import { formatVars, parseVars } from "@/lib/vars";
 
 export const Route = createFileRoute("/triggers/$triggerId")({
 	component: TriggerDetail,
@@ -42,32 +46,55 @@ function TriggerDetail() {
 	const deleteMutation = useMutation(deleteTrigger);
 
 	const [name, setName] = useState("");
+	const [version, setVersion] = useState(0n);
 	const [prompt, setPrompt] = useState("");
 	const [cronExpr, setCronExpr] = useState("");
 	const [enabled, setEnabled] = useState(true);
+	// Minutes; empty uses the server default.
+	const [maxDuration, setMaxDuration] = useState("");
+	// Empty uses the default of scheduled runs.
+	const [priority, setPriority] = useState("");
+	const [vars, setVars] = useState("");
 
 	useEffect(() => {
 		if (trigger) {
+			setVersion(trigger.version);
 			setName(trigger.name);
 			setPrompt(trigger.prompt);
 			setCronExpr(trigger.cronExpr);
 			setEnabled(trigger.enabled);
+			setMaxDuration(
+				trigger.maxDurationSeconds
+					? String(trigger.maxDurationSeconds / 60)
+					: "",
+			);
+			setPriority(trigger.priority ? String(trigger.priority) : "");
+			setVars(formatVars(trigger.vars));
 		}
 	}, [trigger]);
 
 	const handleSubmit = async (e: React.FormEvent) => {
 		e.preventDefault();
 		try {
-			await updateMutation.mutateAsync({
+			const updated = await updateMutation.mutateAsync({
 				id: triggerId,
+				version,
 				name,
 				prompt,
 				cronExpr,
 				enabled,
+				maxDurationSeconds: Math.round(Number(maxDuration) * 60),
+				priority: Math.round(Number(priority)),
+				vars: parseVars(vars),
 			});
+			setVersion(updated.version);
 			toast.success("Trigger updated");
-		} catch {
-			toast.error("Failed to update trigger");
+		} catch (err) {
+			toast.error(
+				isStaleVersionError(err)
+					? "Trigger was changed elsewhere. Reload to get the latest version."
+					: "Failed to update trigger",
+			);
 		}
 	};
 
@@ -175,6 +202,54 @@ function TriggerDetail() {
 							</p>
 						</div>
 
+						<div className="space-y-2">
+							<Label htmlFor="maxDuration">Max Duration (minutes)</Label>
+							<Input
+								id="maxDuration"
+								type="number"
+								min={1}
+								value={maxDuration}
+								onChange={(e) => setMaxDuration(e.target.value)}
+								placeholder="Server default"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Runs taking longer are stopped and marked as timed out
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="priority">Priority</Label>
+							<Input
+								id="priority"
+								type="number"
+								min={1}
+								max={100}
+								value={priority}
+								onChange={(e) => setPriority(e.target.value)}
+								placeholder="10"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Runs waiting for the agent's concurrency limit start in order of
+								priority; chats are 30 and webhooks 20
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="vars">Variables</Label>
+							<Textarea
+								id="vars"
+								value={vars}
+								onChange={(e) => setVars(e.target.value)}
+								placeholder="BRANCH=main"
+								rows={3}
+								className="font-mono"
+							/>
+							<p className="text-xs text-muted-foreground">
+								One NAME=value per line, set as environment variables of bash
+								commands and replacing {"{{NAME}}"} in the prompt
+							</p>
+						</div>
+
 						<div className="flex items-center space-x-2">
 							<Checkbox
 								id="enabled"
@@ -190,6 +265,10 @@ function TriggerDetail() {
 					</form>
 				</CardContent>
 			</Card>
+
+			<TriggerRunsCard triggerId={triggerId} agentId={trigger.agentId} />
+
+			<TriggerWebhookCard triggerId={triggerId} />
 		</PageContent>
 	);
 }
@@ -23,6 +23,7 @@ import {
 import { Textarea } from "@/components/ui/textarea";
 import { listAgents } from "@/lib/rpc/agent/agent-AgentService_connectquery";
 import { createTrigger } from "@/lib/rpc/trigger/trigger-TriggerService_connectquery";
+import { parseVars } from "@/lib/vars";
 
 export const Route = createFileRoute("/triggers/new")({
 	component: NewTrigger,
@@ -39,6 +40,11 @@ function NewTrigger() {
 	const [scheduleType, setScheduleType] = useState<"cron" | "delay">("cron");
 	const [cronExpr, setCronExpr] = useState("");
 	const [delay, setDelay] = useState("");
+	// Minutes; empty uses the server default.
+	const [maxDuration, setMaxDuration] = useState("");
+	// Empty uses the default of scheduled runs.
+	const [priority, setPriority] = useState("");
+	const [vars, setVars] = useState("");
 
 	const agents = agentsData?.agents ?? [];
 
@@ -51,6 +57,9 @@ function NewTrigger() {
 				prompt,
 				cronExpr: scheduleType === "cron" ? cronExpr : "",
 				delay: scheduleType === "delay" ? delay : "",
+				maxDurationSeconds: Math.round(Number(maxDuration) * 60),
+				priority: Math.round(Number(priority)),
+				vars: parseVars(vars),
 			});
 			toast.success("Trigger created");
 			navigate({
@@ -175,6 +184,54 @@ function NewTrigger() {
 							)}
 						</div>
 
+						<div className="space-y-2">
+							<Label htmlFor="maxDuration">Max Duration (minutes)</Label>
+							<Input
+								id="maxDuration"
+								type="number"
+								min={1}
+								value={maxDuration}
+								onChange={(e) => setMaxDuration(e.target.value)}
+								placeholder="Server default"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Runs taking longer are stopped and marked as timed out
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="priority">Priority</Label>
+							<Input
+								id="priority"
+								type="number"
+								min={1}
+								max={100}
+								value={priority}
+								onChange={(e) => setPriority(e.target.value)}
+								placeholder="10"
+							/>
+							<p className="text-xs text-muted-foreground">
+								Runs waiting for the agent's concurrency limit start in order of
+								priority; chats are 30 and webhooks 20
+							</p>
+						</div>
+
+						<div className="space-y-2">
+							<Label htmlFor="vars">Variables</Label>
+							<Textarea
+								id="vars"
+								value={vars}
+								onChange={(e) => setVars(e.target.value)}
+								placeholder="BRANCH=main"
+								rows={3}
+								className="font-mono"
+							/>
+							<p className="text-xs text-muted-foreground">
+								One NAME=value per line, set as environment variables of bash
+								commands and replacing {"{{NAME}}"} in the prompt
+							</p>
+						</div>
+
 						<div className="flex gap-3">
 							<Button type="submit" disabled={mutation.isPending}>
 								{mutation.isPending ? "Creating..." : "Create Trigger"}